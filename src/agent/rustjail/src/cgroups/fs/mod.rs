@@ -13,6 +13,7 @@ use cgroups::freezer::{FreezerController, FreezerState};
 use cgroups::hugetlb::HugeTlbController;
 use cgroups::memory::MemController;
 use cgroups::pid::PidController;
+use cgroups::rdma::RdmaController;
 use cgroups::{
     BlkIoDeviceResource, BlkIoDeviceThrottleResource, Cgroup, CgroupPid, Controller,
     DeviceResource, HugePageResource, MaxValue, NetworkPriority,
@@ -24,7 +25,7 @@ use anyhow::{anyhow, Context, Result};
 use libc::{self, pid_t};
 use oci::{
     LinuxBlockIo, LinuxCpu, LinuxDevice, LinuxDeviceCgroup, LinuxHugepageLimit, LinuxMemory,
-    LinuxNetwork, LinuxPids, LinuxResources,
+    LinuxNetwork, LinuxPids, LinuxRdma, LinuxResources,
 };
 
 use protobuf::MessageField;
@@ -101,6 +102,11 @@ impl CgroupManager for Manager {
             set_pids_resources(&self.cgroup, pids_resources)?;
         }
 
+        // set rdma resources
+        if !r.rdma.is_empty() {
+            set_rdma_resources(&self.cgroup, &r.rdma)?;
+        }
+
         // set block_io resources
         if let Some(blkio) = &r.block_io {
             set_block_io_resources(&self.cgroup, blkio, res);
@@ -503,6 +509,34 @@ fn set_pids_resources(cg: &cgroups::Cgroup, pids: &LinuxPids) -> Result<()> {
         .context("failed to set pids resources")
 }
 
+fn set_rdma_resources(cg: &cgroups::Cgroup, rdma: &HashMap<String, LinuxRdma>) -> Result<()> {
+    info!(sl(), "cgroup manager set rdma");
+    let rdma_controller: &RdmaController = cg
+        .controller_of()
+        .ok_or_else(|| anyhow!("rdma cgroup controller is not available"))?;
+
+    for (device, limit) in rdma.iter() {
+        rdma_controller
+            .set_max(&rdma_max(device, limit))
+            .context("failed to set rdma resources")?;
+    }
+
+    Ok(())
+}
+
+// rdma_max returns the rdma.max line of the device limits, the unset limits
+// are left as is.
+fn rdma_max(device: &str, limit: &LinuxRdma) -> String {
+    let mut max = device.to_string();
+    if let Some(handles) = limit.hca_handles {
+        max.push_str(&format!(" hca_handle={}", handles));
+    }
+    if let Some(objects) = limit.hca_objects {
+        max.push_str(&format!(" hca_object={}", objects));
+    }
+    max
+}
+
 fn build_blk_io_device_throttle_resource(
     input: &[oci::LinuxThrottleDevice],
 ) -> Vec<BlkIoDeviceThrottleResource> {
@@ -1133,4 +1167,22 @@ mod tests {
             );
         }
     }
+
+    #[test]
+    fn test_rdma_max() {
+        let test_cases = vec![
+            (None, None, "mlx5_0"),
+            (Some(4), None, "mlx5_0 hca_handle=4"),
+            (None, Some(1000), "mlx5_0 hca_object=1000"),
+            (Some(4), Some(1000), "mlx5_0 hca_handle=4 hca_object=1000"),
+        ];
+
+        for (hca_handles, hca_objects, expected) in test_cases {
+            let limit = LinuxRdma {
+                hca_handles,
+                hca_objects,
+            };
+            assert_eq!(rdma_max("mlx5_0", &limit), expected);
+        }
+    }
 }
@@ -12,6 +12,7 @@ use std::ffi::CString;
 use std::fmt::Display;
 use std::fs;
 use std::os::unix::io::RawFd;
+use std::os::unix::process::CommandExt;
 use std::path::{Path, PathBuf};
 use std::time::SystemTime;
 
@@ -43,6 +44,8 @@ use nix::mount::MntFlags;
 use nix::pty;
 use nix::sched::{self, CloneFlags};
 use nix::sys::signal::{self, Signal};
+#[cfg(feature = "seccomp")]
+use nix::sys::socket::{self, AddressFamily, SockFlag, SockType};
 use nix::sys::stat::{self, Mode};
 use nix::unistd::{self, fork, ForkResult, Gid, Pid, Uid, User};
 use std::os::unix::fs::MetadataExt;
@@ -55,6 +58,7 @@ use regex::Regex;
 use std::collections::HashMap;
 use std::os::unix::io::FromRawFd;
 use std::str::FromStr;
+use std::sync::atomic::{AtomicI32, Ordering};
 use std::sync::Arc;
 
 use slog::{info, o, Logger};
@@ -81,6 +85,24 @@ const FIFO_FD: &str = "FIFO_FD";
 const HOME_ENV_KEY: &str = "HOME";
 const PIDNS_FD: &str = "PIDNS_FD";
 const CONSOLE_SOCKET_FD: &str = "CONSOLE_SOCKET_FD";
+const SECCOMP_NOTIFY_FD: &str = "SECCOMP_NOTIFY_FD";
+const EXEC_SYNC_FD: &str = "EXEC_SYNC_FD";
+
+// The write end of the exec sync pipe of the init process, which the exec of
+// the entrypoint closes, or -1.
+static EXEC_SYNC: AtomicI32 = AtomicI32::new(-1);
+
+// notify_exec_failed tells the agent that the init process failed before
+// executing its entrypoint.
+fn notify_exec_failed() {
+    let fd = EXEC_SYNC.load(Ordering::SeqCst);
+    if fd >= 0 {
+        // The agent may not wait for the exec, the process is exiting
+        // anyway and must not be killed by SIGPIPE.
+        unsafe { libc::signal(libc::SIGPIPE, libc::SIG_IGN) };
+        let _ = unistd::write(fd, &[0]);
+    }
+}
 
 #[derive(Debug)]
 pub struct ContainerStatus {
@@ -325,6 +347,7 @@ pub fn init_child() {
         Err(e) => {
             log_child!(cfd_log, "temporary parent process exit:child exit: {:?}", e);
             let _ = write_sync(cwfd, SYNC_FAILED, format!("{:?}", e).as_str());
+            notify_exec_failed();
         }
     }
 }
@@ -339,6 +362,18 @@ fn do_init_child(cwfd: RawFd) -> Result<()> {
     let crfd = std::env::var(CRFD_FD)?.parse::<i32>().unwrap();
     let cfd_log = std::env::var(CLOG_FD)?.parse::<i32>().unwrap();
 
+    // The socket to send the listener of the seccomp notifications on, it
+    // must not be inherited by the container process.
+    #[cfg(feature = "seccomp")]
+    let seccomp_notify_fd = match std::env::var(SECCOMP_NOTIFY_FD) {
+        Ok(fd) => {
+            let fd = fd.parse::<i32>().context("get seccomp notify fd")?;
+            fcntl::fcntl(fd, FcntlArg::F_SETFD(FdFlag::FD_CLOEXEC))?;
+            Some(fd)
+        }
+        Err(_e) => None,
+    };
+
     // get the pidns fd from parent, if parent had passed the pidns fd,
     // then get it and join in this pidns; otherwise, create a new pidns
     // by unshare from the parent pidns.
@@ -664,7 +699,7 @@ fn do_init_child(cwfd: RawFd) -> Result<()> {
     #[cfg(feature = "seccomp")]
     if !oci_process.no_new_privileges {
         if let Some(ref scmp) = linux.seccomp {
-            seccomp::init_seccomp(scmp)?;
+            load_seccomp(scmp, seccomp_notify_fd)?;
         }
     }
 
@@ -680,6 +715,11 @@ fn do_init_child(cwfd: RawFd) -> Result<()> {
     let mut fifofd = -1;
     if init {
         fifofd = std::env::var(FIFO_FD)?.parse::<i32>().unwrap();
+
+        // The exec of the entrypoint closes the exec sync pipe.
+        let exec_sync = std::env::var(EXEC_SYNC_FD)?.parse::<i32>().unwrap();
+        fcntl::fcntl(exec_sync, FcntlArg::F_SETFD(FdFlag::FD_CLOEXEC))?;
+        EXEC_SYNC.store(exec_sync, Ordering::SeqCst);
     }
 
     // cleanup the env inherited from parent
@@ -767,13 +807,24 @@ fn do_init_child(cwfd: RawFd) -> Result<()> {
     #[cfg(feature = "seccomp")]
     if oci_process.no_new_privileges {
         if let Some(ref scmp) = linux.seccomp {
-            seccomp::init_seccomp(scmp)?;
+            load_seccomp(scmp, seccomp_notify_fd)?;
         }
     }
 
     do_exec(&args);
 }
 
+// load_seccomp loads the seccomp filter of the container process, sending
+// the listener of its notifications to the agent.
+#[cfg(feature = "seccomp")]
+fn load_seccomp(scmp: &oci::LinuxSeccomp, notify_sock: Option<RawFd>) -> Result<()> {
+    if let Some(notify_fd) = seccomp::init_seccomp(scmp)? {
+        let sock = notify_sock.ok_or_else(|| anyhow!("no socket to send the seccomp notify fd"))?;
+        seccomp::send_notify_fd(sock, notify_fd)?;
+    }
+    Ok(())
+}
+
 // set_stdio_permissions fixes the permissions of PID 1's STDIO
 // within the container to the specified user.
 // The ownership needs to match because it is created outside of
@@ -984,6 +1035,25 @@ impl BaseContainer for LinuxContainer {
 
         let pidns = get_pid_namespace(&self.logger, linux)?;
 
+        // The container process sends the listener of its seccomp
+        // notifications on this socket, when its profile traps system calls.
+        #[cfg(feature = "seccomp")]
+        let seccomp_notify = match linux.seccomp {
+            Some(ref scmp) if seccomp::has_notify_rules(scmp) => {
+                let (psock, csock) = socket::socketpair(
+                    AddressFamily::Unix,
+                    SockType::Stream,
+                    None,
+                    SockFlag::empty(),
+                )
+                .context("failed to create seccomp notify socket")?;
+                let _ = fcntl::fcntl(psock, FcntlArg::F_SETFD(FdFlag::FD_CLOEXEC))
+                    .map_err(|e| warn!(logger, "fcntl seccomp notify FD_CLOEXEC {:?}", e));
+                Some((psock, csock))
+            }
+            _ => None,
+        };
+
         defer!(if let Some(pid) = pidns {
             let _ = unistd::close(pid);
         });
@@ -1018,7 +1088,42 @@ impl BaseContainer for LinuxContainer {
             child = child.env(PIDNS_FD, format!("{}", pidns.unwrap()));
         }
 
-        child.spawn()?;
+        #[cfg(feature = "seccomp")]
+        if let Some((_, csock)) = seccomp_notify {
+            child = child.env(SECCOMP_NOTIFY_FD, format!("{}", csock));
+        }
+
+        // Both ends of the exec sync pipe are closed on exec, the write end
+        // is only inherited by the init process.
+        let exec_sync = if p.init {
+            let (rfd, wfd) = unistd::pipe2(OFlag::O_CLOEXEC).context("failed to create pipe")?;
+            child = child.env(EXEC_SYNC_FD, format!("{}", wfd));
+            unsafe {
+                child.pre_exec(move || {
+                    if libc::fcntl(wfd, libc::F_SETFD, 0) < 0 {
+                        return Err(std::io::Error::last_os_error());
+                    }
+                    Ok(())
+                });
+            }
+            Some((rfd, wfd))
+        } else {
+            None
+        };
+
+        let spawned = child.spawn();
+
+        if let Some((rfd, wfd)) = exec_sync {
+            let _ = unistd::close(wfd);
+            p.exec_sync = Some(unsafe { fs::File::from_raw_fd(rfd) });
+        }
+        spawned?;
+
+        #[cfg(feature = "seccomp")]
+        if let Some((psock, csock)) = seccomp_notify {
+            unistd::close(csock)?;
+            p.seccomp_notify = Some(psock);
+        }
 
         unistd::close(crfd)?;
         unistd::close(cwfd)?;
@@ -1216,9 +1321,12 @@ fn do_exec(args: &[String]) -> ! {
         .map(|s| CString::new(s.to_string()).unwrap_or_default())
         .collect();
 
-    let _ = unistd::execvp(p.as_c_str(), &sa).map_err(|e| match e {
-        nix::Error::UnknownErrno => std::process::exit(-2),
-        _ => std::process::exit(e as i32),
+    let _ = unistd::execvp(p.as_c_str(), &sa).map_err(|e| {
+        notify_exec_failed();
+        match e {
+            nix::Error::UnknownErrno => std::process::exit(-2),
+            _ => std::process::exit(e as i32),
+        }
     });
 
     unreachable!()
@@ -1556,6 +1664,41 @@ impl LinuxContainer {
         self.console_socket = console_socket.to_path_buf();
         Ok(())
     }
+
+    // replace_init_process makes pid, e.g. the root of a process tree
+    // restored from a checkpoint, the init process of the created container
+    // in place of the init process waiting to exec the entrypoint, which is
+    // killed. The IO streams of the init process are kept.
+    pub fn replace_init_process(&mut self, pid: pid_t) -> Result<()> {
+        if self.status.status() != ContainerState::Created {
+            return Err(anyhow!("container {} is not created", self.id));
+        }
+
+        let waiting_pid = self.init_process_pid;
+        let mut p = self
+            .processes
+            .remove(&waiting_pid)
+            .ok_or_else(|| anyhow!("container {} has no init process", self.id))?;
+        p.pid = pid;
+        self.processes.insert(pid, p);
+        self.init_process_pid = pid;
+
+        if let Err(e) = signal::kill(Pid::from_raw(waiting_pid), Some(Signal::SIGKILL)) {
+            warn!(
+                self.logger,
+                "kill the waiting init process {} error: {:?}", waiting_pid, e
+            );
+        }
+        let _ = fs::remove_file(format!("{}/{}", &self.root, EXEC_FIFO_FILENAME));
+
+        self.init_process_start_time = SystemTime::now()
+            .duration_since(SystemTime::UNIX_EPOCH)
+            .unwrap()
+            .as_secs();
+        self.status.transition(ContainerState::Running);
+
+        Ok(())
+    }
 }
 
 use std::fs::OpenOptions;
@@ -62,6 +62,12 @@ pub struct Process {
     pub parent_stdout: Option<RawFd>,
     pub parent_stderr: Option<RawFd>,
     pub init: bool,
+    // the agent end of the socket the process sends the listener of its
+    // seccomp notifications on, when its profile traps system calls.
+    pub seccomp_notify: Option<RawFd>,
+    // the agent end of the pipe closed by the exec of the entrypoint of the
+    // init process, or written to when the process fails before.
+    pub exec_sync: Option<File>,
     // pid of the init/exec process. since we have no command
     // struct to store pid, we must store pid here.
     pub pid: pid_t,
@@ -123,6 +129,8 @@ impl Process {
             parent_stdout: None,
             parent_stderr: None,
             init,
+            seccomp_notify: None,
+            exec_sync: None,
             pid: -1,
             exit_code: 0,
             exit_watchers: Vec::new(),
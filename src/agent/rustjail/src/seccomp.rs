@@ -3,11 +3,19 @@
 // SPDX-License-Identifier: Apache-2.0
 //
 
-use anyhow::{anyhow, Result};
+use anyhow::{anyhow, Context, Result};
 use libseccomp::*;
+use nix::errno::Errno;
+use nix::poll::{poll, PollFd, PollFlags};
+use nix::sys::socket;
+use nix::unistd;
 use oci::{LinuxSeccomp, LinuxSeccompArg};
+use std::io::{IoSlice, IoSliceMut};
+use std::os::unix::io::RawFd;
 use std::str::FromStr;
 
+const SCMP_ACT_NOTIFY: &str = "SCMP_ACT_NOTIFY";
+
 fn get_filter_attr_from_flag(flag: &str) -> Result<ScmpFilterAttr> {
     match flag {
         "SECCOMP_FILTER_FLAG_TSYNC" => Ok(ScmpFilterAttr::CtlTsync),
@@ -60,9 +68,18 @@ pub fn get_unknown_syscalls(scmp: &LinuxSeccomp) -> Option<Vec<String>> {
     }
 }
 
+// has_notify_rules tells if the seccomp profile traps system calls with
+// SCMP_ACT_NOTIFY rules, the notifications being responded to by the agent.
+pub fn has_notify_rules(scmp: &LinuxSeccomp) -> bool {
+    scmp.syscalls
+        .iter()
+        .any(|syscall| syscall.action == SCMP_ACT_NOTIFY)
+}
+
 // init_seccomp creates a seccomp filter and loads it for the current process
-// including all the child processes.
-pub fn init_seccomp(scmp: &LinuxSeccomp) -> Result<()> {
+// including all the child processes. The listener of the notifications of the
+// filter is returned when it has SCMP_ACT_NOTIFY rules.
+pub fn init_seccomp(scmp: &LinuxSeccomp) -> Result<Option<RawFd>> {
     let def_action = ScmpAction::from_str(scmp.default_action.as_str(), Some(libc::EPERM))?;
 
     // Create a new filter context
@@ -116,6 +133,125 @@ pub fn init_seccomp(scmp: &LinuxSeccomp) -> Result<()> {
     // Load the filter
     filter.load()?;
 
+    if has_notify_rules(scmp) {
+        return Ok(Some(filter.get_notify_fd()?));
+    }
+
+    Ok(None)
+}
+
+// send_notify_fd sends the listener of the seccomp notifications of the
+// current process to the agent, over the socket the agent passed to it. Both
+// are closed, the container process must not respond to its own
+// notifications. The filter is loaded already, so sendmsg and close must not
+// be denied nor trapped by the profile.
+pub fn send_notify_fd(sock: RawFd, notify_fd: RawFd) -> Result<()> {
+    let iov = [IoSlice::new(b"\0")];
+    let fds = [notify_fd];
+    let cmsg = socket::ControlMessage::ScmRights(&fds);
+
+    socket::sendmsg::<()>(sock, &iov, &[cmsg], socket::MsgFlags::empty(), None)
+        .context("send seccomp notify fd")?;
+
+    let _ = unistd::close(notify_fd);
+    let _ = unistd::close(sock);
+
+    Ok(())
+}
+
+// recv_notify_fd receives the listener of the seccomp notifications sent by
+// the container process with send_notify_fd. None is returned when the
+// process closed the socket without sending it, e.g. when it failed to start.
+pub fn recv_notify_fd(sock: RawFd) -> Result<Option<RawFd>> {
+    let mut buf = [0u8; 1];
+    let mut iov = [IoSliceMut::new(&mut buf)];
+    let mut cmsg_buf = nix::cmsg_space!([RawFd; 1]);
+
+    let msg = socket::recvmsg::<()>(
+        sock,
+        &mut iov,
+        Some(&mut cmsg_buf),
+        socket::MsgFlags::MSG_CMSG_CLOEXEC,
+    )
+    .context("receive seccomp notify fd")?;
+
+    for cmsg in msg.cmsgs() {
+        if let socket::ControlMessageOwned::ScmRights(fds) = cmsg {
+            return Ok(fds.first().copied());
+        }
+    }
+
+    Ok(None)
+}
+
+// A system call trapped by a SCMP_ACT_NOTIFY rule, the process making it
+// being blocked until it is responded to.
+#[derive(Debug)]
+pub struct Notification {
+    // the id of the notification for the kernel
+    pub id: u64,
+    pub pid: u32,
+    pub syscall: String,
+    // the libseccomp name of the architecture of the system call
+    pub arch: String,
+    pub args: Vec<u64>,
+}
+
+// receive_notification waits for the next notification of the listener.
+// None is returned once all the processes of the filter exited.
+pub fn receive_notification(notify_fd: RawFd) -> Result<Option<Notification>> {
+    // The kernel does not fail the receive when there is no process left,
+    // it reports the hangup on poll.
+    let mut fds = [PollFd::new(notify_fd, PollFlags::POLLIN)];
+    loop {
+        match poll(&mut fds, -1) {
+            Err(Errno::EINTR) => continue,
+            Err(e) => return Err(e).context("poll seccomp notify fd"),
+            Ok(_) => break,
+        }
+    }
+    let revents = fds[0].revents().unwrap_or_else(PollFlags::empty);
+    if !revents.contains(PollFlags::POLLIN) {
+        return Ok(None);
+    }
+
+    let req = ScmpNotifReq::receive(notify_fd)?;
+
+    let syscall = req
+        .data
+        .syscall
+        .get_name_by_arch(req.data.arch)
+        .unwrap_or_else(|_| format!("{:?}", req.data.syscall));
+
+    Ok(Some(Notification {
+        id: req.id,
+        pid: req.pid,
+        syscall,
+        arch: format!("{:?}", req.data.arch).to_lowercase(),
+        args: req.data.args.to_vec(),
+    }))
+}
+
+// respond_notification lets the system call of the notification run when
+// allow is set, fail with the error errno when it is not 0, or return value
+// without running otherwise.
+pub fn respond_notification(
+    notify_fd: RawFd,
+    id: u64,
+    allow: bool,
+    error: i32,
+    value: i64,
+) -> Result<()> {
+    let resp = if allow {
+        ScmpNotifResp::new_continue(id, ScmpNotifRespFlags::CONTINUE)
+    } else if error != 0 {
+        ScmpNotifResp::new_error(id, -error.abs(), ScmpNotifRespFlags::empty())
+    } else {
+        ScmpNotifResp::new_val(id, value, ScmpNotifRespFlags::empty())
+    };
+
+    resp.respond(notify_fd)?;
+
     Ok(())
 }
 
@@ -223,6 +359,15 @@ mod tests {
         assert_eq!(syscalls, vec!["invalid_syscall1", "invalid_syscall2"]);
     }
 
+    #[test]
+    fn test_has_notify_rules() {
+        let mut scmp: oci::LinuxSeccomp = serde_json::from_str(TEST_DATA).unwrap();
+        assert!(!has_notify_rules(&scmp));
+
+        scmp.syscalls[0].action = SCMP_ACT_NOTIFY.to_string();
+        assert!(has_notify_rules(&scmp));
+    }
+
     #[test]
     fn test_init_seccomp() {
         skip_if_not_root!();
@@ -0,0 +1,487 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Checkpoint and restore of the containers with CRIU (experimental).
+//
+// The process tree of a container is dumped by CRIU into an image
+// directory of the guest, and restored in a container created from the same
+// spec, possibly in another sandbox, in place of its init process waiting to
+// exec the entrypoint. The filesystem state of the container is expected to
+// be in its volumes: the bind mounts of the container, which are provided by
+// the new sandbox, are external to the images. The restored processes join
+// the network, IPC and UTS namespaces of the new sandbox, and keep the IO
+// streams of the new container.
+
+use std::fs::{self, File, OpenOptions};
+use std::io::Read;
+use std::os::unix::fs::MetadataExt;
+use std::os::unix::io::{AsRawFd, RawFd};
+use std::os::unix::process::CommandExt;
+use std::path::Path;
+use std::process::{Command, Stdio};
+
+use anyhow::{anyhow, Context, Result};
+use libc::pid_t;
+use nix::sys::wait::WaitStatus;
+use oci::{Spec, IPCNAMESPACE, PIDNAMESPACE, UTSNAMESPACE};
+use rustjail::container::LinuxContainer;
+use slog::Logger;
+
+use crate::signal;
+
+const CRIU: &str = "criu";
+
+// The directory holding the image directories of the containers.
+pub const CHECKPOINTS_DIR: &str = "/run/kata-containers/checkpoints";
+
+// The file of the image directory recording the IO streams of the init
+// process, as the targets of its /proc/<pid>/fd/{0,1,2} links.
+const DESCRIPTORS_FILE: &str = "descriptors.json";
+
+// The key of the external network namespace of the images.
+const NETNS_KEY: &str = "kata-netns";
+
+// The first descriptor criu inherits, after its own IO streams.
+const FIRST_INHERITED_FD: RawFd = 3;
+
+// CriuOptions are the options of a dump and of the following restore.
+#[derive(Debug, Default)]
+pub struct CriuOptions {
+    pub tcp_established: bool,
+    pub file_locks: bool,
+    pub ext_unix_sockets: bool,
+}
+
+impl CriuOptions {
+    fn args(&self) -> Vec<String> {
+        let mut args = Vec::new();
+        if self.tcp_established {
+            args.push("--tcp-established".to_string());
+        }
+        if self.file_locks {
+            args.push("--file-locks".to_string());
+        }
+        if self.ext_unix_sockets {
+            args.push("--ext-unix-sk".to_string());
+        }
+        args
+    }
+}
+
+pub fn container_spec(ctr: &LinuxContainer) -> Result<&Spec> {
+    ctr.config
+        .spec
+        .as_ref()
+        .ok_or_else(|| anyhow!("container {} has no spec", ctr.id))
+}
+
+// namespace_path returns the path of the namespace the container joins, or
+// an empty string when it has a namespace of its own.
+fn namespace_path<'a>(spec: &'a Spec, ns_type: &str) -> Option<&'a str> {
+    spec.linux
+        .as_ref()?
+        .namespaces
+        .iter()
+        .find(|ns| ns.r#type == ns_type)
+        .map(|ns| ns.path.as_str())
+}
+
+// check_spec checks that the container can be checkpointed: its process
+// tree must be the init of a PID namespace of its own, so that criu can
+// restore the same PIDs, and have no terminal.
+fn check_spec(spec: &Spec) -> Result<()> {
+    if namespace_path(spec, PIDNAMESPACE) != Some("") {
+        return Err(anyhow!(
+            "only the containers with a PID namespace of their own can be checkpointed"
+        ));
+    }
+
+    if spec.process.as_ref().map_or(false, |p| p.terminal) {
+        return Err(anyhow!(
+            "the containers with a terminal cannot be checkpointed"
+        ));
+    }
+
+    Ok(())
+}
+
+// external_mounts returns the mounts of the container which are external to
+// the images, as (mount point, source in the guest) pairs: the bind mounts,
+// the cgroup filesystems and the masked files.
+fn external_mounts(spec: &Spec) -> Vec<(String, String)> {
+    let mut mounts: Vec<(String, String)> = spec
+        .mounts
+        .iter()
+        .filter_map(|m| {
+            let bind = m.r#type == "bind" || m.options.iter().any(|o| o == "bind" || o == "rbind");
+            if bind {
+                Some((m.destination.clone(), m.source.clone()))
+            } else if m.r#type == "cgroup" || m.r#type == "cgroup2" {
+                Some((m.destination.clone(), "/sys/fs/cgroup".to_string()))
+            } else {
+                None
+            }
+        })
+        .collect();
+
+    // The masked files are bind mounts of /dev/null, the masked
+    // directories are tmpfs mounts dumped by criu.
+    if let Some(linux) = spec.linux.as_ref() {
+        let root = spec.root.as_ref().map(|r| r.path.as_str()).unwrap_or("/");
+        for path in linux.masked_paths.iter() {
+            let target = Path::new(root).join(path.trim_start_matches('/'));
+            if fs::metadata(&target).map_or(false, |m| !m.is_dir()) {
+                mounts.push((path.clone(), "/dev/null".to_string()));
+            }
+        }
+    }
+
+    mounts
+}
+
+fn criu_command(args: &[String], files: &[File]) -> Command {
+    let fds: Vec<RawFd> = files.iter().map(|f| f.as_raw_fd()).collect();
+
+    let mut cmd = Command::new(CRIU);
+    cmd.args(args);
+    unsafe {
+        cmd.pre_exec(move || {
+            for (i, fd) in fds.iter().enumerate() {
+                // dup2 clears FD_CLOEXEC.
+                if libc::dup2(*fd, FIRST_INHERITED_FD + i as RawFd) < 0 {
+                    return Err(std::io::Error::last_os_error());
+                }
+            }
+            Ok(())
+        });
+    }
+
+    cmd
+}
+
+// run_criu runs criu with args, the descriptors of files being inherited
+// from FIRST_INHERITED_FD on. The caller holds WAIT_PID_LOCKER, so that the
+// reaper of the agent does not reap criu.
+fn run_criu(logger: &Logger, args: &[String], files: &[File]) -> Result<()> {
+    info!(logger, "running criu"; "args" => format!("{:?}", args));
+
+    let output = criu_command(args, files).output().context("run criu")?;
+    if !output.status.success() {
+        return Err(anyhow!(
+            "criu {} failed: {}: {}",
+            args[0],
+            output.status,
+            String::from_utf8_lossy(&output.stderr).trim()
+        ));
+    }
+
+    Ok(())
+}
+
+// run_criu_reaped runs criu with args, holding WAIT_PID_LOCKER only while
+// spawning it: the reaper of the agent goes on reaping the processes of the
+// containers, and sends the exit status of criu.
+async fn run_criu_reaped(logger: &Logger, args: &[String]) -> Result<()> {
+    info!(logger, "running criu"; "args" => format!("{:?}", args));
+
+    let (mut child, exit) = {
+        let _locker = rustjail::container::WAIT_PID_LOCKER.lock().await;
+        let child = criu_command(args, &[])
+            .stdout(Stdio::null())
+            .stderr(Stdio::piped())
+            .spawn()
+            .context("run criu")?;
+        let exit = signal::watch_helper(child.id() as pid_t);
+        (child, exit)
+    };
+
+    let mut stderr = child
+        .stderr
+        .take()
+        .ok_or_else(|| anyhow!("criu has no stderr"))?;
+    let stderr = tokio::task::spawn_blocking(move || {
+        let mut buf = String::new();
+        let _ = stderr.read_to_string(&mut buf);
+        buf
+    });
+
+    let status = exit.await.context("wait for criu")?;
+    let stderr = stderr.await.unwrap_or_default();
+    match status {
+        WaitStatus::Exited(_, 0) => Ok(()),
+        status => Err(anyhow!(
+            "criu {} failed: {:?}: {}",
+            args[0],
+            status,
+            stderr.trim()
+        )),
+    }
+}
+
+// checkpoint dumps the process tree of the container with the given spec
+// and init process in image_dir, leaving it running when leave_running is
+// set, and returns the names of the image files. The reaper of the agent
+// must not be locked: it reaps criu.
+pub async fn checkpoint(
+    logger: &Logger,
+    spec: &Spec,
+    pid: pid_t,
+    image_dir: &Path,
+    options: &CriuOptions,
+    leave_running: bool,
+) -> Result<Vec<String>> {
+    check_spec(spec)?;
+
+    let _ = fs::remove_dir_all(image_dir);
+    fs::create_dir_all(image_dir).context(format!("create {:?}", image_dir))?;
+
+    let descriptors: Vec<String> = (0..3)
+        .map(|fd| {
+            fs::read_link(format!("/proc/{}/fd/{}", pid, fd))
+                .map(|target| target.to_string_lossy().into_owned())
+                .unwrap_or_default()
+        })
+        .collect();
+    fs::write(
+        image_dir.join(DESCRIPTORS_FILE),
+        serde_json::to_vec(&descriptors)?,
+    )?;
+
+    let netns = fs::metadata(format!("/proc/{}/ns/net", pid))?.ino();
+
+    let mut args = vec![
+        "dump".to_string(),
+        "--tree".to_string(),
+        pid.to_string(),
+        "--images-dir".to_string(),
+        image_dir.to_string_lossy().into_owned(),
+        "--log-file".to_string(),
+        "dump.log".to_string(),
+        "--manage-cgroups=ignore".to_string(),
+        "--external".to_string(),
+        format!("net[{}]:{}", netns, NETNS_KEY),
+    ];
+    for (mount_point, _) in external_mounts(spec) {
+        args.push("--external".to_string());
+        args.push(format!("mnt[{}]:{}", mount_point, mount_point));
+    }
+    if leave_running {
+        args.push("--leave-running".to_string());
+    }
+    args.extend(options.args());
+
+    run_criu_reaped(logger, &args).await?;
+
+    let mut files = Vec::new();
+    for entry in fs::read_dir(image_dir)? {
+        let entry = entry?;
+        if entry.file_type()?.is_file() {
+            files.push(entry.file_name().to_string_lossy().into_owned());
+        }
+    }
+    files.sort();
+
+    Ok(files)
+}
+
+// restore restores the process tree dumped in image_dir in the created
+// container, in place of its init process. The caller holds WAIT_PID_LOCKER
+// until the restored process replaces the init process, so that the reaper
+// does not reap it before.
+pub fn restore(
+    logger: &Logger,
+    ctr: &mut LinuxContainer,
+    image_dir: &Path,
+    options: &CriuOptions,
+) -> Result<()> {
+    let spec = container_spec(ctr)?;
+    check_spec(spec)?;
+
+    let rootfs = spec
+        .root
+        .as_ref()
+        .map(|r| r.path.clone())
+        .ok_or_else(|| anyhow!("container {} has no root", ctr.id))?;
+
+    // The init process waiting to exec provides the namespaces and the IO
+    // streams of the container until it is replaced.
+    let waiting_pid = ctr.init_process_pid;
+    let pid_file = image_dir.join("restore.pid");
+    let _ = fs::remove_file(&pid_file);
+
+    let mut args = vec![
+        "restore".to_string(),
+        "--images-dir".to_string(),
+        image_dir.to_string_lossy().into_owned(),
+        "--log-file".to_string(),
+        "restore.log".to_string(),
+        "--manage-cgroups=ignore".to_string(),
+        "--restore-detached".to_string(),
+        "--pidfile".to_string(),
+        pid_file.to_string_lossy().into_owned(),
+        "--root".to_string(),
+        rootfs,
+    ];
+
+    let mut files = vec![File::open(format!("/proc/{}/ns/net", waiting_pid))?];
+    args.push("--inherit-fd".to_string());
+    args.push(format!("fd[{}]:{}", FIRST_INHERITED_FD, NETNS_KEY));
+
+    for ns_type in [IPCNAMESPACE, UTSNAMESPACE] {
+        if namespace_path(spec, ns_type).map_or(false, |path| !path.is_empty()) {
+            args.push("--join-ns".to_string());
+            args.push(format!("{}:/proc/{}/ns/{}", ns_type, waiting_pid, ns_type));
+        }
+    }
+
+    let descriptors: Vec<String> =
+        serde_json::from_slice(&fs::read(image_dir.join(DESCRIPTORS_FILE))?)?;
+    for (fd, target) in descriptors.iter().enumerate() {
+        if !target.starts_with("pipe:") {
+            continue;
+        }
+        let stream = OpenOptions::new()
+            .read(fd == 0)
+            .write(fd != 0)
+            .open(format!("/proc/{}/fd/{}", waiting_pid, fd))?;
+        args.push("--inherit-fd".to_string());
+        args.push(format!(
+            "fd[{}]:{}",
+            FIRST_INHERITED_FD + files.len() as RawFd,
+            target
+        ));
+        files.push(stream);
+    }
+
+    for (mount_point, source) in external_mounts(spec) {
+        args.push("--external".to_string());
+        args.push(format!("mnt[{}]:{}", mount_point, source));
+    }
+    args.extend(options.args());
+
+    run_criu(logger, &args, &files)?;
+
+    let pid: pid_t = fs::read_to_string(&pid_file)?
+        .trim()
+        .parse()
+        .context("parse the pid of the restored process")?;
+
+    // criu leaves the restored processes in its own cgroup.
+    let pidns = fs::read_link(format!("/proc/{}/ns/pid", pid))?;
+    for entry in fs::read_dir("/proc")? {
+        let member = match entry?
+            .file_name()
+            .to_str()
+            .and_then(|n| n.parse::<pid_t>().ok())
+        {
+            Some(member) => member,
+            None => continue,
+        };
+        if fs::read_link(format!("/proc/{}/ns/pid", member)).ok() == Some(pidns.clone()) {
+            ctr.cgroup_manager.as_ref().apply(member)?;
+        }
+    }
+
+    ctr.replace_init_process(pid)
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+    use oci::{Linux, LinuxNamespace, Mount, Process, Root};
+
+    fn test_spec() -> Spec {
+        Spec {
+            root: Some(Root {
+                path: "/run/kata-containers/app/rootfs".to_string(),
+                readonly: false,
+            }),
+            mounts: vec![
+                Mount {
+                    destination: "/proc".to_string(),
+                    r#type: "proc".to_string(),
+                    source: "proc".to_string(),
+                    options: vec![],
+                },
+                Mount {
+                    destination: "/data".to_string(),
+                    r#type: "bind".to_string(),
+                    source: "/run/kata-containers/shared/containers/app-data".to_string(),
+                    options: vec!["rbind".to_string()],
+                },
+                Mount {
+                    destination: "/etc/hosts".to_string(),
+                    r#type: "".to_string(),
+                    source: "/run/kata-containers/sandbox/etc/hosts".to_string(),
+                    options: vec!["bind".to_string(), "ro".to_string()],
+                },
+                Mount {
+                    destination: "/sys/fs/cgroup".to_string(),
+                    r#type: "cgroup".to_string(),
+                    source: "cgroup".to_string(),
+                    options: vec![],
+                },
+            ],
+            linux: Some(Linux {
+                namespaces: vec![
+                    LinuxNamespace {
+                        r#type: PIDNAMESPACE.to_string(),
+                        path: "".to_string(),
+                    },
+                    LinuxNamespace {
+                        r#type: IPCNAMESPACE.to_string(),
+                        path: "/proc/1/ns/ipc".to_string(),
+                    },
+                ],
+                ..Default::default()
+            }),
+            ..Default::default()
+        }
+    }
+
+    #[test]
+    fn test_external_mounts() {
+        assert_eq!(
+            external_mounts(&test_spec()),
+            vec![
+                (
+                    "/data".to_string(),
+                    "/run/kata-containers/shared/containers/app-data".to_string()
+                ),
+                (
+                    "/etc/hosts".to_string(),
+                    "/run/kata-containers/sandbox/etc/hosts".to_string()
+                ),
+                ("/sys/fs/cgroup".to_string(), "/sys/fs/cgroup".to_string()),
+            ]
+        );
+    }
+
+    #[test]
+    fn test_check_spec() {
+        let mut spec = test_spec();
+        assert!(check_spec(&spec).is_ok());
+
+        spec.process = Some(Process {
+            terminal: true,
+            ..Default::default()
+        });
+        assert!(check_spec(&spec).is_err());
+
+        let mut spec = test_spec();
+        spec.linux.as_mut().unwrap().namespaces[0].path = "/proc/1/ns/pid".to_string();
+        assert!(check_spec(&spec).is_err());
+    }
+
+    #[test]
+    fn test_criu_options() {
+        let options = CriuOptions {
+            tcp_established: true,
+            file_locks: false,
+            ext_unix_sockets: true,
+        };
+        assert_eq!(options.args(), vec!["--tcp-established", "--ext-unix-sk"]);
+    }
+}
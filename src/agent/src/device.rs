@@ -14,16 +14,18 @@ use std::os::unix::fs::MetadataExt;
 use std::path::{Path, PathBuf};
 use std::str::FromStr;
 use std::sync::Arc;
+use std::time::Duration;
 use tokio::sync::Mutex;
 
 use crate::linux_abi::*;
 use crate::pci;
+use crate::rpc::load_kernel_module;
 use crate::sandbox::Sandbox;
-use crate::uevent::{wait_for_uevent, Uevent, UeventMatcher};
+use crate::uevent::{wait_for_uevent, wait_for_uevent_timeout, Uevent, UeventMatcher};
 use anyhow::{anyhow, Context, Result};
 use cfg_if::cfg_if;
-use oci::{LinuxDeviceCgroup, LinuxResources, Spec};
-use protocols::agent::Device;
+use oci::{LinuxDeviceCgroup, LinuxRdma, LinuxResources, Spec};
+use protocols::agent::{Device, KernelModule};
 use tracing::instrument;
 
 // Convenience function to obtain the scope logger.
@@ -43,12 +45,20 @@ pub const DRIVER_NVDIMM_TYPE: &str = "nvdimm";
 pub const DRIVER_EPHEMERAL_TYPE: &str = "ephemeral";
 pub const DRIVER_LOCAL_TYPE: &str = "local";
 pub const DRIVER_WATCHABLE_BIND_TYPE: &str = "watchable-bind";
+pub const DRIVER_FUSE_TYPE: &str = "fuse";
+pub const DRIVER_NFS_TYPE: &str = "nfs";
 // VFIO PCI device to be bound to a guest kernel driver
 pub const DRIVER_VFIO_PCI_GK_TYPE: &str = "vfio-pci-gk";
 // VFIO PCI device to be bound to vfio-pci and made available inside the
 // container as a VFIO device node
 pub const DRIVER_VFIO_PCI_TYPE: &str = "vfio-pci";
 pub const DRIVER_VFIO_AP_TYPE: &str = "vfio-ap";
+// Device node provided by the guest itself, e.g. an emulated device
+pub const DRIVER_GUEST_TYPE: &str = "guest";
+// Render node of a virtio-gpu device
+pub const DRIVER_VIRTIO_GPU_TYPE: &str = "virtio-gpu";
+// RDMA device node of a VF passed through to the guest
+pub const DRIVER_RDMA_TYPE: &str = "rdma";
 pub const DRIVER_OVERLAYFS_TYPE: &str = "overlayfs";
 pub const FS_TYPE_HUGETLB: &str = "hugetlbfs";
 
@@ -147,7 +157,7 @@ where
 // provided.
 #[instrument]
 pub fn pcipath_to_sysfs(root_bus_sysfs: &str, pcipath: &pci::Path) -> Result<String> {
-    let mut bus = "0000:00".to_string();
+    let mut bus = format!("{:04x}:00", pcipath.domain());
     let mut relpath = String::new();
 
     for i in 0..pcipath.len() {
@@ -346,8 +356,8 @@ struct PciMatcher {
 }
 
 impl PciMatcher {
-    fn new(relpath: &str) -> Result<PciMatcher> {
-        let root_bus = create_pci_root_bus_path();
+    fn new(domain: u16, relpath: &str) -> Result<PciMatcher> {
+        let root_bus = create_pci_domain_root_bus_path(domain);
         Ok(PciMatcher {
             devpath: format!("{}{}", root_bus, relpath),
         })
@@ -364,9 +374,13 @@ pub async fn wait_for_pci_device(
     sandbox: &Arc<Mutex<Sandbox>>,
     pcipath: &pci::Path,
 ) -> Result<pci::Address> {
-    let root_bus_sysfs = format!("{}{}", SYSFS_DIR, create_pci_root_bus_path());
+    let root_bus_sysfs = format!(
+        "{}{}",
+        SYSFS_DIR,
+        create_pci_domain_root_bus_path(pcipath.domain())
+    );
     let sysfs_rel_path = pcipath_to_sysfs(&root_bus_sysfs, pcipath)?;
-    let matcher = PciMatcher::new(&sysfs_rel_path)?;
+    let matcher = PciMatcher::new(pcipath.domain(), &sysfs_rel_path)?;
 
     let uev = wait_for_uevent(sandbox, matcher).await?;
 
@@ -379,6 +393,58 @@ pub async fn wait_for_pci_device(
     Ok(addr)
 }
 
+// wait_for_device waits for the device of type dev_type to appear at address
+// in the guest, for at most timeout. It lets the runtime check that the
+// devices it hotplugged are there before creating the containers using them,
+// and wait for them according to how loaded the host is.
+#[instrument]
+pub async fn wait_for_device(
+    sandbox: &Arc<Mutex<Sandbox>>,
+    dev_type: &str,
+    address: &str,
+    timeout: Duration,
+) -> Result<()> {
+    match dev_type {
+        DRIVER_BLK_TYPE | DRIVER_VFIO_PCI_TYPE => {
+            let pcipath = pci::Path::from_str(address)?;
+            let root_bus_sysfs = format!(
+                "{}{}",
+                SYSFS_DIR,
+                create_pci_domain_root_bus_path(pcipath.domain())
+            );
+            let sysfs_rel_path = pcipath_to_sysfs(&root_bus_sysfs, &pcipath)?;
+
+            if dev_type == DRIVER_BLK_TYPE {
+                let matcher = VirtioBlkPciMatcher::new(&sysfs_rel_path);
+                wait_for_uevent_timeout(sandbox, matcher, timeout).await?;
+            } else {
+                let matcher = PciMatcher::new(pcipath.domain(), &sysfs_rel_path)?;
+                wait_for_uevent_timeout(sandbox, matcher, timeout).await?;
+            }
+        }
+        #[cfg(target_arch = "s390x")]
+        DRIVER_BLK_CCW_TYPE => {
+            let device = ccw::Device::from_str(address)?;
+            let matcher = VirtioBlkCCWMatcher::new(CCW_ROOT_BUS_PATH, &device);
+            wait_for_uevent_timeout(sandbox, matcher, timeout).await?;
+        }
+        DRIVER_SCSI_TYPE => {
+            let matcher = ScsiBlockMatcher::new(address);
+            scan_scsi_bus(address)?;
+            wait_for_uevent_timeout(sandbox, matcher, timeout).await?;
+        }
+        _ => {
+            return Err(anyhow!(
+                "Cannot wait for device of type {} at {}",
+                dev_type,
+                address
+            ))
+        }
+    }
+
+    Ok(())
+}
+
 #[derive(Debug)]
 struct VfioMatcher {
     syspath: String,
@@ -574,6 +640,8 @@ struct SpecUpdate {
     dev: Option<DevUpdate>,
     // optional corrections for PCI addresses
     pci: Vec<(pci::Address, pci::Address)>,
+    // optional rdma cgroup limits for the guest RDMA device
+    rdma: Option<(String, LinuxRdma)>,
 }
 
 impl<T: Into<DevUpdate>> From<T> for SpecUpdate {
@@ -581,6 +649,7 @@ impl<T: Into<DevUpdate>> From<T> for SpecUpdate {
         SpecUpdate {
             dev: Some(dev.into()),
             pci: Vec::new(),
+            rdma: None,
         }
     }
 }
@@ -785,6 +854,193 @@ async fn virtio_nvdimm_device_handler(
     Ok(DevNumUpdate::from_vm_path(&device.vm_path)?.into())
 }
 
+#[instrument]
+async fn guest_device_handler(
+    device: &Device,
+    _sandbox: &Arc<Mutex<Sandbox>>,
+) -> Result<SpecUpdate> {
+    if device.vm_path.is_empty() {
+        return Err(anyhow!("Invalid path for guest device"));
+    }
+
+    Ok(DevNumUpdate::from_vm_path(&device.vm_path)?.into())
+}
+
+#[instrument]
+async fn virtio_gpu_device_handler(
+    device: &Device,
+    _sandbox: &Arc<Mutex<Sandbox>>,
+) -> Result<SpecUpdate> {
+    let name = Path::new(&device.vm_path)
+        .file_name()
+        .ok_or_else(|| anyhow!("Invalid path for virtio-gpu device"))?;
+
+    // The render node is provided by the guest, make sure it is
+    // actually backed by virtio-gpu rather than e.g. an emulated VGA.
+    let driver_path = Path::new(SYSFS_DRM_PATH)
+        .join(name)
+        .join("device")
+        .join("driver");
+    let driver = fs::read_link(&driver_path).with_context(|| {
+        format!(
+            "virtio-gpu device {} not found, check the guest kernel has virtio-gpu support",
+            device.vm_path
+        )
+    })?;
+
+    if driver.file_name() != Some(OsStr::new(VIRTIO_GPU_DRIVER)) {
+        return Err(anyhow!(
+            "device {} is driven by {:?}, not {}",
+            device.vm_path,
+            driver,
+            VIRTIO_GPU_DRIVER
+        ));
+    }
+
+    Ok(DevNumUpdate::from_vm_path(&device.vm_path)?.into())
+}
+
+// The RDMA modules of the drivers of the devices that have one, the
+// drivers themselves do not provide RDMA devices.
+fn rdma_provider_module(driver: &str) -> Option<&'static str> {
+    match driver {
+        "mlx5_core" => Some("mlx5_ib"),
+        "ice" => Some("irdma"),
+        _ => None,
+    }
+}
+
+// The options of an RDMA device, as "key=value" strings:
+//     bdf: the PCI address of the device in the host
+//     mac: the hardware address of its network interface
+//     driver: its host driver
+//     hca_handle, hca_object: the rdma cgroup limits of the container
+#[derive(Debug, Default, PartialEq)]
+struct RdmaOptions {
+    bdf: Option<pci::Address>,
+    mac: Option<String>,
+    driver: Option<String>,
+    limit: LinuxRdma,
+}
+
+impl RdmaOptions {
+    fn from_options(options: &[String]) -> Result<Self> {
+        let mut rdma = RdmaOptions::default();
+
+        for opt in options {
+            let (key, value) = opt
+                .split_once('=')
+                .ok_or_else(|| anyhow!("Malformed RDMA option {:?}", opt))?;
+            match key {
+                "bdf" => {
+                    rdma.bdf = Some(
+                        pci::Address::from_str(value)
+                            .with_context(|| format!("Bad PCI address in RDMA option {:?}", opt))?,
+                    )
+                }
+                "mac" => rdma.mac = Some(value.to_string()),
+                "driver" => rdma.driver = Some(value.to_string()),
+                "hca_handle" => rdma.limit.hca_handles = Some(value.parse()?),
+                "hca_object" => rdma.limit.hca_objects = Some(value.parse()?),
+                _ => return Err(anyhow!("Unknown RDMA option {:?}", opt)),
+            }
+        }
+
+        Ok(rdma)
+    }
+}
+
+// find_rdma_verbs_device returns the name of the guest verbs device of the
+// PCI device addr, or of the network interface with the hardware address
+// mac.
+fn find_rdma_verbs_device(
+    sysfs_verbs: &str,
+    addr: Option<pci::Address>,
+    mac: Option<&str>,
+) -> Result<Option<String>> {
+    let entries = fs::read_dir(sysfs_verbs)
+        .context("no RDMA device found, check the guest kernel has RDMA support for the device")?;
+
+    for entry in entries {
+        let entry = entry?;
+        let name = entry.file_name().to_string_lossy().to_string();
+        if !name.starts_with("uverbs") {
+            continue;
+        }
+
+        let device = entry.path().join("device");
+        if let Some(addr) = addr {
+            let path = fs::canonicalize(&device)?;
+            if path.file_name() == Some(OsStr::new(&addr.to_string())) {
+                return Ok(Some(name));
+            }
+        }
+
+        if let (Some(mac), Ok(ifaces)) = (mac, fs::read_dir(device.join("net"))) {
+            for iface in ifaces {
+                let address = fs::read_to_string(iface?.path().join("address"))?;
+                if address.trim().eq_ignore_ascii_case(mac) {
+                    return Ok(Some(name));
+                }
+            }
+        }
+    }
+
+    Ok(None)
+}
+
+// The RDMA device nodes are the ones of the VFs passed through to the guest,
+// their guest name may differ from the host one.
+#[instrument]
+async fn rdma_device_handler(device: &Device, sandbox: &Arc<Mutex<Sandbox>>) -> Result<SpecUpdate> {
+    let options = RdmaOptions::from_options(&device.options)?;
+
+    let mut modules = Vec::new();
+    if let Some(driver) = options.driver.as_deref() {
+        modules.push(driver);
+        modules.extend(rdma_provider_module(driver));
+    }
+    modules.extend(["ib_uverbs", "rdma_ucm"]);
+
+    for name in modules {
+        let module = KernelModule {
+            name: name.to_string(),
+            ..Default::default()
+        };
+        // The modules may be built in the guest kernel.
+        if let Err(e) = load_kernel_module(&module) {
+            info!(sl(), "failed to load RDMA module {}: {:?}", name, e);
+        }
+    }
+
+    // The connection manager node is not bound to a device.
+    if options.bdf.is_none() && options.mac.is_none() {
+        return Ok(DevNumUpdate::from_vm_path(&device.vm_path)?.into());
+    }
+
+    let addr = match options.bdf {
+        Some(bdf) => sandbox.lock().await.pcimap.get(&bdf).copied(),
+        None => None,
+    };
+    let mac = options.mac.as_deref();
+    let name = find_rdma_verbs_device(SYSFS_INFINIBAND_VERBS_PATH, addr, mac)?
+        .ok_or_else(|| anyhow!("RDMA device {} not found in guest", device.container_path))?;
+
+    let vm_path = Path::new(RDMA_DEV_DIR)
+        .join(&name)
+        .to_string_lossy()
+        .to_string();
+    let sysfs_path = Path::new(SYSFS_INFINIBAND_VERBS_PATH).join(&name);
+    let ibdev = fs::read_to_string(sysfs_path.join("ibdev"))?;
+
+    Ok(SpecUpdate {
+        dev: Some(DevUpdate::from_vm_path(&vm_path, vm_path.clone())?),
+        pci: Vec::new(),
+        rdma: (options.limit != LinuxRdma::default())
+            .then(|| (ibdev.trim().to_string(), options.limit)),
+    })
+}
+
 fn split_vfio_pci_option(opt: &str) -> Option<(&str, &str)> {
     let mut tokens = opt.split('=');
     let hostbdf = tokens.next()?;
@@ -831,9 +1087,9 @@ async fn vfio_pci_device_handler(
             }
 
             group = Some(devgroup);
-
-            pci_fixups.push((host, guestdev));
         }
+
+        pci_fixups.push((host, guestdev));
     }
 
     let dev_update = if vfio_in_guest {
@@ -850,6 +1106,7 @@ async fn vfio_pci_device_handler(
     Ok(SpecUpdate {
         dev: dev_update,
         pci: pci_fixups,
+        rdma: None,
     })
 }
 
@@ -882,9 +1139,11 @@ pub async fn add_devices(
     sandbox: &Arc<Mutex<Sandbox>>,
 ) -> Result<()> {
     let mut dev_updates = HashMap::<&str, DevUpdate>::with_capacity(devices.len());
+    let mut rdma_limits = HashMap::new();
 
     for device in devices.iter() {
         let update = add_device(device, sandbox).await?;
+        rdma_limits.extend(update.rdma);
         if let Some(dev_update) = update.dev {
             if dev_updates
                 .insert(&device.container_path, dev_update)
@@ -913,7 +1172,29 @@ pub async fn add_devices(
     if let Some(process) = spec.process.as_mut() {
         update_env_pci(&mut process.env, &sandbox.lock().await.pcimap)?
     }
-    update_spec_devices(spec, dev_updates)
+    update_spec_devices(spec, dev_updates)?;
+    update_spec_rdma(spec, rdma_limits)
+}
+
+// update_spec_rdma sets the rdma cgroup limits of the guest RDMA devices, the
+// ones of the host devices are not passed to the agent.
+#[instrument]
+fn update_spec_rdma(spec: &mut Spec, limits: HashMap<String, LinuxRdma>) -> Result<()> {
+    if limits.is_empty() {
+        return Ok(());
+    }
+
+    let linux = spec
+        .linux
+        .as_mut()
+        .ok_or_else(|| anyhow!("Spec didn't contain linux field"))?;
+    linux
+        .resources
+        .get_or_insert_with(LinuxResources::default)
+        .rdma
+        .extend(limits);
+
+    Ok(())
 }
 
 #[instrument]
@@ -944,6 +1225,9 @@ async fn add_device(device: &Device, sandbox: &Arc<Mutex<Sandbox>>) -> Result<Sp
             vfio_pci_device_handler(device, sandbox).await
         }
         DRIVER_VFIO_AP_TYPE => vfio_ap_device_handler(device, sandbox).await,
+        DRIVER_GUEST_TYPE => guest_device_handler(device, sandbox).await,
+        DRIVER_VIRTIO_GPU_TYPE => virtio_gpu_device_handler(device, sandbox).await,
+        DRIVER_RDMA_TYPE => rdma_device_handler(device, sandbox).await,
         _ => Err(anyhow!("Unknown device type {}", device.type_)),
     }
 }
@@ -1415,6 +1699,11 @@ mod tests {
 
         let relpath = pcipath_to_sysfs(rootbuspath, &path234);
         assert_eq!(relpath.unwrap(), "/0000:00:02.0/0000:01:03.0/0000:02:04.0");
+
+        // The device at slot 01 of the root bus of the domain 0001
+        let path1 = pci::Path::from_str("0001:01").unwrap();
+        let relpath = pcipath_to_sysfs(rootbuspath, &path1);
+        assert_eq!(relpath.unwrap(), "/0001:00:01.0");
     }
 
     // We use device specific variants of this for real cases, but
@@ -1466,6 +1755,36 @@ mod tests {
         assert_eq!(name.unwrap(), devname);
     }
 
+    #[tokio::test]
+    async fn test_wait_for_device() {
+        let root_bus = create_pci_root_bus_path();
+        let devpath = format!("{}/0000:00:02.0", root_bus);
+        let timeout = Duration::from_millis(10);
+
+        let logger = slog::Logger::root(slog::Discard, o!());
+        let sandbox = Arc::new(Mutex::new(Sandbox::new(&logger).unwrap()));
+
+        let result = wait_for_device(&sandbox, DRIVER_VFIO_PCI_TYPE, "02", timeout).await;
+        assert!(result.is_err(), "device acknowledged before its uevent");
+
+        let mut uev = crate::uevent::Uevent::default();
+        uev.action = crate::linux_abi::U_EVENT_ACTION_ADD.to_string();
+        uev.devpath = devpath.clone();
+
+        let mut sb = sandbox.lock().await;
+        sb.uevent_map.insert(devpath, uev);
+        drop(sb); // unlock
+
+        let result = wait_for_device(&sandbox, DRIVER_VFIO_PCI_TYPE, "02", timeout).await;
+        assert!(result.is_ok(), "{}", result.unwrap_err());
+
+        let result = wait_for_device(&sandbox, DRIVER_VFIO_PCI_TYPE, "zz", timeout).await;
+        assert!(result.is_err(), "invalid PCI path accepted");
+
+        let result = wait_for_device(&sandbox, DRIVER_NVDIMM_TYPE, "02", timeout).await;
+        assert!(result.is_err(), "unsupported device type accepted");
+    }
+
     #[tokio::test]
     async fn test_virtio_blk_matcher() {
         let root_bus = create_pci_root_bus_path();
@@ -1715,6 +2034,98 @@ mod tests {
         assert!(pci_iommu_group(&syspci, dev2).is_err());
     }
 
+    #[test]
+    fn test_rdma_options() {
+        let options = RdmaOptions::from_options(&[
+            "bdf=0000:3b:00.2".to_string(),
+            "mac=02:00:00:00:00:01".to_string(),
+            "driver=mlx5_core".to_string(),
+            "hca_handle=4".to_string(),
+        ])
+        .unwrap();
+
+        assert_eq!(
+            options,
+            RdmaOptions {
+                bdf: Some(pci::Address::from_str("0000:3b:00.2").unwrap()),
+                mac: Some("02:00:00:00:00:01".to_string()),
+                driver: Some("mlx5_core".to_string()),
+                limit: LinuxRdma {
+                    hca_handles: Some(4),
+                    hca_objects: None,
+                },
+            }
+        );
+        assert_eq!(
+            RdmaOptions::from_options(&[]).unwrap(),
+            RdmaOptions::default()
+        );
+
+        assert!(RdmaOptions::from_options(&["bdf".to_string()]).is_err());
+        assert!(RdmaOptions::from_options(&["bdf=00:01".to_string()]).is_err());
+        assert!(RdmaOptions::from_options(&["hca_object=-1".to_string()]).is_err());
+        assert!(RdmaOptions::from_options(&["port=1".to_string()]).is_err());
+    }
+
+    #[test]
+    fn test_find_rdma_verbs_device() {
+        let testdir = tempdir().expect("failed to create tmpdir");
+        let sysfs_verbs = testdir.path().join("infiniband_verbs");
+        let sysfs_verbs = sysfs_verbs.to_str().unwrap();
+
+        let addr0 = pci::Address::from_str("0000:00:02.0").unwrap();
+        let addr1 = pci::Address::from_str("0000:00:03.0").unwrap();
+
+        assert!(find_rdma_verbs_device(sysfs_verbs, Some(addr0), None).is_err());
+
+        for (name, addr, mac) in [
+            ("uverbs0", addr0, "02:00:00:00:00:01"),
+            ("uverbs1", addr1, "02:00:00:00:00:0a"),
+        ] {
+            let device = testdir.path().join("devices").join(addr.to_string());
+            let iface = device.join("net").join("eth0");
+            fs::create_dir_all(&iface).unwrap();
+            fs::write(iface.join("address"), format!("{}\n", mac)).unwrap();
+
+            let verbs = Path::new(sysfs_verbs).join(name);
+            fs::create_dir_all(&verbs).unwrap();
+            std::os::unix::fs::symlink(&device, verbs.join("device")).unwrap();
+        }
+        fs::write(Path::new(sysfs_verbs).join("abi_version"), "6\n").unwrap();
+
+        let find = |addr, mac| find_rdma_verbs_device(sysfs_verbs, addr, mac).unwrap();
+        assert_eq!(find(Some(addr1), None), Some("uverbs1".to_string()));
+        assert_eq!(
+            find(None, Some("02:00:00:00:00:01")),
+            Some("uverbs0".to_string())
+        );
+        assert_eq!(
+            find(None, Some("02:00:00:00:00:0A")),
+            Some("uverbs1".to_string())
+        );
+        assert_eq!(find(None, Some("02:00:00:00:00:02")), None);
+        assert_eq!(find(None, None), None);
+    }
+
+    #[test]
+    fn test_update_spec_rdma() {
+        let mut spec = Spec::default();
+        assert!(update_spec_rdma(&mut spec, HashMap::new()).is_ok());
+
+        let limits = HashMap::from_iter(vec![(
+            "mlx5_0".to_string(),
+            LinuxRdma {
+                hca_handles: Some(4),
+                hca_objects: Some(1000),
+            },
+        )]);
+        assert!(update_spec_rdma(&mut spec, limits.clone()).is_err());
+
+        spec.linux = Some(Linux::default());
+        update_spec_rdma(&mut spec, limits.clone()).unwrap();
+        assert_eq!(spec.linux.unwrap().resources.unwrap().rdma, limits);
+    }
+
     #[cfg(target_arch = "s390x")]
     #[tokio::test]
     async fn test_vfio_ap_matcher() {
@@ -0,0 +1,109 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+use anyhow::{anyhow, Result};
+use nix::errno::Errno;
+use nix::fcntl::{self, OFlag};
+use nix::sys::stat::Mode;
+use std::collections::HashSet;
+use std::fs::{self, File};
+use std::io;
+use std::os::unix::fs::MetadataExt;
+use std::os::unix::io::{AsRawFd, FromRawFd};
+
+// _IOWR('X', 119, int) and _IOWR('X', 120, int)
+const FIFREEZE: libc::c_ulong = 0xc0045877;
+const FITHAW: libc::c_ulong = 0xc0045878;
+
+// Handle the differing ioctl(2) request types for different targets
+#[cfg(target_env = "musl")]
+type IoctlRequestType = libc::c_int;
+#[cfg(target_env = "gnu")]
+type IoctlRequestType = libc::c_ulong;
+
+fn fs_ioctl(mount_point: &str, request: libc::c_ulong) -> nix::Result<()> {
+    let fd = fcntl::open(
+        mount_point,
+        OFlag::O_RDONLY | OFlag::O_CLOEXEC,
+        Mode::empty(),
+    )?;
+    // Wrap fd with `File` to properly close descriptor on exit
+    let dir = unsafe { File::from_raw_fd(fd) };
+    let ret = unsafe { libc::ioctl(dir.as_raw_fd(), request as IoctlRequestType, 0) };
+    Errno::result(ret).map(drop)
+}
+
+// freeze freezes the filesystems of the mount points and returns the mount
+// points it froze. The mount points which are not there any more, those of
+// the filesystems which cannot be frozen, as virtio-fs or overlay, and those
+// of a filesystem already frozen through another mount point are skipped.
+// The filesystems are thawed again when one of them fails to freeze.
+pub fn freeze(mount_points: &[String]) -> Result<Vec<String>> {
+    let mut frozen: Vec<String> = Vec::new();
+    let mut devices = HashSet::new();
+
+    for mount_point in mount_points {
+        let dev = match fs::metadata(mount_point) {
+            Ok(metadata) => metadata.dev(),
+            Err(e) if e.kind() == io::ErrorKind::NotFound => continue,
+            Err(e) => {
+                let _ = thaw(&mut frozen);
+                return Err(anyhow!(e).context(format!("stat {}", mount_point)));
+            }
+        };
+        if !devices.insert(dev) {
+            continue;
+        }
+
+        match fs_ioctl(mount_point, FIFREEZE) {
+            Ok(()) => frozen.push(mount_point.clone()),
+            Err(Errno::EOPNOTSUPP) | Err(Errno::ENOTTY) | Err(Errno::ENOENT) => continue,
+            Err(e) => {
+                let _ = thaw(&mut frozen);
+                return Err(anyhow!(e).context(format!("freeze {}", mount_point)));
+            }
+        }
+    }
+
+    Ok(frozen)
+}
+
+// thaw thaws the filesystems of the mount points, skipping those which are
+// not frozen, and returns the first error after trying all of them. The mount
+// points of the filesystems which failed to thaw are kept, for a later thaw
+// to try them again.
+pub fn thaw(mount_points: &mut Vec<String>) -> Result<()> {
+    let mut res = Ok(());
+
+    mount_points.retain(|mount_point| match fs_ioctl(mount_point, FITHAW) {
+        Ok(()) | Err(Errno::EINVAL) | Err(Errno::ENOENT) => false,
+        Err(e) => {
+            if res.is_ok() {
+                res = Err(anyhow!(e).context(format!("thaw {}", mount_point)));
+            }
+            true
+        }
+    });
+
+    res
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+    use tempfile::tempdir;
+
+    #[test]
+    fn test_freeze_missing_mount_points() {
+        let dir = tempdir().unwrap();
+        let missing = dir.path().join("missing").to_string_lossy().to_string();
+
+        assert!(freeze(&[missing.clone()]).unwrap().is_empty());
+
+        let mut mount_points = vec![missing];
+        assert!(thaw(&mut mount_points).is_ok());
+        assert!(mount_points.is_empty());
+    }
+}
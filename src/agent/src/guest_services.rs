@@ -0,0 +1,229 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Guest services are systemd units of the guest image, e.g. chronyd or
+// nvidia-persistenced, the runtime asks the agent to run for the pod. Only
+// the units the image lists as allowed are started, the agent then checks
+// them periodically and restarts those which stopped.
+
+use std::collections::HashSet;
+use std::fs;
+use std::process::Command;
+use std::sync::Arc;
+use std::time::Duration;
+
+use anyhow::{anyhow, Context, Result};
+use protocols::agent::GuestService;
+use tokio::sync::Mutex;
+
+use crate::sandbox::Sandbox;
+
+// Units the guest image allows the runtime to start, one per line.
+const ALLOWED_SERVICES_PATH: &str = "/etc/kata-containers/guest-services";
+const SYSTEMCTL: &str = "systemctl";
+const CHECK_INTERVAL: Duration = Duration::from_secs(10);
+
+// Convenience function to obtain the scope logger.
+fn sl() -> slog::Logger {
+    slog_scope::logger().new(o!("subsystem" => "guest-services"))
+}
+
+// unit_name appends the service suffix to the names without unit type.
+fn unit_name(name: &str) -> String {
+    if name.contains('.') {
+        name.to_string()
+    } else {
+        format!("{}.service", name)
+    }
+}
+
+// allowed_services parses the list of the allowed units, ignoring the empty
+// lines and the comments.
+fn allowed_services(content: &str) -> HashSet<String> {
+    content
+        .lines()
+        .map(|line| line.split('#').next().unwrap_or_default().trim())
+        .filter(|line| !line.is_empty())
+        .map(unit_name)
+        .collect()
+}
+
+fn systemctl(args: &[&str]) -> Result<String> {
+    let output = Command::new(SYSTEMCTL)
+        .args(args)
+        .output()
+        .context("run systemctl")?;
+    let stdout = String::from_utf8_lossy(&output.stdout).trim().to_string();
+    if !output.status.success() {
+        return Err(anyhow!(
+            "systemctl {} failed: {}{}",
+            args.join(" "),
+            stdout,
+            String::from_utf8_lossy(&output.stderr).trim()
+        ));
+    }
+    Ok(stdout)
+}
+
+// active_state returns the active state of the unit, systemctl is-active
+// exits with an error for the units which are not active.
+fn active_state(unit: &str) -> String {
+    let output = Command::new(SYSTEMCTL).args(["is-active", unit]).output();
+    match output {
+        Ok(output) => String::from_utf8_lossy(&output.stdout).trim().to_string(),
+        Err(_) => "unknown".to_string(),
+    }
+}
+
+// oneshot_succeeded tells, from the Type and Result properties of a unit, whether
+// it is a oneshot one which ran to completion.
+fn oneshot_succeeded(properties: &str) -> bool {
+    let mut oneshot = false;
+    let mut success = false;
+    for line in properties.lines() {
+        match line.trim() {
+            "Type=oneshot" => oneshot = true,
+            "Result=success" => success = true,
+            _ => {}
+        }
+    }
+    oneshot && success
+}
+
+// exited is true for the oneshot units which exited successfully, those go
+// inactive once done, unless they remain after exit, and are healthy.
+fn exited(unit: &str) -> bool {
+    systemctl(&["show", "--property=Type,Result", unit])
+        .map(|properties| oneshot_succeeded(&properties))
+        .unwrap_or(false)
+}
+
+// start starts the units of the services, all of which must be allowed by
+// the guest image, and returns their status.
+pub fn start(names: &[String], init_mode: bool) -> Result<Vec<GuestService>> {
+    if names.is_empty() {
+        return Ok(Vec::new());
+    }
+    if init_mode {
+        return Err(anyhow!(
+            "guest services need systemd, the agent runs as the init of the guest"
+        ));
+    }
+
+    let allowed = fs::read_to_string(ALLOWED_SERVICES_PATH)
+        .map(|content| allowed_services(&content))
+        .unwrap_or_default();
+    let units: Vec<String> = names.iter().map(|name| unit_name(name)).collect();
+    let denied: Vec<&String> = units.iter().filter(|u| !allowed.contains(*u)).collect();
+    if !denied.is_empty() {
+        return Err(anyhow!(
+            "guest services {:?} are not allowed by the guest image, see {}",
+            denied,
+            ALLOWED_SERVICES_PATH
+        ));
+    }
+
+    let mut services = Vec::new();
+    for unit in units {
+        systemctl(&["start", &unit])?;
+        info!(sl(), "guest service started"; "unit" => &unit);
+
+        let mut service = GuestService::new();
+        service.state = active_state(&unit);
+        service.name = unit;
+        services.push(service);
+    }
+
+    Ok(services)
+}
+
+// check updates the state of the services and restarts those which stopped,
+// whether they failed or exited, but for the oneshot units which completed.
+fn check(services: &mut [GuestService]) {
+    for service in services.iter_mut() {
+        service.state = active_state(&service.name);
+        if service.state != "failed" && service.state != "inactive" {
+            continue;
+        }
+        if service.state == "inactive" && exited(&service.name) {
+            continue;
+        }
+
+        warn!(sl(), "restarting guest service";
+            "unit" => &service.name, "state" => &service.state);
+        match systemctl(&["restart", &service.name]) {
+            Ok(_) => {
+                service.restarts += 1;
+                service.state = active_state(&service.name);
+            }
+            Err(e) => {
+                error!(sl(), "failed to restart guest service";
+                    "unit" => &service.name, "error" => format!("{:?}", e));
+            }
+        }
+    }
+}
+
+// watch health-checks the services of the sandbox until it stops running.
+pub async fn watch(sandbox: Arc<Mutex<Sandbox>>) {
+    loop {
+        tokio::time::sleep(CHECK_INTERVAL).await;
+
+        let services = {
+            let s = sandbox.lock().await;
+            if !s.running {
+                return;
+            }
+            s.guest_services.clone()
+        };
+
+        let services = match tokio::task::spawn_blocking(move || {
+            let mut services = services;
+            check(&mut services);
+            services
+        })
+        .await
+        {
+            Ok(services) => services,
+            Err(e) => {
+                error!(sl(), "failed to check guest services"; "error" => format!("{:?}", e));
+                continue;
+            }
+        };
+
+        sandbox.lock().await.guest_services = services;
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn test_allowed_services() {
+        let allowed =
+            allowed_services("# time\nchronyd\n\niscsid.service  # storage\nnvidia-persistenced\n");
+
+        assert_eq!(allowed.len(), 3);
+        assert!(allowed.contains("chronyd.service"));
+        assert!(allowed.contains("iscsid.service"));
+        assert!(allowed.contains("nvidia-persistenced.service"));
+    }
+
+    #[test]
+    fn test_oneshot_succeeded() {
+        assert!(oneshot_succeeded("Type=oneshot\nResult=success\n"));
+        assert!(!oneshot_succeeded("Type=oneshot\nResult=exit-code\n"));
+        assert!(!oneshot_succeeded("Type=simple\nResult=success\n"));
+        assert!(!oneshot_succeeded(""));
+    }
+
+    #[test]
+    fn test_start_not_allowed() {
+        assert!(start(&[], true).unwrap().is_empty());
+        assert!(start(&["chronyd".to_string()], true).is_err());
+        assert!(start(&["sshd".to_string()], false).is_err());
+    }
+}
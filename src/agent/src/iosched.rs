@@ -0,0 +1,166 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// The block IO weights of the cgroups of the containers are only honoured by
+// the BFQ IO scheduler, which the block devices of the guest do not use by
+// default, so it is selected for the devices of the containers given weights.
+
+use anyhow::{anyhow, Context, Result};
+use nix::sys::stat::{major, minor};
+use oci::LinuxResources;
+use std::fs;
+use std::io;
+use std::os::unix::fs::MetadataExt;
+use std::path::{Path, PathBuf};
+
+const SYSFS_DIR: &str = "/sys";
+
+const BFQ_SCHEDULER: &str = "bfq";
+
+// has_blkio_weight tells if the resources give the cgroup a block IO weight.
+pub fn has_blkio_weight(resources: Option<&LinuxResources>) -> bool {
+    resources
+        .and_then(|r| r.block_io.as_ref())
+        .map_or(false, |blkio| {
+            blkio.weight.unwrap_or(0) > 0 || blkio.leaf_weight.unwrap_or(0) > 0
+        })
+}
+
+// select_bfq selects the BFQ scheduler for the block devices of the
+// filesystems of the mount points, and returns the devices it changed. The
+// mount points which are not there any more, and those of the filesystems
+// without a block device, as virtio-fs or overlay, are skipped.
+pub fn select_bfq(mount_points: &[String]) -> Result<Vec<PathBuf>> {
+    select_bfq_in(Path::new(SYSFS_DIR), mount_points)
+}
+
+fn select_bfq_in(sysfs: &Path, mount_points: &[String]) -> Result<Vec<PathBuf>> {
+    let mut changed = Vec::new();
+
+    for mount_point in mount_points {
+        let dev = match fs::metadata(mount_point) {
+            Ok(metadata) => metadata.dev(),
+            Err(e) if e.kind() == io::ErrorKind::NotFound => continue,
+            Err(e) => return Err(anyhow!(e).context(format!("stat {}", mount_point))),
+        };
+
+        let device = sysfs
+            .join("dev/block")
+            .join(format!("{}:{}", major(dev), minor(dev)));
+        let scheduler = match queue_scheduler(&device) {
+            Some(scheduler) => scheduler,
+            None => continue,
+        };
+
+        let schedulers =
+            fs::read_to_string(&scheduler).context(format!("read {}", scheduler.display()))?;
+        if selected_scheduler(&schedulers) == Some(BFQ_SCHEDULER) {
+            continue;
+        }
+        if !schedulers.split_whitespace().any(|s| s == BFQ_SCHEDULER) {
+            return Err(anyhow!(
+                "{} is not available for {}",
+                BFQ_SCHEDULER,
+                mount_point
+            ));
+        }
+
+        fs::write(&scheduler, BFQ_SCHEDULER).context(format!("write {}", scheduler.display()))?;
+        changed.push(device);
+    }
+
+    Ok(changed)
+}
+
+// queue_scheduler returns the scheduler file of the queue of a block device,
+// that of the disk for a partition, if it is a block device.
+fn queue_scheduler(device: &Path) -> Option<PathBuf> {
+    [device.join("queue"), device.join("../queue")]
+        .iter()
+        .map(|queue| queue.join("scheduler"))
+        .find(|scheduler| scheduler.exists())
+}
+
+// selected_scheduler returns the scheduler between brackets in the list of
+// the schedulers of a queue.
+fn selected_scheduler(schedulers: &str) -> Option<&str> {
+    schedulers
+        .split_whitespace()
+        .find(|s| s.starts_with('[') && s.ends_with(']'))
+        .map(|s| s.trim_start_matches('[').trim_end_matches(']'))
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+    use oci::LinuxBlockIo;
+    use tempfile::tempdir;
+
+    #[test]
+    fn test_has_blkio_weight() {
+        assert!(!has_blkio_weight(None));
+
+        let mut resources = LinuxResources::default();
+        assert!(!has_blkio_weight(Some(&resources)));
+
+        resources.block_io = Some(LinuxBlockIo {
+            weight: Some(0),
+            ..Default::default()
+        });
+        assert!(!has_blkio_weight(Some(&resources)));
+
+        resources.block_io = Some(LinuxBlockIo {
+            weight: Some(500),
+            ..Default::default()
+        });
+        assert!(has_blkio_weight(Some(&resources)));
+    }
+
+    #[test]
+    fn test_selected_scheduler() {
+        assert_eq!(
+            selected_scheduler("[mq-deadline] kyber bfq none\n"),
+            Some("mq-deadline")
+        );
+        assert_eq!(selected_scheduler("mq-deadline [bfq] none"), Some("bfq"));
+        assert_eq!(selected_scheduler("none"), None);
+    }
+
+    #[test]
+    fn test_select_bfq() {
+        let sysfs = tempdir().unwrap();
+        let dir = tempdir().unwrap();
+        let mount_point = dir.path().to_string_lossy().to_string();
+        let missing = dir.path().join("missing").to_string_lossy().to_string();
+
+        // not a block device
+        assert!(select_bfq_in(sysfs.path(), &[mount_point.clone(), missing])
+            .unwrap()
+            .is_empty());
+
+        let dev = fs::metadata(dir.path()).unwrap().dev();
+        let device = sysfs
+            .path()
+            .join("dev/block")
+            .join(format!("{}:{}", major(dev), minor(dev)));
+        fs::create_dir_all(device.join("queue")).unwrap();
+        let scheduler = device.join("queue/scheduler");
+
+        fs::write(&scheduler, "[none] mq-deadline\n").unwrap();
+        assert!(select_bfq_in(sysfs.path(), &[mount_point.clone()]).is_err());
+
+        fs::write(&scheduler, "[mq-deadline] bfq none\n").unwrap();
+        assert_eq!(
+            select_bfq_in(sysfs.path(), &[mount_point.clone()]).unwrap(),
+            vec![device]
+        );
+        assert_eq!(fs::read_to_string(&scheduler).unwrap(), "bfq");
+
+        fs::write(&scheduler, "mq-deadline [bfq] none\n").unwrap();
+        assert!(select_bfq_in(sysfs.path(), &[mount_point])
+            .unwrap()
+            .is_empty());
+    }
+}
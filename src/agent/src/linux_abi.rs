@@ -21,6 +21,23 @@ pub fn create_pci_root_bus_path() -> String {
     String::from("/devices/pci0000:00")
 }
 
+// create_pci_domain_root_bus_path returns the path of the root bus of a PCI
+// domain, the extra PCI host bridges of a pseries machine being the root
+// buses of their own domains.
+pub fn create_pci_domain_root_bus_path(domain: u16) -> String {
+    if domain == 0 {
+        return create_pci_root_bus_path();
+    }
+    format!("/devices/pci{:04x}:00", domain)
+}
+
+// The PCIe host bridge of the riscv64 virt machine is only described by the
+// device tree, there is no ACPI.
+#[cfg(target_arch = "riscv64")]
+pub fn create_pci_root_bus_path() -> String {
+    String::from("/devices/platform/soc/30000000.pci/pci0000:00")
+}
+
 #[cfg(target_arch = "aarch64")]
 pub fn create_pci_root_bus_path() -> String {
     let ret = String::from("/devices/platform/4010000000.pcie/pci0000:00");
@@ -90,6 +107,12 @@ pub const SYSFS_MEMORY_ONLINE_PATH: &str = "/sys/devices/system/memory";
 
 pub const SYSFS_SCSI_HOST_PATH: &str = "/sys/class/scsi_host";
 
+pub const SYSFS_DRM_PATH: &str = "/sys/class/drm";
+pub const VIRTIO_GPU_DRIVER: &str = "virtio_gpu";
+
+pub const SYSFS_INFINIBAND_VERBS_PATH: &str = "/sys/class/infiniband_verbs";
+pub const RDMA_DEV_DIR: &str = "/dev/infiniband";
+
 pub const SYSFS_BUS_PCI_PATH: &str = "/sys/bus/pci";
 
 pub const SYSFS_CGROUPPATH: &str = "/sys/fs/cgroup";
@@ -23,6 +23,7 @@ use anyhow::{anyhow, Context, Result};
 use cfg_if::cfg_if;
 use clap::{AppSettings, Parser};
 use nix::fcntl::OFlag;
+use nix::sys::reboot;
 use nix::sys::socket::{self, AddressFamily, SockFlag, SockType, VsockAddr};
 use nix::unistd::{self, dup, Pid};
 use std::env;
@@ -35,18 +36,25 @@ use std::process::exit;
 use std::sync::Arc;
 use tracing::{instrument, span};
 
+mod checkpoint;
 mod config;
 mod console;
 mod device;
+mod fsfreeze;
+mod guest_services;
+mod iosched;
 mod linux_abi;
 mod metrics;
 mod mount;
 mod namespace;
 mod netlink;
 mod network;
+mod network_policy;
 mod pci;
 pub mod random;
 mod sandbox;
+mod seccomp_notify;
+mod serial;
 mod signal;
 mod uevent;
 mod util;
@@ -266,6 +274,16 @@ async fn real_main() -> std::result::Result<(), Box<dyn std::error::Error>> {
         }
     }
 
+    // As the init of the guest, power the VM off rather than exiting, which
+    // panics the kernel, so that the hypervisor exits cleanly and without
+    // waiting for the ACPI power button to be handled.
+    if init_mode {
+        unistd::sync();
+        if let Err(e) = reboot::reboot(reboot::RebootMode::RB_POWER_OFF) {
+            eprintln!("failed to power off the guest: {:?}", e);
+        }
+    }
+
     if wait_errors.is_empty() {
         Ok(())
     } else {
@@ -343,10 +361,27 @@ async fn start_sandbox(
     let (tx, rx) = tokio::sync::oneshot::channel();
     sandbox.lock().await.sender = Some(tx);
 
-    // vsock:///dev/vsock, port
-    let mut server = rpc::start(sandbox.clone(), config.server_addr.as_str(), init_mode)?;
+    // With the serial transport, the runtime reaches the ttrpc server
+    // through a virtio-serial port relayed to a unix socket.
+    let serial_port = serial::find_port(Path::new(serial::VIRTIO_PORTS_PATH));
+    let server_addr = match serial_port {
+        Some(_) => {
+            serial::prepare_server_addr()?;
+            serial::SERVER_ADDR
+        }
+        // vsock:///dev/vsock, port
+        None => config.server_addr.as_str(),
+    };
+
+    let mut server = rpc::start(sandbox.clone(), server_addr, init_mode)?;
     server.start().await?;
 
+    if let Some(port) = serial_port {
+        let serial_task = tokio::spawn(serial::relay(logger.clone(), port, shutdown.clone()));
+
+        tasks.push(serial_task);
+    }
+
     rx.await?;
     server.shutdown().await?;
 
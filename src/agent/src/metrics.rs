@@ -9,12 +9,18 @@ use prometheus::{Encoder, Gauge, GaugeVec, IntCounter, Opts, Registry, TextEncod
 
 use anyhow::{anyhow, Result};
 use slog::warn;
+use std::fs::OpenOptions;
+use std::io::{ErrorKind, Read};
+use std::os::unix::fs::OpenOptionsExt;
 use std::sync::Mutex;
 use tracing::instrument;
 
 const NAMESPACE_KATA_AGENT: &str = "kata_agent";
 const NAMESPACE_KATA_GUEST: &str = "kata_guest";
 
+const HWRNG_PATH: &str = "/dev/hwrng";
+const ENTROPY_AVAIL_PATH: &str = "/proc/sys/kernel/random/entropy_avail";
+
 // Convenience function to obtain the scope logger.
 fn sl() -> slog::Logger {
     slog_scope::logger().new(o!("subsystem" => "metrics"))
@@ -73,6 +79,9 @@ lazy_static! {
 
     static ref GUEST_MEMINFO: GaugeVec =
     GaugeVec::new(Opts::new(format!("{}_{}",NAMESPACE_KATA_GUEST,"meminfo"), "Statistics about memory usage in the system."), &["item"]).unwrap();
+
+    static ref GUEST_ENTROPY: GaugeVec =
+    GaugeVec::new(Opts::new(format!("{}_{}",NAMESPACE_KATA_GUEST,"entropy"), "Guest entropy statistics."), &["item"]).unwrap();
 }
 
 #[instrument]
@@ -125,6 +134,7 @@ fn register_metrics() -> Result<()> {
     REGISTRY.register(Box::new(GUEST_NETDEV_STAT.clone()))?;
     REGISTRY.register(Box::new(GUEST_DISKSTAT.clone()))?;
     REGISTRY.register(Box::new(GUEST_MEMINFO.clone()))?;
+    REGISTRY.register(Box::new(GUEST_ENTROPY.clone()))?;
 
     Ok(())
 }
@@ -263,6 +273,76 @@ fn update_guest_metrics() {
             set_gauge_vec_meminfo(&GUEST_MEMINFO, &meminfo);
         }
     }
+
+    update_guest_entropy();
+}
+
+// update_guest_entropy exports the signals of a guest starved of entropy:
+// the crng blocks the readers of random bytes until it is initialized, and
+// the hardware RNG of the guest, the virtio-rng device, runs dry when the
+// host rate limits it.
+fn update_guest_entropy() {
+    let ready = if crng_ready() { 1.0 } else { 0.0 };
+    GUEST_ENTROPY.with_label_values(&["crng_ready"]).set(ready);
+
+    match read_hwrng() {
+        Err(err) => {
+            info!(sl(), "failed to read the guest hwrng: {:?}", err);
+        }
+        Ok(got_bytes) => {
+            GUEST_ENTROPY.with_label_values(&["hwrng_reads"]).inc();
+            if !got_bytes {
+                GUEST_ENTROPY.with_label_values(&["hwrng_starved"]).inc();
+            }
+        }
+    }
+
+    // Only meaningful before Linux 5.18, where the pool became constant.
+    match read_entropy_stat(ENTROPY_AVAIL_PATH) {
+        Err(err) => {
+            info!(sl(), "failed to get guest entropy_avail: {:?}", err);
+        }
+        Ok(value) => {
+            GUEST_ENTROPY
+                .with_label_values(&["entropy_avail"])
+                .set(value as f64);
+        }
+    }
+}
+
+// crng_ready tells whether the crng of the guest is initialized, i.e. if
+// getrandom(2) does not block.
+fn crng_ready() -> bool {
+    let mut buf = [0u8; 1];
+    let ret = unsafe {
+        libc::getrandom(
+            buf.as_mut_ptr() as *mut libc::c_void,
+            buf.len(),
+            libc::GRND_NONBLOCK,
+        )
+    };
+    ret >= 0
+}
+
+// read_hwrng reads a few bytes from the hardware RNG of the guest without
+// blocking, and tells whether it had some.
+fn read_hwrng() -> Result<bool> {
+    let mut hwrng = OpenOptions::new()
+        .read(true)
+        .custom_flags(libc::O_NONBLOCK)
+        .open(HWRNG_PATH)?;
+    let mut buf = [0u8; 16];
+
+    match hwrng.read(&mut buf) {
+        Ok(n) => Ok(n > 0),
+        Err(err) if err.kind() == ErrorKind::WouldBlock => Ok(false),
+        Err(err) => Err(err.into()),
+    }
+}
+
+fn read_entropy_stat(path: &str) -> Result<u64> {
+    let value = std::fs::read_to_string(path)?;
+    Ok(value.trim().parse::<u64>()?)
 }
 
 #[instrument]
@@ -8,8 +8,9 @@ use std::fs;
 use std::fs::{File, OpenOptions};
 use std::io::{BufRead, BufReader, Write};
 use std::iter;
+use std::net::ToSocketAddrs;
 use std::os::unix::fs::{MetadataExt, PermissionsExt};
-use std::path::Path;
+use std::path::{Path, PathBuf};
 use std::str::FromStr;
 use std::sync::Arc;
 
@@ -23,9 +24,9 @@ use regex::Regex;
 use crate::device::{
     get_scsi_device_name, get_virtio_blk_pci_device_name, get_virtio_mmio_device_name,
     online_device, wait_for_pmem_device, DRIVER_9P_TYPE, DRIVER_BLK_CCW_TYPE, DRIVER_BLK_TYPE,
-    DRIVER_EPHEMERAL_TYPE, DRIVER_LOCAL_TYPE, DRIVER_MMIO_BLK_TYPE, DRIVER_NVDIMM_TYPE,
-    DRIVER_OVERLAYFS_TYPE, DRIVER_SCSI_TYPE, DRIVER_VIRTIOFS_TYPE, DRIVER_WATCHABLE_BIND_TYPE,
-    FS_TYPE_HUGETLB,
+    DRIVER_EPHEMERAL_TYPE, DRIVER_FUSE_TYPE, DRIVER_LOCAL_TYPE, DRIVER_MMIO_BLK_TYPE,
+    DRIVER_NFS_TYPE, DRIVER_NVDIMM_TYPE, DRIVER_OVERLAYFS_TYPE, DRIVER_SCSI_TYPE,
+    DRIVER_VIRTIOFS_TYPE, DRIVER_WATCHABLE_BIND_TYPE, FS_TYPE_HUGETLB,
 };
 use crate::linux_abi::*;
 use crate::pci;
@@ -40,6 +41,10 @@ use tracing::instrument;
 
 pub const TYPE_ROOTFS: &str = "rootfs";
 const SYS_FS_HUGEPAGES_PREFIX: &str = "/sys/kernel/mm/hugepages";
+
+// The mount point of configfs, whose tsm reports are the attestation evidence
+// of the confidential guests (TDX, SEV-SNP, Arm CCA).
+const SYS_KERNEL_CONFIG: &str = "/sys/kernel/config";
 pub const MOUNT_GUEST_TAG: &str = "kataShared";
 
 // Allocating an FSGroup that owns the pod's volumes
@@ -143,8 +148,16 @@ pub const STORAGE_HANDLER_LIST: &[&str] = &[
     DRIVER_SCSI_TYPE,
     DRIVER_NVDIMM_TYPE,
     DRIVER_WATCHABLE_BIND_TYPE,
+    DRIVER_FUSE_TYPE,
+    DRIVER_NFS_TYPE,
 ];
 
+// The port of the NFS servers.
+const NFS_PORT: u16 = 2049;
+
+// The directories of the guest image searched for the FUSE mount helpers.
+const FUSE_MOUNT_HELPER_DIRS: [&str; 4] = ["/sbin", "/usr/sbin", "/bin", "/usr/bin"];
+
 #[instrument]
 pub fn baremount(
     source: &Path,
@@ -209,13 +222,12 @@ async fn ephemeral_storage_handler(
     // normal ephemeral storage
     fs::create_dir_all(Path::new(&storage.mount_point))?;
 
-    // By now we only support one option field: "fsGroup" which
-    // isn't an valid mount option, thus we should remove it when
-    // do mount.
+    // The "fsGroup" option isn't a valid mount option, thus we should
+    // remove it when do mount. The other options, e.g. the size limit of
+    // the volume, are passed to the mount.
     if !storage.options.is_empty() {
-        // ephemeral_storage didn't support mount options except fsGroup.
         let mut new_storage = storage.clone();
-        new_storage.options = Default::default();
+        new_storage.options = ephemeral_mount_options(&storage.options);
         common_storage_handler(logger, &new_storage)?;
 
         let opts_vec: Vec<String> = storage.options.to_vec();
@@ -241,6 +253,15 @@ async fn ephemeral_storage_handler(
     Ok("".to_string())
 }
 
+// ephemeral_mount_options returns the mount options of an ephemeral storage.
+fn ephemeral_mount_options(options: &[String]) -> Vec<String> {
+    options
+        .iter()
+        .filter(|opt| !opt.starts_with(FS_GID))
+        .cloned()
+        .collect()
+}
+
 // update_ephemeral_mounts takes a list of ephemeral mounts and remounts them
 // with mount options passed by the caller
 #[instrument]
@@ -354,6 +375,131 @@ async fn local_storage_handler(
     Ok("".to_string())
 }
 
+// fuse_mount_helper returns the command mounting a FUSE filesystem of type
+// fstype, e.g. fuse.s3fs, with the mount helpers shipped in the guest image:
+// mount.fuse.s3fs if there is one, else the generic mount.fuse3 or mount.fuse
+// given the subtype s3fs.
+fn fuse_mount_helper(fstype: &str) -> Result<(PathBuf, Vec<String>)> {
+    let subtype = fstype
+        .strip_prefix("fuse.")
+        .filter(|s| !s.is_empty())
+        .ok_or_else(|| anyhow!("invalid FUSE filesystem type {:?}", fstype))?;
+
+    let find = |name: &str| {
+        FUSE_MOUNT_HELPER_DIRS
+            .iter()
+            .map(|dir| Path::new(dir).join(name))
+            .find(|p| p.is_file())
+    };
+
+    if let Some(helper) = find(&format!("mount.{}", fstype)) {
+        return Ok((helper, vec![]));
+    }
+    for generic in ["mount.fuse3", "mount.fuse"].iter() {
+        if let Some(helper) = find(generic) {
+            return Ok((helper, vec!["-t".to_string(), subtype.to_string()]));
+        }
+    }
+
+    Err(anyhow!(
+        "no mount helper for the FUSE filesystem type {} in the guest image",
+        fstype
+    ))
+}
+
+// fuse_storage_handler mounts a user-space filesystem with its mount helper.
+// The helper daemonizes the filesystem server, which runs until the storage
+// is unmounted.
+#[instrument]
+async fn fuse_storage_handler(
+    logger: &Logger,
+    storage: &Storage,
+    _sandbox: Arc<Mutex<Sandbox>>,
+) -> Result<String> {
+    let (helper, args) = fuse_mount_helper(&storage.fstype)?;
+
+    fs::create_dir_all(&storage.mount_point).context(format!(
+        "failed to create dir all {:?}",
+        &storage.mount_point
+    ))?;
+
+    let mut cmd = tokio::process::Command::new(&helper);
+    cmd.arg(&storage.source)
+        .arg(&storage.mount_point)
+        .args(&args);
+    if !storage.options.is_empty() {
+        cmd.arg("-o").arg(storage.options.join(","));
+    }
+
+    info!(logger, "mounting FUSE storage";
+        "helper" => helper.display().to_string(),
+        "source" => &storage.source,
+        "mount-point" => &storage.mount_point,
+    );
+
+    // The daemonized server inherits the standard streams, they must not be
+    // pipes waited for.
+    let status = cmd
+        .stdin(std::process::Stdio::null())
+        .stdout(std::process::Stdio::null())
+        .stderr(std::process::Stdio::null())
+        .status()
+        .await
+        .context(format!("failed to run {:?}", helper))?;
+    if !status.success() {
+        return Err(anyhow!(
+            "{:?} failed to mount {} on {}: {}",
+            helper,
+            storage.source,
+            storage.mount_point,
+            status
+        ));
+    }
+
+    Ok(storage.mount_point.clone())
+}
+
+// nfs_server_addr returns the address of the server of a NFS export given as
+// host:/path or [ipv6]:/path.
+fn nfs_server_addr(source: &str) -> Result<std::net::IpAddr> {
+    let host = match source.rfind(":/") {
+        Some(i) if i > 0 => &source[..i],
+        _ => return Err(anyhow!("invalid NFS export {:?}", source)),
+    };
+    let host = host.trim_start_matches('[').trim_end_matches(']');
+
+    if let Ok(addr) = host.parse() {
+        return Ok(addr);
+    }
+
+    (host, NFS_PORT)
+        .to_socket_addrs()
+        .context(format!("failed to resolve the NFS server {}", host))?
+        .next()
+        .map(|a| a.ip())
+        .ok_or_else(|| anyhow!("no address for the NFS server {}", host))
+}
+
+// nfs_storage_handler mounts a NFS export over the network of the guest.
+// Unlike mount.nfs, the kernel does not resolve the server, its address is
+// passed with the addr option.
+#[instrument]
+async fn nfs_storage_handler(
+    logger: &Logger,
+    storage: &Storage,
+    _sandbox: Arc<Mutex<Sandbox>>,
+) -> Result<String> {
+    let mut storage = storage.clone();
+
+    if !storage.options.iter().any(|o| o.starts_with("addr=")) {
+        let source = storage.source.clone();
+        let addr = tokio::task::spawn_blocking(move || nfs_server_addr(&source)).await??;
+        storage.options.push(format!("addr={}", addr));
+    }
+
+    common_storage_handler(logger, &storage)
+}
+
 #[instrument]
 async fn virtio9p_storage_handler(
     logger: &Logger,
@@ -379,23 +525,48 @@ async fn handle_hugetlbfs_storage(logger: &Logger, storage: &Storage) -> Result<
     Ok("".to_string())
 }
 
-// Allocate hugepages by writing to sysfs
+// Allocate hugepages for the mount. The pool is only grown, as it is sized
+// to the hugepages requested by all the containers of the sandbox when they
+// are created.
 fn allocate_hugepages(logger: &Logger, options: &[String]) -> Result<()> {
     info!(logger, "mounting hugePages storage options: {:?}", options);
 
     let (pagesize, size) = get_pagesize_and_size_from_option(options)
         .context(format!("parse mount options: {:?}", &options))?;
 
+    let path = hugepages_pool_path(pagesize);
+    let current = fs::read_to_string(&path)
+        .context(format!("reading {:?}", &path))?
+        .trim_end()
+        .parse::<u64>()
+        .unwrap_or(0);
+    if current >= size / pagesize {
+        info!(
+            logger,
+            "hugepages already allocated. pageSize: {}, pages: {}", pagesize, current
+        );
+        return Ok(());
+    }
+
+    set_hugepages_pool(logger, pagesize, size)
+}
+
+// sysfs entry is always of the form hugepages-${pagesize}kB
+// Ref: https://www.kernel.org/doc/Documentation/vm/hugetlbpage.txt
+fn hugepages_pool_path(pagesize: u64) -> PathBuf {
+    Path::new(SYS_FS_HUGEPAGES_PREFIX)
+        .join(format!("hugepages-{}kB", pagesize / 1024))
+        .join("nr_hugepages")
+}
+
+// Resize the pool of hugepages of the given size by writing to sysfs
+pub fn set_hugepages_pool(logger: &Logger, pagesize: u64, size: u64) -> Result<()> {
     info!(
         logger,
         "allocate hugepages. pageSize: {}, size: {}", pagesize, size
     );
 
-    // sysfs entry is always of the form hugepages-${pagesize}kB
-    // Ref: https://www.kernel.org/doc/Documentation/vm/hugetlbpage.txt
-    let path = Path::new(SYS_FS_HUGEPAGES_PREFIX)
-        .join(format!("hugepages-{}kB", pagesize / 1024))
-        .join("nr_hugepages");
+    let path = hugepages_pool_path(pagesize);
 
     // write numpages to nr_hugepages file.
     let numpages = format!("{}", size / pagesize);
@@ -467,6 +638,27 @@ fn get_pagesize_and_size_from_option(options: &[String]) -> Result<(u64, u64)> {
     Ok((pagesize, size))
 }
 
+// Parse an OCI hugepage size, e.g. "2MB" or "1GB", to bytes
+pub fn parse_hugepage_size(page_size: &str) -> Result<u64> {
+    let (value, unit) = page_size.split_at(
+        page_size
+            .find(|c: char| !c.is_ascii_digit())
+            .unwrap_or(page_size.len()),
+    );
+
+    let value = value
+        .parse::<u64>()
+        .context(format!("parse hugepage size: {:?}", page_size))?;
+    let shift = match unit {
+        "KB" | "kB" => 10,
+        "MB" => 20,
+        "GB" => 30,
+        _ => return Err(anyhow!("invalid hugepage size: {:?}", page_size)),
+    };
+
+    Ok(value << shift)
+}
+
 // virtiommio_blk_storage_handler handles the storage for mmio blk driver.
 #[instrument]
 async fn virtiommio_blk_storage_handler(
@@ -634,6 +826,12 @@ fn mount_storage(logger: &Logger, storage: &Storage) -> Result<()> {
     let options_vec = options_vec.iter().map(String::as_str).collect();
     let (flags, options) = parse_mount_flags_and_options(options_vec);
 
+    // A mount call changing the propagation type of a mount does nothing
+    // else, the propagation type is changed once mounted.
+    let propagation = flags
+        & (MsFlags::MS_SHARED | MsFlags::MS_SLAVE | MsFlags::MS_PRIVATE | MsFlags::MS_UNBINDABLE);
+    let flags = flags & !propagation;
+
     let source = Path::new(&storage.source);
 
     info!(logger, "mounting storage";
@@ -650,7 +848,25 @@ fn mount_storage(logger: &Logger, storage: &Storage) -> Result<()> {
         flags,
         options.as_str(),
         &logger,
-    )
+    )?;
+
+    if !propagation.is_empty() {
+        nix::mount::mount(
+            None::<&str>,
+            mount_path,
+            None::<&str>,
+            propagation | (flags & MsFlags::MS_REC),
+            None::<&str>,
+        )
+        .with_context(|| {
+            format!(
+                "failed to change the propagation of {:?} to {:?}",
+                mount_path, propagation
+            )
+        })?;
+    }
+
+    Ok(())
 }
 
 #[instrument]
@@ -832,6 +1048,8 @@ pub async fn add_storages(
                 virtiommio_blk_storage_handler(&logger, &storage, sandbox.clone()).await
             }
             DRIVER_LOCAL_TYPE => local_storage_handler(&logger, &storage, sandbox.clone()).await,
+            DRIVER_FUSE_TYPE => fuse_storage_handler(&logger, &storage, sandbox.clone()).await,
+            DRIVER_NFS_TYPE => nfs_storage_handler(&logger, &storage, sandbox.clone()).await,
             DRIVER_SCSI_TYPE => {
                 virtio_scsi_storage_handler(&logger, &storage, sandbox.clone()).await
             }
@@ -908,9 +1126,35 @@ pub fn general_mount(logger: &Logger) -> Result<()> {
         mount_to_rootfs(&logger, m)?;
     }
 
+    mount_configfs(&logger);
+
     Ok(())
 }
 
+// mount_configfs mounts configfs for the attestation components to get the
+// evidence of a confidential guest. The mount point only exists when the
+// guest kernel has configfs, which is not required otherwise.
+fn mount_configfs(logger: &Logger) {
+    let dest = Path::new(SYS_KERNEL_CONFIG);
+    if !dest.is_dir() {
+        return;
+    }
+
+    if let Err(e) = baremount(
+        Path::new("configfs"),
+        dest,
+        "configfs",
+        MsFlags::MS_NOSUID | MsFlags::MS_NODEV | MsFlags::MS_NOEXEC,
+        "",
+        logger,
+    ) {
+        warn!(
+            logger,
+            "Could not mount configfs on {}: {:?}", SYS_KERNEL_CONFIG, e
+        );
+    }
+}
+
 #[inline]
 pub fn get_mount_fs_type(mount_point: &str) -> Result<String> {
     get_mount_fs_type_from_file(PROC_MOUNTSTATS, mount_point)
@@ -1726,6 +1970,17 @@ mod tests {
                 error_contains: "Could not create mountpoint",
                 ..Default::default()
             },
+            TestData {
+                test_user: TestUserType::RootOnly,
+                storage: Storage {
+                    mount_point: "mnt".to_string(),
+                    source: "src".to_string(),
+                    fstype: "bind".to_string(),
+                    options: vec!["rbind".to_string(), "rshared".to_string()],
+                    ..Default::default()
+                },
+                ..Default::default()
+            },
         ];
 
         for (i, d) in tests.iter().enumerate() {
@@ -1943,6 +2198,28 @@ mod tests {
         }
     }
 
+    #[test]
+    fn test_parse_hugepage_size() {
+        let data = vec![
+            // (input, expected, is_ok)
+            ("64KB", 64 << 10, true),
+            ("2MB", 2 << 20, true),
+            ("1GB", 1 << 30, true),
+            ("2M", 0, false),
+            ("MB", 0, false),
+            ("", 0, false),
+        ];
+
+        for (input, expected, is_ok) in data {
+            let r = parse_hugepage_size(input);
+            if is_ok {
+                assert_eq!(expected, r.unwrap(), "input: {}", input);
+            } else {
+                assert!(r.is_err(), "input: {}", input);
+            }
+        }
+    }
+
     #[test]
     fn test_parse_mount_flags_and_options() {
         #[derive(Debug)]
@@ -1994,6 +2271,16 @@ mod tests {
         }
     }
 
+    #[test]
+    fn test_ephemeral_mount_options() {
+        let options = vec!["fsgid=1000".to_string(), "size=1048576".to_string()];
+        assert_eq!(
+            ephemeral_mount_options(&options),
+            vec!["size=1048576".to_string()]
+        );
+        assert!(ephemeral_mount_options(&options[..1]).is_empty());
+    }
+
     #[test]
     fn test_set_ownership() {
         skip_if_not_root!();
@@ -2074,6 +2361,41 @@ mod tests {
         }
     }
 
+    #[test]
+    fn test_nfs_server_addr() {
+        assert_eq!(
+            nfs_server_addr("192.168.1.10:/export").unwrap(),
+            "192.168.1.10".parse::<std::net::IpAddr>().unwrap()
+        );
+        assert_eq!(
+            nfs_server_addr("[fd00::1]:/export/data").unwrap(),
+            "fd00::1".parse::<std::net::IpAddr>().unwrap()
+        );
+        assert!(nfs_server_addr("localhost:/export").unwrap().is_loopback());
+
+        for source in ["/export", ":/export", "server"].iter() {
+            let err = nfs_server_addr(source).unwrap_err();
+            assert!(format!("{}", err).contains("invalid NFS export"), "{}", err);
+        }
+    }
+
+    #[test]
+    fn test_fuse_mount_helper() {
+        for fstype in ["fuse", "fuse.", "ext4"].iter() {
+            let err = fuse_mount_helper(fstype).unwrap_err();
+            assert!(
+                format!("{}", err).contains("invalid FUSE filesystem type"),
+                "{}",
+                err
+            );
+        }
+
+        let err = fuse_mount_helper("fuse.kata-no-such-helper");
+        if let Err(err) = err {
+            assert!(format!("{}", err).contains("no mount helper"), "{}", err);
+        }
+    }
+
     #[test]
     fn test_recursive_ownership_change() {
         skip_if_not_root!();
@@ -0,0 +1,1034 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// The network policy of the pod is enforced by an eBPF filter the runtime
+// provides. The agent loads its object, pins its programs and maps in the
+// bpf filesystem, and attaches the programs with netlink to the clsact
+// qdiscs of the network interfaces of the guest. A policy is set as a whole,
+// by swapping a new inner map holding it in the map of maps the programs
+// read, and the connections tracked by the filter are flushed.
+
+use std::collections::HashMap;
+use std::convert::TryInto;
+use std::ffi::CString;
+use std::fs;
+use std::net::IpAddr;
+use std::os::unix::ffi::OsStrExt;
+use std::os::unix::io::RawFd;
+use std::path::Path;
+
+use anyhow::{anyhow, Context, Result};
+use nix::errno::Errno;
+use nix::mount::{self, MsFlags};
+use nix::net::if_::if_nametoindex;
+use nix::sys::statfs;
+use protocols::agent::{NetworkPolicyRule, SetNetworkPolicyRequest};
+
+const NETWORK_POLICY_DIR: &str = "/run/kata-containers/network-policy";
+const NETWORK_POLICY_OBJECT: &str = "/run/kata-containers/network-policy/network-policy.bpf.o";
+const BPF_FS_PATH: &str = "/sys/fs/bpf";
+const BPF_FS_MAGIC: i64 = 0xcafe4a11;
+// Programs and maps of the filter pinned by the agent
+const PIN_DIR: &str = "/sys/fs/bpf/kata-network-policy";
+const POLICY_MAP: &str = "kata_np_policy";
+const FLOWS_MAP: &str = "kata_np_flows";
+const INGRESS_PROGRAM: &str = "tc/ingress";
+const EGRESS_PROGRAM: &str = "tc/egress";
+const INGRESS_PIN: &str = "ingress";
+const EGRESS_PIN: &str = "egress";
+const FILTER_PRIO: u32 = 1;
+const FILTER_HANDLE: u32 = 1;
+
+// Must match KATA_NP_MAX_RULES of the filter
+const MAX_RULES: usize = 64;
+
+const DIRECTION_INGRESS: u8 = 1;
+const DIRECTION_EGRESS: u8 = 2;
+
+const BPF_MAP_CREATE: libc::c_long = 0;
+const BPF_MAP_UPDATE_ELEM: libc::c_long = 2;
+const BPF_MAP_DELETE_ELEM: libc::c_long = 3;
+const BPF_MAP_GET_NEXT_KEY: libc::c_long = 4;
+const BPF_PROG_LOAD: libc::c_long = 5;
+const BPF_OBJ_PIN: libc::c_long = 6;
+const BPF_OBJ_GET: libc::c_long = 7;
+
+const BPF_MAP_TYPE_ARRAY: u32 = 2;
+const BPF_MAP_TYPE_ARRAY_OF_MAPS: u32 = 12;
+const BPF_MAP_TYPE_HASH_OF_MAPS: u32 = 13;
+const BPF_PROG_TYPE_SCHED_CLS: u32 = 3;
+const BPF_LD_IMM64: u8 = 0x18;
+const BPF_PSEUDO_MAP_FD: u8 = 1;
+const BPF_INSN_SIZE: usize = 8;
+const BPF_LOG_SIZE: usize = 64 * 1024;
+
+const EM_BPF: u16 = 247;
+const SHT_SYMTAB: u32 = 2;
+const SHT_NOBITS: u32 = 8;
+const SHT_REL: u32 = 9;
+const ELF_HEADER_SIZE: u64 = 64;
+const SECTION_HEADER_SIZE: u64 = 64;
+const SYMBOL_SIZE: u64 = 24;
+const REL_SIZE: u64 = 16;
+// struct bpf_elf_map of the filter
+const MAP_DEF_SIZE: u64 = 36;
+
+const RTM_NEWQDISC: u16 = 36;
+const RTM_DELQDISC: u16 = 37;
+const RTM_NEWTFILTER: u16 = 44;
+const NLM_F_REQUEST: u16 = 0x1;
+const NLM_F_ACK: u16 = 0x4;
+const NLM_F_REPLACE: u16 = 0x100;
+const NLM_F_CREATE: u16 = 0x400;
+const NLMSG_HDR_SIZE: usize = 16;
+const TCMSG_SIZE: usize = 20;
+const TCA_KIND: u16 = 1;
+const TCA_OPTIONS: u16 = 2;
+const TCA_BPF_FD: u16 = 6;
+const TCA_BPF_NAME: u16 = 7;
+const TCA_BPF_FLAGS: u16 = 8;
+const TCA_BPF_FLAG_ACT_DIRECT: u32 = 1;
+const TC_H_CLSACT: u32 = 0xffff_fff1;
+const TC_H_MAJ_MASK: u32 = 0xffff_0000;
+const TC_H_MIN_INGRESS: u32 = 0xfff2;
+const TC_H_MIN_EGRESS: u32 = 0xfff3;
+const ETH_P_ALL: u16 = 0x0003;
+
+// Convenience function to obtain the scope logger.
+fn sl() -> slog::Logger {
+    slog_scope::logger().new(o!("subsystem" => "network-policy"))
+}
+
+// The struct kata_np_rule of the filter
+#[repr(C)]
+#[derive(Clone, Copy, Debug, Default, PartialEq)]
+struct Rule {
+    addr: [u8; 16],
+    prefixlen: u8,
+    direction: u8,
+    protocol: u8,
+    pad: u8,
+    port: u16,
+    end_port: u16,
+}
+
+// The struct kata_np_config of the filter
+#[repr(C)]
+#[derive(Debug, Default)]
+struct Config {
+    nr_rules: u32,
+    ingress: u8,
+    egress: u8,
+    pad: u16,
+}
+
+// The struct kata_np_policy of the filter, the value of the inner maps
+#[repr(C)]
+struct Policy {
+    config: Config,
+    rules: [Rule; MAX_RULES],
+}
+
+// The struct kata_np_flow of the filter, the key of the flows map
+const FLOW_SIZE: usize = 40;
+
+// The BPF_MAP_CREATE command of union bpf_attr
+#[repr(C)]
+struct BpfMapCreateAttr {
+    map_type: u32,
+    key_size: u32,
+    value_size: u32,
+    max_entries: u32,
+    map_flags: u32,
+    inner_map_fd: u32,
+}
+
+// The BPF_PROG_LOAD command of union bpf_attr
+#[repr(C)]
+struct BpfProgLoadAttr {
+    prog_type: u32,
+    insn_cnt: u32,
+    insns: u64,
+    license: u64,
+    log_level: u32,
+    log_size: u32,
+    log_buf: u64,
+    kern_version: u32,
+    prog_flags: u32,
+}
+
+// The BPF_OBJ_PIN and BPF_OBJ_GET commands of union bpf_attr
+#[repr(C)]
+struct BpfObjAttr {
+    pathname: u64,
+    bpf_fd: u32,
+    file_flags: u32,
+}
+
+// The BPF_MAP_*_ELEM and BPF_MAP_GET_NEXT_KEY commands of union bpf_attr,
+// value being the next key of the latter
+#[repr(C)]
+struct BpfMapElemAttr {
+    map_fd: u32,
+    pad: u32,
+    key: u64,
+    value: u64,
+    flags: u64,
+}
+
+fn bpf<T>(cmd: libc::c_long, attr: &T) -> nix::Result<libc::c_long> {
+    let ret = unsafe {
+        libc::syscall(
+            libc::SYS_bpf,
+            cmd,
+            attr as *const T,
+            std::mem::size_of::<T>(),
+        )
+    };
+    Errno::result(ret)
+}
+
+// A file descriptor closed when dropped
+#[derive(Debug)]
+struct Fd(RawFd);
+
+impl Drop for Fd {
+    fn drop(&mut self) {
+        unsafe { libc::close(self.0) };
+    }
+}
+
+// parse_rule converts a rule of the runtime to the one of the filter, the
+// IPv4 networks being mapped in IPv6.
+fn parse_rule(rule: &NetworkPolicyRule) -> Result<Rule> {
+    let direction = match rule.direction.as_str() {
+        "ingress" => DIRECTION_INGRESS,
+        "egress" => DIRECTION_EGRESS,
+        d => return Err(anyhow!("invalid direction {:?}", d)),
+    };
+    let protocol = match rule.protocol.as_str() {
+        "" => 0,
+        "tcp" => libc::IPPROTO_TCP as u8,
+        "udp" => libc::IPPROTO_UDP as u8,
+        "sctp" => libc::IPPROTO_SCTP as u8,
+        p => return Err(anyhow!("invalid protocol {:?}", p)),
+    };
+    if rule.port > u16::MAX as u32 || rule.end_port > u16::MAX as u32 {
+        return Err(anyhow!(
+            "invalid port range {}-{}",
+            rule.port,
+            rule.end_port
+        ));
+    }
+
+    let (addr, prefixlen) = rule
+        .cidr
+        .split_once('/')
+        .ok_or_else(|| anyhow!("invalid CIDR {:?}", rule.cidr))?;
+    let addr: IpAddr = addr
+        .parse()
+        .with_context(|| format!("invalid CIDR {:?}", rule.cidr))?;
+    let prefixlen: u8 = prefixlen
+        .parse()
+        .with_context(|| format!("invalid CIDR {:?}", rule.cidr))?;
+    let (addr, prefixlen) = match addr {
+        IpAddr::V4(v4) if prefixlen <= 32 => (v4.to_ipv6_mapped().octets(), prefixlen + 96),
+        IpAddr::V6(v6) if prefixlen <= 128 => (v6.octets(), prefixlen),
+        _ => return Err(anyhow!("invalid CIDR {:?}", rule.cidr)),
+    };
+
+    Ok(Rule {
+        addr,
+        prefixlen,
+        direction,
+        protocol,
+        port: rule.port as u16,
+        end_port: rule.end_port as u16,
+        ..Default::default()
+    })
+}
+
+fn slice(data: &[u8], offset: u64, size: u64) -> Result<&[u8]> {
+    offset
+        .checked_add(size)
+        .and_then(|end| data.get(offset as usize..end as usize))
+        .ok_or_else(|| anyhow!("truncated eBPF object"))
+}
+
+fn u16_at(data: &[u8], offset: u64) -> Result<u16> {
+    Ok(u16::from_le_bytes(slice(data, offset, 2)?.try_into()?))
+}
+
+fn u32_at(data: &[u8], offset: u64) -> Result<u32> {
+    Ok(u32::from_le_bytes(slice(data, offset, 4)?.try_into()?))
+}
+
+fn u64_at(data: &[u8], offset: u64) -> Result<u64> {
+    Ok(u64::from_le_bytes(slice(data, offset, 8)?.try_into()?))
+}
+
+fn str_at(strtab: &[u8], offset: u32) -> Result<String> {
+    let s = strtab
+        .get(offset as usize..)
+        .ok_or_else(|| anyhow!("truncated eBPF object"))?;
+    let end = s
+        .iter()
+        .position(|b| *b == 0)
+        .ok_or_else(|| anyhow!("truncated eBPF object"))?;
+    Ok(String::from_utf8_lossy(&s[..end]).into_owned())
+}
+
+struct Section<'a> {
+    name: String,
+    kind: u32,
+    link: u32,
+    info: u32,
+    data: &'a [u8],
+}
+
+struct Symbol {
+    name: String,
+    section: u16,
+    value: u64,
+}
+
+// The struct bpf_elf_map of the filter
+#[derive(Debug)]
+struct MapDef {
+    kind: u32,
+    key_size: u32,
+    value_size: u32,
+    max_entries: u32,
+    flags: u32,
+    id: u32,
+    inner_id: u32,
+}
+
+impl MapDef {
+    fn is_map_of_maps(&self) -> bool {
+        self.kind == BPF_MAP_TYPE_ARRAY_OF_MAPS || self.kind == BPF_MAP_TYPE_HASH_OF_MAPS
+    }
+}
+
+// The sections and symbols of the 64-bit little-endian ELF object of the
+// filter
+struct Object<'a> {
+    sections: Vec<Section<'a>>,
+    symbols: Vec<Symbol>,
+}
+
+impl<'a> Object<'a> {
+    fn parse(data: &'a [u8]) -> Result<Object<'a>> {
+        if data.len() < ELF_HEADER_SIZE as usize
+            || &data[..4] != b"\x7fELF"
+            || data[4] != 2
+            || data[5] != 1
+        {
+            return Err(anyhow!("not a 64-bit little-endian ELF object"));
+        }
+        if u16_at(data, 18)? != EM_BPF {
+            return Err(anyhow!("not an eBPF object"));
+        }
+
+        let shoff = u64_at(data, 40)?;
+        let shnum = u16_at(data, 60)? as u64;
+        let shstrndx = u16_at(data, 62)? as usize;
+        if u16_at(data, 58)? as u64 != SECTION_HEADER_SIZE {
+            return Err(anyhow!("invalid section header size"));
+        }
+
+        let mut headers = Vec::new();
+        for i in 0..shnum {
+            let h = slice(data, shoff + i * SECTION_HEADER_SIZE, SECTION_HEADER_SIZE)?;
+            let kind = u32_at(h, 4)?;
+            let section_data = if kind == SHT_NOBITS {
+                &[][..]
+            } else {
+                slice(data, u64_at(h, 24)?, u64_at(h, 32)?)?
+            };
+            headers.push((
+                u32_at(h, 0)?,
+                kind,
+                u32_at(h, 40)?,
+                u32_at(h, 44)?,
+                section_data,
+            ));
+        }
+
+        let shstrtab = headers
+            .get(shstrndx)
+            .ok_or_else(|| anyhow!("no section names"))?
+            .4;
+        let mut sections = Vec::new();
+        for (name, kind, link, info, data) in headers {
+            sections.push(Section {
+                name: str_at(shstrtab, name)?,
+                kind,
+                link,
+                info,
+                data,
+            });
+        }
+
+        let mut symbols = Vec::new();
+        if let Some(symtab) = sections.iter().find(|s| s.kind == SHT_SYMTAB) {
+            let strtab = sections
+                .get(symtab.link as usize)
+                .ok_or_else(|| anyhow!("no symbol names"))?
+                .data;
+            for i in 0..symtab.data.len() as u64 / SYMBOL_SIZE {
+                let sym = slice(symtab.data, i * SYMBOL_SIZE, SYMBOL_SIZE)?;
+                symbols.push(Symbol {
+                    name: str_at(strtab, u32_at(sym, 0)?)?,
+                    section: u16_at(sym, 6)?,
+                    value: u64_at(sym, 8)?,
+                });
+            }
+        }
+
+        Ok(Object { sections, symbols })
+    }
+
+    fn section(&self, name: &str) -> Option<(usize, &Section<'a>)> {
+        self.sections
+            .iter()
+            .enumerate()
+            .find(|(_, s)| s.name == name)
+    }
+
+    // map_defs returns the maps defined in the maps section, by name
+    fn map_defs(&self) -> Result<Vec<(String, MapDef)>> {
+        let (index, maps) = self
+            .section("maps")
+            .ok_or_else(|| anyhow!("no maps section"))?;
+
+        let mut defs = Vec::new();
+        for sym in self.symbols.iter().filter(|s| s.section as usize == index) {
+            let def = slice(maps.data, sym.value, MAP_DEF_SIZE)?;
+            defs.push((
+                sym.name.clone(),
+                MapDef {
+                    kind: u32_at(def, 0)?,
+                    key_size: u32_at(def, 4)?,
+                    value_size: u32_at(def, 8)?,
+                    max_entries: u32_at(def, 12)?,
+                    flags: u32_at(def, 16)?,
+                    id: u32_at(def, 20)?,
+                    inner_id: u32_at(def, 28)?,
+                },
+            ));
+        }
+        Ok(defs)
+    }
+
+    // program returns the instructions of the program of a section, with
+    // the map references relocated to the file descriptors of the maps
+    fn program(&self, name: &str, maps: &HashMap<String, Fd>) -> Result<Vec<u8>> {
+        let (index, section) = self
+            .section(name)
+            .ok_or_else(|| anyhow!("no {} program", name))?;
+        let mut insns = section.data.to_vec();
+        if insns.is_empty() || insns.len() % BPF_INSN_SIZE != 0 {
+            return Err(anyhow!("invalid {} program", name));
+        }
+
+        for rel in self
+            .sections
+            .iter()
+            .filter(|s| s.kind == SHT_REL && s.info as usize == index)
+        {
+            for i in 0..rel.data.len() as u64 / REL_SIZE {
+                let offset = u64_at(rel.data, i * REL_SIZE)? as usize;
+                let sym = (u64_at(rel.data, i * REL_SIZE + 8)? >> 32) as usize;
+                let sym = self
+                    .symbols
+                    .get(sym)
+                    .ok_or_else(|| anyhow!("invalid relocation of {}", name))?;
+                let map = maps.get(&sym.name).ok_or_else(|| {
+                    anyhow!("relocation of {} to unknown map {:?}", name, sym.name)
+                })?;
+                let insn = offset
+                    .checked_add(BPF_INSN_SIZE)
+                    .and_then(|end| insns.get_mut(offset..end))
+                    .ok_or_else(|| anyhow!("invalid relocation of {}", name))?;
+                if insn[0] != BPF_LD_IMM64 {
+                    return Err(anyhow!("invalid relocation of {}", name));
+                }
+                insn[1] = (insn[1] & 0x0f) | (BPF_PSEUDO_MAP_FD << 4);
+                insn[4..8].copy_from_slice(&map.0.to_le_bytes());
+            }
+        }
+        Ok(insns)
+    }
+}
+
+fn create_map(def: &MapDef, inner_map_fd: RawFd) -> Result<Fd> {
+    let attr = BpfMapCreateAttr {
+        map_type: def.kind,
+        key_size: def.key_size,
+        value_size: def.value_size,
+        max_entries: def.max_entries,
+        map_flags: def.flags,
+        inner_map_fd: inner_map_fd as u32,
+    };
+    Ok(Fd(bpf(BPF_MAP_CREATE, &attr)? as RawFd))
+}
+
+fn load_program(insns: &[u8], license: &CString) -> Result<Fd> {
+    let mut attr = BpfProgLoadAttr {
+        prog_type: BPF_PROG_TYPE_SCHED_CLS,
+        insn_cnt: (insns.len() / BPF_INSN_SIZE) as u32,
+        insns: insns.as_ptr() as u64,
+        license: license.as_ptr() as u64,
+        log_level: 0,
+        log_size: 0,
+        log_buf: 0,
+        kern_version: 0,
+        prog_flags: 0,
+    };
+    if let Ok(fd) = bpf(BPF_PROG_LOAD, &attr) {
+        return Ok(Fd(fd as RawFd));
+    }
+
+    // Load it again with the log of the verifier for the error
+    let mut log = vec![0u8; BPF_LOG_SIZE];
+    attr.log_level = 1;
+    attr.log_size = log.len() as u32;
+    attr.log_buf = log.as_mut_ptr() as u64;
+    match bpf(BPF_PROG_LOAD, &attr) {
+        Ok(fd) => Ok(Fd(fd as RawFd)),
+        Err(e) => {
+            let end = log.iter().position(|b| *b == 0).unwrap_or(log.len());
+            Err(anyhow!(
+                "{}: {}",
+                e,
+                String::from_utf8_lossy(&log[..end]).trim()
+            ))
+        }
+    }
+}
+
+fn path_cstring(path: &Path) -> Result<CString> {
+    Ok(CString::new(path.as_os_str().as_bytes())?)
+}
+
+fn pin(fd: &Fd, name: &str) -> Result<()> {
+    let path = path_cstring(&Path::new(PIN_DIR).join(name))?;
+    let attr = BpfObjAttr {
+        pathname: path.as_ptr() as u64,
+        bpf_fd: fd.0 as u32,
+        file_flags: 0,
+    };
+    bpf(BPF_OBJ_PIN, &attr).with_context(|| format!("pin {}", name))?;
+    Ok(())
+}
+
+fn get_pinned(name: &str) -> Result<Fd> {
+    let path = path_cstring(&Path::new(PIN_DIR).join(name))?;
+    let attr = BpfObjAttr {
+        pathname: path.as_ptr() as u64,
+        bpf_fd: 0,
+        file_flags: 0,
+    };
+    let fd = bpf(BPF_OBJ_GET, &attr).with_context(|| format!("open {}", name))?;
+    Ok(Fd(fd as RawFd))
+}
+
+// The filter: its programs, and the maps the agent updates
+struct Filter {
+    ingress: Fd,
+    egress: Fd,
+    policy: Fd,
+    flows: Fd,
+}
+
+impl Filter {
+    // load loads the programs and creates the maps of the eBPF object
+    fn load(data: &[u8]) -> Result<Filter> {
+        let obj = Object::parse(data)?;
+        let license = obj
+            .section("license")
+            .map(|(_, s)| {
+                s.data
+                    .split(|b| *b == 0)
+                    .next()
+                    .unwrap_or_default()
+                    .to_vec()
+            })
+            .ok_or_else(|| anyhow!("no license section"))?;
+        let license = CString::new(license)?;
+
+        // The maps of maps are created with their inner map as template.
+        let defs = obj.map_defs()?;
+        let mut maps = HashMap::new();
+        for (name, def) in defs.iter().filter(|(_, d)| !d.is_map_of_maps()) {
+            let fd = create_map(def, 0).with_context(|| format!("create map {}", name))?;
+            maps.insert(name.clone(), fd);
+        }
+        for (name, def) in defs.iter().filter(|(_, d)| d.is_map_of_maps()) {
+            let inner = defs
+                .iter()
+                .find(|(_, d)| d.id != 0 && d.id == def.inner_id)
+                .and_then(|(inner, _)| maps.get(inner))
+                .ok_or_else(|| anyhow!("no inner map for map {}", name))?;
+            let fd = create_map(def, inner.0).with_context(|| format!("create map {}", name))?;
+            maps.insert(name.clone(), fd);
+        }
+
+        let ingress = load_program(&obj.program(INGRESS_PROGRAM, &maps)?, &license)
+            .with_context(|| format!("load program {}", INGRESS_PROGRAM))?;
+        let egress = load_program(&obj.program(EGRESS_PROGRAM, &maps)?, &license)
+            .with_context(|| format!("load program {}", EGRESS_PROGRAM))?;
+
+        let mut take = |name: &str| maps.remove(name).ok_or_else(|| anyhow!("no {} map", name));
+        Ok(Filter {
+            ingress,
+            egress,
+            policy: take(POLICY_MAP)?,
+            flows: take(FLOWS_MAP)?,
+        })
+    }
+
+    // pin pins the programs and the maps of the filter, so that they are
+    // found again for the next policies and the new interfaces
+    fn pin(&self) -> Result<()> {
+        let _ = fs::remove_dir_all(PIN_DIR);
+        fs::create_dir_all(PIN_DIR)?;
+        pin(&self.ingress, INGRESS_PIN)?;
+        pin(&self.egress, EGRESS_PIN)?;
+        pin(&self.policy, POLICY_MAP)?;
+        pin(&self.flows, FLOWS_MAP)
+    }
+
+    fn pinned() -> Result<Filter> {
+        Ok(Filter {
+            ingress: get_pinned(INGRESS_PIN)?,
+            egress: get_pinned(EGRESS_PIN)?,
+            policy: get_pinned(POLICY_MAP)?,
+            flows: get_pinned(FLOWS_MAP)?,
+        })
+    }
+
+    // open returns the filter of the eBPF object, the filter loaded for the
+    // previous policy being kept when the object did not change.
+    fn open(data: &[u8]) -> Result<Filter> {
+        if fs::read(NETWORK_POLICY_OBJECT).map_or(false, |o| o == data) {
+            if let Ok(filter) = Filter::pinned() {
+                return Ok(filter);
+            }
+        }
+
+        let filter = Filter::load(data)?;
+        filter.pin()?;
+        fs::create_dir_all(NETWORK_POLICY_DIR)?;
+        fs::write(NETWORK_POLICY_OBJECT, data).context("write the eBPF object")?;
+        Ok(filter)
+    }
+
+    // attach attaches the programs to the interface, replacing the
+    // previous ones.
+    fn attach(&self, interface: &str) -> Result<()> {
+        let ifindex = if_nametoindex(interface)? as i32;
+
+        netlink_request(&tc_message(
+            RTM_NEWQDISC,
+            NLM_F_CREATE | NLM_F_REPLACE,
+            ifindex,
+            TC_H_CLSACT & TC_H_MAJ_MASK,
+            TC_H_CLSACT,
+            0,
+            &nlattr(TCA_KIND, b"clsact\0"),
+        ))
+        .context("add the clsact qdisc")?;
+
+        for (program, minor, name) in [
+            (&self.ingress, TC_H_MIN_INGRESS, "kata_np_ingress\0"),
+            (&self.egress, TC_H_MIN_EGRESS, "kata_np_egress\0"),
+        ] {
+            let mut options = nlattr(TCA_BPF_FD, &(program.0 as u32).to_ne_bytes());
+            options.extend(nlattr(TCA_BPF_NAME, name.as_bytes()));
+            options.extend(nlattr(
+                TCA_BPF_FLAGS,
+                &TCA_BPF_FLAG_ACT_DIRECT.to_ne_bytes(),
+            ));
+            let mut attrs = nlattr(TCA_KIND, b"bpf\0");
+            attrs.extend(nlattr(TCA_OPTIONS, &options));
+
+            netlink_request(&tc_message(
+                RTM_NEWTFILTER,
+                NLM_F_CREATE | NLM_F_REPLACE,
+                ifindex,
+                FILTER_HANDLE,
+                (TC_H_CLSACT & TC_H_MAJ_MASK) | minor,
+                (FILTER_PRIO << 16) | ETH_P_ALL.to_be() as u32,
+                &attrs,
+            ))
+            .with_context(|| format!("add the {} filter", name.trim_end_matches('\0')))?;
+        }
+        Ok(())
+    }
+
+    // set_policy fills a new inner map with the policy, and swaps it in the
+    // map of maps the programs read, so that they never see a policy being
+    // written.
+    fn set_policy(&self, policy: &Policy) -> Result<()> {
+        let def = MapDef {
+            kind: BPF_MAP_TYPE_ARRAY,
+            key_size: std::mem::size_of::<u32>() as u32,
+            value_size: std::mem::size_of::<Policy>() as u32,
+            max_entries: 1,
+            flags: 0,
+            id: 0,
+            inner_id: 0,
+        };
+        let inner = create_map(&def, 0).context("create the policy map")?;
+        update_elem(&inner, &0u32, policy).context("fill the policy map")?;
+        update_elem(&self.policy, &0u32, &(inner.0 as u32)).context("swap the policy map")
+    }
+
+    // flush_flows forgets the connections the previous policy allowed
+    fn flush_flows(&self) -> Result<()> {
+        let mut key = [0u8; FLOW_SIZE];
+        loop {
+            // The first key, the previous one being deleted
+            let attr = BpfMapElemAttr {
+                map_fd: self.flows.0 as u32,
+                pad: 0,
+                key: 0,
+                value: key.as_mut_ptr() as u64,
+                flags: 0,
+            };
+            match bpf(BPF_MAP_GET_NEXT_KEY, &attr) {
+                Ok(_) => {}
+                Err(Errno::ENOENT) => return Ok(()),
+                Err(e) => return Err(e).context("flush the flows map"),
+            }
+
+            let attr = BpfMapElemAttr {
+                map_fd: self.flows.0 as u32,
+                pad: 0,
+                key: key.as_ptr() as u64,
+                value: 0,
+                flags: 0,
+            };
+            match bpf(BPF_MAP_DELETE_ELEM, &attr) {
+                Ok(_) | Err(Errno::ENOENT) => {}
+                Err(e) => return Err(e).context("flush the flows map"),
+            }
+        }
+    }
+}
+
+fn update_elem<K, V>(map: &Fd, key: &K, value: &V) -> Result<()> {
+    let attr = BpfMapElemAttr {
+        map_fd: map.0 as u32,
+        pad: 0,
+        key: key as *const K as u64,
+        value: value as *const V as u64,
+        flags: 0,
+    };
+    bpf(BPF_MAP_UPDATE_ELEM, &attr)?;
+    Ok(())
+}
+
+// nlattr returns the netlink attribute of payload, padded to 4 bytes
+fn nlattr(kind: u16, payload: &[u8]) -> Vec<u8> {
+    let len = 4 + payload.len();
+    let mut attr = Vec::with_capacity((len + 3) & !3);
+    attr.extend((len as u16).to_ne_bytes());
+    attr.extend(kind.to_ne_bytes());
+    attr.extend(payload);
+    attr.resize((len + 3) & !3, 0);
+    attr
+}
+
+// tc_message returns the rtnetlink request of a qdisc or filter, with its
+// struct tcmsg and attributes
+fn tc_message(
+    kind: u16,
+    flags: u16,
+    ifindex: i32,
+    handle: u32,
+    parent: u32,
+    info: u32,
+    attrs: &[u8],
+) -> Vec<u8> {
+    let len = NLMSG_HDR_SIZE + TCMSG_SIZE + attrs.len();
+    let mut msg = Vec::with_capacity(len);
+    msg.extend((len as u32).to_ne_bytes());
+    msg.extend(kind.to_ne_bytes());
+    msg.extend((flags | NLM_F_REQUEST | NLM_F_ACK).to_ne_bytes());
+    msg.extend(1u32.to_ne_bytes());
+    msg.extend(0u32.to_ne_bytes());
+    msg.extend([libc::AF_UNSPEC as u8, 0, 0, 0]);
+    msg.extend(ifindex.to_ne_bytes());
+    msg.extend(handle.to_ne_bytes());
+    msg.extend(parent.to_ne_bytes());
+    msg.extend(info.to_ne_bytes());
+    msg.extend(attrs);
+    msg
+}
+
+// netlink_request sends a request to the kernel and waits for its ack
+fn netlink_request(msg: &[u8]) -> Result<()> {
+    let fd = unsafe {
+        libc::socket(
+            libc::AF_NETLINK,
+            libc::SOCK_RAW | libc::SOCK_CLOEXEC,
+            libc::NETLINK_ROUTE,
+        )
+    };
+    let fd = Fd(Errno::result(fd).context("open a netlink socket")?);
+
+    let mut addr: libc::sockaddr_nl = unsafe { std::mem::zeroed() };
+    addr.nl_family = libc::AF_NETLINK as libc::sa_family_t;
+    let ret = unsafe {
+        libc::sendto(
+            fd.0,
+            msg.as_ptr() as *const libc::c_void,
+            msg.len(),
+            0,
+            &addr as *const libc::sockaddr_nl as *const libc::sockaddr,
+            std::mem::size_of::<libc::sockaddr_nl>() as libc::socklen_t,
+        )
+    };
+    Errno::result(ret).context("send the netlink request")?;
+
+    let mut buf = vec![0u8; 4096];
+    let n = unsafe { libc::recv(fd.0, buf.as_mut_ptr() as *mut libc::c_void, buf.len(), 0) };
+    let n = Errno::result(n).context("receive the netlink ack")? as usize;
+
+    // A struct nlmsghdr followed by a struct nlmsgerr
+    if n < NLMSG_HDR_SIZE + 4 || u16::from_ne_bytes([buf[4], buf[5]]) != libc::NLMSG_ERROR as u16 {
+        return Err(anyhow!("unexpected netlink reply"));
+    }
+    let errno = i32::from_ne_bytes(buf[NLMSG_HDR_SIZE..NLMSG_HDR_SIZE + 4].try_into()?);
+    if errno != 0 {
+        return Err(anyhow!(Errno::from_i32(-errno)));
+    }
+    Ok(())
+}
+
+fn mount_bpf_fs() -> Result<()> {
+    fs::create_dir_all(BPF_FS_PATH)?;
+    if let Ok(st) = statfs::statfs(BPF_FS_PATH) {
+        if st.filesystem_type().0 as i64 == BPF_FS_MAGIC {
+            return Ok(());
+        }
+    }
+    mount::mount(
+        Some("bpf"),
+        BPF_FS_PATH,
+        Some("bpf"),
+        MsFlags::MS_NOSUID | MsFlags::MS_NODEV | MsFlags::MS_NOEXEC,
+        None::<&str>,
+    )
+    .context("mount the bpf filesystem")
+}
+
+// attach_new_interface attaches the filter to an interface added after the
+// policy was set, if any.
+pub fn attach_new_interface(interface: &str) -> Result<()> {
+    if interface == "lo" || !Path::new(NETWORK_POLICY_OBJECT).exists() {
+        return Ok(());
+    }
+    Filter::pinned()?.attach(interface)
+}
+
+fn detach(interface: &str) -> Result<()> {
+    let ifindex = if_nametoindex(interface)? as i32;
+    netlink_request(&tc_message(
+        RTM_DELQDISC,
+        0,
+        ifindex,
+        TC_H_CLSACT & TC_H_MAJ_MASK,
+        TC_H_CLSACT,
+        0,
+        &nlattr(TCA_KIND, b"clsact\0"),
+    ))
+}
+
+// set enforces the policy of the request on the interfaces, an empty program
+// removing the filter.
+pub fn set(req: &SetNetworkPolicyRequest, interfaces: &[String]) -> Result<()> {
+    let interfaces: Vec<&String> = interfaces.iter().filter(|i| *i != "lo").collect();
+
+    if req.program.is_empty() {
+        for interface in interfaces {
+            if let Err(e) = detach(interface) {
+                warn!(sl(), "failed to remove the network policy filter";
+                    "interface" => interface, "error" => format!("{:?}", e));
+            }
+        }
+        let _ = fs::remove_dir_all(PIN_DIR);
+        let _ = fs::remove_file(NETWORK_POLICY_OBJECT);
+        info!(sl(), "network policy removed");
+        return Ok(());
+    }
+
+    if req.rules.len() > MAX_RULES {
+        return Err(anyhow!(
+            "{} rules, at most {} are supported",
+            req.rules.len(),
+            MAX_RULES
+        ));
+    }
+    let mut policy = Policy {
+        config: Config {
+            nr_rules: req.rules.len() as u32,
+            ingress: req.ingress as u8,
+            egress: req.egress as u8,
+            ..Default::default()
+        },
+        rules: [Rule::default(); MAX_RULES],
+    };
+    for (i, rule) in req.rules.iter().enumerate() {
+        policy.rules[i] = parse_rule(rule)?;
+    }
+
+    mount_bpf_fs()?;
+    let filter = Filter::open(&req.program)?;
+    for interface in interfaces {
+        filter
+            .attach(interface)
+            .with_context(|| format!("attach the filter to {}", interface))?;
+    }
+
+    // The connections are tracked again under the new policy.
+    filter.set_policy(&policy)?;
+    filter.flush_flows()?;
+
+    info!(sl(), "network policy set";
+        "rules" => req.rules.len(), "ingress" => req.ingress, "egress" => req.egress);
+    Ok(())
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    fn rule(
+        direction: &str,
+        cidr: &str,
+        protocol: &str,
+        port: u32,
+        end_port: u32,
+    ) -> NetworkPolicyRule {
+        let mut r = NetworkPolicyRule::new();
+        r.direction = direction.to_string();
+        r.cidr = cidr.to_string();
+        r.protocol = protocol.to_string();
+        r.port = port;
+        r.end_port = end_port;
+        r
+    }
+
+    #[test]
+    fn test_layout() {
+        assert_eq!(std::mem::size_of::<Rule>(), 24);
+        assert_eq!(std::mem::size_of::<Config>(), 8);
+        assert_eq!(std::mem::size_of::<Policy>(), 8 + 24 * MAX_RULES);
+    }
+
+    #[test]
+    fn test_nlattr() {
+        assert_eq!(nlattr(TCA_KIND, b"bpf\0"), {
+            let mut a = 8u16.to_ne_bytes().to_vec();
+            a.extend(TCA_KIND.to_ne_bytes());
+            a.extend(b"bpf\0");
+            a
+        });
+        // padded to 4 bytes
+        assert_eq!(nlattr(TCA_KIND, b"clsact\0").len(), 12);
+    }
+
+    #[test]
+    fn test_tc_message() {
+        let attrs = nlattr(TCA_KIND, b"clsact\0");
+        let msg = tc_message(
+            RTM_NEWQDISC,
+            NLM_F_CREATE | NLM_F_REPLACE,
+            2,
+            0xffff_0000,
+            TC_H_CLSACT,
+            0,
+            &attrs,
+        );
+        assert_eq!(msg.len(), NLMSG_HDR_SIZE + TCMSG_SIZE + attrs.len());
+        assert_eq!(
+            u32::from_ne_bytes(msg[0..4].try_into().unwrap()) as usize,
+            msg.len()
+        );
+        assert_eq!(u16::from_ne_bytes([msg[4], msg[5]]), RTM_NEWQDISC);
+        assert_eq!(
+            u16::from_ne_bytes([msg[6], msg[7]]),
+            NLM_F_REQUEST | NLM_F_ACK | NLM_F_CREATE | NLM_F_REPLACE
+        );
+        // ifindex, handle and parent of the struct tcmsg
+        assert_eq!(i32::from_ne_bytes(msg[20..24].try_into().unwrap()), 2);
+        assert_eq!(
+            u32::from_ne_bytes(msg[24..28].try_into().unwrap()),
+            0xffff_0000
+        );
+        assert_eq!(
+            u32::from_ne_bytes(msg[28..32].try_into().unwrap()),
+            TC_H_CLSACT
+        );
+        assert_eq!(&msg[NLMSG_HDR_SIZE + TCMSG_SIZE..], &attrs[..]);
+    }
+
+    #[test]
+    fn test_parse_object() {
+        assert!(Object::parse(b"not an object").is_err());
+
+        let mut header = vec![0u8; ELF_HEADER_SIZE as usize];
+        header[..4].copy_from_slice(b"\x7fELF");
+        header[4] = 2;
+        header[5] = 1;
+        // x86-64
+        header[18] = 62;
+        assert!(Object::parse(&header).is_err());
+
+        // an eBPF object whose section headers are missing
+        header[18..20].copy_from_slice(&EM_BPF.to_le_bytes());
+        header[40..48].copy_from_slice(&4096u64.to_le_bytes());
+        header[58..60].copy_from_slice(&(SECTION_HEADER_SIZE as u16).to_le_bytes());
+        header[60..62].copy_from_slice(&1u16.to_le_bytes());
+        assert!(Object::parse(&header).is_err());
+    }
+
+    #[test]
+    fn test_parse_rule() {
+        let r = parse_rule(&rule("ingress", "10.1.0.0/16", "tcp", 80, 0)).unwrap();
+        let mut addr = [0u8; 16];
+        addr[10] = 0xff;
+        addr[11] = 0xff;
+        addr[12] = 10;
+        addr[13] = 1;
+        assert_eq!(
+            r,
+            Rule {
+                addr,
+                prefixlen: 112,
+                direction: DIRECTION_INGRESS,
+                protocol: libc::IPPROTO_TCP as u8,
+                port: 80,
+                ..Default::default()
+            }
+        );
+
+        let r = parse_rule(&rule("egress", "fd00::/8", "", 0, 0)).unwrap();
+        assert_eq!(r.addr[0], 0xfd);
+        assert_eq!(r.prefixlen, 8);
+        assert_eq!(r.direction, DIRECTION_EGRESS);
+        assert_eq!(r.protocol, 0);
+
+        assert!(parse_rule(&rule("both", "10.0.0.0/8", "", 0, 0)).is_err());
+        assert!(parse_rule(&rule("ingress", "10.0.0.0", "", 0, 0)).is_err());
+        assert!(parse_rule(&rule("ingress", "10.0.0.0/33", "", 0, 0)).is_err());
+        assert!(parse_rule(&rule("ingress", "10.0.0.0/8", "icmp", 0, 0)).is_err());
+        assert!(parse_rule(&rule("ingress", "10.0.0.0/8", "tcp", 70000, 0)).is_err());
+    }
+}
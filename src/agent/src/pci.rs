@@ -141,15 +141,30 @@ impl fmt::Display for Address {
     }
 }
 
+// Represents the path of a PCI function from the root bus of its PCI
+// domain, written "[DDDD:]SS.F/.../SS.F", the domain being omitted when 0.
+// The extra PCI host bridges of a pseries machine are the root buses of
+// their own domains.
 #[derive(Clone, Debug, PartialEq, Eq)]
-pub struct Path(Vec<SlotFn>);
+pub struct Path {
+    domain: u16,
+    slots: Vec<SlotFn>,
+}
 
 impl Path {
     pub fn new(slots: Vec<SlotFn>) -> anyhow::Result<Self> {
         if slots.is_empty() {
             return Err(anyhow!("PCI path must have at least one element"));
         }
-        Ok(Path(slots))
+        Ok(Path { domain: 0, slots })
+    }
+
+    pub fn with_domain(self, domain: u16) -> Self {
+        Path { domain, ..self }
+    }
+
+    pub fn domain(&self) -> u16 {
+        self.domain
     }
 }
 
@@ -158,17 +173,20 @@ impl Deref for Path {
     type Target = [SlotFn];
 
     fn deref(&self) -> &Self::Target {
-        &self.0
+        &self.slots
     }
 }
 
 impl fmt::Display for Path {
     fn fmt(&self, f: &mut fmt::Formatter) -> Result<(), fmt::Error> {
         let sslots: Vec<String> = self
-            .0
+            .slots
             .iter()
             .map(std::string::ToString::to_string)
             .collect();
+        if self.domain != 0 {
+            write!(f, "{:04x}:", self.domain)?;
+        }
         write!(f, "{}", sslots.join("/"))
     }
 }
@@ -177,8 +195,12 @@ impl FromStr for Path {
     type Err = anyhow::Error;
 
     fn from_str(s: &str) -> anyhow::Result<Self> {
+        let (domain, s) = match s.split_once(':') {
+            Some((domain, slots)) => (u16::from_str_radix(domain, 16)?, slots),
+            None => (0, s),
+        };
         let rslots: anyhow::Result<Vec<SlotFn>> = s.split('/').map(SlotFn::from_str).collect();
-        Path::new(rslots?)
+        Ok(Path::new(rslots?)?.with_domain(domain))
     }
 }
 
@@ -338,10 +360,21 @@ mod tests {
         assert_eq!(pcipath[2], sfc_7);
 
         // Bad paths
+        // Paths outside of the domain 0
+        let pcipath = Path::new(vec![sf3_0, sf4_0]).unwrap().with_domain(0x1e);
+        assert_eq!(pcipath.domain(), 0x1e);
+        assert_eq!(format!("{}", pcipath), "001e:03.0/04.0");
+        let pcipath2 = Path::from_str("001e:03/04").unwrap();
+        assert_eq!(pcipath, pcipath2);
+        let pcipath2 = Path::from_str("0000:03/04").unwrap();
+        assert_eq!(format!("{}", pcipath2), "03.0/04.0");
+
         assert!(Path::new(vec!()).is_err());
         assert!(Path::from_str("20").is_err());
         assert!(Path::from_str("00.8").is_err());
         assert!(Path::from_str("//").is_err());
         assert!(Path::from_str("xyz").is_err());
+        assert!(Path::from_str("10000:03").is_err());
+        assert!(Path::from_str("0001:").is_err());
     }
 }
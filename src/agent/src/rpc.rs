@@ -8,6 +8,7 @@ use rustjail::{pipestream::PipeStream, process::StreamType};
 use tokio::io::{AsyncReadExt, AsyncWriteExt, ReadHalf};
 use tokio::sync::Mutex;
 
+use std::collections::HashMap;
 use std::ffi::CString;
 use std::io;
 use std::path::Path;
@@ -23,8 +24,10 @@ use cgroups::freezer::FreezerState;
 use oci::{LinuxNamespace, Root, Spec};
 use protobuf::{MessageDyn, MessageField};
 use protocols::agent::{
-    AddSwapRequest, AgentDetails, CopyFileRequest, GetIPTablesRequest, GetIPTablesResponse,
-    GuestDetailsResponse, Interfaces, Metrics, OOMEvent, ReadStreamResponse, Routes,
+    AddSwapRequest, AgentDetails, CheckpointContainerRequest, CheckpointContainerResponse,
+    CopyFileRequest, Diagnostics, GetDiagnosticsRequest, GetIPTablesRequest, GetIPTablesResponse,
+    GuestDetailsResponse, GuestServices, Interfaces, Metrics, OOMEvent, ReadFileRequest,
+    ReadFileResponse, ReadStreamResponse, RestoreContainerRequest, Routes, SeccompNotification,
     SetIPTablesRequest, SetIPTablesResponse, StatsContainerResponse, VolumeStatsRequest,
     WaitProcessResponse, WriteStreamResponse,
 };
@@ -50,14 +53,22 @@ use nix::sys::{stat, statfs};
 use nix::unistd::{self, Pid};
 use rustjail::process::ProcessOperations;
 
+use crate::checkpoint;
 use crate::device::{
     add_devices, get_virtio_blk_pci_device_name, update_device_cgroup, update_env_pci,
+    wait_for_device,
 };
+use crate::fsfreeze;
+use crate::guest_services;
+use crate::iosched;
 use crate::linux_abi::*;
 use crate::metrics::get_metrics;
-use crate::mount::{add_storages, baremount, update_ephemeral_mounts, STORAGE_HANDLER_LIST};
+use crate::mount::{
+    add_storages, baremount, parse_hugepage_size, update_ephemeral_mounts, STORAGE_HANDLER_LIST,
+};
 use crate::namespace::{NSTYPEIPC, NSTYPEPID, NSTYPEUTS};
 use crate::network::setup_guest_dns;
+use crate::network_policy;
 use crate::pci;
 use crate::random;
 use crate::sandbox::Sandbox;
@@ -80,12 +91,15 @@ use std::time::Duration;
 
 use nix::unistd::{Gid, Uid};
 use std::fs::{File, OpenOptions};
-use std::io::{BufRead, BufReader, Write};
-use std::os::unix::fs::FileExt;
-use std::path::PathBuf;
+use std::io::{BufRead, BufReader, Read, Seek, SeekFrom, Write};
+use std::os::unix::fs::{FileExt, OpenOptionsExt};
+use std::os::unix::io::{AsRawFd, IntoRawFd};
+use std::path::{Component, PathBuf};
 
 const CONTAINER_BASE: &str = "/run/kata-containers";
 const MODPROBE_PATH: &str = "/sbin/modprobe";
+// Written by osbuilder when building the guest image.
+const IMAGE_METADATA_PATH: &str = "/var/lib/osbuilder/image-metadata.json";
 
 /// the iptables seriers binaries could appear either in /sbin
 /// or /usr/sbin, we need to check both of them
@@ -98,6 +112,11 @@ const IP6TABLES_SAVE: &str = "/sbin/ip6tables-save";
 const USR_IP6TABLES_RESTORE: &str = "/usr/sbin/ip6tables-save";
 const IP6TABLES_RESTORE: &str = "/sbin/ip6tables-restore";
 const KATA_GUEST_SHARE_DIR: &str = "/run/kata-containers/shared/containers/";
+// The name resolution files of the sandbox, bind mounted in its containers.
+const GUEST_HOSTS_PATH: &str = "/run/kata-containers/sandbox/etc/hosts";
+const GUEST_RESOLV_CONF_PATH: &str = "/run/kata-containers/sandbox/etc/resolv.conf";
+const KMSG_PATH: &str = "/dev/kmsg";
+const PROC_SELF_MOUNTINFO: &str = "/proc/self/mountinfo";
 
 const ERR_CANNOT_GET_WRITER: &str = "Cannot get writer";
 const ERR_INVALID_BLOCK_SIZE: &str = "Invalid block size";
@@ -192,6 +211,8 @@ impl AgentService {
             sandbox = self.sandbox.clone();
             s = sandbox.lock().await;
             s.container_mounts.insert(cid.clone(), m);
+            s.set_container_hugepages(&cid, container_hugepages(&oci)?)
+                .context("resize hugepages pools")?;
         }
 
         update_container_namespaces(&s, &mut oci, use_sandbox_pidns)?;
@@ -254,6 +275,14 @@ impl AgentService {
             return Err(err);
         }
 
+        if let Ok(p) = ctr.get_process(&cid) {
+            s.seccomp_notifier.watch(&cid, p);
+        }
+
+        if iosched::has_blkio_weight(oci.linux.as_ref().and_then(|l| l.resources.as_ref())) {
+            select_bfq(&container_mount_points(&s, &cid));
+        }
+
         s.update_shared_pidns(&ctr)?;
         s.add_container(ctr);
         info!(sl(), "created container!");
@@ -264,6 +293,7 @@ impl AgentService {
     #[instrument]
     async fn do_start_container(&self, req: protocols::agent::StartContainerRequest) -> Result<()> {
         let cid = req.container_id;
+        let exec_timeout = req.exec_timeout;
 
         let sandbox = self.sandbox.clone();
         let mut s = sandbox.lock().await;
@@ -274,18 +304,88 @@ impl AgentService {
             .ok_or_else(|| anyhow!("Invalid container id"))?;
 
         ctr.exec().await?;
+        let init_pid = ctr.init_process_pid;
+        let exec_sync = ctr
+            .processes
+            .get_mut(&init_pid)
+            .and_then(|p| p.exec_sync.take());
 
-        if sid == cid {
-            return Ok(());
+        if sid != cid {
+            // start oom event loop
+
+            let cg_path = ctr.cgroup_manager.as_ref().get_cgroup_path("memory");
+
+            if let Ok(cg_path) = cg_path {
+                let rx = notifier::notify_oom(cid.as_str(), cg_path.to_string()).await?;
+
+                s.run_oom_event_monitor(rx, cid.clone()).await;
+            }
         }
+        drop(s);
 
-        // start oom event loop
+        if let Some(exec_sync) = exec_sync.filter(|_| exec_timeout > 0) {
+            wait_for_exec(exec_sync, Duration::from_millis(exec_timeout.into()))
+                .await
+                .with_context(|| format!("container {} failed to start", cid))?;
+        }
 
-        let cg_path = ctr.cgroup_manager.as_ref().get_cgroup_path("memory");
+        Ok(())
+    }
+
+    async fn do_checkpoint_container(
+        &self,
+        req: &CheckpointContainerRequest,
+    ) -> Result<Vec<String>> {
+        let image_dir = check_image_path(&req.image_path)?;
+        let options = criu_options(req.options.as_ref());
+
+        // Neither the sandbox nor the reaper are locked during the dump,
+        // which may take a while.
+        let (spec, pid) = {
+            let sandbox = self.sandbox.clone();
+            let mut s = sandbox.lock().await;
+
+            if req.container_id == s.id {
+                return Err(anyhow!("the sandbox container cannot be checkpointed"));
+            }
+
+            let ctr = s
+                .get_container(&req.container_id)
+                .ok_or_else(|| anyhow!("Invalid container id"))?;
 
+            (
+                checkpoint::container_spec(ctr)?.clone(),
+                ctr.init_process_pid,
+            )
+        };
+
+        checkpoint::checkpoint(&sl(), &spec, pid, &image_dir, &options, req.leave_running).await
+    }
+
+    async fn do_restore_container(&self, req: &RestoreContainerRequest) -> Result<()> {
+        let cid = req.container_id.clone();
+        let image_dir = check_image_path(&req.image_path)?;
+        let options = criu_options(req.options.as_ref());
+
+        // Keep the reaper from reaping criu, locking as the reaper does.
+        let _locker = rustjail::container::WAIT_PID_LOCKER.lock().await;
+        let sandbox = self.sandbox.clone();
+        let mut s = sandbox.lock().await;
+
+        if cid == s.id {
+            return Err(anyhow!("the sandbox container cannot be restored"));
+        }
+
+        let ctr = s
+            .get_container(&cid)
+            .ok_or_else(|| anyhow!("Invalid container id"))?;
+
+        checkpoint::restore(&sl(), ctr, &image_dir, &options)?;
+
+        // start oom event loop
+        let cg_path = ctr.cgroup_manager.as_ref().get_cgroup_path("memory");
         if let Ok(cg_path) = cg_path {
             let rx = notifier::notify_oom(cid.as_str(), cg_path.to_string()).await?;
-
             s.run_oom_event_monitor(rx, cid.clone()).await;
         }
 
@@ -370,12 +470,17 @@ impl AgentService {
         let ocip = rustjail::process_grpc_to_oci(&process);
         let p = Process::new(&sl(), &ocip, exec_id.as_str(), false, pipe_size)?;
 
+        let seccomp_notifier = sandbox.seccomp_notifier.clone();
         let ctr = sandbox
             .get_container(&cid)
             .ok_or_else(|| anyhow!("Invalid container id"))?;
 
         ctr.run(p).await?;
 
+        if let Ok(p) = ctr.get_process(&exec_id) {
+            seccomp_notifier.watch(&cid, p);
+        }
+
         Ok(())
     }
 
@@ -729,6 +834,7 @@ impl agent_ttrpc::AgentService for AgentService {
 
         let s = Arc::clone(&self.sandbox);
         let mut sandbox = s.lock().await;
+        let mount_points = container_mount_points(&sandbox, &cid);
 
         let ctr = sandbox.get_container(&cid).ok_or_else(|| {
             ttrpc_error(
@@ -741,12 +847,18 @@ impl agent_ttrpc::AgentService for AgentService {
 
         if let Some(res) = res.as_ref() {
             let oci_res = rustjail::resources_grpc_to_oci(res);
+            let blkio_weight = iosched::has_blkio_weight(Some(&oci_res));
             match ctr.set(oci_res) {
                 Err(e) => {
                     return Err(ttrpc_error(ttrpc::Code::INTERNAL, e));
                 }
 
-                Ok(_) => return Ok(resp),
+                Ok(_) => {
+                    if blkio_weight {
+                        select_bfq(&mount_points);
+                    }
+                    return Ok(resp);
+                }
             }
         }
 
@@ -973,6 +1085,13 @@ impl agent_ttrpc::AgentService for AgentService {
                 ttrpc_error(ttrpc::Code::INTERNAL, format!("update interface: {:?}", e))
             })?;
 
+        network_policy::attach_new_interface(&interface.name).map_err(|e| {
+            ttrpc_error(
+                ttrpc::Code::INTERNAL,
+                format!("attach network policy: {:?}", e),
+            )
+        })?;
+
         Ok(interface)
     }
 
@@ -1297,6 +1416,19 @@ impl agent_ttrpc::AgentService for AgentService {
             Err(e) => return Err(ttrpc_error(ttrpc::Code::INTERNAL, e)),
         };
 
+        if !req.guest_services.is_empty() {
+            let names = req.guest_services.clone();
+            let init_mode = self.init_mode;
+            let services =
+                tokio::task::spawn_blocking(move || guest_services::start(&names, init_mode))
+                    .await
+                    .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e))?
+                    .map_err(|e| ttrpc_error(ttrpc::Code::FAILED_PRECONDITION, e))?;
+
+            self.sandbox.lock().await.guest_services = services;
+            tokio::spawn(guest_services::watch(self.sandbox.clone()));
+        }
+
         Ok(Empty::new())
     }
 
@@ -1316,6 +1448,9 @@ impl agent_ttrpc::AgentService for AgentService {
             .destroy()
             .await
             .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e))?;
+        // Write the dirty pages of the guest back to the block devices
+        // before the runtime stops the VM.
+        do_sync_fs(None).map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e))?;
         // Close get_oom_event connection,
         // otherwise it will block the shutdown of ttrpc.
         sandbox.event_tx.take();
@@ -1476,6 +1611,70 @@ impl agent_ttrpc::AgentService for AgentService {
         Ok(Empty::new())
     }
 
+    async fn read_file(
+        &self,
+        ctx: &TtrpcContext,
+        req: ReadFileRequest,
+    ) -> ttrpc::Result<ReadFileResponse> {
+        trace_rpc_call!(ctx, "read_file", req);
+        is_allowed(&req)?;
+
+        let mut resp = ReadFileResponse::new();
+        resp.data = do_read_file(&req).map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e))?;
+
+        Ok(resp)
+    }
+
+    async fn get_diagnostics(
+        &self,
+        ctx: &TtrpcContext,
+        req: GetDiagnosticsRequest,
+    ) -> ttrpc::Result<Diagnostics> {
+        trace_rpc_call!(ctx, "get_diagnostics", req);
+        is_allowed(&req)?;
+
+        let mut resp = Diagnostics::new();
+        resp.kernel_log = read_kernel_log(req.max_kernel_log_size as usize)
+            .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e))?;
+        resp.mounts =
+            fs::read(PROC_SELF_MOUNTINFO).map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e))?;
+        resp.processes = list_processes().map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e))?;
+
+        Ok(resp)
+    }
+
+    async fn checkpoint_container(
+        &self,
+        ctx: &TtrpcContext,
+        req: CheckpointContainerRequest,
+    ) -> ttrpc::Result<CheckpointContainerResponse> {
+        trace_rpc_call!(ctx, "checkpoint_container", req);
+        is_allowed(&req)?;
+
+        let mut resp = CheckpointContainerResponse::new();
+        resp.files = self
+            .do_checkpoint_container(&req)
+            .await
+            .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e))?;
+
+        Ok(resp)
+    }
+
+    async fn restore_container(
+        &self,
+        ctx: &TtrpcContext,
+        req: RestoreContainerRequest,
+    ) -> ttrpc::Result<Empty> {
+        trace_rpc_call!(ctx, "restore_container", req);
+        is_allowed(&req)?;
+
+        self.do_restore_container(&req)
+            .await
+            .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e))?;
+
+        Ok(Empty::new())
+    }
+
     async fn get_metrics(
         &self,
         ctx: &TtrpcContext,
@@ -1528,35 +1727,38 @@ impl agent_ttrpc::AgentService for AgentService {
         is_allowed(&req)?;
 
         info!(sl(), "get volume stats!");
-        let mut resp = VolumeStatsResponse::new();
-
-        let mut condition = VolumeCondition::new();
-
-        match File::open(&req.volume_guest_path) {
-            Ok(_) => {
-                condition.abnormal = false;
-                condition.message = String::from("OK");
-            }
-            Err(e) => {
-                info!(sl(), "failed to open the volume");
-                return Err(ttrpc_error(ttrpc::Code::INTERNAL, e));
-            }
-        };
 
-        let mut usage_vec = Vec::new();
+        get_volume_stats(&req.volume_guest_path).map_err(|e| {
+            info!(sl(), "failed to get the volume stats");
+            ttrpc_error(ttrpc::Code::INTERNAL, e)
+        })
+    }
 
-        // to get volume capacity stats
-        get_volume_capacity_stats(&req.volume_guest_path)
-            .map(|u| usage_vec.push(u))
-            .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e))?;
+    async fn get_container_volume_stats(
+        &self,
+        ctx: &TtrpcContext,
+        req: protocols::agent::ContainerVolumeStatsRequest,
+    ) -> ttrpc::Result<protocols::agent::ContainerVolumeStatsResponse> {
+        trace_rpc_call!(ctx, "get_container_volume_stats", req);
+        is_allowed(&req)?;
 
-        // to get volume inode stats
-        get_volume_inode_stats(&req.volume_guest_path)
-            .map(|u| usage_vec.push(u))
-            .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e))?;
+        let mounts = {
+            let mut sandbox = self.sandbox.lock().await;
+            let ctr = sandbox.get_container(&req.container_id).ok_or_else(|| {
+                ttrpc_error(
+                    ttrpc::Code::INVALID_ARGUMENT,
+                    "invalid container id".to_string(),
+                )
+            })?;
+            ctr.config
+                .spec
+                .as_ref()
+                .map(|spec| spec.mounts.clone())
+                .unwrap_or_default()
+        };
 
-        resp.usage = usage_vec;
-        resp.volume_condition = MessageField::some(condition);
+        let mut resp = protocols::agent::ContainerVolumeStatsResponse::new();
+        resp.volumes = container_volume_stats(&mounts);
         Ok(resp)
     }
 
@@ -1574,6 +1776,209 @@ impl agent_ttrpc::AgentService for AgentService {
 
         Ok(Empty::new())
     }
+
+    async fn wait_device(
+        &self,
+        ctx: &TtrpcContext,
+        req: protocols::agent::WaitDeviceRequest,
+    ) -> ttrpc::Result<Empty> {
+        trace_rpc_call!(ctx, "wait_device", req);
+        is_allowed(&req)?;
+
+        let timeout = match req.timeout {
+            0 => AGENT_CONFIG.hotplug_timeout,
+            ms => Duration::from_millis(ms.into()),
+        };
+
+        wait_for_device(&self.sandbox, &req.type_, &req.address, timeout)
+            .await
+            .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e))?;
+
+        Ok(Empty::new())
+    }
+
+    async fn sync_fs(
+        &self,
+        ctx: &TtrpcContext,
+        req: protocols::agent::SyncFsRequest,
+    ) -> ttrpc::Result<Empty> {
+        trace_rpc_call!(ctx, "sync_fs", req);
+        is_allowed(&req)?;
+
+        // The storages of the container and its rootfs, or all the
+        // filesystems of the guest.
+        let mount_points = if req.container_id.is_empty() {
+            None
+        } else {
+            let sandbox = self.sandbox.lock().await;
+            Some(container_mount_points(&sandbox, &req.container_id))
+        };
+
+        let task = tokio::task::spawn_blocking(move || do_sync_fs(mount_points));
+        let res = match req.timeout {
+            0 => task.await,
+            ms => tokio::time::timeout(Duration::from_millis(ms.into()), task)
+                .await
+                .map_err(|_| {
+                    ttrpc_error(
+                        ttrpc::Code::DEADLINE_EXCEEDED,
+                        "timed out syncing the filesystems",
+                    )
+                })?,
+        };
+        res.map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e))?
+            .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e))?;
+
+        Ok(Empty::new())
+    }
+
+    async fn freeze_fs(
+        &self,
+        ctx: &TtrpcContext,
+        req: protocols::agent::FreezeFsRequest,
+    ) -> ttrpc::Result<Empty> {
+        trace_rpc_call!(ctx, "freeze_fs", req);
+        is_allowed(&req)?;
+
+        let mut sandbox = self.sandbox.lock().await;
+        if !sandbox.frozen_filesystems.is_empty() {
+            return Err(ttrpc_error(
+                ttrpc::Code::FAILED_PRECONDITION,
+                "the filesystems are already frozen",
+            ));
+        }
+
+        let container_ids = if req.container_id.is_empty() {
+            sandbox.containers.keys().cloned().collect()
+        } else {
+            vec![req.container_id.clone()]
+        };
+        let mount_points: Vec<String> = container_ids
+            .iter()
+            .flat_map(|cid| container_mount_points(&sandbox, cid))
+            .collect();
+
+        sandbox.frozen_filesystems =
+            fsfreeze::freeze(&mount_points).map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e))?;
+
+        info!(sl(), "filesystems frozen";
+            "mount-points" => format!("{:?}", sandbox.frozen_filesystems));
+
+        Ok(Empty::new())
+    }
+
+    async fn thaw_fs(
+        &self,
+        ctx: &TtrpcContext,
+        req: protocols::agent::ThawFsRequest,
+    ) -> ttrpc::Result<Empty> {
+        trace_rpc_call!(ctx, "thaw_fs", req);
+        is_allowed(&req)?;
+
+        // The filesystems which fail to thaw are kept in the sandbox, for
+        // the runtime to try them again.
+        let mut sandbox = self.sandbox.lock().await;
+        fsfreeze::thaw(&mut sandbox.frozen_filesystems)
+            .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e))?;
+
+        Ok(Empty::new())
+    }
+
+    async fn set_name_resolution(
+        &self,
+        ctx: &TtrpcContext,
+        req: protocols::agent::SetNameResolutionRequest,
+    ) -> ttrpc::Result<Empty> {
+        trace_rpc_call!(ctx, "set_name_resolution", req);
+        is_allowed(&req)?;
+
+        for (path, data) in [
+            (GUEST_HOSTS_PATH, &req.hosts),
+            (GUEST_RESOLV_CONF_PATH, &req.resolv_conf),
+        ]
+        .iter()
+        {
+            if data.is_empty() {
+                continue;
+            }
+            do_write_in_place(Path::new(path), data)
+                .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e))?;
+        }
+
+        Ok(Empty::new())
+    }
+
+    async fn get_guest_services(
+        &self,
+        ctx: &TtrpcContext,
+        req: protocols::agent::GetGuestServicesRequest,
+    ) -> ttrpc::Result<GuestServices> {
+        trace_rpc_call!(ctx, "get_guest_services", req);
+        is_allowed(&req)?;
+
+        let sandbox = self.sandbox.lock().await;
+        let mut resp = GuestServices::new();
+        resp.services = sandbox.guest_services.clone();
+
+        Ok(resp)
+    }
+
+    async fn set_network_policy(
+        &self,
+        ctx: &TtrpcContext,
+        req: protocols::agent::SetNetworkPolicyRequest,
+    ) -> ttrpc::Result<Empty> {
+        trace_rpc_call!(ctx, "set_network_policy", req);
+        is_allowed(&req)?;
+
+        let interfaces: Vec<String> = self
+            .sandbox
+            .lock()
+            .await
+            .rtnl
+            .list_interfaces()
+            .await
+            .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, format!("{:?}", e)))?
+            .into_iter()
+            .map(|i| i.name)
+            .collect();
+
+        network_policy::set(&req, &interfaces)
+            .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, format!("{:?}", e)))?;
+
+        Ok(Empty::new())
+    }
+
+    async fn get_seccomp_notification(
+        &self,
+        _ctx: &TtrpcContext,
+        req: protocols::agent::GetSeccompNotificationRequest,
+    ) -> ttrpc::Result<SeccompNotification> {
+        is_allowed(&req)?;
+        let notifier = self.sandbox.lock().await.seccomp_notifier.clone();
+
+        if let Some(notification) = notifier.get().await {
+            return Ok(notification);
+        }
+
+        Err(ttrpc_error(ttrpc::Code::INTERNAL, ""))
+    }
+
+    async fn send_seccomp_notification_response(
+        &self,
+        ctx: &TtrpcContext,
+        req: protocols::agent::SeccompNotificationResponse,
+    ) -> ttrpc::Result<Empty> {
+        trace_rpc_call!(ctx, "send_seccomp_notification_response", req);
+        is_allowed(&req)?;
+
+        let notifier = self.sandbox.lock().await.seccomp_notifier.clone();
+        notifier
+            .respond(&req)
+            .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, format!("{:?}", e)))?;
+
+        Ok(Empty::new())
+    }
 }
 
 #[derive(Clone)]
@@ -1652,6 +2057,53 @@ fn get_memory_info(
     Ok((size, plug))
 }
 
+// get_volume_stats returns the capacity and inode usage of the filesystem of
+// path, as the CSI NodeGetVolumeStats response.
+fn get_volume_stats(path: &str) -> Result<VolumeStatsResponse> {
+    File::open(path).with_context(|| format!("open {}", path))?;
+
+    let mut condition = VolumeCondition::new();
+    condition.abnormal = false;
+    condition.message = String::from("OK");
+
+    let mut resp = VolumeStatsResponse::new();
+    resp.usage = vec![
+        get_volume_capacity_stats(path)?,
+        get_volume_inode_stats(path)?,
+    ];
+    resp.volume_condition = MessageField::some(condition);
+    Ok(resp)
+}
+
+// container_volume_stats returns the stats of the volumes of the container,
+// the directories bind mounted from the guest. The volumes whose stats
+// cannot be read are reported as abnormal.
+fn container_volume_stats(mounts: &[oci::Mount]) -> Vec<protocols::agent::ContainerVolumeStats> {
+    mounts
+        .iter()
+        .filter(|m| {
+            (m.r#type == "bind" || m.options.iter().any(|o| o == "bind" || o == "rbind"))
+                && Path::new(&m.source).is_dir()
+        })
+        .map(|m| {
+            let stats = get_volume_stats(&m.source).unwrap_or_else(|e| {
+                let mut condition = VolumeCondition::new();
+                condition.abnormal = true;
+                condition.message = format!("{:?}", e);
+                let mut stats = VolumeStatsResponse::new();
+                stats.volume_condition = MessageField::some(condition);
+                stats
+            });
+
+            let mut volume = protocols::agent::ContainerVolumeStats::new();
+            volume.destination = m.destination.clone();
+            volume.volume_guest_path = m.source.clone();
+            volume.stats = MessageField::some(stats);
+            volume
+        })
+        .collect()
+}
+
 fn get_volume_capacity_stats(path: &str) -> Result<VolumeUsage> {
     let mut usage = VolumeUsage::new();
 
@@ -1699,6 +2151,10 @@ fn get_agent_details() -> AgentDetails {
         .map(|x| x.to_string())
         .collect();
 
+    // The runtime checks its configuration against the image metadata, an
+    // image built without it is left unchecked.
+    detail.image_metadata = fs::read_to_string(IMAGE_METADATA_PATH).unwrap_or_default();
+
     detail
 }
 
@@ -1819,11 +2275,37 @@ fn remove_container_resources(sandbox: &mut Sandbox, cid: &str) -> Result<()> {
         }
     }
 
+    if let Err(err) = sandbox.set_container_hugepages(cid, HashMap::new()) {
+        error!(
+            sl(),
+            "failed to release the hugepages of container {}, error: {:?}", cid, err
+        );
+    }
+
+    // The images of the container, if it was checkpointed or restored.
+    let _ = fs::remove_dir_all(Path::new(checkpoint::CHECKPOINTS_DIR).join(cid));
+
     sandbox.container_mounts.remove(cid);
     sandbox.containers.remove(cid);
     Ok(())
 }
 
+// container_hugepages returns the hugepages requested by the container, in
+// bytes by page size.
+fn container_hugepages(oci: &Spec) -> Result<HashMap<u64, u64>> {
+    let mut hugepages = HashMap::new();
+
+    if let Some(resources) = oci.linux.as_ref().and_then(|l| l.resources.as_ref()) {
+        for l in resources.hugepage_limits.iter().filter(|l| l.limit > 0) {
+            *hugepages
+                .entry(parse_hugepage_size(&l.page_size)?)
+                .or_insert(0) += l.limit;
+        }
+    }
+
+    Ok(hugepages)
+}
+
 fn append_guest_hooks(s: &Sandbox, oci: &mut Spec) -> Result<()> {
     if let Some(ref guest_hooks) = s.hooks {
         let mut hooks = oci.hooks.take().unwrap_or_default();
@@ -1964,6 +2446,156 @@ fn do_copy_file(req: &CopyFileRequest) -> Result<()> {
     Ok(())
 }
 
+fn do_read_file(req: &ReadFileRequest) -> Result<Vec<u8>> {
+    let path = PathBuf::from(req.path.as_str());
+
+    if !path.starts_with(CONTAINER_BASE) {
+        return Err(anyhow!(
+            "Path {:?} does not start with {}",
+            path,
+            CONTAINER_BASE
+        ));
+    }
+
+    // The symlinks could lead out of CONTAINER_BASE.
+    if fs::canonicalize(&path)? != path {
+        return Err(anyhow!("Path {:?} is not canonical", path));
+    }
+
+    let mut file = File::open(&path)?;
+    file.seek(SeekFrom::Start(req.offset))?;
+    let mut data = Vec::new();
+    if req.max_size == 0 {
+        file.read_to_end(&mut data)?;
+    } else {
+        file.take(req.max_size).read_to_end(&mut data)?;
+    }
+
+    Ok(data)
+}
+
+// check_image_path checks that the CRIU image directory is below
+// CHECKPOINTS_DIR.
+fn check_image_path(path: &str) -> Result<PathBuf> {
+    let path = PathBuf::from(path);
+
+    if !path.starts_with(checkpoint::CHECKPOINTS_DIR)
+        || path.components().any(|c| c == Component::ParentDir)
+    {
+        return Err(anyhow!(
+            "Path {:?} is not a directory below {}",
+            path,
+            checkpoint::CHECKPOINTS_DIR
+        ));
+    }
+
+    Ok(path)
+}
+
+fn criu_options(options: Option<&protocols::agent::CriuOptions>) -> checkpoint::CriuOptions {
+    options
+        .map(|o| checkpoint::CriuOptions {
+            tcp_established: o.tcp_established,
+            file_locks: o.file_locks,
+            ext_unix_sockets: o.ext_unix_sockets,
+        })
+        .unwrap_or_default()
+}
+
+// read_kernel_log returns the tail of the kernel log still in the ring
+// buffer, of max_size bytes at most, the whole log when max_size is 0.
+fn read_kernel_log(max_size: usize) -> Result<Vec<u8>> {
+    let mut kmsg = OpenOptions::new()
+        .read(true)
+        .custom_flags(libc::O_NONBLOCK)
+        .open(KMSG_PATH)
+        .context(format!("open {}", KMSG_PATH))?;
+
+    let mut log = Vec::new();
+    let mut record = vec![0u8; 8192];
+    loop {
+        // Each read returns a single record.
+        let n = match kmsg.read(&mut record) {
+            Ok(0) => break,
+            Ok(n) => n,
+            Err(e) if e.kind() == io::ErrorKind::WouldBlock => break,
+            // The record was overwritten in the ring buffer.
+            Err(e) if e.raw_os_error() == Some(libc::EPIPE) => continue,
+            Err(e) => return Err(e).context(format!("read {}", KMSG_PATH)),
+        };
+        log.extend_from_slice(format_kmsg_record(&record[..n]).as_bytes());
+    }
+
+    if max_size != 0 && log.len() > max_size {
+        log.drain(..log.len() - max_size);
+    }
+
+    Ok(log)
+}
+
+// format_kmsg_record formats a /dev/kmsg record as dmesg does, the record
+// being "<priority>,<sequence>,<timestamp in us>,<flags>;<message>" followed
+// by its dictionary.
+fn format_kmsg_record(record: &[u8]) -> String {
+    let record = String::from_utf8_lossy(record);
+    let (prefix, message) = match record.split_once(';') {
+        Some(fields) => fields,
+        None => return record.into_owned(),
+    };
+    let message = message.lines().next().unwrap_or("");
+    let usecs = prefix
+        .split(',')
+        .nth(2)
+        .and_then(|t| t.parse::<u64>().ok())
+        .unwrap_or(0);
+
+    format!(
+        "[{:5}.{:06}] {}\n",
+        usecs / 1_000_000,
+        usecs % 1_000_000,
+        message
+    )
+}
+
+// list_processes lists the processes of the guest, one per line as
+// "<pid> <ppid> <state> <command line>".
+fn list_processes() -> Result<Vec<u8>> {
+    let mut pids: Vec<i32> = fs::read_dir("/proc")?
+        .filter_map(|e| e.ok())
+        .filter_map(|e| e.file_name().to_str().and_then(|n| n.parse().ok()))
+        .collect();
+    pids.sort_unstable();
+
+    let mut list = String::from("PID PPID STATE COMMAND\n");
+    for pid in pids {
+        // The process may have exited meanwhile.
+        let stat = match fs::read_to_string(format!("/proc/{}/stat", pid)) {
+            Ok(stat) => stat,
+            Err(_) => continue,
+        };
+        // The command name is parenthesized and may contain spaces.
+        let (comm, fields) = match (stat.find('('), stat.rfind(')')) {
+            (Some(start), Some(end)) if start < end => (&stat[start + 1..end], &stat[end + 1..]),
+            _ => continue,
+        };
+        let mut fields = fields.split_whitespace();
+        let state = fields.next().unwrap_or("?");
+        let ppid = fields.next().unwrap_or("?");
+
+        // The kernel threads have no command line.
+        let cmdline = fs::read(format!("/proc/{}/cmdline", pid)).unwrap_or_default();
+        let cmdline = String::from_utf8_lossy(&cmdline).replace('\0', " ");
+        let command = match cmdline.trim_end() {
+            "" => format!("[{}]", comm),
+            c => c.to_string(),
+        };
+
+        list.push_str(&format!("{} {} {} {}\n", pid, ppid, state, command));
+    }
+
+    Ok(list.into_bytes())
+}
+
 async fn do_add_swap(sandbox: &Arc<Mutex<Sandbox>>, req: &AddSwapRequest) -> Result<()> {
     let mut slots = Vec::new();
     for slot in &req.PCIPath {
@@ -1984,6 +2616,102 @@ async fn do_add_swap(sandbox: &Arc<Mutex<Sandbox>>, req: &AddSwapRequest) -> Res
     Ok(())
 }
 
+// wait_for_exec waits for the init process of a container to exec its
+// entrypoint: the exec closes the write end of the exec sync pipe, which the
+// process writes to when it fails before.
+async fn wait_for_exec(exec_sync: File, timeout: Duration) -> Result<()> {
+    let mut exec_sync = PipeStream::from_fd(exec_sync.into_raw_fd());
+    let mut buf = [0u8; 1];
+
+    match tokio::time::timeout(timeout, exec_sync.read(&mut buf)).await {
+        Ok(Ok(0)) => Ok(()),
+        Ok(Ok(_)) => Err(anyhow!(
+            "the process exited before executing its entrypoint"
+        )),
+        Ok(Err(e)) => Err(anyhow!(e).context("wait for the process to execute its entrypoint")),
+        Err(_) => Err(anyhow!(
+            "the process did not execute its entrypoint in {:?}",
+            timeout
+        )),
+    }
+}
+
+// select_bfq selects the BFQ scheduler for the block devices of the
+// container, for its cgroup to get the block IO weight it is given. The
+// container runs without the weight when the scheduler cannot be selected.
+fn select_bfq(mount_points: &[String]) {
+    match iosched::select_bfq(mount_points) {
+        Ok(devices) if !devices.is_empty() => {
+            info!(sl(), "selected the BFQ scheduler"; "devices" => format!("{:?}", devices))
+        }
+        Ok(_) => {}
+        Err(e) => warn!(sl(), "failed to select the BFQ scheduler: {:?}", e),
+    }
+}
+
+// container_mount_points returns the mount points of the storages of the
+// container and of its rootfs.
+fn container_mount_points(sandbox: &Sandbox, cid: &str) -> Vec<String> {
+    let mut mount_points = sandbox
+        .container_mounts
+        .get(cid)
+        .cloned()
+        .unwrap_or_default();
+    let rootfs = Path::new(CONTAINER_BASE).join(cid).join("rootfs");
+    mount_points.push(rootfs.to_string_lossy().to_string());
+    mount_points
+}
+
+// do_sync_fs writes the dirty pages of the filesystems of the mount points
+// back to their devices, or of all the filesystems when there are none. The
+// mount points which are not there any more are skipped.
+fn do_sync_fs(mount_points: Option<Vec<String>>) -> Result<()> {
+    let mount_points = match mount_points {
+        Some(mount_points) => mount_points,
+        None => {
+            unistd::sync();
+            return Ok(());
+        }
+    };
+
+    for mount_point in mount_points {
+        let dir = match File::open(&mount_point) {
+            Ok(dir) => dir,
+            Err(e) if e.kind() == io::ErrorKind::NotFound => continue,
+            Err(e) => return Err(anyhow!(e).context(format!("open {}", mount_point))),
+        };
+        let ret = unsafe { libc::syncfs(dir.as_raw_fd()) };
+        if ret != 0 {
+            return Err(anyhow!(
+                "syncfs {} failed: {}",
+                mount_point,
+                io::Error::last_os_error()
+            ));
+        }
+    }
+
+    Ok(())
+}
+
+// do_write_in_place writes the file at path without replacing it, so that
+// the bind mounts of the file see its new contents.
+fn do_write_in_place(path: &Path, data: &[u8]) -> Result<()> {
+    if let Some(parent) = path.parent() {
+        fs::create_dir_all(parent).context(format!("create {:?}", parent))?;
+    }
+
+    let mut file = OpenOptions::new()
+        .write(true)
+        .create(true)
+        .truncate(true)
+        .mode(0o644)
+        .open(path)
+        .context(format!("open {:?}", path))?;
+    file.write_all(data).context(format!("write {:?}", path))?;
+
+    Ok(())
+}
+
 // Setup container bundle under CONTAINER_BASE, which is cleaned up
 // before removing a container.
 // - bundle path is /<CONTAINER_BASE>/<cid>/
@@ -2039,7 +2767,7 @@ pub fn setup_bundle(cid: &str, spec: &mut Spec) -> Result<PathBuf> {
     Ok(olddir)
 }
 
-fn load_kernel_module(module: &protocols::agent::KernelModule) -> Result<()> {
+pub fn load_kernel_module(module: &protocols::agent::KernelModule) -> Result<()> {
     if module.name.is_empty() {
         return Err(anyhow!("Kernel module name is empty"));
     }
@@ -2086,6 +2814,7 @@ mod tests {
     use nix::mount;
     use nix::sched::{unshare, CloneFlags};
     use oci::{Hook, Hooks, Linux, LinuxNamespace};
+    use std::os::unix::fs::MetadataExt;
     use tempfile::{tempdir, TempDir};
     use test_utils::{assert_result, skip_if_not_root};
     use ttrpc::{r#async::TtrpcContext, MessageHeader};
@@ -2166,6 +2895,164 @@ mod tests {
         assert!(result.is_ok(), "load module should success");
     }
 
+    #[test]
+    fn test_container_volume_stats() {
+        let dir = tempdir().expect("failed to make tempdir");
+        let volume = dir.path().to_string_lossy().to_string();
+        let gone = dir.path().join("gone").to_string_lossy().to_string();
+
+        let mount = |destination: &str, r#type: &str, source: &str, options: &[&str]| oci::Mount {
+            destination: destination.to_string(),
+            r#type: r#type.to_string(),
+            source: source.to_string(),
+            options: options.iter().map(|o| o.to_string()).collect(),
+        };
+        let mounts = vec![
+            mount("/proc", "proc", "proc", &[]),
+            mount("/data", "bind", &volume, &["rbind", "rw"]),
+            mount("/cache", "", &volume, &["bind"]),
+            mount("/gone", "bind", &gone, &["rbind"]),
+        ];
+
+        let volumes = container_volume_stats(&mounts);
+        assert_eq!(volumes.len(), 2);
+        assert_eq!(volumes[0].destination, "/data");
+        assert_eq!(volumes[0].volume_guest_path, volume);
+        assert_eq!(volumes[1].destination, "/cache");
+
+        let stats = &volumes[0].stats;
+        assert_eq!(stats.usage.len(), 2);
+        assert!(stats.usage[0].total > 0);
+        assert!(!stats.volume_condition.abnormal);
+
+        assert!(get_volume_stats(&gone).is_err());
+    }
+
+    #[test]
+    fn test_do_sync_fs() {
+        let dir = tempdir().expect("failed to make tempdir");
+        fs::write(dir.path().join("data"), "data").unwrap();
+
+        let mount_points = vec![
+            dir.path().to_string_lossy().to_string(),
+            dir.path().join("gone").to_string_lossy().to_string(),
+        ];
+        assert!(do_sync_fs(Some(mount_points)).is_ok());
+        assert!(do_sync_fs(None).is_ok());
+    }
+
+    #[test]
+    fn test_do_read_file() {
+        let dir = tempfile::Builder::new()
+            .prefix("read-file")
+            .tempdir_in(CONTAINER_BASE);
+        let dir = match dir {
+            Ok(dir) => dir,
+            // CONTAINER_BASE is only writable by root.
+            Err(_) => return,
+        };
+        let path = dir.path().join("termination-log");
+        fs::write(&path, b"failed to connect").unwrap();
+
+        let mut req = ReadFileRequest::new();
+        req.path = path.to_string_lossy().to_string();
+        assert_eq!(do_read_file(&req).unwrap(), b"failed to connect");
+
+        req.max_size = 6;
+        assert_eq!(do_read_file(&req).unwrap(), b"failed");
+
+        req.offset = 10;
+        assert_eq!(do_read_file(&req).unwrap(), b"connec");
+
+        req.path = format!("{}/../etc/passwd", dir.path().display());
+        assert!(do_read_file(&req).is_err());
+
+        req.path = "/etc/passwd".to_string();
+        assert!(do_read_file(&req).is_err());
+    }
+
+    #[test]
+    fn test_check_image_path() {
+        assert!(check_image_path("/run/kata-containers/checkpoints/app").is_ok());
+        assert!(check_image_path("/run/kata-containers/checkpoints/../../etc").is_err());
+        assert!(check_image_path("/run/kata-containers/app").is_err());
+    }
+
+    #[test]
+    fn test_format_kmsg_record() {
+        assert_eq!(
+            format_kmsg_record(b"6,339,5140900,-;NET: Registered protocol family 10\n"),
+            "[    5.140900] NET: Registered protocol family 10\n"
+        );
+        assert_eq!(
+            format_kmsg_record(
+                b"3,1034,41262013,-;app[212]: segfault at 0 ip 00000000004011d6\n SUBSYSTEM=cpu\n"
+            ),
+            "[   41.262013] app[212]: segfault at 0 ip 00000000004011d6\n"
+        );
+    }
+
+    #[test]
+    fn test_list_processes() {
+        let list = String::from_utf8(list_processes().unwrap()).unwrap();
+        let pid = format!("{} ", std::process::id());
+
+        assert!(list.starts_with("PID PPID STATE COMMAND\n"));
+        assert!(list.lines().any(|l| l.starts_with(&pid)));
+    }
+
+    #[test]
+    fn test_do_write_in_place() {
+        let dir = tempdir().expect("failed to make tempdir");
+        let path = dir.path().join("etc").join("hosts");
+
+        do_write_in_place(&path, b"127.0.0.1 localhost\n10.0.0.1 a\n").unwrap();
+        let ino = fs::metadata(&path).unwrap().ino();
+
+        do_write_in_place(&path, b"127.0.0.1 localhost\n").unwrap();
+        assert_eq!(fs::read(&path).unwrap(), b"127.0.0.1 localhost\n");
+        assert_eq!(fs::metadata(&path).unwrap().ino(), ino);
+    }
+
+    #[test]
+    fn test_container_hugepages() {
+        let mut spec = Spec::default();
+        assert!(container_hugepages(&spec).unwrap().is_empty());
+
+        spec.linux = Some(Linux {
+            resources: Some(oci::LinuxResources {
+                hugepage_limits: vec![
+                    oci::LinuxHugepageLimit {
+                        page_size: "2MB".to_string(),
+                        limit: 512 << 20,
+                    },
+                    oci::LinuxHugepageLimit {
+                        page_size: "1GB".to_string(),
+                        limit: 0,
+                    },
+                ],
+                ..Default::default()
+            }),
+            ..Default::default()
+        });
+        assert_eq!(
+            container_hugepages(&spec).unwrap(),
+            HashMap::from([(2 << 20, 512 << 20)])
+        );
+
+        let limits = &mut spec
+            .linux
+            .as_mut()
+            .unwrap()
+            .resources
+            .as_mut()
+            .unwrap()
+            .hugepage_limits;
+        limits[1].page_size = "1G".to_string();
+        limits[1].limit = 1 << 30;
+        assert!(container_hugepages(&spec).is_err());
+    }
+
     #[tokio::test]
     async fn test_append_guest_hooks() {
         let logger = slog::Logger::root(slog::Discard, o!());
@@ -2992,4 +3879,31 @@ COMMIT
             "We should see the resulting rule"
         );
     }
+
+    #[tokio::test]
+    async fn test_wait_for_exec() {
+        use std::os::unix::io::FromRawFd;
+
+        let timeout = Duration::from_millis(100);
+        let pipe = || {
+            let (rfd, wfd) = unistd::pipe().unwrap();
+            (unsafe { File::from_raw_fd(rfd) }, wfd)
+        };
+
+        // closed by the exec
+        let (exec_sync, wfd) = pipe();
+        unistd::close(wfd).unwrap();
+        assert!(wait_for_exec(exec_sync, timeout).await.is_ok());
+
+        // written to by the failing process
+        let (exec_sync, wfd) = pipe();
+        unistd::write(wfd, &[0]).unwrap();
+        unistd::close(wfd).unwrap();
+        assert!(wait_for_exec(exec_sync, timeout).await.is_err());
+
+        // still open
+        let (exec_sync, wfd) = pipe();
+        assert!(wait_for_exec(exec_sync, timeout).await.is_err());
+        unistd::close(wfd).unwrap();
+    }
 }
@@ -4,18 +4,19 @@
 //
 
 use crate::linux_abi::*;
-use crate::mount::{get_mount_fs_type, remove_mounts, TYPE_ROOTFS};
+use crate::mount::{get_mount_fs_type, remove_mounts, set_hugepages_pool, TYPE_ROOTFS};
 use crate::namespace::Namespace;
 use crate::netlink::Handle;
 use crate::network::Network;
 use crate::pci;
+use crate::seccomp_notify::SeccompNotifier;
 use crate::uevent::{Uevent, UeventMatcher};
 use crate::watcher::BindWatcher;
 use anyhow::{anyhow, Context, Result};
 use kata_types::cpu::CpuSet;
 use libc::pid_t;
 use oci::{Hook, Hooks};
-use protocols::agent::OnlineCPUMemRequest;
+use protocols::agent::{GuestService, OnlineCPUMemRequest};
 use regex::Regex;
 use rustjail::cgroups as rustjail_cgroups;
 use rustjail::container::BaseContainer;
@@ -62,6 +63,13 @@ pub struct Sandbox {
     pub event_tx: Option<Sender<String>>,
     pub bind_watcher: BindWatcher,
     pub pcimap: HashMap<pci::Address, pci::Address>,
+    // hugepages requested by each container, in bytes by page size
+    pub hugepages: HashMap<String, HashMap<u64, u64>>,
+    // mount points of the filesystems frozen until they are thawed
+    pub frozen_filesystems: Vec<String>,
+    // systemd units of the guest image run for the pod
+    pub guest_services: Vec<GuestService>,
+    pub seccomp_notifier: SeccompNotifier,
 }
 
 impl Sandbox {
@@ -95,6 +103,10 @@ impl Sandbox {
             event_tx: Some(tx),
             bind_watcher: BindWatcher::new(),
             pcimap: HashMap::new(),
+            hugepages: HashMap::new(),
+            frozen_filesystems: Vec::new(),
+            guest_services: Vec::new(),
+            seccomp_notifier: SeccompNotifier::new(),
         })
     }
 
@@ -177,6 +189,45 @@ impl Sandbox {
         Ok(())
     }
 
+    // hugepages_pool_size returns the size of the pool of hugepages of the
+    // given size requested by the containers of the sandbox.
+    pub fn hugepages_pool_size(&self, page_size: u64) -> u64 {
+        self.hugepages
+            .values()
+            .filter_map(|h| h.get(&page_size))
+            .sum()
+    }
+
+    // set_container_hugepages records the hugepages requested by the
+    // container, then resizes the guest pools to the size requested by all
+    // the containers of the sandbox. An empty request releases the pages of
+    // the container.
+    //
+    // It's assumed that caller is calling this method after
+    // acquiring a lock on sandbox.
+    #[instrument]
+    pub fn set_container_hugepages(
+        &mut self,
+        cid: &str,
+        hugepages: HashMap<u64, u64>,
+    ) -> Result<()> {
+        let mut page_sizes: Vec<u64> = hugepages.keys().copied().collect();
+        if let Some(previous) = self.hugepages.remove(cid) {
+            page_sizes.extend(previous.keys());
+        }
+        if !hugepages.is_empty() {
+            self.hugepages.insert(cid.to_string(), hugepages);
+        }
+
+        page_sizes.sort_unstable();
+        page_sizes.dedup();
+        for page_size in page_sizes {
+            set_hugepages_pool(&self.logger, page_size, self.hugepages_pool_size(page_size))?;
+        }
+
+        Ok(())
+    }
+
     #[instrument]
     pub async fn setup_shared_namespaces(&mut self) -> Result<bool> {
         // Set up shared IPC namespace
@@ -514,6 +565,26 @@ mod tests {
 
     use serial_test::serial;
 
+    #[tokio::test]
+    #[serial]
+    async fn hugepages_pool_size() {
+        let logger = slog::Logger::root(slog::Discard, o!());
+        let mut s = Sandbox::new(&logger).unwrap();
+
+        assert_eq!(s.hugepages_pool_size(2 << 20), 0);
+
+        s.hugepages.insert(
+            "c1".to_string(),
+            HashMap::from([(2 << 20, 512 << 20), (1 << 30, 1 << 30)]),
+        );
+        s.hugepages
+            .insert("c2".to_string(), HashMap::from([(2 << 20, 256 << 20)]));
+
+        assert_eq!(s.hugepages_pool_size(2 << 20), 768 << 20);
+        assert_eq!(s.hugepages_pool_size(1 << 30), 1 << 30);
+        assert_eq!(s.hugepages_pool_size(64 << 10), 0);
+    }
+
     #[tokio::test]
     #[serial]
     async fn set_sandbox_storage() {
@@ -0,0 +1,239 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// The system calls of the container processes trapped by the SCMP_ACT_NOTIFY
+// rules of their seccomp profiles are queued as notifications, which the
+// runtime gets with GetSeccompNotification and responds to, on behalf of a
+// host daemon, with SendSeccompNotificationResponse. A container process
+// sends the listener of its notifications to the agent once its filter is
+// loaded, the listener being read by a blocking task until the processes of
+// the filter exit.
+
+#![cfg_attr(not(feature = "seccomp"), allow(dead_code, unused_variables))]
+
+use std::collections::HashMap;
+use std::fs::File;
+#[cfg(feature = "seccomp")]
+use std::os::unix::io::{AsRawFd, FromRawFd, RawFd};
+use std::sync::{Arc, Mutex as SyncMutex};
+
+use anyhow::{anyhow, Result};
+use protocols::agent::{SeccompNotification, SeccompNotificationResponse};
+use rustjail::process::Process;
+#[cfg(feature = "seccomp")]
+use rustjail::seccomp;
+use tokio::sync::mpsc::{channel, Receiver, Sender};
+use tokio::sync::Mutex;
+
+// The processes are blocked until their notifications are responded to, so
+// few of them are queued at any time.
+const NOTIFICATIONS_CAPACITY: usize = 100;
+
+// Convenience function to obtain the scope logger.
+fn sl() -> slog::Logger {
+    slog_scope::logger().new(o!("subsystem" => "seccomp-notify"))
+}
+
+// A notification not responded to yet
+#[derive(Debug)]
+struct Pending {
+    listener: Arc<File>,
+    // the id of the notification for the kernel
+    id: u64,
+}
+
+#[derive(Debug, Default)]
+struct PendingNotifications {
+    next_id: u64,
+    notifications: HashMap<u64, Pending>,
+}
+
+#[derive(Clone, Debug)]
+pub struct SeccompNotifier {
+    tx: Sender<SeccompNotification>,
+    rx: Arc<Mutex<Receiver<SeccompNotification>>>,
+    pending: Arc<SyncMutex<PendingNotifications>>,
+}
+
+impl Default for SeccompNotifier {
+    fn default() -> Self {
+        Self::new()
+    }
+}
+
+impl SeccompNotifier {
+    pub fn new() -> Self {
+        let (tx, rx) = channel(NOTIFICATIONS_CAPACITY);
+
+        SeccompNotifier {
+            tx,
+            rx: Arc::new(Mutex::new(rx)),
+            pending: Arc::new(SyncMutex::new(PendingNotifications::default())),
+        }
+    }
+
+    // watch queues the notifications of the process, when its seccomp profile
+    // traps system calls.
+    #[cfg(feature = "seccomp")]
+    pub fn watch(&self, container_id: &str, p: &mut Process) {
+        let sock = match p.seccomp_notify.take() {
+            Some(sock) => sock,
+            None => return,
+        };
+        let container_id = container_id.to_string();
+        // The init process of a container has no exec id for the runtime
+        let exec_id = if p.init {
+            String::new()
+        } else {
+            p.exec_id.clone()
+        };
+
+        let notifier = self.clone();
+        tokio::task::spawn_blocking(move || {
+            if let Err(e) = notifier.run(sock, &container_id, &exec_id) {
+                warn!(sl(), "failed to watch seccomp notifications: {:?}", e;
+                    "container" => &container_id, "exec" => &exec_id);
+            }
+        });
+    }
+
+    #[cfg(not(feature = "seccomp"))]
+    pub fn watch(&self, _container_id: &str, _p: &mut Process) {}
+
+    #[cfg(feature = "seccomp")]
+    fn run(&self, sock: RawFd, container_id: &str, exec_id: &str) -> Result<()> {
+        let sock = unsafe { File::from_raw_fd(sock) };
+        let fd = match seccomp::recv_notify_fd(sock.as_raw_fd())? {
+            Some(fd) => fd,
+            None => return Ok(()),
+        };
+        drop(sock);
+        let listener = Arc::new(unsafe { File::from_raw_fd(fd) });
+
+        info!(sl(), "watching seccomp notifications";
+            "container" => container_id, "exec" => exec_id);
+
+        loop {
+            let n = match seccomp::receive_notification(listener.as_raw_fd()) {
+                Ok(Some(n)) => n,
+                Ok(None) => break,
+                // The process may have been killed in its system call, the
+                // next poll reports the hangup if it was the last one.
+                Err(e) => {
+                    warn!(sl(), "failed to receive seccomp notification: {:?}", e);
+                    continue;
+                }
+            };
+
+            let id = self.add_pending(&listener, n.id);
+            let notification = SeccompNotification {
+                id,
+                container_id: container_id.to_string(),
+                exec_id: exec_id.to_string(),
+                pid: n.pid,
+                syscall: n.syscall,
+                arch: n.arch,
+                args: n.args,
+                ..Default::default()
+            };
+            if self.tx.blocking_send(notification).is_err() {
+                break;
+            }
+        }
+
+        // The processes still waiting for a response fail with ENOSYS once
+        // the listener is closed.
+        self.remove_listener(&listener);
+        info!(sl(), "stopped watching seccomp notifications";
+            "container" => container_id, "exec" => exec_id);
+
+        Ok(())
+    }
+
+    fn add_pending(&self, listener: &Arc<File>, id: u64) -> u64 {
+        let mut pending = self.pending.lock().unwrap();
+        pending.next_id += 1;
+        let next_id = pending.next_id;
+        pending.notifications.insert(
+            next_id,
+            Pending {
+                listener: listener.clone(),
+                id,
+            },
+        );
+        next_id
+    }
+
+    fn remove_listener(&self, listener: &Arc<File>) {
+        self.pending
+            .lock()
+            .unwrap()
+            .notifications
+            .retain(|_, n| !Arc::ptr_eq(&n.listener, listener));
+    }
+
+    // get waits for the next notification of the containers.
+    pub async fn get(&self) -> Option<SeccompNotification> {
+        self.rx.lock().await.recv().await
+    }
+
+    // respond responds to a notification, which unblocks the process.
+    pub fn respond(&self, resp: &SeccompNotificationResponse) -> Result<()> {
+        let pending = self
+            .pending
+            .lock()
+            .unwrap()
+            .notifications
+            .remove(&resp.id)
+            .ok_or_else(|| anyhow!("unknown seccomp notification {}", resp.id))?;
+
+        info!(sl(), "seccomp notification response";
+            "id" => resp.id, "allow" => resp.allow, "error" => resp.error, "value" => resp.value);
+
+        #[cfg(feature = "seccomp")]
+        seccomp::respond_notification(
+            pending.listener.as_raw_fd(),
+            pending.id,
+            resp.allow,
+            resp.error,
+            resp.value,
+        )?;
+
+        Ok(())
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[tokio::test]
+    async fn test_respond_unknown_notification() {
+        let notifier = SeccompNotifier::new();
+
+        let resp = SeccompNotificationResponse {
+            id: 1,
+            allow: true,
+            ..Default::default()
+        };
+        assert!(notifier.respond(&resp).is_err());
+    }
+
+    #[tokio::test]
+    async fn test_remove_listener() {
+        let notifier = SeccompNotifier::new();
+        let listener = Arc::new(tempfile::tempfile().unwrap());
+        let other = Arc::new(tempfile::tempfile().unwrap());
+
+        let id = notifier.add_pending(&listener, 10);
+        let other_id = notifier.add_pending(&other, 10);
+        assert_ne!(id, other_id);
+
+        notifier.remove_listener(&listener);
+        let pending = notifier.pending.lock().unwrap();
+        assert!(!pending.notifications.contains_key(&id));
+        assert_eq!(pending.notifications[&other_id].id, 10);
+    }
+}
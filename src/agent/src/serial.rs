@@ -0,0 +1,184 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// With the serial transport, the runtime talks to the agent over a
+// virtio-serial port rather than vsock. A port carries a single stream, so
+// the ttrpc server listens on a unix socket, and each connection of the
+// runtime to the port is relayed to a new connection to that socket.
+
+use anyhow::{anyhow, Context, Result};
+use slog::Logger;
+use std::fs;
+use std::path::{Path, PathBuf};
+use std::time::Duration;
+use tokio::io::{AsyncReadExt, AsyncWriteExt};
+use tokio::net::UnixStream;
+use tokio::select;
+use tokio::sync::watch::Receiver;
+
+// name of the port added by the runtime for the serial transport
+const AGENT_PORT_NAME: &str = "agent.channel.0";
+
+pub const VIRTIO_PORTS_PATH: &str = "/sys/class/virtio-ports";
+
+// address of the ttrpc server when the port is relayed to it
+pub const SERVER_ADDR: &str = "unix:///run/kata-containers/agent-serial.sock";
+
+// A port reads EOF as long as the runtime is not connected to it.
+const RELAY_RETRY: Duration = Duration::from_millis(50);
+
+const RELAY_BUFFER_SIZE: usize = 64 * 1024;
+
+// find_port returns the device of the port added by the runtime for the
+// serial transport, if the VM has one.
+pub fn find_port(sysfs: &Path) -> Option<PathBuf> {
+    for entry in fs::read_dir(sysfs).ok()?.flatten() {
+        let name = fs::read_to_string(entry.path().join("name")).unwrap_or_default();
+        if name.trim() == AGENT_PORT_NAME {
+            return Some(Path::new("/dev").join(entry.file_name()));
+        }
+    }
+
+    None
+}
+
+// prepare_server_addr creates the directory of the unix socket the ttrpc
+// server listens on.
+pub fn prepare_server_addr() -> Result<()> {
+    let path = socket_path(SERVER_ADDR)?;
+    if let Some(dir) = path.parent() {
+        fs::create_dir_all(dir).context(format!("create {:?}", dir))?;
+    }
+    // A socket left by a previous agent would fail the bind.
+    let _ = fs::remove_file(path);
+
+    Ok(())
+}
+
+fn socket_path(addr: &str) -> Result<&Path> {
+    addr.strip_prefix("unix://")
+        .map(Path::new)
+        .ok_or_else(|| anyhow!("not a unix socket address: {}", addr))
+}
+
+// relay relays the connections of the runtime to the port to the ttrpc
+// server, until the agent shuts down.
+pub async fn relay(logger: Logger, port: PathBuf, mut shutdown: Receiver<bool>) -> Result<()> {
+    let logger = logger.new(o!("subsystem" => "serial", "port" => port.display().to_string()));
+    let server = socket_path(SERVER_ADDR)?;
+
+    info!(logger, "relaying the serial port to the ttrpc server");
+
+    loop {
+        select! {
+            _ = shutdown.changed() => {
+                info!(logger, "serial relay got shutdown request");
+                return Ok(());
+            }
+
+            res = relay_connection(&port, server) => {
+                match res {
+                    Ok(true) => debug!(logger, "runtime disconnected from the serial port"),
+                    Ok(false) => {}
+                    Err(e) => warn!(logger, "failed to relay the serial port: {:?}", e),
+                }
+            }
+        }
+
+        tokio::time::sleep(RELAY_RETRY).await;
+    }
+}
+
+// relay_connection relays one connection of the runtime to the port, and
+// tells if the runtime was connected.
+async fn relay_connection(port: &Path, server: &Path) -> Result<bool> {
+    let file = fs::OpenOptions::new()
+        .read(true)
+        .write(true)
+        .open(port)
+        .context(format!("open {:?}", port))?;
+
+    // A file of tokio does one operation at a time: the responses are
+    // written to the port while a read of the next request is pending.
+    let mut port_reader = tokio::fs::File::from_std(file.try_clone()?);
+    let mut port_writer = tokio::fs::File::from_std(file);
+
+    let mut buf = vec![0u8; RELAY_BUFFER_SIZE];
+    let n = port_reader.read(&mut buf).await?;
+    if n == 0 {
+        return Ok(false);
+    }
+
+    let conn = UnixStream::connect(server)
+        .await
+        .context(format!("connect to {:?}", server))?;
+    let (mut conn_reader, mut conn_writer) = conn.into_split();
+    conn_writer.write_all(&buf[..n]).await?;
+
+    // The connection to the ttrpc server is closed, along with the
+    // streams of the runtime, once the runtime disconnects.
+    select! {
+        res = tokio::io::copy(&mut port_reader, &mut conn_writer) => {
+            res.context("relay to the ttrpc server")?;
+        }
+        res = tokio::io::copy(&mut conn_reader, &mut port_writer) => {
+            res.context("relay to the runtime")?;
+        }
+    }
+
+    Ok(true)
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+    use tempfile::tempdir;
+
+    #[test]
+    fn test_find_port() {
+        let dir = tempdir().unwrap();
+
+        assert_eq!(find_port(dir.path()), None);
+        assert_eq!(find_port(&dir.path().join("none")), None);
+
+        for (port, name) in [("vport1p1", "agent.log"), ("vport1p2", "agent.channel.0")] {
+            fs::create_dir(dir.path().join(port)).unwrap();
+            fs::write(dir.path().join(port).join("name"), format!("{}\n", name)).unwrap();
+        }
+
+        assert_eq!(find_port(dir.path()), Some(PathBuf::from("/dev/vport1p2")));
+    }
+
+    #[test]
+    fn test_socket_path() {
+        assert_eq!(
+            socket_path(SERVER_ADDR).unwrap(),
+            Path::new("/run/kata-containers/agent-serial.sock")
+        );
+        assert!(socket_path("vsock://-1:1024").is_err());
+    }
+
+    #[tokio::test]
+    async fn test_relay_connection() {
+        let dir = tempdir().unwrap();
+        let server = dir.path().join("agent.sock");
+        let listener = tokio::net::UnixListener::bind(&server).unwrap();
+
+        // nothing to relay from a port the runtime is not connected to
+        let port = dir.path().join("port");
+        fs::write(&port, b"").unwrap();
+        assert!(!relay_connection(&port, &server).await.unwrap());
+
+        fs::write(&port, b"request").unwrap();
+        let (res, accepted) = tokio::join!(relay_connection(&port, &server), async {
+            let (mut conn, _) = listener.accept().await.unwrap();
+            let mut buf = vec![0u8; 7];
+            conn.read_exact(&mut buf).await.unwrap();
+            buf
+        });
+        assert!(res.unwrap());
+        assert_eq!(accepted, b"request");
+    }
+}
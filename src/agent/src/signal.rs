@@ -11,13 +11,31 @@ use nix::sys::wait::WaitPidFlag;
 use nix::sys::wait::{self, WaitStatus};
 use nix::unistd;
 use slog::{error, info, o, Logger};
+use std::collections::HashMap;
 use std::sync::Arc;
 use tokio::select;
 use tokio::signal::unix::{signal, SignalKind};
+use tokio::sync::oneshot;
 use tokio::sync::watch::Receiver;
 use tokio::sync::Mutex;
 use unistd::Pid;
 
+lazy_static! {
+    // The helper processes of the agent, which are not processes of the
+    // containers, waiting for their exit status from the reaper.
+    static ref HELPERS: std::sync::Mutex<HashMap<i32, oneshot::Sender<WaitStatus>>> =
+        std::sync::Mutex::new(HashMap::new());
+}
+
+// watch_helper returns the receiver of the exit status of a helper process
+// of the agent. The caller must hold WAIT_PID_LOCKER from the spawn of the
+// helper until the call, so that the reaper does not reap it before.
+pub fn watch_helper(pid: i32) -> oneshot::Receiver<WaitStatus> {
+    let (tx, rx) = oneshot::channel();
+    HELPERS.lock().unwrap().insert(pid, tx);
+    rx
+}
+
 async fn handle_sigchild(logger: Logger, sandbox: Arc<Mutex<Sandbox>>) -> Result<()> {
     info!(logger, "handling signal"; "signal" => "SIGCHLD");
 
@@ -48,6 +66,11 @@ async fn handle_sigchild(logger: Logger, sandbox: Arc<Mutex<Sandbox>>) -> Result
 
             let logger = logger.new(o!("child-pid" => child_pid));
 
+            if let Some(tx) = HELPERS.lock().unwrap().remove(&raw_pid) {
+                let _ = tx.send(wait_status);
+                continue;
+            }
+
             let sandbox_ref = sandbox.clone();
             let mut sandbox = sandbox_ref.lock().await;
 
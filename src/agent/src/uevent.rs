@@ -14,6 +14,7 @@ use netlink_sys::{protocols, SocketAddr, TokioSocket};
 use std::fmt::Debug;
 use std::os::unix::io::FromRawFd;
 use std::sync::Arc;
+use std::time::Duration;
 use tokio::select;
 use tokio::sync::watch::Receiver;
 use tokio::sync::Mutex;
@@ -115,6 +116,17 @@ impl Uevent {
 pub async fn wait_for_uevent(
     sandbox: &Arc<Mutex<Sandbox>>,
     matcher: impl UeventMatcher,
+) -> Result<Uevent> {
+    wait_for_uevent_timeout(sandbox, matcher, AGENT_CONFIG.hotplug_timeout).await
+}
+
+// wait_for_uevent_timeout is wait_for_uevent with the hotplug timeout given
+// by the caller, e.g. the one given by the runtime to acknowledge a device.
+#[instrument]
+pub async fn wait_for_uevent_timeout(
+    sandbox: &Arc<Mutex<Sandbox>>,
+    matcher: impl UeventMatcher,
+    hotplug_timeout: Duration,
 ) -> Result<Uevent> {
     let logprefix = format!("Waiting for {:?}", &matcher);
 
@@ -139,8 +151,6 @@ pub async fn wait_for_uevent(
 
     info!(sl(), "{}: waiting on channel", logprefix);
 
-    let hotplug_timeout = AGENT_CONFIG.hotplug_timeout;
-
     let uev = match tokio::time::timeout(hotplug_timeout, rx).await {
         Ok(v) => v?,
         Err(_) => {
@@ -29,6 +29,7 @@ use tracing::instrument;
 use kata_sys_util::hooks::HookStates;
 
 use super::{logger_with_process, Container};
+use crate::sandbox_persist;
 
 pub struct VirtContainerManager {
     sid: String,
@@ -106,8 +107,17 @@ impl ContainerManager for VirtContainerManager {
         }
 
         let mut containers = self.containers.write().await;
-        container.create(spec).await.context("create")?;
+        container.create(spec.clone()).await.context("create")?;
         containers.insert(container.container_id.to_string(), container);
+        sandbox_persist::record_container(
+            &self.sid,
+            sandbox_persist::ContainerState {
+                id: config.container_id.clone(),
+                bundle: config.bundle.clone(),
+                annotations: spec.annotations.clone(),
+            },
+        )
+        .context("record container")?;
         Ok(PID { pid: self.pid })
     }
 
@@ -132,6 +142,12 @@ impl ContainerManager for VirtContainerManager {
                 let c = containers
                     .remove(container_id)
                     .ok_or_else(|| Error::ContainerNotFound(container_id.to_string()))?;
+                if let Err(err) = sandbox_persist::forget_container(&self.sid, container_id) {
+                    warn!(
+                        sl!(),
+                        "failed to forget container {}: {:?}", container_id, err
+                    );
+                }
 
                 // Poststop Hooks:
                 // * should be run in runtime namespace
@@ -17,8 +17,12 @@ use common::{
     Sandbox, SandboxNetworkEnv,
 };
 use containerd_shim_protos::events::task::TaskOOM;
+#[cfg(feature = "cloud-hypervisor")]
+use hypervisor::ch::CloudHypervisor;
 use hypervisor::{dragonball::Dragonball, Hypervisor, HYPERVISOR_DRAGONBALL};
 use kata_sys_util::hooks::HookStates;
+#[cfg(feature = "cloud-hypervisor")]
+use kata_types::config::hypervisor::HYPERVISOR_NAME_CH;
 use kata_types::config::TomlConfig;
 use resource::{
     manager::ManagerArgs,
@@ -431,10 +435,16 @@ impl Persist for VirtSandbox {
 
     /// Save a state of Sandbox
     async fn save(&self) -> Result<Self::State> {
+        // The containers are recorded as they are created and deleted.
+        let containers = persist::from_disk::<Self::State>(&self.sid)
+            .map(|state| state.containers)
+            .unwrap_or_default();
         let sandbox_state = crate::sandbox_persist::SandboxState {
             sandbox_type: VIRTCONTAINER.to_string(),
+            schema_version: crate::sandbox_persist::SCHEMA_VERSION,
             resource: Some(self.resource_manager.save().await?),
             hypervisor: Some(self.hypervisor.save_state().await?),
+            containers,
         };
         persist::to_disk(&sandbox_state, &self.sid)?;
         Ok(sandbox_state)
@@ -444,12 +454,20 @@ impl Persist for VirtSandbox {
         sandbox_args: Self::ConstructorArgs,
         sandbox_state: Self::State,
     ) -> Result<Self> {
+        if sandbox_state.schema_version > crate::sandbox_persist::SCHEMA_VERSION {
+            return Err(anyhow!(
+                "Unsupported sandbox state schema version {}",
+                sandbox_state.schema_version
+            ));
+        }
         let config = sandbox_args.toml_config;
         let r = sandbox_state.resource.unwrap_or_default();
         let h = sandbox_state.hypervisor.unwrap_or_default();
         let hypervisor = match h.hypervisor_type.as_str() {
             // TODO support other hypervisors
             HYPERVISOR_DRAGONBALL => Ok(Arc::new(Dragonball::restore((), h).await?)),
+            #[cfg(feature = "cloud-hypervisor")]
+            HYPERVISOR_NAME_CH => Ok(Arc::new(CloudHypervisor::restore((), h).await?)),
             _ => Err(anyhow!("Unsupported hypervisor {}", &h.hypervisor_type)),
         }?;
         let agent = Arc::new(KataAgent::new(kata_types::config::Agent::default()));
@@ -4,13 +4,49 @@
 // SPDX-License-Identifier: Apache-2.0
 //
 
+use std::collections::HashMap;
+
+use anyhow::{Context, Result};
 use hypervisor::hypervisor_persist::HypervisorState;
 use resource::resource_persist::ResourceState;
 use serde::{Deserialize, Serialize};
 
+/// Version of the sandbox state shared with the Go runtime, which converts it
+/// with `kata-runtime convert-state`. It must be bumped along with the Go
+/// runtime `CompatSchemaVersion` whenever a change breaks the conversion.
+pub const SCHEMA_VERSION: u32 = 2;
+
 #[derive(Serialize, Deserialize)]
 pub struct SandboxState {
     pub sandbox_type: String,
+    #[serde(default)]
+    pub schema_version: u32,
     pub resource: Option<ResourceState>,
     pub hypervisor: Option<HypervisorState>,
+    /// The containers of the sandbox, which the Go runtime needs to adopt
+    /// it, as they are not restored by runtime-rs.
+    #[serde(default)]
+    pub containers: Vec<ContainerState>,
+}
+
+#[derive(Serialize, Deserialize, Default, Clone, Debug, PartialEq)]
+pub struct ContainerState {
+    pub id: String,
+    pub bundle: String,
+    pub annotations: HashMap<String, String>,
+}
+
+/// Records a container created in the sandbox in its persisted state.
+pub fn record_container(sid: &str, container: ContainerState) -> Result<()> {
+    let mut state: SandboxState = persist::from_disk(sid).context("load sandbox state")?;
+    state.containers.retain(|c| c.id != container.id);
+    state.containers.push(container);
+    persist::to_disk(&state, sid).context("save sandbox state")
+}
+
+/// Removes a deleted container from the persisted state of the sandbox.
+pub fn forget_container(sid: &str, cid: &str) -> Result<()> {
+    let mut state: SandboxState = persist::from_disk(sid).context("load sandbox state")?;
+    state.containers.retain(|c| c.id != cid);
+    persist::to_disk(&state, sid).context("save sandbox state")
 }
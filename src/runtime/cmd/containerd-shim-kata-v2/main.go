@@ -28,5 +28,14 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Take a running sandbox over from the shim of a previous version
+	if len(os.Args) == 3 && os.Args[1] == "--handover" {
+		if err := shim.Handover(os.Args[2]); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to take sandbox %s over: %v\n", os.Args[2], err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	shimapi.Run(types.DefaultKataRuntimeName, shim.New, shimConfig)
 }
@@ -8,21 +8,47 @@ package main
 import (
 	"flag"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
+	"path/filepath"
 	goruntime "runtime"
+	"strings"
+	"syscall"
 	"text/template"
 	"time"
 
 	kataMonitor "github.com/kata-containers/kata-containers/src/runtime/pkg/kata-monitor"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/kata-monitor/deviceplugin"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/kata-monitor/hostprobes"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/webhook"
 	"github.com/sirupsen/logrus"
 )
 
 const defaultListenAddress = "127.0.0.1:8090"
 
+const defaultDeviceClaimsSocket = "/run/kata-containers/kata-monitor-device-claims.sock"
+
 var monitorListenAddr = flag.String("listen-address", defaultListenAddress, "The address to listen on for HTTP requests.")
 var runtimeEndpoint = flag.String("runtime-endpoint", "/run/containerd/containerd.sock", "Endpoint of CRI container runtime service.")
 var logLevel = flag.String("log-level", "info", "Log level of logrus(trace/debug/info/warn/error/fatal/panic).")
+var hungShimDumpDir = flag.String("hung-shim-dump-dir", "", "Directory collecting the goroutine dumps of the shims whose metrics endpoint stops responding. The detection is disabled when empty.")
+var hungShimProbeInterval = flag.Duration("hung-shim-probe-interval", 30*time.Second, "Interval between two probes of the shims metrics endpoint.")
+var hungShimProbeTimeout = flag.Duration("hung-shim-probe-timeout", 10*time.Second, "Timeout of a probe of the shims metrics endpoint.")
+var hungShimFailureThreshold = flag.Int("hung-shim-failure-threshold", 3, "Number of consecutive failed probes after which a shim is considered hung.")
+var hungShimMaxDumps = flag.Int("hung-shim-max-dumps", 20, "Number of hung shim dumps kept, the oldest ones are removed first (0 keeps all of them).")
+var devicePlugin = flag.Bool("device-plugin", false, "Serve the vfio-pci bound devices of the node to the kubelet as kata.io/vfio-<class> resources.")
+var devicePluginDir = flag.String("device-plugin-dir", deviceplugin.DevicePluginPath, "Directory of the kubelet device plugin sockets.")
+var webhookListenAddr = flag.String("webhook-listen-address", "", "The address to serve the validating admission webhook of the Kata Containers annotations on, over HTTPS. The webhook is disabled when empty.")
+var webhookTLSCert = flag.String("webhook-tls-cert-file", "", "The TLS certificate of the validating admission webhook.")
+var webhookTLSKey = flag.String("webhook-tls-key-file", "", "The TLS private key of the validating admission webhook.")
+var webhookConfig = flag.String("webhook-config", "", "The Kata Containers configuration file the annotations are validated against, the default one when empty.")
+var webhookRuntimeClasses = flag.String("webhook-runtime-classes", "kata*", "Comma separated globs of the runtime classes of the pods whose annotations are validated.")
+var devicePluginRescanInterval = flag.Duration("device-plugin-rescan-interval", 30*time.Second, "Interval between two scans of the vfio-pci bound devices of the node.")
+var hostProbes = flag.Bool("host-probes", false, "Count the KVM exits, the vhost kicks and the vsock bytes of the sandboxes with eBPF probes of the host kernel.")
+var deviceClaimsSocket = flag.String("device-claims-socket", defaultDeviceClaimsSocket, "The unix socket, only accessible to root, to list, claim or release the IOMMU groups of the node on. The claims are disabled when empty.")
+var hostProbesRefreshInterval = flag.Duration("host-probes-refresh-interval", 10*time.Second, "Interval between two updates of the sandboxes counted by the host probes.")
 
 // These values are overridden via ldflags
 var (
@@ -96,9 +122,14 @@ func main() {
 		"git-commit": ver.GitCommit,
 
 		// properties from command-line options
-		"listen-address":   *monitorListenAddr,
-		"runtime-endpoint": *runtimeEndpoint,
-		"log-level":        *logLevel,
+		"listen-address":     *monitorListenAddr,
+		"runtime-endpoint":   *runtimeEndpoint,
+		"log-level":          *logLevel,
+		"hung-shim-dump-dir": *hungShimDumpDir,
+		"device-plugin":      *devicePlugin,
+		"webhook-address":    *webhookListenAddr,
+		"host-probes":        *hostProbes,
+		"device-claims":      *deviceClaimsSocket,
 	}
 
 	logrus.WithFields(announceFields).Info("announce")
@@ -109,6 +140,48 @@ func main() {
 		panic(err)
 	}
 
+	err = km.StartHungShimDetector(kataMonitor.HungShimConfig{
+		DumpDir:          *hungShimDumpDir,
+		ProbeInterval:    *hungShimProbeInterval,
+		ProbeTimeout:     *hungShimProbeTimeout,
+		FailureThreshold: *hungShimFailureThreshold,
+		MaxDumps:         *hungShimMaxDumps,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	err = km.StartHostProbes(kataMonitor.HostProbesConfig{
+		Enabled:         *hostProbes,
+		RefreshInterval: *hostProbesRefreshInterval,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	if *devicePlugin {
+		dpm, err := deviceplugin.NewManager(deviceplugin.Config{
+			PluginDir:      *devicePluginDir,
+			RescanInterval: *devicePluginRescanInterval,
+		})
+		if err != nil {
+			panic(err)
+		}
+		dpm.Start()
+	}
+
+	if *webhookListenAddr != "" {
+		if err := startWebhook(); err != nil {
+			panic(err)
+		}
+	}
+
+	if *deviceClaimsSocket != "" {
+		if err := startDeviceClaims(km); err != nil {
+			panic(err)
+		}
+	}
+
 	// setup handlers, currently only metrics are supported
 	m := http.NewServeMux()
 	endpoints = []endpoint{
@@ -122,11 +195,21 @@ func main() {
 			desc:    "List all Kata Containers sandboxes.",
 			handler: km.ListSandboxes,
 		},
+		{
+			path:    "/inventory",
+			desc:    "List the Kata Containers sandboxes with their details as JSON, filtered by `namespace` and `labelSelector`, streaming the changes with `watch=true`.",
+			handler: km.SandboxInventory,
+		},
 		{
 			path:    "/agent-url",
 			desc:    "Get sandbox agent URL.",
 			handler: km.GetAgentURL,
 		},
+		{
+			path:    "/volume-stats",
+			desc:    "Get the capacity and inode usage of the volumes of the `container` of a sandbox, as in the CSI NodeGetVolumeStats response.",
+			handler: km.GetVolumeStats,
+		},
 		{
 			path:    "/debug/vars",
 			desc:    "Golang pprof `/debug/vars` endpoint for kata runtime shim process.",
@@ -174,6 +257,69 @@ func main() {
 	logrus.Fatal(svr.ListenAndServe())
 }
 
+// startWebhook serves the validating admission webhook of the Kata
+// Containers annotations, checked against the configuration of the node.
+func startWebhook() error {
+	if *webhookTLSCert == "" || *webhookTLSKey == "" {
+		return fmt.Errorf("the webhook needs a TLS certificate and key")
+	}
+
+	configPath, runtimeConfig, err := katautils.LoadConfiguration(*webhookConfig, true)
+	if err != nil {
+		return err
+	}
+	logrus.WithField("config", configPath).Info("validating the annotations against the configuration")
+
+	m := http.NewServeMux()
+	m.Handle("/validate-annotations", &webhook.Validator{
+		Runtime:        runtimeConfig,
+		RuntimeClasses: strings.Split(*webhookRuntimeClasses, ","),
+	})
+	svr := &http.Server{
+		Handler: m,
+		Addr:    *webhookListenAddr,
+	}
+	go func() {
+		logrus.Fatal(svr.ListenAndServeTLS(*webhookTLSCert, *webhookTLSKey))
+	}()
+
+	return nil
+}
+
+// startDeviceClaims serves the claims of the IOMMU groups of the node on a
+// unix socket only accessible to root, as they decide which pod gets a device.
+func startDeviceClaims(km *kataMonitor.KataMonitor) error {
+	if err := os.MkdirAll(filepath.Dir(*deviceClaimsSocket), 0700); err != nil {
+		return err
+	}
+	if err := os.Remove(*deviceClaimsSocket); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	// no one but root may connect while the permissions are changed
+	oldMask := syscall.Umask(0077)
+	l, err := net.Listen("unix", *deviceClaimsSocket)
+	syscall.Umask(oldMask)
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(*deviceClaimsSocket, 0600); err != nil {
+		l.Close()
+		return err
+	}
+
+	m := http.NewServeMux()
+	m.Handle("/device-claims", http.HandlerFunc(km.DeviceClaims))
+	svr := &http.Server{
+		Handler: m,
+	}
+	go func() {
+		logrus.Fatal(svr.Serve(l))
+	}()
+
+	return nil
+}
+
 func indexPage(w http.ResponseWriter, r *http.Request) {
 	htmlResponse := kataMonitor.IfReturnHTMLResponse(w, r)
 	if htmlResponse {
@@ -239,4 +385,6 @@ func initLog() {
 	kataMonitorLog.Logger.Formatter = &logrus.TextFormatter{TimestampFormat: time.RFC3339Nano}
 
 	kataMonitor.SetLogger(kataMonitorLog)
+	deviceplugin.SetLogger(kataMonitorLog)
+	hostprobes.SetLogger(kataMonitorLog)
 }
@@ -160,10 +160,11 @@ var initFactoryCommand = cli.Command{
 		}
 
 		factoryConfig := vf.Config{
-			Template:     runtimeConfig.FactoryConfig.Template,
-			TemplatePath: runtimeConfig.FactoryConfig.TemplatePath,
-			Cache:        runtimeConfig.FactoryConfig.VMCacheNumber,
-			VMCache:      runtimeConfig.FactoryConfig.VMCacheNumber > 0,
+			Template:         runtimeConfig.FactoryConfig.Template,
+			TemplatePrefetch: runtimeConfig.FactoryConfig.TemplatePrefetch,
+			TemplatePath:     runtimeConfig.FactoryConfig.TemplatePath,
+			Cache:            runtimeConfig.FactoryConfig.VMCacheNumber,
+			VMCache:          runtimeConfig.FactoryConfig.VMCacheNumber > 0,
 			VMConfig: vc.VMConfig{
 				HypervisorType:   runtimeConfig.HypervisorType,
 				HypervisorConfig: runtimeConfig.HypervisorConfig,
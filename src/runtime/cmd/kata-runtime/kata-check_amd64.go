@@ -8,6 +8,8 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"syscall"
 	"unsafe"
@@ -47,6 +49,12 @@ const (
 
 const acrnDevice = "/dev/acrn_hsm"
 
+// sgxVEPCDevice is used by the hypervisor to allocate EPC sections to VMs.
+const sgxVEPCDevice = "/dev/sgx_vepc"
+
+// sgxTotalBytesGlob matches the per NUMA node EPC sizes reported by the kernel.
+var sgxTotalBytesGlob = "/sys/devices/system/node/node*/x86/sgx_total_bytes"
+
 // ioctl_ACRN_CREATE_VM is the IOCTL to create VM in ACRN.
 // Current Linux mainstream kernel doesn't have support for ACRN.
 // Due to this several macros are not defined in Linux headers.
@@ -329,7 +337,64 @@ func archHostCanCreateVMContainer(hypervisorType vc.HypervisorType) error {
 // hostIsVMContainerCapable checks to see if the host is theoretically capable
 // of creating a VM container.
 func hostIsVMContainerCapable(details vmContainerCapableDetails) error {
-	return genericHostIsVMContainerCapable(details)
+	if err := genericHostIsVMContainerCapable(details); err != nil {
+		return err
+	}
+
+	checkSGXEPC()
+
+	return nil
+}
+
+// getSGXEPCSize returns the total size in bytes of the host EPC.
+func getSGXEPCSize() (uint64, error) {
+	files, err := filepath.Glob(sgxTotalBytesGlob)
+	if err != nil {
+		return 0, err
+	}
+
+	var total uint64
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return 0, err
+		}
+
+		size, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		total += size
+	}
+
+	return total, nil
+}
+
+// checkSGXEPC reports whether EPC sections can be given to VMs, which SGX
+// workloads (sgx.intel.com/epc annotation) depend on. SGX is optional so
+// this never fails the check.
+func checkSGXEPC() {
+	fields := logrus.Fields{"feature": "sgx-epc"}
+
+	size, err := getSGXEPCSize()
+	if err != nil {
+		kataLog.WithFields(fields).WithError(err).Warn("cannot determine SGX EPC size")
+		return
+	}
+
+	if size == 0 {
+		kataLog.WithFields(fields).Info("feature not available")
+		return
+	}
+	fields["size"] = size
+
+	if _, err := os.Stat(sgxVEPCDevice); err != nil {
+		fields["device"] = sgxVEPCDevice
+		kataLog.WithFields(fields).WithError(err).Warn("SGX EPC present but cannot be given to VMs")
+		return
+	}
+
+	kataLog.WithFields(fields).Info("feature available")
 }
 
 func archKernelParamHandler(onVMM bool, fields logrus.Fields, msg string) bool {
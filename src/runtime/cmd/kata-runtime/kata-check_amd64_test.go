@@ -413,6 +413,33 @@ func TestKvmIsUsable(t *testing.T) {
 	assert.Error(err)
 }
 
+func TestGetSGXEPCSize(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	savedGlob := sgxTotalBytesGlob
+	sgxTotalBytesGlob = filepath.Join(dir, "node*", "x86", "sgx_total_bytes")
+	defer func() { sgxTotalBytesGlob = savedGlob }()
+
+	size, err := getSGXEPCSize()
+	assert.NoError(err)
+	assert.Zero(size)
+
+	for node, data := range []string{"67108864\n", "33554432\n"} {
+		nodeDir := filepath.Join(dir, fmt.Sprintf("node%d", node), "x86")
+		assert.NoError(os.MkdirAll(nodeDir, 0755))
+		assert.NoError(os.WriteFile(filepath.Join(nodeDir, "sgx_total_bytes"), []byte(data), 0644))
+	}
+
+	size, err = getSGXEPCSize()
+	assert.NoError(err)
+	assert.Equal(uint64(96<<20), size)
+
+	assert.NoError(os.WriteFile(filepath.Join(dir, "node0", "x86", "sgx_total_bytes"), []byte("invalid"), 0644))
+	_, err = getSGXEPCSize()
+	assert.Error(err)
+}
+
 func TestGetCPUDetails(t *testing.T) {
 	const validVendorName = "a vendor"
 	validVendor := fmt.Sprintf(`%s  : %s`, archCPUVendorField, validVendorName)
@@ -0,0 +1,63 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupCheckHostIsVMContainerCapable(assert *assert.Assertions, cpuInfoFile string, cpuData []testCPUData, moduleData []testModuleData) {
+	// The ISA of the test cpuinfo has the hypervisor extension, only the
+	// modules are checked
+	_ = cpuData
+
+	createModules(assert, cpuInfoFile, moduleData)
+
+	err := makeCPUInfoFile(cpuInfoFile, "", "")
+	assert.NoError(err)
+}
+
+func TestCCCheckCLIFunction(t *testing.T) {
+	var cpuData []testCPUData
+	moduleData := []testModuleData{
+		{filepath.Join(sysModuleDir, "kvm"), "", true},
+		{filepath.Join(sysModuleDir, "vhost"), "", true},
+		{filepath.Join(sysModuleDir, "vhost_net"), "", true},
+	}
+
+	genericCheckCLIFunction(t, cpuData, moduleData)
+}
+
+func TestHasHypervisorExtension(t *testing.T) {
+	assert := assert.New(t)
+
+	data := []struct {
+		isa      string
+		expected bool
+	}{
+		{"", false},
+		{"rv64imafdch", true},
+		{"rv64imafdch_zicsr_zifencei", true},
+		{"RV64IMAFDCH_ZICSR", true},
+		{"rv32imafdch", true},
+		{"rv64imafdc", false},
+		{"rv64imafdc_zicsr_zihpm", false},
+		{"rv64imafdc_xtheadvector_zhinx", false},
+		{"x86_64", false},
+	}
+
+	for _, d := range data {
+		assert.Equal(d.expected, hasHypervisorExtension(d.isa), fmt.Sprintf("%+v", d))
+	}
+}
+
+func TestSetCPUtype(t *testing.T) {
+	testSetCPUTypeGeneric(t)
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist"
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"github.com/urfave/cli"
+)
+
+const (
+	// rsStateDir is where runtime-rs persists the state of its sandboxes.
+	rsStateDir  = "/run/kata"
+	rsStateFile = "state.json"
+
+	convertToRust = "rust"
+	convertToGo   = "go"
+)
+
+var kataConvertStateCLICommand = cli.Command{
+	Name:      "convert-state",
+	Usage:     "convert the persisted state of a sandbox between the Go runtime and runtime-rs",
+	UsageText: "convert-state --to <rust|go> <sandbox-id>",
+	Description: `The sandbox state persisted by one runtime is converted to the state
+   persisted by the other one, so that the sandboxes created before a rolling
+   upgrade between both runtimes can still be managed and cleaned up. The
+   state of the source runtime is left untouched.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "to",
+			Usage: "the runtime to convert the state to, rust or go",
+		},
+	},
+	Action: func(context *cli.Context) error {
+		id := context.Args().First()
+		if id == "" {
+			return fmt.Errorf("missing sandbox id")
+		}
+
+		driver, err := persist.GetDriver()
+		if err != nil {
+			return err
+		}
+
+		c := &stateConverter{
+			driver:     driver,
+			rsStateDir: rsStateDir,
+		}
+
+		switch to := context.String("to"); to {
+		case convertToRust:
+			return c.toRust(id)
+		case convertToGo:
+			return c.toGo(id)
+		default:
+			return fmt.Errorf("invalid runtime %q, expected %s or %s", to, convertToRust, convertToGo)
+		}
+	},
+}
+
+// stateConverter converts the sandbox states between the Go runtime persist
+// driver and the runtime-rs state files.
+type stateConverter struct {
+	driver     persistapi.PersistDriver
+	rsStateDir string
+}
+
+func (c *stateConverter) rsStatePath(id string) string {
+	return filepath.Join(c.rsStateDir, id, rsStateFile)
+}
+
+func (c *stateConverter) toRust(id string) error {
+	path := c.rsStatePath(id)
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("runtime-rs state of sandbox %s already exists", id)
+	}
+
+	ss, containers, err := c.driver.FromDisk(id)
+	if err != nil {
+		return fmt.Errorf("failed to load the state of sandbox %s: %v", id, err)
+	}
+
+	cs, err := persist.ToCompatState(ss, containers, c.driver.RunVMStoragePath())
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(cs)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0640)
+}
+
+func (c *stateConverter) toGo(id string) error {
+	if _, _, err := c.driver.FromDisk(id); err == nil {
+		return fmt.Errorf("state of sandbox %s already exists", id)
+	}
+
+	data, err := os.ReadFile(c.rsStatePath(id))
+	if err != nil {
+		return fmt.Errorf("failed to load the runtime-rs state of sandbox %s: %v", id, err)
+	}
+
+	var cs persistapi.CompatSandboxState
+	if err := json.Unmarshal(data, &cs); err != nil {
+		return err
+	}
+
+	ss, containers, err := persist.FromCompatState(cs, id)
+	if err != nil {
+		return err
+	}
+
+	return c.driver.ToDisk(ss, containers)
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	hv "github.com/kata-containers/kata-containers/src/runtime/pkg/hypervisors"
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"github.com/stretchr/testify/assert"
+)
+
+// statesDriver is a persist driver keeping the sandbox states in memory.
+type statesDriver struct {
+	persistapi.PersistDriver
+	states     map[string]persistapi.SandboxState
+	containers map[string]map[string]persistapi.ContainerState
+}
+
+func (d *statesDriver) FromDisk(sid string) (persistapi.SandboxState, map[string]persistapi.ContainerState, error) {
+	ss, ok := d.states[sid]
+	if !ok {
+		return ss, nil, os.ErrNotExist
+	}
+	return ss, d.containers[sid], nil
+}
+
+func (d *statesDriver) ToDisk(ss persistapi.SandboxState, cs map[string]persistapi.ContainerState) error {
+	d.states[ss.SandboxContainer] = ss
+	d.containers[ss.SandboxContainer] = cs
+	return nil
+}
+
+func (d *statesDriver) RunVMStoragePath() string {
+	return "/run/vc/vm"
+}
+
+func TestConvertState(t *testing.T) {
+	assert := assert.New(t)
+
+	id := "6fcf0a90b01e90d8747177aa466c3462d02e02a878bc393649df83d4c314af0c"
+	ss := persistapi.SandboxState{
+		SandboxContainer:   id,
+		SandboxCgroupPath:  "/kata_" + id,
+		OverheadCgroupPath: "/kata_overhead/" + id,
+		HypervisorState: hv.HypervisorState{
+			Type:              "clh",
+			UUID:              "5b0ae0fc-8e94-4a3a-b9d4-3c5dc3a2b1c6",
+			APISocket:         "/run/vc/vm/" + id + "/clh-api.sock",
+			Pid:               1000,
+			VirtiofsDaemonPid: 1001,
+		},
+	}
+	ss.Network.NetworkID = "/var/run/netns/cni-1234"
+	ss.Config.HypervisorConfig.HypervisorPath = "/usr/bin/cloud-hypervisor"
+	bundle := "/run/containerd/io.containerd.runtime.v2.task/k8s.io/"
+	ss.Config.ContainerConfigs = []persistapi.ContainerConfig{
+		{ID: id, Annotations: map[string]string{
+			"io.kubernetes.cri.container-type":      "sandbox",
+			"io.katacontainers.pkg.oci.bundle_path": bundle + id,
+		}},
+		{ID: "c1", Annotations: map[string]string{
+			"io.kubernetes.cri.container-type":      "container",
+			"io.katacontainers.pkg.oci.bundle_path": bundle + "c1",
+		}},
+	}
+	cs := map[string]persistapi.ContainerState{
+		id:   {State: "running", BundlePath: bundle + id},
+		"c1": {State: "running", BundlePath: bundle + "c1"},
+	}
+
+	driver := &statesDriver{
+		states:     map[string]persistapi.SandboxState{id: ss},
+		containers: map[string]map[string]persistapi.ContainerState{id: cs},
+	}
+	c := &stateConverter{
+		driver:     driver,
+		rsStateDir: t.TempDir(),
+	}
+
+	assert.Error(c.toGo(id))
+	assert.NoError(c.toRust(id))
+	assert.Error(c.toRust(id))
+
+	data, err := os.ReadFile(filepath.Join(c.rsStateDir, id, rsStateFile))
+	assert.NoError(err)
+
+	var state map[string]interface{}
+	assert.NoError(json.Unmarshal(data, &state))
+	assert.Equal("virt_container", state["sandbox_type"])
+	hypervisor := state["hypervisor"].(map[string]interface{})
+	assert.Equal("cloud-hypervisor", hypervisor["hypervisor_type"])
+	assert.Equal(float64(1000), hypervisor["pid"])
+	assert.Equal("/var/run/netns/cni-1234", hypervisor["netns"])
+	assert.Len(state["containers"], 2)
+
+	delete(driver.states, id)
+	assert.NoError(c.toGo(id))
+	converted := driver.states[id]
+	assert.Equal(ss.SandboxCgroupPath, converted.SandboxCgroupPath)
+	assert.Equal(ss.OverheadCgroupPath, converted.OverheadCgroupPath)
+	assert.Equal(ss.HypervisorState.Type, converted.HypervisorState.Type)
+	assert.Equal(ss.HypervisorState.UUID, converted.HypervisorState.UUID)
+	assert.Equal(ss.HypervisorState.APISocket, converted.HypervisorState.APISocket)
+	assert.Equal(ss.HypervisorState.Pid, converted.HypervisorState.Pid)
+	assert.Equal(ss.HypervisorState.VirtiofsDaemonPid, converted.HypervisorState.VirtiofsDaemonPid)
+	assert.Equal(ss.Network.NetworkID, converted.Network.NetworkID)
+	assert.Equal(ss.Config.HypervisorConfig.HypervisorPath, converted.Config.HypervisorConfig.HypervisorPath)
+	assert.Equal(ss.Config.ContainerConfigs, converted.Config.ContainerConfigs)
+	assert.Equal(cs, driver.containers[id])
+
+	// runtime-rs records the bundle of the containers out of their
+	// annotations
+	containers := state["containers"].([]interface{})
+	assert.Equal(bundle+"c1", containers[1].(map[string]interface{})["bundle"])
+	containers[1] = map[string]interface{}{"id": "c1", "bundle": bundle + "c1", "annotations": map[string]string{}}
+	data, err = json.Marshal(state)
+	assert.NoError(err)
+	assert.NoError(os.WriteFile(filepath.Join(c.rsStateDir, id, rsStateFile), data, 0600))
+	delete(driver.states, id)
+	assert.NoError(c.toGo(id))
+	assert.Equal(bundle+"c1", driver.states[id].Config.ContainerConfigs[1].Annotations["io.katacontainers.pkg.oci.bundle_path"])
+
+	// states written by a newer runtime-rs, or by an older one which did
+	// not record the containers, are rejected
+	state["schema_version"] = 3
+	data, err = json.Marshal(state)
+	assert.NoError(err)
+	assert.NoError(os.WriteFile(filepath.Join(c.rsStateDir, id, rsStateFile), data, 0600))
+	delete(driver.states, id)
+	assert.Error(c.toGo(id))
+	state["schema_version"] = 1
+	data, err = json.Marshal(state)
+	assert.NoError(err)
+	assert.NoError(os.WriteFile(filepath.Join(c.rsStateDir, id, rsStateFile), data, 0600))
+	assert.Error(c.toGo(id))
+
+	// hypervisors runtime-rs cannot adopt are rejected
+	for _, hypervisorType := range []string{"qemu", "firecracker", "acrn"} {
+		ss.HypervisorState.Type = hypervisorType
+		driver.states["other"] = ss
+		assert.Error(c.toRust("other"), hypervisorType)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/featureflags"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/oci"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/utils"
@@ -30,7 +31,7 @@ import (
 //
 // XXX: Increment for every change to the output format
 // (meaning any change to the EnvInfo type).
-const formatVersion = "1.0.26"
+const formatVersion = "1.0.30"
 
 // MetaInfo stores information on the format of the output itself
 type MetaInfo struct {
@@ -78,11 +79,15 @@ type RuntimeInfo struct {
 	Config              RuntimeConfigInfo
 	Path                string
 	GuestSeLinuxLabel   string
+	GuestSeccompMode    string
 	Experimental        []exp.Feature
+	FeatureFlags        []featureflags.State
 	Version             RuntimeVersionInfo
 	Debug               bool
 	Trace               bool
 	DisableGuestSeccomp bool
+	GuestSeccompReport  bool
+	EnableCoreDumps     bool
 	DisableNewNetNs     bool
 	SandboxCgroupOnly   bool
 }
@@ -111,10 +116,13 @@ type HypervisorInfo struct {
 	SharedFS             string
 	VirtioFSDaemon       string
 	SocketPath           string
+	ValidExtraArgs       []string
+	ValidExtraAPIFields  []string
 	Msize9p              uint32
 	MemorySlots          uint32
 	HotPlugVFIO          config.PCIePort
 	ColdPlugVFIO         config.PCIePort
+	PCIHotplugMode       string
 	HotplugVFIOOnRootBus bool
 	Debug                bool
 }
@@ -188,7 +196,11 @@ func getRuntimeInfo(configFile string, config oci.RuntimeConfig) RuntimeInfo {
 		DisableNewNetNs:     config.DisableNewNetNs,
 		SandboxCgroupOnly:   config.SandboxCgroupOnly,
 		Experimental:        config.Experimental,
+		FeatureFlags:        featureflags.List(),
 		DisableGuestSeccomp: config.DisableGuestSeccomp,
+		GuestSeccompMode:    config.GuestSeccompMode,
+		GuestSeccompReport:  config.GuestSeccompReport,
+		EnableCoreDumps:     config.CoreDump.Enabled,
 		GuestSeLinuxLabel:   config.GuestSeLinuxLabel,
 	}
 }
@@ -319,8 +331,11 @@ func getHypervisorInfo(config oci.RuntimeConfig) (HypervisorInfo, error) {
 		VirtioFSDaemon:       config.HypervisorConfig.VirtioFSDaemon,
 		HotPlugVFIO:          config.HypervisorConfig.HotPlugVFIO,
 		ColdPlugVFIO:         config.HypervisorConfig.ColdPlugVFIO,
+		PCIHotplugMode:       vc.PCIHotplugMode(&config.HypervisorConfig),
 		HotplugVFIOOnRootBus: config.HypervisorConfig.HotplugVFIOOnRootBus,
 		SocketPath:           socketPath,
+		ValidExtraArgs:       config.HypervisorConfig.ExtraArgsList,
+		ValidExtraAPIFields:  config.HypervisorConfig.ExtraAPIFieldsList,
 	}, nil
 }
 
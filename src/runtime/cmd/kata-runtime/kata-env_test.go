@@ -25,6 +25,7 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/featureflags"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/katatestutils"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/oci"
@@ -280,6 +281,7 @@ func getExpectedHypervisor(config oci.RuntimeConfig) HypervisorInfo {
 		HotplugVFIOOnRootBus: config.HypervisorConfig.HotplugVFIOOnRootBus,
 		HotPlugVFIO:          config.HypervisorConfig.HotPlugVFIO,
 		ColdPlugVFIO:         config.HypervisorConfig.ColdPlugVFIO,
+		PCIHotplugMode:       vc.PCIHotplugMode(&config.HypervisorConfig),
 	}
 
 	if os.Geteuid() == 0 {
@@ -323,6 +325,7 @@ func getExpectedRuntimeDetails(config oci.RuntimeConfig, configFile string) Runt
 		Debug:           config.Debug,
 		Trace:           config.Trace,
 		DisableNewNetNs: config.DisableNewNetNs,
+		FeatureFlags:    featureflags.List(),
 	}
 }
 
@@ -0,0 +1,109 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	cdshim "github.com/containerd/containerd/runtime/v2/shim"
+	containerdshim "github.com/kata-containers/kata-containers/src/runtime/pkg/containerd-shim-v2"
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	"github.com/urfave/cli"
+)
+
+const (
+	defaultGCGracePeriod = 10 * time.Minute
+	shimProbeTimeout     = 5 * time.Second
+)
+
+var kataGCCLICommand = cli.Command{
+	Name:  "gc",
+	Usage: "clean up the host resources left behind by crashed shims",
+	Description: `The directories, network interfaces, loop devices and VFIO devices
+   of the sandboxes whose shim is not running anymore are released. Sandboxes
+   whose directories were modified during the grace period are left alone.`,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "only list the resources that would be released",
+		},
+		cli.DurationFlag{
+			Name:  "grace-period",
+			Value: defaultGCGracePeriod,
+			Usage: "do not collect the sandboxes modified more recently than this",
+		},
+		cli.DurationFlag{
+			Name:  "interval",
+			Usage: "collect periodically at this interval instead of once",
+		},
+	},
+	Action: func(context *cli.Context) error {
+		config := vc.OrphanGCConfig{
+			IsSandboxLive: isShimRunning,
+			GracePeriod:   context.Duration("grace-period"),
+		}
+		dryRun := context.Bool("dry-run")
+
+		interval := context.Duration("interval")
+		if interval < 0 {
+			return fmt.Errorf("invalid interval %v", interval)
+		}
+		if interval == 0 {
+			return collectOrphans(os.Stdout, config, dryRun)
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := collectOrphans(os.Stdout, config, dryRun); err != nil {
+				kataLog.WithError(err).Error("failed to collect orphan resources")
+			}
+			<-ticker.C
+		}
+	},
+}
+
+// isShimRunning tells whether the shim of the sandbox accepts connections on
+// its monitoring socket. A hung shim is still considered running.
+func isShimRunning(sandboxID string) bool {
+	conn, err := cdshim.AnonDialer(containerdshim.ClientSocketAddress(sandboxID), shimProbeTimeout)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+// collectOrphans releases the orphan resources, or only lists them in dry
+// run mode. All the resources are tried, the first error is returned.
+func collectOrphans(w io.Writer, config vc.OrphanGCConfig, dryRun bool) error {
+	resources, err := vc.FindOrphanResources(config)
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+	for _, r := range resources {
+		if dryRun {
+			fmt.Fprintf(w, "would remove %s\n", r)
+			continue
+		}
+
+		if err := r.Remove(); err != nil {
+			kataLog.WithError(err).WithField("resource", r.String()).Warn("failed to remove orphan resource")
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to remove %s: %w", r, err)
+			}
+			continue
+		}
+		fmt.Fprintf(w, "removed %s\n", r)
+	}
+
+	return firstErr
+}
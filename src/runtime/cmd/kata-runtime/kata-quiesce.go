@@ -0,0 +1,76 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"encoding/json"
+	"time"
+
+	containerdshim "github.com/kata-containers/kata-containers/src/runtime/pkg/containerd-shim-v2"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/utils/shimclient"
+	"github.com/urfave/cli"
+)
+
+// quiesceRequestTimeout bounds the quiesce requests, freezing the
+// filesystems writes their dirty pages back first.
+const quiesceRequestTimeout = 60 * time.Second
+
+var quiesceTimeout uint
+
+var kataQuiesceCommand = cli.Command{
+	Name:  "quiesce",
+	Usage: "freeze the filesystems of the containers of a sandbox and flush its drives, to snapshot its volumes",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:        "sandbox-id",
+			Usage:       "the target sandbox",
+			Required:    true,
+			Destination: &sandboxID,
+		},
+		cli.UintFlag{
+			Name:        "timeout",
+			Usage:       "seconds after which the sandbox is unquiesced, 60 when 0",
+			Destination: &quiesceTimeout,
+		},
+	},
+	Action: func(c *cli.Context) error {
+		// verify sandbox exists:
+		if err := katautils.VerifyContainerID(sandboxID); err != nil {
+			return err
+		}
+
+		body, err := json.Marshal(containerdshim.QuiesceRequest{
+			Timeout: uint32(quiesceTimeout),
+		})
+		if err != nil {
+			return err
+		}
+
+		return shimclient.DoPut(sandboxID, quiesceRequestTimeout, containerdshim.QuiesceUrl, "application/json", body)
+	},
+}
+
+var kataUnquiesceCommand = cli.Command{
+	Name:  "unquiesce",
+	Usage: "thaw the filesystems of the containers of a quiesced sandbox",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:        "sandbox-id",
+			Usage:       "the target sandbox",
+			Required:    true,
+			Destination: &sandboxID,
+		},
+	},
+	Action: func(c *cli.Context) error {
+		// verify sandbox exists:
+		if err := katautils.VerifyContainerID(sandboxID); err != nil {
+			return err
+		}
+
+		return shimclient.DoPut(sandboxID, defaultTimeout, containerdshim.UnquiesceUrl, "application/json", nil)
+	},
+}
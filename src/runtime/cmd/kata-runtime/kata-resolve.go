@@ -0,0 +1,192 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	ctrAnnotations "github.com/containerd/containerd/pkg/cri/annotations"
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist"
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"github.com/urfave/cli"
+)
+
+var kataResolveCLICommand = cli.Command{
+	Name:      "resolve",
+	Usage:     "find the sandbox a host tap interface, socket or process belongs to",
+	UsageText: "resolve <tap interface|socket path|pid>",
+	Action: func(context *cli.Context) error {
+		resource := context.Args().First()
+		if resource == "" {
+			return fmt.Errorf("missing tap interface, socket path or pid")
+		}
+
+		driver, err := persist.GetDriver()
+		if err != nil {
+			return err
+		}
+
+		r := &sandboxResolver{
+			storagePaths: []string{driver.RunStoragePath(), driver.RunVMStoragePath()},
+			loadState: func(id string) (persistapi.SandboxState, error) {
+				ss, _, err := driver.FromDisk(id)
+				return ss, err
+			},
+			procDir: "/proc",
+		}
+
+		sandboxes, err := r.resolve(resource)
+		if err != nil {
+			return err
+		}
+		if len(sandboxes) == 0 {
+			return fmt.Errorf("no sandbox found for %s", resource)
+		}
+
+		for _, id := range sandboxes {
+			fmt.Println(r.describe(id))
+		}
+
+		return nil
+	},
+}
+
+// sandboxResolver finds the sandboxes host resources belong to, from their
+// name and the sandboxes persisted state.
+type sandboxResolver struct {
+	loadState    func(id string) (persistapi.SandboxState, error)
+	procDir      string
+	storagePaths []string
+}
+
+func (r *sandboxResolver) sandboxes() ([]string, error) {
+	entries, err := os.ReadDir(r.storagePaths[0])
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var ids []string
+	for _, e := range entries {
+		if e.IsDir() {
+			ids = append(ids, e.Name())
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (r *sandboxResolver) resolve(resource string) ([]string, error) {
+	if pid, err := strconv.Atoi(resource); err == nil {
+		return r.resolvePid(pid)
+	}
+
+	if strings.Contains(resource, "/") {
+		path, err := filepath.Abs(resource)
+		if err != nil {
+			return nil, err
+		}
+		if id, ok := vc.SandboxIDFromPath(path, r.storagePaths...); ok {
+			return []string{id}, nil
+		}
+		return nil, nil
+	}
+
+	shortID, ok := vc.NetInterfaceSandboxShortID(resource)
+	if !ok {
+		return nil, fmt.Errorf("%s is neither a Kata Containers tap interface, a socket path nor a pid", resource)
+	}
+
+	ids, err := r.sandboxes()
+	if err != nil {
+		return nil, err
+	}
+
+	var sandboxes []string
+	for _, id := range ids {
+		if vc.SandboxShortID(id) == shortID {
+			sandboxes = append(sandboxes, id)
+		}
+	}
+	return sandboxes, nil
+}
+
+// resolvePid looks for the sandbox the process or one of its ancestors is
+// the hypervisor or a helper daemon of.
+func (r *sandboxResolver) resolvePid(pid int) ([]string, error) {
+	ids, err := r.sandboxes()
+	if err != nil {
+		return nil, err
+	}
+
+	pids := make(map[int]string)
+	for _, id := range ids {
+		ss, err := r.loadState(id)
+		if err != nil {
+			continue
+		}
+		for _, p := range []int{ss.HypervisorState.Pid, ss.HypervisorState.VirtiofsDaemonPid, ss.HypervisorState.VirtioGPUDaemonPid, ss.HypervisorState.SwtpmPid} {
+			if p > 0 {
+				pids[p] = id
+			}
+		}
+	}
+
+	for pid > 1 {
+		if id, ok := pids[pid]; ok {
+			return []string{id}, nil
+		}
+		if pid, err = r.parentPid(pid); err != nil {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *sandboxResolver) parentPid(pid int) (int, error) {
+	data, err := os.ReadFile(filepath.Join(r.procDir, strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return 0, err
+	}
+
+	// The command name may contain spaces and parentheses
+	stat := string(data)
+	fields := strings.Fields(stat[strings.LastIndex(stat, ")")+1:])
+	if len(fields) < 2 {
+		return 0, fmt.Errorf("invalid stat of process %d", pid)
+	}
+	return strconv.Atoi(fields[1])
+}
+
+// describe returns the sandbox ID, along with its pod when it is known.
+func (r *sandboxResolver) describe(id string) string {
+	ss, err := r.loadState(id)
+	if err != nil {
+		return id
+	}
+
+	for _, c := range ss.Config.ContainerConfigs {
+		if c.ID != id {
+			continue
+		}
+		name := c.Annotations[ctrAnnotations.SandboxName]
+		namespace := c.Annotations[ctrAnnotations.SandboxNamespace]
+		if name != "" {
+			return fmt.Sprintf("%s pod=%s/%s", id, namespace, name)
+		}
+	}
+
+	return id
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ctrAnnotations "github.com/containerd/containerd/pkg/cri/annotations"
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSandboxResolver(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	sbs := filepath.Join(dir, "sbs")
+	vm := filepath.Join(dir, "vm")
+	proc := filepath.Join(dir, "proc")
+
+	sandboxID := "6fcf0a90b01e90d8747177aa466c3462d02e02a878bc393649df83d4c314af0c"
+	otherID := "df96b24bd49ec437c872c1a758edc084121d607ce1242ff5d2263a0e1b693343"
+	for _, id := range []string{sandboxID, otherID} {
+		assert.NoError(os.MkdirAll(filepath.Join(sbs, id), 0700))
+	}
+
+	// the hypervisor 100 runs the process 102 through 101
+	for pid, ppid := range map[int]int{100: 1, 101: 100, 102: 101, 200: 1} {
+		assert.NoError(os.MkdirAll(filepath.Join(proc, fmt.Sprint(pid)), 0700))
+		stat := fmt.Sprintf("%d (qemu (x86) ) S %d 1 1 0", pid, ppid)
+		assert.NoError(os.WriteFile(filepath.Join(proc, fmt.Sprint(pid), "stat"), []byte(stat), 0600))
+	}
+
+	r := &sandboxResolver{
+		storagePaths: []string{sbs, vm},
+		procDir:      proc,
+		loadState: func(id string) (persistapi.SandboxState, error) {
+			ss := persistapi.SandboxState{}
+			if id == sandboxID {
+				ss.HypervisorState.Pid = 100
+				ss.Config.ContainerConfigs = []persistapi.ContainerConfig{{
+					ID: id,
+					Annotations: map[string]string{
+						ctrAnnotations.SandboxName:      "web",
+						ctrAnnotations.SandboxNamespace: "default",
+					},
+				}}
+			}
+			return ss, nil
+		},
+	}
+
+	data := []struct {
+		resource  string
+		sandboxes []string
+		valid     bool
+	}{
+		{"tap0_6fcf0a90", []string{sandboxID}, true},
+		{"br1_df96b24b", []string{otherID}, true},
+		{"tap0_00000000", nil, true},
+		{filepath.Join(vm, sandboxID, "qmp.sock"), []string{sandboxID}, true},
+		{"/run/containerd/containerd.sock", nil, true},
+		{"100", []string{sandboxID}, true},
+		{"102", []string{sandboxID}, true},
+		{"200", nil, true},
+		{"300", nil, true},
+		{"eth0", nil, false},
+	}
+
+	for _, d := range data {
+		sandboxes, err := r.resolve(d.resource)
+		if !d.valid {
+			assert.Error(err, "%+v", d)
+			continue
+		}
+		assert.NoError(err, "%+v", d)
+		assert.Equal(d.sandboxes, sandboxes, "%+v", d)
+	}
+
+	assert.Equal(sandboxID+" pod=default/web", r.describe(sandboxID))
+	assert.Equal(otherID, r.describe(otherID))
+}
@@ -0,0 +1,514 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/oci"
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/urfave/cli"
+	"github.com/vishvananda/netlink"
+)
+
+const (
+	defaultSelfTestTimeout = 2 * time.Minute
+
+	// selfTestPayloadPath is where the payload is copied in the rootfs of
+	// the self-test container.
+	selfTestPayloadPath = "/bin/payload"
+
+	// selfTestExitCode is the exit code the exec check expects back.
+	selfTestExitCode = 3
+
+	// selfTestInterface is the veth added to the network namespace of the
+	// sandbox, which the network check expects in the guest.
+	selfTestInterface = "eth0"
+
+	// selfTestAddress is the address of selfTestInterface, from the
+	// documentation range.
+	selfTestAddress = "192.0.2.1/24"
+)
+
+var kataSelfTestCLICommand = cli.Command{
+	Name:  "self-test",
+	Usage: "boot a throwaway sandbox and check it runs workloads",
+	Description: `A sandbox is created with the current configuration, a built-in payload
+   is run in its container, and the agent IO, exec and metrics paths are
+   checked. The sandbox is removed afterwards. The command fails when any
+   check fails, so that it can be used as a node readiness gate.`,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "network",
+			Usage: "create a network namespace with a veth for the sandbox and check the guest gets it",
+		},
+		cli.StringFlag{
+			Name:  "payload",
+			Value: katautils.DEFAULTSELFTESTPAYLOAD,
+			Usage: "static binary run in the sandbox",
+		},
+		cli.DurationFlag{
+			Name:  "timeout",
+			Value: defaultSelfTestTimeout,
+			Usage: "time after which a check is failed",
+		},
+		cli.BoolFlag{
+			Name:  "json",
+			Usage: "print the report in JSON format",
+		},
+	},
+	Action: func(context *cli.Context) error {
+		if os.Geteuid() != 0 {
+			return errors.New("self-test must be run as root")
+		}
+
+		ctx, err := cliContextToContext(context)
+		if err != nil {
+			return err
+		}
+
+		runtimeConfig, ok := context.App.Metadata["runtimeConfig"].(oci.RuntimeConfig)
+		if !ok {
+			return errors.New("self-test: cannot determine runtime config")
+		}
+
+		timeout := context.Duration("timeout")
+		if timeout <= 0 {
+			return fmt.Errorf("invalid timeout %v", timeout)
+		}
+
+		st := &selfTest{
+			ctx:     ctx,
+			id:      fmt.Sprintf("kata-self-test-%d", os.Getpid()),
+			payload: context.String("payload"),
+			network: context.Bool("network"),
+			timeout: timeout,
+		}
+		report := st.run(runtimeConfig)
+
+		if context.Bool("json") {
+			if err := json.NewEncoder(defaultOutputFile).Encode(report); err != nil {
+				return err
+			}
+		} else {
+			report.write(defaultOutputFile)
+		}
+
+		if !report.Passed {
+			return errors.New("self-test failed")
+		}
+		return nil
+	},
+}
+
+// selfTestCheck is the outcome of a self-test check.
+type selfTestCheck struct {
+	Name     string
+	Passed   bool
+	Skipped  bool   `json:",omitempty"`
+	Duration string `json:",omitempty"`
+	Error    string `json:",omitempty"`
+}
+
+type selfTestReport struct {
+	SandboxID string
+	Passed    bool
+	Checks    []selfTestCheck
+}
+
+func (r *selfTestReport) write(w io.Writer) {
+	for _, c := range r.Checks {
+		switch {
+		case c.Skipped:
+			fmt.Fprintf(w, "SKIP  %s\n", c.Name)
+		case c.Passed:
+			fmt.Fprintf(w, "PASS  %s (%s)\n", c.Name, c.Duration)
+		default:
+			fmt.Fprintf(w, "FAIL  %s (%s): %s\n", c.Name, c.Duration, c.Error)
+		}
+	}
+
+	if r.Passed {
+		fmt.Fprintln(w, "self-test passed")
+	} else {
+		fmt.Fprintln(w, "self-test failed")
+	}
+}
+
+type selfTest struct {
+	ctx     context.Context
+	sandbox vc.VCSandbox
+	netns   ns.NetNS
+	report  selfTestReport
+	id      string
+	bundle  string
+	payload string
+	timeout time.Duration
+	network bool
+}
+
+// check runs a check within the timeout and records its outcome, it tells
+// whether the check passed. The check is skipped when skip is set.
+func (st *selfTest) check(name string, skip bool, fn func() error) bool {
+	if skip {
+		st.report.Checks = append(st.report.Checks, selfTestCheck{Name: name, Skipped: true})
+		return false
+	}
+
+	start := time.Now()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- fn()
+	}()
+
+	var err error
+	select {
+	case err = <-errCh:
+	case <-time.After(st.timeout):
+		err = fmt.Errorf("timed out after %v", st.timeout)
+	}
+
+	c := selfTestCheck{
+		Name:     name,
+		Passed:   err == nil,
+		Duration: time.Since(start).Round(time.Millisecond).String(),
+	}
+	if err != nil {
+		c.Error = err.Error()
+		st.report.Passed = false
+		kataLog.WithError(err).WithField("check", name).Error("self-test check failed")
+	}
+	st.report.Checks = append(st.report.Checks, c)
+
+	return c.Passed
+}
+
+// run creates the sandbox, runs the checks against it, then removes it.
+func (st *selfTest) run(runtimeConfig oci.RuntimeConfig) selfTestReport {
+	st.report = selfTestReport{
+		SandboxID: st.id,
+		Passed:    true,
+	}
+
+	created := st.check("create", false, func() error {
+		return st.create(runtimeConfig)
+	})
+	started := st.check("start", !created, func() error {
+		return st.sandbox.Start(st.ctx)
+	})
+	st.check("io", !started, st.checkIO)
+	st.check("exec", !started, st.checkExec)
+	st.check("metrics", !started, st.checkMetrics)
+	st.check("network", !started || !st.network, st.checkNetwork)
+	st.check("cleanup", st.bundle == "", st.cleanup)
+
+	return st.report
+}
+
+// selfTestSpec returns the spec of the self-test container, its process
+// idles so that the payload can be exec'ed next to it.
+func selfTestSpec(id, rootfs string) specs.Spec {
+	return specs.Spec{
+		Version: specs.Version,
+		Root: &specs.Root{
+			Path: rootfs,
+		},
+		Hostname: id,
+		Process: &specs.Process{
+			Args: []string{selfTestPayloadPath, "sleep"},
+			Env:  []string{"PATH=/bin"},
+			Cwd:  "/",
+		},
+		Mounts: []specs.Mount{
+			{
+				Destination: "/proc",
+				Type:        "proc",
+				Source:      "proc",
+				Options:     []string{"nosuid", "noexec", "nodev"},
+			},
+			{
+				Destination: "/dev",
+				Type:        "tmpfs",
+				Source:      "tmpfs",
+				Options:     []string{"nosuid", "strictatime", "mode=755", "size=65536k"},
+			},
+			{
+				Destination: "/sys",
+				Type:        "sysfs",
+				Source:      "sysfs",
+				Options:     []string{"nosuid", "noexec", "nodev", "ro"},
+			},
+		},
+		Linux: &specs.Linux{
+			Namespaces: []specs.LinuxNamespace{
+				{Type: specs.PIDNamespace},
+				{Type: specs.IPCNamespace},
+				{Type: specs.UTSNamespace},
+				{Type: specs.MountNamespace},
+				{Type: specs.NetworkNamespace},
+			},
+		},
+	}
+}
+
+// create lays the bundle out, the payload being the only file of the
+// rootfs, and creates the sandbox.
+func (st *selfTest) create(runtimeConfig oci.RuntimeConfig) error {
+	if _, err := os.Stat(st.payload); err != nil {
+		return fmt.Errorf("payload not found: %v", err)
+	}
+
+	bundle, err := os.MkdirTemp("", st.id+"-")
+	if err != nil {
+		return err
+	}
+	st.bundle = bundle
+
+	rootfs := filepath.Join(bundle, "rootfs")
+	for _, dir := range []string{"bin", "tmp"} {
+		if err := os.MkdirAll(filepath.Join(rootfs, dir), 0755); err != nil {
+			return err
+		}
+	}
+	if err := utils.FileCopy(st.payload, filepath.Join(rootfs, selfTestPayloadPath)); err != nil {
+		return fmt.Errorf("failed to copy the payload: %v", err)
+	}
+
+	spec := selfTestSpec(st.id, rootfs)
+	runtimeConfig.DisableNewNetNs = !st.network
+	if st.network {
+		netns, err := newSelfTestNetNS()
+		if err != nil {
+			return err
+		}
+		st.netns = netns
+
+		for i := range spec.Linux.Namespaces {
+			if spec.Linux.Namespaces[i].Type == specs.NetworkNamespace {
+				spec.Linux.Namespaces[i].Path = netns.Path()
+			}
+		}
+	}
+
+	sandbox, _, err := katautils.CreateSandbox(st.ctx, vci, spec, runtimeConfig,
+		vc.RootFs{Mounted: true}, st.id, bundle, false, false)
+	if err != nil {
+		return err
+	}
+	st.sandbox = sandbox
+
+	return nil
+}
+
+// newSelfTestNetNS creates the network namespace of the sandbox with a
+// configured veth, which the runtime hands over to the guest. The peer of
+// the veth is left unconfigured, so that the runtime skips it.
+func newSelfTestNetNS() (ns.NetNS, error) {
+	addr, err := netlink.ParseAddr(selfTestAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	netns, err := testutils.NewNS()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the network namespace: %v", err)
+	}
+
+	err = netns.Do(func(ns.NetNS) error {
+		veth := &netlink.Veth{
+			LinkAttrs: netlink.LinkAttrs{Name: selfTestInterface},
+			PeerName:  selfTestInterface + "-peer",
+		}
+		if err := netlink.LinkAdd(veth); err != nil {
+			return err
+		}
+		link, err := netlink.LinkByName(selfTestInterface)
+		if err != nil {
+			return err
+		}
+		if err := netlink.AddrAdd(link, addr); err != nil {
+			return err
+		}
+		return netlink.LinkSetUp(link)
+	})
+	if err != nil {
+		netns.Close()
+		testutils.UnmountNS(netns)
+		return nil, fmt.Errorf("failed to add the veth to the network namespace: %v", err)
+	}
+
+	return netns, nil
+}
+
+// exec runs an applet of the payload in the container.
+func (st *selfTest) exec(args ...string) (*vc.Process, error) {
+	_, process, err := st.sandbox.EnterContainer(st.ctx, st.id, types.Cmd{
+		Args:         append([]string{selfTestPayloadPath}, args...),
+		Envs:         []types.EnvVar{{Var: "PATH", Value: "/bin"}},
+		User:         "0",
+		PrimaryGroup: "0",
+		WorkDir:      "/",
+		Detach:       true,
+	})
+	return process, err
+}
+
+func (st *selfTest) wait(process *vc.Process, expected int32) error {
+	code, err := st.sandbox.WaitProcess(st.ctx, st.id, process.Token)
+	if err != nil {
+		return err
+	}
+	if code != expected {
+		return fmt.Errorf("process exited with %d, expecting %d", code, expected)
+	}
+	return nil
+}
+
+// checkIO echoes a token through the standard input and output of a
+// process.
+func (st *selfTest) checkIO() error {
+	process, err := st.exec("cat")
+	if err != nil {
+		return err
+	}
+
+	stdin, stdout, _, err := st.sandbox.IOStream(st.id, process.Token)
+	if err != nil {
+		return err
+	}
+
+	token := st.id
+	if _, err := stdin.Write([]byte(token)); err != nil {
+		return fmt.Errorf("failed to write stdin: %v", err)
+	}
+	if err := stdin.Close(); err != nil {
+		return fmt.Errorf("failed to close stdin: %v", err)
+	}
+
+	out := make([]byte, len(token))
+	if _, err := io.ReadFull(stdout, out); err != nil {
+		return fmt.Errorf("failed to read stdout: %v", err)
+	}
+	if string(out) != token {
+		return fmt.Errorf("read %q back from stdout, expecting %q", out, token)
+	}
+
+	return st.wait(process, 0)
+}
+
+// checkExec checks the container environment and the exit codes of the
+// processes.
+func (st *selfTest) checkExec() error {
+	process, err := st.exec("check")
+	if err != nil {
+		return err
+	}
+	if err := st.wait(process, 0); err != nil {
+		return fmt.Errorf("environment check failed: %v", err)
+	}
+
+	process, err = st.exec("exit", fmt.Sprint(selfTestExitCode))
+	if err != nil {
+		return err
+	}
+	return st.wait(process, selfTestExitCode)
+}
+
+// checkMetrics gathers the metrics the shim exports for the sandbox.
+func (st *selfTest) checkMetrics() error {
+	if err := st.sandbox.UpdateRuntimeMetrics(); err != nil {
+		return fmt.Errorf("failed to update the runtime metrics: %v", err)
+	}
+
+	metrics, err := st.sandbox.GetAgentMetrics(st.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the agent metrics: %v", err)
+	}
+	if !strings.Contains(metrics, "kata_agent_") {
+		return errors.New("no agent metrics returned")
+	}
+
+	stats, err := st.sandbox.StatsContainer(st.ctx, st.id)
+	if err != nil {
+		return fmt.Errorf("failed to get the container stats: %v", err)
+	}
+	if stats.CgroupStats == nil {
+		return errors.New("no container cgroup stats returned")
+	}
+
+	return nil
+}
+
+// checkNetwork lists the guest interfaces, the veth of the network
+// namespace is expected with its address.
+func (st *selfTest) checkNetwork() error {
+	interfaces, err := st.sandbox.ListInterfaces(st.ctx)
+	if err != nil {
+		return err
+	}
+
+	addr, err := netlink.ParseAddr(selfTestAddress)
+	if err != nil {
+		return err
+	}
+	for _, i := range interfaces {
+		if i.Name != selfTestInterface {
+			continue
+		}
+		for _, a := range i.IPAddresses {
+			if a.Address == addr.IP.String() {
+				return nil
+			}
+		}
+		return fmt.Errorf("interface %s has not the address %s in the guest", selfTestInterface, selfTestAddress)
+	}
+	return fmt.Errorf("no interface %s in the guest", selfTestInterface)
+}
+
+// cleanup stops and deletes the sandbox, and removes its network namespace
+// and the bundle. All the steps are attempted, the first error is returned.
+func (st *selfTest) cleanup() error {
+	var errs []error
+	if st.sandbox != nil {
+		if err := st.sandbox.Stop(st.ctx, true); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop the sandbox: %v", err))
+		}
+		if err := st.sandbox.Delete(st.ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete the sandbox: %v", err))
+		}
+	}
+	if st.netns != nil {
+		st.netns.Close()
+		if err := testutils.UnmountNS(st.netns); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := os.RemoveAll(st.bundle); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	for _, err := range errs[1:] {
+		kataLog.WithError(err).Error("self-test cleanup failed")
+	}
+	return errs[0]
+}
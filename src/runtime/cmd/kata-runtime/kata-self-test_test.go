@@ -0,0 +1,205 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	ktu "github.com/kata-containers/kata-containers/src/runtime/pkg/katatestutils"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/oci"
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	pbTypes "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/vcmock"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/vishvananda/netlink"
+)
+
+const testSelfTestID = "kata-self-test-1"
+
+// selfTestSandbox runs the payload applets in process.
+type selfTestSandbox struct {
+	*vcmock.Sandbox
+
+	mu        sync.Mutex
+	processes map[string]*selfTestProcess
+}
+
+type selfTestProcess struct {
+	stdin  *io.PipeWriter
+	stdout *io.PipeReader
+	exit   chan int32
+}
+
+func (s *selfTestSandbox) EnterContainer(ctx context.Context, containerID string, cmd types.Cmd) (vc.VCContainer, *vc.Process, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token := strconv.Itoa(len(s.processes))
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	p := &selfTestProcess{stdin: inW, stdout: outR, exit: make(chan int32, 1)}
+	s.processes[token] = p
+
+	go func() {
+		var code int32
+		switch cmd.Args[1] {
+		case "cat":
+			io.Copy(outW, inR)
+		case "exit":
+			c, _ := strconv.Atoi(cmd.Args[2])
+			code = int32(c)
+		}
+		outW.Close()
+		p.exit <- code
+	}()
+
+	return &vcmock.Container{}, &vc.Process{Token: token}, nil
+}
+
+func (s *selfTestSandbox) IOStream(containerID, processID string) (io.WriteCloser, io.Reader, io.Reader, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.processes[processID]
+	return p.stdin, p.stdout, &bytes.Buffer{}, nil
+}
+
+func (s *selfTestSandbox) WaitProcess(ctx context.Context, containerID, processID string) (int32, error) {
+	s.mu.Lock()
+	p := s.processes[processID]
+	s.mu.Unlock()
+
+	return <-p.exit, nil
+}
+
+func newSelfTest(sandbox vc.VCSandbox) *selfTest {
+	return &selfTest{
+		ctx:     context.Background(),
+		id:      testSelfTestID,
+		sandbox: sandbox,
+		timeout: time.Second,
+		report:  selfTestReport{Passed: true},
+	}
+}
+
+func TestSelfTestChecks(t *testing.T) {
+	assert := assert.New(t)
+
+	sandbox := &selfTestSandbox{
+		Sandbox:   &vcmock.Sandbox{MockID: testSelfTestID},
+		processes: make(map[string]*selfTestProcess),
+	}
+	st := newSelfTest(sandbox)
+
+	assert.NoError(st.checkIO())
+	assert.NoError(st.checkExec())
+
+	// The mock returns neither agent metrics nor stats.
+	assert.Error(st.checkMetrics())
+	sandbox.GetAgentMetricsFunc = func() (string, error) {
+		return "kata_agent_process_cpu_seconds_total 1\n", nil
+	}
+	assert.Error(st.checkMetrics())
+	sandbox.StatsContainerFunc = func(contID string) (vc.ContainerStats, error) {
+		return vc.ContainerStats{CgroupStats: &vc.CgroupStats{}}, nil
+	}
+	assert.NoError(st.checkMetrics())
+
+	// The loopback alone does not tell the network namespace was used.
+	interfaces := []*pbTypes.Interface{{Name: "lo", IPAddresses: []*pbTypes.IPAddress{{Address: "127.0.0.1"}}}}
+	sandbox.ListInterfacesFunc = func() ([]*pbTypes.Interface, error) {
+		return interfaces, nil
+	}
+	assert.Error(st.checkNetwork())
+	interfaces = append(interfaces, &pbTypes.Interface{Name: selfTestInterface})
+	assert.Error(st.checkNetwork())
+	interfaces[1].IPAddresses = []*pbTypes.IPAddress{{Address: "192.0.2.1", Mask: "24"}}
+	assert.NoError(st.checkNetwork())
+}
+
+func TestSelfTestNetNS(t *testing.T) {
+	if tc.NotValid(ktu.NeedRoot()) {
+		t.Skip(testDisabledAsNonRoot)
+	}
+	assert := assert.New(t)
+
+	netns, err := newSelfTestNetNS()
+	assert.NoError(err)
+	defer func() {
+		netns.Close()
+		assert.NoError(testutils.UnmountNS(netns))
+	}()
+
+	err = netns.Do(func(ns.NetNS) error {
+		link, err := netlink.LinkByName(selfTestInterface)
+		if err != nil {
+			return err
+		}
+		addrs, err := netlink.AddrList(link, netlink.FAMILY_V4)
+		if err != nil {
+			return err
+		}
+		assert.Len(addrs, 1)
+		assert.Equal(selfTestAddress, addrs[0].IPNet.String())
+		return nil
+	})
+	assert.NoError(err)
+}
+
+func TestSelfTestCheck(t *testing.T) {
+	assert := assert.New(t)
+
+	st := newSelfTest(nil)
+	st.timeout = 10 * time.Millisecond
+
+	assert.True(st.check("pass", false, func() error { return nil }))
+	assert.True(st.report.Passed)
+	assert.False(st.check("skip", true, func() error { return nil }))
+	assert.True(st.report.Passed)
+	assert.False(st.check("fail", false, func() error { return errors.New("failed") }))
+	assert.False(st.report.Passed)
+	assert.False(st.check("hang", false, func() error {
+		time.Sleep(time.Second)
+		return nil
+	}))
+
+	assert.Len(st.report.Checks, 4)
+	assert.True(st.report.Checks[1].Skipped)
+	assert.Equal("failed", st.report.Checks[2].Error)
+	assert.Contains(st.report.Checks[3].Error, "timed out")
+
+	var out bytes.Buffer
+	st.report.write(&out)
+	assert.Contains(out.String(), "PASS  pass")
+	assert.Contains(out.String(), "SKIP  skip")
+	assert.Contains(out.String(), "FAIL  fail")
+	assert.Contains(out.String(), "self-test failed")
+}
+
+func TestSelfTestCreateMissingPayload(t *testing.T) {
+	assert := assert.New(t)
+
+	st := newSelfTest(nil)
+	st.payload = filepath.Join(t.TempDir(), "payload")
+
+	report := st.run(oci.RuntimeConfig{})
+	assert.False(report.Passed)
+	for _, c := range report.Checks[1:] {
+		assert.True(c.Skipped, c.Name)
+	}
+	assert.Contains(report.Checks[0].Error, "payload not found")
+}
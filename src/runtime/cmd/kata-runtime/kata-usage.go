@@ -0,0 +1,66 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	containerdshim "github.com/kata-containers/kata-containers/src/runtime/pkg/containerd-shim-v2"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/oci"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/usagespool"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/utils/shimclient"
+	"github.com/urfave/cli"
+)
+
+var kataUsageCLICommand = cli.Command{
+	Name:      "usage",
+	Usage:     "report the resource usage of the sandboxes",
+	UsageText: "usage [--spool-dir <dir>] [<sandbox id>]",
+	Description: `The usage of a running sandbox is read from its shim. Without a sandbox
+   ID, the usage persisted in the spool directory is listed, including the
+   one of the sandboxes that are gone.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "spool-dir",
+			Usage: "the usage spool directory, usage_spool_dir of the configuration by default",
+		},
+	},
+	Action: func(context *cli.Context) error {
+		if sandboxID := context.Args().First(); sandboxID != "" {
+			if err := katautils.VerifyContainerID(sandboxID); err != nil {
+				return err
+			}
+
+			usage, err := shimclient.DoGet(sandboxID, defaultTimeout, containerdshim.UsageUrl)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(defaultOutputFile, string(usage))
+			return nil
+		}
+
+		dir := context.String("spool-dir")
+		if dir == "" {
+			runtimeConfig, ok := context.App.Metadata["runtimeConfig"].(oci.RuntimeConfig)
+			if !ok {
+				return errors.New("usage: cannot determine runtime config")
+			}
+			dir = runtimeConfig.UsageSpoolDir
+		}
+		if dir == "" {
+			return errors.New("usage: no spool directory configured")
+		}
+
+		snapshots, err := usagespool.List(dir)
+		if err != nil {
+			return err
+		}
+		return json.NewEncoder(defaultOutputFile).Encode(snapshots)
+	},
+}
@@ -9,10 +9,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
 
 	containerdshim "github.com/kata-containers/kata-containers/src/runtime/pkg/containerd-shim-v2"
 	volume "github.com/kata-containers/kata-containers/src/runtime/pkg/direct-volume"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/utils/shimclient"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist"
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
 
 	"github.com/urfave/cli"
 )
@@ -82,7 +87,7 @@ var removeCommand = cli.Command{
 
 var statsCommand = cli.Command{
 	Name:  "stats",
-	Usage: "get the filesystem stat of a direct assigned volume",
+	Usage: "get the filesystem stat of a direct assigned volume, or of a volume shared with a sandbox",
 	Flags: []cli.Flag{
 		cli.StringFlag{
 			Name:        "volume-path",
@@ -125,8 +130,14 @@ var resizeCommand = cli.Command{
 }
 
 // Stats retrieves the filesystem stats of the direct volume inside the guest.
+// Volumes which are not directly assigned, e.g. the ones a CSI driver published
+// to volumePath and kubelet mounts in the containers, are looked up in the
+// containers mounts of the sandboxes sharing them with the guest.
 func Stats(volumePath string) ([]byte, error) {
 	sandboxId, err := volume.GetSandboxIdForVolume(volumePath)
+	if os.IsNotExist(err) {
+		return sharedVolumeStats(volumePath)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -144,6 +155,62 @@ func Stats(volumePath string) ([]byte, error) {
 	return body, nil
 }
 
+func sharedVolumeStats(volumePath string) ([]byte, error) {
+	driver, err := persist.GetDriver()
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := filepath.Abs(volumePath)
+	if err != nil {
+		return nil, err
+	}
+
+	sandboxId, err := findVolumeSandbox(driver.RunStoragePath(), func(id string) (map[string]persistapi.ContainerState, error) {
+		_, cs, err := driver.FromDisk(id)
+		return cs, err
+	}, source)
+	if err != nil {
+		return nil, err
+	}
+
+	return shimclient.DoGet(sandboxId, defaultTimeout,
+		fmt.Sprintf("%s?%s=%s", containerdshim.VolumeStatsUrl, containerdshim.VolumeSourceKey, url.QueryEscape(source)))
+}
+
+// findVolumeSandbox returns the sandbox, of the ones persisted in storagePath,
+// with a container mounting source.
+func findVolumeSandbox(storagePath string, loadContainers func(id string) (map[string]persistapi.ContainerState, error), source string) (string, error) {
+	entries, err := os.ReadDir(storagePath)
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+
+	var ids []string
+	for _, e := range entries {
+		if e.IsDir() {
+			ids = append(ids, e.Name())
+		}
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		cs, err := loadContainers(id)
+		if err != nil {
+			continue
+		}
+		for _, c := range cs {
+			for _, m := range c.Mounts {
+				if m.Source == source {
+					return id, nil
+				}
+			}
+		}
+	}
+
+	return "", fmt.Errorf("no sandbox found for %s", source)
+}
+
 // Resize resizes a direct volume inside the guest.
 func Resize(volumePath string, size uint64) error {
 	sandboxId, err := volume.GetSandboxIdForVolume(volumePath)
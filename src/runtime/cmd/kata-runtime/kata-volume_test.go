@@ -0,0 +1,53 @@
+// Copyright (c) 2022 Databricks Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindVolumeSandbox(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	sandboxID := "6fcf0a90b01e90d8747177aa466c3462d02e02a878bc393649df83d4c314af0c"
+	otherID := "df96b24bd49ec437c872c1a758edc084121d607ce1242ff5d2263a0e1b693343"
+	brokenID := "0b1d7e7a5bbd3b0cb3c1fd7f3e4f4b3a9e3bd7d1b62a1e2e6a9e1f4b5c6d7e8f"
+	for _, id := range []string{sandboxID, otherID, brokenID} {
+		assert.NoError(os.MkdirAll(filepath.Join(dir, id), 0700))
+	}
+
+	source := "/var/lib/kubelet/pods/uid/volumes/kubernetes.io~csi/pvc/mount"
+	loadContainers := func(id string) (map[string]persistapi.ContainerState, error) {
+		switch id {
+		case sandboxID:
+			return map[string]persistapi.ContainerState{
+				"app": {Mounts: []persistapi.Mount{{Source: source, Destination: "/data"}}},
+			}, nil
+		case otherID:
+			return map[string]persistapi.ContainerState{
+				"app": {Mounts: []persistapi.Mount{{Source: "/etc/hosts", Destination: "/etc/hosts"}}},
+			}, nil
+		}
+		return nil, fmt.Errorf("sandbox %s not found", id)
+	}
+
+	id, err := findVolumeSandbox(dir, loadContainers, source)
+	assert.NoError(err)
+	assert.Equal(sandboxID, id)
+
+	_, err = findVolumeSandbox(dir, loadContainers, "/var/lib/kubelet/pods/uid/volumes/kubernetes.io~csi/other/mount")
+	assert.Error(err)
+
+	_, err = findVolumeSandbox(filepath.Join(dir, "missing"), loadContainers, source)
+	assert.Error(err)
+}
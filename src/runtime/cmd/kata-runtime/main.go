@@ -126,6 +126,13 @@ var runtimeCommands = []cli.Command{
 	factoryCLICommand,
 	kataVolumeCommand,
 	kataIPTablesCommand,
+	kataQuiesceCommand,
+	kataUnquiesceCommand,
+	kataResolveCLICommand,
+	kataGCCLICommand,
+	kataSelfTestCLICommand,
+	kataUsageCLICommand,
+	kataConvertStateCLICommand,
 }
 
 // runtimeBeforeSubcommands is the function to run before command-line
@@ -0,0 +1,133 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// kata-self-test-payload is the workload run by "kata-runtime self-test" in
+// its throwaway sandbox. It is built statically so that it can run as the
+// only file of the container rootfs, and gathers a few applets, picked like
+// busybox does from the name it is called with or its first argument.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type applet func(args []string, stdin io.Reader, stdout, stderr io.Writer) int
+
+var applets = map[string]applet{
+	"cat":   catApplet,
+	"check": checkApplet,
+	"echo":  echoApplet,
+	"exit":  exitApplet,
+	"sleep": sleepApplet,
+}
+
+// procRoot is a variable so that the tests can override it.
+var procRoot = "/proc"
+
+// catApplet copies its standard input to its standard output.
+func catApplet(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if _, err := io.Copy(stdout, stdin); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	return 0
+}
+
+// checkApplet verifies the container environment set up by the agent:
+// /proc is mounted and the root filesystem is writable.
+func checkApplet(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	status := 0
+	if _, err := os.Stat(filepath.Join(procRoot, "self", "status")); err != nil {
+		fmt.Fprintf(stderr, "proc not mounted: %v\n", err)
+		status = 1
+	}
+
+	dir := os.TempDir()
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	f, err := os.CreateTemp(dir, "self-test-")
+	if err != nil {
+		fmt.Fprintf(stderr, "rootfs not writable: %v\n", err)
+		return 1
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	const data = "kata"
+	if _, err := f.WriteString(data); err != nil {
+		fmt.Fprintf(stderr, "rootfs not writable: %v\n", err)
+		return 1
+	}
+	if b, err := os.ReadFile(f.Name()); err != nil || string(b) != data {
+		fmt.Fprintf(stderr, "rootfs reads back %q, %v\n", b, err)
+		status = 1
+	}
+
+	return status
+}
+
+func echoApplet(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fmt.Fprintln(stdout, strings.Join(args, " "))
+	return 0
+}
+
+func exitApplet(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		return 0
+	}
+	code, err := strconv.ParseUint(args[0], 10, 8)
+	if err != nil {
+		fmt.Fprintf(stderr, "invalid exit code %q\n", args[0])
+		return 2
+	}
+	return int(code)
+}
+
+// sleepApplet sleeps for the given number of seconds, forever without one.
+func sleepApplet(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		select {}
+	}
+	seconds, err := strconv.ParseFloat(args[0], 64)
+	if err != nil || seconds < 0 {
+		fmt.Fprintf(stderr, "invalid duration %q\n", args[0])
+		return 2
+	}
+	time.Sleep(time.Duration(seconds * float64(time.Second)))
+	return 0
+}
+
+// run selects the applet from the program name, then from the first
+// argument.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if a, ok := applets[filepath.Base(args[0])]; ok {
+		return a(args[1:], stdin, stdout, stderr)
+	}
+	if len(args) > 1 {
+		if a, ok := applets[args[1]]; ok {
+			return a(args[2:], stdin, stdout, stderr)
+		}
+	}
+
+	names := make([]string, 0, len(applets))
+	for name := range applets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintf(stderr, "usage: %s <applet> [args...], applets: %s\n", filepath.Base(args[0]), strings.Join(names, ", "))
+	return 2
+}
+
+func main() {
+	os.Exit(run(os.Args, os.Stdin, os.Stdout, os.Stderr))
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	assert := assert.New(t)
+
+	data := []struct {
+		args   []string
+		stdin  string
+		stdout string
+		status int
+	}{
+		{[]string{"/bin/echo", "hello", "kata"}, "", "hello kata\n", 0},
+		{[]string{"/bin/payload", "echo", "hello"}, "", "hello\n", 0},
+		{[]string{"/bin/payload", "cat"}, "token", "token", 0},
+		{[]string{"/bin/payload", "exit", "3"}, "", "", 3},
+		{[]string{"/bin/payload", "exit", "three"}, "", "", 2},
+		{[]string{"/bin/payload", "sleep", "0"}, "", "", 0},
+		{[]string{"/bin/payload", "sleep", "-1"}, "", "", 2},
+		{[]string{"/bin/payload", "unknown"}, "", "", 2},
+		{[]string{"/bin/payload"}, "", "", 2},
+	}
+
+	for _, d := range data {
+		var stdout, stderr bytes.Buffer
+		status := run(d.args, strings.NewReader(d.stdin), &stdout, &stderr)
+		assert.Equal(d.status, status, d.args)
+		assert.Equal(d.stdout, stdout.String(), d.args)
+	}
+}
+
+func TestCheckApplet(t *testing.T) {
+	assert := assert.New(t)
+
+	var stdout, stderr bytes.Buffer
+	assert.Equal(0, checkApplet([]string{t.TempDir()}, nil, &stdout, &stderr), stderr.String())
+
+	savedProcRoot := procRoot
+	defer func() { procRoot = savedProcRoot }()
+	procRoot = t.TempDir()
+	stderr.Reset()
+	assert.Equal(1, checkApplet([]string{t.TempDir()}, nil, &stdout, &stderr))
+	assert.Contains(stderr.String(), "proc not mounted")
+}
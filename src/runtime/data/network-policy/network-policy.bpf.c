@@ -0,0 +1,301 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0 OR GPL-2.0-only
+//
+// eBPF filter enforcing the network policy of a pod inside the guest. The
+// agent loads the object, attaches the tc/ingress and tc/egress programs to
+// the clsact qdiscs of the network interfaces of the guest with netlink, and
+// sets the policy by swapping a new kata_np_policy_inner map in the
+// kata_np_policy map of maps.
+//
+// Build with:
+//   clang -O2 -g -target bpf -c network-policy.bpf.c -o network-policy.bpf.o
+
+#include <linux/bpf.h>
+#include <linux/if_ether.h>
+#include <linux/in.h>
+#include <linux/ip.h>
+#include <linux/ipv6.h>
+#include <linux/pkt_cls.h>
+#include <linux/tcp.h>
+#include <linux/udp.h>
+
+#define SEC(name) __attribute__((section(name), used))
+#ifndef __always_inline
+#define __always_inline inline __attribute__((always_inline))
+#endif
+#define bpf_htons(x) __builtin_bswap16(x)
+#define bpf_ntohs(x) __builtin_bswap16(x)
+
+static void *(*bpf_map_lookup_elem)(void *map, const void *key) = (void *)BPF_FUNC_map_lookup_elem;
+static long (*bpf_map_update_elem)(void *map, const void *key, const void *value, __u64 flags) = (void *)BPF_FUNC_map_update_elem;
+
+// Map definition of the iproute2 ELF loader, read by the agent. The inner
+// map of a map of maps is the map whose id is its inner_id.
+struct bpf_elf_map {
+	__u32 type;
+	__u32 size_key;
+	__u32 size_value;
+	__u32 max_elem;
+	__u32 flags;
+	__u32 id;
+	__u32 pinning;
+	__u32 inner_id;
+	__u32 inner_idx;
+};
+
+#define KATA_NP_POLICY_ID 1
+
+// Must match maxNetworkPolicyRules of the runtime
+#define KATA_NP_MAX_RULES 64
+#define KATA_NP_MAX_FLOWS 16384
+
+#define KATA_NP_INGRESS 1
+#define KATA_NP_EGRESS 2
+
+// A rule allowing the traffic of a direction from or to a network. IPv4
+// addresses are mapped in IPv6 (::ffff:a.b.c.d), with their prefix length
+// over the 128 bits. Ports are in host order, any port when port is 0.
+struct kata_np_rule {
+	__u8 addr[16];
+	__u8 prefixlen;
+	__u8 direction;
+	__u8 protocol;
+	__u8 pad;
+	__u16 port;
+	__u16 end_port;
+};
+
+// The number of rules, and the directions whose traffic the rules do not
+// allow is dropped.
+struct kata_np_config {
+	__u32 nr_rules;
+	__u8 ingress;
+	__u8 egress;
+	__u16 pad;
+};
+
+// The policy, the single element of a kata_np_policy_inner map, replaced as
+// a whole by the agent.
+struct kata_np_policy {
+	struct kata_np_config config;
+	struct kata_np_rule rules[KATA_NP_MAX_RULES];
+};
+
+// A connection, from the point of view of the pod, whose replies are allowed
+struct kata_np_flow {
+	__u8 local[16];
+	__u8 peer[16];
+	__u16 local_port;
+	__u16 peer_port;
+	__u8 protocol;
+	__u8 pad[3];
+};
+
+struct bpf_elf_map SEC("maps") kata_np_policy_inner = {
+	.type = BPF_MAP_TYPE_ARRAY,
+	.size_key = sizeof(__u32),
+	.size_value = sizeof(struct kata_np_policy),
+	.max_elem = 1,
+	.id = KATA_NP_POLICY_ID,
+};
+
+struct bpf_elf_map SEC("maps") kata_np_policy = {
+	.type = BPF_MAP_TYPE_ARRAY_OF_MAPS,
+	.size_key = sizeof(__u32),
+	.size_value = sizeof(__u32),
+	.max_elem = 1,
+	.inner_id = KATA_NP_POLICY_ID,
+};
+
+struct bpf_elf_map SEC("maps") kata_np_flows = {
+	.type = BPF_MAP_TYPE_LRU_HASH,
+	.size_key = sizeof(struct kata_np_flow),
+	.size_value = sizeof(__u8),
+	.max_elem = KATA_NP_MAX_FLOWS,
+};
+
+struct packet {
+	__u8 src[16];
+	__u8 dst[16];
+	__u16 src_port;
+	__u16 dst_port;
+	__u8 protocol;
+};
+
+static __always_inline void map_ipv4(__u8 *addr, __be32 ipv4)
+{
+	__builtin_memset(addr, 0, 10);
+	addr[10] = 0xff;
+	addr[11] = 0xff;
+	__builtin_memcpy(addr + 12, &ipv4, 4);
+}
+
+static __always_inline int parse_ports(struct packet *pkt, void *l4, void *data_end)
+{
+	switch (pkt->protocol) {
+	case IPPROTO_TCP:
+	case IPPROTO_UDP:
+	case IPPROTO_SCTP: {
+		// The ports are the first fields of the three headers
+		struct udphdr *udp = l4;
+
+		if ((void *)(udp + 1) > data_end)
+			return -1;
+		pkt->src_port = bpf_ntohs(udp->source);
+		pkt->dst_port = bpf_ntohs(udp->dest);
+	}
+	}
+	return 0;
+}
+
+// parse fills pkt with the addresses and ports of the IP packet of skb, and
+// returns 0, 1 for the packets which are not IP, or -1 for truncated ones.
+static __always_inline int parse(struct __sk_buff *skb, struct packet *pkt)
+{
+	void *data = (void *)(long)skb->data;
+	void *data_end = (void *)(long)skb->data_end;
+	struct ethhdr *eth = data;
+
+	if ((void *)(eth + 1) > data_end)
+		return -1;
+
+	if (eth->h_proto == bpf_htons(ETH_P_IP)) {
+		struct iphdr *ip = (void *)(eth + 1);
+
+		if ((void *)(ip + 1) > data_end || ip->ihl < 5)
+			return -1;
+		map_ipv4(pkt->src, ip->saddr);
+		map_ipv4(pkt->dst, ip->daddr);
+		pkt->protocol = ip->protocol;
+		// The fragments after the first one have no ports
+		if (ip->frag_off & bpf_htons(0x1fff))
+			return 0;
+		return parse_ports(pkt, (void *)ip + ip->ihl * 4, data_end);
+	}
+
+	if (eth->h_proto == bpf_htons(ETH_P_IPV6)) {
+		struct ipv6hdr *ip6 = (void *)(eth + 1);
+
+		if ((void *)(ip6 + 1) > data_end)
+			return -1;
+		__builtin_memcpy(pkt->src, &ip6->saddr, 16);
+		__builtin_memcpy(pkt->dst, &ip6->daddr, 16);
+		// Extension headers are not followed, their packets only
+		// match the rules without protocol.
+		pkt->protocol = ip6->nexthdr;
+		return parse_ports(pkt, ip6 + 1, data_end);
+	}
+
+	return 1;
+}
+
+static __always_inline int prefix_match(const __u8 *addr, const struct kata_np_rule *rule)
+{
+	__u32 bits = rule->prefixlen;
+	int i;
+
+	for (i = 0; i < 16; i++) {
+		__u8 mask;
+
+		if (bits == 0)
+			break;
+		mask = bits >= 8 ? 0xff : (__u8)(0xff << (8 - bits));
+		if ((addr[i] ^ rule->addr[i]) & mask)
+			return 0;
+		bits = bits >= 8 ? bits - 8 : 0;
+	}
+	return 1;
+}
+
+static __always_inline int allowed(const struct kata_np_policy *policy, __u8 direction,
+				   const __u8 *peer, __u16 port, __u8 protocol)
+{
+	__u32 i;
+
+	for (i = 0; i < KATA_NP_MAX_RULES; i++) {
+		const struct kata_np_rule *rule = &policy->rules[i];
+
+		if (i >= policy->config.nr_rules)
+			break;
+		if (rule->direction != direction)
+			continue;
+		if (rule->protocol) {
+			if (rule->protocol != protocol)
+				continue;
+			if (rule->port) {
+				__u16 end = rule->end_port ? rule->end_port : rule->port;
+
+				if (port < rule->port || port > end)
+					continue;
+			}
+		}
+		if (prefix_match(peer, rule))
+			return 1;
+	}
+	return 0;
+}
+
+static __always_inline int filter(struct __sk_buff *skb, __u8 direction)
+{
+	struct kata_np_policy *policy;
+	struct kata_np_flow flow = {};
+	struct packet pkt = {};
+	__u8 enforced, opened = direction;
+	__u32 key = 0;
+	void *inner;
+	int ret;
+
+	inner = bpf_map_lookup_elem(&kata_np_policy, &key);
+	if (!inner)
+		return TC_ACT_OK;
+	policy = bpf_map_lookup_elem(inner, &key);
+	if (!policy)
+		return TC_ACT_OK;
+
+	ret = parse(skb, &pkt);
+	if (ret > 0)
+		return TC_ACT_OK;
+	if (ret < 0)
+		return TC_ACT_SHOT;
+
+	if (direction == KATA_NP_INGRESS) {
+		enforced = policy->config.ingress;
+		__builtin_memcpy(flow.local, pkt.dst, 16);
+		__builtin_memcpy(flow.peer, pkt.src, 16);
+		flow.local_port = pkt.dst_port;
+		flow.peer_port = pkt.src_port;
+	} else {
+		enforced = policy->config.egress;
+		__builtin_memcpy(flow.local, pkt.src, 16);
+		__builtin_memcpy(flow.peer, pkt.dst, 16);
+		flow.local_port = pkt.src_port;
+		flow.peer_port = pkt.dst_port;
+	}
+	flow.protocol = pkt.protocol;
+
+	// The replies of the connections already allowed
+	if (bpf_map_lookup_elem(&kata_np_flows, &flow))
+		return TC_ACT_OK;
+
+	if (enforced && !allowed(policy, direction, flow.peer, pkt.dst_port, pkt.protocol))
+		return TC_ACT_SHOT;
+
+	bpf_map_update_elem(&kata_np_flows, &flow, &opened, BPF_ANY);
+	return TC_ACT_OK;
+}
+
+SEC("tc/ingress")
+int kata_np_ingress(struct __sk_buff *skb)
+{
+	return filter(skb, KATA_NP_INGRESS);
+}
+
+SEC("tc/egress")
+int kata_np_egress(struct __sk_buff *skb)
+{
+	return filter(skb, KATA_NP_EGRESS);
+}
+
+// Loaded under the GPL of the dual license
+char _license[] SEC("license") = "GPL";
@@ -0,0 +1,213 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package agentapi describes the agent API broker of the shim, through which
+// designated host services, e.g. backup or attestation agents, call a
+// restricted set of agent operations on a sandbox without access to its
+// vsock.
+//
+// The broker listens on a socket per sandbox, under SocketDir. The callers
+// are identified by the credentials of their connection, and their calls
+// are allowed or denied by the consumers of the policy, every call being
+// recorded in the audit log of the shim. An operation is called with a
+// request on its path, e.g. "GET /StatsContainer?container=<id>", the
+// operations changing the state of the sandbox requiring a PUT.
+package agentapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// SocketDir is the directory of the sockets of the broker.
+const SocketDir = "/run/kata-containers/agent-api"
+
+// Operations of the broker, named after the agent RPCs they call.
+const (
+	GetMetrics              = "GetMetrics"
+	GetGuestServices        = "GetGuestServices"
+	ListInterfaces          = "ListInterfaces"
+	ListRoutes              = "ListRoutes"
+	GetIPTables             = "GetIPTables"
+	StatsContainer          = "StatsContainer"
+	GetVolumeStats          = "GetVolumeStats"
+	GetContainerVolumeStats = "GetContainerVolumeStats"
+	PauseContainer          = "PauseContainer"
+	ResumeContainer         = "ResumeContainer"
+	FreezeFs                = "FreezeFs"
+	ThawFs                  = "ThawFs"
+)
+
+// Operations are the operations of the broker. The other agent RPCs, e.g.
+// ExecProcess or CopyFile, would let the consumers run arbitrary code in the
+// guest and are not brokered.
+var Operations = []string{
+	GetMetrics,
+	GetGuestServices,
+	ListInterfaces,
+	ListRoutes,
+	GetIPTables,
+	StatsContainer,
+	GetVolumeStats,
+	GetContainerVolumeStats,
+	PauseContainer,
+	ResumeContainer,
+	FreezeFs,
+	ThawFs,
+}
+
+var (
+	// ErrUnknownConsumer is returned when the caller is not a consumer of
+	// the policy.
+	ErrUnknownConsumer = errors.New("caller is not an agent API consumer")
+
+	// ErrOperationDenied is returned when the operation is not allowed to
+	// the consumer.
+	ErrOperationDenied = errors.New("operation not allowed to the agent API consumer")
+)
+
+// SocketPath returns the path of the socket of the broker of a sandbox.
+func SocketPath(sandboxID string) string {
+	return filepath.Join(SocketDir, sandboxID+".sock")
+}
+
+// Caller are the credentials of the process calling the broker.
+type Caller struct {
+	PID int32
+	UID uint32
+	GID uint32
+	// Groups are the supplementary groups of the caller
+	Groups []uint32
+}
+
+// Consumer is a host service allowed to call operations of the broker.
+type Consumer struct {
+	// Name identifies the consumer in the audit log
+	Name string `json:"name"`
+
+	// UIDs and GIDs are the users and the groups of the processes of the
+	// consumer, matched against the effective user of the caller, and
+	// against its effective and supplementary groups.
+	UIDs []uint32 `json:"uids,omitempty"`
+	GIDs []uint32 `json:"gids,omitempty"`
+
+	// Operations are the operations the consumer may call
+	Operations []string `json:"operations"`
+}
+
+// Policy lists the consumers of the broker. A caller is the first consumer
+// it matches.
+type Policy struct {
+	Consumers []Consumer `json:"consumers"`
+}
+
+func isOperation(op string) bool {
+	for _, o := range Operations {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Consumer) validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("missing consumer name")
+	}
+	if len(c.UIDs) == 0 && len(c.GIDs) == 0 {
+		return fmt.Errorf("consumer %q matches no user nor group", c.Name)
+	}
+	if len(c.Operations) == 0 {
+		return fmt.Errorf("consumer %q has no operation", c.Name)
+	}
+	for _, op := range c.Operations {
+		if !isOperation(op) {
+			return fmt.Errorf("consumer %q has an invalid operation %q, expecting one of %v", c.Name, op, Operations)
+		}
+	}
+	return nil
+}
+
+func (c *Consumer) matches(caller Caller) bool {
+	for _, uid := range c.UIDs {
+		if uid == caller.UID {
+			return true
+		}
+	}
+	for _, gid := range c.GIDs {
+		if gid == caller.GID {
+			return true
+		}
+		for _, group := range caller.Groups {
+			if gid == group {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c *Consumer) allows(op string) bool {
+	for _, o := range c.Operations {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+// ParsePolicy parses and validates a JSON policy.
+func ParsePolicy(data []byte) (*Policy, error) {
+	var policy Policy
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&policy); err != nil {
+		return nil, fmt.Errorf("invalid agent API policy: %v", err)
+	}
+
+	names := make(map[string]bool)
+	for i := range policy.Consumers {
+		c := &policy.Consumers[i]
+		if err := c.validate(); err != nil {
+			return nil, fmt.Errorf("invalid agent API policy: %v", err)
+		}
+		if names[c.Name] {
+			return nil, fmt.Errorf("invalid agent API policy: duplicate consumer %q", c.Name)
+		}
+		names[c.Name] = true
+	}
+
+	return &policy, nil
+}
+
+// LoadPolicy loads the JSON policy of a file.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePolicy(data)
+}
+
+// Authorize returns the name of the consumer of the caller if it is allowed
+// to call the operation, ErrUnknownConsumer or ErrOperationDenied otherwise.
+func (p *Policy) Authorize(caller Caller, op string) (string, error) {
+	for i := range p.Consumers {
+		c := &p.Consumers[i]
+		if !c.matches(caller) {
+			continue
+		}
+		if !c.allows(op) {
+			return c.Name, ErrOperationDenied
+		}
+		return c.Name, nil
+	}
+	return "", ErrUnknownConsumer
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package agentapi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPolicy = `{
+	"consumers": [
+		{"name": "backup", "uids": [1001], "operations": ["FreezeFs", "ThawFs"]},
+		{"name": "attestation", "gids": [2000], "operations": ["GetGuestServices", "GetMetrics"]}
+	]
+}`
+
+func TestParsePolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	policy, err := ParsePolicy([]byte(testPolicy))
+	assert.NoError(err)
+	assert.Len(policy.Consumers, 2)
+
+	for _, data := range []string{
+		`{"consumer": []}`,
+		`{"consumers": [{"uids": [0], "operations": ["GetMetrics"]}]}`,
+		`{"consumers": [{"name": "any", "operations": ["GetMetrics"]}]}`,
+		`{"consumers": [{"name": "none", "uids": [0]}]}`,
+		`{"consumers": [{"name": "exec", "uids": [0], "operations": ["ExecProcess"]}]}`,
+		`{"consumers": [{"name": "twice", "uids": [0], "operations": ["GetMetrics"]}, {"name": "twice", "uids": [1], "operations": ["GetMetrics"]}]}`,
+	} {
+		_, err := ParsePolicy([]byte(data))
+		assert.Error(err, data)
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "agent-api-policy.json")
+	_, err := LoadPolicy(path)
+	assert.Error(err)
+
+	assert.NoError(os.WriteFile(path, []byte(testPolicy), 0644))
+	policy, err := LoadPolicy(path)
+	assert.NoError(err)
+	assert.Len(policy.Consumers, 2)
+}
+
+func TestAuthorize(t *testing.T) {
+	assert := assert.New(t)
+
+	policy, err := ParsePolicy([]byte(testPolicy))
+	assert.NoError(err)
+
+	consumer, err := policy.Authorize(Caller{UID: 1001, GID: 1001}, FreezeFs)
+	assert.NoError(err)
+	assert.Equal("backup", consumer)
+
+	consumer, err = policy.Authorize(Caller{UID: 1001, GID: 2000}, GetMetrics)
+	assert.Equal(ErrOperationDenied, err)
+	assert.Equal("backup", consumer)
+
+	consumer, err = policy.Authorize(Caller{UID: 3000, GID: 2000}, GetMetrics)
+	assert.NoError(err)
+	assert.Equal("attestation", consumer)
+
+	// a supplementary group of the caller
+	consumer, err = policy.Authorize(Caller{UID: 3000, GID: 3000, Groups: []uint32{100, 2000}}, GetMetrics)
+	assert.NoError(err)
+	assert.Equal("attestation", consumer)
+
+	_, err = policy.Authorize(Caller{UID: 0, GID: 0}, GetMetrics)
+	assert.Equal(ErrUnknownConsumer, err)
+}
@@ -0,0 +1,324 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+
+	taskAPI "github.com/containerd/containerd/runtime/v2/task"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/agentapi"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// agentAPIAuditLog records the calls of the agent API broker, allowed or not.
+var agentAPIAuditLog = shimLog.WithField("subsystem", "agent-api-audit")
+
+// newAgentAPIAuditLog returns the audit log of the broker. The shim log is
+// at the warning level unless debugging, which would drop the allowed
+// calls: the audit log has a logger of its own, at the info level, writing
+// to the outputs of the shim log.
+func newAgentAPIAuditLog() *logrus.Entry {
+	std := logrus.StandardLogger()
+
+	logger := logrus.New()
+	logger.SetOutput(std.Out)
+	logger.SetFormatter(std.Formatter)
+	logger.SetLevel(logrus.InfoLevel)
+	for _, hooks := range std.Hooks {
+		for _, hook := range hooks {
+			logger.AddHook(hook)
+		}
+	}
+
+	return logger.WithFields(shimLog.Data).WithField("subsystem", "agent-api-audit")
+}
+
+type agentAPICallerKey struct{}
+
+// agentAPIRequestError is returned by the operations for invalid requests.
+type agentAPIRequestError struct {
+	error
+}
+
+// agentAPIParam returns the query parameter of the request, which must be set.
+func agentAPIParam(r *http.Request, key string) (string, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return "", agentAPIRequestError{fmt.Errorf("required parameter %s not found", key)}
+	}
+	return value, nil
+}
+
+// agentAPIOperation calls an operation of the broker, the result being
+// written as is when it is a []byte, as JSON otherwise.
+type agentAPIOperation struct {
+	// mutating operations change the state of the sandbox and must be
+	// called with PUT, the others with GET
+	mutating bool
+	call     func(s *service, r *http.Request) (interface{}, error)
+}
+
+var agentAPIOperations = map[string]agentAPIOperation{
+	agentapi.GetMetrics: {call: func(s *service, r *http.Request) (interface{}, error) {
+		metrics, err := s.sandbox.GetAgentMetrics(r.Context())
+		return []byte(metrics), err
+	}},
+	agentapi.GetGuestServices: {call: func(s *service, r *http.Request) (interface{}, error) {
+		return s.sandbox.GuestServices(r.Context())
+	}},
+	agentapi.ListInterfaces: {call: func(s *service, r *http.Request) (interface{}, error) {
+		return s.sandbox.ListInterfaces(r.Context())
+	}},
+	agentapi.ListRoutes: {call: func(s *service, r *http.Request) (interface{}, error) {
+		return s.sandbox.ListRoutes(r.Context())
+	}},
+	agentapi.GetIPTables: {call: func(s *service, r *http.Request) (interface{}, error) {
+		return s.sandbox.GetIPTables(r.Context(), r.URL.Query().Get("ipv6") == "true")
+	}},
+	agentapi.StatsContainer: {call: func(s *service, r *http.Request) (interface{}, error) {
+		id, err := agentAPIParam(r, ContainerKey)
+		if err != nil {
+			return nil, err
+		}
+		return s.sandbox.StatsContainer(r.Context(), id)
+	}},
+	agentapi.GetVolumeStats: {call: func(s *service, r *http.Request) (interface{}, error) {
+		path, err := agentAPIParam(r, DirectVolumePathKey)
+		if err != nil {
+			return nil, err
+		}
+		return s.sandbox.GuestVolumeStats(r.Context(), path)
+	}},
+	agentapi.GetContainerVolumeStats: {call: func(s *service, r *http.Request) (interface{}, error) {
+		id, err := agentAPIParam(r, ContainerKey)
+		if err != nil {
+			return nil, err
+		}
+		return s.sandbox.ContainerVolumeStats(r.Context(), id)
+	}},
+	// The containers are paused and resumed through the task API, to keep
+	// their status and send the events.
+	agentapi.PauseContainer: {mutating: true, call: func(s *service, r *http.Request) (interface{}, error) {
+		id, err := agentAPIParam(r, ContainerKey)
+		if err != nil {
+			return nil, err
+		}
+		_, err = s.Pause(r.Context(), &taskAPI.PauseRequest{ID: id})
+		return nil, err
+	}},
+	agentapi.ResumeContainer: {mutating: true, call: func(s *service, r *http.Request) (interface{}, error) {
+		id, err := agentAPIParam(r, ContainerKey)
+		if err != nil {
+			return nil, err
+		}
+		_, err = s.Resume(r.Context(), &taskAPI.ResumeRequest{ID: id})
+		return nil, err
+	}},
+	// The filesystems are frozen as with /quiesce, so they are thawed once
+	// the timeout expires if the consumer fails to.
+	agentapi.FreezeFs: {mutating: true, call: func(s *service, r *http.Request) (interface{}, error) {
+		timeout := defaultQuiesceTimeout
+		if value := r.URL.Query().Get("timeout"); value != "" {
+			seconds, err := strconv.ParseUint(value, 10, 32)
+			if err != nil || seconds == 0 {
+				return nil, agentAPIRequestError{fmt.Errorf("invalid timeout %q", value)}
+			}
+			timeout = time.Duration(seconds) * time.Second
+		}
+		return nil, s.quiesceSandbox(r.Context(), timeout)
+	}},
+	agentapi.ThawFs: {mutating: true, call: func(s *service, r *http.Request) (interface{}, error) {
+		return nil, s.unquiesceSandbox(r.Context())
+	}},
+}
+
+// agentAPIConnContext adds the credentials of the peer of the connection to
+// the context of its requests.
+func agentAPIConnContext(ctx context.Context, c net.Conn) context.Context {
+	conn, ok := c.(*net.UnixConn)
+	if !ok {
+		return ctx
+	}
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return ctx
+	}
+
+	var (
+		cred    *unix.Ucred
+		groups  []uint32
+		credErr error
+	)
+	if err := raw.Control(func(fd uintptr) {
+		cred, credErr = unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET, unix.SO_PEERCRED)
+		if credErr == nil {
+			groups, credErr = peerGroups(int(fd))
+		}
+	}); err != nil {
+		credErr = err
+	}
+	if credErr != nil {
+		shimMgtLog.WithError(credErr).Warn("failed to get the credentials of the agent API caller")
+		return ctx
+	}
+
+	return context.WithValue(ctx, agentAPICallerKey{}, agentapi.Caller{PID: cred.Pid, UID: cred.Uid, GID: cred.Gid, Groups: groups})
+}
+
+// peerGroups returns the supplementary groups of the peer of a unix socket,
+// as they were when it connected.
+func peerGroups(fd int) ([]uint32, error) {
+	groups := make([]uint32, 64)
+	for {
+		size := uint32(len(groups) * 4)
+		_, _, errno := unix.Syscall6(unix.SYS_GETSOCKOPT, uintptr(fd), unix.SOL_SOCKET, unix.SO_PEERGROUPS,
+			uintptr(unsafe.Pointer(&groups[0])), uintptr(unsafe.Pointer(&size)), 0)
+		switch errno {
+		case 0:
+			return groups[:size/4], nil
+		case unix.ERANGE:
+			// size is the one needed
+			groups = make([]uint32, size/4)
+		default:
+			return nil, errno
+		}
+	}
+}
+
+// serveAgentAPI handles the calls of the operations of the broker, checking
+// they are allowed by the policy and recording them in the audit log.
+func (s *service) serveAgentAPI(w http.ResponseWriter, r *http.Request) {
+	policy := s.config.AgentAPIPolicy
+	op := strings.TrimPrefix(r.URL.Path, "/")
+
+	caller, ok := r.Context().Value(agentAPICallerKey{}).(agentapi.Caller)
+	if !ok {
+		agentAPIAuditLog.WithField("operation", op).Warn("agent API call denied: unknown caller credentials")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	audit := agentAPIAuditLog.WithFields(logrus.Fields{
+		"operation": op,
+		"query":     r.URL.RawQuery,
+		"pid":       caller.PID,
+		"uid":       caller.UID,
+		"gid":       caller.GID,
+		"groups":    caller.Groups,
+	})
+
+	operation, ok := agentAPIOperations[op]
+	if !ok {
+		audit.Warn("agent API call denied: unknown operation")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	consumer, err := policy.Authorize(caller, op)
+	audit = audit.WithField("consumer", consumer)
+	if err != nil {
+		audit.WithError(err).Warn("agent API call denied")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	if (operation.mutating && r.Method != http.MethodPut) || (!operation.mutating && r.Method != http.MethodGet) {
+		audit.WithField("method", r.Method).Warn("agent API call denied: invalid method")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	start := time.Now()
+	result, err := operation.call(s, r)
+	audit = audit.WithField("duration", time.Since(start))
+	if err != nil {
+		audit.WithError(err).Warn("agent API call failed")
+		if errors.As(err, &agentAPIRequestError{}) {
+			w.WriteHeader(http.StatusBadRequest)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		w.Write([]byte(err.Error()))
+		return
+	}
+	audit.Info("agent API call")
+
+	if result == nil {
+		return
+	}
+	buf, ok := result.([]byte)
+	if !ok {
+		if buf, err = json.Marshal(result); err != nil {
+			shimMgtLog.WithError(err).WithField("operation", op).Error("failed to marshal the agent API result")
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+	}
+	w.Write(buf)
+}
+
+// startAgentAPIBroker serves the agent API broker of the sandbox when a
+// policy is configured. The socket is open to all the users of the host, the
+// callers being authorized by the policy.
+func (s *service) startAgentAPIBroker() {
+	if s.config == nil || s.config.AgentAPIPolicy == nil {
+		return
+	}
+
+	path := agentapi.SocketPath(s.id)
+	if err := os.MkdirAll(agentapi.SocketDir, 0755); err != nil {
+		shimMgtLog.WithError(err).Error("failed to create the agent API directory")
+		return
+	}
+	// The socket of a previous shim of the sandbox, e.g. before a handover
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		shimMgtLog.WithError(err).Error("failed to remove the agent API socket")
+		return
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		shimMgtLog.WithError(err).Error("failed to create the agent API listener")
+		return
+	}
+	if err := os.Chmod(path, 0666); err != nil {
+		shimMgtLog.WithError(err).Error("failed to set the permissions of the agent API socket")
+		listener.Close()
+		return
+	}
+
+	agentAPIAuditLog = newAgentAPIAuditLog()
+	shimMgtLog.WithField("socket", path).WithField("consumers", len(s.config.AgentAPIPolicy.Consumers)).Info("agent API broker started")
+
+	svr := &http.Server{
+		Handler:     http.HandlerFunc(s.serveAgentAPI),
+		ConnContext: agentAPIConnContext,
+	}
+	go svr.Serve(listener)
+}
+
+// removeAgentAPISocket removes the socket of the broker of the sandbox.
+func (s *service) removeAgentAPISocket() {
+	if s.config == nil || s.config.AgentAPIPolicy == nil {
+		return
+	}
+	if err := os.Remove(agentapi.SocketPath(s.id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		shimMgtLog.WithError(err).Warn("failed to remove the agent API socket")
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/agentapi"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/oci"
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/vcmock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServeAgentAPI(t *testing.T) {
+	assert := assert.New(t)
+
+	policy, err := agentapi.ParsePolicy([]byte(`{"consumers": [
+		{"name": "monitoring", "uids": [1001], "operations": ["GetMetrics", "StatsContainer"]},
+		{"name": "backup", "gids": [2000], "operations": ["FreezeFs", "ThawFs"]}
+	]}`))
+	assert.NoError(err)
+
+	var quiesced, unquiesced bool
+	var statsContainer string
+	s := &service{
+		id: testSandboxID,
+		sandbox: &vcmock.Sandbox{
+			MockID: testSandboxID,
+			GetAgentMetricsFunc: func() (string, error) {
+				return "kata_agent_scrape_count 1\n", nil
+			},
+			StatsContainerFunc: func(contID string) (vc.ContainerStats, error) {
+				statsContainer = contID
+				return vc.ContainerStats{}, nil
+			},
+			QuiesceFunc: func() error {
+				quiesced = true
+				return nil
+			},
+			UnquiesceFunc: func() error {
+				unquiesced = true
+				return nil
+			},
+		},
+		config:     &oci.RuntimeConfig{AgentAPIPolicy: policy},
+		containers: make(map[string]*container),
+	}
+
+	call := func(caller *agentapi.Caller, method, target string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, target, nil)
+		if caller != nil {
+			r = r.WithContext(context.WithValue(r.Context(), agentAPICallerKey{}, *caller))
+		}
+		rr := httptest.NewRecorder()
+		s.serveAgentAPI(rr, r)
+		return rr
+	}
+
+	monitoring := &agentapi.Caller{PID: 10, UID: 1001, GID: 1001}
+	backup := &agentapi.Caller{PID: 20, UID: 3000, GID: 2000}
+
+	rr := call(monitoring, http.MethodGet, "/GetMetrics")
+	assert.Equal(http.StatusOK, rr.Code)
+	assert.Equal("kata_agent_scrape_count 1\n", rr.Body.String())
+
+	rr = call(monitoring, http.MethodGet, "/StatsContainer?container=c1")
+	assert.Equal(http.StatusOK, rr.Code)
+	assert.Equal("c1", statsContainer)
+
+	rr = call(monitoring, http.MethodGet, "/StatsContainer")
+	assert.Equal(http.StatusBadRequest, rr.Code)
+
+	// Not allowed to the consumer
+	rr = call(monitoring, http.MethodPut, "/FreezeFs")
+	assert.Equal(http.StatusForbidden, rr.Code)
+	assert.False(quiesced)
+
+	// Not a consumer, or unknown credentials
+	assert.Equal(http.StatusForbidden, call(&agentapi.Caller{UID: 0, GID: 0}, http.MethodGet, "/GetMetrics").Code)
+	assert.Equal(http.StatusForbidden, call(nil, http.MethodGet, "/GetMetrics").Code)
+
+	// Not brokered
+	assert.Equal(http.StatusNotFound, call(backup, http.MethodPut, "/ExecProcess").Code)
+
+	// The mutating operations require a PUT
+	assert.Equal(http.StatusMethodNotAllowed, call(backup, http.MethodGet, "/FreezeFs").Code)
+	assert.Equal(http.StatusBadRequest, call(backup, http.MethodPut, "/FreezeFs?timeout=never").Code)
+	assert.False(quiesced)
+
+	assert.Equal(http.StatusOK, call(backup, http.MethodPut, "/FreezeFs?timeout=30").Code)
+	assert.True(quiesced)
+	assert.Equal(http.StatusOK, call(backup, http.MethodPut, "/ThawFs").Code)
+	assert.True(unquiesced)
+}
+
+func TestAgentAPIConnContext(t *testing.T) {
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "agent-api.sock")
+	listener, err := net.Listen("unix", path)
+	assert.NoError(err)
+	defer listener.Close()
+
+	client, err := net.Dial("unix", path)
+	assert.NoError(err)
+	defer client.Close()
+
+	conn, err := listener.Accept()
+	assert.NoError(err)
+	defer conn.Close()
+
+	ctx := agentAPIConnContext(context.Background(), conn)
+	caller, ok := ctx.Value(agentAPICallerKey{}).(agentapi.Caller)
+	assert.True(ok)
+	assert.Equal(int32(os.Getpid()), caller.PID)
+	assert.Equal(uint32(os.Getuid()), caller.UID)
+	assert.Equal(uint32(os.Getgid()), caller.GID)
+
+	groups, err := os.Getgroups()
+	assert.NoError(err)
+	assert.Len(caller.Groups, len(groups))
+	for _, group := range groups {
+		assert.Contains(caller.Groups, uint32(group))
+	}
+}
+
+func TestNewAgentAPIAuditLog(t *testing.T) {
+	assert := assert.New(t)
+
+	std := logrus.StandardLogger()
+	out, level := std.Out, std.Level
+	defer func() {
+		std.SetOutput(out)
+		std.SetLevel(level)
+	}()
+
+	var buf bytes.Buffer
+	std.SetOutput(&buf)
+	std.SetLevel(logrus.WarnLevel)
+
+	// The allowed calls are recorded whatever the level of the shim log.
+	newAgentAPIAuditLog().Info("agent API call")
+	assert.Contains(buf.String(), "agent API call")
+	assert.Contains(buf.String(), "subsystem=agent-api-audit")
+}
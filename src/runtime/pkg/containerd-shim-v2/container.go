@@ -19,6 +19,7 @@ import (
 
 type container struct {
 	s           *service
+	host        *hostContainer
 	ttyio       *ttyIO
 	spec        *specs.Spec
 	exitTime    time.Time
@@ -32,6 +33,7 @@ type container struct {
 	stdout      string
 	stderr      string
 	bundle      string
+	checkpoint  string
 	cType       vc.ContainerType
 	exit        uint32
 	status      task.Status
@@ -54,6 +56,7 @@ func newContainer(s *service, r *taskAPI.CreateTaskRequest, containerType vc.Con
 		spec:        spec,
 		id:          r.ID,
 		bundle:      r.Bundle,
+		checkpoint:  r.Checkpoint,
 		stdin:       r.Stdin,
 		stdout:      r.Stdout,
 		stderr:      r.Stderr,
@@ -0,0 +1,27 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"github.com/containerd/typeurl"
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+)
+
+// sandboxCPUMitigationsEventTopic is the topic of the CPU mitigations events
+// of the sandboxes.
+const sandboxCPUMitigationsEventTopic = "/kata/sandbox/cpu-mitigations"
+
+// SandboxCPUMitigationsEvent records the CPU vulnerability mitigation profile
+// applied to the guest of a sandbox, for compliance audits. It is published
+// JSON encoded once the sandbox is created, when a profile is set.
+type SandboxCPUMitigationsEvent struct {
+	SandboxID string `json:"sandbox_id"`
+	vc.CPUMitigations
+}
+
+func init() {
+	typeurl.Register(&SandboxCPUMitigationsEvent{}, "io.katacontainers.events", "SandboxCPUMitigationsEvent")
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/containerd/typeurl"
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSandboxCPUMitigationsEvent(t *testing.T) {
+	assert := assert.New(t)
+
+	e := &SandboxCPUMitigationsEvent{
+		SandboxID: testSandboxID,
+		CPUMitigations: vc.CPUMitigations{
+			Profile:      vc.CPUMitigationsFull,
+			KernelParams: []string{"mitigations=auto,nosmt"},
+		},
+	}
+	assert.Equal(sandboxCPUMitigationsEventTopic, getTopic(e))
+
+	any, err := typeurl.MarshalAny(e)
+	assert.NoError(err)
+	var decoded map[string]interface{}
+	assert.NoError(json.Unmarshal(any.Value, &decoded))
+	assert.Equal(testSandboxID, decoded["sandbox_id"])
+	assert.Equal("full", decoded["profile"])
+	assert.Equal([]interface{}{"mitigations=auto,nosmt"}, decoded["kernel_params"])
+}
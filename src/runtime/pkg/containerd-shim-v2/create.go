@@ -68,6 +68,10 @@ func create(ctx context.Context, s *service, r *taskAPI.CreateTaskRequest) (*con
 		return nil, err
 	}
 
+	if r.Checkpoint != "" && containerType != vc.PodContainer {
+		return nil, fmt.Errorf("only the containers of a pod can be restored from a checkpoint")
+	}
+
 	disableOutput := noNeedForOutput(detach, ociSpec.Process.Terminal)
 	rootfs := filepath.Join(r.Bundle, "rootfs")
 
@@ -76,6 +80,10 @@ func create(ctx context.Context, s *service, r *taskAPI.CreateTaskRequest) (*con
 		return nil, err
 	}
 
+	if err := claimDevices(ociSpec); err != nil {
+		return nil, err
+	}
+
 	switch containerType {
 	case vc.PodSandbox, vc.SingleContainer:
 		if s.sandbox != nil {
@@ -84,6 +92,12 @@ func create(ctx context.Context, s *service, r *taskAPI.CreateTaskRequest) (*con
 
 		s.config = runtimeConfig
 
+		// The emptyDir volumes are created on the host when the pod
+		// has host containers, so that they are shared with them.
+		if s.hostContainersEnabled(ociSpec) {
+			s.config.DisableGuestEmptyDir = true
+		}
+
 		// create tracer
 		// This is the earliest location we can create the tracer because we must wait
 		// until the runtime config is loaded
@@ -102,6 +116,7 @@ func create(ctx context.Context, s *service, r *taskAPI.CreateTaskRequest) (*con
 		rootSpan, newCtx := katatrace.Trace(s.ctx, shimLog, "rootSpan", shimTracingTags)
 		s.rootCtx = newCtx
 		s.rootSpan = rootSpan
+		katatrace.AddTags(rootSpan, "correlation_id", s.correlationID)
 
 		// create span
 		span, newCtx := katatrace.Trace(s.rootCtx, shimLog, "create", shimTracingTags)
@@ -116,8 +131,11 @@ func create(ctx context.Context, s *service, r *taskAPI.CreateTaskRequest) (*con
 		//   2. If this is not a sandbox infrastructure container, but instead a standalone single container (analogous to "docker run..."),
 		//	then the container spec itself will contain appropriate sizing information for the entire sandbox (since it is
 		//	a single container.
+		var sizing *oci.SandboxSizing
 		if containerType == vc.PodSandbox {
-			s.config.SandboxCPUs, s.config.SandboxMemMB = oci.CalculateSandboxSizing(ociSpec)
+			details := oci.CalculateSandboxSizingDetails(ociSpec)
+			sizing = &details
+			s.config.SandboxCPUs, s.config.SandboxMemMB = sizing.VCPUs, sizing.MemoryMB
 		} else {
 			s.config.SandboxCPUs, s.config.SandboxMemMB = oci.CalculateContainerSizing(ociSpec)
 		}
@@ -157,6 +175,16 @@ func create(ctx context.Context, s *service, r *taskAPI.CreateTaskRequest) (*con
 		}
 		s.hpid = uint32(pid)
 
+		if sizing != nil {
+			shimLog.WithField("sizing", *sizing).Info("sandbox sized")
+			s.send(&SandboxSizingEvent{SandboxID: r.ID, SandboxSizing: *sizing})
+		}
+
+		if mitigations := s.sandbox.CPUMitigations(); mitigations.Profile != "" {
+			shimLog.WithField("cpu-mitigations", mitigations).Info("sandbox CPU mitigations")
+			s.send(&SandboxCPUMitigationsEvent{SandboxID: r.ID, CPUMitigations: mitigations})
+		}
+
 		if defaultStartManagementServerFunc != nil {
 			defaultStartManagementServerFunc(s, ctx, ociSpec)
 		}
@@ -169,6 +197,19 @@ func create(ctx context.Context, s *service, r *taskAPI.CreateTaskRequest) (*con
 			return nil, fmt.Errorf("BUG: Cannot start the container, since the sandbox hasn't been created")
 		}
 
+		if s.isHostContainer(ociSpec) {
+			host, err := createHostContainer(s, r, ociSpec)
+			if err != nil {
+				return nil, err
+			}
+			container, err := newContainer(s, r, containerType, ociSpec, true)
+			if err != nil {
+				return nil, err
+			}
+			container.host = host
+			return container, nil
+		}
+
 		if rootFs.Mounted, err = checkAndMount(s, r); err != nil {
 			return nil, err
 		}
@@ -247,10 +288,11 @@ func loadRuntimeConfig(s *service, r *taskAPI.CreateTaskRequest, anno map[string
 		configPath = os.Getenv("KATA_CONF_FILE")
 	}
 
-	_, runtimeConfig, err := katautils.LoadConfiguration(configPath, false)
+	configPath, runtimeConfig, err := katautils.LoadConfiguration(configPath, false)
 	if err != nil {
 		return nil, err
 	}
+	s.configPath = configPath
 
 	// For the unit test, the config will be predefined
 	if s.config == nil {
@@ -15,7 +15,11 @@ import (
 )
 
 func deleteContainer(ctx context.Context, s *service, c *container) error {
-	if !c.cType.IsSandbox() {
+	if c.host != nil {
+		if err := c.host.delete(); err != nil {
+			return err
+		}
+	} else if !c.cType.IsSandbox() {
 		if c.status != task.StatusStopped {
 			if _, err := s.sandbox.StopContainer(ctx, c.id, false); err != nil && !isNotFound(err) {
 				return err
@@ -0,0 +1,80 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"path/filepath"
+
+	ctrAnnotations "github.com/containerd/containerd/pkg/cri/annotations"
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/claim"
+)
+
+const vfioDevDir = "/dev/vfio"
+
+// deviceClaimOwner returns the pod of a container, as namespace/name, which
+// owns the claims of its IOMMU groups.
+func deviceClaimOwner(annotations map[string]string) string {
+	namespace := annotations[ctrAnnotations.SandboxNamespace]
+	name := annotations[ctrAnnotations.SandboxName]
+	if namespace == "" || name == "" {
+		return ""
+	}
+	return namespace + "/" + name
+}
+
+// vfioGroups returns the IOMMU groups of the VFIO devices of a container.
+func vfioGroups(ociSpec *specs.Spec) []string {
+	if ociSpec.Linux == nil {
+		return nil
+	}
+
+	var groups []string
+	for _, d := range ociSpec.Linux.Devices {
+		if filepath.Dir(d.Path) == vfioDevDir && filepath.Base(d.Path) != "vfio" {
+			groups = append(groups, filepath.Base(d.Path))
+		}
+	}
+	return groups
+}
+
+// claimDevices claims the IOMMU groups of a container for its pod, when the
+// claims are enabled on the node. It fails when a group is claimed by
+// another pod, be it pre-claimed by the scheduler or given to a pod that
+// landed first. The claims are held by the shim, they expire with it should
+// the sandbox not release them.
+func claimDevices(ociSpec *specs.Spec) error {
+	groups := vfioGroups(ociSpec)
+	if len(groups) == 0 || !claim.Enabled() {
+		return nil
+	}
+
+	owner := deviceClaimOwner(ociSpec.Annotations)
+	if owner == "" {
+		return nil
+	}
+
+	for _, group := range groups {
+		if _, err := claim.Hold(group, owner); err != nil {
+			return err
+		}
+		shimLog.WithField("group", group).WithField("pod", owner).Debug("IOMMU group claimed")
+	}
+	return nil
+}
+
+// releaseDevices releases the claims of the IOMMU groups of a pod.
+func releaseDevices(annotations map[string]string) {
+	owner := deviceClaimOwner(annotations)
+	if owner == "" {
+		return
+	}
+
+	if err := claim.RemoveOwner(owner); err != nil {
+		shimLog.WithError(err).WithField("pod", owner).Warn("failed to release the IOMMU groups of the pod")
+	}
+}
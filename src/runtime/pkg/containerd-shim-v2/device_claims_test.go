@@ -0,0 +1,74 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	ctrAnnotations "github.com/containerd/containerd/pkg/cri/annotations"
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/claim"
+)
+
+func TestClaimDevices(t *testing.T) {
+	assert := assert.New(t)
+
+	savedDir := claim.ClaimsDir
+	defer func() {
+		claim.ClaimsDir = savedDir
+	}()
+	claim.ClaimsDir = filepath.Join(t.TempDir(), "device-claims")
+
+	newSpec := func(name string) *specs.Spec {
+		return &specs.Spec{
+			Annotations: map[string]string{
+				ctrAnnotations.SandboxNamespace: "default",
+				ctrAnnotations.SandboxName:      name,
+			},
+			Linux: &specs.Linux{
+				Devices: []specs.LinuxDevice{
+					{Path: "/dev/vfio/vfio"},
+					{Path: "/dev/vfio/12"},
+					{Path: "/dev/nvidia0"},
+				},
+			},
+		}
+	}
+
+	gpu0 := newSpec("gpu-0")
+	assert.Equal([]string{"12"}, vfioGroups(gpu0))
+	assert.Equal("default/gpu-0", deviceClaimOwner(gpu0.Annotations))
+	assert.Empty(deviceClaimOwner(nil))
+
+	// the claims are disabled
+	assert.NoError(claimDevices(gpu0))
+	assert.False(claim.Enabled())
+
+	// pre-claimed by the scheduler for gpu-1
+	_, err := claim.Add("12", "default/gpu-1", time.Minute)
+	assert.NoError(err)
+
+	err = claimDevices(gpu0)
+	assert.True(errors.Is(err, claim.ErrClaimed))
+
+	gpu1 := newSpec("gpu-1")
+	assert.NoError(claimDevices(gpu1))
+	claims, err := claim.List()
+	assert.NoError(err)
+	assert.Len(claims, 1)
+	assert.Equal("default/gpu-1", claims[0].Owner)
+	assert.Nil(claims[0].Expires)
+	assert.Equal(os.Getpid(), claims[0].Holder.Pid)
+
+	releaseDevices(gpu1.Annotations)
+	assert.NoError(claimDevices(gpu0))
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/containerd/containerd/events"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/faultinject"
 )
 
 type forwarderType string
@@ -39,6 +40,9 @@ type logForwarder struct {
 
 func (lf *logForwarder) forward() {
 	for e := range lf.s.events {
+		if dropEvent(context.Background(), e) {
+			continue
+		}
 		shimLog.WithField("topic", getTopic(e)).Infof("post event: %+v", e)
 	}
 }
@@ -55,6 +59,9 @@ type containerdForwarder struct {
 
 func (cf *containerdForwarder) forward() {
 	for e := range cf.s.events {
+		if dropEvent(cf.ctx, e) {
+			continue
+		}
 		ctx, cancel := context.WithTimeout(cf.ctx, timeOut)
 		err := cf.publisher.Publish(ctx, getTopic(e), e)
 		cancel()
@@ -68,6 +75,17 @@ func (cf *containerdForwarder) forwarderType() forwarderType {
 	return forwarderTypeContainerd
 }
 
+// dropEvent tells if a fault is injected for the event, which is then not
+// forwarded.
+func dropEvent(ctx context.Context, e interface{}) bool {
+	topic := getTopic(e)
+	if err := faultinject.Inject(ctx, faultinject.EventPoint(topic)); err != nil {
+		shimLog.WithError(err).WithField("topic", topic).Warn("dropped event")
+		return true
+	}
+	return false
+}
+
 func (s *service) newEventsForwarder(ctx context.Context, publisher events.Publisher) eventsForwarder {
 	var forwarder eventsForwarder
 	ttrpcAddress := os.Getenv(ttrpcAddressEnv)
@@ -0,0 +1,926 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	sysexec "os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/containerd/containerd/api/types/task"
+	"github.com/containerd/containerd/plugin"
+	cdshim "github.com/containerd/containerd/runtime/v2/shim"
+	"github.com/containerd/ttrpc"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils"
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/compatoci"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+)
+
+const (
+	// handoverEnv tells the shim to adopt the sandbox handed over by the
+	// shim it replaces, rather than waiting for containerd to create one.
+	handoverEnv = "KATA_SHIM_HANDOVER"
+
+	// handoverFile is the file of the sandbox bundle the handed over
+	// state is written to for the new shim.
+	handoverFile = "handover.json"
+
+	// handoverTimeout is how long each step of the handover takes at most.
+	handoverTimeout = 30 * time.Second
+
+	// maxHandoverFiles is the maximum number of files which can be sent
+	// in a single message, SCM_MAX_FD.
+	maxHandoverFiles = 253
+
+	// handoverFirstFD is the first file descriptor of the new shim after
+	// the ttrpc listener.
+	handoverFirstFD = 4
+)
+
+// HandoverRequest is the body of the handover requests.
+type HandoverRequest struct {
+	// Socket is the unix socket the shim sends its state and its ttrpc
+	// sockets to.
+	Socket string
+}
+
+type handoverExec struct {
+	Cmd       *types.Cmd
+	ExitTime  time.Time
+	ID        string
+	ProcessID string
+	Stdin     string
+	Stdout    string
+	Stderr    string
+	Height    uint32
+	Width     uint32
+	ExitCode  int32
+	Status    task.Status
+	Terminal  bool
+}
+
+type handoverContainer struct {
+	ExitTime   time.Time
+	Execs      []handoverExec
+	ID         string
+	Bundle     string
+	Checkpoint string
+	Stdin      string
+	Stdout     string
+	Stderr     string
+	Type       vc.ContainerType
+	Exit       uint32
+	Status     task.Status
+	Terminal   bool
+	Mounted    bool
+}
+
+// handoverState is the state of the shim that is not persisted along with
+// the sandbox, it is handed over to the shim replacing it.
+type handoverState struct {
+	// Args are the command line arguments of the shim.
+	Args []string
+	// Address is the address of the ttrpc socket of the shim.
+	Address string
+	// Bundle is the bundle of the sandbox.
+	Bundle string
+	// ConfigPath is the configuration file the sandbox was created with.
+	ConfigPath string
+	Containers []handoverContainer
+	// Connections is the number of ttrpc connections passed to the new
+	// shim after the ttrpc listener.
+	Connections int
+	// Fifos is the number of container IO fifos passed to the new shim
+	// after the ttrpc connections.
+	Fifos int
+	// Env is the environment of the shim, set by containerd, the new shim
+	// is started with it.
+	Env []string
+	// Cgroups are the cgroup directories of the shim, the new shim is
+	// moved to them.
+	Cgroups []string
+}
+
+// handoverState returns the state to hand over, s.mu must be held.
+func (s *service) handoverState() (*handoverState, error) {
+	if s.sandbox == nil {
+		return nil, fmt.Errorf("no sandbox to hand over")
+	}
+
+	s.quiescer.mu.Lock()
+	quiesced := s.quiescer.timer != nil
+	s.quiescer.mu.Unlock()
+	if quiesced {
+		return nil, fmt.Errorf("the sandbox is quiesced")
+	}
+
+	var containers []handoverContainer
+	for _, c := range s.containers {
+		if c.host != nil {
+			return nil, fmt.Errorf("the host container %s cannot be handed over", c.id)
+		}
+		if c.cType.IsSandbox() && c.status != task.StatusRunning {
+			return nil, fmt.Errorf("the sandbox is not running")
+		}
+
+		hc := handoverContainer{
+			ExitTime:   c.exitTime,
+			ID:         c.id,
+			Bundle:     c.bundle,
+			Checkpoint: c.checkpoint,
+			Stdin:      c.stdin,
+			Stdout:     c.stdout,
+			Stderr:     c.stderr,
+			Type:       c.cType,
+			Exit:       c.exit,
+			Status:     c.status,
+			Terminal:   c.terminal,
+			Mounted:    c.mounted,
+		}
+		for id, e := range c.execs {
+			hc.Execs = append(hc.Execs, handoverExec{
+				Cmd:       e.cmds,
+				ExitTime:  e.exitTime,
+				ID:        id,
+				ProcessID: e.id,
+				Stdin:     e.tty.stdin,
+				Stdout:    e.tty.stdout,
+				Stderr:    e.tty.stderr,
+				Height:    e.tty.height,
+				Width:     e.tty.width,
+				ExitCode:  e.exitCode,
+				Status:    e.status,
+				Terminal:  e.tty.terminal,
+			})
+		}
+		containers = append(containers, hc)
+	}
+
+	address, err := cdshim.ReadAddress("address")
+	if err != nil {
+		return nil, err
+	}
+	bundle, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	cgroups, err := shimCgroups("/proc/self/cgroup")
+	if err != nil {
+		return nil, err
+	}
+
+	var env []string
+	for _, e := range os.Environ() {
+		if !strings.HasPrefix(e, handoverEnv+"=") {
+			env = append(env, e)
+		}
+	}
+
+	state := &handoverState{
+		Args:       os.Args[1:],
+		Address:    address,
+		Bundle:     bundle,
+		ConfigPath: s.configPath,
+		Containers: containers,
+		Env:        env,
+		Cgroups:    cgroups,
+	}
+
+	return state, nil
+}
+
+// shimCgroups returns the cgroup directories of the process, from its cgroup
+// file in proc, one for each hierarchy.
+func shimCgroups(path string) ([]string, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cgroups []string
+	for _, line := range strings.Split(strings.TrimSpace(string(buf)), "\n") {
+		// hierarchy-ID:controller-list:cgroup-path
+		fields := strings.SplitN(line, ":", 3)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("invalid cgroup line %q", line)
+		}
+		controllers := fields[1]
+		switch {
+		case controllers == "":
+			// unified hierarchy
+		case strings.HasPrefix(controllers, "name="):
+			controllers = strings.TrimPrefix(controllers, "name=")
+		}
+		cgroups = append(cgroups, filepath.Join("/sys/fs/cgroup", controllers, fields[2]))
+	}
+
+	return cgroups, nil
+}
+
+// moveToCgroups moves the process to the cgroup directories.
+func moveToCgroups(cgroups []string, pid int) error {
+	for _, dir := range cgroups {
+		procs := filepath.Join(dir, "cgroup.procs")
+		if err := os.WriteFile(procs, []byte(strconv.Itoa(pid)), 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ttrpcSocketFDs returns the file descriptors of the ttrpc listener of the
+// shim, followed by the ones of the connections it accepted.
+func ttrpcSocketFDs(address string) ([]int, error) {
+	name := strings.TrimPrefix(address, "unix://")
+	if len(name) == len(address) {
+		name = "@" + name
+	}
+
+	entries, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		return nil, err
+	}
+
+	var listeners, conns []int
+	for _, entry := range entries {
+		fd, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		sa, err := unix.Getsockname(fd)
+		if err != nil {
+			continue
+		}
+		if addr, ok := sa.(*unix.SockaddrUnix); !ok || addr.Name != name {
+			continue
+		}
+		if listening, err := unix.GetsockoptInt(fd, unix.SOL_SOCKET, unix.SO_ACCEPTCONN); err == nil && listening == 1 {
+			listeners = append(listeners, fd)
+		} else {
+			conns = append(conns, fd)
+		}
+	}
+
+	if len(listeners) != 1 {
+		return nil, fmt.Errorf("found %d ttrpc listeners for %s", len(listeners), address)
+	}
+	if len(conns)+1 > maxHandoverFiles {
+		return nil, fmt.Errorf("too many ttrpc connections to hand over: %d", len(conns))
+	}
+
+	return append(listeners, conns...), nil
+}
+
+// shimTTRPC is the ttrpc server of the shim, captured by the handover ttrpc
+// plugin so that it stops reading the requests before the sandbox is handed
+// over.
+var shimTTRPC struct {
+	sync.Mutex
+	server *ttrpc.Server
+}
+
+type handoverTTRPC struct{}
+
+func init() {
+	plugin.Register(&plugin.Registration{
+		Type: plugin.TTRPCPlugin,
+		ID:   "handover",
+		InitFn: func(*plugin.InitContext) (interface{}, error) {
+			return handoverTTRPC{}, nil
+		},
+	})
+}
+
+// RegisterTTRPC captures the ttrpc server of the shim, it registers no
+// service.
+func (handoverTTRPC) RegisterTTRPC(server *ttrpc.Server) error {
+	shimTTRPC.Lock()
+	defer shimTTRPC.Unlock()
+	shimTTRPC.server = server
+	return nil
+}
+
+// dupTTRPCSockets duplicates the ttrpc sockets of the shim, so that they stay
+// open once the ttrpc server is stopped.
+func dupTTRPCSockets(address string) ([]*os.File, error) {
+	fds, err := ttrpcSocketFDs(address)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*os.File
+	for _, fd := range fds {
+		dup, err := unix.FcntlInt(uintptr(fd), unix.F_DUPFD_CLOEXEC, 0)
+		if err != nil {
+			closeFiles(files)
+			return nil, err
+		}
+		files = append(files, os.NewFile(uintptr(dup), "ttrpc"))
+	}
+
+	return files, nil
+}
+
+func fileFDs(files []*os.File) []int {
+	fds := make([]int, 0, len(files))
+	for _, f := range files {
+		fds = append(fds, int(f.Fd()))
+	}
+	return fds
+}
+
+// deferExit holds the exits through logrus until the returned function is
+// called, which restores the exit function. The shim library exits through
+// logrus as soon as its ttrpc server is closed, the shim only exits that way
+// when it could not hand the sandbox over.
+func deferExit() func() {
+	logger := logrus.StandardLogger()
+	exit := logger.ExitFunc
+
+	var held sync.Mutex
+	held.Lock()
+	logger.ExitFunc = func(code int) {
+		held.Lock()
+		exit(code)
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			logger.ExitFunc = exit
+			held.Unlock()
+		})
+	}
+}
+
+// stopTTRPC closes the ttrpc server of the shim, and waits for it to close
+// its sockets, so that no request is read anymore. Only the duplicates of
+// the sockets, count of them, are left open. shimTTRPC must be held.
+func stopTTRPC(address string, count int) error {
+	if err := shimTTRPC.server.Close(); err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(handoverTimeout)
+	for {
+		fds, err := ttrpcSocketFDs(address)
+		if err == nil && len(fds) == count {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timeout waiting for the ttrpc server to close its sockets")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// serveHandover handles the /handover requests, it sends the state of the
+// shim and its ttrpc sockets to the shim replacing it, then exits without
+// touching the sandbox. The ttrpc server is stopped beforehand, so that the
+// requests sent during the handover are read by the new shim. The shim only
+// answers when it did not hand the sandbox over, it exits when that happens
+// once its ttrpc server is stopped.
+func (s *service) serveHandover(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req HandoverRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	fail := func(status int, err error) {
+		shimMgtLog.WithError(err).Warn("cannot hand the sandbox over")
+		w.WriteHeader(status)
+		w.Write([]byte(err.Error()))
+	}
+
+	shimTTRPC.Lock()
+	defer shimTTRPC.Unlock()
+	if shimTTRPC.server == nil {
+		fail(http.StatusInternalServerError, fmt.Errorf("the ttrpc server of the shim is unknown"))
+		return
+	}
+
+	// Refuse before the ttrpc server is stopped when the sandbox cannot
+	// be handed over.
+	s.mu.Lock()
+	state, err := s.handoverState()
+	s.mu.Unlock()
+	if err != nil {
+		fail(http.StatusConflict, err)
+		return
+	}
+	address := state.Address
+
+	files, err := dupTTRPCSockets(address)
+	if err != nil {
+		fail(http.StatusInternalServerError, err)
+		return
+	}
+	defer closeFiles(files)
+
+	conn, err := dialHandover(req.Socket)
+	if err != nil {
+		fail(http.StatusInternalServerError, err)
+		return
+	}
+	defer conn.Close()
+
+	// The shim cannot serve its ttrpc sockets anymore from here on.
+	release := deferExit()
+
+	status := http.StatusInternalServerError
+	err = stopTTRPC(address, len(files))
+	if err == nil {
+		// The lock is held until the shim exits, so that the state
+		// does not change once it is handed over. The requests read
+		// before the ttrpc server was stopped may have changed it
+		// meanwhile.
+		s.mu.Lock()
+		state, err = s.handoverState()
+		if err != nil {
+			status = http.StatusConflict
+		} else {
+			err = sendHandover(conn, state, fileFDs(files))
+		}
+	}
+	if err != nil {
+		fail(status, err)
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+		shimMgtLog.Error("the ttrpc server is stopped, exiting")
+		release()
+		os.Exit(1)
+	}
+
+	shimMgtLog.Info("sandbox handed over, exiting")
+	os.Exit(0)
+}
+
+// dialHandover connects to the shim listening on socket for the handover.
+func dialHandover(socket string) (*net.UnixConn, error) {
+	conn, err := net.DialTimeout("unix", socket, handoverTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return conn.(*net.UnixConn), nil
+}
+
+// sendHandover sends the state and the ttrpc sockets to the shim connected
+// with conn, and waits for it to acknowledge them. The connection is left
+// open, its closing tells the new shim that this one exited.
+func sendHandover(conn *net.UnixConn, state *handoverState, fds []int) error {
+	if err := conn.SetDeadline(time.Now().Add(handoverTimeout)); err != nil {
+		return err
+	}
+	if _, _, err := conn.WriteMsgUnix([]byte{0}, unix.UnixRights(fds...), nil); err != nil {
+		return err
+	}
+	if err := json.NewEncoder(conn).Encode(state); err != nil {
+		return err
+	}
+
+	ack := make([]byte, 1)
+	if _, err := io.ReadFull(conn, ack); err != nil {
+		return fmt.Errorf("the handover was not acknowledged: %w", err)
+	}
+
+	return nil
+}
+
+// receiveHandover receives the state and the ttrpc sockets sent by
+// sendHandover.
+func receiveHandover(conn *net.UnixConn) (*handoverState, []*os.File, error) {
+	buf := make([]byte, 1)
+	oob := make([]byte, unix.CmsgSpace(maxHandoverFiles*4))
+	_, oobn, _, _, err := conn.ReadMsgUnix(buf, oob)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	msgs, err := unix.ParseSocketControlMessage(oob[:oobn])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var files []*os.File
+	for i := range msgs {
+		fds, err := unix.ParseUnixRights(&msgs[i])
+		if err != nil {
+			closeFiles(files)
+			return nil, nil, err
+		}
+		for _, fd := range fds {
+			files = append(files, os.NewFile(uintptr(fd), "ttrpc"))
+		}
+	}
+
+	var state handoverState
+	if err := json.NewDecoder(conn).Decode(&state); err != nil {
+		closeFiles(files)
+		return nil, nil, err
+	}
+
+	if len(files) == 0 {
+		return nil, nil, fmt.Errorf("no ttrpc socket handed over")
+	}
+	state.Connections = len(files) - 1
+
+	return &state, files, nil
+}
+
+func closeFiles(files []*os.File) {
+	for _, f := range files {
+		f.Close()
+	}
+}
+
+// holdFifos opens the IO fifos of the running processes, so that the other
+// ends of the fifos are not closed in the meantime the new shim opens them.
+func holdFifos(state *handoverState) []*os.File {
+	var files []*os.File
+	hold := func(path string, flag int) {
+		if fi, err := os.Stat(path); err != nil || fi.Mode()&os.ModeNamedPipe == 0 {
+			return
+		}
+		if f, err := os.OpenFile(path, flag|syscall.O_NONBLOCK, 0); err == nil {
+			files = append(files, f)
+		}
+	}
+	holdAll := func(stdin, stdout, stderr string) {
+		hold(stdin, os.O_RDONLY)
+		hold(stdout, os.O_WRONLY)
+		hold(stderr, os.O_WRONLY)
+	}
+
+	for _, c := range state.Containers {
+		if c.Status == task.StatusRunning || c.Status == task.StatusPaused {
+			holdAll(c.Stdin, c.Stdout, c.Stderr)
+		}
+		for _, e := range c.Execs {
+			if e.Status == task.StatusRunning {
+				holdAll(e.Stdin, e.Stdout, e.Stderr)
+			}
+		}
+	}
+
+	return files
+}
+
+// requestHandover asks the shim of the sandbox to hand it over on socket.
+func requestHandover(sandboxID, socket string) error {
+	address := ServerSocketAddress(sandboxID)
+	client := &http.Client{
+		Transport: &http.Transport{
+			DisableKeepAlives: true,
+			Dial: func(proto, addr string) (net.Conn, error) {
+				return cdshim.AnonDialer(address, handoverTimeout)
+			},
+		},
+	}
+
+	body, err := json.Marshal(&HandoverRequest{Socket: socket})
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Post("http://shim"+HandoverUrl, "application/json", strings.NewReader(string(body)))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(resp.Body)
+	return fmt.Errorf("the shim did not hand the sandbox over: status code: %d, response data: %s", resp.StatusCode, string(data))
+}
+
+// Handover takes the sandbox over from the shim running it: this shim
+// binary is started to serve the ttrpc sockets of the running shim and to
+// manage its sandbox, once the running shim has exited.
+func Handover(sandboxID string) error {
+	dir, err := os.MkdirTemp("", "kata-handover-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	socket := filepath.Join(dir, "handover.sock")
+	l, err := net.ListenUnix("unix", &net.UnixAddr{Name: socket, Net: "unix"})
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+
+	reqErr := make(chan error, 1)
+	go func() {
+		reqErr <- requestHandover(sandboxID, socket)
+	}()
+
+	accepted := make(chan *net.UnixConn, 1)
+	go func() {
+		if conn, err := l.AcceptUnix(); err == nil {
+			accepted <- conn
+		}
+	}()
+
+	var conn *net.UnixConn
+	select {
+	case conn = <-accepted:
+	case err := <-reqErr:
+		return err
+	case <-time.After(handoverTimeout):
+		return fmt.Errorf("timeout waiting for the shim of sandbox %s", sandboxID)
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(handoverTimeout)); err != nil {
+		return err
+	}
+
+	state, files, err := receiveHandover(conn)
+	if err != nil {
+		return err
+	}
+	defer closeFiles(files)
+
+	fifos := holdFifos(state)
+	defer closeFiles(fifos)
+	state.Fifos = len(fifos)
+
+	cmd, err := newHandoverCommand(state)
+	if err != nil {
+		return err
+	}
+	cmd.ExtraFiles = append(append(cmd.ExtraFiles, files...), fifos...)
+
+	buf, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(state.Bundle, handoverFile), buf, 0600); err != nil {
+		return err
+	}
+
+	// The running shim exits once acknowledged, no request must be read
+	// from the ttrpc connections before.
+	if _, err := conn.Write([]byte{0}); err != nil {
+		return err
+	}
+	if _, err := io.Copy(io.Discard, conn); err != nil {
+		return fmt.Errorf("waiting for the shim of sandbox %s to exit: %w", sandboxID, err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	if err := cdshim.WritePidFile(filepath.Join(state.Bundle, "shim.pid"), cmd.Process.Pid); err != nil {
+		return err
+	}
+
+	// The shim is started from the cgroup of the caller, it is run in the
+	// cgroup of the shim it replaces instead.
+	if err := moveToCgroups(state.Cgroups, cmd.Process.Pid); err != nil {
+		return fmt.Errorf("moving the shim of sandbox %s to its cgroup: %w", sandboxID, err)
+	}
+
+	return nil
+}
+
+// newHandoverCommand returns the command starting the shim taking the
+// sandbox over, it is started like the shim it replaces, with its arguments
+// and its environment.
+func newHandoverCommand(state *handoverState) (*sysexec.Cmd, error) {
+	self, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := sysexec.Command(self, state.Args...)
+	cmd.Dir = state.Bundle
+	cmd.Env = append(append([]string{}, state.Env...), handoverEnv+"=1")
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setpgid: true,
+	}
+
+	return cmd, nil
+}
+
+// readHandoverState reads the state handed over to the shim, from the
+// sandbox bundle.
+func readHandoverState() (*handoverState, error) {
+	buf, err := os.ReadFile(handoverFile)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(handoverFile)
+
+	var state handoverState
+	if err := json.Unmarshal(buf, &state); err != nil {
+		return nil, err
+	}
+
+	return &state, nil
+}
+
+// adoptSandbox takes over the sandbox handed over by the shim this one
+// replaces, then serves the ttrpc connections it accepted.
+func (s *service) adoptSandbox() error {
+	state, err := readHandoverState()
+	if err != nil {
+		return err
+	}
+
+	fifos := make([]*os.File, 0, state.Fifos)
+	for i := 0; i < state.Fifos; i++ {
+		fifos = append(fifos, os.NewFile(uintptr(handoverFirstFD+state.Connections+i), "fifo"))
+	}
+	defer closeFiles(fifos)
+
+	configPath, runtimeConfig, err := katautils.LoadConfiguration(state.ConfigPath, false)
+	if err != nil {
+		return err
+	}
+	s.config = &runtimeConfig
+	s.configPath = configPath
+	s.rootCtx = s.ctx
+
+	sandbox, err := vci.AdoptSandbox(s.ctx, s.id)
+	if err != nil {
+		return err
+	}
+	s.sandbox = sandbox
+
+	pid, err := s.sandbox.GetHypervisorPid()
+	if err != nil {
+		return err
+	}
+	s.hpid = uint32(pid)
+
+	if err := s.adoptContainers(s.ctx, state); err != nil {
+		return err
+	}
+
+	s.monitor, err = s.sandbox.Monitor(s.ctx)
+	if err != nil {
+		return err
+	}
+	if s.sandbox.CanRestart() {
+		s.vmRestart = newVMRestart()
+	}
+	go watchSandbox(s.ctx, s)
+	go watchOOMEvents(s.ctx, s)
+	go watchSeccompNotifications(s.ctx, s)
+	if events := s.sandbox.MultipathEvents(); events != nil {
+		go forwardMultipathEvents(s.ctx, s, events)
+	}
+	if violations := s.sandbox.SeccompViolations(); violations != nil {
+		go forwardSeccompViolations(s.ctx, s, violations)
+	}
+	s.startUsageRecorder(s.ctx)
+
+	if c, ok := s.containers[s.id]; ok {
+		// The management socket is left behind by the previous shim.
+		_ = cdshim.RemoveSocket(ServerSocketAddress(s.id))
+		go s.startManagementServer(s.ctx, c.spec)
+	}
+
+	for i := 0; i < state.Connections; i++ {
+		conn, err := net.FileConn(os.NewFile(uintptr(handoverFirstFD+i), "ttrpc"))
+		if err != nil {
+			shimLog.WithError(err).Warn("failed to adopt a ttrpc connection")
+			continue
+		}
+		go proxyTTRPCConn(conn, state.Address)
+	}
+
+	shimLog.WithField("containers", len(s.containers)).Info("sandbox adopted")
+	return nil
+}
+
+// adoptContainers restores the containers handed over, and copies the IO
+// streams of the running processes.
+func (s *service) adoptContainers(ctx context.Context, state *handoverState) error {
+	for _, hc := range state.Containers {
+		spec, err := compatoci.ParseConfigJSON(hc.Bundle)
+		if err != nil {
+			return err
+		}
+
+		c := &container{
+			s:           s,
+			spec:        &spec,
+			exitTime:    hc.ExitTime,
+			execs:       make(map[string]*exec),
+			exitIOch:    make(chan struct{}),
+			stdinCloser: make(chan struct{}),
+			exitCh:      make(chan uint32, 1),
+			id:          hc.ID,
+			stdin:       hc.Stdin,
+			stdout:      hc.Stdout,
+			stderr:      hc.Stderr,
+			bundle:      hc.Bundle,
+			checkpoint:  hc.Checkpoint,
+			cType:       hc.Type,
+			exit:        hc.Exit,
+			status:      hc.Status,
+			terminal:    hc.Terminal,
+			mounted:     hc.Mounted,
+		}
+		s.containers[c.id] = c
+
+		switch c.status {
+		case task.StatusRunning, task.StatusPaused:
+			if err := startContainerIO(ctx, s, c); err != nil {
+				return err
+			}
+			go wait(ctx, s, c, "")
+		case task.StatusStopped:
+			c.exitCh <- c.exit
+		}
+
+		for _, he := range hc.Execs {
+			execs := &exec{
+				container: c,
+				cmds:      he.Cmd,
+				tty: &tty{
+					stdin:    he.Stdin,
+					stdout:   he.Stdout,
+					stderr:   he.Stderr,
+					height:   he.Height,
+					width:    he.Width,
+					terminal: he.Terminal,
+				},
+				exitTime:    he.ExitTime,
+				exitIOch:    make(chan struct{}),
+				stdinCloser: make(chan struct{}),
+				exitCh:      make(chan uint32, 1),
+				id:          he.ProcessID,
+				exitCode:    he.ExitCode,
+				status:      he.Status,
+			}
+			c.execs[he.ID] = execs
+
+			switch execs.status {
+			case task.StatusRunning:
+				if err := startExecIO(ctx, s, c, execs, he.ID); err != nil {
+					return err
+				}
+				go wait(ctx, s, c, he.ID)
+			case task.StatusStopped:
+				execs.exitCh <- uint32(execs.exitCode)
+			}
+		}
+	}
+
+	return nil
+}
+
+// proxyTTRPCConn forwards a ttrpc connection accepted by the previous shim
+// to the ttrpc server of this one, until either side closes it.
+func proxyTTRPCConn(conn net.Conn, address string) {
+	defer conn.Close()
+
+	server, err := cdshim.AnonDialer(address, handoverTimeout)
+	if err != nil {
+		shimLog.WithError(err).Error("failed to connect to the ttrpc server")
+		return
+	}
+	defer server.Close()
+
+	done := make(chan struct{}, 2)
+	go func() {
+		io.Copy(server, conn)
+		done <- struct{}{}
+	}()
+	go func() {
+		io.Copy(conn, server)
+		done <- struct{}{}
+	}()
+	<-done
+}
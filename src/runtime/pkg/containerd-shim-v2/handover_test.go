@@ -0,0 +1,296 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/containerd/containerd/api/types/task"
+	taskAPI "github.com/containerd/containerd/runtime/v2/task"
+	"github.com/containerd/ttrpc"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/vcmock"
+)
+
+func TestHandoverStateRefused(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &service{
+		id:         testSandboxID,
+		containers: make(map[string]*container),
+	}
+	_, err := s.handoverState()
+	assert.Error(err)
+
+	s.sandbox = &vcmock.Sandbox{MockID: testSandboxID}
+	s.containers[testSandboxID] = &container{
+		id:     testSandboxID,
+		cType:  vc.PodSandbox,
+		status: task.StatusCreated,
+	}
+	_, err = s.handoverState()
+	assert.Error(err)
+
+	s.containers[testSandboxID].status = task.StatusRunning
+	s.containers[testContainerID] = &container{
+		id:     testContainerID,
+		cType:  vc.PodContainer,
+		status: task.StatusRunning,
+		host:   &hostContainer{},
+	}
+	_, err = s.handoverState()
+	assert.Error(err)
+
+	delete(s.containers, testContainerID)
+	s.quiescer.timer = time.NewTimer(time.Hour)
+	defer s.quiescer.timer.Stop()
+	_, err = s.handoverState()
+	assert.Error(err)
+}
+
+func TestTTRPCSocketFDs(t *testing.T) {
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "ttrpc.sock")
+	l, err := net.Listen("unix", path)
+	assert.NoError(err)
+	defer l.Close()
+
+	client, err := net.Dial("unix", path)
+	assert.NoError(err)
+	defer client.Close()
+	conn, err := l.Accept()
+	assert.NoError(err)
+	defer conn.Close()
+
+	fds, err := ttrpcSocketFDs("unix://" + path)
+	assert.NoError(err)
+	assert.Len(fds, 2)
+
+	_, err = ttrpcSocketFDs("unix://" + path + ".none")
+	assert.Error(err)
+}
+
+func TestSendReceiveHandover(t *testing.T) {
+	assert := assert.New(t)
+
+	socket := filepath.Join(t.TempDir(), "handover.sock")
+	l, err := net.ListenUnix("unix", &net.UnixAddr{Name: socket, Net: "unix"})
+	assert.NoError(err)
+	defer l.Close()
+
+	r, w, err := os.Pipe()
+	assert.NoError(err)
+	defer r.Close()
+	defer w.Close()
+
+	sent := &handoverState{
+		Args:    []string{"-id", testSandboxID},
+		Address: "unix:///run/containerd/s/test",
+		Env:     []string{"TTRPC_ADDRESS=/run/containerd/containerd.sock.ttrpc"},
+		Cgroups: []string{"/sys/fs/cgroup/system.slice/containerd.service"},
+		Containers: []handoverContainer{
+			{ID: testSandboxID, Type: vc.PodSandbox, Status: task.StatusRunning},
+		},
+	}
+
+	type received struct {
+		state *handoverState
+		files []*os.File
+		err   error
+	}
+	ch := make(chan received, 1)
+	go func() {
+		conn, err := l.AcceptUnix()
+		if err != nil {
+			ch <- received{err: err}
+			return
+		}
+		defer conn.Close()
+		state, files, err := receiveHandover(conn)
+		if err == nil {
+			_, err = conn.Write([]byte{0})
+		}
+		ch <- received{state, files, err}
+	}()
+
+	conn, err := dialHandover(socket)
+	assert.NoError(err)
+	defer conn.Close()
+	assert.NoError(sendHandover(conn, sent, []int{int(r.Fd()), int(w.Fd())}))
+
+	got := <-ch
+	assert.NoError(got.err)
+	defer closeFiles(got.files)
+	assert.Len(got.files, 2)
+	assert.Equal(1, got.state.Connections)
+	assert.Equal(sent.Args, got.state.Args)
+	assert.Equal(sent.Address, got.state.Address)
+	assert.Equal(sent.Containers, got.state.Containers)
+	assert.Equal(sent.Env, got.state.Env)
+	assert.Equal(sent.Cgroups, got.state.Cgroups)
+}
+
+func TestShimCgroups(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, d := range []struct {
+		content string
+		cgroups []string
+	}{
+		{"0::/system.slice/containerd.service\n", []string{"/sys/fs/cgroup/system.slice/containerd.service"}},
+		{"4:cpu,cpuacct:/kata\n1:name=systemd:/system.slice/containerd.service\n0::/\n", []string{
+			"/sys/fs/cgroup/cpu,cpuacct/kata",
+			"/sys/fs/cgroup/systemd/system.slice/containerd.service",
+			"/sys/fs/cgroup",
+		}},
+	} {
+		path := filepath.Join(t.TempDir(), "cgroup")
+		assert.NoError(os.WriteFile(path, []byte(d.content), 0600))
+		cgroups, err := shimCgroups(path)
+		assert.NoError(err)
+		assert.Equal(d.cgroups, cgroups)
+	}
+
+	path := filepath.Join(t.TempDir(), "cgroup")
+	assert.NoError(os.WriteFile(path, []byte("invalid\n"), 0600))
+	_, err := shimCgroups(path)
+	assert.Error(err)
+}
+
+func TestMoveToCgroups(t *testing.T) {
+	assert := assert.New(t)
+
+	dirs := []string{t.TempDir(), t.TempDir()}
+	assert.NoError(moveToCgroups(dirs, 42))
+	for _, dir := range dirs {
+		buf, err := os.ReadFile(filepath.Join(dir, "cgroup.procs"))
+		assert.NoError(err)
+		assert.Equal("42", string(buf))
+	}
+}
+
+func TestDeferExit(t *testing.T) {
+	assert := assert.New(t)
+
+	logger := logrus.StandardLogger()
+	exitFunc := logger.ExitFunc
+	defer func() {
+		logger.ExitFunc = exitFunc
+	}()
+
+	exited := make(chan int, 1)
+	logger.ExitFunc = func(code int) {
+		exited <- code
+	}
+
+	release := deferExit()
+	go logger.Exit(3)
+	select {
+	case <-exited:
+		assert.Fail("exit not deferred")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	release()
+	select {
+	case code := <-exited:
+		assert.Equal(3, code)
+	case <-time.After(handoverTimeout):
+		assert.Fail("exit not released")
+	}
+
+	// the exit function is restored
+	logger.Exit(4)
+	assert.Equal(4, <-exited)
+	release()
+}
+
+func TestStopTTRPCRequestInFlight(t *testing.T) {
+	assert := assert.New(t)
+
+	server := shimTTRPC.server
+	defer func() {
+		shimTTRPC.server = server
+	}()
+
+	address := "unix://" + filepath.Join(t.TempDir(), "ttrpc.sock")
+	l, err := net.Listen("unix", address[len("unix://"):])
+	assert.NoError(err)
+	// like the listener inherited by the shim
+	l.(*net.UnixListener).SetUnlinkOnClose(false)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the shim handing the sandbox over
+	old := &service{ctx: ctx, rootCtx: ctx, hpid: 1}
+	shimTTRPC.server, err = ttrpc.NewServer()
+	assert.NoError(err)
+	taskAPI.RegisterTaskService(shimTTRPC.server, old)
+	go shimTTRPC.server.Serve(ctx, l)
+
+	conn, err := net.Dial("unix", address[len("unix://"):])
+	assert.NoError(err)
+	client := ttrpc.NewClient(conn)
+	defer client.Close()
+	task := taskAPI.NewTaskClient(client)
+
+	resp, err := task.Connect(ctx, &taskAPI.ConnectRequest{ID: testSandboxID})
+	assert.NoError(err)
+	assert.Equal(uint32(1), resp.TaskPid)
+
+	files, err := dupTTRPCSockets(address)
+	assert.NoError(err)
+	defer closeFiles(files)
+	assert.Len(files, 2)
+
+	shimTTRPC.Lock()
+	defer shimTTRPC.Unlock()
+	assert.NoError(stopTTRPC(address, len(files)))
+
+	// the request sent during the handover is not read by the stopped
+	// server
+	inFlight := make(chan *taskAPI.ConnectResponse, 1)
+	go func() {
+		resp, err := task.Connect(ctx, &taskAPI.ConnectRequest{ID: testSandboxID})
+		if err == nil {
+			inFlight <- resp
+		}
+	}()
+	select {
+	case <-inFlight:
+		assert.Fail("request answered by the stopped ttrpc server")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// but by the shim the sockets are handed over to
+	newServer, err := ttrpc.NewServer()
+	assert.NoError(err)
+	defer newServer.Close()
+	taskAPI.RegisterTaskService(newServer, &service{ctx: ctx, rootCtx: ctx, hpid: 2})
+	newListener, err := net.FileListener(files[0])
+	assert.NoError(err)
+	go newServer.Serve(ctx, newListener)
+	newConn, err := net.FileConn(files[1])
+	assert.NoError(err)
+	go proxyTTRPCConn(newConn, address)
+
+	select {
+	case resp := <-inFlight:
+		assert.Equal(uint32(2), resp.TaskPid)
+	case <-time.After(handoverTimeout):
+		assert.Fail("request in flight not answered")
+	}
+}
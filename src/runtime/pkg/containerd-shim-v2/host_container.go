@@ -0,0 +1,404 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	sysexec "os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/containerd/containerd/api/types/task"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/mount"
+	ctrAnnotations "github.com/containerd/containerd/pkg/cri/annotations"
+	taskAPI "github.com/containerd/containerd/runtime/v2/task"
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/resourcecontrol"
+	vcAnnotations "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations"
+)
+
+// The host containers of a pod are run on the host by an OCI runtime such as
+// runc, next to the VM running the other containers. They are meant for the
+// infrastructure containers, such as the service mesh sidecars, that gain
+// nothing from the isolation of the VM. They join the network namespace of
+// the pod, and share the emptyDir volumes of the pod through the shared
+// filesystem.
+const (
+	hostContainerDir      = "host"
+	hostContainerStateDir = "state"
+	hostContainerPidFile  = "pid"
+)
+
+// hostContainerCapabilities are the capabilities a host container may have,
+// the default capabilities of the containers. A host container asking for
+// more, such as a privileged container, is rejected as it would get them on
+// the host rather than in the VM.
+var hostContainerCapabilities = map[string]bool{
+	"CAP_AUDIT_WRITE":      true,
+	"CAP_CHOWN":            true,
+	"CAP_DAC_OVERRIDE":     true,
+	"CAP_FOWNER":           true,
+	"CAP_FSETID":           true,
+	"CAP_KILL":             true,
+	"CAP_MKNOD":            true,
+	"CAP_NET_BIND_SERVICE": true,
+	"CAP_NET_RAW":          true,
+	"CAP_SETFCAP":          true,
+	"CAP_SETGID":           true,
+	"CAP_SETPCAP":          true,
+	"CAP_SETUID":           true,
+	"CAP_SYS_CHROOT":       true,
+}
+
+// hostContainer is a container run on the host by the host container
+// runtime.
+type hostContainer struct {
+	cmd *sysexec.Cmd
+	// runtime is the path of the OCI runtime running the container
+	runtime string
+	// bundle is the bundle of the container given to the runtime, with
+	// the spec adapted to the host
+	bundle string
+	id     string
+	// systemdCgroup tells if the cgroup of the container is managed by
+	// systemd
+	systemdCgroup bool
+	// pipes are the shim ends of the IO pipes of the container. They are
+	// owned by the shim rather than by cmd, so that waiting for the
+	// runtime does not close them under the IO copy.
+	pipes []*os.File
+}
+
+// hostContainerNames returns the names of the containers run on the host,
+// as given by the sandbox annotation.
+func hostContainerNames(value string) []string {
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// hostContainerAllowed tells if the operator allows the container of name,
+// running image, on the host. An empty image matches no image pattern.
+func (s *service) hostContainerAllowed(name, image string) bool {
+	for _, n := range s.config.HostContainerNames {
+		if n == name {
+			return true
+		}
+	}
+	if image == "" {
+		return false
+	}
+	for _, pattern := range s.config.HostContainerImages {
+		if ok, _ := path.Match(pattern, image); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// hostContainersEnabled tells if the sandbox of the spec may have containers
+// run on the host. The images of the containers are not known yet, so any
+// annotated container may match the image patterns.
+func (s *service) hostContainersEnabled(ociSpec *specs.Spec) bool {
+	if s.config.HostContainerRuntime == "" {
+		return false
+	}
+	for _, name := range hostContainerNames(ociSpec.Annotations[vcAnnotations.HostContainers]) {
+		if len(s.config.HostContainerImages) > 0 || s.hostContainerAllowed(name, "") {
+			return true
+		}
+	}
+	return false
+}
+
+// isHostContainer tells if the container of the spec is run on the host: it
+// must be listed by the sandbox annotation and allowed by the operator.
+func (s *service) isHostContainer(ociSpec *specs.Spec) bool {
+	if s.config.HostContainerRuntime == "" || s.sandbox == nil {
+		return false
+	}
+
+	value, err := s.sandbox.Annotations(vcAnnotations.HostContainers)
+	if err != nil {
+		return false
+	}
+
+	name := ociSpec.Annotations[ctrAnnotations.ContainerName]
+	for _, n := range hostContainerNames(value) {
+		if n == name {
+			return s.hostContainerAllowed(name, ociSpec.Annotations[ctrAnnotations.ImageName])
+		}
+	}
+	return false
+}
+
+// checkHostContainerSpec rejects the specs asking for more privileges than
+// the default ones, as a host container gets them on the host. The OCI spec
+// does not tell if a container is privileged, so the privileged containers
+// are rejected for their capabilities, devices or lack of masked paths.
+func checkHostContainerSpec(ociSpec *specs.Spec) error {
+	if ociSpec.Process != nil && ociSpec.Process.Capabilities != nil {
+		caps := ociSpec.Process.Capabilities
+		for _, set := range [][]string{caps.Bounding, caps.Effective, caps.Inheritable, caps.Permitted, caps.Ambient} {
+			for _, c := range set {
+				if !hostContainerCapabilities[c] {
+					return fmt.Errorf("host container cannot have capability %s", c)
+				}
+			}
+		}
+	}
+
+	if ociSpec.Linux == nil {
+		return fmt.Errorf("host container must have a Linux spec")
+	}
+	if len(ociSpec.Linux.Devices) > 0 {
+		return fmt.Errorf("host container cannot have devices")
+	}
+	if len(ociSpec.Linux.MaskedPaths) == 0 {
+		return fmt.Errorf("host container cannot be privileged")
+	}
+
+	return nil
+}
+
+// createHostContainer writes the bundle of a host container. The spec is
+// the one of the container, except for its namespaces: the container joins
+// the network namespace of the pod on the host, and gets its own PID, IPC
+// and UTS namespaces instead of the ones of the hypervisor.
+func createHostContainer(s *service, r *taskAPI.CreateTaskRequest, ociSpec *specs.Spec) (_ *hostContainer, err error) {
+	if r.Terminal {
+		return nil, fmt.Errorf("host container %s cannot have a terminal", r.ID)
+	}
+	if err := checkHostContainerSpec(ociSpec); err != nil {
+		return nil, fmt.Errorf("invalid host container %s: %w", r.ID, err)
+	}
+
+	rootfs := filepath.Join(r.Bundle, "rootfs")
+	if err := doMount(r.Rootfs, rootfs); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			if err2 := mount.UnmountAll(rootfs, 0); err2 != nil {
+				shimLog.WithError(err2).WithField("container", r.ID).Warn("failed to cleanup rootfs mount")
+			}
+		}
+	}()
+
+	spec := *ociSpec
+	spec.Root = &specs.Root{
+		Path:     rootfs,
+		Readonly: ociSpec.Root != nil && ociSpec.Root.Readonly,
+	}
+
+	if ociSpec.Linux != nil {
+		linux := *ociSpec.Linux
+		linux.Namespaces = nil
+		for _, ns := range ociSpec.Linux.Namespaces {
+			switch ns.Type {
+			case specs.NetworkNamespace:
+				ns.Path = s.sandbox.GetNetNs()
+			case specs.PIDNamespace, specs.IPCNamespace, specs.UTSNamespace:
+				ns.Path = ""
+			}
+			linux.Namespaces = append(linux.Namespaces, ns)
+		}
+		spec.Linux = &linux
+	}
+
+	h := &hostContainer{
+		runtime:       s.config.HostContainerRuntime,
+		bundle:        filepath.Join(r.Bundle, hostContainerDir),
+		id:            r.ID,
+		systemdCgroup: spec.Linux != nil && resourcecontrol.IsSystemdCgroup(spec.Linux.CgroupsPath),
+	}
+
+	if err := os.MkdirAll(filepath.Join(h.bundle, hostContainerStateDir), 0700); err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(h.bundle, "config.json"), data, 0600); err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+// command returns the command running the runtime with args.
+func (h *hostContainer) command(args ...string) *sysexec.Cmd {
+	global := []string{"--root", filepath.Join(h.bundle, hostContainerStateDir)}
+	if h.systemdCgroup {
+		global = append(global, "--systemd-cgroup")
+	}
+	return sysexec.Command(h.runtime, append(global, args...)...)
+}
+
+// start runs the container. The runtime stays in the foreground until the
+// container exits, and exits with its status. Only the IO streams the
+// container has are piped, the others are redirected to the null device.
+func (h *hostContainer) start(withStdin, withStdout, withStderr bool) (stdin io.WriteCloser, stdout, stderr io.Reader, err error) {
+	h.cmd = h.command("run", "--bundle", h.bundle, "--pid-file", filepath.Join(h.bundle, hostContainerPidFile), h.id)
+	// Keep the runtime, and so the container, alive when the shim is
+	// signalled.
+	h.cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+
+	// The runtime ends of the pipes are closed once it is started, or
+	// failed to.
+	var runtimeEnds []*os.File
+	defer func() {
+		for _, f := range runtimeEnds {
+			f.Close()
+		}
+		if err != nil {
+			h.closePipes()
+		}
+	}()
+	if withStdin {
+		r, w, err := os.Pipe()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		runtimeEnds = append(runtimeEnds, r)
+		h.pipes = append(h.pipes, w)
+		h.cmd.Stdin = r
+		stdin = w
+	}
+	if withStdout {
+		r, w, err := os.Pipe()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		runtimeEnds = append(runtimeEnds, w)
+		h.pipes = append(h.pipes, r)
+		h.cmd.Stdout = w
+		stdout = r
+	}
+	if withStderr {
+		r, w, err := os.Pipe()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		runtimeEnds = append(runtimeEnds, w)
+		h.pipes = append(h.pipes, r)
+		h.cmd.Stderr = w
+		stderr = r
+	}
+
+	if err := h.cmd.Start(); err != nil {
+		return nil, nil, nil, err
+	}
+
+	return stdin, stdout, stderr, nil
+}
+
+// closePipes closes the shim ends of the IO pipes.
+func (h *hostContainer) closePipes() {
+	for _, f := range h.pipes {
+		f.Close()
+	}
+	h.pipes = nil
+}
+
+// wait waits for the container to exit and returns its exit status.
+func (h *hostContainer) wait() (int32, error) {
+	if h.cmd == nil {
+		return exitCode255, fmt.Errorf("host container %s is not started", h.id)
+	}
+
+	err := h.cmd.Wait()
+	if exitErr, ok := err.(*sysexec.ExitError); ok {
+		if code := exitErr.ExitCode(); code >= 0 {
+			return int32(code), nil
+		}
+	}
+	if err != nil {
+		return exitCode255, err
+	}
+	return 0, nil
+}
+
+// pid returns the host PID of the container process.
+func (h *hostContainer) pid() (int, error) {
+	data, err := os.ReadFile(filepath.Join(h.bundle, hostContainerPidFile))
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
+
+// signal sends signum to the processes of the container.
+func (h *hostContainer) signal(signum syscall.Signal) error {
+	if out, err := h.command("kill", "--all", h.id, strconv.Itoa(int(signum))).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to signal host container %s: %s: %w", h.id, strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
+// delete deletes the container from the runtime, along with its bundle.
+func (h *hostContainer) delete() error {
+	if out, err := h.command("delete", "--force", h.id).CombinedOutput(); err != nil {
+		shimLog.WithError(err).WithField("container", h.id).Warnf("failed to delete host container: %s", strings.TrimSpace(string(out)))
+	}
+	h.closePipes()
+	return os.RemoveAll(h.bundle)
+}
+
+// startHostContainer starts a host container and copies its IO streams.
+func startHostContainer(ctx context.Context, s *service, c *container) error {
+	stdin, stdout, stderr, err := c.host.start(c.stdin != "", c.stdout != "", c.stderr != "")
+	if err != nil {
+		return err
+	}
+	if stdin == nil {
+		stdin = nopWriteCloser{}
+	}
+
+	c.status = task.StatusRunning
+
+	if err := copyContainerIO(ctx, s, c, stdin, stdout, stderr); err != nil {
+		return err
+	}
+
+	go wait(ctx, s, c, "")
+
+	return nil
+}
+
+// checkInVM returns an error for the operations only supported by the
+// containers run in the VM.
+func (c *container) checkInVM(op string) error {
+	if c.host != nil {
+		return errdefs.ToGRPCf(errdefs.ErrNotImplemented, "%s of host container %s", op, c.id)
+	}
+	return nil
+}
+
+type nopWriteCloser struct{}
+
+func (nopWriteCloser) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
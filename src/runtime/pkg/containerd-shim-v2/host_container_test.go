@@ -0,0 +1,214 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ctrAnnotations "github.com/containerd/containerd/pkg/cri/annotations"
+	taskAPI "github.com/containerd/containerd/runtime/v2/task"
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/oci"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/compatoci"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/vcmock"
+)
+
+func TestIsHostContainer(t *testing.T) {
+	assert := assert.New(t)
+
+	sandbox := &vcmock.Sandbox{
+		MockID: testSandboxID,
+		MockAnnotations: map[string]string{
+			annotations.HostContainers: "istio-proxy, linkerd-proxy",
+		},
+	}
+	s := &service{
+		sandbox: sandbox,
+		config:  &oci.RuntimeConfig{},
+	}
+
+	sandboxSpec := &specs.Spec{Annotations: sandbox.MockAnnotations}
+	spec := &specs.Spec{
+		Annotations: map[string]string{
+			ctrAnnotations.ContainerName: "istio-proxy",
+		},
+	}
+
+	// disabled without a host container runtime
+	assert.False(s.hostContainersEnabled(sandboxSpec))
+	assert.False(s.isHostContainer(spec))
+
+	// disabled by the annotation alone
+	s.config.HostContainerRuntime = "/usr/bin/runc"
+	assert.False(s.hostContainersEnabled(sandboxSpec))
+	assert.False(s.isHostContainer(spec))
+
+	s.config.HostContainerNames = []string{"istio-proxy"}
+	assert.True(s.hostContainersEnabled(sandboxSpec))
+	assert.True(s.isHostContainer(spec))
+
+	spec.Annotations[ctrAnnotations.ContainerName] = "linkerd-proxy"
+	assert.False(s.isHostContainer(spec))
+
+	s.config.HostContainerImages = []string{"cr.l5d.io/linkerd/proxy:*"}
+	assert.False(s.isHostContainer(spec))
+	spec.Annotations[ctrAnnotations.ImageName] = "cr.l5d.io/linkerd/proxy:stable-2.14.0"
+	assert.True(s.isHostContainer(spec))
+	spec.Annotations[ctrAnnotations.ImageName] = "docker.io/library/busybox:latest"
+	assert.False(s.isHostContainer(spec))
+
+	spec.Annotations[ctrAnnotations.ContainerName] = "app"
+	assert.False(s.isHostContainer(spec))
+
+	assert.False(s.hostContainersEnabled(&specs.Spec{}))
+}
+
+func TestCreateHostContainer(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &service{
+		sandbox: &vcmock.Sandbox{
+			MockID:    testSandboxID,
+			MockNetNs: "/var/run/netns/cni-test",
+		},
+		config: &oci.RuntimeConfig{
+			HostContainerRuntime: "/usr/bin/runc",
+		},
+	}
+
+	spec := &specs.Spec{
+		Process: &specs.Process{Args: []string{"pilot-agent"}},
+		Root:    &specs.Root{Path: "rootfs", Readonly: true},
+		Linux: &specs.Linux{
+			CgroupsPath: "kubepods-besteffort.slice:cri-containerd:" + testContainerID,
+			MaskedPaths: []string{"/proc/kcore"},
+			Namespaces: []specs.LinuxNamespace{
+				{Type: specs.NetworkNamespace, Path: "/proc/1234/ns/net"},
+				{Type: specs.PIDNamespace, Path: "/proc/1234/ns/pid"},
+				{Type: specs.IPCNamespace, Path: "/proc/1234/ns/ipc"},
+				{Type: specs.UTSNamespace, Path: "/proc/1234/ns/uts"},
+				{Type: specs.MountNamespace},
+			},
+		},
+	}
+
+	r := &taskAPI.CreateTaskRequest{
+		ID:     testContainerID,
+		Bundle: t.TempDir(),
+	}
+
+	h, err := createHostContainer(s, r, spec)
+	assert.NoError(err)
+	assert.True(h.systemdCgroup)
+	assert.Equal(filepath.Join(r.Bundle, hostContainerDir), h.bundle)
+
+	hostSpec, err := compatoci.ParseConfigJSON(h.bundle)
+	assert.NoError(err)
+	assert.Equal(filepath.Join(r.Bundle, "rootfs"), hostSpec.Root.Path)
+	assert.True(hostSpec.Root.Readonly)
+	assert.Equal([]specs.LinuxNamespace{
+		{Type: specs.NetworkNamespace, Path: "/var/run/netns/cni-test"},
+		{Type: specs.PIDNamespace},
+		{Type: specs.IPCNamespace},
+		{Type: specs.UTSNamespace},
+		{Type: specs.MountNamespace},
+	}, hostSpec.Linux.Namespaces)
+
+	// the spec of the container is left untouched
+	assert.Equal("/proc/1234/ns/pid", spec.Linux.Namespaces[1].Path)
+
+	r.Terminal = true
+	_, err = createHostContainer(s, r, spec)
+	assert.Error(err)
+}
+
+func TestCheckHostContainerSpec(t *testing.T) {
+	assert := assert.New(t)
+
+	spec := &specs.Spec{
+		Process: &specs.Process{
+			Capabilities: &specs.LinuxCapabilities{
+				Bounding:  []string{"CAP_CHOWN", "CAP_NET_BIND_SERVICE"},
+				Effective: []string{"CAP_CHOWN", "CAP_NET_BIND_SERVICE"},
+				Permitted: []string{"CAP_CHOWN", "CAP_NET_BIND_SERVICE"},
+			},
+		},
+		Linux: &specs.Linux{
+			MaskedPaths: []string{"/proc/kcore"},
+		},
+	}
+	assert.NoError(checkHostContainerSpec(spec))
+
+	spec.Process.Capabilities.Ambient = []string{"CAP_NET_ADMIN"}
+	assert.Error(checkHostContainerSpec(spec))
+	spec.Process.Capabilities.Ambient = nil
+
+	spec.Process.Capabilities.Bounding = append(spec.Process.Capabilities.Bounding, "CAP_SYS_ADMIN")
+	assert.Error(checkHostContainerSpec(spec))
+	spec.Process.Capabilities.Bounding = spec.Process.Capabilities.Effective
+
+	spec.Linux.Devices = []specs.LinuxDevice{{Path: "/dev/kvm", Type: "c", Major: 10, Minor: 232}}
+	assert.Error(checkHostContainerSpec(spec))
+	spec.Linux.Devices = nil
+
+	// the privileged containers have no masked paths
+	spec.Linux.MaskedPaths = nil
+	assert.Error(checkHostContainerSpec(spec))
+
+	assert.Error(checkHostContainerSpec(&specs.Spec{}))
+}
+
+func TestHostContainerStartIO(t *testing.T) {
+	assert := assert.New(t)
+
+	// the runtime stands for the container, writing to its streams
+	runtime := filepath.Join(t.TempDir(), "runtime")
+	err := os.WriteFile(runtime, []byte("#!/bin/sh\nread line\necho \"$line\"\necho err >&2\nexit 3\n"), 0700)
+	assert.NoError(err)
+
+	h := &hostContainer{
+		runtime: runtime,
+		bundle:  t.TempDir(),
+		id:      testContainerID,
+	}
+
+	stdin, stdout, stderr, err := h.start(true, true, true)
+	assert.NoError(err)
+	_, err = stdin.Write([]byte("out\n"))
+	assert.NoError(err)
+
+	// the streams stay readable once the runtime is waited for
+	code, err := h.wait()
+	assert.NoError(err)
+	assert.Equal(int32(3), code)
+
+	out, err := io.ReadAll(stdout)
+	assert.NoError(err)
+	assert.Equal("out\n", string(out))
+	out, err = io.ReadAll(stderr)
+	assert.NoError(err)
+	assert.Equal("err\n", string(out))
+
+	assert.NoError(h.delete())
+	assert.Empty(h.pipes)
+
+	// the streams of the container are only piped when it has them
+	h.bundle = t.TempDir()
+	stdin, stdout, stderr, err = h.start(false, true, false)
+	assert.NoError(err)
+	assert.Nil(stdin)
+	assert.NotNil(stdout)
+	assert.Nil(stderr)
+	_, err = h.wait()
+	assert.NoError(err)
+	assert.NoError(h.delete())
+}
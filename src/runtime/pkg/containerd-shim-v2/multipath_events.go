@@ -0,0 +1,58 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"context"
+
+	"github.com/containerd/typeurl"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/multipath"
+)
+
+// multipathPathEventTopic is the topic of the path events of the multipath
+// maps attached to a sandbox.
+const multipathPathEventTopic = "/kata/multipath/path"
+
+// MultipathPathEvent is a change of the state of a path of a multipath map
+// attached to a sandbox. It is published JSON encoded.
+type MultipathPathEvent struct {
+	SandboxID string `json:"sandbox_id"`
+	multipath.Event
+}
+
+func init() {
+	typeurl.Register(&MultipathPathEvent{}, "io.katacontainers.events", "MultipathPathEvent")
+}
+
+// forwardMultipathEvents publishes the path events of the sandbox until it
+// stops reporting them.
+func forwardMultipathEvents(ctx context.Context, s *service, events <-chan multipath.Event) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+
+			logger := shimLog.WithField("map", e.Map).WithField("path", e.Path).
+				WithField("state", e.State).WithField("active-paths", e.ActivePaths)
+			if e.ActivePaths == 0 {
+				logger.Error("multipath map lost all its paths")
+			} else if e.State != multipath.PathStateRunning {
+				logger.Warn("multipath path failed")
+			} else {
+				logger.Info("multipath path restored")
+			}
+
+			s.send(&MultipathPathEvent{
+				SandboxID: s.sandbox.ID(),
+				Event:     e,
+			})
+		}
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/containerd/typeurl"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/multipath"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/vcmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForwardMultipathEvents(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &service{
+		sandbox: &vcmock.Sandbox{MockID: testSandboxID},
+		events:  make(chan interface{}, 1),
+	}
+
+	events := make(chan multipath.Event, 1)
+	event := multipath.Event{Map: "mpatha", Path: "sdb", State: "offline", ActivePaths: 1, TotalPaths: 2}
+	events <- event
+	close(events)
+
+	forwardMultipathEvents(context.Background(), s, events)
+
+	e := <-s.events
+	assert.Equal(&MultipathPathEvent{SandboxID: testSandboxID, Event: event}, e)
+	assert.Equal(multipathPathEventTopic, getTopic(e))
+
+	any, err := typeurl.MarshalAny(e)
+	assert.NoError(err)
+	var decoded map[string]interface{}
+	assert.NoError(json.Unmarshal(any.Value, &decoded))
+	assert.Equal(testSandboxID, decoded["sandbox_id"])
+	assert.Equal("sdb", decoded["path"])
+}
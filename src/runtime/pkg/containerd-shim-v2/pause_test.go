@@ -11,7 +11,9 @@ import (
 	"testing"
 
 	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/runtime/v2/runc/options"
 	taskAPI "github.com/containerd/containerd/runtime/v2/task"
+	"github.com/containerd/typeurl"
 
 	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/vcmock"
@@ -201,3 +203,51 @@ func TestResumeContainerFail(t *testing.T) {
 	_, err := s.Resume(ctx, reqResume)
 	assert.Error(err)
 }
+
+func TestCheckpointContainer(t *testing.T) {
+	assert := assert.New(t)
+	var err error
+
+	var checkpointOpts vc.CheckpointOptions
+	sandbox := &vcmock.Sandbox{
+		MockID: testSandboxID,
+		CheckpointContainerFunc: func(contID, imageDir string, opts vc.CheckpointOptions) error {
+			checkpointOpts = opts
+			return nil
+		},
+	}
+
+	s := &service{
+		id:         testSandboxID,
+		sandbox:    sandbox,
+		containers: make(map[string]*container),
+	}
+
+	reqCreate := &taskAPI.CreateTaskRequest{
+		ID: testContainerID,
+	}
+	s.containers[testContainerID], err = newContainer(s, reqCreate, vc.PodContainer, nil, true)
+	assert.NoError(err)
+
+	anyOpts, err := typeurl.MarshalAny(&options.CheckpointOptions{
+		Exit:    true,
+		OpenTcp: true,
+	})
+	assert.NoError(err)
+
+	reqCheckpoint := &taskAPI.CheckpointTaskRequest{
+		ID:      testContainerID,
+		Path:    t.TempDir(),
+		Options: anyOpts,
+	}
+	ctx := namespaces.WithNamespace(context.Background(), "UnitTest")
+
+	_, err = s.Checkpoint(ctx, reqCheckpoint)
+	assert.NoError(err)
+	assert.Equal(vc.CheckpointOptions{TCPEstablished: true}, checkpointOpts)
+
+	// The sandbox container cannot be checkpointed.
+	s.containers[testContainerID].cType = vc.PodSandbox
+	_, err = s.Checkpoint(ctx, reqCheckpoint)
+	assert.Error(err)
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// defaultQuiesceTimeout is how long the sandbox stays quiesced for at most
+// when the quiesce request does not tell.
+const defaultQuiesceTimeout = 60 * time.Second
+
+// unquiesceRetryInterval is how long the sandbox stays quiesced for when it
+// fails to be unquiesced, before trying again.
+var unquiesceRetryInterval = 5 * time.Second
+
+// QuiesceRequest is the body of the quiesce requests.
+type QuiesceRequest struct {
+	// Timeout in seconds after which the sandbox is unquiesced if it has
+	// not been, defaultQuiesceTimeout when 0.
+	Timeout uint32
+}
+
+// quiescer holds the writes of the containers of the sandbox while their
+// volumes are snapshotted. The sandbox is unquiesced once the timeout of the
+// quiesce request expires, so that the containers do not hang forever when
+// the snapshot tooling fails to unquiesce it.
+type quiescer struct {
+	mu    sync.Mutex
+	timer *time.Timer
+}
+
+func (s *service) quiesceSandbox(ctx context.Context, timeout time.Duration) error {
+	s.quiescer.mu.Lock()
+	defer s.quiescer.mu.Unlock()
+
+	if s.quiescer.timer != nil {
+		// Already quiesced, only push the timeout back.
+		s.quiescer.timer.Reset(timeout)
+		return nil
+	}
+
+	if err := s.sandbox.Quiesce(ctx); err != nil {
+		return err
+	}
+
+	s.quiescer.timer = time.AfterFunc(timeout, func() {
+		shimMgtLog.WithField("timeout", timeout).Warn("the quiesce timeout expired, unquiescing the sandbox")
+		if err := s.unquiesceSandbox(context.Background()); err != nil {
+			shimMgtLog.WithError(err).Error("failed to unquiesce the sandbox")
+		}
+	})
+	return nil
+}
+
+func (s *service) unquiesceSandbox(ctx context.Context) error {
+	s.quiescer.mu.Lock()
+	defer s.quiescer.mu.Unlock()
+
+	if s.quiescer.timer == nil {
+		return nil
+	}
+
+	// The timer is armed again, so that the sandbox is unquiesced even
+	// when the timer already expired and the unquiesce is not requested
+	// again.
+	if err := s.sandbox.Unquiesce(ctx); err != nil {
+		s.quiescer.timer.Reset(unquiesceRetryInterval)
+		return err
+	}
+
+	s.quiescer.timer.Stop()
+	s.quiescer.timer = nil
+	return nil
+}
+
+// serveQuiesce handles the /quiesce requests, it freezes the filesystems of
+// the containers and flushes the drives of the sandbox.
+func (s *service) serveQuiesce(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var quiesceReq QuiesceRequest
+	body, err := io.ReadAll(r.Body)
+	if err == nil && len(body) != 0 {
+		err = json.Unmarshal(body, &quiesceReq)
+	}
+	if err != nil {
+		shimMgtLog.WithError(err).Error("failed to read the quiesce request")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	timeout := time.Duration(quiesceReq.Timeout) * time.Second
+	if timeout == 0 {
+		timeout = defaultQuiesceTimeout
+	}
+
+	if err := s.quiesceSandbox(r.Context(), timeout); err != nil {
+		shimMgtLog.WithError(err).Error("failed to quiesce the sandbox")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+	w.Write([]byte(""))
+}
+
+// serveUnquiesce handles the /unquiesce requests, it thaws the filesystems
+// of the containers.
+func (s *service) serveUnquiesce(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := s.unquiesceSandbox(r.Context()); err != nil {
+		shimMgtLog.WithError(err).Error("failed to unquiesce the sandbox")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+	w.Write([]byte(""))
+}
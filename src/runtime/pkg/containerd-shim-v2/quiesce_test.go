@@ -0,0 +1,112 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/vcmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServeQuiesce(t *testing.T) {
+	assert := assert.New(t)
+
+	var quiesced, unquiesced int32
+	sandbox := &vcmock.Sandbox{
+		MockID: testSandboxID,
+		QuiesceFunc: func() error {
+			atomic.AddInt32(&quiesced, 1)
+			return nil
+		},
+		UnquiesceFunc: func() error {
+			atomic.AddInt32(&unquiesced, 1)
+			return nil
+		},
+	}
+
+	s := &service{
+		id:         testSandboxID,
+		sandbox:    sandbox,
+		containers: make(map[string]*container),
+	}
+
+	rr := httptest.NewRecorder()
+	s.serveQuiesce(rr, httptest.NewRequest(http.MethodGet, QuiesceUrl, nil))
+	assert.Equal(http.StatusMethodNotAllowed, rr.Code)
+
+	rr = httptest.NewRecorder()
+	s.serveQuiesce(rr, httptest.NewRequest(http.MethodPut, QuiesceUrl, strings.NewReader("{")))
+	assert.Equal(http.StatusBadRequest, rr.Code)
+
+	// A second quiesce only pushes the timeout back.
+	for i := 0; i < 2; i++ {
+		rr = httptest.NewRecorder()
+		s.serveQuiesce(rr, httptest.NewRequest(http.MethodPut, QuiesceUrl, nil))
+		assert.Equal(http.StatusOK, rr.Code)
+	}
+	assert.Equal(int32(1), atomic.LoadInt32(&quiesced))
+
+	for i := 0; i < 2; i++ {
+		rr = httptest.NewRecorder()
+		s.serveUnquiesce(rr, httptest.NewRequest(http.MethodPut, UnquiesceUrl, nil))
+		assert.Equal(http.StatusOK, rr.Code)
+	}
+	assert.Equal(int32(1), atomic.LoadInt32(&unquiesced))
+
+	// The sandbox is unquiesced once the timeout expires.
+	assert.NoError(s.quiesceSandbox(context.Background(), 10*time.Millisecond))
+	assert.Eventually(func() bool {
+		return atomic.LoadInt32(&unquiesced) == 2
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestUnquiesceRetry(t *testing.T) {
+	assert := assert.New(t)
+
+	savedRetryInterval := unquiesceRetryInterval
+	defer func() {
+		unquiesceRetryInterval = savedRetryInterval
+	}()
+	unquiesceRetryInterval = 10 * time.Millisecond
+
+	var unquiesced int32
+	sandbox := &vcmock.Sandbox{
+		MockID: testSandboxID,
+		QuiesceFunc: func() error {
+			return nil
+		},
+		UnquiesceFunc: func() error {
+			if atomic.AddInt32(&unquiesced, 1) < 3 {
+				return errors.New("unquiesce failed")
+			}
+			return nil
+		},
+	}
+
+	s := &service{
+		id:         testSandboxID,
+		sandbox:    sandbox,
+		containers: make(map[string]*container),
+	}
+
+	// The timer is armed again when the sandbox fails to be unquiesced
+	// once it expired.
+	assert.NoError(s.quiesceSandbox(context.Background(), 10*time.Millisecond))
+	assert.Eventually(func() bool {
+		s.quiescer.mu.Lock()
+		defer s.quiescer.mu.Unlock()
+		return s.quiescer.timer == nil
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(int32(3), atomic.LoadInt32(&unquiesced))
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"context"
+
+	"github.com/containerd/typeurl"
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+)
+
+// sandboxSeccompViolationEventTopic is the topic of the seccomp violations
+// of the guests of the sandboxes.
+const sandboxSeccompViolationEventTopic = "/kata/sandbox/seccomp-violation"
+
+// SandboxSeccompViolationEvent is the first occurrence of a system call made
+// by an executable of the guest of a sandbox that its seccomp profile
+// blocks, or would block in audit mode. It is published JSON encoded.
+type SandboxSeccompViolationEvent struct {
+	SandboxID string `json:"sandbox_id"`
+	Comm      string `json:"comm"`
+	Exe       string `json:"exe"`
+	Arch      string `json:"arch"`
+	Syscall   int    `json:"syscall"`
+}
+
+func init() {
+	typeurl.Register(&SandboxSeccompViolationEvent{}, "io.katacontainers.events", "SandboxSeccompViolationEvent")
+}
+
+// forwardSeccompViolations publishes the seccomp violations of the sandbox
+// until it stops reporting them.
+func forwardSeccompViolations(ctx context.Context, s *service, violations <-chan vc.SeccompViolation) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case v, ok := <-violations:
+			if !ok {
+				return
+			}
+
+			s.send(&SandboxSeccompViolationEvent{
+				SandboxID: s.sandbox.ID(),
+				Comm:      v.Comm,
+				Exe:       v.Exe,
+				Arch:      v.Arch,
+				Syscall:   v.Syscall,
+			})
+		}
+	}
+}
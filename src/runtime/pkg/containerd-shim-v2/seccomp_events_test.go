@@ -0,0 +1,49 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/containerd/typeurl"
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/vcmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForwardSeccompViolations(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &service{
+		sandbox: &vcmock.Sandbox{MockID: testSandboxID},
+		events:  make(chan interface{}, 1),
+	}
+
+	violations := make(chan vc.SeccompViolation, 1)
+	violations <- vc.SeccompViolation{Comm: "mount", Exe: "/usr/bin/mount", Arch: "c000003e", Syscall: 165, Count: 1}
+	close(violations)
+
+	forwardSeccompViolations(context.Background(), s, violations)
+
+	e := <-s.events
+	assert.Equal(&SandboxSeccompViolationEvent{
+		SandboxID: testSandboxID,
+		Comm:      "mount",
+		Exe:       "/usr/bin/mount",
+		Arch:      "c000003e",
+		Syscall:   165,
+	}, e)
+	assert.Equal(sandboxSeccompViolationEventTopic, getTopic(e))
+
+	any, err := typeurl.MarshalAny(e)
+	assert.NoError(err)
+	var decoded map[string]interface{}
+	assert.NoError(json.Unmarshal(any.Value, &decoded))
+	assert.Equal(testSandboxID, decoded["sandbox_id"])
+	assert.Equal(float64(165), decoded["syscall"])
+}
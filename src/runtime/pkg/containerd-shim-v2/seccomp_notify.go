@@ -0,0 +1,167 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// The process of the container is blocked in its system call until the
+// daemon responds, the call is denied when it does not in time.
+const seccompNotifyTimeout = 5 * time.Second
+
+const (
+	seccompNotifyContinue = "continue"
+	seccompNotifyErrno    = "errno"
+	seccompNotifyValue    = "value"
+)
+
+var seccompNotifyLog = shimLog.WithField("subsystem", "seccomp-notify")
+
+// seccompNotifyRequest is sent to the daemon for every system call trapped by
+// a SCMP_ACT_NOTIFY rule, on a connection of its own.
+type seccompNotifyRequest struct {
+	ID          uint64   `json:"id"`
+	SandboxID   string   `json:"sandbox_id"`
+	ContainerID string   `json:"container_id"`
+	ExecID      string   `json:"exec_id,omitempty"`
+	Pid         uint32   `json:"pid"`
+	Syscall     string   `json:"syscall"`
+	Arch        string   `json:"arch"`
+	Args        []uint64 `json:"args"`
+}
+
+// seccompNotifyDecision is the response of the daemon: the system call runs
+// with "continue", fails with Errno with "errno", or returns Value without
+// running with "value".
+type seccompNotifyDecision struct {
+	Action string `json:"action"`
+	Errno  int32  `json:"errno,omitempty"`
+	Value  int64  `json:"value,omitempty"`
+}
+
+func (d *seccompNotifyDecision) response(id uint64) (vc.SeccompNotificationResponse, error) {
+	resp := vc.SeccompNotificationResponse{ID: id}
+	switch d.Action {
+	case seccompNotifyContinue:
+		resp.Allow = true
+	case seccompNotifyErrno:
+		if d.Errno <= 0 {
+			return resp, fmt.Errorf("invalid errno %d", d.Errno)
+		}
+		resp.Errno = d.Errno
+	case seccompNotifyValue:
+		resp.Value = d.Value
+	default:
+		return resp, fmt.Errorf("invalid action %q, expecting one of %s, %s or %s",
+			d.Action, seccompNotifyContinue, seccompNotifyErrno, seccompNotifyValue)
+	}
+	return resp, nil
+}
+
+// askSeccompNotifyDaemon returns the decision of the daemon listening on
+// socket for the notification.
+func askSeccompNotifyDaemon(socket, sandboxID string, n *vc.SeccompNotification) (vc.SeccompNotificationResponse, error) {
+	conn, err := net.DialTimeout("unix", socket, seccompNotifyTimeout)
+	if err != nil {
+		return vc.SeccompNotificationResponse{}, err
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(seccompNotifyTimeout)); err != nil {
+		return vc.SeccompNotificationResponse{}, err
+	}
+
+	req := seccompNotifyRequest{
+		ID:          n.ID,
+		SandboxID:   sandboxID,
+		ContainerID: n.ContainerID,
+		ExecID:      n.ExecID,
+		Pid:         n.Pid,
+		Syscall:     n.Syscall,
+		Arch:        n.Arch,
+		Args:        n.Args,
+	}
+	if err := json.NewEncoder(conn).Encode(&req); err != nil {
+		return vc.SeccompNotificationResponse{}, err
+	}
+
+	var decision seccompNotifyDecision
+	if err := json.NewDecoder(conn).Decode(&decision); err != nil {
+		return vc.SeccompNotificationResponse{}, err
+	}
+	return decision.response(n.ID)
+}
+
+// handleSeccompNotification responds to a notification with the decision of
+// the daemon, denying the system call with EPERM when there is none.
+func (s *service) handleSeccompNotification(ctx context.Context, n *vc.SeccompNotification) {
+	log := seccompNotifyLog.WithFields(logrus.Fields{
+		"container": n.ContainerID,
+		"exec":      n.ExecID,
+		"pid":       n.Pid,
+		"syscall":   n.Syscall,
+	})
+
+	resp, err := askSeccompNotifyDaemon(s.config.SeccompNotifySocket, s.id, n)
+	if err != nil {
+		log.WithError(err).Warn("no decision of the seccomp notification daemon, denying the system call")
+		resp = vc.SeccompNotificationResponse{ID: n.ID, Errno: int32(unix.EPERM)}
+	}
+
+	log.WithFields(logrus.Fields{
+		"allow": resp.Allow,
+		"errno": resp.Errno,
+		"value": resp.Value,
+	}).Info("seccomp notification")
+
+	if err := s.sandbox.RespondSeccompNotification(ctx, resp); err != nil {
+		log.WithError(err).Warn("failed to respond to the seccomp notification")
+	}
+}
+
+// watchSeccompNotifications forwards the system calls of the containers
+// trapped by SCMP_ACT_NOTIFY rules to the daemon of the seccomp notify
+// socket, when one is configured.
+func watchSeccompNotifications(ctx context.Context, s *service) {
+	if s.sandbox == nil || s.config == nil || s.config.SeccompNotifySocket == "" {
+		return
+	}
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		default:
+			n, err := s.sandbox.GetSeccompNotification(ctx)
+			if err != nil {
+				switch err.Error() {
+				case "ttrpc: closed", "Dead agent":
+					seccompNotifyLog.WithError(err).Info("agent has shutdown, return from watching of seccomp notifications")
+					return
+				case "unimplemented":
+					seccompNotifyLog.Warn("the agent cannot trap system calls, return from watching of seccomp notifications")
+					return
+				}
+				seccompNotifyLog.WithError(err).Info("failed to get seccomp notification from sandbox")
+				time.Sleep(defaultCheckInterval)
+				continue
+			}
+
+			// The daemon may be slow to decide, the other notifications
+			// are not held up.
+			go s.handleSeccompNotification(ctx, n)
+		}
+	}
+}
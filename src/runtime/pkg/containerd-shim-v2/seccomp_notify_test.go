@@ -0,0 +1,103 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/oci"
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/vcmock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/unix"
+)
+
+// serveSeccompNotifyDaemon answers the requests on socket with response,
+// sending them on the returned channel.
+func serveSeccompNotifyDaemon(t *testing.T, socket, response string) <-chan seccompNotifyRequest {
+	listener, err := net.Listen("unix", socket)
+	assert.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	requests := make(chan seccompNotifyRequest, 10)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			var req seccompNotifyRequest
+			if err := json.NewDecoder(conn).Decode(&req); err == nil {
+				requests <- req
+				conn.Write([]byte(response))
+			}
+			conn.Close()
+		}
+	}()
+	return requests
+}
+
+func TestHandleSeccompNotification(t *testing.T) {
+	assert := assert.New(t)
+
+	var resp vc.SeccompNotificationResponse
+	s := &service{
+		id: testSandboxID,
+		sandbox: &vcmock.Sandbox{
+			MockID: testSandboxID,
+			RespondSeccompNotificationFunc: func(r vc.SeccompNotificationResponse) error {
+				resp = r
+				return nil
+			},
+		},
+		config: &oci.RuntimeConfig{SeccompNotifySocket: filepath.Join(t.TempDir(), "daemon.sock")},
+	}
+	n := &vc.SeccompNotification{
+		ID:          7,
+		ContainerID: "c1",
+		Pid:         42,
+		Syscall:     "mknod",
+		Arch:        "x86_64",
+		Args:        []uint64{1, 2},
+	}
+
+	// No daemon
+	s.handleSeccompNotification(context.Background(), n)
+	assert.Equal(vc.SeccompNotificationResponse{ID: 7, Errno: int32(unix.EPERM)}, resp)
+
+	for _, d := range []struct {
+		response string
+		expected vc.SeccompNotificationResponse
+	}{
+		{`{"action": "continue"}`, vc.SeccompNotificationResponse{ID: 7, Allow: true}},
+		{`{"action": "errno", "errno": 2}`, vc.SeccompNotificationResponse{ID: 7, Errno: 2}},
+		{`{"action": "value", "value": 3}`, vc.SeccompNotificationResponse{ID: 7, Value: 3}},
+		{`{"action": "errno"}`, vc.SeccompNotificationResponse{ID: 7, Errno: int32(unix.EPERM)}},
+		{`{"action": "allow"}`, vc.SeccompNotificationResponse{ID: 7, Errno: int32(unix.EPERM)}},
+		{`not json`, vc.SeccompNotificationResponse{ID: 7, Errno: int32(unix.EPERM)}},
+	} {
+		s.config.SeccompNotifySocket = filepath.Join(t.TempDir(), "daemon.sock")
+		requests := serveSeccompNotifyDaemon(t, s.config.SeccompNotifySocket, d.response)
+
+		s.handleSeccompNotification(context.Background(), n)
+		assert.Equal(d.expected, resp, d.response)
+
+		req := <-requests
+		assert.Equal(seccompNotifyRequest{
+			ID:          7,
+			SandboxID:   testSandboxID,
+			ContainerID: "c1",
+			Pid:         42,
+			Syscall:     "mknod",
+			Arch:        "x86_64",
+			Args:        []uint64{1, 2},
+		}, req)
+	}
+}
@@ -7,6 +7,8 @@ package containerdshim
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"os"
@@ -21,6 +23,7 @@ import (
 	"github.com/containerd/containerd/errdefs"
 	"github.com/containerd/containerd/namespaces"
 	cdruntime "github.com/containerd/containerd/runtime"
+	"github.com/containerd/containerd/runtime/v2/runc/options"
 	cdshim "github.com/containerd/containerd/runtime/v2/shim"
 	taskAPI "github.com/containerd/containerd/runtime/v2/task"
 	"github.com/containerd/typeurl"
@@ -72,9 +75,15 @@ var shimLog = logrus.WithFields(logrus.Fields{
 
 // New returns a new shim service that can be used via GRPC
 func New(ctx context.Context, id string, publisher cdshim.Publisher, shutdown func()) (cdshim.Shim, error) {
+	correlationID, err := newCorrelationID()
+	if err != nil {
+		return nil, err
+	}
+
 	shimLog = shimLog.WithFields(logrus.Fields{
-		"sandbox": id,
-		"pid":     os.Getpid(),
+		"sandbox":        id,
+		"pid":            os.Getpid(),
+		"correlation_id": correlationID,
 	})
 	// Discard the log before shim init its log output. Otherwise
 	// it will output into stdio, from which containerd would like
@@ -93,14 +102,15 @@ func New(ctx context.Context, id string, publisher cdshim.Publisher, shutdown fu
 	}
 
 	s := &service{
-		id:         id,
-		pid:        uint32(os.Getpid()),
-		ctx:        ctx,
-		containers: make(map[string]*container),
-		events:     make(chan interface{}, chSize),
-		ec:         make(chan exit, bufferSize),
-		cancel:     shutdown,
-		namespace:  ns,
+		id:            id,
+		correlationID: correlationID,
+		pid:           uint32(os.Getpid()),
+		ctx:           ctx,
+		containers:    make(map[string]*container),
+		events:        make(chan interface{}, chSize),
+		ec:            make(chan exit, bufferSize),
+		cancel:        shutdown,
+		namespace:     ns,
 	}
 
 	go s.processExits()
@@ -108,9 +118,24 @@ func New(ctx context.Context, id string, publisher cdshim.Publisher, shutdown fu
 	forwarder := s.newEventsForwarder(ctx, publisher)
 	go forwarder.forward()
 
+	if os.Getenv(handoverEnv) != "" {
+		if err := s.adoptSandbox(); err != nil {
+			return nil, fmt.Errorf("failed to adopt the sandbox handed over: %w", err)
+		}
+	}
+
 	return s, nil
 }
 
+// newCorrelationID returns a random ID, formatted like a trace ID.
+func newCorrelationID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
 type exit struct {
 	timestamp time.Time
 	id        string
@@ -131,21 +156,37 @@ type service struct {
 
 	config *oci.RuntimeConfig
 
+	// configPath is the configuration file config was loaded from
+	configPath string
+
 	monitor chan error
 	ec      chan exit
 
+	// vmRestart is the handling of the next VM crash, when the VM is
+	// restarted on crash
+	vmRestart *vmRestart
+
+	// usage tracks the resource usage of the sandbox once started
+	usage *usageRecorder
+
 	events chan interface{}
 
 	cancel func()
 
 	id string
 
+	// correlationID identifies the sandbox in the shim logs, traces and
+	// metrics exemplars
+	correlationID string
+
 	// Namespace from upper container engine
 	namespace string
 
 	mu          sync.Mutex
 	eventSendMu sync.Mutex
 
+	quiescer quiescer
+
 	// hypervisor pid, Since this shimv2 cannot get the container processes pid from VM,
 	// thus for the returned values needed pid, just return the hypervisor's
 	// pid directly.
@@ -313,6 +354,14 @@ func getTopic(e interface{}) string {
 		return cdruntime.TaskResumedEventTopic
 	case *eventstypes.TaskCheckpointed:
 		return cdruntime.TaskCheckpointedEventTopic
+	case *MultipathPathEvent:
+		return multipathPathEventTopic
+	case *SandboxSizingEvent:
+		return sandboxSizingEventTopic
+	case *SandboxCPUMitigationsEvent:
+		return sandboxCPUMitigationsEventTopic
+	case *SandboxSeccompViolationEvent:
+		return sandboxSeccompViolationEventTopic
 	default:
 		shimLog.WithField("event-type", e).Warn("no topic for event type")
 	}
@@ -359,6 +408,7 @@ func (s *service) Cleanup(ctx context.Context) (_ *taskAPI.DeleteResponse, err e
 		if err != nil {
 			return nil, err
 		}
+		releaseDevices(ociSpec.Annotations)
 	case vc.PodContainer:
 		sandboxID, err := oci.SandboxID(ociSpec)
 		if err != nil {
@@ -384,7 +434,7 @@ func (s *service) Create(ctx context.Context, r *taskAPI.CreateTaskRequest) (_ *
 	start := time.Now()
 	defer func() {
 		err = toGRPC(err)
-		rpcDurationsHistogram.WithLabelValues("create").Observe(float64(time.Since(start).Nanoseconds() / int64(time.Millisecond)))
+		s.observeRPCDuration("create", start)
 	}()
 
 	s.mu.Lock()
@@ -446,7 +496,7 @@ func (s *service) Start(ctx context.Context, r *taskAPI.StartRequest) (_ *taskAP
 	start := time.Now()
 	defer func() {
 		err = toGRPC(err)
-		rpcDurationsHistogram.WithLabelValues("start").Observe(float64(time.Since(start).Nanoseconds() / int64(time.Millisecond)))
+		s.observeRPCDuration("start", start)
 	}()
 
 	s.mu.Lock()
@@ -499,7 +549,7 @@ func (s *service) Delete(ctx context.Context, r *taskAPI.DeleteRequest) (_ *task
 	start := time.Now()
 	defer func() {
 		err = toGRPC(err)
-		rpcDurationsHistogram.WithLabelValues("delete").Observe(float64(time.Since(start).Nanoseconds() / int64(time.Millisecond)))
+		s.observeRPCDuration("delete", start)
 	}()
 
 	s.mu.Lock()
@@ -552,7 +602,7 @@ func (s *service) Exec(ctx context.Context, r *taskAPI.ExecProcessRequest) (_ *p
 
 	start := time.Now()
 	defer func() {
-		rpcDurationsHistogram.WithLabelValues("exec").Observe(float64(time.Since(start).Nanoseconds() / int64(time.Millisecond)))
+		s.observeRPCDuration("exec", start)
 		err = toGRPC(err)
 	}()
 
@@ -564,6 +614,10 @@ func (s *service) Exec(ctx context.Context, r *taskAPI.ExecProcessRequest) (_ *p
 		return nil, err
 	}
 
+	if err := c.checkInVM("exec"); err != nil {
+		return nil, err
+	}
+
 	if execs := c.execs[r.ExecID]; execs != nil {
 		return nil, errdefs.ToGRPCf(errdefs.ErrAlreadyExists, "id %s", r.ExecID)
 	}
@@ -593,7 +647,7 @@ func (s *service) ResizePty(ctx context.Context, r *taskAPI.ResizePtyRequest) (_
 	start := time.Now()
 	defer func() {
 		err = toGRPC(err)
-		rpcDurationsHistogram.WithLabelValues("resize_pty").Observe(float64(time.Since(start).Nanoseconds() / int64(time.Millisecond)))
+		s.observeRPCDuration("resize_pty", start)
 	}()
 
 	s.mu.Lock()
@@ -634,7 +688,7 @@ func (s *service) State(ctx context.Context, r *taskAPI.StateRequest) (_ *taskAP
 	start := time.Now()
 	defer func() {
 		err = toGRPC(err)
-		rpcDurationsHistogram.WithLabelValues("state").Observe(float64(time.Since(start).Nanoseconds() / int64(time.Millisecond)))
+		s.observeRPCDuration("state", start)
 	}()
 
 	s.mu.Lock()
@@ -690,7 +744,7 @@ func (s *service) Pause(ctx context.Context, r *taskAPI.PauseRequest) (_ *ptypes
 	start := time.Now()
 	defer func() {
 		err = toGRPC(err)
-		rpcDurationsHistogram.WithLabelValues("pause").Observe(float64(time.Since(start).Nanoseconds() / int64(time.Millisecond)))
+		s.observeRPCDuration("pause", start)
 	}()
 
 	s.mu.Lock()
@@ -701,6 +755,10 @@ func (s *service) Pause(ctx context.Context, r *taskAPI.PauseRequest) (_ *ptypes
 		return nil, err
 	}
 
+	if err := c.checkInVM("pause"); err != nil {
+		return nil, err
+	}
+
 	c.status = task.StatusPausing
 
 	err = s.sandbox.PauseContainer(spanCtx, r.ID)
@@ -731,7 +789,7 @@ func (s *service) Resume(ctx context.Context, r *taskAPI.ResumeRequest) (_ *ptyp
 	start := time.Now()
 	defer func() {
 		err = toGRPC(err)
-		rpcDurationsHistogram.WithLabelValues("resume").Observe(float64(time.Since(start).Nanoseconds() / int64(time.Millisecond)))
+		s.observeRPCDuration("resume", start)
 	}()
 
 	s.mu.Lock()
@@ -742,6 +800,10 @@ func (s *service) Resume(ctx context.Context, r *taskAPI.ResumeRequest) (_ *ptyp
 		return nil, err
 	}
 
+	if err := c.checkInVM("resume"); err != nil {
+		return nil, err
+	}
+
 	err = s.sandbox.ResumeContainer(spanCtx, c.id)
 	if err == nil {
 		c.status = task.StatusRunning
@@ -770,7 +832,7 @@ func (s *service) Kill(ctx context.Context, r *taskAPI.KillRequest) (_ *ptypes.E
 	start := time.Now()
 	defer func() {
 		err = toGRPC(err)
-		rpcDurationsHistogram.WithLabelValues("kill").Observe(float64(time.Since(start).Nanoseconds() / int64(time.Millisecond)))
+		s.observeRPCDuration("kill", start)
 	}()
 
 	s.mu.Lock()
@@ -820,12 +882,17 @@ func (s *service) Kill(ctx context.Context, r *taskAPI.KillRequest) (_ *ptypes.E
 		return empty, nil
 	}
 
+	if c.host != nil && r.ExecID == "" {
+		return empty, c.host.signal(signum)
+	}
+
 	return empty, s.sandbox.SignalProcess(spanCtx, c.id, processID, signum, r.All)
 }
 
 // Pids returns all pids inside the container
 // Since for kata, it cannot get the process's pid from VM,
-// thus only return the hypervisor's pid directly.
+// thus only return the hypervisor's pid directly, or the pid
+// of the container process for a host container.
 func (s *service) Pids(ctx context.Context, r *taskAPI.PidsRequest) (_ *taskAPI.PidsResponse, err error) {
 	shimLog.WithField("container", r.ID).Debug("Pids() start")
 	defer shimLog.WithField("container", r.ID).Debug("Pids() end")
@@ -837,12 +904,20 @@ func (s *service) Pids(ctx context.Context, r *taskAPI.PidsRequest) (_ *taskAPI.
 	start := time.Now()
 	defer func() {
 		err = toGRPC(err)
-		rpcDurationsHistogram.WithLabelValues("pids").Observe(float64(time.Since(start).Nanoseconds() / int64(time.Millisecond)))
+		s.observeRPCDuration("pids", start)
 	}()
 
 	pInfo := task.ProcessInfo{
 		Pid: s.hpid,
 	}
+
+	s.mu.Lock()
+	if c, err := s.getContainer(r.ID); err == nil && c.host != nil {
+		if pid, err := c.host.pid(); err == nil {
+			pInfo.Pid = uint32(pid)
+		}
+	}
+	s.mu.Unlock()
 	processes = append(processes, &pInfo)
 
 	return &taskAPI.PidsResponse{
@@ -860,7 +935,7 @@ func (s *service) CloseIO(ctx context.Context, r *taskAPI.CloseIORequest) (_ *pt
 	start := time.Now()
 	defer func() {
 		err = toGRPC(err)
-		rpcDurationsHistogram.WithLabelValues("close_io").Observe(float64(time.Since(start).Nanoseconds() / int64(time.Millisecond)))
+		s.observeRPCDuration("close_io", start)
 	}()
 
 	s.mu.Lock()
@@ -897,16 +972,54 @@ func (s *service) CloseIO(ctx context.Context, r *taskAPI.CloseIORequest) (_ *pt
 func (s *service) Checkpoint(ctx context.Context, r *taskAPI.CheckpointTaskRequest) (_ *ptypes.Empty, err error) {
 	shimLog.WithField("container", r.ID).Debug("Checkpoint() start")
 	defer shimLog.WithField("container", r.ID).Debug("Checkpoint() end")
-	span, _ := katatrace.Trace(s.rootCtx, shimLog, "Checkpoint", shimTracingTags)
+	span, spanCtx := katatrace.Trace(s.rootCtx, shimLog, "Checkpoint", shimTracingTags)
 	defer span.End()
 
 	start := time.Now()
 	defer func() {
 		err = toGRPC(err)
-		rpcDurationsHistogram.WithLabelValues("checkpoint").Observe(float64(time.Since(start).Nanoseconds() / int64(time.Millisecond)))
+		s.observeRPCDuration("checkpoint", start)
 	}()
 
-	return nil, errdefs.ToGRPCf(errdefs.ErrNotImplemented, "service Checkpoint")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.getContainer(r.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.checkInVM("checkpoint"); err != nil {
+		return nil, err
+	}
+
+	if c.cType.IsSandbox() {
+		return nil, errdefs.ToGRPCf(errdefs.ErrNotImplemented, "checkpoint of the sandbox container")
+	}
+
+	opts := &options.CheckpointOptions{}
+	if r.Options != nil {
+		v, err := typeurl.UnmarshalAny(r.Options)
+		if err != nil {
+			return nil, err
+		}
+		var ok bool
+		if opts, ok = v.(*options.CheckpointOptions); !ok {
+			return nil, errdefs.ToGRPCf(errdefs.ErrInvalidArgument, "invalid checkpoint options %T", v)
+		}
+	}
+
+	err = s.sandbox.CheckpointContainer(spanCtx, r.ID, r.Path, vc.CheckpointOptions{
+		LeaveRunning:   !opts.Exit,
+		TCPEstablished: opts.OpenTcp,
+		FileLocks:      opts.FileLocks,
+		ExtUnixSockets: opts.ExternalUnixSockets,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return empty, nil
 }
 
 // Connect returns shim information such as the shim's pid
@@ -919,7 +1032,7 @@ func (s *service) Connect(ctx context.Context, r *taskAPI.ConnectRequest) (_ *ta
 	start := time.Now()
 	defer func() {
 		err = toGRPC(err)
-		rpcDurationsHistogram.WithLabelValues("connect").Observe(float64(time.Since(start).Nanoseconds() / int64(time.Millisecond)))
+		s.observeRPCDuration("connect", start)
 	}()
 
 	s.mu.Lock()
@@ -940,7 +1053,7 @@ func (s *service) Shutdown(ctx context.Context, r *taskAPI.ShutdownRequest) (_ *
 	start := time.Now()
 	defer func() {
 		err = toGRPC(err)
-		rpcDurationsHistogram.WithLabelValues("shutdown").Observe(float64(time.Since(start).Nanoseconds() / int64(time.Millisecond)))
+		s.observeRPCDuration("shutdown", start)
 	}()
 
 	s.mu.Lock()
@@ -965,11 +1078,17 @@ func (s *service) Shutdown(ctx context.Context, r *taskAPI.ShutdownRequest) (_ *
 	// exited when shimv2 terminated. Thus here to do the last cleanup of the hypervisor.
 	syscall.Kill(int(s.hpid), syscall.SIGKILL)
 
+	// The VFIO devices of the pod are free once the hypervisor is gone.
+	if s.sandbox != nil {
+		releaseDevices(s.sandbox.GetAnnotations())
+	}
+	s.removeAgentAPISocket()
+
 	// os.Exit() will terminate program immediately, the defer functions won't be executed,
 	// so we add defer functions again before os.Exit().
 	// Refer to https://pkg.go.dev/os#Exit
 	shimLog.WithField("container", r.ID).Debug("Shutdown() end")
-	rpcDurationsHistogram.WithLabelValues("shutdown").Observe(float64(time.Since(start).Nanoseconds() / int64(time.Millisecond)))
+	s.observeRPCDuration("shutdown", start)
 
 	os.Exit(0)
 
@@ -987,7 +1106,7 @@ func (s *service) Stats(ctx context.Context, r *taskAPI.StatsRequest) (_ *taskAP
 	start := time.Now()
 	defer func() {
 		err = toGRPC(err)
-		rpcDurationsHistogram.WithLabelValues("stats").Observe(float64(time.Since(start).Nanoseconds() / int64(time.Millisecond)))
+		s.observeRPCDuration("stats", start)
 	}()
 
 	s.mu.Lock()
@@ -998,6 +1117,10 @@ func (s *service) Stats(ctx context.Context, r *taskAPI.StatsRequest) (_ *taskAP
 		return nil, err
 	}
 
+	if err := c.checkInVM("stats"); err != nil {
+		return nil, err
+	}
+
 	data, err := marshalMetrics(spanCtx, s, c.id)
 	if err != nil {
 		return nil, err
@@ -1018,12 +1141,20 @@ func (s *service) Update(ctx context.Context, r *taskAPI.UpdateTaskRequest) (_ *
 	start := time.Now()
 	defer func() {
 		err = toGRPC(err)
-		rpcDurationsHistogram.WithLabelValues("update").Observe(float64(time.Since(start).Nanoseconds() / int64(time.Millisecond)))
+		s.observeRPCDuration("update", start)
 	}()
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	c, err := s.getContainer(r.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkInVM("update"); err != nil {
+		return nil, err
+	}
+
 	var resources *specs.LinuxResources
 	v, err := typeurl.UnmarshalAny(r.Resources)
 	if err != nil {
@@ -1054,7 +1185,7 @@ func (s *service) Wait(ctx context.Context, r *taskAPI.WaitRequest) (_ *taskAPI.
 	start := time.Now()
 	defer func() {
 		err = toGRPC(err)
-		rpcDurationsHistogram.WithLabelValues("wait").Observe(float64(time.Since(start).Nanoseconds() / int64(time.Millisecond)))
+		s.observeRPCDuration("wait", start)
 	}()
 
 	s.mu.Lock()
@@ -0,0 +1,518 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/syslog"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	ctrAnnotations "github.com/containerd/containerd/pkg/cri/annotations"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// logDriverJournald sends the container output to journald.
+	logDriverJournald = "journald"
+
+	// logDriverSyslog sends the container output to the local syslog.
+	logDriverSyslog = "syslog"
+
+	// logDriverFluentd sends the container output to a fluentd forward
+	// input.
+	logDriverFluentd = "fluentd"
+
+	journaldSocket = "/run/systemd/journal/socket"
+
+	// Lines longer than this are split into several records.
+	maxLogLineSize = 16 << 10
+
+	fluentdDialTimeout = 5 * time.Second
+
+	logStreamStdout = "stdout"
+	logStreamStderr = "stderr"
+)
+
+var (
+	_ IO = &logDriverIO{}
+
+	// overridden in tests
+	journaldSocketPath   = journaldSocket
+	fluentdBufferSize    = 1024
+	fluentdRetryInterval = time.Second
+)
+
+// logMetadata describes the process the output comes from.
+type logMetadata struct {
+	fields      map[string]string
+	containerID string
+}
+
+func newLogMetadata(s *service, c *container, execID string) logMetadata {
+	fields := map[string]string{
+		"CONTAINER_ID_FULL":   c.id,
+		"CONTAINER_ID":        shortID(c.id),
+		"CONTAINER_NAMESPACE": s.namespace,
+		"SANDBOX_ID":          s.id,
+	}
+	if execID != "" {
+		fields["EXEC_ID"] = execID
+	}
+
+	for key, annotation := range map[string]string{
+		"CONTAINER_NAME":       ctrAnnotations.ContainerName,
+		"POD_NAME":             ctrAnnotations.SandboxName,
+		"KUBERNETES_NAMESPACE": ctrAnnotations.SandboxNamespace,
+	} {
+		if value := c.spec.Annotations[annotation]; value != "" {
+			fields[key] = value
+		}
+	}
+
+	return logMetadata{
+		fields:      fields,
+		containerID: c.id,
+	}
+}
+
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
+// logDriver sends the output lines of a container process to a log
+// collector.
+type logDriver interface {
+	io.Closer
+	log(stream string, line []byte) error
+}
+
+// openLogDrivers opens the log drivers configured for the container, none
+// if its namespace is not selected. The drivers failing to open are
+// skipped, the container output is still available on the containerd
+// FIFO.
+func (s *service) openLogDrivers(c *container, execID string) []logDriver {
+	if s.config == nil || len(s.config.StdioLogDrivers) == 0 {
+		return nil
+	}
+
+	namespace := s.sandboxNamespace(c.spec.Annotations)
+	if len(s.config.StdioLogNamespaces) > 0 {
+		selected := false
+		for _, ns := range s.config.StdioLogNamespaces {
+			selected = selected || ns == namespace
+		}
+		if !selected {
+			return nil
+		}
+	}
+
+	metadata := newLogMetadata(s, c, execID)
+
+	var drivers []logDriver
+	for _, name := range s.config.StdioLogDrivers {
+		var (
+			driver logDriver
+			err    error
+		)
+
+		switch name {
+		case logDriverJournald:
+			driver, err = newJournaldDriver(metadata)
+		case logDriverSyslog:
+			driver, err = newSyslogDriver(metadata)
+		case logDriverFluentd:
+			driver, err = newFluentdDriver(metadata, s.config.StdioFluentdAddress)
+		default:
+			err = fmt.Errorf("unknown log driver %s", name)
+		}
+
+		if err != nil {
+			shimLog.WithError(err).WithFields(logrus.Fields{
+				"container": c.id,
+				"driver":    name,
+			}).Warn("failed to open log driver")
+			continue
+		}
+		drivers = append(drivers, driver)
+	}
+
+	return drivers
+}
+
+// logDriverIO copies the container output to log drivers, in addition to
+// the IO it wraps.
+type logDriverIO struct {
+	io      IO
+	stdout  io.Writer
+	stderr  io.Writer
+	outLog  *logLineWriter
+	errLog  *logLineWriter
+	drivers []logDriver
+}
+
+func newLogDriverIO(base IO, drivers []logDriver) IO {
+	if len(drivers) == 0 {
+		return base
+	}
+
+	lio := &logDriverIO{
+		io:      base,
+		drivers: drivers,
+	}
+
+	if base.Stdout() != nil {
+		lio.outLog = newLogLineWriter(drivers, logStreamStdout)
+		lio.stdout = io.MultiWriter(base.Stdout(), lio.outLog)
+	}
+	if base.Stderr() != nil {
+		lio.errLog = newLogLineWriter(drivers, logStreamStderr)
+		lio.stderr = io.MultiWriter(base.Stderr(), lio.errLog)
+	}
+
+	return lio
+}
+
+func (lio *logDriverIO) Close() error {
+	// The last lines may not be terminated
+	for _, l := range []*logLineWriter{lio.outLog, lio.errLog} {
+		if l != nil {
+			l.flush()
+		}
+	}
+
+	for _, d := range lio.drivers {
+		d.Close()
+	}
+
+	return lio.io.Close()
+}
+
+func (lio *logDriverIO) Stdin() io.ReadCloser {
+	return lio.io.Stdin()
+}
+
+func (lio *logDriverIO) Stdout() io.Writer {
+	return lio.stdout
+}
+
+func (lio *logDriverIO) Stderr() io.Writer {
+	return lio.stderr
+}
+
+// logLineWriter splits an output stream into lines for the log drivers.
+// It never fails, so that a log collector being unavailable does not
+// interrupt the copy of the output to containerd.
+type logLineWriter struct {
+	stream  string
+	drivers []logDriver
+	buf     []byte
+	failed  map[int]bool
+	sync.Mutex
+}
+
+func newLogLineWriter(drivers []logDriver, stream string) *logLineWriter {
+	return &logLineWriter{
+		stream:  stream,
+		drivers: drivers,
+		failed:  make(map[int]bool),
+	}
+}
+
+func (l *logLineWriter) Write(p []byte) (int, error) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.buf = append(l.buf, p...)
+	for {
+		i := bytes.IndexByte(l.buf, '\n')
+		if i < 0 {
+			break
+		}
+		l.send(l.buf[:i])
+		l.buf = l.buf[i+1:]
+	}
+
+	for len(l.buf) >= maxLogLineSize {
+		l.send(l.buf[:maxLogLineSize])
+		l.buf = l.buf[maxLogLineSize:]
+	}
+
+	// Do not keep growing the underlying array
+	l.buf = append([]byte(nil), l.buf...)
+
+	return len(p), nil
+}
+
+func (l *logLineWriter) flush() {
+	l.Lock()
+	defer l.Unlock()
+
+	if len(l.buf) > 0 {
+		l.send(l.buf)
+		l.buf = nil
+	}
+}
+
+func (l *logLineWriter) send(line []byte) {
+	for i, d := range l.drivers {
+		err := d.log(l.stream, line)
+		// Only report when a driver starts failing
+		if err != nil && !l.failed[i] {
+			shimLog.WithError(err).WithField("stream", l.stream).Warn("failed to send container output to log driver")
+		}
+		l.failed[i] = err != nil
+	}
+}
+
+// journaldDriver sends the lines to journald with its native protocol,
+// along with the metadata fields.
+type journaldDriver struct {
+	conn     *net.UnixConn
+	metadata logMetadata
+	sync.Mutex
+}
+
+func newJournaldDriver(metadata logMetadata) (*journaldDriver, error) {
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: journaldSocketPath, Net: "unixgram"})
+	if err != nil {
+		return nil, err
+	}
+
+	return &journaldDriver{
+		conn:     conn,
+		metadata: metadata,
+	}, nil
+}
+
+// appendJournaldField serializes a journal field, values with new lines
+// are sent with their size.
+func appendJournaldField(buf *bytes.Buffer, key string, value []byte) {
+	buf.WriteString(key)
+	if bytes.IndexByte(value, '\n') < 0 {
+		buf.WriteByte('=')
+		buf.Write(value)
+	} else {
+		buf.WriteByte('\n')
+		binary.Write(buf, binary.LittleEndian, uint64(len(value)))
+		buf.Write(value)
+	}
+	buf.WriteByte('\n')
+}
+
+func (j *journaldDriver) log(stream string, line []byte) error {
+	priority := "6"
+	if stream == logStreamStderr {
+		priority = "3"
+	}
+
+	var buf bytes.Buffer
+	appendJournaldField(&buf, "MESSAGE", line)
+	appendJournaldField(&buf, "PRIORITY", []byte(priority))
+	appendJournaldField(&buf, "SYSLOG_IDENTIFIER", []byte(shortID(j.metadata.containerID)))
+	appendJournaldField(&buf, "CONTAINER_STREAM", []byte(stream))
+
+	keys := make([]string, 0, len(j.metadata.fields))
+	for key := range j.metadata.fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		appendJournaldField(&buf, key, []byte(j.metadata.fields[key]))
+	}
+
+	j.Lock()
+	defer j.Unlock()
+
+	_, err := j.conn.Write(buf.Bytes())
+	return err
+}
+
+func (j *journaldDriver) Close() error {
+	return j.conn.Close()
+}
+
+// syslogDriver sends the lines to the local syslog, tagged with the
+// container ID.
+type syslogDriver struct {
+	writer *syslog.Writer
+}
+
+func newSyslogDriver(metadata logMetadata) (*syslogDriver, error) {
+	writer, err := syslog.New(syslog.LOG_DAEMON|syslog.LOG_INFO, "kata/"+shortID(metadata.containerID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &syslogDriver{writer: writer}, nil
+}
+
+func (s *syslogDriver) log(stream string, line []byte) error {
+	if stream == logStreamStderr {
+		return s.writer.Err(string(line))
+	}
+	return s.writer.Info(string(line))
+}
+
+func (s *syslogDriver) Close() error {
+	return s.writer.Close()
+}
+
+// fluentdDriver sends the lines as JSON forward messages to a fluentd
+// forward input. The records are buffered and sent in the background, so
+// that a slow or unreachable fluentd never holds the copy of the container
+// output, the connection is established again after a failure. The records
+// are dropped when the buffer is full.
+type fluentdDriver struct {
+	records  chan []byte
+	done     chan struct{}
+	metadata logMetadata
+	network  string
+	address  string
+	tag      string
+	closed   bool
+	sync.Mutex
+}
+
+// parseFluentdAddress parses unix:///path or tcp://host:port addresses.
+func parseFluentdAddress(address string) (string, string, error) {
+	uri, err := url.Parse(address)
+	if err != nil {
+		return "", "", err
+	}
+
+	switch uri.Scheme {
+	case "unix":
+		if uri.Path == "" {
+			return "", "", fmt.Errorf("missing fluentd socket path in %q", address)
+		}
+		return "unix", uri.Path, nil
+	case "tcp":
+		if _, _, err := net.SplitHostPort(uri.Host); err != nil {
+			return "", "", fmt.Errorf("invalid fluentd address %q: %v", address, err)
+		}
+		return "tcp", uri.Host, nil
+	default:
+		return "", "", fmt.Errorf("invalid fluentd address %q, expecting unix:// or tcp://", address)
+	}
+}
+
+func newFluentdDriver(metadata logMetadata, address string) (*fluentdDriver, error) {
+	network, addr, err := parseFluentdAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &fluentdDriver{
+		records:  make(chan []byte, fluentdBufferSize),
+		done:     make(chan struct{}),
+		metadata: metadata,
+		network:  network,
+		address:  addr,
+		tag:      "kata." + shortID(metadata.containerID),
+	}
+	go f.run()
+
+	return f, nil
+}
+
+// run sends the buffered records until the driver is closed. The records
+// left once it is closed are still sent, unless fluentd cannot be reached.
+func (f *fluentdDriver) run() {
+	var (
+		conn    net.Conn
+		failing bool
+	)
+
+	defer func() {
+		if conn != nil {
+			conn.Close()
+		}
+	}()
+
+	for msg := range f.records {
+		for {
+			if conn == nil {
+				c, err := net.DialTimeout(f.network, f.address, fluentdDialTimeout)
+				if err != nil {
+					// Only report when fluentd starts failing
+					if !failing {
+						shimLog.WithError(err).WithField("address", f.address).Warn("failed to connect to fluentd")
+					}
+					failing = true
+
+					select {
+					case <-time.After(fluentdRetryInterval):
+						continue
+					case <-f.done:
+						return
+					}
+				}
+				conn = c
+				failing = false
+			}
+
+			if _, err := conn.Write(msg); err != nil {
+				conn.Close()
+				conn = nil
+				continue
+			}
+			break
+		}
+	}
+}
+
+func (f *fluentdDriver) log(stream string, line []byte) error {
+	record := map[string]string{
+		"log":    string(line),
+		"source": stream,
+	}
+	for key, value := range f.metadata.fields {
+		record[strings.ToLower(key)] = value
+	}
+
+	msg, err := json.Marshal([]interface{}{f.tag, time.Now().Unix(), record})
+	if err != nil {
+		return err
+	}
+
+	f.Lock()
+	defer f.Unlock()
+
+	if f.closed {
+		return fmt.Errorf("fluentd driver closed")
+	}
+
+	select {
+	case f.records <- msg:
+		return nil
+	default:
+		return fmt.Errorf("fluentd buffer full, dropping the record")
+	}
+}
+
+func (f *fluentdDriver) Close() error {
+	f.Lock()
+	defer f.Unlock()
+
+	if !f.closed {
+		f.closed = true
+		close(f.done)
+		close(f.records)
+	}
+	return nil
+}
@@ -0,0 +1,284 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	ctrAnnotations "github.com/containerd/containerd/pkg/cri/annotations"
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/oci"
+)
+
+type fakeLogDriver struct {
+	lines  []string
+	err    error
+	closed bool
+	sync.Mutex
+}
+
+func (f *fakeLogDriver) log(stream string, line []byte) error {
+	f.Lock()
+	defer f.Unlock()
+	f.lines = append(f.lines, stream+":"+string(line))
+	return f.err
+}
+
+func (f *fakeLogDriver) Close() error {
+	f.closed = true
+	return nil
+}
+
+type fakeIO struct {
+	stdout bytes.Buffer
+	closed bool
+}
+
+func (f *fakeIO) Close() error {
+	f.closed = true
+	return nil
+}
+
+func (f *fakeIO) Stdin() io.ReadCloser {
+	return nil
+}
+
+func (f *fakeIO) Stdout() io.Writer {
+	return &f.stdout
+}
+
+func (f *fakeIO) Stderr() io.Writer {
+	return nil
+}
+
+func TestLogDriverIO(t *testing.T) {
+	assert := assert.New(t)
+
+	base := &fakeIO{}
+	assert.Equal(base, newLogDriverIO(base, nil))
+
+	driver := &fakeLogDriver{}
+	failing := &fakeLogDriver{err: io.ErrClosedPipe}
+	lio := newLogDriverIO(base, []logDriver{driver, failing})
+	assert.Nil(lio.Stderr())
+
+	// a failing driver does not interrupt the output
+	for _, s := range []string{"hello\nwor", "ld\n", "last"} {
+		n, err := lio.Stdout().Write([]byte(s))
+		assert.NoError(err)
+		assert.Equal(len(s), n)
+	}
+	assert.Equal([]string{"stdout:hello", "stdout:world"}, driver.lines)
+
+	assert.NoError(lio.Close())
+	assert.Equal([]string{"stdout:hello", "stdout:world", "stdout:last"}, driver.lines)
+	assert.Equal("hello\nworld\nlast", base.stdout.String())
+	assert.True(base.closed)
+	assert.True(driver.closed)
+	assert.True(failing.closed)
+}
+
+func TestLogLineWriterLongLine(t *testing.T) {
+	assert := assert.New(t)
+
+	driver := &fakeLogDriver{}
+	w := newLogLineWriter([]logDriver{driver}, logStreamStderr)
+
+	_, err := w.Write(bytes.Repeat([]byte("a"), maxLogLineSize+10))
+	assert.NoError(err)
+	assert.Len(driver.lines, 1)
+	assert.Equal("stderr:"+strings.Repeat("a", maxLogLineSize), driver.lines[0])
+
+	w.flush()
+	assert.Equal("stderr:"+strings.Repeat("a", 10), driver.lines[1])
+}
+
+func TestAppendJournaldField(t *testing.T) {
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+	appendJournaldField(&buf, "MESSAGE", []byte("hello"))
+	assert.Equal("MESSAGE=hello\n", buf.String())
+
+	buf.Reset()
+	appendJournaldField(&buf, "MESSAGE", []byte("a\nb"))
+	expected := bytes.NewBufferString("MESSAGE\n")
+	binary.Write(expected, binary.LittleEndian, uint64(3))
+	expected.WriteString("a\nb\n")
+	assert.Equal(expected.Bytes(), buf.Bytes())
+}
+
+func testLogService(drivers []string, namespaces []string, address string) (*service, *container) {
+	s := &service{
+		id:        testSandboxID,
+		namespace: "k8s.io",
+		config: &oci.RuntimeConfig{
+			StdioLogDrivers:     drivers,
+			StdioLogNamespaces:  namespaces,
+			StdioFluentdAddress: address,
+		},
+	}
+	c := &container{
+		id: testContainerID,
+		spec: &specs.Spec{
+			Annotations: map[string]string{
+				ctrAnnotations.SandboxNamespace: "default",
+				ctrAnnotations.ContainerName:    "app",
+			},
+		},
+	}
+	return s, c
+}
+
+func TestJournaldDriver(t *testing.T) {
+	assert := assert.New(t)
+
+	journaldSocketPath = filepath.Join(t.TempDir(), "journal.sock")
+	defer func() {
+		journaldSocketPath = journaldSocket
+	}()
+
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: journaldSocketPath, Net: "unixgram"})
+	assert.NoError(err)
+	defer conn.Close()
+
+	s, c := testLogService([]string{logDriverJournald}, nil, "")
+	drivers := s.openLogDrivers(c, "")
+	assert.Len(drivers, 1)
+	defer drivers[0].Close()
+
+	assert.NoError(drivers[0].log(logStreamStderr, []byte("oops")))
+
+	buf := make([]byte, 4096)
+	n, err := conn.Read(buf)
+	assert.NoError(err)
+	msg := string(buf[:n])
+	assert.Contains(msg, "MESSAGE=oops\n")
+	assert.Contains(msg, "PRIORITY=3\n")
+	assert.Contains(msg, "CONTAINER_STREAM=stderr\n")
+	assert.Contains(msg, "CONTAINER_ID_FULL="+testContainerID+"\n")
+	assert.Contains(msg, "SANDBOX_ID="+testSandboxID+"\n")
+	assert.Contains(msg, "CONTAINER_NAME=app\n")
+	assert.Contains(msg, "KUBERNETES_NAMESPACE=default\n")
+}
+
+func TestFluentdDriver(t *testing.T) {
+	assert := assert.New(t)
+
+	sock := filepath.Join(t.TempDir(), "fluentd.sock")
+	listener, err := net.Listen("unix", sock)
+	assert.NoError(err)
+	defer listener.Close()
+
+	s, c := testLogService([]string{logDriverFluentd}, nil, "unix://"+sock)
+	drivers := s.openLogDrivers(c, "")
+	assert.Len(drivers, 1)
+	defer drivers[0].Close()
+
+	// the connection is established with the first record
+	assert.NoError(drivers[0].log(logStreamStdout, []byte("hello")))
+
+	conn, err := listener.Accept()
+	assert.NoError(err)
+	defer conn.Close()
+
+	var msg []interface{}
+	assert.NoError(json.NewDecoder(conn).Decode(&msg))
+	assert.Len(msg, 3)
+	assert.Equal("kata."+shortID(testContainerID), msg[0])
+	record := msg[2].(map[string]interface{})
+	assert.Equal("hello", record["log"])
+	assert.Equal("stdout", record["source"])
+	assert.Equal("app", record["container_name"])
+}
+
+func TestFluentdDriverBuffer(t *testing.T) {
+	assert := assert.New(t)
+
+	fluentdBufferSize = 2
+	fluentdRetryInterval = 10 * time.Millisecond
+	defer func() {
+		fluentdBufferSize = 1024
+		fluentdRetryInterval = time.Second
+	}()
+
+	// the records are buffered until fluentd is reachable
+	sock := filepath.Join(t.TempDir(), "fluentd.sock")
+	s, c := testLogService([]string{logDriverFluentd}, nil, "unix://"+sock)
+	drivers := s.openLogDrivers(c, "")
+	assert.Len(drivers, 1)
+	defer drivers[0].Close()
+
+	// the first record may already be waiting for the connection
+	var sent []string
+	for _, line := range []string{"a", "b", "c", "d"} {
+		if drivers[0].log(logStreamStdout, []byte(line)) == nil {
+			sent = append(sent, line)
+		}
+	}
+	assert.NotEqual(4, len(sent))
+
+	listener, err := net.Listen("unix", sock)
+	assert.NoError(err)
+	defer listener.Close()
+
+	conn, err := listener.Accept()
+	assert.NoError(err)
+	defer conn.Close()
+
+	decoder := json.NewDecoder(conn)
+	for _, line := range sent {
+		var msg []interface{}
+		assert.NoError(decoder.Decode(&msg))
+		assert.Equal(line, msg[2].(map[string]interface{})["log"])
+	}
+
+	drivers[0].Close()
+	assert.Error(drivers[0].log(logStreamStdout, []byte("e")))
+}
+
+func TestOpenLogDrivers(t *testing.T) {
+	assert := assert.New(t)
+
+	s, c := testLogService(nil, nil, "")
+	assert.Empty(s.openLogDrivers(c, ""))
+
+	// drivers failing to open are skipped
+	s, c = testLogService([]string{logDriverFluentd}, nil, "http://127.0.0.1:24224")
+	assert.Empty(s.openLogDrivers(c, ""))
+
+	sock := filepath.Join(t.TempDir(), "fluentd.sock")
+	listener, err := net.Listen("unix", sock)
+	assert.NoError(err)
+	defer listener.Close()
+
+	// only the selected namespaces
+	s, c = testLogService([]string{logDriverFluentd}, []string{"kube-system"}, "unix://"+sock)
+	assert.Empty(s.openLogDrivers(c, ""))
+
+	s.config.StdioLogNamespaces = []string{"default"}
+	drivers := s.openLogDrivers(c, "")
+	assert.Len(drivers, 1)
+	drivers[0].Close()
+
+	// the containerd namespace is used out of Kubernetes
+	delete(c.spec.Annotations, ctrAnnotations.SandboxNamespace)
+	s.config.StdioLogNamespaces = []string{"k8s.io"}
+	drivers = s.openLogDrivers(c, "")
+	assert.Len(drivers, 1)
+	drivers[0].Close()
+}
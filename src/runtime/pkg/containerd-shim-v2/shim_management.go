@@ -16,12 +16,16 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	goruntime "runtime"
+	runtimePprof "runtime/pprof"
+	"sort"
 	"strconv"
 	"strings"
 
 	"google.golang.org/grpc/codes"
 
 	cdshim "github.com/containerd/containerd/runtime/v2/shim"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/faultinject"
 	mutils "github.com/kata-containers/kata-containers/src/runtime/pkg/utils"
 	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
 	vcAnnotations "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations"
@@ -34,12 +38,24 @@ import (
 
 const (
 	DirectVolumePathKey   = "path"
+	ContainerKey          = "container"
+	VolumeSourceKey       = "source"
 	AgentUrl              = "/agent-url"
 	DirectVolumeStatUrl   = "/direct-volume/stats"
+	VolumeStatsUrl        = "/volume-stats"
 	DirectVolumeResizeUrl = "/direct-volume/resize"
 	IPTablesUrl           = "/iptables"
 	IP6TablesUrl          = "/ip6tables"
 	MetricsUrl            = "/metrics"
+	SeccompReportUrl      = "/seccomp-report"
+	GoroutinesUrl         = "/debug/goroutines"
+	FaultInjectionUrl     = "/debug/faults"
+	QuiesceUrl            = "/quiesce"
+	UnquiesceUrl          = "/unquiesce"
+	GuestServicesUrl      = "/guest-services"
+	NetworkPolicyUrl      = "/network-policy"
+	HandoverUrl           = "/handover"
+	UsageUrl              = "/usage"
 )
 
 var (
@@ -73,14 +89,22 @@ func (s *service) serveMetrics(w http.ResponseWriter, r *http.Request) {
 	// update metrics for shim process
 	updateShimMetrics()
 
+	// update metrics of the containers
+	s.setContainerMetrics(r.Context())
+
 	// metrics gathered by shim
 	mfs, err := prometheus.DefaultGatherer.Gather()
 	if err != nil {
 		return
 	}
 
-	// encode the metrics
-	encoder := expfmt.NewEncoder(w, expfmt.FmtText)
+	// encode the metrics, the protobuf format keeps the exemplars
+	format := expfmt.Negotiate(r.Header)
+	w.Header().Set("Content-Type", string(format))
+	encoder := expfmt.NewEncoder(w, format)
+	if closer, ok := encoder.(expfmt.Closer); ok {
+		defer closer.Close()
+	}
 	for _, mf := range mfs {
 		encoder.Encode(mf)
 	}
@@ -144,6 +168,111 @@ func decodeAgentMetrics(body string) []*dto.MetricFamily {
 	return list
 }
 
+// serveSeccompReport handle /seccomp-report requests
+func (s *service) serveSeccompReport(w http.ResponseWriter, r *http.Request) {
+	report, err := s.sandbox.GetSeccompReport()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	buf, err := json.Marshal(report)
+	if err != nil {
+		shimMgtLog.WithError(err).Error("failed to marshal the seccomp report")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+	w.Write(buf)
+}
+
+// serveGuestServices handles /guest-services requests
+func (s *service) serveGuestServices(w http.ResponseWriter, r *http.Request) {
+	services, err := s.sandbox.GuestServices(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	buf, err := json.Marshal(services)
+	if err != nil {
+		shimMgtLog.WithError(err).Error("failed to marshal the guest services")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+	w.Write(buf)
+}
+
+// serveNetworkPolicy handles /network-policy requests: PUT sets the network
+// policy of the JSON body inside the guest, DELETE removes it.
+func (s *service) serveNetworkPolicy(w http.ResponseWriter, r *http.Request) {
+	var policy *vc.NetworkPolicy
+
+	switch r.Method {
+	case http.MethodPut:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		if policy, err = vc.ParseNetworkPolicy(string(body)); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+	case http.MethodDelete:
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := s.sandbox.SetNetworkPolicy(r.Context(), policy); err != nil {
+		shimMgtLog.WithError(err).Error("failed to set the network policy")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+}
+
+// serveFaults handles /debug/faults requests: GET lists the injected faults,
+// PUT sets the fault described by the JSON body and DELETE removes the fault
+// of the point query parameter, or all of them.
+func serveFaults(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		buf, err := json.Marshal(faultinject.List())
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		w.Write(buf)
+	case http.MethodPut:
+		var fault faultinject.Fault
+		if err := json.NewDecoder(r.Body).Decode(&fault); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		if err := faultinject.Set(fault); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		shimMgtLog.WithField("fault", fault).Warn("fault injection set")
+	case http.MethodDelete:
+		point := r.URL.Query().Get("point")
+		faultinject.Clear(point)
+		shimMgtLog.WithField("point", point).Info("fault injection cleared")
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
 func (s *service) serveVolumeStats(w http.ResponseWriter, r *http.Request) {
 	val := r.URL.Query().Get(DirectVolumePathKey)
 	if val == "" {
@@ -172,6 +301,80 @@ func (s *service) serveVolumeStats(w http.ResponseWriter, r *http.Request) {
 	w.Write(buf)
 }
 
+// serveContainerVolumeStats handles /volume-stats requests, it returns the stats of
+// the volumes of the container of the container query parameter, or the stats of
+// the volume mounted from the host path of the source query parameter, in the
+// format of the direct volumes stats.
+func (s *service) serveContainerVolumeStats(w http.ResponseWriter, r *http.Request) {
+	if source := r.URL.Query().Get(VolumeSourceKey); source != "" {
+		s.serveSourceVolumeStats(w, source)
+		return
+	}
+
+	containerID := r.URL.Query().Get(ContainerKey)
+	if containerID == "" {
+		msg := fmt.Sprintf("Required parameter %s or %s not found", ContainerKey, VolumeSourceKey)
+		shimMgtLog.Info(msg)
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(msg))
+		return
+	}
+
+	stats, err := s.sandbox.ContainerVolumeStats(context.Background(), containerID)
+	if err != nil {
+		shimMgtLog.WithError(err).WithField("container", containerID).Error("failed to get the volume stats of the container")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	buf, err := json.Marshal(stats)
+	if err != nil {
+		shimMgtLog.WithError(err).Error("failed to marshal the volume stats")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+	w.Write(buf)
+}
+
+func (s *service) serveSourceVolumeStats(w http.ResponseWriter, source string) {
+	s.mu.Lock()
+	containerIDs := make([]string, 0, len(s.containers))
+	for id := range s.containers {
+		containerIDs = append(containerIDs, id)
+	}
+	s.mu.Unlock()
+	sort.Strings(containerIDs)
+
+	for _, id := range containerIDs {
+		stats, err := s.sandbox.ContainerVolumeStats(context.Background(), id)
+		if err != nil {
+			shimMgtLog.WithError(err).WithField("container", id).Warn("failed to get the volume stats of the container")
+			continue
+		}
+		for _, st := range stats {
+			if st.Source != source || st.Stats == nil {
+				continue
+			}
+			buf, err := json.Marshal(st.Stats)
+			if err != nil {
+				shimMgtLog.WithError(err).Error("failed to marshal the volume stats")
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(err.Error()))
+				return
+			}
+			w.Write(buf)
+			return
+		}
+	}
+
+	msg := fmt.Sprintf("no volume mounted from %s", source)
+	shimMgtLog.Info(msg)
+	w.WriteHeader(http.StatusNotFound)
+	w.Write([]byte(msg))
+}
+
 func (s *service) serveVolumeResize(w http.ResponseWriter, r *http.Request) {
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
@@ -265,8 +468,20 @@ func (s *service) startManagementServer(ctx context.Context, ociSpec *specs.Spec
 	m.Handle(AgentUrl, http.HandlerFunc(s.agentURL))
 	m.Handle(DirectVolumeStatUrl, http.HandlerFunc(s.serveVolumeStats))
 	m.Handle(DirectVolumeResizeUrl, http.HandlerFunc(s.serveVolumeResize))
+	m.Handle(VolumeStatsUrl, http.HandlerFunc(s.serveContainerVolumeStats))
 	m.Handle(IPTablesUrl, http.HandlerFunc(s.ipTablesHandler))
 	m.Handle(IP6TablesUrl, http.HandlerFunc(s.ip6TablesHandler))
+	m.Handle(SeccompReportUrl, http.HandlerFunc(s.serveSeccompReport))
+	m.Handle(QuiesceUrl, http.HandlerFunc(s.serveQuiesce))
+	m.Handle(UnquiesceUrl, http.HandlerFunc(s.serveUnquiesce))
+	m.Handle(GuestServicesUrl, http.HandlerFunc(s.serveGuestServices))
+	m.Handle(NetworkPolicyUrl, http.HandlerFunc(s.serveNetworkPolicy))
+	m.Handle(HandoverUrl, http.HandlerFunc(s.serveHandover))
+	m.Handle(UsageUrl, http.HandlerFunc(s.serveUsage))
+	m.Handle(GoroutinesUrl, http.HandlerFunc(serveGoroutines))
+	if s.config.EnableFaultInjection {
+		m.Handle(FaultInjectionUrl, http.HandlerFunc(serveFaults))
+	}
 	s.mountPprofHandle(m, ociSpec)
 
 	// register shim metrics
@@ -275,25 +490,61 @@ func (s *service) startManagementServer(ctx context.Context, ociSpec *specs.Spec
 	// register sandbox metrics
 	vc.RegisterMetrics()
 
+	s.startAgentAPIBroker()
+
 	// start serve
 	svr := &http.Server{Handler: m}
 	svr.Serve(listener)
 }
 
+// serveGoroutines writes the stack traces of all the shim goroutines. Unlike
+// the pprof endpoints, it is always available so that a stuck shim can be
+// diagnosed after the fact.
+func serveGoroutines(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if err := runtimePprof.Lookup("goroutine").WriteTo(w, 2); err != nil {
+		shimMgtLog.WithError(err).Error("failed to dump goroutines")
+	}
+}
+
+// pprofEnabled tells if pprof is enabled for the sandbox, globally, for its
+// namespace or by annotation.
+func (s *service) pprofEnabled(ociSpec *specs.Spec) bool {
+	if s.config.EnablePprof {
+		return true
+	}
+
+	namespace := s.sandboxNamespace(ociSpec.Annotations)
+	for _, ns := range s.config.PprofNamespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+
+	value, ok := ociSpec.Annotations[vcAnnotations.EnablePprof]
+	if !ok {
+		return false
+	}
+	enabled, err := strconv.ParseBool(value)
+	return err == nil && enabled
+}
+
 // mountPprofHandle provides a debug endpoint
 func (s *service) mountPprofHandle(m *http.ServeMux, ociSpec *specs.Spec) {
 
 	// return if not enabled
-	if !s.config.EnablePprof {
-		value, ok := ociSpec.Annotations[vcAnnotations.EnablePprof]
-		if !ok {
-			return
-		}
-		enabled, err := strconv.ParseBool(value)
-		if err != nil || !enabled {
-			return
-		}
+	if !s.pprofEnabled(ociSpec) {
+		return
 	}
+
+	// The mutex and block profiles are empty unless sampling is enabled
+	if s.config.PprofMutexProfileFraction > 0 {
+		goruntime.SetMutexProfileFraction(s.config.PprofMutexProfileFraction)
+	}
+	if s.config.PprofBlockProfileRate > 0 {
+		goruntime.SetBlockProfileRate(s.config.PprofBlockProfileRate)
+	}
+
 	m.Handle("/debug/vars", expvar.Handler())
 	m.Handle("/debug/pprof/", http.HandlerFunc(pprof.Index))
 	m.Handle("/debug/pprof/cmdline", http.HandlerFunc(pprof.Cmdline))
@@ -6,13 +6,22 @@
 package containerdshim
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
 
+	ctrAnnotations "github.com/containerd/containerd/pkg/cri/annotations"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/faultinject"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/oci"
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/grpc"
+	vcAnnotations "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/vcmock"
+	"github.com/opencontainers/runtime-spec/specs-go"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -61,3 +70,174 @@ go_threads 23
 	body = rr.Body.String()
 	assert.Equal(true, len(strings.Split(body, "\n")) > 0)
 }
+
+func TestServeSeccompReport(t *testing.T) {
+	assert := assert.New(t)
+
+	sandbox := &vcmock.Sandbox{
+		MockID: testSandboxID,
+	}
+
+	s := &service{
+		id:         testSandboxID,
+		sandbox:    sandbox,
+		containers: make(map[string]*container),
+	}
+
+	expected := []vc.SeccompViolation{
+		{Comm: "mount", Exe: "/usr/bin/mount", Arch: "c000003e", Syscall: 165, Count: 2},
+	}
+	sandbox.GetSeccompReportFunc = func() ([]vc.SeccompViolation, error) {
+		return expected, nil
+	}
+
+	rr := httptest.NewRecorder()
+	s.serveSeccompReport(rr, &http.Request{})
+	assert.Equal(200, rr.Code)
+
+	var report []vc.SeccompViolation
+	assert.NoError(json.Unmarshal(rr.Body.Bytes(), &report))
+	assert.Equal(expected, report)
+
+	sandbox.GetSeccompReportFunc = func() ([]vc.SeccompViolation, error) {
+		return nil, fmt.Errorf("guest seccomp reporting is not enabled")
+	}
+
+	rr = httptest.NewRecorder()
+	s.serveSeccompReport(rr, &http.Request{})
+	assert.Equal(500, rr.Code)
+}
+
+func TestServeContainerVolumeStats(t *testing.T) {
+	assert := assert.New(t)
+
+	sandbox := &vcmock.Sandbox{
+		MockID: testSandboxID,
+	}
+
+	s := &service{
+		id:         testSandboxID,
+		sandbox:    sandbox,
+		containers: make(map[string]*container),
+	}
+
+	expected := []vc.ContainerVolumeStats{
+		{
+			Source:      "/var/lib/kubelet/pods/uid/volumes/kubernetes.io~empty-dir/data",
+			Destination: "/data",
+			Stats: &grpc.VolumeStatsResponse{
+				Usage: []*grpc.VolumeUsage{
+					{Available: 768, Total: 1024, Used: 256, Unit: grpc.VolumeUsage_BYTES},
+					{Available: 90, Total: 100, Used: 10, Unit: grpc.VolumeUsage_INODES},
+				},
+				VolumeCondition: &grpc.VolumeCondition{Message: "OK"},
+			},
+		},
+	}
+	sandbox.ContainerVolumeStatsFunc = func(containerID string) ([]vc.ContainerVolumeStats, error) {
+		if containerID != testContainerID {
+			return nil, fmt.Errorf("container %s not found", containerID)
+		}
+		return expected, nil
+	}
+
+	rr := httptest.NewRecorder()
+	s.serveContainerVolumeStats(rr, httptest.NewRequest(http.MethodGet, VolumeStatsUrl+"?container="+testContainerID, nil))
+	assert.Equal(200, rr.Code)
+
+	var stats []vc.ContainerVolumeStats
+	assert.NoError(json.Unmarshal(rr.Body.Bytes(), &stats))
+	assert.Equal(expected, stats)
+
+	rr = httptest.NewRecorder()
+	s.serveContainerVolumeStats(rr, httptest.NewRequest(http.MethodGet, VolumeStatsUrl, nil))
+	assert.Equal(400, rr.Code)
+
+	rr = httptest.NewRecorder()
+	s.serveContainerVolumeStats(rr, httptest.NewRequest(http.MethodGet, VolumeStatsUrl+"?container=unknown", nil))
+	assert.Equal(500, rr.Code)
+
+	// the stats of a volume, from its host path, are the direct volumes ones
+	s.containers[testContainerID] = &container{}
+	rr = httptest.NewRecorder()
+	s.serveContainerVolumeStats(rr, httptest.NewRequest(http.MethodGet, VolumeStatsUrl+"?source="+url.QueryEscape(expected[0].Source), nil))
+	assert.Equal(200, rr.Code)
+
+	var volumeStats grpc.VolumeStatsResponse
+	assert.NoError(json.Unmarshal(rr.Body.Bytes(), &volumeStats))
+	assert.Equal(expected[0].Stats, &volumeStats)
+
+	rr = httptest.NewRecorder()
+	s.serveContainerVolumeStats(rr, httptest.NewRequest(http.MethodGet, VolumeStatsUrl+"?source=/unknown", nil))
+	assert.Equal(404, rr.Code)
+}
+
+func TestServeGoroutines(t *testing.T) {
+	assert := assert.New(t)
+
+	rr := httptest.NewRecorder()
+	serveGoroutines(rr, &http.Request{})
+	assert.Equal(200, rr.Code)
+	assert.Contains(rr.Body.String(), "goroutine ")
+	assert.Contains(rr.Body.String(), "TestServeGoroutines")
+}
+
+func TestPprofEnabled(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &service{
+		namespace: "k8s.io",
+		config:    &oci.RuntimeConfig{},
+	}
+
+	spec := &specs.Spec{Annotations: map[string]string{}}
+	assert.False(s.pprofEnabled(spec))
+
+	spec.Annotations[vcAnnotations.EnablePprof] = "true"
+	assert.True(s.pprofEnabled(spec))
+
+	// selected by the Kubernetes namespace
+	spec.Annotations = map[string]string{ctrAnnotations.SandboxNamespace: "perf"}
+	s.config.PprofNamespaces = []string{"perf"}
+	assert.True(s.pprofEnabled(spec))
+
+	s.config.PprofNamespaces = []string{"k8s.io"}
+	assert.False(s.pprofEnabled(spec))
+
+	// or by the containerd one
+	spec.Annotations = map[string]string{}
+	assert.True(s.pprofEnabled(spec))
+
+	s.config.PprofNamespaces = nil
+	s.config.EnablePprof = true
+	assert.True(s.pprofEnabled(spec))
+}
+
+func TestServeFaults(t *testing.T) {
+	assert := assert.New(t)
+	defer faultinject.Clear("")
+
+	rr := httptest.NewRecorder()
+	serveFaults(rr, httptest.NewRequest(http.MethodPut, FaultInjectionUrl, strings.NewReader(`{"point": "hypervisor.StartVM", "error": "boom"}`)))
+	assert.Equal(200, rr.Code)
+
+	rr = httptest.NewRecorder()
+	serveFaults(rr, httptest.NewRequest(http.MethodPut, FaultInjectionUrl, strings.NewReader(`{"point": "hypervisor.StartVM"}`)))
+	assert.Equal(400, rr.Code)
+
+	rr = httptest.NewRecorder()
+	serveFaults(rr, httptest.NewRequest(http.MethodGet, FaultInjectionUrl, nil))
+	assert.Equal(200, rr.Code)
+	var faults []faultinject.Fault
+	assert.NoError(json.Unmarshal(rr.Body.Bytes(), &faults))
+	assert.Equal([]faultinject.Fault{{Point: "hypervisor.StartVM", Error: "boom"}}, faults)
+
+	rr = httptest.NewRecorder()
+	serveFaults(rr, httptest.NewRequest(http.MethodDelete, FaultInjectionUrl+"?point=hypervisor.StartVM", nil))
+	assert.Equal(200, rr.Code)
+	assert.Empty(faultinject.List())
+
+	rr = httptest.NewRecorder()
+	serveFaults(rr, httptest.NewRequest(http.MethodPost, FaultInjectionUrl, nil))
+	assert.Equal(http.StatusMethodNotAllowed, rr.Code)
+}
@@ -9,6 +9,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/featureflags"
 	mutils "github.com/kata-containers/kata-containers/src/runtime/pkg/utils"
 	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
 	"github.com/prometheus/client_golang/prometheus"
@@ -82,6 +83,38 @@ var (
 		Name:      "pod_overhead_memory_in_bytes",
 		Help:      "Kata Pod overhead for memory resources(bytes).",
 	})
+
+	katashimContainerCPU = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespaceKatashim,
+		Name:      "container_cpu",
+		Help:      "CPU statistics of the container cgroups inside guest (nanoseconds and periods).",
+	},
+		[]string{"container_id", "item"},
+	)
+
+	katashimContainerMemory = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespaceKatashim,
+		Name:      "container_memory",
+		Help:      "Memory statistics of the container cgroups inside guest (bytes).",
+	},
+		[]string{"container_id", "item"},
+	)
+
+	katashimContainerPids = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespaceKatashim,
+		Name:      "container_pids",
+		Help:      "Number of processes of the container cgroups inside guest.",
+	},
+		[]string{"container_id", "item"},
+	)
+
+	katashimFeatureFlags = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespaceKatashim,
+		Name:      "feature_flag",
+		Help:      "Feature flags of the runtime, 1 when enabled on the node.",
+	},
+		[]string{"name", "rollout", "source"},
+	)
 )
 
 func registerMetrics() {
@@ -94,6 +127,35 @@ func registerMetrics() {
 	prometheus.MustRegister(katashimOpenFDs)
 	prometheus.MustRegister(katashimPodOverheadCPU)
 	prometheus.MustRegister(katashimPodOverheadMemory)
+	prometheus.MustRegister(katashimContainerCPU)
+	prometheus.MustRegister(katashimContainerMemory)
+	prometheus.MustRegister(katashimContainerPids)
+	prometheus.MustRegister(katashimFeatureFlags)
+}
+
+// observeRPCDuration records the duration of an RPC, along with an exemplar
+// carrying the sandbox correlation ID and trace ID.
+func (s *service) observeRPCDuration(action string, start time.Time) {
+	duration := float64(time.Since(start).Nanoseconds() / int64(time.Millisecond))
+	observer := rpcDurationsHistogram.WithLabelValues(action)
+
+	if exemplarObserver, ok := observer.(prometheus.ExemplarObserver); ok && s.correlationID != "" {
+		exemplarObserver.ObserveWithExemplar(duration, s.exemplarLabels())
+		return
+	}
+	observer.Observe(duration)
+}
+
+// exemplarLabels returns the labels identifying the sandbox in the metrics
+// exemplars, the trace ID being only known when tracing.
+func (s *service) exemplarLabels() prometheus.Labels {
+	labels := prometheus.Labels{"correlation_id": s.correlationID}
+	if s.rootSpan != nil {
+		if spanContext := s.rootSpan.SpanContext(); spanContext.IsValid() {
+			labels["trace_id"] = spanContext.TraceID().String()
+		}
+	}
+	return labels
 }
 
 // updateShimMetrics will update metrics for kata shim process itself
@@ -132,9 +194,23 @@ func updateShimMetrics() error {
 		mutils.SetGaugeVecProcIO(katashimIOStat, ioStat)
 	}
 
+	setFeatureFlagsMetrics()
+
 	return nil
 }
 
+// setFeatureFlagsMetrics reports the states of the feature flags.
+func setFeatureFlagsMetrics() {
+	katashimFeatureFlags.Reset()
+	for _, flag := range featureflags.List() {
+		value := 0.0
+		if flag.Enabled {
+			value = 1
+		}
+		katashimFeatureFlags.WithLabelValues(flag.Name, flag.Rollout, flag.Source).Set(value)
+	}
+}
+
 // statsSandbox returns a detailed sandbox stats.
 func (s *service) statsSandbox(ctx context.Context) (vc.SandboxStats, []vc.ContainerStats, error) {
 	sandboxStats, err := s.sandbox.Stats(ctx)
@@ -154,6 +230,57 @@ func (s *service) statsSandbox(ctx context.Context) (vc.SandboxStats, []vc.Conta
 	return sandboxStats, containerStats, nil
 }
 
+// setContainerMetrics sets the metrics of each container from the stats of
+// its cgroup inside guest, so that the containers of a pod are accounted for
+// individually. The metrics of the containers that are gone are dropped.
+func (s *service) setContainerMetrics(ctx context.Context) {
+	katashimContainerCPU.Reset()
+	katashimContainerMemory.Reset()
+	katashimContainerPids.Reset()
+
+	for _, c := range s.sandbox.GetAllContainers() {
+		stats, err := s.sandbox.StatsContainer(ctx, c.ID())
+		if err != nil {
+			shimLog.WithError(err).WithField("container", c.ID()).Debug("failed to get container stats")
+			continue
+		}
+		setContainerStatsMetrics(c.ID(), stats)
+	}
+}
+
+func setContainerStatsMetrics(containerID string, stats vc.ContainerStats) {
+	if stats.CgroupStats == nil {
+		return
+	}
+
+	cpu := stats.CgroupStats.CPUStats
+	for item, value := range map[string]uint64{
+		"usage_total":       cpu.CPUUsage.TotalUsage,
+		"usage_user":        cpu.CPUUsage.UsageInUsermode,
+		"usage_kernel":      cpu.CPUUsage.UsageInKernelmode,
+		"periods":           cpu.ThrottlingData.Periods,
+		"throttled_periods": cpu.ThrottlingData.ThrottledPeriods,
+		"throttled_time":    cpu.ThrottlingData.ThrottledTime,
+	} {
+		katashimContainerCPU.WithLabelValues(containerID, item).Set(float64(value))
+	}
+
+	memory := stats.CgroupStats.MemoryStats
+	for item, value := range map[string]uint64{
+		"usage":     memory.Usage.Usage,
+		"max_usage": memory.Usage.MaxUsage,
+		"limit":     memory.Usage.Limit,
+		"failcnt":   memory.Usage.Failcnt,
+		"cache":     memory.Cache,
+	} {
+		katashimContainerMemory.WithLabelValues(containerID, item).Set(float64(value))
+	}
+
+	pids := stats.CgroupStats.PidsStats
+	katashimContainerPids.WithLabelValues(containerID, "current").Set(float64(pids.Current))
+	katashimContainerPids.WithLabelValues(containerID, "limit").Set(float64(pids.Limit))
+}
+
 func calcOverhead(initialSandboxStats, finishSandboxStats vc.SandboxStats, initialContainerStats, finishContainersStats []vc.ContainerStats, deltaTime float64) (float64, float64) {
 	hostInitCPU := initialSandboxStats.CgroupStats.CPUStats.CPUUsage.TotalUsage
 	guestInitCPU := uint64(0)
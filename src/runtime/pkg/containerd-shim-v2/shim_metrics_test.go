@@ -8,9 +8,12 @@ package containerdshim
 import (
 	"context"
 	"testing"
+	"time"
 
 	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/vcmock"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -113,3 +116,83 @@ func TestStatsSandbox(t *testing.T) {
 	//       = 50000
 	assert.Equal(float64(50000), mem)
 }
+
+func TestObserveRPCDuration(t *testing.T) {
+	assert := assert.New(t)
+
+	correlationID, err := newCorrelationID()
+	assert.NoError(err)
+	assert.Len(correlationID, 32)
+
+	s := &service{
+		correlationID: correlationID,
+	}
+	s.observeRPCDuration("test_exemplar", time.Now().Add(-3*time.Millisecond))
+
+	m := &dto.Metric{}
+	assert.NoError(rpcDurationsHistogram.WithLabelValues("test_exemplar").(prometheus.Metric).Write(m))
+	assert.Equal(uint64(1), m.Histogram.GetSampleCount())
+
+	var exemplar *dto.Exemplar
+	for _, b := range m.Histogram.Bucket {
+		if b.Exemplar != nil {
+			exemplar = b.Exemplar
+		}
+	}
+	assert.NotNil(exemplar)
+	// no trace ID without tracing
+	assert.Len(exemplar.Label, 1)
+	assert.Equal("correlation_id", exemplar.Label[0].GetName())
+	assert.Equal(correlationID, exemplar.Label[0].GetValue())
+}
+
+func TestSetContainerMetrics(t *testing.T) {
+	assert := assert.New(t)
+
+	sandbox := &vcmock.Sandbox{
+		MockID:             testSandboxID,
+		StatsContainerFunc: getStatsContainerCPUFunc(100, 200, 10000, 20000),
+		MockContainers: []*vcmock.Container{
+			{
+				MockID: "foo",
+			},
+			{
+				MockID: "bar",
+			},
+		},
+	}
+
+	s := &service{
+		id:         testSandboxID,
+		sandbox:    sandbox,
+		containers: make(map[string]*container),
+	}
+
+	gaugeValue := func(gv *prometheus.GaugeVec, containerID, item string) float64 {
+		m := &dto.Metric{}
+		assert.NoError(gv.WithLabelValues(containerID, item).Write(m))
+		return m.Gauge.GetValue()
+	}
+
+	s.setContainerMetrics(context.Background())
+	assert.Equal(float64(100*1e9), gaugeValue(katashimContainerCPU, "foo", "usage_total"))
+	assert.Equal(float64(200*1e9), gaugeValue(katashimContainerCPU, "bar", "usage_total"))
+	assert.Equal(float64(10000), gaugeValue(katashimContainerMemory, "foo", "usage"))
+	assert.Equal(float64(20000), gaugeValue(katashimContainerMemory, "bar", "usage"))
+
+	// the metrics of the deleted containers are dropped
+	sandbox.MockContainers = sandbox.MockContainers[:1]
+	s.setContainerMetrics(context.Background())
+	ch := make(chan prometheus.Metric, 100)
+	katashimContainerMemory.Collect(ch)
+	close(ch)
+	for metric := range ch {
+		m := &dto.Metric{}
+		assert.NoError(metric.Write(m))
+		for _, label := range m.Label {
+			if label.GetName() == "container_id" {
+				assert.Equal("foo", label.GetValue())
+			}
+		}
+	}
+}
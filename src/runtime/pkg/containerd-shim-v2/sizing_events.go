@@ -0,0 +1,27 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"github.com/containerd/typeurl"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/oci"
+)
+
+// sandboxSizingEventTopic is the topic of the sizing events of the
+// sandboxes.
+const sandboxSizingEventTopic = "/kata/sandbox/sizing"
+
+// SandboxSizingEvent details how the resources added to the VM of a sandbox
+// were calculated, for debugging sizing decisions. It is published JSON
+// encoded once the sandbox is created.
+type SandboxSizingEvent struct {
+	SandboxID string `json:"sandbox_id"`
+	oci.SandboxSizing
+}
+
+func init() {
+	typeurl.Register(&SandboxSizingEvent{}, "io.katacontainers.events", "SandboxSizingEvent")
+}
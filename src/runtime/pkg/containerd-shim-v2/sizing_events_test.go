@@ -0,0 +1,37 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/containerd/typeurl"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/oci"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSandboxSizingEvent(t *testing.T) {
+	assert := assert.New(t)
+
+	e := &SandboxSizingEvent{
+		SandboxID: testSandboxID,
+		SandboxSizing: oci.SandboxSizing{
+			WorkloadMilliCPUs: 2000,
+			InitMilliCPUs:     3500,
+			VCPUs:             4,
+		},
+	}
+	assert.Equal(sandboxSizingEventTopic, getTopic(e))
+
+	any, err := typeurl.MarshalAny(e)
+	assert.NoError(err)
+	var decoded map[string]interface{}
+	assert.NoError(json.Unmarshal(any.Value, &decoded))
+	assert.Equal(testSandboxID, decoded["sandbox_id"])
+	assert.Equal(float64(3500), decoded["init_milli_cpus"])
+	assert.Equal(float64(4), decoded["vcpus"])
+}
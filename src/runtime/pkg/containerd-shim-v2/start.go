@@ -8,6 +8,7 @@ package containerdshim
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"github.com/sirupsen/logrus"
 
@@ -34,6 +35,10 @@ func startContainer(ctx context.Context, s *service, c *container) (retErr error
 		return err
 	}
 
+	if c.host != nil {
+		return startHostContainer(ctx, s, c)
+	}
+
 	if c.cType.IsSandbox() {
 		err := s.sandbox.Start(ctx)
 		if err != nil {
@@ -44,11 +49,28 @@ func startContainer(ctx context.Context, s *service, c *container) (retErr error
 		if err != nil {
 			return err
 		}
+		if s.sandbox.CanRestart() {
+			s.vmRestart = newVMRestart()
+		}
 		go watchSandbox(ctx, s)
 
 		// We use s.ctx(`ctx` derived from `s.ctx`) to check for cancellation of the
 		// shim context and the context passed to startContainer for tracing.
 		go watchOOMEvents(ctx, s)
+		go watchSeccompNotifications(ctx, s)
+
+		if events := s.sandbox.MultipathEvents(); events != nil {
+			go forwardMultipathEvents(ctx, s, events)
+		}
+		if violations := s.sandbox.SeccompViolations(); violations != nil {
+			go forwardSeccompViolations(ctx, s, violations)
+		}
+		s.startUsageRecorder(ctx)
+	} else if c.checkpoint != "" {
+		_, err := s.sandbox.RestoreContainer(ctx, c.id, c.checkpoint)
+		if err != nil {
+			return err
+		}
 	} else {
 		_, err := s.sandbox.StartContainer(ctx, c.id)
 		if err != nil {
@@ -68,11 +90,27 @@ func startContainer(ctx context.Context, s *service, c *container) (retErr error
 
 	c.status = task.StatusRunning
 
+	if err := startContainerIO(ctx, s, c); err != nil {
+		return err
+	}
+
+	go wait(ctx, s, c, "")
+
+	return nil
+}
+
+// startContainerIO copies the IO streams of the container process.
+func startContainerIO(ctx context.Context, s *service, c *container) error {
 	stdin, stdout, stderr, err := s.sandbox.IOStream(c.id, c.id)
 	if err != nil {
 		return err
 	}
 
+	return copyContainerIO(ctx, s, c, stdin, stdout, stderr)
+}
+
+// copyContainerIO copies the given IO streams of the container process.
+func copyContainerIO(ctx context.Context, s *service, c *container, stdin io.WriteCloser, stdout, stderr io.Reader) error {
 	c.stdinPipe = stdin
 
 	if c.stdin != "" || c.stdout != "" || c.stderr != "" {
@@ -80,6 +118,7 @@ func startContainer(ctx context.Context, s *service, c *container) (retErr error
 		if err != nil {
 			return err
 		}
+		tty.io = newLogDriverIO(tty.io, s.openLogDrivers(c, ""))
 		c.ttyio = tty
 
 		go ioCopy(shimLog.WithField("container", c.id), c.exitIOch, c.stdinCloser, tty, stdin, stdout, stderr)
@@ -92,8 +131,6 @@ func startContainer(ctx context.Context, s *service, c *container) (retErr error
 		close(c.stdinCloser)
 	}
 
-	go wait(ctx, s, c, "")
-
 	return nil
 }
 
@@ -135,17 +172,29 @@ func startExec(ctx context.Context, s *service, containerID, execID string) (e *
 		}
 	}
 
+	if err := startExecIO(ctx, s, c, execs, execID); err != nil {
+		return nil, err
+	}
+
+	go wait(ctx, s, c, execID)
+
+	return execs, nil
+}
+
+// startExecIO copies the IO streams of the exec process.
+func startExecIO(ctx context.Context, s *service, c *container, execs *exec, execID string) error {
 	stdin, stdout, stderr, err := s.sandbox.IOStream(c.id, execs.id)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	execs.stdinPipe = stdin
 
 	tty, err := newTtyIO(ctx, s.namespace, execs.id, execs.tty.stdin, execs.tty.stdout, execs.tty.stderr, execs.tty.terminal)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	tty.io = newLogDriverIO(tty.io, s.openLogDrivers(c, execID))
 	execs.ttyio = tty
 
 	go ioCopy(shimLog.WithFields(logrus.Fields{
@@ -153,7 +202,5 @@ func startExec(ctx context.Context, s *service, containerID, execID string) (e *
 		"exec":      execID,
 	}), execs.exitIOch, execs.stdinCloser, tty, stdin, stdout, stderr)
 
-	go wait(ctx, s, c, execID)
-
-	return execs, nil
+	return nil
 }
@@ -0,0 +1,283 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/procfs"
+
+	resCtrl "github.com/kata-containers/kata-containers/src/runtime/pkg/resourcecontrol"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/usagespool"
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+)
+
+const defaultUsageSnapshotInterval = 60 * time.Second
+
+// usageCounter accumulates a cumulative counter across its resets, when the
+// VM is restarted for instance.
+type usageCounter struct {
+	total uint64
+	last  uint64
+}
+
+func (c *usageCounter) update(value uint64) uint64 {
+	if value >= c.last {
+		c.total += value - c.last
+	} else {
+		c.total += value
+	}
+	c.last = value
+	return c.total
+}
+
+// usageRecorder tracks the resource usage of the sandbox, and persists it
+// in the spool directory when one is configured.
+type usageRecorder struct {
+	sandbox vc.VCSandbox
+	stop    chan struct{}
+	dir     string
+
+	mu       sync.Mutex
+	snapshot usagespool.Snapshot
+	cpu      usageCounter
+	netRx    usageCounter
+	netTx    usageCounter
+	blkRead  usageCounter
+	blkWrite usageCounter
+	final    bool
+}
+
+// newUsageRecorder returns the recorder of the sandbox. The usage persisted
+// by a previous shim of the sandbox is carried on.
+func newUsageRecorder(sandbox vc.VCSandbox, dir string) *usageRecorder {
+	r := &usageRecorder{
+		sandbox: sandbox,
+		stop:    make(chan struct{}),
+		dir:     dir,
+		snapshot: usagespool.Snapshot{
+			SandboxID: sandbox.ID(),
+			StartTime: time.Now().UTC(),
+		},
+	}
+
+	if dir == "" {
+		return r
+	}
+	if prev, err := usagespool.Read(dir, sandbox.ID()); err == nil && !prev.Final {
+		r.snapshot = prev
+		r.cpu = usageCounter{prev.CPUTime, prev.CPUTime}
+		r.netRx = usageCounter{prev.NetRxBytes, prev.NetRxBytes}
+		r.netTx = usageCounter{prev.NetTxBytes, prev.NetTxBytes}
+		r.blkRead = usageCounter{prev.BlockReadBytes, prev.BlockReadBytes}
+		r.blkWrite = usageCounter{prev.BlockWriteBytes, prev.BlockWriteBytes}
+	}
+
+	return r
+}
+
+var (
+	// isCgroupV1 tells in which unit the CPU time of the sandbox is
+	// reported, microseconds with cgroup v2.
+	isCgroupV1 = resCtrl.IsCgroupV1
+
+	// procNetDev returns the interfaces of the network namespace of a
+	// process.
+	procNetDev = func(pid int) (procfs.NetDev, error) {
+		proc, err := procfs.NewProc(pid)
+		if err != nil {
+			return nil, err
+		}
+		return proc.NetDev()
+	}
+)
+
+// cpuTime returns the CPU time of the sandbox in nanoseconds.
+func cpuTime(stats vc.CPUStats) (uint64, error) {
+	v1, err := isCgroupV1()
+	if err != nil {
+		return 0, err
+	}
+	if v1 {
+		return stats.CPUUsage.TotalUsage, nil
+	}
+	return stats.CPUUsage.TotalUsage * uint64(time.Microsecond), nil
+}
+
+// netUsage returns the bytes received and sent on the interfaces of the
+// network namespace of the sandbox, the one the hypervisor runs in. The shim
+// runs in the network namespace of the host.
+func netUsage(sandbox vc.VCSandbox) (rx, tx uint64, err error) {
+	pid, err := sandbox.GetHypervisorPid()
+	if err != nil {
+		return 0, 0, err
+	}
+	netdev, err := procNetDev(pid)
+	if err != nil {
+		return 0, 0, err
+	}
+	for name, line := range netdev {
+		if name == "lo" {
+			continue
+		}
+		rx += line.RxBytes
+		tx += line.TxBytes
+	}
+	return rx, tx, nil
+}
+
+// blockUsage returns the bytes read from and written to the block devices.
+func blockUsage(stats vc.BlkioStats) (read, write uint64) {
+	for _, e := range stats.IoServiceBytesRecursive {
+		switch strings.ToLower(e.Op) {
+		case "read":
+			read += e.Value
+		case "write":
+			write += e.Value
+		}
+	}
+	return read, write
+}
+
+// record takes a snapshot of the usage of the sandbox and persists it. The
+// final snapshot is persisted with the last known usage when the sandbox
+// cannot report it anymore, and no snapshot is taken after it.
+func (r *usageRecorder) record(ctx context.Context, final bool) (usagespool.Snapshot, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.final {
+		return r.snapshot, nil
+	}
+
+	var cpu uint64
+	stats, err := r.sandbox.Stats(ctx)
+	if err == nil {
+		cpu, err = cpuTime(stats.CgroupStats.CPUStats)
+	}
+	if err == nil {
+		cgroup := stats.CgroupStats
+		r.snapshot.CPUTime = r.cpu.update(cpu)
+		for _, mem := range []uint64{cgroup.MemoryStats.Usage.Usage, cgroup.MemoryStats.Usage.MaxUsage} {
+			if mem > r.snapshot.MaxMemory {
+				r.snapshot.MaxMemory = mem
+			}
+		}
+		read, write := blockUsage(cgroup.BlkioStats)
+		r.snapshot.BlockReadBytes = r.blkRead.update(read)
+		r.snapshot.BlockWriteBytes = r.blkWrite.update(write)
+
+		var rx, tx uint64
+		if rx, tx, err = netUsage(r.sandbox); err == nil {
+			r.snapshot.NetRxBytes = r.netRx.update(rx)
+			r.snapshot.NetTxBytes = r.netTx.update(tx)
+		}
+	}
+	if err != nil && !final {
+		return r.snapshot, err
+	}
+
+	r.snapshot.Sequence++
+	r.snapshot.Timestamp = time.Now().UTC()
+	r.snapshot.Final = final
+	if final {
+		r.final = true
+		close(r.stop)
+	}
+
+	if r.dir != "" {
+		if werr := usagespool.Write(r.dir, r.snapshot); werr != nil {
+			return r.snapshot, werr
+		}
+	}
+
+	return r.snapshot, err
+}
+
+// run persists the usage of the sandbox at each interval, until the final
+// snapshot is taken.
+func (r *usageRecorder) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			if _, err := r.record(ctx, false); err != nil {
+				shimLog.WithError(err).Warn("failed to record the sandbox usage")
+			}
+		}
+	}
+}
+
+// startUsageRecorder starts tracking the usage of the sandbox once it is
+// started.
+func (s *service) startUsageRecorder(ctx context.Context) {
+	var dir string
+	interval := defaultUsageSnapshotInterval
+	if s.config != nil {
+		dir = s.config.UsageSpoolDir
+		if s.config.UsageSnapshotInterval > 0 {
+			interval = time.Duration(s.config.UsageSnapshotInterval) * time.Second
+		}
+	}
+
+	s.usage = newUsageRecorder(s.sandbox, dir)
+	if dir == "" {
+		return
+	}
+
+	if _, err := s.usage.record(ctx, false); err != nil {
+		shimLog.WithError(err).Warn("failed to record the sandbox usage")
+	}
+	go s.usage.run(ctx, interval)
+}
+
+// recordFinalUsage takes the final usage snapshot, before the sandbox is
+// stopped.
+func (s *service) recordFinalUsage(ctx context.Context) {
+	if s.usage == nil {
+		return
+	}
+	if _, err := s.usage.record(ctx, true); err != nil {
+		shimLog.WithError(err).Warn("failed to record the final sandbox usage")
+	}
+}
+
+// serveUsage handles /usage requests, returning the current usage of the
+// sandbox.
+func (s *service) serveUsage(w http.ResponseWriter, r *http.Request) {
+	if s.usage == nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte("sandbox not started"))
+		return
+	}
+
+	snapshot, err := s.usage.record(r.Context(), false)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	buf, err := json.Marshal(snapshot)
+	if err != nil {
+		shimMgtLog.WithError(err).Error("failed to marshal the sandbox usage")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+	w.Write(buf)
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/procfs"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/oci"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/usagespool"
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/vcmock"
+)
+
+func TestUsageCounter(t *testing.T) {
+	assert := assert.New(t)
+
+	var c usageCounter
+	assert.Equal(uint64(10), c.update(10))
+	assert.Equal(uint64(25), c.update(25))
+	// The counter restarts from zero.
+	assert.Equal(uint64(30), c.update(5))
+	assert.Equal(uint64(32), c.update(7))
+}
+
+func TestBlockUsage(t *testing.T) {
+	assert := assert.New(t)
+
+	read, write := blockUsage(vc.BlkioStats{
+		IoServiceBytesRecursive: []vc.BlkioStatEntry{
+			{Op: "Read", Major: 8, Value: 100},
+			{Op: "Write", Major: 8, Value: 10},
+			{Op: "Total", Major: 8, Value: 110},
+			{Op: "read", Major: 253, Value: 1},
+		},
+	})
+	assert.Equal(uint64(101), read)
+	assert.Equal(uint64(10), write)
+}
+
+func TestCPUTime(t *testing.T) {
+	assert := assert.New(t)
+
+	saved := isCgroupV1
+	defer func() {
+		isCgroupV1 = saved
+	}()
+
+	stats := vc.CPUStats{CPUUsage: vc.CPUUsage{TotalUsage: 1500}}
+
+	isCgroupV1 = func() (bool, error) { return true, nil }
+	cpu, err := cpuTime(stats)
+	assert.NoError(err)
+	assert.Equal(uint64(1500), cpu)
+
+	// cgroup v2 reports microseconds
+	isCgroupV1 = func() (bool, error) { return false, nil }
+	cpu, err = cpuTime(stats)
+	assert.NoError(err)
+	assert.Equal(uint64(1500000), cpu)
+}
+
+// mockUsageSources reports the CPU time as cgroup v1 does, and the given
+// network usage in the network namespace of the hypervisor.
+func mockUsageSources(rx, tx *uint64) func() {
+	savedIsCgroupV1, savedProcNetDev := isCgroupV1, procNetDev
+	isCgroupV1 = func() (bool, error) { return true, nil }
+	procNetDev = func(pid int) (procfs.NetDev, error) {
+		return procfs.NetDev{
+			"lo":   {Name: "lo", RxBytes: 100, TxBytes: 100},
+			"eth0": {Name: "eth0", RxBytes: *rx, TxBytes: *tx},
+		}, nil
+	}
+	return func() {
+		isCgroupV1, procNetDev = savedIsCgroupV1, savedProcNetDev
+	}
+}
+
+func testUsageStats(cpu, mem uint64) vc.SandboxStats {
+	stats := vc.SandboxStats{}
+	stats.CgroupStats.CPUStats.CPUUsage.TotalUsage = cpu
+	stats.CgroupStats.MemoryStats.Usage.Usage = mem
+	return stats
+}
+
+func TestUsageRecorder(t *testing.T) {
+	assert := assert.New(t)
+
+	rx, tx := uint64(2048), uint64(512)
+	defer mockUsageSources(&rx, &tx)()
+
+	dir := t.TempDir()
+	var statsErr error
+	stats := testUsageStats(1000, 4096)
+	sandbox := &vcmock.Sandbox{
+		MockID: testSandboxID,
+		StatsFunc: func() (vc.SandboxStats, error) {
+			return stats, statsErr
+		},
+	}
+
+	s := &service{
+		sandbox: sandbox,
+		config: &oci.RuntimeConfig{
+			UsageSpoolDir: dir,
+		},
+	}
+	s.startUsageRecorder(context.Background())
+	defer s.recordFinalUsage(context.Background())
+
+	snapshot, err := usagespool.Read(dir, testSandboxID)
+	assert.NoError(err)
+	assert.Equal(uint64(1), snapshot.Sequence)
+	assert.Equal(uint64(1000), snapshot.CPUTime)
+	assert.Equal(uint64(4096), snapshot.MaxMemory)
+	assert.Equal(uint64(2048), snapshot.NetRxBytes)
+	assert.Equal(uint64(512), snapshot.NetTxBytes)
+
+	stats = testUsageStats(1500, 1024)
+	rx, tx = 4096, 1024
+	snapshot, err = s.usage.record(context.Background(), false)
+	assert.NoError(err)
+	assert.Equal(uint64(2), snapshot.Sequence)
+	assert.Equal(uint64(1500), snapshot.CPUTime)
+	assert.Equal(uint64(4096), snapshot.MaxMemory)
+	assert.Equal(uint64(4096), snapshot.NetRxBytes)
+	assert.Equal(uint64(1024), snapshot.NetTxBytes)
+
+	// A new shim of the sandbox carries the usage on.
+	r := newUsageRecorder(sandbox, dir)
+	stats = testUsageStats(1600, 1024)
+	snapshot, err = r.record(context.Background(), false)
+	assert.NoError(err)
+	assert.Equal(uint64(3), snapshot.Sequence)
+	assert.Equal(uint64(1600), snapshot.CPUTime)
+
+	// The final snapshot keeps the last known usage.
+	statsErr = errors.New("sandbox stopped")
+	_, err = r.record(context.Background(), false)
+	assert.Error(err)
+	snapshot, err = r.record(context.Background(), true)
+	assert.Error(err)
+	assert.True(snapshot.Final)
+	assert.Equal(uint64(1600), snapshot.CPUTime)
+
+	snapshot, err = r.record(context.Background(), false)
+	assert.NoError(err)
+	assert.Equal(uint64(4), snapshot.Sequence)
+
+	snapshot, err = usagespool.Read(dir, testSandboxID)
+	assert.NoError(err)
+	assert.True(snapshot.Final)
+
+	// The final snapshot is not carried on.
+	r = newUsageRecorder(sandbox, dir)
+	assert.Equal(uint64(0), r.snapshot.Sequence)
+}
+
+func TestServeUsage(t *testing.T) {
+	assert := assert.New(t)
+
+	rx, tx := uint64(0), uint64(0)
+	defer mockUsageSources(&rx, &tx)()
+
+	s := &service{
+		sandbox: &vcmock.Sandbox{
+			MockID: testSandboxID,
+			StatsFunc: func() (vc.SandboxStats, error) {
+				return testUsageStats(1000, 4096), nil
+			},
+		},
+	}
+
+	rr := httptest.NewRecorder()
+	s.serveUsage(rr, httptest.NewRequest(http.MethodGet, UsageUrl, nil))
+	assert.Equal(http.StatusServiceUnavailable, rr.Code)
+
+	// The usage can be read without a spool directory.
+	s.startUsageRecorder(context.Background())
+	rr = httptest.NewRecorder()
+	s.serveUsage(rr, httptest.NewRequest(http.MethodGet, UsageUrl, nil))
+	assert.Equal(http.StatusOK, rr.Code)
+
+	var snapshot usagespool.Snapshot
+	assert.NoError(json.Unmarshal(rr.Body.Bytes(), &snapshot))
+	assert.Equal(testSandboxID, snapshot.SandboxID)
+	assert.Equal(uint64(1000), snapshot.CPUTime)
+}
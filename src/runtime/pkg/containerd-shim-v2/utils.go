@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/containerd/containerd/mount"
+	ctrAnnotations "github.com/containerd/containerd/pkg/cri/annotations"
 	cdshim "github.com/containerd/containerd/runtime/v2/shim"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/oci"
@@ -114,3 +115,13 @@ func getAddress(ctx context.Context, bundlePath, address, id string) (string, er
 func noNeedForOutput(detach bool, tty bool) bool {
 	return detach && tty
 }
+
+// sandboxNamespace returns the namespace the per namespace options apply to:
+// the Kubernetes namespace of the pods, the containerd namespace of other
+// containers.
+func (s *service) sandboxNamespace(annotations map[string]string) string {
+	if namespace := annotations[ctrAnnotations.SandboxNamespace]; namespace != "" {
+		return namespace
+	}
+	return s.namespace
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"context"
+	"time"
+
+	eventstypes "github.com/containerd/containerd/api/events"
+	"github.com/containerd/containerd/api/types/task"
+)
+
+// vmCrashDetectTimeout is the time a container waiter whose process could
+// not be waited waits for the sandbox watcher to notice a VM crash, before
+// reporting the exit of the process.
+var vmCrashDetectTimeout = 5 * time.Second
+
+// vmRestart is the handling of a VM crash by the sandbox watcher, the
+// container waiters wait for its outcome before reporting the exit of
+// their process.
+type vmRestart struct {
+	startCh   chan struct{}
+	doneCh    chan struct{}
+	restarted bool
+}
+
+func newVMRestart() *vmRestart {
+	return &vmRestart{
+		startCh: make(chan struct{}),
+		doneCh:  make(chan struct{}),
+	}
+}
+
+// start tells the waiters that the VM crashed.
+func (r *vmRestart) start() {
+	close(r.startCh)
+}
+
+// done tells the waiters if the VM was restarted.
+func (r *vmRestart) done(restarted bool) {
+	r.restarted = restarted
+	close(r.doneCh)
+}
+
+// wait waits for the handling of a VM crash, it tells if the VM was
+// restarted. The process may have failed to be waited for another reason,
+// the handling of a crash is only waited for if it starts soon enough.
+func (r *vmRestart) wait() bool {
+	if r == nil {
+		return false
+	}
+
+	select {
+	case <-r.startCh:
+	case <-time.After(vmCrashDetectTimeout):
+		return false
+	}
+
+	<-r.doneCh
+	return r.restarted
+}
+
+// restartSandbox restarts the crashed VM of the sandbox along with the
+// running containers, then copies their new IO streams and watches the new
+// VM. It tells if the VM was restarted.
+func restartSandbox(ctx context.Context, s *service) bool {
+	if !s.sandbox.CanRestart() {
+		return false
+	}
+
+	var running []*container
+	for _, c := range s.containers {
+		if c.status == task.StatusRunning && c.host == nil {
+			running = append(running, c)
+		}
+	}
+
+	shimLog.WithField("sandbox", s.sandbox.ID()).Warn("restarting the crashed VM")
+	if err := s.sandbox.Restart(ctx); err != nil {
+		shimLog.WithError(err).Error("failed to restart the VM")
+		return false
+	}
+
+	if pid, err := s.sandbox.GetHypervisorPid(); err == nil {
+		s.hpid = uint32(pid)
+	}
+
+	monitor, err := s.sandbox.Monitor(ctx)
+	if err != nil {
+		shimLog.WithError(err).Error("failed to monitor the restarted VM")
+		return false
+	}
+
+	for _, c := range running {
+		c.exitIOch = make(chan struct{})
+		c.stdinCloser = make(chan struct{})
+		if err := startContainerIO(ctx, s, c); err != nil {
+			// The container runs without IO rather than not being
+			// waited.
+			shimLog.WithError(err).WithField("container", c.id).Error("failed to copy the IO of the restarted container")
+			close(c.exitIOch)
+			close(c.stdinCloser)
+		}
+
+		s.send(&eventstypes.TaskStart{
+			ContainerID: c.id,
+			Pid:         s.hpid,
+		})
+	}
+
+	s.monitor = monitor
+	if s.sandbox.CanRestart() {
+		s.vmRestart = newVMRestart()
+	}
+	go watchSandbox(ctx, s)
+
+	return true
+}
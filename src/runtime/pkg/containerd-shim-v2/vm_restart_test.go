@@ -0,0 +1,39 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVMRestartWait(t *testing.T) {
+	assert := assert.New(t)
+
+	var none *vmRestart
+	assert.False(none.wait())
+
+	savedTimeout := vmCrashDetectTimeout
+	defer func() {
+		vmCrashDetectTimeout = savedTimeout
+	}()
+	vmCrashDetectTimeout = 10 * time.Millisecond
+
+	// no crash is handled
+	assert.False(newVMRestart().wait())
+
+	for _, restarted := range []bool{true, false} {
+		restart := newVMRestart()
+		go func() {
+			restart.start()
+			time.Sleep(2 * vmCrashDetectTimeout)
+			restart.done(restarted)
+		}()
+		assert.Equal(restarted, restart.wait())
+	}
+}
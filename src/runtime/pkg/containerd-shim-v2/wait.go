@@ -47,8 +47,24 @@ func wait(ctx context.Context, s *service, c *container, execID string) (int32,
 		processID = execs.id
 	}
 
-	ret, err := s.sandbox.WaitProcess(ctx, c.id, processID)
+	s.mu.Lock()
+	restart := s.vmRestart
+	s.mu.Unlock()
+
+	var ret int32
+	if c.host != nil && execID == "" {
+		ret, err = c.host.wait()
+	} else {
+		ret, err = s.sandbox.WaitProcess(ctx, c.id, processID)
+	}
 	if err != nil {
+		// The container is waited again when it was restarted
+		// along with the VM.
+		if execID == "" && c.host == nil && restart.wait() {
+			shimLog.WithField("container", c.id).Info("container restarted with the VM")
+			return wait(ctx, s, c, execID)
+		}
+
 		shimLog.WithError(err).WithFields(logrus.Fields{
 			"container": c.id,
 			"pid":       processID,
@@ -75,6 +91,7 @@ func wait(ctx context.Context, s *service, c *container, execID string) (int32,
 				shimLog.WithField("sandbox", s.sandbox.ID()).Info("cancel watcher")
 				s.monitor <- nil
 			}
+			s.recordFinalUsage(ctx)
 			if err = s.sandbox.Stop(ctx, true); err != nil {
 				shimLog.WithField("sandbox", s.sandbox.ID()).Error("failed to stop sandbox")
 			}
@@ -82,7 +99,7 @@ func wait(ctx context.Context, s *service, c *container, execID string) (int32,
 			if err = s.sandbox.Delete(ctx); err != nil {
 				shimLog.WithField("sandbox", s.sandbox.ID()).Error("failed to delete sandbox")
 			}
-		} else {
+		} else if c.host == nil {
 			if _, err = s.sandbox.StopContainer(ctx, c.id, true); err != nil {
 				shimLog.WithError(err).WithField("container", c.id).Warn("stop container failed")
 			}
@@ -126,8 +143,19 @@ func watchSandbox(ctx context.Context, s *service) {
 
 	s.monitor = nil
 
+	if restart := s.vmRestart; restart != nil {
+		s.vmRestart = nil
+		restart.start()
+		restarted := restartSandbox(ctx, s)
+		restart.done(restarted)
+		if restarted {
+			return
+		}
+	}
+
 	// sandbox malfunctioning, cleanup as much as we can
 	shimLog.WithError(err).Warn("sandbox stopped unexpectedly")
+	s.recordFinalUsage(ctx)
 	err = s.sandbox.Stop(ctx, true)
 	if err != nil {
 		shimLog.WithError(err).Warn("stop sandbox failed")
@@ -0,0 +1,217 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package blockid resolves the persistent identifiers of the block devices of
+// the host to their device node. The volumes given by such an identifier,
+// rather than by a /dev path, keep working when the devices of the host are
+// renumbered, e.g. across a reboot or a rescan of a SAN.
+//
+// The identifiers are written as <kind>:<value>:
+//   - wwid:<WWID> is the World Wide Identifier of a SCSI LUN or of an NVMe
+//     namespace, as found in its wwid sysfs attribute.
+//   - serial:<serial> is the serial number of a disk, of a virtio-blk device
+//     or of the controller of an NVMe namespace.
+//   - nvme:<PCI address>/<nsid> is the namespace nsid of the NVMe controller
+//     at the PCI address, e.g. nvme:0000:3b:00.0/1.
+//
+// A LUN reachable through several paths matches one device per path, it is
+// resolved to the multipath map of these paths.
+package blockid
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/multipath"
+)
+
+const (
+	KindWWID   = "wwid"
+	KindSerial = "serial"
+	KindNVMe   = "nvme"
+)
+
+// pollInterval is how often the devices are looked for again while they are
+// not there.
+const pollInterval = 100 * time.Millisecond
+
+var (
+	// SysBlockDir is the directory of the block devices in sysfs.
+	SysBlockDir = "/sys/block"
+
+	// DevDir is the directory of the device nodes.
+	DevDir = "/dev"
+
+	// udevSettle waits for the pending udev events, so that the device
+	// nodes of the devices which just appeared are created.
+	udevSettle = func(timeout time.Duration) {
+		seconds := int(timeout.Seconds())
+		if seconds < 1 {
+			seconds = 1
+		}
+		exec.Command("udevadm", "settle", fmt.Sprintf("--timeout=%d", seconds)).Run()
+	}
+)
+
+// ID is a persistent identifier of a block device.
+type ID struct {
+	Kind  string
+	Value string
+}
+
+func (id ID) String() string {
+	return id.Kind + ":" + id.Value
+}
+
+// Parse parses a persistent identifier. ok is false when s is not one, e.g.
+// when it is a path.
+func Parse(s string) (id ID, ok bool, err error) {
+	kind, value, found := strings.Cut(s, ":")
+	if !found {
+		return ID{}, false, nil
+	}
+
+	switch kind {
+	case KindWWID, KindSerial:
+	case KindNVMe:
+		addr, nsid, found := strings.Cut(value, "/")
+		if !found || addr == "" {
+			return ID{}, true, fmt.Errorf("invalid NVMe namespace %q, expected <PCI address>/<nsid>", value)
+		}
+		if _, err := strconv.ParseUint(nsid, 10, 32); err != nil {
+			return ID{}, true, fmt.Errorf("invalid NVMe namespace ID %q", nsid)
+		}
+	default:
+		return ID{}, false, nil
+	}
+
+	if value == "" {
+		return ID{}, true, fmt.Errorf("missing value of the %s identifier", kind)
+	}
+	return ID{Kind: kind, Value: value}, true, nil
+}
+
+// readAttr returns the value of a sysfs attribute of a block device, the empty
+// string if it has none.
+func readAttr(dev string, attr string) string {
+	data, err := os.ReadFile(filepath.Join(SysBlockDir, dev, attr))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// vpdSerial returns the unit serial number of a SCSI device, from its VPD page
+// 0x80: a 4 bytes header followed by the serial.
+func vpdSerial(dev string) string {
+	data, err := os.ReadFile(filepath.Join(SysBlockDir, dev, "device", "vpd_pg80"))
+	if err != nil || len(data) <= 4 {
+		return ""
+	}
+	return strings.TrimSpace(string(bytes.Trim(data[4:], "\x00")))
+}
+
+// nvmeController returns the PCI address of the NVMe controller of a
+// namespace, from the path of the namespace in sysfs:
+// /sys/devices/pci0000:00/0000:00:04.0/nvme/nvme0/nvme0n1.
+func nvmeController(dev string) string {
+	path, err := filepath.EvalSymlinks(filepath.Join(SysBlockDir, dev))
+	if err != nil {
+		return ""
+	}
+	parts := strings.Split(path, "/")
+	for i := len(parts) - 1; i > 0; i-- {
+		if parts[i] == "nvme" {
+			return parts[i-1]
+		}
+	}
+	return ""
+}
+
+func (id ID) matches(dev string) bool {
+	switch id.Kind {
+	case KindWWID:
+		for _, attr := range []string{"wwid", "device/wwid"} {
+			if wwid := readAttr(dev, attr); wwid != "" {
+				return strings.EqualFold(wwid, id.Value)
+			}
+		}
+	case KindSerial:
+		for _, serial := range []string{readAttr(dev, "serial"), readAttr(dev, "device/serial"), vpdSerial(dev)} {
+			if serial != "" {
+				return serial == id.Value
+			}
+		}
+	case KindNVMe:
+		addr, nsid, _ := strings.Cut(id.Value, "/")
+		return readAttr(dev, "nsid") == nsid && strings.EqualFold(nvmeController(dev), addr)
+	}
+	return false
+}
+
+// find returns the block devices matching id.
+func (id ID) find() ([]string, error) {
+	entries, err := os.ReadDir(SysBlockDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var devs []string
+	for _, entry := range entries {
+		if id.matches(entry.Name()) {
+			devs = append(devs, entry.Name())
+		}
+	}
+	return devs, nil
+}
+
+// Resolve returns the device node of the block device identified by id, or of
+// its multipath map when it is a path of one. The device is waited for, for at
+// most timeout, as it may not be there yet when it was just attached to the
+// host, or its device node not be created yet by udev.
+func Resolve(id ID, timeout time.Duration) (string, error) {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		udevSettle(time.Until(deadline))
+
+		devs, err := id.find()
+		if err != nil {
+			return "", err
+		}
+		if m := multipath.MapOfPaths(devs); m != nil {
+			path := m.DevicePath()
+			if _, err := os.Stat(path); err == nil {
+				return path, nil
+			}
+			devs = []string{m.Dev}
+		}
+		if len(devs) > 1 {
+			return "", fmt.Errorf("block device %s is ambiguous, it matches %s", id, strings.Join(devs, ", "))
+		}
+		if len(devs) == 1 {
+			// The device node is named after the device, with the
+			// '!' of the sysfs names being '/'.
+			path := filepath.Join(DevDir, strings.ReplaceAll(devs[0], "!", "/"))
+			if _, err := os.Stat(path); err == nil {
+				return path, nil
+			}
+		}
+
+		if time.Now().After(deadline) {
+			if len(devs) == 1 {
+				return "", fmt.Errorf("no device node for block device %s (%s)", id, devs[0])
+			}
+			return "", fmt.Errorf("block device %s not found", id)
+		}
+		time.Sleep(pollInterval)
+	}
+}
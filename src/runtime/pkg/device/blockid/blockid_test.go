@@ -0,0 +1,134 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package blockid
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/multipath"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, d := range []struct {
+		s     string
+		id    ID
+		ok    bool
+		error bool
+	}{
+		{"/dev/sda", ID{}, false, false},
+		{"/var/lib/kubelet/pods/a:b", ID{}, false, false},
+		{"wwid:naa.600a098038304437", ID{KindWWID, "naa.600a098038304437"}, true, false},
+		{"serial:S4EWNX0R123456", ID{KindSerial, "S4EWNX0R123456"}, true, false},
+		{"nvme:0000:3b:00.0/1", ID{KindNVMe, "0000:3b:00.0/1"}, true, false},
+		{"wwid:", ID{}, true, true},
+		{"nvme:0000:3b:00.0", ID{}, true, true},
+		{"nvme:0000:3b:00.0/x", ID{}, true, true},
+	} {
+		id, ok, err := Parse(d.s)
+		assert.Equal(d.ok, ok, d.s)
+		if d.error {
+			assert.Error(err, d.s)
+			continue
+		}
+		assert.NoError(err, d.s)
+		assert.Equal(d.id, id, d.s)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	assert := assert.New(t)
+
+	savedSysBlockDir, savedDevDir, savedSettle := SysBlockDir, DevDir, udevSettle
+	savedMpathSysBlockDir, savedMpathDevDir := multipath.SysBlockDir, multipath.DevDir
+	defer func() {
+		SysBlockDir, DevDir, udevSettle = savedSysBlockDir, savedDevDir, savedSettle
+		multipath.SysBlockDir, multipath.DevDir = savedMpathSysBlockDir, savedMpathDevDir
+	}()
+
+	root := t.TempDir()
+	SysBlockDir = filepath.Join(root, "sys", "block")
+	DevDir = filepath.Join(root, "dev")
+	udevSettle = func(time.Duration) {}
+	multipath.SysBlockDir, multipath.DevDir = SysBlockDir, DevDir
+
+	writeAttr := func(path, value string) {
+		path = filepath.Join(root, path)
+		assert.NoError(os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(os.WriteFile(path, []byte(value+"\n"), 0644))
+	}
+
+	// a SCSI LUN, a virtio-blk disk and an NVMe namespace
+	writeAttr("sys/block/sdb/device/wwid", "naa.600a098038304437")
+	writeAttr("sys/block/sdb/device/vpd_pg80", "\x00\x80\x00\x0cLUN-SERIAL-1")
+	writeAttr("sys/block/vda/serial", "disk-1")
+
+	nvmeDev := filepath.Join(root, "sys/devices/pci0000:00/0000:3b:00.0/nvme/nvme0/nvme0n1")
+	writeAttr("sys/devices/pci0000:00/0000:3b:00.0/nvme/nvme0/nvme0n1/nsid", "1")
+	writeAttr("sys/devices/pci0000:00/0000:3b:00.0/nvme/nvme0/nvme0n1/wwid", "eui.0025388b91b2d4a1")
+	assert.NoError(os.Symlink(nvmeDev, filepath.Join(SysBlockDir, "nvme0n1")))
+
+	for _, dev := range []string{"sdb", "vda", "nvme0n1"} {
+		writeAttr(filepath.Join("dev", dev), "")
+	}
+
+	for _, d := range []struct {
+		id       ID
+		expected string
+	}{
+		{ID{KindWWID, "NAA.600A098038304437"}, "sdb"},
+		{ID{KindSerial, "LUN-SERIAL-1"}, "sdb"},
+		{ID{KindSerial, "disk-1"}, "vda"},
+		{ID{KindWWID, "eui.0025388b91b2d4a1"}, "nvme0n1"},
+		{ID{KindNVMe, "0000:3b:00.0/1"}, "nvme0n1"},
+	} {
+		path, err := Resolve(d.id, 0)
+		assert.NoError(err, d.id.String())
+		assert.Equal(filepath.Join(DevDir, d.expected), path, d.id.String())
+	}
+
+	_, err := Resolve(ID{KindNVMe, "0000:3b:00.0/2"}, 0)
+	assert.Error(err)
+
+	// a device without its node yet
+	writeAttr("sys/block/sdc/device/wwid", "naa.600a098038304438")
+	_, err = Resolve(ID{KindWWID, "naa.600a098038304438"}, 0)
+	assert.Error(err)
+
+	// the device appears while it is waited for
+	go func() {
+		time.Sleep(2 * pollInterval)
+		writeAttr("dev/sdc", "")
+	}()
+	path, err := Resolve(ID{KindWWID, "naa.600a098038304438"}, time.Minute)
+	assert.NoError(err)
+	assert.Equal(filepath.Join(DevDir, "sdc"), path)
+
+	// two devices with the same identifier
+	writeAttr("sys/block/sdd/device/wwid", "naa.600a098038304438")
+	_, err = Resolve(ID{KindWWID, "naa.600a098038304438"}, 0)
+	assert.Error(err)
+
+	// which are the two paths of a multipath map
+	writeAttr("sys/block/dm-0/dm/uuid", "mpath-3600a098038304438")
+	writeAttr("sys/block/dm-0/dm/name", "mpatha")
+	for _, dev := range []string{"sdc", "sdd"} {
+		assert.NoError(os.MkdirAll(filepath.Join(SysBlockDir, dev, "holders"), 0755))
+		assert.NoError(os.Symlink(filepath.Join(SysBlockDir, "dm-0"), filepath.Join(SysBlockDir, dev, "holders", "dm-0")))
+	}
+	_, err = Resolve(ID{KindWWID, "naa.600a098038304438"}, 0)
+	assert.Error(err)
+
+	writeAttr("dev/mapper/mpatha", "")
+	path, err = Resolve(ID{KindWWID, "naa.600a098038304438"}, 0)
+	assert.NoError(err)
+	assert.Equal(filepath.Join(DevDir, "mapper", "mpatha"), path)
+}
@@ -0,0 +1,316 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package claim implements the claims of the IOMMU groups of the node by the
+// pods. A scheduler or device plugin pre-claims the groups of a pod through
+// kata-monitor before the pod is created, and the runtime honors the claims
+// when the pod lands: a group claimed by a pod cannot be given to another one,
+// so that the GPU pods created at the same time do not race for the same
+// devices.
+package claim
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const lockFile = ".lock"
+
+// ClaimsDir is the directory of the claims, one file per IOMMU group. The
+// claims are only honored by the runtime when it exists, it is created by
+// the first claim made through kata-monitor.
+var ClaimsDir = "/run/kata-containers/device-claims"
+
+// ErrClaimed is returned when a group is claimed by another pod.
+var ErrClaimed = errors.New("IOMMU group is claimed by another pod")
+
+// procDir is where the processes holding the claims are looked for.
+var procDir = "/proc"
+
+// Claim is the claim of an IOMMU group by a pod.
+type Claim struct {
+	// Expires is when the claim of a pod that has not landed yet
+	// expires
+	Expires *time.Time `json:"expires,omitempty"`
+	// Holder is the shim of the sandbox of the pod once it landed, the
+	// claim expires with it, should the sandbox not release it
+	Holder *Process `json:"holder,omitempty"`
+	Group  string   `json:"group"`
+	// Owner is the pod owning the group, as namespace/name
+	Owner string `json:"owner"`
+}
+
+// Process identifies a process, its start time telling it apart from a
+// later one reusing its pid.
+type Process struct {
+	Pid       int    `json:"pid"`
+	StartTime uint64 `json:"start_time"`
+}
+
+// processStartTime returns the start time of the process, in clock ticks
+// after the boot, as the 22nd field of its stat file.
+func processStartTime(pid int) (uint64, error) {
+	data, err := os.ReadFile(filepath.Join(procDir, strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return 0, err
+	}
+	// The command name, the 2nd field, may hold spaces and parentheses.
+	stat := string(data)
+	i := strings.LastIndex(stat, ")")
+	if i < 0 {
+		return 0, fmt.Errorf("invalid stat of process %d", pid)
+	}
+	fields := strings.Fields(stat[i+1:])
+	if len(fields) < 20 {
+		return 0, fmt.Errorf("invalid stat of process %d", pid)
+	}
+	return strconv.ParseUint(fields[19], 10, 64)
+}
+
+// alive tells if the process is still running.
+func (p *Process) alive() bool {
+	startTime, err := processStartTime(p.Pid)
+	return err == nil && startTime == p.StartTime
+}
+
+func (c *Claim) expired(now time.Time) bool {
+	return (c.Expires != nil && now.After(*c.Expires)) || (c.Holder != nil && !c.Holder.alive())
+}
+
+// Enabled tells if the claims are honored.
+func Enabled() bool {
+	_, err := os.Stat(ClaimsDir)
+	return err == nil
+}
+
+// validGroup checks that group is the number of an IOMMU group, as found in
+// /dev/vfio.
+func validGroup(group string) error {
+	if _, err := strconv.ParseUint(group, 10, 32); err != nil {
+		return fmt.Errorf("invalid IOMMU group %q", group)
+	}
+	return nil
+}
+
+// lock takes the lock of the claims, serializing the claims of kata-monitor
+// and of the runtimes of the node.
+func lock() (func(), error) {
+	if err := os.MkdirAll(ClaimsDir, 0700); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(filepath.Join(ClaimsDir, lockFile), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return func() {
+		syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+	}, nil
+}
+
+func read(group string) (*Claim, error) {
+	data, err := os.ReadFile(filepath.Join(ClaimsDir, group))
+	if err != nil {
+		return nil, err
+	}
+
+	var c Claim
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("invalid claim of IOMMU group %s: %v", group, err)
+	}
+	return &c, nil
+}
+
+// Add claims group for owner until ttl passes, the claim is renewed when
+// owner claims the group again. ErrClaimed is returned when another pod holds
+// an unexpired claim of the group.
+func Add(group, owner string, ttl time.Duration) (*Claim, error) {
+	if ttl == 0 {
+		return nil, fmt.Errorf("the claim of IOMMU group %s would never expire", group)
+	}
+	return add(group, owner, ttl, nil)
+}
+
+// Hold claims group for owner on behalf of the calling process, the shim of
+// the sandbox of owner: the claim expires when the process exits. ErrClaimed
+// is returned when another pod holds an unexpired claim of the group.
+func Hold(group, owner string) (*Claim, error) {
+	pid := os.Getpid()
+	startTime, err := processStartTime(pid)
+	if err != nil {
+		return nil, err
+	}
+	return add(group, owner, 0, &Process{Pid: pid, StartTime: startTime})
+}
+
+func add(group, owner string, ttl time.Duration, holder *Process) (*Claim, error) {
+	if err := validGroup(group); err != nil {
+		return nil, err
+	}
+	if owner == "" {
+		return nil, fmt.Errorf("missing owner of the claim of IOMMU group %s", group)
+	}
+
+	unlock, err := lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	now := time.Now()
+	current, err := read(group)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if current != nil && current.Owner != owner && !current.expired(now) {
+		return current, fmt.Errorf("%w: group %s is claimed by %s", ErrClaimed, group, current.Owner)
+	}
+
+	c := &Claim{Group: group, Owner: owner, Holder: holder}
+	if ttl != 0 {
+		expires := now.Add(ttl)
+		c.Expires = &expires
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	// Write the claim atomically, so that it is never read partially.
+	tmp := filepath.Join(ClaimsDir, "."+group)
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp, filepath.Join(ClaimsDir, group)); err != nil {
+		os.Remove(tmp)
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Remove releases the claim of group by owner, if any.
+func Remove(group, owner string) error {
+	if err := validGroup(group); err != nil {
+		return err
+	}
+
+	unlock, err := lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	c, err := read(group)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if c.Owner != owner {
+		return fmt.Errorf("%w: group %s is claimed by %s", ErrClaimed, group, c.Owner)
+	}
+
+	return os.Remove(filepath.Join(ClaimsDir, group))
+}
+
+// RemoveOwner releases all the claims of owner, along with the expired ones.
+func RemoveOwner(owner string) error {
+	if !Enabled() {
+		return nil
+	}
+
+	unlock, err := lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	claims, err := list()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, c := range claims {
+		if c.Owner == owner || c.expired(now) {
+			if err := os.Remove(filepath.Join(ClaimsDir, c.Group)); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// List returns the unexpired claims, sorted by group.
+func List() ([]Claim, error) {
+	if !Enabled() {
+		return []Claim{}, nil
+	}
+
+	unlock, err := lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	claims, err := list()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	valid := []Claim{}
+	for _, c := range claims {
+		if !c.expired(now) {
+			valid = append(valid, c)
+		}
+	}
+	return valid, nil
+}
+
+func list() ([]Claim, error) {
+	entries, err := os.ReadDir(ClaimsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims []Claim
+	for _, entry := range entries {
+		if validGroup(entry.Name()) != nil {
+			continue
+		}
+		c, err := read(entry.Name())
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		claims = append(claims, *c)
+	}
+
+	sort.Slice(claims, func(i, j int) bool {
+		gi, _ := strconv.Atoi(claims[i].Group)
+		gj, _ := strconv.Atoi(claims[j].Group)
+		return gi < gj
+	})
+	return claims, nil
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package claim
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClaims(t *testing.T) {
+	assert := assert.New(t)
+
+	savedDir := ClaimsDir
+	defer func() {
+		ClaimsDir = savedDir
+	}()
+	ClaimsDir = filepath.Join(t.TempDir(), "device-claims")
+
+	assert.False(Enabled())
+	claims, err := List()
+	assert.NoError(err)
+	assert.Empty(claims)
+
+	// pre-claims of the scheduler
+	c, err := Add("12", "default/gpu-0", time.Minute)
+	assert.NoError(err)
+	assert.NotNil(c.Expires)
+	assert.True(Enabled())
+
+	_, err = Add("13", "default/gpu-1", -time.Second)
+	assert.NoError(err)
+
+	// the claim of another pod is honored until it expires
+	_, err = Hold("12", "default/gpu-1")
+	assert.True(errors.Is(err, ErrClaimed))
+	c, err = Hold("13", "default/gpu-0")
+	assert.NoError(err)
+	assert.Nil(c.Expires)
+	assert.Equal(os.Getpid(), c.Holder.Pid)
+
+	// the owner renews its claim, held by its shim once it landed
+	c, err = Hold("12", "default/gpu-0")
+	assert.NoError(err)
+	assert.Nil(c.Expires)
+
+	_, err = Add("12", "default/gpu-0", 0)
+	assert.Error(err)
+	_, err = Add("12a", "default/gpu-0", time.Minute)
+	assert.Error(err)
+	_, err = Add("14", "", time.Minute)
+	assert.Error(err)
+
+	claims, err = List()
+	assert.NoError(err)
+	holder := c.Holder
+	assert.Equal([]Claim{
+		{Group: "12", Owner: "default/gpu-0", Holder: holder},
+		{Group: "13", Owner: "default/gpu-0", Holder: holder},
+	}, claims)
+
+	assert.Error(Remove("12", "default/gpu-1"))
+	assert.NoError(Remove("12", "default/gpu-0"))
+	assert.NoError(Remove("12", "default/gpu-0"))
+
+	assert.NoError(RemoveOwner("default/gpu-0"))
+	claims, err = List()
+	assert.NoError(err)
+	assert.Empty(claims)
+}
+
+func TestHolderExpiry(t *testing.T) {
+	assert := assert.New(t)
+
+	savedDir, savedProcDir := ClaimsDir, procDir
+	defer func() {
+		ClaimsDir, procDir = savedDir, savedProcDir
+	}()
+	ClaimsDir = filepath.Join(t.TempDir(), "device-claims")
+	procDir = t.TempDir()
+
+	stat := filepath.Join(procDir, strconv.Itoa(os.Getpid()), "stat")
+	writeStat := func(startTime string) {
+		assert.NoError(os.MkdirAll(filepath.Dir(stat), 0755))
+		assert.NoError(os.WriteFile(stat, []byte("1234 (containerd-shim (kata)) S 1 1234 1234 0 -1 4194560 "+
+			"4000 0 0 0 20 10 0 0 20 0 12 0 "+startTime+" 1500000000 5000\n"), 0644))
+	}
+
+	writeStat("4242")
+	c, err := Hold("12", "default/gpu-0")
+	assert.NoError(err)
+	assert.Equal(uint64(4242), c.Holder.StartTime)
+	_, err = Hold("12", "default/gpu-1")
+	assert.True(errors.Is(err, ErrClaimed))
+
+	// the pid of the shim was reused by another process
+	writeStat("4343")
+	_, err = Hold("12", "default/gpu-1")
+	assert.NoError(err)
+
+	// the shim exited without releasing its claims
+	assert.NoError(os.Remove(stat))
+	claims, err := List()
+	assert.NoError(err)
+	assert.Empty(claims)
+	assert.NoError(RemoveOwner("default/gpu-2"))
+	entries, err := os.ReadDir(ClaimsDir)
+	assert.NoError(err)
+	assert.Len(entries, 1)
+	assert.Equal(lockFile, entries[0].Name())
+}
@@ -42,6 +42,10 @@ const (
 
 	//VhostUserFS represents a virtio-fs vhostuser device type
 	VhostUserFS = "vhost-user-fs-pci"
+
+	//VhostVDPABlk represents a block device served by a vDPA backend,
+	//e.g. a VDUSE userspace dataplane, through vhost-vdpa
+	VhostVDPABlk = "vhost-vdpa-device-pci"
 )
 
 const (
@@ -100,6 +104,14 @@ const (
 	BlockDriverOpt = "block-driver"
 
 	VhostUserReconnectTimeOutOpt = "vhost-user-reconnect-timeout"
+
+	// VhostVDPAHookOpt is the hook run around the attach and detach of
+	// vhost-vdpa devices
+	VhostVDPAHookOpt = "vhost-vdpa-hook"
+
+	// TDISPRequiredOpt requires the PCI devices of a VFIO group to support
+	// TDISP, for them to be assigned to a confidential guest
+	TDISPRequiredOpt = "tdisp-required"
 )
 
 const (
@@ -110,6 +122,9 @@ const (
 	VhostUserSCSIMajor = 242
 )
 
+// VhostVDPAPrefix is the path prefix of vhost-vdpa character devices
+const VhostVDPAPrefix = "/dev/vhost-vdpa-"
+
 const (
 
 	// The timeout for reconnecting on non-server sockets when the remote end
@@ -368,6 +383,66 @@ func (m *VFIOModeType) VFIOSetMode(modeName string) error {
 	return fmt.Errorf("Unknown VFIO mode %s", modeName)
 }
 
+// HostDevicePolicy indicates how a host device listed in the OCI spec
+// is made available to the container
+type HostDevicePolicy string
+
+const (
+	// HostDevicePassthrough attaches the host device to the VM
+	HostDevicePassthrough HostDevicePolicy = "passthrough"
+
+	// HostDeviceEmulate uses the device of the same path provided by the
+	// guest, e.g. a device emulated by the hypervisor
+	HostDeviceEmulate HostDevicePolicy = "emulate"
+
+	// HostDeviceGuestNode creates a plain device node in the guest using
+	// the major and minor numbers from the OCI spec
+	HostDeviceGuestNode HostDevicePolicy = "node"
+
+	// HostDeviceReject fails the container creation
+	HostDeviceReject HostDevicePolicy = "reject"
+)
+
+// HostDevicePolicyRule applies Policy to the devices whose container
+// path matches Pattern
+type HostDevicePolicyRule struct {
+	Pattern string
+	Policy  HostDevicePolicy
+}
+
+// ParseHostDevicePolicyRule parses a "pattern=policy" rule, pattern
+// following the filepath.Match syntax
+func ParseHostDevicePolicyRule(rule string) (HostDevicePolicyRule, error) {
+	fields := strings.SplitN(rule, "=", 2)
+	if len(fields) != 2 || fields[0] == "" {
+		return HostDevicePolicyRule{}, fmt.Errorf("Invalid host device policy %q, expected pattern=policy", rule)
+	}
+
+	if _, err := filepath.Match(fields[0], ""); err != nil {
+		return HostDevicePolicyRule{}, fmt.Errorf("Invalid host device pattern %q: %v", fields[0], err)
+	}
+
+	policy := HostDevicePolicy(fields[1])
+	switch policy {
+	case HostDevicePassthrough, HostDeviceEmulate, HostDeviceGuestNode, HostDeviceReject:
+	default:
+		return HostDevicePolicyRule{}, fmt.Errorf("Unknown host device policy %q for %s", fields[1], fields[0])
+	}
+
+	return HostDevicePolicyRule{Pattern: fields[0], Policy: policy}, nil
+}
+
+// GetHostDevicePolicy returns the policy of the first rule matching path,
+// HostDevicePassthrough if none does
+func GetHostDevicePolicy(rules []HostDevicePolicyRule, path string) HostDevicePolicy {
+	for _, r := range rules {
+		if match, _ := filepath.Match(r.Pattern, path); match {
+			return r.Policy
+		}
+	}
+	return HostDevicePassthrough
+}
+
 // VFIODeviceType indicates VFIO device type
 type VFIODeviceType uint32
 
@@ -425,6 +500,15 @@ type VFIODev struct {
 
 	// Port is the PCIe port type to which the device is attached
 	Port PCIePort
+
+	// GPUDirectClique is the GPUDirect peer-to-peer clique of an NVIDIA
+	// GPU, empty when peer-to-peer DMA is not enabled
+	GPUDirectClique string
+
+	// TDISP specifies the device supports TEE-IO, i.e. its interface can
+	// be locked and attested with TDISP to be trusted by a confidential
+	// guest
+	TDISP bool
 }
 
 // RNGDev represents a random number generator device
@@ -433,6 +517,14 @@ type RNGDev struct {
 	ID string
 	// Filename is the file to use as entropy source.
 	Filename string
+	// Builtin uses the entropy source built in the hypervisor instead of
+	// Filename.
+	Builtin bool
+	// MaxBytes is the number of bytes the guest can read per Period, no
+	// limit if 0.
+	MaxBytes uint32
+	// Period is the rate limiting period in milliseconds.
+	Period uint32
 }
 
 // VhostUserDeviceAttrs represents data shared by most vhost-user devices
@@ -451,7 +543,7 @@ type VhostUserDeviceAttrs struct {
 
 	// PCIPath is the PCI path used to identify the slot at which
 	// the drive is attached.  It is only meaningful for vhost
-	// user block devices
+	// user and vhost-vdpa block devices
 	PCIPath vcTypes.PciPath
 
 	// Block index of the device if assigned
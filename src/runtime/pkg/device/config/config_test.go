@@ -68,3 +68,25 @@ func TestGetSysDevPathImpl(t *testing.T) {
 	assert.Contains(path, expectedFormat)
 	assert.Contains(path, "block")
 }
+
+func TestHostDevicePolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, rule := range []string{"", "/dev/fuse", "=node", "/dev/fuse=unknown", "/dev/[=node"} {
+		_, err := ParseHostDevicePolicyRule(rule)
+		assert.Error(err, rule)
+	}
+
+	var rules []HostDevicePolicyRule
+	for _, rule := range []string{"/dev/fuse=node", "/dev/dri/*=emulate", "/dev/net/tun=reject"} {
+		r, err := ParseHostDevicePolicyRule(rule)
+		assert.NoError(err)
+		rules = append(rules, r)
+	}
+
+	assert.Equal(HostDeviceGuestNode, GetHostDevicePolicy(rules, "/dev/fuse"))
+	assert.Equal(HostDeviceEmulate, GetHostDevicePolicy(rules, "/dev/dri/renderD128"))
+	assert.Equal(HostDeviceReject, GetHostDevicePolicy(rules, "/dev/net/tun"))
+	assert.Equal(HostDevicePassthrough, GetHostDevicePolicy(rules, "/dev/sda"))
+	assert.Equal(HostDevicePassthrough, GetHostDevicePolicy(nil, "/dev/fuse"))
+}
@@ -8,6 +8,8 @@ package config
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"syscall"
 
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
@@ -87,6 +89,50 @@ func PmemDeviceInfo(source, destination string) (*DeviceInfo, error) {
 	return device, nil
 }
 
+// PmemVolumeDeviceInfo returns a DeviceInfo for a volume on persistent
+// memory, mapped as a NVDIMM in the guest. source is either a DAX capable
+// block device of the host, e.g. the fsdax namespace of a NVDIMM, or a file
+// emulating one. It must have the PFN signature, for the guest to see a fsdax
+// namespace its filesystem can be mounted with DAX from.
+func PmemVolumeDeviceInfo(source, destination, fstype string, readonly bool) (*DeviceInfo, error) {
+	stat := syscall.Stat_t{}
+	if err := syscall.Stat(source, &stat); err != nil {
+		return nil, err
+	}
+
+	device := &DeviceInfo{
+		HostPath:      source,
+		ContainerPath: destination,
+		DevType:       "b",
+		ReadOnly:      readonly,
+		Pmem:          true,
+		DriverOptions: map[string]string{FsTypeOpt: fstype},
+	}
+
+	switch stat.Mode & syscall.S_IFMT {
+	case syscall.S_IFREG:
+		// emulated persistent memory
+	case syscall.S_IFBLK:
+		info := DeviceInfo{
+			DevType: "b",
+			Major:   int64(unix.Major(uint64(stat.Rdev))),
+			Minor:   int64(unix.Minor(uint64(stat.Rdev))),
+		}
+		dax, err := os.ReadFile(filepath.Join(getSysDevPath(info), "queue", "dax"))
+		if err != nil || strings.TrimSpace(string(dax)) != "1" {
+			return nil, fmt.Errorf("block device %v is not DAX capable", source)
+		}
+	default:
+		return nil, fmt.Errorf("%v is neither a block device nor a file", source)
+	}
+
+	if !hasPFNSignature(source) {
+		return nil, fmt.Errorf("%v has no PFN signature, it cannot be mounted with DAX in the guest", source)
+	}
+
+	return device, nil
+}
+
 // returns true if the file/device path has the PFN signature
 // required to use it as PMEM device and enable DAX.
 // See [1] to know more about the PFN signature.
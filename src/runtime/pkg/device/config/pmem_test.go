@@ -46,3 +46,31 @@ func TestHasPFNSignature(t *testing.T) {
 	b = hasPFNSignature(pfnFile)
 	assert.True(b)
 }
+
+func TestPmemVolumeDeviceInfo(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+
+	_, err := PmemVolumeDeviceInfo(filepath.Join(dir, "missing"), "/data", "ext4", false)
+	assert.Error(err)
+
+	_, err = PmemVolumeDeviceInfo(dir, "/data", "ext4", false)
+	assert.Error(err)
+
+	empty := filepath.Join(dir, "empty")
+	assert.NoError(os.WriteFile(empty, nil, 0600))
+	_, err = PmemVolumeDeviceInfo(empty, "/data", "ext4", false)
+	assert.Error(err)
+
+	pfnFile := createPFNFile(assert, dir)
+	info, err := PmemVolumeDeviceInfo(pfnFile, "/data", "xfs", true)
+	assert.NoError(err)
+	assert.Equal(&DeviceInfo{
+		HostPath:      pfnFile,
+		ContainerPath: "/data",
+		DevType:       "b",
+		ReadOnly:      true,
+		Pmem:          true,
+		DriverOptions: map[string]string{FsTypeOpt: "xfs"},
+	}, info)
+}
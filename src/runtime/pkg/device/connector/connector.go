@@ -0,0 +1,164 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package connector connects the host to the remote block storage of the
+// direct assigned volumes: it logs in to iSCSI targets or connects to NVMe
+// over Fabrics subsystems, returns the block device of the volume, and tears
+// the session down when the volume is unpublished. The parameters of the
+// session are the publish context of the CSI driver, they must not hold any
+// secret as they are persisted along with the mount info of the volume: the
+// CHAP credentials of iSCSI, or the DH-HMAC-CHAP keys of NVMe-oF, are taken
+// from the configuration of the initiator of the host.
+package connector
+
+import (
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+const (
+	TypeISCSI  = "iscsi"
+	TypeNVMeoF = "nvme-of"
+)
+
+// pollInterval is how often the device of a volume is looked for while it
+// is not there.
+const pollInterval = 100 * time.Millisecond
+
+// runCommand runs an initiator command, it returns its combined output.
+var runCommand = func(name string, args ...string) ([]byte, error) {
+	return exec.Command(name, args...).CombinedOutput()
+}
+
+// Info is the remote storage a direct assigned volume is on.
+type Info struct {
+	// Type is the storage protocol, iscsi or nvme-of.
+	Type string `json:"type"`
+	// Params are the parameters of the session, e.g. the CSI publish
+	// context.
+	Params map[string]string `json:"params"`
+	// Opened are what Connect established, as the iSCSI logins or the
+	// NVMe controllers, in the format of the connector. Only those are torn
+	// down by Disconnect, the sessions the host had before are left alone.
+	Opened []string `json:"opened,omitempty"`
+}
+
+// connector is a storage protocol.
+type connector interface {
+	// validate checks the parameters of the session.
+	validate() error
+	// connect establishes the session if it is not already, and returns
+	// the device node of the volume once it appeared, along with what it
+	// opened, even on error.
+	connect(timeout time.Duration) (string, []string, error)
+	// disconnect tears down what connect opened.
+	disconnect(opened []string) error
+	// session identifies the session, the volumes of the same session
+	// share it.
+	session() string
+}
+
+func (i *Info) connector() (connector, error) {
+	switch i.Type {
+	case TypeISCSI:
+		return &iscsi{params: i.Params}, nil
+	case TypeNVMeoF:
+		return &nvmeof{params: i.Params}, nil
+	}
+	return nil, fmt.Errorf("unknown storage connector %q, expected %s or %s", i.Type, TypeISCSI, TypeNVMeoF)
+}
+
+// Validate checks the storage connector parameters.
+func (i *Info) Validate() error {
+	c, err := i.connector()
+	if err != nil {
+		return err
+	}
+	return c.validate()
+}
+
+// Session identifies the session of the volume, the volumes of the same
+// session share it: it is torn down with the last one.
+func (i *Info) Session() string {
+	c, err := i.connector()
+	if err != nil {
+		return ""
+	}
+	return i.Type + ":" + c.session()
+}
+
+// Connect establishes the session of the volume if it is not already, and
+// returns the device node of the volume. It waits for at most timeout for
+// the device to appear. What it opened is added to Opened, and closed again
+// if it fails.
+func (i *Info) Connect(timeout time.Duration) (string, error) {
+	c, err := i.connector()
+	if err != nil {
+		return "", err
+	}
+	if err := c.validate(); err != nil {
+		return "", err
+	}
+	dev, opened, err := c.connect(timeout)
+	if err != nil {
+		if len(opened) > 0 {
+			if e := c.disconnect(opened); e != nil {
+				return "", fmt.Errorf("%v, and failed to close it: %v", err, e)
+			}
+		}
+		return "", err
+	}
+	for _, o := range opened {
+		if !i.opened(o) {
+			i.Opened = append(i.Opened, o)
+		}
+	}
+	return dev, nil
+}
+
+func (i *Info) opened(o string) bool {
+	for _, opened := range i.Opened {
+		if opened == o {
+			return true
+		}
+	}
+	return false
+}
+
+// Disconnect tears down what Connect opened for the session of the volume.
+func (i *Info) Disconnect() error {
+	c, err := i.connector()
+	if err != nil {
+		return err
+	}
+	if err := c.disconnect(i.Opened); err != nil {
+		return err
+	}
+	i.Opened = nil
+	return nil
+}
+
+// waitFor calls find until it returns a device or timeout passes.
+func waitFor(what string, timeout time.Duration, find func() (string, error)) (string, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		dev, err := find()
+		if err != nil {
+			return "", err
+		}
+		if dev != "" {
+			return dev, nil
+		}
+		if time.Now().After(deadline) {
+			return "", fmt.Errorf("timed out waiting for %s", what)
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+func commandError(err error, output []byte, name string, args ...string) error {
+	return fmt.Errorf("%s %v failed: %v: %s", name, args, err, output)
+}
@@ -0,0 +1,226 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package connector
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeCommands replaces the initiator commands, it returns the commands run.
+func fakeCommands(t *testing.T, run func(cmd string) (string, error)) *[]string {
+	savedRunCommand := runCommand
+	t.Cleanup(func() {
+		runCommand = savedRunCommand
+	})
+
+	var cmds []string
+	runCommand = func(name string, args ...string) ([]byte, error) {
+		cmd := strings.Join(append([]string{name}, args...), " ")
+		cmds = append(cmds, cmd)
+		output, err := run(cmd)
+		return []byte(output), err
+	}
+	return &cmds
+}
+
+func TestValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, d := range []struct {
+		info  Info
+		valid bool
+	}{
+		{Info{Type: TypeISCSI, Params: map[string]string{ISCSITargetPortal: "10.0.0.1", ISCSIIQN: "iqn.2003-01.org:t1", ISCSILUN: "0"}}, true},
+		{Info{Type: TypeISCSI, Params: map[string]string{ISCSITargetPortal: "10.0.0.1", ISCSIIQN: "iqn.2003-01.org:t1"}}, false},
+		{Info{Type: TypeISCSI, Params: map[string]string{ISCSIIQN: "iqn.2003-01.org:t1", ISCSILUN: "0"}}, false},
+		{Info{Type: TypeNVMeoF, Params: map[string]string{NVMeoFTransport: "tcp", NVMeoFTrAddr: "10.0.0.1", NVMeoFNQN: "nqn.2014-08.org:s1"}}, true},
+		{Info{Type: TypeNVMeoF, Params: map[string]string{NVMeoFTransport: "pcie", NVMeoFTrAddr: "10.0.0.1", NVMeoFNQN: "nqn.2014-08.org:s1"}}, false},
+		{Info{Type: TypeNVMeoF, Params: map[string]string{NVMeoFTransport: "tcp", NVMeoFTrAddr: "10.0.0.1", NVMeoFNQN: "nqn.2014-08.org:s1", NVMeoFNSID: "x"}}, false},
+		{Info{Type: "fcp"}, false},
+	} {
+		err := d.info.Validate()
+		if d.valid {
+			assert.NoError(err, "%+v", d.info)
+		} else {
+			assert.Error(err, "%+v", d.info)
+		}
+	}
+}
+
+func TestISCSI(t *testing.T) {
+	assert := assert.New(t)
+
+	savedDevDiskByPathDir := DevDiskByPathDir
+	defer func() {
+		DevDiskByPathDir = savedDevDiskByPathDir
+	}()
+	root := t.TempDir()
+	DevDiskByPathDir = filepath.Join(root, "by-path")
+	assert.NoError(os.MkdirAll(DevDiskByPathDir, 0755))
+	assert.NoError(os.WriteFile(filepath.Join(root, "sdb"), nil, 0644))
+
+	info := Info{
+		Type: TypeISCSI,
+		Params: map[string]string{
+			ISCSITargetPortal: "10.0.0.1",
+			ISCSIPortals:      "10.0.0.1:3260, [fd00::1]",
+			ISCSIIQN:          "iqn.2003-01.org:t1",
+			ISCSILUN:          "2",
+		},
+	}
+	assert.Equal("iscsi:iqn.2003-01.org:t1@10.0.0.1:3260,[fd00::1]:3260", info.Session())
+
+	// single portal, the node and the session are new
+	delete(info.Params, ISCSIPortals)
+	cmds := fakeCommands(t, func(cmd string) (string, error) {
+		return "", nil
+	})
+	assert.NoError(os.Symlink(filepath.Join(root, "sdb"), filepath.Join(DevDiskByPathDir, "ip-10.0.0.1:3260-iscsi-iqn.2003-01.org:t1-lun-2")))
+
+	dev, err := info.Connect(0)
+	assert.NoError(err)
+	assert.Equal(filepath.Join(root, "sdb"), dev)
+	node := "iscsiadm -m node -T iqn.2003-01.org:t1 -p 10.0.0.1:3260 -I default "
+	assert.Equal([]string{
+		node + "-o new",
+		node + "-o update -n node.startup -v manual",
+		node + "--login",
+	}, *cmds)
+	assert.Equal([]string{"node:10.0.0.1:3260", "session:10.0.0.1:3260"}, info.Opened)
+
+	*cmds = nil
+	assert.NoError(info.Disconnect())
+	assert.Equal([]string{node + "--logout", node + "-o delete"}, *cmds)
+	assert.Empty(info.Opened)
+
+	// the host already had the node and the session, they are left alone
+	cmds = fakeCommands(t, func(cmd string) (string, error) {
+		if strings.HasSuffix(cmd, "-o new") {
+			return "iscsiadm: Error while adding record: node record already exists", errors.New("exit status 15")
+		}
+		if strings.HasSuffix(cmd, "--login") {
+			return "iscsiadm: default: 1 session requested, but 1 already present.", errors.New("exit status 15")
+		}
+		return "", nil
+	})
+	_, err = info.Connect(0)
+	assert.NoError(err)
+	assert.Equal([]string{node + "-o new", node + "--login"}, *cmds)
+	assert.Empty(info.Opened)
+
+	*cmds = nil
+	assert.NoError(info.Disconnect())
+	assert.Empty(*cmds)
+
+	// the LUN does not show up, the session is closed again
+	cmds = fakeCommands(t, func(cmd string) (string, error) {
+		return "", nil
+	})
+	info.Params[ISCSILUN] = "3"
+	_, err = info.Connect(0)
+	assert.Error(err)
+	assert.Equal([]string{node + "--logout", node + "-o delete"}, (*cmds)[3:])
+	assert.Empty(info.Opened)
+
+	// the login fails, the node is deleted again
+	cmds = fakeCommands(t, func(cmd string) (string, error) {
+		if strings.HasSuffix(cmd, "--login") {
+			return "iscsiadm: Could not login to [iface: default, target: iqn.2003-01.org:t1]", errors.New("exit status 24")
+		}
+		return "", nil
+	})
+	_, err = info.Connect(0)
+	assert.Error(err)
+	assert.Equal([]string{node + "-o delete"}, (*cmds)[3:])
+}
+
+func TestNVMeoF(t *testing.T) {
+	assert := assert.New(t)
+
+	savedSysClassNVMeDir, savedSysBlockDir, savedDevDir := SysClassNVMeDir, SysBlockDir, DevDir
+	defer func() {
+		SysClassNVMeDir, SysBlockDir, DevDir = savedSysClassNVMeDir, savedSysBlockDir, savedDevDir
+	}()
+	root := t.TempDir()
+	SysClassNVMeDir = filepath.Join(root, "sys", "class", "nvme")
+	SysBlockDir = filepath.Join(root, "sys", "block")
+	DevDir = filepath.Join(root, "dev")
+
+	writeAttr := func(path, value string) {
+		path = filepath.Join(root, path)
+		assert.NoError(os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(os.WriteFile(path, []byte(value+"\n"), 0644))
+	}
+
+	info := Info{
+		Type: TypeNVMeoF,
+		Params: map[string]string{
+			NVMeoFTransport: "tcp",
+			NVMeoFTrAddr:    "10.0.0.1",
+			NVMeoFNQN:       "nqn.2014-08.org:s1",
+			NVMeoFNSID:      "2",
+		},
+	}
+	assert.Equal("nvme-of:nqn.2014-08.org:s1", info.Session())
+
+	// nvme connect creates the controller and the namespaces of the
+	// subsystem, nvme0c0n2 being the hidden path of nvme0n2
+	cmds := fakeCommands(t, func(cmd string) (string, error) {
+		writeAttr("sys/class/nvme/nvme0/subsysnqn", "nqn.2014-08.org:s1")
+		writeAttr("sys/class/nvme/nvme0/transport", "tcp")
+		writeAttr("sys/class/nvme/nvme0/address", "traddr=10.0.0.1,trsvcid=4420,src_addr=10.0.0.2")
+		writeAttr("sys/block/nvme0c0n2/hidden", "1")
+		writeAttr("sys/block/nvme0c0n2/nsid", "2")
+		writeAttr("sys/block/nvme0c0n2/device/subsysnqn", "nqn.2014-08.org:s1")
+		for _, ns := range []string{"1", "2"} {
+			writeAttr("sys/block/nvme0n"+ns+"/nsid", ns)
+			writeAttr("sys/block/nvme0n"+ns+"/device/subsysnqn", "nqn.2014-08.org:s1")
+			writeAttr("dev/nvme0n"+ns, "")
+		}
+		return "", nil
+	})
+
+	dev, err := info.Connect(0)
+	assert.NoError(err)
+	assert.Equal(filepath.Join(DevDir, "nvme0n2"), dev)
+	assert.Equal([]string{"nvme connect -t tcp -a 10.0.0.1 -n nqn.2014-08.org:s1 -s 4420"}, *cmds)
+	assert.Equal([]string{"nvme0"}, info.Opened)
+
+	// the subsystem is already connected
+	*cmds = nil
+	dev, err = info.Connect(0)
+	assert.NoError(err)
+	assert.Equal(filepath.Join(DevDir, "nvme0n2"), dev)
+	assert.Empty(*cmds)
+	assert.Equal([]string{"nvme0"}, info.Opened)
+
+	// the namespace does not exist
+	info.Params[NVMeoFNSID] = "3"
+	_, err = info.Connect(0)
+	assert.Error(err)
+
+	// only the controller which was connected is disconnected
+	writeAttr("sys/class/nvme/nvme1/subsysnqn", "nqn.2014-08.org:s1")
+	assert.NoError(info.Disconnect())
+	assert.Equal([]string{"nvme disconnect -d nvme0"}, *cmds)
+	assert.Empty(info.Opened)
+
+	// a controller the host had before is left alone
+	other := Info{Type: TypeNVMeoF, Params: info.Params}
+	*cmds = nil
+	info.Params[NVMeoFNSID] = "2"
+	_, err = other.Connect(0)
+	assert.NoError(err)
+	assert.Empty(other.Opened)
+	assert.NoError(other.Disconnect())
+	assert.Empty(*cmds)
+}
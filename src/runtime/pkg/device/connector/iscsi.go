@@ -0,0 +1,184 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package connector
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/multipath"
+)
+
+// The parameters of an iSCSI session, named as in the publish context of the
+// CSI iSCSI driver.
+const (
+	ISCSITargetPortal = "targetPortal"
+	ISCSIPortals      = "portals"
+	ISCSIIQN          = "iqn"
+	ISCSILUN          = "lun"
+	ISCSIInterface    = "iscsiInterface"
+)
+
+const iscsiDefaultPort = "3260"
+
+// DevDiskByPathDir is the directory of the udev links of the block devices by
+// path.
+var DevDiskByPathDir = "/dev/disk/by-path"
+
+// iscsi logs in to an iSCSI target through one portal, or through several
+// ones when the LUN is multipathed.
+type iscsi struct {
+	params map[string]string
+}
+
+// portals returns the portals of the target, with their port.
+func (c *iscsi) portals() []string {
+	var portals []string
+	seen := make(map[string]bool)
+	for _, portal := range append([]string{c.params[ISCSITargetPortal]}, strings.Split(c.params[ISCSIPortals], ",")...) {
+		portal = strings.TrimSpace(portal)
+		if portal == "" {
+			continue
+		}
+		// The host may be an IPv6 address, between brackets.
+		host := portal
+		if i := strings.LastIndex(portal, "]"); i >= 0 {
+			host = portal[i+1:]
+		}
+		if !strings.Contains(host, ":") {
+			portal += ":" + iscsiDefaultPort
+		}
+		if !seen[portal] {
+			seen[portal] = true
+			portals = append(portals, portal)
+		}
+	}
+	return portals
+}
+
+func (c *iscsi) iface() string {
+	if iface := c.params[ISCSIInterface]; iface != "" {
+		return iface
+	}
+	return "default"
+}
+
+func (c *iscsi) validate() error {
+	if c.params[ISCSITargetPortal] == "" {
+		return fmt.Errorf("missing iSCSI %s", ISCSITargetPortal)
+	}
+	if c.params[ISCSIIQN] == "" {
+		return fmt.Errorf("missing iSCSI %s", ISCSIIQN)
+	}
+	if _, err := strconv.ParseUint(c.params[ISCSILUN], 10, 16); err != nil {
+		return fmt.Errorf("invalid iSCSI %s %q", ISCSILUN, c.params[ISCSILUN])
+	}
+	return nil
+}
+
+func (c *iscsi) session() string {
+	return c.params[ISCSIIQN] + "@" + strings.Join(c.portals(), ",")
+}
+
+// What an iSCSI connect opened is recorded as the node records it created
+// and the sessions it logged in, each followed by its portal.
+const (
+	iscsiOpenedNode    = "node:"
+	iscsiOpenedSession = "session:"
+)
+
+// iscsiadm runs iscsiadm on the node of the target at portal. The errors
+// telling that there is nothing to do, e.g. as the session is already
+// logged in, are ignored: it then returns false.
+func (c *iscsi) iscsiadm(portal string, args ...string) (bool, error) {
+	args = append([]string{"-m", "node", "-T", c.params[ISCSIIQN], "-p", portal, "-I", c.iface()}, args...)
+	output, err := runCommand("iscsiadm", args...)
+	if err != nil {
+		out := string(output)
+		if strings.Contains(out, "already present") || strings.Contains(out, "already exists") ||
+			strings.Contains(out, "No matching sessions") || strings.Contains(out, "No records found") {
+			return false, nil
+		}
+		return false, commandError(err, output, "iscsiadm", args...)
+	}
+	return true, nil
+}
+
+func (c *iscsi) connect(timeout time.Duration) (string, []string, error) {
+	var devs, opened []string
+	for _, portal := range c.portals() {
+		// The node records and the sessions the host already had are
+		// left as they are.
+		created, err := c.iscsiadm(portal, "-o", "new")
+		if err != nil {
+			return "", opened, err
+		}
+		if created {
+			opened = append(opened, iscsiOpenedNode+portal)
+			if _, err := c.iscsiadm(portal, "-o", "update", "-n", "node.startup", "-v", "manual"); err != nil {
+				return "", opened, err
+			}
+		}
+		loggedIn, err := c.iscsiadm(portal, "--login")
+		if err != nil {
+			return "", opened, err
+		}
+		if loggedIn {
+			opened = append(opened, iscsiOpenedSession+portal)
+		}
+
+		link := filepath.Join(DevDiskByPathDir, fmt.Sprintf("ip-%s-iscsi-%s-lun-%s", portal, c.params[ISCSIIQN], c.params[ISCSILUN]))
+		dev, err := waitFor(link, timeout, func() (string, error) {
+			dev, err := filepath.EvalSymlinks(link)
+			if os.IsNotExist(err) {
+				return "", nil
+			}
+			return dev, err
+		})
+		if err != nil {
+			return "", opened, err
+		}
+		devs = append(devs, dev)
+	}
+
+	if len(devs) == 1 {
+		return devs[0], opened, nil
+	}
+
+	// The paths of a multipathed LUN are used through their map, which
+	// multipathd creates once they are there.
+	dev, err := waitFor(fmt.Sprintf("the multipath map of %s", strings.Join(devs, ", ")), timeout, func() (string, error) {
+		if m := multipath.MapOfPath(filepath.Base(devs[0])); m != nil {
+			return m.DevicePath(), nil
+		}
+		return "", nil
+	})
+	return dev, opened, err
+}
+
+// disconnect logs out of the sessions connect logged in, then deletes the
+// node records it created.
+func (c *iscsi) disconnect(opened []string) error {
+	for _, o := range opened {
+		if portal := strings.TrimPrefix(o, iscsiOpenedSession); portal != o {
+			if _, err := c.iscsiadm(portal, "--logout"); err != nil {
+				return err
+			}
+		}
+	}
+	for _, o := range opened {
+		if portal := strings.TrimPrefix(o, iscsiOpenedNode); portal != o {
+			if _, err := c.iscsiadm(portal, "-o", "delete"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
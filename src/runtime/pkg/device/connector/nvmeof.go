@@ -0,0 +1,185 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package connector
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The parameters of an NVMe over Fabrics session, named as the options of
+// nvme connect.
+const (
+	NVMeoFTransport = "transport"
+	NVMeoFTrAddr    = "traddr"
+	NVMeoFTrSvcID   = "trsvcid"
+	NVMeoFNQN       = "nqn"
+	NVMeoFNSID      = "nsid"
+	NVMeoFHostNQN   = "hostnqn"
+)
+
+const nvmeofDefaultPort = "4420"
+
+var (
+	// SysClassNVMeDir is the directory of the NVMe controllers in sysfs.
+	SysClassNVMeDir = "/sys/class/nvme"
+
+	// SysBlockDir is the directory of the block devices in sysfs.
+	SysBlockDir = "/sys/block"
+
+	// DevDir is the directory of the device nodes.
+	DevDir = "/dev"
+)
+
+// nvmeof connects to an NVMe subsystem over TCP, RDMA or Fibre Channel. With
+// the native NVMe multipath, the namespaces of the subsystem reached through
+// several controllers have a single block device.
+type nvmeof struct {
+	params map[string]string
+}
+
+func (c *nvmeof) trsvcid() string {
+	if trsvcid := c.params[NVMeoFTrSvcID]; trsvcid != "" || c.params[NVMeoFTransport] == "fc" {
+		return trsvcid
+	}
+	return nvmeofDefaultPort
+}
+
+func (c *nvmeof) nsid() string {
+	if nsid := c.params[NVMeoFNSID]; nsid != "" {
+		return nsid
+	}
+	return "1"
+}
+
+func (c *nvmeof) validate() error {
+	switch c.params[NVMeoFTransport] {
+	case "tcp", "rdma", "fc":
+	default:
+		return fmt.Errorf("invalid NVMe-oF %s %q, expected tcp, rdma or fc", NVMeoFTransport, c.params[NVMeoFTransport])
+	}
+	if c.params[NVMeoFTrAddr] == "" {
+		return fmt.Errorf("missing NVMe-oF %s", NVMeoFTrAddr)
+	}
+	if c.params[NVMeoFNQN] == "" {
+		return fmt.Errorf("missing NVMe-oF %s", NVMeoFNQN)
+	}
+	if _, err := strconv.ParseUint(c.nsid(), 10, 32); err != nil {
+		return fmt.Errorf("invalid NVMe-oF %s %q", NVMeoFNSID, c.params[NVMeoFNSID])
+	}
+	return nil
+}
+
+func (c *nvmeof) session() string {
+	return c.params[NVMeoFNQN]
+}
+
+func readAttr(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// controllers returns the controllers of the host connected to the
+// subsystem at the address of the session.
+func (c *nvmeof) controllers() []string {
+	entries, err := os.ReadDir(SysClassNVMeDir)
+	if err != nil {
+		return nil
+	}
+
+	var ctrls []string
+	for _, entry := range entries {
+		ctrl := filepath.Join(SysClassNVMeDir, entry.Name())
+		if readAttr(filepath.Join(ctrl, "subsysnqn")) != c.params[NVMeoFNQN] ||
+			readAttr(filepath.Join(ctrl, "transport")) != c.params[NVMeoFTransport] {
+			continue
+		}
+
+		// The address is e.g. traddr=10.0.0.1,trsvcid=4420,src_addr=10.0.0.2
+		address := make(map[string]string)
+		for _, field := range strings.Split(readAttr(filepath.Join(ctrl, "address")), ",") {
+			if key, value, ok := strings.Cut(field, "="); ok {
+				address[key] = value
+			}
+		}
+		if address[NVMeoFTrAddr] == c.params[NVMeoFTrAddr] && address[NVMeoFTrSvcID] == c.trsvcid() {
+			ctrls = append(ctrls, entry.Name())
+		}
+	}
+	return ctrls
+}
+
+// namespace returns the device node of the namespace of the session, the
+// empty string when it is not there.
+func (c *nvmeof) namespace() (string, error) {
+	entries, err := os.ReadDir(SysBlockDir)
+	if err != nil {
+		return "", err
+	}
+
+	for _, entry := range entries {
+		dev := filepath.Join(SysBlockDir, entry.Name())
+		// The namespaces of the controllers of a multipathed
+		// subsystem are hidden behind the one of the subsystem.
+		if !strings.HasPrefix(entry.Name(), "nvme") || readAttr(filepath.Join(dev, "hidden")) == "1" {
+			continue
+		}
+		if readAttr(filepath.Join(dev, "device", "subsysnqn")) == c.params[NVMeoFNQN] &&
+			readAttr(filepath.Join(dev, "nsid")) == c.nsid() {
+			path := filepath.Join(DevDir, entry.Name())
+			if _, err := os.Stat(path); err != nil {
+				return "", nil
+			}
+			return path, nil
+		}
+	}
+	return "", nil
+}
+
+// connect connects a controller to the subsystem unless the host already
+// has one, what it opened is the new controller.
+func (c *nvmeof) connect(timeout time.Duration) (string, []string, error) {
+	var opened []string
+	if len(c.controllers()) == 0 {
+		args := []string{"connect", "-t", c.params[NVMeoFTransport], "-a", c.params[NVMeoFTrAddr], "-n", c.params[NVMeoFNQN]}
+		if trsvcid := c.trsvcid(); trsvcid != "" {
+			args = append(args, "-s", trsvcid)
+		}
+		if hostnqn := c.params[NVMeoFHostNQN]; hostnqn != "" {
+			args = append(args, "-q", hostnqn)
+		}
+		if output, err := runCommand("nvme", args...); err != nil {
+			return "", nil, commandError(err, output, "nvme", args...)
+		}
+		opened = c.controllers()
+	}
+
+	dev, err := waitFor(fmt.Sprintf("namespace %s of %s", c.nsid(), c.params[NVMeoFNQN]), timeout, c.namespace)
+	return dev, opened, err
+}
+
+// disconnect disconnects the controllers connect connected, those which
+// are gone already, or whose name was given to another subsystem since, are
+// skipped.
+func (c *nvmeof) disconnect(opened []string) error {
+	for _, ctrl := range opened {
+		if readAttr(filepath.Join(SysClassNVMeDir, ctrl, "subsysnqn")) != c.params[NVMeoFNQN] {
+			continue
+		}
+		args := []string{"disconnect", "-d", ctrl}
+		if output, err := runCommand("nvme", args...); err != nil {
+			return commandError(err, output, "nvme", args...)
+		}
+	}
+	return nil
+}
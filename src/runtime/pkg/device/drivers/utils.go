@@ -7,9 +7,11 @@
 package drivers
 
 import (
+	"encoding/binary"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -39,6 +41,7 @@ type PCISysFsProperty string
 
 var (
 	PCISysFsDevicesClass     PCISysFsProperty = "class"         // /sys/bus/pci/devices/xxx/class
+	PCISysFsDevicesVendor    PCISysFsProperty = "vendor"        // /sys/bus/pci/devices/xxx/vendor
 	PCISysFsSlotsAddress     PCISysFsProperty = "address"       // /sys/bus/pci/slots/xxx/address
 	PCISysFsSlotsMaxBusSpeed PCISysFsProperty = "max_bus_speed" // /sys/bus/pci/slots/xxx/max_bus_speed
 )
@@ -205,6 +208,9 @@ func GetAllVFIODevicesFromIOMMUGroup(device config.DeviceInfo) ([]*config.VFIODe
 				Rank:     -1,
 				Port:     device.Port,
 			}
+			if vfioDeviceType == config.VFIOPCIDeviceNormalType && vfio.IsPCIe {
+				vfio.TDISP = IsPCIDeviceTDISPCapable(deviceBDF)
+			}
 
 		case config.VFIOAPDeviceMediatedType:
 			devices, err := GetAPVFIODevices(deviceSysfsDev)
@@ -226,3 +232,213 @@ func GetAllVFIODevicesFromIOMMUGroup(device config.DeviceInfo) ([]*config.VFIODe
 
 	return vfioDevs, nil
 }
+
+// The flags of the resources in /sys/bus/pci/devices/xxx/resource, see
+// include/linux/ioport.h
+const (
+	ioResourceMem   = 0x00000200
+	ioResourceMem64 = 0x00100000
+
+	pciNumBARs = 6
+)
+
+// GetPCIDeviceBARSizes returns the total sizes of the 32-bit and 64-bit memory
+// BARs of a PCI device, as read from /sys/bus/pci/devices/xxx/resource. They
+// tell the size of the MMIO windows the device needs in the guest.
+func GetPCIDeviceBARSizes(bdf string) (uint64, uint64, error) {
+	if len(strings.Split(bdf, ":")) == 2 {
+		bdf = PCIDomain + ":" + bdf
+	}
+
+	data, err := os.ReadFile(filepath.Join(config.SysBusPciDevicesPath, bdf, "resource"))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var memSize32bit, memSize64bit uint64
+	for i, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if i == pciNumBARs {
+			break
+		}
+
+		var start, end, flags uint64
+		if _, err := fmt.Sscanf(line, "0x%x 0x%x 0x%x", &start, &end, &flags); err != nil {
+			return 0, 0, fmt.Errorf("invalid PCI resource %q of device %s: %v", line, bdf, err)
+		}
+		if flags&ioResourceMem == 0 || end <= start {
+			continue
+		}
+
+		size := end - start + 1
+		if flags&ioResourceMem64 != 0 {
+			memSize64bit += size
+		} else {
+			memSize32bit += size
+		}
+	}
+
+	return memSize32bit, memSize64bit, nil
+}
+
+// GetPCIDeviceIOMMUGroup returns the IOMMU group of a PCI device, as linked
+// from /sys/bus/pci/devices/xxx/iommu_group.
+func GetPCIDeviceIOMMUGroup(bdf string) (string, error) {
+	if len(strings.Split(bdf, ":")) == 2 {
+		bdf = PCIDomain + ":" + bdf
+	}
+
+	group, err := os.Readlink(filepath.Join(config.SysBusPciDevicesPath, bdf, "iommu_group"))
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Base(group), nil
+}
+
+// GetPCIDeviceVendor returns the vendor id of a PCI device, such as 0x10de.
+func GetPCIDeviceVendor(bdf string) string {
+	return getPCIDeviceProperty(bdf, PCISysFsDevicesVendor)
+}
+
+// The ACS extended capability of the PCIe ports, see
+// include/uapi/linux/pci_regs.h
+const (
+	pciExtCapStart = 0x100
+	pciExtCapIDACS = 0x000d
+	pciACSCtrl     = 0x06
+	pciACSRR       = 0x0004 // P2P Request Redirect
+	pciACSCR       = 0x0008 // P2P Completion Redirect
+
+	pciExtConfigSpaceSize = 4096
+)
+
+var pciBDFRegex = regexp.MustCompile(`^[0-9a-f]{4}:[0-9a-f]{2}:[0-9a-f]{2}\.[0-7]$`)
+
+// pcieACSRedirect tells if the ACS capability found in the config space of a
+// PCIe port redirects the peer-to-peer requests or completions upstream.
+func pcieACSRedirect(configSpace []byte) (bool, error) {
+	if len(configSpace) < pciExtConfigSpaceSize {
+		return false, fmt.Errorf("extended config space is not readable")
+	}
+
+	// Walk the extended capabilities list, which is bounded by the size
+	// of the extended config space.
+	for offset, n := pciExtCapStart, 0; offset != 0 && n < (pciExtConfigSpaceSize-pciExtCapStart)/4; n++ {
+		if offset < pciExtCapStart || offset+pciACSCtrl+2 > len(configSpace) {
+			break
+		}
+		header := binary.LittleEndian.Uint32(configSpace[offset:])
+		if header == 0 || header == 0xffffffff {
+			break
+		}
+		if header&0xffff == pciExtCapIDACS {
+			ctrl := binary.LittleEndian.Uint16(configSpace[offset+pciACSCtrl:])
+			return ctrl&(pciACSRR|pciACSCR) != 0, nil
+		}
+		offset = int(header>>20) &^ 3
+	}
+
+	return false, nil
+}
+
+// GetPCIeP2PSwitch returns the BDF of the upstream port of the host PCIe
+// switch a device does peer-to-peer DMA through, the topmost one when
+// switches are cascaded. The device must be behind a switch whose downstream
+// ports do not redirect the peer-to-peer traffic to the root complex with
+// ACS, otherwise the DMA between the devices of the switch would go through
+// the IOMMU of the host, when it goes at all.
+func GetPCIeP2PSwitch(bdf string) (string, error) {
+	if len(strings.Split(bdf, ":")) == 2 {
+		bdf = PCIDomain + ":" + bdf
+	}
+
+	devicePath, err := filepath.EvalSymlinks(filepath.Join(config.SysBusPciDevicesPath, bdf))
+	if err != nil {
+		return "", err
+	}
+
+	// The sysfs path of a device is the one of its parents, from the root
+	// port down to the device itself.
+	var chain []string
+	for dir := devicePath; pciBDFRegex.MatchString(filepath.Base(dir)); dir = filepath.Dir(dir) {
+		chain = append([]string{dir}, chain...)
+	}
+
+	// The root port, the upstream and downstream ports of a switch and
+	// the device.
+	if len(chain) < 4 {
+		return "", fmt.Errorf("device %s is not behind a PCIe switch, peer-to-peer DMA is not supported", bdf)
+	}
+
+	for _, port := range chain[2 : len(chain)-1] {
+		configSpace, err := os.ReadFile(filepath.Join(port, "config"))
+		if err != nil {
+			return "", err
+		}
+		redirect, err := pcieACSRedirect(configSpace)
+		if err != nil {
+			return "", fmt.Errorf("cannot check the ACS of PCIe port %s: %v", filepath.Base(port), err)
+		}
+		if redirect {
+			return "", fmt.Errorf("ACS of PCIe port %s redirects the peer-to-peer DMA of device %s", filepath.Base(port), bdf)
+		}
+	}
+
+	return filepath.Base(chain[1]), nil
+}
+
+// The PCI Express capability of the PCIe devices, see
+// include/uapi/linux/pci_regs.h
+const (
+	pciStatus           = 0x06
+	pciStatusCapList    = 0x10
+	pciCapabilityList   = 0x34
+	pciCapIDExp         = 0x10
+	pciExpDevCap        = 0x04
+	pciExpDevCapTEEIO   = 0x40000000 // TEE-IO (TDISP) Supported
+	pciStdConfigSpaceSz = 64
+)
+
+// pcieTEEIOSupported tells if the PCI Express capability found in the config
+// space of a device has TEE-IO support, i.e. if the device implements TDISP.
+func pcieTEEIOSupported(configSpace []byte) bool {
+	if len(configSpace) < pciStdConfigSpaceSz {
+		return false
+	}
+	if binary.LittleEndian.Uint16(configSpace[pciStatus:])&pciStatusCapList == 0 {
+		return false
+	}
+
+	// Walk the capabilities list, which is bounded by the size of the
+	// config space.
+	offset := int(configSpace[pciCapabilityList]) &^ 3
+	for n := 0; offset != 0 && n < PCIConfigSpaceSize/4; n++ {
+		if offset < pciStdConfigSpaceSz || offset+pciExpDevCap+4 > len(configSpace) {
+			break
+		}
+		if configSpace[offset] == pciCapIDExp {
+			devCap := binary.LittleEndian.Uint32(configSpace[offset+pciExpDevCap:])
+			return devCap&pciExpDevCapTEEIO != 0
+		}
+		offset = int(configSpace[offset+1]) &^ 3
+	}
+
+	return false
+}
+
+// IsPCIDeviceTDISPCapable tells if a PCI device supports TEE-IO, so that its
+// interface can be locked and attested with TDISP for a confidential guest
+// to trust it. The config space past its header is only readable by root.
+func IsPCIDeviceTDISPCapable(bdf string) bool {
+	if len(strings.Split(bdf, ":")) == 2 {
+		bdf = PCIDomain + ":" + bdf
+	}
+
+	configSpace, err := os.ReadFile(filepath.Join(config.SysBusPciDevicesPath, bdf, "config"))
+	if err != nil {
+		deviceLogger().WithError(err).WithField("device", bdf).Warn("Cannot read the config space of the device")
+		return false
+	}
+
+	return pcieTEEIOSupported(configSpace)
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package drivers
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPCIDeviceBARSizes(t *testing.T) {
+	assert := assert.New(t)
+
+	savedPath := config.SysBusPciDevicesPath
+	defer func() {
+		config.SysBusPciDevicesPath = savedPath
+	}()
+	config.SysBusPciDevicesPath = t.TempDir()
+
+	bdf := "0000:41:00.0"
+	assert.NoError(os.MkdirAll(filepath.Join(config.SysBusPciDevicesPath, bdf), 0755))
+
+	// a GPU with a 16M 32-bit BAR, a 128G and a 32M 64-bit prefetchable
+	// BARs, and an IO BAR
+	resource := `0x00000000fa000000 0x00000000faffffff 0x0000000000040200
+0x0000020000000000 0x0000021fffffffff 0x000000000014220c
+0x0000000000000000 0x0000000000000000 0x0000000000000000
+0x0000022000000000 0x0000022001ffffff 0x000000000014220c
+0x0000000000000000 0x0000000000000000 0x0000000000000000
+0x000000000000e000 0x000000000000e07f 0x0000000000040101
+0x00000000fb000000 0x00000000fb07ffff 0x0000000000046200
+`
+	assert.NoError(os.WriteFile(filepath.Join(config.SysBusPciDevicesPath, bdf, "resource"), []byte(resource), 0644))
+
+	memSize32bit, memSize64bit, err := GetPCIDeviceBARSizes("41:00.0")
+	assert.NoError(err)
+	assert.Equal(uint64(16<<20), memSize32bit)
+	assert.Equal(uint64(128<<30+32<<20), memSize64bit)
+
+	_, _, err = GetPCIDeviceBARSizes("0000:42:00.0")
+	assert.Error(err)
+}
+
+func TestGetPCIDeviceIOMMUGroup(t *testing.T) {
+	assert := assert.New(t)
+
+	savedPath := config.SysBusPciDevicesPath
+	defer func() {
+		config.SysBusPciDevicesPath = savedPath
+	}()
+	config.SysBusPciDevicesPath = t.TempDir()
+
+	bdf := "0000:41:00.0"
+	assert.NoError(os.MkdirAll(filepath.Join(config.SysBusPciDevicesPath, bdf), 0755))
+	assert.NoError(os.Symlink("../../../../kernel/iommu_groups/12", filepath.Join(config.SysBusPciDevicesPath, bdf, "iommu_group")))
+
+	group, err := GetPCIDeviceIOMMUGroup("41:00.0")
+	assert.NoError(err)
+	assert.Equal("12", group)
+
+	_, err = GetPCIDeviceIOMMUGroup("0000:42:00.0")
+	assert.Error(err)
+}
+
+// pcieExtConfigSpace returns the extended config space of a PCIe port with an
+// AER capability followed by an ACS one.
+func pcieExtConfigSpace(acsCtrl uint16) []byte {
+	configSpace := make([]byte, pciExtConfigSpaceSize)
+	// AER, version 2, next at 0x148
+	binary.LittleEndian.PutUint32(configSpace[0x100:], 0x0001|2<<16|0x148<<20)
+	// ACS, version 1, last
+	binary.LittleEndian.PutUint32(configSpace[0x148:], pciExtCapIDACS|1<<16)
+	binary.LittleEndian.PutUint16(configSpace[0x148+pciACSCtrl:], acsCtrl)
+	return configSpace
+}
+
+func TestPCIeACSRedirect(t *testing.T) {
+	assert := assert.New(t)
+
+	redirect, err := pcieACSRedirect(pcieExtConfigSpace(0x001d))
+	assert.NoError(err)
+	assert.True(redirect)
+
+	// source validation and translation blocking only
+	redirect, err = pcieACSRedirect(pcieExtConfigSpace(0x0003))
+	assert.NoError(err)
+	assert.False(redirect)
+
+	// no extended capability
+	redirect, err = pcieACSRedirect(make([]byte, pciExtConfigSpaceSize))
+	assert.NoError(err)
+	assert.False(redirect)
+
+	// only the legacy config space can be read without CAP_SYS_ADMIN
+	_, err = pcieACSRedirect(make([]byte, 64))
+	assert.Error(err)
+}
+
+// pcieTEEIOConfigSpace returns a config space with a power management
+// capability chained to a PCI Express capability at 0x70.
+func pcieTEEIOConfigSpace(devCap uint32) []byte {
+	configSpace := make([]byte, PCIConfigSpaceSize)
+	binary.LittleEndian.PutUint16(configSpace[pciStatus:], pciStatusCapList)
+	configSpace[pciCapabilityList] = 0x40
+	configSpace[0x40] = 0x01
+	configSpace[0x41] = 0x70
+	configSpace[0x70] = pciCapIDExp
+	binary.LittleEndian.PutUint32(configSpace[0x70+pciExpDevCap:], devCap)
+	return configSpace
+}
+
+func TestPCIeTEEIOSupported(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(pcieTEEIOSupported(pcieTEEIOConfigSpace(pciExpDevCapTEEIO | 0x8fc2)))
+	assert.False(pcieTEEIOSupported(pcieTEEIOConfigSpace(0x8fc2)))
+
+	// no capabilities list
+	configSpace := pcieTEEIOConfigSpace(pciExpDevCapTEEIO)
+	binary.LittleEndian.PutUint16(configSpace[pciStatus:], 0)
+	assert.False(pcieTEEIOSupported(configSpace))
+
+	// capabilities pointing at each other must not loop forever
+	configSpace = pcieTEEIOConfigSpace(pciExpDevCapTEEIO)
+	configSpace[0x41] = 0x40
+	assert.False(pcieTEEIOSupported(configSpace))
+
+	assert.False(pcieTEEIOSupported(nil))
+}
+
+func TestGetPCIeP2PSwitch(t *testing.T) {
+	assert := assert.New(t)
+
+	savedPath := config.SysBusPciDevicesPath
+	defer func() {
+		config.SysBusPciDevicesPath = savedPath
+	}()
+	dir := t.TempDir()
+	config.SysBusPciDevicesPath = filepath.Join(dir, "bus")
+	assert.NoError(os.MkdirAll(config.SysBusPciDevicesPath, 0755))
+
+	addDevice := func(path string) {
+		devicePath := filepath.Join(dir, "devices", path)
+		assert.NoError(os.MkdirAll(devicePath, 0755))
+		assert.NoError(os.Symlink(devicePath, filepath.Join(config.SysBusPciDevicesPath, filepath.Base(path))))
+	}
+
+	// a GPU behind a switch, and a NIC on a root port
+	rootPort := "pci0000:00/0000:00:01.0"
+	downstreamPort := rootPort + "/0000:01:00.0/0000:02:08.0"
+	addDevice(downstreamPort + "/0000:03:00.0")
+	addDevice("pci0000:00/0000:00:02.0/0000:04:00.0")
+
+	configPath := filepath.Join(dir, "devices", downstreamPort, "config")
+	assert.NoError(os.WriteFile(configPath, pcieExtConfigSpace(0), 0644))
+
+	sw, err := GetPCIeP2PSwitch("03:00.0")
+	assert.NoError(err)
+	assert.Equal("0000:01:00.0", sw)
+
+	_, err = GetPCIeP2PSwitch("0000:04:00.0")
+	assert.Error(err)
+
+	assert.NoError(os.WriteFile(configPath, pcieExtConfigSpace(pciACSRR|pciACSCR), 0644))
+	_, err = GetPCIeP2PSwitch("0000:03:00.0")
+	assert.Error(err)
+}
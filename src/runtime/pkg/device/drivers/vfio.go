@@ -69,6 +69,13 @@ func (device *VFIODevice) Attach(ctx context.Context, devReceiver api.DeviceRece
 	if err != nil {
 		return err
 	}
+	if device.DeviceInfo.DriverOptions[config.TDISPRequiredOpt] == "true" {
+		for _, vfio := range device.VfioDevs {
+			if vfio.Type != config.VFIOAPDeviceMediatedType && !vfio.TDISP {
+				return fmt.Errorf("device %s does not support TDISP and cannot be trusted by a confidential guest requiring TDISP devices", vfio.BDF)
+			}
+		}
+	}
 	for _, vfio := range device.VfioDevs {
 		if vfio.IsPCIe {
 			busIndex := len(config.PCIeDevices[vfio.Port])
@@ -178,6 +185,7 @@ func (device *VFIODevice) Load(ds config.DeviceState) {
 				Type:     config.VFIODeviceType(dev.Type),
 				BDF:      dev.BDF,
 				SysfsDev: dev.SysfsDev,
+				TDISP:    dev.TDISP,
 			}
 		case config.VFIOAPDeviceMediatedType:
 			vfio = config.VFIODev{
@@ -0,0 +1,202 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package drivers
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/api"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// vhostVDPAHookAttach is passed to the hook before the device is
+	// attached to the VM.
+	vhostVDPAHookAttach = "attach"
+
+	// vhostVDPAHookDetach is passed to the hook after the device is
+	// detached from the VM.
+	vhostVDPAHookDetach = "detach"
+)
+
+// vhostVDPAHookTimeout is how long the dataplane is given to answer a hook.
+var vhostVDPAHookTimeout = 30 * time.Second
+
+// VhostVDPABlkDevice is a block device served by a vDPA backend, such as a
+// VDUSE userspace dataplane, and given to the VM through vhost-vdpa.
+type VhostVDPABlkDevice struct {
+	*GenericDevice
+	VhostUserDeviceAttrs *config.VhostUserDeviceAttrs
+}
+
+// NewVhostVDPABlkDevice creates a new vhost-vdpa block device based on DeviceInfo
+func NewVhostVDPABlkDevice(devInfo *config.DeviceInfo) *VhostVDPABlkDevice {
+	return &VhostVDPABlkDevice{
+		GenericDevice: &GenericDevice{
+			ID:         devInfo.ID,
+			DeviceInfo: devInfo,
+		},
+	}
+}
+
+//
+// VhostVDPABlkDevice's implementation of the device interface:
+//
+
+// Attach is standard interface of api.Device, it's used to add device to some
+// DeviceReceiver
+func (device *VhostVDPABlkDevice) Attach(ctx context.Context, devReceiver api.DeviceReceiver) (err error) {
+	skip, err := device.bumpAttachCount(true)
+	if err != nil {
+		return err
+	}
+	if skip {
+		return nil
+	}
+
+	// The guest sees a virtio-blk device, which uses the "vd" prefix as
+	// vhost-user-blk does.
+	index := -1
+	updateBlockIndex := isVirtioBlkBlockDriver(device.DeviceInfo.DriverOptions)
+	if updateBlockIndex {
+		index, err = devReceiver.GetAndSetSandboxBlockIndex()
+	}
+
+	defer func() {
+		if err != nil {
+			if updateBlockIndex {
+				devReceiver.UnsetSandboxBlockIndex(index)
+			}
+			device.bumpAttachCount(false)
+		}
+	}()
+
+	if err != nil {
+		return err
+	}
+
+	if err = device.runHook(ctx, vhostVDPAHookAttach); err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			device.runHook(ctx, vhostVDPAHookDetach)
+		}
+	}()
+
+	vAttrs := &config.VhostUserDeviceAttrs{
+		DevID:      utils.MakeNameID("vdpa", device.DeviceInfo.ID, maxDevIDSize),
+		SocketPath: device.DeviceInfo.HostPath,
+		Type:       config.VhostVDPABlk,
+		Index:      index,
+	}
+
+	deviceLogger().WithFields(logrus.Fields{
+		"device": device.DeviceInfo.HostPath,
+		"Type":   config.VhostVDPABlk,
+		"Index":  index,
+	}).Info("Attaching device")
+
+	device.VhostUserDeviceAttrs = vAttrs
+	if err = devReceiver.HotplugAddDevice(ctx, device, config.VhostVDPABlk); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Detach is standard interface of api.Device, it's used to remove device from some
+// DeviceReceiver
+func (device *VhostVDPABlkDevice) Detach(ctx context.Context, devReceiver api.DeviceReceiver) error {
+	skip, err := device.bumpAttachCount(false)
+	if err != nil {
+		return err
+	}
+	if skip {
+		return nil
+	}
+
+	defer func() {
+		if err != nil {
+			device.bumpAttachCount(true)
+		} else {
+			updateBlockIndex := isVirtioBlkBlockDriver(device.DeviceInfo.DriverOptions)
+			if updateBlockIndex {
+				devReceiver.UnsetSandboxBlockIndex(device.VhostUserDeviceAttrs.Index)
+			}
+		}
+	}()
+
+	deviceLogger().WithField("device", device.DeviceInfo.HostPath).Info("Unplugging vhost-vdpa-blk device")
+
+	if err = devReceiver.HotplugRemoveDevice(ctx, device, config.VhostVDPABlk); err != nil {
+		deviceLogger().WithError(err).Error("Failed to unplug vhost-vdpa-blk device")
+		return err
+	}
+
+	// The device is gone from the VM, a failing hook must not make it
+	// look attached again.
+	if hookErr := device.runHook(ctx, vhostVDPAHookDetach); hookErr != nil {
+		deviceLogger().WithError(hookErr).Warn("vhost-vdpa detach hook failed")
+	}
+
+	return nil
+}
+
+// runHook lets the dataplane serving the device know it is about to be
+// attached to, or was detached from, the VM. The hook is called with the
+// action and the path of the vhost-vdpa device.
+func (device *VhostVDPABlkDevice) runHook(ctx context.Context, action string) error {
+	hook := device.DeviceInfo.DriverOptions[config.VhostVDPAHookOpt]
+	if hook == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, vhostVDPAHookTimeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, hook, action, device.DeviceInfo.HostPath).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("vhost-vdpa %s hook %s failed: %v: %s", action, hook, err, out)
+	}
+
+	return nil
+}
+
+// DeviceType is standard interface of api.Device, it returns device type
+func (device *VhostVDPABlkDevice) DeviceType() config.DeviceType {
+	return config.VhostVDPABlk
+}
+
+// GetDeviceInfo returns device information used for creating
+func (device *VhostVDPABlkDevice) GetDeviceInfo() interface{} {
+	return device.VhostUserDeviceAttrs
+}
+
+// Save converts Device to DeviceState
+func (device *VhostVDPABlkDevice) Save() config.DeviceState {
+	ds := device.GenericDevice.Save()
+	ds.Type = string(device.DeviceType())
+	ds.VhostUserDev = device.VhostUserDeviceAttrs
+
+	return ds
+}
+
+// Load loads DeviceState and converts it to specific device
+func (device *VhostVDPABlkDevice) Load(ds config.DeviceState) {
+	device.GenericDevice = &GenericDevice{}
+	device.GenericDevice.Load(ds)
+	device.VhostUserDeviceAttrs = ds.VhostUserDev
+}
+
+// It should implement GetAttachCount() and DeviceID() as api.Device implementation
+// here it shares function from *GenericDevice so we don't need duplicate codes
@@ -18,6 +18,7 @@ import (
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/api"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/config"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/drivers"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/faultinject"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
 )
 
@@ -45,6 +46,12 @@ type deviceManager struct {
 	vhostUserStoreEnabled bool
 
 	vhostUserReconnectTimeout uint32
+
+	vhostVDPAHook string
+
+	// requireTDISP rejects the passthrough of the VFIO devices which do not
+	// support TDISP, since a confidential guest cannot trust them
+	requireTDISP bool
 }
 
 func deviceLogger() *logrus.Entry {
@@ -52,11 +59,13 @@ func deviceLogger() *logrus.Entry {
 }
 
 // NewDeviceManager creates a deviceManager object behaved as api.DeviceManager
-func NewDeviceManager(blockDriver string, vhostUserStoreEnabled bool, vhostUserStorePath string, vhostUserReconnect uint32, devices []api.Device) api.DeviceManager {
+func NewDeviceManager(blockDriver string, vhostUserStoreEnabled bool, vhostUserStorePath string, vhostUserReconnect uint32, vhostVDPAHook string, requireTDISP bool, devices []api.Device) api.DeviceManager {
 	dm := &deviceManager{
 		vhostUserStoreEnabled:     vhostUserStoreEnabled,
 		vhostUserStorePath:        vhostUserStorePath,
 		vhostUserReconnectTimeout: vhostUserReconnect,
+		vhostVDPAHook:             vhostVDPAHook,
+		requireTDISP:              requireTDISP,
 		devices:                   make(map[string]api.Device),
 	}
 	if blockDriver == config.VirtioMmio {
@@ -93,6 +102,15 @@ func (dm *deviceManager) findDeviceByMajorMinor(major, minor int64) api.Device {
 	return nil
 }
 
+func (dm *deviceManager) findPmemFile(path string) api.Device {
+	for _, dev := range dm.devices {
+		if b, ok := dev.(*drivers.BlockDevice); ok && b.DeviceInfo.Pmem && b.DeviceInfo.HostPath == path {
+			return b
+		}
+	}
+	return nil
+}
+
 // createDevice creates one device based on DeviceInfo
 func (dm *deviceManager) createDevice(devInfo config.DeviceInfo) (dev api.Device, err error) {
 	// pmem device may points to block devices or raw files,
@@ -111,7 +129,13 @@ func (dm *deviceManager) createDevice(devInfo config.DeviceInfo) (dev api.Device
 		}
 	}()
 
-	if existingDev := dm.findDeviceByMajorMinor(devInfo.Major, devInfo.Minor); existingDev != nil {
+	if devInfo.Pmem && devInfo.Major == 0 && devInfo.Minor == 0 {
+		// The files emulating pmem have no device number, they
+		// are shared by path.
+		if existingDev := dm.findPmemFile(devInfo.HostPath); existingDev != nil {
+			return existingDev, nil
+		}
+	} else if existingDev := dm.findDeviceByMajorMinor(devInfo.Major, devInfo.Minor); existingDev != nil {
 		return existingDev, nil
 	}
 
@@ -121,6 +145,12 @@ func (dm *deviceManager) createDevice(devInfo config.DeviceInfo) (dev api.Device
 		return nil, err
 	}
 	if IsVFIO(devInfo.HostPath) {
+		if dm.requireTDISP {
+			if devInfo.DriverOptions == nil {
+				devInfo.DriverOptions = make(map[string]string)
+			}
+			devInfo.DriverOptions[config.TDISPRequiredOpt] = "true"
+		}
 		return drivers.NewVFIODevice(&devInfo), nil
 	} else if IsVhostUserBlk(devInfo) {
 		if devInfo.DriverOptions == nil {
@@ -129,6 +159,13 @@ func (dm *deviceManager) createDevice(devInfo config.DeviceInfo) (dev api.Device
 		devInfo.DriverOptions[config.BlockDriverOpt] = dm.blockDriver
 		devInfo.DriverOptions[config.VhostUserReconnectTimeOutOpt] = fmt.Sprintf("%d", dm.vhostUserReconnectTimeout)
 		return drivers.NewVhostUserBlkDevice(&devInfo), nil
+	} else if IsVhostVDPA(devInfo.HostPath) {
+		if devInfo.DriverOptions == nil {
+			devInfo.DriverOptions = make(map[string]string)
+		}
+		devInfo.DriverOptions[config.BlockDriverOpt] = dm.blockDriver
+		devInfo.DriverOptions[config.VhostVDPAHookOpt] = dm.vhostVDPAHook
+		return drivers.NewVhostVDPABlkDevice(&devInfo), nil
 	} else if isBlock(devInfo) {
 		if devInfo.DriverOptions == nil {
 			devInfo.DriverOptions = make(map[string]string)
@@ -196,6 +233,10 @@ func (dm *deviceManager) AttachDevice(ctx context.Context, id string, dr api.Dev
 		return ErrDeviceNotExist
 	}
 
+	if err := faultinject.Inject(ctx, faultinject.DeviceAttach); err != nil {
+		return err
+	}
+
 	if err := d.Attach(ctx, dr); err != nil {
 		return err
 	}
@@ -214,6 +255,10 @@ func (dm *deviceManager) DetachDevice(ctx context.Context, id string, dr api.Dev
 		return ErrDeviceNotAttached
 	}
 
+	if err := faultinject.Inject(ctx, faultinject.DeviceDetach); err != nil {
+		return err
+	}
+
 	if err := d.Detach(ctx, dr); err != nil {
 		return err
 	}
@@ -270,6 +315,8 @@ func (dm *deviceManager) LoadDevices(devStates []config.DeviceState) {
 			dev = &drivers.VhostUserBlkDevice{}
 		case config.VhostUserNet:
 			dev = &drivers.VhostUserNetDevice{}
+		case config.VhostVDPABlk:
+			dev = &drivers.VhostVDPABlkDevice{}
 		default:
 			deviceLogger().WithField("device-type", ds.Type).Warning("unrecognized device type is detected")
 			// continue the for loop
@@ -207,8 +207,90 @@ func TestAttachBlockDevice(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestNewPmemFileDevice(t *testing.T) {
+	assert := assert.New(t)
+	dm := &deviceManager{
+		blockDriver: config.VirtioBlock,
+		devices:     make(map[string]api.Device),
+	}
+
+	pmemInfo := func(path string) config.DeviceInfo {
+		return config.DeviceInfo{
+			HostPath:      path,
+			ContainerPath: "/data",
+			DevType:       "b",
+			Pmem:          true,
+		}
+	}
+
+	// the files emulating pmem are told apart by path, not by number
+	d1, err := dm.NewDevice(pmemInfo("/srv/pmem1.img"))
+	assert.NoError(err)
+	d2, err := dm.NewDevice(pmemInfo("/srv/pmem2.img"))
+	assert.NoError(err)
+	assert.NotEqual(d1.DeviceID(), d2.DeviceID())
+
+	d3, err := dm.NewDevice(pmemInfo("/srv/pmem1.img"))
+	assert.NoError(err)
+	assert.Equal(d1.DeviceID(), d3.DeviceID())
+	assert.Len(dm.devices, 2)
+}
+
+func TestAttachVhostVDPADevice(t *testing.T) {
+	assert := assert.New(t)
+
+	tmpDir := t.TempDir()
+	hookLog := filepath.Join(tmpDir, "hook.log")
+	hook := filepath.Join(tmpDir, "hook")
+	err := os.WriteFile(hook, []byte("#!/bin/sh\necho \"$@\" >> "+hookLog+"\n"), 0755)
+	assert.NoError(err)
+
+	dm := &deviceManager{
+		blockDriver:   config.VirtioBlock,
+		vhostVDPAHook: hook,
+		devices:       make(map[string]api.Device),
+	}
+	path := "/dev/vhost-vdpa-0"
+	deviceInfo := config.DeviceInfo{
+		ContainerPath: path,
+		DevType:       "c",
+		Major:         511,
+	}
+
+	device, err := dm.NewDevice(deviceInfo)
+	assert.NoError(err)
+	_, ok := device.(*drivers.VhostVDPABlkDevice)
+	assert.True(ok)
+
+	devReceiver := &api.MockDeviceReceiver{}
+	err = device.Attach(context.Background(), devReceiver)
+	assert.NoError(err)
+
+	vAttrs, ok := device.GetDeviceInfo().(*config.VhostUserDeviceAttrs)
+	assert.True(ok)
+	assert.Equal(path, vAttrs.SocketPath)
+	assert.Equal(config.DeviceType(config.VhostVDPABlk), vAttrs.Type)
+
+	err = device.Detach(context.Background(), devReceiver)
+	assert.NoError(err)
+
+	data, err := os.ReadFile(hookLog)
+	assert.NoError(err)
+	assert.Equal("attach "+path+"\ndetach "+path+"\n", string(data))
+
+	// A failing attach hook fails the attach
+	dm.vhostVDPAHook = "/bin/false"
+	deviceInfo.ContainerPath = "/dev/vhost-vdpa-1"
+	deviceInfo.Minor = 1
+	device, err = dm.NewDevice(deviceInfo)
+	assert.NoError(err)
+	err = device.Attach(context.Background(), devReceiver)
+	assert.Error(err)
+	assert.Equal(uint(0), device.GetAttachCount())
+}
+
 func TestAttachDetachDevice(t *testing.T) {
-	dm := NewDeviceManager(config.VirtioSCSI, false, "", 0, nil)
+	dm := NewDeviceManager(config.VirtioSCSI, false, "", 0, "", false, nil)
 
 	path := "/dev/hda"
 	deviceInfo := config.DeviceInfo{
@@ -41,6 +41,11 @@ func IsVhostUserBlk(devInfo config.DeviceInfo) bool {
 	return devInfo.DevType == "b" && devInfo.Major == config.VhostUserBlkMajor
 }
 
+// IsVhostVDPA checks if the device is a vhost-vdpa character device.
+func IsVhostVDPA(hostPath string) bool {
+	return strings.HasPrefix(hostPath, config.VhostVDPAPrefix) && len(hostPath) > len(config.VhostVDPAPrefix)
+}
+
 // isVhostUserSCSI checks if the device is a VhostUserSCSI device.
 func isVhostUserSCSI(devInfo config.DeviceInfo) bool {
 	return devInfo.DevType == "b" && devInfo.Major == config.VhostUserSCSIMajor
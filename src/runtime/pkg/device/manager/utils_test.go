@@ -103,3 +103,11 @@ func TestIsVhostUserSCSI(t *testing.T) {
 		assert.Equal(t, d.expected, isVhostUserSCSI)
 	}
 }
+
+func TestIsVhostVDPA(t *testing.T) {
+	assert.True(t, IsVhostVDPA("/dev/vhost-vdpa-0"))
+	assert.True(t, IsVhostVDPA("/dev/vhost-vdpa-12"))
+	assert.False(t, IsVhostVDPA("/dev/vhost-vdpa-"))
+	assert.False(t, IsVhostVDPA("/dev/vhost-net"))
+	assert.False(t, IsVhostVDPA("/dev/vduse/blk0"))
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package multipath finds the device-mapper multipath maps of the host and
+// the paths they are made of. A SAN LUN reachable through several paths
+// shows up as one SCSI or NVMe block device per path, plus the multipath map
+// routing the IO over the paths which are up: the map is what must be
+// attached to a VM, as attaching one of its paths bypasses the failover and
+// races the IO of the map.
+package multipath
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// mpathUUIDPrefix is the prefix of the device-mapper UUID of the maps
+// created by multipathd.
+const mpathUUIDPrefix = "mpath-"
+
+// PathStateRunning is the state of a SCSI path able to carry IO.
+const PathStateRunning = "running"
+
+var (
+	// SysBlockDir is the directory of the block devices in sysfs.
+	SysBlockDir = "/sys/block"
+
+	// SysDevBlockDir is the directory of the block devices by number in
+	// sysfs.
+	SysDevBlockDir = "/sys/dev/block"
+
+	// DevDir is the directory of the device nodes.
+	DevDir = "/dev"
+)
+
+// Map is a device-mapper multipath map.
+type Map struct {
+	// Name is the device-mapper name of the map, e.g. mpatha or its WWID.
+	Name string
+	// Dev is the block device of the map, e.g. dm-3.
+	Dev string
+	// Paths are the block devices of the paths of the map, e.g. sdb, sdc.
+	Paths []string
+}
+
+// DevicePath returns the device node of the map, its /dev/mapper link when
+// there is one, as it is stable across reboots.
+func (m *Map) DevicePath() string {
+	if m.Name != "" {
+		path := filepath.Join(DevDir, "mapper", m.Name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return filepath.Join(DevDir, m.Dev)
+}
+
+func readAttr(dev, attr string) string {
+	data, err := os.ReadFile(filepath.Join(SysBlockDir, dev, attr))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func listDir(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsMap tells if the block device dev is a multipath map.
+func IsMap(dev string) bool {
+	return strings.HasPrefix(readAttr(dev, "dm/uuid"), mpathUUIDPrefix)
+}
+
+// GetMap returns the multipath map of the block device dev, nil when dev is
+// not one.
+func GetMap(dev string) *Map {
+	if !IsMap(dev) {
+		return nil
+	}
+	return &Map{
+		Name:  readAttr(dev, "dm/name"),
+		Dev:   dev,
+		Paths: listDir(filepath.Join(SysBlockDir, dev, "slaves")),
+	}
+}
+
+// MapOfPath returns the multipath map the block device dev is a path of, nil
+// when it is not a path of a map.
+func MapOfPath(dev string) *Map {
+	for _, holder := range listDir(filepath.Join(SysBlockDir, dev, "holders")) {
+		if m := GetMap(holder); m != nil {
+			return m
+		}
+	}
+	return nil
+}
+
+// MapOfPaths returns the multipath map which all of devs are paths of, nil
+// when there is none. It tells the paths of one LUN from distinct devices
+// sharing an identifier.
+func MapOfPaths(devs []string) *Map {
+	var m *Map
+	for _, dev := range devs {
+		pm := MapOfPath(dev)
+		if pm == nil || (m != nil && pm.Dev != m.Dev) {
+			return nil
+		}
+		m = pm
+	}
+	return m
+}
+
+// DeviceName returns the name of the block device major:minor, e.g. sdb.
+func DeviceName(major, minor int64) (string, error) {
+	link := filepath.Join(SysDevBlockDir, fmt.Sprintf("%d:%d", major, minor))
+	path, err := filepath.EvalSymlinks(link)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Base(path), nil
+}
+
+// PathState returns the state of the SCSI path dev, e.g. running, offline or
+// transport-offline, the empty string when dev has no such state as NVMe
+// paths.
+func PathState(dev string) string {
+	return readAttr(dev, "device/state")
+}
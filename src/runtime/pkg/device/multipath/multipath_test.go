@@ -0,0 +1,120 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package multipath
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSysfs fakes the sysfs and /dev of a host with the multipath map
+// mpatha, dm-0, of the paths sdb and sdc, and the plain disk sda.
+func fakeSysfs(t *testing.T) (writeAttr func(path, value string)) {
+	savedSysBlockDir, savedSysDevBlockDir, savedDevDir := SysBlockDir, SysDevBlockDir, DevDir
+	t.Cleanup(func() {
+		SysBlockDir, SysDevBlockDir, DevDir = savedSysBlockDir, savedSysDevBlockDir, savedDevDir
+	})
+
+	root := t.TempDir()
+	SysBlockDir = filepath.Join(root, "sys", "block")
+	SysDevBlockDir = filepath.Join(root, "sys", "dev", "block")
+	DevDir = filepath.Join(root, "dev")
+
+	writeAttr = func(path, value string) {
+		path = filepath.Join(root, path)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, os.WriteFile(path, []byte(value+"\n"), 0644))
+	}
+	link := func(target, path string) {
+		path = filepath.Join(root, path)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, os.Symlink(filepath.Join(root, target), path))
+	}
+
+	writeAttr("sys/block/dm-0/dm/uuid", "mpath-3600a098038304437")
+	writeAttr("sys/block/dm-0/dm/name", "mpatha")
+	for _, path := range []string{"sdb", "sdc"} {
+		writeAttr("sys/block/"+path+"/device/state", PathStateRunning)
+		link("sys/block/"+path, "sys/block/dm-0/slaves/"+path)
+		link("sys/block/dm-0", "sys/block/"+path+"/holders/dm-0")
+	}
+	writeAttr("sys/block/sda/device/state", PathStateRunning)
+	link("sys/block/sdb", "sys/dev/block/8:16")
+	writeAttr("dev/dm-0", "")
+
+	return writeAttr
+}
+
+func TestMaps(t *testing.T) {
+	assert := assert.New(t)
+	writeAttr := fakeSysfs(t)
+
+	assert.True(IsMap("dm-0"))
+	assert.False(IsMap("sdb"))
+
+	m := GetMap("dm-0")
+	assert.NotNil(m)
+	assert.Equal(&Map{Name: "mpatha", Dev: "dm-0", Paths: []string{"sdb", "sdc"}}, m)
+	assert.Nil(GetMap("sda"))
+
+	// the map has no /dev/mapper link yet
+	assert.Equal(filepath.Join(DevDir, "dm-0"), m.DevicePath())
+	writeAttr("dev/mapper/mpatha", "")
+	assert.Equal(filepath.Join(DevDir, "mapper", "mpatha"), m.DevicePath())
+
+	assert.Equal(m, MapOfPath("sdc"))
+	assert.Nil(MapOfPath("sda"))
+	assert.Equal(m, MapOfPaths([]string{"sdb", "sdc"}))
+	assert.Nil(MapOfPaths([]string{"sdb", "sda"}))
+	assert.Nil(MapOfPaths(nil))
+
+	// a holder which is not a multipath map, e.g. an LVM volume
+	writeAttr("sys/block/dm-1/dm/uuid", "LVM-abcdef")
+	assert.NoError(os.MkdirAll(filepath.Join(SysBlockDir, "sda", "holders", "dm-1"), 0755))
+	assert.Nil(MapOfPath("sda"))
+
+	dev, err := DeviceName(8, 16)
+	assert.NoError(err)
+	assert.Equal("sdb", dev)
+	_, err = DeviceName(8, 32)
+	assert.Error(err)
+}
+
+func TestWatcher(t *testing.T) {
+	assert := assert.New(t)
+	writeAttr := fakeSysfs(t)
+
+	w := NewWatcher(time.Minute)
+	w.Add(GetMap("dm-0"))
+
+	// nothing changed
+	w.poll()
+	assert.Len(w.events, 0)
+
+	writeAttr("sys/block/sdb/device/state", "transport-offline")
+	w.poll()
+	assert.Equal(Event{Map: "mpatha", Path: "sdb", State: "transport-offline", ActivePaths: 1, TotalPaths: 2}, <-w.Events())
+	assert.Len(w.events, 0)
+
+	writeAttr("sys/block/sdb/device/state", PathStateRunning)
+	w.poll()
+	assert.Equal(Event{Map: "mpatha", Path: "sdb", State: PathStateRunning, ActivePaths: 2, TotalPaths: 2}, <-w.Events())
+
+	// sdc leaves the map
+	assert.NoError(os.Remove(filepath.Join(SysBlockDir, "dm-0", "slaves", "sdc")))
+	w.poll()
+	assert.Equal(Event{Map: "mpatha", Path: "sdc", State: PathStateRemoved, ActivePaths: 1, TotalPaths: 1}, <-w.Events())
+
+	// the map is flushed
+	assert.NoError(os.RemoveAll(filepath.Join(SysBlockDir, "dm-0")))
+	w.poll()
+	assert.Len(w.events, 0)
+	assert.Empty(w.maps)
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package multipath
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// PathStateRemoved is the state of a path which left its map.
+const PathStateRemoved = "removed"
+
+// eventsBacklog is the number of events kept for a slow consumer, the
+// newer ones are dropped.
+const eventsBacklog = 64
+
+// Event is a change of the state of a path of a multipath map.
+type Event struct {
+	Map         string `json:"map"`
+	Path        string `json:"path"`
+	State       string `json:"state"`
+	ActivePaths int    `json:"active_paths"`
+	TotalPaths  int    `json:"total_paths"`
+}
+
+// pathUp tells if a path in state can carry IO: a running SCSI device, a
+// live NVMe controller, or a device without a state.
+func pathUp(state string) bool {
+	return state == "" || state == PathStateRunning || state == "live"
+}
+
+// Watcher polls the states of the paths of multipath maps, and reports
+// their changes as events.
+type Watcher struct {
+	// maps are the states of the paths of each watched map
+	maps     map[string]map[string]string
+	names    map[string]string
+	events   chan Event
+	interval time.Duration
+	sync.Mutex
+}
+
+// NewWatcher returns a watcher polling the paths every interval.
+func NewWatcher(interval time.Duration) *Watcher {
+	return &Watcher{
+		maps:     make(map[string]map[string]string),
+		names:    make(map[string]string),
+		events:   make(chan Event, eventsBacklog),
+		interval: interval,
+	}
+}
+
+// Add watches the paths of m, from their current state.
+func (w *Watcher) Add(m *Map) {
+	w.Lock()
+	defer w.Unlock()
+
+	if _, ok := w.maps[m.Dev]; ok {
+		return
+	}
+	states := make(map[string]string)
+	for _, path := range m.Paths {
+		states[path] = PathState(path)
+	}
+	w.maps[m.Dev] = states
+	w.names[m.Dev] = m.Name
+	if m.Name == "" {
+		w.names[m.Dev] = m.Dev
+	}
+}
+
+// Events returns the channel of the path events, it is closed when Run
+// returns.
+func (w *Watcher) Events() <-chan Event {
+	return w.events
+}
+
+// Run polls the paths until ctx is done.
+func (w *Watcher) Run(ctx context.Context) {
+	defer close(w.events)
+
+	tick := time.NewTicker(w.interval)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+			w.poll()
+		}
+	}
+}
+
+// poll compares the states of the paths with the previous ones, and sends
+// an event for each path whose state changed.
+func (w *Watcher) poll() {
+	w.Lock()
+	defer w.Unlock()
+
+	for dev, states := range w.maps {
+		m := GetMap(dev)
+		if m == nil {
+			// the map was flushed
+			delete(w.maps, dev)
+			delete(w.names, dev)
+			continue
+		}
+
+		current := make(map[string]string)
+		for _, path := range m.Paths {
+			current[path] = PathState(path)
+		}
+		active := 0
+		for _, state := range current {
+			if pathUp(state) {
+				active++
+			}
+		}
+
+		send := func(path, state string) {
+			event := Event{
+				Map:         w.names[dev],
+				Path:        path,
+				State:       state,
+				ActivePaths: active,
+				TotalPaths:  len(current),
+			}
+			select {
+			case w.events <- event:
+			default:
+			}
+		}
+
+		for path, state := range current {
+			if previous, ok := states[path]; !ok || previous != state {
+				send(path, state)
+			}
+		}
+		for path := range states {
+			if _, ok := current[path]; !ok {
+				send(path, PathStateRemoved)
+			}
+		}
+		w.maps[dev] = current
+	}
+}
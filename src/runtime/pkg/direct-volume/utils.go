@@ -12,11 +12,24 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/blockid"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/connector"
 )
 
 const (
 	mountInfoFileName = "mountInfo.json"
 
+	// PmemVolumeType is the type of the volumes on persistent memory,
+	// mapped as a NVDIMM in the guest and mounted with DAX.
+	PmemVolumeType = "pmem"
+
+	// NfsVolumeType is the type of the NFS exports mounted by the agent
+	// over the network of the pod, the device being the host:/path of the
+	// export.
+	NfsVolumeType = "nfs"
+
 	FSGroupMetadataKey             = "fsGroup"
 	FSGroupChangePolicyMetadataKey = "fsGroupChangePolicy"
 )
@@ -39,6 +52,11 @@ const (
 
 var kataDirectVolumeRootPath = "/run/kata-containers/shared/direct-volumes"
 
+// disconnect tears the storage session of a volume down.
+var disconnect = func(info *connector.Info) error {
+	return info.Disconnect()
+}
+
 // MountInfo contains the information needed by Kata to consume a host block device and mount it as a filesystem inside the guest VM.
 type MountInfo struct {
 	// The type of the volume (ie. block)
@@ -51,6 +69,9 @@ type MountInfo struct {
 	Metadata map[string]string `json:"metadata,omitempty"`
 	// Additional mount options.
 	Options []string `json:"options,omitempty"`
+	// The remote storage session to establish on the host for the device
+	// to appear, the device is then the one of the session.
+	Connector *connector.Info `json:"connector,omitempty"`
 }
 
 // Add writes the mount info of a direct volume into a filesystem path known to Kata Container.
@@ -73,15 +94,118 @@ func Add(volumePath string, mountInfo string) error {
 	if err := json.Unmarshal([]byte(mountInfo), &deserialized); err != nil {
 		return err
 	}
+	if deserialized.VolumeType == NfsVolumeType {
+		if !strings.Contains(deserialized.Device, ":/") {
+			return fmt.Errorf("invalid NFS export %q, expected host:/path", deserialized.Device)
+		}
+	} else if _, _, err := blockid.Parse(deserialized.Device); err != nil {
+		return err
+	}
+	if deserialized.Connector != nil {
+		if err := deserialized.Connector.Validate(); err != nil {
+			return err
+		}
+	}
 
 	return os.WriteFile(filepath.Join(volumeDir, mountInfoFileName), []byte(mountInfo), 0600)
 }
 
-// Remove deletes the direct volume path including all the files inside it.
+// Remove deletes the direct volume path including all the files inside it. The
+// storage session of the volume is torn down first, unless other volumes
+// share it: what the runtime opened for it is then handed over to one of
+// them, to be torn down with the last one.
 func Remove(volumePath string) error {
+	mountInfo, err := VolumeMountInfo(volumePath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if mountInfo != nil && mountInfo.Connector != nil {
+		sharer, err := sessionSharer(volumePath, mountInfo.Connector.Session())
+		if err != nil {
+			return err
+		}
+		if sharer == nil {
+			if err := disconnect(mountInfo.Connector); err != nil {
+				return err
+			}
+		} else if len(mountInfo.Connector.Opened) > 0 {
+			for _, o := range mountInfo.Connector.Opened {
+				if !contains(sharer.info.Connector.Opened, o) {
+					sharer.info.Connector.Opened = append(sharer.info.Connector.Opened, o)
+				}
+			}
+			if err := writeMountInfo(sharer.dir, sharer.info); err != nil {
+				return err
+			}
+		}
+	}
+
 	return os.RemoveAll(filepath.Join(kataDirectVolumeRootPath, b64.URLEncoding.EncodeToString([]byte(volumePath))))
 }
 
+// volumeEntry is the mount info of a direct volume, with its directory.
+type volumeEntry struct {
+	dir  string
+	info *MountInfo
+}
+
+// sessionSharer returns a direct volume other than volumePath which uses
+// the storage session, nil if there is none.
+func sessionSharer(volumePath string, session string) (*volumeEntry, error) {
+	entries, err := os.ReadDir(kataDirectVolumeRootPath)
+	if err != nil {
+		return nil, err
+	}
+
+	encodedPath := b64.URLEncoding.EncodeToString([]byte(volumePath))
+	for _, entry := range entries {
+		if entry.Name() == encodedPath {
+			continue
+		}
+		dir := filepath.Join(kataDirectVolumeRootPath, entry.Name())
+		buf, err := os.ReadFile(filepath.Join(dir, mountInfoFileName))
+		if err != nil {
+			continue
+		}
+		var mountInfo MountInfo
+		if err := json.Unmarshal(buf, &mountInfo); err != nil {
+			continue
+		}
+		if mountInfo.Connector != nil && mountInfo.Connector.Session() == session {
+			return &volumeEntry{dir, &mountInfo}, nil
+		}
+	}
+	return nil, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
+
+func writeMountInfo(volumeDir string, mountInfo *MountInfo) error {
+	buf, err := json.Marshal(mountInfo)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(volumeDir, mountInfoFileName), buf, 0600)
+}
+
+// RecordConnector records the storage session of a direct volume once
+// connected, so that what the runtime opened for it is torn down on Remove.
+func RecordConnector(volumePath string, info *connector.Info) error {
+	mountInfo, err := VolumeMountInfo(volumePath)
+	if err != nil {
+		return err
+	}
+	mountInfo.Connector = info
+	return writeMountInfo(filepath.Join(kataDirectVolumeRootPath, b64.URLEncoding.EncodeToString([]byte(volumePath))), mountInfo)
+}
+
 // VolumeMountInfo retrieves the mount info of a direct volume.
 func VolumeMountInfo(volumePath string) (*MountInfo, error) {
 	mountInfoFilePath := filepath.Join(kataDirectVolumeRootPath, b64.URLEncoding.EncodeToString([]byte(volumePath)), mountInfoFileName)
@@ -13,6 +13,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/connector"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/uuid"
 	"github.com/stretchr/testify/assert"
 )
@@ -54,6 +55,16 @@ func TestAdd(t *testing.T) {
 	assert.True(t, errors.Is(err, os.ErrNotExist))
 	_, err = os.Stat(filepath.Join(kataDirectVolumeRootPath))
 	assert.Nil(t, err)
+
+	// The device may be given by a persistent identifier
+	assert.Nil(t, Add(volumePath, `{"volume-type": "block", "device": "wwid:naa.600a098038304437", "fstype": "ext4"}`))
+	assert.Nil(t, Remove(volumePath))
+	assert.NotNil(t, Add(volumePath, `{"volume-type": "block", "device": "nvme:0000:3b:00.0", "fstype": "ext4"}`))
+
+	// NFS exports are given as host:/path
+	assert.Nil(t, Add(volumePath, `{"volume-type": "nfs", "device": "nfs.example.com:/export", "fstype": "nfs4", "options": ["vers=4.1"]}`))
+	assert.Nil(t, Remove(volumePath))
+	assert.NotNil(t, Add(volumePath, `{"volume-type": "nfs", "device": "/export", "fstype": "nfs4"}`))
 }
 
 func TestRecordSandboxId(t *testing.T) {
@@ -92,3 +103,60 @@ func TestRecordSandboxIdNoMountInfoFile(t *testing.T) {
 	assert.Error(t, err)
 	assert.True(t, errors.Is(err, os.ErrNotExist))
 }
+
+func TestRemoveConnector(t *testing.T) {
+	kataDirectVolumeRootPath = t.TempDir()
+
+	savedDisconnect := disconnect
+	defer func() {
+		disconnect = savedDisconnect
+	}()
+	var disconnected []string
+	var closed []string
+	disconnect = func(info *connector.Info) error {
+		disconnected = append(disconnected, info.Session())
+		closed = append(closed, info.Opened...)
+		return nil
+	}
+
+	// two LUNs of the same target
+	lun := func(n string) string {
+		return `{"volume-type": "block", "fstype": "ext4", "connector": {"type": "iscsi", "params": ` +
+			`{"targetPortal": "10.0.0.1", "iqn": "iqn.2003-01.org:t1", "lun": "` + n + `"}}}`
+	}
+	assert.Nil(t, Add("/a/b/c", lun("0")))
+	assert.Nil(t, Add("/a/b/d", lun("1")))
+	assert.NotNil(t, Add("/a/b/e", `{"volume-type": "block", "fstype": "ext4", "connector": {"type": "iscsi", "params": {"targetPortal": "10.0.0.1"}}}`))
+
+	mountInfo, err := VolumeMountInfo("/a/b/c")
+	assert.Nil(t, err)
+	assert.Equal(t, "0", mountInfo.Connector.Params["lun"])
+	mountInfo, err = VolumeMountInfo("/a/b/d")
+	assert.Nil(t, err)
+	assert.Equal(t, "1", mountInfo.Connector.Params["lun"])
+
+	// the session was opened for the first volume
+	mountInfo, err = VolumeMountInfo("/a/b/c")
+	assert.Nil(t, err)
+	mountInfo.Connector.Opened = []string{"node:10.0.0.1:3260", "session:10.0.0.1:3260"}
+	assert.Nil(t, RecordConnector("/a/b/c", mountInfo.Connector))
+	mountInfo, err = VolumeMountInfo("/a/b/c")
+	assert.Nil(t, err)
+	assert.Equal(t, "block", mountInfo.VolumeType)
+	assert.Len(t, mountInfo.Connector.Opened, 2)
+
+	// the session is torn down with the last volume, which it is handed
+	// over to
+	assert.Nil(t, Remove("/a/b/c"))
+	assert.Empty(t, disconnected)
+	mountInfo, err = VolumeMountInfo("/a/b/d")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"node:10.0.0.1:3260", "session:10.0.0.1:3260"}, mountInfo.Connector.Opened)
+	assert.Nil(t, Remove("/a/b/d"))
+	assert.Equal(t, []string{"iscsi:iqn.2003-01.org:t1@10.0.0.1:3260"}, disconnected)
+	assert.Equal(t, []string{"node:10.0.0.1:3260", "session:10.0.0.1:3260"}, closed)
+
+	// the volume was already removed
+	assert.Nil(t, Remove("/a/b/d"))
+	assert.Len(t, disconnected, 1)
+}
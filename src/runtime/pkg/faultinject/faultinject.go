@@ -0,0 +1,210 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package faultinject injects delays and errors at the boundaries of the
+// runtime, i.e. the hypervisor, the agent RPCs, the device manager and the
+// events sent to the container manager, to test how the orchestration
+// behaves when a sandbox misbehaves.
+//
+// Faults are only injected once they are set, which the shim allows through
+// its monitoring socket when fault injection is enabled in the configuration.
+package faultinject
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Injection points, the agent ones are named after the request, e.g.
+// "agent.CreateContainerRequest", and the event ones after the topic, e.g.
+// "event/tasks/exit".
+const (
+	HypervisorStartVM             = "hypervisor.StartVM"
+	HypervisorStopVM              = "hypervisor.StopVM"
+	HypervisorHotplugAddDevice    = "hypervisor.HotplugAddDevice"
+	HypervisorHotplugRemoveDevice = "hypervisor.HotplugRemoveDevice"
+	DeviceAttach                  = "device.Attach"
+	DeviceDetach                  = "device.Detach"
+
+	agentPointPrefix = "agent."
+	eventPointPrefix = "event"
+)
+
+// Fault describes what happens when an injection point is reached.
+type Fault struct {
+	// Point is the injection point, a trailing "*" matches all the
+	// points with the given prefix, e.g. "agent.*".
+	Point string `json:"point"`
+
+	// Error is the message of the error returned, no error is returned
+	// when it is empty. For events, an error drops the event.
+	Error string `json:"error,omitempty"`
+
+	// DelayMs delays the operation, in milliseconds.
+	DelayMs uint64 `json:"delay_ms,omitempty"`
+
+	// Probability of the fault being triggered when the point is reached,
+	// between 0 and 1. The fault is always triggered when it is 0.
+	Probability float64 `json:"probability,omitempty"`
+
+	// Count is the number of times the fault is triggered before being
+	// removed. It is not removed when it is 0.
+	Count uint64 `json:"count,omitempty"`
+}
+
+// Validate checks the fault is well formed.
+func (f Fault) Validate() error {
+	if f.Point == "" {
+		return fmt.Errorf("missing fault injection point")
+	}
+	if strings.Contains(strings.TrimSuffix(f.Point, "*"), "*") {
+		return fmt.Errorf("invalid fault injection point %q, only a trailing wildcard is supported", f.Point)
+	}
+	if f.Probability < 0 || f.Probability > 1 {
+		return fmt.Errorf("invalid fault probability %v, expecting a value between 0 and 1", f.Probability)
+	}
+	if f.Error == "" && f.DelayMs == 0 {
+		return fmt.Errorf("fault for %q has neither an error nor a delay", f.Point)
+	}
+	return nil
+}
+
+func (f Fault) matches(point string) bool {
+	if prefix := strings.TrimSuffix(f.Point, "*"); prefix != f.Point {
+		return strings.HasPrefix(point, prefix)
+	}
+	return f.Point == point
+}
+
+var (
+	lock   sync.Mutex
+	faults = make(map[string]*Fault)
+	// set when faults are registered, to keep the injection points cheap
+	active atomic.Bool
+	random = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// Set registers a fault, replacing the one set for the same point.
+func Set(f Fault) error {
+	if err := f.Validate(); err != nil {
+		return err
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	faults[f.Point] = &f
+	active.Store(true)
+	return nil
+}
+
+// Clear removes the fault set for point, or all the faults if point is
+// empty.
+func Clear(point string) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	if point == "" {
+		faults = make(map[string]*Fault)
+	} else {
+		delete(faults, point)
+	}
+	active.Store(len(faults) > 0)
+}
+
+// List returns the registered faults, sorted by point.
+func List() []Fault {
+	lock.Lock()
+	defer lock.Unlock()
+
+	list := make([]Fault, 0, len(faults))
+	for _, f := range faults {
+		list = append(list, *f)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Point < list[j].Point
+	})
+	return list
+}
+
+// trigger returns the fault triggered at point, if any. The exact points
+// take precedence over the wildcard ones.
+func trigger(point string) (Fault, bool) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	f, ok := faults[point]
+	if !ok {
+		var prefix string
+		for _, candidate := range faults {
+			// the longest prefix wins
+			if candidate.matches(point) && len(candidate.Point) > len(prefix) {
+				f, prefix = candidate, candidate.Point
+			}
+		}
+		if f == nil {
+			return Fault{}, false
+		}
+	}
+
+	if f.Probability > 0 && random.Float64() >= f.Probability {
+		return Fault{}, false
+	}
+
+	if f.Count > 0 {
+		f.Count--
+		if f.Count == 0 {
+			delete(faults, f.Point)
+			active.Store(len(faults) > 0)
+		}
+	}
+
+	return *f, true
+}
+
+// Inject applies the fault set for point, if any: it waits for its delay,
+// unless ctx is done first, then returns its error.
+func Inject(ctx context.Context, point string) error {
+	if !active.Load() {
+		return nil
+	}
+
+	f, ok := trigger(point)
+	if !ok {
+		return nil
+	}
+
+	if f.DelayMs > 0 {
+		timer := time.NewTimer(time.Duration(f.DelayMs) * time.Millisecond)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if f.Error != "" {
+		return fmt.Errorf("injected fault at %s: %s", point, f.Error)
+	}
+	return nil
+}
+
+// AgentPoint returns the injection point of an agent request, named after
+// its protobuf message name without the package.
+func AgentPoint(msgName string) string {
+	return agentPointPrefix + msgName[strings.LastIndex(msgName, ".")+1:]
+}
+
+// EventPoint returns the injection point of an event topic.
+func EventPoint(topic string) string {
+	return eventPointPrefix + topic
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package faultinject
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFaultValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	data := []struct {
+		fault Fault
+		valid bool
+	}{
+		{Fault{Point: HypervisorStartVM, Error: "boom"}, true},
+		{Fault{Point: "agent.*", DelayMs: 10}, true},
+		{Fault{Point: HypervisorStartVM, Error: "boom", Probability: 0.5, Count: 1}, true},
+		{Fault{Error: "boom"}, false},
+		{Fault{Point: HypervisorStartVM}, false},
+		{Fault{Point: "agent.*Request", Error: "boom"}, false},
+		{Fault{Point: HypervisorStartVM, Error: "boom", Probability: 1.5}, false},
+	}
+
+	for _, d := range data {
+		err := d.fault.Validate()
+		if d.valid {
+			assert.NoError(err, "%+v", d.fault)
+		} else {
+			assert.Error(err, "%+v", d.fault)
+		}
+	}
+}
+
+func TestInject(t *testing.T) {
+	assert := assert.New(t)
+	defer Clear("")
+
+	ctx := context.Background()
+	assert.NoError(Inject(ctx, HypervisorStartVM))
+
+	assert.NoError(Set(Fault{Point: HypervisorStartVM, Error: "boom", Count: 2}))
+	assert.Error(Inject(ctx, HypervisorStartVM))
+	assert.NoError(Inject(ctx, HypervisorStopVM))
+	assert.Error(Inject(ctx, HypervisorStartVM))
+	// removed once triggered count times
+	assert.NoError(Inject(ctx, HypervisorStartVM))
+	assert.Empty(List())
+
+	// the exact points take precedence over the wildcard ones
+	assert.NoError(Set(Fault{Point: "agent.*", Error: "boom"}))
+	assert.NoError(Set(Fault{Point: AgentPoint("grpc.CheckRequest"), DelayMs: 1}))
+	assert.Error(Inject(ctx, AgentPoint("grpc.CreateContainerRequest")))
+	assert.NoError(Inject(ctx, AgentPoint("grpc.CheckRequest")))
+	assert.Len(List(), 2)
+
+	Clear("agent.*")
+	assert.Equal([]Fault{{Point: "agent.CheckRequest", DelayMs: 1}}, List())
+
+	// the delay is bounded by the context
+	assert.NoError(Set(Fault{Point: EventPoint("/tasks/exit"), DelayMs: 60000}))
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(Inject(ctx, "event/tasks/exit"), context.DeadlineExceeded)
+
+	Clear("")
+	assert.Empty(List())
+	assert.False(active.Load())
+}
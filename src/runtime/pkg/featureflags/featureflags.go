@@ -0,0 +1,202 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package featureflags guards the new behaviors of the runtime, so that they
+// can be rolled out across a fleet in stages.
+//
+// The flags are declared in code with their default state. The state of each
+// flag can be set in the configuration file and overridden per node with the
+// KATA_FEATURE_FLAGS environment variable. A state is either "true", "false"
+// or a percentage, e.g. "25%", the flag being then enabled on that share of
+// the nodes, picked by a hash of their hostname.
+package featureflags
+
+import (
+	"fmt"
+	"hash/fnv"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// The feature flags. A flag is only declared for a behavior the runtime
+// implements: pulling the images inside the guest has none, the runtime
+// does not take part in it, so it has no flag.
+const (
+	VirtioMem         = "virtio_mem"
+	PCIeNativeHotplug = "pcie_native_hotplug"
+)
+
+// EnvVar is the environment variable overriding the states of the flags, as
+// a comma separated list of name=state pairs.
+const EnvVar = "KATA_FEATURE_FLAGS"
+
+// Where the state of a flag comes from.
+const (
+	SourceDefault = "default"
+	SourceConfig  = "config"
+	SourceEnv     = "env"
+)
+
+// Flag is a feature flag declared in code.
+type Flag struct {
+	Name        string
+	Description string
+	Default     bool
+}
+
+var flags = []Flag{
+	{
+		Name:        VirtioMem,
+		Description: "Resize the guest memory with virtio-mem when enable_virtio_mem is set",
+		Default:     true,
+	},
+	{
+		Name:        PCIeNativeHotplug,
+		Description: "Hotplug the PCI devices of the q35 machine natively on the PCIe ports, rather than through ACPI, when pci_hotplug_mode is native",
+		Default:     true,
+	},
+}
+
+// State is the state of a flag on the node.
+type State struct {
+	Name        string
+	Description string
+	// Rollout is the configured state, "true", "false" or a percentage
+	// of the nodes.
+	Rollout string
+	// Source tells where Rollout comes from.
+	Source  string
+	Enabled bool
+}
+
+var (
+	lock   sync.RWMutex
+	states = defaultStates()
+)
+
+func defaultStates() map[string]State {
+	states := make(map[string]State, len(flags))
+	for _, f := range flags {
+		states[f.Name] = State{
+			Name:        f.Name,
+			Description: f.Description,
+			Rollout:     strconv.FormatBool(f.Default),
+			Source:      SourceDefault,
+			Enabled:     f.Default,
+		}
+	}
+	return states
+}
+
+// parseRollout tells if the flag is enabled on the node for the given
+// rollout state.
+func parseRollout(name, rollout, node string) (bool, error) {
+	if pct, ok := strings.CutSuffix(rollout, "%"); ok {
+		n, err := strconv.ParseUint(pct, 10, 8)
+		if err != nil || n > 100 {
+			return false, fmt.Errorf("invalid rollout %q for feature flag %s, expecting a percentage between 0%% and 100%%", rollout, name)
+		}
+		h := fnv.New32a()
+		h.Write([]byte(node + "/" + name))
+		return h.Sum32()%100 < uint32(n), nil
+	}
+
+	enabled, err := strconv.ParseBool(rollout)
+	if err != nil {
+		return false, fmt.Errorf("invalid rollout %q for feature flag %s, expecting true, false or a percentage", rollout, name)
+	}
+	return enabled, nil
+}
+
+// parseEnv parses the value of EnvVar.
+func parseEnv(value string) (map[string]string, error) {
+	rollouts := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, rollout, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid %s entry %q, expecting name=state", EnvVar, pair)
+		}
+		rollouts[strings.TrimSpace(name)] = strings.TrimSpace(rollout)
+	}
+	return rollouts, nil
+}
+
+// Configure sets the states of the flags from the configuration, then from
+// the environment. The flags not set keep their default state.
+func Configure(config map[string]string) error {
+	node, err := os.Hostname()
+	if err != nil {
+		return err
+	}
+
+	env, err := parseEnv(os.Getenv(EnvVar))
+	if err != nil {
+		return err
+	}
+
+	return configure(node, config, env)
+}
+
+func configure(node string, config, env map[string]string) error {
+	next := defaultStates()
+	for _, source := range []struct {
+		name     string
+		rollouts map[string]string
+	}{
+		{SourceConfig, config},
+		{SourceEnv, env},
+	} {
+		for name, rollout := range source.rollouts {
+			state, ok := next[name]
+			if !ok {
+				return fmt.Errorf("unknown feature flag %q", name)
+			}
+			enabled, err := parseRollout(name, rollout, node)
+			if err != nil {
+				return err
+			}
+			state.Rollout = rollout
+			state.Source = source.name
+			state.Enabled = enabled
+			next[name] = state
+		}
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	states = next
+	return nil
+}
+
+// Enabled tells if the flag is enabled on the node.
+func Enabled(name string) bool {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	return states[name].Enabled
+}
+
+// List returns the states of the flags, sorted by name.
+func List() []State {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	list := make([]State, 0, len(states))
+	for _, s := range states {
+		list = append(list, s)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package featureflags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRollout(t *testing.T) {
+	assert := assert.New(t)
+
+	data := []struct {
+		rollout string
+		enabled bool
+		valid   bool
+	}{
+		{"true", true, true},
+		{"false", false, true},
+		{"0%", false, true},
+		{"100%", true, true},
+		{"101%", false, false},
+		{"-1%", false, false},
+		{"half", false, false},
+	}
+
+	for _, d := range data {
+		enabled, err := parseRollout(VirtioMem, d.rollout, "node")
+		if d.valid {
+			assert.NoError(err, d.rollout)
+			assert.Equal(d.enabled, enabled, d.rollout)
+		} else {
+			assert.Error(err, d.rollout)
+		}
+	}
+
+	// The share of the nodes a flag is enabled on follows the percentage.
+	enabled := 0
+	for i := 0; i < 1000; i++ {
+		if ok, _ := parseRollout(VirtioMem, "30%", "node"+string(rune('a'+i%26))+string(rune('a'+i/26))); ok {
+			enabled++
+		}
+	}
+	assert.InDelta(300, enabled, 60)
+}
+
+func TestParseEnv(t *testing.T) {
+	assert := assert.New(t)
+
+	rollouts, err := parseEnv(" virtio_mem=false, pcie_native_hotplug = 10% ,")
+	assert.NoError(err)
+	assert.Equal(map[string]string{VirtioMem: "false", PCIeNativeHotplug: "10%"}, rollouts)
+
+	_, err = parseEnv("virtio_mem")
+	assert.Error(err)
+}
+
+func TestConfigure(t *testing.T) {
+	assert := assert.New(t)
+	defer configure("node", nil, nil)
+
+	assert.NoError(configure("node", nil, nil))
+	for _, s := range List() {
+		assert.Equal(SourceDefault, s.Source)
+	}
+	assert.True(Enabled(VirtioMem))
+	assert.True(Enabled(PCIeNativeHotplug))
+
+	assert.NoError(configure("node",
+		map[string]string{VirtioMem: "false", PCIeNativeHotplug: "true"},
+		map[string]string{PCIeNativeHotplug: "0%"}))
+	assert.False(Enabled(VirtioMem))
+	assert.False(Enabled(PCIeNativeHotplug))
+
+	list := List()
+	assert.Len(list, len(flags))
+	assert.Equal(PCIeNativeHotplug, list[0].Name)
+	assert.Equal(SourceEnv, list[0].Source)
+	assert.Equal("0%", list[0].Rollout)
+
+	// The states are left untouched on error.
+	assert.Error(configure("node", map[string]string{"unknown": "true"}, nil))
+	assert.Error(configure("node", map[string]string{VirtioMem: "maybe"}, nil))
+	assert.False(Enabled(VirtioMem))
+}
@@ -60,6 +60,10 @@ const (
 	// NVDIMM is the Non Volatile DIMM device driver.
 	NVDIMM DeviceDriver = "nvdimm"
 
+	// IvshmemPlain is the inter-VM shared memory device driver, without
+	// interrupts.
+	IvshmemPlain DeviceDriver = "ivshmem-plain"
+
 	// VirtioNet is the virtio networking device driver.
 	VirtioNet DeviceDriver = "virtio-net"
 
@@ -90,6 +94,24 @@ const (
 	// VirtioBalloon is the memory balloon device driver.
 	VirtioBalloon DeviceDriver = "virtio-balloon"
 
+	// VirtioGPUGL is the virtio-gpu device driver with 3D acceleration.
+	VirtioGPUGL DeviceDriver = "virtio-gpu-gl"
+
+	// XHCI is the USB 3 host controller device driver.
+	XHCI DeviceDriver = "qemu-xhci"
+
+	// USBHost is the host USB device passthrough driver.
+	USBHost DeviceDriver = "usb-host"
+
+	// TPMCRB is the TPM 2.0 Command Response Buffer interface device driver.
+	TPMCRB DeviceDriver = "tpm-crb"
+
+	// TPMTISDevice is the TPM TIS interface device driver for sysbus machines.
+	TPMTISDevice DeviceDriver = "tpm-tis-device"
+
+	// TPMSpapr is the TPM device driver for pseries machines.
+	TPMSpapr DeviceDriver = "tpm-spapr"
+
 	//VhostUserSCSI represents a SCSI vhostuser device type.
 	VhostUserSCSI DeviceDriver = "vhost-user-scsi"
 
@@ -102,6 +124,9 @@ const (
 	//VhostUserFS represents a virtio-fs vhostuser device type
 	VhostUserFS DeviceDriver = "vhost-user-fs"
 
+	//VhostUserGPU represents a virtio-gpu vhostuser device type
+	VhostUserGPU DeviceDriver = "vhost-user-gpu"
+
 	// PCIBridgeDriver represents a PCI bridge device type.
 	PCIBridgeDriver DeviceDriver = "pci-bridge"
 
@@ -136,6 +161,9 @@ const (
 
 	// SpaprTPMProxy is used for enabling guest to run in secure mode on ppc64le.
 	SpaprTPMProxy DeviceDriver = "spapr-tpm-proxy"
+
+	// SpaprPHB is a PCI host bridge of the pseries machine.
+	SpaprPHB DeviceDriver = "spapr-pci-host-bridge"
 )
 
 func isDimmSupported(config *Config) bool {
@@ -247,6 +275,13 @@ const (
 
 	// PEFGuest represent ppc64le PEF(Protected Execution Facility) object.
 	PEFGuest ObjectType = "pef-guest"
+
+	// RMEGuest represents an Arm CCA Realm (Realm Management Extension) object.
+	RMEGuest ObjectType = "rme-guest"
+
+	// IGVMCfg represents an IGVM (Independent Guest Virtual Machine) file
+	// to load the guest firmware from, instead of the BIOS.
+	IGVMCfg ObjectType = "igvm-cfg"
 )
 
 // Object is a qemu object representation.
@@ -293,8 +328,32 @@ type Object struct {
 	// ReadOnly specifies whether `MemPath` is opened read-only or read/write (default)
 	ReadOnly bool
 
+	// Share specifies whether the writes to `MemPath` are shared with the
+	// other processes mapping it.
+	Share bool
+
 	// Prealloc enables memory preallocation
 	Prealloc bool
+
+	// MeasurementAlgorithm is the hash algorithm measuring the guest.
+	// This is only relevant for rme-guest objects
+	MeasurementAlgorithm string
+
+	// PersonalizationValue is the base64 encoded Realm Personalization
+	// Value. This is only relevant for rme-guest objects
+	PersonalizationValue string
+
+	// MeasurementLog enables the log of the measurements of the guest.
+	// This is only relevant for rme-guest objects
+	MeasurementLog bool
+
+	// HostData is the base64 encoded data the host binds to the attestation
+	// report of the guest. This is only relevant for sev-snp-guest objects
+	HostData string
+
+	// MrConfigID is the base64 encoded ID of the configuration of the guest
+	// in its attestation report. This is only relevant for tdx-guest objects
+	MrConfigID string
 }
 
 // Valid returns true if the Object structure is valid and complete.
@@ -305,15 +364,21 @@ func (object Object) Valid() bool {
 	case MemoryBackendEPC:
 		return object.ID != "" && object.Size != 0
 	case TDXGuest:
-		return object.ID != "" && object.File != "" && object.DeviceID != ""
+		// The firmware file is empty when the firmware is loaded from
+		// an igvm-cfg object.
+		return object.ID != "" && object.DeviceID != ""
 	case SEVGuest:
 		fallthrough
 	case SNPGuest:
-		return object.ID != "" && object.File != "" && object.CBitPos != 0 && object.ReducedPhysBits != 0
+		return object.ID != "" && object.CBitPos != 0 && object.ReducedPhysBits != 0
 	case SecExecGuest:
 		return object.ID != ""
 	case PEFGuest:
 		return object.ID != "" && object.File != ""
+	case RMEGuest:
+		return object.ID != ""
+	case IGVMCfg:
+		return object.ID != "" && object.File != ""
 
 	default:
 		return false
@@ -342,6 +407,9 @@ func (object Object) QemuParams(config *Config) []string {
 			objectParams = append(objectParams, "readonly=on")
 			deviceParams = append(deviceParams, "unarmed=on")
 		}
+		if object.Share {
+			objectParams = append(objectParams, "share=on")
+		}
 	case MemoryBackendEPC:
 		objectParams = append(objectParams, string(object.Type))
 		objectParams = append(objectParams, fmt.Sprintf("id=%s", object.ID))
@@ -357,7 +425,12 @@ func (object Object) QemuParams(config *Config) []string {
 		if object.Debug {
 			objectParams = append(objectParams, "debug=on")
 		}
-		config.Bios = object.File
+		if object.MrConfigID != "" {
+			objectParams = append(objectParams, fmt.Sprintf("mrconfigid=%s", object.MrConfigID))
+		}
+		if object.File != "" {
+			config.Bios = object.File
+		}
 	case SEVGuest:
 		fallthrough
 	case SNPGuest:
@@ -365,9 +438,14 @@ func (object Object) QemuParams(config *Config) []string {
 		objectParams = append(objectParams, fmt.Sprintf("id=%s", object.ID))
 		objectParams = append(objectParams, fmt.Sprintf("cbitpos=%d", object.CBitPos))
 		objectParams = append(objectParams, fmt.Sprintf("reduced-phys-bits=%d", object.ReducedPhysBits))
+		if object.Type == SNPGuest && object.HostData != "" {
+			objectParams = append(objectParams, fmt.Sprintf("host-data=%s", object.HostData))
+		}
 
-		driveParams = append(driveParams, "if=pflash,format=raw,readonly=on")
-		driveParams = append(driveParams, fmt.Sprintf("file=%s", object.File))
+		if object.File != "" {
+			driveParams = append(driveParams, "if=pflash,format=raw,readonly=on")
+			driveParams = append(driveParams, fmt.Sprintf("file=%s", object.File))
+		}
 	case SecExecGuest:
 		objectParams = append(objectParams, string(object.Type))
 		objectParams = append(objectParams, fmt.Sprintf("id=%s", object.ID))
@@ -378,6 +456,22 @@ func (object Object) QemuParams(config *Config) []string {
 		deviceParams = append(deviceParams, string(object.Driver))
 		deviceParams = append(deviceParams, fmt.Sprintf("id=%s", object.DeviceID))
 		deviceParams = append(deviceParams, fmt.Sprintf("host-path=%s", object.File))
+	case RMEGuest:
+		objectParams = append(objectParams, string(object.Type))
+		objectParams = append(objectParams, fmt.Sprintf("id=%s", object.ID))
+		if object.MeasurementAlgorithm != "" {
+			objectParams = append(objectParams, fmt.Sprintf("measurement-algorithm=%s", object.MeasurementAlgorithm))
+		}
+		if object.PersonalizationValue != "" {
+			objectParams = append(objectParams, fmt.Sprintf("personalization-value=%s", object.PersonalizationValue))
+		}
+		if object.MeasurementLog {
+			objectParams = append(objectParams, "measurement-log=on")
+		}
+	case IGVMCfg:
+		objectParams = append(objectParams, string(object.Type))
+		objectParams = append(objectParams, fmt.Sprintf("id=%s", object.ID))
+		objectParams = append(objectParams, fmt.Sprintf("file=%s", object.File))
 	}
 
 	if len(deviceParams) > 0 {
@@ -717,6 +811,9 @@ const (
 
 	// VHOSTUSER is a vhost-user port (socket)
 	VHOSTUSER NetDeviceType = "vhostuser"
+
+	// AFXDP is an AF_XDP socket bound to the queues of a host network interface
+	AFXDP NetDeviceType = "af-xdp"
 )
 
 // QemuNetdevParam converts to the QEMU -netdev parameter notation
@@ -744,6 +841,8 @@ func (n NetDeviceType) QemuNetdevParam(netdev *NetDevice, config *Config) string
 			log.Fatal("vhost-user devices are not supported on IBM Z")
 		}
 		return "vhost-user" // -netdev type=vhost-user (no device)
+	case AFXDP:
+		return "af-xdp" // -netdev type=af-xdp -device virtio-net-pci
 	default:
 		return ""
 
@@ -777,6 +876,8 @@ func (n NetDeviceType) QemuDeviceParam(netdev *NetDevice, config *Config) Device
 			log.Fatal("vhost-user devices are not supported on IBM Z")
 		}
 		return "" // -netdev type=vhost-user (no device)
+	case AFXDP:
+		device = "virtio-net"
 	default:
 		return ""
 	}
@@ -842,6 +943,17 @@ type NetDevice struct {
 
 	// Transport is the virtio transport for this device.
 	Transport VirtioTransport
+
+	// XDPMode is the AF_XDP mode of the interface, native or skb.
+	XDPMode string
+
+	// XDPQueues is the number of interface queues the AF_XDP sockets are
+	// bound to, starting from XDPStartQueue.
+	XDPQueues int
+
+	// XDPStartQueue is the first interface queue the AF_XDP sockets are
+	// bound to.
+	XDPStartQueue int
 }
 
 // VirtioNetTransport is a map of the virtio-net device name that corresponds
@@ -863,11 +975,21 @@ func (netdev NetDevice) Valid() bool {
 		return true
 	case MACVTAP:
 		return true
+	case AFXDP:
+		return true
 	default:
 		return false
 	}
 }
 
+// queues returns the number of queue pairs of the device.
+func (netdev NetDevice) queues() int {
+	if netdev.Type == AFXDP {
+		return netdev.XDPQueues
+	}
+	return len(netdev.FDs)
+}
+
 // mqParameter returns the parameters for multi-queue driver. If the driver is a PCI device then the
 // vector flag is required. If the driver is a CCW type than the vector flag is not implemented and only
 // multi-queue option mq needs to be activated. See comment in libvirt code at
@@ -885,7 +1007,7 @@ func (netdev NetDevice) mqParameter(config *Config) string {
 		// Clearlinux automatically sets up the queues properly
 		// The agent implementation should do this to ensure that it is
 		// always set
-		vectors := len(netdev.FDs)*2 + 2
+		vectors := netdev.queues()*2 + 2
 		p = append(p, fmt.Sprintf("vectors=%d", vectors))
 	}
 
@@ -919,7 +1041,7 @@ func (netdev NetDevice) QemuDeviceParams(config *Config) []string {
 		deviceParams = append(deviceParams, s)
 	}
 
-	if len(netdev.FDs) > 0 {
+	if len(netdev.FDs) > 0 || netdev.XDPQueues > 1 {
 		// Note: We are appending to the device params here
 		deviceParams = append(deviceParams, netdev.mqParameter(config))
 	}
@@ -950,6 +1072,22 @@ func (netdev NetDevice) QemuNetdevParams(config *Config) []string {
 	netdevParams = append(netdevParams, netdevType)
 	netdevParams = append(netdevParams, fmt.Sprintf("id=%s", netdev.ID))
 
+	if netdev.Type == AFXDP {
+		// QEMU creates and binds the sockets, and loads the XDP program
+		// redirecting the packets to them.
+		netdevParams = append(netdevParams, fmt.Sprintf("ifname=%s", netdev.IFName))
+		if netdev.XDPMode != "" {
+			netdevParams = append(netdevParams, fmt.Sprintf("mode=%s", netdev.XDPMode))
+		}
+		if netdev.XDPQueues > 0 {
+			netdevParams = append(netdevParams, fmt.Sprintf("queues=%d", netdev.XDPQueues))
+		}
+		if netdev.XDPStartQueue > 0 {
+			netdevParams = append(netdevParams, fmt.Sprintf("start-queue=%d", netdev.XDPStartQueue))
+		}
+		return netdevParams
+	}
+
 	if netdev.VHost {
 		netdevParams = append(netdevParams, "vhost=on")
 		if len(netdev.VhostFDs) > 0 {
@@ -1296,6 +1434,35 @@ func (dev PVPanicDevice) QemuParams(config *Config) []string {
 	return []string{"-device", "pvpanic"}
 }
 
+// IvshmemDoorbellDevice represents a qemu inter-VM shared memory device
+// with doorbell interrupts, connected to an ivshmem-server.
+type IvshmemDoorbellDevice struct {
+	// ID is the device ID, the ID of its character device being derived
+	// from it.
+	ID string
+
+	// SocketPath is the path of the UNIX socket of the ivshmem-server.
+	SocketPath string
+
+	// Vectors is the number of MSI vectors of the device.
+	Vectors uint32
+}
+
+// Valid returns true if there is a valid structure defined for IvshmemDoorbellDevice
+func (dev IvshmemDoorbellDevice) Valid() bool {
+	return dev.ID != "" && dev.SocketPath != "" && dev.Vectors != 0
+}
+
+// QemuParams returns the qemu parameters built out of this ivshmem device.
+func (dev IvshmemDoorbellDevice) QemuParams(config *Config) []string {
+	chardevID := "char" + dev.ID
+
+	return []string{
+		"-chardev", fmt.Sprintf("socket,id=%s,path=%s", chardevID, dev.SocketPath),
+		"-device", fmt.Sprintf("ivshmem-doorbell,id=%s,chardev=%s,vectors=%d", dev.ID, chardevID, dev.Vectors),
+	}
+}
+
 // LoaderDevice represents a qemu loader device.
 type LoaderDevice struct {
 	File string
@@ -1342,6 +1509,7 @@ type VhostUserDevice struct {
 	CacheSize      uint32 //virtio-fs DAX cache size in MiB
 	QueueSize      uint32 //size of virtqueues
 	SharedVersions bool   //enable virtio-fs shared version metadata
+	Reconnect      uint32 //seconds before reconnecting when the backend goes away
 	VhostUserType  DeviceDriver
 
 	// ROMFile specifies the ROM file being used for this device.
@@ -1386,6 +1554,13 @@ var VhostUserFSTransport = map[VirtioTransport]string{
 	TransportMMIO: "vhost-user-fs-device",
 }
 
+// VhostUserGPUTransport is a map of the vhost-user-gpu device name that
+// corresponds to each transport.
+var VhostUserGPUTransport = map[VirtioTransport]string{
+	TransportPCI:  "vhost-user-gpu-pci",
+	TransportMMIO: "vhost-user-gpu",
+}
+
 // Valid returns true if there is a valid structure defined for VhostUserDevice
 func (vhostuserDev VhostUserDevice) Valid() bool {
 
@@ -1407,6 +1582,7 @@ func (vhostuserDev VhostUserDevice) Valid() bool {
 		if vhostuserDev.Tag == "" {
 			return false
 		}
+	case VhostUserGPU:
 	default:
 		return false
 	}
@@ -1535,6 +1711,31 @@ func (vhostuserDev VhostUserDevice) QemuFSParams(config *Config) []string {
 	return qemuParams
 }
 
+// QemuGPUParams builds QEMU device parameters for a VhostUserGPU device
+func (vhostuserDev VhostUserDevice) QemuGPUParams(config *Config) []string {
+	var qemuParams []string
+	var deviceParams []string
+
+	driver := vhostuserDev.deviceName(config)
+	if driver == "" {
+		return nil
+	}
+
+	deviceParams = append(deviceParams, driver)
+	deviceParams = append(deviceParams, fmt.Sprintf("chardev=%s", vhostuserDev.CharDevID))
+	if vhostuserDev.TypeDevID != "" {
+		deviceParams = append(deviceParams, fmt.Sprintf("id=%s", vhostuserDev.TypeDevID))
+	}
+	if vhostuserDev.Transport.isVirtioPCI(config) && vhostuserDev.ROMFile != "" {
+		deviceParams = append(deviceParams, fmt.Sprintf("romfile=%s", vhostuserDev.ROMFile))
+	}
+
+	qemuParams = append(qemuParams, "-device")
+	qemuParams = append(qemuParams, strings.Join(deviceParams, ","))
+
+	return qemuParams
+}
+
 // QemuParams returns the qemu parameters built out of this vhostuser device.
 func (vhostuserDev VhostUserDevice) QemuParams(config *Config) []string {
 	var qemuParams []string
@@ -1544,6 +1745,9 @@ func (vhostuserDev VhostUserDevice) QemuParams(config *Config) []string {
 	charParams = append(charParams, "socket")
 	charParams = append(charParams, fmt.Sprintf("id=%s", vhostuserDev.CharDevID))
 	charParams = append(charParams, fmt.Sprintf("path=%s", vhostuserDev.SocketPath))
+	if vhostuserDev.Reconnect != 0 {
+		charParams = append(charParams, fmt.Sprintf("reconnect=%d", vhostuserDev.Reconnect))
+	}
 
 	qemuParams = append(qemuParams, "-chardev")
 	qemuParams = append(qemuParams, strings.Join(charParams, ","))
@@ -1557,6 +1761,8 @@ func (vhostuserDev VhostUserDevice) QemuParams(config *Config) []string {
 		deviceParams = vhostuserDev.QemuBlkParams(config)
 	case VhostUserFS:
 		deviceParams = vhostuserDev.QemuFSParams(config)
+	case VhostUserGPU:
+		deviceParams = vhostuserDev.QemuGPUParams(config)
 	default:
 		return nil
 	}
@@ -1584,6 +1790,8 @@ func (vhostuserDev VhostUserDevice) deviceName(config *Config) string {
 		return VhostUserBlkTransport[vhostuserDev.Transport]
 	case VhostUserFS:
 		return VhostUserFSTransport[vhostuserDev.Transport]
+	case VhostUserGPU:
+		return VhostUserGPUTransport[vhostuserDev.Transport]
 	default:
 		return ""
 	}
@@ -1644,6 +1852,8 @@ func (b PCIeRootPortDevice) QemuParams(config *Config) []string {
 		if b.Addr == "" {
 			b.Addr = "0x00"
 		}
+	}
+	if b.Addr != "" {
 		deviceParams = append(deviceParams, fmt.Sprintf("addr=%s", b.Addr))
 	}
 	deviceParams = append(deviceParams, fmt.Sprintf("multifunction=%v", multifunction))
@@ -1727,6 +1937,7 @@ type PCIeSwitchDownstreamPortDevice struct {
 	Bus     string // default is rp0
 	Chassis string // (slot, chassis) pair is mandatory and must be unique for each downstream port, >=0, default is 0x00
 	Slot    string // >=0, default is 0x00
+	Addr    string // address on the bus of the upstream port, assigned by QEMU when empty
 	// This to work needs patches to QEMU
 	BusReserve string
 	// Pref64 and Pref32 are not allowed to be set simultaneously
@@ -1747,6 +1958,9 @@ func (b PCIeSwitchDownstreamPortDevice) QemuParams(config *Config) []string {
 	deviceParams = append(deviceParams, fmt.Sprintf("bus=%s", b.Bus))
 	deviceParams = append(deviceParams, fmt.Sprintf("chassis=%s", b.Chassis))
 	deviceParams = append(deviceParams, fmt.Sprintf("slot=%s", b.Slot))
+	if b.Addr != "" {
+		deviceParams = append(deviceParams, fmt.Sprintf("addr=%s", b.Addr))
+	}
 	if b.BusReserve != "" {
 		deviceParams = append(deviceParams, fmt.Sprintf("bus-reserve=%s", b.BusReserve))
 	}
@@ -1809,6 +2023,10 @@ type VFIODevice struct {
 	// Bus specifies device bus
 	Bus string
 
+	// GPUDirectClique is the GPUDirect peer-to-peer clique of an NVIDIA
+	// GPU, the GPUs of a same clique can do peer-to-peer DMA.
+	GPUDirectClique string
+
 	// Transport is the virtio transport for this device.
 	Transport VirtioTransport
 }
@@ -1844,6 +2062,9 @@ func (vfioDev VFIODevice) QemuParams(config *Config) []string {
 		if vfioDev.ROMFile != "" {
 			deviceParams = append(deviceParams, fmt.Sprintf("romfile=%s", vfioDev.ROMFile))
 		}
+		if vfioDev.GPUDirectClique != "" {
+			deviceParams = append(deviceParams, fmt.Sprintf("x-nv-gpudirect-clique=%s", vfioDev.GPUDirectClique))
+		}
 	}
 
 	if vfioDev.Bus != "" {
@@ -2065,6 +2286,27 @@ func (bridgeDev BridgeDevice) QemuParams(config *Config) []string {
 	return qemuParams
 }
 
+// SpaprPHBDevice represents an extra PCI host bridge (PHB) of a pseries
+// machine. QEMU places the MMIO windows of the PHB from its index, each PHB
+// getting its own 32-bit and 64-bit windows, and its root bus is <ID>.0.
+type SpaprPHBDevice struct {
+	// ID is used to identify the PHB in qemu
+	ID string
+
+	// Index of the PHB, 0 being the one of the machine
+	Index uint32
+}
+
+// Valid returns true if the SpaprPHBDevice structure is valid and complete.
+func (phb SpaprPHBDevice) Valid() bool {
+	return phb.ID != "" && phb.Index != 0
+}
+
+// QemuParams returns the qemu parameters built out of this PHB device.
+func (phb SpaprPHBDevice) QemuParams(config *Config) []string {
+	return []string{"-device", fmt.Sprintf("%s,index=%d,id=%s", SpaprPHB, phb.Index, phb.ID)}
+}
+
 // VSOCKDevice represents a AF_VSOCK socket.
 // nolint: govet
 type VSOCKDevice struct {
@@ -2169,9 +2411,11 @@ type RngDevice struct {
 	ID string
 	// Filename is entropy source on the host
 	Filename string
+	// Builtin uses the entropy source built in QEMU instead of Filename
+	Builtin bool
 	// MaxBytes is the bytes allowed to guest to get from the host’s entropy per period
 	MaxBytes uint
-	// Period is duration of a read period in seconds
+	// Period is duration of a read period in milliseconds
 	Period uint
 	// ROMFile specifies the ROM file being used for this device.
 	ROMFile string
@@ -2203,7 +2447,11 @@ func (v RngDevice) QemuParams(config *Config) []string {
 	//-device virtio-rng-pci,rng=rng0,max-bytes=1024,period=1000
 	var deviceParams []string
 
-	objectParams = append(objectParams, "rng-random")
+	if v.Builtin {
+		objectParams = append(objectParams, "rng-builtin")
+	} else {
+		objectParams = append(objectParams, "rng-random")
+	}
 	objectParams = append(objectParams, "id="+v.ID)
 
 	deviceParams = append(deviceParams, v.deviceName(config))
@@ -2220,7 +2468,7 @@ func (v RngDevice) QemuParams(config *Config) []string {
 		deviceParams = append(deviceParams, fmt.Sprintf("devno=%s", v.DevNo))
 	}
 
-	if v.Filename != "" {
+	if v.Filename != "" && !v.Builtin {
 		objectParams = append(objectParams, "filename="+v.Filename)
 	}
 
@@ -2258,6 +2506,10 @@ type BalloonDevice struct {
 	DisableModern bool
 	ID            string
 
+	// FreePageReporting lets the guest report free pages so that
+	// they can be released back to the host.
+	FreePageReporting bool
+
 	// ROMFile specifies the ROM file being used for this device.
 	ROMFile string
 
@@ -2300,6 +2552,9 @@ func (b BalloonDevice) QemuParams(config *Config) []string {
 	} else {
 		deviceParams = append(deviceParams, "deflate-on-oom=off")
 	}
+	if b.FreePageReporting {
+		deviceParams = append(deviceParams, "free-page-reporting=on")
+	}
 	if s := b.Transport.disableModern(config, b.DisableModern); s != "" {
 		deviceParams = append(deviceParams, s)
 	}
@@ -2324,6 +2579,172 @@ func (b BalloonDevice) deviceName(config *Config) string {
 	return BalloonDeviceTransport[b.Transport]
 }
 
+// VirtioGPUDevice represents a virtio-gpu device rendering with the host GPU.
+type VirtioGPUDevice struct {
+	// ID is the device ID
+	ID string
+
+	// HostMem is the size of the host visible memory region, e.g. "4G".
+	HostMem string
+
+	// Transport is the virtio transport for this device.
+	Transport VirtioTransport
+
+	// Venus enables the Vulkan context type.
+	Venus bool
+
+	// DRMNativeContext enables the native DRM context type (vDRM).
+	DRMNativeContext bool
+}
+
+// VirtioGPUDeviceTransport is a map of the virtio-gpu-gl device name that
+// corresponds to each transport.
+var VirtioGPUDeviceTransport = map[VirtioTransport]string{
+	TransportPCI:  "virtio-gpu-gl-pci",
+	TransportMMIO: "virtio-gpu-gl-device",
+}
+
+// Valid returns true if the VirtioGPUDevice structure is valid and complete.
+func (g VirtioGPUDevice) Valid() bool {
+	return g.ID != ""
+}
+
+// QemuParams returns the qemu parameters built out of the VirtioGPUDevice.
+func (g VirtioGPUDevice) QemuParams(config *Config) []string {
+	var deviceParams []string
+
+	deviceParams = append(deviceParams, g.deviceName(config))
+	deviceParams = append(deviceParams, fmt.Sprintf("id=%s", g.ID))
+
+	// Both context types map host allocated resources into the guest.
+	if g.Venus || g.DRMNativeContext {
+		deviceParams = append(deviceParams, "blob=true")
+	}
+	if g.HostMem != "" {
+		deviceParams = append(deviceParams, fmt.Sprintf("hostmem=%s", g.HostMem))
+	}
+	if g.Venus {
+		deviceParams = append(deviceParams, "venus=true")
+	}
+	if g.DRMNativeContext {
+		deviceParams = append(deviceParams, "drm_native_context=on")
+	}
+
+	return []string{"-device", strings.Join(deviceParams, ",")}
+}
+
+// deviceName returns the QEMU device name for the current combination of
+// driver and transport.
+func (g VirtioGPUDevice) deviceName(config *Config) string {
+	if g.Transport == "" {
+		g.Transport = g.Transport.defaultTransport(config)
+	}
+
+	return VirtioGPUDeviceTransport[g.Transport]
+}
+
+// XHCIDevice represents a USB 3 host controller.
+type XHCIDevice struct {
+	// ID is the device ID
+	ID string
+}
+
+// Valid returns true if the XHCIDevice structure is valid and complete.
+func (x XHCIDevice) Valid() bool {
+	return x.ID != ""
+}
+
+// QemuParams returns the qemu parameters built out of the XHCIDevice.
+func (x XHCIDevice) QemuParams(config *Config) []string {
+	return []string{"-device", fmt.Sprintf("%s,id=%s", XHCI, x.ID)}
+}
+
+// USBHostDevice represents a host USB device passed through to the guest.
+// The device is either identified by its vendor and product IDs, or by the
+// host bus and port it is plugged in.
+type USBHostDevice struct {
+	// ID is the device ID
+	ID string
+
+	// Bus is the USB bus of the controller the device is attached to,
+	// e.g. xhci0.0
+	Bus string
+
+	// VendorID is the USB vendor ID, e.g. 0x18d1
+	VendorID string
+
+	// ProductID is the USB product ID, e.g. 0x4ee7
+	ProductID string
+
+	// HostBus is the host USB bus number
+	HostBus string
+
+	// HostPort is the host USB port path, e.g. 1.2
+	HostPort string
+}
+
+// Valid returns true if the USBHostDevice structure is valid and complete.
+func (u USBHostDevice) Valid() bool {
+	if u.ID == "" {
+		return false
+	}
+
+	return (u.VendorID != "" && u.ProductID != "") || (u.HostBus != "" && u.HostPort != "")
+}
+
+// QemuParams returns the qemu parameters built out of the USBHostDevice.
+func (u USBHostDevice) QemuParams(config *Config) []string {
+	var deviceParams []string
+
+	deviceParams = append(deviceParams, string(USBHost))
+	deviceParams = append(deviceParams, fmt.Sprintf("id=%s", u.ID))
+	if u.Bus != "" {
+		deviceParams = append(deviceParams, fmt.Sprintf("bus=%s", u.Bus))
+	}
+
+	if u.VendorID != "" {
+		deviceParams = append(deviceParams, fmt.Sprintf("vendorid=%s", u.VendorID))
+		deviceParams = append(deviceParams, fmt.Sprintf("productid=%s", u.ProductID))
+	} else {
+		deviceParams = append(deviceParams, fmt.Sprintf("hostbus=%s", u.HostBus))
+		deviceParams = append(deviceParams, fmt.Sprintf("hostport=%s", u.HostPort))
+	}
+
+	return []string{"-device", strings.Join(deviceParams, ",")}
+}
+
+// TPMDevice represents a TPM backed by a TPM emulator, e.g. swtpm, serving
+// its control channel on a UNIX socket.
+type TPMDevice struct {
+	// ID is the tpmdev ID
+	ID string
+
+	// CharDevID is the ID of the chardev connected to the emulator
+	CharDevID string
+
+	// SocketPath is the control channel socket of the emulator
+	SocketPath string
+
+	// Driver is the TPM interface device driver
+	Driver DeviceDriver
+}
+
+// Valid returns true if the TPMDevice structure is valid and complete.
+func (t TPMDevice) Valid() bool {
+	return t.ID != "" && t.CharDevID != "" && t.SocketPath != "" && t.Driver != ""
+}
+
+// QemuParams returns the qemu parameters built out of the TPMDevice.
+func (t TPMDevice) QemuParams(config *Config) []string {
+	var qemuParams []string
+
+	qemuParams = append(qemuParams, "-chardev", fmt.Sprintf("socket,id=%s,path=%s", t.CharDevID, t.SocketPath))
+	qemuParams = append(qemuParams, "-tpmdev", fmt.Sprintf("emulator,id=%s,chardev=%s", t.ID, t.CharDevID))
+	qemuParams = append(qemuParams, "-device", fmt.Sprintf("%s,tpmdev=%s", t.Driver, t.ID))
+
+	return qemuParams
+}
+
 // IommuDev represents a Intel IOMMU Device
 type IommuDev struct {
 	Intremap    bool
@@ -2610,6 +3031,13 @@ type Knobs struct {
 	// MemShared will set the memory device as shared.
 	MemShared bool
 
+	// MemFD backs the guest memory with an anonymous memfd, as required
+	// to share it with the host GPU.
+	MemFD bool
+
+	// MemNoMerge will exclude the memory device from KSM merging.
+	MemNoMerge bool
+
 	// Mlock will control locking of memory
 	Mlock bool
 
@@ -2697,6 +3125,10 @@ type Config struct {
 	// VGA is the qemu VGA mode.
 	VGA string
 
+	// Display is the qemu display type. It cannot be combined with
+	// Knobs.NoGraphic.
+	Display string
+
 	// Kernel is the guest kernel configuration.
 	Kernel Kernel
 
@@ -2709,6 +3141,9 @@ type Config struct {
 	// GlobalParam is the -global parameter.
 	GlobalParam string
 
+	// GlobalParams are additional -global parameters.
+	GlobalParams []string
+
 	// Knobs is a set of qemu boolean settings.
 	Knobs Knobs
 
@@ -2732,6 +3167,9 @@ type Config struct {
 	// PidFile is the -pidfile parameter
 	PidFile string
 
+	// ExtraParams are raw parameters appended after all the others.
+	ExtraParams []string
+
 	qemuParams []string
 }
 
@@ -2767,6 +3205,10 @@ func (config *Config) appendSeccompSandbox() {
 	}
 }
 
+func (config *Config) appendExtraParams() {
+	config.qemuParams = append(config.qemuParams, config.ExtraParams...)
+}
+
 func (config *Config) appendName() {
 	if config.Name != "" {
 		config.qemuParams = append(config.qemuParams, "-name")
@@ -2930,6 +3372,11 @@ func (config *Config) appendGlobalParam() {
 		config.qemuParams = append(config.qemuParams, "-global")
 		config.qemuParams = append(config.qemuParams, config.GlobalParam)
 	}
+
+	for _, param := range config.GlobalParams {
+		config.qemuParams = append(config.qemuParams, "-global")
+		config.qemuParams = append(config.qemuParams, param)
+	}
 }
 
 func (config *Config) appendPFlashParam() {
@@ -2946,6 +3393,13 @@ func (config *Config) appendVGA() {
 	}
 }
 
+func (config *Config) appendDisplay() {
+	if config.Display != "" {
+		config.qemuParams = append(config.qemuParams, "-display")
+		config.qemuParams = append(config.qemuParams, config.Display)
+	}
+}
+
 func (config *Config) appendKernel() {
 	if config.Kernel.Path != "" {
 		config.qemuParams = append(config.qemuParams, "-kernel")
@@ -2972,6 +3426,9 @@ func (config *Config) appendMemoryKnobs() {
 	if config.Knobs.HugePages {
 		objMemParam = "memory-backend-file,id=" + dimmName + ",size=" + config.Memory.Size + ",mem-path=/dev/hugepages"
 		numaMemParam = "node,memdev=" + dimmName
+	} else if config.Knobs.MemFD {
+		objMemParam = "memory-backend-memfd,id=" + dimmName + ",size=" + config.Memory.Size
+		numaMemParam = "node,memdev=" + dimmName
 	} else if config.Knobs.FileBackedMem && config.Memory.Path != "" {
 		objMemParam = "memory-backend-file,id=" + dimmName + ",size=" + config.Memory.Size + ",mem-path=" + config.Memory.Path
 		numaMemParam = "node,memdev=" + dimmName
@@ -2986,6 +3443,9 @@ func (config *Config) appendMemoryKnobs() {
 	if config.Knobs.MemPrealloc {
 		objMemParam += ",prealloc=on"
 	}
+	if config.Knobs.MemNoMerge {
+		objMemParam += ",merge=off"
+	}
 	config.qemuParams = append(config.qemuParams, "-object")
 	config.qemuParams = append(config.qemuParams, objMemParam)
 
@@ -3106,6 +3566,7 @@ func LaunchQemu(config Config, logger QMPLog) (*exec.Cmd, io.ReadCloser, error)
 	config.appendGlobalParam()
 	config.appendPFlashParam()
 	config.appendVGA()
+	config.appendDisplay()
 	config.appendKnobs()
 	config.appendKernel()
 	config.appendBios()
@@ -3119,6 +3580,8 @@ func LaunchQemu(config Config, logger QMPLog) (*exec.Cmd, io.ReadCloser, error)
 		return nil, nil, err
 	}
 
+	config.appendExtraParams()
+
 	ctx := config.Ctx
 	if ctx == nil {
 		ctx = context.Background()
@@ -13,18 +13,23 @@ var (
 	deviceFSString                 = "-device virtio-9p-pci,disable-modern=true,fsdev=workload9p,mount_tag=rootfs,romfile=efi-virtio.rom -fsdev local,id=workload9p,path=/var/lib/docker/devicemapper/mnt/e31ebda2,security_model=none,multidevs=remap"
 	deviceNetworkString            = "-netdev tap,id=tap0,vhost=on,ifname=ceth0,downscript=no,script=no -device driver=virtio-net-pci,netdev=tap0,mac=01:02:de:ad:be:ef,bus=/pci-bus/pcie.0,addr=ff,disable-modern=true,romfile=efi-virtio.rom"
 	deviceNetworkStringMq          = "-netdev tap,id=tap0,vhost=on,fds=3:4 -device driver=virtio-net-pci,netdev=tap0,mac=01:02:de:ad:be:ef,bus=/pci-bus/pcie.0,addr=ff,disable-modern=true,mq=on,vectors=6,romfile=efi-virtio.rom"
+	deviceNetworkStringAFXDP       = "-netdev af-xdp,id=xdp0,ifname=eth0,mode=native,queues=2,start-queue=4 -device driver=virtio-net-pci,netdev=xdp0,mac=01:02:de:ad:be:ef,bus=/pci-bus/pcie.0,addr=ff,disable-modern=true,mq=on,vectors=6,romfile=efi-virtio.rom"
 	deviceSerialString             = "-device virtio-serial-pci,disable-modern=true,id=serial0,romfile=efi-virtio.rom,max_ports=2"
 	deviceVhostUserNetString       = "-chardev socket,id=char1,path=/tmp/nonexistentsocket.socket -netdev type=vhost-user,id=net1,chardev=char1,vhostforce -device virtio-net-pci,netdev=net1,mac=00:11:22:33:44:55,romfile=efi-virtio.rom"
 	deviceVSOCKString              = "-device vhost-vsock-pci,disable-modern=true,id=vhost-vsock-pci0,guest-cid=4,romfile=efi-virtio.rom"
 	deviceVFIOString               = "-device vfio-pci,host=02:10.0,x-pci-vendor-id=0x1234,x-pci-device-id=0x5678,romfile=efi-virtio.rom"
 	devicePCIeRootPortSimpleString = "-device pcie-root-port,id=rp1,bus=pcie.0,chassis=0x00,slot=0x00,multifunction=off"
 	devicePCIeRootPortFullString   = "-device pcie-root-port,id=rp2,bus=pcie.0,chassis=0x0,slot=0x1,addr=0x2,multifunction=on,bus-reserve=0x3,pref64-reserve=16G,mem-reserve=1G,io-reserve=512M,romfile=efi-virtio.rom"
+	devicePCIeRootPortAddrString   = "-device pcie-root-port,id=rp3,bus=pcie.0,chassis=0x00,slot=0x00,addr=0x1e,multifunction=off"
+	devicePCIeDownstreamPortString = "-device xio3130-downstream,id=swdp0,bus=swup0,chassis=1,slot=0,addr=0x00"
 	deviceVFIOPCIeSimpleString     = "-device vfio-pci,host=02:00.0,bus=rp0"
 	deviceVFIOPCIeFullString       = "-device vfio-pci,host=02:00.0,x-pci-vendor-id=0x10de,x-pci-device-id=0x15f8,romfile=efi-virtio.rom,bus=rp1"
+	deviceVFIOPCIeCliqueString     = "-device vfio-pci,host=03:00.0,x-nv-gpudirect-clique=1,bus=swdp0"
 	deviceSCSIControllerStr        = "-device virtio-scsi-pci,id=foo,disable-modern=false,romfile=efi-virtio.rom"
 	deviceSCSIControllerBusAddrStr = "-device virtio-scsi-pci,id=foo,bus=pci.0,addr=00:04.0,disable-modern=true,iothread=iothread1,romfile=efi-virtio.rom"
 	deviceVhostUserSCSIString      = "-chardev socket,id=char1,path=/tmp/nonexistentsocket.socket -device vhost-user-scsi-pci,id=scsi1,chardev=char1,romfile=efi-virtio.rom"
 	deviceVhostUserBlkString       = "-chardev socket,id=char2,path=/tmp/nonexistentsocket.socket -device vhost-user-blk-pci,logical_block_size=4096,size=512M,chardev=char2,romfile=efi-virtio.rom"
+	deviceVhostUserGPUString       = "-chardev socket,id=char3,path=/tmp/nonexistentsocket.socket,reconnect=1 -device vhost-user-gpu-pci,chardev=char3,id=gpu0,romfile=efi-virtio.rom"
 	deviceBlockString              = "-device virtio-blk-pci,disable-modern=true,drive=hd0,scsi=off,config-wce=off,romfile=efi-virtio.rom,share-rw=on,serial=hd0 -drive id=hd0,file=/var/lib/vm.img,aio=threads,format=qcow2,if=none,readonly=on"
 	devicePCIBridgeString          = "-device pci-bridge,bus=/pci-bus/pcie.0,id=mybridge,chassis_nr=5,shpc=on,addr=ff,romfile=efi-virtio.rom"
 	devicePCIBridgeStringReserved  = "-device pci-bridge,bus=/pci-bus/pcie.0,id=mybridge,chassis_nr=5,shpc=off,addr=ff,romfile=efi-virtio.rom,io-reserve=4k,mem-reserve=1m,pref64-reserve=1m"
@@ -63,6 +68,16 @@ func TestAppendDeviceVhostUser(t *testing.T) {
 		ROMFile:       romfile,
 	}
 	testAppend(vhostuserNetDevice, deviceVhostUserNetString, t)
+
+	vhostuserGPUDevice := VhostUserDevice{
+		SocketPath:    "/tmp/nonexistentsocket.socket",
+		CharDevID:     "char3",
+		TypeDevID:     "gpu0",
+		Reconnect:     1,
+		VhostUserType: VhostUserGPU,
+		ROMFile:       romfile,
+	}
+	testAppend(vhostuserGPUDevice, deviceVhostUserGPUString, t)
 }
 
 func TestAppendVirtioBalloon(t *testing.T) {
@@ -88,6 +103,8 @@ func TestAppendVirtioBalloon(t *testing.T) {
 	balloonDevice.DisableModern = true
 	testAppend(balloonDevice, deviceString+OnDeflateOnOMM+OnDisableModern, t)
 
+	balloonDevice.FreePageReporting = true
+	testAppend(balloonDevice, deviceString+OnDeflateOnOMM+",free-page-reporting=on"+OnDisableModern, t)
 }
 
 func TestAppendPCIBridgeDevice(t *testing.T) {
@@ -185,6 +202,25 @@ func TestAppendDevicePCIeRootPort(t *testing.T) {
 		t.Fatalf("failed to validate for %v", pcieRootPortID)
 	}
 	testAppend(pcieRootPortDevice, devicePCIeRootPortFullString, t)
+
+	// single function port at a given address
+	pcieRootPortID = "rp3"
+	pcieRootPortDevice = PCIeRootPortDevice{
+		ID:   pcieRootPortID,
+		Addr: "0x1e",
+	}
+	testAppend(pcieRootPortDevice, devicePCIeRootPortAddrString, t)
+}
+
+func TestAppendDevicePCIeSwitchDownstreamPort(t *testing.T) {
+	downstreamPort := PCIeSwitchDownstreamPortDevice{
+		ID:      "swdp0",
+		Bus:     "swup0",
+		Chassis: "1",
+		Slot:    "0",
+		Addr:    "0x00",
+	}
+	testAppend(downstreamPort, devicePCIeDownstreamPortString, t)
 }
 
 func TestAppendDeviceVFIOPCIe(t *testing.T) {
@@ -206,4 +242,12 @@ func TestAppendDeviceVFIOPCIe(t *testing.T) {
 		DeviceID: "0x15f8",
 	}
 	testAppend(vfioDevice, deviceVFIOPCIeFullString, t)
+
+	// GPUDirect clique test
+	vfioDevice = VFIODevice{
+		BDF:             "03:00.0",
+		Bus:             "swdp0",
+		GPUDirectClique: "1",
+	}
+	testAppend(vfioDevice, deviceVFIOPCIeCliqueString, t)
 }
@@ -127,6 +127,51 @@ func TestAppendDeviceNVDIMM(t *testing.T) {
 	testAppend(object, deviceNVDIMMString, t)
 }
 
+var deviceIvshmemPlainString = "-device ivshmem-plain,id=shm0,memdev=shmmem0 -object memory-backend-file,id=shmmem0,mem-path=/dev/shm/segment,size=1048576,share=on"
+
+func TestAppendDeviceIvshmemPlain(t *testing.T) {
+	object := Object{
+		Driver:   IvshmemPlain,
+		Type:     MemoryBackendFile,
+		DeviceID: "shm0",
+		ID:       "shmmem0",
+		MemPath:  "/dev/shm/segment",
+		Size:     1 << 20,
+		Share:    true,
+	}
+
+	testAppend(object, deviceIvshmemPlainString, t)
+}
+
+var deviceSpaprPHBString = "-device spapr-pci-host-bridge,index=1,id=phb1"
+
+func TestAppendDeviceSpaprPHB(t *testing.T) {
+	phb := SpaprPHBDevice{
+		ID:    "phb1",
+		Index: 1,
+	}
+
+	testAppend(phb, deviceSpaprPHBString, t)
+
+	// The PHB of the machine cannot be added
+	phb.Index = 0
+	if phb.Valid() {
+		t.Fatalf("PHB %+v should be invalid", phb)
+	}
+}
+
+var deviceIvshmemDoorbellString = "-chardev socket,id=charshmch0,path=/run/vc/shm-channels/0123.sock -device ivshmem-doorbell,id=shmch0,chardev=charshmch0,vectors=1"
+
+func TestAppendDeviceIvshmemDoorbell(t *testing.T) {
+	dev := IvshmemDoorbellDevice{
+		ID:         "shmch0",
+		SocketPath: "/run/vc/shm-channels/0123.sock",
+		Vectors:    1,
+	}
+
+	testAppend(dev, deviceIvshmemDoorbellString, t)
+}
+
 var objectEPCString = "-object memory-backend-epc,id=epc0,size=65536,prealloc=on"
 
 func TestAppendEPCObject(t *testing.T) {
@@ -140,6 +185,60 @@ func TestAppendEPCObject(t *testing.T) {
 	testAppend(object, objectEPCString, t)
 }
 
+var objectRMEString = "-object rme-guest,id=rme0,measurement-algorithm=sha512,personalization-value=cnB2,measurement-log=on"
+
+func TestAppendRMEObject(t *testing.T) {
+	object := Object{
+		Type:                 RMEGuest,
+		ID:                   "rme0",
+		MeasurementAlgorithm: "sha512",
+		PersonalizationValue: "cnB2",
+		MeasurementLog:       true,
+	}
+
+	testAppend(object, objectRMEString, t)
+}
+
+var objectIGVMString = "-object igvm-cfg,id=igvm0,file=/usr/share/igvm/coconut-qemu.igvm"
+
+func TestAppendIGVMObject(t *testing.T) {
+	object := Object{
+		Type: IGVMCfg,
+		ID:   "igvm0",
+		File: "/usr/share/igvm/coconut-qemu.igvm",
+	}
+
+	testAppend(object, objectIGVMString, t)
+}
+
+// The firmware of SNP guests is not loaded from the pflash with IGVM
+var objectSNPIGVMString = "-object sev-snp-guest,id=snp,cbitpos=51,reduced-phys-bits=1"
+
+func TestAppendSNPObjectIGVM(t *testing.T) {
+	object := Object{
+		Type:            SNPGuest,
+		ID:              "snp",
+		CBitPos:         51,
+		ReducedPhysBits: 1,
+	}
+
+	testAppend(object, objectSNPIGVMString, t)
+}
+
+var objectSNPHostDataString = "-object sev-snp-guest,id=snp,cbitpos=51,reduced-phys-bits=1,host-data=aG9zdA=="
+
+func TestAppendSNPObjectHostData(t *testing.T) {
+	object := Object{
+		Type:            SNPGuest,
+		ID:              "snp",
+		CBitPos:         51,
+		ReducedPhysBits: 1,
+		HostData:        "aG9zdA==",
+	}
+
+	testAppend(object, objectSNPHostDataString, t)
+}
+
 func TestAppendDeviceFS(t *testing.T) {
 	fsdev := FSDevice{
 		Driver:        Virtio9P,
@@ -219,6 +318,30 @@ func TestAppendDeviceNetworkMq(t *testing.T) {
 	testAppend(netdev, deviceNetworkStringMq, t)
 }
 
+func TestAppendDeviceNetworkAFXDP(t *testing.T) {
+	netdev := NetDevice{
+		Driver:        VirtioNet,
+		Type:          AFXDP,
+		ID:            "xdp0",
+		IFName:        "eth0",
+		XDPMode:       "native",
+		XDPQueues:     2,
+		XDPStartQueue: 4,
+		MACAddress:    "01:02:de:ad:be:ef",
+		DisableModern: true,
+		ROMFile:       romfile,
+	}
+
+	if netdev.Transport.isVirtioPCI(nil) {
+		netdev.Bus = "/pci-bus/pcie.0"
+		netdev.Addr = "255"
+	} else if netdev.Transport.isVirtioCCW(nil) {
+		netdev.DevNo = DevNo
+	}
+
+	testAppend(netdev, deviceNetworkStringAFXDP, t)
+}
+
 var deviceLegacySerialString = "-serial chardev:tlserial0"
 
 func TestAppendLegacySerial(t *testing.T) {
@@ -386,6 +509,10 @@ func TestAppendVirtioRng(t *testing.T) {
 	deviceString += fmt.Sprintf(",period=%d", rngDevice.Period)
 	testAppend(rngDevice, objectString+" "+deviceString, t)
 
+	rngDevice.Builtin = true
+
+	objectString = "-object rng-builtin,id=rng0"
+	testAppend(rngDevice, objectString+" "+deviceString, t)
 }
 
 func TestVirtioRngValid(t *testing.T) {
@@ -404,6 +531,61 @@ func TestVirtioRngValid(t *testing.T) {
 
 }
 
+func TestAppendVirtioGPU(t *testing.T) {
+	gpu := VirtioGPUDevice{
+		ID: "gpu0",
+	}
+	deviceString := "-device " + gpu.deviceName(nil) + ",id=gpu0"
+	testAppend(gpu, deviceString, t)
+
+	gpu.Venus = true
+	gpu.HostMem = "4G"
+	testAppend(gpu, deviceString+",blob=true,hostmem=4G,venus=true", t)
+
+	gpu.Venus = false
+	gpu.DRMNativeContext = true
+	testAppend(gpu, deviceString+",blob=true,hostmem=4G,drm_native_context=on", t)
+}
+
+func TestAppendUSBHost(t *testing.T) {
+	testAppend(XHCIDevice{ID: "xhci0"}, "-device qemu-xhci,id=xhci0", t)
+
+	usb := USBHostDevice{
+		ID:        "usb0",
+		Bus:       "xhci0.0",
+		VendorID:  "0x18d1",
+		ProductID: "0x4ee7",
+	}
+	testAppend(usb, "-device usb-host,id=usb0,bus=xhci0.0,vendorid=0x18d1,productid=0x4ee7", t)
+
+	usb = USBHostDevice{
+		ID:       "usb1",
+		HostBus:  "1",
+		HostPort: "1.2",
+	}
+	testAppend(usb, "-device usb-host,id=usb1,hostbus=1,hostport=1.2", t)
+
+	usb.HostPort = ""
+	if usb.Valid() {
+		t.Fatalf("usb-host should be not valid without host port")
+	}
+}
+
+func TestAppendTPM(t *testing.T) {
+	tpm := TPMDevice{
+		ID:         "tpm0",
+		CharDevID:  "chrtpm0",
+		SocketPath: "/run/vc/vm/foo/swtpm.sock",
+		Driver:     TPMCRB,
+	}
+	testAppend(tpm, "-chardev socket,id=chrtpm0,path=/run/vc/vm/foo/swtpm.sock -tpmdev emulator,id=tpm0,chardev=chrtpm0 -device tpm-crb,tpmdev=tpm0", t)
+
+	tpm.SocketPath = ""
+	if tpm.Valid() {
+		t.Fatalf("tpm should be not valid without socket path")
+	}
+}
+
 func TestVirtioBalloonValid(t *testing.T) {
 	balloon := BalloonDevice{
 		ID: "",
@@ -541,6 +723,35 @@ func TestAppendMemoryMemPrealloc(t *testing.T) {
 	testConfigAppend(conf, knobs, memString+" "+knobsString, t)
 }
 
+func TestAppendMemoryMemNoMerge(t *testing.T) {
+	conf := &Config{
+		Memory: Memory{
+			Size:   "1G",
+			Slots:  8,
+			MaxMem: "3G",
+			Path:   "foobar",
+		},
+	}
+	memString := "-m 1G,slots=8,maxmem=3G"
+	testConfigAppend(conf, conf.Memory, memString, t)
+
+	knobs := Knobs{
+		MemNoMerge: true,
+	}
+	objMemString := "-object memory-backend-ram,id=dimm1,size=1G,merge=off"
+	numaMemString := "-numa node,memdev=dimm1"
+	memBackendString := "-machine memory-backend=dimm1"
+
+	knobsString := objMemString + " "
+	if isDimmSupported(nil) {
+		knobsString += numaMemString
+	} else {
+		knobsString += memBackendString
+	}
+
+	testConfigAppend(conf, knobs, memString+" "+knobsString, t)
+}
+
 func TestAppendMemoryMemShared(t *testing.T) {
 	conf := &Config{
 		Memory: Memory{
@@ -632,6 +843,37 @@ func TestAppendMemoryFileBackedMemPrealloc(t *testing.T) {
 	testConfigAppend(conf, knobs, memString+" "+knobsString, t)
 }
 
+func TestAppendMemoryMemFD(t *testing.T) {
+	conf := &Config{
+		Memory: Memory{
+			Size:   "1G",
+			Slots:  8,
+			MaxMem: "3G",
+			Path:   "foobar",
+		},
+	}
+	memString := "-m 1G,slots=8,maxmem=3G"
+	testConfigAppend(conf, conf.Memory, memString, t)
+
+	knobs := Knobs{
+		FileBackedMem: true,
+		MemFD:         true,
+		MemShared:     true,
+	}
+	objMemString := "-object memory-backend-memfd,id=dimm1,size=1G,share=on"
+	numaMemString := "-numa node,memdev=dimm1"
+	memBackendString := "-machine memory-backend=dimm1"
+
+	knobsString := objMemString + " "
+	if isDimmSupported(nil) {
+		knobsString += numaMemString
+	} else {
+		knobsString += memBackendString
+	}
+
+	testConfigAppend(conf, knobs, memString+" "+knobsString, t)
+}
+
 func TestNoRebootKnob(t *testing.T) {
 	conf := &Config{}
 
@@ -1001,6 +1243,22 @@ func TestBadGlobalParam(t *testing.T) {
 	}
 }
 
+func TestAppendGlobalParams(t *testing.T) {
+	c := &Config{
+		GlobalParam:  "kvm-pit.lost_tick_policy=discard",
+		GlobalParams: []string{"q35-pcihost.pci-hole64-size=137438953472"},
+	}
+	c.appendGlobalParam()
+
+	expected := []string{
+		"-global", "kvm-pit.lost_tick_policy=discard",
+		"-global", "q35-pcihost.pci-hole64-size=137438953472",
+	}
+	if !reflect.DeepEqual(c.qemuParams, expected) {
+		t.Errorf("Expected %v, found %v", expected, c.qemuParams)
+	}
+}
+
 func TestBadPFlash(t *testing.T) {
 	c := &Config{}
 	c.appendPFlashParam()
@@ -1039,6 +1297,20 @@ func TestValidSeccompSandbox(t *testing.T) {
 	}
 }
 
+func TestAppendExtraParams(t *testing.T) {
+	c := &Config{}
+	c.appendExtraParams()
+	if len(c.qemuParams) != 0 {
+		t.Errorf("Expected empty qemuParams, found %s", c.qemuParams)
+	}
+
+	c.ExtraParams = []string{"-global", "kvm-pit.lost_tick_policy=discard"}
+	c.appendExtraParams()
+	if !reflect.DeepEqual(c.ExtraParams, c.qemuParams) {
+		t.Errorf("Expected %v, found %v", c.ExtraParams, c.qemuParams)
+	}
+}
+
 func TestBadVGA(t *testing.T) {
 	c := &Config{}
 	c.appendVGA()
@@ -707,6 +707,10 @@ func QMPStart(ctx context.Context, socket string, cfg QMPConfig, disconnectedCh
 
 // Same as QMPStart but with a pre-established connection
 func QMPStartWithConn(ctx context.Context, conn net.Conn, cfg QMPConfig, disconnectedCh chan struct{}) (*QMP, *QMPVersion, error) {
+	if cfg.Logger == nil {
+		cfg.Logger = qmpNullLogger{}
+	}
+
 	if conn == nil {
 		close(disconnectedCh)
 		return nil, nil, fmt.Errorf("invalid connection")
@@ -935,6 +939,24 @@ func (q *QMP) ExecuteBlockdevDel(ctx context.Context, blockdevID string) error {
 	return q.executeCommand(ctx, "blockdev-del", args, nil)
 }
 
+// ExecuteBlockdevFlush writes the data cached by QEMU for the block device
+// blockdevID back to its backing storage. QMP has no command to do so, the
+// flush command of qemu-io is sent through the human monitor, which reports
+// its errors in its output.
+func (q *QMP) ExecuteBlockdevFlush(ctx context.Context, blockdevID string) error {
+	args := map[string]interface{}{
+		"command-line": fmt.Sprintf("qemu-io %s flush", blockdevID),
+	}
+	response, err := q.executeCommandWithResponse(ctx, "human-monitor-command", args, nil, nil)
+	if err != nil {
+		return err
+	}
+	if output, ok := response.(string); ok && strings.TrimSpace(output) != "" {
+		return fmt.Errorf("flush of %s failed: %s", blockdevID, strings.TrimSpace(output))
+	}
+	return nil
+}
+
 // ExecuteChardevDel deletes a char device by sending a chardev-remove command.
 // chardevID is the id of the char device to be deleted. Typically, this will
 // match the id passed to ExecuteCharDevUnixSocketAdd. It must be a valid QMP id.
@@ -1148,6 +1170,26 @@ func (q *QMP) ExecutePCIVhostUserDevAdd(ctx context.Context, driver, devID, char
 	return q.executeCommand(ctx, "device_add", args, nil)
 }
 
+// ExecutePCIVhostVDPADevAdd adds a vhost-vdpa device to a QEMU instance using the device_add command.
+// devID is the id of the device to add. Must be valid QMP identifier.
+// vhostdev is the path of the vhost-vdpa character device on the host.
+// addr is the PCI slot of the device and bus is the optional ID of the PCI bridge or
+// pcie-root-port to plug the device into.
+func (q *QMP) ExecutePCIVhostVDPADevAdd(ctx context.Context, devID, vhostdev, addr, bus string) error {
+	args := map[string]interface{}{
+		"driver":   "vhost-vdpa-device-pci",
+		"id":       devID,
+		"vhostdev": vhostdev,
+		"addr":     addr,
+	}
+
+	if bus != "" {
+		args["bus"] = bus
+	}
+
+	return q.executeCommand(ctx, "device_add", args, nil)
+}
+
 // ExecuteVFIODeviceAdd adds a VFIO device to a QEMU instance using the device_add command.
 // devID is the id of the device to add. Must be valid QMP identifier.
 // bdf is the PCI bus-device-function of the pci device.
@@ -1174,6 +1216,23 @@ func (q *QMP) ExecuteVFIODeviceAdd(ctx context.Context, devID, bdf, bus, romfile
 	return q.executeCommand(ctx, "device_add", args, nil)
 }
 
+// ExecuteGPUDirectVFIODeviceAdd adds an NVIDIA GPU to a QEMU instance using the
+// device_add command, like ExecuteVFIODeviceAdd, in the GPUDirect peer-to-peer
+// clique given by clique.
+func (q *QMP) ExecuteGPUDirectVFIODeviceAdd(ctx context.Context, devID, bdf, bus, romfile, clique string) error {
+	args := map[string]interface{}{
+		"id":                    devID,
+		"driver":                VfioPCI,
+		"host":                  bdf,
+		"romfile":               romfile,
+		"x-nv-gpudirect-clique": clique,
+	}
+	if bus != "" {
+		args["bus"] = bus
+	}
+	return q.executeCommand(ctx, "device_add", args, nil)
+}
+
 // ExecutePCIVFIODeviceAdd adds a VFIO device to a QEMU instance using the device_add command.
 // This function can be used to hot plug VFIO devices on PCI(E) bridges, unlike
 // ExecuteVFIODeviceAdd this function receives the bus and the device address on its parent bus.
@@ -1225,6 +1284,18 @@ func (q *QMP) ExecuteAPVFIOMediatedDeviceAdd(ctx context.Context, sysfsdev strin
 	return q.executeCommand(ctx, "device_add", args, nil)
 }
 
+// ExecuteSpaprPHBDeviceAdd hotplugs an extra PCI host bridge of index index
+// to a pseries machine using the device_add command. Its root bus is <id>.0.
+func (q *QMP) ExecuteSpaprPHBDeviceAdd(ctx context.Context, id string, index uint32) error {
+	args := map[string]interface{}{
+		"driver": SpaprPHB,
+		"id":     id,
+		"index":  index,
+	}
+
+	return q.executeCommand(ctx, "device_add", args, nil)
+}
+
 // isSocketIDSupported returns if the cpu driver supports the socket id option
 func isSocketIDSupported(driver string) bool {
 	if driver == "host-s390x-cpu" || driver == "host-powerpc64-cpu" {
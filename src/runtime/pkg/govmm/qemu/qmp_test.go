@@ -657,6 +657,31 @@ func TestQMPBlockdevDel(t *testing.T) {
 	<-disconnectedCh
 }
 
+// Checks that the flush of a block device is correctly sent through the
+// human monitor, and that the errors it outputs are reported.
+func TestQMPBlockdevFlush(t *testing.T) {
+	connectedCh := make(chan *QMPVersion)
+	disconnectedCh := make(chan struct{})
+	buf := newQMPTestCommandBuffer(t)
+	blockdevID := fmt.Sprintf("drive_%s", volumeUUID)
+	args := map[string]interface{}{
+		"command-line": fmt.Sprintf("qemu-io %s flush", blockdevID),
+	}
+	buf.AddCommand("human-monitor-command", args, "return", "")
+	buf.AddCommand("human-monitor-command", args, "return", "flush failed: Input/output error\r\n")
+	cfg := QMPConfig{Logger: qmpTestLogger{}}
+	q := startQMPLoop(buf, cfg, connectedCh, disconnectedCh)
+	q.version = checkVersion(t, connectedCh)
+	if err := q.ExecuteBlockdevFlush(context.Background(), blockdevID); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if err := q.ExecuteBlockdevFlush(context.Background(), blockdevID); err == nil {
+		t.Fatalf("Expected error")
+	}
+	q.Shutdown()
+	<-disconnectedCh
+}
+
 // Checks that the chardev-remove command is correctly sent.
 //
 // We start a QMPLoop, send the chardev-remove command and stop the loop.
@@ -1119,6 +1144,27 @@ func TestQMPPCIVFIOPCIeDeviceAdd(t *testing.T) {
 	<-disconnectedCh
 }
 
+func TestQMPGPUDirectVFIODeviceAdd(t *testing.T) {
+	connectedCh := make(chan *QMPVersion)
+	disconnectedCh := make(chan struct{})
+	buf := newQMPTestCommandBuffer(t)
+	buf.AddCommand("device_add", nil, "return", nil)
+	cfg := QMPConfig{Logger: qmpTestLogger{}}
+	q := startQMPLoop(buf, cfg, connectedCh, disconnectedCh)
+	checkVersion(t, connectedCh)
+	bdf := "03:00.0"
+	bus := "swdp0"
+	romfile := ""
+	clique := "0"
+	devID := fmt.Sprintf("device_%s", volumeUUID)
+	err := q.ExecuteGPUDirectVFIODeviceAdd(context.Background(), devID, bdf, bus, romfile, clique)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	q.Shutdown()
+	<-disconnectedCh
+}
+
 func TestQMPAPVFIOMediatedDeviceAdd(t *testing.T) {
 	connectedCh := make(chan *QMPVersion)
 	disconnectedCh := make(chan struct{})
@@ -1136,6 +1182,23 @@ func TestQMPAPVFIOMediatedDeviceAdd(t *testing.T) {
 	<-disconnectedCh
 }
 
+// Checks that a PCI host bridge is correctly added using device_add
+func TestQMPSpaprPHBDeviceAdd(t *testing.T) {
+	connectedCh := make(chan *QMPVersion)
+	disconnectedCh := make(chan struct{})
+	buf := newQMPTestCommandBuffer(t)
+	buf.AddCommand("device_add", nil, "return", nil)
+	cfg := QMPConfig{Logger: qmpTestLogger{}}
+	q := startQMPLoop(buf, cfg, connectedCh, disconnectedCh)
+	checkVersion(t, connectedCh)
+	err := q.ExecuteSpaprPHBDeviceAdd(context.Background(), "phb1", 1)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	q.Shutdown()
+	<-disconnectedCh
+}
+
 // Checks that CPU are correctly added using device_add
 func TestQMPCPUDeviceAdd(t *testing.T) {
 	drivers := []string{"host-x86_64-cpu", "host-s390x-cpu", "host-powerpc64-cpu"}
@@ -1419,6 +1482,23 @@ func TestExecutePCIVhostUserDevAdd(t *testing.T) {
 	<-disconnectedCh
 }
 
+// Checks that the vhost-vdpa device_add command is correctly sent.
+func TestExecutePCIVhostVDPADevAdd(t *testing.T) {
+	connectedCh := make(chan *QMPVersion)
+	disconnectedCh := make(chan struct{})
+	buf := newQMPTestCommandBuffer(t)
+	buf.AddCommand("device_add", nil, "return", nil)
+	cfg := QMPConfig{Logger: qmpTestLogger{}}
+	q := startQMPLoop(buf, cfg, connectedCh, disconnectedCh)
+	checkVersion(t, connectedCh)
+	err := q.ExecutePCIVhostVDPADevAdd(context.Background(), "vhost-vdpa-blk0", "/dev/vhost-vdpa-0", "1", "1")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	q.Shutdown()
+	<-disconnectedCh
+}
+
 // Checks getfd
 func TestExecuteGetFdD(t *testing.T) {
 	connectedCh := make(chan *QMPVersion)
@@ -0,0 +1,15 @@
+//
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package govmm
+
+// MaxVCPUs returns the maximum number of vCPUs supported
+func MaxVCPUs() uint32 {
+	// Max number of virtual Cpu defined in qemu for the virt machine. See
+	// https://github.com/qemu/qemu/blob/master/include/hw/riscv/virt.h
+	// #define VIRT_CPUS_MAX 512
+	return uint32(512)
+}
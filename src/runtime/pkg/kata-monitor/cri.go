@@ -143,9 +143,11 @@ func (km *KataMonitor) syncSandboxes(sandboxList []string) ([]string, error) {
 		for _, sandbox := range sandboxList {
 			if pod.Id == sandbox {
 				km.sandboxCache.setCRIMetadata(sandbox, sandboxCRIMetadata{
-					uid:       pod.Metadata.Uid,
-					name:      pod.Metadata.Name,
-					namespace: pod.Metadata.Namespace,
+					uid:            pod.Metadata.Uid,
+					name:           pod.Metadata.Name,
+					namespace:      pod.Metadata.Namespace,
+					labels:         pod.Labels,
+					runtimeHandler: pod.RuntimeHandler,
 				})
 
 				sandboxList = removeFromSandboxList(sandboxList, sandbox)
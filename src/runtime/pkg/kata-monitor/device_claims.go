@@ -0,0 +1,83 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package katamonitor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/claim"
+)
+
+// defaultDeviceClaimTTL is how long the claim of a pod that has not landed
+// yet is honored, unless the ttl parameter says otherwise.
+const defaultDeviceClaimTTL = 5 * time.Minute
+
+// DeviceClaims manages the claims of the IOMMU groups of the node, which let a
+// scheduler or device plugin reserve the VFIO devices of a pod before it is
+// created:
+//   - GET lists the claims as JSON.
+//   - POST claims the group for the pod, given as namespace/name, for ttl.
+//   - DELETE releases the claim of the group by the pod.
+func (km *KataMonitor) DeviceClaims(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	group := query.Get("group")
+	pod := query.Get("pod")
+
+	switch r.Method {
+	case http.MethodGet:
+		claims, err := claim.List()
+		if err != nil {
+			commonServeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		w.Header().Set("Content-Type", contentTypeJSON)
+		json.NewEncoder(w).Encode(claims)
+
+	case http.MethodPost:
+		ttl := defaultDeviceClaimTTL
+		if value := query.Get("ttl"); value != "" {
+			var err error
+			if ttl, err = time.ParseDuration(value); err != nil || ttl <= 0 {
+				commonServeError(w, http.StatusBadRequest, fmt.Errorf("invalid ttl %q", value))
+				return
+			}
+		}
+
+		c, err := claim.Add(group, pod, ttl)
+		if errors.Is(err, claim.ErrClaimed) {
+			commonServeError(w, http.StatusConflict, err)
+			return
+		}
+		if err != nil {
+			commonServeError(w, http.StatusBadRequest, err)
+			return
+		}
+		monitorLog.WithField("group", group).WithField("pod", pod).Info("IOMMU group claimed")
+
+		w.Header().Set("Content-Type", contentTypeJSON)
+		json.NewEncoder(w).Encode(c)
+
+	case http.MethodDelete:
+		err := claim.Remove(group, pod)
+		if errors.Is(err, claim.ErrClaimed) {
+			commonServeError(w, http.StatusConflict, err)
+			return
+		}
+		if err != nil {
+			commonServeError(w, http.StatusBadRequest, err)
+			return
+		}
+		monitorLog.WithField("group", group).WithField("pod", pod).Info("IOMMU group released")
+
+	default:
+		w.Header().Set("Allow", "GET, POST, DELETE")
+		commonServeError(w, http.StatusMethodNotAllowed, nil)
+	}
+}
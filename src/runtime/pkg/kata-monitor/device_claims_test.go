@@ -0,0 +1,51 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package katamonitor
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/claim"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeviceClaims(t *testing.T) {
+	assert := assert.New(t)
+
+	savedDir := claim.ClaimsDir
+	defer func() {
+		claim.ClaimsDir = savedDir
+	}()
+	claim.ClaimsDir = filepath.Join(t.TempDir(), "device-claims")
+
+	km := &KataMonitor{}
+	request := func(method, query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		km.DeviceClaims(w, httptest.NewRequest(method, "/device-claims?"+query, nil))
+		return w
+	}
+
+	assert.Equal(http.StatusOK, request(http.MethodPost, "group=12&pod=default/gpu-0").Code)
+	assert.Equal(http.StatusConflict, request(http.MethodPost, "group=12&pod=default/gpu-1").Code)
+	assert.Equal(http.StatusBadRequest, request(http.MethodPost, "group=13&pod=default/gpu-1&ttl=forever").Code)
+	assert.Equal(http.StatusBadRequest, request(http.MethodPost, "group=gpu&pod=default/gpu-1").Code)
+
+	w := request(http.MethodGet, "")
+	assert.Equal(http.StatusOK, w.Code)
+	var claims []claim.Claim
+	assert.NoError(json.Unmarshal(w.Body.Bytes(), &claims))
+	assert.Len(claims, 1)
+	assert.Equal("default/gpu-0", claims[0].Owner)
+	assert.NotNil(claims[0].Expires)
+
+	assert.Equal(http.StatusConflict, request(http.MethodDelete, "group=12&pod=default/gpu-1").Code)
+	assert.Equal(http.StatusOK, request(http.MethodDelete, "group=12&pod=default/gpu-0").Code)
+	assert.Equal(http.StatusMethodNotAllowed, request(http.MethodPut, "").Code)
+}
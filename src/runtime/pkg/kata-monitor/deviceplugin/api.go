@@ -0,0 +1,329 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package deviceplugin
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+)
+
+// The messages and services below are the ones of the v1beta1 device plugin
+// API of the kubelet (k8s.io/kubelet/pkg/apis/deviceplugin/v1beta1), which is
+// not vendored. Only the fields used by the plugin are declared, the field
+// numbers and types must match the ones of the kubelet.
+
+const (
+	// Version is the version of the device plugin API.
+	Version = "v1beta1"
+
+	// DevicePluginPath is the directory of the sockets of the kubelet and
+	// of the device plugins.
+	DevicePluginPath = "/var/lib/kubelet/device-plugins/"
+
+	// KubeletSocket is the name of the registration socket of the kubelet.
+	KubeletSocket = "kubelet.sock"
+
+	// Healthy means the device can be allocated.
+	Healthy = "Healthy"
+	// Unhealthy means the device cannot be allocated.
+	Unhealthy = "Unhealthy"
+)
+
+type Empty struct{}
+
+func (m *Empty) Reset()         { *m = Empty{} }
+func (m *Empty) String() string { return proto.CompactTextString(m) }
+func (*Empty) ProtoMessage()    {}
+
+type DevicePluginOptions struct {
+	PreStartRequired                bool `protobuf:"varint,1,opt,name=pre_start_required,json=preStartRequired,proto3" json:"pre_start_required,omitempty"`
+	GetPreferredAllocationAvailable bool `protobuf:"varint,2,opt,name=get_preferred_allocation_available,json=getPreferredAllocationAvailable,proto3" json:"get_preferred_allocation_available,omitempty"`
+}
+
+func (m *DevicePluginOptions) Reset()         { *m = DevicePluginOptions{} }
+func (m *DevicePluginOptions) String() string { return proto.CompactTextString(m) }
+func (*DevicePluginOptions) ProtoMessage()    {}
+
+type RegisterRequest struct {
+	Version      string               `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Endpoint     string               `protobuf:"bytes,2,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	ResourceName string               `protobuf:"bytes,3,opt,name=resource_name,json=resourceName,proto3" json:"resource_name,omitempty"`
+	Options      *DevicePluginOptions `protobuf:"bytes,4,opt,name=options,proto3" json:"options,omitempty"`
+}
+
+func (m *RegisterRequest) Reset()         { *m = RegisterRequest{} }
+func (m *RegisterRequest) String() string { return proto.CompactTextString(m) }
+func (*RegisterRequest) ProtoMessage()    {}
+
+type NUMANode struct {
+	ID int64 `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+}
+
+func (m *NUMANode) Reset()         { *m = NUMANode{} }
+func (m *NUMANode) String() string { return proto.CompactTextString(m) }
+func (*NUMANode) ProtoMessage()    {}
+
+type TopologyInfo struct {
+	Nodes []*NUMANode `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+}
+
+func (m *TopologyInfo) Reset()         { *m = TopologyInfo{} }
+func (m *TopologyInfo) String() string { return proto.CompactTextString(m) }
+func (*TopologyInfo) ProtoMessage()    {}
+
+type Device struct {
+	ID       string        `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Health   string        `protobuf:"bytes,2,opt,name=health,proto3" json:"health,omitempty"`
+	Topology *TopologyInfo `protobuf:"bytes,3,opt,name=topology,proto3" json:"topology,omitempty"`
+}
+
+func (m *Device) Reset()         { *m = Device{} }
+func (m *Device) String() string { return proto.CompactTextString(m) }
+func (*Device) ProtoMessage()    {}
+
+type ListAndWatchResponse struct {
+	Devices []*Device `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+}
+
+func (m *ListAndWatchResponse) Reset()         { *m = ListAndWatchResponse{} }
+func (m *ListAndWatchResponse) String() string { return proto.CompactTextString(m) }
+func (*ListAndWatchResponse) ProtoMessage()    {}
+
+type ContainerPreferredAllocationRequest struct {
+	AvailableDeviceIDs   []string `protobuf:"bytes,1,rep,name=available_deviceIDs,json=availableDeviceIDs,proto3" json:"available_deviceIDs,omitempty"`
+	MustIncludeDeviceIDs []string `protobuf:"bytes,2,rep,name=must_include_deviceIDs,json=mustIncludeDeviceIDs,proto3" json:"must_include_deviceIDs,omitempty"`
+	AllocationSize       int32    `protobuf:"varint,3,opt,name=allocation_size,json=allocationSize,proto3" json:"allocation_size,omitempty"`
+}
+
+func (m *ContainerPreferredAllocationRequest) Reset() {
+	*m = ContainerPreferredAllocationRequest{}
+}
+func (m *ContainerPreferredAllocationRequest) String() string { return proto.CompactTextString(m) }
+func (*ContainerPreferredAllocationRequest) ProtoMessage()    {}
+
+type PreferredAllocationRequest struct {
+	ContainerRequests []*ContainerPreferredAllocationRequest `protobuf:"bytes,1,rep,name=container_requests,json=containerRequests,proto3" json:"container_requests,omitempty"`
+}
+
+func (m *PreferredAllocationRequest) Reset()         { *m = PreferredAllocationRequest{} }
+func (m *PreferredAllocationRequest) String() string { return proto.CompactTextString(m) }
+func (*PreferredAllocationRequest) ProtoMessage()    {}
+
+type ContainerPreferredAllocationResponse struct {
+	DeviceIDs []string `protobuf:"bytes,1,rep,name=deviceIDs,proto3" json:"deviceIDs,omitempty"`
+}
+
+func (m *ContainerPreferredAllocationResponse) Reset() {
+	*m = ContainerPreferredAllocationResponse{}
+}
+func (m *ContainerPreferredAllocationResponse) String() string { return proto.CompactTextString(m) }
+func (*ContainerPreferredAllocationResponse) ProtoMessage()    {}
+
+type PreferredAllocationResponse struct {
+	ContainerResponses []*ContainerPreferredAllocationResponse `protobuf:"bytes,1,rep,name=container_responses,json=containerResponses,proto3" json:"container_responses,omitempty"`
+}
+
+func (m *PreferredAllocationResponse) Reset()         { *m = PreferredAllocationResponse{} }
+func (m *PreferredAllocationResponse) String() string { return proto.CompactTextString(m) }
+func (*PreferredAllocationResponse) ProtoMessage()    {}
+
+type ContainerAllocateRequest struct {
+	DevicesIDs []string `protobuf:"bytes,1,rep,name=devicesIDs,proto3" json:"devicesIDs,omitempty"`
+}
+
+func (m *ContainerAllocateRequest) Reset()         { *m = ContainerAllocateRequest{} }
+func (m *ContainerAllocateRequest) String() string { return proto.CompactTextString(m) }
+func (*ContainerAllocateRequest) ProtoMessage()    {}
+
+type AllocateRequest struct {
+	ContainerRequests []*ContainerAllocateRequest `protobuf:"bytes,1,rep,name=container_requests,json=containerRequests,proto3" json:"container_requests,omitempty"`
+}
+
+func (m *AllocateRequest) Reset()         { *m = AllocateRequest{} }
+func (m *AllocateRequest) String() string { return proto.CompactTextString(m) }
+func (*AllocateRequest) ProtoMessage()    {}
+
+type DeviceSpec struct {
+	ContainerPath string `protobuf:"bytes,1,opt,name=container_path,json=containerPath,proto3" json:"container_path,omitempty"`
+	HostPath      string `protobuf:"bytes,2,opt,name=host_path,json=hostPath,proto3" json:"host_path,omitempty"`
+	Permissions   string `protobuf:"bytes,3,opt,name=permissions,proto3" json:"permissions,omitempty"`
+}
+
+func (m *DeviceSpec) Reset()         { *m = DeviceSpec{} }
+func (m *DeviceSpec) String() string { return proto.CompactTextString(m) }
+func (*DeviceSpec) ProtoMessage()    {}
+
+type ContainerAllocateResponse struct {
+	Envs        map[string]string `protobuf:"bytes,1,rep,name=envs,proto3" json:"envs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Devices     []*DeviceSpec     `protobuf:"bytes,3,rep,name=devices,proto3" json:"devices,omitempty"`
+	Annotations map[string]string `protobuf:"bytes,4,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (m *ContainerAllocateResponse) Reset()         { *m = ContainerAllocateResponse{} }
+func (m *ContainerAllocateResponse) String() string { return proto.CompactTextString(m) }
+func (*ContainerAllocateResponse) ProtoMessage()    {}
+
+type AllocateResponse struct {
+	ContainerResponses []*ContainerAllocateResponse `protobuf:"bytes,1,rep,name=container_responses,json=containerResponses,proto3" json:"container_responses,omitempty"`
+}
+
+func (m *AllocateResponse) Reset()         { *m = AllocateResponse{} }
+func (m *AllocateResponse) String() string { return proto.CompactTextString(m) }
+func (*AllocateResponse) ProtoMessage()    {}
+
+type PreStartContainerRequest struct {
+	DevicesIDs []string `protobuf:"bytes,1,rep,name=devicesIDs,proto3" json:"devicesIDs,omitempty"`
+}
+
+func (m *PreStartContainerRequest) Reset()         { *m = PreStartContainerRequest{} }
+func (m *PreStartContainerRequest) String() string { return proto.CompactTextString(m) }
+func (*PreStartContainerRequest) ProtoMessage()    {}
+
+type PreStartContainerResponse struct{}
+
+func (m *PreStartContainerResponse) Reset()         { *m = PreStartContainerResponse{} }
+func (m *PreStartContainerResponse) String() string { return proto.CompactTextString(m) }
+func (*PreStartContainerResponse) ProtoMessage()    {}
+
+// RegistrationClient registers the device plugins to the kubelet.
+type RegistrationClient interface {
+	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*Empty, error)
+}
+
+type registrationClient struct {
+	cc *grpc.ClientConn
+}
+
+// NewRegistrationClient returns a client of the registration service of the
+// kubelet.
+func NewRegistrationClient(cc *grpc.ClientConn) RegistrationClient {
+	return &registrationClient{cc}
+}
+
+func (c *registrationClient) Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	if err := c.cc.Invoke(ctx, "/v1beta1.Registration/Register", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RegistrationServer is the registration service of the kubelet, only
+// implemented by the tests.
+type RegistrationServer interface {
+	Register(context.Context, *RegisterRequest) (*Empty, error)
+}
+
+func registerHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	return srv.(RegistrationServer).Register(ctx, in)
+}
+
+var registrationServiceDesc = grpc.ServiceDesc{
+	ServiceName: "v1beta1.Registration",
+	HandlerType: (*RegistrationServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "Register", Handler: registerHandler},
+	},
+}
+
+// RegisterRegistrationServer registers the registration service to the
+// server.
+func RegisterRegistrationServer(s *grpc.Server, srv RegistrationServer) {
+	s.RegisterService(&registrationServiceDesc, srv)
+}
+
+// DevicePluginServer is the service of a device plugin, called by the
+// kubelet.
+type DevicePluginServer interface {
+	GetDevicePluginOptions(context.Context, *Empty) (*DevicePluginOptions, error)
+	ListAndWatch(*Empty, DevicePlugin_ListAndWatchServer) error
+	GetPreferredAllocation(context.Context, *PreferredAllocationRequest) (*PreferredAllocationResponse, error)
+	Allocate(context.Context, *AllocateRequest) (*AllocateResponse, error)
+	PreStartContainer(context.Context, *PreStartContainerRequest) (*PreStartContainerResponse, error)
+}
+
+// DevicePlugin_ListAndWatchServer streams the devices of a plugin to the
+// kubelet.
+//
+//nolint:revive,stylecheck
+type DevicePlugin_ListAndWatchServer interface {
+	Send(*ListAndWatchResponse) error
+	grpc.ServerStream
+}
+
+type devicePluginListAndWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *devicePluginListAndWatchServer) Send(m *ListAndWatchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func getDevicePluginOptionsHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	return srv.(DevicePluginServer).GetDevicePluginOptions(ctx, in)
+}
+
+func getPreferredAllocationHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreferredAllocationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	return srv.(DevicePluginServer).GetPreferredAllocation(ctx, in)
+}
+
+func allocateHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AllocateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	return srv.(DevicePluginServer).Allocate(ctx, in)
+}
+
+func preStartContainerHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreStartContainerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	return srv.(DevicePluginServer).PreStartContainer(ctx, in)
+}
+
+func listAndWatchHandler(srv interface{}, stream grpc.ServerStream) error {
+	in := new(Empty)
+	if err := stream.RecvMsg(in); err != nil {
+		return err
+	}
+	return srv.(DevicePluginServer).ListAndWatch(in, &devicePluginListAndWatchServer{stream})
+}
+
+var devicePluginServiceDesc = grpc.ServiceDesc{
+	ServiceName: "v1beta1.DevicePlugin",
+	HandlerType: (*DevicePluginServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "GetDevicePluginOptions", Handler: getDevicePluginOptionsHandler},
+		{MethodName: "GetPreferredAllocation", Handler: getPreferredAllocationHandler},
+		{MethodName: "Allocate", Handler: allocateHandler},
+		{MethodName: "PreStartContainer", Handler: preStartContainerHandler},
+	},
+	Streams: []grpc.StreamDesc{
+		{StreamName: "ListAndWatch", Handler: listAndWatchHandler, ServerStreams: true},
+	},
+}
+
+// RegisterDevicePluginServer registers the device plugin service to the
+// server.
+func RegisterDevicePluginServer(s *grpc.Server, srv DevicePluginServer) {
+	s.RegisterService(&devicePluginServiceDesc, srv)
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package deviceplugin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const vfioDriver = "vfio-pci"
+
+// pciBridgeClass is the base class of the PCI bridges, which are not passed
+// to the guest, see drivers.GetAllVFIODevicesFromIOMMUGroup.
+const pciBridgeClass = 0x06
+
+// pciClasses are the names of the resource classes of the PCI base classes,
+// by priority: a group is of the class of its device of highest priority,
+// e.g. a GPU along with its audio function is a GPU. The other base classes
+// are named after their number.
+var pciClasses = []struct {
+	base uint64
+	name string
+}{
+	{0x03, "gpu"},
+	{0x12, "accel"},
+	{0x02, "net"},
+	{0x01, "storage"},
+}
+
+// vfioGroup is an IOMMU group of the host with VFIO devices, which is the
+// unit of allocation of the devices.
+type vfioGroup struct {
+	// Group is the number of the group, as found in /dev/vfio.
+	Group string
+	// Class is the resource class of the group.
+	Class string
+	// Devices are the PCI addresses of the devices of the group, bridges
+	// excluded.
+	Devices []string
+	// NUMANode is the NUMA node of the devices, -1 when unknown.
+	NUMANode int64
+	// Healthy tells if the group can be given to a guest: all its
+	// devices are bound to vfio-pci and its device node exists.
+	Healthy bool
+}
+
+func readSysfsUint(path string) (uint64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(data)), 0, 64)
+}
+
+func pciClassName(base uint64) string {
+	for _, c := range pciClasses {
+		if c.base == base {
+			return c.name
+		}
+	}
+	return fmt.Sprintf("%02x", base)
+}
+
+func pciClassPriority(name string) int {
+	for i, c := range pciClasses {
+		if c.name == name {
+			return i
+		}
+	}
+	return len(pciClasses)
+}
+
+// scanVFIOGroups returns the IOMMU groups of the PCI devices found in
+// pciDevicesPath which have a device bound to vfio-pci, sorted by number.
+// The groups with a device bound to another driver are returned as
+// unhealthy, as they cannot be opened.
+func scanVFIOGroups(pciDevicesPath, vfioDevPath string) ([]vfioGroup, error) {
+	entries, err := os.ReadDir(pciDevicesPath)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string]*vfioGroup)
+	bound := make(map[string]bool)
+	for _, entry := range entries {
+		devPath := filepath.Join(pciDevicesPath, entry.Name())
+
+		link, err := os.Readlink(filepath.Join(devPath, "iommu_group"))
+		if err != nil {
+			// The device is not behind an IOMMU.
+			continue
+		}
+		number := filepath.Base(link)
+
+		class, err := readSysfsUint(filepath.Join(devPath, "class"))
+		if err != nil {
+			return nil, err
+		}
+		base := class >> 16
+		if base == pciBridgeClass {
+			continue
+		}
+
+		group, ok := groups[number]
+		if !ok {
+			group = &vfioGroup{Group: number, NUMANode: -1, Healthy: true}
+			groups[number] = group
+		}
+		group.Devices = append(group.Devices, entry.Name())
+
+		if name := pciClassName(base); group.Class == "" || pciClassPriority(name) < pciClassPriority(group.Class) {
+			group.Class = name
+			if node, err := os.ReadFile(filepath.Join(devPath, "numa_node")); err == nil {
+				if n, err := strconv.ParseInt(strings.TrimSpace(string(node)), 10, 64); err == nil {
+					group.NUMANode = n
+				}
+			}
+		}
+
+		driver, err := os.Readlink(filepath.Join(devPath, "driver"))
+		if err == nil && filepath.Base(driver) == vfioDriver {
+			bound[number] = true
+		} else if err == nil {
+			// A device without driver does not prevent the group
+			// from being opened, one bound to another driver does.
+			group.Healthy = false
+		}
+	}
+
+	var result []vfioGroup
+	for number, group := range groups {
+		if !bound[number] {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(vfioDevPath, number)); err != nil {
+			group.Healthy = false
+		}
+		sort.Strings(group.Devices)
+		result = append(result, *group)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, _ := strconv.Atoi(result[i].Group)
+		b, _ := strconv.Atoi(result[j].Group)
+		return a < b
+	})
+
+	return result, nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package deviceplugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writePCIDevice creates a PCI device in a fake sysfs tree.
+func writePCIDevice(t *testing.T, root, bdf, class, group, driver, numa string) {
+	dir := filepath.Join(root, "devices", bdf)
+	assert.NoError(t, os.MkdirAll(dir, 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "class"), []byte(class+"\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "numa_node"), []byte(numa+"\n"), 0644))
+	if group != "" {
+		assert.NoError(t, os.Symlink(filepath.Join("../../kernel/iommu_groups", group), filepath.Join(dir, "iommu_group")))
+	}
+	if driver != "" {
+		assert.NoError(t, os.Symlink(filepath.Join("../../bus/pci/drivers", driver), filepath.Join(dir, "driver")))
+	}
+}
+
+func TestScanVFIOGroups(t *testing.T) {
+	assert := assert.New(t)
+
+	root := t.TempDir()
+	vfioDir := filepath.Join(root, "vfio")
+	assert.NoError(os.MkdirAll(vfioDir, 0755))
+	for _, group := range []string{"3", "12", "40"} {
+		assert.NoError(os.WriteFile(filepath.Join(vfioDir, group), nil, 0644))
+	}
+
+	// A GPU along with its audio function
+	writePCIDevice(t, root, "0000:41:00.0", "0x030000", "12", vfioDriver, "1")
+	writePCIDevice(t, root, "0000:41:00.1", "0x040300", "12", vfioDriver, "1")
+	// A NIC behind a bridge
+	writePCIDevice(t, root, "0000:02:00.0", "0x060400", "3", "pcieport", "0")
+	writePCIDevice(t, root, "0000:03:00.0", "0x020000", "3", vfioDriver, "-1")
+	// A NIC sharing its group with a device bound to its driver
+	writePCIDevice(t, root, "0000:05:00.0", "0x020000", "40", vfioDriver, "0")
+	writePCIDevice(t, root, "0000:05:00.1", "0x020000", "40", "ixgbe", "0")
+	// A device not bound to vfio-pci, and one without IOMMU
+	writePCIDevice(t, root, "0000:06:00.0", "0x010802", "41", "nvme", "0")
+	writePCIDevice(t, root, "0000:07:00.0", "0x0b4000", "", vfioDriver, "0")
+	// A device whose node is missing
+	writePCIDevice(t, root, "0000:08:00.0", "0x0b4000", "50", vfioDriver, "0")
+
+	groups, err := scanVFIOGroups(filepath.Join(root, "devices"), vfioDir)
+	assert.NoError(err)
+	assert.Equal([]vfioGroup{
+		{Group: "3", Class: "net", Devices: []string{"0000:03:00.0"}, NUMANode: -1, Healthy: true},
+		{Group: "12", Class: "gpu", Devices: []string{"0000:41:00.0", "0000:41:00.1"}, NUMANode: 1, Healthy: true},
+		{Group: "40", Class: "net", Devices: []string{"0000:05:00.0", "0000:05:00.1"}, NUMANode: 0, Healthy: false},
+		{Group: "50", Class: "0b", Devices: []string{"0000:08:00.0"}, NUMANode: 0, Healthy: false},
+	}, groups)
+
+	_, err = scanVFIOGroups(filepath.Join(root, "missing"), vfioDir)
+	assert.Error(err)
+}
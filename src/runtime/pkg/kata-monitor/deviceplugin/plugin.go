@@ -0,0 +1,346 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package deviceplugin implements a Kubernetes device plugin serving the
+// VFIO devices of the node to the Kata Containers pods. The IOMMU groups
+// with devices bound to vfio-pci are registered to the kubelet as
+// "kata.io/vfio-<class>" resources, e.g. kata.io/vfio-gpu, one device per
+// group. The allocated groups are given to the containers as their
+// /dev/vfio device nodes, which the runtime passes through as the other
+// VFIO devices, so that no third-party device plugin is needed for the GPU
+// or NIC passthrough.
+package deviceplugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// ResourcePrefix prefixes the resource names of the classes of devices.
+const ResourcePrefix = "kata.io/vfio-"
+
+const (
+	socketPrefix    = "kata-vfio-"
+	registerTimeout = 10 * time.Second
+
+	// Rescanning more often would add load to the node for no benefit.
+	minRescanInterval = time.Second
+
+	// vfioContainerPath is the directory of the VFIO device nodes in the
+	// containers, vfioContainerDevice the VFIO container device.
+	vfioContainerPath   = "/dev/vfio"
+	vfioContainerDevice = "/dev/vfio/vfio"
+)
+
+var (
+	pciDevicesPath = "/sys/bus/pci/devices"
+	vfioDevPath    = "/dev/vfio"
+)
+
+var pluginLog = logrus.WithField("source", "kata-monitor").WithField("subsystem", "device-plugin")
+
+// SetLogger sets the logger of the device plugin.
+func SetLogger(logger *logrus.Entry) {
+	fields := pluginLog.Data
+	pluginLog = logger.WithFields(fields)
+}
+
+// Config configures the device plugin.
+type Config struct {
+	// PluginDir is the directory of the sockets of the kubelet and of
+	// the device plugins.
+	PluginDir string
+
+	// RescanInterval is the delay between two scans of the VFIO devices
+	// of the node.
+	RescanInterval time.Duration
+}
+
+// Manager serves a device plugin per class of VFIO devices found on the
+// node, and keeps them registered to the kubelet.
+type Manager struct {
+	config Config
+
+	sync.Mutex
+	groups  map[string]vfioGroup
+	plugins map[string]*plugin
+	// changed is closed and replaced when the groups change, to wake
+	// the ListAndWatch streams up.
+	changed chan struct{}
+}
+
+// plugin is the device plugin of a class of devices.
+type plugin struct {
+	mgr    *Manager
+	class  string
+	socket string
+	server *grpc.Server
+}
+
+// NewManager returns a device plugin manager, which does nothing until it
+// is started.
+func NewManager(config Config) (*Manager, error) {
+	if config.PluginDir == "" {
+		config.PluginDir = DevicePluginPath
+	}
+	if config.RescanInterval < minRescanInterval {
+		return nil, fmt.Errorf("device plugin rescan interval must be at least %v", minRescanInterval)
+	}
+
+	return &Manager{
+		config:  config,
+		groups:  make(map[string]vfioGroup),
+		plugins: make(map[string]*plugin),
+		changed: make(chan struct{}),
+	}, nil
+}
+
+// Start scans the VFIO devices of the node and registers their classes to
+// the kubelet, then keeps doing it in the background.
+func (m *Manager) Start() {
+	m.refresh()
+	go func() {
+		ticker := time.NewTicker(m.config.RescanInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			m.refresh()
+		}
+	}()
+}
+
+// refresh rescans the VFIO devices, and (re)starts the plugins of the
+// classes which are not served: new classes, and the plugins whose socket
+// was removed by a restart of the kubelet.
+func (m *Manager) refresh() {
+	groups, err := scanVFIOGroups(pciDevicesPath, vfioDevPath)
+	if err != nil {
+		pluginLog.WithError(err).Warn("failed to scan the VFIO devices")
+		return
+	}
+
+	m.Lock()
+	m.updateGroups(groups)
+	classes := make(map[string]bool)
+	for _, g := range m.groups {
+		classes[g.Class] = true
+	}
+	for class := range m.plugins {
+		classes[class] = true
+	}
+	m.Unlock()
+
+	for class := range classes {
+		m.Lock()
+		p := m.plugins[class]
+		m.Unlock()
+
+		if p != nil {
+			if _, err := os.Stat(p.socket); err == nil {
+				continue
+			}
+			pluginLog.WithField("resource", ResourcePrefix+class).Info("device plugin socket removed, restarting the plugin")
+			p.stop()
+		}
+
+		p, err := m.startPlugin(class)
+		if err != nil {
+			pluginLog.WithError(err).WithField("resource", ResourcePrefix+class).Warn("failed to start the device plugin")
+			continue
+		}
+		m.Lock()
+		m.plugins[class] = p
+		m.Unlock()
+	}
+}
+
+// updateGroups records the groups of a scan, waking the ListAndWatch
+// streams up when they changed.
+func (m *Manager) updateGroups(groups []vfioGroup) {
+	changed := len(groups) != len(m.groups)
+	current := make(map[string]vfioGroup, len(groups))
+	for _, g := range groups {
+		old, ok := m.groups[g.Group]
+		if !ok || old.Class != g.Class || old.Healthy != g.Healthy || old.NUMANode != g.NUMANode {
+			changed = true
+		}
+		current[g.Group] = g
+	}
+	m.groups = current
+
+	if changed {
+		close(m.changed)
+		m.changed = make(chan struct{})
+	}
+}
+
+// devices returns the plugin devices of a class, along with the channel
+// closed on the next change.
+func (m *Manager) devices(class string) ([]*Device, <-chan struct{}) {
+	m.Lock()
+	defer m.Unlock()
+
+	var devices []*Device
+	for _, g := range m.groups {
+		if g.Class != class {
+			continue
+		}
+		d := &Device{ID: g.Group, Health: Unhealthy}
+		if g.Healthy {
+			d.Health = Healthy
+		}
+		if g.NUMANode >= 0 {
+			d.Topology = &TopologyInfo{Nodes: []*NUMANode{{ID: g.NUMANode}}}
+		}
+		devices = append(devices, d)
+	}
+	sort.Slice(devices, func(i, j int) bool { return devices[i].ID < devices[j].ID })
+
+	return devices, m.changed
+}
+
+func (m *Manager) startPlugin(class string) (*plugin, error) {
+	p := &plugin{
+		mgr:    m,
+		class:  class,
+		socket: filepath.Join(m.config.PluginDir, socketPrefix+class+".sock"),
+		server: grpc.NewServer(),
+	}
+
+	if err := os.Remove(p.socket); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	listener, err := net.Listen("unix", p.socket)
+	if err != nil {
+		return nil, err
+	}
+
+	RegisterDevicePluginServer(p.server, p)
+	go func() {
+		if err := p.server.Serve(listener); err != nil {
+			pluginLog.WithError(err).WithField("resource", ResourcePrefix+class).Warn("device plugin stopped")
+		}
+	}()
+
+	if err := p.register(); err != nil {
+		p.stop()
+		return nil, err
+	}
+	pluginLog.WithField("resource", ResourcePrefix+class).Info("device plugin registered")
+
+	return p, nil
+}
+
+func (p *plugin) stop() {
+	p.server.Stop()
+	os.Remove(p.socket)
+}
+
+// register registers the plugin to the kubelet.
+func (p *plugin) register() error {
+	ctx, cancel := context.WithTimeout(context.Background(), registerTimeout)
+	defer cancel()
+
+	kubelet := filepath.Join(p.mgr.config.PluginDir, KubeletSocket)
+	conn, err := grpc.DialContext(ctx, "unix://"+kubelet, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	if err != nil {
+		return fmt.Errorf("connect kubelet socket %s: %w", kubelet, err)
+	}
+	defer conn.Close()
+
+	_, err = NewRegistrationClient(conn).Register(ctx, &RegisterRequest{
+		Version:      Version,
+		Endpoint:     filepath.Base(p.socket),
+		ResourceName: ResourcePrefix + p.class,
+		Options:      &DevicePluginOptions{},
+	})
+	return err
+}
+
+func (p *plugin) GetDevicePluginOptions(context.Context, *Empty) (*DevicePluginOptions, error) {
+	return &DevicePluginOptions{}, nil
+}
+
+// ListAndWatch streams the devices of the class, until the kubelet or the
+// plugin goes away.
+func (p *plugin) ListAndWatch(_ *Empty, stream DevicePlugin_ListAndWatchServer) error {
+	for {
+		devices, changed := p.mgr.devices(p.class)
+		if err := stream.Send(&ListAndWatchResponse{Devices: devices}); err != nil {
+			return err
+		}
+
+		select {
+		case <-changed:
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+func (p *plugin) GetPreferredAllocation(context.Context, *PreferredAllocationRequest) (*PreferredAllocationResponse, error) {
+	return &PreferredAllocationResponse{}, nil
+}
+
+// Allocate gives the groups to the containers as their /dev/vfio device
+// nodes, along with the VFIO container device.
+func (p *plugin) Allocate(_ context.Context, req *AllocateRequest) (*AllocateResponse, error) {
+	resp := &AllocateResponse{}
+	for _, creq := range req.ContainerRequests {
+		for _, id := range creq.DevicesIDs {
+			if err := p.check(id); err != nil {
+				return nil, err
+			}
+		}
+
+		devices := []*DeviceSpec{{
+			ContainerPath: vfioContainerDevice,
+			HostPath:      filepath.Join(vfioDevPath, "vfio"),
+			Permissions:   "rw",
+		}}
+		for _, id := range creq.DevicesIDs {
+			devices = append(devices, &DeviceSpec{
+				ContainerPath: filepath.Join(vfioContainerPath, id),
+				HostPath:      filepath.Join(vfioDevPath, id),
+				Permissions:   "rw",
+			})
+		}
+
+		resp.ContainerResponses = append(resp.ContainerResponses, &ContainerAllocateResponse{
+			Devices: devices,
+		})
+		pluginLog.WithField("resource", ResourcePrefix+p.class).WithField("groups", creq.DevicesIDs).Info("VFIO groups allocated")
+	}
+	return resp, nil
+}
+
+// check checks that a group can be allocated from the plugin.
+func (p *plugin) check(id string) error {
+	p.mgr.Lock()
+	defer p.mgr.Unlock()
+
+	g, ok := p.mgr.groups[id]
+	if !ok || g.Class != p.class {
+		return fmt.Errorf("unknown %s device %q", ResourcePrefix+p.class, id)
+	}
+	if !g.Healthy {
+		return fmt.Errorf("%s device %q is unhealthy", ResourcePrefix+p.class, id)
+	}
+	return nil
+}
+
+func (p *plugin) PreStartContainer(context.Context, *PreStartContainerRequest) (*PreStartContainerResponse, error) {
+	return nil, errors.New("PreStartContainer is not supported")
+}
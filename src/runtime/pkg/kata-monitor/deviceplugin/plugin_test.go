@@ -0,0 +1,115 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package deviceplugin
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type fakeKubelet struct {
+	requests chan *RegisterRequest
+}
+
+func (k *fakeKubelet) Register(_ context.Context, req *RegisterRequest) (*Empty, error) {
+	k.requests <- req
+	return &Empty{}, nil
+}
+
+func TestDevicePlugin(t *testing.T) {
+	assert := assert.New(t)
+
+	root := t.TempDir()
+	pluginDir := filepath.Join(root, "plugins")
+	assert.NoError(os.MkdirAll(pluginDir, 0755))
+	vfioDir := filepath.Join(root, "vfio")
+	assert.NoError(os.MkdirAll(vfioDir, 0755))
+	assert.NoError(os.WriteFile(filepath.Join(vfioDir, "12"), nil, 0644))
+	writePCIDevice(t, root, "0000:41:00.0", "0x030000", "12", vfioDriver, "1")
+
+	savedPCIDevicesPath, savedVFIODevPath := pciDevicesPath, vfioDevPath
+	pciDevicesPath, vfioDevPath = filepath.Join(root, "devices"), vfioDir
+	defer func() {
+		pciDevicesPath, vfioDevPath = savedPCIDevicesPath, savedVFIODevPath
+	}()
+
+	kubelet := &fakeKubelet{requests: make(chan *RegisterRequest, 10)}
+	server := grpc.NewServer()
+	RegisterRegistrationServer(server, kubelet)
+	listener, err := net.Listen("unix", filepath.Join(pluginDir, KubeletSocket))
+	assert.NoError(err)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	_, err = NewManager(Config{PluginDir: pluginDir})
+	assert.Error(err)
+
+	m, err := NewManager(Config{PluginDir: pluginDir, RescanInterval: time.Hour})
+	assert.NoError(err)
+	m.refresh()
+	defer m.plugins["gpu"].stop()
+
+	req := <-kubelet.requests
+	assert.Equal(Version, req.Version)
+	assert.Equal("kata.io/vfio-gpu", req.ResourceName)
+	assert.Equal("kata-vfio-gpu.sock", req.Endpoint)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, "unix://"+filepath.Join(pluginDir, req.Endpoint), grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	assert.NoError(err)
+	defer conn.Close()
+
+	stream, err := conn.NewStream(ctx, &devicePluginServiceDesc.Streams[0], "/v1beta1.DevicePlugin/ListAndWatch")
+	assert.NoError(err)
+	assert.NoError(stream.SendMsg(&Empty{}))
+	assert.NoError(stream.CloseSend())
+
+	list := &ListAndWatchResponse{}
+	assert.NoError(stream.RecvMsg(list))
+	assert.Len(list.Devices, 1)
+	assert.Equal("12", list.Devices[0].ID)
+	assert.Equal(Healthy, list.Devices[0].Health)
+	assert.Equal(int64(1), list.Devices[0].Topology.Nodes[0].ID)
+
+	// The removal of the device node is streamed
+	assert.NoError(os.Remove(filepath.Join(vfioDir, "12")))
+	m.refresh()
+	assert.NoError(stream.RecvMsg(list))
+	assert.Equal(Unhealthy, list.Devices[0].Health)
+
+	alloc := &AllocateResponse{}
+	err = conn.Invoke(ctx, "/v1beta1.DevicePlugin/Allocate", &AllocateRequest{
+		ContainerRequests: []*ContainerAllocateRequest{{DevicesIDs: []string{"12"}}},
+	}, alloc)
+	assert.Error(err)
+
+	assert.NoError(os.WriteFile(filepath.Join(vfioDir, "12"), nil, 0644))
+	m.refresh()
+	err = conn.Invoke(ctx, "/v1beta1.DevicePlugin/Allocate", &AllocateRequest{
+		ContainerRequests: []*ContainerAllocateRequest{{DevicesIDs: []string{"12"}}},
+	}, alloc)
+	assert.NoError(err)
+	assert.Len(alloc.ContainerResponses, 1)
+	assert.Equal([]*DeviceSpec{
+		{ContainerPath: "/dev/vfio/vfio", HostPath: filepath.Join(vfioDir, "vfio"), Permissions: "rw"},
+		{ContainerPath: "/dev/vfio/12", HostPath: filepath.Join(vfioDir, "12"), Permissions: "rw"},
+	}, alloc.ContainerResponses[0].Devices)
+	assert.Empty(alloc.ContainerResponses[0].Annotations)
+
+	err = conn.Invoke(ctx, "/v1beta1.DevicePlugin/Allocate", &AllocateRequest{
+		ContainerRequests: []*ContainerAllocateRequest{{DevicesIDs: []string{"13"}}},
+	}, alloc)
+	assert.Error(err)
+}
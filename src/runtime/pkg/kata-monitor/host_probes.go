@@ -0,0 +1,180 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package katamonitor
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/kata-monitor/hostprobes"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// The targets of the probes change with the sandboxes, refreshing them more
+// often would mostly read the state of the sandboxes again.
+const minHostProbesRefreshInterval = time.Second
+
+var hostProbesLabels = []string{"sandbox_id", "cri_uid", "cri_name", "cri_namespace"}
+
+var (
+	kvmExitsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(promNamespaceMonitor, "", "kvm_exits_total"),
+		"Number of KVM exits of the hypervisor of the sandbox, by exit reason.",
+		append(hostProbesLabels, "reason"), nil)
+
+	vhostKicksDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(promNamespaceMonitor, "", "vhost_kicks_total"),
+		"Number of notifications of the vhost devices of the hypervisor of the sandbox.",
+		hostProbesLabels, nil)
+
+	vsockBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(promNamespaceMonitor, "", "vsock_bytes_total"),
+		"Number of bytes exchanged with the guest of the sandbox over vsock, by direction.",
+		append(hostProbesLabels, "direction"), nil)
+)
+
+// HostProbesConfig configures the eBPF probes counting the host kernel
+// events of the sandboxes.
+type HostProbesConfig struct {
+	// Enabled attaches the probes.
+	Enabled bool
+
+	// RefreshInterval is the delay between two updates of the sandboxes
+	// probed, the events of a new sandbox are not counted before.
+	RefreshInterval time.Duration
+}
+
+// hostProbes are the probes counting the events of the sandboxes, as
+// implemented by hostprobes.Probes.
+type hostProbes interface {
+	SetTargets(targets map[string]hostprobes.Target) error
+	Counts() (map[string]*hostprobes.Counts, error)
+}
+
+// hostProbesCollector exports the counts of the probes of the sandboxes.
+type hostProbesCollector struct {
+	probes hostProbes
+	cache  *sandboxCache
+	// sandboxes are the CRI metadata of the targets of the probes
+	sandboxes map[string]sandboxCRIMetadata
+	sync.Mutex
+	// overridden in tests
+	target func(id string, metadata sandboxCRIMetadata) hostprobes.Target
+}
+
+func newHostProbesCollector(probes hostProbes, cache *sandboxCache) *hostProbesCollector {
+	return &hostProbesCollector{
+		probes:    probes,
+		cache:     cache,
+		sandboxes: make(map[string]sandboxCRIMetadata),
+		target:    hostProbesTarget,
+	}
+}
+
+// hostProbesTarget returns the target of the probes of a sandbox, from the
+// state persisted by its runtime.
+func hostProbesTarget(id string, metadata sandboxCRIMetadata) hostprobes.Target {
+	info := getSandboxInfo(getSandboxFS(), id, metadata)
+	target := hostprobes.Target{HypervisorPid: info.HypervisorPid}
+	if info.HypervisorPid > 0 {
+		target.GuestCID = hostprobes.GuestCID(info.HypervisorPid)
+	}
+	return target
+}
+
+// refresh sets the sandboxes of the cache as the targets of the probes.
+func (c *hostProbesCollector) refresh() error {
+	sandboxes := c.cache.getSandboxes()
+
+	targets := make(map[string]hostprobes.Target, len(sandboxes))
+	for id, metadata := range sandboxes {
+		target := c.target(id, metadata)
+		if target.HypervisorPid <= 0 {
+			continue
+		}
+		targets[id] = target
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	for id := range sandboxes {
+		if _, ok := targets[id]; !ok {
+			delete(sandboxes, id)
+		}
+	}
+	c.sandboxes = sandboxes
+	return c.probes.SetTargets(targets)
+}
+
+// Describe implements prometheus.Collector.
+func (c *hostProbesCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- kvmExitsDesc
+	ch <- vhostKicksDesc
+	ch <- vsockBytesDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *hostProbesCollector) Collect(ch chan<- prometheus.Metric) {
+	c.Lock()
+	defer c.Unlock()
+
+	counts, err := c.probes.Counts()
+	if err != nil {
+		monitorLog.WithError(err).Warn("failed to read the host probes counts")
+		return
+	}
+
+	for id, count := range counts {
+		metadata, ok := c.sandboxes[id]
+		if !ok {
+			continue
+		}
+		labels := []string{id, metadata.uid, metadata.name, metadata.namespace}
+
+		for reason, exits := range count.KVMExits {
+			ch <- prometheus.MustNewConstMetric(kvmExitsDesc, prometheus.CounterValue, float64(exits),
+				append(labels, strconv.FormatUint(uint64(reason), 10))...)
+		}
+		ch <- prometheus.MustNewConstMetric(vhostKicksDesc, prometheus.CounterValue, float64(count.VhostKicks), labels...)
+		ch <- prometheus.MustNewConstMetric(vsockBytesDesc, prometheus.CounterValue, float64(count.VsockBytesToGuest),
+			append(labels, "host_to_guest")...)
+		ch <- prometheus.MustNewConstMetric(vsockBytesDesc, prometheus.CounterValue, float64(count.VsockBytesFromGuest),
+			append(labels, "guest_to_host")...)
+	}
+}
+
+// StartHostProbes attaches the eBPF probes counting the KVM exits, the
+// vhost kicks and the vsock bytes of the sandboxes of the node.
+func (km *KataMonitor) StartHostProbes(config HostProbesConfig) error {
+	if !config.Enabled {
+		return nil
+	}
+	if config.RefreshInterval < minHostProbesRefreshInterval {
+		return fmt.Errorf("host probes refresh interval must be at least %v", minHostProbesRefreshInterval)
+	}
+
+	probes, err := hostprobes.New()
+	if err != nil {
+		return err
+	}
+
+	c := newHostProbesCollector(probes, km.sandboxCache)
+	prometheus.MustRegister(c)
+
+	go func() {
+		ticker := time.NewTicker(config.RefreshInterval)
+		defer ticker.Stop()
+		for ; true; <-ticker.C {
+			if err := c.refresh(); err != nil {
+				monitorLog.WithError(err).Warn("failed to refresh the host probes")
+			}
+		}
+	}()
+
+	return nil
+}
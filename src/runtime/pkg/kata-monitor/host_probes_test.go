@@ -0,0 +1,111 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package katamonitor
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/kata-monitor/hostprobes"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeHostProbes struct {
+	targets map[string]hostprobes.Target
+	counts  map[string]*hostprobes.Counts
+}
+
+func (p *fakeHostProbes) SetTargets(targets map[string]hostprobes.Target) error {
+	p.targets = targets
+	return nil
+}
+
+func (p *fakeHostProbes) Counts() (map[string]*hostprobes.Counts, error) {
+	counts := make(map[string]*hostprobes.Counts)
+	for id := range p.targets {
+		if c, ok := p.counts[id]; ok {
+			counts[id] = c
+		}
+	}
+	return counts, nil
+}
+
+func countMetrics(families []*dto.MetricFamily) int {
+	count := 0
+	for _, family := range families {
+		count += len(family.GetMetric())
+	}
+	return count
+}
+
+func TestHostProbesCollector(t *testing.T) {
+	assert := assert.New(t)
+
+	cache := &sandboxCache{
+		Mutex: &sync.Mutex{},
+		sandboxes: map[string]sandboxCRIMetadata{
+			"running": {uid: "uid", name: "web", namespace: "default"},
+			"stopped": {},
+		},
+	}
+	probes := &fakeHostProbes{
+		counts: map[string]*hostprobes.Counts{
+			"running": {
+				KVMExits:            map[uint32]uint64{30: 12, 48: 3},
+				VhostKicks:          7,
+				VsockBytesToGuest:   100,
+				VsockBytesFromGuest: 200,
+			},
+		},
+	}
+
+	c := newHostProbesCollector(probes, cache)
+	c.target = func(id string, metadata sandboxCRIMetadata) hostprobes.Target {
+		if id == "running" {
+			return hostprobes.Target{HypervisorPid: 42, GuestCID: 3}
+		}
+		return hostprobes.Target{}
+	}
+
+	assert.NoError(c.refresh())
+	assert.Equal(map[string]hostprobes.Target{"running": {HypervisorPid: 42, GuestCID: 3}}, probes.targets)
+
+	registry := prometheus.NewPedanticRegistry()
+	assert.NoError(registry.Register(c))
+
+	families, err := registry.Gather()
+	assert.NoError(err)
+	assert.Equal(5, countMetrics(families))
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			labels := make(map[string]string)
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			assert.Equal("running", labels["sandbox_id"])
+			assert.Equal("web", labels["cri_name"])
+
+			value := m.GetCounter().GetValue()
+			switch family.GetName() {
+			case "kata_monitor_kvm_exits_total":
+				assert.Equal(map[string]float64{"30": 12, "48": 3}[labels["reason"]], value)
+			case "kata_monitor_vhost_kicks_total":
+				assert.Equal(float64(7), value)
+			case "kata_monitor_vsock_bytes_total":
+				assert.Equal(map[string]float64{"host_to_guest": 100, "guest_to_host": 200}[labels["direction"]], value)
+			}
+		}
+	}
+
+	// The counts of the sandboxes gone are not exported any more
+	cache.sandboxes = map[string]sandboxCRIMetadata{}
+	assert.NoError(c.refresh())
+	families, err = registry.Gather()
+	assert.NoError(err)
+	assert.Equal(0, countMetrics(families))
+}
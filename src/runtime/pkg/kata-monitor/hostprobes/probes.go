@@ -0,0 +1,463 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package hostprobes counts host kernel events of the Kata Containers
+// sandboxes with eBPF programs attached to tracepoints and kprobes: the KVM
+// exits and the vhost virtqueue kicks of their hypervisor, filtered by its
+// PID, and the vsock bytes exchanged with their guest, filtered by its
+// context ID. The programs are assembled at runtime, from the record
+// formats of the tracepoints of the running kernel, so that no compiler or
+// kernel headers are needed on the nodes.
+package hostprobes
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/asm"
+	"github.com/cilium/ebpf/link"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// maxTargets bounds the number of hypervisors and guests probed.
+	maxTargets = 1024
+
+	// maxCounters bounds the number of counters of a kind, e.g. of KVM
+	// exits by sandbox and exit reason.
+	maxCounters = 16384
+
+	vsockToGuest   = 0
+	vsockFromGuest = 1
+
+	// The programs only use helpers which are not restricted to GPL
+	// programs.
+	programLicense = "Apache-2.0"
+)
+
+var probesLog = logrus.WithField("source", "kata-monitor/hostprobes")
+
+// SetLogger sets the logger of the hostprobes package.
+func SetLogger(logger *logrus.Entry) {
+	fields := probesLog.Data
+	probesLog = logger.WithFields(fields)
+}
+
+// Target is a sandbox whose events are counted.
+type Target struct {
+	// HypervisorPid is the PID of the hypervisor process of the sandbox
+	HypervisorPid int
+	// GuestCID is the vsock context ID of the guest, 0 when the guest has
+	// no vhost-vsock device, e.g. with a hybrid vsock
+	GuestCID uint32
+}
+
+// Counts are the events counted for a sandbox since it is a target.
+type Counts struct {
+	// KVMExits are the KVM exits of the hypervisor by exit reason, as
+	// defined by the virtualization extensions of the CPU
+	KVMExits map[uint32]uint64
+	// VhostKicks are the notifications of the in kernel vhost devices of
+	// the hypervisor, e.g. vhost-net or vhost-vsock
+	VhostKicks uint64
+	// VsockBytesToGuest are the bytes sent to the guest over vsock
+	VsockBytesToGuest uint64
+	// VsockBytesFromGuest are the bytes received from the guest over vsock
+	VsockBytesFromGuest uint64
+}
+
+// key is the key of the counters.
+type key struct {
+	ID  uint32
+	Sub uint32
+}
+
+// Probes are the eBPF programs counting the events of the targets.
+type Probes struct {
+	pids       *ebpf.Map
+	cids       *ebpf.Map
+	kvmExits   *ebpf.Map
+	vhostKicks *ebpf.Map
+	vsockBytes *ebpf.Map
+
+	programs []*ebpf.Program
+	links    []link.Link
+	attached []string
+
+	targets map[string]Target
+	sync.Mutex
+}
+
+// probe is a program to attach.
+type probe struct {
+	name   string
+	typ    ebpf.ProgramType
+	insns  func() (asm.Instructions, error)
+	attach func(*ebpf.Program) (link.Link, error)
+}
+
+func newCountersMap(name string, maxEntries uint32) (*ebpf.Map, error) {
+	return ebpf.NewMap(&ebpf.MapSpec{
+		Name:       name,
+		Type:       ebpf.Hash,
+		KeySize:    8,
+		ValueSize:  8,
+		MaxEntries: maxEntries,
+	})
+}
+
+func newFilterMap(name string) (*ebpf.Map, error) {
+	return ebpf.NewMap(&ebpf.MapSpec{
+		Name:       name,
+		Type:       ebpf.Hash,
+		KeySize:    4,
+		ValueSize:  4,
+		MaxEntries: maxTargets,
+	})
+}
+
+// New loads the programs and attaches those the kernel supports, an error
+// is returned when none of them can be attached.
+func New() (p *Probes, err error) {
+	// The maps are charged to the memlock limit by the kernels older
+	// than 5.11.
+	if err := unix.Setrlimit(unix.RLIMIT_MEMLOCK, &unix.Rlimit{Cur: unix.RLIM_INFINITY, Max: unix.RLIM_INFINITY}); err != nil {
+		probesLog.WithError(err).Warn("failed to remove the memlock limit")
+	}
+
+	p = &Probes{targets: make(map[string]Target)}
+	defer func() {
+		if err != nil {
+			p.Close()
+		}
+	}()
+
+	for name, m := range map[string]**ebpf.Map{"kata_pids": &p.pids, "kata_cids": &p.cids} {
+		if *m, err = newFilterMap(name); err != nil {
+			return nil, fmt.Errorf("create %s map: %w", name, err)
+		}
+	}
+	for name, m := range map[string]**ebpf.Map{"kata_kvm_exits": &p.kvmExits, "kata_vhost_kicks": &p.vhostKicks, "kata_vsock_bytes": &p.vsockBytes} {
+		if *m, err = newCountersMap(name, maxCounters); err != nil {
+			return nil, fmt.Errorf("create %s map: %w", name, err)
+		}
+	}
+
+	for _, pr := range p.probes() {
+		if err := p.load(pr); err != nil {
+			probesLog.WithError(err).WithField("probe", pr.name).Warn("probe not attached")
+			continue
+		}
+		p.attached = append(p.attached, pr.name)
+	}
+	if len(p.attached) == 0 {
+		return nil, errors.New("no host probe could be attached")
+	}
+
+	probesLog.WithField("probes", p.attached).Info("host probes attached")
+	return p, nil
+}
+
+func (p *Probes) probes() []probe {
+	tracepoint := func(group, name string) func(*ebpf.Program) (link.Link, error) {
+		return func(prog *ebpf.Program) (link.Link, error) {
+			return link.Tracepoint(group, name, prog)
+		}
+	}
+	vsock := func(name, cidField string, direction int32) func() (asm.Instructions, error) {
+		return func() (asm.Instructions, error) {
+			fields, err := tracepointFields("vsock", name)
+			if err != nil {
+				return nil, err
+			}
+			cid, err := tracepointField(fields, cidField)
+			if err != nil {
+				return nil, err
+			}
+			length, err := tracepointField(fields, "len")
+			if err != nil {
+				return nil, err
+			}
+			return vsockProgram(p.cids.FD(), p.vsockBytes.FD(), cid, length, direction), nil
+		}
+	}
+
+	return []probe{
+		{
+			name: "kvm:kvm_exit",
+			typ:  ebpf.TracePoint,
+			insns: func() (asm.Instructions, error) {
+				fields, err := tracepointFields("kvm", "kvm_exit")
+				if err != nil {
+					return nil, err
+				}
+				reason, err := tracepointField(fields, "exit_reason")
+				if err != nil {
+					return nil, err
+				}
+				return kvmExitProgram(p.pids.FD(), p.kvmExits.FD(), reason), nil
+			},
+			attach: tracepoint("kvm", "kvm_exit"),
+		},
+		{
+			name: "kprobe:vhost_poll_wakeup",
+			typ:  ebpf.Kprobe,
+			insns: func() (asm.Instructions, error) {
+				return vhostKickProgram(p.pids.FD(), p.vhostKicks.FD()), nil
+			},
+			attach: func(prog *ebpf.Program) (link.Link, error) {
+				return link.Kprobe("vhost_poll_wakeup", prog)
+			},
+		},
+		{
+			name:   "vsock:virtio_transport_alloc_pkt",
+			typ:    ebpf.TracePoint,
+			insns:  vsock("virtio_transport_alloc_pkt", "dst_cid", vsockToGuest),
+			attach: tracepoint("vsock", "virtio_transport_alloc_pkt"),
+		},
+		{
+			name:   "vsock:virtio_transport_recv_pkt",
+			typ:    ebpf.TracePoint,
+			insns:  vsock("virtio_transport_recv_pkt", "src_cid", vsockFromGuest),
+			attach: tracepoint("vsock", "virtio_transport_recv_pkt"),
+		},
+	}
+}
+
+func (p *Probes) load(pr probe) error {
+	insns, err := pr.insns()
+	if err != nil {
+		return err
+	}
+
+	prog, err := ebpf.NewProgram(&ebpf.ProgramSpec{
+		Type:         pr.typ,
+		Instructions: insns,
+		License:      programLicense,
+	})
+	if err != nil {
+		return err
+	}
+	l, err := pr.attach(prog)
+	if err != nil {
+		prog.Close()
+		return err
+	}
+
+	p.programs = append(p.programs, prog)
+	p.links = append(p.links, l)
+	return nil
+}
+
+// Attached returns the names of the probes attached.
+func (p *Probes) Attached() []string {
+	return p.attached
+}
+
+// SetTargets sets the sandboxes whose events are counted, by ID. The
+// counters of the sandboxes which are not targets any more are removed.
+func (p *Probes) SetTargets(targets map[string]Target) error {
+	p.Lock()
+	defer p.Unlock()
+
+	if len(targets) > maxTargets {
+		return fmt.Errorf("%d sandboxes, at most %d can be probed", len(targets), maxTargets)
+	}
+
+	pids := make(map[uint32]bool)
+	cids := make(map[uint32]bool)
+	for _, t := range targets {
+		if t.HypervisorPid > 0 {
+			pids[uint32(t.HypervisorPid)] = true
+		}
+		if t.GuestCID != 0 {
+			cids[t.GuestCID] = true
+		}
+	}
+
+	var errs []error
+	for _, f := range []struct {
+		filter   *ebpf.Map
+		ids      map[uint32]bool
+		counters []*ebpf.Map
+	}{
+		{p.pids, pids, []*ebpf.Map{p.kvmExits, p.vhostKicks}},
+		{p.cids, cids, []*ebpf.Map{p.vsockBytes}},
+	} {
+		if err := syncFilter(f.filter, f.ids); err != nil {
+			errs = append(errs, err)
+		}
+		for _, m := range f.counters {
+			if err := pruneCounters(m, f.ids); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	p.targets = targets
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to set the probed sandboxes: %v", errs)
+	}
+	return nil
+}
+
+// syncFilter sets the IDs of the filter map to ids.
+func syncFilter(filter *ebpf.Map, ids map[uint32]bool) error {
+	var (
+		id, value uint32
+		stale     []uint32
+	)
+
+	entries := filter.Iterate()
+	for entries.Next(&id, &value) {
+		if !ids[id] {
+			stale = append(stale, id)
+		}
+	}
+	if err := entries.Err(); err != nil {
+		return err
+	}
+	for _, id := range stale {
+		if err := filter.Delete(id); err != nil && !errors.Is(err, ebpf.ErrKeyNotExist) {
+			return err
+		}
+	}
+
+	for id := range ids {
+		if err := filter.Put(id, uint32(1)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pruneCounters removes the counters of the IDs which are not in ids.
+func pruneCounters(counters *ebpf.Map, ids map[uint32]bool) error {
+	var (
+		k     key
+		value uint64
+		stale []key
+	)
+
+	entries := counters.Iterate()
+	for entries.Next(&k, &value) {
+		if !ids[k.ID] {
+			stale = append(stale, k)
+		}
+	}
+	if err := entries.Err(); err != nil {
+		return err
+	}
+	for _, k := range stale {
+		if err := counters.Delete(k); err != nil && !errors.Is(err, ebpf.ErrKeyNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Counts returns the counts of the targets by sandbox ID.
+func (p *Probes) Counts() (map[string]*Counts, error) {
+	p.Lock()
+	defer p.Unlock()
+
+	byPid := make(map[uint32]*Counts)
+	byCID := make(map[uint32]*Counts)
+	counts := make(map[string]*Counts)
+	for id, t := range p.targets {
+		c := &Counts{KVMExits: make(map[uint32]uint64)}
+		counts[id] = c
+		if t.HypervisorPid > 0 {
+			byPid[uint32(t.HypervisorPid)] = c
+		}
+		if t.GuestCID != 0 {
+			byCID[t.GuestCID] = c
+		}
+	}
+
+	var (
+		k     key
+		value uint64
+	)
+
+	for _, m := range []struct {
+		counters *ebpf.Map
+		targets  map[uint32]*Counts
+		add      func(c *Counts, sub uint32, value uint64)
+	}{
+		{p.kvmExits, byPid, func(c *Counts, reason uint32, value uint64) { c.KVMExits[reason] += value }},
+		{p.vhostKicks, byPid, func(c *Counts, _ uint32, value uint64) { c.VhostKicks += value }},
+		{p.vsockBytes, byCID, func(c *Counts, direction uint32, value uint64) {
+			if direction == vsockToGuest {
+				c.VsockBytesToGuest += value
+			} else {
+				c.VsockBytesFromGuest += value
+			}
+		}},
+	} {
+		entries := m.counters.Iterate()
+		for entries.Next(&k, &value) {
+			if c, ok := m.targets[k.ID]; ok {
+				m.add(c, k.Sub, value)
+			}
+		}
+		if err := entries.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	return counts, nil
+}
+
+// Close detaches the programs and releases the maps.
+func (p *Probes) Close() {
+	for _, l := range p.links {
+		l.Close()
+	}
+	for _, prog := range p.programs {
+		prog.Close()
+	}
+	for _, m := range []*ebpf.Map{p.pids, p.cids, p.kvmExits, p.vhostKicks, p.vsockBytes} {
+		if m != nil {
+			m.Close()
+		}
+	}
+}
+
+// parseGuestCID returns the guest-cid property of the vhost-vsock device of
+// a QEMU command line, as read from /proc/<pid>/cmdline.
+func parseGuestCID(cmdline []byte) (uint32, bool) {
+	for _, arg := range bytes.Split(cmdline, []byte{0}) {
+		for _, prop := range bytes.Split(arg, []byte(",")) {
+			value := bytes.TrimPrefix(prop, []byte("guest-cid="))
+			if len(value) == len(prop) {
+				continue
+			}
+			cid, err := strconv.ParseUint(string(value), 10, 32)
+			if err != nil {
+				return 0, false
+			}
+			return uint32(cid), true
+		}
+	}
+	return 0, false
+}
+
+// GuestCID returns the vsock context ID of the guest of a hypervisor, 0
+// when it has no vhost-vsock device.
+func GuestCID(hypervisorPid int) uint32 {
+	cmdline, err := os.ReadFile(fmt.Sprintf("/proc/%d/cmdline", hypervisorPid))
+	if err != nil {
+		return 0
+	}
+	cid, _ := parseGuestCID(cmdline)
+	return cid
+}
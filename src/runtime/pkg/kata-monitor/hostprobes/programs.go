@@ -0,0 +1,116 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package hostprobes
+
+import (
+	"github.com/cilium/ebpf/asm"
+)
+
+// The programs store the key of the event on their stack, the ID of the
+// target in its first word and a sub key, e.g. the KVM exit reason, in the
+// second one, and add the value in R7 to the counter of the key when the
+// target is in the filter map. The ID is the thread group ID of the
+// hypervisor, or the vsock context ID of the guest.
+const (
+	stackKey   = -8
+	stackValue = -16
+)
+
+// sizeOf returns the size of a load of n bytes.
+func sizeOf(n int) asm.Size {
+	switch n {
+	case 1:
+		return asm.Byte
+	case 2:
+		return asm.Half
+	case 4:
+		return asm.Word
+	}
+	return asm.DWord
+}
+
+// storeTgid stores the thread group ID of the current task as the target ID.
+func storeTgid() asm.Instructions {
+	return asm.Instructions{
+		asm.FnGetCurrentPidTgid.Call(),
+		asm.RSh.Imm(asm.R0, 32),
+		asm.StoreMem(asm.RFP, stackKey, asm.R0, asm.Word),
+	}
+}
+
+// count adds R7 to the counter of the key of the stack in counters, if the
+// target ID is in filter.
+func count(filter, counters int) asm.Instructions {
+	return asm.Instructions{
+		asm.LoadMapPtr(asm.R1, filter),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, stackKey),
+		asm.FnMapLookupElem.Call(),
+		asm.JEq.Imm(asm.R0, 0, "exit"),
+
+		asm.LoadMapPtr(asm.R1, counters),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, stackKey),
+		asm.FnMapLookupElem.Call(),
+		asm.JEq.Imm(asm.R0, 0, "create"),
+		asm.StoreXAdd(asm.R0, asm.R7, asm.DWord),
+		asm.Ja.Label("exit"),
+
+		// A concurrent creation of the counter may lose an event.
+		asm.StoreMem(asm.RFP, stackValue, asm.R7, asm.DWord).Sym("create"),
+		asm.LoadMapPtr(asm.R1, counters),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, stackKey),
+		asm.Mov.Reg(asm.R3, asm.RFP),
+		asm.Add.Imm(asm.R3, stackValue),
+		asm.Mov.Imm(asm.R4, 0),
+		asm.FnMapUpdateElem.Call(),
+
+		asm.Mov.Imm(asm.R0, 0).Sym("exit"),
+		asm.Return(),
+	}
+}
+
+// kvmExitProgram counts the kvm:kvm_exit events of the hypervisors by exit
+// reason.
+func kvmExitProgram(pids, counters int, reason field) asm.Instructions {
+	insns := asm.Instructions{
+		asm.Mov.Reg(asm.R6, asm.R1),
+	}
+	insns = append(insns, storeTgid()...)
+	insns = append(insns,
+		asm.LoadMem(asm.R1, asm.R6, reason.offset, sizeOf(reason.size)),
+		asm.StoreMem(asm.RFP, stackKey+4, asm.R1, asm.Word),
+		asm.Mov.Imm(asm.R7, 1),
+	)
+	return append(insns, count(pids, counters)...)
+}
+
+// vhostKickProgram counts the calls of vhost_poll_wakeup, i.e. the kicks of
+// the vhost virtqueues, by the hypervisors. The kicks are signalled by KVM
+// from the vCPU threads through the ioeventfds of the virtqueues.
+func vhostKickProgram(pids, counters int) asm.Instructions {
+	insns := storeTgid()
+	insns = append(insns,
+		asm.StoreImm(asm.RFP, stackKey+4, 0, asm.Word),
+		asm.Mov.Imm(asm.R7, 1),
+	)
+	return append(insns, count(pids, counters)...)
+}
+
+// vsockProgram counts the bytes of the vsock packets of a
+// vsock:virtio_transport_* tracepoint by guest context ID, given by the cid
+// field, with direction as the sub key.
+func vsockProgram(cids, counters int, cid, length field, direction int32) asm.Instructions {
+	insns := asm.Instructions{
+		asm.Mov.Reg(asm.R6, asm.R1),
+		asm.LoadMem(asm.R1, asm.R6, cid.offset, sizeOf(cid.size)),
+		asm.StoreMem(asm.RFP, stackKey, asm.R1, asm.Word),
+		asm.StoreImm(asm.RFP, stackKey+4, int64(direction), asm.Word),
+		asm.LoadMem(asm.R7, asm.R6, length.offset, sizeOf(length.size)),
+	}
+	return append(insns, count(cids, counters)...)
+}
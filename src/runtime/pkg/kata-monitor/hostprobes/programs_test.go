@@ -0,0 +1,31 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package hostprobes
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/cilium/ebpf/asm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrograms(t *testing.T) {
+	assert := assert.New(t)
+
+	for name, insns := range map[string]asm.Instructions{
+		"kvm_exit":   kvmExitProgram(3, 4, field{offset: 8, size: 4}),
+		"vhost_kick": vhostKickProgram(3, 4),
+		"vsock_to":   vsockProgram(3, 4, field{offset: 24, size: 4}, field{offset: 32, size: 4}, vsockToGuest),
+		"vsock_from": vsockProgram(3, 4, field{offset: 8, size: 8}, field{offset: 32, size: 4}, vsockFromGuest),
+	} {
+		// The jumps are resolved when the program is encoded.
+		var buf bytes.Buffer
+		assert.NoError(insns.Marshal(&buf, binary.LittleEndian), name)
+		assert.Equal(asm.Return(), insns[len(insns)-1], name)
+	}
+}
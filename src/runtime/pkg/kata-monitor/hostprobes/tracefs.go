@@ -0,0 +1,103 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package hostprobes
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// tracefsDirs are the mount points of tracefs, the debugfs one being used by
+// the older kernels.
+var tracefsDirs = []string{"/sys/kernel/tracing", "/sys/kernel/debug/tracing"}
+
+// field is a field of the record of a tracepoint.
+type field struct {
+	offset int16
+	size   int
+}
+
+// parseFormat parses the format file of a tracepoint, and returns its
+// fields by name.
+func parseFormat(data []byte) (map[string]field, error) {
+	fields := make(map[string]field)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		// field:unsigned int exit_reason;	offset:8;	size:4;	signed:0;
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "field:") {
+			continue
+		}
+
+		var name string
+		var f field
+		for _, part := range strings.Split(line, ";") {
+			part = strings.TrimSpace(part)
+			kv := strings.SplitN(part, ":", 2)
+			if len(kv) != 2 {
+				continue
+			}
+			switch kv[0] {
+			case "field":
+				decl := strings.Fields(kv[1])
+				if len(decl) == 0 {
+					return nil, fmt.Errorf("invalid field %q", line)
+				}
+				// Arrays are declared as name[size]
+				name = strings.SplitN(decl[len(decl)-1], "[", 2)[0]
+			case "offset":
+				offset, err := strconv.ParseInt(kv[1], 10, 16)
+				if err != nil {
+					return nil, fmt.Errorf("invalid field %q: %v", line, err)
+				}
+				f.offset = int16(offset)
+			case "size":
+				size, err := strconv.Atoi(kv[1])
+				if err != nil {
+					return nil, fmt.Errorf("invalid field %q: %v", line, err)
+				}
+				f.size = size
+			}
+		}
+		fields[name] = f
+	}
+
+	return fields, scanner.Err()
+}
+
+// tracepointFields returns the fields of the tracepoint group:name, an
+// error when the tracepoint does not exist, e.g. when the module defining
+// it is not loaded.
+func tracepointFields(group, name string) (map[string]field, error) {
+	var err error
+	for _, dir := range tracefsDirs {
+		var data []byte
+		data, err = os.ReadFile(filepath.Join(dir, "events", group, name, "format"))
+		if err == nil {
+			return parseFormat(data)
+		}
+	}
+	return nil, err
+}
+
+// tracepointField returns the field of fields, which must be an integer.
+func tracepointField(fields map[string]field, name string) (field, error) {
+	f, ok := fields[name]
+	if !ok {
+		return field{}, fmt.Errorf("no %s field", name)
+	}
+	switch f.size {
+	case 1, 2, 4, 8:
+		return f, nil
+	}
+	return field{}, fmt.Errorf("%s field of size %d", name, f.size)
+}
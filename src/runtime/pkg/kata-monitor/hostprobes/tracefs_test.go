@@ -0,0 +1,65 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package hostprobes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const kvmExitFormat = `name: kvm_exit
+ID: 1722
+format:
+	field:unsigned short common_type;	offset:0;	size:2;	signed:0;
+	field:unsigned char common_flags;	offset:2;	size:1;	signed:0;
+	field:unsigned char common_preempt_count;	offset:3;	size:1;	signed:0;
+	field:int common_pid;	offset:4;	size:4;	signed:1;
+
+	field:unsigned int exit_reason;	offset:8;	size:4;	signed:0;
+	field:unsigned long guest_rip;	offset:16;	size:8;	signed:0;
+	field:u32 isa;	offset:24;	size:4;	signed:0;
+	field:u64 info1;	offset:32;	size:8;	signed:0;
+	field:char comm[16];	offset:40;	size:16;	signed:1;
+
+print fmt: "reason %s rip 0x%lx", __print_symbolic(REC->exit_reason, ...), REC->guest_rip
+`
+
+func TestParseFormat(t *testing.T) {
+	assert := assert.New(t)
+
+	fields, err := parseFormat([]byte(kvmExitFormat))
+	assert.NoError(err)
+	assert.Equal(field{offset: 8, size: 4}, fields["exit_reason"])
+	assert.Equal(field{offset: 40, size: 16}, fields["comm"])
+	assert.Len(fields, 9)
+
+	f, err := tracepointField(fields, "guest_rip")
+	assert.NoError(err)
+	assert.Equal(field{offset: 16, size: 8}, f)
+
+	_, err = tracepointField(fields, "comm")
+	assert.Error(err)
+	_, err = tracepointField(fields, "dst_cid")
+	assert.Error(err)
+
+	_, err = parseFormat([]byte("\tfield:unsigned int len;\toffset:eight;\tsize:4;\n"))
+	assert.Error(err)
+}
+
+func TestParseGuestCID(t *testing.T) {
+	assert := assert.New(t)
+
+	cmdline := []byte("/usr/bin/qemu-system-x86_64\x00-device\x00vhost-vsock-pci,disable-modern=false,vhostfd=3,id=vsock-1,guest-cid=1563,romfile=\x00")
+	cid, ok := parseGuestCID(cmdline)
+	assert.True(ok)
+	assert.Equal(uint32(1563), cid)
+
+	_, ok = parseGuestCID([]byte("/usr/bin/cloud-hypervisor\x00--vsock\x00cid=3,socket=/run/vc/vm/id/clh.sock\x00"))
+	assert.False(ok)
+	_, ok = parseGuestCID([]byte("qemu\x00-device\x00vhost-vsock-pci,guest-cid=x\x00"))
+	assert.False(ok)
+}
@@ -0,0 +1,308 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package katamonitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	containerdshim "github.com/kata-containers/kata-containers/src/runtime/pkg/containerd-shim-v2"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/utils/shimclient"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	hungShimInfoFile = "info.json"
+
+	// Probing more often would add load to the shims for no benefit.
+	minHungShimProbeInterval = 10 * time.Second
+)
+
+// hungShimProfiles are the shim debug endpoints collected when a shim stops
+// responding, along with the file they are stored to. The pprof ones are
+// only served when pprof is enabled for the sandbox.
+var hungShimProfiles = []struct {
+	url  string
+	file string
+}{
+	{containerdshim.GoroutinesUrl, "goroutines.txt"},
+	{"/debug/pprof/heap", "heap.pb.gz"},
+	{"/debug/pprof/block", "block.pb.gz"},
+	{"/debug/pprof/mutex", "mutex.pb.gz"},
+	{"/debug/pprof/threadcreate?debug=1", "threadcreate.txt"},
+}
+
+var hungShimDumpCount = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: promNamespaceMonitor,
+	Name:      "hung_shim_dumps_total",
+	Help:      "Number of dumps collected from shims not responding on their metrics endpoint.",
+})
+
+// HungShimConfig configures the detection of the shims whose metrics
+// endpoint stops responding, and the collection of their goroutine dumps.
+type HungShimConfig struct {
+	// DumpDir is where the dumps are stored, one sub directory per dump.
+	// The detection is disabled when it is empty.
+	DumpDir string
+
+	// ProbeInterval is the delay between two probes of a shim.
+	ProbeInterval time.Duration
+
+	// ProbeTimeout bounds the duration of a probe.
+	ProbeTimeout time.Duration
+
+	// FailureThreshold is the number of consecutive failed probes after
+	// which a shim is considered hung.
+	FailureThreshold int
+
+	// MaxDumps is the number of dumps kept in DumpDir, the oldest ones
+	// are removed first. Dumps are not removed when it is 0.
+	MaxDumps int
+}
+
+// hungShimInfo describes a dump, it is stored along with the profiles.
+type hungShimInfo struct {
+	Time       time.Time `json:"time"`
+	SandboxID  string    `json:"sandbox_id"`
+	Name       string    `json:"name,omitempty"`
+	Namespace  string    `json:"namespace,omitempty"`
+	ProbeError string    `json:"probe_error"`
+	Failures   int       `json:"failures"`
+	Missing    []string  `json:"missing,omitempty"`
+}
+
+// hungShimDetector probes the shims of the sandbox cache.
+type hungShimDetector struct {
+	config   HungShimConfig
+	cache    *sandboxCache
+	failures map[string]int
+	// overridden in tests
+	probe func(sandboxID string, timeout time.Duration) error
+	fetch func(sandboxID string, timeout time.Duration, url string) ([]byte, error)
+	now   func() time.Time
+}
+
+func newHungShimDetector(config HungShimConfig, cache *sandboxCache) *hungShimDetector {
+	return &hungShimDetector{
+		config:   config,
+		cache:    cache,
+		failures: make(map[string]int),
+		probe:    probeShim,
+		fetch:    fetchShimProfile,
+		now:      time.Now,
+	}
+}
+
+// probeShim checks that the shim metrics endpoint responds in time.
+func probeShim(sandboxID string, timeout time.Duration) error {
+	_, err := fetchShimProfile(sandboxID, timeout, containerdshim.MetricsUrl)
+	return err
+}
+
+func fetchShimProfile(sandboxID string, timeout time.Duration, url string) ([]byte, error) {
+	client, err := shimclient.BuildShimClient(sandboxID, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Get(fmt.Sprintf("http://shim%s", url))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d for %s", resp.StatusCode, url)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// StartHungShimDetector starts probing the shims of the node, a dump is
+// collected from the shims that stop responding.
+func (km *KataMonitor) StartHungShimDetector(config HungShimConfig) error {
+	if config.DumpDir == "" {
+		return nil
+	}
+	if config.ProbeInterval < minHungShimProbeInterval {
+		return fmt.Errorf("hung shim probe interval must be at least %v", minHungShimProbeInterval)
+	}
+	if config.ProbeTimeout <= 0 || config.ProbeTimeout >= config.ProbeInterval {
+		return fmt.Errorf("hung shim probe timeout must be positive and lower than the probe interval")
+	}
+	if config.FailureThreshold < 1 {
+		return fmt.Errorf("hung shim failure threshold must be at least 1")
+	}
+	if config.MaxDumps < 0 {
+		return fmt.Errorf("hung shim max dumps cannot be negative")
+	}
+
+	if err := os.MkdirAll(config.DumpDir, 0700); err != nil {
+		return err
+	}
+
+	prometheus.MustRegister(hungShimDumpCount)
+
+	d := newHungShimDetector(config, km.sandboxCache)
+	go func() {
+		ticker := time.NewTicker(config.ProbeInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			d.probeAll()
+		}
+	}()
+
+	return nil
+}
+
+// probeAll probes the shims concurrently, so that hung shims do not delay
+// the detection of the others.
+func (d *hungShimDetector) probeAll() {
+	sandboxes := d.cache.getSandboxes()
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		results = make(map[string]error, len(sandboxes))
+	)
+
+	for id := range sandboxes {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			err := d.probe(id, d.config.ProbeTimeout)
+			mu.Lock()
+			results[id] = err
+			mu.Unlock()
+		}(id)
+	}
+	wg.Wait()
+
+	// forget the sandboxes that are gone
+	for id := range d.failures {
+		if _, ok := sandboxes[id]; !ok {
+			delete(d.failures, id)
+		}
+	}
+
+	for id, err := range results {
+		if err == nil {
+			delete(d.failures, id)
+			continue
+		}
+
+		d.failures[id]++
+		failures := d.failures[id]
+		monitorLog.WithError(err).WithField("sandbox", id).WithField("failures", failures).Debug("shim probe failed")
+
+		// one dump per hang
+		if failures != d.config.FailureThreshold {
+			continue
+		}
+
+		dir, err := d.collect(id, sandboxes[id], err, failures)
+		if err != nil {
+			monitorLog.WithError(err).WithField("sandbox", id).Error("failed to collect hung shim dump")
+			continue
+		}
+		hungShimDumpCount.Inc()
+		monitorLog.WithField("sandbox", id).WithField("dump", dir).Warn("shim is not responding, dump collected")
+
+		if err := pruneHungShimDumps(d.config.DumpDir, d.config.MaxDumps); err != nil {
+			monitorLog.WithError(err).Warn("failed to prune hung shim dumps")
+		}
+	}
+}
+
+// collect stores the profiles the shim still serves in a new dump
+// directory, named after the sandbox and the time of the dump.
+func (d *hungShimDetector) collect(id string, metadata sandboxCRIMetadata, probeErr error, failures int) (string, error) {
+	now := d.now()
+	dir := filepath.Join(d.config.DumpDir, fmt.Sprintf("%s-%s", id, now.UTC().Format("20060102T150405Z")))
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+
+	info := hungShimInfo{
+		Time:       now,
+		SandboxID:  id,
+		Name:       metadata.name,
+		Namespace:  metadata.namespace,
+		ProbeError: probeErr.Error(),
+		Failures:   failures,
+	}
+
+	for _, p := range hungShimProfiles {
+		data, err := d.fetch(id, d.config.ProbeTimeout, p.url)
+		if err != nil {
+			info.Missing = append(info.Missing, fmt.Sprintf("%s: %v", p.url, err))
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(dir, p.file), data, 0600); err != nil {
+			return dir, err
+		}
+	}
+
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return dir, err
+	}
+
+	return dir, os.WriteFile(filepath.Join(dir, hungShimInfoFile), data, 0600)
+}
+
+// pruneHungShimDumps keeps the maxDumps most recent dumps of dir.
+func pruneHungShimDumps(dir string, maxDumps int) error {
+	if maxDumps == 0 {
+		return nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	type dump struct {
+		modTime time.Time
+		name    string
+	}
+
+	var dumps []dump
+	for _, e := range entries {
+		if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		dumps = append(dumps, dump{modTime: info.ModTime(), name: e.Name()})
+	}
+
+	if len(dumps) <= maxDumps {
+		return nil
+	}
+
+	sort.Slice(dumps, func(i, j int) bool {
+		return dumps[i].modTime.Before(dumps[j].modTime)
+	})
+
+	for _, dump := range dumps[:len(dumps)-maxDumps] {
+		if err := os.RemoveAll(filepath.Join(dir, dump.name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
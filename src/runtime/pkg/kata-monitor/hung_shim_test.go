@@ -0,0 +1,156 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package katamonitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	containerdshim "github.com/kata-containers/kata-containers/src/runtime/pkg/containerd-shim-v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHungShimDetector(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+
+	cache := &sandboxCache{
+		Mutex: &sync.Mutex{},
+		sandboxes: map[string]sandboxCRIMetadata{
+			"hung":    {name: "web", namespace: "default"},
+			"healthy": {},
+		},
+	}
+
+	d := newHungShimDetector(HungShimConfig{
+		DumpDir:          dir,
+		ProbeTimeout:     time.Second,
+		FailureThreshold: 2,
+	}, cache)
+
+	hung := true
+	d.probe = func(sandboxID string, timeout time.Duration) error {
+		if sandboxID == "hung" && hung {
+			return fmt.Errorf("context deadline exceeded")
+		}
+		return nil
+	}
+	d.fetch = func(sandboxID string, timeout time.Duration, url string) ([]byte, error) {
+		assert.Equal("hung", sandboxID)
+		if url == containerdshim.GoroutinesUrl {
+			return []byte("goroutine 1 [select]:"), nil
+		}
+		return nil, fmt.Errorf("unexpected status 404 for %s", url)
+	}
+	now := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	d.now = func() time.Time { return now }
+
+	dumps := func() []string {
+		entries, err := os.ReadDir(dir)
+		assert.NoError(err)
+		var names []string
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		return names
+	}
+
+	// below the threshold
+	d.probeAll()
+	assert.Empty(dumps())
+
+	d.probeAll()
+	assert.Equal([]string{"hung-20230601T120000Z"}, dumps())
+
+	dump := filepath.Join(dir, "hung-20230601T120000Z")
+	data, err := os.ReadFile(filepath.Join(dump, "goroutines.txt"))
+	assert.NoError(err)
+	assert.Equal("goroutine 1 [select]:", string(data))
+
+	data, err = os.ReadFile(filepath.Join(dump, hungShimInfoFile))
+	assert.NoError(err)
+	var info hungShimInfo
+	assert.NoError(json.Unmarshal(data, &info))
+	assert.Equal("hung", info.SandboxID)
+	assert.Equal("web", info.Name)
+	assert.Equal(2, info.Failures)
+	assert.Len(info.Missing, len(hungShimProfiles)-1)
+
+	// a single dump per hang
+	now = now.Add(time.Minute)
+	d.probeAll()
+	assert.Len(dumps(), 1)
+
+	// the shim recovers, then hangs again
+	hung = false
+	d.probeAll()
+	hung = true
+	d.probeAll()
+	d.probeAll()
+	assert.Equal([]string{"hung-20230601T120000Z", "hung-20230601T120100Z"}, dumps())
+
+	// forget removed sandboxes
+	cache.deleteIfExists("hung")
+	d.probeAll()
+	assert.NotContains(d.failures, "hung")
+}
+
+func TestPruneHungShimDumps(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+
+	start := time.Now().Add(-time.Hour)
+	for i := 0; i < 4; i++ {
+		dump := filepath.Join(dir, fmt.Sprintf("dump-%d", i))
+		assert.NoError(os.Mkdir(dump, 0700))
+		mtime := start.Add(time.Duration(i) * time.Minute)
+		assert.NoError(os.Chtimes(dump, mtime, mtime))
+	}
+
+	assert.NoError(pruneHungShimDumps(dir, 0))
+	entries, err := os.ReadDir(dir)
+	assert.NoError(err)
+	assert.Len(entries, 4)
+
+	assert.NoError(pruneHungShimDumps(dir, 2))
+	entries, err = os.ReadDir(dir)
+	assert.NoError(err)
+	assert.Len(entries, 2)
+	assert.Equal("dump-2", entries[0].Name())
+	assert.Equal("dump-3", entries[1].Name())
+}
+
+func TestStartHungShimDetectorConfig(t *testing.T) {
+	assert := assert.New(t)
+	km := &KataMonitor{}
+
+	// disabled
+	assert.NoError(km.StartHungShimDetector(HungShimConfig{}))
+
+	valid := HungShimConfig{
+		DumpDir:          t.TempDir(),
+		ProbeInterval:    30 * time.Second,
+		ProbeTimeout:     10 * time.Second,
+		FailureThreshold: 3,
+	}
+
+	for _, update := range []func(c *HungShimConfig){
+		func(c *HungShimConfig) { c.ProbeInterval = time.Second },
+		func(c *HungShimConfig) { c.ProbeTimeout = 0 },
+		func(c *HungShimConfig) { c.ProbeTimeout = c.ProbeInterval },
+		func(c *HungShimConfig) { c.FailureThreshold = 0 },
+		func(c *HungShimConfig) { c.MaxDumps = -1 },
+	} {
+		config := valid
+		update(&config)
+		assert.Error(km.StartHungShimDetector(config), "%+v", config)
+	}
+}
@@ -0,0 +1,273 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package katamonitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"github.com/prometheus/procfs"
+)
+
+const (
+	contentTypeJSON = "application/json"
+
+	sandboxPersistFile = "persist.json"
+)
+
+// SandboxInfo describes a Kata sandbox of the node.
+type SandboxInfo struct {
+	Labels         map[string]string `json:"labels,omitempty"`
+	ID             string            `json:"id"`
+	Name           string            `json:"name,omitempty"`
+	Namespace      string            `json:"namespace,omitempty"`
+	UID            string            `json:"uid,omitempty"`
+	RuntimeHandler string            `json:"runtime_handler,omitempty"`
+	State          string            `json:"state,omitempty"`
+	HypervisorType string            `json:"hypervisor_type,omitempty"`
+	HypervisorPid  int               `json:"hypervisor_pid,omitempty"`
+	// Resource usage of the hypervisor process
+	UptimeSeconds       float64 `json:"uptime_seconds,omitempty"`
+	CPUSeconds          float64 `json:"cpu_seconds,omitempty"`
+	ResidentMemoryBytes int     `json:"resident_memory_bytes,omitempty"`
+}
+
+// SandboxInventoryEvent is a change of the sandbox inventory, streamed in
+// watch mode.
+type SandboxInventoryEvent struct {
+	Type    string      `json:"type"`
+	Sandbox SandboxInfo `json:"sandbox"`
+}
+
+// labelRequirement is a term of a label selector, i.e. key=value,
+// key!=value, key or !key.
+type labelRequirement struct {
+	key      string
+	value    string
+	hasValue bool
+	negated  bool
+}
+
+// parseLabelSelector parses a comma separated list of Kubernetes equality
+// based label requirements.
+func parseLabelSelector(selector string) ([]labelRequirement, error) {
+	var requirements []labelRequirement
+
+	for _, term := range strings.Split(selector, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		var r labelRequirement
+		switch {
+		case strings.Contains(term, "!="):
+			kv := strings.SplitN(term, "!=", 2)
+			r = labelRequirement{key: kv[0], value: kv[1], hasValue: true, negated: true}
+		case strings.Contains(term, "=="):
+			kv := strings.SplitN(term, "==", 2)
+			r = labelRequirement{key: kv[0], value: kv[1], hasValue: true}
+		case strings.Contains(term, "="):
+			kv := strings.SplitN(term, "=", 2)
+			r = labelRequirement{key: kv[0], value: kv[1], hasValue: true}
+		case strings.HasPrefix(term, "!"):
+			r = labelRequirement{key: strings.TrimPrefix(term, "!"), negated: true}
+		default:
+			r = labelRequirement{key: term}
+		}
+
+		r.key = strings.TrimSpace(r.key)
+		r.value = strings.TrimSpace(r.value)
+		if r.key == "" {
+			return nil, fmt.Errorf("invalid label selector term %q", term)
+		}
+		requirements = append(requirements, r)
+	}
+
+	return requirements, nil
+}
+
+func (r labelRequirement) matches(labels map[string]string) bool {
+	value, found := labels[r.key]
+	if !r.hasValue {
+		return found != r.negated
+	}
+	return (found && value == r.value) != r.negated
+}
+
+// sandboxFilter selects the sandboxes of the inventory.
+type sandboxFilter struct {
+	namespace string
+	labels    []labelRequirement
+}
+
+func newSandboxFilter(r *http.Request) (sandboxFilter, error) {
+	query := r.URL.Query()
+	labels, err := parseLabelSelector(query.Get("labelSelector"))
+	if err != nil {
+		return sandboxFilter{}, err
+	}
+
+	return sandboxFilter{
+		namespace: query.Get("namespace"),
+		labels:    labels,
+	}, nil
+}
+
+func (f sandboxFilter) matches(metadata sandboxCRIMetadata) bool {
+	if f.namespace != "" && f.namespace != metadata.namespace {
+		return false
+	}
+	for _, r := range f.labels {
+		if !r.matches(metadata.labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// getSandboxInfo completes the CRI metadata of a sandbox with the state
+// persisted by its runtime and the usage of its hypervisor process.
+func getSandboxInfo(sandboxesDir, id string, metadata sandboxCRIMetadata) SandboxInfo {
+	info := SandboxInfo{
+		ID:             id,
+		Name:           metadata.name,
+		Namespace:      metadata.namespace,
+		UID:            metadata.uid,
+		Labels:         metadata.labels,
+		RuntimeHandler: metadata.runtimeHandler,
+	}
+
+	data, err := os.ReadFile(filepath.Join(sandboxesDir, id, sandboxPersistFile))
+	if err != nil {
+		monitorLog.WithError(err).WithField("sandbox_id", id).Debug("failed to read sandbox state")
+		return info
+	}
+
+	var state persistapi.SandboxState
+	if err := json.Unmarshal(data, &state); err != nil {
+		monitorLog.WithError(err).WithField("sandbox_id", id).Warn("failed to parse sandbox state")
+		return info
+	}
+
+	info.State = state.State
+	info.HypervisorType = state.HypervisorState.Type
+	info.HypervisorPid = state.HypervisorState.Pid
+	if info.HypervisorPid <= 0 {
+		return info
+	}
+
+	proc, err := procfs.NewProc(info.HypervisorPid)
+	if err != nil {
+		return info
+	}
+	stat, err := proc.Stat()
+	if err != nil {
+		return info
+	}
+
+	info.CPUSeconds = stat.CPUTime()
+	info.ResidentMemoryBytes = stat.ResidentMemory()
+	if startTime, err := stat.StartTime(); err == nil {
+		info.UptimeSeconds = float64(time.Now().Unix()) - startTime
+	}
+
+	return info
+}
+
+// SandboxInventory lists the sandboxes of the node as JSON, filtered by
+// namespace and labelSelector. With watch=true, the changes are then
+// streamed as JSON events, one per line.
+func (km *KataMonitor) SandboxInventory(w http.ResponseWriter, r *http.Request) {
+	filter, err := newSandboxFilter(r)
+	if err != nil {
+		commonServeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if r.URL.Query().Get("watch") != "true" {
+		sandboxes := km.sandboxCache.getSandboxes()
+		inventory := []SandboxInfo{}
+		for id, metadata := range sandboxes {
+			if filter.matches(metadata) {
+				inventory = append(inventory, getSandboxInfo(getSandboxFS(), id, metadata))
+			}
+		}
+		sort.Slice(inventory, func(i, j int) bool {
+			return inventory[i].ID < inventory[j].ID
+		})
+
+		w.Header().Set("Content-Type", contentTypeJSON)
+		json.NewEncoder(w).Encode(inventory)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		commonServeError(w, http.StatusInternalServerError, fmt.Errorf("streaming not supported"))
+		return
+	}
+
+	sandboxes, events := km.sandboxCache.watch()
+	defer km.sandboxCache.unwatch(events)
+
+	w.Header().Set("Content-Type", contentTypeJSON)
+	encoder := json.NewEncoder(w)
+
+	send := func(eventType sandboxEventType, id string, metadata sandboxCRIMetadata) error {
+		if !filter.matches(metadata) {
+			return nil
+		}
+
+		info := SandboxInfo{ID: id}
+		if eventType == sandboxDeleted {
+			info.Name = metadata.name
+			info.Namespace = metadata.namespace
+			info.UID = metadata.uid
+		} else {
+			info = getSandboxInfo(getSandboxFS(), id, metadata)
+		}
+
+		if err := encoder.Encode(SandboxInventoryEvent{Type: string(eventType), Sandbox: info}); err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	}
+
+	ids := make([]string, 0, len(sandboxes))
+	for id := range sandboxes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if err := send(sandboxAdded, id, sandboxes[id]); err != nil {
+			return
+		}
+	}
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				// fell behind, the client has to list again
+				return
+			}
+			if err := send(event.eventType, event.id, event.metadata); err != nil {
+				return
+			}
+		}
+	}
+}
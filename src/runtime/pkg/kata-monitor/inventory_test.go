@@ -0,0 +1,135 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package katamonitor
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	hv "github.com/kata-containers/kata-containers/src/runtime/pkg/hypervisors"
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLabelSelector(t *testing.T) {
+	assert := assert.New(t)
+
+	labels := map[string]string{"app": "web", "tier": "front"}
+
+	data := []struct {
+		selector string
+		matches  bool
+		valid    bool
+	}{
+		{"", true, true},
+		{"app=web", true, true},
+		{"app==web,tier=front", true, true},
+		{"app=db", false, true},
+		{"app!=db", true, true},
+		{"tier!=front", false, true},
+		{"app", true, true},
+		{"!app", false, true},
+		{"!owner", true, true},
+		{"app=web, owner", false, true},
+		{"=web", false, false},
+	}
+
+	for _, d := range data {
+		requirements, err := parseLabelSelector(d.selector)
+		if !d.valid {
+			assert.Error(err, "%+v", d)
+			continue
+		}
+		assert.NoError(err, "%+v", d)
+
+		filter := sandboxFilter{labels: requirements}
+		assert.Equal(d.matches, filter.matches(sandboxCRIMetadata{labels: labels}), "%+v", d)
+	}
+
+	filter := sandboxFilter{namespace: "default"}
+	assert.True(filter.matches(sandboxCRIMetadata{namespace: "default"}))
+	assert.False(filter.matches(sandboxCRIMetadata{namespace: "kube-system"}))
+}
+
+func TestGetSandboxInfo(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+
+	metadata := sandboxCRIMetadata{
+		uid:            "1-2-3",
+		name:           "pod",
+		namespace:      "default",
+		runtimeHandler: "kata",
+	}
+
+	// no persisted state
+	info := getSandboxInfo(dir, "sandbox", metadata)
+	assert.Equal(SandboxInfo{
+		ID:             "sandbox",
+		Name:           "pod",
+		Namespace:      "default",
+		UID:            "1-2-3",
+		RuntimeHandler: "kata",
+	}, info)
+
+	state := persistapi.SandboxState{
+		State: "running",
+		HypervisorState: hv.HypervisorState{
+			Type: "qemu",
+			Pid:  os.Getpid(),
+		},
+	}
+	data, err := json.Marshal(state)
+	assert.NoError(err)
+	assert.NoError(os.MkdirAll(filepath.Join(dir, "sandbox"), 0700))
+	assert.NoError(os.WriteFile(filepath.Join(dir, "sandbox", sandboxPersistFile), data, 0600))
+
+	info = getSandboxInfo(dir, "sandbox", metadata)
+	assert.Equal("running", info.State)
+	assert.Equal("qemu", info.HypervisorType)
+	assert.Equal(os.Getpid(), info.HypervisorPid)
+	assert.Greater(info.ResidentMemoryBytes, 0)
+}
+
+func TestSandboxCacheWatch(t *testing.T) {
+	assert := assert.New(t)
+	sc := &sandboxCache{
+		Mutex:     &sync.Mutex{},
+		sandboxes: map[string]sandboxCRIMetadata{"111": {name: "test-name"}},
+	}
+
+	sandboxes, events := sc.watch()
+	assert.Len(sandboxes, 1)
+
+	sc.putIfNotExists("222", sandboxCRIMetadata{})
+	sc.setCRIMetadata("222", sandboxCRIMetadata{name: "pod"})
+	sc.deleteIfExists("111")
+
+	for _, expected := range []sandboxEvent{
+		{eventType: sandboxAdded, id: "222"},
+		{eventType: sandboxModified, id: "222", metadata: sandboxCRIMetadata{name: "pod"}},
+		{eventType: sandboxDeleted, id: "111", metadata: sandboxCRIMetadata{name: "test-name"}},
+	} {
+		assert.Equal(expected, <-events)
+	}
+
+	sc.unwatch(events)
+	_, ok := <-events
+	assert.False(ok)
+
+	// a watcher falling behind is dropped
+	_, events = sc.watch()
+	for i := 0; i <= sandboxWatcherBufferSize; i++ {
+		sc.setCRIMetadata("222", sandboxCRIMetadata{})
+	}
+	for range events {
+	}
+	assert.Empty(sc.watchers)
+	sc.unwatch(events)
+}
@@ -95,7 +95,8 @@ func (km *KataMonitor) ProcessMetricsRequest(w http.ResponseWriter, r *http.Requ
 	// if no sandbox provided, will get all sandbox's metrics.
 
 	// prepare writer for writing response.
-	contentType := expfmt.Negotiate(r.Header)
+	// OpenMetrics is the only text format carrying the exemplars
+	contentType := expfmt.NegotiateIncludingOpenMetrics(r.Header)
 
 	// set response header
 	header := w.Header()
@@ -121,6 +122,9 @@ func (km *KataMonitor) ProcessMetricsRequest(w http.ResponseWriter, r *http.Requ
 
 	// create encoder to encode metrics.
 	encoder := expfmt.NewEncoder(writer, contentType)
+	if closer, ok := encoder.(expfmt.Closer); ok {
+		defer closer.Close()
+	}
 
 	if len(filterFamilies) == 0 {
 		// gather metrics collected for management agent.
@@ -259,12 +263,15 @@ func (km *KataMonitor) aggregateSandboxMetrics(encoder expfmt.Encoder, filterFam
 }
 
 func getParsedMetrics(sandboxID string, sandboxMetadata sandboxCRIMetadata) ([]*dto.MetricFamily, error) {
-	body, err := shimclient.DoGet(sandboxID, defaultTimeout, containerdshim.MetricsUrl)
+	// Ask for the protobuf format to get the exemplars of the shim
+	header := http.Header{}
+	header.Set("Accept", string(expfmt.FmtProtoDelim))
+	body, respHeader, err := shimclient.DoGetWithHeader(sandboxID, defaultTimeout, containerdshim.MetricsUrl, header)
 	if err != nil {
 		return nil, err
 	}
 
-	return parsePrometheusMetrics(sandboxID, sandboxMetadata, body)
+	return parsePrometheusMetrics(sandboxID, sandboxMetadata, body, expfmt.ResponseFormat(respHeader))
 }
 
 // GetSandboxMetrics will get sandbox's metrics from shim
@@ -277,11 +284,11 @@ func GetSandboxMetrics(sandboxID string) (string, error) {
 	return string(body), nil
 }
 
-// parsePrometheusMetrics will decode metrics from Prometheus text format
+// parsePrometheusMetrics will decode metrics from Prometheus text or protobuf format
 // and return array of *dto.MetricFamily with an ASC order
-func parsePrometheusMetrics(sandboxID string, sandboxMetadata sandboxCRIMetadata, body []byte) ([]*dto.MetricFamily, error) {
+func parsePrometheusMetrics(sandboxID string, sandboxMetadata sandboxCRIMetadata, body []byte, format expfmt.Format) ([]*dto.MetricFamily, error) {
 	reader := bytes.NewReader(body)
-	decoder := expfmt.NewDecoder(reader, expfmt.FmtText)
+	decoder := expfmt.NewDecoder(reader, format)
 
 	// decode metrics from sandbox to MetricFamily
 	list := make([]*dto.MetricFamily, 0)
@@ -40,10 +40,10 @@ ttt 999
 func TestParsePrometheusMetrics(t *testing.T) {
 	assert := assert.New(t)
 	sandboxID := "sandboxID-abc"
-	sandboxMetadata := sandboxCRIMetadata{"123", "pod-name", "pod-namespace"}
+	sandboxMetadata := sandboxCRIMetadata{uid: "123", name: "pod-name", namespace: "pod-namespace"}
 
 	// parse metrics
-	list, err := parsePrometheusMetrics(sandboxID, sandboxMetadata, []byte(shimMetricBody))
+	list, err := parsePrometheusMetrics(sandboxID, sandboxMetadata, []byte(shimMetricBody), expfmt.FmtText)
 	assert.Nil(err, "parsePrometheusMetrics should not return error")
 
 	assert.Equal(4, len(list), "should return 3 metric families")
@@ -145,3 +145,44 @@ func TestEncodeMetricFamily(t *testing.T) {
 		}
 	}
 }
+
+func TestParsePrometheusMetricsExemplars(t *testing.T) {
+	assert := assert.New(t)
+	sandboxMetadata := sandboxCRIMetadata{uid: "123", name: "pod-name", namespace: "pod-namespace"}
+
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "kata_shim_rpc_durations_histogram_milliseconds",
+		Help:    "RPC latency distributions.",
+		Buckets: prometheus.ExponentialBuckets(1, 2, 10),
+	})
+	histogram.(prometheus.ExemplarObserver).ObserveWithExemplar(3, prometheus.Labels{"correlation_id": "abc"})
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(histogram)
+	mfs, err := registry.Gather()
+	assert.NoError(err)
+
+	// the exemplars are only kept by the protobuf format
+	body := bytes.Buffer{}
+	encoder := expfmt.NewEncoder(&body, expfmt.FmtProtoDelim)
+	for _, mf := range mfs {
+		assert.NoError(encoder.Encode(mf))
+	}
+
+	list, err := parsePrometheusMetrics("sandboxID-abc", sandboxMetadata, body.Bytes(), expfmt.FmtProtoDelim)
+	assert.NoError(err)
+	assert.Len(list, 1)
+
+	m := list[0].Metric[0]
+	assert.Equal("sandbox_id", m.Label[0].GetName())
+
+	var exemplars int
+	for _, b := range m.Histogram.Bucket {
+		if b.Exemplar != nil {
+			exemplars++
+			assert.Equal("correlation_id", b.Exemplar.Label[0].GetName())
+			assert.Equal("abc", b.Exemplar.Label[0].GetValue())
+		}
+	}
+	assert.Equal(1, exemplars)
+}
@@ -9,11 +9,13 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
 	"sync"
 	"time"
 
+	containerdshim "github.com/kata-containers/kata-containers/src/runtime/pkg/containerd-shim-v2"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/utils/shimclient"
 
 	"github.com/fsnotify/fsnotify"
@@ -192,6 +194,32 @@ func (km *KataMonitor) GetAgentURL(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprintln(w, string(data))
 }
 
+// GetVolumeStats returns the stats of the volumes of a container of a sandbox,
+// as the JSON served by the shim.
+func (km *KataMonitor) GetVolumeStats(w http.ResponseWriter, r *http.Request) {
+	sandboxID, err := getSandboxIDFromReq(r)
+	if err != nil {
+		commonServeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	containerID := r.URL.Query().Get(containerdshim.ContainerKey)
+	if containerID == "" {
+		commonServeError(w, http.StatusBadRequest, fmt.Errorf("%s not found in %+v", containerdshim.ContainerKey, r.URL.Query()))
+		return
+	}
+
+	data, err := shimclient.DoGet(sandboxID, defaultTimeout,
+		fmt.Sprintf("%s?%s=%s", containerdshim.VolumeStatsUrl, containerdshim.ContainerKey, url.QueryEscape(containerID)))
+	if err != nil {
+		commonServeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
 // ListSandboxes list all sandboxes running in Kata
 func (km *KataMonitor) ListSandboxes(w http.ResponseWriter, r *http.Request) {
 	sandboxes := km.sandboxCache.getSandboxList()
@@ -10,14 +10,37 @@ import (
 )
 
 type sandboxCRIMetadata struct {
-	uid       string
-	name      string
-	namespace string
+	labels         map[string]string
+	uid            string
+	name           string
+	namespace      string
+	runtimeHandler string
 }
+
+// sandboxEventType is the type of a change of the sandbox cache
+type sandboxEventType string
+
+const (
+	sandboxAdded    sandboxEventType = "ADDED"
+	sandboxModified sandboxEventType = "MODIFIED"
+	sandboxDeleted  sandboxEventType = "DELETED"
+
+	// events buffered for a watcher, a watcher falling behind is dropped
+	sandboxWatcherBufferSize = 64
+)
+
+type sandboxEvent struct {
+	eventType sandboxEventType
+	id        string
+	metadata  sandboxCRIMetadata
+}
+
 type sandboxCache struct {
 	*sync.Mutex
 	// the sandboxCRIMetadata links the sandbox id from the container manager to the pod metadata of kubernetes
 	sandboxes map[string]sandboxCRIMetadata
+	// watchers get the changes of the sandbox cache
+	watchers map[chan sandboxEvent]struct{}
 }
 
 func (sc *sandboxCache) getSandboxList() []string {
@@ -34,8 +57,9 @@ func (sc *sandboxCache) deleteIfExists(id string) bool {
 	sc.Lock()
 	defer sc.Unlock()
 
-	if _, found := sc.sandboxes[id]; found {
+	if metadata, found := sc.sandboxes[id]; found {
 		delete(sc.sandboxes, id)
+		sc.notify(sandboxDeleted, id, metadata)
 		return true
 	}
 
@@ -49,6 +73,7 @@ func (sc *sandboxCache) putIfNotExists(id string, value sandboxCRIMetadata) bool
 
 	if _, found := sc.sandboxes[id]; !found {
 		sc.sandboxes[id] = value
+		sc.notify(sandboxAdded, id, value)
 		return true
 	}
 
@@ -61,6 +86,7 @@ func (sc *sandboxCache) setCRIMetadata(id string, value sandboxCRIMetadata) {
 	defer sc.Unlock()
 
 	sc.sandboxes[id] = value
+	sc.notify(sandboxModified, id, value)
 }
 
 func (sc *sandboxCache) getCRIMetadata(id string) (sandboxCRIMetadata, bool) {
@@ -70,3 +96,58 @@ func (sc *sandboxCache) getCRIMetadata(id string) (sandboxCRIMetadata, bool) {
 	metadata, ok := sc.sandboxes[id]
 	return metadata, ok
 }
+
+// getSandboxes returns a copy of the sandbox cache.
+func (sc *sandboxCache) getSandboxes() map[string]sandboxCRIMetadata {
+	sc.Lock()
+	defer sc.Unlock()
+
+	sandboxes := make(map[string]sandboxCRIMetadata, len(sc.sandboxes))
+	for id, metadata := range sc.sandboxes {
+		sandboxes[id] = metadata
+	}
+	return sandboxes
+}
+
+// watch returns the current sandboxes along with a channel getting the
+// following changes, until unwatch is called. The channel is closed if the
+// watcher falls behind.
+func (sc *sandboxCache) watch() (map[string]sandboxCRIMetadata, chan sandboxEvent) {
+	sc.Lock()
+	defer sc.Unlock()
+
+	sandboxes := make(map[string]sandboxCRIMetadata, len(sc.sandboxes))
+	for id, metadata := range sc.sandboxes {
+		sandboxes[id] = metadata
+	}
+
+	if sc.watchers == nil {
+		sc.watchers = make(map[chan sandboxEvent]struct{})
+	}
+	ch := make(chan sandboxEvent, sandboxWatcherBufferSize)
+	sc.watchers[ch] = struct{}{}
+
+	return sandboxes, ch
+}
+
+func (sc *sandboxCache) unwatch(ch chan sandboxEvent) {
+	sc.Lock()
+	defer sc.Unlock()
+
+	if _, found := sc.watchers[ch]; found {
+		delete(sc.watchers, ch)
+		close(ch)
+	}
+}
+
+// notify sends a change to the watchers, the cache lock must be held.
+func (sc *sandboxCache) notify(eventType sandboxEventType, id string, metadata sandboxCRIMetadata) {
+	for ch := range sc.watchers {
+		select {
+		case ch <- sandboxEvent{eventType: eventType, id: id, metadata: metadata}:
+		default:
+			delete(sc.watchers, ch)
+			close(ch)
+		}
+	}
+}
@@ -16,7 +16,7 @@ func TestSandboxCache(t *testing.T) {
 	assert := assert.New(t)
 	sc := &sandboxCache{
 		Mutex:     &sync.Mutex{},
-		sandboxes: map[string]sandboxCRIMetadata{"111": {"1-2-3", "test-name", "test-namespace"}},
+		sandboxes: map[string]sandboxCRIMetadata{"111": {uid: "1-2-3", name: "test-name", namespace: "test-namespace"}},
 	}
 
 	assert.Equal(1, len(sc.getSandboxList()))
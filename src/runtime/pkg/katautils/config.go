@@ -10,14 +10,18 @@ package katautils
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
+	"path"
 	"path/filepath"
 	"reflect"
 	goruntime "runtime"
 	"strings"
 
 	"github.com/BurntSushi/toml"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/agentapi"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/featureflags"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/govmm"
 	govmmQemu "github.com/kata-containers/kata-containers/src/runtime/pkg/govmm/qemu"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils/katatrace"
@@ -70,10 +74,16 @@ type image struct {
 }
 
 type factory struct {
-	TemplatePath    string `toml:"template_path"`
-	VMCacheEndpoint string `toml:"vm_cache_endpoint"`
-	VMCacheNumber   uint   `toml:"vm_cache_number"`
-	Template        bool   `toml:"enable_template"`
+	TemplatePath     string `toml:"template_path"`
+	VMCacheEndpoint  string `toml:"vm_cache_endpoint"`
+	VMCacheNumber    uint   `toml:"vm_cache_number"`
+	Template         bool   `toml:"enable_template"`
+	TemplatePrefetch bool   `toml:"enable_template_prefetch"`
+}
+
+type kernelVariant struct {
+	Path         string `toml:"path"`
+	KernelParams string `toml:"kernel_params"`
 }
 
 type hypervisor struct {
@@ -92,23 +102,42 @@ type hypervisor struct {
 	MachineType                    string          `toml:"machine_type"`
 	BlockDeviceDriver              string          `toml:"block_device_driver"`
 	EntropySource                  string          `toml:"entropy_source"`
+	EntropyBackend                 string          `toml:"entropy_backend"`
 	SharedFS                       string          `toml:"shared_fs"`
 	VirtioFSDaemon                 string          `toml:"virtio_fs_daemon"`
 	VirtioFSCache                  string          `toml:"virtio_fs_cache"`
 	VhostUserStorePath             string          `toml:"vhost_user_store_path"`
+	VhostVDPAHookPath              string          `toml:"vhost_vdpa_hook_path"`
 	FileBackedMemRootDir           string          `toml:"file_mem_backend"`
+	HostSharedMemory               string          `toml:"host_shared_memory"`
 	GuestHookPath                  string          `toml:"guest_hook_path"`
 	GuestMemoryDumpPath            string          `toml:"guest_memory_dump_path"`
 	SeccompSandbox                 string          `toml:"seccompsandbox"`
 	BlockDeviceAIO                 string          `toml:"block_device_aio"`
+	MemoryTHP                      string          `toml:"memory_thp"`
+	MemMerge                       string          `toml:"mem_merge"`
+	AFXDPMode                      string          `toml:"af_xdp_mode"`
+	VirtioGPU                      string          `toml:"virtio_gpu"`
+	VirtioGPUDaemon                string          `toml:"virtio_gpu_daemon"`
+	SwtpmPath                      string          `toml:"swtpm_path"`
+	VTPMStatePath                  string          `toml:"vtpm_state_dir"`
+	CCAMeasurementAlgorithm        string          `toml:"cca_measurement_algorithm"`
+	CCAPersonalizationValue        string          `toml:"cca_personalization_value"`
+	IvshmemServerPath              string          `toml:"ivshmem_server"`
 	HypervisorPathList             []string        `toml:"valid_hypervisor_paths"`
 	JailerPathList                 []string        `toml:"valid_jailer_paths"`
 	CtlPathList                    []string        `toml:"valid_ctlpaths"`
 	VirtioFSDaemonList             []string        `toml:"valid_virtio_fs_daemon_paths"`
 	VirtioFSExtraArgs              []string        `toml:"virtio_fs_extra_args"`
+	VirtioGPUDaemonExtraArgs       []string        `toml:"virtio_gpu_daemon_extra_args"`
 	PFlashList                     []string        `toml:"pflashes"`
 	VhostUserStorePathList         []string        `toml:"valid_vhost_user_store_paths"`
+	USBDevices                     []string        `toml:"usb_devices"`
+	USBDevicesList                 []string        `toml:"valid_usb_devices"`
+	ExtraArgsList                  []string        `toml:"valid_extra_args"`
+	ExtraAPIFieldsList             []string        `toml:"valid_extra_api_fields"`
 	FileBackedMemRootList          []string        `toml:"valid_file_mem_backends"`
+	HostSharedMemoryList           []string        `toml:"valid_host_shared_memory"`
 	EntropySourceList              []string        `toml:"valid_entropy_sources"`
 	EnableAnnotations              []string        `toml:"enable_annotations"`
 	RxRateLimiterMaxRate           uint64          `toml:"rx_rate_limiter_max_rate"`
@@ -130,63 +159,234 @@ type hypervisor struct {
 	MemSlots                       uint32          `toml:"memory_slots"`
 	DefaultBridges                 uint32          `toml:"default_bridges"`
 	Msize9p                        uint32          `toml:"msize_9p"`
+	VirtioGPUHostMem               uint32          `toml:"virtio_gpu_hostmem"`
+	ShmChannelSize                 uint32          `toml:"shm_channel_size"`
+	ShutdownGracePeriod            uint32          `toml:"shutdown_grace_period"`
+	AFXDPQueues                    uint32          `toml:"af_xdp_queues"`
+	AFXDPStartQueue                uint32          `toml:"af_xdp_start_queue"`
+	AFXDPBusyPollTimeout           uint32          `toml:"af_xdp_busy_poll_timeout"`
+	EntropyMaxBytes                uint32          `toml:"entropy_max_bytes"`
+	EntropyPeriod                  uint32          `toml:"entropy_period"`
 	NumVCPUs                       int32           `toml:"default_vcpus"`
 	BlockDeviceCacheSet            bool            `toml:"block_device_cache_set"`
 	BlockDeviceCacheDirect         bool            `toml:"block_device_cache_direct"`
 	BlockDeviceCacheNoflush        bool            `toml:"block_device_cache_noflush"`
 	EnableVhostUserStore           bool            `toml:"enable_vhost_user_store"`
+	VirtioFSSubmounts              bool            `toml:"virtio_fs_announce_submounts"`
 	VhostUserDeviceReconnect       uint32          `toml:"vhost_user_reconnect_timeout_sec"`
 	DisableBlockDeviceUse          bool            `toml:"disable_block_device_use"`
 	MemPrealloc                    bool            `toml:"enable_mem_prealloc"`
 	HugePages                      bool            `toml:"enable_hugepages"`
+	ReclaimGuestFreedMemory        bool            `toml:"reclaim_guest_freed_memory"`
 	VirtioMem                      bool            `toml:"enable_virtio_mem"`
 	IOMMU                          bool            `toml:"enable_iommu"`
 	IOMMUPlatform                  bool            `toml:"enable_iommu_platform"`
+	EnableVTPM                     bool            `toml:"enable_vtpm"`
 	Debug                          bool            `toml:"enable_debug"`
 	DisableNestingChecks           bool            `toml:"disable_nesting_checks"`
 	EnableIOThreads                bool            `toml:"enable_iothreads"`
 	DisableImageNvdimm             bool            `toml:"disable_image_nvdimm"`
+	SharedBootAssets               bool            `toml:"shared_boot_assets"`
+	PmemVolumesSize                uint32          `toml:"pmem_volumes_size"`
 	HotplugVFIOOnRootBus           bool            `toml:"hotplug_vfio_on_root_bus"`
 	HotPlugVFIO                    config.PCIePort `toml:"hot_plug_vfio"`
 	ColdPlugVFIO                   config.PCIePort `toml:"cold_plug_vfio"`
+	PCIeP2P                        bool            `toml:"pcie_p2p"`
+	PCIHotplugMode                 string          `toml:"pci_hotplug_mode"`
 	DisableVhostNet                bool            `toml:"disable_vhost_net"`
 	GuestMemoryDumpPaging          bool            `toml:"guest_memory_dump_paging"`
 	ConfidentialGuest              bool            `toml:"confidential_guest"`
 	SevSnpGuest                    bool            `toml:"sev_snp_guest"`
+	CCAMeasurementLog              bool            `toml:"cca_measurement_log"`
+	RequireTDISPDevices            bool            `toml:"require_tdisp_devices"`
 	GuestSwap                      bool            `toml:"enable_guest_swap"`
 	Rootless                       bool            `toml:"rootless"`
 	DisableSeccomp                 bool            `toml:"disable_seccomp"`
 	DisableSeLinux                 bool            `toml:"disable_selinux"`
 	DisableGuestSeLinux            bool            `toml:"disable_guest_selinux"`
 	LegacySerial                   bool            `toml:"use_legacy_serial"`
+
+	// FirmwareVariants maps the names of the firmware a sandbox can select
+	// with an annotation to their path
+	FirmwareVariants map[string]string `toml:"firmware_variants"`
+
+	// KernelVariants maps the names of the guest kernels a sandbox can
+	// select with an annotation to their path and parameters
+	KernelVariants map[string]kernelVariant `toml:"kernel_variants"`
+
+	// CPUMitigations is the CPU vulnerability mitigation profile of the
+	// guests, and ValidCPUMitigations the profiles a sandbox can select
+	// with an annotation
+	CPUMitigations      string   `toml:"cpu_mitigations"`
+	ValidCPUMitigations []string `toml:"valid_cpu_mitigations"`
 }
 
 type runtime struct {
-	InterNetworkModel         string   `toml:"internetworking_model"`
-	JaegerEndpoint            string   `toml:"jaeger_endpoint"`
-	JaegerUser                string   `toml:"jaeger_user"`
-	JaegerPassword            string   `toml:"jaeger_password"`
-	VfioMode                  string   `toml:"vfio_mode"`
-	GuestSeLinuxLabel         string   `toml:"guest_selinux_label"`
-	SandboxBindMounts         []string `toml:"sandbox_bind_mounts"`
-	Experimental              []string `toml:"experimental"`
-	Tracing                   bool     `toml:"enable_tracing"`
-	DisableNewNetNs           bool     `toml:"disable_new_netns"`
-	DisableGuestSeccomp       bool     `toml:"disable_guest_seccomp"`
-	EnableVCPUsPinning        bool     `toml:"enable_vcpus_pinning"`
-	Debug                     bool     `toml:"enable_debug"`
-	SandboxCgroupOnly         bool     `toml:"sandbox_cgroup_only"`
-	StaticSandboxResourceMgmt bool     `toml:"static_sandbox_resource_mgmt"`
-	EnablePprof               bool     `toml:"enable_pprof"`
-	DisableGuestEmptyDir      bool     `toml:"disable_guest_empty_dir"`
+	InterNetworkModel            string   `toml:"internetworking_model"`
+	JaegerEndpoint               string   `toml:"jaeger_endpoint"`
+	JaegerUser                   string   `toml:"jaeger_user"`
+	JaegerPassword               string   `toml:"jaeger_password"`
+	VfioMode                     string   `toml:"vfio_mode"`
+	GuestSeLinuxLabel            string   `toml:"guest_selinux_label"`
+	GuestSeccompMode             string   `toml:"guest_seccomp_mode"`
+	CoreDumpDir                  string   `toml:"core_dump_dir"`
+	SandboxBindMounts            []string `toml:"sandbox_bind_mounts"`
+	CoreDumpNamespaces           []string `toml:"core_dump_namespaces"`
+	StdioLogDrivers              []string `toml:"stdio_log_drivers"`
+	StdioLogNamespaces           []string `toml:"stdio_log_namespaces"`
+	StdioFluentdAddress          string   `toml:"stdio_fluentd_address"`
+	HostContainerRuntime         string   `toml:"host_container_runtime"`
+	HostContainerNames           []string `toml:"host_container_names"`
+	HostContainerImages          []string `toml:"host_container_images"`
+	VMMSchedClass                string   `toml:"vmm_sched_class"`
+	EntitlementsPath             string   `toml:"entitlements_path"`
+	NetworkPolicyObject          string   `toml:"network_policy_bpf_object"`
+	AgentAPIPolicy               string   `toml:"agent_api_policy"`
+	SeccompNotifySocket          string   `toml:"seccomp_notify_socket"`
+	Profile                      string   `toml:"profile"`
+	PprofNamespaces              []string `toml:"pprof_namespaces"`
+	HostDevicePolicy             []string `toml:"host_device_policy"`
+	KernelParamsRules            []string `toml:"kernel_params_rules"`
+	MetadataAnnotations          []string `toml:"metadata_annotations"`
+	ResourceHooks                []string `toml:"resource_hooks"`
+	ImageVolumePaths             []string `toml:"image_volume_paths"`
+	SandboxPlacement             string   `toml:"sandbox_placement"`
+	SandboxPlacementDomain       string   `toml:"sandbox_placement_domain"`
+	UsageSpoolDir                string   `toml:"usage_spool_dir"`
+	Experimental                 []string `toml:"experimental"`
+	CoreDumpMaxSize              uint64   `toml:"core_dump_max_size"`
+	CoreDumpDirMaxSize           uint64   `toml:"core_dump_dir_max_size"`
+	GuestPidsLimit               uint64   `toml:"guest_pids_limit"`
+	VMMMaxOpenFiles              uint64   `toml:"vmm_max_open_files"`
+	VMMMaxThreads                uint64   `toml:"vmm_max_threads"`
+	MultipathEvents              bool     `toml:"multipath_events"`
+	LazyDeviceAttach             bool     `toml:"lazy_device_attach"`
+	StopFlushTimeout             uint32   `toml:"stop_flush_timeout"`
+	ConfirmExecTimeout           uint32   `toml:"confirm_exec_timeout"`
+	ForensicSnapshotThreshold    uint32   `toml:"forensic_snapshot_threshold"`
+	ForensicSnapshotCount        uint32   `toml:"forensic_snapshot_count"`
+	UsageSnapshotInterval        uint32   `toml:"usage_snapshot_interval"`
+	GuestShmSizePercent          uint32   `toml:"guest_shm_size_percent"`
+	GuestMemoryVolumeSizePercent uint32   `toml:"guest_memory_volume_size_percent"`
+	GuestTmpfsSizePercent        uint32   `toml:"guest_tmpfs_size_percent"`
+	VMMLimitAlertPercent         uint32   `toml:"vmm_limit_alert_percent"`
+	SandboxBootConcurrency       uint32   `toml:"sandbox_boot_concurrency"`
+	GuestNameResolution          bool     `toml:"guest_name_resolution"`
+	MetadataService              bool     `toml:"metadata_service"`
+	Pauseless                    bool     `toml:"pauseless"`
+	SharePidNs                   bool     `toml:"share_pid_ns"`
+	PprofMutexProfileFraction    int      `toml:"pprof_mutex_profile_fraction"`
+	PprofBlockProfileRate        int      `toml:"pprof_block_profile_rate"`
+	VMMSchedRTPriority           int      `toml:"vmm_sched_rt_priority"`
+	VMMSchedMaxRTPriority        int      `toml:"vmm_sched_max_rt_priority"`
+	Tracing                      bool     `toml:"enable_tracing"`
+	DisableNewNetNs              bool     `toml:"disable_new_netns"`
+	SimulateNetwork              bool     `toml:"simulate_network"`
+	DisableGuestSeccomp          bool     `toml:"disable_guest_seccomp"`
+	GuestSeccompReport           bool     `toml:"guest_seccomp_report"`
+	EnableVCPUsPinning           bool     `toml:"enable_vcpus_pinning"`
+	Debug                        bool     `toml:"enable_debug"`
+	SandboxCgroupOnly            bool     `toml:"sandbox_cgroup_only"`
+	StaticSandboxResourceMgmt    bool     `toml:"static_sandbox_resource_mgmt"`
+	EnablePprof                  bool     `toml:"enable_pprof"`
+	EnableFaultInjection         bool     `toml:"enable_fault_injection"`
+	DisableGuestEmptyDir         bool     `toml:"disable_guest_empty_dir"`
+	EnableCoreDumps              bool     `toml:"enable_core_dumps"`
+
+	// FeatureFlags maps the names of the feature flags to their state,
+	// true, false or the percentage of the nodes they are enabled on
+	FeatureFlags map[string]string `toml:"feature_flags"`
+}
+
+func (r runtime) stdioLogDrivers() ([]string, error) {
+	fluentd := false
+	for _, driver := range r.StdioLogDrivers {
+		switch driver {
+		case "journald", "syslog":
+		case "fluentd":
+			fluentd = true
+		default:
+			return nil, fmt.Errorf("Invalid stdio_log_drivers entry %q, valid drivers are journald, syslog and fluentd", driver)
+		}
+	}
+
+	if fluentd {
+		uri, err := url.Parse(r.StdioFluentdAddress)
+		if err != nil || (uri.Scheme != "unix" && uri.Scheme != "tcp") {
+			return nil, fmt.Errorf("Invalid stdio_fluentd_address %q, expecting unix:///path or tcp://host:port", r.StdioFluentdAddress)
+		}
+	}
+
+	return r.StdioLogDrivers, nil
+}
+
+func (r runtime) coreDump() (vc.CoreDumpConfig, error) {
+	dir := r.CoreDumpDir
+	if dir == "" {
+		dir = defaultCoreDumpDir
+	}
+
+	if !filepath.IsAbs(dir) {
+		return vc.CoreDumpConfig{}, fmt.Errorf("core_dump_dir %q is not an absolute path", dir)
+	}
+
+	return vc.CoreDumpConfig{
+		HostDir:     filepath.Clean(dir),
+		MaxCoreSize: r.CoreDumpMaxSize << utils.MibToBytesShift,
+		MaxDirSize:  r.CoreDumpDirMaxSize << utils.MibToBytesShift,
+		Enabled:     r.EnableCoreDumps,
+	}, nil
+}
+
+func (r runtime) tmpfsSizing() (vc.TmpfsSizing, error) {
+	for key, percent := range map[string]uint32{
+		"guest_shm_size_percent":           r.GuestShmSizePercent,
+		"guest_memory_volume_size_percent": r.GuestMemoryVolumeSizePercent,
+		"guest_tmpfs_size_percent":         r.GuestTmpfsSizePercent,
+	} {
+		if percent > 100 {
+			return vc.TmpfsSizing{}, fmt.Errorf("Invalid %s %d, it cannot be over 100", key, percent)
+		}
+	}
+
+	return vc.TmpfsSizing{
+		ShmPercent:          r.GuestShmSizePercent,
+		MemoryVolumePercent: r.GuestMemoryVolumeSizePercent,
+		TmpfsPercent:        r.GuestTmpfsSizePercent,
+	}, nil
+}
+
+func (r runtime) vmmLimits() (vc.VMMLimits, error) {
+	if r.VMMLimitAlertPercent > 100 {
+		return vc.VMMLimits{}, fmt.Errorf("Invalid vmm_limit_alert_percent %d, it cannot be over 100", r.VMMLimitAlertPercent)
+	}
+
+	return vc.VMMLimits{
+		MaxOpenFiles: r.VMMMaxOpenFiles,
+		MaxThreads:   r.VMMMaxThreads,
+		AlertPercent: r.VMMLimitAlertPercent,
+	}, nil
+}
+
+func (r runtime) pprofRates() (int, int, error) {
+	if r.PprofMutexProfileFraction < 0 {
+		return 0, 0, fmt.Errorf("Invalid pprof_mutex_profile_fraction %d, it cannot be negative", r.PprofMutexProfileFraction)
+	}
+	if r.PprofBlockProfileRate < 0 {
+		return 0, 0, fmt.Errorf("Invalid pprof_block_profile_rate %d, it cannot be negative", r.PprofBlockProfileRate)
+	}
+
+	return r.PprofMutexProfileFraction, r.PprofBlockProfileRate, nil
 }
 
 type agent struct {
 	KernelModules       []string `toml:"kernel_modules"`
+	Transport           string   `toml:"transport"`
 	Debug               bool     `toml:"enable_debug"`
 	Tracing             bool     `toml:"enable_tracing"`
 	DebugConsoleEnabled bool     `toml:"debug_console_enabled"`
 	DialTimeout         uint32   `toml:"dial_timeout"`
+	StreamConnections   uint32   `toml:"stream_connections"`
 }
 
 func (orig *tomlConfig) Clone() tomlConfig {
@@ -243,6 +443,41 @@ func (h hypervisor) kernel() (string, error) {
 	return ResolvePath(p)
 }
 
+func (h hypervisor) kernelVariants() (map[string]vc.KernelVariant, error) {
+	if len(h.KernelVariants) == 0 {
+		return nil, nil
+	}
+
+	variants := make(map[string]vc.KernelVariant, len(h.KernelVariants))
+	for name, v := range h.KernelVariants {
+		if v.Path == "" {
+			return nil, fmt.Errorf("kernel variant %s has no path", name)
+		}
+		resolved, err := ResolvePath(v.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve path of kernel variant %s: %v", name, err)
+		}
+		variants[name] = vc.KernelVariant{
+			Path:   resolved,
+			Params: vc.DeserializeParams(strings.Fields(v.KernelParams)),
+		}
+	}
+
+	return variants, nil
+}
+
+func (h hypervisor) checkCPUMitigations() error {
+	if h.CPUMitigations != "" && !vc.ValidCPUMitigations(h.CPUMitigations) {
+		return fmt.Errorf("invalid CPU mitigations profile %s", h.CPUMitigations)
+	}
+	for _, profile := range h.ValidCPUMitigations {
+		if !vc.ValidCPUMitigations(profile) {
+			return fmt.Errorf("invalid CPU mitigations profile %s in valid_cpu_mitigations", profile)
+		}
+	}
+	return nil
+}
+
 func (h hypervisor) initrd() (string, error) {
 	p := h.Initrd
 
@@ -286,6 +521,23 @@ func (h hypervisor) firmware() (string, error) {
 	return ResolvePath(p)
 }
 
+func (h hypervisor) firmwareVariants() (map[string]string, error) {
+	if len(h.FirmwareVariants) == 0 {
+		return nil, nil
+	}
+
+	variants := make(map[string]string, len(h.FirmwareVariants))
+	for name, p := range h.FirmwareVariants {
+		resolved, err := ResolvePath(p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve path of firmware variant %s: %v", name, err)
+		}
+		variants[name] = resolved
+	}
+
+	return variants, nil
+}
+
 func (h hypervisor) coldPlugVFIO() config.PCIePort {
 	if h.ColdPlugVFIO == "" {
 		return defaultColdPlugVFIO
@@ -516,6 +768,102 @@ func (h hypervisor) blockDeviceAIO() (string, error) {
 	return "", fmt.Errorf("Invalid hypervisor block storage I/O mechanism  %v specified (supported AIO: %v)", h.BlockDeviceAIO, supportedBlockAIO)
 }
 
+func (h hypervisor) entropyBackend() (string, error) {
+	supportedBackends := []string{vc.EntropyBackendRandom, vc.EntropyBackendBuiltin}
+
+	if h.EntropyBackend == "" {
+		return vc.EntropyBackendRandom, nil
+	}
+
+	for _, b := range supportedBackends {
+		if b == h.EntropyBackend {
+			return h.EntropyBackend, nil
+		}
+	}
+
+	return "", fmt.Errorf("Invalid entropy backend %v specified (supported backends: %v)", h.EntropyBackend, supportedBackends)
+}
+
+func (h hypervisor) memoryTHP() (string, error) {
+	supportedTHP := []string{vc.MemoryTHPAlways, vc.MemoryTHPMadvise, vc.MemoryTHPNever}
+
+	if h.MemoryTHP == "" {
+		return "", nil
+	}
+
+	for _, t := range supportedTHP {
+		if t == h.MemoryTHP {
+			return h.MemoryTHP, nil
+		}
+	}
+
+	return "", fmt.Errorf("Invalid transparent huge page policy %v specified (supported policies: %v)", h.MemoryTHP, supportedTHP)
+}
+
+func (h hypervisor) memMerge() (string, error) {
+	supportedPolicies := []string{vc.MemMergeOn, vc.MemMergeOff}
+
+	if h.MemMerge == "" {
+		return "", nil
+	}
+
+	for _, p := range supportedPolicies {
+		if p == h.MemMerge {
+			return h.MemMerge, nil
+		}
+	}
+
+	return "", fmt.Errorf("Invalid KSM policy %v specified (supported policies: %v)", h.MemMerge, supportedPolicies)
+}
+
+func (h hypervisor) pciHotplugMode() (string, error) {
+	supportedModes := []string{vc.PCIHotplugAuto, vc.PCIHotplugACPI, vc.PCIHotplugNative}
+
+	if h.PCIHotplugMode == "" {
+		return vc.PCIHotplugAuto, nil
+	}
+
+	for _, m := range supportedModes {
+		if m == h.PCIHotplugMode {
+			return h.PCIHotplugMode, nil
+		}
+	}
+
+	return "", fmt.Errorf("Invalid PCI hotplug mode %v specified (supported modes: %v)", h.PCIHotplugMode, supportedModes)
+}
+
+func (h hypervisor) afXDPMode() (string, error) {
+	supportedModes := []string{vc.AFXDPModeNative, vc.AFXDPModeSKB}
+
+	if h.AFXDPMode == "" {
+		return "", nil
+	}
+
+	for _, m := range supportedModes {
+		if m == h.AFXDPMode {
+			return h.AFXDPMode, nil
+		}
+	}
+
+	return "", fmt.Errorf("Invalid AF_XDP mode %v specified (supported modes: %v)", h.AFXDPMode, supportedModes)
+}
+
+func (h hypervisor) virtioGPU() (string, error) {
+	supportedVirtioGPU := []string{vc.VirtioGPUVenus, vc.VirtioGPUDRM}
+
+	if h.VirtioGPU == "" {
+		return "", nil
+	}
+
+	for _, g := range supportedVirtioGPU {
+		if g == h.VirtioGPU {
+			return h.VirtioGPU, nil
+		}
+	}
+
+	return "", fmt.Errorf("Invalid virtio-gpu backend %v specified (supported backends: %v)", h.VirtioGPU, supportedVirtioGPU)
+}
+
 func (h hypervisor) sharedFS() (string, error) {
 	supportedSharedFS := []string{config.Virtio9P, config.VirtioFS, config.VirtioFSNydus, config.NoSharedFS}
 
@@ -547,6 +895,20 @@ func (h hypervisor) guestHookPath() string {
 	return h.GuestHookPath
 }
 
+func (h hypervisor) swtpmPath() string {
+	if h.SwtpmPath == "" {
+		return defaultSwtpmPath
+	}
+	return h.SwtpmPath
+}
+
+func (h hypervisor) vtpmStatePath() string {
+	if h.VTPMStatePath == "" {
+		return defaultVTPMStatePath
+	}
+	return h.VTPMStatePath
+}
+
 func (h hypervisor) vhostUserStorePath() string {
 	if h.VhostUserStorePath == "" {
 		return defaultVhostUserStorePath
@@ -635,6 +997,24 @@ func (a agent) dialTimout() uint32 {
 	return a.DialTimeout
 }
 
+func (a agent) transport() (string, error) {
+	switch a.Transport {
+	case "":
+		return vc.AgentTransportVSock, nil
+	case vc.AgentTransportVSock, vc.AgentTransportSerial, vc.AgentTransportAuto:
+		return a.Transport, nil
+	default:
+		return "", fmt.Errorf("unknown agent transport %q", a.Transport)
+	}
+}
+
+func (a agent) streamConnections() (uint32, error) {
+	if err := vc.ValidateAgentStreamConnections(uint64(a.StreamConnections)); err != nil {
+		return 0, err
+	}
+	return a.StreamConnections, nil
+}
+
 func (a agent) debug() bool {
 	return a.Debug
 }
@@ -663,6 +1043,15 @@ func newFirecrackerHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 		return vc.HypervisorConfig{}, err
 	}
 
+	kernelVariants, err := h.kernelVariants()
+	if err != nil {
+		return vc.HypervisorConfig{}, err
+	}
+
+	if err := h.checkCPUMitigations(); err != nil {
+		return vc.HypervisorConfig{}, err
+	}
+
 	initrd, err := h.initrd()
 	if err != nil {
 		return vc.HypervisorConfig{}, err
@@ -699,6 +1088,9 @@ func newFirecrackerHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 		JailerPath:            jailer,
 		JailerPathList:        h.JailerPathList,
 		KernelPath:            kernel,
+		KernelVariants:        kernelVariants,
+		CPUMitigations:        h.CPUMitigations,
+		ValidCPUMitigations:   h.ValidCPUMitigations,
 		InitrdPath:            initrd,
 		ImagePath:             image,
 		RootfsType:            rootfsType,
@@ -739,6 +1131,15 @@ func newQemuHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 		return vc.HypervisorConfig{}, err
 	}
 
+	kernelVariants, err := h.kernelVariants()
+	if err != nil {
+		return vc.HypervisorConfig{}, err
+	}
+
+	if err := h.checkCPUMitigations(); err != nil {
+		return vc.HypervisorConfig{}, err
+	}
+
 	initrd, err := h.initrd()
 	if err != nil {
 		return vc.HypervisorConfig{}, err
@@ -769,6 +1170,11 @@ func newQemuHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 		return vc.HypervisorConfig{}, err
 	}
 
+	firmwareVariants, err := h.firmwareVariants()
+	if err != nil {
+		return vc.HypervisorConfig{}, err
+	}
+
 	machineAccelerators := h.machineAccelerators()
 	cpuFeatures := h.cpuFeatures()
 	kernelParams := h.kernelParams()
@@ -782,6 +1188,10 @@ func newQemuHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 		kataUtilsLogger.Info("Setting 'disable_image_nvdimm = true' as microvm does not support NVDIMM")
 	}
 
+	if h.PmemVolumesSize > 0 && (machineType == govmmQemu.MachineTypeMicrovm || h.ConfidentialGuest) {
+		return vc.HypervisorConfig{}, fmt.Errorf("pmem_volumes_size needs NVDIMM support, which the microvm machine type and confidential guests lack")
+	}
+
 	// Nvdimm can only be support when UEFI/ACPI is enabled on arm64, otherwise disable it.
 	if goruntime.GOARCH == "arm64" && firmware == "" {
 		if p, err := h.PFlash(); err == nil {
@@ -792,6 +1202,10 @@ func newQemuHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 		}
 	}
 
+	if err := h.checkSharedBootAssets(initrd); err != nil {
+		return vc.HypervisorConfig{}, err
+	}
+
 	blockDriver, err := h.blockDeviceDriver()
 	if err != nil {
 		return vc.HypervisorConfig{}, err
@@ -802,6 +1216,36 @@ func newQemuHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 		return vc.HypervisorConfig{}, err
 	}
 
+	memoryTHP, err := h.memoryTHP()
+	if err != nil {
+		return vc.HypervisorConfig{}, err
+	}
+
+	memMerge, err := h.memMerge()
+	if err != nil {
+		return vc.HypervisorConfig{}, err
+	}
+
+	entropyBackend, err := h.entropyBackend()
+	if err != nil {
+		return vc.HypervisorConfig{}, err
+	}
+
+	pciHotplugMode, err := h.pciHotplugMode()
+	if err != nil {
+		return vc.HypervisorConfig{}, err
+	}
+
+	virtioGPU, err := h.virtioGPU()
+	if err != nil {
+		return vc.HypervisorConfig{}, err
+	}
+
+	afXDPMode, err := h.afXDPMode()
+	if err != nil {
+		return vc.HypervisorConfig{}, err
+	}
+
 	sharedFS, err := h.sharedFS()
 	if err != nil {
 		return vc.HypervisorConfig{}, err
@@ -812,10 +1256,6 @@ func newQemuHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 			fmt.Errorf("cannot enable %s without daemon path in configuration file", sharedFS)
 	}
 
-	if vSock, err := utils.SupportsVsocks(); !vSock {
-		return vc.HypervisorConfig{}, err
-	}
-
 	rxRateLimiterMaxRate := h.getRxRateLimiterCfg()
 	txRateLimiterMaxRate := h.getTxRateLimiterCfg()
 
@@ -823,11 +1263,15 @@ func newQemuHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 		HypervisorPath:          hypervisor,
 		HypervisorPathList:      h.HypervisorPathList,
 		KernelPath:              kernel,
+		KernelVariants:          kernelVariants,
+		CPUMitigations:          h.CPUMitigations,
+		ValidCPUMitigations:     h.ValidCPUMitigations,
 		InitrdPath:              initrd,
 		ImagePath:               image,
 		RootfsType:              rootfsType,
 		FirmwarePath:            firmware,
 		FirmwareVolumePath:      firmwareVolume,
+		FirmwareVariants:        firmwareVariants,
 		PFlash:                  pflashes,
 		MachineAccelerators:     machineAccelerators,
 		CPUFeatures:             cpuFeatures,
@@ -842,6 +1286,9 @@ func newQemuHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 		VirtioMem:               h.VirtioMem,
 		EntropySource:           h.GetEntropySource(),
 		EntropySourceList:       h.EntropySourceList,
+		EntropyBackend:          entropyBackend,
+		EntropyMaxBytes:         h.EntropyMaxBytes,
+		EntropyPeriod:           h.EntropyPeriod,
 		DefaultBridges:          h.defaultBridges(),
 		DisableBlockDeviceUse:   h.DisableBlockDeviceUse,
 		SharedFS:                sharedFS,
@@ -851,12 +1298,18 @@ func newQemuHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 		VirtioFSCache:           h.defaultVirtioFSCache(),
 		VirtioFSQueueSize:       h.VirtioFSQueueSize,
 		VirtioFSExtraArgs:       h.VirtioFSExtraArgs,
+		VirtioFSSubmounts:       h.VirtioFSSubmounts,
 		MemPrealloc:             h.MemPrealloc,
 		HugePages:               h.HugePages,
+		MemMerge:                memMerge,
+		ReclaimGuestFreedMemory: h.ReclaimGuestFreedMemory,
+		MemoryTHP:               memoryTHP,
 		IOMMU:                   h.IOMMU,
 		IOMMUPlatform:           h.getIOMMUPlatform(),
 		FileBackedMemRootDir:    h.FileBackedMemRootDir,
 		FileBackedMemRootList:   h.FileBackedMemRootList,
+		HostSharedMemory:        h.HostSharedMemory,
+		HostSharedMemoryList:    h.HostSharedMemoryList,
 		Debug:                   h.Debug,
 		DisableNestingChecks:    h.DisableNestingChecks,
 		BlockDeviceDriver:       blockDriver,
@@ -867,13 +1320,25 @@ func newQemuHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 		EnableIOThreads:         h.EnableIOThreads,
 		Msize9p:                 h.msize9p(),
 		DisableImageNvdimm:      h.DisableImageNvdimm,
+		SharedBootAssets:        h.SharedBootAssets,
+		PmemVolumesSize:         h.PmemVolumesSize,
 		HotplugVFIOOnRootBus:    h.HotplugVFIOOnRootBus,
 		HotPlugVFIO:             h.hotPlugVFIO(),
 		ColdPlugVFIO:            h.coldPlugVFIO(),
+		PCIeP2P:                 h.PCIeP2P,
+		PCIHotplugMode:          pciHotplugMode,
 		DisableVhostNet:         h.DisableVhostNet,
+		AFXDPMode:               afXDPMode,
+		AFXDPQueues:             h.AFXDPQueues,
+		AFXDPStartQueue:         h.AFXDPStartQueue,
+		AFXDPBusyPollTimeout:    h.AFXDPBusyPollTimeout,
 		EnableVhostUserStore:    h.EnableVhostUserStore,
 		VhostUserStorePath:      h.vhostUserStorePath(),
 		VhostUserStorePathList:  h.VhostUserStorePathList,
+		VhostVDPAHookPath:       h.VhostVDPAHookPath,
+		USBDevices:              h.USBDevices,
+		USBDevicesList:          h.USBDevicesList,
+		ExtraArgsList:           h.ExtraArgsList,
 		SeccompSandbox:          h.SeccompSandbox,
 		GuestHookPath:           h.guestHookPath(),
 		RxRateLimiterMaxRate:    rxRateLimiterMaxRate,
@@ -888,6 +1353,20 @@ func newQemuHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 		LegacySerial:            h.LegacySerial,
 		DisableSeLinux:          h.DisableSeLinux,
 		DisableGuestSeLinux:     h.DisableGuestSeLinux,
+		VirtioGPU:               virtioGPU,
+		VirtioGPUHostMemMB:      h.VirtioGPUHostMem,
+		VirtioGPUDaemon:         h.VirtioGPUDaemon,
+		VirtioGPUExtraArgs:      h.VirtioGPUDaemonExtraArgs,
+		EnableVTPM:              h.EnableVTPM,
+		SwtpmPath:               h.swtpmPath(),
+		VTPMStatePath:           h.vtpmStatePath(),
+		IvshmemServerPath:       h.IvshmemServerPath,
+		ShmChannelSizeMB:        h.ShmChannelSize,
+		ShutdownGracePeriod:     h.ShutdownGracePeriod,
+		CCAMeasurementAlgorithm: h.CCAMeasurementAlgorithm,
+		CCAPersonalizationValue: h.CCAPersonalizationValue,
+		CCAMeasurementLog:       h.CCAMeasurementLog,
+		RequireTDISPDevices:     h.RequireTDISPDevices,
 	}, nil
 }
 
@@ -907,6 +1386,15 @@ func newAcrnHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 		return vc.HypervisorConfig{}, err
 	}
 
+	kernelVariants, err := h.kernelVariants()
+	if err != nil {
+		return vc.HypervisorConfig{}, err
+	}
+
+	if err := h.checkCPUMitigations(); err != nil {
+		return vc.HypervisorConfig{}, err
+	}
+
 	image, err := h.image()
 	if err != nil {
 		return vc.HypervisorConfig{}, err
@@ -938,6 +1426,9 @@ func newAcrnHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 		HypervisorPath:        hypervisor,
 		HypervisorPathList:    h.HypervisorPathList,
 		KernelPath:            kernel,
+		KernelVariants:        kernelVariants,
+		CPUMitigations:        h.CPUMitigations,
+		ValidCPUMitigations:   h.ValidCPUMitigations,
 		ImagePath:             image,
 		RootfsType:            rootfsType,
 		HypervisorCtlPath:     hypervisorctl,
@@ -975,6 +1466,15 @@ func newClhHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 		return vc.HypervisorConfig{}, err
 	}
 
+	kernelVariants, err := h.kernelVariants()
+	if err != nil {
+		return vc.HypervisorConfig{}, err
+	}
+
+	if err := h.checkCPUMitigations(); err != nil {
+		return vc.HypervisorConfig{}, err
+	}
+
 	initrd, err := h.initrd()
 	if err != nil {
 		return vc.HypervisorConfig{}, err
@@ -1019,6 +1519,16 @@ func newClhHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 			fmt.Errorf("Cloud Hypervisor does not support %s shared filesystem option", sharedFS)
 	}
 
+	memoryTHP, err := h.memoryTHP()
+	if err != nil {
+		return vc.HypervisorConfig{}, err
+	}
+
+	memMerge, err := h.memMerge()
+	if err != nil {
+		return vc.HypervisorConfig{}, err
+	}
+
 	if (sharedFS == config.VirtioFS || sharedFS == config.VirtioFSNydus) && h.VirtioFSDaemon == "" {
 		return vc.HypervisorConfig{},
 			fmt.Errorf("cannot enable %s without daemon path in configuration file", sharedFS)
@@ -1028,6 +1538,9 @@ func newClhHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 		HypervisorPath:                 hypervisor,
 		HypervisorPathList:             h.HypervisorPathList,
 		KernelPath:                     kernel,
+		KernelVariants:                 kernelVariants,
+		CPUMitigations:                 h.CPUMitigations,
+		ValidCPUMitigations:            h.ValidCPUMitigations,
 		InitrdPath:                     initrd,
 		ImagePath:                      image,
 		RootfsType:                     rootfsType,
@@ -1053,6 +1566,9 @@ func newClhHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 		VirtioFSCache:                  h.VirtioFSCache,
 		MemPrealloc:                    h.MemPrealloc,
 		HugePages:                      h.HugePages,
+		MemMerge:                       memMerge,
+		ReclaimGuestFreedMemory:        h.ReclaimGuestFreedMemory,
+		MemoryTHP:                      memoryTHP,
 		FileBackedMemRootDir:           h.FileBackedMemRootDir,
 		FileBackedMemRootList:          h.FileBackedMemRootList,
 		Debug:                          h.Debug,
@@ -1069,8 +1585,10 @@ func newClhHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 		DisableVhostNet:                true,
 		GuestHookPath:                  h.guestHookPath(),
 		VirtioFSExtraArgs:              h.VirtioFSExtraArgs,
+		VirtioFSSubmounts:              h.VirtioFSSubmounts,
 		SGXEPCSize:                     defaultSGXEPCSize,
 		EnableAnnotations:              h.EnableAnnotations,
+		ExtraAPIFieldsList:             h.ExtraAPIFieldsList,
 		DisableSeccomp:                 h.DisableSeccomp,
 		ConfidentialGuest:              h.ConfidentialGuest,
 		Rootless:                       h.Rootless,
@@ -1093,6 +1611,15 @@ func newDragonballHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 		return vc.HypervisorConfig{}, err
 	}
 
+	kernelVariants, err := h.kernelVariants()
+	if err != nil {
+		return vc.HypervisorConfig{}, err
+	}
+
+	if err := h.checkCPUMitigations(); err != nil {
+		return vc.HypervisorConfig{}, err
+	}
+
 	image, err := h.image()
 	if err != nil {
 		return vc.HypervisorConfig{}, err
@@ -1106,16 +1633,19 @@ func newDragonballHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 	kernelParams := h.kernelParams()
 
 	return vc.HypervisorConfig{
-		KernelPath:      kernel,
-		ImagePath:       image,
-		RootfsType:      rootfsType,
-		KernelParams:    vc.DeserializeParams(strings.Fields(kernelParams)),
-		NumVCPUs:        h.defaultVCPUs(),
-		DefaultMaxVCPUs: h.defaultMaxVCPUs(),
-		MemorySize:      h.defaultMemSz(),
-		MemSlots:        h.defaultMemSlots(),
-		EntropySource:   h.GetEntropySource(),
-		Debug:           h.Debug,
+		KernelPath:          kernel,
+		KernelVariants:      kernelVariants,
+		CPUMitigations:      h.CPUMitigations,
+		ValidCPUMitigations: h.ValidCPUMitigations,
+		ImagePath:           image,
+		RootfsType:          rootfsType,
+		KernelParams:        vc.DeserializeParams(strings.Fields(kernelParams)),
+		NumVCPUs:            h.defaultVCPUs(),
+		DefaultMaxVCPUs:     h.defaultMaxVCPUs(),
+		MemorySize:          h.defaultMemSz(),
+		MemSlots:            h.defaultMemSlots(),
+		EntropySource:       h.GetEntropySource(),
+		Debug:               h.Debug,
 	}, nil
 }
 
@@ -1127,10 +1657,11 @@ func newFactoryConfig(f factory) (oci.FactoryConfig, error) {
 		f.VMCacheEndpoint = defaultVMCacheEndpoint
 	}
 	return oci.FactoryConfig{
-		Template:        f.Template,
-		TemplatePath:    f.TemplatePath,
-		VMCacheNumber:   f.VMCacheNumber,
-		VMCacheEndpoint: f.VMCacheEndpoint,
+		Template:         f.Template,
+		TemplatePrefetch: f.TemplatePrefetch,
+		TemplatePath:     f.TemplatePath,
+		VMCacheNumber:    f.VMCacheNumber,
+		VMCacheEndpoint:  f.VMCacheEndpoint,
 	}, nil
 }
 
@@ -1169,13 +1700,25 @@ func updateRuntimeConfigHypervisor(configPath string, tomlConf tomlConfig, confi
 
 func updateRuntimeConfigAgent(configPath string, tomlConf tomlConfig, config *oci.RuntimeConfig) error {
 	for _, agent := range tomlConf.Agent {
+		transport, err := agent.transport()
+		if err != nil {
+			return err
+		}
+
+		streamConnections, err := agent.streamConnections()
+		if err != nil {
+			return err
+		}
+
 		config.AgentConfig = vc.KataAgentConfig{
+			Transport:          transport,
 			LongLiveConn:       true,
 			Debug:              agent.debug(),
 			Trace:              agent.trace(),
 			KernelModules:      agent.kernelModules(),
 			EnableDebugConsole: agent.debugConsoleEnabled(),
 			DialTimeout:        agent.dialTimout(),
+			StreamConnections:  streamConnections,
 		}
 	}
 
@@ -1246,6 +1789,14 @@ func updateRuntimeConfig(configPath string, tomlConf tomlConfig, config *oci.Run
 		return err
 	}
 
+	// Only the vsock agent transport requires vsock support from the host
+	transport := config.AgentConfig.Transport
+	if config.HypervisorType == vc.QemuHypervisor && (transport == "" || transport == vc.AgentTransportVSock) {
+		if vSock, err := utils.SupportsVsocks(); !vSock {
+			return fmt.Errorf("%v: %v", configPath, err)
+		}
+	}
+
 	fConfig, err := newFactoryConfig(tomlConf.Factory)
 	if err != nil {
 		return fmt.Errorf("%v: %v", configPath, err)
@@ -1260,6 +1811,32 @@ func updateRuntimeConfig(configPath string, tomlConf tomlConfig, config *oci.Run
 	return nil
 }
 
+// checkSharedBootAssets checks the guests can boot from assets shared by
+// the sandboxes. The root filesystem is read from the NVDIMM image through
+// DAX, in place of an initrd unpacked in the private memory of each guest.
+// The kernel and firmware are still copied in the guest memory by QEMU, the
+// copies being identical across the guests for KSM to merge.
+func (h hypervisor) checkSharedBootAssets(initrd string) error {
+	if !h.SharedBootAssets {
+		return nil
+	}
+
+	if initrd != "" {
+		return fmt.Errorf("shared_boot_assets needs a guest image, the initrd is unpacked in the private memory of each guest")
+	}
+	if h.DisableImageNvdimm {
+		return fmt.Errorf("shared_boot_assets needs the guest image to be mapped as an NVDIMM")
+	}
+	if h.ConfidentialGuest {
+		return fmt.Errorf("shared_boot_assets cannot be used with confidential guests, whose memory is private")
+	}
+	if h.MemMerge == vc.MemMergeOff {
+		return fmt.Errorf("shared_boot_assets cannot be used with mem_merge = \"off\", the guest kernel and firmware are merged by KSM")
+	}
+
+	return nil
+}
+
 func GetDefaultHypervisorConfig() vc.HypervisorConfig {
 	return vc.HypervisorConfig{
 		HypervisorPath:           defaultHypervisorPath,
@@ -1355,6 +1932,10 @@ func LoadConfiguration(configPath string, ignoreLogging bool) (resolvedConfigPat
 		return "", oci.RuntimeConfig{}, err
 	}
 
+	if err = applyProfile(&tomlConf); err != nil {
+		return "", oci.RuntimeConfig{}, err
+	}
+
 	config.Debug = tomlConf.Runtime.Debug
 	if !tomlConf.Runtime.Debug {
 		// If debug is not required, switch back to the original
@@ -1380,6 +1961,10 @@ func LoadConfiguration(configPath string, ignoreLogging bool) (resolvedConfigPat
 		}
 	}
 
+	if !vc.ValidGuestSeccompMode(tomlConf.Runtime.GuestSeccompMode) {
+		return "", config, fmt.Errorf("Invalid guest_seccomp_mode %q", tomlConf.Runtime.GuestSeccompMode)
+	}
+
 	if !ignoreLogging {
 		err := handleSystemLog("", "")
 		if err != nil {
@@ -1398,12 +1983,38 @@ func LoadConfiguration(configPath string, ignoreLogging bool) (resolvedConfigPat
 	}
 
 	config.DisableGuestSeccomp = tomlConf.Runtime.DisableGuestSeccomp
+	config.GuestSeccompMode = tomlConf.Runtime.GuestSeccompMode
+	config.GuestSeccompReport = tomlConf.Runtime.GuestSeccompReport
+	config.GuestPidsLimit = tomlConf.Runtime.GuestPidsLimit
+	config.MultipathEvents = tomlConf.Runtime.MultipathEvents
+	config.StopFlushTimeout = tomlConf.Runtime.StopFlushTimeout
+	config.ConfirmExecTimeout = tomlConf.Runtime.ConfirmExecTimeout
+	config.ForensicSnapshotThreshold = tomlConf.Runtime.ForensicSnapshotThreshold
+	config.ForensicSnapshotCount = tomlConf.Runtime.ForensicSnapshotCount
+	config.UsageSpoolDir = tomlConf.Runtime.UsageSpoolDir
+	config.UsageSnapshotInterval = tomlConf.Runtime.UsageSnapshotInterval
+	config.GuestNameResolution = tomlConf.Runtime.GuestNameResolution
+	config.MetadataService = tomlConf.Runtime.MetadataService
+	config.Pauseless = tomlConf.Runtime.Pauseless
+	config.SharePidNs = tomlConf.Runtime.SharePidNs
+	config.Profile = tomlConf.Runtime.Profile
+	config.MetadataAnnotations = tomlConf.Runtime.MetadataAnnotations
 	config.EnableVCPUsPinning = tomlConf.Runtime.EnableVCPUsPinning
+
+	if !vc.ValidVMMSchedClass(tomlConf.Runtime.VMMSchedClass) {
+		return "", config, fmt.Errorf("Invalid vmm_sched_class %q, valid classes are default, latency and batch", tomlConf.Runtime.VMMSchedClass)
+	}
+	config.VMMSchedClass = tomlConf.Runtime.VMMSchedClass
+	config.VMMSchedRTPriority = tomlConf.Runtime.VMMSchedRTPriority
+	config.VMMSchedMaxRTPriority = tomlConf.Runtime.VMMSchedMaxRTPriority
+
 	config.GuestSeLinuxLabel = tomlConf.Runtime.GuestSeLinuxLabel
 	config.StaticSandboxResourceMgmt = tomlConf.Runtime.StaticSandboxResourceMgmt
 	config.SandboxCgroupOnly = tomlConf.Runtime.SandboxCgroupOnly
 	config.DisableNewNetNs = tomlConf.Runtime.DisableNewNetNs
+	config.SimulateNetwork = tomlConf.Runtime.SimulateNetwork
 	config.EnablePprof = tomlConf.Runtime.EnablePprof
+	config.EnableFaultInjection = tomlConf.Runtime.EnableFaultInjection
 	config.JaegerEndpoint = tomlConf.Runtime.JaegerEndpoint
 	config.JaegerUser = tomlConf.Runtime.JaegerUser
 	config.JaegerPassword = tomlConf.Runtime.JaegerPassword
@@ -1415,12 +2026,115 @@ func LoadConfiguration(configPath string, ignoreLogging bool) (resolvedConfigPat
 		config.Experimental = append(config.Experimental, *feature)
 	}
 
+	if err := featureflags.Configure(tomlConf.Runtime.FeatureFlags); err != nil {
+		return "", config, err
+	}
+
 	if err = validateBindMounts(tomlConf.Runtime.SandboxBindMounts); err != nil {
 		return "", config, err
 	}
 	config.SandboxBindMounts = tomlConf.Runtime.SandboxBindMounts
 
+	if tomlConf.Runtime.EntitlementsPath != "" {
+		if config.EntitlementsPath, err = ResolvePath(tomlConf.Runtime.EntitlementsPath); err != nil {
+			return "", config, fmt.Errorf("Invalid entitlements_path: %v", err)
+		}
+	}
+
+	if tomlConf.Runtime.NetworkPolicyObject != "" {
+		if config.NetworkPolicyObject, err = ResolvePath(tomlConf.Runtime.NetworkPolicyObject); err != nil {
+			return "", config, fmt.Errorf("Invalid network_policy_bpf_object: %v", err)
+		}
+	}
+
+	if tomlConf.Runtime.AgentAPIPolicy != "" {
+		path, err := ResolvePath(tomlConf.Runtime.AgentAPIPolicy)
+		if err != nil {
+			return "", config, fmt.Errorf("Invalid agent_api_policy: %v", err)
+		}
+		if config.AgentAPIPolicy, err = agentapi.LoadPolicy(path); err != nil {
+			return "", config, fmt.Errorf("Invalid agent_api_policy: %v", err)
+		}
+	}
+
+	// The daemon may start after the runtime, its socket is not resolved.
+	if socket := tomlConf.Runtime.SeccompNotifySocket; socket != "" {
+		if !filepath.IsAbs(socket) {
+			return "", config, fmt.Errorf("Invalid seccomp_notify_socket: %q is not an absolute path", socket)
+		}
+		config.SeccompNotifySocket = filepath.Clean(socket)
+	}
+
+	for _, h := range tomlConf.Runtime.ResourceHooks {
+		hook, err := ResolvePath(h)
+		if err != nil {
+			return "", config, fmt.Errorf("Invalid resource hook: %v", err)
+		}
+		config.ResourceHooks = append(config.ResourceHooks, hook)
+	}
+
+	for _, pattern := range tomlConf.Runtime.ImageVolumePaths {
+		if _, err := filepath.Match(pattern, ""); err != nil || !filepath.IsAbs(pattern) {
+			return "", config, fmt.Errorf("Invalid image_volume_paths pattern %q", pattern)
+		}
+	}
+	config.ImageVolumePaths = tomlConf.Runtime.ImageVolumePaths
+
+	switch tomlConf.Runtime.SandboxPlacement {
+	case "", vc.PlacementSpread, vc.PlacementPack:
+		config.Placement = tomlConf.Runtime.SandboxPlacement
+	default:
+		return "", config, fmt.Errorf("Invalid sandbox_placement %q, expected %s or %s", tomlConf.Runtime.SandboxPlacement, vc.PlacementSpread, vc.PlacementPack)
+	}
+	switch tomlConf.Runtime.SandboxPlacementDomain {
+	case "", vc.PlacementDomainNUMA, vc.PlacementDomainL3:
+		config.PlacementDomain = tomlConf.Runtime.SandboxPlacementDomain
+	default:
+		return "", config, fmt.Errorf("Invalid sandbox_placement_domain %q, expected %s or %s", tomlConf.Runtime.SandboxPlacementDomain, vc.PlacementDomainNUMA, vc.PlacementDomainL3)
+	}
+
+	if config.HostDevicePolicies, err = parseHostDevicePolicies(tomlConf.Runtime.HostDevicePolicy); err != nil {
+		return "", config, err
+	}
+
+	for _, r := range tomlConf.Runtime.KernelParamsRules {
+		rule, err := vc.ParseKernelParamsRule(r)
+		if err != nil {
+			return "", config, err
+		}
+		config.KernelParamsRules = append(config.KernelParamsRules, rule)
+	}
+
 	config.DisableGuestEmptyDir = tomlConf.Runtime.DisableGuestEmptyDir
+	config.HostContainerRuntime = tomlConf.Runtime.HostContainerRuntime
+	config.HostContainerNames = tomlConf.Runtime.HostContainerNames
+	config.HostContainerImages = tomlConf.Runtime.HostContainerImages
+
+	if config.CoreDump, err = tomlConf.Runtime.coreDump(); err != nil {
+		return "", config, err
+	}
+	config.CoreDumpNamespaces = tomlConf.Runtime.CoreDumpNamespaces
+
+	if config.TmpfsSizing, err = tomlConf.Runtime.tmpfsSizing(); err != nil {
+		return "", config, err
+	}
+
+	if config.VMMLimits, err = tomlConf.Runtime.vmmLimits(); err != nil {
+		return "", config, err
+	}
+	config.BootConcurrency = tomlConf.Runtime.SandboxBootConcurrency
+	config.LazyDeviceAttach = tomlConf.Runtime.LazyDeviceAttach
+
+	if config.StdioLogDrivers, err = tomlConf.Runtime.stdioLogDrivers(); err != nil {
+		return "", config, err
+	}
+	config.StdioLogNamespaces = tomlConf.Runtime.StdioLogNamespaces
+	config.StdioFluentdAddress = tomlConf.Runtime.StdioFluentdAddress
+
+	if config.PprofMutexProfileFraction, config.PprofBlockProfileRate, err = tomlConf.Runtime.pprofRates(); err != nil {
+		return "", config, err
+	}
+	config.PprofNamespaces = tomlConf.Runtime.PprofNamespaces
 
 	if err := checkConfig(config); err != nil {
 		return "", config, err
@@ -1454,6 +2168,20 @@ func validateBindMounts(mounts []string) error {
 	return nil
 }
 
+func parseHostDevicePolicies(policies []string) ([]config.HostDevicePolicyRule, error) {
+	var rules []config.HostDevicePolicyRule
+
+	for _, p := range policies {
+		rule, err := config.ParseHostDevicePolicyRule(p)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
 func decodeConfig(configPath string) (tomlConfig, string, error) {
 	var (
 		resolved string
@@ -1660,6 +2388,10 @@ func checkConfig(config oci.RuntimeConfig) error {
 		return err
 	}
 
+	if err := checkNetworkModelConfig(config); err != nil {
+		return err
+	}
+
 	if err := checkHypervisorConfig(config.HypervisorConfig); err != nil {
 		return err
 	}
@@ -1668,6 +2400,10 @@ func checkConfig(config oci.RuntimeConfig) error {
 		return err
 	}
 
+	if err := checkHostContainerConfig(config); err != nil {
+		return err
+	}
+
 	hotPlugVFIO := config.HypervisorConfig.HotPlugVFIO
 	coldPlugVFIO := config.HypervisorConfig.ColdPlugVFIO
 	machineType := config.HypervisorConfig.HypervisorMachineType
@@ -1709,6 +2445,27 @@ func checkPCIeConfig(coldPlug config.PCIePort, hotPlug config.PCIePort, machineT
 		coldPlug, config.NoPort, config.BridgePort, config.RootPort, config.SwitchPort)
 }
 
+// checkHostContainerConfig ensures the host containers are restricted to the
+// containers allowed by the operator, the annotation of a pod cannot run any
+// of its containers on the host by itself.
+func checkHostContainerConfig(config oci.RuntimeConfig) error {
+	if config.HostContainerRuntime == "" {
+		return nil
+	}
+
+	if len(config.HostContainerNames) == 0 && len(config.HostContainerImages) == 0 {
+		return fmt.Errorf("host_container_runtime requires host_container_names or host_container_images")
+	}
+
+	for _, pattern := range config.HostContainerImages {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid host_container_images pattern %q: %w", pattern, err)
+		}
+	}
+
+	return nil
+}
+
 // checkNetNsConfig performs sanity checks on disable_new_netns config.
 // Because it is an expert option and conflicts with some other common configs.
 func checkNetNsConfig(config oci.RuntimeConfig) error {
@@ -1721,6 +2478,16 @@ func checkNetNsConfig(config oci.RuntimeConfig) error {
 	return nil
 }
 
+// checkNetworkModelConfig ensures the internetworking model is supported by
+// the hypervisor.
+func checkNetworkModelConfig(config oci.RuntimeConfig) error {
+	if config.InterNetworkModel == vc.NetXConnectAFXDPModel && config.HypervisorType != vc.QemuHypervisor {
+		return fmt.Errorf("'af_xdp' internetworking_model is only supported with QEMU")
+	}
+
+	return nil
+}
+
 // checkFactoryConfig ensures the VM factory configuration is valid.
 func checkFactoryConfig(config oci.RuntimeConfig) error {
 	if config.FactoryConfig.Template {
@@ -178,6 +178,7 @@ func createAllRuntimeConfigFiles(dir, hypervisor string) (testConfig testRuntime
 		Msize9p:               defaultMsize9p,
 		MemSlots:              defaultMemSlots,
 		EntropySource:         defaultEntropySource,
+		EntropyBackend:        vc.EntropyBackendRandom,
 		GuestHookPath:         defaultGuestHookPath,
 		VhostUserStorePath:    defaultVhostUserStorePath,
 		SharedFS:              sharedFS,
@@ -1159,6 +1160,150 @@ func TestAgentDefaults(t *testing.T) {
 	assert.Equal(a.trace(), a.Tracing)
 }
 
+func TestAgentTransport(t *testing.T) {
+	assert := assert.New(t)
+
+	a := agent{}
+
+	transport, err := a.transport()
+	assert.NoError(err)
+	assert.Equal(vc.AgentTransportVSock, transport)
+
+	a.Transport = vc.AgentTransportSerial
+	transport, err = a.transport()
+	assert.NoError(err)
+	assert.Equal(vc.AgentTransportSerial, transport)
+
+	a.Transport = "tls"
+	_, err = a.transport()
+	assert.Error(err)
+
+	a.Transport = "carrier-pigeon"
+	_, err = a.transport()
+	assert.Error(err)
+}
+
+func TestCheckSharedBootAssets(t *testing.T) {
+	assert := assert.New(t)
+
+	h := hypervisor{}
+	assert.NoError(h.checkSharedBootAssets("/usr/share/kata-containers/kata-containers-initrd.img"))
+
+	h.SharedBootAssets = true
+	assert.NoError(h.checkSharedBootAssets(""))
+	assert.Error(h.checkSharedBootAssets("/usr/share/kata-containers/kata-containers-initrd.img"))
+
+	h.DisableImageNvdimm = true
+	assert.Error(h.checkSharedBootAssets(""))
+
+	h = hypervisor{SharedBootAssets: true, ConfidentialGuest: true}
+	assert.Error(h.checkSharedBootAssets(""))
+
+	h = hypervisor{SharedBootAssets: true, MemMerge: vc.MemMergeOff}
+	assert.Error(h.checkSharedBootAssets(""))
+}
+
+func TestRuntimeStdioLogDrivers(t *testing.T) {
+	assert := assert.New(t)
+
+	r := runtime{}
+	drivers, err := r.stdioLogDrivers()
+	assert.NoError(err)
+	assert.Empty(drivers)
+
+	r.StdioLogDrivers = []string{"journald", "syslog"}
+	drivers, err = r.stdioLogDrivers()
+	assert.NoError(err)
+	assert.Equal([]string{"journald", "syslog"}, drivers)
+
+	// fluentd needs an address
+	r.StdioLogDrivers = []string{"fluentd"}
+	_, err = r.stdioLogDrivers()
+	assert.Error(err)
+
+	r.StdioFluentdAddress = "tcp://127.0.0.1:24224"
+	_, err = r.stdioLogDrivers()
+	assert.NoError(err)
+
+	r.StdioLogDrivers = []string{"splunk"}
+	_, err = r.stdioLogDrivers()
+	assert.Error(err)
+}
+
+func TestRuntimePprofRates(t *testing.T) {
+	assert := assert.New(t)
+
+	r := runtime{}
+	mutexFraction, blockRate, err := r.pprofRates()
+	assert.NoError(err)
+	assert.Zero(mutexFraction)
+	assert.Zero(blockRate)
+
+	r.PprofMutexProfileFraction = 5
+	r.PprofBlockProfileRate = 1000
+	mutexFraction, blockRate, err = r.pprofRates()
+	assert.NoError(err)
+	assert.Equal(5, mutexFraction)
+	assert.Equal(1000, blockRate)
+
+	r.PprofMutexProfileFraction = -1
+	_, _, err = r.pprofRates()
+	assert.Error(err)
+
+	r.PprofMutexProfileFraction = 0
+	r.PprofBlockProfileRate = -1
+	_, _, err = r.pprofRates()
+	assert.Error(err)
+}
+
+func TestRuntimeVMMLimits(t *testing.T) {
+	assert := assert.New(t)
+
+	r := runtime{}
+	limits, err := r.vmmLimits()
+	assert.NoError(err)
+	assert.Equal(vc.VMMLimits{}, limits)
+
+	r.VMMMaxOpenFiles = 65536
+	r.VMMMaxThreads = 512
+	r.VMMLimitAlertPercent = 90
+	limits, err = r.vmmLimits()
+	assert.NoError(err)
+	assert.Equal(vc.VMMLimits{MaxOpenFiles: 65536, MaxThreads: 512, AlertPercent: 90}, limits)
+
+	r.VMMLimitAlertPercent = 101
+	_, err = r.vmmLimits()
+	assert.Error(err)
+}
+
+func TestRuntimeCoreDump(t *testing.T) {
+	assert := assert.New(t)
+
+	r := runtime{}
+
+	coreDump, err := r.coreDump()
+	assert.NoError(err)
+	assert.False(coreDump.Enabled)
+	assert.Equal(defaultCoreDumpDir, coreDump.HostDir)
+
+	r.EnableCoreDumps = true
+	r.CoreDumpDir = "/var/crash/kata/"
+	r.CoreDumpMaxSize = 64
+	r.CoreDumpDirMaxSize = 1024
+	coreDump, err = r.coreDump()
+	assert.NoError(err)
+	assert.Equal(vc.CoreDumpConfig{
+		HostDir:     "/var/crash/kata",
+		MaxCoreSize: 64 << 20,
+		MaxDirSize:  1024 << 20,
+		Enabled:     true,
+	}, coreDump)
+
+	r.CoreDumpDir = "cores"
+	_, err = r.coreDump()
+	assert.Error(err)
+}
+
 func TestGetDefaultConfigFilePaths(t *testing.T) {
 	assert := assert.New(t)
 
@@ -1283,6 +1428,34 @@ func TestDefaultFirmware(t *testing.T) {
 	defaultFirmwarePath = oldDefaultFirmwarePath
 }
 
+func TestKernelVariants(t *testing.T) {
+	assert := assert.New(t)
+
+	kernel := filepath.Join(t.TempDir(), "vmlinux-rt")
+	assert.NoError(os.WriteFile(kernel, []byte("kernel"), 0644))
+
+	h := hypervisor{}
+	variants, err := h.kernelVariants()
+	assert.NoError(err)
+	assert.Nil(variants)
+
+	h.KernelVariants = map[string]kernelVariant{
+		"rt": {Path: kernel, KernelParams: "isolcpus=1 nohz_full=1"},
+	}
+	variants, err = h.kernelVariants()
+	assert.NoError(err)
+	assert.Equal(map[string]vc.KernelVariant{
+		"rt": {
+			Path:   kernel,
+			Params: []vc.Param{{Key: "isolcpus", Value: "1"}, {Key: "nohz_full", Value: "1"}},
+		},
+	}, variants)
+
+	h.KernelVariants["hardened"] = kernelVariant{Path: "/does/not/exist"}
+	_, err = h.kernelVariants()
+	assert.Error(err)
+}
+
 func TestDefaultFirmwareVolume(t *testing.T) {
 	assert := assert.New(t)
 
@@ -1594,6 +1767,42 @@ func TestCheckNetNsConfig(t *testing.T) {
 	assert.Error(err)
 }
 
+func TestCheckHostContainerConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	config := oci.RuntimeConfig{}
+	assert.NoError(checkHostContainerConfig(config))
+
+	// the annotation alone cannot run containers on the host
+	config.HostContainerRuntime = "/usr/bin/runc"
+	assert.Error(checkHostContainerConfig(config))
+
+	config.HostContainerNames = []string{"istio-proxy"}
+	assert.NoError(checkHostContainerConfig(config))
+
+	config.HostContainerImages = []string{"docker.io/istio/proxyv2:*"}
+	assert.NoError(checkHostContainerConfig(config))
+
+	config.HostContainerImages = []string{"docker.io/istio/proxyv2:["}
+	assert.Error(checkHostContainerConfig(config))
+}
+
+func TestCheckNetworkModelConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	config := oci.RuntimeConfig{
+		HypervisorType:    vc.QemuHypervisor,
+		InterNetworkModel: vc.NetXConnectAFXDPModel,
+	}
+	assert.NoError(checkNetworkModelConfig(config))
+
+	config.HypervisorType = vc.ClhHypervisor
+	assert.Error(checkNetworkModelConfig(config))
+
+	config.InterNetworkModel = vc.NetXConnectTCFilterModel
+	assert.NoError(checkNetworkModelConfig(config))
+}
+
 func TestCheckFactoryConfig(t *testing.T) {
 	assert := assert.New(t)
 
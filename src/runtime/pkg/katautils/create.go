@@ -66,10 +66,11 @@ func HandleFactory(ctx context.Context, vci vc.VC, runtimeConfig *oci.RuntimeCon
 		return
 	}
 	factoryConfig := vf.Config{
-		Template:        runtimeConfig.FactoryConfig.Template,
-		TemplatePath:    runtimeConfig.FactoryConfig.TemplatePath,
-		VMCache:         runtimeConfig.FactoryConfig.VMCacheNumber > 0,
-		VMCacheEndpoint: runtimeConfig.FactoryConfig.VMCacheEndpoint,
+		Template:         runtimeConfig.FactoryConfig.Template,
+		TemplatePrefetch: runtimeConfig.FactoryConfig.TemplatePrefetch,
+		TemplatePath:     runtimeConfig.FactoryConfig.TemplatePath,
+		VMCache:          runtimeConfig.FactoryConfig.VMCacheNumber > 0,
+		VMCacheEndpoint:  runtimeConfig.FactoryConfig.VMCacheEndpoint,
 		VMConfig: vc.VMConfig{
 			HypervisorType:   runtimeConfig.HypervisorType,
 			HypervisorConfig: runtimeConfig.HypervisorConfig,
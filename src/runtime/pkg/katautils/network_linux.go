@@ -36,6 +36,12 @@ func SetupNetworkNamespace(config *vc.NetworkConfig) error {
 		return nil
 	}
 
+	if config.Simulated {
+		kataUtilsLogger.Info("SimulateNetwork is on, shim and hypervisor are running in the current netns")
+		config.NetworkID = ""
+		return nil
+	}
+
 	var err error
 	var n ns.NetNS
 
@@ -149,3 +149,13 @@ func TestSetupNetworkNamespace(t *testing.T) {
 	err = SetupNetworkNamespace(config)
 	assert.NoError(err)
 }
+
+func TestSetupNetworkNamespaceSimulated(t *testing.T) {
+	assert := assert.New(t)
+
+	// the given netns is ignored and none is created
+	config := &vc.NetworkConfig{NetworkID: "/proc/123456789/ns/net", Simulated: true}
+	assert.NoError(SetupNetworkNamespace(config))
+	assert.Empty(config.NetworkID)
+	assert.False(config.NetworkCreated)
+}
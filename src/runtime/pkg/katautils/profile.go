@@ -0,0 +1,153 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package katautils
+
+import (
+	"fmt"
+	"reflect"
+
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+)
+
+// A profile applies a coordinated set of hypervisor options as one switch,
+// e.g. for a runtime class:
+//
+//	[runtime]
+//	profile = "density"
+const (
+	// densityProfile packs many small sandboxes on a node: the guests
+	// start with less memory and grow it with virtio-mem, give the pages
+	// they free back to the host, have their memory merged by KSM and keep
+	// the files of the containers in the page cache of the host only.
+	densityProfile = "density"
+
+	densityMemorySize uint32 = 512 // MiB
+)
+
+// profile is a set of hypervisor options.
+type profile struct {
+	// hypervisors are the hypervisors supporting the options
+	hypervisors []string
+
+	options []profileOption
+
+	// confidential profiles can be used with confidential guests
+	confidential bool
+}
+
+// profileOption is a hypervisor option set by a profile.
+type profileOption struct {
+	// value is the value the profile sets
+	value interface{}
+
+	// defaultValue is the default of a tunable option
+	defaultValue interface{}
+
+	// key is the TOML key of the option in the hypervisor table
+	key string
+
+	// conflicts are the values of the option the profile cannot be
+	// used with
+	conflicts []interface{}
+
+	// hypervisors are the hypervisors the option applies to, all those of
+	// the profile when empty
+	hypervisors []string
+
+	// tunable options set to another value than their default are kept
+	tunable bool
+}
+
+func (o profileOption) appliesTo(hypervisorName string) bool {
+	return len(o.hypervisors) == 0 || contains(o.hypervisors, hypervisorName)
+}
+
+var profiles = map[string]profile{
+	// Cloud Hypervisor grows the guest memory with ACPI hotplug rather
+	// than virtio-mem, and shares it with virtiofsd, which KSM does not
+	// merge: virtio-mem and KSM only apply to QEMU.
+	densityProfile: {
+		hypervisors: []string{qemuHypervisorTableType, clhHypervisorTableType},
+		options: []profileOption{
+			{key: "default_memory", value: densityMemorySize, defaultValue: defaultMemSize, tunable: true},
+			{key: "enable_virtio_mem", value: true, hypervisors: []string{qemuHypervisorTableType}},
+			{key: "reclaim_guest_freed_memory", value: true},
+			{key: "mem_merge", value: vc.MemMergeOn, conflicts: []interface{}{vc.MemMergeOff}, hypervisors: []string{qemuHypervisorTableType}},
+			{key: "virtio_fs_cache", value: "never", conflicts: []interface{}{"always"}},
+		},
+	},
+	// The options of the sandboxes of the low latency profile, which can
+	// also be selected per pod, are applied to their configuration.
+	vc.LowLatencyProfile: {
+		hypervisors: []string{qemuHypervisorTableType, clhHypervisorTableType},
+		options: []profileOption{
+			{key: "enable_virtio_mem", value: false, conflicts: []interface{}{true}, hypervisors: []string{qemuHypervisorTableType}},
+			{key: "reclaim_guest_freed_memory", value: false, conflicts: []interface{}{true}},
+		},
+		confidential: true,
+	},
+}
+
+// applyProfile sets the options of the profile of the configuration, and
+// returns an error when the configuration conflicts with it.
+func applyProfile(tomlConf *tomlConfig) error {
+	name := tomlConf.Runtime.Profile
+	if name == "" {
+		return nil
+	}
+
+	p, ok := profiles[name]
+	if !ok {
+		return fmt.Errorf("Unknown profile %q", name)
+	}
+
+	for hypervisorName, h := range tomlConf.Hypervisor {
+		if !contains(p.hypervisors, hypervisorName) {
+			return fmt.Errorf("The %s profile is not supported with the %s hypervisor", name, hypervisorName)
+		}
+		if h.ConfidentialGuest && !p.confidential {
+			return fmt.Errorf("The %s profile cannot be used with confidential guests", name)
+		}
+
+		hv := reflect.ValueOf(&h).Elem()
+		for _, o := range p.options {
+			if !o.appliesTo(hypervisorName) {
+				continue
+			}
+
+			current, err := getValue(hv, o.key)
+			if err != nil {
+				return err
+			}
+
+			if o.tunable && !current.IsZero() && current.Interface() != o.defaultValue {
+				continue
+			}
+			for _, c := range o.conflicts {
+				if current.Interface() == c {
+					return fmt.Errorf("%s = %v conflicts with the %s profile", o.key, c, name)
+				}
+			}
+
+			if err := setValue(hv, o.key, reflect.ValueOf(o.value)); err != nil {
+				return err
+			}
+		}
+
+		tomlConf.Hypervisor[hypervisorName] = h
+	}
+
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
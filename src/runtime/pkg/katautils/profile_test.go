@@ -0,0 +1,76 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package katautils
+
+import (
+	"testing"
+
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyProfile(t *testing.T) {
+	assert := assert.New(t)
+
+	newConf := func(profile string, h hypervisor) *tomlConfig {
+		return &tomlConfig{
+			Hypervisor: map[string]hypervisor{qemuHypervisorTableType: h},
+			Runtime:    runtime{Profile: profile},
+		}
+	}
+
+	// Without a profile, the configuration is left as is
+	conf := newConf("", hypervisor{MemorySize: defaultMemSize, VirtioFSCache: "auto"})
+	assert.NoError(applyProfile(conf))
+	assert.Equal(hypervisor{MemorySize: defaultMemSize, VirtioFSCache: "auto"}, conf.Hypervisor[qemuHypervisorTableType])
+
+	conf = newConf(densityProfile, hypervisor{MemorySize: defaultMemSize, VirtioFSCache: "auto"})
+	assert.NoError(applyProfile(conf))
+	h := conf.Hypervisor[qemuHypervisorTableType]
+	assert.Equal(densityMemorySize, h.MemorySize)
+	assert.True(h.VirtioMem)
+	assert.True(h.ReclaimGuestFreedMemory)
+	assert.Equal(vc.MemMergeOn, h.MemMerge)
+	assert.Equal("never", h.VirtioFSCache)
+
+	// virtio-mem and KSM are left alone with Cloud Hypervisor
+	conf = newConf(densityProfile, hypervisor{MemorySize: defaultMemSize, MemMerge: vc.MemMergeOff})
+	conf.Hypervisor = map[string]hypervisor{clhHypervisorTableType: conf.Hypervisor[qemuHypervisorTableType]}
+	assert.NoError(applyProfile(conf))
+	h = conf.Hypervisor[clhHypervisorTableType]
+	assert.Equal(densityMemorySize, h.MemorySize)
+	assert.False(h.VirtioMem)
+	assert.True(h.ReclaimGuestFreedMemory)
+	assert.Equal(vc.MemMergeOff, h.MemMerge)
+	assert.Equal("never", h.VirtioFSCache)
+
+	// The memory size set in the configuration is kept
+	conf = newConf(densityProfile, hypervisor{MemorySize: 256})
+	assert.NoError(applyProfile(conf))
+	assert.Equal(uint32(256), conf.Hypervisor[qemuHypervisorTableType].MemorySize)
+
+	for _, h := range []hypervisor{
+		{MemMerge: vc.MemMergeOff},
+		{VirtioFSCache: "always"},
+		{ConfidentialGuest: true},
+	} {
+		assert.Error(applyProfile(newConf(densityProfile, h)))
+	}
+
+	conf = newConf(densityProfile, hypervisor{})
+	conf.Hypervisor = map[string]hypervisor{firecrackerHypervisorTableType: {}}
+	assert.Error(applyProfile(conf))
+
+	assert.Error(applyProfile(newConf("unknown", hypervisor{})))
+
+	// The low latency profile can be used with confidential guests
+	conf = newConf(vc.LowLatencyProfile, hypervisor{ConfidentialGuest: true, MemorySize: defaultMemSize})
+	assert.NoError(applyProfile(conf))
+	assert.Equal(defaultMemSize, conf.Hypervisor[qemuHypervisorTableType].MemorySize)
+
+	assert.Error(applyProfile(newConf(vc.LowLatencyProfile, hypervisor{VirtioMem: true})))
+	assert.Error(applyProfile(newConf(vc.LowLatencyProfile, hypervisor{ReclaimGuestFreedMemory: true})))
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/api/resource"
 
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/agentapi"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/govmm"
 	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
 
@@ -31,6 +32,7 @@ import (
 	exp "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/experimental"
 	vcAnnotations "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations"
 	dockershimAnnotations "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations/dockershim"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/cpuset"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
 	vcutils "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
 )
@@ -81,6 +83,10 @@ const (
 
 const KernelModulesSeparator = ";"
 
+// nestedContainersAnnotation is the name enabling the nested containers
+// annotation in enable_annotations.
+const nestedContainersAnnotation = "nested_containers"
+
 // FactoryConfig is a structure to set the VM factory configuration.
 type FactoryConfig struct {
 	// TemplatePath specifies the path of template.
@@ -94,6 +100,10 @@ type FactoryConfig struct {
 
 	// Template enables VM templating support in VM factory.
 	Template bool
+
+	// TemplatePrefetch enables prefaulting the template memory of new VMs
+	// in the order it was accessed on a previous boot.
+	TemplatePrefetch bool
 }
 
 // RuntimeConfig aggregates all runtime specific settings
@@ -105,6 +115,35 @@ type RuntimeConfig struct {
 	//Experimental features enabled
 	Experimental []exp.Feature
 
+	// HostDevicePolicies selects how the OCI spec host devices are
+	// made available to the containers
+	HostDevicePolicies []config.HostDevicePolicyRule
+
+	// KernelParamsRules add guest kernel parameters according to the
+	// resources of the sandboxes
+	KernelParamsRules []vc.KernelParamsRule
+
+	// CoreDumpNamespaces restricts the core dumps to the pods of
+	// these Kubernetes namespaces
+	CoreDumpNamespaces []string
+
+	// CoreDump is the core dump policy of the sandboxes
+	CoreDump vc.CoreDumpConfig
+
+	// TmpfsSizing is the sizing policy of the tmpfs of the guests
+	TmpfsSizing vc.TmpfsSizing
+
+	// VMMLimits are the resource limits of the VMM processes
+	VMMLimits vc.VMMLimits
+
+	// BootConcurrency is the number of sandboxes of the node booting at
+	// once
+	BootConcurrency uint32
+
+	// LazyDeviceAttach hotplugs the network interfaces while the guests
+	// boot
+	LazyDeviceAttach bool
+
 	JaegerEndpoint string
 	JaegerUser     string
 	JaegerPassword string
@@ -128,12 +167,118 @@ type RuntimeConfig struct {
 	//Determines if seccomp should be applied inside guest
 	DisableGuestSeccomp bool
 
+	// GuestSeccompReport collects the system calls blocked by seccomp inside guest
+	GuestSeccompReport bool
+
+	// GuestPidsLimit is the pids limit inside guest of the containers
+	// without a limit of their own
+	GuestPidsLimit uint64
+
+	// MultipathEvents reports the path failures of the multipath maps
+	// attached to the sandboxes
+	MultipathEvents bool
+
+	// StopFlushTimeout is how long in seconds the filesystems and the
+	// drives of the containers are flushed for when they stop, 0 for not
+	// flushing them
+	StopFlushTimeout uint32
+
+	// ConfirmExecTimeout is how long in seconds the start of a container
+	// waits for the agent to confirm its process executed its entrypoint,
+	// 0 for not waiting
+	ConfirmExecTimeout uint32
+
+	// ForensicSnapshotThreshold is the number of nonzero exits of a
+	// container after which a diagnostic snapshot is captured at each of
+	// its exits, 0 for none
+	ForensicSnapshotThreshold uint32
+
+	// ForensicSnapshotCount is the number of snapshots kept per sandbox
+	ForensicSnapshotCount uint32
+
+	// UsageSpoolDir is the directory the shims persist the resource usage
+	// of their sandbox in, empty for not persisting it
+	UsageSpoolDir string
+
+	// UsageSnapshotInterval is how often in seconds the resource usage of
+	// the sandboxes is persisted
+	UsageSnapshotInterval uint32
+
+	// GuestNameResolution makes the agent manage the /etc/hosts and
+	// /etc/resolv.conf files of the containers
+	GuestNameResolution bool
+
+	// Pauseless does not create the sandbox containers inside the
+	// guests
+	Pauseless bool
+
+	// SharePidNs makes the containers of the sandboxes share one PID
+	// namespace
+	SharePidNs bool
+
+	// Profile is the profile of the configuration
+	Profile string
+
+	// EntitlementsPath is the host directory of the entitlements
+	// provisioned inside the guests
+	EntitlementsPath string
+
+	// MetadataService serves the metadata of the pods inside the guests
+	MetadataService bool
+
+	// NetworkPolicyObject is the host path of the eBPF object enforcing
+	// the network policies of the pods inside the guests
+	NetworkPolicyObject string
+
+	// AgentAPIPolicy lists the host services allowed to call agent
+	// operations through the agent API broker of the shim, the broker is
+	// disabled when it is nil
+	AgentAPIPolicy *agentapi.Policy
+
+	// SeccompNotifySocket is the socket of the host daemon responding to
+	// the system calls trapped by SCMP_ACT_NOTIFY rules of the seccomp
+	// profiles of the containers, which are rejected when it is empty
+	SeccompNotifySocket string
+
+	// MetadataAnnotations are the patterns of the pod annotations
+	// included in the metadata
+	MetadataAnnotations []string
+
+	// ResourceHooks are the executables adjusting the sandbox resources
+	// when they are created
+	ResourceHooks []string
+
+	// ImageVolumePaths are the patterns of the host paths of the image
+	// volumes
+	ImageVolumePaths []string
+
+	// Placement is the policy placing the sandboxes on host domains
+	Placement string
+
+	// PlacementDomain is the type of the host domains the sandboxes are
+	// placed on
+	PlacementDomain string
+
 	// EnableVCPUsPinning controls whether each vCPU thread should be scheduled to a fixed CPU
 	EnableVCPUsPinning bool
 
+	// VMMSchedClass is the default scheduling class of the VMM threads
+	VMMSchedClass string
+
+	// VMMSchedRTPriority is the default realtime priority of the vCPU
+	// threads of the latency scheduling class
+	VMMSchedRTPriority int
+
+	// VMMSchedMaxRTPriority is the highest realtime priority of the vCPU
+	// threads, the latency scheduling class is disabled when 0
+	VMMSchedMaxRTPriority int
+
 	//SELinux security context applied to the container process inside guest.
 	GuestSeLinuxLabel string
 
+	// GuestSeccompMode selects how seccomp is applied inside guest
+	GuestSeccompMode string
+
 	// Sandbox sizing information which, if provided, indicates the size of
 	// the sandbox needed for the workload(s)
 	SandboxCPUs  uint32
@@ -146,14 +291,61 @@ type RuntimeConfig struct {
 	// Determines if create a netns for hypervisor process
 	DisableNewNetNs bool
 
+	// SimulateNetwork selects the simulated network, with no host network
+	// operation, for the environments without network privileges
+	SimulateNetwork bool
+
 	//Determines kata processes are managed only in sandbox cgroup
 	SandboxCgroupOnly bool
 
 	// Determines if enable pprof
 	EnablePprof bool
 
+	// PprofNamespaces enables pprof for the pods of these Kubernetes
+	// namespaces, or the containers of these containerd namespaces
+	PprofNamespaces []string
+
+	// PprofMutexProfileFraction is the rate of mutex contention events
+	// reported by the pprof mutex profile, it is disabled when 0
+	PprofMutexProfileFraction int
+
+	// PprofBlockProfileRate is the rate of blocking events reported by the
+	// pprof block profile, it is disabled when 0
+	PprofBlockProfileRate int
+
+	// EnableFaultInjection allows injecting faults in the sandbox through
+	// the shim monitoring socket
+	EnableFaultInjection bool
+
+	// StdioLogDrivers are the log drivers the container output is sent
+	// to, in addition to containerd
+	StdioLogDrivers []string
+
+	// StdioLogNamespaces restricts the log drivers to the containers of
+	// these namespaces
+	StdioLogNamespaces []string
+
+	// StdioFluentdAddress is the address of the fluentd forward input
+	StdioFluentdAddress string
+
+	// HostContainerRuntime is the OCI runtime running the host containers
+	// of the pods, the host containers are disabled when empty
+	HostContainerRuntime string
+
+	// HostContainerNames are the names of the containers allowed to run
+	// on the host
+	HostContainerNames []string
+
+	// HostContainerImages are the patterns of the images of the
+	// containers allowed to run on the host
+	HostContainerImages []string
+
 	// Determines if Kata creates emptyDir on the guest
 	DisableGuestEmptyDir bool
+
+	// anyHost skips the checks of the annotations against the resources
+	// of the host, when they are checked for the nodes of a cluster
+	anyHost bool
 }
 
 // AddKernelParam allows the addition of new kernel parameters to an existing
@@ -251,6 +443,16 @@ func regexpContains(regexps []string, toMatch string) bool {
 	return false
 }
 
+// regexpMatchesFully checks if a value is entirely matched by one of the regexps
+func regexpMatchesFully(regexps []string, toMatch string) bool {
+	for _, candidate := range regexps {
+		if matched, _ := regexp.MatchString("^(?:"+candidate+")$", toMatch); matched {
+			return true
+		}
+	}
+	return false
+}
+
 func checkPathIsInGlobs(globs []string, path string) bool {
 	for _, glob := range globs {
 		filenames, _ := filepath.Glob(glob)
@@ -264,6 +466,17 @@ func checkPathIsInGlobs(globs []string, path string) bool {
 	return false
 }
 
+// Check if a value that is not a path matches one of the glob patterns
+func checkValueIsInGlobs(globs []string, value string) bool {
+	for _, glob := range globs {
+		if matched, _ := filepath.Match(glob, value); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Check if an annotation name either belongs to another prefix, matches regexp list
 func checkAnnotationNameIsValid(list []string, name string, prefix string) bool {
 	if strings.HasPrefix(name, prefix) {
@@ -344,6 +557,7 @@ func networkConfig(ocispec specs.Spec, config RuntimeConfig) (vc.NetworkConfig,
 	}
 	netConf.InterworkingModel = config.InterNetworkModel
 	netConf.DisableNewNetwork = config.DisableNewNetNs
+	netConf.Simulated = config.SimulateNetwork
 
 	return netConf, nil
 }
@@ -418,6 +632,8 @@ func addAssetAnnotations(ocispec specs.Spec, config *vc.SandboxConfig) error {
 		return err
 	}
 
+	assetAnnotations = append(assetAnnotations, vcAnnotations.AssetHashType)
+
 	for _, a := range assetAnnotations {
 		value, ok := ocispec.Annotations[a]
 		if ok {
@@ -429,7 +645,7 @@ func addAssetAnnotations(ocispec specs.Spec, config *vc.SandboxConfig) error {
 }
 
 func addHypervisorConfigOverrides(ocispec specs.Spec, config *vc.SandboxConfig, runtime RuntimeConfig) error {
-	if err := addHypervisorCPUOverrides(ocispec, config); err != nil {
+	if err := addHypervisorCPUOverrides(ocispec, config, runtime); err != nil {
 		return err
 	}
 
@@ -457,6 +673,10 @@ func addHypervisorConfigOverrides(ocispec specs.Spec, config *vc.SandboxConfig,
 		return err
 	}
 
+	if err := addHypervisorExtraOverrides(ocispec, config, runtime); err != nil {
+		return err
+	}
+
 	if value, ok := ocispec.Annotations[vcAnnotations.MachineType]; ok {
 		if value != "" {
 			config.HypervisorConfig.HypervisorMachineType = value
@@ -500,12 +720,33 @@ func addHypervisorConfigOverrides(ocispec specs.Spec, config *vc.SandboxConfig,
 		return err
 	}
 
+	if err := newAnnotationConfiguration(ocispec, vcAnnotations.PmemVolumesSize).setUint(func(size uint64) {
+		config.HypervisorConfig.PmemVolumesSize = uint32(size)
+	}); err != nil {
+		return err
+	}
+
 	if err := newAnnotationConfiguration(ocispec, vcAnnotations.HotplugVFIOOnRootBus).setBool(func(hotplugVFIOOnRootBus bool) {
 		config.HypervisorConfig.HotplugVFIOOnRootBus = hotplugVFIOOnRootBus
 	}); err != nil {
 		return err
 	}
 
+	if value, ok := ocispec.Annotations[vcAnnotations.PCIHotplugMode]; ok {
+		switch value {
+		case vc.PCIHotplugAuto, vc.PCIHotplugACPI, vc.PCIHotplugNative:
+			config.HypervisorConfig.PCIHotplugMode = value
+		default:
+			return fmt.Errorf("Invalid PCI hotplug mode %v specified in annotation %v", value, vcAnnotations.PCIHotplugMode)
+		}
+	}
+
+	if err := newAnnotationConfiguration(ocispec, vcAnnotations.PCIeP2P).setBool(func(pcieP2P bool) {
+		config.HypervisorConfig.PCIeP2P = pcieP2P
+	}); err != nil {
+		return err
+	}
+
 	if err := newAnnotationConfiguration(ocispec, vcAnnotations.UseLegacySerial).setBool(func(useLegacySerial bool) {
 		config.HypervisorConfig.LegacySerial = useLegacySerial
 	}); err != nil {
@@ -520,6 +761,41 @@ func addHypervisorConfigOverrides(ocispec specs.Spec, config *vc.SandboxConfig,
 			config.HypervisorConfig.EntropySource = value
 		}
 	}
+
+	if value, ok := ocispec.Annotations[vcAnnotations.VirtioGPU]; ok {
+		supportedVirtioGPU := []string{vc.VirtioGPUVenus, vc.VirtioGPUDRM}
+
+		valid := false
+		for _, g := range supportedVirtioGPU {
+			if g == value {
+				config.HypervisorConfig.VirtioGPU = value
+				valid = true
+			}
+		}
+
+		if !valid {
+			return fmt.Errorf("Invalid virtio-gpu backend %v specified in annotation (supported backends: %v)", value, supportedVirtioGPU)
+		}
+	}
+
+	if value, ok := ocispec.Annotations[vcAnnotations.USBDevices]; ok {
+		var devices []string
+		for _, dev := range strings.Split(value, ",") {
+			dev = strings.TrimSpace(dev)
+			if !checkValueIsInGlobs(runtime.HypervisorConfig.USBDevicesList, dev) {
+				return fmt.Errorf("USB device %v required from annotation is not valid", dev)
+			}
+			devices = append(devices, dev)
+		}
+		config.HypervisorConfig.USBDevices = devices
+	}
+
+	if err := newAnnotationConfiguration(ocispec, vcAnnotations.VirtioGPUHostMem).setUint(func(hostMem uint64) {
+		config.HypervisorConfig.VirtioGPUHostMemMB = uint32(hostMem)
+	}); err != nil {
+		return err
+	}
+
 	if epcSize, ok := ocispec.Annotations[vcAnnotations.SGXEPC]; ok {
 		quantity, err := resource.ParseQuantity(epcSize)
 		if err != nil {
@@ -560,6 +836,48 @@ func addHypervisorPathOverrides(ocispec specs.Spec, config *vc.SandboxConfig, ru
 		config.HypervisorConfig.HypervisorCtlPath = value
 	}
 
+	if value, ok := ocispec.Annotations[vcAnnotations.FirmwareVariant]; ok {
+		path, ok := runtime.HypervisorConfig.FirmwareVariants[value]
+		if !ok {
+			return fmt.Errorf("firmware variant %v required from annotation is not valid", value)
+		}
+		if firmware, ok := config.Annotations[vcAnnotations.FirmwarePath]; ok && firmware != path {
+			return fmt.Errorf("firmware variant %v required from annotation conflicts with firmware %v", value, firmware)
+		}
+		// The variant is handled as a custom firmware, so that the
+		// firmware_hash annotation pins it.
+		config.Annotations[vcAnnotations.FirmwarePath] = path
+	}
+
+	if value, ok := ocispec.Annotations[vcAnnotations.KernelVariant]; ok {
+		variant, ok := runtime.HypervisorConfig.KernelVariants[value]
+		if !ok {
+			return fmt.Errorf("kernel variant %v required from annotation is not valid", value)
+		}
+		if kernel, ok := config.Annotations[vcAnnotations.KernelPath]; ok && kernel != variant.Path {
+			return fmt.Errorf("kernel variant %v required from annotation conflicts with kernel %v", value, kernel)
+		}
+		// The variant is handled as a custom kernel, so that the
+		// kernel_hash annotation pins it.
+		config.Annotations[vcAnnotations.KernelPath] = variant.Path
+		for _, param := range variant.Params {
+			if err := config.HypervisorConfig.AddKernelParam(param); err != nil {
+				return fmt.Errorf("Error adding kernel parameters of kernel variant %v: %v", value, err)
+			}
+		}
+	}
+
+	if value, ok := ocispec.Annotations[vcAnnotations.CPUMitigations]; ok {
+		if !vc.ValidCPUMitigations(value) {
+			return fmt.Errorf("Invalid CPU mitigations profile %s specified in annotation %v", value, vcAnnotations.CPUMitigations)
+		}
+		if !contains(runtime.HypervisorConfig.ValidCPUMitigations, value) {
+			return fmt.Errorf("CPU mitigations profile %s specified in annotation %v is not allowed, expecting one of %v",
+				value, vcAnnotations.CPUMitigations, runtime.HypervisorConfig.ValidCPUMitigations)
+		}
+		config.HypervisorConfig.CPUMitigations = value
+	}
+
 	if value, ok := ocispec.Annotations[vcAnnotations.KernelParams]; ok {
 		if value != "" {
 			params := vc.DeserializeParams(strings.Fields(value))
@@ -652,12 +970,48 @@ func addHypervisorMemoryOverrides(ocispec specs.Spec, sbConfig *vc.SandboxConfig
 		sbConfig.HypervisorConfig.FileBackedMemRootDir = value
 	}
 
+	if value, ok := ocispec.Annotations[vcAnnotations.HostSharedMemory]; ok {
+		if !checkPathIsInGlobs(runtime.HypervisorConfig.HostSharedMemoryList, value) {
+			return fmt.Errorf("host_shared_memory value %v required from annotation is not valid", value)
+		}
+		sbConfig.HypervisorConfig.HostSharedMemory = value
+	}
+
 	if err := newAnnotationConfiguration(ocispec, vcAnnotations.HugePages).setBool(func(hugePages bool) {
 		sbConfig.HypervisorConfig.HugePages = hugePages
 	}); err != nil {
 		return err
 	}
 
+	if err := newAnnotationConfiguration(ocispec, vcAnnotations.ReclaimGuestFreedMemory).setBool(func(reclaimGuestFreedMemory bool) {
+		sbConfig.HypervisorConfig.ReclaimGuestFreedMemory = reclaimGuestFreedMemory
+	}); err != nil {
+		return err
+	}
+
+	if value, ok := ocispec.Annotations[vcAnnotations.MemMerge]; ok {
+		if value != vc.MemMergeOn && value != vc.MemMergeOff {
+			return fmt.Errorf("Invalid KSM policy %v specified in annotation (supported policies: %v)", value, []string{vc.MemMergeOn, vc.MemMergeOff})
+		}
+		sbConfig.HypervisorConfig.MemMerge = value
+	}
+
+	if value, ok := ocispec.Annotations[vcAnnotations.MemoryTHP]; ok {
+		supportedTHP := []string{vc.MemoryTHPAlways, vc.MemoryTHPMadvise, vc.MemoryTHPNever}
+
+		valid := false
+		for _, t := range supportedTHP {
+			if t == value {
+				sbConfig.HypervisorConfig.MemoryTHP = value
+				valid = true
+			}
+		}
+
+		if !valid {
+			return fmt.Errorf("Invalid transparent huge page policy %v specified in annotation (supported policies: %v)", value, supportedTHP)
+		}
+	}
+
 	if err := newAnnotationConfiguration(ocispec, vcAnnotations.IOMMU).setBool(func(iommu bool) {
 		sbConfig.HypervisorConfig.IOMMU = iommu
 	}); err != nil {
@@ -670,6 +1024,12 @@ func addHypervisorMemoryOverrides(ocispec specs.Spec, sbConfig *vc.SandboxConfig
 		return err
 	}
 
+	if err := newAnnotationConfiguration(ocispec, vcAnnotations.EnableVTPM).setBool(func(enableVTPM bool) {
+		sbConfig.HypervisorConfig.EnableVTPM = enableVTPM
+	}); err != nil {
+		return err
+	}
+
 	if err := newAnnotationConfiguration(ocispec, vcAnnotations.EnableGuestSwap).setBool(func(enableGuestSwap bool) {
 		sbConfig.HypervisorConfig.GuestSwap = enableGuestSwap
 	}); err != nil {
@@ -685,11 +1045,11 @@ func addHypervisorMemoryOverrides(ocispec specs.Spec, sbConfig *vc.SandboxConfig
 	return nil
 }
 
-func addHypervisorCPUOverrides(ocispec specs.Spec, sbConfig *vc.SandboxConfig) error {
+func addHypervisorCPUOverrides(ocispec specs.Spec, sbConfig *vc.SandboxConfig, runtime RuntimeConfig) error {
 	numCPUs := goruntime.NumCPU()
 
 	if err := newAnnotationConfiguration(ocispec, vcAnnotations.DefaultVCPUs).setUintWithCheck(func(vcpus uint64) error {
-		if uint32(vcpus) > uint32(numCPUs) {
+		if !runtime.anyHost && uint32(vcpus) > uint32(numCPUs) {
 			return fmt.Errorf("Number of cpus %d specified in annotation default_vcpus is greater than the number of CPUs %d on the system", vcpus, numCPUs)
 		}
 		sbConfig.HypervisorConfig.NumVCPUs = uint32(vcpus)
@@ -701,7 +1061,7 @@ func addHypervisorCPUOverrides(ocispec specs.Spec, sbConfig *vc.SandboxConfig) e
 	return newAnnotationConfiguration(ocispec, vcAnnotations.DefaultMaxVCPUs).setUintWithCheck(func(maxVCPUs uint64) error {
 		max := uint32(maxVCPUs)
 
-		if max > uint32(numCPUs) {
+		if !runtime.anyHost && max > uint32(numCPUs) {
 			return fmt.Errorf("Number of cpus %d in annotation default_maxvcpus is greater than the number of CPUs %d on the system", max, numCPUs)
 		}
 
@@ -807,6 +1167,12 @@ func addHypervisorVirtioFsOverrides(ocispec specs.Spec, sbConfig *vc.SandboxConf
 		sbConfig.HypervisorConfig.VirtioFSExtraArgs = append(sbConfig.HypervisorConfig.VirtioFSExtraArgs, parsedValue...)
 	}
 
+	if err := newAnnotationConfiguration(ocispec, vcAnnotations.VirtioFSSubmounts).setBool(func(submounts bool) {
+		sbConfig.HypervisorConfig.VirtioFSSubmounts = submounts
+	}); err != nil {
+		return err
+	}
+
 	if sbConfig.HypervisorConfig.SharedFS == config.VirtioFS && sbConfig.HypervisorConfig.VirtioFSDaemon == "" {
 		return fmt.Errorf("cannot enable virtio-fs without daemon path")
 	}
@@ -849,11 +1215,48 @@ func addHypervisorNetworkOverrides(ocispec specs.Spec, sbConfig *vc.SandboxConfi
 		return err
 	}
 
+	if value, ok := ocispec.Annotations[vcAnnotations.SRIOVVFConfig]; ok {
+		configs, err := vc.ParseSRIOVVFConfigs(value)
+		if err != nil {
+			return fmt.Errorf("Error parsing annotation for %s: %v", vcAnnotations.SRIOVVFConfig, err)
+		}
+		sbConfig.SRIOVVFConfigs = configs
+	}
+
 	return newAnnotationConfiguration(ocispec, vcAnnotations.TxRateLimiterMaxRate).setUint(func(txRateLimiterMaxRate uint64) {
 		sbConfig.HypervisorConfig.TxRateLimiterMaxRate = txRateLimiterMaxRate
 	})
 }
 
+// addHypervisorExtraOverrides handles the escape hatch annotations passing raw
+// options to the hypervisor. Values must be allowed by the configuration and
+// every use is logged.
+func addHypervisorExtraOverrides(ocispec specs.Spec, sbConfig *vc.SandboxConfig, runtime RuntimeConfig) error {
+	if value, ok := ocispec.Annotations[vcAnnotations.ExtraArgs]; ok {
+		if !regexpMatchesFully(runtime.HypervisorConfig.ExtraArgsList, value) {
+			return fmt.Errorf("extra_args value %v required from annotation is not valid", value)
+		}
+		ociLog.WithField("extra_args", value).Warn("appending extra hypervisor arguments from annotation")
+		sbConfig.HypervisorConfig.ExtraArgs = strings.Fields(value)
+	}
+
+	if value, ok := ocispec.Annotations[vcAnnotations.ExtraAPIFields]; ok {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(value), &fields); err != nil {
+			return fmt.Errorf("extra_api_fields value %v required from annotation is not a JSON object: %v", value, err)
+		}
+		for field := range fields {
+			if !regexpMatchesFully(runtime.HypervisorConfig.ExtraAPIFieldsList, field) {
+				return fmt.Errorf("extra_api_fields field %v required from annotation is not valid", field)
+			}
+		}
+		ociLog.WithField("extra_api_fields", value).Warn("applying extra hypervisor API fields from annotation")
+		sbConfig.HypervisorConfig.ExtraAPIFields = value
+	}
+
+	return nil
+}
+
 func addRuntimeConfigOverrides(ocispec specs.Spec, sbConfig *vc.SandboxConfig, runtime RuntimeConfig) error {
 
 	if err := newAnnotationConfiguration(ocispec, vcAnnotations.DisableGuestSeccomp).setBool(func(disableGuestSeccomp bool) {
@@ -862,6 +1265,153 @@ func addRuntimeConfigOverrides(ocispec specs.Spec, sbConfig *vc.SandboxConfig, r
 		return err
 	}
 
+	if value, ok := ocispec.Annotations[vcAnnotations.GuestSeccompMode]; ok {
+		if !vc.ValidGuestSeccompMode(value) {
+			return fmt.Errorf("Invalid guest seccomp mode %s specified in annotation %v", value, vcAnnotations.GuestSeccompMode)
+		}
+		sbConfig.GuestSeccompMode = value
+	}
+
+	if err := newAnnotationConfiguration(ocispec, vcAnnotations.GuestSeccompReport).setBool(func(guestSeccompReport bool) {
+		sbConfig.GuestSeccompReport = guestSeccompReport
+	}); err != nil {
+		return err
+	}
+
+	if err := newAnnotationConfiguration(ocispec, vcAnnotations.GuestPidsLimit).setUint(func(guestPidsLimit uint64) {
+		sbConfig.GuestPidsLimit = guestPidsLimit
+	}); err != nil {
+		return err
+	}
+
+	if err := newAnnotationConfiguration(ocispec, vcAnnotations.MultipathEvents).setBool(func(multipathEvents bool) {
+		sbConfig.MultipathEvents = multipathEvents
+	}); err != nil {
+		return err
+	}
+
+	if err := newAnnotationConfiguration(ocispec, vcAnnotations.StopFlushTimeout).setUint(func(stopFlushTimeout uint64) {
+		sbConfig.StopFlushTimeout = uint32(stopFlushTimeout)
+	}); err != nil {
+		return err
+	}
+
+	if err := newAnnotationConfiguration(ocispec, vcAnnotations.ConfirmExecTimeout).setUint(func(confirmExecTimeout uint64) {
+		sbConfig.ConfirmExecTimeout = uint32(confirmExecTimeout)
+	}); err != nil {
+		return err
+	}
+
+	if err := newAnnotationConfiguration(ocispec, vcAnnotations.ForensicSnapshotThreshold).setUint(func(forensicSnapshotThreshold uint64) {
+		sbConfig.ForensicSnapshotThreshold = uint32(forensicSnapshotThreshold)
+	}); err != nil {
+		return err
+	}
+
+	if err := newAnnotationConfiguration(ocispec, vcAnnotations.GuestNameResolution).setBool(func(guestNameResolution bool) {
+		sbConfig.GuestNameResolution = guestNameResolution
+	}); err != nil {
+		return err
+	}
+
+	if value, ok := ocispec.Annotations[vcAnnotations.Profile]; ok {
+		if value != vc.LowLatencyProfile {
+			return fmt.Errorf("Invalid profile %s specified in annotation %v, only the %s profile can be selected per pod", value, vcAnnotations.Profile, vc.LowLatencyProfile)
+		}
+		if runtime.Profile != "" && runtime.Profile != value {
+			return fmt.Errorf("Profile %s specified in annotation %v conflicts with the %s profile of the runtime", value, vcAnnotations.Profile, runtime.Profile)
+		}
+		sbConfig.Profile = value
+	}
+
+	if err := newAnnotationConfiguration(ocispec, vcAnnotations.SharePidNs).setBool(func(sharePidNs bool) {
+		sbConfig.SharePidNs = sharePidNs
+	}); err != nil {
+		return err
+	}
+
+	if err := newAnnotationConfiguration(ocispec, vcAnnotations.Pauseless).setBool(func(pauseless bool) {
+		sbConfig.Pauseless = pauseless
+	}); err != nil {
+		return err
+	}
+
+	if err := newAnnotationConfiguration(ocispec, vcAnnotations.MetadataService).setBool(func(metadataService bool) {
+		if !metadataService {
+			sbConfig.Metadata = nil
+		} else if sbConfig.Metadata == nil {
+			sbConfig.Metadata = sandboxMetadata(ocispec, runtime.MetadataAnnotations, sbConfig.ID)
+		}
+	}); err != nil {
+		return err
+	}
+
+	if value, ok := ocispec.Annotations[vcAnnotations.GuestServices]; ok {
+		services, err := vc.ParseGuestServices(value)
+		if err != nil {
+			return fmt.Errorf("Error parsing annotation for %s: %v", vcAnnotations.GuestServices, err)
+		}
+		sbConfig.GuestServices = services
+	}
+
+	if value, ok := ocispec.Annotations[vcAnnotations.PlacementNUMANodes]; ok {
+		if _, err := cpuset.Parse(value); err != nil {
+			return fmt.Errorf("Invalid NUMA nodes %s specified in annotation %v: %v", value, vcAnnotations.PlacementNUMANodes, err)
+		}
+		sbConfig.PlacementNUMANodes = value
+	}
+
+	if value, ok := ocispec.Annotations[vcAnnotations.WasmRuntime]; ok {
+		if !vc.ValidWasmRuntime(value) {
+			return fmt.Errorf("Invalid WASM runtime %s specified in annotation %v", value, vcAnnotations.WasmRuntime)
+		}
+		sbConfig.WasmRuntime = value
+	}
+
+	if value, ok := ocispec.Annotations[vcAnnotations.ShmChannel]; ok {
+		if !vc.ValidShmChannelName(value) {
+			return fmt.Errorf("Invalid shared memory channel %s specified in annotation %v", value, vcAnnotations.ShmChannel)
+		}
+		if runtime.HypervisorType != vc.QemuHypervisor || runtime.HypervisorConfig.IvshmemServerPath == "" {
+			return fmt.Errorf("shared memory channels need QEMU and ivshmem_server in the hypervisor configuration")
+		}
+		// The channel is scoped to the namespace of the pod.
+		sbConfig.ShmChannel = ocispec.Annotations[ctrAnnotations.SandboxNamespace] + "/" + value
+	}
+
+	// The nested containers grant /dev/fuse, /dev/net/tun and a writable
+	// cgroupfs to all the containers of the pod, they have to be enabled
+	// by the administrator, like the hypervisor annotations.
+	if _, ok := ocispec.Annotations[vcAnnotations.NestedContainers]; ok &&
+		!regexpContains(runtime.HypervisorConfig.EnableAnnotations, nestedContainersAnnotation) {
+		return fmt.Errorf("annotation %v is not enabled, %q has to be listed in enable_annotations", vcAnnotations.NestedContainers, nestedContainersAnnotation)
+	}
+	if err := newAnnotationConfiguration(ocispec, vcAnnotations.NestedContainers).setBool(func(nestedContainers bool) {
+		sbConfig.NestedContainers = nestedContainers
+	}); err != nil {
+		return err
+	}
+
+	if value, ok := ocispec.Annotations[vcAnnotations.VMRestartPolicy]; ok {
+		if !vc.ValidVMRestartPolicy(value) {
+			return fmt.Errorf("Invalid VM restart policy %s specified in annotation %v", value, vcAnnotations.VMRestartPolicy)
+		}
+		sbConfig.VMRestartPolicy = value
+	}
+
+	if err := newAnnotationConfiguration(ocispec, vcAnnotations.EnableCoreDumps).setBool(func(enableCoreDumps bool) {
+		sbConfig.CoreDump.Enabled = enableCoreDumps
+	}); err != nil {
+		return err
+	}
+
+	// Core dumps can be restricted to some namespaces, whatever the
+	// annotations of the pod say.
+	if sbConfig.CoreDump.Enabled && len(runtime.CoreDumpNamespaces) > 0 &&
+		!contains(runtime.CoreDumpNamespaces, ocispec.Annotations[ctrAnnotations.SandboxNamespace]) {
+		sbConfig.CoreDump.Enabled = false
+	}
+
 	if err := newAnnotationConfiguration(ocispec, vcAnnotations.SandboxCgroupOnly).setBool(func(sandboxCgroupOnly bool) {
 		sbConfig.SandboxCgroupOnly = sandboxCgroupOnly
 	}); err != nil {
@@ -874,6 +1424,30 @@ func addRuntimeConfigOverrides(ocispec specs.Spec, sbConfig *vc.SandboxConfig, r
 		return err
 	}
 
+	if value, ok := ocispec.Annotations[vcAnnotations.VMMSchedClass]; ok {
+		if !vc.ValidVMMSchedClass(value) {
+			return fmt.Errorf("Invalid VMM scheduling class %s specified in annotation %v", value, vcAnnotations.VMMSchedClass)
+		}
+		sbConfig.VMMSchedClass = value
+	}
+
+	if err := newAnnotationConfiguration(ocispec, vcAnnotations.VMMSchedRTPriority).setUint(func(priority uint64) {
+		sbConfig.VMMSchedRTPriority = int(priority)
+	}); err != nil {
+		return err
+	}
+
+	// The realtime priority is bounded by the configuration, whatever
+	// the annotations of the pod say.
+	if sbConfig.VMMSchedClass == vc.VMMSchedLatency {
+		if sbConfig.VMMSchedRTPriority == 0 {
+			sbConfig.VMMSchedRTPriority = 1
+		}
+		if err := vc.ValidVMMSchedRTPriority(sbConfig.VMMSchedRTPriority, runtime.VMMSchedMaxRTPriority); err != nil {
+			return fmt.Errorf("Invalid VMM latency scheduling class: %v", err)
+		}
+	}
+
 	if value, ok := ocispec.Annotations[vcAnnotations.Experimental]; ok {
 		features := strings.Split(value, " ")
 		sbConfig.Experimental = []exp.Feature{}
@@ -933,6 +1507,16 @@ func addAgentConfigOverrides(ocispec specs.Spec, config *vc.SandboxConfig) error
 		return err
 	}
 
+	if err := newAnnotationConfiguration(ocispec, vcAnnotations.AgentStreamConnections).setUintWithCheck(func(streamConnections uint64) error {
+		if err := vc.ValidateAgentStreamConnections(streamConnections); err != nil {
+			return err
+		}
+		c.StreamConnections = uint32(streamConnections)
+		return nil
+	}); err != nil {
+		return err
+	}
+
 	config.AgentConfig = c
 
 	return nil
@@ -956,6 +1540,11 @@ func SandboxConfig(ocispec specs.Spec, runtime RuntimeConfig, bundlePath, cid st
 		return vc.SandboxConfig{}, err
 	}
 
+	var metadata *vc.SandboxMetadata
+	if runtime.MetadataService {
+		metadata = sandboxMetadata(ocispec, runtime.MetadataAnnotations, cid)
+	}
+
 	sandboxConfig := vc.SandboxConfig{
 		ID: cid,
 
@@ -991,18 +1580,76 @@ func SandboxConfig(ocispec specs.Spec, runtime RuntimeConfig, bundlePath, cid st
 		SandboxBindMounts: runtime.SandboxBindMounts,
 
 		DisableGuestSeccomp: runtime.DisableGuestSeccomp,
+		GuestSeccompReport:  runtime.GuestSeccompReport,
+		GuestSeccompMode:    runtime.GuestSeccompMode,
+
+		GuestPidsLimit: runtime.GuestPidsLimit,
+
+		MultipathEvents: runtime.MultipathEvents,
+
+		StopFlushTimeout: runtime.StopFlushTimeout,
+
+		ConfirmExecTimeout: runtime.ConfirmExecTimeout,
+
+		ForensicSnapshotThreshold: runtime.ForensicSnapshotThreshold,
+		ForensicSnapshotCount:     runtime.ForensicSnapshotCount,
+
+		GuestNameResolution: runtime.GuestNameResolution,
+
+		Pauseless: runtime.Pauseless,
+
+		SharePidNs: runtime.SharePidNs,
+
+		Profile: runtime.Profile,
+
+		EntitlementsPath: runtime.EntitlementsPath,
+
+		NetworkPolicyObject: runtime.NetworkPolicyObject,
+
+		SeccompNotify: runtime.SeccompNotifySocket != "",
+
+		Metadata: metadata,
+
+		ResourceHooks: runtime.ResourceHooks,
+
+		ImageVolumePaths: runtime.ImageVolumePaths,
+
+		Placement:       runtime.Placement,
+		PlacementDomain: runtime.PlacementDomain,
+
+		CoreDump: runtime.CoreDump,
+
+		TmpfsSizing: runtime.TmpfsSizing,
+
+		VMMLimits: runtime.VMMLimits,
+
+		BootConcurrency:  runtime.BootConcurrency,
+		LazyDeviceAttach: runtime.LazyDeviceAttach,
 
 		EnableVCPUsPinning: runtime.EnableVCPUsPinning,
 
+		VMMSchedClass:      runtime.VMMSchedClass,
+		VMMSchedRTPriority: runtime.VMMSchedRTPriority,
+
 		GuestSeLinuxLabel: runtime.GuestSeLinuxLabel,
 
 		Experimental: runtime.Experimental,
+
+		HostDevicePolicies: runtime.HostDevicePolicies,
+
+		KernelParamsRules: runtime.KernelParamsRules,
 	}
 
 	if err := addAnnotations(ocispec, &sandboxConfig, runtime); err != nil {
 		return vc.SandboxConfig{}, err
 	}
 
+	if sandboxConfig.Profile == vc.LowLatencyProfile {
+		if err := applyLowLatencyProfile(ocispec, &sandboxConfig, runtime); err != nil {
+			return vc.SandboxConfig{}, err
+		}
+	}
+
 	// If we are utilizing static resource management for the sandbox, ensure that the hypervisor is started
 	// with the base number of CPU/memory (which is equal to the default CPU/memory specified for the runtime
 	// configuration or annotations) as well as any specified workload resources.
@@ -1174,15 +1821,172 @@ func (a *annotationConfiguration) setUintWithCheck(f func(uint64) error) error {
 	return nil
 }
 
+// lowLatencyProfileConflicts are the boolean annotations the low latency
+// profile sets, with the value conflicting with it.
+var lowLatencyProfileConflicts = []struct {
+	annotation string
+	value      bool
+}{
+	{vcAnnotations.EnableVCPUsPinning, false},
+	{vcAnnotations.VirtioMem, true},
+	{vcAnnotations.ReclaimGuestFreedMemory, true},
+}
+
+// applyLowLatencyProfile sets the options of the sandboxes of the low latency
+// profile. The vCPU threads are given the realtime scheduling of the latency
+// class when the runtime allows it. The annotations conflicting with the
+// profile are rejected, and the options of the configuration it overrides are
+// logged.
+func applyLowLatencyProfile(ocispec specs.Spec, sbConfig *vc.SandboxConfig, runtime RuntimeConfig) error {
+	for _, c := range lowLatencyProfileConflicts {
+		value, ok := ocispec.Annotations[c.annotation]
+		if !ok {
+			continue
+		}
+		if b, err := strconv.ParseBool(value); err == nil && b == c.value {
+			return fmt.Errorf("Annotation %s=%s conflicts with the %s profile", c.annotation, value, vc.LowLatencyProfile)
+		}
+	}
+
+	overridden := logrus.Fields{}
+	if !sbConfig.EnableVCPUsPinning {
+		overridden["enable_vcpus_pinning"] = false
+	}
+	if !sbConfig.StaticResourceMgmt {
+		overridden["static_sandbox_resource_mgmt"] = false
+	}
+	if sbConfig.HypervisorConfig.VirtioMem {
+		overridden["enable_virtio_mem"] = true
+	}
+	if sbConfig.HypervisorConfig.ReclaimGuestFreedMemory {
+		overridden["reclaim_guest_freed_memory"] = true
+	}
+	if len(overridden) > 0 {
+		ociLog.WithFields(overridden).Infof("configuration overridden by the %s profile", vc.LowLatencyProfile)
+	}
+
+	sbConfig.EnableVCPUsPinning = true
+	sbConfig.StaticResourceMgmt = true
+	sbConfig.HypervisorConfig.VirtioMem = false
+	sbConfig.HypervisorConfig.ReclaimGuestFreedMemory = false
+
+	switch sbConfig.VMMSchedClass {
+	case vc.VMMSchedBatch:
+		return fmt.Errorf("The %s VMM scheduling class conflicts with the %s profile", sbConfig.VMMSchedClass, vc.LowLatencyProfile)
+	case "", vc.VMMSchedDefault:
+		if runtime.VMMSchedMaxRTPriority > 0 {
+			sbConfig.VMMSchedClass = vc.VMMSchedLatency
+			if sbConfig.VMMSchedRTPriority == 0 {
+				sbConfig.VMMSchedRTPriority = 1
+			}
+		}
+	}
+
+	return nil
+}
+
+// sandboxMetadata returns the metadata of the pod of the sandbox spec, with
+// the pod annotations matching one of the allowed patterns.
+func sandboxMetadata(spec specs.Spec, allowedAnnotations []string, cid string) *vc.SandboxMetadata {
+	metadata := &vc.SandboxMetadata{
+		InstanceID:    cid,
+		LocalHostname: spec.Hostname,
+	}
+
+	for _, key := range []string{ctrAnnotations.SandboxName, podmanAnnotations.KubeName} {
+		if name, ok := spec.Annotations[key]; ok {
+			metadata.Name = name
+			break
+		}
+	}
+	for _, key := range []string{ctrAnnotations.SandboxNamespace, podmanAnnotations.Namespace} {
+		if namespace, ok := spec.Annotations[key]; ok {
+			metadata.Namespace = namespace
+			break
+		}
+	}
+
+	// Only CRI-O passes the pod labels on to the runtime
+	if labels, ok := spec.Annotations[podmanAnnotations.Labels]; ok {
+		if err := json.Unmarshal([]byte(labels), &metadata.Labels); err != nil {
+			ociLog.WithError(err).Warn("failed to parse the pod labels")
+		}
+	}
+
+	for key, value := range spec.Annotations {
+		for _, pattern := range allowedAnnotations {
+			if matched, _ := filepath.Match(pattern, key); matched {
+				if metadata.Annotations == nil {
+					metadata.Annotations = make(map[string]string)
+				}
+				metadata.Annotations[key] = value
+				break
+			}
+		}
+	}
+
+	metadata.VCPUs, metadata.MemoryMB = CalculateSandboxSizing(&spec)
+
+	return metadata
+}
+
+// SandboxSizing details how the resources added to the VM of a sandbox are
+// calculated: the workload needs the largest of the resources of the
+// containers of the pod and of its init containers, which run before them,
+// plus the memory holding the ephemeral storage of the pod in the guest.
+type SandboxSizing struct {
+	// WorkloadMilliCPUs and WorkloadMemMB are the resources of the
+	// containers of the pod.
+	WorkloadMilliCPUs uint32 `json:"workload_milli_cpus"`
+	WorkloadMemMB     uint32 `json:"workload_memory_mb"`
+
+	// InitMilliCPUs and InitMemMB are the peak resources of the init
+	// containers of the pod.
+	InitMilliCPUs uint32 `json:"init_milli_cpus"`
+	InitMemMB     uint32 `json:"init_memory_mb"`
+
+	// EphemeralStorageMB is the ephemeral storage of the pod held in the
+	// guest memory.
+	EphemeralStorageMB uint32 `json:"ephemeral_storage_mb"`
+
+	// VCPUs and MemoryMB are the resources added to the VM.
+	VCPUs    uint32 `json:"vcpus"`
+	MemoryMB uint32 `json:"memory_mb"`
+}
+
 // CalculateSandboxSizing will calculate the number of CPUs and amount of Memory that should
 // be added to the VM if sandbox annotations are provided with this sizing details
 func CalculateSandboxSizing(spec *specs.Spec) (numCPU, memSizeMB uint32) {
+	sizing := CalculateSandboxSizingDetails(spec)
+	return sizing.VCPUs, sizing.MemoryMB
+}
+
+// parseSizingAnnotation parses a sizing annotation, 0 if it isn't defined or
+// if there's an error in parsing.
+func parseSizingAnnotation(spec *specs.Spec, key string) int64 {
+	annotation, ok := spec.Annotations[key]
+	if !ok {
+		return 0
+	}
+	value, err := strconv.ParseInt(annotation, 10, 64)
+	if err != nil {
+		ociLog.Warningf("sandbox-sizing: failure to parse %s: %s", key, annotation)
+		return 0
+	}
+	return value
+}
+
+// CalculateSandboxSizingDetails calculates the resources that should be added
+// to the VM from the sizing annotations of the sandbox, with the details of
+// the calculation.
+func CalculateSandboxSizingDetails(spec *specs.Spec) SandboxSizing {
+	var sizing SandboxSizing
 	var memory, quota int64
 	var period uint64
 	var err error
 
 	if spec == nil || spec.Annotations == nil {
-		return 0, 0
+		return sizing
 	}
 
 	// For each annotation, if it isn't defined, or if there's an error in parsing, we'll log
@@ -1218,7 +2022,31 @@ func CalculateSandboxSizing(spec *specs.Spec) (numCPU, memSizeMB uint32) {
 		}
 	}
 
-	return calculateVMResources(period, quota, memory)
+	sizing.WorkloadMilliCPUs = vcutils.CalculateMilliCPUs(quota, period)
+	sizing.WorkloadMemMB = memoryMB(memory)
+
+	// The upper layer runtimes size the sandbox from the containers of the
+	// pod only, the init containers and the ephemeral storage are declared
+	// with annotations of their own.
+	if initMilliCPUs := parseSizingAnnotation(spec, vcAnnotations.SizingInitMilliCPUs); initMilliCPUs > 0 {
+		sizing.InitMilliCPUs = uint32(initMilliCPUs)
+	}
+	sizing.InitMemMB = memoryMB(parseSizingAnnotation(spec, vcAnnotations.SizingInitMemory))
+	sizing.EphemeralStorageMB = memoryMB(parseSizingAnnotation(spec, vcAnnotations.SizingEphemeralStorage))
+
+	milliCPUs := sizing.WorkloadMilliCPUs
+	if sizing.InitMilliCPUs > milliCPUs {
+		milliCPUs = sizing.InitMilliCPUs
+	}
+	sizing.VCPUs = vcutils.CalculateVCpusFromMilliCpus(milliCPUs)
+
+	sizing.MemoryMB = sizing.WorkloadMemMB
+	if sizing.InitMemMB > sizing.MemoryMB {
+		sizing.MemoryMB = sizing.InitMemMB
+	}
+	sizing.MemoryMB += sizing.EphemeralStorageMB
+
+	return sizing
 }
 
 // CalculateContainerSizing will calculate the number of CPUs and amount of memory that is needed
@@ -1247,15 +2075,16 @@ func CalculateContainerSizing(spec *specs.Spec) (numCPU, memSizeMB uint32) {
 
 func calculateVMResources(period uint64, quota int64, memory int64) (numCPU, memSizeMB uint32) {
 	numCPU = vcutils.CalculateVCpusFromMilliCpus(vcutils.CalculateMilliCPUs(quota, period))
+	return numCPU, memoryMB(memory)
+}
 
+func memoryMB(memory int64) uint32 {
 	if memory < 0 {
 		// While spec allows for a negative value to indicate unconstrained, we don't
 		// see this in practice. Since we rely only on default memory if the workload
 		// is unconstrained, we will treat as 0 for VM resource accounting.
 		ociLog.Infof("memory limit provided < 0, treating as 0 MB for VM sizing: %d", memory)
-		memSizeMB = 0
-	} else {
-		memSizeMB = uint32(memory / 1024 / 1024)
+		return 0
 	}
-	return numCPU, memSizeMB
+	return uint32(memory / 1024 / 1024)
 }
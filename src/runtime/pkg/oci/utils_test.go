@@ -497,6 +497,8 @@ func TestAddAssetAnnotations(t *testing.T) {
 
 		vcAnnotations.KernelPath: fakeAssetFile,
 		vcAnnotations.KernelHash: "3l2353we871g",
+
+		vcAnnotations.AssetHashType: "sha256",
 	}
 
 	config := vc.SandboxConfig{
@@ -540,6 +542,118 @@ func TestAddAssetAnnotations(t *testing.T) {
 	assert.Exactly(expectedAnnotations, config.Annotations)
 }
 
+func TestAddFirmwareVariantAnnotation(t *testing.T) {
+	assert := assert.New(t)
+
+	config := vc.SandboxConfig{
+		Annotations: make(map[string]string),
+	}
+
+	ocispec := specs.Spec{
+		Annotations: map[string]string{
+			vcAnnotations.FirmwareVariant: "ovmf-tdx",
+		},
+	}
+
+	runtimeConfig := RuntimeConfig{
+		HypervisorType: vc.QemuHypervisor,
+	}
+	runtimeConfig.HypervisorConfig.EnableAnnotations = []string{"firmware_variant"}
+
+	// the variant is not configured
+	err := addAnnotations(ocispec, &config, runtimeConfig)
+	assert.Error(err)
+
+	runtimeConfig.HypervisorConfig.FirmwareVariants = map[string]string{
+		"ovmf":     "/usr/share/ovmf/OVMF.fd",
+		"ovmf-tdx": "/usr/share/ovmf/OVMF.inteltdx.fd",
+	}
+	err = addAnnotations(ocispec, &config, runtimeConfig)
+	assert.NoError(err)
+	assert.Equal("/usr/share/ovmf/OVMF.inteltdx.fd", config.Annotations[vcAnnotations.FirmwarePath])
+
+	// the variant cannot be combined with another firmware
+	config.Annotations = map[string]string{
+		vcAnnotations.FirmwarePath: "/usr/share/ovmf/OVMF.fd",
+	}
+	err = addHypervisorPathOverrides(ocispec, &config, runtimeConfig)
+	assert.Error(err)
+}
+
+func TestAddKernelVariantAnnotation(t *testing.T) {
+	assert := assert.New(t)
+
+	config := vc.SandboxConfig{
+		Annotations: make(map[string]string),
+	}
+
+	ocispec := specs.Spec{
+		Annotations: map[string]string{
+			vcAnnotations.KernelVariant: "rt",
+		},
+	}
+
+	runtimeConfig := RuntimeConfig{
+		HypervisorType: vc.QemuHypervisor,
+	}
+	runtimeConfig.HypervisorConfig.EnableAnnotations = []string{"kernel_variant"}
+
+	// the variant is not configured
+	err := addAnnotations(ocispec, &config, runtimeConfig)
+	assert.Error(err)
+
+	runtimeConfig.HypervisorConfig.KernelVariants = map[string]vc.KernelVariant{
+		"rt": {
+			Path:   "/usr/share/kata-containers/vmlinux-rt.container",
+			Params: []vc.Param{{Key: "isolcpus", Value: "1"}, {Key: "nohz_full", Value: "1"}},
+		},
+	}
+	err = addAnnotations(ocispec, &config, runtimeConfig)
+	assert.NoError(err)
+	assert.Equal("/usr/share/kata-containers/vmlinux-rt.container", config.Annotations[vcAnnotations.KernelPath])
+	assert.Equal(runtimeConfig.HypervisorConfig.KernelVariants["rt"].Params, config.HypervisorConfig.KernelParams)
+
+	// the variant cannot be combined with another kernel
+	config.Annotations = map[string]string{
+		vcAnnotations.KernelPath: "/usr/share/kata-containers/vmlinux.container",
+	}
+	err = addHypervisorPathOverrides(ocispec, &config, runtimeConfig)
+	assert.Error(err)
+}
+
+func TestAddCPUMitigationsAnnotation(t *testing.T) {
+	assert := assert.New(t)
+
+	config := vc.SandboxConfig{
+		Annotations: make(map[string]string),
+	}
+
+	ocispec := specs.Spec{
+		Annotations: map[string]string{
+			vcAnnotations.CPUMitigations: vc.CPUMitigationsOff,
+		},
+	}
+
+	runtimeConfig := RuntimeConfig{
+		HypervisorType: vc.QemuHypervisor,
+	}
+	runtimeConfig.HypervisorConfig.CPUMitigations = vc.CPUMitigationsAuto
+	runtimeConfig.HypervisorConfig.EnableAnnotations = []string{"cpu_mitigations"}
+
+	// the profile is not allowed
+	err := addAnnotations(ocispec, &config, runtimeConfig)
+	assert.Error(err)
+
+	runtimeConfig.HypervisorConfig.ValidCPUMitigations = []string{vc.CPUMitigationsOff, vc.CPUMitigationsFull}
+	err = addAnnotations(ocispec, &config, runtimeConfig)
+	assert.NoError(err)
+	assert.Equal(vc.CPUMitigationsOff, config.HypervisorConfig.CPUMitigations)
+
+	ocispec.Annotations[vcAnnotations.CPUMitigations] = "none"
+	err = addAnnotations(ocispec, &config, runtimeConfig)
+	assert.Error(err)
+}
+
 func TestAddAgentAnnotations(t *testing.T) {
 	assert := assert.New(t)
 
@@ -558,6 +672,7 @@ func TestAddAgentAnnotations(t *testing.T) {
 			"i915 enable_ppgtt=0",
 		},
 		ContainerPipeSize: 1024,
+		StreamConnections: 2,
 	}
 
 	runtimeConfig := RuntimeConfig{
@@ -566,6 +681,7 @@ func TestAddAgentAnnotations(t *testing.T) {
 
 	ocispec.Annotations[vcAnnotations.KernelModules] = strings.Join(expectedAgentConfig.KernelModules, KernelModulesSeparator)
 	ocispec.Annotations[vcAnnotations.AgentContainerPipeSize] = "1024"
+	ocispec.Annotations[vcAnnotations.AgentStreamConnections] = "2"
 	addAnnotations(ocispec, &config, runtimeConfig)
 	assert.Exactly(expectedAgentConfig, config.AgentConfig)
 }
@@ -596,6 +712,35 @@ func TestContainerPipeSizeAnnotation(t *testing.T) {
 	assert.Exactly(expectedAgentConfig, config.AgentConfig)
 }
 
+func TestAgentStreamConnectionsAnnotation(t *testing.T) {
+	assert := assert.New(t)
+
+	config := vc.SandboxConfig{
+		Annotations: make(map[string]string),
+		AgentConfig: vc.KataAgentConfig{},
+	}
+
+	ocispec := specs.Spec{
+		Annotations: make(map[string]string),
+	}
+
+	runtimeConfig := RuntimeConfig{
+		HypervisorType: vc.QemuHypervisor,
+	}
+
+	for _, value := range []string{"9", "4294967296", "4294967297"} {
+		ocispec.Annotations[vcAnnotations.AgentStreamConnections] = value
+		err := addAnnotations(ocispec, &config, runtimeConfig)
+		assert.Error(err, value)
+		assert.Zero(config.AgentConfig.StreamConnections, value)
+	}
+
+	ocispec.Annotations[vcAnnotations.AgentStreamConnections] = "8"
+	err := addAnnotations(ocispec, &config, runtimeConfig)
+	assert.NoError(err)
+	assert.Equal(vc.MaxAgentStreamConnections, config.AgentConfig.StreamConnections)
+}
+
 func TestAddHypervisorAnnotations(t *testing.T) {
 	assert := assert.New(t)
 
@@ -640,6 +785,9 @@ func TestAddHypervisorAnnotations(t *testing.T) {
 	ocispec.Annotations[vcAnnotations.MemPrealloc] = "true"
 	ocispec.Annotations[vcAnnotations.FileBackedMemRootDir] = "/dev/shm"
 	ocispec.Annotations[vcAnnotations.HugePages] = "true"
+	ocispec.Annotations[vcAnnotations.MemoryTHP] = "never"
+	ocispec.Annotations[vcAnnotations.MemMerge] = "off"
+	ocispec.Annotations[vcAnnotations.ReclaimGuestFreedMemory] = "true"
 	ocispec.Annotations[vcAnnotations.IOMMU] = "true"
 	ocispec.Annotations[vcAnnotations.BlockDeviceDriver] = "virtio-scsi"
 	ocispec.Annotations[vcAnnotations.BlockDeviceAIO] = "io_uring"
@@ -652,6 +800,7 @@ func TestAddHypervisorAnnotations(t *testing.T) {
 	ocispec.Annotations[vcAnnotations.VirtioFSDaemon] = "/bin/false"
 	ocispec.Annotations[vcAnnotations.VirtioFSCache] = "auto"
 	ocispec.Annotations[vcAnnotations.VirtioFSExtraArgs] = "[ \"arg0\", \"arg1\" ]"
+	ocispec.Annotations[vcAnnotations.VirtioFSSubmounts] = "true"
 	ocispec.Annotations[vcAnnotations.Msize9p] = "512"
 	ocispec.Annotations[vcAnnotations.MachineType] = "q35"
 	ocispec.Annotations[vcAnnotations.MachineAccelerators] = "nofw"
@@ -659,11 +808,17 @@ func TestAddHypervisorAnnotations(t *testing.T) {
 	ocispec.Annotations[vcAnnotations.DisableVhostNet] = "true"
 	ocispec.Annotations[vcAnnotations.GuestHookPath] = "/usr/bin/"
 	ocispec.Annotations[vcAnnotations.DisableImageNvdimm] = "true"
+	ocispec.Annotations[vcAnnotations.PmemVolumesSize] = "4096"
 	ocispec.Annotations[vcAnnotations.HotplugVFIOOnRootBus] = "true"
+	ocispec.Annotations[vcAnnotations.PCIeP2P] = "true"
+	ocispec.Annotations[vcAnnotations.PCIHotplugMode] = "native"
 	ocispec.Annotations[vcAnnotations.ColdPlugVFIO] = config.BridgePort
 	ocispec.Annotations[vcAnnotations.HotPlugVFIO] = config.NoPort
 	ocispec.Annotations[vcAnnotations.IOMMUPlatform] = "true"
+	ocispec.Annotations[vcAnnotations.EnableVTPM] = "true"
 	ocispec.Annotations[vcAnnotations.SGXEPC] = "64Mi"
+	ocispec.Annotations[vcAnnotations.VirtioGPU] = "venus"
+	ocispec.Annotations[vcAnnotations.VirtioGPUHostMem] = "2048"
 	ocispec.Annotations[vcAnnotations.UseLegacySerial] = "true"
 	// 10Mbit
 	ocispec.Annotations[vcAnnotations.RxRateLimiterMaxRate] = "10000000"
@@ -681,6 +836,9 @@ func TestAddHypervisorAnnotations(t *testing.T) {
 	assert.Equal(sbConfig.HypervisorConfig.MemPrealloc, true)
 	assert.Equal(sbConfig.HypervisorConfig.FileBackedMemRootDir, "/dev/shm")
 	assert.Equal(sbConfig.HypervisorConfig.HugePages, true)
+	assert.Equal(sbConfig.HypervisorConfig.MemoryTHP, "never")
+	assert.Equal(sbConfig.HypervisorConfig.MemMerge, "off")
+	assert.Equal(sbConfig.HypervisorConfig.ReclaimGuestFreedMemory, true)
 	assert.Equal(sbConfig.HypervisorConfig.IOMMU, true)
 	assert.Equal(sbConfig.HypervisorConfig.BlockDeviceDriver, "virtio-scsi")
 	assert.Equal(sbConfig.HypervisorConfig.BlockDeviceAIO, "io_uring")
@@ -693,6 +851,7 @@ func TestAddHypervisorAnnotations(t *testing.T) {
 	assert.Equal(sbConfig.HypervisorConfig.VirtioFSDaemon, "/bin/false")
 	assert.Equal(sbConfig.HypervisorConfig.VirtioFSCache, "auto")
 	assert.ElementsMatch(sbConfig.HypervisorConfig.VirtioFSExtraArgs, [2]string{"arg0", "arg1"})
+	assert.True(sbConfig.HypervisorConfig.VirtioFSSubmounts)
 	assert.Equal(sbConfig.HypervisorConfig.Msize9p, uint32(512))
 	assert.Equal(sbConfig.HypervisorConfig.HypervisorMachineType, "q35")
 	assert.Equal(sbConfig.HypervisorConfig.MachineAccelerators, "nofw")
@@ -700,11 +859,17 @@ func TestAddHypervisorAnnotations(t *testing.T) {
 	assert.Equal(sbConfig.HypervisorConfig.DisableVhostNet, true)
 	assert.Equal(sbConfig.HypervisorConfig.GuestHookPath, "/usr/bin/")
 	assert.Equal(sbConfig.HypervisorConfig.DisableImageNvdimm, true)
+	assert.Equal(sbConfig.HypervisorConfig.PmemVolumesSize, uint32(4096))
 	assert.Equal(sbConfig.HypervisorConfig.HotplugVFIOOnRootBus, true)
+	assert.Equal(sbConfig.HypervisorConfig.PCIeP2P, true)
+	assert.Equal(sbConfig.HypervisorConfig.PCIHotplugMode, "native")
 	assert.Equal(string(sbConfig.HypervisorConfig.ColdPlugVFIO), string(config.BridgePort))
 	assert.Equal(string(sbConfig.HypervisorConfig.HotPlugVFIO), string(config.NoPort))
 	assert.Equal(sbConfig.HypervisorConfig.IOMMUPlatform, true)
+	assert.Equal(sbConfig.HypervisorConfig.EnableVTPM, true)
 	assert.Equal(sbConfig.HypervisorConfig.SGXEPCSize, int64(67108864))
+	assert.Equal(sbConfig.HypervisorConfig.VirtioGPU, "venus")
+	assert.Equal(sbConfig.HypervisorConfig.VirtioGPUHostMemMB, uint32(2048))
 	assert.Equal(sbConfig.HypervisorConfig.LegacySerial, true)
 	assert.Equal(sbConfig.HypervisorConfig.RxRateLimiterMaxRate, uint64(10000000))
 	assert.Equal(sbConfig.HypervisorConfig.TxRateLimiterMaxRate, uint64(10000000))
@@ -728,6 +893,37 @@ func TestAddHypervisorAnnotations(t *testing.T) {
 	assert.Error(err)
 }
 
+func TestAddSRIOVVFConfigAnnotation(t *testing.T) {
+	assert := assert.New(t)
+
+	config := vc.SandboxConfig{
+		Annotations: make(map[string]string),
+	}
+	ocispec := specs.Spec{
+		Annotations: map[string]string{
+			vcAnnotations.SRIOVVFConfig: "net1 vlan=100; net2 trust=on",
+		},
+	}
+	runtimeConfig := RuntimeConfig{
+		HypervisorType: vc.QemuHypervisor,
+	}
+
+	// The VFs are only programmed when the configuration allows it
+	err := addAnnotations(ocispec, &config, runtimeConfig)
+	assert.Error(err)
+	assert.Empty(config.SRIOVVFConfigs)
+
+	runtimeConfig.HypervisorConfig.EnableAnnotations = []string{"sriov_vf_config"}
+	err = addAnnotations(ocispec, &config, runtimeConfig)
+	assert.NoError(err)
+	assert.Len(config.SRIOVVFConfigs, 2)
+	assert.Equal(100, *config.SRIOVVFConfigs["net1"].Vlan)
+
+	ocispec.Annotations[vcAnnotations.SRIOVVFConfig] = "net1 vlan=4096"
+	err = addAnnotations(ocispec, &config, runtimeConfig)
+	assert.Error(err)
+}
+
 func TestAddProtectedHypervisorAnnotations(t *testing.T) {
 	assert := assert.New(t)
 
@@ -753,6 +949,10 @@ func TestAddProtectedHypervisorAnnotations(t *testing.T) {
 	ocispec.Annotations[vcAnnotations.FileBackedMemRootDir] = "/dev/shm"
 	ocispec.Annotations[vcAnnotations.VirtioFSDaemon] = "/bin/false"
 	ocispec.Annotations[vcAnnotations.EntropySource] = "/dev/urandom"
+	ocispec.Annotations[vcAnnotations.USBDevices] = "18d1:4ee7, 1-1.2"
+	segment := filepath.Join(t.TempDir(), "inference")
+	assert.NoError(os.WriteFile(segment, nil, 0600))
+	ocispec.Annotations[vcAnnotations.HostSharedMemory] = segment
 
 	config.HypervisorConfig.FileBackedMemRootDir = "do-not-touch"
 	config.HypervisorConfig.VirtioFSDaemon = "dangerous-daemon"
@@ -763,13 +963,19 @@ func TestAddProtectedHypervisorAnnotations(t *testing.T) {
 	assert.Equal(config.HypervisorConfig.FileBackedMemRootDir, "do-not-touch")
 	assert.Equal(config.HypervisorConfig.VirtioFSDaemon, "dangerous-daemon")
 	assert.Equal(config.HypervisorConfig.EntropySource, "truly-random")
+	assert.Empty(config.HypervisorConfig.USBDevices)
+	assert.Empty(config.HypervisorConfig.HostSharedMemory)
 
 	// Now enable them and check again
 	runtimeConfig.HypervisorConfig.FileBackedMemRootList = []string{"/dev/*m"}
 	runtimeConfig.HypervisorConfig.VirtioFSDaemonList = []string{"/bin/*ls*"}
 	runtimeConfig.HypervisorConfig.EntropySourceList = []string{"/dev/*random*"}
+	runtimeConfig.HypervisorConfig.USBDevicesList = []string{"18d1:*", "1-1.*"}
+	runtimeConfig.HypervisorConfig.HostSharedMemoryList = []string{filepath.Join(filepath.Dir(segment), "*")}
 	err = addAnnotations(ocispec, &config, runtimeConfig)
 	assert.NoError(err)
+	assert.Equal(segment, config.HypervisorConfig.HostSharedMemory)
+	assert.Equal([]string{"18d1:4ee7", "1-1.2"}, config.HypervisorConfig.USBDevices)
 	assert.Equal(config.HypervisorConfig.FileBackedMemRootDir, "/dev/shm")
 	assert.Equal(config.HypervisorConfig.VirtioFSDaemon, "/bin/false")
 	assert.Equal(config.HypervisorConfig.EntropySource, "/dev/urandom")
@@ -812,12 +1018,145 @@ func TestAddRuntimeAnnotations(t *testing.T) {
 	ocispec.Annotations[vcAnnotations.SandboxCgroupOnly] = "true"
 	ocispec.Annotations[vcAnnotations.DisableNewNetNs] = "true"
 	ocispec.Annotations[vcAnnotations.InterNetworkModel] = "macvtap"
+	ocispec.Annotations[vcAnnotations.GuestSeccompMode] = "audit"
+	ocispec.Annotations[vcAnnotations.GuestSeccompReport] = "true"
+	ocispec.Annotations[vcAnnotations.GuestPidsLimit] = "1024"
+	ocispec.Annotations[vcAnnotations.MultipathEvents] = "true"
+	ocispec.Annotations[vcAnnotations.StopFlushTimeout] = "10"
+	ocispec.Annotations[vcAnnotations.ConfirmExecTimeout] = "5"
+	ocispec.Annotations[vcAnnotations.ForensicSnapshotThreshold] = "3"
+	ocispec.Annotations[vcAnnotations.GuestNameResolution] = "true"
+	ocispec.Annotations[vcAnnotations.MetadataService] = "true"
+	ocispec.Annotations[vcAnnotations.Pauseless] = "true"
+	ocispec.Annotations[vcAnnotations.SharePidNs] = "true"
 
 	addAnnotations(ocispec, &config, runtimeConfig)
 	assert.Equal(config.DisableGuestSeccomp, true)
 	assert.Equal(config.SandboxCgroupOnly, true)
 	assert.Equal(config.NetworkConfig.DisableNewNetwork, true)
 	assert.Equal(config.NetworkConfig.InterworkingModel, vc.NetXConnectMacVtapModel)
+	assert.Equal(config.GuestSeccompMode, vc.GuestSeccompAudit)
+	assert.Equal(config.GuestSeccompReport, true)
+	assert.Equal(config.GuestPidsLimit, uint64(1024))
+	assert.Equal(config.MultipathEvents, true)
+	assert.Equal(config.StopFlushTimeout, uint32(10))
+	assert.Equal(config.ConfirmExecTimeout, uint32(5))
+	assert.Equal(config.ForensicSnapshotThreshold, uint32(3))
+	assert.Equal(config.GuestNameResolution, true)
+	assert.NotNil(config.Metadata)
+	assert.Equal(config.Pauseless, true)
+	assert.Equal(config.SharePidNs, true)
+
+	ocispec.Annotations[vcAnnotations.GuestSeccompMode] = "permissive"
+	err := addAnnotations(ocispec, &config, runtimeConfig)
+	assert.Error(err)
+	ocispec.Annotations[vcAnnotations.GuestSeccompMode] = "audit"
+
+	ocispec.Annotations[vcAnnotations.VMRestartPolicy] = "on-crash"
+	err = addAnnotations(ocispec, &config, runtimeConfig)
+	assert.NoError(err)
+	assert.Equal(vc.VMRestartOnCrash, config.VMRestartPolicy)
+
+	ocispec.Annotations[vcAnnotations.VMRestartPolicy] = "always"
+	err = addAnnotations(ocispec, &config, runtimeConfig)
+	assert.Error(err)
+	delete(ocispec.Annotations, vcAnnotations.VMRestartPolicy)
+
+	ocispec.Annotations[vcAnnotations.PlacementNUMANodes] = "0-1"
+	err = addAnnotations(ocispec, &config, runtimeConfig)
+	assert.NoError(err)
+	assert.Equal("0-1", config.PlacementNUMANodes)
+
+	ocispec.Annotations[vcAnnotations.PlacementNUMANodes] = "node0"
+	err = addAnnotations(ocispec, &config, runtimeConfig)
+	assert.Error(err)
+	delete(ocispec.Annotations, vcAnnotations.PlacementNUMANodes)
+
+	ocispec.Annotations[vcAnnotations.WasmRuntime] = "wasmtime"
+	err = addAnnotations(ocispec, &config, runtimeConfig)
+	assert.NoError(err)
+	assert.Equal(vc.WasmRuntimeWasmtime, config.WasmRuntime)
+
+	ocispec.Annotations[vcAnnotations.WasmRuntime] = "wasmedge"
+	err = addAnnotations(ocispec, &config, runtimeConfig)
+	assert.Error(err)
+	delete(ocispec.Annotations, vcAnnotations.WasmRuntime)
+
+	ocispec.Annotations[vcAnnotations.NestedContainers] = "true"
+	err = addAnnotations(ocispec, &config, runtimeConfig)
+	assert.Error(err)
+	assert.False(config.NestedContainers)
+
+	runtimeConfig.HypervisorConfig.EnableAnnotations = []string{"nested_containers"}
+	err = addAnnotations(ocispec, &config, runtimeConfig)
+	assert.NoError(err)
+	assert.True(config.NestedContainers)
+	runtimeConfig.HypervisorConfig.EnableAnnotations = nil
+	delete(ocispec.Annotations, vcAnnotations.NestedContainers)
+
+	ocispec.Annotations[vcAnnotations.ShmChannel] = "frontend-cache"
+	err = addAnnotations(ocispec, &config, runtimeConfig)
+	assert.Error(err)
+
+	runtimeConfig.HypervisorConfig.IvshmemServerPath = "/usr/bin/ivshmem-server"
+	ocispec.Annotations[ctrAnnotations.SandboxNamespace] = "shop"
+	err = addAnnotations(ocispec, &config, runtimeConfig)
+	assert.NoError(err)
+	assert.Equal("shop/frontend-cache", config.ShmChannel)
+
+	ocispec.Annotations[vcAnnotations.ShmChannel] = "../frontend"
+	err = addAnnotations(ocispec, &config, runtimeConfig)
+	assert.Error(err)
+	delete(ocispec.Annotations, vcAnnotations.ShmChannel)
+	delete(ocispec.Annotations, ctrAnnotations.SandboxNamespace)
+
+	// core dumps are only enabled for the allowed namespaces
+	ocispec.Annotations[vcAnnotations.EnableCoreDumps] = "true"
+	ocispec.Annotations[ctrAnnotations.SandboxNamespace] = "default"
+	runtimeConfig.CoreDumpNamespaces = []string{"debug"}
+	err = addAnnotations(ocispec, &config, runtimeConfig)
+	assert.NoError(err)
+	assert.False(config.CoreDump.Enabled)
+
+	ocispec.Annotations[ctrAnnotations.SandboxNamespace] = "debug"
+	err = addAnnotations(ocispec, &config, runtimeConfig)
+	assert.NoError(err)
+	assert.True(config.CoreDump.Enabled)
+
+	ocispec.Annotations[vcAnnotations.GuestServices] = "chronyd, nvidia-persistenced.service"
+	err = addAnnotations(ocispec, &config, runtimeConfig)
+	assert.NoError(err)
+	assert.Equal([]string{"chronyd", "nvidia-persistenced.service"}, config.GuestServices)
+
+	ocispec.Annotations[vcAnnotations.GuestServices] = "../chronyd"
+	err = addAnnotations(ocispec, &config, runtimeConfig)
+	assert.Error(err)
+	delete(ocispec.Annotations, vcAnnotations.GuestServices)
+
+	ocispec.Annotations[vcAnnotations.VMMSchedClass] = "batch"
+	err = addAnnotations(ocispec, &config, runtimeConfig)
+	assert.NoError(err)
+	assert.Equal(vc.VMMSchedBatch, config.VMMSchedClass)
+
+	ocispec.Annotations[vcAnnotations.VMMSchedClass] = "realtime"
+	err = addAnnotations(ocispec, &config, runtimeConfig)
+	assert.Error(err)
+
+	// the realtime priority is bounded by the configuration
+	ocispec.Annotations[vcAnnotations.VMMSchedClass] = "latency"
+	ocispec.Annotations[vcAnnotations.VMMSchedRTPriority] = "10"
+	err = addAnnotations(ocispec, &config, runtimeConfig)
+	assert.Error(err)
+
+	runtimeConfig.VMMSchedMaxRTPriority = 5
+	err = addAnnotations(ocispec, &config, runtimeConfig)
+	assert.Error(err)
+
+	ocispec.Annotations[vcAnnotations.VMMSchedRTPriority] = "5"
+	err = addAnnotations(ocispec, &config, runtimeConfig)
+	assert.NoError(err)
+	assert.Equal(vc.VMMSchedLatency, config.VMMSchedClass)
+	assert.Equal(5, config.VMMSchedRTPriority)
 }
 
 func TestRegexpContains(t *testing.T) {
@@ -881,6 +1220,65 @@ func TestCheckPathIsInGlobs(t *testing.T) {
 	}
 }
 
+func TestCheckValueIsInGlobs(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.False(checkValueIsInGlobs([]string{}, "046d:0a38"))
+	assert.True(checkValueIsInGlobs([]string{"046d:0a38"}, "046d:0a38"))
+	assert.True(checkValueIsInGlobs([]string{"1-2.1", "046d:*"}, "046d:0a38"))
+	assert.False(checkValueIsInGlobs([]string{"046d:*"}, "1050:0407"))
+}
+
+func TestAddHypervisorExtraOverrides(t *testing.T) {
+	assert := assert.New(t)
+
+	config := vc.SandboxConfig{
+		Annotations: make(map[string]string),
+	}
+
+	ocispec := specs.Spec{
+		Annotations: make(map[string]string),
+	}
+
+	runtimeConfig := RuntimeConfig{
+		HypervisorType: vc.QemuHypervisor,
+	}
+
+	// Nothing is allowed by default
+	ocispec.Annotations[vcAnnotations.ExtraArgs] = "-no-hpet"
+	err := addHypervisorExtraOverrides(ocispec, &config, runtimeConfig)
+	assert.Error(err)
+	assert.Empty(config.HypervisorConfig.ExtraArgs)
+
+	// The whole value has to match the allowlist
+	runtimeConfig.HypervisorConfig.ExtraArgsList = []string{"-no-hpet", "-global [a-z._-]+=on"}
+	ocispec.Annotations[vcAnnotations.ExtraArgs] = "-no-hpet -S"
+	err = addHypervisorExtraOverrides(ocispec, &config, runtimeConfig)
+	assert.Error(err)
+	assert.Empty(config.HypervisorConfig.ExtraArgs)
+
+	ocispec.Annotations[vcAnnotations.ExtraArgs] = "-global kvm-pit.lost_tick_policy=on"
+	err = addHypervisorExtraOverrides(ocispec, &config, runtimeConfig)
+	assert.NoError(err)
+	assert.Equal([]string{"-global", "kvm-pit.lost_tick_policy=on"}, config.HypervisorConfig.ExtraArgs)
+
+	// Every top level field has to be allowed
+	ocispec.Annotations[vcAnnotations.ExtraAPIFields] = `{"watchdog": true, "platform": {"num_pci_segments": 2}}`
+	runtimeConfig.HypervisorConfig.ExtraAPIFieldsList = []string{"watchdog"}
+	err = addHypervisorExtraOverrides(ocispec, &config, runtimeConfig)
+	assert.Error(err)
+	assert.Empty(config.HypervisorConfig.ExtraAPIFields)
+
+	runtimeConfig.HypervisorConfig.ExtraAPIFieldsList = []string{"watchdog", "platform"}
+	err = addHypervisorExtraOverrides(ocispec, &config, runtimeConfig)
+	assert.NoError(err)
+	assert.Equal(ocispec.Annotations[vcAnnotations.ExtraAPIFields], config.HypervisorConfig.ExtraAPIFields)
+
+	ocispec.Annotations[vcAnnotations.ExtraAPIFields] = `["watchdog"]`
+	err = addHypervisorExtraOverrides(ocispec, &config, runtimeConfig)
+	assert.Error(err)
+}
+
 func TestIsCRIOContainerManager(t *testing.T) {
 	assert := assert.New(t)
 
@@ -1207,6 +1605,37 @@ func TestCalculateSandboxSizing(t *testing.T) {
 	}
 }
 
+func TestCalculateSandboxSizingDetails(t *testing.T) {
+	assert := assert.New(t)
+
+	// 2 CPUs and 1 GiB for the containers
+	spec := makeSizingAnnotations("1073741824", "200000", "100000")
+
+	// The init containers peak at 3.5 CPUs and 512 MiB.
+	spec.Annotations[vcAnnotations.SizingInitMilliCPUs] = "3500"
+	spec.Annotations[vcAnnotations.SizingInitMemory] = "536870912"
+	// 256 MiB of images pulled in the guest
+	spec.Annotations[vcAnnotations.SizingEphemeralStorage] = "268435456"
+
+	assert.Equal(SandboxSizing{
+		WorkloadMilliCPUs:  2000,
+		WorkloadMemMB:      1024,
+		InitMilliCPUs:      3500,
+		InitMemMB:          512,
+		EphemeralStorageMB: 256,
+		VCPUs:              4,
+		MemoryMB:           1280,
+	}, CalculateSandboxSizingDetails(spec))
+
+	// Invalid declarations are ignored.
+	spec.Annotations[vcAnnotations.SizingInitMilliCPUs] = "-1"
+	spec.Annotations[vcAnnotations.SizingInitMemory] = "lots"
+	spec.Annotations[vcAnnotations.SizingEphemeralStorage] = "-268435456"
+	cpu, mem := CalculateSandboxSizing(spec)
+	assert.Equal(uint32(2), cpu)
+	assert.Equal(uint32(1024), mem)
+}
+
 func TestNewMount(t *testing.T) {
 	assert := assert.New(t)
 
@@ -1282,3 +1711,111 @@ func TestNewMount(t *testing.T) {
 		assert.Equal(tt.out.ReadOnly, actualMount.ReadOnly, "unexpected mount ReadOnly")
 	}
 }
+
+func TestSandboxMetadata(t *testing.T) {
+	assert := assert.New(t)
+
+	spec := specs.Spec{
+		Hostname: "web-0",
+		Annotations: map[string]string{
+			ctrAnnotations.SandboxName:      "web-0",
+			ctrAnnotations.SandboxNamespace: "shop",
+			ctrAnnotations.SandboxCPUPeriod: "100000",
+			ctrAnnotations.SandboxCPUQuota:  "150000",
+			ctrAnnotations.SandboxMem:       "268435456",
+			"example.com/tier":              "frontend",
+			"example.com/owner":             "team-a",
+			"secret.example.com/token":      "s3cr3t",
+		},
+	}
+
+	metadata := sandboxMetadata(spec, []string{"example.com/*"}, "sandbox")
+	assert.Equal(&vc.SandboxMetadata{
+		InstanceID:    "sandbox",
+		LocalHostname: "web-0",
+		Name:          "web-0",
+		Namespace:     "shop",
+		Annotations: map[string]string{
+			"example.com/tier":  "frontend",
+			"example.com/owner": "team-a",
+		},
+		VCPUs:    2,
+		MemoryMB: 256,
+	}, metadata)
+
+	// No annotation is included by default
+	metadata = sandboxMetadata(spec, nil, "sandbox")
+	assert.Nil(metadata.Annotations)
+
+	// CRI-O passes the pod labels on
+	spec.Annotations = map[string]string{
+		podmanAnnotations.KubeName:  "db-0",
+		podmanAnnotations.Namespace: "shop",
+		podmanAnnotations.Labels:    `{"app":"db"}`,
+	}
+	metadata = sandboxMetadata(spec, nil, "sandbox")
+	assert.Equal("db-0", metadata.Name)
+	assert.Equal("shop", metadata.Namespace)
+	assert.Equal(map[string]string{"app": "db"}, metadata.Labels)
+}
+
+func TestLowLatencyProfile(t *testing.T) {
+	assert := assert.New(t)
+
+	runtimeConfig := RuntimeConfig{
+		HypervisorType: vc.QemuHypervisor,
+		HypervisorConfig: vc.HypervisorConfig{
+			VirtioMem:               true,
+			ReclaimGuestFreedMemory: true,
+		},
+		VMMSchedMaxRTPriority: 10,
+	}
+	ocispec := specs.Spec{
+		Annotations: map[string]string{vcAnnotations.Profile: vc.LowLatencyProfile},
+	}
+
+	config := vc.SandboxConfig{HypervisorConfig: runtimeConfig.HypervisorConfig}
+	assert.NoError(addAnnotations(ocispec, &config, runtimeConfig))
+	assert.Equal(vc.LowLatencyProfile, config.Profile)
+
+	assert.NoError(applyLowLatencyProfile(ocispec, &config, runtimeConfig))
+	assert.True(config.EnableVCPUsPinning)
+	assert.True(config.StaticResourceMgmt)
+	assert.False(config.HypervisorConfig.VirtioMem)
+	assert.False(config.HypervisorConfig.ReclaimGuestFreedMemory)
+	assert.Equal(vc.VMMSchedLatency, config.VMMSchedClass)
+	assert.Equal(1, config.VMMSchedRTPriority)
+
+	// Without realtime scheduling, the vCPU threads keep the default class
+	config = vc.SandboxConfig{Profile: vc.LowLatencyProfile}
+	assert.NoError(applyLowLatencyProfile(ocispec, &config, RuntimeConfig{}))
+	assert.Empty(config.VMMSchedClass)
+
+	config = vc.SandboxConfig{Profile: vc.LowLatencyProfile, VMMSchedClass: vc.VMMSchedBatch}
+	assert.Error(applyLowLatencyProfile(ocispec, &config, runtimeConfig))
+
+	// The annotations conflicting with the profile are rejected
+	for annotation, value := range map[string]string{
+		vcAnnotations.EnableVCPUsPinning:      "false",
+		vcAnnotations.VirtioMem:               "true",
+		vcAnnotations.ReclaimGuestFreedMemory: "true",
+	} {
+		ocispec.Annotations[annotation] = value
+		config = vc.SandboxConfig{Profile: vc.LowLatencyProfile}
+		assert.Error(applyLowLatencyProfile(ocispec, &config, runtimeConfig), annotation)
+		delete(ocispec.Annotations, annotation)
+	}
+
+	ocispec.Annotations[vcAnnotations.EnableVCPUsPinning] = "true"
+	config = vc.SandboxConfig{Profile: vc.LowLatencyProfile}
+	assert.NoError(applyLowLatencyProfile(ocispec, &config, runtimeConfig))
+	delete(ocispec.Annotations, vcAnnotations.EnableVCPUsPinning)
+
+	// Only the low latency profile can be selected per pod
+	ocispec.Annotations[vcAnnotations.Profile] = "density"
+	assert.Error(addAnnotations(ocispec, &vc.SandboxConfig{}, runtimeConfig))
+
+	ocispec.Annotations[vcAnnotations.Profile] = vc.LowLatencyProfile
+	runtimeConfig.Profile = "density"
+	assert.Error(addAnnotations(ocispec, &vc.SandboxConfig{}, runtimeConfig))
+}
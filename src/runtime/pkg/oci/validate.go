@@ -0,0 +1,69 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package oci
+
+import (
+	"sort"
+	"strings"
+
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+)
+
+const kataAnnotationsPrefix = "io.katacontainers."
+
+// ValidateAnnotations checks the annotations of a pod against the runtime
+// configuration of the nodes, as they are checked when the sandbox of the
+// pod is created, so that the errors are reported before the pod is
+// scheduled. An error is returned per invalid annotation, along with the
+// ones of the sandbox as a whole.
+//
+// The checks against the resources of a host, e.g. its CPUs, are skipped:
+// the pod may land on any node, whose resources are not the ones of the
+// host running the check. So are the limits of the configuration, which are
+// capped to the resources of the host it is loaded on.
+func ValidateAnnotations(annotations map[string]string, runtime RuntimeConfig) []error {
+	runtime.anyHost = true
+
+	var keys []string
+	for key := range annotations {
+		if strings.HasPrefix(key, kataAnnotationsPrefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	for _, key := range keys {
+		if _, err := annotatedSandboxConfig(map[string]string{key: annotations[key]}, runtime); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	if _, err := annotatedSandboxConfig(annotations, runtime); err != nil {
+		return []error{err}
+	}
+
+	return nil
+}
+
+// annotatedSandboxConfig returns the part of the sandbox configuration
+// which is overridden by the annotations.
+func annotatedSandboxConfig(annotations map[string]string, runtime RuntimeConfig) (vc.SandboxConfig, error) {
+	config := vc.SandboxConfig{
+		HypervisorType:   runtime.HypervisorType,
+		HypervisorConfig: runtime.HypervisorConfig,
+		AgentConfig:      runtime.AgentConfig,
+		Annotations:      map[string]string{},
+	}
+
+	err := addAnnotations(specs.Spec{Annotations: annotations}, &config, runtime)
+	return config, err
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package oci
+
+import (
+	goruntime "runtime"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	vcAnnotations "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations"
+)
+
+func TestValidateAnnotations(t *testing.T) {
+	assert := assert.New(t)
+
+	runtimeConfig := RuntimeConfig{
+		HypervisorType: vc.QemuHypervisor,
+		HypervisorConfig: vc.HypervisorConfig{
+			EnableAnnotations:    []string{"default_memory", "default_vcpus"},
+			DefaultMaxMemorySize: 4096,
+			DefaultMaxVCPUs:      1,
+		},
+	}
+
+	assert.Empty(ValidateAnnotations(nil, runtimeConfig))
+	assert.Empty(ValidateAnnotations(map[string]string{
+		vcAnnotations.DefaultMemory:      "2048",
+		"io.kubernetes.cri.sandbox-name": "foo",
+	}, runtimeConfig))
+
+	// An error per invalid annotation
+	errs := ValidateAnnotations(map[string]string{
+		vcAnnotations.KernelPath:          "/tmp/vmlinux",
+		vcAnnotations.DefaultMemory:       "1",
+		vcAnnotations.DisableGuestSeccomp: "maybe",
+	}, runtimeConfig)
+	assert.Len(errs, 3)
+	assert.Contains(errs[0].Error(), vcAnnotations.DefaultMemory)
+	assert.Contains(errs[1].Error(), vcAnnotations.KernelPath)
+	assert.Contains(errs[2].Error(), vcAnnotations.DisableGuestSeccomp)
+
+	// Not the resources of the host running the check, nor the limits
+	// capped to them
+	assert.Empty(ValidateAnnotations(map[string]string{
+		vcAnnotations.DefaultMemory: "8192",
+		vcAnnotations.DefaultVCPUs:  strconv.Itoa(goruntime.NumCPU() + 1),
+	}, runtimeConfig))
+}
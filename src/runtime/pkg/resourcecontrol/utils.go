@@ -19,6 +19,36 @@ var (
 	ErrCgroupMode = errors.New("cgroup controller type error")
 )
 
+// The scheduling policies of a thread, see sched(7).
+const (
+	SchedOther = 0
+	SchedFIFO  = 1
+	SchedIdle  = 5
+)
+
+// The IO scheduling classes of a thread, see ioprio_set(2).
+const (
+	IOPrioClassNone       = 0
+	IOPrioClassBestEffort = 2
+	IOPrioClassIdle       = 3
+)
+
+// RlimitNofile is the resource limit of the open files of a process, see
+// getrlimit(2).
+const RlimitNofile = 7
+
+// ThreadSched is the CPU and IO scheduling of a thread.
+type ThreadSched struct {
+	// Policy is the CPU scheduling policy
+	Policy int
+	// Priority is the static priority of the realtime policies
+	Priority int
+	// IOClass is the IO scheduling class
+	IOClass int
+	// IOLevel is the priority level within the best effort IO class
+	IOLevel int
+}
+
 func DeviceToCgroupDeviceRule(device string) (*devices.Rule, error) {
 	var st unix.Stat_t
 	deviceRule := devices.Rule{
@@ -8,3 +8,23 @@ package resourcecontrol
 func SetThreadAffinity(threadID int, cpuSetSlice []int) error {
 	return nil
 }
+
+func ProcessThreadIDs(pid int) ([]int, error) {
+	return nil, nil
+}
+
+func SetThreadSched(threadID int, sched ThreadSched) (bool, error) {
+	return false, nil
+}
+
+func ProcessRlimit(pid, resource int) (uint64, error) {
+	return 0, nil
+}
+
+func SetProcessSoftRlimit(pid, resource int, limit uint64) (bool, error) {
+	return false, nil
+}
+
+func ProcessOpenFiles(pid int) (int, error) {
+	return 0, nil
+}
@@ -8,8 +8,11 @@ package resourcecontrol
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"unsafe"
 
 	"github.com/containerd/cgroups"
 	systemdDbus "github.com/coreos/go-systemd/v22/dbus"
@@ -166,3 +169,128 @@ func SetThreadAffinity(threadID int, cpuSetSlice []int) error {
 
 	return nil
 }
+
+// ProcessThreadIDs returns the IDs of the threads of a process.
+func ProcessThreadIDs(pid int) ([]int, error) {
+	entries, err := os.ReadDir(filepath.Join("/proc", strconv.Itoa(pid), "task"))
+	if err != nil {
+		return nil, err
+	}
+
+	var tids []int
+	for _, e := range entries {
+		if tid, err := strconv.Atoi(e.Name()); err == nil {
+			tids = append(tids, tid)
+		}
+	}
+	return tids, nil
+}
+
+const (
+	ioprioWhoProcess = 1
+	ioprioClassShift = 13
+)
+
+type schedParam struct {
+	priority int32
+}
+
+// threadSched returns the current scheduling of a thread.
+func threadSched(threadID int) (ThreadSched, error) {
+	policy, _, errno := unix.RawSyscall(unix.SYS_SCHED_GETSCHEDULER, uintptr(threadID), 0, 0)
+	if errno != 0 {
+		return ThreadSched{}, errno
+	}
+
+	var param schedParam
+	if _, _, errno := unix.RawSyscall(unix.SYS_SCHED_GETPARAM, uintptr(threadID), uintptr(unsafe.Pointer(&param)), 0); errno != 0 {
+		return ThreadSched{}, errno
+	}
+
+	ioprio, _, errno := unix.RawSyscall(unix.SYS_IOPRIO_GET, ioprioWhoProcess, uintptr(threadID), 0)
+	if errno != 0 {
+		return ThreadSched{}, errno
+	}
+
+	sched := ThreadSched{
+		// The policy may have SCHED_RESET_ON_FORK set.
+		Policy:   int(policy) &^ 0x40000000,
+		Priority: int(param.priority),
+		IOClass:  int(ioprio) >> ioprioClassShift,
+	}
+	if sched.IOClass == IOPrioClassBestEffort {
+		sched.IOLevel = int(ioprio) & (1<<ioprioClassShift - 1)
+	}
+	return sched, nil
+}
+
+// SetThreadSched sets the scheduling of a thread, unless it is already set.
+// It tells if the scheduling of the thread was changed, so that the callers
+// applying the scheduling periodically notice the threads created since, or
+// changed behind their back.
+func SetThreadSched(threadID int, sched ThreadSched) (bool, error) {
+	current, err := threadSched(threadID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get thread %d scheduling: %v", threadID, err)
+	}
+	if current == sched {
+		return false, nil
+	}
+
+	if current.Policy != sched.Policy || current.Priority != sched.Priority {
+		param := schedParam{priority: int32(sched.Priority)}
+		if _, _, errno := unix.RawSyscall(unix.SYS_SCHED_SETSCHEDULER, uintptr(threadID), uintptr(sched.Policy), uintptr(unsafe.Pointer(&param))); errno != 0 {
+			return false, fmt.Errorf("failed to set thread %d scheduling policy %d priority %d: %v", threadID, sched.Policy, sched.Priority, errno)
+		}
+	}
+
+	if current.IOClass != sched.IOClass || current.IOLevel != sched.IOLevel {
+		ioprio := sched.IOClass<<ioprioClassShift | sched.IOLevel
+		if _, _, errno := unix.RawSyscall(unix.SYS_IOPRIO_SET, ioprioWhoProcess, uintptr(threadID), uintptr(ioprio)); errno != 0 {
+			return false, fmt.Errorf("failed to set thread %d IO priority class %d level %d: %v", threadID, sched.IOClass, sched.IOLevel, errno)
+		}
+	}
+
+	return true, nil
+}
+
+// ProcessRlimit returns the soft limit of a resource of a process.
+func ProcessRlimit(pid, resource int) (uint64, error) {
+	var current unix.Rlimit
+	if err := unix.Prlimit(pid, resource, nil, &current); err != nil {
+		return 0, fmt.Errorf("failed to get process %d limit %d: %v", pid, resource, err)
+	}
+	return current.Cur, nil
+}
+
+// SetProcessSoftRlimit sets the soft limit of a resource of a process,
+// capped to its hard limit. The hard limit is left as is: lowering it cannot
+// be undone without privileges, and would fail the devices hotplugged later
+// to the VMM. Like SetThreadSched, it tells if the limit was changed.
+func SetProcessSoftRlimit(pid, resource int, limit uint64) (bool, error) {
+	var current unix.Rlimit
+	if err := unix.Prlimit(pid, resource, nil, &current); err != nil {
+		return false, fmt.Errorf("failed to get process %d limit %d: %v", pid, resource, err)
+	}
+	if limit > current.Max {
+		limit = current.Max
+	}
+	if current.Cur == limit {
+		return false, nil
+	}
+
+	rlimit := unix.Rlimit{Cur: limit, Max: current.Max}
+	if err := unix.Prlimit(pid, resource, &rlimit, nil); err != nil {
+		return false, fmt.Errorf("failed to set process %d limit %d to %d: %v", pid, resource, limit, err)
+	}
+	return true, nil
+}
+
+// ProcessOpenFiles returns the number of file descriptors a process has open.
+func ProcessOpenFiles(pid int) (int, error) {
+	entries, err := os.ReadDir(filepath.Join("/proc", strconv.Itoa(pid), "fd"))
+	if err != nil {
+		return 0, err
+	}
+	return len(entries), nil
+}
@@ -6,12 +6,17 @@
 package resourcecontrol
 
 import (
+	"fmt"
+	"math"
 	"os"
+	"os/exec"
 	"path/filepath"
+	goruntime "runtime"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/unix"
 )
 
 func TestIsSystemdCgroup(t *testing.T) {
@@ -227,3 +232,111 @@ func TestDeviceToLinuxDevice(t *testing.T) {
 	assert.NotEmpty(dev.Access)
 	assert.True(dev.Allow)
 }
+
+func TestSetThreadSched(t *testing.T) {
+	assert := assert.New(t)
+
+	errCh := make(chan error)
+	go func() {
+		// The thread exits with the goroutine, along with its
+		// scheduling.
+		goruntime.LockOSThread()
+		tid := unix.Gettid()
+
+		idle := ThreadSched{Policy: SchedIdle, IOClass: IOPrioClassIdle}
+		changed, err := SetThreadSched(tid, idle)
+		if err == nil && !changed {
+			err = fmt.Errorf("thread scheduling not changed")
+		}
+		if err == nil {
+			if changed, err = SetThreadSched(tid, idle); err == nil && changed {
+				err = fmt.Errorf("thread scheduling changed twice")
+			}
+		}
+		if err == nil {
+			var current ThreadSched
+			if current, err = threadSched(tid); err == nil && current != idle {
+				err = fmt.Errorf("unexpected thread scheduling %+v", current)
+			}
+		}
+		errCh <- err
+	}()
+
+	assert.NoError(<-errCh)
+
+	_, err := SetThreadSched(-1, ThreadSched{})
+	assert.Error(err)
+}
+
+func TestProcessThreadIDs(t *testing.T) {
+	assert := assert.New(t)
+
+	tids, err := ProcessThreadIDs(os.Getpid())
+	assert.NoError(err)
+	assert.Contains(tids, os.Getpid())
+
+	_, err = ProcessThreadIDs(-1)
+	assert.Error(err)
+}
+
+func TestSetProcessSoftRlimit(t *testing.T) {
+	assert := assert.New(t)
+
+	pid := os.Getpid()
+	limit, err := ProcessRlimit(pid, RlimitNofile)
+	assert.NoError(err)
+	assert.NotZero(limit)
+
+	// The limits of a child process are changed, not to change those of
+	// the tests.
+	cmd := exec.Command("sleep", "10")
+	assert.NoError(cmd.Start())
+	defer cmd.Process.Kill()
+
+	var before unix.Rlimit
+	assert.NoError(unix.Prlimit(cmd.Process.Pid, RlimitNofile, nil, &before))
+
+	changed, err := SetProcessSoftRlimit(cmd.Process.Pid, RlimitNofile, 64)
+	assert.NoError(err)
+	assert.True(changed)
+	changed, err = SetProcessSoftRlimit(cmd.Process.Pid, RlimitNofile, 64)
+	assert.NoError(err)
+	assert.False(changed)
+
+	// the hard limit is left as is
+	var after unix.Rlimit
+	assert.NoError(unix.Prlimit(cmd.Process.Pid, RlimitNofile, nil, &after))
+	assert.Equal(uint64(64), after.Cur)
+	assert.Equal(before.Max, after.Max)
+
+	// and caps the soft limit
+	if before.Max < math.MaxUint64 {
+		_, err = SetProcessSoftRlimit(cmd.Process.Pid, RlimitNofile, before.Max+1)
+		assert.NoError(err)
+		limit, err = ProcessRlimit(cmd.Process.Pid, RlimitNofile)
+		assert.NoError(err)
+		assert.Equal(before.Max, limit)
+	}
+
+	_, err = SetProcessSoftRlimit(-1, RlimitNofile, 64)
+	assert.Error(err)
+}
+
+func TestProcessOpenFiles(t *testing.T) {
+	assert := assert.New(t)
+
+	files, err := ProcessOpenFiles(os.Getpid())
+	assert.NoError(err)
+	assert.NotZero(files)
+
+	f, err := os.Open(os.DevNull)
+	assert.NoError(err)
+	defer f.Close()
+
+	more, err := ProcessOpenFiles(os.Getpid())
+	assert.NoError(err)
+	assert.Greater(more, files)
+
+	_, err = ProcessOpenFiles(-1)
+	assert.Error(err)
+}
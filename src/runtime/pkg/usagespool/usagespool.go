@@ -0,0 +1,140 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package usagespool persists the resource usage of the sandboxes, so that
+// metering agents can charge for it even when they miss scrape intervals.
+//
+// The shim of each sandbox periodically rewrites the snapshot of the usage
+// of its sandbox in the spool directory, as <sandbox ID>.json. The counters
+// of a snapshot are cumulative since the sandbox started, and the last
+// snapshot is marked final once the sandbox is gone. The snapshots are left
+// in the spool until the metering agent removes them.
+package usagespool
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const snapshotExt = ".json"
+
+// Snapshot is the resource usage of a sandbox.
+type Snapshot struct {
+	SandboxID string    `json:"sandbox_id"`
+	StartTime time.Time `json:"start_time"`
+	Timestamp time.Time `json:"timestamp"`
+
+	// Sequence is incremented with each snapshot of the sandbox.
+	Sequence uint64 `json:"sequence"`
+
+	// CPUTime is the CPU time used by the sandbox, in nanoseconds.
+	CPUTime uint64 `json:"cpu_time_ns"`
+	// MaxMemory is the highest memory usage of the sandbox, in bytes.
+	MaxMemory uint64 `json:"max_memory_bytes"`
+
+	NetRxBytes      uint64 `json:"net_rx_bytes"`
+	NetTxBytes      uint64 `json:"net_tx_bytes"`
+	BlockReadBytes  uint64 `json:"block_read_bytes"`
+	BlockWriteBytes uint64 `json:"block_write_bytes"`
+
+	// Final is set on the last snapshot of the sandbox.
+	Final bool `json:"final"`
+}
+
+func snapshotPath(dir, sandboxID string) string {
+	return filepath.Join(dir, sandboxID+snapshotExt)
+}
+
+// Write replaces the snapshot of the sandbox in the spool directory. The
+// snapshot is replaced atomically, readers never see a partial one.
+func Write(dir string, snapshot Snapshot) error {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(dir, "."+snapshot.SandboxID+"-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), snapshotPath(dir, snapshot.SandboxID))
+}
+
+// Read returns the snapshot of the sandbox from the spool directory.
+func Read(dir, sandboxID string) (Snapshot, error) {
+	var snapshot Snapshot
+
+	data, err := os.ReadFile(snapshotPath(dir, sandboxID))
+	if err != nil {
+		return snapshot, err
+	}
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return snapshot, fmt.Errorf("invalid usage snapshot of sandbox %s: %v", sandboxID, err)
+	}
+
+	return snapshot, nil
+}
+
+// List returns the snapshots of the spool directory, sorted by sandbox ID.
+func List(dir string) ([]Snapshot, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := []Snapshot{}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || strings.HasPrefix(name, ".") || !strings.HasSuffix(name, snapshotExt) {
+			continue
+		}
+		snapshot, err := Read(dir, strings.TrimSuffix(name, snapshotExt))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].SandboxID < snapshots[j].SandboxID
+	})
+	return snapshots, nil
+}
+
+// Remove removes the snapshot of the sandbox from the spool directory, once
+// it is accounted for.
+func Remove(dir, sandboxID string) error {
+	err := os.Remove(snapshotPath(dir, sandboxID))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
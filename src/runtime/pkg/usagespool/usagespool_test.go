@@ -0,0 +1,69 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package usagespool
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpool(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := filepath.Join(t.TempDir(), "spool")
+
+	snapshots, err := List(dir)
+	assert.Error(err)
+	assert.Empty(snapshots)
+
+	start := time.Now().UTC().Truncate(time.Second)
+	for _, id := range []string{"sandbox-b", "sandbox-a"} {
+		assert.NoError(Write(dir, Snapshot{
+			SandboxID: id,
+			StartTime: start,
+			Timestamp: start,
+			Sequence:  1,
+			CPUTime:   1000,
+		}))
+	}
+	assert.NoError(Write(dir, Snapshot{
+		SandboxID: "sandbox-a",
+		StartTime: start,
+		Timestamp: start.Add(time.Minute),
+		Sequence:  2,
+		CPUTime:   2000,
+		Final:     true,
+	}))
+
+	// Neither the temporary files nor the other files are listed.
+	assert.NoError(os.WriteFile(filepath.Join(dir, ".sandbox-c-123"), []byte("{"), 0600))
+	assert.NoError(os.WriteFile(filepath.Join(dir, "README"), nil, 0600))
+
+	snapshots, err = List(dir)
+	assert.NoError(err)
+	assert.Len(snapshots, 2)
+	assert.Equal("sandbox-a", snapshots[0].SandboxID)
+	assert.Equal(uint64(2), snapshots[0].Sequence)
+	assert.Equal(uint64(2000), snapshots[0].CPUTime)
+	assert.True(snapshots[0].Final)
+	assert.True(start.Equal(snapshots[0].StartTime))
+	assert.Equal("sandbox-b", snapshots[1].SandboxID)
+
+	assert.NoError(Remove(dir, "sandbox-a"))
+	assert.NoError(Remove(dir, "sandbox-a"))
+	_, err = Read(dir, "sandbox-a")
+	assert.True(os.IsNotExist(err))
+
+	assert.NoError(os.WriteFile(filepath.Join(dir, "sandbox-d.json"), []byte("{"), 0600))
+	_, err = Read(dir, "sandbox-d")
+	assert.Error(err)
+	_, err = List(dir)
+	assert.Error(err)
+}
@@ -43,14 +43,32 @@ func buildUnixSocketClient(socketAddr string, timeout time.Duration) (*http.Clie
 }
 
 func DoGet(sandboxID string, timeoutInSeconds time.Duration, urlPath string) ([]byte, error) {
+	body, _, err := DoGetWithHeader(sandboxID, timeoutInSeconds, urlPath, nil)
+	return body, err
+}
+
+// DoGetWithHeader makes a GET request with the given headers to the shim
+// endpoint that handles the given sandbox ID, and returns the response
+// body and headers
+func DoGetWithHeader(sandboxID string, timeoutInSeconds time.Duration, urlPath string, header http.Header) ([]byte, http.Header, error) {
 	client, err := BuildShimClient(sandboxID, timeoutInSeconds)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://shim%s", urlPath), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
 	}
 
-	resp, err := client.Get(fmt.Sprintf("http://shim%s", urlPath))
+	resp, err := client.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	defer func() {
@@ -59,10 +77,10 @@ func DoGet(sandboxID string, timeoutInSeconds time.Duration, urlPath string) ([]
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return body, nil
+	return body, resp.Header, nil
 }
 
 // DoPut will make a PUT request to the shim endpoint that handles the given sandbox ID
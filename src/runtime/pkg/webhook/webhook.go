@@ -0,0 +1,167 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package webhook implements a Kubernetes validating admission webhook
+// checking the Kata Containers annotations of the pods against the runtime
+// configuration of the nodes, so that an invalid annotation is reported when
+// the pod is created, instead of failing its sandbox creation on the node.
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/oci"
+)
+
+const (
+	admissionAPIVersion = "admission.k8s.io/v1"
+	admissionKind       = "AdmissionReview"
+
+	// maxReviewSize bounds the size of the admission reviews, which
+	// embed the pod.
+	maxReviewSize = 3 * 1024 * 1024
+)
+
+// The admission review types below are the subset of the ones of
+// k8s.io/api/admission/v1 used by the webhook, which is not vendored.
+
+// AdmissionReview is the request sent to the webhook by the API server, and
+// the response of the webhook.
+type AdmissionReview struct {
+	APIVersion string             `json:"apiVersion"`
+	Kind       string             `json:"kind"`
+	Request    *AdmissionRequest  `json:"request,omitempty"`
+	Response   *AdmissionResponse `json:"response,omitempty"`
+}
+
+// AdmissionRequest is the object under admission.
+type AdmissionRequest struct {
+	UID       string          `json:"uid"`
+	Namespace string          `json:"namespace,omitempty"`
+	Name      string          `json:"name,omitempty"`
+	Operation string          `json:"operation,omitempty"`
+	Object    json.RawMessage `json:"object,omitempty"`
+}
+
+// AdmissionResponse tells if the object is admitted.
+type AdmissionResponse struct {
+	UID     string  `json:"uid"`
+	Allowed bool    `json:"allowed"`
+	Result  *Status `json:"status,omitempty"`
+}
+
+// Status describes why the object is not admitted.
+type Status struct {
+	Message string `json:"message,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	Code    int32  `json:"code,omitempty"`
+}
+
+// pod is the part of a pod checked by the webhook.
+type pod struct {
+	Metadata struct {
+		Annotations map[string]string `json:"annotations,omitempty"`
+	} `json:"metadata"`
+	Spec struct {
+		RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+	} `json:"spec"`
+}
+
+// Validator is the handler of the admission reviews of the pods.
+type Validator struct {
+	// Runtime is the runtime configuration the annotations are checked
+	// against.
+	Runtime oci.RuntimeConfig
+
+	// RuntimeClasses are the globs of the names of the runtime classes
+	// of Kata Containers. The pods of the other runtime classes are
+	// admitted without check.
+	RuntimeClasses []string
+}
+
+// Validate checks the Kata Containers annotations of a pod given by its
+// JSON object, and returns the reason it is not admitted, or an empty
+// string.
+func (v *Validator) Validate(object []byte) (string, error) {
+	var p pod
+	if err := json.Unmarshal(object, &p); err != nil {
+		return "", fmt.Errorf("invalid pod: %w", err)
+	}
+
+	if !v.kataRuntimeClass(p.Spec.RuntimeClassName) {
+		return "", nil
+	}
+
+	errs := oci.ValidateAnnotations(p.Metadata.Annotations, v.Runtime)
+	if len(errs) == 0 {
+		return "", nil
+	}
+
+	msgs := make([]string, 0, len(errs))
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("the Kata Containers annotations of the pod are not valid on the nodes of runtime class %s: %s",
+		*p.Spec.RuntimeClassName, strings.Join(msgs, "; ")), nil
+}
+
+func (v *Validator) kataRuntimeClass(name *string) bool {
+	if name == nil {
+		return false
+	}
+	for _, glob := range v.RuntimeClasses {
+		if matched, _ := filepath.Match(glob, *name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// ServeHTTP answers an admission review of a pod.
+func (v *Validator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var review AdmissionReview
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxReviewSize)).Decode(&review); err != nil {
+		http.Error(w, fmt.Sprintf("invalid admission review: %v", err), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, "admission review without request", http.StatusBadRequest)
+		return
+	}
+
+	response := &AdmissionResponse{UID: review.Request.UID, Allowed: true}
+	reason, err := v.Validate(review.Request.Object)
+	if err != nil {
+		response.Allowed = false
+		response.Result = &Status{Message: err.Error(), Reason: "BadRequest", Code: http.StatusBadRequest}
+	} else if reason != "" {
+		response.Allowed = false
+		response.Result = &Status{Message: reason, Reason: "Invalid", Code: http.StatusUnprocessableEntity}
+	}
+
+	// The response is of the version of the request, v1 or v1beta1.
+	apiVersion := review.APIVersion
+	if apiVersion == "" {
+		apiVersion = admissionAPIVersion
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(AdmissionReview{
+		APIVersion: apiVersion,
+		Kind:       admissionKind,
+		Response:   response,
+	})
+}
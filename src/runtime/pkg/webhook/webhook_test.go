@@ -0,0 +1,87 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/oci"
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+)
+
+func TestValidator(t *testing.T) {
+	assert := assert.New(t)
+
+	v := &Validator{
+		Runtime: oci.RuntimeConfig{
+			HypervisorType: vc.QemuHypervisor,
+			HypervisorConfig: vc.HypervisorConfig{
+				EnableAnnotations:    []string{"default_memory"},
+				DefaultMaxMemorySize: 4096,
+			},
+		},
+		RuntimeClasses: []string{"kata*"},
+	}
+
+	review := func(object string) (int, AdmissionReview) {
+		body, err := json.Marshal(AdmissionReview{
+			APIVersion: "admission.k8s.io/v1",
+			Kind:       admissionKind,
+			Request:    &AdmissionRequest{UID: "42", Object: json.RawMessage(object)},
+		})
+		assert.NoError(err)
+
+		w := httptest.NewRecorder()
+		v.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/validate-annotations", bytes.NewReader(body)))
+
+		var resp AdmissionReview
+		if w.Code == http.StatusOK {
+			assert.NoError(json.NewDecoder(w.Body).Decode(&resp))
+			assert.Equal("42", resp.Response.UID)
+		}
+		return w.Code, resp
+	}
+
+	code, resp := review(`{"metadata": {"annotations": {"io.katacontainers.config.hypervisor.default_memory": "2048"}}, "spec": {"runtimeClassName": "kata-qemu"}}`)
+	assert.Equal(http.StatusOK, code)
+	assert.True(resp.Response.Allowed)
+
+	code, resp = review(`{"metadata": {"annotations": {"io.katacontainers.config.hypervisor.default_memory": "1"}}, "spec": {"runtimeClassName": "kata-qemu"}}`)
+	assert.Equal(http.StatusOK, code)
+	assert.False(resp.Response.Allowed)
+	assert.Contains(resp.Response.Result.Message, "default_memory")
+
+	// The limits depending on the node are left to the node
+	code, resp = review(`{"metadata": {"annotations": {"io.katacontainers.config.hypervisor.default_memory": "8192"}}, "spec": {"runtimeClassName": "kata-qemu"}}`)
+	assert.Equal(http.StatusOK, code)
+	assert.True(resp.Response.Allowed)
+
+	// The pods of the other runtime classes are not checked
+	code, resp = review(`{"metadata": {"annotations": {"io.katacontainers.config.hypervisor.kernel": "/tmp/vmlinux"}}, "spec": {"runtimeClassName": "runc"}}`)
+	assert.Equal(http.StatusOK, code)
+	assert.True(resp.Response.Allowed)
+	code, resp = review(`{"metadata": {"annotations": {"io.katacontainers.config.hypervisor.kernel": "/tmp/vmlinux"}}, "spec": {}}`)
+	assert.Equal(http.StatusOK, code)
+	assert.True(resp.Response.Allowed)
+
+	code, resp = review(`[]`)
+	assert.Equal(http.StatusOK, code)
+	assert.False(resp.Response.Allowed)
+
+	w := httptest.NewRecorder()
+	v.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/validate-annotations", bytes.NewReader([]byte("{}"))))
+	assert.Equal(http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	v.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/validate-annotations", nil))
+	assert.Equal(http.StatusMethodNotAllowed, w.Code)
+}
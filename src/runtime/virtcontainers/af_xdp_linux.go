@@ -0,0 +1,180 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"github.com/vishvananda/netns"
+	"golang.org/x/sys/unix"
+)
+
+// With the af_xdp internetworking model there is no tap: the hypervisor
+// binds AF_XDP sockets to queues of the network interface provided by the
+// network plugin and loads the XDP program redirecting their packets to the
+// sockets, from the pod network namespace it runs in.
+
+const (
+	// number of polls the interface interrupts stay masked for, after a
+	// poll that found packets
+	afXDPNapiDeferHardIrqs = 2
+
+	// path to the sysfs mount of the pod network namespace
+	afXDPSysfsPath = "/sys"
+)
+
+// afXDPNetDeviceAttrs returns the sysfs attributes of the network
+// interface polled every busyPollTimeout microseconds with its
+// interrupts masked.
+func afXDPNetDeviceAttrs(busyPollTimeout uint32) map[string]string {
+	return map[string]string{
+		"napi_defer_hard_irqs": strconv.Itoa(afXDPNapiDeferHardIrqs),
+		"gro_flush_timeout":    strconv.FormatUint(uint64(busyPollTimeout)*1000, 10),
+	}
+}
+
+// checkAFXDPQueues checks that the interface has the queues the sockets are
+// bound to.
+func checkAFXDPQueues(iface string, numQueues int, startQueue, queues uint32) error {
+	if queues == 0 {
+		queues = 1
+	}
+	if int(startQueue)+int(queues) > numQueues {
+		return fmt.Errorf("interface %s has %d queues, %d requested from queue %d", iface, numQueues, queues, startQueue)
+	}
+	return nil
+}
+
+// writeNetDeviceAttrs writes the attributes of the network interface in the
+// sysfs mounted at sysfs.
+func writeNetDeviceAttrs(sysfs, iface string, attrs map[string]string) error {
+	for attr, value := range attrs {
+		path := filepath.Join(sysfs, "class", "net", iface, attr)
+		if err := os.WriteFile(path, []byte(value), 0644); err != nil {
+			return fmt.Errorf("failed to set %s of interface %s: %w", attr, iface, err)
+		}
+	}
+	return nil
+}
+
+// setNetDeviceAttrs sets the sysfs attributes of a network interface of the
+// network namespace of the calling thread. The sysfs of the runtime only
+// shows the interfaces of the host network namespace, the attributes are
+// written from a thread in its own mount namespace, with a sysfs mounted
+// from the network namespace of the interface.
+func setNetDeviceAttrs(iface string, attrs map[string]string) error {
+	nsHandle, err := netns.Get()
+	if err != nil {
+		return err
+	}
+	defer nsHandle.Close()
+
+	errCh := make(chan error)
+	go func() {
+		// The thread is never unlocked, so that it exits with the
+		// goroutine instead of being reused from another mount namespace.
+		runtime.LockOSThread()
+
+		errCh <- func() error {
+			if err := netns.Set(nsHandle); err != nil {
+				return err
+			}
+			if err := unix.Unshare(unix.CLONE_NEWNS); err != nil {
+				return err
+			}
+			if err := unix.Mount("", "/", "", unix.MS_SLAVE|unix.MS_REC, ""); err != nil {
+				return err
+			}
+			if err := unix.Mount("sysfs", afXDPSysfsPath, "sysfs", 0, ""); err != nil {
+				return err
+			}
+			return writeNetDeviceAttrs(afXDPSysfsPath, iface, attrs)
+		}()
+	}()
+
+	return <-errCh
+}
+
+func setupAFXDP(ctx context.Context, endpoint Endpoint, config HypervisorConfig) error {
+	span, _ := networkTrace(ctx, "setupAFXDP", endpoint)
+	defer span.End()
+
+	netHandle, err := netlink.NewHandle()
+	if err != nil {
+		return err
+	}
+	defer netHandle.Close()
+
+	netPair := endpoint.NetworkPair()
+
+	link, err := getLinkForEndpoint(endpoint, netHandle)
+	if err != nil {
+		return err
+	}
+	attrs := link.Attrs()
+
+	if err := checkAFXDPQueues(attrs.Name, attrs.NumRxQueues, config.AFXDPStartQueue, config.AFXDPQueues); err != nil {
+		return err
+	}
+
+	// The guest gets the address of the interface, the one the network
+	// plugin expects the traffic from, as with the TC filter model.
+	netPair.TAPIface.HardAddr = attrs.HardwareAddr.String()
+	netPair.afXDP = afXDPBinding{
+		mode:       config.AFXDPMode,
+		queues:     config.AFXDPQueues,
+		startQueue: config.AFXDPStartQueue,
+	}
+
+	if config.AFXDPBusyPollTimeout > 0 {
+		if err := setNetDeviceAttrs(attrs.Name, afXDPNetDeviceAttrs(config.AFXDPBusyPollTimeout)); err != nil {
+			return err
+		}
+	}
+
+	if err := netHandle.LinkSetUp(link); err != nil {
+		return fmt.Errorf("Could not enable interface %s: %s", attrs.Name, err)
+	}
+
+	return nil
+}
+
+func removeAFXDP(ctx context.Context, endpoint Endpoint) error {
+	span, _ := networkTrace(ctx, "removeAFXDP", endpoint)
+	defer span.End()
+
+	netHandle, err := netlink.NewHandle()
+	if err != nil {
+		return err
+	}
+	defer netHandle.Close()
+
+	link, err := getLinkForEndpoint(endpoint, netHandle)
+	if err != nil {
+		return err
+	}
+
+	// The hypervisor unloads its XDP program when it closes the sockets,
+	// unless it did not exit cleanly.
+	if xdp := link.Attrs().Xdp; xdp != nil && xdp.Attached {
+		flags := unix.XDP_FLAGS_DRV_MODE
+		if xdp.AttachMode == nl.XDP_ATTACHED_SKB {
+			flags = unix.XDP_FLAGS_SKB_MODE
+		}
+		if err := netlink.LinkSetXdpFdWithFlags(link, -1, flags); err != nil {
+			return fmt.Errorf("Could not remove the XDP program of %s: %s", link.Attrs().Name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckAFXDPQueues(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(checkAFXDPQueues("eth0", 1, 0, 0))
+	assert.NoError(checkAFXDPQueues("eth0", 4, 2, 2))
+	assert.Error(checkAFXDPQueues("eth0", 1, 1, 0))
+	assert.Error(checkAFXDPQueues("eth0", 4, 2, 3))
+}
+
+func TestWriteNetDeviceAttrs(t *testing.T) {
+	assert := assert.New(t)
+
+	sysfs := t.TempDir()
+	dir := filepath.Join(sysfs, "class", "net", "eth0")
+	assert.NoError(os.MkdirAll(dir, 0700))
+
+	assert.NoError(writeNetDeviceAttrs(sysfs, "eth0", afXDPNetDeviceAttrs(50)))
+
+	value, err := os.ReadFile(filepath.Join(dir, "napi_defer_hard_irqs"))
+	assert.NoError(err)
+	assert.Equal("2", string(value))
+
+	value, err = os.ReadFile(filepath.Join(dir, "gro_flush_timeout"))
+	assert.NoError(err)
+	assert.Equal("50000", string(value))
+
+	assert.Error(writeNetDeviceAttrs(sysfs, "eth1", afXDPNetDeviceAttrs(50)))
+}
@@ -200,6 +200,9 @@ type agent interface {
 	// getGuestVolumeStats get the filesystem stats of a volume specified by the volume mount path on the guest.
 	getGuestVolumeStats(ctx context.Context, volumeGuestPath string) ([]byte, error)
 
+	// getContainerVolumeStats gets the filesystem stats of the volumes of a container on the guest.
+	getContainerVolumeStats(ctx context.Context, containerID string) ([]*grpc.ContainerVolumeStats, error)
+
 	// resizeGuestVolume resizes a volume specified by the volume mount path on the guest.
 	resizeGuestVolume(ctx context.Context, volumeGuestPath string, size uint64) error
 
@@ -208,4 +211,71 @@ type agent interface {
 
 	// setIPTables sets the iptables from the guest
 	setIPTables(ctx context.Context, isIPv6 bool, data []byte) error
+
+	// waitDevice waits for the device of type devType to appear at address
+	// in the guest, for at most timeout. errUnimplemented is returned when
+	// the agent cannot acknowledge the hotplugged devices.
+	waitDevice(ctx context.Context, devType, address string, timeout time.Duration) error
+
+	// syncFs writes the dirty pages of the storages and the rootfs of the
+	// container back to their devices, or of all the filesystems of the
+	// guest when containerID is empty, for at most timeout.
+	// errUnimplemented is returned when the agent cannot sync them.
+	syncFs(ctx context.Context, containerID string, timeout time.Duration) error
+
+	// freezeFs freezes the filesystems of the storages and the rootfs of
+	// the container inside the guest, or of all the containers when
+	// containerID is empty, until thawFs is called. errUnimplemented is
+	// returned when the agent cannot freeze them.
+	freezeFs(ctx context.Context, containerID string) error
+
+	// thawFs thaws the filesystems freezeFs froze.
+	thawFs(ctx context.Context) error
+
+	// setNameResolution writes the /etc/hosts and /etc/resolv.conf files
+	// of the sandbox inside the guest, those with empty contents are left
+	// as is. errUnimplemented is returned when the agent cannot write them.
+	setNameResolution(ctx context.Context, hosts, resolvConf []byte) error
+
+	// getGuestServices returns the status of the guest services the agent
+	// started for the sandbox. errUnimplemented is returned when the agent
+	// cannot run them.
+	getGuestServices(ctx context.Context) ([]GuestServiceStatus, error)
+
+	// setNetworkPolicy attaches the eBPF program enforcing policy to the
+	// network interfaces of the guest, an empty program removing it.
+	// errUnimplemented is returned when the agent cannot enforce it.
+	setNetworkPolicy(ctx context.Context, program []byte, policy *NetworkPolicy) error
+
+	// getSeccompNotification waits for the next system call of the
+	// containers trapped by a SCMP_ACT_NOTIFY rule of their seccomp
+	// profile. errUnimplemented is returned when the agent cannot trap
+	// them.
+	getSeccompNotification(ctx context.Context) (*SeccompNotification, error)
+
+	// respondSeccompNotification responds to a notification of
+	// getSeccompNotification.
+	respondSeccompNotification(ctx context.Context, resp SeccompNotificationResponse) error
+
+	// readFile reads at most maxSize bytes of the file at path inside the
+	// guest from offset, up to its end when maxSize is 0. errUnimplemented
+	// is returned when the agent cannot read it.
+	readFile(ctx context.Context, path string, offset, maxSize uint64) ([]byte, error)
+
+	// getDiagnostics returns the tail of the kernel log of the guest, of
+	// maxKernelLogSize bytes at most, its mount table and its process list.
+	// errUnimplemented is returned when the agent cannot provide them.
+	getDiagnostics(ctx context.Context, maxKernelLogSize uint64) (*grpc.Diagnostics, error)
+
+	// checkpointContainer dumps the processes of the container with CRIU
+	// in the imagePath directory of the guest, and returns the names of the
+	// image files. errUnimplemented is returned when the agent cannot
+	// checkpoint it.
+	checkpointContainer(ctx context.Context, c *Container, imagePath string, opts CheckpointOptions) ([]string, error)
+
+	// restoreContainer restores the processes of the created container from
+	// the images of the imagePath directory of the guest, in place of
+	// starting it. errUnimplemented is returned when the agent cannot
+	// restore it.
+	restoreContainer(ctx context.Context, c *Container, imagePath string, opts CheckpointOptions) error
 }
@@ -57,16 +57,19 @@ func createSandboxFromConfig(ctx context.Context, sandboxConfig SandboxConfig, f
 	span, ctx := katatrace.Trace(ctx, virtLog, "createSandboxFromConfig", apiTracingTags)
 	defer span.End()
 
+	applyFeatureFlags(&sandboxConfig)
+
 	// Create the sandbox.
 	s, err := createSandbox(ctx, sandboxConfig, factory)
 	if err != nil {
 		return nil, err
 	}
 
-	// Cleanup sandbox resources in case of any failure
+	// Release every resource allocated so far in case of any failure,
+	// in the reverse order of their allocation.
 	defer func() {
 		if err != nil {
-			s.Delete(ctx)
+			s.undo.unwind(s.Logger())
 		}
 	}()
 
@@ -74,30 +77,30 @@ func createSandboxFromConfig(ctx context.Context, sandboxConfig SandboxConfig, f
 	if err = s.createNetwork(ctx); err != nil {
 		return nil, err
 	}
-
-	// network rollback
-	defer func() {
-		if err != nil {
-			s.removeNetwork(ctx)
-		}
-	}()
+	s.undo.push("network", func() error {
+		return s.removeNetwork(ctx)
+	})
 
 	// Set the sandbox host cgroups.
-	if err := s.setupResourceController(); err != nil {
+	if err = s.setupResourceController(); err != nil {
+		return nil, err
+	}
+
+	// Wait for the node to allow another sandbox to boot, until its
+	// containers are created.
+	releaseBootSlot, err := s.acquireBootSlot(ctx)
+	if err != nil {
 		return nil, err
 	}
+	defer releaseBootSlot()
 
 	// Start the VM
 	if err = s.startVM(ctx, prestartHookFunc); err != nil {
 		return nil, err
 	}
-
-	// rollback to stop VM if error occurs
-	defer func() {
-		if err != nil {
-			s.stopVM(ctx)
-		}
-	}()
+	s.undo.push("VM", func() error {
+		return s.stopVM(ctx)
+	})
 
 	s.postCreatedNetwork(ctx)
 
@@ -106,10 +109,20 @@ func createSandboxFromConfig(ctx context.Context, sandboxConfig SandboxConfig, f
 	}
 
 	// Create Containers
+	s.undo.push("containers", func() error {
+		for _, c := range s.containers {
+			if err := c.delete(ctx); err != nil {
+				s.Logger().WithError(err).WithField("container", c.id).Debug("failed to delete container")
+			}
+		}
+		return nil
+	})
 	if err = s.createContainers(ctx); err != nil {
 		return nil, err
 	}
 
+	s.undo.commit()
+
 	return s, nil
 }
 
@@ -164,3 +177,23 @@ func CleanupContainer(ctx context.Context, sandboxID, containerID string, force
 
 	return nil
 }
+
+// AdoptSandbox is used by shimv2 to take over a running sandbox from the shim
+// it is handed over by. It fetches the sandbox and its containers from their
+// persisted state, without touching the VM.
+func AdoptSandbox(ctx context.Context, sandboxID string) (VCSandbox, error) {
+	span, ctx := katatrace.Trace(ctx, virtLog, "AdoptSandbox", apiTracingTags)
+	defer span.End()
+
+	if sandboxID == "" {
+		return nil, vcTypes.ErrNeedSandboxID
+	}
+
+	unlock, err := rwLockSandbox(sandboxID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	return fetchSandbox(ctx, sandboxID)
+}
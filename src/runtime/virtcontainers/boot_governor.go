@@ -0,0 +1,105 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+// The heavyweight phases of the boot of the sandboxes, starting the VMM,
+// preallocating the guest memory and setting up the container images, are
+// governed by a semaphore shared by the runtimes of the node, so that a burst
+// of pod admissions does not stampede the host. Each slot of the semaphore
+// is a file the booting sandbox holds a lock on, the kernel releasing it
+// when the runtime dies.
+
+const (
+	bootSlotsDir         = "boot-slots"
+	bootSlotPollInterval = 50 * time.Millisecond
+)
+
+// bootGovernor limits the number of sandboxes booting at once.
+type bootGovernor struct {
+	dir          string
+	pollInterval time.Duration
+	slots        uint32
+}
+
+// tryAcquire locks the first free slot, and returns its file, nil when all
+// the slots are held.
+func (g *bootGovernor) tryAcquire() (*os.File, error) {
+	for i := uint32(0); i < g.slots; i++ {
+		f, err := os.OpenFile(filepath.Join(g.dir, fmt.Sprintf("slot-%d", i)), os.O_RDWR|os.O_CREATE, 0600)
+		if err != nil {
+			return nil, err
+		}
+
+		err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+		if err == nil {
+			return f, nil
+		}
+		f.Close()
+		if err != syscall.EWOULDBLOCK {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+// acquire waits for a free slot, and returns the function releasing it.
+func (g *bootGovernor) acquire(ctx context.Context) (func(), error) {
+	if err := os.MkdirAll(g.dir, DirMode); err != nil {
+		return nil, err
+	}
+
+	for {
+		f, err := g.tryAcquire()
+		if err != nil {
+			return nil, err
+		}
+		if f != nil {
+			// Closing the file drops the lock.
+			return func() { f.Close() }, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(g.pollInterval):
+		}
+	}
+}
+
+// acquireBootSlot waits for the sandbox to be allowed to boot, and returns
+// the function to call once the heavyweight phases are over.
+func (s *Sandbox) acquireBootSlot(ctx context.Context) (func(), error) {
+	if s.config.BootConcurrency == 0 {
+		return func() {}, nil
+	}
+
+	g := &bootGovernor{
+		dir:          filepath.Join(filepath.Dir(s.store.RunStoragePath()), bootSlotsDir),
+		pollInterval: bootSlotPollInterval,
+		slots:        s.config.BootConcurrency,
+	}
+
+	start := time.Now()
+	release, err := g.acquire(ctx)
+	wait := time.Since(start)
+	bootQueueWaitHistogram.Observe(float64(wait.Milliseconds()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for a sandbox boot slot: %v", err)
+	}
+
+	s.Logger().WithField("wait", wait).Debug("sandbox boot slot acquired")
+	return release, nil
+}
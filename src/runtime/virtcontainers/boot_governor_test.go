@@ -0,0 +1,52 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBootGovernor(t *testing.T) {
+	assert := assert.New(t)
+
+	g := &bootGovernor{
+		dir:          t.TempDir(),
+		pollInterval: time.Millisecond,
+		slots:        2,
+	}
+
+	release1, err := g.acquire(context.Background())
+	assert.NoError(err)
+	release2, err := g.acquire(context.Background())
+	assert.NoError(err)
+
+	// All the slots are held, the next sandbox waits.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = g.acquire(ctx)
+	assert.Equal(context.DeadlineExceeded, err)
+
+	acquired := make(chan struct{})
+	go func() {
+		release, err := g.acquire(context.Background())
+		assert.NoError(err)
+		release()
+		close(acquired)
+	}()
+
+	release2()
+	select {
+	case <-acquired:
+	case <-time.After(5 * time.Second):
+		t.Fatal("boot slot not acquired once released")
+	}
+
+	release1()
+}
@@ -0,0 +1,219 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	exp "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/experimental"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+)
+
+const (
+	// containerCheckpointFeature enables to checkpoint the containers with
+	// CRIU inside the guest, and to restore them in another sandbox.
+	containerCheckpointFeature = "container_checkpoint"
+
+	// checkpointGuestDir holds the image directories of the containers
+	// inside the guest.
+	checkpointGuestDir = "/run/kata-containers/checkpoints"
+
+	// checkpointOptionsFile records the options of a checkpoint next to its
+	// images, CRIU needs the same ones to restore it.
+	checkpointOptionsFile = "kata-checkpoint.json"
+)
+
+func init() {
+	if err := exp.Register(exp.Feature{
+		Name:        containerCheckpointFeature,
+		Description: "Checkpoint and restore the containers with CRIU inside the guest",
+		ExpRelease:  "4.0.0",
+	}); err != nil {
+		panic(err)
+	}
+}
+
+// CheckpointOptions are the options of a container checkpoint.
+type CheckpointOptions struct {
+	// LeaveRunning keeps the container running once checkpointed.
+	LeaveRunning bool `json:"-"`
+
+	// TCPEstablished checkpoints the established TCP connections.
+	TCPEstablished bool `json:"tcp_established"`
+
+	// FileLocks checkpoints the file locks.
+	FileLocks bool `json:"file_locks"`
+
+	// ExtUnixSockets checkpoints the connections to external unix sockets.
+	ExtUnixSockets bool `json:"ext_unix_sockets"`
+}
+
+// experimentalEnabled returns whether the experimental feature name is
+// enabled for the sandbox.
+func (s *Sandbox) experimentalEnabled(name string) bool {
+	for _, f := range s.config.Experimental {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckpointContainer checkpoints the processes of a running container and
+// writes their images in imageDir. The container exits once checkpointed
+// unless opts.LeaveRunning is set.
+func (s *Sandbox) CheckpointContainer(ctx context.Context, containerID, imageDir string, opts CheckpointOptions) error {
+	if !s.experimentalEnabled(containerCheckpointFeature) {
+		return fmt.Errorf("experimental feature %q is not enabled", containerCheckpointFeature)
+	}
+
+	c, err := s.findContainer(containerID)
+	if err != nil {
+		return err
+	}
+
+	if c.state.State != types.StateRunning {
+		return fmt.Errorf("Container not running, impossible to checkpoint")
+	}
+
+	guestDir := filepath.Join(checkpointGuestDir, c.id)
+	files, err := s.agent.checkpointContainer(ctx, c, guestDir, opts)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(imageDir, DirMode); err != nil {
+		return err
+	}
+
+	for _, name := range files {
+		if filepath.Base(name) != name || name == checkpointOptionsFile {
+			return fmt.Errorf("invalid checkpoint image file %q", name)
+		}
+		if err := s.fetchGuestFile(ctx, filepath.Join(guestDir, name), filepath.Join(imageDir, name)); err != nil {
+			return fmt.Errorf("fetch checkpoint image file %s: %w", name, err)
+		}
+	}
+
+	data, err := json.Marshal(opts)
+	if err != nil {
+		return err
+	}
+
+	s.Logger().WithField("container", containerID).Info("Container is checkpointed")
+
+	return os.WriteFile(filepath.Join(imageDir, checkpointOptionsFile), data, 0600)
+}
+
+// fetchGuestFile copies the file at guestPath inside the guest to hostPath.
+func (s *Sandbox) fetchGuestFile(ctx context.Context, guestPath, hostPath string) error {
+	f, err := os.OpenFile(hostPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var offset uint64
+	for {
+		data, err := s.agent.readFile(ctx, guestPath, offset, uint64(grpcMaxDataSize))
+		if err != nil {
+			return err
+		}
+
+		if _, err := f.Write(data); err != nil {
+			return err
+		}
+
+		if int64(len(data)) < grpcMaxDataSize {
+			return nil
+		}
+		offset += uint64(len(data))
+	}
+}
+
+// RestoreContainer starts a created container by restoring the processes
+// checkpointed in imageDir in place of its own.
+func (s *Sandbox) RestoreContainer(ctx context.Context, containerID, imageDir string) (VCContainer, error) {
+	if !s.experimentalEnabled(containerCheckpointFeature) {
+		return nil, fmt.Errorf("experimental feature %q is not enabled", containerCheckpointFeature)
+	}
+
+	c, err := s.findContainer(containerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts CheckpointOptions
+	data, err := os.ReadFile(filepath.Join(imageDir, checkpointOptionsFile))
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &opts); err != nil {
+		return nil, err
+	}
+
+	if err = c.restore(ctx, imageDir, opts); err != nil {
+		return nil, err
+	}
+
+	if err = s.storeSandbox(ctx); err != nil {
+		return nil, err
+	}
+
+	s.Logger().WithField("container", containerID).Info("Container is restored")
+
+	if err = s.updateResources(ctx); err != nil {
+		return nil, err
+	}
+
+	if err = s.checkVCPUsPinning(ctx); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// restore copies the checkpoint images of imageDir into the guest and
+// restores them as the processes of the container.
+func (c *Container) restore(ctx context.Context, imageDir string, opts CheckpointOptions) error {
+	if err := c.checkSandboxRunning("restore"); err != nil {
+		return err
+	}
+
+	if c.state.State != types.StateReady {
+		return fmt.Errorf("Container not ready, impossible to restore")
+	}
+
+	entries, err := os.ReadDir(imageDir)
+	if err != nil {
+		return err
+	}
+
+	guestDir := filepath.Join(checkpointGuestDir, c.id)
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || entry.Name() == checkpointOptionsFile {
+			continue
+		}
+		if err := c.sandbox.agent.copyFile(ctx, filepath.Join(imageDir, entry.Name()), filepath.Join(guestDir, entry.Name())); err != nil {
+			return err
+		}
+	}
+
+	if err := c.sandbox.agent.restoreContainer(ctx, c, guestDir, opts); err != nil {
+		c.Logger().WithError(err).Error("Failed to restore container")
+
+		if err := c.stop(ctx, true); err != nil {
+			c.Logger().WithError(err).Warn("Failed to stop container")
+		}
+		return err
+	}
+
+	return c.setContainerState(types.StateRunning)
+}
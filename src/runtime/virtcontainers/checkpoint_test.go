@@ -0,0 +1,115 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	exp "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/experimental"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// criuAgent checkpoints and restores the containers of a fake guest.
+type criuAgent struct {
+	mockAgent
+	files    map[string][]byte
+	restored string
+	opts     CheckpointOptions
+}
+
+func (a *criuAgent) checkpointContainer(ctx context.Context, c *Container, imagePath string, opts CheckpointOptions) ([]string, error) {
+	a.opts = opts
+	a.files[filepath.Join(imagePath, "core-1.img")] = bytes.Repeat([]byte{0xc0}, int(grpcMaxDataSize)+10)
+	a.files[filepath.Join(imagePath, "dump.log")] = []byte("Dumping finished successfully\n")
+	return []string{"core-1.img", "dump.log"}, nil
+}
+
+func (a *criuAgent) readFile(ctx context.Context, path string, offset, maxSize uint64) ([]byte, error) {
+	data, ok := a.files[path]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	data = data[offset:]
+	if uint64(len(data)) > maxSize {
+		data = data[:maxSize]
+	}
+	return data, nil
+}
+
+func (a *criuAgent) copyFile(ctx context.Context, src, dst string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	a.files[dst] = data
+	return nil
+}
+
+func (a *criuAgent) restoreContainer(ctx context.Context, c *Container, imagePath string, opts CheckpointOptions) error {
+	a.restored = imagePath
+	a.opts = opts
+	return nil
+}
+
+func TestCheckpointRestoreContainer(t *testing.T) {
+	assert := assert.New(t)
+
+	contID := "checkpoint"
+	s, err := testCreateSandbox(t, testSandboxID, MockHypervisor, newHypervisorConfig(nil, nil), NetworkConfig{}, []ContainerConfig{newTestContainerConfigNoop(contID)}, nil)
+	assert.NoError(err)
+	defer cleanUp()
+
+	agent := &criuAgent{files: make(map[string][]byte)}
+	s.agent = agent
+	s.state.State = types.StateRunning
+
+	c, err := s.findContainer(contID)
+	assert.NoError(err)
+
+	imageDir := t.TempDir()
+	opts := CheckpointOptions{TCPEstablished: true, FileLocks: true}
+
+	// The feature is experimental.
+	err = s.CheckpointContainer(context.Background(), contID, imageDir, opts)
+	assert.Error(err)
+	s.config.Experimental = []exp.Feature{*exp.Get(containerCheckpointFeature)}
+
+	// Only the running containers are checkpointed.
+	err = s.CheckpointContainer(context.Background(), contID, imageDir, opts)
+	assert.Error(err)
+
+	c.state.State = types.StateRunning
+	err = s.CheckpointContainer(context.Background(), contID, imageDir, opts)
+	assert.NoError(err)
+	assert.Equal(opts, agent.opts)
+
+	guestDir := filepath.Join(checkpointGuestDir, contID)
+	for _, name := range []string{"core-1.img", "dump.log"} {
+		data, err := os.ReadFile(filepath.Join(imageDir, name))
+		assert.NoError(err)
+		assert.Equal(agent.files[filepath.Join(guestDir, name)], data)
+	}
+
+	// Only the created containers are restored.
+	_, err = s.RestoreContainer(context.Background(), contID, imageDir)
+	assert.Error(err)
+
+	agent.files = make(map[string][]byte)
+	agent.opts = CheckpointOptions{}
+	c.state.State = types.StateReady
+	_, err = s.RestoreContainer(context.Background(), contID, imageDir)
+	assert.NoError(err)
+	assert.Equal(types.StateRunning, c.state.State)
+	assert.Equal(guestDir, agent.restored)
+	assert.Equal(opts, agent.opts)
+	assert.Len(agent.files, 2)
+	assert.NotContains(agent.files, filepath.Join(guestDir, checkpointOptionsFile))
+}
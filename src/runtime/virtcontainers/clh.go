@@ -345,6 +345,7 @@ func (clh *cloudHypervisor) createVirtiofsDaemon(sharedPath string) (VirtiofsDae
 		socketPath: virtiofsdSocketPath,
 		extraArgs:  clh.config.VirtioFSExtraArgs,
 		cache:      clh.config.VirtioFSCache,
+		submounts:  clh.config.VirtioFSSubmounts,
 	}, nil
 }
 
@@ -502,6 +503,20 @@ func (clh *cloudHypervisor) CreateVM(ctx context.Context, id string, network Net
 	clh.vmconfig.Memory.Shared = func(b bool) *bool { return &b }(true)
 	// Enable hugepages if needed
 	clh.vmconfig.Memory.Hugepages = func(b bool) *bool { return &b }(clh.config.HugePages)
+	// Let the guest report freed pages so they are released back to the host
+	if clh.config.ReclaimGuestFreedMemory {
+		clh.vmconfig.Balloon = chclient.NewBalloonConfig(0)
+		clh.vmconfig.Balloon.SetDeflateOnOom(true)
+		clh.vmconfig.Balloon.SetFreePageReporting(true)
+	}
+	// Cloud Hypervisor does not mark guest memory as mergeable by default,
+	// only do it when the operator opts in and the sandbox allows it
+	if clh.config.MemMerge == MemMergeOn && clh.config.MemoryMergeable() {
+		clh.vmconfig.Memory.Mergeable = func(b bool) *bool { return &b }(true)
+	}
+	if clh.config.MemoryTHP != "" {
+		clh.vmconfig.Memory.Thp = func(b bool) *bool { return &b }(clh.config.MemoryTHP != MemoryTHPNever)
+	}
 	if !clh.config.ConfidentialGuest {
 		hotplugSize := clh.config.DefaultMaxMemorySize
 		// OpenAPI only supports int64 values
@@ -982,6 +997,10 @@ func (clh *cloudHypervisor) ResizeMemory(ctx context.Context, reqMemMB uint32, m
 
 	// HotplugSize can be nil in cases where Hotplug is not supported, as Cloud Hypervisor API
 	// does *not* allow us to set 0 as the HotplugSize.
+	// The balloon only depends on the memory requested, not on how much of
+	// it can be hotplugged
+	requestedMem := utils.MemUnit(reqMemMB) * utils.MiB
+
 	maxHotplugSize := 0 * utils.Byte
 	if info.Config.Memory.HotplugSize != nil {
 		maxHotplugSize = utils.MemUnit(*info.Config.Memory.HotplugSize) * utils.Byte
@@ -994,6 +1013,13 @@ func (clh *cloudHypervisor) ResizeMemory(ctx context.Context, reqMemMB uint32, m
 	currentMem := utils.MemUnit(info.Config.Memory.Size) * utils.Byte
 	newMem := utils.MemUnit(reqMemMB) * utils.MiB
 
+	// The guest is allowed all of its memory, make sure the balloon is deflated
+	if clh.config.ReclaimGuestFreedMemory && currentMem <= requestedMem {
+		if err := clh.resizeBalloon(ctx, 0); err != nil {
+			return uint32(currentMem.ToMiB()), MemoryDevice{}, err
+		}
+	}
+
 	// Early Check to verify if boot memory is the same as requested
 	if currentMem == newMem {
 		clh.Logger().WithField("memory", reqMemMB).Debugf("VM already has requested memory")
@@ -1001,6 +1027,14 @@ func (clh *cloudHypervisor) ResizeMemory(ctx context.Context, reqMemMB uint32, m
 	}
 
 	if currentMem > newMem {
+		if clh.config.ReclaimGuestFreedMemory && currentMem > requestedMem {
+			// Memory cannot be removed, inflate the balloon instead so that
+			// the guest stays within the sandbox memory limit.
+			if err := clh.resizeBalloon(ctx, currentMem-requestedMem); err != nil {
+				return uint32(currentMem.ToMiB()), MemoryDevice{}, err
+			}
+			return uint32(currentMem.ToMiB()), MemoryDevice{}, nil
+		}
 		clh.Logger().Warn("Remove memory is not supported, nothing to do")
 		return uint32(currentMem.ToMiB()), MemoryDevice{}, nil
 	}
@@ -1039,6 +1073,23 @@ func (clh *cloudHypervisor) ResizeMemory(ctx context.Context, reqMemMB uint32, m
 	return uint32(newMem.ToMiB()), MemoryDevice{SizeMB: int(hotplugSize.ToMiB())}, nil
 }
 
+// resizeBalloon sets the amount of guest memory held by the balloon.
+func (clh *cloudHypervisor) resizeBalloon(ctx context.Context, size utils.MemUnit) error {
+	cl := clh.client()
+	ctx, cancelResize := context.WithTimeout(ctx, clh.getClhAPITimeout()*time.Second)
+	defer cancelResize()
+
+	resize := *chclient.NewVmResize()
+	// OpenApi does not support uint64, convert to int64
+	resize.DesiredBalloon = func(i int64) *int64 { return &i }(int64(size.ToBytes()))
+	clh.Logger().WithField("balloon-size", size).Debug("updating VM memory balloon")
+	if _, err := cl.VmResizePut(ctx, resize); err != nil {
+		return fmt.Errorf("Failed to resize balloon to %d: %s", size, openAPIClientError(err))
+	}
+
+	return nil
+}
+
 func (clh *cloudHypervisor) ResizeVCPUs(ctx context.Context, reqVCPUs uint32) (currentVCPUs uint32, newVCPUs uint32, err error) {
 	cl := clh.client()
 
@@ -1210,6 +1261,7 @@ func (clh *cloudHypervisor) Capabilities(ctx context.Context) types.Capabilities
 		caps.SetFsSharingSupport()
 	}
 	caps.SetBlockDeviceHotplugSupport()
+	caps.SetNetworkDeviceHotplugSupport()
 	return caps
 }
 
@@ -1479,10 +1531,74 @@ func (clh *cloudHypervisor) vmAddNetPut() error {
 	return vmAddNetPutRequest(clh)
 }
 
+// applyVMConfigPatch applies a JSON merge patch (RFC 7386) to the VM config.
+// Fields the API client does not know about are rejected rather than dropped.
+func applyVMConfigPatch(vmconfig chclient.VmConfig, patch string) (chclient.VmConfig, error) {
+	var doc, p interface{}
+
+	body, err := json.Marshal(vmconfig)
+	if err != nil {
+		return vmconfig, err
+	}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return vmconfig, err
+	}
+	if err := json.Unmarshal([]byte(patch), &p); err != nil {
+		return vmconfig, fmt.Errorf("invalid extra API fields: %v", err)
+	}
+
+	merged, err := json.Marshal(mergeJSONPatch(doc, p))
+	if err != nil {
+		return vmconfig, err
+	}
+
+	var patched chclient.VmConfig
+	dec := json.NewDecoder(bytes.NewReader(merged))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&patched); err != nil {
+		return vmconfig, fmt.Errorf("invalid extra API fields: %v", err)
+	}
+
+	return patched, nil
+}
+
+// mergeJSONPatch merges patch into target, a null value in the patch
+// removes the field from the target.
+func mergeJSONPatch(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = map[string]interface{}{}
+	}
+
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+		} else {
+			t[k] = mergeJSONPatch(t[k], v)
+		}
+	}
+
+	return t
+}
+
 func (clh *cloudHypervisor) bootVM(ctx context.Context) error {
 
 	cl := clh.client()
 
+	if clh.config.ExtraAPIFields != "" {
+		clh.Logger().WithField("extra-api-fields", clh.config.ExtraAPIFields).Warn("Applying extra fields to the VM config")
+		vmconfig, err := applyVMConfigPatch(clh.vmconfig, clh.config.ExtraAPIFields)
+		if err != nil {
+			return err
+		}
+		clh.vmconfig = vmconfig
+	}
+
 	if clh.config.Debug {
 		bodyBuf, err := json.Marshal(clh.vmconfig)
 		if err != nil {
@@ -1755,3 +1871,9 @@ func (clh *cloudHypervisor) vmInfo() (chclient.VmInfo, error) {
 func (clh *cloudHypervisor) IsRateLimiterBuiltin() bool {
 	return true
 }
+
+func (clh *cloudHypervisor) FlushDrives(ctx context.Context, driveIDs []string) error {
+	// The drives do not have a write-back cache of their own, the
+	// flushes of the guest reach the backing storage.
+	return nil
+}
@@ -72,7 +72,8 @@ func newClhConfig() (HypervisorConfig, error) {
 }
 
 type clhClientMock struct {
-	vmInfo chclient.VmInfo
+	vmInfo   chclient.VmInfo
+	vmResize []chclient.VmResize
 }
 
 func (c *clhClientMock) VmmPingGet(ctx context.Context) (chclient.VmmPingResponse, *http.Response, error) {
@@ -100,6 +101,7 @@ func (c *clhClientMock) BootVM(ctx context.Context) (*http.Response, error) {
 
 //nolint:golint
 func (c *clhClientMock) VmResizePut(ctx context.Context, vmResize chclient.VmResize) (*http.Response, error) {
+	c.vmResize = append(c.vmResize, vmResize)
 	return nil, nil
 }
 
@@ -514,6 +516,41 @@ func TestClhCreateVM(t *testing.T) {
 	}
 }
 
+func TestClhCreateVMMemoryMergeable(t *testing.T) {
+	assert := assert.New(t)
+
+	store, err := persist.GetDriver()
+	assert.NoError(err)
+
+	network, err := NewNetwork()
+	assert.NoError(err)
+
+	for _, d := range []struct {
+		memMerge  string
+		mergeable bool
+	}{
+		{"", false},
+		{MemMergeOn, true},
+		{MemMergeOff, false},
+	} {
+		config, err := newClhConfig()
+		assert.NoError(err)
+		config.MemMerge = d.memMerge
+
+		clh := &cloudHypervisor{
+			config: HypervisorConfig{
+				VMStorePath:  store.RunVMStoragePath(),
+				RunStorePath: store.RunStoragePath(),
+			},
+		}
+		assert.NoError(clh.CreateVM(context.Background(), "testSandbox", network, &config))
+
+		// Cloud Hypervisor keeps guest memory unmergeable when unset
+		mergeable, ok := clh.vmconfig.Memory.GetMergeableOk()
+		assert.Equal(d.mergeable, ok && *mergeable, "%+v", d)
+	}
+}
+
 func TestCloudHypervisorStartSandbox(t *testing.T) {
 	assert := assert.New(t)
 	clhConfig, err := newClhConfig()
@@ -636,6 +673,39 @@ func TestCloudHypervisorResizeMemory(t *testing.T) {
 	}
 }
 
+func TestCloudHypervisorResizeMemoryBalloon(t *testing.T) {
+	assert := assert.New(t)
+	clhConfig, err := newClhConfig()
+	assert.NoError(err)
+	clhConfig.ReclaimGuestFreedMemory = true
+
+	mockClient := &clhClientMock{}
+	mockClient.vmInfo.Config = *chclient.NewVmConfig(*chclient.NewPayloadConfig())
+	// Without hotplug, the memory requested is still what the guest is
+	// allowed
+	mockClient.vmInfo.Config.Memory = chclient.NewMemoryConfig(int64(utils.MemUnit(clhConfig.MemorySize) * utils.MiB))
+
+	clh := cloudHypervisor{
+		APIClient: mockClient,
+		config:    clhConfig,
+	}
+
+	// Memory cannot be removed, the balloon holds what exceeds the limit
+	newMem, memDev, err := clh.ResizeMemory(context.Background(), clhConfig.MemorySize-512, 128, false)
+	assert.NoError(err)
+	assert.Equal(clhConfig.MemorySize, newMem)
+	assert.Equal(MemoryDevice{}, memDev)
+	assert.Len(mockClient.vmResize, 1)
+	assert.Equal(int64(512*utils.MiB.ToBytes()), mockClient.vmResize[0].GetDesiredBalloon())
+
+	// The balloon is deflated once the guest is allowed all of its memory
+	newMem, _, err = clh.ResizeMemory(context.Background(), clhConfig.MemorySize, 128, false)
+	assert.NoError(err)
+	assert.Equal(clhConfig.MemorySize, newMem)
+	assert.Len(mockClient.vmResize, 2)
+	assert.Equal(int64(0), mockClient.vmResize[1].GetDesiredBalloon())
+}
+
 func TestCloudHypervisorHotplugAddBlockDevice(t *testing.T) {
 	assert := assert.New(t)
 
@@ -753,3 +823,30 @@ func TestClhCapabilities(t *testing.T) {
 	c = clh.Capabilities(ctx)
 	assert.False(c.IsFsSharingSupported())
 }
+
+func TestClhApplyVMConfigPatch(t *testing.T) {
+	assert := assert.New(t)
+
+	kvmHyperv := true
+	vmconfig := *chclient.NewVmConfig(*chclient.NewPayloadConfig())
+	vmconfig.Cpus = chclient.NewCpusConfig(1, 4)
+	vmconfig.Cpus.KvmHyperv = &kvmHyperv
+
+	patched, err := applyVMConfigPatch(vmconfig, `{"cpus": {"max_phys_bits": 40, "kvm_hyperv": null}, "watchdog": true}`)
+	assert.NoError(err)
+	assert.Equal(int32(1), patched.Cpus.BootVcpus)
+	assert.Equal(int32(4), patched.Cpus.MaxVcpus)
+	assert.Equal(int32(40), *patched.Cpus.MaxPhysBits)
+	assert.Nil(patched.Cpus.KvmHyperv)
+	assert.True(*patched.Watchdog)
+
+	// the original config is left untouched
+	assert.Nil(vmconfig.Cpus.MaxPhysBits)
+	assert.False(*vmconfig.Watchdog)
+
+	_, err = applyVMConfigPatch(vmconfig, `{"unknown_field": true}`)
+	assert.Error(err)
+
+	_, err = applyVMConfigPatch(vmconfig, `not json`)
+	assert.Error(err)
+}
@@ -16,6 +16,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/blockid"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/config"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/manager"
 	volume "github.com/kata-containers/kata-containers/src/runtime/pkg/direct-volume"
@@ -60,6 +61,14 @@ var cdromMajors = map[int64]string{
 // #define FLOPPY_MAJOR		2
 const floppyMajor = int64(2)
 
+// blockIDResolveTimeout is how long the block device of a volume given by a
+// persistent identifier is waited for, when it is not there yet.
+const blockIDResolveTimeout = 30 * time.Second
+
+// storageConnectTimeout is how long the device of a volume on remote storage
+// is waited for, once the session with the storage is established.
+const storageConnectTimeout = 60 * time.Second
+
 // Process gathers data related to a container process.
 type Process struct {
 	StartTime time.Time
@@ -333,6 +342,10 @@ type Container struct {
 
 	devices []ContainerDevice
 
+	// emulatedDevices are the container paths of the devices
+	// provided by the guest rather than attached from the host.
+	emulatedDevices []string
+
 	state types.ContainerState
 
 	process Process
@@ -340,6 +353,10 @@ type Container struct {
 	rootFs RootFs
 
 	systemMountsInfo SystemMountsInfo
+
+	// outputTail keeps the tail of the container output for the forensic
+	// snapshots.
+	outputTail *tailBuffer
 }
 
 // ID returns the container identifier string.
@@ -472,6 +489,21 @@ func (c *Container) mountSharedDirMounts(ctx context.Context, sharedDirMounts, i
 			continue
 		}
 
+		guestPath, err := c.sandbox.shareNameResolutionFile(ctx, m)
+		if err != nil {
+			return storages, err
+		}
+		if guestPath != "" {
+			sharedDirMounts[m.Destination] = Mount{
+				Source:      guestPath,
+				Destination: m.Destination,
+				Type:        m.Type,
+				Options:     m.Options,
+				ReadOnly:    m.ReadOnly,
+			}
+			continue
+		}
+
 		sharedFile, err := c.sandbox.fsShare.ShareFile(ctx, c, &c.mounts[idx])
 		if err != nil {
 			return storages, err
@@ -526,6 +558,36 @@ func (c *Container) mountSharedDirMounts(ctx context.Context, sharedDirMounts, i
 			sharedDirMount.Source = watchableGuestMount
 		}
 
+		// The containers using a volume with a shared propagation share its
+		// mount in the guest: the agent mounts it once with that propagation
+		// in the sandbox directory, then the containers bind mount it from
+		// there, so that the mounts created below it by one of them are
+		// seen by the others.
+		if mountPropagation(m.Options) == "rshared" && caps.IsFsSharingSupported() {
+			driver := kata9pDevType
+			if sharedFS := c.sandbox.config.HypervisorConfig.SharedFS; sharedFS == config.VirtioFS || sharedFS == config.VirtioFSNydus {
+				driver = kataVirtioFSDevType
+			}
+
+			propagatedGuestMount := filepath.Join(kataGuestSandboxDir(), "propagated", filepath.Base(sharedFile.guestPath))
+
+			options := []string{"rbind", "rshared"}
+			if m.ReadOnly {
+				options = append(options, "ro")
+			}
+
+			storage := &grpc.Storage{
+				Driver:     driver,
+				Source:     sharedFile.guestPath,
+				Fstype:     "bind",
+				MountPoint: propagatedGuestMount,
+				Options:    options,
+			}
+			storages = append(storages, storage)
+
+			sharedDirMount.Source = propagatedGuestMount
+		}
+
 		sharedDirMounts[sharedDirMount.Destination] = sharedDirMount
 	}
 
@@ -557,7 +619,9 @@ func (c *Container) unmountHostMounts(ctx context.Context) error {
 	}
 
 	for _, m := range c.mounts {
-		if m.HostPath != "" {
+		// The volumes with a shared propagation are unshared by the last
+		// container using them.
+		if m.HostPath != "" && !c.sandbox.isHostPathShared(c, m.HostPath) {
 			if err := unmountFunc(m); err != nil {
 				return err
 			}
@@ -621,8 +685,9 @@ func (c *Container) createBlockDevices(ctx context.Context) error {
 		}
 
 		if mntInfo != nil {
+			volumePath := c.mounts[i].Source
 			// Write out sandbox info file on the mount source to allow CSI to communicate with the runtime
-			if err := volume.RecordSandboxId(c.sandboxID, c.mounts[i].Source); err != nil {
+			if err := volume.RecordSandboxId(c.sandboxID, volumePath); err != nil {
 				c.Logger().WithError(err).Error("error writing sandbox info")
 			}
 
@@ -658,6 +723,70 @@ func (c *Container) createBlockDevices(ctx context.Context) error {
 					c.Logger().Warnf("Ignoring unsupported direct-assignd volume metadata key: %s, value: %s", key, value)
 				}
 			}
+
+			// A NFS export is mounted by the agent, there is no device
+			// on the host.
+			if mntInfo.VolumeType == volume.NfsVolumeType {
+				if c.mounts[i].Type == "" {
+					c.mounts[i].Type = volume.NfsVolumeType
+				}
+				continue
+			}
+
+			// The device of a volume on remote storage appears once
+			// the host is connected to the storage.
+			if mntInfo.Connector != nil {
+				devPath, err := mntInfo.Connector.Connect(storageConnectTimeout)
+				if err != nil {
+					return fmt.Errorf("failed to connect the storage of mount %s: %v", c.mounts[i].Destination, err)
+				}
+				c.Logger().WithField("session", mntInfo.Connector.Session()).WithField("device", devPath).
+					Info("remote storage connected")
+				// What was opened for the session is torn down when
+				// the volume is removed, the rest is left alone.
+				if err := volume.RecordConnector(volumePath, mntInfo.Connector); err != nil {
+					return fmt.Errorf("failed to record the storage session of mount %s: %v", c.mounts[i].Destination, err)
+				}
+				c.mounts[i].Source = devPath
+			}
+		}
+
+		if mntInfo == nil && c.sandbox.config.isImageVolume(c.mounts[i].Source) {
+			if err := c.attachImageVolume(&c.mounts[i]); err != nil {
+				return fmt.Errorf("failed to attach the image volume %s: %v", c.mounts[i].Destination, err)
+			}
+		}
+
+		// The device may be given by a persistent identifier, which is
+		// resolved to its device node of the moment.
+		if id, ok, err := blockid.Parse(c.mounts[i].Source); ok {
+			if err != nil {
+				return err
+			}
+			devPath, err := blockid.Resolve(id, blockIDResolveTimeout)
+			if err != nil {
+				return fmt.Errorf("failed to resolve the device of mount %s: %v", c.mounts[i].Destination, err)
+			}
+			c.Logger().WithField("id", id).WithField("device", devPath).Info("block device resolved")
+			c.mounts[i].Source = devPath
+		}
+
+		// A volume on persistent memory is mapped as a NVDIMM rather
+		// than attached as a block device.
+		if mntInfo != nil && mntInfo.VolumeType == volume.PmemVolumeType {
+			if c.sandbox.config.HypervisorConfig.PmemVolumesSize == 0 {
+				return fmt.Errorf("pmem volume %s needs pmem_volumes_size in the hypervisor configuration", c.mounts[i].Destination)
+			}
+			di, err := config.PmemVolumeDeviceInfo(c.mounts[i].Source, c.mounts[i].Destination, c.mounts[i].Type, c.mounts[i].ReadOnly)
+			if err != nil {
+				return fmt.Errorf("invalid pmem volume %s: %v", c.mounts[i].Destination, err)
+			}
+			b, err := c.sandbox.devManager.NewDevice(*di)
+			if err != nil {
+				return err
+			}
+			c.mounts[i].BlockDeviceID = b.DeviceID()
+			continue
 		}
 
 		var stat unix.Stat_t
@@ -671,12 +800,19 @@ func (c *Container) createBlockDevices(ctx context.Context) error {
 		// Check if mount is a block device file. If it is, the block device will be attached to the host
 		// instead of passing this as a shared mount.
 		if stat.Mode&unix.S_IFBLK == unix.S_IFBLK {
+			source, major, minor, err := c.sandbox.multipathSubstitute(c.mounts[i].Source,
+				int64(unix.Major(uint64(stat.Rdev))), int64(unix.Minor(uint64(stat.Rdev))))
+			if err != nil {
+				return fmt.Errorf("no multipath map device for mount %s: %v", c.mounts[i].Destination, err)
+			}
+			c.mounts[i].Source = source
+
 			di = &config.DeviceInfo{
 				HostPath:      c.mounts[i].Source,
 				ContainerPath: c.mounts[i].Destination,
 				DevType:       "b",
-				Major:         int64(unix.Major(uint64(stat.Rdev))),
-				Minor:         int64(unix.Minor(uint64(stat.Rdev))),
+				Major:         major,
+				Minor:         minor,
 				ReadOnly:      c.mounts[i].ReadOnly,
 			}
 			// Check whether source can be used as a pmem device
@@ -794,6 +930,26 @@ func (c *Container) createDevices(contConfig *ContainerConfig) error {
 	// from the configuration. This should happen at create.
 	var storedDevices []ContainerDevice
 	for _, info := range contConfig.DeviceInfos {
+		switch c.sandbox.config.hostDevicePolicy(info.ContainerPath) {
+		case config.HostDeviceReject:
+			return fmt.Errorf("device %s is rejected by the host device policy", info.ContainerPath)
+		case config.HostDeviceGuestNode:
+			// The agent creates the node from the OCI spec major
+			// and minor numbers, there is nothing to attach.
+			continue
+		case config.HostDeviceEmulate:
+			c.emulatedDevices = append(c.emulatedDevices, info.ContainerPath)
+			continue
+		}
+
+		if info.DevType == "b" {
+			hostPath, major, minor, err := c.sandbox.multipathSubstitute(info.HostPath, info.Major, info.Minor)
+			if err != nil {
+				return fmt.Errorf("no multipath map device for device %s: %v", info.ContainerPath, err)
+			}
+			info.HostPath, info.Major, info.Minor = hostPath, major, minor
+		}
+
 		dev, err := c.sandbox.devManager.NewDevice(info)
 		if err != nil {
 			return err
@@ -862,6 +1018,14 @@ func (c *Container) create(ctx context.Context) (err error) {
 		}
 	}()
 
+	// The sandbox container of a pause-less sandbox is not created
+	// inside the guest.
+	if c.pauseless() {
+		c.process = Process{Token: c.id, StartTime: time.Now().UTC()}
+		return c.setContainerState(types.StateReady)
+	}
+
+	attachStart := time.Now()
 	if c.checkBlockDeviceSupport(ctx) && c.rootFs.Type != NydusRootFSType {
 		// If the rootfs is backed by a block device, go ahead and hotplug it to the guest
 		if err = c.hotplugDrive(ctx); err != nil {
@@ -869,6 +1033,10 @@ func (c *Container) create(ctx context.Context) (err error) {
 		}
 	}
 
+	if err = c.addCoreDumpMount(); err != nil {
+		return
+	}
+
 	c.Logger().WithFields(logrus.Fields{
 		"devices": c.devices,
 	}).Info("Attach devices")
@@ -876,6 +1044,10 @@ func (c *Container) create(ctx context.Context) (err error) {
 		return
 	}
 
+	if err = c.ackDevices(ctx, attachStart); err != nil {
+		return
+	}
+
 	// Deduce additional system mount info that should be handled by the agent
 	// inside the VM
 	c.getSystemMountInfo()
@@ -951,6 +1123,10 @@ func (c *Container) start(ctx context.Context) error {
 		return err
 	}
 
+	if c.pauseless() {
+		return c.setContainerState(types.StateRunning)
+	}
+
 	if err := c.sandbox.agent.startContainer(ctx, c.sandbox, c); err != nil {
 		c.Logger().WithError(err).Error("Failed to start container")
 
@@ -983,6 +1159,11 @@ func (c *Container) stop(ctx context.Context, force bool) error {
 		return err
 	}
 
+	if c.pauseless() {
+		c.sandbox.pauseless.signal(syscall.SIGKILL)
+		return c.setContainerState(types.StateStopped)
+	}
+
 	// Force the container to be killed. For most of the cases, this
 	// should not matter and it should return an error that will be
 	// ignored.
@@ -994,6 +1175,14 @@ func (c *Container) stop(ctx context.Context, force bool) error {
 	// get failed if the process hasn't exited.
 	c.sandbox.agent.waitProcess(ctx, c, c.id)
 
+	// Flush the data of the container through to its volumes while they
+	// are still mounted in the guest.
+	c.flush(ctx)
+
+	// Read back the termination message of the container, kubelet reading
+	// it once the exit of the container is reported.
+	c.propagateTerminationMessage(ctx)
+
 	defer func() {
 		// Save device and drive data.
 		// TODO: can we merge this saving with setContainerState()?
@@ -1053,6 +1242,10 @@ func (c *Container) enter(ctx context.Context, cmd types.Cmd) (*Process, error)
 			"impossible to enter")
 	}
 
+	if c.pauseless() {
+		return nil, fmt.Errorf("Container of a pause-less sandbox, impossible to enter")
+	}
+
 	process, err := c.sandbox.agent.exec(ctx, c.sandbox, *c, cmd)
 	if err != nil {
 		return nil, err
@@ -1068,6 +1261,10 @@ func (c *Container) wait(ctx context.Context, processID string) (int32, error) {
 			"impossible to wait")
 	}
 
+	if c.pauseless() {
+		return c.sandbox.pauseless.wait(), nil
+	}
+
 	return c.sandbox.agent.waitProcess(ctx, c, processID)
 }
 
@@ -1084,6 +1281,11 @@ func (c *Container) signalProcess(ctx context.Context, processID string, signal
 		return fmt.Errorf("Container not ready, running or paused, impossible to signal the container")
 	}
 
+	if c.pauseless() {
+		c.sandbox.pauseless.signal(signal)
+		return nil
+	}
+
 	// kill(2) method can return ESRCH in certain cases, which is not handled by containerd cri server in container_stop.go.
 	// CRIO server also doesn't handle ESRCH. So kata runtime will swallow it here.
 	var err error
@@ -1103,6 +1305,10 @@ func (c *Container) winsizeProcess(ctx context.Context, processID string, height
 		return fmt.Errorf("Container not ready or running, impossible to signal the container")
 	}
 
+	if c.pauseless() {
+		return nil
+	}
+
 	return c.sandbox.agent.winsizeProcess(ctx, c, processID, height, width)
 }
 
@@ -1112,6 +1318,12 @@ func (c *Container) ioStream(processID string) (io.WriteCloser, io.Reader, io.Re
 	}
 
 	stream := newIOStream(c.sandbox, c, processID)
+	if processID == c.id && c.sandbox.forensicsEnabled() {
+		if c.outputTail == nil {
+			c.outputTail = newTailBuffer(forensicLogSize)
+		}
+		stream.tail = c.outputTail
+	}
 
 	return stream.stdin(), stream.stdout(), stream.stderr(), nil
 }
@@ -1120,6 +1332,9 @@ func (c *Container) stats(ctx context.Context) (*ContainerStats, error) {
 	if err := c.checkSandboxRunning("stats"); err != nil {
 		return nil, err
 	}
+	if c.pauseless() {
+		return &ContainerStats{}, nil
+	}
 	return c.sandbox.agent.statsContainer(ctx, c.sandbox, *c)
 }
 
@@ -1159,16 +1374,16 @@ func (c *Container) update(ctx context.Context, resources specs.LinuxResources)
 		c.config.Resources.Memory.Limit = mem.Limit
 	}
 
+	if pids := resources.Pids; pids != nil {
+		c.config.Resources.Pids = &specs.LinuxPids{Limit: pids.Limit}
+	}
+
 	if err := c.sandbox.updateResources(ctx); err != nil {
 		return err
 	}
 
-	// There currently isn't a notion of cpusets.cpus or mems being tracked
-	// inside of the guest. Make sure we clear these before asking agent to update
-	// the container's cgroups.
-	if resources.CPU != nil {
-		resources.CPU.Mems = ""
-		resources.CPU.Cpus = ""
+	if c.pauseless() {
+		return nil
 	}
 
 	return c.sandbox.agent.updateContainer(ctx, c.sandbox, *c, resources)
@@ -1183,6 +1398,10 @@ func (c *Container) pause(ctx context.Context) error {
 		return fmt.Errorf("Container not running, impossible to pause")
 	}
 
+	if c.pauseless() {
+		return c.setContainerState(types.StatePaused)
+	}
+
 	if err := c.sandbox.agent.pauseContainer(ctx, c.sandbox, *c); err != nil {
 		return err
 	}
@@ -1199,6 +1418,10 @@ func (c *Container) resume(ctx context.Context) error {
 		return fmt.Errorf("Container not paused, impossible to resume")
 	}
 
+	if c.pauseless() {
+		return c.setContainerState(types.StateRunning)
+	}
+
 	if err := c.sandbox.agent.resumeContainer(ctx, c.sandbox, *c); err != nil {
 		return err
 	}
@@ -0,0 +1,234 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+const (
+	// coreDumpGuestDir is where the core dumps directory of the sandbox
+	// is mounted within the containers.
+	coreDumpGuestDir = "/run/kata-cores"
+
+	// coreDumpPattern names the core files after the executable, the
+	// pid and the time of the crash. As the pattern is not a pipe, the
+	// path is resolved within the mount namespace of the crashing process.
+	coreDumpPattern = coreDumpGuestDir + "/core.%e.%p.%t"
+
+	coreDumpRlimit = "RLIMIT_CORE"
+)
+
+// coreDumpPruneInterval is the interval between two prunings of the core
+// dumps directory while the sandbox runs, the cores keep coming between the
+// deletions of its containers. Overridden in tests.
+var coreDumpPruneInterval = time.Minute
+
+// CoreDumpConfig is the core dump policy of a sandbox.
+type CoreDumpConfig struct {
+	// HostDir is the host directory the core dumps are collected into,
+	// one sub directory per sandbox.
+	HostDir string
+
+	// MaxCoreSize is the size limit of a single core file, in bytes.
+	// No limit is applied when it is 0.
+	MaxCoreSize uint64
+
+	// MaxDirSize bounds the total size of HostDir, in bytes. The oldest
+	// core files are removed to stay within this limit. It is not bounded
+	// when it is 0.
+	MaxDirSize uint64
+
+	// Enabled enables the core dumps of the sandbox containers.
+	Enabled bool
+}
+
+// kernelParams returns the guest kernel parameters setting the core
+// pattern of the guest.
+func (c CoreDumpConfig) kernelParams() []Param {
+	if !c.Enabled {
+		return nil
+	}
+
+	return []Param{{Key: "sysctl.kernel.core_pattern", Value: coreDumpPattern}}
+}
+
+// coreDumpDir returns the host directory collecting the core dumps of the
+// sandbox.
+func (s *Sandbox) coreDumpDir() string {
+	return filepath.Join(s.config.CoreDump.HostDir, s.id)
+}
+
+// addCoreDumpMount makes the sandbox core dumps directory available to the
+// container and sets the core file size limit of its processes.
+func (c *Container) addCoreDumpMount() error {
+	coreDump := c.sandbox.config.CoreDump
+	if !coreDump.Enabled || c.config.CustomSpec == nil {
+		return nil
+	}
+
+	for _, m := range c.mounts {
+		if m.Destination == coreDumpGuestDir {
+			return nil
+		}
+	}
+
+	if err := os.MkdirAll(coreDump.HostDir, DirMode); err != nil {
+		return err
+	}
+
+	// The container processes may run as any user, the sticky bit keeps
+	// them from removing each other's files.
+	dir := c.sandbox.coreDumpDir()
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	if err := os.Chmod(dir, os.ModeSticky|0777); err != nil {
+		return err
+	}
+
+	options := []string{"rbind", "rw"}
+	c.mounts = append(c.mounts, Mount{
+		Source:      dir,
+		Destination: coreDumpGuestDir,
+		Type:        "bind",
+		Options:     options,
+	})
+
+	spec := c.config.CustomSpec
+	spec.Mounts = append(spec.Mounts, specs.Mount{
+		Source:      dir,
+		Destination: coreDumpGuestDir,
+		Type:        "bind",
+		Options:     options,
+	})
+
+	if spec.Process == nil {
+		return nil
+	}
+
+	limit := ^uint64(0)
+	if coreDump.MaxCoreSize > 0 {
+		limit = coreDump.MaxCoreSize
+	}
+
+	rlimits := []specs.POSIXRlimit{}
+	for _, r := range spec.Process.Rlimits {
+		if r.Type != coreDumpRlimit {
+			rlimits = append(rlimits, r)
+		}
+	}
+	spec.Process.Rlimits = append(rlimits, specs.POSIXRlimit{Type: coreDumpRlimit, Hard: limit, Soft: limit})
+
+	return nil
+}
+
+// pruneCoreDumps removes the oldest core files found under dir until their
+// total size is at most maxSize.
+func pruneCoreDumps(dir string, maxSize uint64) error {
+	if maxSize == 0 {
+		return nil
+	}
+
+	type coreFile struct {
+		info fs.FileInfo
+		path string
+	}
+
+	var (
+		files []coreFile
+		total uint64
+	)
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		files = append(files, coreFile{info: info, path: path})
+		total += uint64(info.Size())
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].info.ModTime().Before(files[j].info.ModTime())
+	})
+
+	for _, f := range files {
+		if total <= maxSize {
+			break
+		}
+		if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		total -= uint64(f.info.Size())
+	}
+
+	return nil
+}
+
+// pruneCoreDumps bounds the size of the core dumps directory.
+func (s *Sandbox) pruneCoreDumps() {
+	coreDump := s.config.CoreDump
+	if !coreDump.Enabled {
+		return
+	}
+
+	if err := pruneCoreDumps(coreDump.HostDir, coreDump.MaxDirSize); err != nil {
+		s.Logger().WithError(err).Warn("failed to prune core dumps")
+	}
+}
+
+// startCoreDumpPruner prunes the core dumps directory periodically until
+// the sandbox is deleted, when it is bounded.
+func (s *Sandbox) startCoreDumpPruner() {
+	coreDump := s.config.CoreDump
+	if !coreDump.Enabled || coreDump.MaxDirSize == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.coreDumpCancel = cancel
+
+	go func() {
+		ticker := time.NewTicker(coreDumpPruneInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.pruneCoreDumps()
+			}
+		}
+	}()
+}
+
+func (s *Sandbox) stopCoreDumpPruner() {
+	if s.coreDumpCancel != nil {
+		s.coreDumpCancel()
+	}
+}
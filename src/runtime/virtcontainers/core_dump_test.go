@@ -0,0 +1,136 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoreDumpKernelParams(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Empty(CoreDumpConfig{}.kernelParams())
+	assert.Equal([]Param{{Key: "sysctl.kernel.core_pattern", Value: "/run/kata-cores/core.%e.%p.%t"}},
+		CoreDumpConfig{Enabled: true}.kernelParams())
+}
+
+func TestAddCoreDumpMount(t *testing.T) {
+	assert := assert.New(t)
+	hostDir := filepath.Join(t.TempDir(), "cores")
+
+	s := &Sandbox{
+		id: "sandbox",
+		config: &SandboxConfig{
+			CoreDump: CoreDumpConfig{HostDir: hostDir, MaxCoreSize: 1 << 20},
+		},
+	}
+	c := &Container{
+		sandbox: s,
+		config: &ContainerConfig{
+			CustomSpec: &specs.Spec{
+				Process: &specs.Process{
+					Rlimits: []specs.POSIXRlimit{
+						{Type: "RLIMIT_NOFILE", Hard: 1024, Soft: 1024},
+						{Type: "RLIMIT_CORE", Hard: 0, Soft: 0},
+					},
+				},
+			},
+		},
+	}
+
+	// disabled
+	assert.NoError(c.addCoreDumpMount())
+	assert.Empty(c.mounts)
+	assert.Empty(c.config.CustomSpec.Mounts)
+
+	s.config.CoreDump.Enabled = true
+	assert.NoError(c.addCoreDumpMount())
+	// only mounted once
+	assert.NoError(c.addCoreDumpMount())
+
+	dir := filepath.Join(hostDir, "sandbox")
+	info, err := os.Stat(dir)
+	assert.NoError(err)
+	assert.Equal(os.ModeDir|os.ModeSticky|0777, info.Mode())
+
+	assert.Len(c.mounts, 1)
+	assert.Equal(dir, c.mounts[0].Source)
+	assert.Equal(coreDumpGuestDir, c.mounts[0].Destination)
+	assert.Len(c.config.CustomSpec.Mounts, 1)
+	assert.Equal(coreDumpGuestDir, c.config.CustomSpec.Mounts[0].Destination)
+
+	assert.Equal([]specs.POSIXRlimit{
+		{Type: "RLIMIT_NOFILE", Hard: 1024, Soft: 1024},
+		{Type: "RLIMIT_CORE", Hard: 1 << 20, Soft: 1 << 20},
+	}, c.config.CustomSpec.Process.Rlimits)
+}
+
+func TestPruneCoreDumps(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+
+	now := time.Now()
+	files := []string{"a/core.old", "b/core.mid", "a/core.new"}
+	for i, f := range files {
+		path := filepath.Join(dir, f)
+		assert.NoError(os.MkdirAll(filepath.Dir(path), 0700))
+		assert.NoError(os.WriteFile(path, make([]byte, 100), 0600))
+		mtime := now.Add(time.Duration(i-len(files)) * time.Minute)
+		assert.NoError(os.Chtimes(path, mtime, mtime))
+	}
+
+	// no limit
+	assert.NoError(pruneCoreDumps(dir, 0))
+	for _, f := range files {
+		assert.FileExists(filepath.Join(dir, f))
+	}
+
+	assert.NoError(pruneCoreDumps(dir, 250))
+	assert.NoFileExists(filepath.Join(dir, "a/core.old"))
+	assert.FileExists(filepath.Join(dir, "b/core.mid"))
+	assert.FileExists(filepath.Join(dir, "a/core.new"))
+
+	assert.NoError(pruneCoreDumps(dir, 100))
+	assert.NoFileExists(filepath.Join(dir, "b/core.mid"))
+	assert.FileExists(filepath.Join(dir, "a/core.new"))
+
+	// a missing directory has nothing to prune
+	assert.NoError(pruneCoreDumps(filepath.Join(dir, "missing"), 100))
+}
+
+func TestCoreDumpPruner(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+
+	coreDumpPruneInterval = 10 * time.Millisecond
+	defer func() {
+		coreDumpPruneInterval = time.Minute
+	}()
+
+	s := &Sandbox{
+		id: testSandboxID,
+		config: &SandboxConfig{
+			CoreDump: CoreDumpConfig{HostDir: dir, MaxDirSize: 100, Enabled: true},
+		},
+	}
+	s.startCoreDumpPruner()
+	defer s.stopCoreDumpPruner()
+
+	core := filepath.Join(dir, testSandboxID, "core.crash")
+	assert.NoError(os.MkdirAll(filepath.Dir(core), 0700))
+	assert.NoError(os.WriteFile(core, make([]byte, 200), 0600))
+
+	assert.Eventually(func() bool {
+		_, err := os.Stat(core)
+		return os.IsNotExist(err)
+	}, 5*time.Second, 10*time.Millisecond)
+}
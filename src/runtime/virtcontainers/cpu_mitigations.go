@@ -0,0 +1,147 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// The CPU vulnerability mitigation profiles of the guests.
+const (
+	// CPUMitigationsOff disables the mitigations of the guest kernel, for
+	// the trusted performance critical workloads, e.g. batch jobs.
+	CPUMitigationsOff = "off"
+
+	// CPUMitigationsAuto enables the mitigations of the guest kernel for
+	// the vulnerabilities of the CPU, keeping SMT.
+	CPUMitigationsAuto = "auto"
+
+	// CPUMitigationsFull also keeps the hypervisor from sharing the cores
+	// of the host with other tasks, for the multi-tenant nodes, see
+	// isolateVMMCores.
+	CPUMitigationsFull = "full"
+)
+
+// cpuMitigationsProfiles are the guest kernel parameters of the profiles.
+var cpuMitigationsProfiles = map[string][]Param{
+	CPUMitigationsOff:  {{Key: "mitigations", Value: "off"}},
+	CPUMitigationsAuto: {{Key: "mitigations", Value: "auto"}},
+	CPUMitigationsFull: {{Key: "mitigations", Value: "auto,nosmt"}},
+}
+
+// cpuMitigationsKernelParams are the guest kernel parameters controlling the
+// mitigations, which would override the profile.
+var cpuMitigationsKernelParams = map[string]bool{
+	"mitigations":               true,
+	"nosmt":                     true,
+	"nospectre_v1":              true,
+	"nospectre_v2":              true,
+	"spectre_v2":                true,
+	"spec_store_bypass_disable": true,
+	"mds":                       true,
+	"tsx_async_abort":           true,
+}
+
+// ValidCPUMitigations tells if profile is a known CPU mitigation profile.
+func ValidCPUMitigations(profile string) bool {
+	_, ok := cpuMitigationsProfiles[profile]
+	return ok
+}
+
+// CPUMitigations records the CPU vulnerability mitigation profile applied to
+// the guest of a sandbox.
+type CPUMitigations struct {
+	// Profile is empty when the defaults of the guest kernel apply
+	Profile      string   `json:"profile"`
+	KernelParams []string `json:"kernel_params,omitempty"`
+}
+
+func cpuMitigations(profile string) CPUMitigations {
+	m := CPUMitigations{Profile: profile}
+	if profile == "" {
+		return m
+	}
+	m.KernelParams = SerializeParams(cpuMitigationsProfiles[profile], "=")
+	return m
+}
+
+// applyCPUMitigations adds the kernel parameters of the CPU mitigation profile
+// of the guest. The profile must be the only source of
+// the mitigation parameters, so that it is the one applied.
+func (conf *HypervisorConfig) applyCPUMitigations() error {
+	if conf.CPUMitigations == "" {
+		return nil
+	}
+	params, ok := cpuMitigationsProfiles[conf.CPUMitigations]
+	if !ok {
+		return fmt.Errorf("invalid CPU mitigations profile %s", conf.CPUMitigations)
+	}
+
+	for _, p := range conf.KernelParams {
+		if cpuMitigationsKernelParams[p.Key] {
+			return fmt.Errorf("the kernel parameter %s conflicts with the %s CPU mitigations profile", p.Key, conf.CPUMitigations)
+		}
+	}
+	conf.KernelParams = append(conf.KernelParams, params...)
+
+	return nil
+}
+
+var (
+	// smtActivePath tells if the cores of the host run several threads.
+	smtActivePath = "/sys/devices/system/cpu/smt/active"
+
+	// schedCoreCreate gives the threads of a process a core scheduling
+	// cookie of their own, which the threads it creates inherit.
+	schedCoreCreate = func(pid int) error {
+		return unix.Prctl(unix.PR_SCHED_CORE, unix.PR_SCHED_CORE_CREATE, uintptr(pid), unix.PR_SCHED_CORE_SCOPE_THREAD_GROUP, 0)
+	}
+)
+
+// isolateVMMCores keeps the hypervisor of a sandbox with the full profile
+// from sharing the cores of the host with the other tasks, with core
+// scheduling: the sibling threads of a core only run the threads of the
+// hypervisor together, the other siblings idling. The guest cannot do it
+// itself, its vCPUs are not bound to the threads of the host cores. The
+// full profile is not applied without it on the hosts running SMT.
+func (s *Sandbox) isolateVMMCores() error {
+	if s.config.HypervisorConfig.CPUMitigations != CPUMitigationsFull {
+		return nil
+	}
+
+	active, err := os.ReadFile(smtActivePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(string(active)) != "1" {
+		return nil
+	}
+
+	pids := s.hypervisor.GetPids()
+	if len(pids) == 0 || pids[0] == 0 {
+		return fmt.Errorf("the %s CPU mitigations profile requires the hypervisor process", CPUMitigationsFull)
+	}
+	if err := schedCoreCreate(pids[0]); err != nil {
+		return fmt.Errorf("the %s CPU mitigations profile requires core scheduling on hosts running SMT: %v", CPUMitigationsFull, err)
+	}
+
+	s.Logger().WithField("pid", pids[0]).Info("hypervisor cores isolated")
+	return nil
+}
+
+// CPUMitigations returns the CPU vulnerability mitigation profile of the
+// guest.
+func (s *Sandbox) CPUMitigations() CPUMitigations {
+	return cpuMitigations(s.config.HypervisorConfig.CPUMitigations)
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyCPUMitigations(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(ValidCPUMitigations(CPUMitigationsOff))
+	assert.True(ValidCPUMitigations(CPUMitigationsFull))
+	assert.False(ValidCPUMitigations(""))
+	assert.False(ValidCPUMitigations("none"))
+
+	// The defaults of the guest kernel apply
+	conf := HypervisorConfig{KernelParams: []Param{{Key: "mitigations", Value: "off"}}}
+	assert.NoError(conf.applyCPUMitigations())
+	assert.Len(conf.KernelParams, 1)
+	assert.Equal(CPUMitigations{}, cpuMitigations(""))
+
+	conf = HypervisorConfig{
+		CPUMitigations: CPUMitigationsOff,
+		KernelParams:   []Param{{Key: "quiet"}},
+		CPUFeatures:    "pmu=off",
+	}
+	assert.NoError(conf.applyCPUMitigations())
+	assert.Equal([]Param{{Key: "quiet"}, {Key: "mitigations", Value: "off"}}, conf.KernelParams)
+	assert.Equal("pmu=off", conf.CPUFeatures)
+
+	conf = HypervisorConfig{CPUMitigations: CPUMitigationsFull}
+	assert.NoError(conf.applyCPUMitigations())
+	assert.Equal([]Param{{Key: "mitigations", Value: "auto,nosmt"}}, conf.KernelParams)
+
+	m := cpuMitigations(CPUMitigationsFull)
+	assert.Equal([]string{"mitigations=auto,nosmt"}, m.KernelParams)
+
+	// The profile conflicts with the mitigation parameters
+	conf = HypervisorConfig{
+		CPUMitigations: CPUMitigationsAuto,
+		KernelParams:   []Param{{Key: "nosmt"}},
+	}
+	assert.Error(conf.applyCPUMitigations())
+
+	conf = HypervisorConfig{CPUMitigations: "none"}
+	assert.Error(conf.applyCPUMitigations())
+}
+
+func TestIsolateVMMCores(t *testing.T) {
+	assert := assert.New(t)
+
+	savedSMTActivePath, savedSchedCoreCreate := smtActivePath, schedCoreCreate
+	defer func() {
+		smtActivePath, schedCoreCreate = savedSMTActivePath, savedSchedCoreCreate
+	}()
+
+	var isolated []int
+	var schedCoreErr error
+	schedCoreCreate = func(pid int) error {
+		isolated = append(isolated, pid)
+		return schedCoreErr
+	}
+	smtActivePath = filepath.Join(t.TempDir(), "active")
+
+	s := &Sandbox{
+		config:     &SandboxConfig{},
+		hypervisor: &mockHypervisor{},
+	}
+
+	// Only the full profile isolates the cores
+	assert.NoError(os.WriteFile(smtActivePath, []byte("1\n"), 0644))
+	s.config.HypervisorConfig.CPUMitigations = CPUMitigationsAuto
+	assert.NoError(s.isolateVMMCores())
+	assert.Empty(isolated)
+
+	// No SMT to isolate from
+	s.config.HypervisorConfig.CPUMitigations = CPUMitigationsFull
+	assert.NoError(os.WriteFile(smtActivePath, []byte("0\n"), 0644))
+	assert.NoError(s.isolateVMMCores())
+	assert.Empty(isolated)
+
+	// The mock hypervisor has no process
+	assert.NoError(os.WriteFile(smtActivePath, []byte("1\n"), 0644))
+	assert.Error(s.isolateVMMCores())
+
+	s.hypervisor = &mockHypervisor{mockPid: 42}
+	assert.NoError(s.isolateVMMCores())
+	assert.Equal([]int{42}, isolated)
+
+	// Core scheduling is not supported by the host
+	schedCoreErr = errors.New("invalid argument")
+	assert.Error(s.isolateVMMCores())
+}
@@ -65,6 +65,9 @@ const (
 
 	// IPVlanEndpointType is ipvlan network interface.
 	IPVlanEndpointType EndpointType = "ipvlan"
+
+	// SimulatedEndpointType is a network interface of the simulated network.
+	SimulatedEndpointType EndpointType = "simulated"
 )
 
 // Set sets an endpoint type based on the input string.
@@ -94,6 +97,9 @@ func (endpointType *EndpointType) Set(value string) error {
 	case "ipvlan":
 		*endpointType = IPVlanEndpointType
 		return nil
+	case "simulated":
+		*endpointType = SimulatedEndpointType
+		return nil
 	default:
 		return fmt.Errorf("Unknown endpoint type %s", value)
 	}
@@ -118,6 +124,8 @@ func (endpointType *EndpointType) String() string {
 		return string(TuntapEndpointType)
 	case IPVlanEndpointType:
 		return string(IPVlanEndpointType)
+	case SimulatedEndpointType:
+		return string(SimulatedEndpointType)
 	default:
 		return ""
 	}
@@ -174,6 +182,7 @@ func saveNetIfPair(pair *NetworkInterfacePair) *persistapi.NetworkInterfacePair
 		TapInterface:         *tapif,
 		VirtIface:            virtif,
 		NetInterworkingModel: int(pair.NetInterworkingModel),
+		Queues:               pair.Queues,
 	}
 }
 
@@ -196,6 +205,7 @@ func loadNetIfPair(pair *persistapi.NetworkInterfacePair) *NetworkInterfacePair
 		TapInterface:         *tapif,
 		VirtIface:            virtif,
 		NetInterworkingModel: NetInterworkingModel(pair.NetInterworkingModel),
+		Queues:               pair.Queues,
 	}
 }
 
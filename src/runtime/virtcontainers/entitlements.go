@@ -0,0 +1,156 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// With EntitlementsPath, the files of a host directory, e.g. the licenses of
+// the GPU drivers or the entitlement certificates of a subscription, are
+// provisioned inside the guest for the guest services when the sandbox
+// starts, and provisioned again when they are rotated on the host. The files
+// are copied through the agent, which works without a shared filesystem.
+
+// entitlements tracks the host directory of the entitlements of a sandbox.
+type entitlements struct {
+	watcher *fsnotify.Watcher
+	sync.Mutex
+}
+
+// guestEntitlementsDir is the directory of the guest the entitlements are
+// provisioned in.
+func guestEntitlementsDir() string {
+	return filepath.Join(kataGuestSandboxDir(), "entitlements")
+}
+
+// copyEntitlements copies the files of the entitlements directory inside
+// the guest, and returns the directories they are in.
+func (s *Sandbox) copyEntitlements(ctx context.Context) ([]string, error) {
+	var dirs []string
+
+	root := s.config.EntitlementsPath
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		// The ..data directories and links of the atomic writers of
+		// Kubernetes are followed through the links to the files.
+		if strings.HasPrefix(d.Name(), "..") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			dirs = append(dirs, path)
+			return nil
+		}
+
+		if st, err := os.Stat(path); err != nil || !st.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		return s.agent.copyFile(ctx, path, filepath.Join(guestEntitlementsDir(), rel))
+	})
+
+	return dirs, err
+}
+
+// provisionEntitlements copies the entitlements inside the guest and
+// watches them for rotations.
+func (s *Sandbox) provisionEntitlements(ctx context.Context) error {
+	if s.config.EntitlementsPath == "" {
+		return nil
+	}
+
+	e := &s.entitlements
+	e.Lock()
+	defer e.Unlock()
+
+	dirs, err := s.copyEntitlements(ctx)
+	if err != nil {
+		return err
+	}
+
+	if e.watcher == nil {
+		watcher, err := fsnotify.NewWatcher()
+		if err != nil {
+			return err
+		}
+		e.watcher = watcher
+		go s.watchEntitlements(watcher)
+	}
+
+	// The symbolic links the rotated files are replaced through are only
+	// seen by watching their directories.
+	for _, dir := range dirs {
+		if err := e.watcher.Add(dir); err != nil {
+			s.Logger().WithError(err).WithField("dir", dir).Warn("failed to watch the entitlements directory")
+		}
+	}
+
+	s.Logger().WithField("path", guestEntitlementsDir()).Info("entitlements provisioned in the guest")
+	return nil
+}
+
+// watchEntitlements copies the entitlements inside the guest again when they
+// change on the host, until the watcher is closed. The files removed on the
+// host are left inside the guest.
+func (s *Sandbox) watchEntitlements(watcher *fsnotify.Watcher) {
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+				continue
+			}
+
+			s.entitlements.Lock()
+			dirs, err := s.copyEntitlements(s.ctx)
+			if err != nil {
+				s.Logger().WithError(err).Warn("failed to update the entitlements")
+			} else {
+				for _, dir := range dirs {
+					// Directories already watched are ignored.
+					watcher.Add(dir)
+				}
+				s.Logger().WithField("file", event.Name).Debug("entitlements updated")
+			}
+			s.entitlements.Unlock()
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			s.Logger().WithError(err).Warn("entitlements watcher error")
+		}
+	}
+}
+
+// stopEntitlements stops watching the entitlements.
+func (s *Sandbox) stopEntitlements() {
+	e := &s.entitlements
+	e.Lock()
+	defer e.Unlock()
+
+	if e.watcher != nil {
+		e.watcher.Close()
+		e.watcher = nil
+	}
+}
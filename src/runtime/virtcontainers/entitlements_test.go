@@ -0,0 +1,67 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProvisionEntitlements(t *testing.T) {
+	assert := assert.New(t)
+
+	agent := &copyFileAgent{files: make(map[string]string)}
+	s := &Sandbox{
+		ctx:    context.Background(),
+		config: &SandboxConfig{},
+		agent:  agent,
+	}
+	defer s.stopEntitlements()
+
+	// Nothing is provisioned unless the directory is set
+	assert.NoError(s.provisionEntitlements(context.Background()))
+	assert.Empty(agent.files)
+
+	// The files are laid out like the ones of a Kubernetes secret
+	dir := t.TempDir()
+	data := filepath.Join(dir, "..2023_01_01")
+	assert.NoError(os.MkdirAll(filepath.Join(data, "pki"), 0755))
+	assert.NoError(os.WriteFile(filepath.Join(data, "license.lic"), []byte("v1"), 0644))
+	assert.NoError(os.WriteFile(filepath.Join(data, "pki", "cert.pem"), []byte("cert"), 0644))
+	assert.NoError(os.Symlink(filepath.Base(data), filepath.Join(dir, "..data")))
+	assert.NoError(os.Symlink(filepath.Join("..data", "license.lic"), filepath.Join(dir, "license.lic")))
+	assert.NoError(os.Symlink(filepath.Join("..data", "pki", "cert.pem"), filepath.Join(dir, "cert.pem")))
+
+	s.config.EntitlementsPath = dir
+	assert.NoError(s.provisionEntitlements(context.Background()))
+
+	license := filepath.Join(guestEntitlementsDir(), "license.lic")
+	content, ok := agent.file(license)
+	assert.True(ok)
+	assert.Equal("v1", content)
+	content, ok = agent.file(filepath.Join(guestEntitlementsDir(), "cert.pem"))
+	assert.True(ok)
+	assert.Equal("cert", content)
+	assert.Len(agent.files, 2)
+
+	// Rotate the files, swapping the ..data link
+	rotated := filepath.Join(dir, "..2023_02_01")
+	assert.NoError(os.MkdirAll(filepath.Join(rotated, "pki"), 0755))
+	assert.NoError(os.WriteFile(filepath.Join(rotated, "license.lic"), []byte("v2"), 0644))
+	assert.NoError(os.WriteFile(filepath.Join(rotated, "pki", "cert.pem"), []byte("cert"), 0644))
+	assert.NoError(os.Symlink(filepath.Base(rotated), filepath.Join(dir, "..data_tmp")))
+	assert.NoError(os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")))
+
+	assert.Eventually(func() bool {
+		content, _ := agent.file(license)
+		return content == "v2"
+	}, 5*time.Second, 10*time.Millisecond)
+}
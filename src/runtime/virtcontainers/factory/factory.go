@@ -31,6 +31,10 @@ type Config struct {
 
 	Template bool
 	VMCache  bool
+
+	// TemplatePrefetch prefaults the template memory of new VMs
+	// following the access order recorded on a previous boot.
+	TemplatePrefetch bool
 }
 
 // SetLogger sets the logger for the factory.
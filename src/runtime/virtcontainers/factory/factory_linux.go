@@ -48,12 +48,12 @@ func NewFactory(ctx context.Context, config Config, fetchOnly bool) (vc.Factory,
 	} else {
 		if config.Template {
 			if fetchOnly {
-				b, err = template.Fetch(config.VMConfig, config.TemplatePath)
+				b, err = template.Fetch(config.VMConfig, config.TemplatePath, config.TemplatePrefetch)
 				if err != nil {
 					return nil, err
 				}
 			} else {
-				b, err = template.New(ctx, config.VMConfig, config.TemplatePath)
+				b, err = template.New(ctx, config.VMConfig, config.TemplatePath, config.TemplatePrefetch)
 				if err != nil {
 					return nil, err
 				}
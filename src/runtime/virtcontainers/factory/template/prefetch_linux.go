@@ -0,0 +1,282 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package template
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// prefetchProfileFile stores the guest memory access order recorded
+	// on the first boot from the template.
+	prefetchProfileFile = "prefetch"
+
+	// pagemap entries are 64 bits wide, bit 63 being set when the page
+	// is present in memory.
+	pagemapEntrySize   = 8
+	pagemapPresentBit  = uint64(1) << 63
+	pagemapSwappedBit  = uint64(1) << 62
+	processMadviseIovs = 1024
+)
+
+var (
+	// prefetchRecordDuration is how long the memory accesses of a VM booted
+	// from the template are recorded for.
+	prefetchRecordDuration = 5 * time.Second
+
+	// prefetchRecordInterval is the time between two samples of the pages
+	// touched by the VM.
+	prefetchRecordInterval = 100 * time.Millisecond
+)
+
+// prefetchRange is a range of the template memory file, in bytes.
+type prefetchRange struct {
+	Offset uint64 `json:"offset"`
+	Length uint64 `json:"length"`
+}
+
+// prefetchProfile lists the ranges of the template memory file in the
+// order the guest first accessed them.
+type prefetchProfile struct {
+	Ranges []prefetchRange `json:"ranges"`
+}
+
+// iovec mirrors struct iovec, the addresses being in the address space of
+// the hypervisor rather than ours.
+type iovec struct {
+	base uintptr
+	len  uintptr
+}
+
+// memoryMapping describes where the template memory file is mapped in the
+// address space of the hypervisor.
+type memoryMapping struct {
+	start  uint64
+	end    uint64
+	offset uint64
+}
+
+func (t *template) prefetchProfilePath() string {
+	return filepath.Join(t.statePath, prefetchProfileFile)
+}
+
+// prefetchMemory prefaults the template memory of a VM that was just booted
+// from the template, following the access order recorded on a previous boot.
+// If no access order was recorded yet, it is recorded from this VM.
+func (t *template) prefetchMemory(pid int) {
+	memPath := filepath.Join(t.statePath, "memory")
+
+	data, err := os.ReadFile(t.prefetchProfilePath())
+	if os.IsNotExist(err) {
+		go func() {
+			if err := t.recordAccessOrder(pid, memPath); err != nil {
+				t.Logger().WithError(err).Warn("failed to record template memory access order")
+			}
+		}()
+		return
+	} else if err != nil {
+		t.Logger().WithError(err).Warn("failed to read template memory access order")
+		return
+	}
+
+	var profile prefetchProfile
+	if err := json.Unmarshal(data, &profile); err != nil {
+		t.Logger().WithError(err).Warn("invalid template memory access order")
+		return
+	}
+
+	go func() {
+		if err := replayAccessOrder(pid, memPath, profile); err != nil {
+			t.Logger().WithError(err).Warn("failed to prefetch template memory")
+		}
+	}()
+}
+
+// recordAccessOrder samples which pages of the template memory mapping the
+// hypervisor has faulted in, and saves them in the order they first appeared.
+func (t *template) recordAccessOrder(pid int, memPath string) error {
+	mapping, err := findMemoryMapping(pid, memPath)
+	if err != nil {
+		return err
+	}
+
+	pagemap, err := os.Open(fmt.Sprintf("/proc/%d/pagemap", pid))
+	if err != nil {
+		return err
+	}
+	defer pagemap.Close()
+
+	pageSize := uint64(os.Getpagesize())
+	pages := (mapping.end - mapping.start) / pageSize
+	seen := make([]bool, pages)
+	entries := make([]byte, pages*pagemapEntrySize)
+
+	var profile prefetchProfile
+	deadline := time.Now().Add(prefetchRecordDuration)
+	for time.Now().Before(deadline) {
+		if _, err := pagemap.ReadAt(entries, int64(mapping.start/pageSize*pagemapEntrySize)); err != nil {
+			// The hypervisor went away, keep what was recorded so far.
+			break
+		}
+
+		for i := uint64(0); i < pages; i++ {
+			entry := binary.LittleEndian.Uint64(entries[i*pagemapEntrySize:])
+			if seen[i] || entry&(pagemapPresentBit|pagemapSwappedBit) == 0 {
+				continue
+			}
+			seen[i] = true
+
+			offset := mapping.offset + i*pageSize
+			last := len(profile.Ranges) - 1
+			if last >= 0 && profile.Ranges[last].Offset+profile.Ranges[last].Length == offset {
+				profile.Ranges[last].Length += pageSize
+			} else {
+				profile.Ranges = append(profile.Ranges, prefetchRange{Offset: offset, Length: pageSize})
+			}
+		}
+
+		time.Sleep(prefetchRecordInterval)
+	}
+
+	data, err := json.Marshal(profile)
+	if err != nil {
+		return err
+	}
+
+	// Several VMs may record at the same time, only the last one wins.
+	tmp := fmt.Sprintf("%s.%d", t.prefetchProfilePath(), pid)
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+
+	t.Logger().WithField("ranges", len(profile.Ranges)).Info("recorded template memory access order")
+
+	return os.Rename(tmp, t.prefetchProfilePath())
+}
+
+// replayAccessOrder asks the kernel to fault in the recorded ranges, in order,
+// in the address space of the hypervisor. Kernels without process_madvise(2)
+// fall back to reading ahead the template memory file.
+func replayAccessOrder(pid int, memPath string, profile prefetchProfile) error {
+	mapping, err := findMemoryMapping(pid, memPath)
+	if err != nil {
+		return err
+	}
+
+	pidfd, err := unix.PidfdOpen(pid, 0)
+	if err != nil {
+		return fadviseAccessOrder(memPath, profile)
+	}
+	defer unix.Close(pidfd)
+
+	iovs := make([]iovec, 0, processMadviseIovs)
+	flush := func() error {
+		if len(iovs) == 0 {
+			return nil
+		}
+		_, _, errno := unix.Syscall6(unix.SYS_PROCESS_MADVISE, uintptr(pidfd),
+			uintptr(unsafe.Pointer(&iovs[0])), uintptr(len(iovs)), unix.MADV_WILLNEED, 0, 0)
+		iovs = iovs[:0]
+		if errno != 0 {
+			return errno
+		}
+		return nil
+	}
+
+	for _, r := range profile.Ranges {
+		if r.Offset < mapping.offset || r.Offset+r.Length > mapping.offset+mapping.end-mapping.start {
+			continue
+		}
+
+		iovs = append(iovs, iovec{
+			base: uintptr(mapping.start + r.Offset - mapping.offset),
+			len:  uintptr(r.Length),
+		})
+
+		if len(iovs) == processMadviseIovs {
+			if err := flush(); err != nil {
+				return fadviseAccessOrder(memPath, profile)
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return fadviseAccessOrder(memPath, profile)
+	}
+
+	return nil
+}
+
+func fadviseAccessOrder(memPath string, profile prefetchProfile) error {
+	f, err := os.Open(memPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	for _, r := range profile.Ranges {
+		if err := unix.Fadvise(int(f.Fd()), int64(r.Offset), int64(r.Length), unix.FADV_WILLNEED); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// findMemoryMapping looks up the mapping of memPath in /proc/<pid>/maps.
+func findMemoryMapping(pid int, memPath string) (memoryMapping, error) {
+	f, err := os.Open(fmt.Sprintf("/proc/%d/maps", pid))
+	if err != nil {
+		return memoryMapping{}, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// address perms offset dev inode pathname
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 6 || fields[5] != memPath {
+			continue
+		}
+
+		addrs := strings.SplitN(fields[0], "-", 2)
+		if len(addrs) != 2 {
+			continue
+		}
+
+		start, err := strconv.ParseUint(addrs[0], 16, 64)
+		if err != nil {
+			return memoryMapping{}, err
+		}
+		end, err := strconv.ParseUint(addrs[1], 16, 64)
+		if err != nil {
+			return memoryMapping{}, err
+		}
+		offset, err := strconv.ParseUint(fields[2], 16, 64)
+		if err != nil {
+			return memoryMapping{}, err
+		}
+
+		return memoryMapping{start: start, end: end, offset: offset}, nil
+	}
+
+	if err := scanner.Err(); err != nil {
+		return memoryMapping{}, err
+	}
+
+	return memoryMapping{}, fmt.Errorf("%s is not mapped by process %d", memPath, pid)
+}
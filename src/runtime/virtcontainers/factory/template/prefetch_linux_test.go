@@ -0,0 +1,64 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package template
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/unix"
+)
+
+func TestPrefetchRecordAndReplay(t *testing.T) {
+	assert := assert.New(t)
+
+	savedRecordDuration, savedRecordInterval := prefetchRecordDuration, prefetchRecordInterval
+	defer func() {
+		prefetchRecordDuration, prefetchRecordInterval = savedRecordDuration, savedRecordInterval
+	}()
+	prefetchRecordDuration = 10 * time.Millisecond
+	prefetchRecordInterval = time.Millisecond
+
+	testDir := t.TempDir()
+	memPath := filepath.Join(testDir, "memory")
+	pageSize := os.Getpagesize()
+
+	f, err := os.Create(memPath)
+	assert.NoError(err)
+	defer f.Close()
+	assert.NoError(f.Truncate(int64(8 * pageSize)))
+
+	mem, err := unix.Mmap(int(f.Fd()), 0, 8*pageSize, unix.PROT_READ|unix.PROT_WRITE, unix.MAP_PRIVATE)
+	assert.NoError(err)
+	defer unix.Munmap(mem)
+
+	// touch the third and fourth pages only
+	mem[2*pageSize] = 1
+	mem[3*pageSize] = 1
+
+	mapping, err := findMemoryMapping(os.Getpid(), memPath)
+	assert.NoError(err)
+	assert.Equal(uint64(8*pageSize), mapping.end-mapping.start)
+
+	tt := template{statePath: testDir}
+	assert.NoError(tt.recordAccessOrder(os.Getpid(), memPath))
+
+	data, err := os.ReadFile(tt.prefetchProfilePath())
+	assert.NoError(err)
+
+	var profile prefetchProfile
+	assert.NoError(json.Unmarshal(data, &profile))
+	assert.Equal([]prefetchRange{{Offset: uint64(2 * pageSize), Length: uint64(2 * pageSize)}}, profile.Ranges)
+
+	assert.NoError(replayAccessOrder(os.Getpid(), memPath, profile))
+
+	_, err = findMemoryMapping(os.Getpid(), filepath.Join(testDir, "unmapped"))
+	assert.Error(err)
+}
@@ -22,14 +22,17 @@ import (
 type template struct {
 	statePath string
 	config    vc.VMConfig
+	prefetch  bool
 }
 
 var templateWaitForAgent = 2 * time.Second
 
 // Fetch finds and returns a pre-built template factory.
+// When prefetch is set, the template memory of new VMs is prefaulted
+// following the access order recorded on a previous boot.
 // TODO: save template metadata and fetch from storage.
-func Fetch(config vc.VMConfig, templatePath string) (base.FactoryBase, error) {
-	t := &template{templatePath, config}
+func Fetch(config vc.VMConfig, templatePath string, prefetch bool) (base.FactoryBase, error) {
+	t := &template{templatePath, config, prefetch}
 
 	err := t.checkTemplateVM()
 	if err != nil {
@@ -40,8 +43,8 @@ func Fetch(config vc.VMConfig, templatePath string) (base.FactoryBase, error) {
 }
 
 // New creates a new VM template factory.
-func New(ctx context.Context, config vc.VMConfig, templatePath string) (base.FactoryBase, error) {
-	t := &template{templatePath, config}
+func New(ctx context.Context, config vc.VMConfig, templatePath string, prefetch bool) (base.FactoryBase, error) {
+	t := &template{templatePath, config, prefetch}
 
 	err := t.checkTemplateVM()
 	if err == nil {
@@ -166,7 +169,16 @@ func (t *template) createFromTemplateVM(ctx context.Context, c vc.VMConfig) (*vc
 	config.HypervisorConfig.VMStorePath = c.HypervisorConfig.VMStorePath
 	config.HypervisorConfig.RunStorePath = c.HypervisorConfig.RunStorePath
 
-	return vc.NewVM(ctx, config)
+	vm, err := vc.NewVM(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	if pids := vm.GetPids(); t.prefetch && len(pids) > 0 {
+		t.prefetchMemory(pids[0])
+	}
+
+	return vm, nil
 }
 
 func (t *template) checkTemplateVM() error {
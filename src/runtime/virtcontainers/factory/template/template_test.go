@@ -58,7 +58,7 @@ func TestTemplateFactory(t *testing.T) {
 	defer hybridVSockTTRPCMock.Stop()
 
 	// New
-	f, err := New(ctx, vmConfig, testDir)
+	f, err := New(ctx, vmConfig, testDir, false)
 	assert.Nil(err)
 
 	// Config
@@ -0,0 +1,30 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/featureflags"
+)
+
+// applyFeatureFlags turns off the behaviors of the sandbox whose feature flag
+// is disabled on the node. It is only applied to the new sandboxes, the
+// existing ones keeping the configuration they were created with.
+func applyFeatureFlags(conf *SandboxConfig) {
+	hconf := &conf.HypervisorConfig
+
+	if hconf.VirtioMem && !featureflags.Enabled(featureflags.VirtioMem) {
+		virtLog.WithField("flag", featureflags.VirtioMem).Info("feature flag disabled, not using virtio-mem")
+		hconf.VirtioMem = false
+	}
+
+	// Only the configured native hotplug is turned off, the guest kernels
+	// the automatic mode picks it for do not support the ACPI hotplug.
+	if hconf.HypervisorMachineType == QemuQ35 && hconf.PCIHotplugMode == PCIHotplugNative &&
+		!featureflags.Enabled(featureflags.PCIeNativeHotplug) {
+		virtLog.WithField("flag", featureflags.PCIeNativeHotplug).Info("feature flag disabled, using the ACPI PCI hotplug")
+		hconf.PCIHotplugMode = PCIHotplugACPI
+	}
+}
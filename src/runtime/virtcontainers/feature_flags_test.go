@@ -0,0 +1,53 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/featureflags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyFeatureFlags(t *testing.T) {
+	assert := assert.New(t)
+	t.Setenv(featureflags.EnvVar, "")
+	defer featureflags.Configure(nil)
+
+	newConfig := func() SandboxConfig {
+		return SandboxConfig{
+			HypervisorConfig: HypervisorConfig{
+				HypervisorMachineType: QemuQ35,
+				PCIHotplugMode:        PCIHotplugNative,
+				VirtioMem:             true,
+			},
+		}
+	}
+
+	assert.NoError(featureflags.Configure(nil))
+	conf := newConfig()
+	applyFeatureFlags(&conf)
+	assert.True(conf.HypervisorConfig.VirtioMem)
+	assert.Equal(PCIHotplugNative, conf.HypervisorConfig.PCIHotplugMode)
+
+	assert.NoError(featureflags.Configure(map[string]string{
+		featureflags.VirtioMem:         "false",
+		featureflags.PCIeNativeHotplug: "0%",
+	}))
+	conf = newConfig()
+	applyFeatureFlags(&conf)
+	assert.False(conf.HypervisorConfig.VirtioMem)
+	assert.Equal(PCIHotplugACPI, conf.HypervisorConfig.PCIHotplugMode)
+
+	// the native hotplug the automatic mode picks for the old guest
+	// kernels is kept
+	conf = newConfig()
+	conf.HypervisorConfig.PCIHotplugMode = PCIHotplugAuto
+	conf.HypervisorConfig.KernelPath = "/usr/share/kata-containers/vmlinux-5.4.60"
+	applyFeatureFlags(&conf)
+	assert.Equal(PCIHotplugAuto, conf.HypervisorConfig.PCIHotplugMode)
+	assert.Equal(PCIHotplugNative, PCIHotplugMode(&conf.HypervisorConfig))
+}
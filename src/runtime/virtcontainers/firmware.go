@@ -0,0 +1,203 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// The OVMF builds describe what they support in a table of GUIDed entries
+// located right before the reset vector, at the end of the image. QEMU parses
+// it the same way, see hw/i386/pc_sysfw_ovmf.c.
+const (
+	ovmfTableFooterGUID   = "96b582de-1fb2-45f7-baea-a366c55a082d"
+	ovmfSEVInfoBlockGUID  = "00f771de-1a7e-4fcb-890e-68c77e2fb44e"
+	ovmfSEVMetadataGUID   = "dc886566-984a-4798-a75e-5585a7bf67cc"
+	ovmfTDXMetadataGUID   = "e47a6535-984a-4798-865e-4685a7bf8ec2"
+	ovmfBytesAfterFooter  = 32
+	ovmfTableEntryHdrSize = 16 + 2
+)
+
+// The IGVM files start with a fixed header, followed by variable headers
+// among which the platforms the file can be launched on.
+const (
+	igvmMagic                 = "IGVM"
+	igvmFixedHeaderSize       = 24
+	igvmVariableHeaderSize    = 8
+	igvmVHTSupportedPlatform  = 0x1
+	igvmPlatformTypeOffset    = 5
+	igvmPlatformTypeSEVSNP    = 0x2
+	igvmPlatformTypeTDX       = 0x3
+	igvmPlatformTypeSEV       = 0x4
+	igvmSupportedPlatformSize = 16
+)
+
+// efiGUID returns the in-memory representation of a GUID, whose first three
+// fields are little-endian.
+func efiGUID(guid string) []byte {
+	b, err := hex.DecodeString(strings.ReplaceAll(guid, "-", ""))
+	if err != nil || len(b) != 16 {
+		panic(fmt.Sprintf("invalid GUID %q", guid))
+	}
+
+	b[0], b[1], b[2], b[3] = b[3], b[2], b[1], b[0]
+	b[4], b[5] = b[5], b[4]
+	b[6], b[7] = b[7], b[6]
+
+	return b
+}
+
+// isIGVMFirmware tells if the firmware is an Independent Guest Virtual
+// Machine file, bundling the firmware along with how to load it.
+func isIGVMFirmware(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	magic := make([]byte, len(igvmMagic))
+	if _, err := io.ReadFull(f, magic); err != nil {
+		return false, nil
+	}
+
+	return string(magic) == igvmMagic, nil
+}
+
+// ovmfTableEntries returns the GUIDs of the entries of the table of an OVMF
+// image, or none when the firmware is not OVMF.
+func ovmfTableEntries(image []byte) [][]byte {
+	footer := len(image) - ovmfBytesAfterFooter - 16
+	if footer < 2 || !bytes.Equal(image[footer:footer+16], efiGUID(ovmfTableFooterGUID)) {
+		return nil
+	}
+
+	end := footer - 2
+	tableLen := int(binary.LittleEndian.Uint16(image[end:])) - ovmfTableEntryHdrSize
+	if tableLen < 0 || tableLen > end {
+		return nil
+	}
+	table := image[end-tableLen : end]
+
+	var guids [][]byte
+	for ptr := len(table); ptr >= ovmfTableEntryHdrSize; {
+		guids = append(guids, table[ptr-16:ptr])
+		entryLen := int(binary.LittleEndian.Uint16(table[ptr-ovmfTableEntryHdrSize:]))
+		if entryLen < ovmfTableEntryHdrSize {
+			break
+		}
+		ptr -= entryLen
+	}
+
+	return guids
+}
+
+// igvmPlatforms returns the platform types an IGVM file supports.
+func igvmPlatforms(image []byte) []uint8 {
+	if len(image) < igvmFixedHeaderSize || string(image[:len(igvmMagic)]) != igvmMagic {
+		return nil
+	}
+
+	offset := int(binary.LittleEndian.Uint32(image[8:]))
+	end := offset + int(binary.LittleEndian.Uint32(image[12:]))
+	if end > len(image) {
+		end = len(image)
+	}
+
+	var platforms []uint8
+	for offset+igvmVariableHeaderSize <= end {
+		headerType := binary.LittleEndian.Uint32(image[offset:])
+		headerLen := int(binary.LittleEndian.Uint32(image[offset+4:]))
+		offset += igvmVariableHeaderSize
+
+		if headerType == igvmVHTSupportedPlatform && headerLen >= igvmSupportedPlatformSize && offset+headerLen <= end {
+			platforms = append(platforms, image[offset+igvmPlatformTypeOffset])
+		}
+
+		// The variable headers are 8 bytes aligned
+		offset += (headerLen + 7) &^ 7
+	}
+
+	return platforms
+}
+
+// firmwareSupportsProtection tells if a firmware image can boot a guest with
+// the given protection, from what it advertises.
+func firmwareSupportsProtection(image []byte, protection guestProtection) bool {
+	if platforms := igvmPlatforms(image); platforms != nil {
+		var platform uint8
+		switch protection {
+		case tdxProtection:
+			platform = igvmPlatformTypeTDX
+		case sevProtection:
+			platform = igvmPlatformTypeSEV
+		case snpProtection:
+			platform = igvmPlatformTypeSEVSNP
+		default:
+			return false
+		}
+
+		for _, p := range platforms {
+			if p == platform {
+				return true
+			}
+		}
+		return false
+	}
+
+	var guid []byte
+	switch protection {
+	case tdxProtection:
+		guid = efiGUID(ovmfTDXMetadataGUID)
+	case sevProtection:
+		guid = efiGUID(ovmfSEVInfoBlockGUID)
+	case snpProtection:
+		guid = efiGUID(ovmfSEVMetadataGUID)
+	default:
+		return false
+	}
+
+	for _, g := range ovmfTableEntries(image) {
+		if bytes.Equal(g, guid) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkFirmwareProtection makes sure the firmware of a confidential guest
+// matches the protection of the host, rather than failing the launch of the
+// VM or booting a guest the attestation will reject. Only the firmware of
+// the x86 TEEs is checked, the others are not described this way.
+func checkFirmwareProtection(path string, protection guestProtection) error {
+	switch protection {
+	case tdxProtection, sevProtection, snpProtection:
+	default:
+		return nil
+	}
+
+	if path == "" {
+		return fmt.Errorf("a firmware is required by %s guests", protection)
+	}
+
+	image, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if !firmwareSupportsProtection(image, protection) {
+		return fmt.Errorf("firmware %s does not support %s guests", path, protection)
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ovmfImage builds an OVMF image whose table has an entry per GUID.
+func ovmfImage(guids ...string) []byte {
+	var table []byte
+	for _, guid := range guids {
+		entry := make([]byte, 4) // entry data
+		entry = binary.LittleEndian.AppendUint16(entry, uint16(len(entry)+ovmfTableEntryHdrSize))
+		table = append(table, append(entry, efiGUID(guid)...)...)
+	}
+
+	image := make([]byte, 4096)
+	image = append(image, table...)
+	image = binary.LittleEndian.AppendUint16(image, uint16(len(table)+ovmfTableEntryHdrSize))
+	image = append(image, efiGUID(ovmfTableFooterGUID)...)
+	return append(image, make([]byte, ovmfBytesAfterFooter)...)
+}
+
+// igvmImage builds an IGVM file supporting the given platform types.
+func igvmImage(platforms ...uint8) []byte {
+	var headers []byte
+	for _, p := range platforms {
+		headers = binary.LittleEndian.AppendUint32(headers, igvmVHTSupportedPlatform)
+		headers = binary.LittleEndian.AppendUint32(headers, igvmSupportedPlatformSize)
+		platform := make([]byte, igvmSupportedPlatformSize)
+		platform[igvmPlatformTypeOffset] = p
+		headers = append(headers, platform...)
+	}
+
+	image := []byte(igvmMagic)
+	image = binary.LittleEndian.AppendUint32(image, 1)
+	image = binary.LittleEndian.AppendUint32(image, igvmFixedHeaderSize)
+	image = binary.LittleEndian.AppendUint32(image, uint32(len(headers)))
+	image = binary.LittleEndian.AppendUint32(image, uint32(igvmFixedHeaderSize+len(headers)))
+	image = binary.LittleEndian.AppendUint32(image, 0)
+	return append(image, headers...)
+}
+
+func TestFirmwareSupportsProtection(t *testing.T) {
+	assert := assert.New(t)
+
+	ovmf := ovmfImage(ovmfSEVInfoBlockGUID, ovmfSEVMetadataGUID)
+	assert.True(firmwareSupportsProtection(ovmf, sevProtection))
+	assert.True(firmwareSupportsProtection(ovmf, snpProtection))
+	assert.False(firmwareSupportsProtection(ovmf, tdxProtection))
+
+	tdvf := ovmfImage(ovmfTDXMetadataGUID)
+	assert.True(firmwareSupportsProtection(tdvf, tdxProtection))
+	assert.False(firmwareSupportsProtection(tdvf, snpProtection))
+
+	igvm := igvmImage(igvmPlatformTypeSEVSNP)
+	assert.True(firmwareSupportsProtection(igvm, snpProtection))
+	assert.False(firmwareSupportsProtection(igvm, tdxProtection))
+
+	// neither OVMF nor IGVM
+	assert.False(firmwareSupportsProtection(make([]byte, 4096), sevProtection))
+	assert.False(firmwareSupportsProtection(nil, tdxProtection))
+}
+
+func TestCheckFirmwareProtection(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	igvm := filepath.Join(dir, "snp.igvm")
+	assert.NoError(os.WriteFile(igvm, igvmImage(igvmPlatformTypeSEVSNP), 0644))
+	ovmf := filepath.Join(dir, "OVMF.fd")
+	assert.NoError(os.WriteFile(ovmf, ovmfImage(ovmfSEVInfoBlockGUID), 0644))
+
+	assert.NoError(checkFirmwareProtection(igvm, snpProtection))
+	assert.Error(checkFirmwareProtection(ovmf, snpProtection))
+	assert.Error(checkFirmwareProtection("", tdxProtection))
+
+	// the firmware of the other guests is not checked
+	assert.NoError(checkFirmwareProtection(ovmf, noneProtection))
+	assert.NoError(checkFirmwareProtection("", noneProtection))
+
+	isIGVM, err := isIGVMFirmware(igvm)
+	assert.NoError(err)
+	assert.True(isIGVM)
+
+	isIGVM, err = isIGVMFirmware(ovmf)
+	assert.NoError(err)
+	assert.False(isIGVM)
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/config"
+)
+
+// The flush barrier makes the data the containers wrote reach the storage of
+// their volumes before the volumes are detached: the agent syncs the
+// filesystems inside the guest, then the hypervisor flushes the drives. Both
+// are bounded by the stop flush timeout of the sandbox, their failures are
+// only logged as the containers and the sandbox stop anyway.
+
+// flushTimeout returns how long the flush barrier lasts for at most, 0 when
+// it is disabled.
+func (s *Sandbox) flushTimeout() time.Duration {
+	return time.Duration(s.config.StopFlushTimeout) * time.Second
+}
+
+// driveIDs returns the IDs of the hypervisor drives of the block devices of
+// deviceIDs.
+func (s *Sandbox) driveIDs(deviceIDs []string) []string {
+	var driveIDs []string
+	for _, id := range deviceIDs {
+		device := s.devManager.GetDeviceByID(id)
+		if device == nil || !s.devManager.IsDeviceAttached(id) {
+			continue
+		}
+		// The NVDIMMs are mapped in the guest memory, there is nothing
+		// in between to flush.
+		if drive, ok := device.GetDeviceInfo().(*config.BlockDrive); ok && drive != nil && !drive.Pmem && drive.NvdimmID == "" {
+			driveIDs = append(driveIDs, drive.ID)
+		}
+	}
+	return driveIDs
+}
+
+// flush syncs the filesystems of the container containerID inside the guest,
+// or all of them when containerID is empty, then flushes the drives of the
+// devices of deviceIDs.
+func (s *Sandbox) flush(ctx context.Context, containerID string, deviceIDs []string) {
+	timeout := s.flushTimeout()
+	if timeout == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	logger := s.Logger().WithField("container", containerID)
+	start := time.Now()
+
+	if err := s.agent.syncFs(ctx, containerID, timeout); err == errUnimplemented {
+		logger.Debug("the agent cannot sync the filesystems, they are synced when unmounted")
+	} else if err != nil {
+		logger.WithError(err).Warn("failed to sync the filesystems inside the guest")
+	}
+
+	if err := s.hypervisor.FlushDrives(ctx, s.driveIDs(deviceIDs)); err != nil {
+		logger.WithError(err).Warn("failed to flush the drives")
+	}
+
+	logger.WithField("duration", time.Since(start)).Debug("flushed")
+}
+
+// flush flushes the filesystems and the drives of the container, before it
+// is removed from the guest and its devices detached.
+func (c *Container) flush(ctx context.Context) {
+	var deviceIDs []string
+	for _, d := range c.devices {
+		deviceIDs = append(deviceIDs, d.ID)
+	}
+	if c.isDriveUsed() {
+		deviceIDs = append(deviceIDs, c.state.BlockDeviceID)
+	}
+
+	c.sandbox.flush(ctx, c.id, deviceIDs)
+}
+
+// deviceIDs returns the IDs of the devices attached to the sandbox.
+func (s *Sandbox) deviceIDs() []string {
+	var deviceIDs []string
+	for _, d := range s.devManager.GetAllDevices() {
+		deviceIDs = append(deviceIDs, d.DeviceID())
+	}
+	return deviceIDs
+}
+
+// flushAll flushes all the filesystems of the guest and the drives still
+// attached to the sandbox, before the VM is stopped.
+func (s *Sandbox) flushAll(ctx context.Context) {
+	s.flush(ctx, "", s.deviceIDs())
+}
+
+// Quiesce makes the data the containers wrote reach the storage of their
+// volumes and holds their writes until Unquiesce is called, so that the
+// volumes can be snapshotted in a consistent state: the agent freezes the
+// filesystems of the containers inside the guest, then the hypervisor
+// flushes the drives.
+func (s *Sandbox) Quiesce(ctx context.Context) error {
+	if err := s.agent.freezeFs(ctx, ""); err == errUnimplemented {
+		return fmt.Errorf("the agent cannot freeze the filesystems of the containers")
+	} else if err != nil {
+		return err
+	}
+
+	if err := s.hypervisor.FlushDrives(ctx, s.driveIDs(s.deviceIDs())); err != nil {
+		if err := s.agent.thawFs(ctx); err != nil {
+			s.Logger().WithError(err).Warn("failed to thaw the filesystems")
+		}
+		return err
+	}
+
+	s.Logger().Info("Sandbox is quiesced")
+	return nil
+}
+
+// Unquiesce thaws the filesystems Quiesce froze.
+func (s *Sandbox) Unquiesce(ctx context.Context) error {
+	if err := s.agent.thawFs(ctx); err != nil {
+		return err
+	}
+
+	s.Logger().Info("Sandbox is unquiesced")
+	return nil
+}
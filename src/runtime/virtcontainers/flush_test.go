@@ -0,0 +1,113 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/api"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/drivers"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/manager"
+	"github.com/stretchr/testify/assert"
+)
+
+// flushHypervisor records the drives it flushes.
+type flushHypervisor struct {
+	mockHypervisor
+	flushed []string
+}
+
+func (h *flushHypervisor) FlushDrives(ctx context.Context, driveIDs []string) error {
+	h.flushed = append(h.flushed, driveIDs...)
+	return nil
+}
+
+func TestSandboxFlush(t *testing.T) {
+	assert := assert.New(t)
+
+	newBlockDevice := func(id string, attached bool, drive *config.BlockDrive) api.Device {
+		blk := drivers.NewBlockDevice(&config.DeviceInfo{ID: id})
+		blk.BlockDrive = drive
+		if attached {
+			blk.AttachCount = 1
+		}
+		return blk
+	}
+	devices := []api.Device{
+		newBlockDevice("blk1", true, &config.BlockDrive{ID: "drive-blk1"}),
+		newBlockDevice("blk2", false, &config.BlockDrive{ID: "drive-blk2"}),
+		newBlockDevice("pmem", true, &config.BlockDrive{ID: "drive-pmem", Pmem: true}),
+	}
+
+	h := &flushHypervisor{}
+	s := &Sandbox{
+		config:     &SandboxConfig{},
+		agent:      &mockAgent{},
+		hypervisor: h,
+		devManager: manager.NewDeviceManager(config.VirtioBlock, false, "", 0, "", false, devices),
+	}
+	assert.Equal([]string{"drive-blk1"}, s.driveIDs([]string{"blk1", "blk2", "pmem", "unknown"}))
+
+	// the barrier is disabled
+	s.flushAll(context.Background())
+	assert.Empty(h.flushed)
+
+	s.config.StopFlushTimeout = 10
+	s.flushAll(context.Background())
+	assert.Equal([]string{"drive-blk1"}, h.flushed)
+
+	h.flushed = nil
+	c := &Container{
+		id:      "c1",
+		sandbox: s,
+		devices: []ContainerDevice{{ID: "blk2"}},
+	}
+	c.flush(context.Background())
+	assert.Empty(h.flushed)
+}
+
+// freezeAgent records the freezes of the filesystems of the guest.
+type freezeAgent struct {
+	mockAgent
+	frozen bool
+}
+
+func (a *freezeAgent) freezeFs(ctx context.Context, containerID string) error {
+	a.frozen = true
+	return nil
+}
+
+func (a *freezeAgent) thawFs(ctx context.Context) error {
+	a.frozen = false
+	return nil
+}
+
+func TestSandboxQuiesce(t *testing.T) {
+	assert := assert.New(t)
+
+	blk := drivers.NewBlockDevice(&config.DeviceInfo{ID: "blk1"})
+	blk.BlockDrive = &config.BlockDrive{ID: "drive-blk1"}
+	blk.AttachCount = 1
+
+	h := &flushHypervisor{}
+	agent := &freezeAgent{}
+	s := &Sandbox{
+		config:     &SandboxConfig{},
+		agent:      agent,
+		hypervisor: h,
+		devManager: manager.NewDeviceManager(config.VirtioBlock, false, "", 0, "", false, []api.Device{blk}),
+	}
+
+	// The drives are flushed even when the flush barrier is disabled.
+	assert.NoError(s.Quiesce(context.Background()))
+	assert.True(agent.frozen)
+	assert.Equal([]string{"drive-blk1"}, h.flushed)
+
+	assert.NoError(s.Unquiesce(context.Background()))
+	assert.False(agent.frozen)
+}
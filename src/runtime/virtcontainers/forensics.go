@@ -0,0 +1,238 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	ctrAnnotations "github.com/containerd/containerd/pkg/cri/annotations"
+)
+
+// The crash loops which only reproduce with Kata are hard to debug once the
+// container is gone. Past a number of nonzero exits of a container, i.e. of
+// the successive containers of the same name in the pod, a snapshot of the
+// guest at the time of the exit and of the container output is captured in
+// the sandbox state directory, each snapshot being a directory of:
+//  - exit.json, the container and its exit status,
+//  - container.log, the tail of the container output,
+//  - kernel.log, mountinfo and processes, the tail of the guest kernel log,
+//    the mount table and the process list of the guest.
+
+const (
+	forensicsDir = "forensics"
+
+	defaultForensicSnapshotCount = 5
+
+	// forensicLogSize is the size of the tails of the container output
+	// and of the kernel log kept in a snapshot.
+	forensicLogSize = 16 << 10
+)
+
+// forensicSnapshotTimeout bounds the capture of a snapshot, which waits for
+// the diagnostics of the agent in the background of the container exit.
+var forensicSnapshotTimeout = 10 * time.Second
+
+// forensicExit is the exit a snapshot is captured for.
+type forensicExit struct {
+	Time          time.Time `json:"time"`
+	ContainerID   string    `json:"container_id"`
+	ContainerName string    `json:"container_name"`
+	ExitCode      int32     `json:"exit_code"`
+	// Exits is the number of nonzero exits of the container.
+	Exits uint32 `json:"exits"`
+}
+
+// forensics counts the nonzero exits of the containers of the sandbox, by
+// name.
+type forensics struct {
+	exits map[string]uint32
+
+	// captures are the snapshots being captured, one at a time.
+	captures  sync.WaitGroup
+	capturing sync.Mutex
+
+	sync.Mutex
+}
+
+// tailBuffer keeps the last bytes written to it.
+type tailBuffer struct {
+	data []byte
+	size int
+	sync.Mutex
+}
+
+func newTailBuffer(size int) *tailBuffer {
+	return &tailBuffer{size: size}
+}
+
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.data = append(t.data, p...)
+	if excess := len(t.data) - t.size; excess > 0 {
+		t.data = append(t.data[:0], t.data[excess:]...)
+	}
+
+	return len(p), nil
+}
+
+func (t *tailBuffer) Bytes() []byte {
+	t.Lock()
+	defer t.Unlock()
+
+	return append([]byte(nil), t.data...)
+}
+
+// forensicsEnabled tells whether the snapshots are captured.
+func (s *Sandbox) forensicsEnabled() bool {
+	return s.config != nil && s.config.ForensicSnapshotThreshold > 0
+}
+
+func (s *Sandbox) forensicsPath() string {
+	return filepath.Join(s.store.RunStoragePath(), s.id, forensicsDir)
+}
+
+// recordContainerExit counts the nonzero exits of the container, and
+// captures a snapshot once they exceed the threshold. The snapshot is
+// captured in the background, the exit of the container is not delayed.
+func (s *Sandbox) recordContainerExit(c *Container, exitCode int32) {
+	if !s.forensicsEnabled() || exitCode == 0 {
+		return
+	}
+
+	var name string
+	if c.config != nil {
+		name = c.config.Annotations[ctrAnnotations.ContainerName]
+	}
+	if name == "" {
+		name = c.id
+	}
+
+	s.forensics.Lock()
+	if s.forensics.exits == nil {
+		s.forensics.exits = make(map[string]uint32)
+	}
+	s.forensics.exits[name]++
+	exits := s.forensics.exits[name]
+	s.forensics.Unlock()
+
+	if exits <= s.config.ForensicSnapshotThreshold {
+		return
+	}
+
+	exit := forensicExit{
+		Time:          time.Now(),
+		ContainerID:   c.id,
+		ContainerName: name,
+		ExitCode:      exitCode,
+		Exits:         exits,
+	}
+	var output []byte
+	if c.outputTail != nil {
+		output = c.outputTail.Bytes()
+	}
+
+	s.forensics.captures.Add(1)
+	go func() {
+		defer s.forensics.captures.Done()
+
+		s.forensics.capturing.Lock()
+		defer s.forensics.capturing.Unlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), forensicSnapshotTimeout)
+		defer cancel()
+
+		if err := s.captureForensicSnapshot(ctx, c, exit, output); err != nil {
+			c.Logger().WithError(err).Warn("failed to capture the forensic snapshot")
+		}
+	}()
+}
+
+// waitForensicSnapshots waits for the snapshots being captured.
+func (s *Sandbox) waitForensicSnapshots() {
+	s.forensics.captures.Wait()
+}
+
+// captureForensicSnapshot captures the snapshot of the exit of the
+// container, with the tail of its output, and removes the oldest snapshots.
+func (s *Sandbox) captureForensicSnapshot(ctx context.Context, c *Container, exit forensicExit, output []byte) error {
+	dir := s.forensicsPath()
+	// The snapshots are sorted by time.
+	snapshot := filepath.Join(dir, fmt.Sprintf("%d-%s", exit.Time.UnixNano(), exit.ContainerName))
+	if err := os.MkdirAll(snapshot, 0700); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(exit, "", "  ")
+	if err != nil {
+		return err
+	}
+	files := map[string][]byte{"exit.json": data}
+
+	if output != nil {
+		files["container.log"] = output
+	}
+
+	diagnostics, err := s.agent.getDiagnostics(ctx, forensicLogSize)
+	if err == errUnimplemented {
+		c.Logger().Debug("the agent cannot provide the diagnostics of the guest")
+	} else if err != nil {
+		c.Logger().WithError(err).Warn("failed to get the diagnostics of the guest")
+	} else {
+		files["kernel.log"] = diagnostics.KernelLog
+		files["mountinfo"] = diagnostics.Mounts
+		files["processes"] = diagnostics.Processes
+	}
+
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(snapshot, name), data, 0600); err != nil {
+			return err
+		}
+	}
+
+	c.Logger().WithField("snapshot", snapshot).WithField("exits", exit.Exits).
+		Info("captured the forensic snapshot of the container exit")
+
+	return s.pruneForensicSnapshots(dir)
+}
+
+// pruneForensicSnapshots removes the oldest snapshots of dir.
+func (s *Sandbox) pruneForensicSnapshots(dir string) error {
+	count := int(s.config.ForensicSnapshotCount)
+	if count == 0 {
+		count = defaultForensicSnapshotCount
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	if len(entries) <= count {
+		return nil
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+
+	for _, name := range names[:len(names)-count] {
+		if err := os.RemoveAll(filepath.Join(dir, name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	ctrAnnotations "github.com/containerd/containerd/pkg/cri/annotations"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/grpc"
+	"github.com/stretchr/testify/assert"
+)
+
+// diagnosticsAgent provides the diagnostics of a fake guest.
+type diagnosticsAgent struct {
+	mockAgent
+}
+
+func (d *diagnosticsAgent) getDiagnostics(ctx context.Context, maxKernelLogSize uint64) (*grpc.Diagnostics, error) {
+	return &grpc.Diagnostics{
+		KernelLog: []byte("[   41.262013] app[212]: segfault at 0 ip 00000000004011d6\n"),
+		Mounts:    []byte("22 1 0:20 / / rw - rootfs rootfs rw\n"),
+		Processes: []byte("PID PPID STATE COMMAND\n1 0 S /init\n"),
+	}, nil
+}
+
+func TestTailBuffer(t *testing.T) {
+	assert := assert.New(t)
+
+	tail := newTailBuffer(8)
+	tail.Write([]byte("hello"))
+	assert.Equal("hello", string(tail.Bytes()))
+
+	tail.Write([]byte(" world"))
+	assert.Equal("lo world", string(tail.Bytes()))
+
+	tail.Write([]byte("0123456789"))
+	assert.Equal("23456789", string(tail.Bytes()))
+}
+
+func TestRecordContainerExit(t *testing.T) {
+	assert := assert.New(t)
+
+	store, err := persist.GetDriver()
+	assert.NoError(err)
+
+	s := &Sandbox{
+		id:    "forensics",
+		store: store,
+		agent: &diagnosticsAgent{},
+		config: &SandboxConfig{
+			ForensicSnapshotThreshold: 1,
+			ForensicSnapshotCount:     2,
+		},
+	}
+	defer os.RemoveAll(filepath.Join(store.RunStoragePath(), s.id))
+
+	newContainer := func(id string) *Container {
+		c := &Container{
+			id:      id,
+			sandbox: s,
+			config: &ContainerConfig{
+				Annotations: map[string]string{ctrAnnotations.ContainerName: "app"},
+			},
+			outputTail: newTailBuffer(forensicLogSize),
+		}
+		c.outputTail.Write([]byte("panic: nil pointer dereference\n"))
+		return c
+	}
+
+	snapshots := func() []string {
+		s.waitForensicSnapshots()
+		entries, _ := os.ReadDir(s.forensicsPath())
+		var names []string
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		return names
+	}
+
+	// The successful exits are not counted, nor the first failures.
+	s.recordContainerExit(newContainer("c1"), 0)
+	s.recordContainerExit(newContainer("c1"), 2)
+	assert.Empty(snapshots())
+
+	// The restarted containers of the same name are counted together.
+	s.recordContainerExit(newContainer("c2"), 2)
+	assert.Len(snapshots(), 1)
+
+	snapshot := filepath.Join(s.forensicsPath(), snapshots()[0])
+	data, err := os.ReadFile(filepath.Join(snapshot, "exit.json"))
+	assert.NoError(err)
+	var exit forensicExit
+	assert.NoError(json.Unmarshal(data, &exit))
+	assert.Equal("c2", exit.ContainerID)
+	assert.Equal("app", exit.ContainerName)
+	assert.Equal(int32(2), exit.ExitCode)
+	assert.Equal(uint32(2), exit.Exits)
+
+	for file, contents := range map[string]string{
+		"container.log": "panic: nil pointer dereference\n",
+		"kernel.log":    "[   41.262013] app[212]: segfault at 0 ip 00000000004011d6\n",
+		"processes":     "PID PPID STATE COMMAND\n1 0 S /init\n",
+	} {
+		data, err := os.ReadFile(filepath.Join(snapshot, file))
+		assert.NoError(err)
+		assert.Equal(contents, string(data))
+	}
+
+	// The oldest snapshots are removed.
+	s.recordContainerExit(newContainer("c3"), 137)
+	s.recordContainerExit(newContainer("c4"), 137)
+	names := snapshots()
+	assert.Len(names, 2)
+	assert.NotContains(names, filepath.Base(snapshot))
+}
+
+// hangingAgent does not answer the requests for diagnostics.
+type hangingAgent struct {
+	mockAgent
+}
+
+func (h *hangingAgent) getDiagnostics(ctx context.Context, maxKernelLogSize uint64) (*grpc.Diagnostics, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestRecordContainerExitTimeout(t *testing.T) {
+	assert := assert.New(t)
+
+	savedTimeout := forensicSnapshotTimeout
+	forensicSnapshotTimeout = 100 * time.Millisecond
+	defer func() { forensicSnapshotTimeout = savedTimeout }()
+
+	store, err := persist.GetDriver()
+	assert.NoError(err)
+
+	s := &Sandbox{
+		id:     "forensics-timeout",
+		store:  store,
+		agent:  &hangingAgent{},
+		config: &SandboxConfig{ForensicSnapshotThreshold: 1},
+	}
+	defer os.RemoveAll(filepath.Join(store.RunStoragePath(), s.id))
+
+	c := &Container{id: "c1", sandbox: s, outputTail: newTailBuffer(forensicLogSize)}
+	s.recordContainerExit(c, 1)
+
+	// The exit does not wait for the agent
+	start := time.Now()
+	s.recordContainerExit(c, 1)
+	assert.Less(time.Since(start), forensicSnapshotTimeout)
+
+	// The snapshot is captured without the diagnostics of the guest
+	s.waitForensicSnapshots()
+	entries, err := os.ReadDir(s.forensicsPath())
+	assert.NoError(err)
+	assert.Len(entries, 1)
+	snapshot := filepath.Join(s.forensicsPath(), entries[0].Name())
+	assert.FileExists(filepath.Join(snapshot, "exit.json"))
+	assert.NoFileExists(filepath.Join(snapshot, "kernel.log"))
+}
@@ -231,6 +231,10 @@ func (f *FilesystemShare) ShareFile(ctx context.Context, c *Container, m *Mount)
 	}
 
 	filename := fmt.Sprintf("%s-%s-%s", c.id, hex.EncodeToString(randBytes), filepath.Base(m.Destination))
+	propagation := mountPropagation(m.Options)
+	if propagation == "rshared" {
+		filename = propagatedMountName(m.Source, m.ReadOnly)
+	}
 	guestPath := filepath.Join(kataGuestSharedDir(), filename)
 
 	// copy file to container's rootfs if filesystem sharing is not supported, otherwise
@@ -256,11 +260,22 @@ func (f *FilesystemShare) ShareFile(ctx context.Context, c *Container, m *Mount)
 		if err := f.sandbox.agent.copyFile(ctx, m.Source, guestPath); err != nil {
 			return nil, err
 		}
+		m.GuestPath = guestPath
 	} else {
 		// These mounts are created in the shared dir
 		mountDest := filepath.Join(getMountPath(f.sandbox.ID()), filename)
-		if !m.ReadOnly {
-			if err := bindMount(ctx, m.Source, mountDest, false, "private"); err != nil {
+
+		// Keep receiving the mounts created below the source on the host
+		// unless the volume is private.
+		pgtype := "private"
+		if propagation != "" {
+			pgtype = "slave"
+		}
+
+		if propagation == "rshared" && f.sandbox.isHostPathShared(c, mountDest) {
+			f.Logger().WithField("host-path", mountDest).Debug("volume already shared by another container")
+		} else if !m.ReadOnly {
+			if err := bindMount(ctx, m.Source, mountDest, false, pgtype); err != nil {
 				return nil, err
 			}
 		} else {
@@ -272,14 +287,14 @@ func (f *FilesystemShare) ShareFile(ctx context.Context, c *Container, m *Mount)
 			// 3. umount the private bind mount created in step 1
 			privateDest := filepath.Join(getPrivatePath(f.sandbox.ID()), filename)
 
-			if err := bindMount(ctx, m.Source, privateDest, true, "private"); err != nil {
+			if err := bindMount(ctx, m.Source, privateDest, true, pgtype); err != nil {
 				return nil, err
 			}
 			defer func() {
 				unmountNoFollow(privateDest)
 			}()
 
-			if err := bindMount(ctx, privateDest, mountDest, false, "private"); err != nil {
+			if err := bindMount(ctx, privateDest, mountDest, false, pgtype); err != nil {
 				return nil, err
 			}
 		}
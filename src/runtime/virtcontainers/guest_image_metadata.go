@@ -0,0 +1,117 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/config"
+)
+
+// guestImageMetadataFormat is the major version of the format of the guest
+// image metadata understood by the runtime. osbuilder bumps it on the
+// changes breaking the existing readers.
+const guestImageMetadataFormat = "1"
+
+// guestImageMetadata describes the guest image the agent runs from. osbuilder
+// writes it into the image, as /var/lib/osbuilder/image-metadata.json, and the
+// agent reports it in its details.
+type guestImageMetadata struct {
+	FormatVersion string `json:"format_version"`
+	Agent         struct {
+		Version  string   `json:"version"`
+		Features []string `json:"features"`
+	} `json:"agent"`
+	// KernelModules lists the loadable and built-in modules of the guest
+	// kernel, nil when the image does not ship them and they are unknown.
+	KernelModules []string `json:"kernel_modules"`
+}
+
+// kernelModuleRequirement is a guest kernel module needed by a setting of
+// the configuration.
+type kernelModuleRequirement struct {
+	module  string
+	setting string
+	value   string
+}
+
+func parseGuestImageMetadata(data string) (*guestImageMetadata, error) {
+	var metadata guestImageMetadata
+	if err := json.Unmarshal([]byte(data), &metadata); err != nil {
+		return nil, fmt.Errorf("invalid guest image metadata: %v", err)
+	}
+	return &metadata, nil
+}
+
+func (m *guestImageMetadata) hasKernelModule(name string) bool {
+	for _, module := range m.KernelModules {
+		if strings.ReplaceAll(module, "-", "_") == name {
+			return true
+		}
+	}
+	return false
+}
+
+// requiredKernelModules returns the guest kernel modules the hypervisor
+// configuration relies on.
+func requiredKernelModules(hconfig *HypervisorConfig) []kernelModuleRequirement {
+	var required []kernelModuleRequirement
+
+	if hconfig.VirtioMem {
+		required = append(required, kernelModuleRequirement{"virtio_mem", "enable_virtio_mem", "true"})
+	}
+	switch hconfig.SharedFS {
+	case config.VirtioFS, config.VirtioFSNydus:
+		required = append(required, kernelModuleRequirement{"virtiofs", "shared_fs", hconfig.SharedFS})
+	case config.Virtio9P:
+		required = append(required, kernelModuleRequirement{"9pnet_virtio", "shared_fs", hconfig.SharedFS})
+	}
+	if hconfig.BlockDeviceDriver == config.VirtioSCSI {
+		required = append(required, kernelModuleRequirement{"virtio_scsi", "block_device_driver", hconfig.BlockDeviceDriver})
+	}
+
+	return required
+}
+
+// checkGuestImageMetadata checks the configuration of the sandbox against the
+// metadata of the guest image, so that a sandbox relying on a feature the
+// guest lacks fails to start with the setting to change, instead of failing
+// later in a way hard to relate to the image. Images built without metadata,
+// or without their kernel modules, are not checked.
+func (s *Sandbox) checkGuestImageMetadata(data string) error {
+	if data == "" {
+		return nil
+	}
+
+	metadata, err := parseGuestImageMetadata(data)
+	if err != nil {
+		return err
+	}
+
+	if major, _, _ := strings.Cut(metadata.FormatVersion, "."); major != guestImageMetadataFormat {
+		s.Logger().WithField("format-version", metadata.FormatVersion).Warn("unsupported guest image metadata format, not checking the guest image")
+		return nil
+	}
+
+	if metadata.KernelModules == nil {
+		return nil
+	}
+
+	image, _ := s.config.HypervisorConfig.ImageAssetPath()
+	if image == "" {
+		image, _ = s.config.HypervisorConfig.InitrdAssetPath()
+	}
+	for _, req := range requiredKernelModules(&s.config.HypervisorConfig) {
+		if !metadata.hasKernelModule(req.module) {
+			return fmt.Errorf("guest image %s (agent %s) lacks the %s kernel module required by %s = %s: use a guest kernel providing it or change %s in the configuration file",
+				image, metadata.Agent.Version, req.module, req.setting, req.value, req.setting)
+		}
+	}
+
+	return nil
+}
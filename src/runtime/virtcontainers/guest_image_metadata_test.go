@@ -0,0 +1,46 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckGuestImageMetadata(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{
+		config: &SandboxConfig{
+			HypervisorConfig: HypervisorConfig{
+				ImagePath: "/usr/share/kata-containers/kata-containers.img",
+				VirtioMem: true,
+				SharedFS:  config.VirtioFS,
+			},
+		},
+	}
+
+	// Images built without metadata, or without their kernel modules,
+	// are not checked
+	assert.NoError(s.checkGuestImageMetadata(""))
+	assert.NoError(s.checkGuestImageMetadata(`{"format_version": "1.0.0", "agent": {"version": "3.1.0"}}`))
+	// Nor are the images of an unknown format
+	assert.NoError(s.checkGuestImageMetadata(`{"format_version": "2.0.0", "kernel_modules": []}`))
+
+	assert.Error(s.checkGuestImageMetadata("not json"))
+
+	err := s.checkGuestImageMetadata(`{"format_version": "1.0.0", "agent": {"version": "3.1.0"}, "kernel_modules": ["virtiofs"]}`)
+	assert.EqualError(err, "guest image /usr/share/kata-containers/kata-containers.img (agent 3.1.0) lacks the virtio_mem kernel module required by enable_virtio_mem = true: use a guest kernel providing it or change enable_virtio_mem in the configuration file")
+
+	assert.NoError(s.checkGuestImageMetadata(`{"format_version": "1.0.0", "kernel_modules": ["virtiofs", "virtio-mem"]}`))
+
+	s.config.HypervisorConfig.VirtioMem = false
+	s.config.HypervisorConfig.BlockDeviceDriver = config.VirtioSCSI
+	err = s.checkGuestImageMetadata(`{"format_version": "1.0.0", "kernel_modules": ["virtiofs"]}`)
+	assert.ErrorContains(err, "virtio_scsi kernel module required by block_device_driver = virtio-scsi")
+}
@@ -0,0 +1,201 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/grpc"
+)
+
+const (
+	// GuestSeccompEnforce applies the seccomp profile of the containers
+	// as is inside the guest.
+	GuestSeccompEnforce = "enforce"
+
+	// GuestSeccompAudit lets every system call through, the ones the
+	// seccomp profile would block are logged by the guest kernel.
+	GuestSeccompAudit = "audit"
+
+	// GuestSeccompUnconfined does not apply any seccomp profile inside
+	// the guest.
+	GuestSeccompUnconfined = "unconfined"
+)
+
+const (
+	seccompActAllow = "SCMP_ACT_ALLOW"
+	seccompActLog   = "SCMP_ACT_LOG"
+	seccompFlagLog  = "SECCOMP_FILTER_FLAG_LOG"
+
+	// The guest kernel prints the seccomp audit records with KERN_NOTICE,
+	// they only reach the console with a log level above it.
+	seccompReportKernelLogLevel = "6"
+
+	// Number of violations waiting to be published, the next ones are
+	// only recorded in the report.
+	seccompEventsSize = 64
+)
+
+// seccompAuditRegexp matches the audit records the guest kernel logs when
+// a system call is blocked, or logged, by a seccomp filter, e.g.
+// audit: type=1326 audit(1690000000.123:2): auid=4294967295 uid=0 gid=0
+// ses=4294967295 pid=123 comm="mount" exe="/usr/bin/mount" sig=0
+// arch=c000003e syscall=165 compat=0 ip=0x7f0000000000 code=0x50000
+var seccompAuditRegexp = regexp.MustCompile(`type=1326 .*comm="([^"]*)" exe="([^"]*)".* arch=([0-9a-f]+) syscall=([0-9]+)`)
+
+// ValidGuestSeccompMode tells if mode is a known guest seccomp mode, the
+// empty string being the default enforce mode.
+func ValidGuestSeccompMode(mode string) bool {
+	switch mode {
+	case "", GuestSeccompEnforce, GuestSeccompAudit, GuestSeccompUnconfined:
+		return true
+	default:
+		return false
+	}
+}
+
+// guestSeccompReporting tells if the seccomp violations of the sandbox
+// containers have to be collected.
+func (sandboxConfig *SandboxConfig) guestSeccompReporting() bool {
+	if sandboxConfig.DisableGuestSeccomp || sandboxConfig.GuestSeccompMode == GuestSeccompUnconfined {
+		return false
+	}
+
+	return sandboxConfig.GuestSeccompReport || sandboxConfig.GuestSeccompMode == GuestSeccompAudit
+}
+
+// guestSeccompProfile adapts the seccomp profile of a container to the
+// guest seccomp mode. In audit mode, the actions blocking system calls are
+// replaced with logging. When reporting, the filter logs the system calls
+// it blocks.
+func guestSeccompProfile(profile *grpc.LinuxSeccomp, mode string, report bool) *grpc.LinuxSeccomp {
+	if profile == nil {
+		return nil
+	}
+
+	switch mode {
+	case GuestSeccompUnconfined:
+		return nil
+	case GuestSeccompAudit:
+		if profile.DefaultAction != seccompActAllow {
+			profile.DefaultAction = seccompActLog
+		}
+		for i := range profile.Syscalls {
+			if profile.Syscalls[i].Action != seccompActAllow {
+				profile.Syscalls[i].Action = seccompActLog
+				profile.Syscalls[i].ErrnoRet = nil
+			}
+		}
+	default:
+		if !report {
+			break
+		}
+		for _, flag := range profile.Flags {
+			if flag == seccompFlagLog {
+				return profile
+			}
+		}
+		profile.Flags = append(profile.Flags, seccompFlagLog)
+	}
+
+	return profile
+}
+
+// SeccompViolation is a system call made by a process in the guest that
+// its seccomp profile blocks, or would block in audit mode.
+type SeccompViolation struct {
+	Comm    string
+	Exe     string
+	Arch    string
+	Syscall int
+	Count   uint64
+}
+
+type seccompViolationKey struct {
+	comm    string
+	exe     string
+	arch    string
+	syscall int
+}
+
+// seccompReport collects the seccomp violations of a sandbox, the first
+// occurrence of each one is reported on the events channel.
+type seccompReport struct {
+	violations map[seccompViolationKey]uint64
+	events     chan SeccompViolation
+	sync.Mutex
+}
+
+func newSeccompReport() *seccompReport {
+	return &seccompReport{
+		violations: make(map[seccompViolationKey]uint64),
+		events:     make(chan SeccompViolation, seccompEventsSize),
+	}
+}
+
+// parseSeccompAuditLine extracts a seccomp violation from a line of the
+// guest console.
+func parseSeccompAuditLine(line string) (SeccompViolation, bool) {
+	m := seccompAuditRegexp.FindStringSubmatch(line)
+	if m == nil {
+		return SeccompViolation{}, false
+	}
+
+	syscall, err := strconv.Atoi(m[4])
+	if err != nil {
+		return SeccompViolation{}, false
+	}
+
+	return SeccompViolation{
+		Comm:    m[1],
+		Exe:     m[2],
+		Arch:    m[3],
+		Syscall: syscall,
+		Count:   1,
+	}, true
+}
+
+// add records a violation, it returns true the first time a given
+// executable makes a given system call.
+func (r *seccompReport) add(v SeccompViolation) bool {
+	r.Lock()
+	defer r.Unlock()
+
+	key := seccompViolationKey{comm: v.Comm, exe: v.Exe, arch: v.Arch, syscall: v.Syscall}
+	r.violations[key] += v.Count
+
+	return r.violations[key] == v.Count
+}
+
+// list returns the violations recorded so far, ordered by executable and
+// system call.
+func (r *seccompReport) list() []SeccompViolation {
+	r.Lock()
+	defer r.Unlock()
+
+	violations := make([]SeccompViolation, 0, len(r.violations))
+	for key, count := range r.violations {
+		violations = append(violations, SeccompViolation{
+			Comm:    key.comm,
+			Exe:     key.exe,
+			Arch:    key.arch,
+			Syscall: key.syscall,
+			Count:   count,
+		})
+	}
+
+	sort.Slice(violations, func(i, j int) bool {
+		if violations[i].Exe != violations[j].Exe {
+			return violations[i].Exe < violations[j].Exe
+		}
+		return violations[i].Syscall < violations[j].Syscall
+	})
+
+	return violations
+}
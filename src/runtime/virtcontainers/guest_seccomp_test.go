@@ -0,0 +1,117 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/grpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func testSeccompProfile() *grpc.LinuxSeccomp {
+	return &grpc.LinuxSeccomp{
+		DefaultAction: "SCMP_ACT_ERRNO",
+		Syscalls: []grpc.LinuxSyscall{
+			{Names: []string{"read", "write"}, Action: seccompActAllow},
+			{Names: []string{"mount"}, Action: "SCMP_ACT_ERRNO", ErrnoRet: &grpc.LinuxSyscall_Errnoret{Errnoret: 1}},
+		},
+	}
+}
+
+func TestGuestSeccompProfile(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(guestSeccompProfile(nil, GuestSeccompAudit, true))
+	assert.Nil(guestSeccompProfile(testSeccompProfile(), GuestSeccompUnconfined, false))
+
+	// enforce keeps the profile as is
+	assert.Equal(testSeccompProfile(), guestSeccompProfile(testSeccompProfile(), "", false))
+	assert.Equal(testSeccompProfile(), guestSeccompProfile(testSeccompProfile(), GuestSeccompEnforce, false))
+
+	// reporting logs what the filter blocks
+	profile := guestSeccompProfile(testSeccompProfile(), GuestSeccompEnforce, true)
+	assert.Equal([]string{seccompFlagLog}, profile.Flags)
+	assert.Equal("SCMP_ACT_ERRNO", profile.DefaultAction)
+	profile = guestSeccompProfile(profile, GuestSeccompEnforce, true)
+	assert.Equal([]string{seccompFlagLog}, profile.Flags)
+
+	// audit only logs
+	profile = guestSeccompProfile(testSeccompProfile(), GuestSeccompAudit, true)
+	assert.Equal(seccompActLog, profile.DefaultAction)
+	assert.Equal(seccompActAllow, profile.Syscalls[0].Action)
+	assert.Equal(seccompActLog, profile.Syscalls[1].Action)
+	assert.Nil(profile.Syscalls[1].ErrnoRet)
+}
+
+func TestGuestSeccompReporting(t *testing.T) {
+	assert := assert.New(t)
+
+	config := SandboxConfig{}
+	assert.False(config.guestSeccompReporting())
+
+	config.GuestSeccompReport = true
+	assert.True(config.guestSeccompReporting())
+
+	config.GuestSeccompReport = false
+	config.GuestSeccompMode = GuestSeccompAudit
+	assert.True(config.guestSeccompReporting())
+
+	config.DisableGuestSeccomp = true
+	assert.False(config.guestSeccompReporting())
+
+	config.DisableGuestSeccomp = false
+	config.GuestSeccompReport = true
+	config.GuestSeccompMode = GuestSeccompUnconfined
+	assert.False(config.guestSeccompReporting())
+}
+
+func TestSeccompReport(t *testing.T) {
+	assert := assert.New(t)
+
+	_, ok := parseSeccompAuditLine("[    0.000000] Linux version 6.1.38")
+	assert.False(ok)
+
+	line := `[   12.345678] audit: type=1326 audit(1690000000.123:2): auid=4294967295 uid=0 gid=0 ses=4294967295 pid=123 comm="mount" exe="/usr/bin/mount" sig=0 arch=c000003e syscall=165 compat=0 ip=0x7f0000000000 code=0x7ffc0000`
+	v, ok := parseSeccompAuditLine(line)
+	assert.True(ok)
+	assert.Equal(SeccompViolation{Comm: "mount", Exe: "/usr/bin/mount", Arch: "c000003e", Syscall: 165, Count: 1}, v)
+
+	r := newSeccompReport()
+	assert.True(r.add(v))
+	assert.False(r.add(v))
+
+	other := v
+	other.Exe = "/usr/bin/busybox"
+	assert.True(r.add(other))
+
+	report := r.list()
+	assert.Len(report, 2)
+	assert.Equal("/usr/bin/busybox", report[0].Exe)
+	assert.Equal(uint64(1), report[0].Count)
+	assert.Equal("/usr/bin/mount", report[1].Exe)
+	assert.Equal(uint64(2), report[1].Count)
+}
+
+func TestSeccompViolations(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{config: &SandboxConfig{}}
+	assert.Nil(s.SeccompViolations())
+
+	s.seccompReport = newSeccompReport()
+	line := `[   12.345678] audit: type=1326 audit(1690000000.123:2): auid=4294967295 uid=0 gid=0 ses=4294967295 pid=123 comm="mount" exe="/usr/bin/mount" sig=0 arch=c000003e syscall=165 compat=0 ip=0x7f0000000000 code=0x7ffc0000`
+
+	// only the first occurrence is published
+	s.reportSeccompViolation(line)
+	s.reportSeccompViolation(line)
+
+	violations := s.SeccompViolations()
+	assert.Len(violations, 1)
+	v := <-violations
+	assert.Equal("/usr/bin/mount", v.Exe)
+	assert.Equal(165, v.Syscall)
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Guest services are systemd units of the guest image, e.g. chronyd or
+// nvidia-persistenced, a pod asks the agent to run next to its containers.
+// The agent only starts the units the image lists as allowed, in
+// /etc/kata-containers/guest-services, restarts them when they stop and
+// reports their status, which the shim serves on its /guest-services
+// endpoint.
+
+var guestServiceNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9:_.@-]+$`)
+
+// GuestServiceStatus is the status of a guest service.
+type GuestServiceStatus struct {
+	// Name is the systemd unit of the service
+	Name string `json:"name"`
+	// State is the active state of the unit, e.g. active or failed
+	State string `json:"state"`
+	// Restarts is the number of times the agent restarted the unit
+	Restarts uint32 `json:"restarts"`
+}
+
+// ParseGuestServices parses a comma separated list of guest services.
+func ParseGuestServices(value string) ([]string, error) {
+	var services []string
+
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !guestServiceNameRegexp.MatchString(name) {
+			return nil, fmt.Errorf("invalid guest service name %q", name)
+		}
+		services = append(services, name)
+	}
+
+	return services, nil
+}
+
+// GuestServices returns the status of the guest services of the sandbox.
+func (s *Sandbox) GuestServices(ctx context.Context) ([]GuestServiceStatus, error) {
+	services, err := s.agent.getGuestServices(ctx)
+	if err == errUnimplemented {
+		return nil, fmt.Errorf("the agent cannot run guest services")
+	}
+	return services, err
+}
@@ -0,0 +1,29 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGuestServices(t *testing.T) {
+	assert := assert.New(t)
+
+	services, err := ParseGuestServices("chronyd, iscsid.service,,getty@tty1.service")
+	assert.NoError(err)
+	assert.Equal([]string{"chronyd", "iscsid.service", "getty@tty1.service"}, services)
+
+	services, err = ParseGuestServices("")
+	assert.NoError(err)
+	assert.Empty(services)
+
+	for _, value := range []string{"../chronyd", "chronyd;reboot", "chrony d"} {
+		_, err = ParseGuestServices(value)
+		assert.Error(err, value)
+	}
+}
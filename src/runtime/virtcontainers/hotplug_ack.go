@@ -0,0 +1,165 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/api"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/config"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// hotplugLatencyWindow is the number of recent attach latencies the
+	// timeout of the device acknowledgments is computed from.
+	hotplugLatencyWindow = 16
+
+	// hotplugAckLatencyFactor is the margin given to the devices over the
+	// slowest recent attach.
+	hotplugAckLatencyFactor = 4
+
+	// hotplugAckMinTimeout and hotplugAckMaxTimeout bound the timeout of
+	// the device acknowledgments.
+	hotplugAckMinTimeout = 10 * time.Second
+	hotplugAckMaxTimeout = 5 * time.Minute
+)
+
+// hotplugLatencies tracks the recent attach latencies of the devices of the
+// sandbox, from the hotplug of a device to its acknowledgment by the agent,
+// so that the devices are waited for longer when the node is loaded.
+type hotplugLatencies struct {
+	latencies []time.Duration
+	next      int
+	sync.Mutex
+}
+
+// record adds the latency of an attach, the oldest one being forgotten when
+// the window is full.
+func (h *hotplugLatencies) record(latency time.Duration) {
+	h.Lock()
+	defer h.Unlock()
+
+	if len(h.latencies) < hotplugLatencyWindow {
+		h.latencies = append(h.latencies, latency)
+		return
+	}
+	h.latencies[h.next] = latency
+	h.next = (h.next + 1) % hotplugLatencyWindow
+}
+
+// timeout returns how long a device is waited for: a multiple of the slowest
+// recent attach, within the bounds of the acknowledgments.
+func (h *hotplugLatencies) timeout() time.Duration {
+	h.Lock()
+	defer h.Unlock()
+
+	var slowest time.Duration
+	for _, latency := range h.latencies {
+		if latency > slowest {
+			slowest = latency
+		}
+	}
+
+	timeout := slowest * hotplugAckLatencyFactor
+	if timeout < hotplugAckMinTimeout {
+		return hotplugAckMinTimeout
+	}
+	if timeout > hotplugAckMaxTimeout {
+		return hotplugAckMaxTimeout
+	}
+	return timeout
+}
+
+// hotplugAck is a device the agent acknowledges, by its type and address in
+// the guest.
+type hotplugAck struct {
+	devType string
+	address string
+}
+
+// hotplugAcks returns the devices of device the agent can acknowledge, the
+// ones whose address in the guest is known.
+func hotplugAcks(device api.Device, blockDriver string) []hotplugAck {
+	switch d := device.GetDeviceInfo().(type) {
+	case *config.BlockDrive:
+		if d.Pmem {
+			return nil
+		}
+		switch blockDriver {
+		case config.VirtioBlock:
+			if !d.PCIPath.IsNil() {
+				return []hotplugAck{{kataBlkDevType, d.PCIPath.String()}}
+			}
+		case config.VirtioBlockCCW:
+			return []hotplugAck{{kataBlkCCWDevType, d.DevNo}}
+		case config.VirtioSCSI:
+			return []hotplugAck{{kataSCSIDevType, d.SCSIAddr}}
+		}
+	case *config.VhostUserDeviceAttrs:
+		if d.Type == config.VhostUserBlk && !d.PCIPath.IsNil() {
+			return []hotplugAck{{kataBlkDevType, d.PCIPath.String()}}
+		}
+	case []*config.VFIODev:
+		var acks []hotplugAck
+		for _, dev := range d {
+			if dev.Type != config.VFIOAPDeviceMediatedType && !dev.GuestPciPath.IsNil() {
+				acks = append(acks, hotplugAck{kataVfioPciDevType, dev.GuestPciPath.String()})
+			}
+		}
+		return acks
+	}
+	return nil
+}
+
+// ackDevices waits for the agent to acknowledge the devices of the container,
+// hotplugged since start, so that the container is not created before they
+// appear in the guest. The devices are waited for according to the recent
+// attach latencies, rather than to the fixed hotplug timeout of the agent.
+func (c *Container) ackDevices(ctx context.Context, start time.Time) error {
+	deviceIDs := make([]string, 0, len(c.devices)+1)
+	if c.state.BlockDeviceID != "" {
+		deviceIDs = append(deviceIDs, c.state.BlockDeviceID)
+	}
+	for _, dev := range c.devices {
+		deviceIDs = append(deviceIDs, dev.ID)
+	}
+
+	latencies := &c.sandbox.hotplugLatencies
+	for _, id := range deviceIDs {
+		device := c.sandbox.devManager.GetDeviceByID(id)
+		if device == nil {
+			return fmt.Errorf("failed to find device by id %s", id)
+		}
+
+		for _, ack := range hotplugAcks(device, c.sandbox.config.HypervisorConfig.BlockDeviceDriver) {
+			timeout := latencies.timeout()
+			err := c.sandbox.agent.waitDevice(ctx, ack.devType, ack.address, timeout)
+			if errors.Is(err, errUnimplemented) {
+				// The agent waits for the devices itself.
+				c.Logger().Debug("agent cannot acknowledge the devices")
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("device %s did not appear at %s in the guest within %v: %w", id, ack.address, timeout, err)
+			}
+
+			latency := time.Since(start)
+			latencies.record(latency)
+			c.Logger().WithFields(logrus.Fields{
+				"device":  id,
+				"address": ack.address,
+				"latency": latency,
+			}).Debug("device acknowledged by the agent")
+		}
+	}
+
+	return nil
+}
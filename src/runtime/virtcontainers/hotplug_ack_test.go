@@ -0,0 +1,66 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/drivers"
+	vcTypes "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHotplugLatencies(t *testing.T) {
+	assert := assert.New(t)
+
+	var h hotplugLatencies
+	assert.Equal(hotplugAckMinTimeout, h.timeout())
+
+	h.record(time.Second)
+	assert.Equal(hotplugAckMinTimeout, h.timeout())
+
+	h.record(5 * time.Second)
+	assert.Equal(20*time.Second, h.timeout())
+
+	h.record(time.Hour)
+	assert.Equal(hotplugAckMaxTimeout, h.timeout())
+
+	// the slow attaches are forgotten once out of the window
+	for i := 0; i < hotplugLatencyWindow; i++ {
+		h.record(3 * time.Second)
+	}
+	assert.Len(h.latencies, hotplugLatencyWindow)
+	assert.Equal(12*time.Second, h.timeout())
+}
+
+func TestHotplugAcks(t *testing.T) {
+	assert := assert.New(t)
+
+	pciPath, err := vcTypes.PciPathFromString("02/01")
+	assert.NoError(err)
+
+	drive := &config.BlockDrive{PCIPath: pciPath, DevNo: "fe.0.0001", SCSIAddr: "0:1"}
+	blk := drivers.NewBlockDevice(&config.DeviceInfo{ID: "blk"})
+	blk.BlockDrive = drive
+
+	assert.Equal([]hotplugAck{{kataBlkDevType, "02/01"}}, hotplugAcks(blk, config.VirtioBlock))
+	assert.Equal([]hotplugAck{{kataBlkCCWDevType, "fe.0.0001"}}, hotplugAcks(blk, config.VirtioBlockCCW))
+	assert.Equal([]hotplugAck{{kataSCSIDevType, "0:1"}}, hotplugAcks(blk, config.VirtioSCSI))
+	assert.Empty(hotplugAcks(blk, config.VirtioMmio))
+
+	drive.Pmem = true
+	assert.Empty(hotplugAcks(blk, config.VirtioBlock))
+
+	vfio := drivers.NewVFIODevice(&config.DeviceInfo{ID: "vfio"})
+	vfio.VfioDevs = []*config.VFIODev{
+		{Type: config.VFIOPCIDeviceNormalType, GuestPciPath: pciPath},
+		{Type: config.VFIOPCIDeviceNormalType},
+		{Type: config.VFIOAPDeviceMediatedType},
+	}
+	assert.Equal([]hotplugAck{{kataVfioPciDevType, "02/01"}}, hotplugAcks(vfio, config.VirtioBlock))
+}
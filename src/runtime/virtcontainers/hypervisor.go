@@ -91,6 +91,96 @@ var (
 // cores.
 var defaultMaxVCPUs = govmm.MaxVCPUs()
 
+const (
+	// MemoryTHPAlways keeps the transparent huge page policy of the
+	// host for the hypervisor process, it does not force huge pages.
+	MemoryTHPAlways = "always"
+
+	// MemoryTHPMadvise restricts transparent huge pages to the
+	// regions the hypervisor explicitly advises, i.e. guest memory.
+	MemoryTHPMadvise = "madvise"
+
+	// MemoryTHPNever disables transparent huge pages for the
+	// hypervisor process.
+	MemoryTHPNever = "never"
+)
+
+const (
+	// MemMergeOn marks the guest memory as mergeable by KSM, which the
+	// hypervisors not doing it by default, i.e. Cloud Hypervisor, need.
+	MemMergeOn = "on"
+
+	// MemMergeOff keeps the guest memory from being merged by KSM, which
+	// the hypervisors doing it by default, i.e. QEMU, need.
+	MemMergeOff = "off"
+)
+
+const (
+	// EntropyBackendRandom feeds the guest virtio-rng device from the
+	// host entropy source file.
+	EntropyBackendRandom = "random"
+
+	// EntropyBackendBuiltin feeds the guest virtio-rng device from the
+	// generator built in the hypervisor, without reading a host device.
+	EntropyBackendBuiltin = "builtin"
+)
+
+// defaultEntropyPeriodMs is the rate limiting period of the virtio-rng
+// device when only its maximum number of bytes is set.
+const defaultEntropyPeriodMs = 1000
+
+const (
+	// CCAMeasurementSHA256 measures the Realm of an Arm CCA guest with
+	// SHA-256.
+	CCAMeasurementSHA256 = "sha256"
+
+	// CCAMeasurementSHA512 measures the Realm of an Arm CCA guest with
+	// SHA-512.
+	CCAMeasurementSHA512 = "sha512"
+)
+
+const (
+	// PCIHotplugAuto selects the PCI hotplug mode of the q35 machine
+	// from the version of the guest kernel.
+	PCIHotplugAuto = "auto"
+
+	// PCIHotplugACPI hotplugs the PCI devices through ACPI, handled by
+	// the acpiphp driver of the guest.
+	PCIHotplugACPI = "acpi"
+
+	// PCIHotplugNative hotplugs the PCI devices natively on the PCIe
+	// ports, handled by the pciehp driver of the guest.
+	PCIHotplugNative = "native"
+)
+
+const (
+	// AFXDPModeNative attaches the XDP program of the AF_XDP network
+	// backend in the driver of the interface.
+	AFXDPModeNative = "native"
+
+	// AFXDPModeSKB attaches the XDP program of the AF_XDP network backend
+	// in the generic network stack, for the interfaces with no native XDP
+	// support.
+	AFXDPModeSKB = "skb"
+)
+
+const (
+	// VirtioGPUVenus exposes the host GPU to the guest through Vulkan.
+	VirtioGPUVenus = "venus"
+
+	// VirtioGPUDRM exposes the host GPU to the guest through the native
+	// DRM context of the host driver (vDRM).
+	VirtioGPUDRM = "drm"
+
+	// defaultVirtioGPUHostMemMB is the default size of the host visible
+	// memory region of the virtio-gpu device.
+	defaultVirtioGPUHostMemMB = 4096
+
+	// defaultShmChannelSizeMB is the default size of the shared memory of
+	// the channels between sandboxes.
+	defaultShmChannelSizeMB = 4
+)
+
 // RootfsDriver describes a rootfs driver.
 type RootfsDriver string
 
@@ -301,6 +391,16 @@ type Param struct {
 	Value string
 }
 
+// KernelVariant is a guest kernel a sandbox can select instead of the
+// configured one, e.g. a real-time or a hardened build.
+type KernelVariant struct {
+	// Path is the host path of the kernel.
+	Path string
+
+	// Params are the kernel parameters added when booting this kernel.
+	Params []Param
+}
+
 // HypervisorConfig is the hypervisor configuration.
 // nolint: govet
 type HypervisorConfig struct {
@@ -316,6 +416,10 @@ type HypervisorConfig struct {
 	// KernelPath is the guest kernel host path.
 	KernelPath string
 
+	// KernelVariants are the named guest kernels a sandbox can select
+	// instead of KernelPath.
+	KernelVariants map[string]KernelVariant
+
 	// ImagePath is the guest image host path.
 	ImagePath string
 
@@ -332,12 +436,25 @@ type HypervisorConfig struct {
 	// FirmwareVolumePath is the configuration volume path for the firmware
 	FirmwareVolumePath string
 
+	// FirmwareVariants are the named firmware a sandbox can select instead
+	// of FirmwarePath, e.g. the OVMF builds or the IGVM files of the
+	// different TEEs.
+	FirmwareVariants map[string]string
+
 	// MachineAccelerators are machine specific accelerators
 	MachineAccelerators string
 
 	// CPUFeatures are cpu specific features
 	CPUFeatures string
 
+	// CPUMitigations is the CPU vulnerability mitigation profile of the
+	// guest, the defaults of the guest kernel apply when it is empty.
+	CPUMitigations string
+
+	// ValidCPUMitigations are the CPU mitigation profiles a sandbox can
+	// select with an annotation.
+	ValidCPUMitigations []string
+
 	// HypervisorPath is the hypervisor executable host path.
 	HypervisorPath string
 
@@ -367,6 +484,10 @@ type HypervisorConfig struct {
 	// entropy (/dev/random, /dev/urandom or real hardware RNG device)
 	EntropySource string
 
+	// EntropyBackend is the backend of the guest virtio-rng device: random
+	// or builtin. Empty is random.
+	EntropyBackend string
+
 	// Shared file system type:
 	//   - virtio-9p
 	//   - virtio-fs (default)
@@ -384,6 +505,19 @@ type HypervisorConfig struct {
 	// File based memory backend root directory
 	FileBackedMemRootDir string
 
+	// HostSharedMemory is the host shared memory segment, e.g. a file of
+	// /dev/shm or /dev/hugepages, mapped in the guest.
+	HostSharedMemory string
+
+	// MemMerge is the KSM policy of guest memory: on or off. Empty keeps
+	// the hypervisor default. Memory of confidential guests is never
+	// marked as mergeable.
+	MemMerge string
+
+	// MemoryTHP is the transparent huge page policy applied to guest
+	// memory: always, madvise or never. Empty keeps the hypervisor default.
+	MemoryTHP string
+
 	// VhostUserStorePath is the directory path where vhost-user devices
 	// related folders, sockets and device nodes should be.
 	VhostUserStorePath string
@@ -392,12 +526,55 @@ type HypervisorConfig struct {
 	// when the remote end goes away. Zero disables reconnecting.
 	VhostUserDeviceReconnect uint32
 
+	// VhostVDPAHookPath is an executable called with "attach" or "detach"
+	// and the device path around the attachment of vhost-vdpa devices, so
+	// that the userspace dataplane serving them can be coordinated with.
+	VhostVDPAHookPath string
+
 	// GuestCoredumpPath is the path in host for saving guest memory dump
 	GuestMemoryDumpPath string
 
 	// GuestHookPath is the path within the VM that will be used for 'drop-in' hooks
 	GuestHookPath string
 
+	// VirtioGPU is the rendering backend (venus or drm) of the virtio-gpu
+	// device added to the VM. Empty disables the device.
+	VirtioGPU string
+
+	// VirtioGPUDaemon is the path to a vhost-user-gpu daemon rendering for
+	// the virtio-gpu device out of the hypervisor process. Empty renders in
+	// the hypervisor process.
+	VirtioGPUDaemon string
+
+	// SwtpmPath is the path to the swtpm binary emulating the vTPM of the VM.
+	SwtpmPath string
+
+	// VTPMStatePath is the directory keeping the TPM state of the vTPM of
+	// the sandboxes, across the restarts of their VM.
+	VTPMStatePath string
+
+	// CCAMeasurementAlgorithm is the hash algorithm measuring the Realm of
+	// an Arm CCA guest, sha256 or sha512.
+	CCAMeasurementAlgorithm string
+
+	// CCAPersonalizationValue is the base64 encoded Realm Personalization
+	// Value of an Arm CCA guest, up to 64 bytes included in its
+	// attestation token.
+	CCAPersonalizationValue string
+
+	// IvshmemServerPath is the path to the ivshmem-server binary serving
+	// the shared memory channels between sandboxes. Empty disables the
+	// channels.
+	IvshmemServerPath string
+
+	// ShmChannelSocket is the socket of the ivshmem-server of the shared
+	// memory channel of the sandbox, set when it joins the channel.
+	ShmChannelSocket string
+
+	// AFXDPMode is the XDP attach mode of the af_xdp internetworking model,
+	// native or skb. Empty tries native first and falls back to skb.
+	AFXDPMode string
+
 	// VMid is the id of the VM that create the hypervisor if the VM is created by the factory.
 	// VMid is "" if the hypervisor is not created by the factory.
 	VMid string
@@ -429,18 +606,48 @@ type HypervisorConfig struct {
 	// VirtioFSExtraArgs passes options to virtiofsd daemon
 	VirtioFSExtraArgs []string
 
+	// VirtioGPUExtraArgs passes options to the vhost-user-gpu daemon
+	VirtioGPUExtraArgs []string
+
 	// Enable annotations by name
 	EnableAnnotations []string
 
 	// FileBackedMemRootList is the list of valid root directories values for annotations
 	FileBackedMemRootList []string
 
+	// HostSharedMemoryList is the list of valid host shared memory
+	// segments for annotations
+	HostSharedMemoryList []string
+
 	// PFlash image paths
 	PFlash []string
 
 	// VhostUserStorePathList is the list of valid values for vhost-user paths
 	VhostUserStorePathList []string
 
+	// USBDevices is the list of host USB devices passed through to the VM,
+	// identified as vendor:product (e.g. 18d1:4ee7) or bus-port (e.g. 1-1.2)
+	USBDevices []string
+
+	// USBDevicesList is the list of valid USB devices for annotations
+	USBDevicesList []string
+
+	// ExtraArgs are raw arguments appended to the hypervisor command line.
+	ExtraArgs []string
+
+	// ExtraArgsList is the list of regular expressions the extra_args
+	// annotation must fully match. Empty rejects the annotation.
+	ExtraArgsList []string
+
+	// ExtraAPIFields is a JSON merge patch applied to the VM configuration
+	// sent to the hypervisor API.
+	ExtraAPIFields string
+
+	// ExtraAPIFieldsList is the list of regular expressions the top level
+	// fields of the extra_api_fields annotation must fully match. Empty
+	// rejects the annotation.
+	ExtraAPIFieldsList []string
+
 	// SeccompSandbox is the qemu function which enables the seccomp feature
 	SeccompSandbox string
 
@@ -521,6 +728,10 @@ type HypervisorConfig struct {
 	// root port, switch or no port
 	ColdPlugVFIO config.PCIePort
 
+	// PCIHotplugMode is how the PCI devices are hotplugged on the q35
+	// machine, through ACPI or natively, "auto" by default
+	PCIHotplugMode string
+
 	// NumVCPUs specifies default number of vCPUs for the VM.
 	NumVCPUs uint32
 
@@ -543,6 +754,41 @@ type HypervisorConfig struct {
 	// MemSlots specifies default memory slots the VM.
 	MemSlots uint32
 
+	// VirtioGPUHostMemMB is the size in MiB of the host visible memory
+	// region of the virtio-gpu device.
+	VirtioGPUHostMemMB uint32
+
+	// ShmChannelSizeMB is the size in MiB of the shared memory of the
+	// channels between sandboxes, a power of 2.
+	ShmChannelSizeMB uint32
+
+	// ShutdownGracePeriod is the time in seconds the guest is given to shut
+	// down after its ACPI power button was pressed, before the hypervisor
+	// is killed. 0 kills the hypervisor right away.
+	ShutdownGracePeriod uint32
+
+	// AFXDPQueues is the number of interface queues the af_xdp
+	// internetworking model binds AF_XDP sockets to, and of queue pairs of
+	// the guest network device.
+	AFXDPQueues uint32
+
+	// AFXDPStartQueue is the first interface queue the af_xdp
+	// internetworking model binds AF_XDP sockets to.
+	AFXDPStartQueue uint32
+
+	// AFXDPBusyPollTimeout is the time in microseconds the interface keeps
+	// polling with its interrupts masked after a poll with the af_xdp
+	// internetworking model. 0 leaves the interface settings unchanged.
+	AFXDPBusyPollTimeout uint32
+
+	// EntropyMaxBytes is the number of bytes the guest can read from the
+	// virtio-rng device per EntropyPeriod. 0 does not limit the device.
+	EntropyMaxBytes uint32
+
+	// EntropyPeriod is the rate limiting period of the virtio-rng device in
+	// milliseconds.
+	EntropyPeriod uint32
+
 	// VirtioFSCacheSize is the DAX cache size in MiB
 	VirtioFSCacheSize uint32
 
@@ -583,6 +829,11 @@ type HypervisorConfig struct {
 	// HugePages specifies if the memory should be pre-allocated from huge pages
 	HugePages bool
 
+	// ReclaimGuestFreedMemory adds a balloon device with free page reporting,
+	// so that memory freed by the guest is returned to the host, and uses it
+	// to keep the guest memory within the sandbox memory limit.
+	ReclaimGuestFreedMemory bool
+
 	// VirtioMem is used to enable/disable virtio-mem
 	VirtioMem bool
 
@@ -592,6 +843,10 @@ type HypervisorConfig struct {
 	// IOMMUPlatform is used to indicate if IOMMU_PLATFORM is enabled for supported devices
 	IOMMUPlatform bool
 
+	// EnableVTPM adds a TPM 2.0 device to the VM, emulated by a swtpm
+	// instance running for the lifetime of the sandbox.
+	EnableVTPM bool
+
 	// DisableNestingChecks is used to override customizations performed
 	// when running on top of another VMM.
 	DisableNestingChecks bool
@@ -599,10 +854,23 @@ type HypervisorConfig struct {
 	// DisableImageNvdimm is used to disable guest rootfs image nvdimm devices
 	DisableImageNvdimm bool
 
+	// SharedBootAssets ensures the guests boot from assets shared by the
+	// sandboxes: the root filesystem is read through DAX from the host page
+	// cache of the guest image, and the kernel copies are merged by KSM
+	SharedBootAssets bool
+
+	// PmemVolumesSize is the guest physical memory reserved, in MiB, for
+	// mapping the volumes on persistent memory as NVDIMMs, 0 disables them
+	PmemVolumesSize uint32
+
 	// HotplugVFIOOnRootBus is used to indicate if devices need to be hotplugged on the
 	// root bus instead of a bridge.
 	HotplugVFIOOnRootBus bool
 
+	// PCIeP2P enables the peer-to-peer DMA between the VFIO devices
+	// sharing a PCIe switch on the host.
+	PCIeP2P bool
+
 	// GuestMemoryDumpPaging is used to indicate if enable paging
 	// for QEMU dump-guest-memory command
 	GuestMemoryDumpPaging bool
@@ -615,6 +883,15 @@ type HypervisorConfig struct {
 	// Enable SEV-SNP guests on AMD machines capable of both
 	SevSnpGuest bool
 
+	// CCAMeasurementLog makes the hypervisor log the measurements of the
+	// boot artifacts of an Arm CCA Realm, for the guest firmware to hand
+	// them over to the attestation components.
+	CCAMeasurementLog bool
+
+	// RequireTDISPDevices refuses to pass through to a confidential guest
+	// the devices which cannot be attested with TDISP.
+	RequireTDISPDevices bool
+
 	// BootToBeTemplate used to indicate if the VM is created to be a template VM
 	BootToBeTemplate bool
 
@@ -644,6 +921,11 @@ type HypervisorConfig struct {
 
 	// Use legacy serial for the guest console
 	LegacySerial bool
+
+	// VirtioFSSubmounts makes virtiofsd announce the submounts of the shared
+	// directory to the guest, so that the mounts propagated from the host are
+	// seen as distinct filesystems.
+	VirtioFSSubmounts bool
 }
 
 // vcpu mapping from vcpu number to thread number
@@ -777,6 +1059,13 @@ func (conf *HypervisorConfig) IfPVPanicEnabled() bool {
 	return conf.GuestMemoryDumpPath != ""
 }
 
+// MemoryMergeable returns true if guest memory may be merged by KSM,
+// i.e. unless the KSM policy is off. Sharing pages across VMs opens a side
+// channel, so it is never allowed for confidential guests.
+func (conf *HypervisorConfig) MemoryMergeable() bool {
+	return conf.MemMerge != MemMergeOff && !conf.ConfidentialGuest
+}
+
 // HypervisorCtlAssetPath returns the VM hypervisor ctl path
 func (conf *HypervisorConfig) HypervisorCtlAssetPath() (string, error) {
 	return conf.assetPath(types.HypervisorCtlAsset)
@@ -975,10 +1264,15 @@ const (
 	// https://www.kernel.org/doc/html/latest/virt/kvm/s390-pv.html
 	// Exclude from lint checking for it won't be used on arm64 code
 	seProtection
+
+	// Arm Confidential Compute Architecture (Realms)
+	// https://www.arm.com/architecture/security-features/arm-confidential-compute-architecture
+	ccaProtection
 )
 
 var guestProtectionStr = [...]string{
 	noneProtection: "none",
+	ccaProtection:  "cca",
 	pefProtection:  "pef",
 	seProtection:   "se",
 	sevProtection:  "sev",
@@ -1043,4 +1337,8 @@ type Hypervisor interface {
 
 	// check if hypervisor supports built-in rate limiter.
 	IsRateLimiterBuiltin() bool
+
+	// FlushDrives writes the data the hypervisor caches for the block
+	// drives of IDs driveIDs back to their backing storage.
+	FlushDrives(ctx context.Context, driveIDs []string) error
 }
@@ -6,11 +6,16 @@
 package virtcontainers
 
 import (
+	"encoding/base64"
 	"fmt"
 
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/config"
 )
 
+// ccaPersonalizationValueSize is the size of the Realm Personalization Value
+// of an Arm CCA guest.
+const ccaPersonalizationValueSize = 64
+
 func validateHypervisorConfig(conf *HypervisorConfig) error {
 
 	if conf.KernelPath == "" {
@@ -59,6 +64,83 @@ func validateHypervisorConfig(conf *HypervisorConfig) error {
 		conf.DefaultMaxVCPUs = conf.NumVCPUs
 	}
 
+	switch conf.MemoryTHP {
+	case "", MemoryTHPAlways, MemoryTHPMadvise, MemoryTHPNever:
+	default:
+		return fmt.Errorf("Invalid transparent huge page policy %q", conf.MemoryTHP)
+	}
+
+	switch conf.MemMerge {
+	case "", MemMergeOn, MemMergeOff:
+	default:
+		return fmt.Errorf("Invalid KSM policy %q", conf.MemMerge)
+	}
+
+	switch conf.EntropyBackend {
+	case "", EntropyBackendRandom, EntropyBackendBuiltin:
+	default:
+		return fmt.Errorf("Invalid entropy backend %q", conf.EntropyBackend)
+	}
+
+	if conf.EntropyMaxBytes > 0 && conf.EntropyPeriod == 0 {
+		conf.EntropyPeriod = defaultEntropyPeriodMs
+	}
+
+	switch conf.PCIHotplugMode {
+	case "", PCIHotplugAuto, PCIHotplugACPI, PCIHotplugNative:
+	default:
+		return fmt.Errorf("Invalid PCI hotplug mode %q", conf.PCIHotplugMode)
+	}
+
+	switch conf.AFXDPMode {
+	case "", AFXDPModeNative, AFXDPModeSKB:
+	default:
+		return fmt.Errorf("Invalid AF_XDP mode %q", conf.AFXDPMode)
+	}
+
+	switch conf.VirtioGPU {
+	case "":
+	case VirtioGPUVenus, VirtioGPUDRM:
+		if conf.VirtioGPUHostMemMB == 0 {
+			conf.VirtioGPUHostMemMB = defaultVirtioGPUHostMemMB
+		}
+	default:
+		return fmt.Errorf("Invalid virtio-gpu backend %q", conf.VirtioGPU)
+	}
+
+	if conf.IvshmemServerPath != "" {
+		if conf.ShmChannelSizeMB == 0 {
+			conf.ShmChannelSizeMB = defaultShmChannelSizeMB
+		}
+		if conf.ShmChannelSizeMB&(conf.ShmChannelSizeMB-1) != 0 {
+			return fmt.Errorf("Invalid shared memory channel size %d MiB, it must be a power of 2", conf.ShmChannelSizeMB)
+		}
+	}
+
+	switch conf.CCAMeasurementAlgorithm {
+	case "", CCAMeasurementSHA256, CCAMeasurementSHA512:
+	default:
+		return fmt.Errorf("Invalid Arm CCA measurement algorithm %q", conf.CCAMeasurementAlgorithm)
+	}
+
+	if conf.CCAPersonalizationValue != "" {
+		rpv, err := base64.StdEncoding.DecodeString(conf.CCAPersonalizationValue)
+		if err != nil {
+			return fmt.Errorf("Invalid Arm CCA personalization value: %v", err)
+		}
+		if len(rpv) > ccaPersonalizationValueSize {
+			return fmt.Errorf("Invalid Arm CCA personalization value of %d bytes, it must be at most %d bytes", len(rpv), ccaPersonalizationValueSize)
+		}
+	}
+
+	if conf.EnableVTPM && conf.SwtpmPath == "" {
+		return fmt.Errorf("swtpm path must be set to enable the vTPM")
+	}
+
+	if conf.EnableVTPM && conf.VTPMStatePath == "" {
+		return fmt.Errorf("vTPM state path must be set to enable the vTPM")
+	}
+
 	if conf.Msize9p == 0 && conf.SharedFS != config.VirtioFS {
 		conf.Msize9p = defaultMsize9p
 	}
@@ -6,6 +6,7 @@
 package virtcontainers
 
 import (
+	"encoding/base64"
 	"fmt"
 	"testing"
 
@@ -53,6 +54,90 @@ func TestHypervisorConfigBothInitrdAndImage(t *testing.T) {
 	testHypervisorConfigValid(t, hypervisorConfig, false)
 }
 
+func TestHypervisorConfigMemoryTHP(t *testing.T) {
+	hypervisorConfig := &HypervisorConfig{
+		KernelPath:     fmt.Sprintf("%s/%s", testDir, testKernel),
+		ImagePath:      fmt.Sprintf("%s/%s", testDir, testImage),
+		HypervisorPath: fmt.Sprintf("%s/%s", testDir, testHypervisor),
+		MemoryTHP:      MemoryTHPMadvise,
+	}
+
+	testHypervisorConfigValid(t, hypervisorConfig, true)
+
+	hypervisorConfig.MemoryTHP = "sometimes"
+	testHypervisorConfigValid(t, hypervisorConfig, false)
+}
+
+func TestHypervisorConfigEntropy(t *testing.T) {
+	hypervisorConfig := &HypervisorConfig{
+		KernelPath:      fmt.Sprintf("%s/%s", testDir, testKernel),
+		ImagePath:       fmt.Sprintf("%s/%s", testDir, testImage),
+		HypervisorPath:  fmt.Sprintf("%s/%s", testDir, testHypervisor),
+		EntropyBackend:  EntropyBackendBuiltin,
+		EntropyMaxBytes: 1024,
+	}
+
+	testHypervisorConfigValid(t, hypervisorConfig, true)
+	assert.Equal(t, uint32(defaultEntropyPeriodMs), hypervisorConfig.EntropyPeriod)
+
+	hypervisorConfig.EntropyBackend = "rdrand"
+	testHypervisorConfigValid(t, hypervisorConfig, false)
+}
+
+func TestHypervisorConfigVirtioGPU(t *testing.T) {
+	hypervisorConfig := &HypervisorConfig{
+		KernelPath:     fmt.Sprintf("%s/%s", testDir, testKernel),
+		ImagePath:      fmt.Sprintf("%s/%s", testDir, testImage),
+		HypervisorPath: fmt.Sprintf("%s/%s", testDir, testHypervisor),
+		VirtioGPU:      VirtioGPUVenus,
+	}
+
+	testHypervisorConfigValid(t, hypervisorConfig, true)
+	assert.Equal(t, uint32(defaultVirtioGPUHostMemMB), hypervisorConfig.VirtioGPUHostMemMB)
+
+	hypervisorConfig.VirtioGPU = "opengl"
+	testHypervisorConfigValid(t, hypervisorConfig, false)
+}
+
+func TestHypervisorConfigVTPM(t *testing.T) {
+	hypervisorConfig := &HypervisorConfig{
+		KernelPath:     fmt.Sprintf("%s/%s", testDir, testKernel),
+		ImagePath:      fmt.Sprintf("%s/%s", testDir, testImage),
+		HypervisorPath: fmt.Sprintf("%s/%s", testDir, testHypervisor),
+		EnableVTPM:     true,
+	}
+
+	testHypervisorConfigValid(t, hypervisorConfig, false)
+
+	hypervisorConfig.SwtpmPath = "/usr/bin/swtpm"
+	testHypervisorConfigValid(t, hypervisorConfig, false)
+
+	hypervisorConfig.VTPMStatePath = "/var/lib/kata-containers/vtpm"
+	testHypervisorConfigValid(t, hypervisorConfig, true)
+}
+
+func TestHypervisorConfigCCA(t *testing.T) {
+	hypervisorConfig := &HypervisorConfig{
+		KernelPath:              fmt.Sprintf("%s/%s", testDir, testKernel),
+		ImagePath:               fmt.Sprintf("%s/%s", testDir, testImage),
+		HypervisorPath:          fmt.Sprintf("%s/%s", testDir, testHypervisor),
+		CCAMeasurementAlgorithm: CCAMeasurementSHA512,
+		CCAPersonalizationValue: base64.StdEncoding.EncodeToString(make([]byte, 64)),
+	}
+
+	testHypervisorConfigValid(t, hypervisorConfig, true)
+
+	hypervisorConfig.CCAMeasurementAlgorithm = "md5"
+	testHypervisorConfigValid(t, hypervisorConfig, false)
+
+	hypervisorConfig.CCAMeasurementAlgorithm = CCAMeasurementSHA256
+	hypervisorConfig.CCAPersonalizationValue = base64.StdEncoding.EncodeToString(make([]byte, 65))
+	testHypervisorConfigValid(t, hypervisorConfig, false)
+
+	hypervisorConfig.CCAPersonalizationValue = "not base64"
+	testHypervisorConfigValid(t, hypervisorConfig, false)
+}
+
 func TestHypervisorConfigSecureExecution(t *testing.T) {
 	hypervisorConfig := &HypervisorConfig{
 		KernelPath:            fmt.Sprintf("%s/%s", testDir, testKernel),
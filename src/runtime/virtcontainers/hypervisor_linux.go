@@ -6,14 +6,69 @@
 package virtcontainers
 
 import (
+	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
+	"golang.org/x/sys/unix"
 )
 
+// prTHPDisableExceptAdvised is the PR_SET_THP_DISABLE flag that keeps
+// transparent huge pages for madvise(MADV_HUGEPAGE) regions only.
+const prTHPDisableExceptAdvised = 1 << 1
+
+// setTHPPolicy sets the transparent huge page policy of the current process.
+// The policy is inherited across fork and exec, which is how it reaches the
+// hypervisor process.
+// The always policy clears PR_SET_THP_DISABLE, which leaves the process with
+// the system-wide policy of the host: it does not force huge pages when the
+// host only uses them for advised regions.
+func setTHPPolicy(policy string) error {
+	var disable, flags uintptr
+
+	switch policy {
+	case MemoryTHPAlways:
+	case MemoryTHPMadvise:
+		disable, flags = 1, prTHPDisableExceptAdvised
+	case MemoryTHPNever:
+		disable = 1
+	default:
+		return fmt.Errorf("Invalid transparent huge page policy %q", policy)
+	}
+
+	if err := unix.Prctl(unix.PR_SET_THP_DISABLE, disable, flags, 0, 0); err != nil {
+		return fmt.Errorf("Could not set transparent huge page policy %q: %v", policy, err)
+	}
+
+	return nil
+}
+
+var (
+	thpMadviseOnce      sync.Once
+	thpMadviseAvailable bool
+)
+
+// thpMadviseSupported returns true if the host kernel accepts the
+// PR_THP_DISABLE_EXCEPT_ADVISED flag the madvise policy relies on, older
+// kernels reject it with EINVAL.
+// The function is declared this way for mocking in unit tests.
+var thpMadviseSupported = func() bool {
+	thpMadviseOnce.Do(func() {
+		err := unix.Prctl(unix.PR_SET_THP_DISABLE, 1, prTHPDisableExceptAdvised, 0, 0)
+		thpMadviseAvailable = !errors.Is(err, unix.EINVAL)
+		if err == nil {
+			if err := unix.Prctl(unix.PR_SET_THP_DISABLE, 0, 0, 0, 0); err != nil {
+				hvLogger.WithError(err).Warn("failed to restore transparent huge page policy")
+			}
+		}
+	})
+	return thpMadviseAvailable
+}
+
 func generateVMSocket(id string, vmStogarePath string) (interface{}, error) {
-	vhostFd, contextID, err := utils.FindContextID()
+	vhostFd, contextID, err := utils.FindContextIDFrom(sandboxContextID(id))
 	if err != nil {
 		return nil, err
 	}
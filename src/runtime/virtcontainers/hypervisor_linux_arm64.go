@@ -4,7 +4,36 @@
 
 package virtcontainers
 
-// Guest protection is not supported on ARM64.
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	kvmDevice = "/dev/kvm"
+
+	// KVM_CHECK_EXTENSION, _IO(KVMIO, 0x03)
+	kvmCheckExtension = 0xAE03
+
+	// KVM_CAP_ARM_RME, reported by the Arm CCA host support of KVM when
+	// the hardware has the Realm Management Extension and the RMM
+	// firmware is running.
+	kvmCapArmRME = 300
+)
+
+// Arm CCA is supported when KVM can create Realms.
 func availableGuestProtection() (guestProtection, error) {
-	return noneProtection, nil
+	f, err := os.Open(kvmDevice)
+	if err != nil {
+		return noneProtection, nil
+	}
+	defer f.Close()
+
+	ret, _, errno := unix.Syscall(unix.SYS_IOCTL, f.Fd(), kvmCheckExtension, kvmCapArmRME)
+	if errno != 0 || ret == 0 {
+		return noneProtection, nil
+	}
+
+	return ccaProtection, nil
 }
@@ -0,0 +1,10 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package virtcontainers
+
+// Guest protection is not supported on RISC-V.
+func availableGuestProtection() (guestProtection, error) {
+	return noneProtection, nil
+}
@@ -490,3 +490,20 @@ func TestAssetPath(t *testing.T) {
 		assert.Equal(expected, p, msg)
 	}
 }
+
+func TestMemoryMergeable(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := HypervisorConfig{}
+	assert.True(conf.MemoryMergeable())
+
+	conf.MemMerge = MemMergeOn
+	assert.True(conf.MemoryMergeable())
+
+	conf.MemMerge = MemMergeOff
+	assert.False(conf.MemoryMergeable())
+
+	conf.MemMerge = MemMergeOn
+	conf.ConfidentialGuest = true
+	assert.False(conf.MemoryMergeable())
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/prometheus/procfs"
+)
+
+// The roles of the hypervisor threads.
+const (
+	// HypervisorThreadVCPU runs the guest code.
+	HypervisorThreadVCPU = "vcpu"
+	// HypervisorThreadIO emulates the IO of the virtio devices.
+	HypervisorThreadIO = "iothread"
+	// HypervisorThreadEmulator runs the main loop, the API and the
+	// workers of the hypervisor.
+	HypervisorThreadEmulator = "emulator"
+)
+
+// userHZ is the unit of the CPU times of /proc/<pid>/stat.
+const userHZ = 100
+
+var (
+	// vcpuThreadName matches the vCPU threads of QEMU, Cloud Hypervisor and
+	// Firecracker.
+	vcpuThreadName = regexp.MustCompile(`^(CPU \d+/|vcpu\d+$|fc_vcpu)`)
+
+	// ioThreadNamePrefixes match the iothreads of QEMU and the virtio
+	// device threads of Cloud Hypervisor and Dragonball.
+	ioThreadNamePrefixes = []string{"IO ", "iothread", "virtio", "_disk", "_net", "_fs", "_pmem", "_rng", "_vsock", "_balloon", "_console"}
+)
+
+// HypervisorThreadStats is the host CPU usage of the hypervisor threads of a
+// role.
+type HypervisorThreadStats struct {
+	Role          string
+	Threads       int
+	UserSeconds   float64
+	SystemSeconds float64
+}
+
+// hypervisorThreadRole tells the role of a hypervisor thread from its name.
+// The vCPU threads reported by the hypervisor are trusted over the names.
+func hypervisorThreadRole(tid int, name string, vcpus map[int]bool) string {
+	if vcpus[tid] || vcpuThreadName.MatchString(name) {
+		return HypervisorThreadVCPU
+	}
+	for _, prefix := range ioThreadNamePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return HypervisorThreadIO
+		}
+	}
+	return HypervisorThreadEmulator
+}
+
+// hypervisorThreadStats breaks the CPU usage of the hypervisor process down
+// by thread role, from the stat files of its tasks. All the roles are
+// returned, the vCPUs first.
+func hypervisorThreadStats(procRoot string, pid int, vcpus map[int]bool) ([]HypervisorThreadStats, error) {
+	fs, err := procfs.NewFS(filepath.Join(procRoot, fmt.Sprint(pid), "task"))
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := fs.AllProcs()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := []HypervisorThreadStats{
+		{Role: HypervisorThreadVCPU},
+		{Role: HypervisorThreadIO},
+		{Role: HypervisorThreadEmulator},
+	}
+	for _, task := range tasks {
+		stat, err := task.Stat()
+		if err != nil {
+			// The thread exited in the meantime.
+			continue
+		}
+		role := hypervisorThreadRole(task.PID, stat.Comm, vcpus)
+		for i := range stats {
+			if stats[i].Role == role {
+				stats[i].Threads++
+				stats[i].UserSeconds += float64(stat.UTime) / userHZ
+				stats[i].SystemSeconds += float64(stat.STime) / userHZ
+			}
+		}
+	}
+
+	return stats, nil
+}
+
+// vcpuThreads returns the set of the thread IDs of the vCPUs.
+func (t VcpuThreadIDs) vcpuThreads() map[int]bool {
+	vcpus := make(map[int]bool, len(t.vcpus))
+	for _, tid := range t.vcpus {
+		vcpus[tid] = true
+	}
+	return vcpus
+}
+
+// HypervisorThreadStats returns the CPU usage of the hypervisor threads by
+// role.
+func (s *Sandbox) HypervisorThreadStats(ctx context.Context) ([]HypervisorThreadStats, error) {
+	pids := s.hypervisor.GetPids()
+	if len(pids) == 0 {
+		return nil, nil
+	}
+
+	// The names of the threads are used when the hypervisor does not
+	// report the vCPU threads.
+	tids, err := s.hypervisor.GetThreadIDs(ctx)
+	if err != nil {
+		s.Logger().WithError(err).Debug("failed to get the vCPU threads")
+	}
+
+	return hypervisorThreadStats("/proc", pids[0], tids.vcpuThreads())
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHypervisorThreadRole(t *testing.T) {
+	assert := assert.New(t)
+
+	data := []struct {
+		name string
+		role string
+	}{
+		{"qemu-system-x86", HypervisorThreadEmulator},
+		{"CPU 0/KVM", HypervisorThreadVCPU},
+		{"IO iothread0", HypervisorThreadIO},
+		{"IO mon_iothread", HypervisorThreadIO},
+		{"worker", HypervisorThreadEmulator},
+		{"vcpu12", HypervisorThreadVCPU},
+		{"vcpu_affinity", HypervisorThreadEmulator},
+		{"_disk0_q0", HypervisorThreadIO},
+		{"_net1_qp0", HypervisorThreadIO},
+		{"http-server", HypervisorThreadEmulator},
+		{"fc_vcpu 1", HypervisorThreadVCPU},
+		{"fc_vmm", HypervisorThreadEmulator},
+	}
+	for _, d := range data {
+		assert.Equal(d.role, hypervisorThreadRole(100, d.name, nil), d.name)
+	}
+
+	// The vCPUs reported by the hypervisor are trusted over the names.
+	assert.Equal(HypervisorThreadVCPU, hypervisorThreadRole(100, "worker", map[int]bool{100: true}))
+}
+
+func writeTaskStat(t *testing.T, root string, pid, tid int, comm string, utime, stime uint64) {
+	dir := filepath.Join(root, fmt.Sprint(pid), "task", fmt.Sprint(tid))
+	assert.NoError(t, os.MkdirAll(dir, 0755))
+	stat := fmt.Sprintf("%d (%s) S 1 1 1 0 -1 0 0 0 0 0 %d %d 0 0 20 0 1 0 100 1000 10 0%s\n",
+		tid, comm, utime, stime, strings.Repeat(" 0", 20))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "stat"), []byte(stat), 0644))
+}
+
+func TestHypervisorThreadStats(t *testing.T) {
+	assert := assert.New(t)
+
+	root := t.TempDir()
+	writeTaskStat(t, root, 10, 10, "qemu-system-x86", 100, 50)
+	writeTaskStat(t, root, 10, 11, "IO iothread0", 20, 30)
+	writeTaskStat(t, root, 10, 12, "CPU 0/KVM", 1000, 10)
+	writeTaskStat(t, root, 10, 13, "CPU 1/KVM", 500, 10)
+	writeTaskStat(t, root, 10, 14, "worker", 1, 1)
+
+	stats, err := hypervisorThreadStats(root, 10, nil)
+	assert.NoError(err)
+	assert.Equal([]HypervisorThreadStats{
+		{Role: HypervisorThreadVCPU, Threads: 2, UserSeconds: 15, SystemSeconds: 0.2},
+		{Role: HypervisorThreadIO, Threads: 1, UserSeconds: 0.2, SystemSeconds: 0.3},
+		{Role: HypervisorThreadEmulator, Threads: 2, UserSeconds: 1.01, SystemSeconds: 0.51},
+	}, stats)
+
+	_, err = hypervisorThreadStats(root, 20, nil)
+	assert.Error(err)
+}
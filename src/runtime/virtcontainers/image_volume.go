@@ -0,0 +1,105 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// Image volumes, the volumes of the Kubernetes image volume source, are
+// read-only mounts of an image prepared by the snapshotter of the host. When
+// the image is an erofs filesystem on a block device, e.g. with the erofs
+// snapshotter, the device is attached read-only to the guest and the image
+// is mounted there, rather than shared file by file. The other image
+// volumes are shared read-only.
+
+const imageVolumeFsType = "erofs"
+
+// hostMountInfoPath is the mountinfo file of the runtime.
+var hostMountInfoPath = "/proc/self/mountinfo"
+
+// isImageVolume tells whether a mount source is an image volume.
+func (sandboxConfig *SandboxConfig) isImageVolume(source string) bool {
+	for _, pattern := range sandboxConfig.ImageVolumePaths {
+		if ok, _ := filepath.Match(pattern, source); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// imageVolumeDevice returns the block device of the erofs filesystem mounted
+// at path, or an empty string if path is not the mount point of one.
+func imageVolumeDevice(path string) (string, error) {
+	f, err := os.Open(hostMountInfoPath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	unescape := strings.NewReplacer(`\040`, " ", `\011`, "\t", `\012`, "\n", `\134`, `\`)
+
+	// The last mount of the mount point is the one visible.
+	device := ""
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// The optional fields end with a "-" separator, followed by the
+		// filesystem type and the mount source.
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 || unescape.Replace(fields[4]) != path {
+			continue
+		}
+
+		device = ""
+		for i := 5; i+2 < len(fields); i++ {
+			if fields[i] == "-" {
+				if fields[i+1] == imageVolumeFsType {
+					device = unescape.Replace(fields[i+2])
+				}
+				break
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil || device == "" {
+		return "", err
+	}
+
+	var stat unix.Stat_t
+	if err := unix.Stat(device, &stat); err != nil || stat.Mode&unix.S_IFMT != unix.S_IFBLK {
+		// e.g. an image file mounted without a loop device
+		return "", nil
+	}
+
+	return device, nil
+}
+
+// attachImageVolume prepares the attachment of an image volume mount: its
+// erofs device is attached if it has one, otherwise it is shared
+// read-only.
+func (c *Container) attachImageVolume(m *Mount) error {
+	device, err := imageVolumeDevice(m.Source)
+	if err != nil {
+		return err
+	}
+
+	m.ReadOnly = true
+	if device == "" {
+		return nil
+	}
+
+	c.Logger().WithField("volume", m.Destination).WithField("device", device).
+		Info("attaching the image volume device")
+	m.Source = device
+	m.Type = imageVolumeFsType
+	m.Options = []string{"ro"}
+
+	return nil
+}
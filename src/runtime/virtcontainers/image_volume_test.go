@@ -0,0 +1,62 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageVolumes(t *testing.T) {
+	assert := assert.New(t)
+
+	volumes := "/var/lib/containerd/io.containerd.grpc.v1.cri/image-volumes"
+	mountInfo := filepath.Join(t.TempDir(), "mountinfo")
+	assert.NoError(os.WriteFile(mountInfo, []byte(
+		"22 1 8:1 / / rw,relatime shared:1 - ext4 /dev/sda1 rw\n"+
+			"40 22 0:40 / "+volumes+"/overlay rw shared:20 - overlay overlay ro,lowerdir=/l1:/l2\n"+
+			"41 22 7:0 / "+volumes+"/erofs\\040image ro shared:21 - erofs /dev/kata-missing-loop ro\n"+
+			"42 22 0:41 / "+volumes+"/remounted ro master:3 - erofs /dev/kata-missing-loop ro\n"+
+			"43 42 0:42 / "+volumes+"/remounted ro - tmpfs tmpfs ro\n"), 0644))
+
+	savedMountInfoPath := hostMountInfoPath
+	hostMountInfoPath = mountInfo
+	defer func() { hostMountInfoPath = savedMountInfoPath }()
+
+	config := &SandboxConfig{ImageVolumePaths: []string{volumes + "/*"}}
+	assert.True(config.isImageVolume(volumes + "/overlay"))
+	assert.False(config.isImageVolume("/var/lib/kubelet/pods/volume"))
+	assert.False((&SandboxConfig{}).isImageVolume(volumes + "/overlay"))
+
+	for _, path := range []string{
+		// not erofs
+		volumes + "/overlay",
+		// the erofs device is not a block device
+		volumes + "/erofs image",
+		// the erofs mount is hidden
+		volumes + "/remounted",
+		// not a mount point
+		volumes,
+	} {
+		device, err := imageVolumeDevice(path)
+		assert.NoError(err)
+		assert.Empty(device, path)
+	}
+
+	// Image volumes without a device are shared read-only
+	c := &Container{}
+	m := Mount{Source: volumes + "/overlay", Destination: "/model", Type: "bind"}
+	assert.NoError(c.attachImageVolume(&m))
+	assert.True(m.ReadOnly)
+	assert.Equal(volumes+"/overlay", m.Source)
+	assert.Equal("bind", m.Type)
+
+	hostMountInfoPath = filepath.Join(t.TempDir(), "missing")
+	assert.Error(c.attachImageVolume(&m))
+}
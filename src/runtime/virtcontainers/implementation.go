@@ -41,3 +41,8 @@ func (impl *VCImpl) CreateSandbox(ctx context.Context, sandboxConfig SandboxConf
 func (impl *VCImpl) CleanupContainer(ctx context.Context, sandboxID, containerID string, force bool) error {
 	return CleanupContainer(ctx, sandboxID, containerID, force)
 }
+
+// AdoptSandbox implements the VC function of the same name.
+func (impl *VCImpl) AdoptSandbox(ctx context.Context, sandboxID string) (VCSandbox, error) {
+	return AdoptSandbox(ctx, sandboxID)
+}
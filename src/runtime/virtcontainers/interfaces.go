@@ -12,6 +12,7 @@ import (
 
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/api"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/multipath"
 	pbTypes "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
@@ -25,6 +26,7 @@ type VC interface {
 
 	CreateSandbox(ctx context.Context, sandboxConfig SandboxConfig, hookFunc func(context.Context) error) (VCSandbox, error)
 	CleanupContainer(ctx context.Context, sandboxID, containerID string, force bool) error
+	AdoptSandbox(ctx context.Context, sandboxID string) (VCSandbox, error)
 }
 
 // VCSandbox is the Sandbox interface
@@ -44,6 +46,8 @@ type VCSandbox interface {
 	Stop(ctx context.Context, force bool) error
 	Release(ctx context.Context) error
 	Monitor(ctx context.Context) (chan error, error)
+	CanRestart() bool
+	Restart(ctx context.Context) error
 	Delete(ctx context.Context) error
 	Status() SandboxStatus
 	CreateContainer(ctx context.Context, contConfig ContainerConfig) (VCContainer, error)
@@ -55,6 +59,8 @@ type VCSandbox interface {
 	StatsContainer(ctx context.Context, containerID string) (ContainerStats, error)
 	PauseContainer(ctx context.Context, containerID string) error
 	ResumeContainer(ctx context.Context, containerID string) error
+	CheckpointContainer(ctx context.Context, containerID, imageDir string, opts CheckpointOptions) error
+	RestoreContainer(ctx context.Context, containerID, imageDir string) (VCContainer, error)
 	EnterContainer(ctx context.Context, containerID string, cmd types.Cmd) (VCContainer, *Process, error)
 	UpdateContainer(ctx context.Context, containerID string, resources specs.LinuxResources) error
 	WaitProcess(ctx context.Context, containerID, processID string) (int32, error)
@@ -76,9 +82,20 @@ type VCSandbox interface {
 	UpdateRuntimeMetrics() error
 	GetAgentMetrics(ctx context.Context) (string, error)
 	GetAgentURL() (string, error)
+	GetSeccompReport() ([]SeccompViolation, error)
+	SeccompViolations() <-chan SeccompViolation
+	CPUMitigations() CPUMitigations
+	MultipathEvents() <-chan multipath.Event
 
 	GuestVolumeStats(ctx context.Context, volumePath string) ([]byte, error)
+	ContainerVolumeStats(ctx context.Context, containerID string) ([]ContainerVolumeStats, error)
 	ResizeGuestVolume(ctx context.Context, volumePath string, size uint64) error
+	Quiesce(ctx context.Context) error
+	Unquiesce(ctx context.Context) error
+	GuestServices(ctx context.Context) ([]GuestServiceStatus, error)
+	SetNetworkPolicy(ctx context.Context, policy *NetworkPolicy) error
+	GetSeccompNotification(ctx context.Context) (*SeccompNotification, error)
+	RespondSeccompNotification(ctx context.Context, resp SeccompNotificationResponse) error
 
 	GetIPTables(ctx context.Context, isIPv6 bool) ([]byte, error)
 	SetIPTables(ctx context.Context, isIPv6 bool, data []byte) error
@@ -15,7 +15,9 @@ type iostream struct {
 	sandbox   *Sandbox
 	container *Container
 	process   string
-	closed    bool
+	// tail keeps the tail of the output of the process
+	tail   io.Writer
+	closed bool
 }
 
 // io.WriteCloser
@@ -83,7 +85,11 @@ func (s *stdoutStream) Read(data []byte) (n int, err error) {
 	}
 
 	// can not pass context to Read(), so use background context
-	return s.sandbox.agent.readProcessStdout(context.Background(), s.container, s.process, data)
+	n, err = s.sandbox.agent.readProcessStdout(context.Background(), s.container, s.process, data)
+	if s.tail != nil && n > 0 {
+		s.tail.Write(data[:n])
+	}
+	return n, err
 }
 
 func (s *stderrStream) Read(data []byte) (n int, err error) {
@@ -92,5 +98,9 @@ func (s *stderrStream) Read(data []byte) (n int, err error) {
 	}
 
 	// can not pass context to Read(), so use background context
-	return s.sandbox.agent.readProcessStderr(context.Background(), s.container, s.process, data)
+	n, err = s.sandbox.agent.readProcessStderr(context.Background(), s.container, s.process, data)
+	if s.tail != nil && n > 0 {
+		s.tail.Write(data[:n])
+	}
+	return n, err
 }
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"hash/fnv"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -23,6 +24,7 @@ import (
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/config"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/drivers"
 	volume "github.com/kata-containers/kata-containers/src/runtime/pkg/direct-volume"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/faultinject"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils/katatrace"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/uuid"
 	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
@@ -66,6 +68,18 @@ const (
 	// path to vfio devices
 	vfioPath = "/dev/vfio/"
 
+	// path to the render nodes of virtio-gpu devices
+	virtioGPUDevDir = "/dev/dri"
+
+	// SGX device nodes, provided by the guest kernel
+	sgxDevPattern = "/dev/sgx_*"
+
+	// FUSE device node, provided by the guest kernel
+	fuseDevPath = "/dev/fuse"
+
+	// prefix of the types of the mounts of user-space filesystems
+	fuseTypePrefix = "fuse."
+
 	NydusRootFSType = "fuse.nydus-overlayfs"
 
 	// enable debug console
@@ -75,6 +89,26 @@ const (
 
 	// Default SELinux type applied to the container process inside guest
 	defaultSeLinuxContainerType = "container_t"
+
+	// virtio-serial port used by the serial agent transport
+	agentSerialSocket   = "agent.sock"
+	agentSerialName     = "agent.channel.0"
+	agentSerialDeviceID = "channel0"
+	agentSerialID       = "charch0"
+)
+
+const (
+	// AgentTransportVSock talks to the agent over vsock, or hybrid vsock
+	// depending on the hypervisor. This is the default.
+	AgentTransportVSock = "vsock"
+
+	// AgentTransportSerial talks to the agent over a virtio-serial port,
+	// for hosts where vsock is not available.
+	AgentTransportSerial = "serial"
+
+	// AgentTransportAuto uses vsock when the host supports it and falls
+	// back to a virtio-serial port otherwise.
+	AgentTransportAuto = "auto"
 )
 
 var (
@@ -98,9 +132,14 @@ var (
 	kataVirtioFSDevType           = "virtio-fs"
 	kataOverlayDevType            = "overlayfs"
 	kataWatchableBindDevType      = "watchable-bind"
+	kataFuseDevType               = "fuse"
+	kataNfsDevType                = "nfs"
 	kataVfioPciDevType            = "vfio-pci"    // VFIO PCI device to used as VFIO in the container
 	kataVfioPciGuestKernelDevType = "vfio-pci-gk" // VFIO PCI device for consumption by the guest kernel
 	kataVfioApDevType             = "vfio-ap"
+	kataGuestDevType              = "guest"
+	kataVirtioGPUDevType          = "virtio-gpu"
+	kataRDMADevType               = "rdma"
 	sharedDir9pOptions            = []string{"trans=virtio,version=9p2000.L,cache=mmap", "nodev"}
 	sharedDirVirtioFSOptions      = []string{}
 	sharedDirVirtioFSDaxOptions   = "dax"
@@ -147,9 +186,23 @@ const (
 	grpcGetMetricsRequest                     = "grpc.GetMetricsRequest"
 	grpcAddSwapRequest                        = "grpc.AddSwapRequest"
 	grpcVolumeStatsRequest                    = "grpc.VolumeStatsRequest"
+	grpcContainerVolumeStatsRequest           = "grpc.ContainerVolumeStatsRequest"
 	grpcResizeVolumeRequest                   = "grpc.ResizeVolumeRequest"
 	grpcGetIPTablesRequest                    = "grpc.GetIPTablesRequest"
 	grpcSetIPTablesRequest                    = "grpc.SetIPTablesRequest"
+	grpcWaitDeviceRequest                     = "grpc.WaitDeviceRequest"
+	grpcSyncFsRequest                         = "grpc.SyncFsRequest"
+	grpcSetNameResolutionRequest              = "grpc.SetNameResolutionRequest"
+	grpcGetGuestServicesRequest               = "grpc.GetGuestServicesRequest"
+	grpcSetNetworkPolicyRequest               = "grpc.SetNetworkPolicyRequest"
+	grpcGetSeccompNotificationRequest         = "grpc.GetSeccompNotificationRequest"
+	grpcSeccompNotificationResponse           = "grpc.SeccompNotificationResponse"
+	grpcFreezeFsRequest                       = "grpc.FreezeFsRequest"
+	grpcThawFsRequest                         = "grpc.ThawFsRequest"
+	grpcReadFileRequest                       = "grpc.ReadFileRequest"
+	grpcGetDiagnosticsRequest                 = "grpc.GetDiagnosticsRequest"
+	grpcCheckpointContainerRequest            = "grpc.CheckpointContainerRequest"
+	grpcRestoreContainerRequest               = "grpc.RestoreContainerRequest"
 )
 
 // newKataAgent returns an agent from an agent type.
@@ -269,8 +322,10 @@ func ephemeralPath() string {
 // to reach the Kata Containers agent.
 type KataAgentConfig struct {
 	KernelModules      []string
+	Transport          string
 	ContainerPipeSize  uint32
 	DialTimeout        uint32
+	StreamConnections  uint32
 	LongLiveConn       bool
 	Debug              bool
 	Trace              bool
@@ -298,7 +353,13 @@ type kataAgent struct {
 	reqHandlers map[string]reqFunc
 	kmodules    []string
 
-	dialTimout uint32
+	// streamClients are dedicated agent connections carrying bulk data
+	// (process stdio and file copies), so that control requests such as
+	// health checks are never queued behind them.
+	streamClients []*kataclient.AgentClient
+
+	dialTimout  uint32
+	streamConns uint32
 
 	keepConn bool
 	dead     bool
@@ -363,6 +424,7 @@ func (k *kataAgent) init(ctx context.Context, sandbox *Sandbox, config KataAgent
 	k.keepConn = config.LongLiveConn
 	k.kmodules = config.KernelModules
 	k.dialTimout = config.DialTimeout
+	k.streamConns = config.StreamConnections
 
 	return disableVMShutdown, nil
 }
@@ -375,11 +437,48 @@ func (k *kataAgent) agentURL() (string, error) {
 		return s.String(), nil
 	case types.MockHybridVSock:
 		return s.String(), nil
+	case types.SerialSock:
+		return s.String(), nil
 	default:
 		return "", fmt.Errorf("Invalid socket type")
 	}
 }
 
+// agentSocket returns the socket used to talk to the agent with the
+// configured transport.
+func (k *kataAgent) agentSocket(ctx context.Context, h Hypervisor, id string, config KataAgentConfig) (interface{}, error) {
+	switch config.Transport {
+	case "", AgentTransportVSock:
+		return h.GenerateSocket(id)
+	case AgentTransportAuto:
+		sock, err := h.GenerateSocket(id)
+		if err == nil {
+			return sock, nil
+		}
+		caps := h.Capabilities(ctx)
+		if !caps.IsSerialPortSupported() {
+			return nil, err
+		}
+		k.Logger().WithError(err).Warn("vsock is not available, using a serial port to talk to the agent")
+		return agentSerialSock(h, id), nil
+	case AgentTransportSerial:
+		caps := h.Capabilities(ctx)
+		if !caps.IsSerialPortSupported() {
+			return nil, fmt.Errorf("agent transport %s is not supported by the hypervisor", config.Transport)
+		}
+		return agentSerialSock(h, id), nil
+	default:
+		return nil, fmt.Errorf("unknown agent transport %q", config.Transport)
+	}
+}
+
+func agentSerialSock(h Hypervisor, id string) types.SerialSock {
+	return types.SerialSock{
+		UdsPath: filepath.Join(h.HypervisorConfig().VMStorePath, id, agentSerialSocket),
+		Name:    agentSerialName,
+	}
+}
+
 func (k *kataAgent) capabilities() types.Capabilities {
 	var caps types.Capabilities
 
@@ -394,7 +493,7 @@ func (k *kataAgent) internalConfigure(ctx context.Context, h Hypervisor, id stri
 	defer span.End()
 
 	var err error
-	if k.vmSocket, err = h.GenerateSocket(id); err != nil {
+	if k.vmSocket, err = k.agentSocket(ctx, h, id, config); err != nil {
 		return err
 	}
 	k.keepConn = config.LongLiveConn
@@ -423,6 +522,16 @@ func (k *kataAgent) configure(ctx context.Context, h Hypervisor, id, sharePath s
 		if err != nil {
 			return err
 		}
+	case types.SerialSock:
+		socket := types.Socket{
+			DeviceID: agentSerialDeviceID,
+			ID:       agentSerialID,
+			HostPath: s.UdsPath,
+			Name:     s.Name,
+		}
+		if err = h.AddDevice(ctx, socket, SerialPortDev); err != nil {
+			return err
+		}
 	case types.MockHybridVSock:
 	default:
 		return types.ErrInvalidConfigType
@@ -747,6 +856,12 @@ func (k *kataAgent) startSandbox(ctx context.Context, sandbox *Sandbox) error {
 		return err
 	}
 
+	// The network interfaces hotplugged while the guest booted must be
+	// attached before they are set up.
+	if err = sandbox.waitLazyAttach(); err != nil {
+		return err
+	}
+
 	// Setup network interfaces and routes
 	interfaces, routes, neighs, err := generateVCNetworkStructures(ctx, sandbox.network)
 	if err != nil {
@@ -774,6 +889,7 @@ func (k *kataAgent) startSandbox(ctx context.Context, sandbox *Sandbox) error {
 		SandboxId:     sandbox.id,
 		GuestHookPath: sandbox.config.HypervisorConfig.GuestHookPath,
 		KernelModules: kmodules,
+		GuestServices: sandbox.config.GuestServices,
 	}
 
 	_, err = k.sendReq(ctx, req)
@@ -781,6 +897,13 @@ func (k *kataAgent) startSandbox(ctx context.Context, sandbox *Sandbox) error {
 		return err
 	}
 
+	// An agent without guest services support ignores them.
+	if len(sandbox.config.GuestServices) > 0 {
+		if _, err := k.getGuestServices(ctx); err == errUnimplemented {
+			return fmt.Errorf("the agent cannot run the guest services %v", sandbox.config.GuestServices)
+		}
+	}
+
 	return nil
 }
 
@@ -859,7 +982,7 @@ func setupStorages(ctx context.Context, sandbox *Sandbox) []*grpc.Storage {
 
 	if sandbox.shmSize > 0 {
 		path := filepath.Join(kataGuestSandboxDir(), shmDir)
-		shmSizeOption := fmt.Sprintf("size=%d", sandbox.shmSize)
+		shmSizeOption := fmt.Sprintf("size=%d", sandbox.guestShmSize())
 
 		shmStorage := &grpc.Storage{
 			Driver:     KataEphemeralDevType,
@@ -918,6 +1041,62 @@ func (k *kataAgent) removeIgnoredOCIMount(spec *specs.Spec, ignoredMounts map[st
 	return nil
 }
 
+// setGuestPidsLimit limits the number of processes of the container inside
+// the guest, unless the container has a limit of its own. The pids limit set
+// by the container manager on the pod cgroup only constrains the hypervisor
+// on the host.
+func setGuestPidsLimit(grpcSpec *grpc.Spec, limit uint64) {
+	if limit == 0 || grpcSpec.Linux == nil {
+		return
+	}
+
+	if grpcSpec.Linux.Resources == nil {
+		grpcSpec.Linux.Resources = &grpc.LinuxResources{}
+	}
+
+	if grpcSpec.Linux.Resources.Pids == nil {
+		grpcSpec.Linux.Resources.Pids = &grpc.LinuxPids{}
+	}
+
+	keepGuestPidsLimit(grpcSpec.Linux.Resources.Pids, limit)
+}
+
+// keepGuestPidsLimit sets the pids limit of a container without a limit of
+// its own, or whose limit is removed by an update, to the guest one.
+func keepGuestPidsLimit(pids *grpc.LinuxPids, limit uint64) {
+	if limit > 0 && pids.Limit <= 0 {
+		pids.Limit = int64(limit)
+	}
+}
+
+// constrainGRPCResources translates the resources of a container to the
+// guest, for the agent to apply them to the cgroup of the container. The
+// cpuset is mapped to the vCPUs by guestCPUs. The guest has a single memory
+// node, and numbers its block devices differently from the host, so the
+// memory nodes and the block IO limits of the devices are dropped, and only
+// the block IO weights are kept.
+func constrainGRPCResources(resources *grpc.LinuxResources, guestCPUs func(string) string) {
+	if resources == nil {
+		return
+	}
+
+	if resources.CPU != nil {
+		resources.CPU.Cpus = guestCPUs(resources.CPU.Cpus)
+		resources.CPU.Mems = ""
+	}
+
+	if blkio := resources.BlockIO; blkio != nil {
+		if blkio.Weight == 0 && blkio.LeafWeight == 0 {
+			resources.BlockIO = nil
+		} else {
+			resources.BlockIO = &grpc.LinuxBlockIO{
+				Weight:     blkio.Weight,
+				LeafWeight: blkio.LeafWeight,
+			}
+		}
+	}
+}
+
 func (k *kataAgent) constrainGRPCSpec(grpcSpec *grpc.Spec, passSeccomp bool, disableGuestSeLinux bool, guestSeLinuxLabel string, stripVfio bool) error {
 	// Disable Hooks since they have been handled on the host and there is
 	// no reason to send them to the agent. It would make no sense to try
@@ -960,17 +1139,12 @@ func (k *kataAgent) constrainGRPCSpec(grpcSpec *grpc.Spec, passSeccomp bool, dis
 		}
 	}
 
-	// By now only CPU constraints are supported
+	// The devices and the network are handled on the host, the other
+	// resources are translated to the guest by constrainGRPCResources.
 	// Issue: https://github.com/kata-containers/runtime/issues/158
 	// Issue: https://github.com/kata-containers/runtime/issues/204
 	grpcSpec.Linux.Resources.Devices = nil
-	grpcSpec.Linux.Resources.Pids = nil
-	grpcSpec.Linux.Resources.BlockIO = nil
 	grpcSpec.Linux.Resources.Network = nil
-	if grpcSpec.Linux.Resources.CPU != nil {
-		grpcSpec.Linux.Resources.CPU.Cpus = ""
-		grpcSpec.Linux.Resources.CPU.Mems = ""
-	}
 
 	// We need agent systemd cgroup now.
 	// There are three main reasons to do not apply systemd cgroups in the VM
@@ -1176,7 +1350,7 @@ func (k *kataAgent) appendDevices(deviceList []*grpc.Device, c *Container) []*gr
 		switch device.DeviceType() {
 		case config.DeviceBlock:
 			kataDevice = k.appendBlockDevice(dev, device, c)
-		case config.VhostUserBlk:
+		case config.VhostUserBlk, config.VhostVDPABlk:
 			kataDevice = k.appendVhostUserBlkDevice(dev, device, c)
 		case config.DeviceVFIO:
 			kataDevice = k.appendVfioDevice(dev, device, c)
@@ -1189,6 +1363,39 @@ func (k *kataAgent) appendDevices(deviceList []*grpc.Device, c *Container) []*gr
 		deviceList = append(deviceList, kataDevice)
 	}
 
+	// Devices provided by the guest are looked up by path, the agent
+	// updates the OCI spec with the guest major and minor numbers.
+	for _, path := range c.emulatedDevices {
+		devType := kataGuestDevType
+		// Let the agent check render nodes are driven by virtio-gpu.
+		if c.sandbox.config.HypervisorConfig.VirtioGPU != "" && strings.HasPrefix(path, virtioGPUDevDir+"/") {
+			devType = kataVirtioGPUDevType
+		}
+
+		var options []string
+		if isRDMADevice(path) {
+			devType = kataRDMADevType
+
+			var limits map[string]specs.LinuxRdma
+			if spec := c.GetPatchedOCISpec(); spec != nil && spec.Linux != nil && spec.Linux.Resources != nil {
+				limits = spec.Linux.Resources.Rdma
+			}
+
+			var err error
+			if options, err = rdmaDeviceOptions(path, limits); err != nil {
+				// The agent looks the node up by path then.
+				k.Logger().WithError(err).WithField("device", path).Warn("failed to find the host RDMA device")
+			}
+		}
+
+		deviceList = append(deviceList, &grpc.Device{
+			ContainerPath: path,
+			Type:          devType,
+			VmPath:        path,
+			Options:       options,
+		})
+	}
+
 	return deviceList
 }
 
@@ -1256,7 +1463,12 @@ func (k *kataAgent) createContainer(ctx context.Context, sandbox *Sandbox, c *Co
 
 	k.handleShm(ociSpec.Mounts, sandbox)
 
-	epheStorages, err := k.handleEphemeralStorage(ociSpec.Mounts)
+	if err = sandbox.capTmpfsMounts(ociSpec.Mounts); err != nil {
+		return nil, err
+	}
+
+	epheStorages, err := k.handleEphemeralStorage(ociSpec.Mounts,
+		sandbox.tmpfsSizeLimit(sandbox.config.TmpfsSizing.MemoryVolumePercent))
 	if err != nil {
 		return nil, err
 	}
@@ -1277,6 +1489,9 @@ func (k *kataAgent) createContainer(ctx context.Context, sandbox *Sandbox, c *Co
 
 	ctrStorages = append(ctrStorages, localStorages...)
 
+	fuseStorages := k.handleFuseStorage(ociSpec.Mounts, c.id)
+	ctrStorages = append(ctrStorages, fuseStorages...)
+
 	// We replace all OCI mount sources that match our container mount
 	// with the right source path (The guest one).
 	if err = k.replaceOCIMountSource(ociSpec, sharedDirMounts); err != nil {
@@ -1300,6 +1515,9 @@ func (k *kataAgent) createContainer(ctx context.Context, sandbox *Sandbox, c *Co
 
 	ctrStorages = append(ctrStorages, volumeStorages...)
 
+	nfsStorages := k.handleNfsOCIMounts(c, ociSpec)
+	ctrStorages = append(ctrStorages, nfsStorages...)
+
 	grpcSpec, err := grpc.OCItoGRPC(ociSpec)
 	if err != nil {
 		return nil, err
@@ -1308,13 +1526,14 @@ func (k *kataAgent) createContainer(ctx context.Context, sandbox *Sandbox, c *Co
 	// We need to give the OCI spec our absolute rootfs path in the guest.
 	grpcSpec.Root.Path = sharedRootfs.guestPath
 
-	sharedPidNs := k.handlePidNamespace(grpcSpec, sandbox)
+	sharedPidNs := useSandboxPidNs(k.handlePidNamespace(grpcSpec, sandbox), sandbox, c)
 
-	if !sandbox.config.DisableGuestSeccomp && !sandbox.seccompSupported {
+	disableSeccomp := sandbox.config.DisableGuestSeccomp || sandbox.config.GuestSeccompMode == GuestSeccompUnconfined
+	if !disableSeccomp && !sandbox.seccompSupported {
 		return nil, fmt.Errorf("Seccomp profiles are passed to the virtual machine, but the Kata agent does not support seccomp")
 	}
 
-	passSeccomp := !sandbox.config.DisableGuestSeccomp && sandbox.seccompSupported
+	passSeccomp := !disableSeccomp && sandbox.seccompSupported
 
 	// Currently, guest SELinux can be enabled only when SELinux is enabled on the host side.
 	if !sandbox.config.HypervisorConfig.DisableGuestSeLinux && !selinux.GetEnabled() {
@@ -1331,6 +1550,30 @@ func (k *kataAgent) createContainer(ctx context.Context, sandbox *Sandbox, c *Co
 		return nil, err
 	}
 
+	constrainGRPCResources(grpcSpec.Linux.Resources, sandbox.guestCPUs)
+
+	setGuestPidsLimit(grpcSpec, sandbox.config.GuestPidsLimit)
+
+	if err := delegateWasm(grpcSpec, sandbox.config.WasmRuntime); err != nil {
+		return nil, err
+	}
+
+	// The sandbox container of a pod does not run an engine.
+	if sandbox.config.NestedContainers && (c.id != sandbox.id || sandbox.pauseless != nil) {
+		ctrStorages = append(ctrStorages, setNestedContainers(grpcSpec, c.id)...)
+	}
+
+	if grpcSpec.Linux != nil {
+		grpcSpec.Linux.Seccomp = guestSeccompProfile(grpcSpec.Linux.Seccomp, sandbox.config.GuestSeccompMode, sandbox.config.guestSeccompReporting())
+		var listenerPath string
+		if ociSpec.Linux.Seccomp != nil {
+			listenerPath = ociSpec.Linux.Seccomp.ListenerPath
+		}
+		if err := sandbox.checkSeccompNotify(grpcSpec.Linux.Seccomp, listenerPath); err != nil {
+			return nil, err
+		}
+	}
+
 	req := &grpc.CreateContainerRequest{
 		ContainerId:  c.id,
 		ExecId:       c.id,
@@ -1420,8 +1663,9 @@ func (k *kataAgent) handleHugepages(mounts []specs.Mount, hugepageLimits []specs
 }
 
 // handleEphemeralStorage handles ephemeral storages by
-// creating a Storage from corresponding source of the mount point
-func (k *kataAgent) handleEphemeralStorage(mounts []specs.Mount) ([]*grpc.Storage, error) {
+// creating a Storage from corresponding source of the mount point,
+// whose size is capped to sizeLimit unless it is 0
+func (k *kataAgent) handleEphemeralStorage(mounts []specs.Mount, sizeLimit uint64) ([]*grpc.Storage, error) {
 	var epheStorages []*grpc.Storage
 	for idx, mnt := range mounts {
 		if mnt.Type == KataEphemeralDevType {
@@ -1442,6 +1686,17 @@ func (k *kataAgent) handleEphemeralStorage(mounts []specs.Mount) ([]*grpc.Storag
 				dir_options = append(dir_options, fmt.Sprintf("%s=%d", fsGid, stat.Gid))
 			}
 
+			// The size of the host tmpfs is the size limit of the
+			// volume, if any.
+			if sizeLimit != 0 {
+				var statfs syscall.Statfs_t
+				if err := syscall.Statfs(origin_src, &statfs); err != nil {
+					return nil, err
+				}
+				size := capTmpfsSize(uint64(statfs.Bsize)*statfs.Blocks, sizeLimit)
+				dir_options = append(dir_options, fmt.Sprintf("size=%d", size))
+			}
+
 			// Set the mount source path to a path that resides inside the VM
 			mounts[idx].Source = filepath.Join(ephemeralPath(), filepath.Base(mnt.Source))
 			// Set the mount type to "bind"
@@ -1507,6 +1762,79 @@ func (k *kataAgent) handleLocalStorage(mounts []specs.Mount, sandboxID string, r
 	return localStorages, nil
 }
 
+// handleNfsOCIMounts handles the NFS direct volumes, mounted by the agent
+// over the network of the pod rather than shared from the host.
+func (k *kataAgent) handleNfsOCIMounts(c *Container, spec *specs.Spec) []*grpc.Storage {
+	var nfsStorages []*grpc.Storage
+
+	for i, m := range c.mounts {
+		if m.Type != "nfs" && m.Type != "nfs4" {
+			continue
+		}
+
+		// Like the block devices, each export is mounted once in the
+		// VM and ref-counted by the agent.
+		path := filepath.Join(kataGuestSandboxStorageDir(), b64.URLEncoding.EncodeToString([]byte(m.Source)))
+
+		for idx, ociMount := range spec.Mounts {
+			if ociMount.Destination != m.Destination {
+				continue
+			}
+			k.Logger().WithFields(logrus.Fields{
+				"original-source": ociMount.Source,
+				"new-source":      path,
+			}).Debug("Replacing OCI mount source")
+			spec.Mounts[idx].Source = path
+			break
+		}
+
+		c.mounts[i].GuestDeviceMount = path
+
+		nfsStorages = append(nfsStorages, &grpc.Storage{
+			Driver:     kataNfsDevType,
+			Source:     m.Source,
+			Fstype:     m.Type,
+			MountPoint: path,
+			Options:    m.Options,
+		})
+	}
+
+	return nfsStorages
+}
+
+// handleFuseStorage handles the mounts of user-space filesystems, e.g. of
+// type fuse.s3fs, by creating Storages mounted by the agent with the mount
+// helpers shipped in the guest image, then bind mounted in the container.
+func (k *kataAgent) handleFuseStorage(mounts []specs.Mount, containerID string) []*grpc.Storage {
+	var fuseStorages []*grpc.Storage
+	for idx, mnt := range mounts {
+		if !strings.HasPrefix(mnt.Type, fuseTypePrefix) || mnt.Type == NydusRootFSType {
+			continue
+		}
+
+		mountPoint := filepath.Join(kataGuestSandboxDir(), kataFuseDevType, fmt.Sprintf("%s-%d", containerID, idx))
+
+		fuseStorages = append(fuseStorages, &grpc.Storage{
+			Driver:     kataFuseDevType,
+			Source:     mnt.Source,
+			Fstype:     mnt.Type,
+			MountPoint: mountPoint,
+			Options:    mnt.Options,
+		})
+
+		options := []string{"rbind"}
+		for _, o := range mnt.Options {
+			if o == "ro" {
+				options = append(options, o)
+			}
+		}
+		mounts[idx].Source = mountPoint
+		mounts[idx].Type = "bind"
+		mounts[idx].Options = options
+	}
+	return fuseStorages
+}
+
 // handleDeviceBlockVolume handles volume that is block device file
 // and DeviceBlock type.
 func (k *kataAgent) handleDeviceBlockVolume(c *Container, m Mount, device api.Device) (*grpc.Storage, error) {
@@ -1524,6 +1852,10 @@ func (k *kataAgent) handleDeviceBlockVolume(c *Container, m Mount, device api.De
 		vol.Source = fmt.Sprintf("/dev/pmem%s", blockDrive.NvdimmID)
 		vol.Fstype = blockDrive.Format
 		vol.Options = []string{"dax"}
+		// The mount options of a direct assigned volume
+		if m.Type != "bind" {
+			vol.Options = append(vol.Options, m.Options...)
+		}
 	case c.sandbox.config.HypervisorConfig.BlockDeviceDriver == config.VirtioBlockCCW:
 		vol.Driver = kataBlkCCWDevType
 		vol.Source = blockDrive.DevNo
@@ -1599,7 +1931,7 @@ func (k *kataAgent) createBlkStorageObject(c *Container, m Mount) (*grpc.Storage
 	switch device.DeviceType() {
 	case config.DeviceBlock:
 		vol, err = k.handleDeviceBlockVolume(c, m, device)
-	case config.VhostUserBlk:
+	case config.VhostUserBlk, config.VhostVDPABlk:
 		vol, err = k.handleVhostUserBlkVolume(c, m, device)
 	default:
 		return nil, fmt.Errorf("Unknown device type")
@@ -1692,6 +2024,21 @@ func (k *kataAgent) handlePidNamespace(grpcSpec *grpc.Spec, sandbox *Sandbox) bo
 	return sharedPidNs
 }
 
+// useSandboxPidNs tells whether a container joins the PID namespace of the
+// sandbox, given whether its spec shares it. With a pod-wide PID namespace,
+// all the containers but the sandbox one join it.
+func useSandboxPidNs(specSharedPidNs bool, sandbox *Sandbox, c *Container) bool {
+	sharedPidNs := specSharedPidNs || (sandbox.sharePidNs && c.id != sandbox.id)
+
+	// The first container of a pause-less sandbox owns the PID
+	// namespace the others share.
+	if sharedPidNs && sandbox.pauseless != nil && !sandbox.guestInitCreated(c) {
+		return false
+	}
+
+	return sharedPidNs
+}
+
 func (k *kataAgent) startContainer(ctx context.Context, sandbox *Sandbox, c *Container) error {
 	span, ctx := katatrace.Trace(ctx, k.Logger(), "startContainer", kataAgentTracingTags)
 	defer span.End()
@@ -1699,6 +2046,9 @@ func (k *kataAgent) startContainer(ctx context.Context, sandbox *Sandbox, c *Con
 	req := &grpc.StartContainerRequest{
 		ContainerId: c.id,
 	}
+	if sandbox.config != nil {
+		req.ExecTimeout = sandbox.config.ConfirmExecTimeout * 1000
+	}
 
 	_, err := k.sendReq(ctx, req)
 	return err
@@ -1745,6 +2095,12 @@ func (k *kataAgent) updateContainer(ctx context.Context, sandbox *Sandbox, c Con
 	if err != nil {
 		return err
 	}
+	constrainGRPCResources(grpcResources, sandbox.guestCPUs)
+
+	// An update leaving the pids limit alone does not carry it.
+	if grpcResources.Pids != nil {
+		keepGuestPidsLimit(grpcResources.Pids, sandbox.config.GuestPidsLimit)
+	}
 
 	req := &grpc.UpdateContainerRequest{
 		ContainerId: c.id,
@@ -1883,6 +2239,16 @@ func (k *kataAgent) disconnect(ctx context.Context) error {
 		return nil
 	}
 
+	for i, c := range k.streamClients {
+		if c == nil {
+			continue
+		}
+		if err := c.Close(); err != nil && grpcStatus.Convert(err).Code() != codes.Canceled {
+			k.Logger().WithError(err).WithField("connection", i).Warn("failed to close agent stream connection")
+		}
+	}
+	k.streamClients = nil
+
 	if err := k.client.Close(); err != nil && grpcStatus.Convert(err).Code() != codes.Canceled {
 		return err
 	}
@@ -1893,6 +2259,66 @@ func (k *kataAgent) disconnect(ctx context.Context) error {
 	return nil
 }
 
+// MaxAgentStreamConnections is the maximum amount of dedicated agent stream
+// connections of a sandbox.
+const MaxAgentStreamConnections uint32 = 8
+
+// ValidateAgentStreamConnections checks the amount of dedicated agent stream
+// connections requested by the configuration or the annotations of a pod.
+func ValidateAgentStreamConnections(conns uint64) error {
+	if conns > uint64(MaxAgentStreamConnections) {
+		return fmt.Errorf("too many agent stream connections requested (%d), the maximum is %d", conns, MaxAgentStreamConnections)
+	}
+	return nil
+}
+
+// streamConnIndex maps a container process to one of the dedicated stream
+// connections, so that the stdio of a given process always uses the same
+// connection while different processes are spread across all of them.
+func streamConnIndex(containerID, execID string, conns uint32) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(containerID))
+	h.Write([]byte(execID))
+	return h.Sum32() % conns
+}
+
+// streamClient returns the agent client used to carry bulk data for the
+// given container process. When no dedicated stream connections are
+// configured, the control connection is shared with all the other requests.
+func (k *kataAgent) streamClient(ctx context.Context, containerID, execID string) (*kataclient.AgentClient, error) {
+	if err := k.connect(ctx); err != nil {
+		return nil, err
+	}
+
+	k.Lock()
+	defer k.Unlock()
+
+	if k.client == nil {
+		return nil, errors.New("Client has already disconnected")
+	}
+
+	// A serial port is a single stream, only one client can be connected
+	if k.streamConns == 0 || strings.HasPrefix(k.state.URL, types.SerialSockScheme+":") {
+		return k.client, nil
+	}
+
+	if k.streamClients == nil {
+		k.streamClients = make([]*kataclient.AgentClient, k.streamConns)
+	}
+
+	idx := streamConnIndex(containerID, execID, k.streamConns)
+	if k.streamClients[idx] == nil {
+		k.Logger().WithField("url", k.state.URL).WithField("connection", idx).Info("New stream client")
+		client, err := kataclient.NewAgentClient(k.ctx, k.state.URL, k.dialTimout)
+		if err != nil {
+			return nil, err
+		}
+		k.streamClients[idx] = client
+	}
+
+	return k.streamClients[idx], nil
+}
+
 // check grpc server is serving
 func (k *kataAgent) check(ctx context.Context) error {
 	_, err := k.sendReq(ctx, &grpc.CheckRequest{})
@@ -1918,7 +2344,7 @@ func (k *kataAgent) waitProcess(ctx context.Context, c *Container, processID str
 }
 
 func (k *kataAgent) writeProcessStdin(ctx context.Context, c *Container, ProcessID string, data []byte) (int, error) {
-	resp, err := k.sendReq(ctx, &grpc.WriteStreamRequest{
+	resp, err := k.sendStreamReq(ctx, c.id, ProcessID, &grpc.WriteStreamRequest{
 		ContainerId: c.id,
 		ExecId:      ProcessID,
 		Data:        data,
@@ -2053,6 +2479,9 @@ func (k *kataAgent) installReqFunc(c *kataclient.AgentClient) {
 	k.reqHandlers[grpcVolumeStatsRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
 		return k.client.AgentServiceClient.GetVolumeStats(ctx, req.(*grpc.VolumeStatsRequest))
 	}
+	k.reqHandlers[grpcContainerVolumeStatsRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return k.client.AgentServiceClient.GetContainerVolumeStats(ctx, req.(*grpc.ContainerVolumeStatsRequest))
+	}
 	k.reqHandlers[grpcResizeVolumeRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
 		return k.client.AgentServiceClient.ResizeVolume(ctx, req.(*grpc.ResizeVolumeRequest))
 	}
@@ -2062,6 +2491,45 @@ func (k *kataAgent) installReqFunc(c *kataclient.AgentClient) {
 	k.reqHandlers[grpcSetIPTablesRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
 		return k.client.AgentServiceClient.SetIPTables(ctx, req.(*grpc.SetIPTablesRequest))
 	}
+	k.reqHandlers[grpcWaitDeviceRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return k.client.AgentServiceClient.WaitDevice(ctx, req.(*grpc.WaitDeviceRequest))
+	}
+	k.reqHandlers[grpcSyncFsRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return k.client.AgentServiceClient.SyncFs(ctx, req.(*grpc.SyncFsRequest))
+	}
+	k.reqHandlers[grpcFreezeFsRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return k.client.AgentServiceClient.FreezeFs(ctx, req.(*grpc.FreezeFsRequest))
+	}
+	k.reqHandlers[grpcThawFsRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return k.client.AgentServiceClient.ThawFs(ctx, req.(*grpc.ThawFsRequest))
+	}
+	k.reqHandlers[grpcSetNameResolutionRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return k.client.AgentServiceClient.SetNameResolution(ctx, req.(*grpc.SetNameResolutionRequest))
+	}
+	k.reqHandlers[grpcGetGuestServicesRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return k.client.AgentServiceClient.GetGuestServices(ctx, req.(*grpc.GetGuestServicesRequest))
+	}
+	k.reqHandlers[grpcSetNetworkPolicyRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return k.client.AgentServiceClient.SetNetworkPolicy(ctx, req.(*grpc.SetNetworkPolicyRequest))
+	}
+	k.reqHandlers[grpcGetSeccompNotificationRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return k.client.AgentServiceClient.GetSeccompNotification(ctx, req.(*grpc.GetSeccompNotificationRequest))
+	}
+	k.reqHandlers[grpcSeccompNotificationResponse] = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return k.client.AgentServiceClient.SendSeccompNotificationResponse(ctx, req.(*grpc.SeccompNotificationResponse))
+	}
+	k.reqHandlers[grpcReadFileRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return k.client.AgentServiceClient.ReadFile(ctx, req.(*grpc.ReadFileRequest))
+	}
+	k.reqHandlers[grpcGetDiagnosticsRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return k.client.AgentServiceClient.GetDiagnostics(ctx, req.(*grpc.GetDiagnosticsRequest))
+	}
+	k.reqHandlers[grpcCheckpointContainerRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return k.client.AgentServiceClient.CheckpointContainer(ctx, req.(*grpc.CheckpointContainerRequest))
+	}
+	k.reqHandlers[grpcRestoreContainerRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return k.client.AgentServiceClient.RestoreContainer(ctx, req.(*grpc.RestoreContainerRequest))
+	}
 	k.reqHandlers[grpcRemoveStaleVirtiofsShareMountsRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
 		return k.client.AgentServiceClient.RemoveStaleVirtiofsShareMounts(ctx, req.(*grpc.RemoveStaleVirtiofsShareMountsRequest))
 	}
@@ -2070,10 +2538,12 @@ func (k *kataAgent) installReqFunc(c *kataclient.AgentClient) {
 func (k *kataAgent) getReqContext(ctx context.Context, reqName string) (newCtx context.Context, cancel context.CancelFunc) {
 	newCtx = ctx
 	switch reqName {
-	case grpcWaitProcessRequest, grpcGetOOMEventRequest:
-		// Wait and GetOOMEvent have no timeout
+	case grpcWaitProcessRequest, grpcGetOOMEventRequest, grpcGetSeccompNotificationRequest:
+		// Wait, GetOOMEvent and GetSeccompNotification have no timeout
 	case grpcCheckRequest:
 		newCtx, cancel = context.WithTimeout(ctx, checkRequestTimeout)
+	case grpcWaitDeviceRequest, grpcSyncFsRequest:
+		// WaitDevice and SyncFs are bounded by the timeout of the request
 	default:
 		newCtx, cancel = context.WithTimeout(ctx, defaultRequestTimeout)
 	}
@@ -2118,31 +2588,80 @@ func (k *kataAgent) sendReq(spanCtx context.Context, request interface{}) (inter
 	defer func() {
 		agentRPCDurationsHistogram.WithLabelValues(msgName).Observe(float64(time.Since(start).Nanoseconds() / int64(time.Millisecond)))
 	}()
+
+	if err := faultinject.Inject(ctx, faultinject.AgentPoint(msgName)); err != nil {
+		return nil, err
+	}
+
 	return handler(ctx, request)
 }
 
+// sendStreamReq sends a request carrying bulk data over the stream connection
+// selected for the given container process. It falls back to sendReq when no
+// dedicated stream connections are configured.
+func (k *kataAgent) sendStreamReq(spanCtx context.Context, containerID, execID string, request interface{}) (interface{}, error) {
+	if k.streamConns == 0 {
+		return k.sendReq(spanCtx, request)
+	}
+
+	start := time.Now()
+
+	client, err := k.streamClient(spanCtx, containerID, execID)
+	if err != nil {
+		return nil, err
+	}
+	if !k.keepConn {
+		defer k.disconnect(spanCtx)
+	}
+
+	msgName := proto.MessageName(request.(proto.Message))
+	ctx, cancel := k.getReqContext(spanCtx, msgName)
+	if cancel != nil {
+		defer cancel()
+	}
+
+	defer func() {
+		agentRPCDurationsHistogram.WithLabelValues(msgName).Observe(float64(time.Since(start).Nanoseconds() / int64(time.Millisecond)))
+	}()
+
+	if err := faultinject.Inject(ctx, faultinject.AgentPoint(msgName)); err != nil {
+		return nil, err
+	}
+
+	switch req := request.(type) {
+	case *grpc.WriteStreamRequest:
+		return client.AgentServiceClient.WriteStdin(ctx, req)
+	case *grpc.CopyFileRequest:
+		return client.AgentServiceClient.CopyFile(ctx, req)
+	default:
+		return nil, errors.New("Invalid stream request type")
+	}
+}
+
 // readStdout and readStderr are special that we cannot differentiate them with the request types...
 func (k *kataAgent) readProcessStdout(ctx context.Context, c *Container, processID string, data []byte) (int, error) {
-	if err := k.connect(ctx); err != nil {
+	client, err := k.streamClient(ctx, c.id, processID)
+	if err != nil {
 		return 0, err
 	}
 	if !k.keepConn {
 		defer k.disconnect(ctx)
 	}
 
-	return k.readProcessStream(c.id, processID, data, k.client.AgentServiceClient.ReadStdout)
+	return k.readProcessStream(c.id, processID, data, client.AgentServiceClient.ReadStdout)
 }
 
 // readStdout and readStderr are special that we cannot differentiate them with the request types...
 func (k *kataAgent) readProcessStderr(ctx context.Context, c *Container, processID string, data []byte) (int, error) {
-	if err := k.connect(ctx); err != nil {
+	client, err := k.streamClient(ctx, c.id, processID)
+	if err != nil {
 		return 0, err
 	}
 	if !k.keepConn {
 		defer k.disconnect(ctx)
 	}
 
-	return k.readProcessStream(c.id, processID, data, k.client.AgentServiceClient.ReadStderr)
+	return k.readProcessStream(c.id, processID, data, client.AgentServiceClient.ReadStderr)
 }
 
 type readFn func(context.Context, *grpc.ReadStreamRequest) (*grpc.ReadStreamResponse, error)
@@ -2209,7 +2728,7 @@ func (k *kataAgent) copyFile(ctx context.Context, src, dst string) error {
 
 	// Handle the special case where the file is empty
 	if fileSize == 0 {
-		_, err = k.sendReq(ctx, cpReq)
+		_, err = k.sendStreamReq(ctx, "", dst, cpReq)
 		return err
 	}
 
@@ -2225,7 +2744,7 @@ func (k *kataAgent) copyFile(ctx context.Context, src, dst string) error {
 		cpReq.Data = b[:bytesToCopy]
 		cpReq.Offset = offset
 
-		if _, err = k.sendReq(ctx, cpReq); err != nil {
+		if _, err = k.sendStreamReq(ctx, "", dst, cpReq); err != nil {
 			return fmt.Errorf("Could not send CopyFile request: %v", err)
 		}
 
@@ -2319,7 +2838,249 @@ func (k *kataAgent) getGuestVolumeStats(ctx context.Context, volumeGuestPath str
 	return buf, nil
 }
 
+func (k *kataAgent) getContainerVolumeStats(ctx context.Context, containerID string) ([]*grpc.ContainerVolumeStats, error) {
+	result, err := k.sendReq(ctx, &grpc.ContainerVolumeStatsRequest{ContainerId: containerID})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*grpc.ContainerVolumeStatsResponse).Volumes, nil
+}
+
 func (k *kataAgent) resizeGuestVolume(ctx context.Context, volumeGuestPath string, size uint64) error {
 	_, err := k.sendReq(ctx, &grpc.ResizeVolumeRequest{VolumeGuestPath: volumeGuestPath, Size_: size})
 	return err
 }
+
+func (k *kataAgent) waitDevice(ctx context.Context, devType, address string, timeout time.Duration) error {
+	span, ctx := katatrace.Trace(ctx, k.Logger(), "waitDevice", kataAgentTracingTags)
+	defer span.End()
+
+	// Leave the agent the time to report the timeout itself.
+	ctx, cancel := context.WithTimeout(ctx, timeout+checkRequestTimeout)
+	defer cancel()
+
+	_, err := k.sendReq(ctx, &grpc.WaitDeviceRequest{
+		Type:    devType,
+		Address: address,
+		Timeout: uint32(timeout.Milliseconds()),
+	})
+	if grpcStatus.Convert(err).Code() == codes.Unimplemented {
+		return errUnimplemented
+	}
+	return err
+}
+
+func (k *kataAgent) syncFs(ctx context.Context, containerID string, timeout time.Duration) error {
+	span, ctx := katatrace.Trace(ctx, k.Logger(), "syncFs", kataAgentTracingTags)
+	defer span.End()
+
+	// Leave the agent the time to report the timeout itself.
+	ctx, cancel := context.WithTimeout(ctx, timeout+checkRequestTimeout)
+	defer cancel()
+
+	_, err := k.sendReq(ctx, &grpc.SyncFsRequest{
+		ContainerId: containerID,
+		Timeout:     uint32(timeout.Milliseconds()),
+	})
+	if grpcStatus.Convert(err).Code() == codes.Unimplemented {
+		return errUnimplemented
+	}
+	return err
+}
+
+func (k *kataAgent) freezeFs(ctx context.Context, containerID string) error {
+	span, ctx := katatrace.Trace(ctx, k.Logger(), "freezeFs", kataAgentTracingTags)
+	defer span.End()
+
+	_, err := k.sendReq(ctx, &grpc.FreezeFsRequest{
+		ContainerId: containerID,
+	})
+	if grpcStatus.Convert(err).Code() == codes.Unimplemented {
+		return errUnimplemented
+	}
+	return err
+}
+
+func (k *kataAgent) thawFs(ctx context.Context) error {
+	span, ctx := katatrace.Trace(ctx, k.Logger(), "thawFs", kataAgentTracingTags)
+	defer span.End()
+
+	_, err := k.sendReq(ctx, &grpc.ThawFsRequest{})
+	return err
+}
+
+func (k *kataAgent) setNameResolution(ctx context.Context, hosts, resolvConf []byte) error {
+	span, ctx := katatrace.Trace(ctx, k.Logger(), "setNameResolution", kataAgentTracingTags)
+	defer span.End()
+
+	_, err := k.sendReq(ctx, &grpc.SetNameResolutionRequest{
+		Hosts:      hosts,
+		ResolvConf: resolvConf,
+	})
+	if grpcStatus.Convert(err).Code() == codes.Unimplemented {
+		return errUnimplemented
+	}
+	return err
+}
+
+func (k *kataAgent) getGuestServices(ctx context.Context) ([]GuestServiceStatus, error) {
+	span, ctx := katatrace.Trace(ctx, k.Logger(), "getGuestServices", kataAgentTracingTags)
+	defer span.End()
+
+	resp, err := k.sendReq(ctx, &grpc.GetGuestServicesRequest{})
+	if grpcStatus.Convert(err).Code() == codes.Unimplemented {
+		return nil, errUnimplemented
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var services []GuestServiceStatus
+	for _, service := range resp.(*grpc.GuestServices).Services {
+		services = append(services, GuestServiceStatus{
+			Name:     service.Name,
+			State:    service.State,
+			Restarts: service.Restarts,
+		})
+	}
+	return services, nil
+}
+
+func (k *kataAgent) setNetworkPolicy(ctx context.Context, program []byte, policy *NetworkPolicy) error {
+	span, ctx := katatrace.Trace(ctx, k.Logger(), "setNetworkPolicy", kataAgentTracingTags)
+	defer span.End()
+
+	req := &grpc.SetNetworkPolicyRequest{
+		Program: program,
+	}
+	if policy != nil {
+		req.Ingress = policy.Ingress
+		req.Egress = policy.Egress
+		for _, rule := range policy.Rules {
+			req.Rules = append(req.Rules, &grpc.NetworkPolicyRule{
+				Direction: rule.Direction,
+				Cidr:      rule.CIDR,
+				Protocol:  rule.Protocol,
+				Port:      uint32(rule.Port),
+				EndPort:   uint32(rule.EndPort),
+			})
+		}
+	}
+
+	_, err := k.sendReq(ctx, req)
+	if grpcStatus.Convert(err).Code() == codes.Unimplemented {
+		return errUnimplemented
+	}
+	return err
+}
+
+func (k *kataAgent) getSeccompNotification(ctx context.Context) (*SeccompNotification, error) {
+	resp, err := k.sendReq(ctx, &grpc.GetSeccompNotificationRequest{})
+	if err != nil {
+		if grpcStatus.Convert(err).Code() == codes.Unimplemented {
+			return nil, errUnimplemented
+		}
+		return nil, err
+	}
+
+	n := resp.(*grpc.SeccompNotification)
+	return &SeccompNotification{
+		ID:          n.Id,
+		ContainerID: n.ContainerId,
+		ExecID:      n.ExecId,
+		Pid:         n.Pid,
+		Syscall:     n.Syscall,
+		Arch:        n.Arch,
+		Args:        n.Args,
+	}, nil
+}
+
+func (k *kataAgent) respondSeccompNotification(ctx context.Context, resp SeccompNotificationResponse) error {
+	span, ctx := katatrace.Trace(ctx, k.Logger(), "respondSeccompNotification", kataAgentTracingTags)
+	defer span.End()
+
+	_, err := k.sendReq(ctx, &grpc.SeccompNotificationResponse{
+		Id:    resp.ID,
+		Allow: resp.Allow,
+		Error: resp.Errno,
+		Value: resp.Value,
+	})
+	return err
+}
+
+func (k *kataAgent) readFile(ctx context.Context, path string, offset, maxSize uint64) ([]byte, error) {
+	span, ctx := katatrace.Trace(ctx, k.Logger(), "readFile", kataAgentTracingTags)
+	defer span.End()
+
+	resp, err := k.sendReq(ctx, &grpc.ReadFileRequest{
+		Path:    path,
+		Offset:  offset,
+		MaxSize: maxSize,
+	})
+	if grpcStatus.Convert(err).Code() == codes.Unimplemented {
+		return nil, errUnimplemented
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*grpc.ReadFileResponse).Data, nil
+}
+
+func (k *kataAgent) getDiagnostics(ctx context.Context, maxKernelLogSize uint64) (*grpc.Diagnostics, error) {
+	span, ctx := katatrace.Trace(ctx, k.Logger(), "getDiagnostics", kataAgentTracingTags)
+	defer span.End()
+
+	resp, err := k.sendReq(ctx, &grpc.GetDiagnosticsRequest{
+		MaxKernelLogSize: maxKernelLogSize,
+	})
+	if grpcStatus.Convert(err).Code() == codes.Unimplemented {
+		return nil, errUnimplemented
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*grpc.Diagnostics), nil
+}
+
+func criuOptions(opts CheckpointOptions) *grpc.CriuOptions {
+	return &grpc.CriuOptions{
+		TcpEstablished: opts.TCPEstablished,
+		FileLocks:      opts.FileLocks,
+		ExtUnixSockets: opts.ExtUnixSockets,
+	}
+}
+
+func (k *kataAgent) checkpointContainer(ctx context.Context, c *Container, imagePath string, opts CheckpointOptions) ([]string, error) {
+	span, ctx := katatrace.Trace(ctx, k.Logger(), "checkpointContainer", kataAgentTracingTags)
+	defer span.End()
+
+	resp, err := k.sendReq(ctx, &grpc.CheckpointContainerRequest{
+		ContainerId:  c.id,
+		ImagePath:    imagePath,
+		LeaveRunning: opts.LeaveRunning,
+		Options:      criuOptions(opts),
+	})
+	if grpcStatus.Convert(err).Code() == codes.Unimplemented {
+		return nil, errUnimplemented
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*grpc.CheckpointContainerResponse).Files, nil
+}
+
+func (k *kataAgent) restoreContainer(ctx context.Context, c *Container, imagePath string, opts CheckpointOptions) error {
+	span, ctx := katatrace.Trace(ctx, k.Logger(), "restoreContainer", kataAgentTracingTags)
+	defer span.End()
+
+	_, err := k.sendReq(ctx, &grpc.RestoreContainerRequest{
+		ContainerId: c.id,
+		ImagePath:   imagePath,
+		Options:     criuOptions(opts),
+	})
+	if grpcStatus.Convert(err).Code() == codes.Unimplemented {
+		return errUnimplemented
+	}
+	return err
+}
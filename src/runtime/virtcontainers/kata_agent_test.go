@@ -8,6 +8,7 @@ package virtcontainers
 import (
 	"bufio"
 	"context"
+	b64 "encoding/base64"
 	"fmt"
 	"os"
 	"path"
@@ -196,7 +197,7 @@ func TestHandleEphemeralStorage(t *testing.T) {
 	}
 
 	ociMounts = append(ociMounts, mount)
-	epheStorages, err := k.handleEphemeralStorage(ociMounts)
+	epheStorages, err := k.handleEphemeralStorage(ociMounts, 0)
 	assert.Nil(t, err)
 
 	epheMountPoint := epheStorages[0].MountPoint
@@ -229,6 +230,79 @@ func TestHandleLocalStorage(t *testing.T) {
 	assert.Equal(t, localMountPoint, expected)
 }
 
+func TestHandleNfsOCIMounts(t *testing.T) {
+	assert := assert.New(t)
+	k := kataAgent{}
+
+	c := &Container{
+		mounts: []Mount{
+			{Source: "nfs.example.com:/export", Destination: "/data", Type: "nfs4", Options: []string{"vers=4.1"}},
+			{Source: "/host/hosts", Destination: "/etc/hosts", Type: "bind"},
+		},
+	}
+	spec := &specs.Spec{
+		Mounts: []specs.Mount{
+			{Source: "/var/lib/kubelet/pods/uid/volumes/nfs", Destination: "/data", Type: "bind", Options: []string{"rbind"}},
+			{Source: "/host/hosts", Destination: "/etc/hosts", Type: "bind", Options: []string{"rbind"}},
+		},
+	}
+
+	storages := k.handleNfsOCIMounts(c, spec)
+	assert.Len(storages, 1)
+
+	path := filepath.Join(kataGuestSandboxStorageDir(), b64.URLEncoding.EncodeToString([]byte("nfs.example.com:/export")))
+	assert.Equal(&pb.Storage{
+		Driver:     kataNfsDevType,
+		Source:     "nfs.example.com:/export",
+		Fstype:     "nfs4",
+		MountPoint: path,
+		Options:    []string{"vers=4.1"},
+	}, storages[0])
+	assert.Equal(path, spec.Mounts[0].Source)
+	assert.Equal("/host/hosts", spec.Mounts[1].Source)
+	assert.Equal(path, c.mounts[0].GuestDeviceMount)
+}
+
+func TestHandleFuseStorage(t *testing.T) {
+	assert := assert.New(t)
+	k := kataAgent{}
+
+	ociMounts := []specs.Mount{
+		{
+			Destination: "/data",
+			Type:        "fuse.s3fs",
+			Source:      "bucket",
+			Options:     []string{"ro", "use_path_request_style"},
+		},
+		{
+			Destination: "/etc/hosts",
+			Type:        "bind",
+			Source:      "/host/hosts",
+			Options:     []string{"rbind"},
+		},
+	}
+
+	storages := k.handleFuseStorage(ociMounts, "cid")
+	assert.Len(storages, 1)
+
+	mountPoint := filepath.Join(kataGuestSandboxDir(), kataFuseDevType, "cid-0")
+	assert.Equal(&pb.Storage{
+		Driver:     kataFuseDevType,
+		Source:     "bucket",
+		Fstype:     "fuse.s3fs",
+		MountPoint: mountPoint,
+		Options:    []string{"ro", "use_path_request_style"},
+	}, storages[0])
+
+	assert.Equal(specs.Mount{
+		Destination: "/data",
+		Type:        "bind",
+		Source:      mountPoint,
+		Options:     []string{"rbind", "ro"},
+	}, ociMounts[0])
+	assert.Equal("/host/hosts", ociMounts[1].Source)
+}
+
 func TestHandleDeviceBlockVolume(t *testing.T) {
 	var gid = 2000
 	k := kataAgent{}
@@ -256,6 +330,25 @@ func TestHandleDeviceBlockVolume(t *testing.T) {
 				Options: []string{"dax"},
 			},
 		},
+		{
+			inputDev: &drivers.BlockDevice{
+				BlockDrive: &config.BlockDrive{
+					Pmem:     true,
+					NvdimmID: testNvdimmID,
+					Format:   "xfs",
+				},
+			},
+			inputMount: Mount{
+				Type:    "xfs",
+				Options: []string{"ro"},
+			},
+			resultVol: &pb.Storage{
+				Driver:  kataNvdimmDevType,
+				Source:  fmt.Sprintf("/dev/pmem%s", testNvdimmID),
+				Fstype:  "xfs",
+				Options: []string{"dax", "ro"},
+			},
+		},
 		{
 			BlockDeviceDriver: config.VirtioBlockCCW,
 			inputMount: Mount{
@@ -408,7 +501,7 @@ func TestHandleBlockVolume(t *testing.T) {
 	mounts = append(mounts, vMount, bMount, dMount)
 
 	tmpDir := "/vhost/user/dir"
-	dm := manager.NewDeviceManager(config.VirtioBlock, true, tmpDir, 0, devices)
+	dm := manager.NewDeviceManager(config.VirtioBlock, true, tmpDir, 0, "", false, devices)
 
 	sConfig := SandboxConfig{}
 	sConfig.HypervisorConfig.BlockDeviceDriver = config.VirtioBlock
@@ -466,7 +559,7 @@ func TestAppendDevicesEmptyContainerDeviceList(t *testing.T) {
 
 	c := &Container{
 		sandbox: &Sandbox{
-			devManager: manager.NewDeviceManager("virtio-scsi", false, "", 0, nil),
+			devManager: manager.NewDeviceManager("virtio-scsi", false, "", 0, "", false, nil),
 		},
 		devices: ctrDevices,
 	}
@@ -476,6 +569,98 @@ func TestAppendDevicesEmptyContainerDeviceList(t *testing.T) {
 		updatedDevList, expected)
 }
 
+func TestAppendDevicesEmulated(t *testing.T) {
+	k := kataAgent{}
+
+	c := &Container{
+		sandbox: &Sandbox{
+			devManager: manager.NewDeviceManager("virtio-scsi", false, "", 0, "", false, nil),
+			config:     &SandboxConfig{},
+		},
+		emulatedDevices: []string{"/dev/dri/renderD128"},
+	}
+
+	expected := []*pb.Device{
+		{
+			ContainerPath: "/dev/dri/renderD128",
+			Type:          kataGuestDevType,
+			VmPath:        "/dev/dri/renderD128",
+		},
+	}
+	updatedDevList := k.appendDevices([]*pb.Device{}, c)
+	assert.True(t, reflect.DeepEqual(updatedDevList, expected),
+		"Device lists didn't match: got %+v, expecting %+v",
+		updatedDevList, expected)
+
+	c.sandbox.config.HypervisorConfig.VirtioGPU = VirtioGPUVenus
+	expected[0].Type = kataVirtioGPUDevType
+	updatedDevList = k.appendDevices([]*pb.Device{}, c)
+	assert.True(t, reflect.DeepEqual(updatedDevList, expected),
+		"Device lists didn't match: got %+v, expecting %+v",
+		updatedDevList, expected)
+}
+
+func TestAppendDevicesRDMA(t *testing.T) {
+	assert := assert.New(t)
+	k := kataAgent{}
+
+	savedSysInfinibandVerbsPath := sysInfinibandVerbsPath
+	defer func() { sysInfinibandVerbsPath = savedSysInfinibandVerbsPath }()
+
+	root := t.TempDir()
+	sysInfinibandVerbsPath = filepath.Join(root, "class", "infiniband_verbs")
+	device := filepath.Join(root, "devices", "0000:3b:00.2")
+	assert.NoError(os.MkdirAll(filepath.Join(sysInfinibandVerbsPath, "uverbs1"), 0700))
+	assert.NoError(os.MkdirAll(filepath.Join(root, "drivers", "mlx5_core"), 0700))
+	assert.NoError(os.MkdirAll(filepath.Join(device, "net", "ens1f0v0"), 0700))
+	assert.NoError(os.WriteFile(filepath.Join(device, "net", "ens1f0v0", "address"), []byte("02:00:00:00:00:01\n"), 0600))
+	assert.NoError(os.Symlink(filepath.Join(root, "drivers", "mlx5_core"), filepath.Join(device, "driver")))
+	assert.NoError(os.Symlink(device, filepath.Join(sysInfinibandVerbsPath, "uverbs1", "device")))
+	assert.NoError(os.WriteFile(filepath.Join(sysInfinibandVerbsPath, "uverbs1", "ibdev"), []byte("mlx5_1\n"), 0600))
+
+	handles := uint32(4)
+	spec := newEmptySpec()
+	spec.Linux.Resources.Rdma = map[string]specs.LinuxRdma{
+		"mlx5_0": {HcaObjects: &handles},
+		"mlx5_1": {HcaHandles: &handles},
+	}
+
+	c := &Container{
+		sandbox: &Sandbox{
+			devManager: manager.NewDeviceManager("virtio-scsi", false, "", 0, "", false, nil),
+			config:     &SandboxConfig{},
+		},
+		config:          &ContainerConfig{CustomSpec: spec},
+		emulatedDevices: []string{"/dev/infiniband/rdma_cm", "/dev/infiniband/uverbs1"},
+	}
+
+	expected := []*pb.Device{
+		{
+			ContainerPath: "/dev/infiniband/rdma_cm",
+			Type:          kataRDMADevType,
+			VmPath:        "/dev/infiniband/rdma_cm",
+		},
+		{
+			ContainerPath: "/dev/infiniband/uverbs1",
+			Type:          kataRDMADevType,
+			VmPath:        "/dev/infiniband/uverbs1",
+			Options:       []string{"bdf=0000:3b:00.2", "driver=mlx5_core", "mac=02:00:00:00:00:01", "hca_handle=4"},
+		},
+	}
+	assert.Equal(expected, k.appendDevices([]*pb.Device{}, c))
+
+	// the agent looks the node up by path when the host device is unknown
+	c.emulatedDevices = []string{"/dev/infiniband/uverbs0"}
+	expected = []*pb.Device{
+		{
+			ContainerPath: "/dev/infiniband/uverbs0",
+			Type:          kataRDMADevType,
+			VmPath:        "/dev/infiniband/uverbs0",
+		},
+	}
+	assert.Equal(expected, k.appendDevices([]*pb.Device{}, c))
+}
+
 func TestAppendDevices(t *testing.T) {
 	k := kataAgent{}
 
@@ -499,7 +684,7 @@ func TestAppendDevices(t *testing.T) {
 
 	c := &Container{
 		sandbox: &Sandbox{
-			devManager: manager.NewDeviceManager("virtio-blk", false, "", 0, ctrDevices),
+			devManager: manager.NewDeviceManager("virtio-blk", false, "", 0, "", false, ctrDevices),
 			config:     sandboxConfig,
 		},
 	}
@@ -547,7 +732,7 @@ func TestAppendVhostUserBlkDevices(t *testing.T) {
 	testVhostUserStorePath := "/test/vhost/user/store/path"
 	c := &Container{
 		sandbox: &Sandbox{
-			devManager: manager.NewDeviceManager("virtio-blk", true, testVhostUserStorePath, 0, ctrDevices),
+			devManager: manager.NewDeviceManager("virtio-blk", true, testVhostUserStorePath, 0, "", false, ctrDevices),
 			config:     sandboxConfig,
 		},
 	}
@@ -570,6 +755,43 @@ func TestAppendVhostUserBlkDevices(t *testing.T) {
 		updatedDevList, expected)
 }
 
+func TestConstrainGRPCResources(t *testing.T) {
+	assert := assert.New(t)
+
+	guestCPUs := func(cpus string) string {
+		if cpus == "4-5" {
+			return "0-1"
+		}
+		return ""
+	}
+
+	r := &pb.LinuxResources{
+		CPU: &pb.LinuxCPU{Cpus: "4-5", Mems: "1"},
+		BlockIO: &pb.LinuxBlockIO{
+			Weight:                500,
+			WeightDevice:          []pb.LinuxWeightDevice{{Major: 8, Minor: 0, Weight: 100}},
+			ThrottleReadBpsDevice: []pb.LinuxThrottleDevice{{Major: 8, Minor: 0, Rate: 1024}},
+		},
+	}
+	constrainGRPCResources(r, guestCPUs)
+	assert.Equal("0-1", r.CPU.Cpus)
+	assert.Empty(r.CPU.Mems)
+	assert.Equal(&pb.LinuxBlockIO{Weight: 500}, r.BlockIO)
+
+	// the limits of the devices of the host are dropped
+	r = &pb.LinuxResources{
+		CPU: &pb.LinuxCPU{Cpus: "7"},
+		BlockIO: &pb.LinuxBlockIO{
+			ThrottleWriteIOPSDevice: []pb.LinuxThrottleDevice{{Major: 8, Minor: 0, Rate: 100}},
+		},
+	}
+	constrainGRPCResources(r, guestCPUs)
+	assert.Empty(r.CPU.Cpus)
+	assert.Nil(r.BlockIO)
+
+	constrainGRPCResources(nil, guestCPUs)
+}
+
 func TestConstrainGRPCSpec(t *testing.T) {
 	assert := assert.New(t)
 	expectedCgroupPath := "system.slice:foo:bar"
@@ -625,8 +847,8 @@ func TestConstrainGRPCSpec(t *testing.T) {
 	assert.NotNil(g.Linux.Seccomp)
 	assert.Nil(g.Linux.Resources.Devices)
 	assert.NotNil(g.Linux.Resources.Memory)
-	assert.Nil(g.Linux.Resources.Pids)
-	assert.Nil(g.Linux.Resources.BlockIO)
+	assert.NotNil(g.Linux.Resources.Pids)
+	assert.NotNil(g.Linux.Resources.BlockIO)
 	assert.Len(g.Linux.Resources.HugepageLimits, 0)
 	assert.Nil(g.Linux.Resources.Network)
 	assert.NotNil(g.Linux.Resources.CPU)
@@ -646,6 +868,50 @@ func TestConstrainGRPCSpec(t *testing.T) {
 	assert.Empty(g.Linux.Devices)
 }
 
+func TestSetGuestPidsLimit(t *testing.T) {
+	assert := assert.New(t)
+
+	newSpec := func(pids *pb.LinuxPids) *pb.Spec {
+		return &pb.Spec{Linux: &pb.Linux{Resources: &pb.LinuxResources{Pids: pids}}}
+	}
+
+	// no default limit
+	g := newSpec(nil)
+	setGuestPidsLimit(g, 0)
+	assert.Nil(g.Linux.Resources.Pids)
+
+	// containers without a limit get the default one
+	g = newSpec(nil)
+	setGuestPidsLimit(g, 1024)
+	assert.Equal(int64(1024), g.Linux.Resources.Pids.Limit)
+
+	g = newSpec(&pb.LinuxPids{Limit: -1})
+	setGuestPidsLimit(g, 1024)
+	assert.Equal(int64(1024), g.Linux.Resources.Pids.Limit)
+
+	// the container limit takes precedence
+	g = newSpec(&pb.LinuxPids{Limit: 100})
+	setGuestPidsLimit(g, 1024)
+	assert.Equal(int64(100), g.Linux.Resources.Pids.Limit)
+
+	g = &pb.Spec{Linux: &pb.Linux{}}
+	setGuestPidsLimit(g, 1024)
+	assert.Equal(int64(1024), g.Linux.Resources.Pids.Limit)
+
+	// an update removing the container limit keeps the default one
+	pids := &pb.LinuxPids{Limit: 0}
+	keepGuestPidsLimit(pids, 1024)
+	assert.Equal(int64(1024), pids.Limit)
+
+	pids = &pb.LinuxPids{Limit: 200}
+	keepGuestPidsLimit(pids, 1024)
+	assert.Equal(int64(200), pids.Limit)
+
+	pids = &pb.LinuxPids{Limit: -1}
+	keepGuestPidsLimit(pids, 0)
+	assert.Equal(int64(-1), pids.Limit)
+}
+
 func TestHandleShm(t *testing.T) {
 	assert := assert.New(t)
 	k := kataAgent{}
@@ -691,7 +957,7 @@ func TestHandleShm(t *testing.T) {
 	assert.Equal(ociMounts[0].Type, KataEphemeralDevType)
 	assert.NotEmpty(ociMounts[0].Source, mountSource)
 
-	epheStorages, err := k.handleEphemeralStorage(ociMounts)
+	epheStorages, err := k.handleEphemeralStorage(ociMounts, 0)
 	assert.Nil(err)
 
 	epheMountPoint := epheStorages[0].MountPoint
@@ -765,6 +1031,23 @@ func TestHandlePidNamespace(t *testing.T) {
 	assert.False(testIsPidNamespacePresent(g))
 }
 
+func TestUseSandboxPidNs(t *testing.T) {
+	assert := assert.New(t)
+
+	sandbox := &Sandbox{id: "sandbox", containers: map[string]*Container{}}
+	pause := &Container{id: "sandbox", sandbox: sandbox}
+	app := &Container{id: "app", sandbox: sandbox}
+
+	assert.False(useSandboxPidNs(false, sandbox, app))
+	assert.True(useSandboxPidNs(true, sandbox, app))
+
+	// With a pod-wide PID namespace, all the containers but the sandbox
+	// one join it
+	sandbox.sharePidNs = true
+	assert.False(useSandboxPidNs(false, sandbox, pause))
+	assert.True(useSandboxPidNs(false, sandbox, app))
+}
+
 func TestAgentConfigure(t *testing.T) {
 	assert := assert.New(t)
 
@@ -787,6 +1070,29 @@ func TestAgentConfigure(t *testing.T) {
 	assert.Nil(err)
 }
 
+func TestAgentConfigureTransport(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+
+	k := &kataAgent{}
+	h := &mockHypervisor{}
+	id := "foobar"
+	ctx := context.Background()
+
+	// vsock is available, auto does not fall back
+	err := k.configure(ctx, h, id, dir, KataAgentConfig{Transport: AgentTransportAuto})
+	assert.NoError(err)
+	assert.IsType(types.MockHybridVSock{}, k.vmSocket)
+
+	// the mock hypervisor has no virtio-serial support
+	err = k.configure(ctx, h, id, dir, KataAgentConfig{Transport: AgentTransportSerial})
+	assert.Error(err)
+
+	err = k.configure(ctx, h, id, dir, KataAgentConfig{Transport: "carrier-pigeon"})
+	assert.Error(err)
+}
+
 func TestCmdToKataProcess(t *testing.T) {
 	assert := assert.New(t)
 
@@ -993,6 +1299,62 @@ func TestKataCopyFile(t *testing.T) {
 	assert.NoError(err)
 }
 
+func TestKataAgentStreamClient(t *testing.T) {
+	assert := assert.New(t)
+
+	url, err := mock.GenerateKataMockHybridVSock()
+	assert.NoError(err)
+	defer mock.RemoveKataMockHybridVSock(url)
+
+	hybridVSockTTRPCMock := mock.HybridVSockTTRPCMock{}
+	err = hybridVSockTTRPCMock.Start(url)
+	assert.NoError(err)
+	defer hybridVSockTTRPCMock.Stop()
+
+	k := &kataAgent{
+		ctx: context.Background(),
+		state: KataAgentState{
+			URL: url,
+		},
+		keepConn: true,
+	}
+
+	// Without dedicated connections, streams share the control connection.
+	client, err := k.streamClient(context.Background(), "foo", "bar")
+	assert.NoError(err)
+	assert.Equal(k.client, client)
+	assert.NoError(k.disconnect(context.Background()))
+
+	k.streamConns = 2
+	client, err = k.streamClient(context.Background(), "foo", "bar")
+	assert.NoError(err)
+	assert.NotEqual(k.client, client)
+	assert.Len(k.streamClients, 2)
+	assert.Equal(client, k.streamClients[streamConnIndex("foo", "bar", 2)])
+
+	// The same process always maps to the same connection.
+	again, err := k.streamClient(context.Background(), "foo", "bar")
+	assert.NoError(err)
+	assert.Equal(client, again)
+
+	_, err = k.sendStreamReq(context.Background(), "foo", "bar", &pb.CheckRequest{})
+	assert.Error(err)
+
+	assert.NoError(k.disconnect(context.Background()))
+	assert.Nil(k.streamClients)
+	assert.Nil(k.client)
+}
+
+func TestStreamConnIndex(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, conns := range []uint32{1, 2, 8} {
+		idx := streamConnIndex("container", "exec", conns)
+		assert.Less(idx, conns)
+		assert.Equal(idx, streamConnIndex("container", "exec", conns))
+	}
+}
+
 func TestKataCleanupSandbox(t *testing.T) {
 	assert := assert.New(t)
 
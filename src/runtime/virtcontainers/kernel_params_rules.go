@@ -0,0 +1,211 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	ctrAnnotations "github.com/containerd/containerd/pkg/cri/annotations"
+	vcAnnotations "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations"
+)
+
+// A kernel parameters rule adds guest kernel parameters to the sandboxes
+// whose resources match its conditions, e.g.
+//
+//	vcpus>=8,memory>=16384: transparent_hugepage=always sysctl.vm.max_map_count=1048576
+//
+// The conditions, none for all the sandboxes, compare the vcpus, memory (MiB)
+// or volumes of the sandbox with <, <=, >, >= or ==. The values of the
+// parameters may use the {vcpus}, {memory} and {volumes} of the sandbox. The
+// guest kernel applies the sysctl.* parameters, so rules tune the sysctls of
+// the guest as well. The parameters of the rules come after the static ones,
+// which they override.
+
+// kernelParamsResources are the resources the rules are evaluated on.
+var kernelParamsResources = []string{"vcpus", "memory", "volumes"}
+
+// kernelParamsOperators are the comparison operators of the conditions, the
+// longest first.
+var kernelParamsOperators = []string{"<=", ">=", "==", "<", ">"}
+
+// kernelParamsCondition compares a resource of the sandbox with a value.
+type kernelParamsCondition struct {
+	resource string
+	operator string
+	value    uint64
+}
+
+// KernelParamsRule adds kernel parameters to the sandboxes matching its
+// conditions.
+type KernelParamsRule struct {
+	conditions []kernelParamsCondition
+	params     []Param
+}
+
+// ParseKernelParamsRule parses a "conditions: params" kernel parameters rule.
+func ParseKernelParamsRule(rule string) (KernelParamsRule, error) {
+	var r KernelParamsRule
+
+	fields := strings.SplitN(rule, ":", 2)
+	if len(fields) != 2 {
+		return r, fmt.Errorf("Invalid kernel parameters rule %q, expected conditions: params", rule)
+	}
+
+	if conditions := strings.TrimSpace(fields[0]); conditions != "" {
+		for _, c := range strings.Split(conditions, ",") {
+			condition, err := parseKernelParamsCondition(strings.TrimSpace(c))
+			if err != nil {
+				return r, fmt.Errorf("Invalid kernel parameters rule %q: %v", rule, err)
+			}
+			r.conditions = append(r.conditions, condition)
+		}
+	}
+
+	r.params = DeserializeParams(strings.Fields(fields[1]))
+	if len(r.params) == 0 {
+		return r, fmt.Errorf("Invalid kernel parameters rule %q, no parameters", rule)
+	}
+
+	return r, nil
+}
+
+func parseKernelParamsCondition(condition string) (kernelParamsCondition, error) {
+	for _, op := range kernelParamsOperators {
+		i := strings.Index(condition, op)
+		if i < 0 {
+			continue
+		}
+
+		c := kernelParamsCondition{
+			resource: strings.TrimSpace(condition[:i]),
+			operator: op,
+		}
+		known := false
+		for _, resource := range kernelParamsResources {
+			known = known || resource == c.resource
+		}
+		if !known {
+			return c, fmt.Errorf("unknown resource %q", c.resource)
+		}
+
+		value, err := strconv.ParseUint(strings.TrimSpace(condition[i+len(op):]), 10, 64)
+		if err != nil {
+			return c, fmt.Errorf("invalid value in %q: %v", condition, err)
+		}
+		c.value = value
+
+		return c, nil
+	}
+
+	return kernelParamsCondition{}, fmt.Errorf("no comparison in %q", condition)
+}
+
+func (c kernelParamsCondition) match(resources map[string]uint64) bool {
+	value := resources[c.resource]
+	switch c.operator {
+	case "<":
+		return value < c.value
+	case "<=":
+		return value <= c.value
+	case ">":
+		return value > c.value
+	case ">=":
+		return value >= c.value
+	default:
+		return value == c.value
+	}
+}
+
+// kubeletPodsDir is the directory where kubelet sets up the volumes of the
+// pods, in volumes/<plugin>/<volume> under the directory of each pod.
+var kubeletPodsDir = "/var/lib/kubelet/pods"
+
+// volumeCount returns the number of volumes of the containers of the sandbox,
+// the bind mounts of the system and the files the container managers provide
+// not being volumes. Under CRI, the sandbox only has its pause container when
+// the VM starts, the volumes of the pod kubelet set up are counted instead.
+func (config *SandboxConfig) volumeCount() uint64 {
+	if len(config.Containers) == 1 && config.Containers[0].Annotations[vcAnnotations.ContainerTypeKey] == string(PodSandbox) {
+		return podVolumeCount(config.Containers[0].Annotations[ctrAnnotations.SandboxLogDir])
+	}
+
+	var count uint64
+	for _, c := range config.Containers {
+		for _, m := range c.Mounts {
+			if isSystemMount(m.Destination) {
+				continue
+			}
+			switch m.Destination {
+			case "/etc/hosts", "/etc/hostname", "/etc/resolv.conf", "/dev/shm", "/dev/termination-log":
+				continue
+			}
+			count++
+		}
+	}
+	return count
+}
+
+// podVolumeCount returns the number of volumes kubelet set up for the pod of
+// the log directory, /var/log/pods/<namespace>_<name>_<uid>.
+func podVolumeCount(logDir string) uint64 {
+	name := filepath.Base(logDir)
+	i := strings.LastIndex(name, "_")
+	if logDir == "" || i < 0 {
+		return 0
+	}
+
+	plugins, err := os.ReadDir(filepath.Join(kubeletPodsDir, name[i+1:], "volumes"))
+	if err != nil {
+		return 0
+	}
+	var count uint64
+	for _, plugin := range plugins {
+		volumes, err := os.ReadDir(filepath.Join(kubeletPodsDir, name[i+1:], "volumes", plugin.Name()))
+		if err != nil {
+			continue
+		}
+		count += uint64(len(volumes))
+	}
+	return count
+}
+
+// ruleKernelParams returns the kernel parameters of the rules matching the
+// resources of the sandbox.
+func (config *SandboxConfig) ruleKernelParams() []Param {
+	if len(config.KernelParamsRules) == 0 {
+		return nil
+	}
+
+	resources := map[string]uint64{
+		"vcpus":   uint64(config.HypervisorConfig.NumVCPUs),
+		"memory":  uint64(config.HypervisorConfig.MemorySize),
+		"volumes": config.volumeCount(),
+	}
+	replacer := strings.NewReplacer(
+		"{vcpus}", strconv.FormatUint(resources["vcpus"], 10),
+		"{memory}", strconv.FormatUint(resources["memory"], 10),
+		"{volumes}", strconv.FormatUint(resources["volumes"], 10))
+
+	var params []Param
+	for _, r := range config.KernelParamsRules {
+		matched := true
+		for _, c := range r.conditions {
+			matched = matched && c.match(resources)
+		}
+		if !matched {
+			continue
+		}
+		for _, p := range r.params {
+			params = append(params, Param{Key: p.Key, Value: replacer.Replace(p.Value)})
+		}
+	}
+
+	return params
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	ctrAnnotations "github.com/containerd/containerd/pkg/cri/annotations"
+	vcAnnotations "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseKernelParamsRule(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, rule := range []string{
+		"",
+		"memory>=1024",
+		"memory>=1024:",
+		"cpus>=2: nr_cpus=2",
+		"memory=1024: transparent_hugepage=never",
+		"memory>=1G: transparent_hugepage=never",
+	} {
+		_, err := ParseKernelParamsRule(rule)
+		assert.Error(err, rule)
+	}
+
+	rule, err := ParseKernelParamsRule("vcpus >= 2, memory<4096: nr_cpus={vcpus} quiet")
+	assert.NoError(err)
+	assert.Equal([]kernelParamsCondition{
+		{resource: "vcpus", operator: ">=", value: 2},
+		{resource: "memory", operator: "<", value: 4096},
+	}, rule.conditions)
+	assert.Equal([]Param{{Key: "nr_cpus", Value: "{vcpus}"}, {Key: "quiet"}}, rule.params)
+}
+
+func TestRuleKernelParams(t *testing.T) {
+	assert := assert.New(t)
+
+	config := &SandboxConfig{
+		HypervisorConfig: HypervisorConfig{NumVCPUs: 4, MemorySize: 8192},
+		Containers: []ContainerConfig{
+			{
+				Mounts: []Mount{
+					{Destination: "/proc"},
+					{Destination: "/etc/hosts"},
+					{Destination: "/data"},
+					{Destination: "/cache"},
+				},
+			},
+		},
+	}
+	assert.Empty(config.ruleKernelParams())
+
+	for _, r := range []string{
+		": nr_cpus={vcpus}",
+		"memory>=8192: transparent_hugepage=always",
+		"memory<8192: transparent_hugepage=never",
+		"vcpus==4,volumes>1: sysctl.fs.aio-max-nr={volumes}00000",
+		"volumes>2: sysctl.fs.inotify.max_user_watches=1048576",
+	} {
+		rule, err := ParseKernelParamsRule(r)
+		assert.NoError(err)
+		config.KernelParamsRules = append(config.KernelParamsRules, rule)
+	}
+
+	assert.Equal([]Param{
+		{Key: "nr_cpus", Value: "4"},
+		{Key: "transparent_hugepage", Value: "always"},
+		{Key: "sysctl.fs.aio-max-nr", Value: "200000"},
+	}, config.ruleKernelParams())
+}
+
+func TestVolumeCountCRI(t *testing.T) {
+	assert := assert.New(t)
+
+	savedKubeletPodsDir := kubeletPodsDir
+	defer func() {
+		kubeletPodsDir = savedKubeletPodsDir
+	}()
+	kubeletPodsDir = t.TempDir()
+
+	uid := "5f0861a0-a987-4a3a-bb0f-1058ddb9678f"
+	for _, volume := range []string{"kubernetes.io~empty-dir/cache", "kubernetes.io~configmap/config", "kubernetes.io~projected/kube-api-access-x2v7q"} {
+		assert.NoError(os.MkdirAll(filepath.Join(kubeletPodsDir, uid, "volumes", volume), 0755))
+	}
+
+	// the pause container of the pod, the only container when the VM
+	// starts
+	config := &SandboxConfig{
+		Containers: []ContainerConfig{
+			{
+				Annotations: map[string]string{
+					vcAnnotations.ContainerTypeKey: string(PodSandbox),
+					ctrAnnotations.SandboxLogDir:   "/var/log/pods/default_web-0_" + uid,
+				},
+				Mounts: []Mount{{Destination: "/dev/shm"}},
+			},
+		},
+	}
+	assert.Equal(uint64(3), config.volumeCount())
+
+	// another pod
+	config.Containers[0].Annotations[ctrAnnotations.SandboxLogDir] = "/var/log/pods/default_web-1_9d2a"
+	assert.Equal(uint64(0), config.volumeCount())
+
+	delete(config.Containers[0].Annotations, ctrAnnotations.SandboxLogDir)
+	assert.Equal(uint64(0), config.volumeCount())
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/cpuset"
+)
+
+// LowLatencyProfile trades the density of the sandboxes for the tail latency
+// of their workloads. The memory of the guests is static, without balloon
+// nor virtio-mem, and their vCPUs are pinned, vCPU 0 to a housekeeping CPU of
+// the host and the others to the CPUs isolated with isolcpus, when there are
+// any. The guests poll before halting their idle vCPUs, and vCPU 0 does their
+// housekeeping so that the ticks and the RCU callbacks of the others are
+// offloaded.
+const LowLatencyProfile = "low-latency"
+
+// hostIsolatedCPUsPath lists the CPUs of the host isolated from the scheduler.
+var hostIsolatedCPUsPath = "/sys/devices/system/cpu/isolated"
+
+// lowLatencyKernelParams returns the guest kernel parameters of the low
+// latency profile.
+func (config *SandboxConfig) lowLatencyKernelParams() []Param {
+	if config.Profile != LowLatencyProfile {
+		return nil
+	}
+
+	params := []Param{{Key: "cpuidle_haltpoll.force", Value: "1"}}
+	if n := config.HypervisorConfig.NumVCPUs; n > 1 {
+		cpus := fmt.Sprintf("1-%d", n-1)
+		params = append(params,
+			Param{Key: "nohz_full", Value: cpus},
+			Param{Key: "rcu_nocbs", Value: cpus})
+	}
+	return params
+}
+
+// housekeepingCPUsFirst orders the host CPUs the vCPUs are pinned to with
+// the ones not isolated first, so that vCPU 0 runs on a housekeeping CPU and
+// the others on the isolated CPUs.
+func housekeepingCPUsFirst(cpus []int) []int {
+	data, err := os.ReadFile(hostIsolatedCPUsPath)
+	if err != nil {
+		return cpus
+	}
+	isolated, err := cpuset.Parse(strings.TrimSpace(string(data)))
+	if err != nil || isolated.IsEmpty() {
+		return cpus
+	}
+
+	ordered := make([]int, 0, len(cpus))
+	for _, cpu := range cpus {
+		if !isolated.Contains(cpu) {
+			ordered = append(ordered, cpu)
+		}
+	}
+	for _, cpu := range cpus {
+		if isolated.Contains(cpu) {
+			ordered = append(ordered, cpu)
+		}
+	}
+	return ordered
+}
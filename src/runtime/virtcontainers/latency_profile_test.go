@@ -0,0 +1,48 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLowLatencyKernelParams(t *testing.T) {
+	assert := assert.New(t)
+
+	config := &SandboxConfig{HypervisorConfig: HypervisorConfig{NumVCPUs: 4}}
+	assert.Empty(config.lowLatencyKernelParams())
+
+	config.Profile = LowLatencyProfile
+	assert.Equal([]Param{
+		{Key: "cpuidle_haltpoll.force", Value: "1"},
+		{Key: "nohz_full", Value: "1-3"},
+		{Key: "rcu_nocbs", Value: "1-3"},
+	}, config.lowLatencyKernelParams())
+
+	// A single vCPU does the housekeeping
+	config.HypervisorConfig.NumVCPUs = 1
+	assert.Equal([]Param{{Key: "cpuidle_haltpoll.force", Value: "1"}}, config.lowLatencyKernelParams())
+}
+
+func TestHousekeepingCPUsFirst(t *testing.T) {
+	assert := assert.New(t)
+
+	savedPath := hostIsolatedCPUsPath
+	defer func() { hostIsolatedCPUsPath = savedPath }()
+
+	hostIsolatedCPUsPath = filepath.Join(t.TempDir(), "isolated")
+	assert.Equal([]int{2, 3, 4}, housekeepingCPUsFirst([]int{2, 3, 4}))
+
+	assert.NoError(os.WriteFile(hostIsolatedCPUsPath, []byte("\n"), 0644))
+	assert.Equal([]int{2, 3, 4}, housekeepingCPUsFirst([]int{2, 3, 4}))
+
+	assert.NoError(os.WriteFile(hostIsolatedCPUsPath, []byte("2-3\n"), 0644))
+	assert.Equal([]int{4, 2, 3}, housekeepingCPUsFirst([]int{2, 3, 4}))
+}
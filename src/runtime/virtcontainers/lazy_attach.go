@@ -0,0 +1,73 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+)
+
+// With a lazy device attach, only the devices the guest needs to boot are
+// cold-plugged. The network interfaces are hotplugged once the VMM runs,
+// while the guest kernel and the agent come up, and are waited for before
+// the agent sets the network up.
+
+// lazyAttach is the hotplug of the network interfaces of a sandbox while its
+// guest boots.
+type lazyAttach struct {
+	// done is closed once the interfaces are hotplugged.
+	done chan struct{}
+	err  error
+}
+
+// lazyDeviceAttach tells if the network interfaces of the sandbox are
+// hotplugged while the guest boots rather than cold-plugged.
+func (s *Sandbox) lazyDeviceAttach(ctx context.Context) bool {
+	// The interfaces of a VM from the factory are hotplugged anyway.
+	if !s.config.LazyDeviceAttach || s.factory != nil || s.config.NetworkConfig.DisableNewNetwork {
+		return false
+	}
+
+	// The AF_XDP network devices cannot be hotplugged, they are always
+	// cold-plugged.
+	if s.config.NetworkConfig.InterworkingModel == NetXConnectAFXDPModel {
+		return false
+	}
+
+	caps := s.hypervisor.Capabilities(ctx)
+	return caps.IsNetworkDeviceHotplugSupported()
+}
+
+// startLazyAttach starts hotplugging the network interfaces of the sandbox
+// once its VM is started.
+func (s *Sandbox) startLazyAttach(ctx context.Context) {
+	if !s.lazyDeviceAttach(ctx) {
+		return
+	}
+
+	attach := &lazyAttach{done: make(chan struct{})}
+	s.lazyAttach = attach
+	go func() {
+		defer close(attach.done)
+		_, attach.err = s.network.AddEndpoints(ctx, s, nil, true)
+		if attach.err == nil {
+			s.Logger().Debug("network interfaces attached while the guest booted")
+		}
+	}()
+}
+
+// waitLazyAttach waits for the network interfaces being hotplugged, if any.
+// It can be called any number of times, and returns the error of the
+// hotplug each time. The endpoints of the network of the sandbox must not be
+// used before it returns.
+func (s *Sandbox) waitLazyAttach() error {
+	attach := s.lazyAttach
+	if attach == nil {
+		return nil
+	}
+
+	<-attach.done
+	return attach.err
+}
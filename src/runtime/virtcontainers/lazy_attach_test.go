@@ -0,0 +1,67 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// netHotplugMockHypervisor is a mock hypervisor hotplugging network devices.
+type netHotplugMockHypervisor struct {
+	mockHypervisor
+}
+
+func (m *netHotplugMockHypervisor) Capabilities(ctx context.Context) types.Capabilities {
+	caps := m.mockHypervisor.Capabilities(ctx)
+	caps.SetNetworkDeviceHotplugSupport()
+	return caps
+}
+
+func TestLazyDeviceAttach(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	n, err := NewNetwork(&NetworkConfig{Simulated: true})
+	assert.NoError(err)
+
+	s := &Sandbox{
+		id:         testSandboxID,
+		config:     &SandboxConfig{LazyDeviceAttach: true},
+		hypervisor: &mockHypervisor{},
+		network:    n,
+	}
+
+	// The hypervisor cannot hotplug the network interfaces.
+	assert.False(s.lazyDeviceAttach(ctx))
+	s.startLazyAttach(ctx)
+	assert.Nil(s.lazyAttach)
+	assert.NoError(s.waitLazyAttach())
+
+	s.hypervisor = &netHotplugMockHypervisor{}
+	assert.True(s.lazyDeviceAttach(ctx))
+
+	s.config.NetworkConfig.DisableNewNetwork = true
+	assert.False(s.lazyDeviceAttach(ctx))
+	s.config.NetworkConfig.DisableNewNetwork = false
+
+	// The AF_XDP network devices are cold-plugged.
+	s.config.NetworkConfig.InterworkingModel = NetXConnectAFXDPModel
+	assert.False(s.lazyDeviceAttach(ctx))
+	s.config.NetworkConfig.InterworkingModel = NetXConnectDefaultModel
+
+	s.startLazyAttach(ctx)
+	assert.NotNil(s.lazyAttach)
+	assert.NoError(s.waitLazyAttach())
+	assert.Len(n.Endpoints(), 1)
+
+	// The hotplug is waited for by the agent and by postCreatedNetwork.
+	assert.NoError(s.waitLazyAttach())
+	assert.Len(n.Endpoints(), 1)
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// SandboxMetadata is the metadata of the pod the sandbox runs, served to the
+// software of the guest in the style of the cloud-init NoCloud data source,
+// so that it can configure itself without access to the Kubernetes API.
+type SandboxMetadata struct {
+	// InstanceID is the sandbox ID
+	InstanceID    string `json:"instance-id"`
+	LocalHostname string `json:"local-hostname,omitempty"`
+
+	// Name and Namespace are the ones of the pod
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+
+	// Labels are the labels of the pod
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations are the annotations of the pod matching the allowlist
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// VCPUs and MemoryMB are the resource limits of the pod
+	VCPUs    uint32 `json:"vcpus,omitempty"`
+	MemoryMB uint32 `json:"memory-mb,omitempty"`
+}
+
+// guestMetadataDir is the directory of the guest the sandbox metadata is
+// written in, the seed directory of a NoCloud data source.
+func guestMetadataDir() string {
+	return filepath.Join(kataGuestSandboxDir(), "metadata")
+}
+
+// writeMetadata writes the metadata of the sandbox inside the guest, as the
+// meta-data file of a NoCloud seed directory along with an empty user-data.
+// JSON being YAML, cloud-init reads the document as is.
+func (s *Sandbox) writeMetadata(ctx context.Context) error {
+	if s.config.Metadata == nil {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(s.config.Metadata, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir, err := os.MkdirTemp("", "kata-metadata-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string][]byte{
+		"meta-data": append(data, '\n'),
+		"user-data": nil,
+	}
+	for name, content := range files {
+		src := filepath.Join(dir, name)
+		if err := os.WriteFile(src, content, 0644); err != nil {
+			return err
+		}
+		if err := s.agent.copyFile(ctx, src, filepath.Join(guestMetadataDir(), name)); err != nil {
+			return err
+		}
+	}
+
+	s.Logger().WithField("path", guestMetadataDir()).Info("sandbox metadata written in the guest")
+	return nil
+}
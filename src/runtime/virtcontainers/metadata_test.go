@@ -0,0 +1,68 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// copyFileAgent records the files it copies to the guest.
+type copyFileAgent struct {
+	mockAgent
+	files map[string]string
+	sync.Mutex
+}
+
+func (c *copyFileAgent) copyFile(ctx context.Context, src, dst string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.files[dst] = string(data)
+	return nil
+}
+
+func (c *copyFileAgent) file(dst string) (string, bool) {
+	c.Lock()
+	defer c.Unlock()
+	data, ok := c.files[dst]
+	return data, ok
+}
+
+func TestWriteMetadata(t *testing.T) {
+	assert := assert.New(t)
+
+	agent := &copyFileAgent{files: make(map[string]string)}
+	s := &Sandbox{
+		config: &SandboxConfig{},
+		agent:  agent,
+	}
+
+	// Nothing is written unless the metadata is served
+	assert.NoError(s.writeMetadata(context.Background()))
+	assert.Empty(agent.files)
+
+	s.config.Metadata = &SandboxMetadata{
+		InstanceID: "sandbox",
+		Name:       "web-0",
+		Labels:     map[string]string{"app": "web"},
+		VCPUs:      2,
+	}
+	assert.NoError(s.writeMetadata(context.Background()))
+	assert.JSONEq(`{"instance-id":"sandbox","name":"web-0","labels":{"app":"web"},"vcpus":2}`,
+		agent.files[filepath.Join(guestMetadataDir(), "meta-data")])
+	userData, ok := agent.files[filepath.Join(guestMetadataDir(), "user-data")]
+	assert.True(ok)
+	assert.Empty(userData)
+}
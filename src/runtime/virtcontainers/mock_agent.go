@@ -252,6 +252,10 @@ func (n *mockAgent) getAgentMetrics(ctx context.Context, req *grpc.GetMetricsReq
 	return nil, nil
 }
 
+func (n *mockAgent) getContainerVolumeStats(ctx context.Context, containerID string) ([]*grpc.ContainerVolumeStats, error) {
+	return nil, nil
+}
+
 func (n *mockAgent) getGuestVolumeStats(ctx context.Context, volumeGuestPath string) ([]byte, error) {
 	return nil, nil
 }
@@ -260,6 +264,58 @@ func (n *mockAgent) resizeGuestVolume(ctx context.Context, volumeGuestPath strin
 	return nil
 }
 
+func (n *mockAgent) waitDevice(ctx context.Context, devType, address string, timeout time.Duration) error {
+	return nil
+}
+
+func (n *mockAgent) syncFs(ctx context.Context, containerID string, timeout time.Duration) error {
+	return nil
+}
+
+func (n *mockAgent) freezeFs(ctx context.Context, containerID string) error {
+	return nil
+}
+
+func (n *mockAgent) thawFs(ctx context.Context) error {
+	return nil
+}
+
+func (n *mockAgent) setNameResolution(ctx context.Context, hosts, resolvConf []byte) error {
+	return nil
+}
+
+func (n *mockAgent) setNetworkPolicy(ctx context.Context, program []byte, policy *NetworkPolicy) error {
+	return nil
+}
+
+func (n *mockAgent) getSeccompNotification(ctx context.Context) (*SeccompNotification, error) {
+	return nil, nil
+}
+
+func (n *mockAgent) respondSeccompNotification(ctx context.Context, resp SeccompNotificationResponse) error {
+	return nil
+}
+
+func (n *mockAgent) getGuestServices(ctx context.Context) ([]GuestServiceStatus, error) {
+	return nil, nil
+}
+
+func (n *mockAgent) readFile(ctx context.Context, path string, offset, maxSize uint64) ([]byte, error) {
+	return nil, nil
+}
+
+func (n *mockAgent) getDiagnostics(ctx context.Context, maxKernelLogSize uint64) (*grpc.Diagnostics, error) {
+	return &grpc.Diagnostics{}, nil
+}
+
+func (n *mockAgent) checkpointContainer(ctx context.Context, c *Container, imagePath string, opts CheckpointOptions) ([]string, error) {
+	return nil, nil
+}
+
+func (n *mockAgent) restoreContainer(ctx context.Context, c *Container, imagePath string, opts CheckpointOptions) error {
+	return nil
+}
+
 func (k *mockAgent) getIPTables(ctx context.Context, isIPv6 bool) ([]byte, error) {
 	return nil, nil
 }
@@ -155,3 +155,7 @@ func (m *mockHypervisor) GenerateSocket(id string) (interface{}, error) {
 func (m *mockHypervisor) IsRateLimiterBuiltin() bool {
 	return false
 }
+
+func (m *mockHypervisor) FlushDrives(ctx context.Context, driveIDs []string) error {
+	return nil
+}
@@ -68,6 +68,8 @@ func (m *monitor) newWatcher(ctx context.Context) (chan error, error) {
 				case <-tick.C:
 					m.watchHypervisor(ctx)
 					m.watchAgent(ctx)
+					m.watchVMMSched(ctx)
+					m.watchVMMLimits()
 				}
 			}
 		}()
@@ -147,6 +149,18 @@ func (m *monitor) watchAgent(ctx context.Context) {
 	}
 }
 
+func (m *monitor) watchVMMSched(ctx context.Context) {
+	if err := m.sandbox.checkVMMSched(ctx); err != nil {
+		monitorLog.WithError(err).Warn("failed to schedule the VMM threads")
+	}
+}
+
+func (m *monitor) watchVMMLimits() {
+	if err := m.sandbox.checkVMMLimits(); err != nil {
+		monitorLog.WithError(err).Warn("failed to limit the VMM processes")
+	}
+}
+
 func (m *monitor) watchHypervisor(ctx context.Context) error {
 	if err := m.sandbox.hypervisor.Check(); err != nil {
 		m.notify(ctx, errors.Wrapf(err, "failed to ping hypervisor process"))
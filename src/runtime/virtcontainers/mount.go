@@ -6,6 +6,8 @@
 package virtcontainers
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -250,6 +252,10 @@ type Mount struct {
 	// HostPath used to store host side bind mount path
 	HostPath string
 
+	// GuestPath is the path of the copy of the file in the guest, when it
+	// is copied rather than shared.
+	GuestPath string
+
 	// GuestDeviceMount represents the path within the VM that the device
 	// is mounted. Only relevant for block devices. This is tracked in the event
 	// runtime wants to query the agent for mount stats.
@@ -415,3 +421,35 @@ func isWatchableMount(path string) bool {
 
 	return false
 }
+
+// mountPropagation returns the propagation requested by the options of a
+// mount, "rshared" or "rslave", or an empty string for the default private
+// propagation. As with mount(8), the last propagation option wins.
+func mountPropagation(options []string) string {
+	propagation := ""
+	for _, o := range options {
+		switch o {
+		case "shared", "rshared":
+			propagation = "rshared"
+		case "slave", "rslave":
+			propagation = "rslave"
+		case "private", "rprivate":
+			propagation = ""
+		}
+	}
+	return propagation
+}
+
+// propagatedMountName returns the name under which a volume with a shared
+// propagation is shared with the guest. It only depends on the source of the
+// volume, for all the containers of the sandbox using it to share its mount,
+// and on whether it is read-only, for the read-only and read-write mounts of
+// a same source not to share a single mount.
+func propagatedMountName(source string, readOnly bool) string {
+	sum := sha256.Sum256([]byte(source))
+	name := "propagated-" + hex.EncodeToString(sum[:])
+	if readOnly {
+		name += "-ro"
+	}
+	return name
+}
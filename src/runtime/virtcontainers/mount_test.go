@@ -200,3 +200,19 @@ func TestIsWatchable(t *testing.T) {
 	result = isWatchableMount(configs)
 	assert.False(result)
 }
+
+func TestMountPropagation(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("", mountPropagation(nil))
+	assert.Equal("", mountPropagation([]string{"rbind", "ro"}))
+	assert.Equal("rshared", mountPropagation([]string{"rbind", "shared"}))
+	assert.Equal("rshared", mountPropagation([]string{"rbind", "rshared"}))
+	assert.Equal("rslave", mountPropagation([]string{"rbind", "rslave"}))
+	assert.Equal("rslave", mountPropagation([]string{"rshared", "slave"}))
+	assert.Equal("", mountPropagation([]string{"rshared", "rprivate"}))
+
+	assert.Equal(propagatedMountName("/foo", false), propagatedMountName("/foo", false))
+	assert.NotEqual(propagatedMountName("/foo", false), propagatedMountName("/bar", false))
+	assert.NotEqual(propagatedMountName("/foo", false), propagatedMountName("/foo", true))
+}
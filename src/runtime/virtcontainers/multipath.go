@@ -0,0 +1,85 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/multipath"
+	"golang.org/x/sys/unix"
+)
+
+// multipathPollInterval is how often the states of the paths of the
+// multipath maps attached to a sandbox are polled.
+const multipathPollInterval = 2 * time.Second
+
+// multipathDevice returns the multipath map the block device major:minor is
+// a path of, nil when it is not a path of a map. The paths of a map are not
+// attached by themselves, as their IO would bypass the failover of the map
+// and race the IO going through it.
+func multipathDevice(major, minor int64) *multipath.Map {
+	dev, err := multipath.DeviceName(major, minor)
+	if err != nil {
+		return nil
+	}
+	return multipath.MapOfPath(dev)
+}
+
+// multipathSubstitute returns the device node and number of the multipath map
+// the block device path, major:minor is a path of, or the given ones when it
+// is not a path of a map.
+func (s *Sandbox) multipathSubstitute(path string, major, minor int64) (string, int64, int64, error) {
+	m := multipathDevice(major, minor)
+	if m == nil {
+		return path, major, minor, nil
+	}
+
+	mapPath := m.DevicePath()
+	var stat unix.Stat_t
+	if err := unix.Stat(mapPath, &stat); err != nil {
+		return "", 0, 0, err
+	}
+
+	s.Logger().WithField("device", path).WithField("multipath", mapPath).
+		Warn("block device is a path of a multipath map, attaching the map instead")
+
+	if s.multipathWatcher != nil {
+		s.multipathWatcher.Add(m)
+	}
+	return mapPath, int64(unix.Major(uint64(stat.Rdev))), int64(unix.Minor(uint64(stat.Rdev))), nil
+}
+
+// startMultipathWatcher starts reporting the path failures of the multipath
+// maps attached to the sandbox, when it is enabled.
+func (s *Sandbox) startMultipathWatcher() {
+	if !s.config.MultipathEvents {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.multipathWatcher = multipath.NewWatcher(multipathPollInterval)
+	s.multipathCancel = cancel
+	go s.multipathWatcher.Run(ctx)
+}
+
+// stopMultipathWatcher stops the watcher, which closes the channel of its
+// events. It can be called more than once.
+func (s *Sandbox) stopMultipathWatcher() {
+	if s.multipathCancel != nil {
+		s.multipathCancel()
+	}
+}
+
+// MultipathEvents returns the channel of the changes of the states of the
+// paths of the multipath maps attached to the sandbox, nil when they are not
+// reported.
+func (s *Sandbox) MultipathEvents() <-chan multipath.Event {
+	if s.multipathWatcher == nil {
+		return nil
+	}
+	return s.multipathWatcher.Events()
+}
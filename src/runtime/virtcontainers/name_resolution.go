@@ -0,0 +1,159 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// With GuestNameResolution, the /etc/hosts and /etc/resolv.conf files the
+// container manager generates for the pod, e.g. from the DNS configuration of
+// its CRI sandbox, are not shared with the guest. The agent writes them in the
+// sandbox directory of the guest, where the containers bind mount them, and
+// rewrites them in place when they change on the host.
+
+// nameResolutionFiles are the names of the files in the guest sandbox
+// directory, by container path.
+var nameResolutionFiles = map[string]string{
+	"/etc/hosts":       "hosts",
+	"/etc/resolv.conf": "resolv.conf",
+}
+
+// nameResolution tracks the host files of the name resolution of a sandbox.
+type nameResolution struct {
+	watcher *fsnotify.Watcher
+	// sources are the host files, by container path
+	sources map[string]string
+	sync.Mutex
+}
+
+// guestNameResolutionPath returns the path of the name resolution file of
+// the guest sandbox directory bind mounted at destination.
+func guestNameResolutionPath(destination string) string {
+	return filepath.Join(kataGuestSandboxDir(), "etc", nameResolutionFiles[destination])
+}
+
+// setNameResolutionFile writes the contents of the host file source as the
+// name resolution file bind mounted at destination inside the guest.
+func (s *Sandbox) setNameResolutionFile(ctx context.Context, destination, source string) error {
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return err
+	}
+
+	// Empty contents are left as is by the agent.
+	if len(data) == 0 {
+		data = []byte("\n")
+	}
+	if destination == "/etc/hosts" {
+		return s.agent.setNameResolution(ctx, data, nil)
+	}
+	return s.agent.setNameResolution(ctx, nil, data)
+}
+
+// shareNameResolutionFile has the agent write the name resolution file m is
+// a bind mount of, when it manages them, and returns the guest path of the
+// file. An empty path is returned when the file is shared with the guest
+// like the other bind mounts.
+func (s *Sandbox) shareNameResolutionFile(ctx context.Context, m Mount) (string, error) {
+	if !s.config.GuestNameResolution {
+		return "", nil
+	}
+	if _, ok := nameResolutionFiles[m.Destination]; !ok {
+		return "", nil
+	}
+
+	nr := &s.nameResolution
+	nr.Lock()
+	defer nr.Unlock()
+
+	// The files are the ones of the sandbox, a container bind mounting
+	// another file has it shared.
+	if source, ok := nr.sources[m.Destination]; ok {
+		if source != m.Source {
+			return "", nil
+		}
+		return guestNameResolutionPath(m.Destination), nil
+	}
+
+	if err := s.setNameResolutionFile(ctx, m.Destination, m.Source); err == errUnimplemented {
+		s.Logger().WithField("file", m.Destination).Warn("the agent cannot write the name resolution files, sharing them")
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	if nr.watcher == nil {
+		watcher, err := fsnotify.NewWatcher()
+		if err != nil {
+			return "", err
+		}
+		nr.watcher = watcher
+		nr.sources = make(map[string]string)
+		go s.watchNameResolution(watcher)
+	}
+
+	// Watch the directory, the files may be replaced rather than written.
+	if err := nr.watcher.Add(filepath.Dir(m.Source)); err != nil {
+		s.Logger().WithError(err).WithField("file", m.Source).Warn("failed to watch the name resolution file")
+	}
+	nr.sources[m.Destination] = m.Source
+
+	return guestNameResolutionPath(m.Destination), nil
+}
+
+// watchNameResolution writes the name resolution files inside the guest
+// again when they change on the host, until the watcher is closed.
+func (s *Sandbox) watchNameResolution(watcher *fsnotify.Watcher) {
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+				continue
+			}
+
+			s.nameResolution.Lock()
+			for destination, source := range s.nameResolution.sources {
+				if source != event.Name {
+					continue
+				}
+				logger := s.Logger().WithField("file", destination)
+				if err := s.setNameResolutionFile(s.ctx, destination, source); err != nil {
+					logger.WithError(err).Warn("failed to update the name resolution file")
+				} else {
+					logger.Debug("name resolution file updated")
+				}
+			}
+			s.nameResolution.Unlock()
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			s.Logger().WithError(err).Warn("name resolution files watcher error")
+		}
+	}
+}
+
+// stopNameResolution stops watching the name resolution files.
+func (s *Sandbox) stopNameResolution() {
+	nr := &s.nameResolution
+	nr.Lock()
+	defer nr.Unlock()
+
+	if nr.watcher != nil {
+		nr.watcher.Close()
+		nr.watcher = nil
+	}
+}
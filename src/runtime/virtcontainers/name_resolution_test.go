@@ -0,0 +1,105 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// nameResolutionAgent records the name resolution files it writes.
+type nameResolutionAgent struct {
+	mockAgent
+	hosts      []byte
+	resolvConf []byte
+	sync.Mutex
+}
+
+func (n *nameResolutionAgent) setNameResolution(ctx context.Context, hosts, resolvConf []byte) error {
+	n.Lock()
+	defer n.Unlock()
+	if len(hosts) != 0 {
+		n.hosts = hosts
+	}
+	if len(resolvConf) != 0 {
+		n.resolvConf = resolvConf
+	}
+	return nil
+}
+
+func (n *nameResolutionAgent) files() (string, string) {
+	n.Lock()
+	defer n.Unlock()
+	return string(n.hosts), string(n.resolvConf)
+}
+
+func TestShareNameResolutionFile(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	hosts := filepath.Join(dir, "etc-hosts")
+	resolvConf := filepath.Join(dir, "resolv.conf")
+	assert.NoError(os.WriteFile(hosts, []byte("127.0.0.1 localhost\n"), 0644))
+	assert.NoError(os.WriteFile(resolvConf, []byte("nameserver 10.96.0.10\n"), 0644))
+
+	agent := &nameResolutionAgent{}
+	s := &Sandbox{
+		ctx:    context.Background(),
+		config: &SandboxConfig{},
+		agent:  agent,
+	}
+	defer s.stopNameResolution()
+
+	hostsMount := Mount{Source: hosts, Destination: "/etc/hosts", Type: "bind"}
+
+	// The host files are shared unless the agent manages them
+	guestPath, err := s.shareNameResolutionFile(context.Background(), hostsMount)
+	assert.NoError(err)
+	assert.Empty(guestPath)
+
+	s.config.GuestNameResolution = true
+	guestPath, err = s.shareNameResolutionFile(context.Background(), hostsMount)
+	assert.NoError(err)
+	assert.Equal(filepath.Join(kataGuestSandboxDir(), "etc", "hosts"), guestPath)
+
+	guestPath, err = s.shareNameResolutionFile(context.Background(), Mount{Source: resolvConf, Destination: "/etc/resolv.conf", Type: "bind"})
+	assert.NoError(err)
+	assert.Equal(filepath.Join(kataGuestSandboxDir(), "etc", "resolv.conf"), guestPath)
+
+	h, r := agent.files()
+	assert.Equal("127.0.0.1 localhost\n", h)
+	assert.Equal("nameserver 10.96.0.10\n", r)
+
+	// Another file bind mounted at /etc/hosts is shared
+	guestPath, err = s.shareNameResolutionFile(context.Background(), Mount{Source: resolvConf, Destination: "/etc/hosts", Type: "bind"})
+	assert.NoError(err)
+	assert.Empty(guestPath)
+
+	guestPath, err = s.shareNameResolutionFile(context.Background(), Mount{Source: hosts, Destination: "/etc/hostname", Type: "bind"})
+	assert.NoError(err)
+	assert.Empty(guestPath)
+
+	// The files rewritten on the host are written again inside the guest
+	assert.NoError(os.WriteFile(hosts, []byte("127.0.0.1 localhost\n10.0.0.1 db\n"), 0644))
+	assert.Eventually(func() bool {
+		h, _ := agent.files()
+		return h == "127.0.0.1 localhost\n10.0.0.1 db\n"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	tmp := filepath.Join(dir, "resolv.conf.tmp")
+	assert.NoError(os.WriteFile(tmp, []byte("nameserver 10.96.0.11\n"), 0644))
+	assert.NoError(os.Rename(tmp, resolvConf))
+	assert.Eventually(func() bool {
+		_, r := agent.files()
+		return r == "nameserver 10.96.0.11\n"
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// The host resources of a sandbox are named after its ID, so that they do
+// not collide with the ones of the other sandboxes and can be traced back to
+// it:
+//
+//   - the tap and bridge interfaces are named "tap<index>_<short ID>" and
+//     "br<index>_<short ID>", see netInterfaceNames.
+//   - the vsock context ID is the first available one starting from a value
+//     derived from the sandbox ID, see sandboxContextID.
+//   - the sockets are created under <VMStorePath>/<sandbox ID> and
+//     <RunStorePath>/<sandbox ID>, the sandbox ID is limited by the maximum
+//     length of a socket path, see utils.BuildSocketPath.
+//   - the sandbox cgroup is the one given by the container manager, with a
+//     "kata_" prefix, the overhead cgroup is kata_overhead/<sandbox ID>.
+const (
+	// sandboxShortIDLen is the length of the sandbox short ID. Network
+	// interface names are limited to 15 characters.
+	sandboxShortIDLen = 8
+
+	maxNetInterfaceNameLen = 15
+)
+
+var (
+	hexSandboxIDRegex     = regexp.MustCompile("^[0-9a-f]{8,}$")
+	netInterfaceNameRegex = regexp.MustCompile(`^(tap|br)([0-9]+)_([0-9a-f]{8})$`)
+)
+
+// SandboxShortID returns the short form of a sandbox ID used to name its
+// host network interfaces: the first 8 characters of hexadecimal IDs, as
+// generated by the container managers, the first 8 characters of the
+// SHA-256 of the others.
+func SandboxShortID(id string) string {
+	if !hexSandboxIDRegex.MatchString(id) {
+		sum := sha256.Sum256([]byte(id))
+		id = hex.EncodeToString(sum[:])
+	}
+	return id[:sandboxShortIDLen]
+}
+
+// netInterfaceNames returns the names of the tap and bridge interfaces of
+// the network endpoint idx of the sandbox.
+func netInterfaceNames(sandboxID string, idx int) (string, string, error) {
+	tap := fmt.Sprintf("tap%d_%s", idx, SandboxShortID(sandboxID))
+	if len(tap) > maxNetInterfaceNameLen {
+		return "", "", fmt.Errorf("too many network endpoints: %d", idx)
+	}
+	return tap, fmt.Sprintf("br%d_%s", idx, SandboxShortID(sandboxID)), nil
+}
+
+// NetInterfaceSandboxShortID returns the short ID of the sandbox a tap or
+// bridge interface was created for.
+func NetInterfaceSandboxShortID(name string) (string, bool) {
+	m := netInterfaceNameRegex.FindStringSubmatch(name)
+	if m == nil {
+		return "", false
+	}
+	return m[3], true
+}
+
+// nameNetInterfaces names the host interfaces created for the endpoint after
+// the sandbox, the interfaces the endpoint does not create are left as is.
+func nameNetInterfaces(sandboxID string, idx int, endpoint Endpoint) error {
+	tap, bridge, err := netInterfaceNames(sandboxID, idx)
+	if err != nil {
+		return err
+	}
+
+	rename := func(pair *NetworkInterfacePair) {
+		if pair == nil || pair.TAPIface.Name == "" {
+			return
+		}
+		pair.TAPIface.Name = tap
+		pair.Name = bridge
+	}
+
+	switch ep := endpoint.(type) {
+	case *TapEndpoint:
+		ep.TapInterface.TAPIface.Name = tap
+	case *TuntapEndpoint:
+		ep.TuntapInterface.TAPIface.Name = tap
+		rename(&ep.NetPair)
+	default:
+		rename(endpoint.NetworkPair())
+	}
+
+	return nil
+}
+
+// sandboxContextID returns the vsock context ID the search for an available
+// one starts from. It is derived from the sandbox ID, context IDs 0 to 2
+// are reserved.
+func sandboxContextID(sandboxID string) uint64 {
+	sum := sha256.Sum256([]byte(sandboxID))
+	return 3 + uint64(binary.BigEndian.Uint32(sum[:4]))%(1<<32-3)
+}
+
+// SandboxIDFromPath returns the ID of the sandbox a path of the given
+// storage directories belongs to.
+func SandboxIDFromPath(path string, storagePaths ...string) (string, bool) {
+	for _, storagePath := range storagePaths {
+		prefix := strings.TrimSuffix(storagePath, "/") + "/"
+		if !strings.HasPrefix(path, prefix) {
+			continue
+		}
+		id := strings.SplitN(strings.TrimPrefix(path, prefix), "/", 2)[0]
+		if id != "" {
+			return id, true
+		}
+	}
+	return "", false
+}
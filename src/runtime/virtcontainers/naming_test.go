@@ -0,0 +1,116 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSandboxShortID(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("6fcf0a90", SandboxShortID("6fcf0a90b01e90d8747177aa466c3462d02e02a878bc393649df83d4c314af0c"))
+
+	// not hexadecimal, the ID is hashed
+	short := SandboxShortID("my-sandbox")
+	assert.Len(short, sandboxShortIDLen)
+	assert.Regexp("^[0-9a-f]{8}$", short)
+	assert.NotEqual(short, SandboxShortID("my-sandbox2"))
+}
+
+func TestNetInterfaceNames(t *testing.T) {
+	assert := assert.New(t)
+
+	id := "6fcf0a90b01e90d8747177aa466c3462d02e02a878bc393649df83d4c314af0c"
+
+	tap, bridge, err := netInterfaceNames(id, 0)
+	assert.NoError(err)
+	assert.Equal("tap0_6fcf0a90", tap)
+	assert.Equal("br0_6fcf0a90", bridge)
+
+	short, ok := NetInterfaceSandboxShortID(tap)
+	assert.True(ok)
+	assert.Equal("6fcf0a90", short)
+	short, ok = NetInterfaceSandboxShortID(bridge)
+	assert.True(ok)
+	assert.Equal("6fcf0a90", short)
+
+	_, ok = NetInterfaceSandboxShortID("tap0_kata")
+	assert.False(ok)
+	_, ok = NetInterfaceSandboxShortID("eth0")
+	assert.False(ok)
+
+	// interface names are limited to 15 characters
+	tap, _, err = netInterfaceNames(id, 99)
+	assert.NoError(err)
+	assert.Len(tap, maxNetInterfaceNameLen-1)
+	_, _, err = netInterfaceNames(id, 1000)
+	assert.Error(err)
+}
+
+func TestNameNetInterfaces(t *testing.T) {
+	assert := assert.New(t)
+
+	id := "6fcf0a90b01e90d8747177aa466c3462d02e02a878bc393649df83d4c314af0c"
+
+	veth, err := createVethNetworkEndpoint(1, "eth1", NetXConnectTCFilterModel)
+	assert.NoError(err)
+	assert.NoError(nameNetInterfaces(id, 1, veth))
+	assert.Equal("tap1_6fcf0a90", veth.NetPair.TAPIface.Name)
+	assert.Equal("br1_6fcf0a90", veth.NetPair.Name)
+	// the guest interface is not renamed
+	assert.Equal("eth1", veth.NetPair.VirtIface.Name)
+
+	tap, err := createTapNetworkEndpoint(2, "")
+	assert.NoError(err)
+	assert.NoError(nameNetInterfaces(id, 2, tap))
+	assert.Equal("tap2_6fcf0a90", tap.TapInterface.TAPIface.Name)
+	assert.Equal("eth2", tap.TapInterface.Name)
+
+	// no host interface is created for physical endpoints
+	physical := &PhysicalEndpoint{IfaceName: "eth0"}
+	assert.NoError(nameNetInterfaces(id, 0, physical))
+	assert.Equal("eth0", physical.IfaceName)
+}
+
+func TestSandboxContextID(t *testing.T) {
+	assert := assert.New(t)
+
+	for i := 0; i < 100; i++ {
+		id := fmt.Sprintf("sandbox-%d", i)
+		cid := sandboxContextID(id)
+		assert.GreaterOrEqual(cid, uint64(3))
+		assert.LessOrEqual(cid, uint64(1<<32-1))
+		assert.Equal(cid, sandboxContextID(id))
+	}
+}
+
+func TestSandboxIDFromPath(t *testing.T) {
+	assert := assert.New(t)
+
+	storagePaths := []string{"/run/vc/sbs", "/run/vc/vm/"}
+
+	data := []struct {
+		path string
+		id   string
+		ok   bool
+	}{
+		{"/run/vc/vm/6fcf0a90/qmp.sock", "6fcf0a90", true},
+		{"/run/vc/sbs/6fcf0a90/shim-monitor.sock", "6fcf0a90", true},
+		{"/run/vc/sbs/6fcf0a90", "6fcf0a90", true},
+		{"/run/vc/sbs/", "", false},
+		{"/run/containerd/containerd.sock", "", false},
+	}
+
+	for _, d := range data {
+		id, ok := SandboxIDFromPath(d.path, storagePaths...)
+		assert.Equal(d.ok, ok, "%+v", d)
+		assert.Equal(d.id, id, "%+v", d)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/grpc"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// With nested containers, the containers of the sandbox can run a container
+// engine, e.g. Docker or Podman, inside the guest:
+//   - the guest uses the unified cgroup hierarchy, mounted with nsdelegate,
+//     and each container gets its own cgroup namespace with a writable
+//     cgroup2 mount, so that the engine manages its own subtree;
+//   - the state directories of the engines are backed by a tmpfs of the
+//     guest, on which overlayfs can create its upper directories, unlike
+//     on the shared filesystem or the container rootfs;
+//   - the /dev/fuse and /dev/net/tun device nodes are created and allowed,
+//     for fuse-overlayfs and the networks of the nested containers.
+// The container still needs the privileges of the engine, e.g. it has to be
+// privileged.
+
+const cgroupMountPath = "/sys/fs/cgroup"
+
+// nestedContainersStorageDirs are the state directories of the container
+// engines, backed by a tmpfs of the guest unless the spec mounts a volume
+// there.
+var nestedContainersStorageDirs = []string{"/var/lib/docker", "/var/lib/containers"}
+
+// nestedContainersDevices are the device nodes the container engines need.
+var nestedContainersDevices = []grpc.LinuxDevice{
+	{Path: "/dev/fuse", Type: "c", Major: 10, Minor: 229, FileMode: 0666},
+	{Path: "/dev/net/tun", Type: "c", Major: 10, Minor: 200, FileMode: 0666},
+}
+
+// nestedContainersKernelParams returns the kernel parameters switching the
+// guest to the unified cgroup hierarchy, whatever its init.
+func (config *SandboxConfig) nestedContainersKernelParams() []Param {
+	if !config.NestedContainers {
+		return nil
+	}
+
+	return []Param{
+		{Key: "agent.unified_cgroup_hierarchy", Value: "1"},
+		{Key: "systemd.unified_cgroup_hierarchy", Value: "1"},
+	}
+}
+
+// setNestedContainers lets the container described by the spec run a
+// container engine, and returns the storages backing its state directories.
+func setNestedContainers(grpcSpec *grpc.Spec, containerID string) []*grpc.Storage {
+	if grpcSpec.Linux == nil {
+		return nil
+	}
+
+	// The cgroup namespace of the spec is dropped for the guest, see
+	// constrainGRPCSpec.
+	grpcSpec.Linux.Namespaces = append(grpcSpec.Linux.Namespaces, grpc.LinuxNamespace{
+		Type: string(specs.CgroupNamespace),
+	})
+
+	cgroupMounted := false
+	for i, m := range grpcSpec.Mounts {
+		if m.Destination != cgroupMountPath {
+			continue
+		}
+		cgroupMounted = true
+		for j, o := range m.Options {
+			if o == "ro" {
+				grpcSpec.Mounts[i].Options[j] = "rw"
+			}
+		}
+	}
+	if !cgroupMounted {
+		grpcSpec.Mounts = append(grpcSpec.Mounts, grpc.Mount{
+			Source:      "cgroup",
+			Destination: cgroupMountPath,
+			Type:        "cgroup",
+			Options:     []string{"nosuid", "noexec", "nodev", "relatime", "rw"},
+		})
+	}
+
+	for _, dev := range nestedContainersDevices {
+		found := false
+		for _, d := range grpcSpec.Linux.Devices {
+			if d.Path == dev.Path {
+				found = true
+				break
+			}
+		}
+		if !found {
+			grpcSpec.Linux.Devices = append(grpcSpec.Linux.Devices, dev)
+		}
+
+		if grpcSpec.Linux.Resources == nil {
+			grpcSpec.Linux.Resources = &grpc.LinuxResources{}
+		}
+		grpcSpec.Linux.Resources.Devices = append(grpcSpec.Linux.Resources.Devices, grpc.LinuxDeviceCgroup{
+			Allow:  true,
+			Type:   dev.Type,
+			Major:  dev.Major,
+			Minor:  dev.Minor,
+			Access: "rwm",
+		})
+	}
+
+	var storages []*grpc.Storage
+	for _, dir := range nestedContainersStorageDirs {
+		mounted := false
+		for _, m := range grpcSpec.Mounts {
+			if m.Destination == dir {
+				mounted = true
+				break
+			}
+		}
+		if mounted {
+			continue
+		}
+
+		source := filepath.Join(ephemeralPath(), fmt.Sprintf("nested-%s-%s", containerID, strings.ReplaceAll(strings.Trim(dir, "/"), "/", "-")))
+		storages = append(storages, &grpc.Storage{
+			Driver:     KataEphemeralDevType,
+			Source:     "tmpfs",
+			Fstype:     "tmpfs",
+			MountPoint: source,
+		})
+		grpcSpec.Mounts = append(grpcSpec.Mounts, grpc.Mount{
+			Source:      source,
+			Destination: dir,
+			Type:        "bind",
+			Options:     []string{"rbind", "rw"},
+		})
+	}
+
+	return storages
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/grpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNestedContainersKernelParams(t *testing.T) {
+	assert := assert.New(t)
+
+	config := &SandboxConfig{}
+	assert.Empty(config.nestedContainersKernelParams())
+
+	config.NestedContainers = true
+	assert.Contains(config.nestedContainersKernelParams(), Param{Key: "agent.unified_cgroup_hierarchy", Value: "1"})
+}
+
+func TestSetNestedContainers(t *testing.T) {
+	assert := assert.New(t)
+
+	spec := &grpc.Spec{
+		Mounts: []grpc.Mount{
+			{Source: "cgroup", Destination: cgroupMountPath, Type: "cgroup", Options: []string{"nosuid", "ro"}},
+			{Source: "/run/kata-containers/shared/containers/vol", Destination: "/var/lib/containers", Type: "bind"},
+		},
+		Linux: &grpc.Linux{
+			Devices: []grpc.LinuxDevice{
+				{Path: "/dev/net/tun", Type: "c", Major: 10, Minor: 200},
+			},
+			Resources: &grpc.LinuxResources{},
+		},
+	}
+
+	storages := setNestedContainers(spec, "foo")
+
+	assert.Contains(spec.Linux.Namespaces, grpc.LinuxNamespace{Type: "cgroup"})
+	assert.Equal([]string{"nosuid", "rw"}, spec.Mounts[0].Options)
+	assert.Len(spec.Linux.Devices, 2)
+	assert.Len(spec.Linux.Resources.Devices, 2)
+
+	// The volume of the spec is kept
+	assert.Len(storages, 1)
+	assert.Equal(KataEphemeralDevType, storages[0].Driver)
+	assert.Len(spec.Mounts, 3)
+	assert.Equal("/var/lib/docker", spec.Mounts[2].Destination)
+	assert.Equal(storages[0].MountPoint, spec.Mounts[2].Source)
+	assert.Contains(storages[0].MountPoint, "nested-foo-var-lib-docker")
+
+	// The cgroup filesystem is mounted when the spec does not
+	spec = &grpc.Spec{Linux: &grpc.Linux{}}
+	storages = setNestedContainers(spec, "bar")
+	assert.Len(storages, 2)
+	assert.Equal(cgroupMountPath, spec.Mounts[0].Destination)
+	assert.NotNil(spec.Linux.Resources)
+}
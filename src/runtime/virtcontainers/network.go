@@ -84,6 +84,22 @@ type NetworkInterfacePair struct {
 	TapInterface
 	VirtIface NetworkInterface
 	NetInterworkingModel
+
+	// Queues is the number of queues of the tap, kept across restarts
+	// so that the guest interface keeps its queues.
+	Queues int
+
+	// afXDP is the binding of the AF_XDP sockets of the hypervisor with
+	// the af_xdp internetworking model.
+	afXDP afXDPBinding
+}
+
+// afXDPBinding describes the queues of the network interface the AF_XDP
+// sockets of the hypervisor are bound to.
+type afXDPBinding struct {
+	mode       string
+	queues     uint32
+	startQueue uint32
 }
 
 // NetlinkIface describes fully a network interface.
@@ -123,6 +139,10 @@ const (
 	// NetXConnectNoneModel can be used when the VM is in the host network namespace
 	NetXConnectNoneModel
 
+	// NetXConnectAFXDPModel binds AF_XDP sockets of the hypervisor to the
+	// network interface provided by the network plugin, with no tap.
+	NetXConnectAFXDPModel
+
 	// NetXConnectInvalidModel is the last item to Check valid values by IsValid()
 	NetXConnectInvalidModel
 )
@@ -140,6 +160,8 @@ const (
 	tcFilterNetModelStr = "tcfilter"
 
 	noneNetModelStr = "none"
+
+	afXDPNetModelStr = "af_xdp"
 )
 
 // GetModel returns the string value of a NetInterworkingModel
@@ -153,6 +175,8 @@ func (n *NetInterworkingModel) GetModel() string {
 		return tcFilterNetModelStr
 	case NetXConnectNoneModel:
 		return noneNetModelStr
+	case NetXConnectAFXDPModel:
+		return afXDPNetModelStr
 	}
 	return "unknown"
 }
@@ -172,6 +196,9 @@ func (n *NetInterworkingModel) SetModel(modelName string) error {
 	case noneNetModelStr:
 		*n = NetXConnectNoneModel
 		return nil
+	case afXDPNetModelStr:
+		*n = NetXConnectAFXDPModel
+		return nil
 	}
 	return fmt.Errorf("Unknown type %s", modelName)
 }
@@ -195,6 +222,10 @@ type NetworkConfig struct {
 	InterworkingModel NetInterworkingModel
 	NetworkCreated    bool
 	DisableNewNetwork bool
+
+	// Simulated selects the simulated network, which runs the networking
+	// flows without any host network operation.
+	Simulated bool
 }
 
 type Network interface {
@@ -229,7 +260,7 @@ type Network interface {
 }
 
 func generateVCNetworkStructures(ctx context.Context, network Network) ([]*pbTypes.Interface, []*pbTypes.Route, []*pbTypes.ARPNeighbor, error) {
-	if network.NetworkID() == "" {
+	if network.NetworkID() == "" && len(network.Endpoints()) == 0 {
 		return nil, nil, nil, nil
 	}
 	span, _ := networkTrace(ctx, "generateVCNetworkStructures", nil)
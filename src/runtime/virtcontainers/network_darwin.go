@@ -39,6 +39,10 @@ func NewNetwork(configs ...*NetworkConfig) (Network, error) {
 		return nil, errors.New("missing network configuration")
 	}
 
+	if config.Simulated {
+		return NewSimulatedNetwork(config), nil
+	}
+
 	return &DarwinNetwork{
 		config.NetworkID,
 		config.InterworkingModel,
@@ -48,6 +52,10 @@ func NewNetwork(configs ...*NetworkConfig) (Network, error) {
 }
 
 func LoadNetwork(netInfo persistapi.NetworkInfo) Network {
+	if netInfo.Simulated {
+		return loadSimulatedNetwork(netInfo)
+	}
+
 	network := DarwinNetwork{
 		networkID:      netInfo.NetworkID,
 		networkCreated: netInfo.NetworkCreated,
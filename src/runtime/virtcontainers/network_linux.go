@@ -61,6 +61,10 @@ func NewNetwork(configs ...*NetworkConfig) (Network, error) {
 		return nil, fmt.Errorf("Missing network configuration")
 	}
 
+	if config.Simulated {
+		return NewSimulatedNetwork(config), nil
+	}
+
 	return &LinuxNetwork{
 		config.NetworkID,
 		[]Endpoint{},
@@ -70,6 +74,10 @@ func NewNetwork(configs ...*NetworkConfig) (Network, error) {
 }
 
 func LoadNetwork(netInfo persistapi.NetworkInfo) Network {
+	if netInfo.Simulated {
+		return loadSimulatedNetwork(netInfo)
+	}
+
 	network := LinuxNetwork{
 		netNSPath:    netInfo.NetworkID,
 		netNSCreated: netInfo.NetworkCreated,
@@ -96,7 +104,7 @@ func LoadNetwork(netInfo persistapi.NetworkInfo) Network {
 			networkLogger().WithField("endpoint-type", e.Type).Error("unknown endpoint type")
 			continue
 		}
-		ep.load(e)
+		loadEndpoint(ep, e)
 		network.eps = append(network.eps, ep)
 	}
 
@@ -121,12 +129,12 @@ func (n *LinuxNetwork) addSingleEndpoint(ctx context.Context, s *Sandbox, netInf
 		return nil, err
 	}
 
+	idx := len(n.eps)
 	if isPhysical {
 		networkLogger().WithField("interface", netInfo.Iface.Name).Info("Physical network interface found")
 		endpoint, err = createPhysicalEndpoint(netInfo)
 	} else {
 		var socketPath string
-		idx := len(n.eps)
 
 		// Check if this is a dummy interface which has a vhost-user socket associated with it
 		socketPath, err = vhostUserSocketPath(netInfo)
@@ -176,6 +184,10 @@ func (n *LinuxNetwork) addSingleEndpoint(ctx context.Context, s *Sandbox, netInf
 		return nil, err
 	}
 
+	if err := nameNetInterfaces(s.id, idx, endpoint); err != nil {
+		return nil, err
+	}
+
 	endpoint.SetProperties(netInfo)
 
 	networkLogger().WithField("endpoint-type", endpoint.Type()).WithField("hotplug", hotplug).Info("Attaching endpoint")
@@ -189,28 +201,43 @@ func (n *LinuxNetwork) addSingleEndpoint(ctx context.Context, s *Sandbox, netInf
 		}
 	}
 
-	if !s.hypervisor.IsRateLimiterBuiltin() {
-		rxRateLimiterMaxRate := s.hypervisor.HypervisorConfig().RxRateLimiterMaxRate
-		if rxRateLimiterMaxRate > 0 {
-			networkLogger().Info("Add Rx Rate Limiter")
-			if err := addRxRateLimiter(endpoint, rxRateLimiterMaxRate); err != nil {
-				return nil, err
-			}
-		}
-		txRateLimiterMaxRate := s.hypervisor.HypervisorConfig().TxRateLimiterMaxRate
-		if txRateLimiterMaxRate > 0 {
-			networkLogger().Info("Add Tx Rate Limiter")
-			if err := addTxRateLimiter(endpoint, txRateLimiterMaxRate); err != nil {
-				return nil, err
-			}
+	// The endpoint is attached, track it so that it gets detached if
+	// setting it up fails from now on.
+	n.eps = append(n.eps, endpoint)
+
+	if err := n.addRateLimiters(s, endpoint); err != nil {
+		if rmErr := n.removeSingleEndpoint(ctx, s, idx, hotplug); rmErr != nil {
+			networkLogger().WithError(rmErr).WithField("endpoint-type", endpoint.Type()).Error("failed to detach endpoint")
 		}
+		return nil, err
 	}
 
-	n.eps = append(n.eps, endpoint)
-
 	return endpoint, nil
 }
 
+func (n *LinuxNetwork) addRateLimiters(s *Sandbox, endpoint Endpoint) error {
+	if s.hypervisor.IsRateLimiterBuiltin() {
+		return nil
+	}
+
+	rxRateLimiterMaxRate := s.hypervisor.HypervisorConfig().RxRateLimiterMaxRate
+	if rxRateLimiterMaxRate > 0 {
+		networkLogger().Info("Add Rx Rate Limiter")
+		if err := addRxRateLimiter(endpoint, rxRateLimiterMaxRate); err != nil {
+			return err
+		}
+	}
+	txRateLimiterMaxRate := s.hypervisor.HypervisorConfig().TxRateLimiterMaxRate
+	if txRateLimiterMaxRate > 0 {
+		networkLogger().Info("Add Tx Rate Limiter")
+		if err := addTxRateLimiter(endpoint, txRateLimiterMaxRate); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (n *LinuxNetwork) removeSingleEndpoint(ctx context.Context, s *Sandbox, idx int, hotplug bool) error {
 	if idx > len(n.eps)-1 {
 		return fmt.Errorf("Endpoint index overflow")
@@ -348,23 +375,33 @@ func (n *LinuxNetwork) AddEndpoints(ctx context.Context, s *Sandbox, endpointsIn
 	katatrace.AddTags(span, "type", n.interworkingModel.GetModel())
 	defer span.End()
 
-	if endpointsInfo == nil {
-		if err := n.addAllEndpoints(ctx, s, hotplug); err != nil {
-			return nil, err
+	// Detach the endpoints attached by this call if a later one fails,
+	// leaving the ones attached before untouched.
+	added := len(n.eps)
+	addErr := func() error {
+		if endpointsInfo == nil {
+			return n.addAllEndpoints(ctx, s, hotplug)
 		}
-	} else {
+
 		for _, ep := range endpointsInfo {
 			if err := doNetNS(n.netNSPath, func(_ ns.NetNS) error {
-				if _, err := n.addSingleEndpoint(ctx, s, ep, hotplug); err != nil {
-					n.eps = nil
-					return err
-				}
-
-				return nil
+				_, err := n.addSingleEndpoint(ctx, s, ep, hotplug)
+				return err
 			}); err != nil {
-				return nil, err
+				return err
+			}
+		}
+		return nil
+	}()
+	if addErr != nil {
+		for len(n.eps) > added {
+			idx := len(n.eps) - 1
+			if err := n.removeSingleEndpoint(ctx, s, idx, hotplug); err != nil {
+				networkLogger().WithError(err).WithField("endpoint", n.eps[idx].Name()).Error("failed to detach endpoint")
+				n.eps = n.eps[:idx]
 			}
 		}
+		return nil, addErr
 	}
 
 	katatrace.AddTags(span, "endpoints", n.eps, "hotplug", hotplug)
@@ -384,16 +421,20 @@ func (n *LinuxNetwork) RemoveEndpoints(ctx context.Context, s *Sandbox, endpoint
 		eps = endpoints
 	}
 
-	for idx, ep := range eps {
-		if endpoints != nil {
-			new_ep, _ := findEndpoint(ep, n.eps)
-			if new_ep == nil {
-				continue
-			}
+	// removeSingleEndpoint shrinks n.eps, look the endpoints up in a copy
+	// and try to remove all of them even if some fail, not to leak them.
+	var removeErr error
+	for _, ep := range append([]Endpoint(nil), eps...) {
+		found, idx := findEndpoint(ep, n.eps)
+		if found == nil {
+			continue
 		}
 
 		if err := n.removeSingleEndpoint(ctx, s, idx, hotplug); err != nil {
-			return err
+			networkLogger().WithError(err).WithField("endpoint", ep.Name()).Error("failed to remove endpoint")
+			if removeErr == nil {
+				removeErr = err
+			}
 		}
 	}
 
@@ -401,10 +442,12 @@ func (n *LinuxNetwork) RemoveEndpoints(ctx context.Context, s *Sandbox, endpoint
 
 	if n.netNSCreated && endpoints == nil {
 		networkLogger().Infof("Network namespace %q deleted", n.netNSPath)
-		return deleteNetNS(n.netNSPath)
+		if err := deleteNetNS(n.netNSPath); err != nil && removeErr == nil {
+			removeErr = err
+		}
 	}
 
-	return nil
+	return removeErr
 }
 
 // Network getters
@@ -545,11 +588,15 @@ func xConnectVMNetwork(ctx context.Context, endpoint Endpoint, h Hypervisor) err
 
 	netPair := endpoint.NetworkPair()
 
-	queues := 0
-	caps := h.Capabilities(ctx)
-	if caps.IsMultiQueueSupported() {
-		queues = int(h.HypervisorConfig().NumVCPUs)
+	// The queues of an endpoint loaded from a saved sandbox are kept,
+	// the number of vCPUs may have changed since it was created.
+	if netPair.Queues == 0 {
+		caps := h.Capabilities(ctx)
+		if caps.IsMultiQueueSupported() {
+			netPair.Queues = int(h.HypervisorConfig().NumVCPUs)
+		}
 	}
+	queues := netPair.Queues
 
 	disableVhostNet := h.HypervisorConfig().DisableVhostNet
 
@@ -564,6 +611,9 @@ func xConnectVMNetwork(ctx context.Context, endpoint Endpoint, h Hypervisor) err
 	case NetXConnectTCFilterModel:
 		networkLogger().Info("connect TCFilter to VM network")
 		err = setupTCFiltering(ctx, endpoint, queues, disableVhostNet)
+	case NetXConnectAFXDPModel:
+		networkLogger().Info("connect AF_XDP to VM network")
+		err = setupAFXDP(ctx, endpoint, h.HypervisorConfig())
 	default:
 		err = fmt.Errorf("Invalid internetworking model")
 	}
@@ -588,6 +638,8 @@ func xDisconnectVMNetwork(ctx context.Context, endpoint Endpoint) error {
 		err = untapNetworkPair(ctx, endpoint)
 	case NetXConnectTCFilterModel:
 		err = removeTCFiltering(ctx, endpoint)
+	case NetXConnectAFXDPModel:
+		err = removeAFXDP(ctx, endpoint)
 	default:
 		err = fmt.Errorf("Invalid internetworking model")
 	}
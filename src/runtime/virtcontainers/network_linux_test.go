@@ -7,6 +7,7 @@ package virtcontainers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"reflect"
@@ -14,10 +15,13 @@ import (
 
 	"github.com/containernetworking/plugins/pkg/ns"
 	ktu "github.com/kata-containers/kata-containers/src/runtime/pkg/katatestutils"
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
 	pbTypes "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols"
+	vcTypes "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
 )
 
 func TestGenerateInterfacesAndRoutes(t *testing.T) {
@@ -121,6 +125,76 @@ func TestGenerateInterfacesAndRoutes(t *testing.T) {
 		"ARP Neighbors returned didn't match: got %+v, expecting %+v", resNeighs, expectedNeighs)
 }
 
+func TestLoadNetworkEndpointState(t *testing.T) {
+	assert := assert.New(t)
+
+	hwAddr, err := net.ParseMAC("02:00:ca:fe:00:04")
+	assert.NoError(err)
+	arpMAC, err := net.ParseMAC("6a:92:3a:59:70:aa")
+	assert.NoError(err)
+
+	networkInfo := NetworkInfo{
+		Iface: NetlinkIface{
+			LinkAttrs: netlink.LinkAttrs{Name: "eth0", HardwareAddr: hwAddr, MTU: 1450, Flags: net.FlagUp},
+			Type:      "veth",
+		},
+		Addrs: []netlink.Addr{
+			{IPNet: &net.IPNet{IP: net.IPv4(172, 17, 0, 2), Mask: net.CIDRMask(16, 32)}},
+			{IPNet: &net.IPNet{IP: net.ParseIP("2001:db8:1::242:ac11:2"), Mask: net.CIDRMask(64, 128)}},
+		},
+		Routes: []netlink.Route{
+			{Gw: net.IPv4(172, 17, 0, 1), Family: netlink.FAMILY_V4, Protocol: unix.RTPROT_BOOT},
+			{Dst: &net.IPNet{IP: net.IPv4(10, 0, 0, 0), Mask: net.CIDRMask(8, 32)}, Src: net.IPv4(172, 17, 0, 2), Gw: net.IPv4(172, 17, 0, 1), Scope: netlink.SCOPE_UNIVERSE, Family: netlink.FAMILY_V4},
+			{Dst: &net.IPNet{IP: net.ParseIP("2001:db8:1::"), Mask: net.CIDRMask(64, 128)}, Family: netlink.FAMILY_V6},
+		},
+		Neighbors: []netlink.Neigh{
+			{IP: net.IPv4(172, 17, 0, 101), State: netlink.NUD_PERMANENT, HardwareAddr: arpMAC, Family: netlink.FAMILY_V4},
+		},
+	}
+
+	endpoint, err := createVethNetworkEndpoint(0, "eth0", NetXConnectTCFilterModel)
+	assert.NoError(err)
+	endpoint.SetProperties(networkInfo)
+	endpoint.NetPair.Queues = 4
+	endpoint.PCIPath, err = vcTypes.PciPathFromString("02/03")
+	assert.NoError(err)
+	assert.NoError(endpoint.SetRxRateLimiter())
+
+	network, err := NewNetwork(&NetworkConfig{NetworkID: "foobar", NetworkCreated: true})
+	assert.NoError(err)
+	network.SetEndpoints([]Endpoint{endpoint})
+
+	// the state is saved as JSON by the persist driver
+	data, err := json.Marshal(persistapi.NetworkInfo{
+		NetworkID:      network.NetworkID(),
+		NetworkCreated: network.NetworkCreated(),
+		Endpoints:      []persistapi.NetworkEndpoint{saveEndpoint(endpoint)},
+	})
+	assert.NoError(err)
+	var saved persistapi.NetworkInfo
+	assert.NoError(json.Unmarshal(data, &saved))
+
+	loaded := LoadNetwork(saved)
+	assert.Len(loaded.Endpoints(), 1)
+
+	loadedEndpoint := loaded.Endpoints()[0]
+	assert.Equal(endpoint.NetworkPair(), loadedEndpoint.NetworkPair())
+	assert.Equal(endpoint.PciPath(), loadedEndpoint.PciPath())
+	assert.True(loadedEndpoint.GetRxRateLimiter())
+	assert.False(loadedEndpoint.GetTxRateLimiter())
+
+	interfaces, routes, neighs, err := generateVCNetworkStructures(context.Background(), network)
+	assert.NoError(err)
+	loadedInterfaces, loadedRoutes, loadedNeighs, err := generateVCNetworkStructures(context.Background(), loaded)
+	assert.NoError(err)
+
+	assert.Len(routes, 3)
+	assert.Len(neighs, 1)
+	assert.Equal(interfaces, loadedInterfaces)
+	assert.Equal(routes, loadedRoutes)
+	assert.Equal(neighs, loadedNeighs)
+}
+
 func TestCreateGetTunTapLink(t *testing.T) {
 	if tc.NotValid(ktu.NeedRoot()) {
 		t.Skip(testDisabledAsNonRoot)
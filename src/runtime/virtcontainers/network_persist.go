@@ -0,0 +1,161 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"net"
+
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	vcTypes "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/vishvananda/netlink"
+)
+
+// The endpoints are saved along with the interface, addresses, routes and
+// neighbors they were created for, so that a sandbox restarted in place
+// gives its guest the same networking without scanning the network
+// namespace again.
+
+func saveNetworkInfo(info NetworkInfo) *persistapi.NetworkProperties {
+	props := &persistapi.NetworkProperties{
+		Iface: persistapi.NetworkIfaceAttrs{
+			Name:     info.Iface.Name,
+			Type:     info.Iface.Type,
+			MTU:      info.Iface.MTU,
+			TxQLen:   info.Iface.TxQLen,
+			Flags:    uint(info.Iface.Flags),
+			RawFlags: info.Iface.RawFlags,
+		},
+		Addrs: info.Addrs,
+	}
+	if info.Iface.HardwareAddr != nil {
+		props.Iface.HardAddr = info.Iface.HardwareAddr.String()
+	}
+
+	for _, r := range info.Routes {
+		route := persistapi.NetworkRoute{
+			Scope:    int(r.Scope),
+			Family:   r.Family,
+			Protocol: int(r.Protocol),
+			Priority: r.Priority,
+			Table:    r.Table,
+			Type:     r.Type,
+			Flags:    r.Flags,
+		}
+		if r.Dst != nil {
+			route.Dst = r.Dst.String()
+		}
+		if r.Src != nil {
+			route.Src = r.Src.String()
+		}
+		if r.Gw != nil {
+			route.Gw = r.Gw.String()
+		}
+		props.Routes = append(props.Routes, route)
+	}
+
+	for _, n := range info.Neighbors {
+		neigh := persistapi.NetworkNeighbor{
+			IP:     n.IP.String(),
+			State:  n.State,
+			Flags:  n.Flags,
+			Family: n.Family,
+		}
+		if n.HardwareAddr != nil {
+			neigh.HardAddr = n.HardwareAddr.String()
+		}
+		props.Neighbors = append(props.Neighbors, neigh)
+	}
+
+	return props
+}
+
+func loadNetworkInfo(props *persistapi.NetworkProperties) NetworkInfo {
+	if props == nil {
+		return NetworkInfo{}
+	}
+
+	info := NetworkInfo{
+		Iface: NetlinkIface{
+			LinkAttrs: netlink.LinkAttrs{
+				Name:     props.Iface.Name,
+				MTU:      props.Iface.MTU,
+				TxQLen:   props.Iface.TxQLen,
+				Flags:    net.Flags(props.Iface.Flags),
+				RawFlags: props.Iface.RawFlags,
+			},
+			Type: props.Iface.Type,
+		},
+		Addrs: props.Addrs,
+	}
+	if hwAddr, err := net.ParseMAC(props.Iface.HardAddr); err == nil {
+		info.Iface.HardwareAddr = hwAddr
+	}
+
+	for _, r := range props.Routes {
+		route := netlink.Route{
+			Scope:    netlink.Scope(r.Scope),
+			Family:   r.Family,
+			Protocol: netlink.RouteProtocol(r.Protocol),
+			Priority: r.Priority,
+			Table:    r.Table,
+			Type:     r.Type,
+			Flags:    r.Flags,
+			Src:      net.ParseIP(r.Src),
+			Gw:       net.ParseIP(r.Gw),
+		}
+		if r.Dst != "" {
+			if _, dst, err := net.ParseCIDR(r.Dst); err == nil {
+				route.Dst = dst
+			}
+		}
+		info.Routes = append(info.Routes, route)
+	}
+
+	for _, n := range props.Neighbors {
+		neigh := netlink.Neigh{
+			IP:     net.ParseIP(n.IP),
+			State:  n.State,
+			Flags:  n.Flags,
+			Family: n.Family,
+		}
+		if hwAddr, err := net.ParseMAC(n.HardAddr); err == nil {
+			neigh.HardwareAddr = hwAddr
+		}
+		info.Neighbors = append(info.Neighbors, neigh)
+	}
+
+	return info
+}
+
+// saveEndpoint returns the persisted state of an endpoint, including the
+// state common to all the endpoint types.
+func saveEndpoint(e Endpoint) persistapi.NetworkEndpoint {
+	saved := e.save()
+	saved.Properties = saveNetworkInfo(e.Properties())
+	saved.PCIPath = e.PciPath().String()
+	saved.RxRateLimiter = e.GetRxRateLimiter()
+	saved.TxRateLimiter = e.GetTxRateLimiter()
+	return saved
+}
+
+// loadEndpoint loads the persisted state of an endpoint.
+func loadEndpoint(e Endpoint, saved persistapi.NetworkEndpoint) {
+	e.load(saved)
+	if saved.Properties != nil {
+		e.SetProperties(loadNetworkInfo(saved.Properties))
+	}
+	if pciPath, err := vcTypes.PciPathFromString(saved.PCIPath); err == nil {
+		e.SetPciPath(pciPath)
+	} else {
+		networkLogger().WithError(err).WithField("endpoint", e.Name()).Warn("invalid saved PCI path")
+	}
+	if saved.RxRateLimiter {
+		_ = e.SetRxRateLimiter()
+	}
+	if saved.TxRateLimiter {
+		_ = e.SetTxRateLimiter()
+	}
+}
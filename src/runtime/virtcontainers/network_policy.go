@@ -0,0 +1,214 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"bytes"
+	"context"
+	"debug/elf"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+)
+
+// The network policy of a sandbox is enforced inside the guest by an eBPF
+// filter the agent attaches to the tc hooks of its network interfaces, so
+// that the policy of the pod still applies when its traffic bypasses the
+// dataplane of the host, e.g. with the virtual functions of SR-IOV NICs.
+// The eBPF object is provided by the host, from NetworkPolicyObject, and
+// verified by the shim before it is sent to the agent, which loads it and
+// swaps a new map holding the policy in the maps of the filter. The policy
+// itself is only accepted from the host, never from the pod.
+
+const (
+	// NetworkPolicyIngress is the direction of the rules allowing the
+	// traffic to the pod.
+	NetworkPolicyIngress = "ingress"
+
+	// NetworkPolicyEgress is the direction of the rules allowing the
+	// traffic from the pod.
+	NetworkPolicyEgress = "egress"
+
+	// maxNetworkPolicyRules is the size of the rules map of the filter.
+	maxNetworkPolicyRules = 64
+
+	// maxNetworkPolicyObjectSize bounds the size of the eBPF object.
+	maxNetworkPolicyObjectSize = 1024 * 1024
+)
+
+// networkPolicySections are the sections of the programs of the filter, by
+// direction.
+var networkPolicySections = map[string]string{
+	NetworkPolicyIngress: "tc/ingress",
+	NetworkPolicyEgress:  "tc/egress",
+}
+
+// networkPolicyMaps are the maps of the filter the agent updates: the map of
+// maps holding the policy, its inner map, and the allowed connections.
+var networkPolicyMaps = []string{"kata_np_policy", "kata_np_policy_inner", "kata_np_flows"}
+
+// NetworkPolicyRule allows the traffic of a direction from or to a network.
+type NetworkPolicyRule struct {
+	// Direction is NetworkPolicyIngress or NetworkPolicyEgress
+	Direction string `json:"direction"`
+	// CIDR is the network of the peers
+	CIDR string `json:"cidr"`
+	// Protocol is "tcp", "udp" or "sctp", any protocol when empty
+	Protocol string `json:"protocol,omitempty"`
+	// Port is the first port of the range of the pod (ingress) or of the
+	// peers (egress), any port when 0
+	Port uint16 `json:"port,omitempty"`
+	// EndPort is the last port of the range, Port only when 0
+	EndPort uint16 `json:"endPort,omitempty"`
+}
+
+// NetworkPolicy is the network policy of a sandbox, as resolved by the
+// network plugin from the NetworkPolicy objects selecting the pod.
+type NetworkPolicy struct {
+	// Ingress drops the traffic to the pod the rules do not allow
+	Ingress bool `json:"ingress,omitempty"`
+	// Egress drops the traffic from the pod the rules do not allow
+	Egress bool `json:"egress,omitempty"`
+	// Rules are the allowed traffic
+	Rules []NetworkPolicyRule `json:"rules,omitempty"`
+}
+
+func (r *NetworkPolicyRule) validate() error {
+	if _, ok := networkPolicySections[r.Direction]; !ok {
+		return fmt.Errorf("invalid direction %q", r.Direction)
+	}
+	if _, _, err := net.ParseCIDR(r.CIDR); err != nil {
+		return err
+	}
+
+	switch r.Protocol {
+	case "":
+		if r.Port != 0 || r.EndPort != 0 {
+			return fmt.Errorf("ports of %s without protocol", r.CIDR)
+		}
+	case "tcp", "udp", "sctp":
+	default:
+		return fmt.Errorf("invalid protocol %q", r.Protocol)
+	}
+
+	if r.EndPort != 0 && (r.Port == 0 || r.EndPort < r.Port) {
+		return fmt.Errorf("invalid port range %d-%d", r.Port, r.EndPort)
+	}
+	return nil
+}
+
+func (p *NetworkPolicy) validate() error {
+	if len(p.Rules) > maxNetworkPolicyRules {
+		return fmt.Errorf("%d rules, at most %d are supported", len(p.Rules), maxNetworkPolicyRules)
+	}
+	for i := range p.Rules {
+		if err := p.Rules[i].validate(); err != nil {
+			return fmt.Errorf("invalid rule %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// ParseNetworkPolicy parses and validates a network policy in JSON.
+func ParseNetworkPolicy(value string) (*NetworkPolicy, error) {
+	var policy NetworkPolicy
+
+	dec := json.NewDecoder(bytes.NewReader([]byte(value)))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&policy); err != nil {
+		return nil, err
+	}
+	if err := policy.validate(); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// verifyNetworkPolicyObject checks data is an eBPF object with the programs
+// and maps of the filter the agent expects.
+func verifyNetworkPolicyObject(data []byte) error {
+	if len(data) > maxNetworkPolicyObjectSize {
+		return fmt.Errorf("object of %d bytes, at most %d are supported", len(data), maxNetworkPolicyObjectSize)
+	}
+
+	f, err := elf.NewFile(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if f.Machine != elf.EM_BPF {
+		return fmt.Errorf("not an eBPF object: machine %v", f.Machine)
+	}
+	for _, name := range networkPolicySections {
+		if s := f.Section(name); s == nil || s.Type != elf.SHT_PROGBITS {
+			return fmt.Errorf("no %s program", name)
+		}
+	}
+
+	symbols, err := f.Symbols()
+	if err != nil {
+		return err
+	}
+	for _, name := range networkPolicyMaps {
+		found := false
+		for _, sym := range symbols {
+			if sym.Name == name && elf.ST_TYPE(sym.Info) == elf.STT_OBJECT {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("no %s map", name)
+		}
+	}
+	return nil
+}
+
+// SetNetworkPolicy enforces policy inside the guest, a nil policy removing
+// the filter.
+func (s *Sandbox) SetNetworkPolicy(ctx context.Context, policy *NetworkPolicy) error {
+	var program []byte
+
+	if policy != nil {
+		if err := policy.validate(); err != nil {
+			return err
+		}
+		if s.config.NetworkPolicyObject == "" {
+			return fmt.Errorf("no network policy eBPF object is configured")
+		}
+
+		data, err := os.ReadFile(s.config.NetworkPolicyObject)
+		if err != nil {
+			return err
+		}
+		if err := verifyNetworkPolicyObject(data); err != nil {
+			return fmt.Errorf("invalid network policy eBPF object %s: %v", s.config.NetworkPolicyObject, err)
+		}
+		program = data
+	}
+
+	err := s.agent.setNetworkPolicy(ctx, program, policy)
+	if err == errUnimplemented {
+		return fmt.Errorf("the agent cannot enforce network policies")
+	} else if err != nil {
+		return err
+	}
+
+	s.config.NetworkPolicy = policy
+	s.Logger().WithField("policy", policy).Info("network policy set in the guest")
+	return nil
+}
+
+// enforceNetworkPolicy enforces the network policy of the sandbox config
+// inside the guest, if any.
+func (s *Sandbox) enforceNetworkPolicy(ctx context.Context) error {
+	if s.config.NetworkPolicy == nil {
+		return nil
+	}
+	return s.SetNetworkPolicy(ctx, s.config.NetworkPolicy)
+}
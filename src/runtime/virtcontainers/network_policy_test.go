@@ -0,0 +1,179 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"bytes"
+	"context"
+	"debug/elf"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testNetworkPolicyObject builds a relocatable ELF object of machine with
+// the sections and the object symbols given.
+func testNetworkPolicyObject(t *testing.T, machine elf.Machine, sections, symbols []string) []byte {
+	const (
+		ehdrSize = 64
+		shdrSize = 64
+	)
+
+	// The string tables start with the empty string.
+	strtab := bytes.NewBuffer([]byte{0})
+	shstrtab := bytes.NewBuffer([]byte{0})
+	addString := func(b *bytes.Buffer, s string) uint32 {
+		off := uint32(b.Len())
+		b.WriteString(s)
+		b.WriteByte(0)
+		return off
+	}
+
+	// Sections: null, programs, .symtab, .strtab, .shstrtab
+	headers := []elf.Section64{{}}
+	var body bytes.Buffer
+	for _, name := range sections {
+		headers = append(headers, elf.Section64{
+			Name:      addString(shstrtab, name),
+			Type:      uint32(elf.SHT_PROGBITS),
+			Flags:     uint64(elf.SHF_ALLOC | elf.SHF_EXECINSTR),
+			Off:       uint64(ehdrSize + body.Len()),
+			Size:      8,
+			Addralign: 8,
+		})
+		body.Write(make([]byte, 8))
+	}
+
+	syms := []elf.Sym64{{}}
+	for _, name := range symbols {
+		syms = append(syms, elf.Sym64{
+			Name:  addString(strtab, name),
+			Info:  elf.ST_INFO(elf.STB_GLOBAL, elf.STT_OBJECT),
+			Shndx: 1,
+			Size:  8,
+		})
+	}
+
+	symtabIndex := len(headers)
+	symtabOff := ehdrSize + body.Len()
+	assert.NoError(t, binary.Write(&body, binary.LittleEndian, syms))
+	headers = append(headers, elf.Section64{
+		Name:      addString(shstrtab, ".symtab"),
+		Type:      uint32(elf.SHT_SYMTAB),
+		Off:       uint64(symtabOff),
+		Size:      uint64(len(syms) * elf.Sym64Size),
+		Link:      uint32(symtabIndex + 1),
+		Info:      1,
+		Addralign: 8,
+		Entsize:   elf.Sym64Size,
+	})
+	headers = append(headers, elf.Section64{
+		Name:      addString(shstrtab, ".strtab"),
+		Type:      uint32(elf.SHT_STRTAB),
+		Off:       uint64(ehdrSize + body.Len()),
+		Size:      uint64(strtab.Len()),
+		Addralign: 1,
+	})
+	body.Write(strtab.Bytes())
+
+	shstrtabHeader := elf.Section64{
+		Name:      addString(shstrtab, ".shstrtab"),
+		Type:      uint32(elf.SHT_STRTAB),
+		Off:       uint64(ehdrSize + body.Len()),
+		Addralign: 1,
+	}
+	shstrtabHeader.Size = uint64(shstrtab.Len())
+	headers = append(headers, shstrtabHeader)
+	body.Write(shstrtab.Bytes())
+
+	header := elf.Header64{
+		Type:      uint16(elf.ET_REL),
+		Machine:   uint16(machine),
+		Version:   uint32(elf.EV_CURRENT),
+		Shoff:     uint64(ehdrSize + body.Len()),
+		Ehsize:    ehdrSize,
+		Shentsize: shdrSize,
+		Shnum:     uint16(len(headers)),
+		Shstrndx:  uint16(len(headers) - 1),
+	}
+	copy(header.Ident[:], elf.ELFMAG)
+	header.Ident[elf.EI_CLASS] = byte(elf.ELFCLASS64)
+	header.Ident[elf.EI_DATA] = byte(elf.ELFDATA2LSB)
+	header.Ident[elf.EI_VERSION] = byte(elf.EV_CURRENT)
+
+	var obj bytes.Buffer
+	assert.NoError(t, binary.Write(&obj, binary.LittleEndian, header))
+	obj.Write(body.Bytes())
+	assert.NoError(t, binary.Write(&obj, binary.LittleEndian, headers))
+	return obj.Bytes()
+}
+
+func TestParseNetworkPolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	policy, err := ParseNetworkPolicy(`{"egress": true, "rules": [{"direction": "egress", "cidr": "fd00::/64", "protocol": "udp", "port": 5000, "endPort": 5010}]}`)
+	assert.NoError(err)
+	assert.False(policy.Ingress)
+	assert.True(policy.Egress)
+	assert.Len(policy.Rules, 1)
+
+	for _, value := range []string{
+		`{"ingres": true}`,
+		`{"rules": [{"direction": "both", "cidr": "10.0.0.0/8"}]}`,
+		`{"rules": [{"direction": "ingress", "cidr": "10.0.0.1"}]}`,
+		`{"rules": [{"direction": "ingress", "cidr": "10.0.0.0/8", "protocol": "icmp"}]}`,
+		`{"rules": [{"direction": "ingress", "cidr": "10.0.0.0/8", "port": 80}]}`,
+		`{"rules": [{"direction": "ingress", "cidr": "10.0.0.0/8", "protocol": "tcp", "port": 80, "endPort": 79}]}`,
+		`{"rules": [{"direction": "ingress", "cidr": "10.0.0.0/8", "protocol": "tcp", "endPort": 80}]}`,
+	} {
+		_, err := ParseNetworkPolicy(value)
+		assert.Error(err, value)
+	}
+}
+
+func TestVerifyNetworkPolicyObject(t *testing.T) {
+	assert := assert.New(t)
+
+	sections := []string{"tc/ingress", "tc/egress"}
+	obj := testNetworkPolicyObject(t, elf.EM_BPF, sections, networkPolicyMaps)
+	assert.NoError(verifyNetworkPolicyObject(obj))
+
+	assert.Error(verifyNetworkPolicyObject([]byte("not an object")))
+	assert.Error(verifyNetworkPolicyObject(testNetworkPolicyObject(t, elf.EM_X86_64, sections, networkPolicyMaps)))
+	assert.Error(verifyNetworkPolicyObject(testNetworkPolicyObject(t, elf.EM_BPF, sections[:1], networkPolicyMaps)))
+	assert.Error(verifyNetworkPolicyObject(testNetworkPolicyObject(t, elf.EM_BPF, sections, networkPolicyMaps[:1])))
+}
+
+func TestSandboxSetNetworkPolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{
+		ctx:    context.Background(),
+		agent:  &mockAgent{},
+		config: &SandboxConfig{},
+	}
+
+	// Nothing to enforce
+	assert.NoError(s.enforceNetworkPolicy(s.ctx))
+
+	policy := &NetworkPolicy{Ingress: true}
+	assert.Error(s.SetNetworkPolicy(s.ctx, policy))
+
+	s.config.NetworkPolicyObject = filepath.Join(t.TempDir(), "network-policy.o")
+	assert.NoError(os.WriteFile(s.config.NetworkPolicyObject, []byte("not an object"), 0644))
+	assert.Error(s.SetNetworkPolicy(s.ctx, policy))
+
+	obj := testNetworkPolicyObject(t, elf.EM_BPF, []string{"tc/ingress", "tc/egress"}, networkPolicyMaps)
+	assert.NoError(os.WriteFile(s.config.NetworkPolicyObject, obj, 0644))
+	assert.NoError(s.SetNetworkPolicy(s.ctx, policy))
+	assert.Equal(policy, s.config.NetworkPolicy)
+
+	assert.NoError(s.SetNetworkPolicy(s.ctx, nil))
+	assert.Nil(s.config.NetworkPolicy)
+}
@@ -0,0 +1,205 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils/katatrace"
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"github.com/vishvananda/netlink"
+)
+
+// The simulated network runs the sandbox networking flows with no host
+// network operation: there is no network namespace to enter, and its
+// endpoints have no host interface, tap or netlink configuration. It needs
+// no privilege, for the CI and development environments without
+// CAP_NET_ADMIN, and is mostly of use with the mock hypervisor and agent.
+
+const (
+	simulatedIfaceName = "eth0"
+	simulatedIfaceMTU  = 1500
+)
+
+var (
+	// address and gateway of the default simulated interface, from the
+	// TEST-NET-1 documentation range
+	simulatedIfaceAddr = &net.IPNet{IP: net.IPv4(192, 0, 2, 2), Mask: net.CIDRMask(24, 32)}
+	simulatedGateway   = net.IPv4(192, 0, 2, 1)
+)
+
+// SimulatedNetwork represents a simulated sandbox networking setup.
+type SimulatedNetwork struct {
+	eps               []Endpoint
+	interworkingModel NetInterworkingModel
+}
+
+// NewSimulatedNetwork creates a new simulated network from a NetworkConfig.
+func NewSimulatedNetwork(config *NetworkConfig) Network {
+	return &SimulatedNetwork{
+		eps:               []Endpoint{},
+		interworkingModel: config.InterworkingModel,
+	}
+}
+
+func loadSimulatedNetwork(netInfo persistapi.NetworkInfo) Network {
+	var network SimulatedNetwork
+
+	for _, e := range netInfo.Endpoints {
+		if EndpointType(e.Type) != SimulatedEndpointType {
+			networkLogger().WithField("endpoint-type", e.Type).Error("unexpected simulated endpoint type")
+			continue
+		}
+		ep := &SimulatedEndpoint{}
+		loadEndpoint(ep, e)
+		network.eps = append(network.eps, ep)
+	}
+
+	return &network
+}
+
+// defaultSimulatedNetworkInfo returns the interface found when the simulated
+// network is scanned.
+func defaultSimulatedNetworkInfo() (NetworkInfo, error) {
+	mac, err := generateRandomPrivateMacAddr()
+	if err != nil {
+		return NetworkInfo{}, err
+	}
+	hwAddr, err := net.ParseMAC(mac)
+	if err != nil {
+		return NetworkInfo{}, err
+	}
+
+	return NetworkInfo{
+		Iface: NetlinkIface{
+			LinkAttrs: netlink.LinkAttrs{
+				Name:         simulatedIfaceName,
+				HardwareAddr: hwAddr,
+				MTU:          simulatedIfaceMTU,
+				Flags:        net.FlagUp,
+			},
+			Type: string(SimulatedEndpointType),
+		},
+		Addrs: []netlink.Addr{{IPNet: simulatedIfaceAddr}},
+		Routes: []netlink.Route{
+			{Gw: simulatedGateway, Family: netlink.FAMILY_V4},
+		},
+	}, nil
+}
+
+func (n *SimulatedNetwork) addSingleEndpoint(ctx context.Context, s *Sandbox, netInfo NetworkInfo, hotplug bool) error {
+	for _, ep := range n.eps {
+		if ep.Name() == netInfo.Iface.Name {
+			return fmt.Errorf("simulated endpoint %s already added", netInfo.Iface.Name)
+		}
+	}
+
+	endpoint := createSimulatedEndpoint(netInfo)
+
+	networkLogger().WithField("endpoint-type", endpoint.Type()).WithField("hotplug", hotplug).Info("Attaching endpoint")
+	var err error
+	if hotplug {
+		err = endpoint.HotAttach(ctx, s.hypervisor)
+	} else {
+		err = endpoint.Attach(ctx, s)
+	}
+	if err != nil {
+		return err
+	}
+
+	n.eps = append(n.eps, endpoint)
+
+	return nil
+}
+
+// AddEndpoints adds simulated endpoints for the given interfaces, or for a
+// single default interface when none is given.
+func (n *SimulatedNetwork) AddEndpoints(ctx context.Context, s *Sandbox, endpointsInfo []NetworkInfo, hotplug bool) ([]Endpoint, error) {
+	span, ctx := networkTrace(ctx, "AddEndpoints", nil)
+	katatrace.AddTags(span, "type", n.interworkingModel.GetModel(), "simulated", true)
+	defer span.End()
+
+	if endpointsInfo == nil {
+		netInfo, err := defaultSimulatedNetworkInfo()
+		if err != nil {
+			return nil, err
+		}
+		endpointsInfo = []NetworkInfo{netInfo}
+	}
+
+	for _, netInfo := range endpointsInfo {
+		if err := n.addSingleEndpoint(ctx, s, netInfo, hotplug); err != nil {
+			n.eps = nil
+			return nil, err
+		}
+	}
+
+	katatrace.AddTags(span, "endpoints", n.eps, "hotplug", hotplug)
+	networkLogger().Debug("Simulated endpoints added")
+
+	return n.eps, nil
+}
+
+// RemoveEndpoints removes the simulated endpoints, or all of them when
+// endpoints is nil.
+func (n *SimulatedNetwork) RemoveEndpoints(ctx context.Context, s *Sandbox, endpoints []Endpoint, hotplug bool) error {
+	span, ctx := networkTrace(ctx, "RemoveEndpoints", nil)
+	defer span.End()
+
+	if endpoints == nil {
+		endpoints = append([]Endpoint{}, n.eps...)
+	}
+
+	for _, ep := range endpoints {
+		endpoint, idx := findEndpoint(ep, n.eps)
+		if endpoint == nil {
+			continue
+		}
+
+		var err error
+		if hotplug && s != nil {
+			err = endpoint.HotDetach(ctx, s.hypervisor, false, "")
+		} else {
+			err = endpoint.Detach(ctx, false, "")
+		}
+		if err != nil {
+			return err
+		}
+
+		n.eps = append(n.eps[:idx], n.eps[idx+1:]...)
+	}
+
+	networkLogger().Debug("Simulated endpoints removed")
+
+	return nil
+}
+
+// Run runs a callback in the current network namespace.
+func (n *SimulatedNetwork) Run(ctx context.Context, cb func() error) error {
+	return cb()
+}
+
+// NetworkID returns an empty network namespace path, so that the shim and
+// hypervisor run in the current network namespace.
+func (n *SimulatedNetwork) NetworkID() string {
+	return ""
+}
+
+// NetworkCreated returns false, the simulated network creates no network
+// namespace.
+func (n *SimulatedNetwork) NetworkCreated() bool {
+	return false
+}
+
+func (n *SimulatedNetwork) Endpoints() []Endpoint {
+	return n.eps
+}
+
+func (n *SimulatedNetwork) SetEndpoints(endpoints []Endpoint) {
+	n.eps = endpoints
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/vishvananda/netlink"
+)
+
+func TestSimulatedNetworkEndpoints(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	n, err := NewNetwork(&NetworkConfig{NetworkID: "/var/run/netns/test", Simulated: true})
+	assert.NoError(err)
+	assert.IsType(&SimulatedNetwork{}, n)
+	assert.Empty(n.NetworkID())
+	assert.False(n.NetworkCreated())
+
+	s := &Sandbox{hypervisor: &mockHypervisor{}}
+
+	// the scan finds the default interface
+	eps, err := n.AddEndpoints(ctx, s, nil, false)
+	assert.NoError(err)
+	assert.Len(eps, 1)
+	assert.Equal(SimulatedEndpointType, eps[0].Type())
+	assert.Equal(simulatedIfaceName, eps[0].Name())
+	assert.NotEmpty(eps[0].HardwareAddr())
+
+	ifaces, routes, _, err := generateVCNetworkStructures(ctx, n)
+	assert.NoError(err)
+	assert.Len(ifaces, 1)
+	assert.Equal("192.0.2.2", ifaces[0].IPAddresses[0].Address)
+	assert.Equal("24", ifaces[0].IPAddresses[0].Mask)
+	assert.Len(routes, 1)
+	assert.Equal("192.0.2.1", routes[0].Gateway)
+
+	// hotplugged interface
+	hwAddr, _ := net.ParseMAC("02:00:ca:fe:00:01")
+	netInfo := NetworkInfo{Iface: NetlinkIface{LinkAttrs: netlink.LinkAttrs{Name: "net1", HardwareAddr: hwAddr}}}
+	eps, err = n.AddEndpoints(ctx, s, []NetworkInfo{netInfo}, true)
+	assert.NoError(err)
+	assert.Len(eps, 2)
+
+	_, err = n.AddEndpoints(ctx, s, []NetworkInfo{netInfo}, true)
+	assert.Error(err)
+	n.SetEndpoints(eps)
+
+	assert.NoError(n.RemoveEndpoints(ctx, s, []Endpoint{eps[1]}, true))
+	assert.Len(n.Endpoints(), 1)
+	assert.Equal(simulatedIfaceName, n.Endpoints()[0].Name())
+
+	assert.NoError(n.RemoveEndpoints(ctx, s, nil, false))
+	assert.Empty(n.Endpoints())
+}
+
+func TestSimulatedNetworkRun(t *testing.T) {
+	n := NewSimulatedNetwork(&NetworkConfig{Simulated: true})
+
+	called := false
+	assert.NoError(t, n.Run(context.Background(), func() error {
+		called = true
+		return nil
+	}))
+	assert.True(t, called)
+}
+
+func TestSimulatedNetworkLoad(t *testing.T) {
+	assert := assert.New(t)
+
+	n := NewSimulatedNetwork(&NetworkConfig{Simulated: true})
+	_, err := n.AddEndpoints(context.Background(), &Sandbox{hypervisor: &mockHypervisor{}}, nil, false)
+	assert.NoError(err)
+
+	netInfo := persistapi.NetworkInfo{Simulated: true}
+	for _, ep := range n.Endpoints() {
+		netInfo.Endpoints = append(netInfo.Endpoints, ep.save())
+	}
+
+	loaded := LoadNetwork(netInfo)
+	assert.IsType(&SimulatedNetwork{}, loaded)
+	assert.Len(loaded.Endpoints(), 1)
+	assert.Equal(n.Endpoints()[0].Name(), loaded.Endpoints()[0].Name())
+	assert.Equal(n.Endpoints()[0].HardwareAddr(), loaded.Endpoints()[0].HardwareAddr())
+}
@@ -22,6 +22,7 @@ func TestNetInterworkingModelIsValid(t *testing.T) {
 		{"Default Model", NetXConnectDefaultModel, true},
 		{"TC Filter Model", NetXConnectTCFilterModel, true},
 		{"Macvtap Model", NetXConnectMacVtapModel, true},
+		{"AF_XDP Model", NetXConnectAFXDPModel, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -44,6 +45,7 @@ func TestNetInterworkingModelSetModel(t *testing.T) {
 		{"macvtap Model", macvtapNetModelStr, false},
 		{"tcfilter Model", tcFilterNetModelStr, false},
 		{"none Model", noneNetModelStr, false},
+		{"af_xdp Model", afXDPNetModelStr, false},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,511 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+	"golang.org/x/sys/unix"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/drivers"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist"
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+)
+
+// OrphanKind is the kind of a host resource left behind by a sandbox.
+type OrphanKind string
+
+const (
+	OrphanVFIODevice   OrphanKind = "vfio-device"
+	OrphanLoopDevice   OrphanKind = "loop-device"
+	OrphanNetInterface OrphanKind = "net-interface"
+	OrphanNetNS        OrphanKind = "netns"
+	OrphanSharedDir    OrphanKind = "shared-dir"
+	OrphanVMDir        OrphanKind = "vm-dir"
+	OrphanSandboxDir   OrphanKind = "sandbox-dir"
+)
+
+const (
+	vfioPCIDriver      = "vfio-pci"
+	procSelfMountInfo  = "/proc/self/mountinfo"
+	sysBlockPath       = "/sys/block"
+	loopBackingFileFmt = "%s/loop/backing_file"
+)
+
+// OrphanResource is a host resource of a sandbox that is not running
+// anymore, typically because its shim crashed before cleaning up.
+type OrphanResource struct {
+	remove func() error
+
+	Kind OrphanKind
+	// SandboxID is the ID of the sandbox the resource belongs to.
+	SandboxID string
+	// Name is the path, the interface or the device of the resource.
+	Name string
+}
+
+func (r OrphanResource) String() string {
+	return fmt.Sprintf("%s %s (sandbox %s)", r.Kind, r.Name, r.SandboxID)
+}
+
+// Remove releases the resource.
+func (r OrphanResource) Remove() error {
+	return r.remove()
+}
+
+// OrphanGCConfig configures the search for orphan resources.
+type OrphanGCConfig struct {
+	// IsSandboxLive tells whether the shim of the sandbox is running.
+	IsSandboxLive func(sandboxID string) bool
+
+	// GracePeriod protects the sandboxes being created or deleted: the
+	// sandboxes whose directories were modified during the grace period
+	// are not collected.
+	GracePeriod time.Duration
+}
+
+// orphanScanner looks for the resources of the sandboxes that are not live.
+// The host paths and the operations are overridden in tests.
+type orphanScanner struct {
+	config OrphanGCConfig
+
+	now       func() time.Time
+	loadState func(sandboxID string) (persistapi.SandboxState, error)
+
+	listNetInterfaces  func(netNSPath string) ([]string, error)
+	deleteNetInterface func(netNSPath, name string) error
+	deleteNetNS        func(netNSPath string) error
+	detachLoopDevice   func(device string) error
+	unmount            func(path string) error
+	bindDeviceToHost   func(bdf, driver, vendorDeviceID string) error
+	restoreSRIOVVF     func(vf *SRIOVVF) error
+
+	runStoragePath    string
+	vmStoragePath     string
+	sharedPath        string
+	mountInfoPath     string
+	sysBlockPath      string
+	sysPCIDevicesPath string
+}
+
+// FindOrphanResources returns the host resources of the sandboxes whose
+// shim is not running anymore, in the order they must be removed.
+func FindOrphanResources(config OrphanGCConfig) ([]OrphanResource, error) {
+	if config.IsSandboxLive == nil {
+		return nil, fmt.Errorf("missing sandbox liveness check")
+	}
+
+	driver, err := persist.GetDriver()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &orphanScanner{
+		config: config,
+		now:    time.Now,
+		loadState: func(sandboxID string) (persistapi.SandboxState, error) {
+			ss, _, err := driver.FromDisk(sandboxID)
+			return ss, err
+		},
+		listNetInterfaces:  listNetInterfaces,
+		deleteNetInterface: deleteNetInterface,
+		deleteNetNS:        deleteNetNS,
+		detachLoopDevice:   detachLoopDevice,
+		unmount:            unmountNoFollow,
+		bindDeviceToHost:   drivers.BindDevicetoHost,
+		restoreSRIOVVF:     restoreSRIOVVF,
+		runStoragePath:     driver.RunStoragePath(),
+		vmStoragePath:      driver.RunVMStoragePath(),
+		sharedPath:         kataHostSharedDir(),
+		mountInfoPath:      procSelfMountInfo,
+		sysBlockPath:       sysBlockPath,
+		sysPCIDevicesPath:  sysPCIDevicesPath,
+	}
+
+	return s.scan()
+}
+
+func (s *orphanScanner) storagePaths() []string {
+	return []string{s.runStoragePath, s.vmStoragePath, s.sharedPath}
+}
+
+// sandboxes returns the sandboxes having a directory in one of the storage
+// paths, along with the last time one of their directories was modified.
+func (s *orphanScanner) sandboxes() (map[string]time.Time, error) {
+	sandboxes := make(map[string]time.Time)
+
+	for _, dir := range s.storagePaths() {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		for _, e := range entries {
+			if !e.IsDir() {
+				continue
+			}
+			info, err := e.Info()
+			if err != nil {
+				continue
+			}
+			if info.ModTime().After(sandboxes[e.Name()]) {
+				sandboxes[e.Name()] = info.ModTime()
+			}
+		}
+	}
+
+	return sandboxes, nil
+}
+
+func (s *orphanScanner) scan() ([]OrphanResource, error) {
+	sandboxes, err := s.sandboxes()
+	if err != nil {
+		return nil, err
+	}
+
+	var orphans []string
+	// the devices of the running sandboxes are never released
+	usedDevices := make(map[string]bool)
+	for id, modTime := range sandboxes {
+		if s.now().Sub(modTime) < s.config.GracePeriod || s.config.IsSandboxLive(id) {
+			if ss, err := s.loadState(id); err == nil {
+				for _, bdf := range physicalEndpointBDFs(ss) {
+					usedDevices[bdf] = true
+				}
+			}
+			continue
+		}
+		orphans = append(orphans, id)
+	}
+	sort.Strings(orphans)
+
+	if len(orphans) == 0 {
+		return nil, nil
+	}
+
+	loopDevices, err := s.loopDevices()
+	if err != nil {
+		return nil, err
+	}
+
+	mounts, err := s.mountPoints()
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []OrphanResource
+	for _, id := range orphans {
+		// the persisted state is missing when the shim crashed early
+		ss, err := s.loadState(id)
+		if err != nil {
+			virtLog.WithError(err).WithField("sandbox", id).Debug("no state for orphan sandbox")
+		}
+
+		resources = append(resources, s.vfioDevices(id, ss, usedDevices)...)
+
+		for _, device := range loopDevices[id] {
+			device := device
+			resources = append(resources, OrphanResource{
+				Kind:      OrphanLoopDevice,
+				SandboxID: id,
+				Name:      device,
+				remove:    func() error { return s.detachLoopDevice(device) },
+			})
+		}
+
+		resources = append(resources, s.netResources(id, ss)...)
+
+		if dir := filepath.Join(s.sharedPath, id); isDir(dir) {
+			resources = append(resources, OrphanResource{
+				Kind:      OrphanSharedDir,
+				SandboxID: id,
+				Name:      dir,
+				remove:    func() error { return s.removeSharedDir(dir, mounts) },
+			})
+		}
+
+		for _, d := range []struct {
+			kind OrphanKind
+			dir  string
+		}{
+			{OrphanVMDir, filepath.Join(s.vmStoragePath, id)},
+			// last, it holds the state of the sandbox
+			{OrphanSandboxDir, filepath.Join(s.runStoragePath, id)},
+		} {
+			if !isDir(d.dir) {
+				continue
+			}
+			dir := d.dir
+			resources = append(resources, OrphanResource{
+				Kind:      d.kind,
+				SandboxID: id,
+				Name:      dir,
+				remove:    func() error { return os.RemoveAll(dir) },
+			})
+		}
+	}
+
+	return resources, nil
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+func physicalEndpointBDFs(ss persistapi.SandboxState) []string {
+	var bdfs []string
+	for _, ep := range ss.Network.Endpoints {
+		if ep.Physical != nil && ep.Physical.BDF != "" {
+			bdfs = append(bdfs, ep.Physical.BDF)
+		}
+	}
+	return bdfs
+}
+
+// vfioDevices returns the physical network devices of the sandbox that are
+// still bound to vfio-pci, they are bound back to their host driver and the
+// VFs programmed on their PF are restored.
+func (s *orphanScanner) vfioDevices(id string, ss persistapi.SandboxState, usedDevices map[string]bool) []OrphanResource {
+	var resources []OrphanResource
+
+	for _, ep := range ss.Network.Endpoints {
+		physical := ep.Physical
+		if physical == nil || physical.BDF == "" || physical.Driver == "" || usedDevices[physical.BDF] {
+			continue
+		}
+
+		driver, err := os.Readlink(filepath.Join(s.sysPCIDevicesPath, physical.BDF, "driver"))
+		if err != nil || filepath.Base(driver) != vfioPCIDriver {
+			continue
+		}
+
+		resources = append(resources, OrphanResource{
+			Kind:      OrphanVFIODevice,
+			SandboxID: id,
+			Name:      physical.BDF,
+			remove: func() error {
+				if err := s.bindDeviceToHost(physical.BDF, physical.Driver, physical.VendorDeviceID); err != nil {
+					return err
+				}
+				if physical.VF == nil {
+					return nil
+				}
+				return s.restoreSRIOVVF(loadSRIOVVF(physical.VF))
+			},
+		})
+	}
+
+	return resources
+}
+
+// loopDevices returns the loop devices backed by a file of a sandbox
+// directory, by sandbox.
+func (s *orphanScanner) loopDevices() (map[string][]string, error) {
+	entries, err := os.ReadDir(s.sysBlockPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	devices := make(map[string][]string)
+	for _, e := range entries {
+		if !strings.HasPrefix(e.Name(), "loop") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(s.sysBlockPath, fmt.Sprintf(loopBackingFileFmt, e.Name())))
+		if err != nil {
+			// not attached
+			continue
+		}
+
+		backingFile := strings.TrimSuffix(strings.TrimSpace(string(data)), " (deleted)")
+		if id, ok := SandboxIDFromPath(backingFile, s.storagePaths()...); ok {
+			devices[id] = append(devices[id], filepath.Join("/dev", e.Name()))
+		}
+	}
+
+	return devices, nil
+}
+
+// netResources returns the tap and bridge interfaces of the sandbox, in the
+// host and in the sandbox network namespaces, and the network namespace
+// itself when it was created by the runtime.
+func (s *orphanScanner) netResources(id string, ss persistapi.SandboxState) []OrphanResource {
+	netNSPaths := []string{""}
+	netNS := ss.Network.NetworkID
+	if netNS != "" {
+		if _, err := os.Stat(netNS); err != nil {
+			netNS = ""
+		} else if !ss.Network.NetworkCreated {
+			netNSPaths = append(netNSPaths, netNS)
+		}
+	}
+
+	var resources []OrphanResource
+	shortID := SandboxShortID(id)
+	for _, netNSPath := range netNSPaths {
+		names, err := s.listNetInterfaces(netNSPath)
+		if err != nil {
+			virtLog.WithError(err).WithField("netns", netNSPath).Warn("failed to list network interfaces")
+			continue
+		}
+
+		for _, name := range names {
+			if nameShortID, ok := NetInterfaceSandboxShortID(name); !ok || nameShortID != shortID {
+				continue
+			}
+			netNSPath, name := netNSPath, name
+			resources = append(resources, OrphanResource{
+				Kind:      OrphanNetInterface,
+				SandboxID: id,
+				Name:      name,
+				remove:    func() error { return s.deleteNetInterface(netNSPath, name) },
+			})
+		}
+	}
+
+	// the interfaces of a namespace created by the runtime go along with it
+	if netNS != "" && ss.Network.NetworkCreated {
+		resources = append(resources, OrphanResource{
+			Kind:      OrphanNetNS,
+			SandboxID: id,
+			Name:      netNS,
+			remove:    func() error { return s.deleteNetNS(netNS) },
+		})
+	}
+
+	return resources
+}
+
+// mountPoints returns the mount points of the host, from mountinfo.
+func (s *orphanScanner) mountPoints() ([]string, error) {
+	f, err := os.Open(s.mountInfoPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	unescape := strings.NewReplacer(`\040`, " ", `\011`, "\t", `\012`, "\n", `\134`, `\`)
+
+	var mounts []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 {
+			continue
+		}
+		mounts = append(mounts, unescape.Replace(fields[4]))
+	}
+
+	return mounts, scanner.Err()
+}
+
+// removeSharedDir unmounts the shared directory mounts, the deepest ones
+// first, then removes the directory. It is only removed when nothing is
+// mounted anymore, not to remove the content of the host directories
+// shared with the sandbox.
+func (s *orphanScanner) removeSharedDir(dir string, mounts []string) error {
+	var dirMounts []string
+	for _, m := range mounts {
+		if m == dir || strings.HasPrefix(m, dir+"/") {
+			dirMounts = append(dirMounts, m)
+		}
+	}
+	sort.Slice(dirMounts, func(i, j int) bool {
+		return len(dirMounts[i]) > len(dirMounts[j])
+	})
+
+	for _, m := range dirMounts {
+		if err := s.unmount(m); err != nil && err != syscall.EINVAL && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to unmount %s: %w", m, err)
+		}
+	}
+
+	mounts, err := s.mountPoints()
+	if err != nil {
+		return err
+	}
+	for _, m := range mounts {
+		if m == dir || strings.HasPrefix(m, dir+"/") {
+			return fmt.Errorf("%s is still mounted, not removing %s", m, dir)
+		}
+	}
+
+	return os.RemoveAll(dir)
+}
+
+func netlinkHandleAt(netNSPath string) (*netlink.Handle, error) {
+	if netNSPath == "" {
+		return netlink.NewHandle()
+	}
+
+	netnsHandle, err := netns.GetFromPath(netNSPath)
+	if err != nil {
+		return nil, err
+	}
+	defer netnsHandle.Close()
+
+	return netlink.NewHandleAt(netnsHandle)
+}
+
+func listNetInterfaces(netNSPath string) ([]string, error) {
+	handle, err := netlinkHandleAt(netNSPath)
+	if err != nil {
+		return nil, err
+	}
+	defer handle.Close()
+
+	links, err := handle.LinkList()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(links))
+	for _, link := range links {
+		names = append(names, link.Attrs().Name)
+	}
+	return names, nil
+}
+
+func deleteNetInterface(netNSPath, name string) error {
+	handle, err := netlinkHandleAt(netNSPath)
+	if err != nil {
+		return err
+	}
+	defer handle.Close()
+
+	link, err := handle.LinkByName(name)
+	if err != nil {
+		return err
+	}
+	return handle.LinkDel(link)
+}
+
+func detachLoopDevice(device string) error {
+	f, err := os.OpenFile(device, os.O_RDONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return unix.IoctlSetInt(int(f.Fd()), unix.LOOP_CLR_FD, 0)
+}
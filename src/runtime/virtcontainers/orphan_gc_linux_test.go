@@ -0,0 +1,229 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"github.com/stretchr/testify/assert"
+)
+
+const mountInfoLineFmt = "100 1 0:50 / %s rw,relatime shared:1 - tmpfs tmpfs rw\n"
+
+func newTestOrphanScanner(t *testing.T, live ...string) (*orphanScanner, map[string]persistapi.SandboxState) {
+	root := t.TempDir()
+	states := make(map[string]persistapi.SandboxState)
+
+	s := &orphanScanner{
+		config: OrphanGCConfig{
+			IsSandboxLive: func(id string) bool {
+				for _, l := range live {
+					if l == id {
+						return true
+					}
+				}
+				return false
+			},
+			GracePeriod: time.Minute,
+		},
+		now: func() time.Time { return time.Now().Add(time.Hour) },
+		loadState: func(id string) (persistapi.SandboxState, error) {
+			ss, ok := states[id]
+			if !ok {
+				return ss, os.ErrNotExist
+			}
+			return ss, nil
+		},
+		listNetInterfaces: func(string) ([]string, error) { return nil, nil },
+		runStoragePath:    filepath.Join(root, "sbs"),
+		vmStoragePath:     filepath.Join(root, "vm"),
+		sharedPath:        filepath.Join(root, "shared"),
+		mountInfoPath:     filepath.Join(root, "mountinfo"),
+		sysBlockPath:      filepath.Join(root, "block"),
+		sysPCIDevicesPath: filepath.Join(root, "pci"),
+	}
+
+	for _, dir := range []string{s.sysBlockPath, s.sysPCIDevicesPath} {
+		assert.NoError(t, os.MkdirAll(dir, 0700))
+	}
+	assert.NoError(t, os.WriteFile(s.mountInfoPath, nil, 0600))
+
+	return s, states
+}
+
+func createTestSandboxDirs(t *testing.T, s *orphanScanner, id string) {
+	for _, dir := range s.storagePaths() {
+		assert.NoError(t, os.MkdirAll(filepath.Join(dir, id), 0700))
+	}
+}
+
+func orphanNames(resources []OrphanResource) []string {
+	var names []string
+	for _, r := range resources {
+		names = append(names, fmt.Sprintf("%s %s", r.Kind, r.Name))
+	}
+	return names
+}
+
+func TestOrphanScannerSkipsLiveAndRecentSandboxes(t *testing.T) {
+	assert := assert.New(t)
+
+	s, _ := newTestOrphanScanner(t, "live")
+	createTestSandboxDirs(t, s, "live")
+
+	resources, err := s.scan()
+	assert.NoError(err)
+	assert.Empty(resources)
+
+	createTestSandboxDirs(t, s, "recent")
+	s.now = time.Now
+
+	resources, err = s.scan()
+	assert.NoError(err)
+	assert.Empty(resources)
+}
+
+func TestOrphanScannerFindsResources(t *testing.T) {
+	assert := assert.New(t)
+
+	s, states := newTestOrphanScanner(t, "live")
+	createTestSandboxDirs(t, s, "live")
+	createTestSandboxDirs(t, s, "dead")
+
+	netNS := filepath.Join(t.TempDir(), "netns")
+	assert.NoError(os.WriteFile(netNS, nil, 0600))
+
+	states["dead"] = persistapi.SandboxState{
+		Network: persistapi.NetworkInfo{
+			NetworkID: netNS,
+			Endpoints: []persistapi.NetworkEndpoint{
+				{Physical: &persistapi.PhysicalEndpoint{BDF: "0000:00:01.0", Driver: "ixgbevf", VendorDeviceID: "8086 10ed"}},
+				// bound to its host driver already
+				{Physical: &persistapi.PhysicalEndpoint{BDF: "0000:00:02.0", Driver: "ixgbevf", VendorDeviceID: "8086 10ed"}},
+				// used by the live sandbox
+				{Physical: &persistapi.PhysicalEndpoint{BDF: "0000:00:03.0", Driver: "ixgbevf", VendorDeviceID: "8086 10ed"}},
+			},
+		},
+	}
+	states["live"] = persistapi.SandboxState{
+		Network: persistapi.NetworkInfo{
+			Endpoints: []persistapi.NetworkEndpoint{
+				{Physical: &persistapi.PhysicalEndpoint{BDF: "0000:00:03.0", Driver: "ixgbevf", VendorDeviceID: "8086 10ed"}},
+			},
+		},
+	}
+	for bdf, driver := range map[string]string{"0000:00:01.0": "vfio-pci", "0000:00:02.0": "ixgbevf", "0000:00:03.0": "vfio-pci"} {
+		dir := filepath.Join(s.sysPCIDevicesPath, bdf)
+		assert.NoError(os.MkdirAll(dir, 0700))
+		assert.NoError(os.Symlink(filepath.Join("../../drivers", driver), filepath.Join(dir, "driver")))
+	}
+
+	for loop, backingFile := range map[string]string{
+		"loop0": filepath.Join(s.vmStoragePath, "dead", "rootfs.img") + " (deleted)",
+		"loop1": filepath.Join(s.vmStoragePath, "live", "rootfs.img"),
+		"loop2": "/var/lib/images/rootfs.img",
+	} {
+		dir := filepath.Join(s.sysBlockPath, loop, "loop")
+		assert.NoError(os.MkdirAll(dir, 0700))
+		assert.NoError(os.WriteFile(filepath.Join(dir, "backing_file"), []byte(backingFile+"\n"), 0600))
+	}
+
+	deadShortID := SandboxShortID("dead")
+	s.listNetInterfaces = func(netNSPath string) ([]string, error) {
+		if netNSPath == "" {
+			return []string{"eth0", "tap1_" + deadShortID, "tap0_" + SandboxShortID("live")}, nil
+		}
+		return []string{"eth0", "tap0_" + deadShortID, "br0_" + deadShortID}, nil
+	}
+
+	resources, err := s.scan()
+	assert.NoError(err)
+	assert.Equal([]string{
+		"vfio-device 0000:00:01.0",
+		"loop-device /dev/loop0",
+		"net-interface tap1_" + deadShortID,
+		"net-interface tap0_" + deadShortID,
+		"net-interface br0_" + deadShortID,
+		"shared-dir " + filepath.Join(s.sharedPath, "dead"),
+		"vm-dir " + filepath.Join(s.vmStoragePath, "dead"),
+		"sandbox-dir " + filepath.Join(s.runStoragePath, "dead"),
+	}, orphanNames(resources))
+	for _, r := range resources {
+		assert.Equal("dead", r.SandboxID)
+	}
+
+	// the runtime created network namespace is removed with its interfaces
+	states["dead"] = persistapi.SandboxState{
+		Network: persistapi.NetworkInfo{NetworkID: netNS, NetworkCreated: true},
+	}
+	resources, err = s.scan()
+	assert.NoError(err)
+	assert.Contains(orphanNames(resources), "netns "+netNS)
+	assert.NotContains(orphanNames(resources), "net-interface tap0_"+deadShortID)
+}
+
+func TestOrphanResourceRemove(t *testing.T) {
+	assert := assert.New(t)
+
+	s, states := newTestOrphanScanner(t)
+	createTestSandboxDirs(t, s, "dead")
+	states["dead"] = persistapi.SandboxState{
+		Network: persistapi.NetworkInfo{
+			Endpoints: []persistapi.NetworkEndpoint{
+				{Physical: &persistapi.PhysicalEndpoint{
+					BDF:            "0000:00:01.0",
+					Driver:         "ixgbevf",
+					VendorDeviceID: "8086 10ed",
+					VF:             &persistapi.SRIOVVF{PF: "ens1f0", Index: 3},
+				}},
+			},
+		},
+	}
+	dir := filepath.Join(s.sysPCIDevicesPath, "0000:00:01.0")
+	assert.NoError(os.MkdirAll(dir, 0700))
+	assert.NoError(os.Symlink("../../drivers/vfio-pci", filepath.Join(dir, "driver")))
+
+	mount := filepath.Join(s.sharedPath, "dead", "shared")
+	assert.NoError(os.WriteFile(s.mountInfoPath, []byte(fmt.Sprintf(mountInfoLineFmt, mount)), 0600))
+
+	var calls []string
+	s.bindDeviceToHost = func(bdf, driver, vendorDeviceID string) error {
+		calls = append(calls, fmt.Sprintf("bind %s %s %s", bdf, driver, vendorDeviceID))
+		return nil
+	}
+	s.restoreSRIOVVF = func(vf *SRIOVVF) error {
+		calls = append(calls, fmt.Sprintf("restore %s %d", vf.PF, vf.Index))
+		return nil
+	}
+	s.unmount = func(path string) error {
+		calls = append(calls, "unmount "+path)
+		return nil
+	}
+
+	resources, err := s.scan()
+	assert.NoError(err)
+	assert.Len(resources, 4)
+
+	// the shared directory is kept while something is still mounted
+	assert.NoError(resources[0].Remove())
+	assert.Error(resources[1].Remove())
+	assert.DirExists(filepath.Join(s.sharedPath, "dead"))
+
+	assert.NoError(os.WriteFile(s.mountInfoPath, nil, 0600))
+	for _, r := range resources[1:] {
+		assert.NoError(r.Remove())
+	}
+
+	assert.Equal([]string{"bind 0000:00:01.0 ixgbevf 8086 10ed", "restore ens1f0 3", "unmount " + mount, "unmount " + mount}, calls)
+	for _, dir := range s.storagePaths() {
+		assert.NoDirExists(filepath.Join(dir, "dead"))
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"sync"
+	"syscall"
+
+	vcAnnotations "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+)
+
+// With Pauseless, the sandbox container, e.g. the pause container of a pod,
+// is not created inside the guest. The first container the agent creates,
+// the workload of a one container pod, is the init task of the sandbox and
+// owns the PID namespace the later containers share. The runtime keeps the
+// sandbox container on the host only, without the agent requests for it,
+// and has it exit like the pause process would.
+
+// pauselessSandbox is the sandbox container of a pause-less sandbox.
+type pauselessSandbox struct {
+	exited chan struct{}
+	status int32
+	once   sync.Once
+}
+
+func newPauselessSandbox() *pauselessSandbox {
+	return &pauselessSandbox{
+		exited: make(chan struct{}),
+	}
+}
+
+// exit has the sandbox container exit with status.
+func (p *pauselessSandbox) exit(status int32) {
+	p.once.Do(func() {
+		p.status = status
+		close(p.exited)
+	})
+}
+
+// wait waits for the sandbox container to exit and returns its status.
+func (p *pauselessSandbox) wait() int32 {
+	<-p.exited
+	return p.status
+}
+
+// signal delivers signal to the sandbox container, which exits on the
+// termination signals and ignores the others, like the pause process.
+func (p *pauselessSandbox) signal(signal syscall.Signal) {
+	switch signal {
+	case syscall.SIGKILL:
+		p.exit(128 + int32(signal))
+	case syscall.SIGTERM, syscall.SIGINT:
+		p.exit(0)
+	}
+}
+
+// pauseless returns whether the container is the sandbox container of a
+// pause-less sandbox, which does not run inside the guest.
+func (c *Container) pauseless() bool {
+	return c.sandbox.pauseless != nil && c.config != nil && c.config.Annotations[vcAnnotations.ContainerTypeKey] == string(PodSandbox)
+}
+
+// guestInitCreated returns whether another container than c runs inside the
+// guest of a pause-less sandbox, and so is the init task of the sandbox.
+func (s *Sandbox) guestInitCreated(c *Container) bool {
+	for _, other := range s.containers {
+		if other.id == c.id || other.pauseless() {
+			continue
+		}
+		switch other.state.State {
+		case types.StateReady, types.StateRunning, types.StatePaused:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"syscall"
+	"testing"
+
+	vcAnnotations "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPauselessSandboxSignal(t *testing.T) {
+	assert := assert.New(t)
+
+	p := newPauselessSandbox()
+	p.signal(syscall.SIGHUP)
+	select {
+	case <-p.exited:
+		t.Fatal("the sandbox container exited on SIGHUP")
+	default:
+	}
+
+	p.signal(syscall.SIGTERM)
+	assert.Equal(int32(0), p.wait())
+
+	// The first exit status is kept
+	p.signal(syscall.SIGKILL)
+	assert.Equal(int32(0), p.wait())
+
+	p = newPauselessSandbox()
+	p.signal(syscall.SIGKILL)
+	assert.Equal(int32(137), p.wait())
+}
+
+func TestPauselessContainer(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{
+		config:     &SandboxConfig{},
+		agent:      &mockAgent{},
+		containers: map[string]*Container{},
+		state:      types.SandboxState{State: types.StateRunning},
+	}
+	sandbox := &Container{
+		id:      "sandbox",
+		sandbox: s,
+		config: &ContainerConfig{
+			Annotations: map[string]string{vcAnnotations.ContainerTypeKey: string(PodSandbox)},
+		},
+		state: types.ContainerState{State: types.StateRunning},
+	}
+	workload := &Container{
+		id:      "workload",
+		sandbox: s,
+		config: &ContainerConfig{
+			Annotations: map[string]string{vcAnnotations.ContainerTypeKey: string(PodContainer)},
+		},
+	}
+	s.containers[sandbox.id] = sandbox
+	s.containers[workload.id] = workload
+
+	// Without Pauseless, the sandbox container runs inside the guest
+	assert.False(sandbox.pauseless())
+
+	s.pauseless = newPauselessSandbox()
+	assert.True(sandbox.pauseless())
+	assert.False(workload.pauseless())
+
+	// The workload is the init task of the sandbox once created
+	assert.False(s.guestInitCreated(workload))
+	workload.state.State = types.StateRunning
+	assert.True(s.guestInitCreated(&Container{id: "other", sandbox: s}))
+	assert.False(s.guestInitCreated(workload))
+
+	_, err := sandbox.enter(context.Background(), types.Cmd{})
+	assert.Error(err)
+
+	stats, err := sandbox.stats(context.Background())
+	assert.NoError(err)
+	assert.Nil(stats.CgroupStats)
+
+	assert.NoError(sandbox.signalProcess(context.Background(), sandbox.id, syscall.SIGTERM, false))
+	status, err := sandbox.wait(context.Background(), sandbox.id)
+	assert.NoError(err)
+	assert.Equal(int32(0), status)
+}
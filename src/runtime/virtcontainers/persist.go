@@ -141,6 +141,7 @@ func (s *Sandbox) dumpMounts(cs map[string]persistapi.ContainerState) {
 				Destination:   m.Destination,
 				Options:       m.Options,
 				HostPath:      m.HostPath,
+				GuestPath:     m.GuestPath,
 				ReadOnly:      m.ReadOnly,
 				BlockDeviceID: m.BlockDeviceID,
 			})
@@ -164,12 +165,14 @@ func (s *Sandbox) dumpAgent(ss *persistapi.SandboxState) {
 }
 
 func (s *Sandbox) dumpNetwork(ss *persistapi.SandboxState) {
+	_, simulated := s.network.(*SimulatedNetwork)
 	ss.Network = persistapi.NetworkInfo{
 		NetworkID:      s.network.NetworkID(),
 		NetworkCreated: s.network.NetworkCreated(),
+		Simulated:      simulated,
 	}
 	for _, e := range s.network.Endpoints() {
-		ss.Network.Endpoints = append(ss.Network.Endpoints, e.save())
+		ss.Network.Endpoints = append(ss.Network.Endpoints, saveEndpoint(e))
 	}
 }
 
@@ -182,15 +185,50 @@ func (s *Sandbox) dumpConfig(ss *persistapi.SandboxState) {
 			NetworkCreated:    sconfig.NetworkConfig.NetworkCreated,
 			DisableNewNetwork: sconfig.NetworkConfig.DisableNewNetwork,
 			InterworkingModel: int(sconfig.NetworkConfig.InterworkingModel),
+			Simulated:         sconfig.NetworkConfig.Simulated,
 		},
 
-		ShmSize:             sconfig.ShmSize,
-		SharePidNs:          sconfig.SharePidNs,
-		SystemdCgroup:       sconfig.SystemdCgroup,
-		SandboxCgroupOnly:   sconfig.SandboxCgroupOnly,
-		DisableGuestSeccomp: sconfig.DisableGuestSeccomp,
-		EnableVCPUsPinning:  sconfig.EnableVCPUsPinning,
-		GuestSeLinuxLabel:   sconfig.GuestSeLinuxLabel,
+		ShmSize:                   sconfig.ShmSize,
+		SharePidNs:                sconfig.SharePidNs,
+		SystemdCgroup:             sconfig.SystemdCgroup,
+		SandboxCgroupOnly:         sconfig.SandboxCgroupOnly,
+		DisableGuestSeccomp:       sconfig.DisableGuestSeccomp,
+		GuestSeccompReport:        sconfig.GuestSeccompReport,
+		GuestPidsLimit:            sconfig.GuestPidsLimit,
+		MultipathEvents:           sconfig.MultipathEvents,
+		StopFlushTimeout:          sconfig.StopFlushTimeout,
+		ConfirmExecTimeout:        sconfig.ConfirmExecTimeout,
+		ForensicSnapshotThreshold: sconfig.ForensicSnapshotThreshold,
+		ForensicSnapshotCount:     sconfig.ForensicSnapshotCount,
+		GuestNameResolution:       sconfig.GuestNameResolution,
+		EntitlementsPath:          sconfig.EntitlementsPath,
+		ImageVolumePaths:          sconfig.ImageVolumePaths,
+		WasmRuntime:               sconfig.WasmRuntime,
+		NestedContainers:          sconfig.NestedContainers,
+		ShmChannel:                sconfig.ShmChannel,
+		Pauseless:                 sconfig.Pauseless,
+		Profile:                   sconfig.Profile,
+		GuestSeccompMode:          sconfig.GuestSeccompMode,
+		EnableVCPUsPinning:        sconfig.EnableVCPUsPinning,
+		VMMSchedClass:             sconfig.VMMSchedClass,
+		VMMSchedRTPriority:        sconfig.VMMSchedRTPriority,
+		GuestSeLinuxLabel:         sconfig.GuestSeLinuxLabel,
+		CoreDump: persistapi.CoreDumpConfig{
+			HostDir:     sconfig.CoreDump.HostDir,
+			MaxCoreSize: sconfig.CoreDump.MaxCoreSize,
+			MaxDirSize:  sconfig.CoreDump.MaxDirSize,
+			Enabled:     sconfig.CoreDump.Enabled,
+		},
+		TmpfsSizing: persistapi.TmpfsSizing{
+			ShmPercent:          sconfig.TmpfsSizing.ShmPercent,
+			MemoryVolumePercent: sconfig.TmpfsSizing.MemoryVolumePercent,
+			TmpfsPercent:        sconfig.TmpfsSizing.TmpfsPercent,
+		},
+		VMMLimits: persistapi.VMMLimits{
+			MaxOpenFiles: sconfig.VMMLimits.MaxOpenFiles,
+			MaxThreads:   sconfig.VMMLimits.MaxThreads,
+			AlertPercent: sconfig.VMMLimits.AlertPercent,
+		},
 	}
 
 	ss.Config.SandboxBindMounts = append(ss.Config.SandboxBindMounts, sconfig.SandboxBindMounts...)
@@ -215,6 +253,7 @@ func (s *Sandbox) dumpConfig(ss *persistapi.SandboxState) {
 		FirmwarePath:            sconfig.HypervisorConfig.FirmwarePath,
 		MachineAccelerators:     sconfig.HypervisorConfig.MachineAccelerators,
 		CPUFeatures:             sconfig.HypervisorConfig.CPUFeatures,
+		CPUMitigations:          sconfig.HypervisorConfig.CPUMitigations,
 		HypervisorPath:          sconfig.HypervisorConfig.HypervisorPath,
 		HypervisorPathList:      sconfig.HypervisorConfig.HypervisorPathList,
 		HypervisorCtlPath:       sconfig.HypervisorConfig.HypervisorCtlPath,
@@ -242,17 +281,26 @@ func (s *Sandbox) dumpConfig(ss *persistapi.SandboxState) {
 		HugePages:               sconfig.HypervisorConfig.HugePages,
 		FileBackedMemRootDir:    sconfig.HypervisorConfig.FileBackedMemRootDir,
 		FileBackedMemRootList:   sconfig.HypervisorConfig.FileBackedMemRootList,
+		HostSharedMemory:        sconfig.HypervisorConfig.HostSharedMemory,
+		HostSharedMemoryList:    sconfig.HypervisorConfig.HostSharedMemoryList,
 		DisableNestingChecks:    sconfig.HypervisorConfig.DisableNestingChecks,
 		DisableImageNvdimm:      sconfig.HypervisorConfig.DisableImageNvdimm,
+		SharedBootAssets:        sconfig.HypervisorConfig.SharedBootAssets,
+		PmemVolumesSize:         sconfig.HypervisorConfig.PmemVolumesSize,
 		HotplugVFIOOnRootBus:    sconfig.HypervisorConfig.HotplugVFIOOnRootBus,
+		PCIeP2P:                 sconfig.HypervisorConfig.PCIeP2P,
 		BootToBeTemplate:        sconfig.HypervisorConfig.BootToBeTemplate,
 		BootFromTemplate:        sconfig.HypervisorConfig.BootFromTemplate,
 		DisableVhostNet:         sconfig.HypervisorConfig.DisableVhostNet,
 		EnableVhostUserStore:    sconfig.HypervisorConfig.EnableVhostUserStore,
+		VirtioFSSubmounts:       sconfig.HypervisorConfig.VirtioFSSubmounts,
 		SeccompSandbox:          sconfig.HypervisorConfig.SeccompSandbox,
 		VhostUserStorePath:      sconfig.HypervisorConfig.VhostUserStorePath,
 		VhostUserStorePathList:  sconfig.HypervisorConfig.VhostUserStorePathList,
 		GuestHookPath:           sconfig.HypervisorConfig.GuestHookPath,
+		CCAMeasurementAlgorithm: sconfig.HypervisorConfig.CCAMeasurementAlgorithm,
+		CCAPersonalizationValue: sconfig.HypervisorConfig.CCAPersonalizationValue,
+		CCAMeasurementLog:       sconfig.HypervisorConfig.CCAMeasurementLog,
 		VMid:                    sconfig.HypervisorConfig.VMid,
 		RxRateLimiterMaxRate:    sconfig.HypervisorConfig.RxRateLimiterMaxRate,
 		TxRateLimiterMaxRate:    sconfig.HypervisorConfig.TxRateLimiterMaxRate,
@@ -351,6 +399,7 @@ func (c *Container) loadContMounts(cs persistapi.ContainerState) {
 			Destination:   m.Destination,
 			Options:       m.Options,
 			HostPath:      m.HostPath,
+			GuestPath:     m.GuestPath,
 			ReadOnly:      m.ReadOnly,
 			BlockDeviceID: m.BlockDeviceID,
 		})
@@ -423,15 +472,50 @@ func loadSandboxConfig(id string) (*SandboxConfig, error) {
 			NetworkCreated:    savedConf.NetworkConfig.NetworkCreated,
 			DisableNewNetwork: savedConf.NetworkConfig.DisableNewNetwork,
 			InterworkingModel: NetInterworkingModel(savedConf.NetworkConfig.InterworkingModel),
+			Simulated:         savedConf.NetworkConfig.Simulated,
 		},
 
-		ShmSize:             savedConf.ShmSize,
-		SharePidNs:          savedConf.SharePidNs,
-		SystemdCgroup:       savedConf.SystemdCgroup,
-		SandboxCgroupOnly:   savedConf.SandboxCgroupOnly,
-		DisableGuestSeccomp: savedConf.DisableGuestSeccomp,
-		EnableVCPUsPinning:  savedConf.EnableVCPUsPinning,
-		GuestSeLinuxLabel:   savedConf.GuestSeLinuxLabel,
+		ShmSize:                   savedConf.ShmSize,
+		SharePidNs:                savedConf.SharePidNs,
+		SystemdCgroup:             savedConf.SystemdCgroup,
+		SandboxCgroupOnly:         savedConf.SandboxCgroupOnly,
+		DisableGuestSeccomp:       savedConf.DisableGuestSeccomp,
+		GuestSeccompReport:        savedConf.GuestSeccompReport,
+		GuestPidsLimit:            savedConf.GuestPidsLimit,
+		MultipathEvents:           savedConf.MultipathEvents,
+		StopFlushTimeout:          savedConf.StopFlushTimeout,
+		ConfirmExecTimeout:        savedConf.ConfirmExecTimeout,
+		ForensicSnapshotThreshold: savedConf.ForensicSnapshotThreshold,
+		ForensicSnapshotCount:     savedConf.ForensicSnapshotCount,
+		GuestNameResolution:       savedConf.GuestNameResolution,
+		EntitlementsPath:          savedConf.EntitlementsPath,
+		ImageVolumePaths:          savedConf.ImageVolumePaths,
+		WasmRuntime:               savedConf.WasmRuntime,
+		NestedContainers:          savedConf.NestedContainers,
+		ShmChannel:                savedConf.ShmChannel,
+		Pauseless:                 savedConf.Pauseless,
+		Profile:                   savedConf.Profile,
+		GuestSeccompMode:          savedConf.GuestSeccompMode,
+		EnableVCPUsPinning:        savedConf.EnableVCPUsPinning,
+		VMMSchedClass:             savedConf.VMMSchedClass,
+		VMMSchedRTPriority:        savedConf.VMMSchedRTPriority,
+		GuestSeLinuxLabel:         savedConf.GuestSeLinuxLabel,
+		CoreDump: CoreDumpConfig{
+			HostDir:     savedConf.CoreDump.HostDir,
+			MaxCoreSize: savedConf.CoreDump.MaxCoreSize,
+			MaxDirSize:  savedConf.CoreDump.MaxDirSize,
+			Enabled:     savedConf.CoreDump.Enabled,
+		},
+		TmpfsSizing: TmpfsSizing{
+			ShmPercent:          savedConf.TmpfsSizing.ShmPercent,
+			MemoryVolumePercent: savedConf.TmpfsSizing.MemoryVolumePercent,
+			TmpfsPercent:        savedConf.TmpfsSizing.TmpfsPercent,
+		},
+		VMMLimits: VMMLimits{
+			MaxOpenFiles: savedConf.VMMLimits.MaxOpenFiles,
+			MaxThreads:   savedConf.VMMLimits.MaxThreads,
+			AlertPercent: savedConf.VMMLimits.AlertPercent,
+		},
 	}
 	sconfig.SandboxBindMounts = append(sconfig.SandboxBindMounts, savedConf.SandboxBindMounts...)
 
@@ -456,6 +540,7 @@ func loadSandboxConfig(id string) (*SandboxConfig, error) {
 		FirmwarePath:            hconf.FirmwarePath,
 		MachineAccelerators:     hconf.MachineAccelerators,
 		CPUFeatures:             hconf.CPUFeatures,
+		CPUMitigations:          hconf.CPUMitigations,
 		HypervisorPath:          hconf.HypervisorPath,
 		HypervisorPathList:      hconf.HypervisorPathList,
 		HypervisorCtlPath:       hconf.HypervisorCtlPath,
@@ -483,18 +568,27 @@ func loadSandboxConfig(id string) (*SandboxConfig, error) {
 		HugePages:               hconf.HugePages,
 		FileBackedMemRootDir:    hconf.FileBackedMemRootDir,
 		FileBackedMemRootList:   hconf.FileBackedMemRootList,
+		HostSharedMemory:        hconf.HostSharedMemory,
+		HostSharedMemoryList:    hconf.HostSharedMemoryList,
 		DisableNestingChecks:    hconf.DisableNestingChecks,
 		DisableImageNvdimm:      hconf.DisableImageNvdimm,
+		SharedBootAssets:        hconf.SharedBootAssets,
+		PmemVolumesSize:         hconf.PmemVolumesSize,
 		HotplugVFIOOnRootBus:    hconf.HotplugVFIOOnRootBus,
+		PCIeP2P:                 hconf.PCIeP2P,
 		HotPlugVFIO:             hconf.HotPlugVFIO,
 		ColdPlugVFIO:            hconf.ColdPlugVFIO,
 		BootToBeTemplate:        hconf.BootToBeTemplate,
 		BootFromTemplate:        hconf.BootFromTemplate,
 		DisableVhostNet:         hconf.DisableVhostNet,
 		EnableVhostUserStore:    hconf.EnableVhostUserStore,
+		VirtioFSSubmounts:       hconf.VirtioFSSubmounts,
 		VhostUserStorePath:      hconf.VhostUserStorePath,
 		VhostUserStorePathList:  hconf.VhostUserStorePathList,
 		GuestHookPath:           hconf.GuestHookPath,
+		CCAMeasurementAlgorithm: hconf.CCAMeasurementAlgorithm,
+		CCAPersonalizationValue: hconf.CCAPersonalizationValue,
+		CCAMeasurementLog:       hconf.CCAMeasurementLog,
 		VMid:                    hconf.VMid,
 		RxRateLimiterMaxRate:    hconf.RxRateLimiterMaxRate,
 		TxRateLimiterMaxRate:    hconf.TxRateLimiterMaxRate,
@@ -0,0 +1,70 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package persistapi
+
+// CompatSchemaVersion is the version of the sandbox state shared between the
+// Go runtime and runtime-rs. It must be bumped along with the runtime-rs
+// SandboxState whenever a change breaks the conversion between both runtimes.
+const CompatSchemaVersion uint = 2
+
+// CompatSandboxState is the sandbox state persisted by runtime-rs in
+// /run/kata/<sandbox-id>/state.json. It carries what both runtimes need to
+// adopt, and clean up after, a sandbox created by the other one.
+type CompatSandboxState struct {
+	Resource      *CompatResourceState   `json:"resource"`
+	Hypervisor    *CompatHypervisorState `json:"hypervisor"`
+	SandboxType   string                 `json:"sandbox_type"`
+	Containers    []CompatContainerState `json:"containers"`
+	SchemaVersion uint                   `json:"schema_version"`
+}
+
+// CompatContainerState is a container of the sandbox, as recorded by
+// runtime-rs when it is created. The adopting runtime reads the rest of its
+// configuration from its bundle.
+type CompatContainerState struct {
+	Annotations map[string]string `json:"annotations"`
+	ID          string            `json:"id"`
+	Bundle      string            `json:"bundle"`
+}
+
+// CompatResourceState is the runtime-rs ResourceState.
+type CompatResourceState struct {
+	CgroupState *CompatCgroupState `json:"cgroup_state"`
+	// Endpoint is not converted: the network endpoints are recreated from
+	// the sandbox network namespace by the adopting runtime.
+	Endpoint []interface{} `json:"endpoint"`
+}
+
+// CompatCgroupState is the runtime-rs CgroupState.
+type CompatCgroupState struct {
+	Path              *string `json:"path"`
+	OverheadPath      *string `json:"overhead_path"`
+	SandboxCgroupOnly bool    `json:"sandbox_cgroup_only"`
+}
+
+// CompatHypervisorState is the runtime-rs HypervisorState.
+type CompatHypervisorState struct {
+	Pid                *int                   `json:"pid"`
+	Netns              *string                `json:"netns"`
+	Config             CompatHypervisorConfig `json:"config"`
+	HypervisorType     string                 `json:"hypervisor_type"`
+	UUID               string                 `json:"uuid"`
+	APISocket          string                 `json:"api_socket"`
+	ID                 string                 `json:"id"`
+	VMPath             string                 `json:"vm_path"`
+	JailerRoot         string                 `json:"jailer_root"`
+	RunDir             string                 `json:"run_dir"`
+	CachedBlockDevices []string               `json:"cached_block_devices"`
+	VirtiofsDaemonPid  int                    `json:"virtiofs_daemon_pid"`
+	Jailed             bool                   `json:"jailed"`
+}
+
+// CompatHypervisorConfig is the subset of the runtime-rs hypervisor
+// configuration known to both runtimes, the other fields take their
+// runtime-rs default values.
+type CompatHypervisorConfig struct {
+	Path string `json:"path"`
+}
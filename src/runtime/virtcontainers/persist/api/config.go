@@ -33,6 +33,10 @@ type HypervisorConfig struct {
 	// CPUFeatures are cpu specific features
 	CPUFeatures string
 
+	// CPUMitigations is the CPU vulnerability mitigation profile of the
+	// guest
+	CPUMitigations string
+
 	// HypervisorPath is the hypervisor executable host path.
 	HypervisorPath string
 
@@ -77,6 +81,10 @@ type HypervisorConfig struct {
 	// File based memory backend root directory
 	FileBackedMemRootDir string
 
+	// HostSharedMemory is the host shared memory segment, e.g. a file of
+	// /dev/shm or /dev/hugepages, mapped in the guest.
+	HostSharedMemory string
+
 	// VhostUserStorePath is the directory path where vhost-user devices
 	// related folders, sockets and device nodes should be.
 	VhostUserStorePath string
@@ -87,6 +95,14 @@ type HypervisorConfig struct {
 	// GuestHookPath is the path within the VM that will be used for 'drop-in' hooks
 	GuestHookPath string
 
+	// CCAMeasurementAlgorithm is the hash algorithm measuring the Realm of
+	// an Arm CCA guest, sha256 or sha512.
+	CCAMeasurementAlgorithm string
+
+	// CCAPersonalizationValue is the base64 encoded Realm Personalization
+	// Value of an Arm CCA guest.
+	CCAPersonalizationValue string
+
 	// VMid is the id of the VM that create the hypervisor if the VM is created by the factory.
 	// VMid is "" if the hypervisor is not created by the factory.
 	VMid string
@@ -112,6 +128,10 @@ type HypervisorConfig struct {
 	// FileBackedMemRootList is the list of valid root directories values for annotations
 	FileBackedMemRootList []string
 
+	// HostSharedMemoryList is the list of valid host shared memory
+	// segments for annotations
+	HostSharedMemoryList []string
+
 	// VhostUserStorePathList is the list of valid values for vhost-user paths
 	VhostUserStorePathList []string
 
@@ -191,10 +211,21 @@ type HypervisorConfig struct {
 	// DisableImageNvdimm disables nvdimm for guest rootfs image
 	DisableImageNvdimm bool
 
+	// SharedBootAssets ensures the guests boot from assets shared by the sandboxes
+	SharedBootAssets bool
+
+	// PmemVolumesSize is the guest physical memory reserved, in MiB, for
+	// mapping the volumes on persistent memory as NVDIMMs
+	PmemVolumesSize uint32
+
 	// HotplugVFIOOnRootBus is used to indicate if devices need to be hotplugged on the
 	// root bus instead of a bridge.
 	HotplugVFIOOnRootBus bool
 
+	// PCIeP2P enables the peer-to-peer DMA between the VFIO devices
+	// sharing a PCIe switch on the host.
+	PCIeP2P bool
+
 	// HotPlugVFIO is used to indicate if devices need to be hotplugged on the
 	// root, switch, bridge or no-port
 	HotPlugVFIO config.PCIePort
@@ -214,6 +245,14 @@ type HypervisorConfig struct {
 
 	// EnableVhostUserStore is used to indicate if host supports vhost-user-blk/scsi
 	EnableVhostUserStore bool
+
+	// VirtioFSSubmounts makes virtiofsd announce the submounts of
+	// the shared directory to the guest
+	VirtioFSSubmounts bool
+
+	// CCAMeasurementLog makes the hypervisor log the measurements of the
+	// boot artifacts of an Arm CCA Realm.
+	CCAMeasurementLog bool
 }
 
 // KataAgentConfig is a structure storing information needed
@@ -235,6 +274,29 @@ type NetworkConfig struct {
 	NetworkCreated    bool
 	DisableNewNetwork bool
 	InterworkingModel int
+	Simulated         bool
+}
+
+// CoreDumpConfig is the core dump policy of a sandbox.
+type CoreDumpConfig struct {
+	HostDir     string
+	MaxCoreSize uint64
+	MaxDirSize  uint64
+	Enabled     bool
+}
+
+// VMMLimits are the resource limits of the VMM processes of a sandbox.
+type VMMLimits struct {
+	MaxOpenFiles uint64
+	MaxThreads   uint64
+	AlertPercent uint32
+}
+
+// TmpfsSizing is the tmpfs sizing policy of a sandbox.
+type TmpfsSizing struct {
+	ShmPercent          uint32
+	MemoryVolumePercent uint32
+	TmpfsPercent        uint32
 }
 
 type ContainerConfig struct {
@@ -294,6 +356,80 @@ type SandboxConfig struct {
 
 	DisableGuestSeccomp bool
 
+	// GuestSeccompReport collects the system calls blocked by seccomp
+	// within the guest
+	GuestSeccompReport bool
+
+	// GuestPidsLimit is the maximum number of processes within the guest
+	// of the containers without a pids limit of their own
+	GuestPidsLimit uint64
+
+	// MultipathEvents reports the path failures of the multipath maps
+	// attached to the sandbox
+	MultipathEvents bool
+
+	// StopFlushTimeout is how long in seconds the filesystems and the
+	// drives of the containers are flushed for when they stop
+	StopFlushTimeout uint32
+
+	// ConfirmExecTimeout is how long in seconds the start of a container
+	// waits for the agent to confirm its process executed its entrypoint
+	ConfirmExecTimeout uint32
+
+	// ForensicSnapshotThreshold is the number of nonzero exits of a
+	// container after which a diagnostic snapshot is captured
+	ForensicSnapshotThreshold uint32
+
+	// ForensicSnapshotCount is the number of snapshots kept
+	ForensicSnapshotCount uint32
+
+	// GuestNameResolution makes the agent manage the /etc/hosts and
+	// /etc/resolv.conf files of the containers
+	GuestNameResolution bool
+
+	// Profile is the profile of the sandbox
+	Profile string
+
+	// Pauseless does not create the sandbox container inside the guest
+	Pauseless bool
+
+	// EntitlementsPath is the host directory of the entitlements
+	// provisioned inside the guest
+	EntitlementsPath string
+
+	// ImageVolumePaths are the patterns of the host paths of the image
+	// volumes
+	ImageVolumePaths []string
+
+	// WasmRuntime is the WASM runtime of the guest running the WASM
+	// containers
+	WasmRuntime string
+
+	// NestedContainers lets the containers run a container engine inside
+	// the guest
+	NestedContainers bool
+
+	// ShmChannel is the shared memory channel the sandbox joins
+	ShmChannel string
+
+	// GuestSeccompMode selects how seccomp is applied within the guest
+	GuestSeccompMode string
+
+	// CoreDump is the core dump policy of the sandbox containers
+	CoreDump CoreDumpConfig
+
+	// TmpfsSizing is the sizing policy of the tmpfs of the guest
+	TmpfsSizing TmpfsSizing
+
 	// EnableVCPUsPinning controls whether each vCPU thread should be scheduled to a fixed CPU
 	EnableVCPUsPinning bool
+
+	// VMMSchedClass is the scheduling class of the VMM threads
+	VMMSchedClass string
+
+	// VMMSchedRTPriority is the realtime priority of the vCPU threads
+	VMMSchedRTPriority int
+
+	// VMMLimits are the resource limits of the VMM processes
+	VMMLimits VMMLimits
 }
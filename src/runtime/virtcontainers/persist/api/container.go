@@ -44,6 +44,9 @@ type Mount struct {
 	// HostPath used to store host side bind mount path
 	HostPath string
 
+	// GuestPath is the path of the copy of the file in the guest
+	GuestPath string
+
 	// BlockDeviceID represents block device that is attached to the
 	// VM in case this mount is a block device file or a directory
 	// backed by a block device.
@@ -38,14 +38,35 @@ type NetworkInterfacePair struct {
 	TapInterface
 	VirtIface            NetworkInterface
 	NetInterworkingModel int
+	Queues               int
 }
 
 type PhysicalEndpoint struct {
+	VF             *SRIOVVF
 	BDF            string
 	Driver         string
 	VendorDeviceID string
 }
 
+// SRIOVVFConfig is the configuration of an SR-IOV VF programmed on its PF
+type SRIOVVFConfig struct {
+	Vlan      *int
+	Qos       *int
+	SpoofChk  *bool
+	Trust     *bool
+	MinTxRate *uint32
+	MaxTxRate *uint32
+	LinkState *uint32
+}
+
+// SRIOVVF is an SR-IOV VF programmed on its PF, along with the configuration
+// it had before it was programmed
+type SRIOVVF struct {
+	PF    string
+	Saved SRIOVVFConfig
+	Index int
+}
+
 type MacvtapEndpoint struct {
 	// This is for showing information.
 	// Remove this field won't impact anything.
@@ -72,6 +93,10 @@ type IPVlanEndpoint struct {
 	NetPair NetworkInterfacePair
 }
 
+type SimulatedEndpoint struct {
+	Iface NetworkInterface
+}
+
 type VhostUserEndpoint struct {
 	// This is for showing information.
 	// Remove these fields won't impact anything.
@@ -79,6 +104,52 @@ type VhostUserEndpoint struct {
 	PCIPath   vcTypes.PciPath
 }
 
+// NetworkIfaceAttrs are the attributes of the network interface an endpoint
+// was created for
+type NetworkIfaceAttrs struct {
+	Name     string
+	Type     string
+	HardAddr string
+	MTU      int
+	TxQLen   int
+	Flags    uint
+	RawFlags uint32
+}
+
+// NetworkRoute is a route of the network interface an endpoint was created
+// for, addresses are saved in their string form
+type NetworkRoute struct {
+	Dst      string
+	Src      string
+	Gw       string
+	Scope    int
+	Family   int
+	Protocol int
+	Priority int
+	Table    int
+	Type     int
+	Flags    int
+}
+
+// NetworkNeighbor is a static neighbor of the network interface an endpoint
+// was created for
+type NetworkNeighbor struct {
+	IP       string
+	HardAddr string
+	State    int
+	Flags    int
+	Family   int
+}
+
+// NetworkProperties are the properties of the network interface an endpoint
+// was created for, the guest network is recreated from them on restart
+type NetworkProperties struct {
+	Iface     NetworkIfaceAttrs
+	Addrs     []netlink.Addr
+	Routes    []NetworkRoute
+	Neighbors []NetworkNeighbor
+}
+
 // NetworkEndpoint contains network interface information
 type NetworkEndpoint struct {
 	// One and only one of these below are not nil according to Type.
@@ -90,6 +161,14 @@ type NetworkEndpoint struct {
 	Tap       *TapEndpoint       `json:",omitempty"`
 	IPVlan    *IPVlanEndpoint    `json:",omitempty"`
 	Tuntap    *TuntapEndpoint    `json:",omitempty"`
+	Simulated *SimulatedEndpoint `json:",omitempty"`
+
+	// Properties, PCIPath and the rate limiters are common to all the
+	// endpoint types. PCIPath is saved in its string form.
+	Properties    *NetworkProperties `json:",omitempty"`
+	PCIPath       string             `json:",omitempty"`
+	RxRateLimiter bool
+	TxRateLimiter bool
 
 	Type string
 }
@@ -99,4 +178,5 @@ type NetworkInfo struct {
 	NetworkID      string
 	Endpoints      []NetworkEndpoint
 	NetworkCreated bool
+	Simulated      bool
 }
@@ -0,0 +1,174 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package persist
+
+import (
+	"fmt"
+	"path/filepath"
+
+	hv "github.com/kata-containers/kata-containers/src/runtime/pkg/hypervisors"
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	vcAnnotations "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+)
+
+// compatSandboxType is the runtime-rs sandbox type of the sandboxes
+// running their containers in a VM.
+const compatSandboxType = "virt_container"
+
+// compatHypervisorTypes maps the Go runtime hypervisor types to the
+// runtime-rs ones, for the hypervisors whose VM both runtimes can adopt:
+// runtime-rs only restores cloud-hypervisor among the hypervisors the Go
+// runtime runs, and dragonball runs in the runtime-rs shim process.
+var compatHypervisorTypes = map[string]string{
+	"clh": "cloud-hypervisor",
+}
+
+// ToCompatState converts the state of a sandbox persisted by the Go runtime
+// to the state runtime-rs persists. vmDir is the directory holding the
+// sandbox VM sockets and shared mount points.
+func ToCompatState(ss persistapi.SandboxState, cs map[string]persistapi.ContainerState, vmDir string) (persistapi.CompatSandboxState, error) {
+	hypervisorType, ok := compatHypervisorTypes[ss.HypervisorState.Type]
+	if !ok {
+		return persistapi.CompatSandboxState{}, fmt.Errorf("hypervisor %q is not supported by runtime-rs", ss.HypervisorState.Type)
+	}
+
+	id := ss.SandboxContainer
+	hs := &persistapi.CompatHypervisorState{
+		HypervisorType:     hypervisorType,
+		UUID:               ss.HypervisorState.UUID,
+		APISocket:          ss.HypervisorState.APISocket,
+		ID:                 id,
+		VMPath:             filepath.Join(vmDir, id),
+		RunDir:             filepath.Join(vmDir, id),
+		VirtiofsDaemonPid:  ss.HypervisorState.VirtiofsDaemonPid,
+		CachedBlockDevices: []string{},
+		Config: persistapi.CompatHypervisorConfig{
+			Path: ss.Config.HypervisorConfig.HypervisorPath,
+		},
+	}
+	if pid := ss.HypervisorState.Pid; pid > 0 {
+		hs.Pid = &pid
+	}
+	if netns := ss.Network.NetworkID; netns != "" {
+		hs.Netns = &netns
+	}
+
+	cgroups := &persistapi.CompatCgroupState{
+		SandboxCgroupOnly: ss.Config.SandboxCgroupOnly,
+	}
+	if path := ss.SandboxCgroupPath; path != "" {
+		cgroups.Path = &path
+	}
+	if path := ss.OverheadCgroupPath; path != "" {
+		cgroups.OverheadPath = &path
+	}
+
+	containers := []persistapi.CompatContainerState{}
+	for _, c := range ss.Config.ContainerConfigs {
+		bundle := c.Annotations[vcAnnotations.BundlePathKey]
+		if bundle == "" {
+			bundle = cs[c.ID].BundlePath
+		}
+		containers = append(containers, persistapi.CompatContainerState{
+			ID:          c.ID,
+			Bundle:      bundle,
+			Annotations: c.Annotations,
+		})
+	}
+
+	return persistapi.CompatSandboxState{
+		SandboxType:   compatSandboxType,
+		SchemaVersion: persistapi.CompatSchemaVersion,
+		Resource: &persistapi.CompatResourceState{
+			CgroupState: cgroups,
+			Endpoint:    []interface{}{},
+		},
+		Hypervisor: hs,
+		Containers: containers,
+	}, nil
+}
+
+// FromCompatState converts the state of a sandbox persisted by runtime-rs to
+// the state the Go runtime persists for the sandbox id, along with the
+// states of its containers.
+func FromCompatState(cs persistapi.CompatSandboxState, id string) (persistapi.SandboxState, map[string]persistapi.ContainerState, error) {
+	// The states written before the containers were recorded would
+	// leave the Go runtime unaware of them.
+	if cs.SchemaVersion != persistapi.CompatSchemaVersion {
+		return persistapi.SandboxState{}, nil, fmt.Errorf("unsupported state schema version %d, expected %d", cs.SchemaVersion, persistapi.CompatSchemaVersion)
+	}
+	if cs.SandboxType != compatSandboxType {
+		return persistapi.SandboxState{}, nil, fmt.Errorf("unsupported sandbox type %q", cs.SandboxType)
+	}
+	if cs.Hypervisor == nil {
+		return persistapi.SandboxState{}, nil, fmt.Errorf("missing hypervisor state")
+	}
+
+	hypervisorType := ""
+	for goType, rsType := range compatHypervisorTypes {
+		if rsType == cs.Hypervisor.HypervisorType {
+			hypervisorType = goType
+		}
+	}
+	if hypervisorType == "" {
+		return persistapi.SandboxState{}, nil, fmt.Errorf("hypervisor %q is not supported by the Go runtime", cs.Hypervisor.HypervisorType)
+	}
+
+	ss := persistapi.SandboxState{
+		State:            string(types.StateRunning),
+		SandboxContainer: id,
+		PersistVersion:   persistapi.CurPersistVersion,
+		HypervisorState: hv.HypervisorState{
+			Type:              hypervisorType,
+			UUID:              cs.Hypervisor.UUID,
+			APISocket:         cs.Hypervisor.APISocket,
+			VirtiofsDaemonPid: cs.Hypervisor.VirtiofsDaemonPid,
+		},
+	}
+	ss.Config.HypervisorType = hypervisorType
+	ss.Config.HypervisorConfig.HypervisorPath = cs.Hypervisor.Config.Path
+	if cs.Hypervisor.Pid != nil {
+		ss.HypervisorState.Pid = *cs.Hypervisor.Pid
+	}
+	if cs.Hypervisor.Netns != nil {
+		ss.Network.NetworkID = *cs.Hypervisor.Netns
+	}
+
+	if cs.Resource != nil && cs.Resource.CgroupState != nil {
+		cgroups := cs.Resource.CgroupState
+		ss.Config.SandboxCgroupOnly = cgroups.SandboxCgroupOnly
+		if cgroups.Path != nil {
+			ss.SandboxCgroupPath = *cgroups.Path
+		}
+		if cgroups.OverheadPath != nil {
+			ss.OverheadCgroupPath = *cgroups.OverheadPath
+		}
+	}
+
+	containers := make(map[string]persistapi.ContainerState)
+	for _, c := range cs.Containers {
+		// The Go runtime finds the bundle of a container in its
+		// annotations.
+		annotations := make(map[string]string, len(c.Annotations)+1)
+		for k, v := range c.Annotations {
+			annotations[k] = v
+		}
+		if _, ok := annotations[vcAnnotations.BundlePathKey]; !ok && c.Bundle != "" {
+			annotations[vcAnnotations.BundlePathKey] = c.Bundle
+		}
+		ss.Config.ContainerConfigs = append(ss.Config.ContainerConfigs, persistapi.ContainerConfig{
+			ID:          c.ID,
+			Annotations: annotations,
+		})
+		containers[c.ID] = persistapi.ContainerState{
+			State:      string(types.StateRunning),
+			BundlePath: c.Bundle,
+		}
+	}
+
+	return ss, containers, nil
+}
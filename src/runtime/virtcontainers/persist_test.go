@@ -32,7 +32,7 @@ func TestSandboxRestore(t *testing.T) {
 	sandbox := Sandbox{
 		id:         "test-exp",
 		containers: container,
-		devManager: manager.NewDeviceManager(config.VirtioSCSI, false, "", 0, nil),
+		devManager: manager.NewDeviceManager(config.VirtioSCSI, false, "", 0, "", false, nil),
 		hypervisor: &mockHypervisor{},
 		network:    network,
 		ctx:        context.Background(),
@@ -81,3 +81,37 @@ func TestSandboxRestore(t *testing.T) {
 	assert.Equal(len(sandbox.state.BlockIndexMap), 1)
 	assert.Equal(sandbox.state.BlockIndexMap[2], struct{}{})
 }
+
+func TestSandboxConfigRestoreCCA(t *testing.T) {
+	assert := assert.New(t)
+
+	sandbox := Sandbox{
+		id: "test-cca",
+		config: &SandboxConfig{
+			ID: "test-cca",
+			HypervisorConfig: HypervisorConfig{
+				CCAMeasurementAlgorithm: CCAMeasurementSHA512,
+				CCAPersonalizationValue: "a2F0YQ==",
+				CCAMeasurementLog:       true,
+			},
+		},
+		hypervisor: &mockHypervisor{},
+		devManager: manager.NewDeviceManager(config.VirtioSCSI, false, "", 0, "", false, nil),
+		state:      types.SandboxState{BlockIndexMap: make(map[int]struct{})},
+		ctx:        context.Background(),
+	}
+
+	var err error
+	sandbox.store, err = persist.GetDriver()
+	assert.NoError(err)
+	sandbox.network, err = NewNetwork()
+	assert.NoError(err)
+	assert.NoError(sandbox.Save())
+	defer sandbox.store.Destroy(sandbox.id)
+
+	sconfig, err := loadSandboxConfig(sandbox.id)
+	assert.NoError(err)
+	assert.Equal(CCAMeasurementSHA512, sconfig.HypervisorConfig.CCAMeasurementAlgorithm)
+	assert.Equal("a2F0YQ==", sconfig.HypervisorConfig.CCAPersonalizationValue)
+	assert.True(sconfig.HypervisorConfig.CCAMeasurementLog)
+}
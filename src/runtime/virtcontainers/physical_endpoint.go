@@ -26,6 +26,8 @@ var physicalTrace = getNetworkTrace(PhysicalEndpointType)
 
 // PhysicalEndpoint gathers a physical network interface and its properties
 type PhysicalEndpoint struct {
+	// VF is set when the endpoint is an SR-IOV VF programmed on its PF
+	VF                 *SRIOVVF
 	IfaceName          string
 	HardAddr           string
 	EndpointProperties NetworkInfo
@@ -78,10 +80,24 @@ func (endpoint *PhysicalEndpoint) NetworkPair() *NetworkInterfacePair {
 
 // Attach for physical endpoint binds the physical network interface to
 // vfio-pci and adds device to the hypervisor with vfio-passthrough.
-func (endpoint *PhysicalEndpoint) Attach(ctx context.Context, s *Sandbox) error {
+func (endpoint *PhysicalEndpoint) Attach(ctx context.Context, s *Sandbox) (err error) {
 	span, ctx := physicalTrace(ctx, "Attach", endpoint)
 	defer span.End()
 
+	// The host side attributes of SR-IOV VFs are programmed on their PF
+	// before they are given to the guest, and restored on detach.
+	if config, ok := s.config.SRIOVVFConfigs[endpoint.IfaceName]; ok {
+		endpoint.VF, err = programSRIOVVF(endpoint.BDF, config)
+		defer func() {
+			if err != nil {
+				endpoint.restoreVF()
+			}
+		}()
+		if err != nil {
+			return err
+		}
+	}
+
 	// Unbind physical interface from host driver and bind to vfio
 	// so that it can be passed to qemu.
 	vfioPath, err := bindNICToVFIO(endpoint)
@@ -118,7 +134,27 @@ func (endpoint *PhysicalEndpoint) Detach(ctx context.Context, netNsCreated bool,
 
 	// We do not need to enter the network namespace to bind back the
 	// physical interface to host driver.
-	return bindNICToHost(endpoint)
+	if err := bindNICToHost(endpoint); err != nil {
+		return err
+	}
+
+	return endpoint.restoreVF()
+}
+
+// restoreVF programs the VF of the endpoint back to the configuration it had
+// before it was attached.
+func (endpoint *PhysicalEndpoint) restoreVF() error {
+	if endpoint.VF == nil {
+		return nil
+	}
+
+	if err := restoreSRIOVVF(endpoint.VF); err != nil {
+		networkLogger().WithError(err).WithField("endpoint", endpoint.IfaceName).Error("failed to restore VF configuration")
+		return err
+	}
+
+	endpoint.VF = nil
+	return nil
 }
 
 // HotAttach for physical endpoint not supported yet
@@ -229,6 +265,7 @@ func (endpoint *PhysicalEndpoint) save() persistapi.NetworkEndpoint {
 		Type: string(endpoint.Type()),
 
 		Physical: &persistapi.PhysicalEndpoint{
+			VF:             endpoint.VF.save(),
 			BDF:            endpoint.BDF,
 			Driver:         endpoint.Driver,
 			VendorDeviceID: endpoint.VendorDeviceID,
@@ -243,6 +280,7 @@ func (endpoint *PhysicalEndpoint) load(s persistapi.NetworkEndpoint) {
 		endpoint.BDF = s.Physical.BDF
 		endpoint.Driver = s.Physical.Driver
 		endpoint.VendorDeviceID = s.Physical.VendorDeviceID
+		endpoint.VF = loadSRIOVVF(s.Physical.VF)
 	}
 }
 
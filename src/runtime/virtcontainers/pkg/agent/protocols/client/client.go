@@ -35,6 +35,7 @@ const (
 	VSockSocketScheme     = "vsock"
 	HybridVSockScheme     = "hvsock"
 	MockHybridVSockScheme = "mock"
+	SerialSockScheme      = "serial"
 )
 
 var defaultDialTimeout = 30 * time.Second
@@ -65,6 +66,8 @@ type dialer func(string, time.Duration) (net.Conn, error)
 //   - hvsock://<path>:<port>. Firecracker implements the virtio-vsock device
 //     model, and mediates communication between AF_UNIX sockets (on the host end)
 //     and AF_VSOCK sockets (on the guest end).
+//   - serial://<path>. virtio-serial port whose host side is a unix socket
+//     served by the hypervisor.
 //   - mock://<path>. just for test use.
 func NewAgentClient(ctx context.Context, sock string, timeout uint32) (*AgentClient, error) {
 	grpcAddr, parsedAddr, err := parse(sock)
@@ -235,6 +238,11 @@ func parse(sock string) (string, *url.URL, error) {
 		}
 		hybridVSockPort = uint32(port)
 		grpcAddr = HybridVSockScheme + ":" + hvsocket[0]
+	case SerialSockScheme:
+		if addr.Path == "" {
+			return "", nil, grpcStatus.Errorf(codes.InvalidArgument, "Invalid serial scheme: %s", sock)
+		}
+		grpcAddr = SerialSockScheme + ":" + addr.Path
 	// just for tests use.
 	case MockHybridVSockScheme:
 		if addr.Path == "" {
@@ -255,6 +263,8 @@ func agentDialer(addr *url.URL) dialer {
 		return VsockDialer
 	case HybridVSockScheme:
 		return HybridVSockDialer
+	case SerialSockScheme:
+		return SerialSockDialer
 	case MockHybridVSockScheme:
 		return MockHybridVSockDialer
 	default:
@@ -435,6 +445,18 @@ func HybridVSockDialer(sock string, timeout time.Duration) (net.Conn, error) {
 	return commonDialer(timeout, dialFunc, timeoutErr)
 }
 
+// SerialSockDialer dials to the host side of a virtio-serial port
+func SerialSockDialer(sock string, timeout time.Duration) (net.Conn, error) {
+	sock = strings.TrimPrefix(sock, SerialSockScheme+":")
+
+	dialFunc := func() (net.Conn, error) {
+		return net.DialTimeout("unix", sock, timeout)
+	}
+
+	timeoutErr := grpcStatus.Errorf(codes.DeadlineExceeded, "timed out connecting to serial port %s", sock)
+	return commonDialer(timeout, dialFunc, timeoutErr)
+}
+
 // just for tests use.
 func MockHybridVSockDialer(sock string, timeout time.Duration) (net.Conn, error) {
 	sock = strings.TrimPrefix(sock, "mock:")
@@ -0,0 +1,75 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package client
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	assert := assert.New(t)
+
+	data := []struct {
+		sock     string
+		grpcAddr string
+		valid    bool
+	}{
+		{"vsock://3:1024", "vsock:3:1024", true},
+		{"vsock://3", "", false},
+		{"hvsock:///run/vc/kata.hvsock:1024", "hvsock:/run/vc/kata.hvsock", true},
+		{"serial:///run/vc/vm/foo/agent.sock", "serial:/run/vc/vm/foo/agent.sock", true},
+		{"serial://", "", false},
+		{"tls://192.168.0.2:1024", "", false},
+		{"mock:///tmp/socket", "mock:/tmp/socket", true},
+		{"foo://bar", "", false},
+	}
+
+	for _, d := range data {
+		grpcAddr, _, err := parse(d.sock)
+		if !d.valid {
+			assert.Error(err, "%+v", d)
+			continue
+		}
+		assert.NoError(err, "%+v", d)
+		assert.Equal(d.grpcAddr, grpcAddr, "%+v", d)
+	}
+}
+
+func TestSerialSockDialer(t *testing.T) {
+	assert := assert.New(t)
+
+	// the host side of the port, served by the hypervisor
+	path := filepath.Join(t.TempDir(), "agent.sock")
+	listener, err := net.Listen("unix", path)
+	assert.NoError(err)
+	defer listener.Close()
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.Write([]byte("ok"))
+	}()
+
+	conn, err := SerialSockDialer(SerialSockScheme+":"+path, 5*time.Second)
+	assert.NoError(err)
+	defer conn.Close()
+
+	buf := make([]byte, 2)
+	_, err = conn.Read(buf)
+	assert.NoError(err)
+	assert.Equal("ok", string(buf))
+
+	_, err = SerialSockDialer(SerialSockScheme+":"+path+".none", time.Second)
+	assert.Error(err)
+}
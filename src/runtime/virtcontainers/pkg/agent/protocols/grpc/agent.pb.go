@@ -80,7 +80,10 @@ func (m *CreateContainerRequest) XXX_DiscardUnknown() {
 var xxx_messageInfo_CreateContainerRequest proto.InternalMessageInfo
 
 type StartContainerRequest struct {
-	ContainerId          string   `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	ContainerId string `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	// Timeout in milliseconds the request waits for the process of the
+	// container to exec its entrypoint, not waiting when 0
+	ExecTimeout          uint32   `protobuf:"varint,2,opt,name=exec_timeout,json=execTimeout,proto3" json:"exec_timeout,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1295,10 +1298,13 @@ type CreateSandboxRequest struct {
 	// that the agent will search for OCI hooks to run within the guest.
 	GuestHookPath string `protobuf:"bytes,6,opt,name=guest_hook_path,json=guestHookPath,proto3" json:"guest_hook_path,omitempty"`
 	// This field is the list of kernel modules to be loaded in the guest kernel.
-	KernelModules        []*KernelModule `protobuf:"bytes,7,rep,name=kernel_modules,json=kernelModules,proto3" json:"kernel_modules,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	KernelModules []*KernelModule `protobuf:"bytes,7,rep,name=kernel_modules,json=kernelModules,proto3" json:"kernel_modules,omitempty"`
+	// Systemd units of the guest image started and health-checked by the
+	// agent, among those the image allows
+	GuestServices        []string `protobuf:"bytes,8,rep,name=guest_services,json=guestServices,proto3" json:"guest_services,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *CreateSandboxRequest) Reset()      { *m = CreateSandboxRequest{} }
@@ -2018,7 +2024,10 @@ type AgentDetails struct {
 	StorageHandlers []string `protobuf:"bytes,4,rep,name=storage_handlers,json=storageHandlers,proto3" json:"storage_handlers,omitempty"`
 	// Set only if the agent is built with seccomp support and the guest
 	// environment supports seccomp.
-	SupportsSeccomp      bool     `protobuf:"varint,5,opt,name=supports_seccomp,json=supportsSeccomp,proto3" json:"supports_seccomp,omitempty"`
+	SupportsSeccomp bool `protobuf:"varint,5,opt,name=supports_seccomp,json=supportsSeccomp,proto3" json:"supports_seccomp,omitempty"`
+	// Content of the metadata file of the guest image, empty if the image
+	// was built without one.
+	ImageMetadata        string   `protobuf:"bytes,6,opt,name=image_metadata,json=imageMetadata,proto3" json:"image_metadata,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2510,6 +2519,350 @@ func (m *CopyFileRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_CopyFileRequest proto.InternalMessageInfo
 
+type ReadFileRequest struct {
+	// Path is the file to read in the guest. It must be absolute,
+	// canonical and below /run.
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// MaxSize is the number of bytes read at most from Offset, the rest of
+	// the file being read when 0.
+	MaxSize uint64 `protobuf:"varint,2,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"`
+	// Offset is where the read starts in the file.
+	Offset               uint64   `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadFileRequest) Reset()      { *m = ReadFileRequest{} }
+func (*ReadFileRequest) ProtoMessage() {}
+func (*ReadFileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{58}
+}
+func (m *ReadFileRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReadFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReadFileRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReadFileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadFileRequest.Merge(m, src)
+}
+func (m *ReadFileRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReadFileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadFileRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadFileRequest proto.InternalMessageInfo
+
+type ReadFileResponse struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadFileResponse) Reset()      { *m = ReadFileResponse{} }
+func (*ReadFileResponse) ProtoMessage() {}
+func (*ReadFileResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{59}
+}
+func (m *ReadFileResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReadFileResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReadFileResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReadFileResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadFileResponse.Merge(m, src)
+}
+func (m *ReadFileResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReadFileResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadFileResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadFileResponse proto.InternalMessageInfo
+
+type GetDiagnosticsRequest struct {
+	// Size of the tail of the kernel log returned at most, the whole log
+	// when 0
+	MaxKernelLogSize     uint64   `protobuf:"varint,1,opt,name=max_kernel_log_size,json=maxKernelLogSize,proto3" json:"max_kernel_log_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetDiagnosticsRequest) Reset()      { *m = GetDiagnosticsRequest{} }
+func (*GetDiagnosticsRequest) ProtoMessage() {}
+func (*GetDiagnosticsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{60}
+}
+func (m *GetDiagnosticsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetDiagnosticsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetDiagnosticsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetDiagnosticsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDiagnosticsRequest.Merge(m, src)
+}
+func (m *GetDiagnosticsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetDiagnosticsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDiagnosticsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDiagnosticsRequest proto.InternalMessageInfo
+
+type Diagnostics struct {
+	// Kernel log, formatted as by dmesg
+	KernelLog []byte `protobuf:"bytes,1,opt,name=kernel_log,json=kernelLog,proto3" json:"kernel_log,omitempty"`
+	// Mount table of the agent, as in /proc/self/mountinfo
+	Mounts []byte `protobuf:"bytes,2,opt,name=mounts,proto3" json:"mounts,omitempty"`
+	// Processes of the guest, one per line
+	Processes            []byte   `protobuf:"bytes,3,opt,name=processes,proto3" json:"processes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Diagnostics) Reset()      { *m = Diagnostics{} }
+func (*Diagnostics) ProtoMessage() {}
+func (*Diagnostics) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{61}
+}
+func (m *Diagnostics) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Diagnostics) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Diagnostics.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Diagnostics) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Diagnostics.Merge(m, src)
+}
+func (m *Diagnostics) XXX_Size() int {
+	return m.Size()
+}
+func (m *Diagnostics) XXX_DiscardUnknown() {
+	xxx_messageInfo_Diagnostics.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Diagnostics proto.InternalMessageInfo
+
+type CriuOptions struct {
+	// Checkpoint the established TCP connections
+	TcpEstablished bool `protobuf:"varint,1,opt,name=tcp_established,json=tcpEstablished,proto3" json:"tcp_established,omitempty"`
+	// Checkpoint the file locks
+	FileLocks bool `protobuf:"varint,2,opt,name=file_locks,json=fileLocks,proto3" json:"file_locks,omitempty"`
+	// Checkpoint the external unix sockets
+	ExtUnixSockets       bool     `protobuf:"varint,3,opt,name=ext_unix_sockets,json=extUnixSockets,proto3" json:"ext_unix_sockets,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CriuOptions) Reset()      { *m = CriuOptions{} }
+func (*CriuOptions) ProtoMessage() {}
+func (*CriuOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{62}
+}
+func (m *CriuOptions) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CriuOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CriuOptions.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CriuOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CriuOptions.Merge(m, src)
+}
+func (m *CriuOptions) XXX_Size() int {
+	return m.Size()
+}
+func (m *CriuOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_CriuOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CriuOptions proto.InternalMessageInfo
+
+type CheckpointContainerRequest struct {
+	ContainerId string `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	// Directory of the guest the CRIU images are written in. It must be
+	// absolute and below /run.
+	ImagePath string `protobuf:"bytes,2,opt,name=image_path,json=imagePath,proto3" json:"image_path,omitempty"`
+	// Leave the container running after the checkpoint
+	LeaveRunning         bool         `protobuf:"varint,3,opt,name=leave_running,json=leaveRunning,proto3" json:"leave_running,omitempty"`
+	Options              *CriuOptions `protobuf:"bytes,4,opt,name=options,proto3" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *CheckpointContainerRequest) Reset()      { *m = CheckpointContainerRequest{} }
+func (*CheckpointContainerRequest) ProtoMessage() {}
+func (*CheckpointContainerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{63}
+}
+func (m *CheckpointContainerRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CheckpointContainerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CheckpointContainerRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CheckpointContainerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointContainerRequest.Merge(m, src)
+}
+func (m *CheckpointContainerRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *CheckpointContainerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointContainerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointContainerRequest proto.InternalMessageInfo
+
+type CheckpointContainerResponse struct {
+	// Names of the files of the image directory
+	Files                []string `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointContainerResponse) Reset()      { *m = CheckpointContainerResponse{} }
+func (*CheckpointContainerResponse) ProtoMessage() {}
+func (*CheckpointContainerResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{64}
+}
+func (m *CheckpointContainerResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CheckpointContainerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CheckpointContainerResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CheckpointContainerResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointContainerResponse.Merge(m, src)
+}
+func (m *CheckpointContainerResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *CheckpointContainerResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointContainerResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointContainerResponse proto.InternalMessageInfo
+
+type RestoreContainerRequest struct {
+	// Created container the process tree is restored in, in place of
+	// starting it
+	ContainerId string `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	// Directory of the guest holding the CRIU images. It must be absolute
+	// and below /run.
+	ImagePath            string       `protobuf:"bytes,2,opt,name=image_path,json=imagePath,proto3" json:"image_path,omitempty"`
+	Options              *CriuOptions `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *RestoreContainerRequest) Reset()      { *m = RestoreContainerRequest{} }
+func (*RestoreContainerRequest) ProtoMessage() {}
+func (*RestoreContainerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{65}
+}
+func (m *RestoreContainerRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RestoreContainerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RestoreContainerRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RestoreContainerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreContainerRequest.Merge(m, src)
+}
+func (m *RestoreContainerRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RestoreContainerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreContainerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreContainerRequest proto.InternalMessageInfo
+
 type GetOOMEventRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -2519,7 +2872,7 @@ type GetOOMEventRequest struct {
 func (m *GetOOMEventRequest) Reset()      { *m = GetOOMEventRequest{} }
 func (*GetOOMEventRequest) ProtoMessage() {}
 func (*GetOOMEventRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{58}
+	return fileDescriptor_56ede974c0020f77, []int{66}
 }
 func (m *GetOOMEventRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2558,7 +2911,7 @@ type OOMEvent struct {
 func (m *OOMEvent) Reset()      { *m = OOMEvent{} }
 func (*OOMEvent) ProtoMessage() {}
 func (*OOMEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{59}
+	return fileDescriptor_56ede974c0020f77, []int{67}
 }
 func (m *OOMEvent) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2597,7 +2950,7 @@ type AddSwapRequest struct {
 func (m *AddSwapRequest) Reset()      { *m = AddSwapRequest{} }
 func (*AddSwapRequest) ProtoMessage() {}
 func (*AddSwapRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{60}
+	return fileDescriptor_56ede974c0020f77, []int{68}
 }
 func (m *AddSwapRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2635,7 +2988,7 @@ type GetMetricsRequest struct {
 func (m *GetMetricsRequest) Reset()      { *m = GetMetricsRequest{} }
 func (*GetMetricsRequest) ProtoMessage() {}
 func (*GetMetricsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{61}
+	return fileDescriptor_56ede974c0020f77, []int{69}
 }
 func (m *GetMetricsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2674,7 +3027,7 @@ type Metrics struct {
 func (m *Metrics) Reset()      { *m = Metrics{} }
 func (*Metrics) ProtoMessage() {}
 func (*Metrics) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{62}
+	return fileDescriptor_56ede974c0020f77, []int{70}
 }
 func (m *Metrics) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2714,7 +3067,7 @@ type VolumeStatsRequest struct {
 func (m *VolumeStatsRequest) Reset()      { *m = VolumeStatsRequest{} }
 func (*VolumeStatsRequest) ProtoMessage() {}
 func (*VolumeStatsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{63}
+	return fileDescriptor_56ede974c0020f77, []int{71}
 }
 func (m *VolumeStatsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2743,26 +3096,24 @@ func (m *VolumeStatsRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_VolumeStatsRequest proto.InternalMessageInfo
 
-type ResizeVolumeRequest struct {
-	// Full VM guest path of the volume (outside the container)
-	VolumeGuestPath      string   `protobuf:"bytes,1,opt,name=volume_guest_path,json=volumeGuestPath,proto3" json:"volume_guest_path,omitempty"`
-	Size_                uint64   `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+type ContainerVolumeStatsRequest struct {
+	ContainerId          string   `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ResizeVolumeRequest) Reset()      { *m = ResizeVolumeRequest{} }
-func (*ResizeVolumeRequest) ProtoMessage() {}
-func (*ResizeVolumeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{64}
+func (m *ContainerVolumeStatsRequest) Reset()      { *m = ContainerVolumeStatsRequest{} }
+func (*ContainerVolumeStatsRequest) ProtoMessage() {}
+func (*ContainerVolumeStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{72}
 }
-func (m *ResizeVolumeRequest) XXX_Unmarshal(b []byte) error {
+func (m *ContainerVolumeStatsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ResizeVolumeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ContainerVolumeStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ResizeVolumeRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ContainerVolumeStatsRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -2772,910 +3123,1072 @@ func (m *ResizeVolumeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *ResizeVolumeRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ResizeVolumeRequest.Merge(m, src)
+func (m *ContainerVolumeStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContainerVolumeStatsRequest.Merge(m, src)
 }
-func (m *ResizeVolumeRequest) XXX_Size() int {
+func (m *ContainerVolumeStatsRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *ResizeVolumeRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ResizeVolumeRequest.DiscardUnknown(m)
+func (m *ContainerVolumeStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContainerVolumeStatsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ResizeVolumeRequest proto.InternalMessageInfo
+var xxx_messageInfo_ContainerVolumeStatsRequest proto.InternalMessageInfo
 
-func init() {
-	proto.RegisterType((*CreateContainerRequest)(nil), "grpc.CreateContainerRequest")
-	proto.RegisterType((*StartContainerRequest)(nil), "grpc.StartContainerRequest")
-	proto.RegisterType((*RemoveContainerRequest)(nil), "grpc.RemoveContainerRequest")
-	proto.RegisterType((*ExecProcessRequest)(nil), "grpc.ExecProcessRequest")
-	proto.RegisterType((*SignalProcessRequest)(nil), "grpc.SignalProcessRequest")
-	proto.RegisterType((*WaitProcessRequest)(nil), "grpc.WaitProcessRequest")
-	proto.RegisterType((*WaitProcessResponse)(nil), "grpc.WaitProcessResponse")
-	proto.RegisterType((*UpdateContainerRequest)(nil), "grpc.UpdateContainerRequest")
-	proto.RegisterType((*StatsContainerRequest)(nil), "grpc.StatsContainerRequest")
-	proto.RegisterType((*PauseContainerRequest)(nil), "grpc.PauseContainerRequest")
-	proto.RegisterType((*ResumeContainerRequest)(nil), "grpc.ResumeContainerRequest")
-	proto.RegisterType((*CpuUsage)(nil), "grpc.CpuUsage")
-	proto.RegisterType((*ThrottlingData)(nil), "grpc.ThrottlingData")
-	proto.RegisterType((*CpuStats)(nil), "grpc.CpuStats")
-	proto.RegisterType((*PidsStats)(nil), "grpc.PidsStats")
-	proto.RegisterType((*MemoryData)(nil), "grpc.MemoryData")
-	proto.RegisterType((*MemoryStats)(nil), "grpc.MemoryStats")
-	proto.RegisterMapType((map[string]uint64)(nil), "grpc.MemoryStats.StatsEntry")
-	proto.RegisterType((*BlkioStatsEntry)(nil), "grpc.BlkioStatsEntry")
-	proto.RegisterType((*BlkioStats)(nil), "grpc.BlkioStats")
-	proto.RegisterType((*HugetlbStats)(nil), "grpc.HugetlbStats")
-	proto.RegisterType((*CgroupStats)(nil), "grpc.CgroupStats")
-	proto.RegisterMapType((map[string]*HugetlbStats)(nil), "grpc.CgroupStats.HugetlbStatsEntry")
-	proto.RegisterType((*NetworkStats)(nil), "grpc.NetworkStats")
-	proto.RegisterType((*StatsContainerResponse)(nil), "grpc.StatsContainerResponse")
-	proto.RegisterType((*WriteStreamRequest)(nil), "grpc.WriteStreamRequest")
-	proto.RegisterType((*WriteStreamResponse)(nil), "grpc.WriteStreamResponse")
-	proto.RegisterType((*ReadStreamRequest)(nil), "grpc.ReadStreamRequest")
-	proto.RegisterType((*ReadStreamResponse)(nil), "grpc.ReadStreamResponse")
-	proto.RegisterType((*CloseStdinRequest)(nil), "grpc.CloseStdinRequest")
-	proto.RegisterType((*TtyWinResizeRequest)(nil), "grpc.TtyWinResizeRequest")
-	proto.RegisterType((*KernelModule)(nil), "grpc.KernelModule")
-	proto.RegisterType((*CreateSandboxRequest)(nil), "grpc.CreateSandboxRequest")
-	proto.RegisterType((*DestroySandboxRequest)(nil), "grpc.DestroySandboxRequest")
-	proto.RegisterType((*RemoveStaleVirtiofsShareMountsRequest)(nil), "grpc.RemoveStaleVirtiofsShareMountsRequest")
-	proto.RegisterType((*Interfaces)(nil), "grpc.Interfaces")
-	proto.RegisterType((*Routes)(nil), "grpc.Routes")
-	proto.RegisterType((*UpdateInterfaceRequest)(nil), "grpc.UpdateInterfaceRequest")
-	proto.RegisterType((*UpdateRoutesRequest)(nil), "grpc.UpdateRoutesRequest")
-	proto.RegisterType((*UpdateEphemeralMountsRequest)(nil), "grpc.UpdateEphemeralMountsRequest")
-	proto.RegisterType((*ListInterfacesRequest)(nil), "grpc.ListInterfacesRequest")
-	proto.RegisterType((*ListRoutesRequest)(nil), "grpc.ListRoutesRequest")
-	proto.RegisterType((*ARPNeighbors)(nil), "grpc.ARPNeighbors")
-	proto.RegisterType((*AddARPNeighborsRequest)(nil), "grpc.AddARPNeighborsRequest")
-	proto.RegisterType((*GetIPTablesRequest)(nil), "grpc.GetIPTablesRequest")
-	proto.RegisterType((*GetIPTablesResponse)(nil), "grpc.GetIPTablesResponse")
-	proto.RegisterType((*SetIPTablesRequest)(nil), "grpc.SetIPTablesRequest")
-	proto.RegisterType((*SetIPTablesResponse)(nil), "grpc.SetIPTablesResponse")
-	proto.RegisterType((*OnlineCPUMemRequest)(nil), "grpc.OnlineCPUMemRequest")
-	proto.RegisterType((*ReseedRandomDevRequest)(nil), "grpc.ReseedRandomDevRequest")
-	proto.RegisterType((*AgentDetails)(nil), "grpc.AgentDetails")
-	proto.RegisterType((*GuestDetailsRequest)(nil), "grpc.GuestDetailsRequest")
-	proto.RegisterType((*GuestDetailsResponse)(nil), "grpc.GuestDetailsResponse")
-	proto.RegisterType((*MemHotplugByProbeRequest)(nil), "grpc.MemHotplugByProbeRequest")
-	proto.RegisterType((*SetGuestDateTimeRequest)(nil), "grpc.SetGuestDateTimeRequest")
-	proto.RegisterType((*FSGroup)(nil), "grpc.FSGroup")
-	proto.RegisterType((*Storage)(nil), "grpc.Storage")
-	proto.RegisterType((*Device)(nil), "grpc.Device")
-	proto.RegisterType((*StringUser)(nil), "grpc.StringUser")
-	proto.RegisterType((*CopyFileRequest)(nil), "grpc.CopyFileRequest")
-	proto.RegisterType((*GetOOMEventRequest)(nil), "grpc.GetOOMEventRequest")
-	proto.RegisterType((*OOMEvent)(nil), "grpc.OOMEvent")
-	proto.RegisterType((*AddSwapRequest)(nil), "grpc.AddSwapRequest")
-	proto.RegisterType((*GetMetricsRequest)(nil), "grpc.GetMetricsRequest")
-	proto.RegisterType((*Metrics)(nil), "grpc.Metrics")
-	proto.RegisterType((*VolumeStatsRequest)(nil), "grpc.VolumeStatsRequest")
-	proto.RegisterType((*ResizeVolumeRequest)(nil), "grpc.ResizeVolumeRequest")
+type ContainerVolumeStats struct {
+	// The volume path in the container
+	Destination string `protobuf:"bytes,1,opt,name=destination,proto3" json:"destination,omitempty"`
+	// The volume path on the guest outside the container
+	VolumeGuestPath string `protobuf:"bytes,2,opt,name=volume_guest_path,json=volumeGuestPath,proto3" json:"volume_guest_path,omitempty"`
+	// The stats of the volume, its condition is abnormal when they could
+	// not be read
+	Stats                *VolumeStatsResponse `protobuf:"bytes,3,opt,name=stats,proto3" json:"stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func init() { proto.RegisterFile("agent.proto", fileDescriptor_56ede974c0020f77) }
-
-var fileDescriptor_56ede974c0020f77 = []byte{
-	// 3275 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x3a, 0x4b, 0x6f, 0x1c, 0x47,
-	0x73, 0xdf, 0x3e, 0xc8, 0xdd, 0xad, 0x7d, 0x71, 0x87, 0x14, 0xb5, 0x5a, 0xeb, 0xa3, 0xe5, 0x91,
-	0x25, 0x51, 0x76, 0x44, 0xda, 0xb2, 0x61, 0xf9, 0x01, 0x47, 0x21, 0x29, 0x9a, 0xa4, 0x6d, 0x5a,
-	0x9b, 0x59, 0xd1, 0x0e, 0x12, 0x24, 0x83, 0xe1, 0x4c, 0x73, 0xb7, 0xcd, 0x9d, 0xe9, 0x71, 0x4f,
-	0x0f, 0x45, 0x3a, 0x40, 0x90, 0x43, 0x90, 0xdc, 0x72, 0xcc, 0x2d, 0x7f, 0x20, 0xc8, 0x3f, 0xc8,
-	0x35, 0x07, 0x23, 0xa7, 0x1c, 0x73, 0x49, 0x10, 0xeb, 0x27, 0xe4, 0x17, 0x04, 0xfd, 0x9a, 0xc7,
-	0xbe, 0x64, 0x10, 0x02, 0xbe, 0xcb, 0x62, 0xba, 0xba, 0xba, 0x5e, 0x5d, 0x5d, 0x5d, 0x55, 0xbd,
-	0x50, 0x77, 0x86, 0x28, 0x60, 0x5b, 0x21, 0x25, 0x8c, 0x18, 0xe5, 0x21, 0x0d, 0xdd, 0x5e, 0x8d,
-	0xb8, 0x58, 0x02, 0x7a, 0x35, 0x37, 0xd2, 0x9f, 0x75, 0x76, 0x15, 0xa2, 0x48, 0x0d, 0xde, 0x1a,
-	0x12, 0x32, 0x1c, 0xa3, 0x6d, 0x31, 0x3a, 0x8d, 0xcf, 0xb6, 0x91, 0x1f, 0xb2, 0x2b, 0x39, 0x69,
-	0xfe, 0x73, 0x11, 0xd6, 0xf7, 0x28, 0x72, 0x18, 0xda, 0x23, 0x01, 0x73, 0x70, 0x80, 0xa8, 0x85,
-	0x7e, 0x8a, 0x51, 0xc4, 0x8c, 0x77, 0xa0, 0xe1, 0x6a, 0x98, 0x8d, 0xbd, 0x6e, 0xe1, 0x4e, 0x61,
-	0xb3, 0x66, 0xd5, 0x13, 0xd8, 0x91, 0x67, 0xdc, 0x84, 0x0a, 0xba, 0x44, 0x2e, 0x9f, 0x2d, 0x8a,
-	0xd9, 0x65, 0x3e, 0x3c, 0xf2, 0x8c, 0x0f, 0xa1, 0x1e, 0x31, 0x8a, 0x83, 0xa1, 0x1d, 0x47, 0x88,
-	0x76, 0x4b, 0x77, 0x0a, 0x9b, 0xf5, 0xc7, 0x2b, 0x5b, 0x5c, 0xe4, 0xad, 0x81, 0x98, 0x38, 0x89,
-	0x10, 0xb5, 0x20, 0x4a, 0xbe, 0x8d, 0xfb, 0x50, 0xf1, 0xd0, 0x05, 0x76, 0x51, 0xd4, 0x2d, 0xdf,
-	0x29, 0x6d, 0xd6, 0x1f, 0x37, 0x24, 0xfa, 0x33, 0x01, 0xb4, 0xf4, 0xa4, 0xf1, 0x10, 0xaa, 0x11,
-	0x23, 0xd4, 0x19, 0xa2, 0xa8, 0xbb, 0x24, 0x10, 0x9b, 0x9a, 0xae, 0x80, 0x5a, 0xc9, 0xb4, 0x71,
-	0x1b, 0x4a, 0xcf, 0xf7, 0x8e, 0xba, 0xcb, 0x82, 0x3b, 0x28, 0xac, 0x10, 0xb9, 0x16, 0x07, 0x1b,
-	0x77, 0xa1, 0x19, 0x39, 0x81, 0x77, 0x4a, 0x2e, 0xed, 0x10, 0x7b, 0x41, 0xd4, 0xad, 0xdc, 0x29,
-	0x6c, 0x56, 0xad, 0x86, 0x02, 0xf6, 0x39, 0xcc, 0xfc, 0x1c, 0x6e, 0x0c, 0x98, 0x43, 0xd9, 0x35,
-	0xac, 0x63, 0x9e, 0xc0, 0xba, 0x85, 0x7c, 0x72, 0x71, 0x2d, 0xd3, 0x76, 0xa1, 0xc2, 0xb0, 0x8f,
-	0x48, 0xcc, 0x84, 0x69, 0x9b, 0x96, 0x1e, 0x9a, 0xff, 0x5a, 0x00, 0x63, 0xff, 0x12, 0xb9, 0x7d,
-	0x4a, 0x5c, 0x14, 0x45, 0x7f, 0xa0, 0xed, 0x7a, 0x00, 0x95, 0x50, 0x0a, 0xd0, 0x2d, 0x0b, 0x74,
-	0xb5, 0x0b, 0x5a, 0x2a, 0x3d, 0x6b, 0xfe, 0x08, 0x6b, 0x03, 0x3c, 0x0c, 0x9c, 0xf1, 0x1b, 0x94,
-	0x77, 0x1d, 0x96, 0x23, 0x41, 0x53, 0x88, 0xda, 0xb4, 0xd4, 0xc8, 0xec, 0x83, 0xf1, 0x83, 0x83,
-	0xd9, 0x9b, 0xe3, 0x64, 0x3e, 0x82, 0xd5, 0x1c, 0xc5, 0x28, 0x24, 0x41, 0x84, 0x84, 0x00, 0xcc,
-	0x61, 0x71, 0x24, 0x88, 0x2d, 0x59, 0x6a, 0x64, 0x12, 0x58, 0x3f, 0x09, 0xbd, 0x6b, 0x9e, 0xa6,
-	0xc7, 0x50, 0xa3, 0x28, 0x22, 0x31, 0xe5, 0x67, 0xa0, 0x28, 0x8c, 0xba, 0x26, 0x8d, 0xfa, 0x2d,
-	0x0e, 0xe2, 0x4b, 0x4b, 0xcf, 0x59, 0x29, 0x9a, 0xf2, 0x4f, 0x16, 0x5d, 0xc7, 0x3f, 0x3f, 0x87,
-	0x1b, 0x7d, 0x27, 0x8e, 0xae, 0x23, 0xab, 0xf9, 0x05, 0xf7, 0xed, 0x28, 0xf6, 0xaf, 0xb5, 0xf8,
-	0x5f, 0x0a, 0x50, 0xdd, 0x0b, 0xe3, 0x93, 0xc8, 0x19, 0x22, 0xe3, 0x6d, 0xa8, 0x33, 0xc2, 0x9c,
-	0xb1, 0x1d, 0xf3, 0xa1, 0x40, 0x2f, 0x5b, 0x20, 0x40, 0x12, 0xe1, 0x1d, 0x68, 0x84, 0x88, 0xba,
-	0x61, 0xac, 0x30, 0x8a, 0x77, 0x4a, 0x9b, 0x65, 0xab, 0x2e, 0x61, 0x12, 0x65, 0x0b, 0x56, 0xc5,
-	0x9c, 0x8d, 0x03, 0xfb, 0x1c, 0xd1, 0x00, 0x8d, 0x7d, 0xe2, 0x21, 0xe1, 0x1c, 0x65, 0xab, 0x23,
-	0xa6, 0x8e, 0x82, 0x6f, 0x92, 0x09, 0xe3, 0x3d, 0xe8, 0x24, 0xf8, 0xdc, 0xe3, 0x05, 0x76, 0x59,
-	0x60, 0xb7, 0x15, 0xf6, 0x89, 0x02, 0x9b, 0x7f, 0x03, 0xad, 0x17, 0x23, 0x4a, 0x18, 0x1b, 0xe3,
-	0x60, 0xf8, 0xcc, 0x61, 0x0e, 0x3f, 0x9a, 0x21, 0xa2, 0x98, 0x78, 0x91, 0x92, 0x56, 0x0f, 0x8d,
-	0xf7, 0xa1, 0xc3, 0x24, 0x2e, 0xf2, 0x6c, 0x8d, 0x53, 0x14, 0x38, 0x2b, 0xc9, 0x44, 0x5f, 0x21,
-	0xdf, 0x83, 0x56, 0x8a, 0xcc, 0x0f, 0xb7, 0x92, 0xb7, 0x99, 0x40, 0x5f, 0x60, 0x1f, 0x99, 0x17,
-	0xc2, 0x56, 0x62, 0x93, 0x8d, 0xf7, 0xa1, 0x96, 0xda, 0xa1, 0x20, 0x3c, 0xa4, 0x25, 0x3d, 0x44,
-	0x9b, 0xd3, 0xaa, 0x26, 0x46, 0xf9, 0x12, 0xda, 0x2c, 0x11, 0xdc, 0xf6, 0x1c, 0xe6, 0xe4, 0x9d,
-	0x2a, 0xaf, 0x95, 0xd5, 0x62, 0xb9, 0xb1, 0xf9, 0x05, 0xd4, 0xfa, 0xd8, 0x8b, 0x24, 0xe3, 0x2e,
-	0x54, 0xdc, 0x98, 0x52, 0x14, 0x30, 0xad, 0xb2, 0x1a, 0x1a, 0x6b, 0xb0, 0x34, 0xc6, 0x3e, 0x66,
-	0x4a, 0x4d, 0x39, 0x30, 0x09, 0xc0, 0x31, 0xf2, 0x09, 0xbd, 0x12, 0x06, 0x5b, 0x83, 0xa5, 0xec,
-	0xe6, 0xca, 0x81, 0xf1, 0x16, 0xd4, 0x7c, 0xe7, 0x32, 0xd9, 0x54, 0x3e, 0x53, 0xf5, 0x9d, 0x4b,
-	0x29, 0x7c, 0x17, 0x2a, 0x67, 0x0e, 0x1e, 0xbb, 0x01, 0x53, 0x56, 0xd1, 0xc3, 0x94, 0x61, 0x39,
-	0xcb, 0xf0, 0xdf, 0x8b, 0x50, 0x97, 0x1c, 0xa5, 0xc0, 0x6b, 0xb0, 0xe4, 0x3a, 0xee, 0x28, 0x61,
-	0x29, 0x06, 0xc6, 0x7d, 0x2d, 0x48, 0x31, 0x1b, 0xe1, 0x52, 0x49, 0xb5, 0x68, 0xdb, 0x00, 0xd1,
-	0x4b, 0x27, 0x54, 0xb2, 0x95, 0xe6, 0x20, 0xd7, 0x38, 0x8e, 0x14, 0xf7, 0x23, 0x68, 0x48, 0xbf,
-	0x53, 0x4b, 0xca, 0x73, 0x96, 0xd4, 0x25, 0x96, 0x5c, 0x74, 0x17, 0x9a, 0x71, 0x84, 0xec, 0x11,
-	0x46, 0xd4, 0xa1, 0xee, 0xe8, 0xaa, 0xbb, 0x24, 0x2f, 0xa0, 0x38, 0x42, 0x87, 0x1a, 0x66, 0x3c,
-	0x86, 0x25, 0x1e, 0x5b, 0xa2, 0xee, 0xb2, 0xb8, 0xeb, 0x6e, 0x67, 0x49, 0x0a, 0x55, 0xb7, 0xc4,
-	0xef, 0x7e, 0xc0, 0xe8, 0x95, 0x25, 0x51, 0x7b, 0x9f, 0x02, 0xa4, 0x40, 0x63, 0x05, 0x4a, 0xe7,
-	0xe8, 0x4a, 0x9d, 0x43, 0xfe, 0xc9, 0x8d, 0x73, 0xe1, 0x8c, 0x63, 0x6d, 0x75, 0x39, 0xf8, 0xbc,
-	0xf8, 0x69, 0xc1, 0x74, 0xa1, 0xbd, 0x3b, 0x3e, 0xc7, 0x24, 0xb3, 0x7c, 0x0d, 0x96, 0x7c, 0xe7,
-	0x47, 0x42, 0xb5, 0x25, 0xc5, 0x40, 0x40, 0x71, 0x40, 0xa8, 0x26, 0x21, 0x06, 0x46, 0x0b, 0x8a,
-	0x24, 0x14, 0xf6, 0xaa, 0x59, 0x45, 0x12, 0xa6, 0x8c, 0xca, 0x19, 0x46, 0xe6, 0xff, 0x94, 0x01,
-	0x52, 0x2e, 0x86, 0x05, 0x3d, 0x4c, 0xec, 0x08, 0x51, 0x7e, 0xbf, 0xdb, 0xa7, 0x57, 0x0c, 0x45,
-	0x36, 0x45, 0x6e, 0x4c, 0x23, 0x7c, 0xc1, 0xf7, 0x8f, 0xab, 0x7d, 0x43, 0xaa, 0x3d, 0x21, 0x9b,
-	0x75, 0x13, 0x93, 0x81, 0x5c, 0xb7, 0xcb, 0x97, 0x59, 0x7a, 0x95, 0x71, 0x04, 0x37, 0x52, 0x9a,
-	0x5e, 0x86, 0x5c, 0x71, 0x11, 0xb9, 0xd5, 0x84, 0x9c, 0x97, 0x92, 0xda, 0x87, 0x55, 0x4c, 0xec,
-	0x9f, 0x62, 0x14, 0xe7, 0x08, 0x95, 0x16, 0x11, 0xea, 0x60, 0xf2, 0xa7, 0x62, 0x41, 0x4a, 0xa6,
-	0x0f, 0xb7, 0x32, 0x5a, 0xf2, 0xe3, 0x9e, 0x21, 0x56, 0x5e, 0x44, 0x6c, 0x3d, 0x91, 0x8a, 0xc7,
-	0x83, 0x94, 0xe2, 0xd7, 0xb0, 0x8e, 0x89, 0xfd, 0xd2, 0xc1, 0x6c, 0x92, 0xdc, 0xd2, 0x6b, 0x94,
-	0xe4, 0x37, 0x5a, 0x9e, 0x96, 0x54, 0xd2, 0x47, 0x74, 0x98, 0x53, 0x72, 0xf9, 0x35, 0x4a, 0x1e,
-	0x8b, 0x05, 0x29, 0x99, 0x1d, 0xe8, 0x60, 0x32, 0x29, 0x4d, 0x65, 0x11, 0x91, 0x36, 0x26, 0x79,
-	0x49, 0x76, 0xa1, 0x13, 0x21, 0x97, 0x11, 0x9a, 0x75, 0x82, 0xea, 0x22, 0x12, 0x2b, 0x0a, 0x3f,
-	0xa1, 0x61, 0xfe, 0x05, 0x34, 0x0e, 0xe3, 0x21, 0x62, 0xe3, 0xd3, 0x24, 0x18, 0xbc, 0xb1, 0xf8,
-	0x63, 0xfe, 0x5f, 0x11, 0xea, 0x7b, 0x43, 0x4a, 0xe2, 0x30, 0x17, 0x93, 0xe5, 0x21, 0x9d, 0x8c,
-	0xc9, 0x02, 0x45, 0xc4, 0x64, 0x89, 0xfc, 0x31, 0x34, 0x7c, 0x71, 0x74, 0x15, 0xbe, 0x8c, 0x43,
-	0x9d, 0xa9, 0x43, 0x6d, 0xd5, 0xfd, 0x4c, 0x30, 0xdb, 0x02, 0x08, 0xb1, 0x17, 0xa9, 0x35, 0x32,
-	0x1c, 0xb5, 0x55, 0xba, 0xa5, 0x43, 0xb4, 0x55, 0x0b, 0x93, 0x68, 0xfd, 0x21, 0xd4, 0x4f, 0xb9,
-	0x91, 0xd4, 0x82, 0x5c, 0x30, 0x4a, 0xad, 0x67, 0xc1, 0x69, 0x7a, 0x08, 0x0f, 0xa1, 0x39, 0x92,
-	0x26, 0x53, 0x8b, 0xa4, 0x0f, 0xdd, 0x55, 0x9a, 0xa4, 0xfa, 0x6e, 0x65, 0x2d, 0x2b, 0x37, 0xa0,
-	0x31, 0xca, 0x80, 0x7a, 0x03, 0xe8, 0x4c, 0xa1, 0xcc, 0x88, 0x41, 0x9b, 0xd9, 0x18, 0x54, 0x7f,
-	0x6c, 0x48, 0x46, 0xd9, 0x95, 0xd9, 0xb8, 0xf4, 0x8f, 0x45, 0x68, 0x7c, 0x87, 0xd8, 0x4b, 0x42,
-	0xcf, 0xa5, 0xbc, 0x06, 0x94, 0x03, 0xc7, 0x47, 0x8a, 0xa2, 0xf8, 0x36, 0x6e, 0x41, 0x95, 0x5e,
-	0xca, 0x00, 0xa2, 0xf6, 0xb3, 0x42, 0x2f, 0x45, 0x60, 0x30, 0x7e, 0x0f, 0x40, 0x2f, 0xed, 0xd0,
-	0x71, 0xcf, 0x91, 0xb2, 0x60, 0xd9, 0xaa, 0xd1, 0xcb, 0xbe, 0x04, 0x70, 0x57, 0xa0, 0x97, 0x36,
-	0xa2, 0x94, 0xd0, 0x48, 0xc5, 0xaa, 0x2a, 0xbd, 0xdc, 0x17, 0x63, 0xb5, 0xd6, 0xa3, 0x24, 0x0c,
-	0x91, 0x27, 0x62, 0xb4, 0x58, 0xfb, 0x4c, 0x02, 0x38, 0x57, 0xa6, 0xb9, 0x2e, 0x4b, 0xae, 0x2c,
-	0xe5, 0xca, 0x52, 0xae, 0x15, 0xb9, 0x92, 0x65, 0xb9, 0xb2, 0x84, 0x6b, 0x55, 0x72, 0x65, 0x19,
-	0xae, 0x2c, 0xe5, 0x5a, 0xd3, 0x6b, 0x15, 0x57, 0xf3, 0x1f, 0x0a, 0xb0, 0x3e, 0x99, 0xf8, 0xa9,
-	0xdc, 0xf4, 0x63, 0x68, 0xb8, 0x62, 0xbf, 0x72, 0x3e, 0xd9, 0x99, 0xda, 0x49, 0xab, 0xee, 0x66,
-	0xdc, 0xf8, 0x09, 0x34, 0x03, 0x69, 0xe0, 0xc4, 0x35, 0x4b, 0xe9, 0xbe, 0x64, 0x6d, 0x6f, 0x35,
-	0x82, 0xcc, 0xc8, 0xf4, 0xc0, 0xf8, 0x81, 0x62, 0x86, 0x06, 0x8c, 0x22, 0xc7, 0x7f, 0x13, 0xd9,
-	0xbd, 0x01, 0x65, 0x91, 0xad, 0xf0, 0x6d, 0x6a, 0x58, 0xe2, 0xdb, 0x7c, 0x00, 0xab, 0x39, 0x2e,
-	0x4a, 0xd7, 0x15, 0x28, 0x8d, 0x51, 0x20, 0xa8, 0x37, 0x2d, 0xfe, 0x69, 0x3a, 0xd0, 0xb1, 0x90,
-	0xe3, 0xbd, 0x39, 0x69, 0x14, 0x8b, 0x52, 0xca, 0x62, 0x13, 0x8c, 0x2c, 0x0b, 0x25, 0x8a, 0x96,
-	0xba, 0x90, 0x91, 0xfa, 0x39, 0x74, 0xf6, 0xc6, 0x24, 0x42, 0x03, 0xe6, 0xe1, 0xe0, 0x4d, 0x94,
-	0x23, 0x7f, 0x0d, 0xab, 0x2f, 0xd8, 0xd5, 0x0f, 0x9c, 0x58, 0x84, 0x7f, 0x46, 0x6f, 0x48, 0x3f,
-	0x4a, 0x5e, 0x6a, 0xfd, 0x28, 0x79, 0xc9, 0x8b, 0x1b, 0x97, 0x8c, 0x63, 0x3f, 0x10, 0x47, 0xa1,
-	0x69, 0xa9, 0x91, 0xb9, 0x0b, 0x0d, 0x99, 0x43, 0x1f, 0x13, 0x2f, 0x1e, 0xa3, 0x99, 0x67, 0x70,
-	0x03, 0x20, 0x74, 0xa8, 0xe3, 0x23, 0x86, 0xa8, 0xf4, 0xa1, 0x9a, 0x95, 0x81, 0x98, 0xff, 0x54,
-	0x84, 0x35, 0xd9, 0x6f, 0x18, 0xc8, 0x32, 0x5b, 0xab, 0xd0, 0x83, 0xea, 0x88, 0x44, 0x2c, 0x43,
-	0x30, 0x19, 0x73, 0x11, 0x79, 0x7d, 0x2e, 0xa9, 0xf1, 0xcf, 0x5c, 0x13, 0xa0, 0xb4, 0xb8, 0x09,
-	0x30, 0x55, 0xe6, 0x97, 0xa7, 0xcb, 0x7c, 0x7e, 0xda, 0x34, 0x12, 0x96, 0x67, 0xbc, 0x66, 0xd5,
-	0x14, 0xe4, 0xc8, 0x33, 0xee, 0x43, 0x7b, 0xc8, 0xa5, 0xb4, 0x47, 0x84, 0x9c, 0xdb, 0xa1, 0xc3,
-	0x46, 0xe2, 0xa8, 0xd7, 0xac, 0xa6, 0x00, 0x1f, 0x12, 0x72, 0xde, 0x77, 0xd8, 0xc8, 0xf8, 0x0c,
-	0x5a, 0x2a, 0x0d, 0xf4, 0x85, 0x89, 0x22, 0x75, 0xf9, 0xa9, 0x53, 0x94, 0xb5, 0x9e, 0xd5, 0x3c,
-	0xcf, 0x8c, 0x22, 0xf3, 0x26, 0xdc, 0x78, 0x86, 0x22, 0x46, 0xc9, 0x55, 0xde, 0x30, 0xe6, 0x03,
-	0xb8, 0x27, 0xbb, 0x08, 0x03, 0xe6, 0x8c, 0xd1, 0xf7, 0x98, 0x32, 0x4c, 0xce, 0xa2, 0xc1, 0xc8,
-	0xa1, 0xe8, 0x98, 0xc4, 0x01, 0xd3, 0x65, 0xae, 0xf9, 0xc7, 0x00, 0x47, 0x01, 0x43, 0xf4, 0xcc,
-	0x71, 0x51, 0x64, 0x7c, 0x90, 0x1d, 0xa9, 0x2c, 0x6a, 0x65, 0x4b, 0xf6, 0x85, 0x92, 0x09, 0x2b,
-	0x83, 0x63, 0x6e, 0xc1, 0xb2, 0x45, 0x62, 0x1e, 0xb7, 0xde, 0xd5, 0x5f, 0x6a, 0x5d, 0x43, 0xad,
-	0x13, 0x40, 0x4b, 0xcd, 0x99, 0x87, 0xba, 0xd6, 0x4d, 0xc9, 0xa9, 0xbd, 0xdc, 0x82, 0x1a, 0xd6,
-	0x30, 0x15, 0x7e, 0xa6, 0x59, 0xa7, 0x28, 0xe6, 0x17, 0xb0, 0x2a, 0x29, 0x49, 0xca, 0x9a, 0xcc,
-	0xbb, 0xb0, 0x4c, 0xb5, 0x18, 0x85, 0xb4, 0x21, 0xa4, 0x90, 0xd4, 0x9c, 0x79, 0x04, 0xb7, 0xe5,
-	0xe2, 0xfd, 0x70, 0x84, 0x7c, 0x44, 0x9d, 0x71, 0xce, 0x2c, 0x39, 0x57, 0x29, 0x2c, 0x74, 0x15,
-	0xbe, 0x07, 0xdf, 0xe2, 0x88, 0xa5, 0x36, 0xd1, 0xa6, 0x5d, 0x85, 0x0e, 0x9f, 0xc8, 0x89, 0x67,
-	0x7e, 0x05, 0x8d, 0x1d, 0xab, 0xff, 0x1d, 0xc2, 0xc3, 0xd1, 0x29, 0x8f, 0xd8, 0x9f, 0xe4, 0xc7,
-	0x8a, 0x99, 0xa1, 0x14, 0xcf, 0x4c, 0x59, 0x39, 0x3c, 0xf3, 0x6b, 0x58, 0xdf, 0xf1, 0xbc, 0x2c,
-	0x48, 0x8b, 0xfe, 0x01, 0xd4, 0x82, 0x0c, 0xb9, 0xcc, 0x3d, 0x99, 0xc3, 0x4e, 0x91, 0xcc, 0x47,
-	0x60, 0x1c, 0x20, 0x76, 0xd4, 0x7f, 0xe1, 0x9c, 0x8e, 0x53, 0x43, 0xde, 0x84, 0x0a, 0x8e, 0x6c,
-	0x1c, 0x5e, 0x7c, 0x22, 0xa8, 0x54, 0xad, 0x65, 0x1c, 0x1d, 0x85, 0x17, 0x9f, 0x98, 0x0f, 0x61,
-	0x35, 0x87, 0xbe, 0x20, 0x94, 0xed, 0x80, 0x31, 0xf8, 0xed, 0x94, 0x13, 0x12, 0xc5, 0x0c, 0x89,
-	0x87, 0xb0, 0x3a, 0xf8, 0x8d, 0xdc, 0xfe, 0x12, 0x56, 0x9f, 0x07, 0x63, 0x1c, 0xa0, 0xbd, 0xfe,
-	0xc9, 0x31, 0x4a, 0xe2, 0xb8, 0x01, 0x65, 0x9e, 0xef, 0x2a, 0x5e, 0xe2, 0x9b, 0x8b, 0x10, 0x9c,
-	0xda, 0x6e, 0x18, 0x47, 0xaa, 0x51, 0xb6, 0x1c, 0x9c, 0xee, 0x85, 0x71, 0xc4, 0x2f, 0x66, 0x9e,
-	0x98, 0x91, 0x60, 0x7c, 0x25, 0xa2, 0x5b, 0xd5, 0xaa, 0xb8, 0x61, 0xfc, 0x3c, 0x18, 0x5f, 0x99,
-	0x7f, 0x24, 0xba, 0x17, 0x08, 0x79, 0x96, 0x13, 0x78, 0xc4, 0x7f, 0x86, 0x2e, 0x32, 0x1c, 0xa6,
-	0xe4, 0xfe, 0xa5, 0x00, 0x8d, 0x9d, 0x21, 0x0a, 0xd8, 0x33, 0xc4, 0x1c, 0x3c, 0x16, 0xd5, 0xf0,
-	0x05, 0xa2, 0x11, 0x26, 0x81, 0x0a, 0x55, 0x7a, 0x68, 0xbc, 0x0d, 0x75, 0x1c, 0x60, 0x66, 0x7b,
-	0x0e, 0xf2, 0x49, 0x20, 0xa8, 0x54, 0x2d, 0xe0, 0xa0, 0x67, 0x02, 0x62, 0x3c, 0x80, 0xb6, 0x6c,
-	0x64, 0xda, 0x23, 0x27, 0xf0, 0xc6, 0x3c, 0x48, 0x96, 0x44, 0x58, 0x6b, 0x49, 0xf0, 0xa1, 0x82,
-	0x1a, 0x0f, 0x61, 0x45, 0xf9, 0x65, 0x8a, 0x59, 0x16, 0x98, 0x6d, 0x05, 0xcf, 0xa1, 0xc6, 0x61,
-	0x48, 0x28, 0x8b, 0xec, 0x08, 0xb9, 0x2e, 0xf1, 0x43, 0x55, 0x4a, 0xb6, 0x35, 0x7c, 0x20, 0xc1,
-	0xe6, 0x10, 0x56, 0x0f, 0xb8, 0x9e, 0x4a, 0x93, 0xf4, 0xa4, 0xb5, 0x7c, 0xe4, 0xdb, 0xa7, 0x63,
-	0xe2, 0x9e, 0xdb, 0xfc, 0x62, 0x51, 0x16, 0xe6, 0xc9, 0xea, 0x2e, 0x07, 0x0e, 0xf0, 0xcf, 0xa2,
-	0x6b, 0xc2, 0xb1, 0x46, 0x84, 0x85, 0xe3, 0x78, 0x68, 0x87, 0x94, 0x9c, 0x22, 0xa5, 0x62, 0xdb,
-	0x47, 0xfe, 0xa1, 0x84, 0xf7, 0x39, 0xd8, 0xfc, 0xb7, 0x02, 0xac, 0xe5, 0x39, 0xa9, 0xdd, 0xde,
-	0x86, 0xb5, 0x3c, 0x2b, 0x95, 0x3a, 0xc9, 0xd4, 0xbc, 0x93, 0x65, 0x28, 0x93, 0xa8, 0x27, 0xd0,
-	0x14, 0x6d, 0x6f, 0xdb, 0x93, 0x94, 0xf2, 0x09, 0x63, 0x76, 0x5f, 0xac, 0x86, 0x93, 0xdd, 0xa5,
-	0xcf, 0xe0, 0x96, 0x52, 0xdf, 0x9e, 0x16, 0x5b, 0x3a, 0xc4, 0xba, 0x42, 0x38, 0x9e, 0x90, 0xfe,
-	0x5b, 0xe8, 0xa6, 0xa0, 0xdd, 0x2b, 0x01, 0x4c, 0x0f, 0xe5, 0xea, 0x84, 0xb2, 0x3b, 0x9e, 0x47,
-	0xc5, 0x69, 0x2f, 0x5b, 0xb3, 0xa6, 0xcc, 0xa7, 0x70, 0x73, 0x80, 0x98, 0xb4, 0x86, 0xc3, 0x54,
-	0x15, 0x27, 0x89, 0xad, 0x40, 0x69, 0x80, 0x5c, 0xa1, 0x7c, 0xc9, 0xe2, 0x9f, 0xdc, 0x01, 0x4f,
-	0x22, 0xe4, 0x0a, 0x2d, 0x4b, 0x96, 0xf8, 0x36, 0x43, 0xa8, 0x7c, 0x35, 0x38, 0xe0, 0xb9, 0x1a,
-	0x77, 0x6a, 0x99, 0xdb, 0xa9, 0x7b, 0xbc, 0x69, 0x55, 0xc4, 0xf8, 0xc8, 0x33, 0xbe, 0x86, 0x55,
-	0x39, 0xe5, 0x8e, 0x9c, 0x60, 0x88, 0xec, 0x90, 0x8c, 0xb1, 0x2b, 0x5d, 0xbf, 0xf5, 0xb8, 0xa7,
-	0xc2, 0x90, 0xa2, 0xb3, 0x27, 0x50, 0xfa, 0x02, 0xc3, 0xea, 0x0c, 0x27, 0x41, 0xe6, 0x7f, 0x17,
-	0xa0, 0xa2, 0xe2, 0x23, 0x4f, 0x07, 0x3c, 0x8a, 0x2f, 0x10, 0x55, 0xce, 0xae, 0x46, 0xc6, 0x3d,
-	0x68, 0xc9, 0x2f, 0x9b, 0x84, 0x0c, 0x93, 0xe4, 0x82, 0x6e, 0x4a, 0xe8, 0x73, 0x09, 0x14, 0xad,
-	0x52, 0xd1, 0xac, 0x54, 0x7d, 0x01, 0x35, 0xe2, 0xf0, 0xb3, 0x88, 0x0b, 0x25, 0x2e, 0xe4, 0x9a,
-	0xa5, 0x46, 0xfc, 0x70, 0x69, 0x7a, 0x4b, 0x82, 0x9e, 0x1e, 0xf2, 0xc3, 0xe5, 0xf3, 0xd0, 0x6e,
-	0x87, 0x04, 0x07, 0x4c, 0xdd, 0xc0, 0x20, 0x40, 0x7d, 0x0e, 0x31, 0x36, 0xa1, 0x7a, 0x16, 0xd9,
-	0x42, 0x1b, 0x91, 0x6d, 0x27, 0xa1, 0x5e, 0x69, 0x6d, 0x55, 0xce, 0x22, 0xf1, 0x61, 0xfe, 0x7d,
-	0x01, 0x96, 0xe5, 0xc3, 0x82, 0xd1, 0x82, 0x62, 0x92, 0x31, 0x15, 0xb1, 0xc8, 0x3e, 0x85, 0x54,
-	0x32, 0x4b, 0x12, 0xdf, 0x3c, 0xc6, 0x5c, 0xf8, 0xf2, 0xde, 0x57, 0x4a, 0x5c, 0xf8, 0xe2, 0xc2,
-	0xbf, 0x07, 0xad, 0x34, 0xf1, 0x12, 0xf3, 0x52, 0x99, 0x66, 0x02, 0x15, 0x68, 0x73, 0x75, 0x32,
-	0xff, 0x0c, 0x20, 0x6d, 0xb0, 0x73, 0x77, 0x88, 0x13, 0x61, 0xf8, 0x27, 0x87, 0x0c, 0x93, 0x94,
-	0x8d, 0x7f, 0x1a, 0xf7, 0xa1, 0xe5, 0x78, 0x1e, 0xe6, 0xcb, 0x9d, 0xf1, 0x01, 0xf6, 0x92, 0x00,
-	0x92, 0x87, 0x9a, 0xff, 0x51, 0x80, 0xf6, 0x1e, 0x09, 0xaf, 0xbe, 0xc2, 0x63, 0x94, 0x89, 0x6e,
-	0x42, 0x48, 0x95, 0xb1, 0xf1, 0x6f, 0x5e, 0x85, 0x9c, 0xe1, 0x31, 0x92, 0xc7, 0x5e, 0x7a, 0x5d,
-	0x95, 0x03, 0xc4, 0x91, 0xd7, 0x93, 0x49, 0x3b, 0xb5, 0x29, 0x27, 0x8f, 0x89, 0x27, 0xea, 0x2d,
-	0x0f, 0x53, 0x3b, 0x69, 0x9e, 0x36, 0xad, 0x8a, 0x87, 0xa9, 0x98, 0x52, 0x8a, 0x2c, 0x89, 0xe6,
-	0x78, 0x56, 0x91, 0x65, 0x09, 0xe1, 0x8a, 0xac, 0xc3, 0x32, 0x39, 0x3b, 0x8b, 0x10, 0x13, 0x7b,
-	0x55, 0xb2, 0xd4, 0x28, 0x09, 0xc1, 0xd5, 0x4c, 0x08, 0x5e, 0x13, 0xf7, 0xda, 0xf3, 0xe7, 0xc7,
-	0xfb, 0x17, 0x28, 0x60, 0xfa, 0x06, 0x7e, 0x04, 0x55, 0x0d, 0xfa, 0x2d, 0x6d, 0xe7, 0xf7, 0xa0,
-	0xb5, 0xe3, 0x79, 0x83, 0x97, 0x4e, 0xa8, 0xed, 0xd1, 0x85, 0x4a, 0x7f, 0xef, 0xa8, 0x2f, 0x4d,
-	0x52, 0xe2, 0x0a, 0xa8, 0x21, 0xbf, 0xf1, 0x0f, 0x10, 0x3b, 0x46, 0x8c, 0x62, 0x37, 0xb9, 0xf1,
-	0xef, 0x42, 0x45, 0x41, 0xf8, 0x4a, 0x5f, 0x7e, 0xea, 0x2b, 0x40, 0x0d, 0xcd, 0x3f, 0x01, 0xe3,
-	0x7b, 0x9e, 0x2f, 0x23, 0x59, 0x2c, 0x29, 0x4e, 0xef, 0x41, 0xe7, 0x42, 0x40, 0x6d, 0x99, 0x48,
-	0x66, 0xb6, 0xa1, 0x2d, 0x27, 0x44, 0x7c, 0x10, 0xbc, 0x4f, 0x60, 0x55, 0xa6, 0xf7, 0x92, 0xce,
-	0x35, 0x48, 0x70, 0x1b, 0x26, 0xfb, 0x59, 0xb6, 0xc4, 0xf7, 0xe3, 0xbf, 0x5b, 0x53, 0xd7, 0x98,
-	0xea, 0x26, 0x19, 0x07, 0xd0, 0x9e, 0x78, 0xfa, 0x33, 0x54, 0x7b, 0x71, 0xf6, 0x8b, 0x60, 0x6f,
-	0x7d, 0x4b, 0x3e, 0x25, 0x6e, 0xe9, 0xa7, 0xc4, 0xad, 0x7d, 0x3f, 0x64, 0x57, 0xc6, 0x3e, 0xb4,
-	0xf2, 0x8f, 0x64, 0xc6, 0x5b, 0x3a, 0xc5, 0x9a, 0xf1, 0x74, 0x36, 0x97, 0xcc, 0x01, 0xb4, 0x27,
-	0xde, 0xcb, 0xb4, 0x3c, 0xb3, 0x9f, 0xd1, 0xe6, 0x12, 0x7a, 0x0a, 0xf5, 0xcc, 0x03, 0x99, 0xd1,
-	0x95, 0x44, 0xa6, 0xdf, 0xcc, 0xe6, 0x12, 0xd8, 0x83, 0x66, 0xee, 0xcd, 0xca, 0xe8, 0x29, 0x7d,
-	0x66, 0x3c, 0x64, 0xcd, 0x25, 0xb2, 0x0b, 0xf5, 0xcc, 0xd3, 0x91, 0x96, 0x62, 0xfa, 0x7d, 0xaa,
-	0x77, 0x6b, 0xc6, 0x8c, 0xba, 0x2d, 0x0f, 0xa0, 0x3d, 0xf1, 0x9e, 0xa4, 0x4d, 0x32, 0xfb, 0x99,
-	0x69, 0xae, 0x30, 0x03, 0xb8, 0x31, 0x33, 0x4b, 0x36, 0xcc, 0x2c, 0xb9, 0xd9, 0x29, 0xf4, 0x5c,
-	0xa2, 0xdf, 0x88, 0x7d, 0xcf, 0xf4, 0x20, 0x32, 0xfb, 0x3e, 0xfd, 0x24, 0xd5, 0xbb, 0x3d, 0x7b,
-	0x52, 0xa9, 0xba, 0x0f, 0xad, 0xfc, 0x6b, 0x94, 0x26, 0x36, 0xf3, 0x8d, 0x6a, 0xb1, 0x13, 0xe5,
-	0x1e, 0xa6, 0x52, 0x27, 0x9a, 0xf5, 0x5e, 0x35, 0x97, 0x10, 0x82, 0x8d, 0xc5, 0x75, 0x97, 0xf1,
-	0x7e, 0xd6, 0x39, 0x5f, 0x53, 0x9d, 0xcd, 0x65, 0xb3, 0x03, 0xa0, 0x1a, 0x1b, 0x1e, 0x0e, 0x12,
-	0x27, 0x99, 0x6a, 0xa8, 0x24, 0x4e, 0x32, 0xa3, 0x09, 0xf2, 0x14, 0x40, 0xf6, 0x23, 0x3c, 0x12,
-	0x33, 0xe3, 0xa6, 0x96, 0x6a, 0xa2, 0x09, 0xd2, 0xeb, 0x4e, 0x4f, 0x4c, 0x11, 0x40, 0x94, 0x5e,
-	0x87, 0xc0, 0x97, 0x00, 0x69, 0x9f, 0x43, 0x13, 0x98, 0xea, 0x7c, 0x2c, 0xb0, 0x41, 0x23, 0xdb,
-	0xd5, 0x30, 0x94, 0xae, 0x33, 0x3a, 0x1d, 0x0b, 0x48, 0xb4, 0x27, 0x8a, 0xd1, 0xfc, 0x41, 0x99,
-	0xac, 0x51, 0x7b, 0x53, 0x05, 0xa9, 0xf1, 0x04, 0x1a, 0xd9, 0x2a, 0x54, 0x4b, 0x31, 0xa3, 0x32,
-	0xed, 0xe5, 0x2a, 0x51, 0xe3, 0x29, 0xb4, 0xf2, 0x65, 0xa3, 0xf6, 0xdc, 0x99, 0xc5, 0x64, 0x4f,
-	0x35, 0x62, 0x33, 0xe8, 0x1f, 0x01, 0xa4, 0xe5, 0xa5, 0x36, 0xdf, 0x54, 0xc1, 0x39, 0xc1, 0xf5,
-	0x00, 0xda, 0x13, 0x65, 0xa3, 0xd6, 0x78, 0x76, 0x35, 0xb9, 0x28, 0x4e, 0x65, 0x8a, 0x40, 0xed,
-	0x82, 0xd3, 0x65, 0xa4, 0x76, 0xc1, 0x59, 0x15, 0xe3, 0x2e, 0xd4, 0x07, 0xd3, 0x34, 0x06, 0x73,
-	0x69, 0xcc, 0xaa, 0x03, 0x3f, 0x06, 0x48, 0xaf, 0x5c, 0x6d, 0x85, 0xa9, 0x4b, 0xb8, 0xd7, 0xd4,
-	0xcd, 0x72, 0x89, 0xb7, 0x07, 0xcd, 0x5c, 0x3f, 0x49, 0x87, 0xea, 0x59, 0x4d, 0xa6, 0x45, 0x17,
-	0x58, 0xbe, 0xf9, 0xa2, 0x77, 0x70, 0x66, 0x4b, 0x66, 0x91, 0x1f, 0x67, 0xab, 0x56, 0xed, 0x41,
-	0x33, 0x2a, 0xd9, 0xd7, 0x84, 0xaf, 0x6c, 0x65, 0x9a, 0x09, 0x5f, 0x33, 0x0a, 0xd6, 0xb9, 0x84,
-	0x0e, 0xa1, 0x7d, 0xa0, 0x8b, 0x0e, 0x55, 0x10, 0xe9, 0xfd, 0x9b, 0x2e, 0x00, 0x7b, 0xbd, 0x59,
-	0x53, 0x6a, 0x5f, 0xbe, 0x81, 0xce, 0x54, 0x31, 0x64, 0x6c, 0x24, 0x4f, 0x16, 0x33, 0xab, 0xa4,
-	0xb9, 0x62, 0x1d, 0xc1, 0xca, 0x64, 0x2d, 0x64, 0xfc, 0x3e, 0xf1, 0x89, 0x59, 0x35, 0xd2, 0x5c,
-	0x52, 0x9f, 0x41, 0x55, 0xe7, 0xb7, 0x86, 0x7a, 0x1a, 0x9a, 0xc8, 0x77, 0xe7, 0x2e, 0x7d, 0x22,
-	0x5c, 0x3e, 0xc9, 0x1d, 0x53, 0x97, 0x9f, 0xc8, 0x30, 0x7b, 0xea, 0x25, 0x27, 0xc1, 0x7c, 0x02,
-	0x15, 0x95, 0x42, 0x1a, 0x6b, 0xc9, 0x61, 0xcb, 0x64, 0x94, 0x8b, 0x3c, 0xec, 0x00, 0xb1, 0x4c,
-	0x62, 0xa8, 0x99, 0x4e, 0xe7, 0x8a, 0xfa, 0x8c, 0xe4, 0x66, 0xd4, 0x5e, 0xec, 0x40, 0x23, 0x9b,
-	0x1a, 0xea, 0x2d, 0x9d, 0x91, 0x2e, 0xce, 0x93, 0x64, 0xf7, 0xf2, 0x97, 0x5f, 0x37, 0x7e, 0xf7,
-	0x5f, 0xbf, 0x6e, 0xfc, 0xee, 0x6f, 0x5f, 0x6d, 0x14, 0x7e, 0x79, 0xb5, 0x51, 0xf8, 0xcf, 0x57,
-	0x1b, 0x85, 0xff, 0x7d, 0xb5, 0x51, 0xf8, 0xf3, 0xbf, 0x1a, 0x62, 0x36, 0x8a, 0x4f, 0xb7, 0x5c,
-	0xe2, 0x6f, 0x9f, 0x3b, 0xcc, 0x79, 0x94, 0x24, 0xcf, 0xd1, 0xd4, 0x38, 0xa2, 0xee, 0x36, 0x8d,
-	0x03, 0x86, 0x7d, 0xb4, 0x7d, 0x81, 0x29, 0xcb, 0x4c, 0x85, 0xe7, 0xc3, 0x6d, 0x51, 0x88, 0xcb,
-	0x7f, 0x9c, 0xb9, 0x64, 0x1c, 0x6d, 0x73, 0x29, 0x4f, 0x97, 0xc5, 0xf8, 0xa3, 0xff, 0x0f, 0x00,
-	0x00, 0xff, 0xff, 0x76, 0x47, 0x9c, 0x5b, 0xc7, 0x26, 0x00, 0x00,
+func (m *ContainerVolumeStats) Reset()      { *m = ContainerVolumeStats{} }
+func (*ContainerVolumeStats) ProtoMessage() {}
+func (*ContainerVolumeStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{73}
 }
-
-func (m *CreateContainerRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ContainerVolumeStats) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ContainerVolumeStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ContainerVolumeStats.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (m *ContainerVolumeStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContainerVolumeStats.Merge(m, src)
+}
+func (m *ContainerVolumeStats) XXX_Size() int {
+	return m.Size()
+}
+func (m *ContainerVolumeStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContainerVolumeStats.DiscardUnknown(m)
 }
 
-func (m *CreateContainerRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_ContainerVolumeStats proto.InternalMessageInfo
+
+type ContainerVolumeStatsResponse struct {
+	// The directories bind mounted in the container
+	Volumes              []*ContainerVolumeStats `protobuf:"bytes,1,rep,name=volumes,proto3" json:"volumes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
 }
 
-func (m *CreateContainerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.SandboxPidns {
-		i--
-		if m.SandboxPidns {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x38
-	}
-	if m.OCI != nil {
-		{
-			size, err := m.OCI.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintAgent(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x32
-	}
-	if len(m.Storages) > 0 {
-		for iNdEx := len(m.Storages) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Storages[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintAgent(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x2a
-		}
-	}
-	if len(m.Devices) > 0 {
-		for iNdEx := len(m.Devices) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Devices[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintAgent(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x22
-		}
-	}
-	if m.StringUser != nil {
-		{
-			size, err := m.StringUser.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintAgent(dAtA, i, uint64(size))
+func (m *ContainerVolumeStatsResponse) Reset()      { *m = ContainerVolumeStatsResponse{} }
+func (*ContainerVolumeStatsResponse) ProtoMessage() {}
+func (*ContainerVolumeStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{74}
+}
+func (m *ContainerVolumeStatsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ContainerVolumeStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ContainerVolumeStatsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.ExecId) > 0 {
-		i -= len(m.ExecId)
-		copy(dAtA[i:], m.ExecId)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.ExecId)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.ContainerId) > 0 {
-		i -= len(m.ContainerId)
-		copy(dAtA[i:], m.ContainerId)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-
-func (m *StartContainerRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *ContainerVolumeStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContainerVolumeStatsResponse.Merge(m, src)
 }
-
-func (m *StartContainerRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ContainerVolumeStatsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ContainerVolumeStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContainerVolumeStatsResponse.DiscardUnknown(m)
 }
 
-func (m *StartContainerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.ContainerId) > 0 {
-		i -= len(m.ContainerId)
-		copy(dAtA[i:], m.ContainerId)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+var xxx_messageInfo_ContainerVolumeStatsResponse proto.InternalMessageInfo
+
+type ResizeVolumeRequest struct {
+	// Full VM guest path of the volume (outside the container)
+	VolumeGuestPath      string   `protobuf:"bytes,1,opt,name=volume_guest_path,json=volumeGuestPath,proto3" json:"volume_guest_path,omitempty"`
+	Size_                uint64   `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RemoveContainerRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ResizeVolumeRequest) Reset()      { *m = ResizeVolumeRequest{} }
+func (*ResizeVolumeRequest) ProtoMessage() {}
+func (*ResizeVolumeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{75}
+}
+func (m *ResizeVolumeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResizeVolumeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResizeVolumeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (m *ResizeVolumeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResizeVolumeRequest.Merge(m, src)
+}
+func (m *ResizeVolumeRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResizeVolumeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResizeVolumeRequest.DiscardUnknown(m)
 }
 
-func (m *RemoveContainerRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_ResizeVolumeRequest proto.InternalMessageInfo
+
+type WaitDeviceRequest struct {
+	// Type of the device, as in Device.type: "blk", "blk-ccw", "scsi" or
+	// "vfio-pci"
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// Address of the device in the guest: PCI path, ccw address or SCSI
+	// address
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// Timeout in milliseconds, the hotplug timeout of the agent when 0
+	Timeout              uint32   `protobuf:"varint,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RemoveContainerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Timeout != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.Timeout))
-		i--
-		dAtA[i] = 0x10
-	}
-	if len(m.ContainerId) > 0 {
-		i -= len(m.ContainerId)
-		copy(dAtA[i:], m.ContainerId)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+func (m *WaitDeviceRequest) Reset()      { *m = WaitDeviceRequest{} }
+func (*WaitDeviceRequest) ProtoMessage() {}
+func (*WaitDeviceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{76}
 }
-
-func (m *ExecProcessRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *WaitDeviceRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WaitDeviceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WaitDeviceRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (m *WaitDeviceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WaitDeviceRequest.Merge(m, src)
+}
+func (m *WaitDeviceRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *WaitDeviceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WaitDeviceRequest.DiscardUnknown(m)
 }
 
-func (m *ExecProcessRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_WaitDeviceRequest proto.InternalMessageInfo
+
+type SyncFsRequest struct {
+	// Container whose storages and rootfs are synced, all the
+	// filesystems of the guest are when empty
+	ContainerId string `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	// Timeout in milliseconds, no timeout when 0
+	Timeout              uint32   `protobuf:"varint,2,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ExecProcessRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Process != nil {
-		{
-			size, err := m.Process.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintAgent(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x22
-	}
-	if m.StringUser != nil {
-		{
-			size, err := m.StringUser.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintAgent(dAtA, i, uint64(size))
+func (m *SyncFsRequest) Reset()      { *m = SyncFsRequest{} }
+func (*SyncFsRequest) ProtoMessage() {}
+func (*SyncFsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{77}
+}
+func (m *SyncFsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SyncFsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SyncFsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.ExecId) > 0 {
-		i -= len(m.ExecId)
-		copy(dAtA[i:], m.ExecId)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.ExecId)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.ContainerId) > 0 {
-		i -= len(m.ContainerId)
-		copy(dAtA[i:], m.ContainerId)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-
-func (m *SignalProcessRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *SyncFsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncFsRequest.Merge(m, src)
 }
-
-func (m *SignalProcessRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *SyncFsRequest) XXX_Size() int {
+	return m.Size()
 }
-
-func (m *SignalProcessRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Signal != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.Signal))
-		i--
-		dAtA[i] = 0x18
-	}
-	if len(m.ExecId) > 0 {
-		i -= len(m.ExecId)
-		copy(dAtA[i:], m.ExecId)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.ExecId)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.ContainerId) > 0 {
-		i -= len(m.ContainerId)
-		copy(dAtA[i:], m.ContainerId)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+func (m *SyncFsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncFsRequest.DiscardUnknown(m)
 }
 
-func (m *WaitProcessRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
+var xxx_messageInfo_SyncFsRequest proto.InternalMessageInfo
 
-func (m *WaitProcessRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type FreezeFsRequest struct {
+	// Container whose storages and rootfs are frozen, those of all the
+	// containers are when empty
+	ContainerId          string   `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *WaitProcessRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.ExecId) > 0 {
-		i -= len(m.ExecId)
-		copy(dAtA[i:], m.ExecId)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.ExecId)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.ContainerId) > 0 {
-		i -= len(m.ContainerId)
-		copy(dAtA[i:], m.ContainerId)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+func (m *FreezeFsRequest) Reset()      { *m = FreezeFsRequest{} }
+func (*FreezeFsRequest) ProtoMessage() {}
+func (*FreezeFsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{78}
 }
-
-func (m *WaitProcessResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *FreezeFsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FreezeFsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FreezeFsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
 }
-
-func (m *WaitProcessResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *FreezeFsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FreezeFsRequest.Merge(m, src)
+}
+func (m *FreezeFsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *FreezeFsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FreezeFsRequest.DiscardUnknown(m)
 }
 
-func (m *WaitProcessResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Status != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.Status))
-		i--
-		dAtA[i] = 0x8
-	}
-	return len(dAtA) - i, nil
+var xxx_messageInfo_FreezeFsRequest proto.InternalMessageInfo
+
+type ThawFsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *UpdateContainerRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ThawFsRequest) Reset()      { *m = ThawFsRequest{} }
+func (*ThawFsRequest) ProtoMessage() {}
+func (*ThawFsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{79}
+}
+func (m *ThawFsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ThawFsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ThawFsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
 }
-
-func (m *UpdateContainerRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ThawFsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThawFsRequest.Merge(m, src)
+}
+func (m *ThawFsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ThawFsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThawFsRequest.DiscardUnknown(m)
 }
 
-func (m *UpdateContainerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Resources != nil {
-		{
-			size, err := m.Resources.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintAgent(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.ContainerId) > 0 {
-		i -= len(m.ContainerId)
-		copy(dAtA[i:], m.ContainerId)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
-}
+var xxx_messageInfo_ThawFsRequest proto.InternalMessageInfo
 
-func (m *StatsContainerRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type SetNameResolutionRequest struct {
+	// Contents of the /etc/hosts file of the sandbox, left as is when empty
+	Hosts []byte `protobuf:"bytes,1,opt,name=hosts,proto3" json:"hosts,omitempty"`
+	// Contents of the /etc/resolv.conf file of the sandbox, left as is
+	// when empty
+	ResolvConf           []byte   `protobuf:"bytes,2,opt,name=resolv_conf,json=resolvConf,proto3" json:"resolv_conf,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *StatsContainerRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *SetNameResolutionRequest) Reset()      { *m = SetNameResolutionRequest{} }
+func (*SetNameResolutionRequest) ProtoMessage() {}
+func (*SetNameResolutionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{80}
 }
-
-func (m *StatsContainerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.ContainerId) > 0 {
-		i -= len(m.ContainerId)
-		copy(dAtA[i:], m.ContainerId)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+func (m *SetNameResolutionRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func (m *PauseContainerRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *SetNameResolutionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SetNameResolutionRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
 }
-
-func (m *PauseContainerRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *SetNameResolutionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetNameResolutionRequest.Merge(m, src)
+}
+func (m *SetNameResolutionRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SetNameResolutionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetNameResolutionRequest.DiscardUnknown(m)
 }
 
-func (m *PauseContainerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.ContainerId) > 0 {
-		i -= len(m.ContainerId)
-		copy(dAtA[i:], m.ContainerId)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+var xxx_messageInfo_SetNameResolutionRequest proto.InternalMessageInfo
+
+type GetGuestServicesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ResumeContainerRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *GetGuestServicesRequest) Reset()      { *m = GetGuestServicesRequest{} }
+func (*GetGuestServicesRequest) ProtoMessage() {}
+func (*GetGuestServicesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{81}
+}
+func (m *GetGuestServicesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetGuestServicesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetGuestServicesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
 }
-
-func (m *ResumeContainerRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *GetGuestServicesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGuestServicesRequest.Merge(m, src)
+}
+func (m *GetGuestServicesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetGuestServicesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGuestServicesRequest.DiscardUnknown(m)
 }
 
-func (m *ResumeContainerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.ContainerId) > 0 {
-		i -= len(m.ContainerId)
-		copy(dAtA[i:], m.ContainerId)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+var xxx_messageInfo_GetGuestServicesRequest proto.InternalMessageInfo
+
+type GuestService struct {
+	// Systemd unit of the service
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Active state of the unit, as reported by systemctl is-active
+	State string `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	// Number of times the agent restarted the unit after it stopped
+	Restarts             uint32   `protobuf:"varint,3,opt,name=restarts,proto3" json:"restarts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CpuUsage) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *GuestService) Reset()      { *m = GuestService{} }
+func (*GuestService) ProtoMessage() {}
+func (*GuestService) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{82}
+}
+func (m *GuestService) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GuestService) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GuestService.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (m *GuestService) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GuestService.Merge(m, src)
+}
+func (m *GuestService) XXX_Size() int {
+	return m.Size()
+}
+func (m *GuestService) XXX_DiscardUnknown() {
+	xxx_messageInfo_GuestService.DiscardUnknown(m)
 }
 
-func (m *CpuUsage) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_GuestService proto.InternalMessageInfo
+
+type GuestServices struct {
+	Services             []*GuestService `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *CpuUsage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.UsageInUsermode != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.UsageInUsermode))
-		i--
-		dAtA[i] = 0x20
-	}
-	if m.UsageInKernelmode != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.UsageInKernelmode))
-		i--
-		dAtA[i] = 0x18
-	}
-	if len(m.PercpuUsage) > 0 {
-		dAtA7 := make([]byte, len(m.PercpuUsage)*10)
-		var j6 int
-		for _, num := range m.PercpuUsage {
-			for num >= 1<<7 {
-				dAtA7[j6] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j6++
-			}
-			dAtA7[j6] = uint8(num)
-			j6++
+func (m *GuestServices) Reset()      { *m = GuestServices{} }
+func (*GuestServices) ProtoMessage() {}
+func (*GuestServices) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{83}
+}
+func (m *GuestServices) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GuestServices) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GuestServices.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i -= j6
-		copy(dAtA[i:], dAtA7[:j6])
-		i = encodeVarintAgent(dAtA, i, uint64(j6))
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.TotalUsage != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.TotalUsage))
-		i--
-		dAtA[i] = 0x8
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-
-func (m *ThrottlingData) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *GuestServices) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GuestServices.Merge(m, src)
+}
+func (m *GuestServices) XXX_Size() int {
+	return m.Size()
+}
+func (m *GuestServices) XXX_DiscardUnknown() {
+	xxx_messageInfo_GuestServices.DiscardUnknown(m)
 }
 
-func (m *ThrottlingData) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_GuestServices proto.InternalMessageInfo
+
+type NetworkPolicyRule struct {
+	// Direction of the traffic allowed by the rule, "ingress" or "egress"
+	Direction string `protobuf:"bytes,1,opt,name=direction,proto3" json:"direction,omitempty"`
+	// Network of the peers, in CIDR notation
+	Cidr string `protobuf:"bytes,2,opt,name=cidr,proto3" json:"cidr,omitempty"`
+	// Protocol of the traffic, "tcp", "udp" or "sctp", any when empty
+	Protocol string `protobuf:"bytes,3,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	// Port range of the pod (ingress) or of the peers (egress), any port
+	// when port is 0, a single one when end_port is 0
+	Port                 uint32   `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
+	EndPort              uint32   `protobuf:"varint,5,opt,name=end_port,json=endPort,proto3" json:"end_port,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ThrottlingData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (m *NetworkPolicyRule) Reset()      { *m = NetworkPolicyRule{} }
+func (*NetworkPolicyRule) ProtoMessage() {}
+func (*NetworkPolicyRule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{84}
+}
+func (m *NetworkPolicyRule) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NetworkPolicyRule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_NetworkPolicyRule.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.ThrottledTime != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.ThrottledTime))
-		i--
-		dAtA[i] = 0x18
+}
+func (m *NetworkPolicyRule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetworkPolicyRule.Merge(m, src)
+}
+func (m *NetworkPolicyRule) XXX_Size() int {
+	return m.Size()
+}
+func (m *NetworkPolicyRule) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetworkPolicyRule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NetworkPolicyRule proto.InternalMessageInfo
+
+type SetNetworkPolicyRequest struct {
+	// eBPF object of the filter, attached to the tc hooks of the network
+	// interfaces of the guest, the filter is removed when empty
+	Program []byte `protobuf:"bytes,1,opt,name=program,proto3" json:"program,omitempty"`
+	// Drop the ingress traffic the rules do not allow
+	Ingress bool `protobuf:"varint,2,opt,name=ingress,proto3" json:"ingress,omitempty"`
+	// Drop the egress traffic the rules do not allow
+	Egress               bool                 `protobuf:"varint,3,opt,name=egress,proto3" json:"egress,omitempty"`
+	Rules                []*NetworkPolicyRule `protobuf:"bytes,4,rep,name=rules,proto3" json:"rules,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *SetNetworkPolicyRequest) Reset()      { *m = SetNetworkPolicyRequest{} }
+func (*SetNetworkPolicyRequest) ProtoMessage() {}
+func (*SetNetworkPolicyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{85}
+}
+func (m *SetNetworkPolicyRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SetNetworkPolicyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SetNetworkPolicyRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.ThrottledPeriods != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.ThrottledPeriods))
-		i--
-		dAtA[i] = 0x10
+}
+func (m *SetNetworkPolicyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetNetworkPolicyRequest.Merge(m, src)
+}
+func (m *SetNetworkPolicyRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SetNetworkPolicyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetNetworkPolicyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetNetworkPolicyRequest proto.InternalMessageInfo
+
+type GetSeccompNotificationRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSeccompNotificationRequest) Reset()      { *m = GetSeccompNotificationRequest{} }
+func (*GetSeccompNotificationRequest) ProtoMessage() {}
+func (*GetSeccompNotificationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{86}
+}
+func (m *GetSeccompNotificationRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetSeccompNotificationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetSeccompNotificationRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.Periods != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.Periods))
-		i--
-		dAtA[i] = 0x8
+}
+func (m *GetSeccompNotificationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSeccompNotificationRequest.Merge(m, src)
+}
+func (m *GetSeccompNotificationRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetSeccompNotificationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSeccompNotificationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSeccompNotificationRequest proto.InternalMessageInfo
+
+// A system call of a container process trapped by a SCMP_ACT_NOTIFY rule of
+// its seccomp profile, the process is blocked until the notification is
+// responded to.
+type SeccompNotification struct {
+	// ID of the notification for the agent
+	Id          uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ContainerId string `protobuf:"bytes,2,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	// Process of the container, empty for the init process
+	ExecId string `protobuf:"bytes,3,opt,name=exec_id,json=execId,proto3" json:"exec_id,omitempty"`
+	// PID of the thread in the PID namespace of the agent
+	Pid     uint32 `protobuf:"varint,4,opt,name=pid,proto3" json:"pid,omitempty"`
+	Syscall string `protobuf:"bytes,5,opt,name=syscall,proto3" json:"syscall,omitempty"`
+	// Architecture of the system call, e.g. "x86_64"
+	Arch                 string   `protobuf:"bytes,6,opt,name=arch,proto3" json:"arch,omitempty"`
+	Args                 []uint64 `protobuf:"varint,7,rep,packed,name=args,proto3" json:"args,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SeccompNotification) Reset()      { *m = SeccompNotification{} }
+func (*SeccompNotification) ProtoMessage() {}
+func (*SeccompNotification) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{87}
+}
+func (m *SeccompNotification) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SeccompNotification) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SeccompNotification.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *SeccompNotification) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SeccompNotification.Merge(m, src)
+}
+func (m *SeccompNotification) XXX_Size() int {
+	return m.Size()
+}
+func (m *SeccompNotification) XXX_DiscardUnknown() {
+	xxx_messageInfo_SeccompNotification.DiscardUnknown(m)
 }
 
-func (m *CpuStats) Marshal() (dAtA []byte, err error) {
+var xxx_messageInfo_SeccompNotification proto.InternalMessageInfo
+
+type SeccompNotificationResponse struct {
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Let the kernel run the system call, the error and value are ignored
+	Allow bool `protobuf:"varint,2,opt,name=allow,proto3" json:"allow,omitempty"`
+	// Fail the system call with this errno, return the value when 0
+	Error                int32    `protobuf:"varint,3,opt,name=error,proto3" json:"error,omitempty"`
+	Value                int64    `protobuf:"varint,4,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SeccompNotificationResponse) Reset()      { *m = SeccompNotificationResponse{} }
+func (*SeccompNotificationResponse) ProtoMessage() {}
+func (*SeccompNotificationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{88}
+}
+func (m *SeccompNotificationResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SeccompNotificationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SeccompNotificationResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SeccompNotificationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SeccompNotificationResponse.Merge(m, src)
+}
+func (m *SeccompNotificationResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SeccompNotificationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SeccompNotificationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SeccompNotificationResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*CreateContainerRequest)(nil), "grpc.CreateContainerRequest")
+	proto.RegisterType((*StartContainerRequest)(nil), "grpc.StartContainerRequest")
+	proto.RegisterType((*RemoveContainerRequest)(nil), "grpc.RemoveContainerRequest")
+	proto.RegisterType((*ExecProcessRequest)(nil), "grpc.ExecProcessRequest")
+	proto.RegisterType((*SignalProcessRequest)(nil), "grpc.SignalProcessRequest")
+	proto.RegisterType((*WaitProcessRequest)(nil), "grpc.WaitProcessRequest")
+	proto.RegisterType((*WaitProcessResponse)(nil), "grpc.WaitProcessResponse")
+	proto.RegisterType((*UpdateContainerRequest)(nil), "grpc.UpdateContainerRequest")
+	proto.RegisterType((*StatsContainerRequest)(nil), "grpc.StatsContainerRequest")
+	proto.RegisterType((*PauseContainerRequest)(nil), "grpc.PauseContainerRequest")
+	proto.RegisterType((*ResumeContainerRequest)(nil), "grpc.ResumeContainerRequest")
+	proto.RegisterType((*CpuUsage)(nil), "grpc.CpuUsage")
+	proto.RegisterType((*ThrottlingData)(nil), "grpc.ThrottlingData")
+	proto.RegisterType((*CpuStats)(nil), "grpc.CpuStats")
+	proto.RegisterType((*PidsStats)(nil), "grpc.PidsStats")
+	proto.RegisterType((*MemoryData)(nil), "grpc.MemoryData")
+	proto.RegisterType((*MemoryStats)(nil), "grpc.MemoryStats")
+	proto.RegisterMapType((map[string]uint64)(nil), "grpc.MemoryStats.StatsEntry")
+	proto.RegisterType((*BlkioStatsEntry)(nil), "grpc.BlkioStatsEntry")
+	proto.RegisterType((*BlkioStats)(nil), "grpc.BlkioStats")
+	proto.RegisterType((*HugetlbStats)(nil), "grpc.HugetlbStats")
+	proto.RegisterType((*CgroupStats)(nil), "grpc.CgroupStats")
+	proto.RegisterMapType((map[string]*HugetlbStats)(nil), "grpc.CgroupStats.HugetlbStatsEntry")
+	proto.RegisterType((*NetworkStats)(nil), "grpc.NetworkStats")
+	proto.RegisterType((*StatsContainerResponse)(nil), "grpc.StatsContainerResponse")
+	proto.RegisterType((*WriteStreamRequest)(nil), "grpc.WriteStreamRequest")
+	proto.RegisterType((*WriteStreamResponse)(nil), "grpc.WriteStreamResponse")
+	proto.RegisterType((*ReadStreamRequest)(nil), "grpc.ReadStreamRequest")
+	proto.RegisterType((*ReadStreamResponse)(nil), "grpc.ReadStreamResponse")
+	proto.RegisterType((*CloseStdinRequest)(nil), "grpc.CloseStdinRequest")
+	proto.RegisterType((*TtyWinResizeRequest)(nil), "grpc.TtyWinResizeRequest")
+	proto.RegisterType((*KernelModule)(nil), "grpc.KernelModule")
+	proto.RegisterType((*CreateSandboxRequest)(nil), "grpc.CreateSandboxRequest")
+	proto.RegisterType((*DestroySandboxRequest)(nil), "grpc.DestroySandboxRequest")
+	proto.RegisterType((*RemoveStaleVirtiofsShareMountsRequest)(nil), "grpc.RemoveStaleVirtiofsShareMountsRequest")
+	proto.RegisterType((*Interfaces)(nil), "grpc.Interfaces")
+	proto.RegisterType((*Routes)(nil), "grpc.Routes")
+	proto.RegisterType((*UpdateInterfaceRequest)(nil), "grpc.UpdateInterfaceRequest")
+	proto.RegisterType((*UpdateRoutesRequest)(nil), "grpc.UpdateRoutesRequest")
+	proto.RegisterType((*UpdateEphemeralMountsRequest)(nil), "grpc.UpdateEphemeralMountsRequest")
+	proto.RegisterType((*ListInterfacesRequest)(nil), "grpc.ListInterfacesRequest")
+	proto.RegisterType((*ListRoutesRequest)(nil), "grpc.ListRoutesRequest")
+	proto.RegisterType((*ARPNeighbors)(nil), "grpc.ARPNeighbors")
+	proto.RegisterType((*AddARPNeighborsRequest)(nil), "grpc.AddARPNeighborsRequest")
+	proto.RegisterType((*GetIPTablesRequest)(nil), "grpc.GetIPTablesRequest")
+	proto.RegisterType((*GetIPTablesResponse)(nil), "grpc.GetIPTablesResponse")
+	proto.RegisterType((*SetIPTablesRequest)(nil), "grpc.SetIPTablesRequest")
+	proto.RegisterType((*SetIPTablesResponse)(nil), "grpc.SetIPTablesResponse")
+	proto.RegisterType((*OnlineCPUMemRequest)(nil), "grpc.OnlineCPUMemRequest")
+	proto.RegisterType((*ReseedRandomDevRequest)(nil), "grpc.ReseedRandomDevRequest")
+	proto.RegisterType((*AgentDetails)(nil), "grpc.AgentDetails")
+	proto.RegisterType((*GuestDetailsRequest)(nil), "grpc.GuestDetailsRequest")
+	proto.RegisterType((*GuestDetailsResponse)(nil), "grpc.GuestDetailsResponse")
+	proto.RegisterType((*MemHotplugByProbeRequest)(nil), "grpc.MemHotplugByProbeRequest")
+	proto.RegisterType((*SetGuestDateTimeRequest)(nil), "grpc.SetGuestDateTimeRequest")
+	proto.RegisterType((*FSGroup)(nil), "grpc.FSGroup")
+	proto.RegisterType((*Storage)(nil), "grpc.Storage")
+	proto.RegisterType((*Device)(nil), "grpc.Device")
+	proto.RegisterType((*StringUser)(nil), "grpc.StringUser")
+	proto.RegisterType((*CopyFileRequest)(nil), "grpc.CopyFileRequest")
+	proto.RegisterType((*ReadFileRequest)(nil), "grpc.ReadFileRequest")
+	proto.RegisterType((*ReadFileResponse)(nil), "grpc.ReadFileResponse")
+	proto.RegisterType((*GetDiagnosticsRequest)(nil), "grpc.GetDiagnosticsRequest")
+	proto.RegisterType((*Diagnostics)(nil), "grpc.Diagnostics")
+	proto.RegisterType((*CriuOptions)(nil), "grpc.CriuOptions")
+	proto.RegisterType((*CheckpointContainerRequest)(nil), "grpc.CheckpointContainerRequest")
+	proto.RegisterType((*CheckpointContainerResponse)(nil), "grpc.CheckpointContainerResponse")
+	proto.RegisterType((*RestoreContainerRequest)(nil), "grpc.RestoreContainerRequest")
+	proto.RegisterType((*GetOOMEventRequest)(nil), "grpc.GetOOMEventRequest")
+	proto.RegisterType((*OOMEvent)(nil), "grpc.OOMEvent")
+	proto.RegisterType((*AddSwapRequest)(nil), "grpc.AddSwapRequest")
+	proto.RegisterType((*GetMetricsRequest)(nil), "grpc.GetMetricsRequest")
+	proto.RegisterType((*Metrics)(nil), "grpc.Metrics")
+	proto.RegisterType((*VolumeStatsRequest)(nil), "grpc.VolumeStatsRequest")
+	proto.RegisterType((*ContainerVolumeStatsRequest)(nil), "grpc.ContainerVolumeStatsRequest")
+	proto.RegisterType((*ContainerVolumeStats)(nil), "grpc.ContainerVolumeStats")
+	proto.RegisterType((*ContainerVolumeStatsResponse)(nil), "grpc.ContainerVolumeStatsResponse")
+	proto.RegisterType((*ResizeVolumeRequest)(nil), "grpc.ResizeVolumeRequest")
+	proto.RegisterType((*WaitDeviceRequest)(nil), "grpc.WaitDeviceRequest")
+	proto.RegisterType((*SyncFsRequest)(nil), "grpc.SyncFsRequest")
+	proto.RegisterType((*FreezeFsRequest)(nil), "grpc.FreezeFsRequest")
+	proto.RegisterType((*ThawFsRequest)(nil), "grpc.ThawFsRequest")
+	proto.RegisterType((*SetNameResolutionRequest)(nil), "grpc.SetNameResolutionRequest")
+	proto.RegisterType((*GetGuestServicesRequest)(nil), "grpc.GetGuestServicesRequest")
+	proto.RegisterType((*GuestService)(nil), "grpc.GuestService")
+	proto.RegisterType((*GuestServices)(nil), "grpc.GuestServices")
+	proto.RegisterType((*NetworkPolicyRule)(nil), "grpc.NetworkPolicyRule")
+	proto.RegisterType((*SetNetworkPolicyRequest)(nil), "grpc.SetNetworkPolicyRequest")
+	proto.RegisterType((*GetSeccompNotificationRequest)(nil), "grpc.GetSeccompNotificationRequest")
+	proto.RegisterType((*SeccompNotification)(nil), "grpc.SeccompNotification")
+	proto.RegisterType((*SeccompNotificationResponse)(nil), "grpc.SeccompNotificationResponse")
+}
+
+func init() { proto.RegisterFile("agent.proto", fileDescriptor_56ede974c0020f77) }
+
+var fileDescriptor_56ede974c0020f77 = []byte{
+	// 4267 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x3a, 0x4d, 0x73, 0x1b, 0x47,
+	0x76, 0x0b, 0x02, 0x24, 0x80, 0x07, 0x80, 0x20, 0x06, 0x14, 0x05, 0x42, 0xb2, 0x2c, 0x8d, 0xd6,
+	0xb6, 0x6c, 0x47, 0xe4, 0x46, 0x76, 0xac, 0xf5, 0xba, 0x1c, 0x59, 0xa2, 0x28, 0x8a, 0xb6, 0x68,
+	0x71, 0x07, 0xd2, 0xda, 0xb5, 0x9b, 0xcd, 0x64, 0x38, 0xd3, 0x04, 0x7a, 0x09, 0x4c, 0xcf, 0x76,
+	0xf7, 0x50, 0xa0, 0x53, 0x95, 0xe4, 0x94, 0xa4, 0x72, 0x48, 0xe5, 0x90, 0x1c, 0xf3, 0x07, 0x52,
+	0x39, 0xe4, 0x92, 0x53, 0x2a, 0xb7, 0x1c, 0xb6, 0x72, 0xca, 0x31, 0x97, 0xa4, 0xb2, 0xfe, 0x03,
+	0xa9, 0xca, 0x2f, 0x48, 0xf5, 0xd7, 0x7c, 0x00, 0x03, 0xc8, 0x51, 0x29, 0xb5, 0x97, 0xa9, 0x7e,
+	0xaf, 0x5f, 0xbf, 0xf7, 0xfa, 0xf5, 0xeb, 0x37, 0xaf, 0x5f, 0x37, 0x34, 0xbc, 0x21, 0x0a, 0xf9,
+	0x4e, 0x44, 0x09, 0x27, 0x56, 0x65, 0x48, 0x23, 0xbf, 0x5f, 0x27, 0x3e, 0x56, 0x88, 0x7e, 0xdd,
+	0x67, 0xa6, 0xd9, 0xe0, 0x17, 0x11, 0x62, 0x1a, 0xb8, 0x32, 0x24, 0x64, 0x38, 0x46, 0xbb, 0x12,
+	0x3a, 0x89, 0x4f, 0x77, 0xd1, 0x24, 0xe2, 0x17, 0xaa, 0xd3, 0xfe, 0xdb, 0x15, 0xd8, 0xda, 0xa3,
+	0xc8, 0xe3, 0x68, 0x8f, 0x84, 0xdc, 0xc3, 0x21, 0xa2, 0x0e, 0xfa, 0x65, 0x8c, 0x18, 0xb7, 0x6e,
+	0x40, 0xd3, 0x37, 0x38, 0x17, 0x07, 0xbd, 0xd2, 0xf5, 0xd2, 0xad, 0xba, 0xd3, 0x48, 0x70, 0x87,
+	0x81, 0x75, 0x19, 0xaa, 0x68, 0x8a, 0x7c, 0xd1, 0xbb, 0x22, 0x7b, 0xd7, 0x04, 0x78, 0x18, 0x58,
+	0xbf, 0x0d, 0x0d, 0xc6, 0x29, 0x0e, 0x87, 0x6e, 0xcc, 0x10, 0xed, 0x95, 0xaf, 0x97, 0x6e, 0x35,
+	0xee, 0x6c, 0xec, 0x08, 0x95, 0x77, 0x06, 0xb2, 0xe3, 0x39, 0x43, 0xd4, 0x01, 0x96, 0xb4, 0xad,
+	0xb7, 0xa1, 0x1a, 0xa0, 0x73, 0xec, 0x23, 0xd6, 0xab, 0x5c, 0x2f, 0xdf, 0x6a, 0xdc, 0x69, 0x2a,
+	0xf2, 0x87, 0x12, 0xe9, 0x98, 0x4e, 0xeb, 0x5d, 0xa8, 0x31, 0x4e, 0xa8, 0x37, 0x44, 0xac, 0xb7,
+	0x2a, 0x09, 0x5b, 0x86, 0xaf, 0xc4, 0x3a, 0x49, 0xb7, 0x75, 0x15, 0xca, 0x4f, 0xf7, 0x0e, 0x7b,
+	0x6b, 0x52, 0x3a, 0x68, 0xaa, 0x08, 0xf9, 0x8e, 0x40, 0x5b, 0x37, 0xa1, 0xc5, 0xbc, 0x30, 0x38,
+	0x21, 0x53, 0x37, 0xc2, 0x41, 0xc8, 0x7a, 0xd5, 0xeb, 0xa5, 0x5b, 0x35, 0xa7, 0xa9, 0x91, 0xc7,
+	0x02, 0x67, 0xff, 0x1c, 0x2e, 0x0d, 0xb8, 0x47, 0xf9, 0xab, 0x58, 0xe7, 0x06, 0x34, 0xa5, 0x75,
+	0x38, 0x9e, 0x20, 0x12, 0x73, 0x69, 0xa2, 0x96, 0xd3, 0x10, 0xb8, 0x67, 0x0a, 0x65, 0x3f, 0x87,
+	0x2d, 0x07, 0x4d, 0xc8, 0xf9, 0x2b, 0x59, 0xbf, 0x07, 0xd5, 0x3c, 0x6b, 0x03, 0xda, 0x7f, 0x5f,
+	0x02, 0x6b, 0x7f, 0x8a, 0xfc, 0x63, 0x4a, 0x7c, 0xc4, 0xd8, 0x6f, 0x68, 0x45, 0xdf, 0x81, 0x6a,
+	0xa4, 0x14, 0xe8, 0x55, 0xae, 0x97, 0xd2, 0x85, 0x32, 0x5a, 0x99, 0x5e, 0xfb, 0x17, 0xb0, 0x39,
+	0xc0, 0xc3, 0xd0, 0x1b, 0xbf, 0x46, 0x7d, 0xb7, 0x60, 0x8d, 0x49, 0x9e, 0x52, 0xd5, 0x96, 0xa3,
+	0x21, 0xfb, 0x18, 0xac, 0xaf, 0x3c, 0xcc, 0x5f, 0x9f, 0x24, 0xfb, 0x36, 0x74, 0x73, 0x1c, 0x59,
+	0x44, 0x42, 0x86, 0xa4, 0x02, 0xdc, 0xe3, 0x31, 0x93, 0xcc, 0x56, 0x1d, 0x0d, 0xd9, 0x04, 0xb6,
+	0x9e, 0x47, 0xc1, 0x2b, 0x6e, 0xb8, 0x3b, 0x50, 0xa7, 0x88, 0x91, 0x98, 0x8a, 0x6d, 0xb2, 0x22,
+	0x8d, 0xba, 0xa9, 0x8c, 0xfa, 0x04, 0x87, 0xf1, 0xd4, 0x31, 0x7d, 0x4e, 0x4a, 0x66, 0xff, 0x48,
+	0xba, 0x30, 0x67, 0xaf, 0x20, 0x4f, 0x8c, 0x3d, 0xf6, 0x62, 0xf6, 0x2a, 0xba, 0xda, 0x9f, 0x08,
+	0xdf, 0x66, 0xf1, 0xe4, 0x95, 0x06, 0xff, 0x5d, 0x09, 0x6a, 0x7b, 0x51, 0xfc, 0x9c, 0x79, 0x43,
+	0x64, 0xbd, 0x09, 0x0d, 0x4e, 0xb8, 0x37, 0x76, 0x63, 0x01, 0x4a, 0xf2, 0x8a, 0x03, 0x12, 0xa5,
+	0x08, 0x6e, 0x40, 0x33, 0x42, 0xd4, 0x8f, 0x62, 0x4d, 0xb1, 0x72, 0xbd, 0x7c, 0xab, 0xe2, 0x34,
+	0x14, 0x4e, 0x91, 0xec, 0x40, 0x57, 0xf6, 0xb9, 0x38, 0x74, 0xcf, 0x10, 0x0d, 0xd1, 0x78, 0x42,
+	0x02, 0x24, 0x9d, 0xa3, 0xe2, 0x74, 0x64, 0xd7, 0x61, 0xf8, 0x45, 0xd2, 0x61, 0xbd, 0x07, 0x9d,
+	0x84, 0x5e, 0x78, 0xbc, 0xa4, 0xae, 0x48, 0xea, 0xb6, 0xa6, 0x7e, 0xae, 0xd1, 0xf6, 0x1f, 0xc1,
+	0xfa, 0xb3, 0x11, 0x25, 0x9c, 0x8f, 0x71, 0x38, 0x7c, 0xe8, 0x71, 0x4f, 0x6c, 0xcd, 0x08, 0x51,
+	0x4c, 0x02, 0xa6, 0xb5, 0x35, 0xa0, 0xf5, 0x3e, 0x74, 0xb8, 0xa2, 0x45, 0x81, 0x6b, 0x68, 0x56,
+	0x24, 0xcd, 0x46, 0xd2, 0x71, 0xac, 0x89, 0xdf, 0x82, 0xf5, 0x94, 0x58, 0x6c, 0x6e, 0xad, 0x6f,
+	0x2b, 0xc1, 0x8a, 0x40, 0x62, 0x9f, 0x4b, 0x5b, 0xc9, 0x45, 0xb6, 0xde, 0x87, 0x7a, 0x6a, 0x87,
+	0x92, 0xf4, 0x90, 0x75, 0xe5, 0x21, 0xc6, 0x9c, 0x4e, 0x2d, 0x31, 0xca, 0xa7, 0xd0, 0xe6, 0x89,
+	0xe2, 0x6e, 0xe0, 0x71, 0x2f, 0xef, 0x54, 0xf9, 0x59, 0x39, 0xeb, 0x3c, 0x07, 0xdb, 0x9f, 0x40,
+	0xfd, 0x18, 0x07, 0x4c, 0x09, 0xee, 0x41, 0xd5, 0x8f, 0x29, 0x45, 0x21, 0x37, 0x53, 0xd6, 0xa0,
+	0xb5, 0x09, 0xab, 0x63, 0x3c, 0xc1, 0x5c, 0x4f, 0x53, 0x01, 0x36, 0x01, 0x38, 0x42, 0x13, 0x42,
+	0x2f, 0xa4, 0xc1, 0x36, 0x61, 0x35, 0xbb, 0xb8, 0x0a, 0xb0, 0xae, 0x40, 0x7d, 0xe2, 0x4d, 0x93,
+	0x45, 0x15, 0x3d, 0xb5, 0x89, 0x37, 0x55, 0xca, 0xf7, 0xa0, 0x7a, 0xea, 0xe1, 0xb1, 0x1f, 0x72,
+	0x6d, 0x15, 0x03, 0xa6, 0x02, 0x2b, 0x59, 0x81, 0xff, 0xb2, 0x02, 0x0d, 0x25, 0x51, 0x29, 0xbc,
+	0x09, 0xab, 0xbe, 0xe7, 0x8f, 0x12, 0x91, 0x12, 0xb0, 0xde, 0x86, 0xd5, 0x54, 0x5c, 0x12, 0xe1,
+	0x52, 0x4d, 0x8d, 0x6a, 0xbb, 0x00, 0xec, 0x85, 0x17, 0x69, 0xdd, 0xca, 0x0b, 0x88, 0xeb, 0x82,
+	0x46, 0xa9, 0xfb, 0x01, 0x34, 0x95, 0xdf, 0xe9, 0x21, 0x95, 0x05, 0x43, 0x1a, 0x8a, 0x4a, 0x0d,
+	0xba, 0x09, 0xad, 0x98, 0x21, 0x77, 0x84, 0x11, 0xf5, 0xa8, 0x3f, 0xba, 0xe8, 0xad, 0xaa, 0x7f,
+	0x54, 0xcc, 0xd0, 0x63, 0x83, 0xb3, 0xee, 0xc0, 0xaa, 0x88, 0x2d, 0xac, 0xb7, 0x26, 0x7f, 0x87,
+	0x57, 0xb3, 0x2c, 0xe5, 0x54, 0x77, 0xe4, 0x77, 0x3f, 0xe4, 0xf4, 0xc2, 0x51, 0xa4, 0xfd, 0x1f,
+	0x02, 0xa4, 0x48, 0x6b, 0x03, 0xca, 0x67, 0xe8, 0x42, 0xef, 0x43, 0xd1, 0x14, 0xc6, 0x39, 0xf7,
+	0xc6, 0xb1, 0xb1, 0xba, 0x02, 0x7e, 0xb4, 0xf2, 0xc3, 0x92, 0xed, 0x43, 0xfb, 0xc1, 0xf8, 0x0c,
+	0x93, 0xcc, 0xf0, 0x4d, 0x58, 0x9d, 0x78, 0xbf, 0x20, 0xd4, 0x58, 0x52, 0x02, 0x12, 0x8b, 0x43,
+	0x42, 0x0d, 0x0b, 0x09, 0x58, 0xeb, 0xb0, 0x42, 0x22, 0x69, 0xaf, 0xba, 0xb3, 0x42, 0xa2, 0x54,
+	0x50, 0x25, 0x23, 0xc8, 0xfe, 0xcf, 0x0a, 0x40, 0x2a, 0xc5, 0x72, 0xa0, 0x8f, 0x89, 0xcb, 0x10,
+	0x15, 0x29, 0x80, 0x7b, 0x72, 0xc1, 0x11, 0x73, 0x29, 0xf2, 0x63, 0xca, 0xf0, 0xb9, 0x58, 0x3f,
+	0x31, 0xed, 0x4b, 0x6a, 0xda, 0x33, 0xba, 0x39, 0x97, 0x31, 0x19, 0xa8, 0x71, 0x0f, 0xc4, 0x30,
+	0xc7, 0x8c, 0xb2, 0x0e, 0xe1, 0x52, 0xca, 0x33, 0xc8, 0xb0, 0x5b, 0x59, 0xc6, 0xae, 0x9b, 0xb0,
+	0x0b, 0x52, 0x56, 0xfb, 0xd0, 0xc5, 0xc4, 0xfd, 0x65, 0x8c, 0xe2, 0x1c, 0xa3, 0xf2, 0x32, 0x46,
+	0x1d, 0x4c, 0x7e, 0x2c, 0x07, 0xa4, 0x6c, 0x8e, 0x61, 0x3b, 0x33, 0x4b, 0xb1, 0xdd, 0x33, 0xcc,
+	0x2a, 0xcb, 0x98, 0x6d, 0x25, 0x5a, 0x89, 0x78, 0x90, 0x72, 0xfc, 0x1c, 0xb6, 0x30, 0x71, 0x5f,
+	0x78, 0x98, 0xcf, 0xb2, 0x5b, 0x7d, 0xc9, 0x24, 0xc5, 0x1f, 0x2d, 0xcf, 0x4b, 0x4d, 0x72, 0x82,
+	0xe8, 0x30, 0x37, 0xc9, 0xb5, 0x97, 0x4c, 0xf2, 0x48, 0x0e, 0x48, 0xd9, 0xdc, 0x87, 0x0e, 0x26,
+	0xb3, 0xda, 0x54, 0x97, 0x31, 0x69, 0x63, 0x92, 0xd7, 0xe4, 0x01, 0x74, 0x18, 0xf2, 0x39, 0xa1,
+	0x59, 0x27, 0xa8, 0x2d, 0x63, 0xb1, 0xa1, 0xe9, 0x13, 0x1e, 0xf6, 0xcf, 0xa0, 0xf9, 0x38, 0x1e,
+	0x22, 0x3e, 0x3e, 0x49, 0x82, 0xc1, 0x6b, 0x8b, 0x3f, 0xf6, 0xff, 0xac, 0x40, 0x63, 0x6f, 0x48,
+	0x49, 0x1c, 0xe5, 0x62, 0xb2, 0xda, 0xa4, 0xb3, 0x31, 0x59, 0x92, 0xc8, 0x98, 0xac, 0x88, 0x3f,
+	0x84, 0xe6, 0x44, 0x6e, 0x5d, 0x4d, 0xaf, 0xe2, 0x50, 0x67, 0x6e, 0x53, 0x3b, 0x8d, 0x49, 0x0a,
+	0x58, 0x3b, 0x00, 0x11, 0x0e, 0x98, 0x1e, 0xa3, 0xc2, 0x51, 0x5b, 0xa7, 0x5b, 0x26, 0x44, 0x3b,
+	0xf5, 0xc8, 0x34, 0x45, 0x3a, 0x77, 0x22, 0x8c, 0xa4, 0x07, 0xe4, 0x82, 0x51, 0x6a, 0x3d, 0x07,
+	0x4e, 0x92, 0xb6, 0xf5, 0x18, 0x5a, 0x23, 0x65, 0x32, 0x3d, 0x48, 0xf9, 0xd0, 0x4d, 0x3d, 0x93,
+	0x74, 0xbe, 0x3b, 0x59, 0xcb, 0xaa, 0x05, 0x68, 0x8e, 0x32, 0xa8, 0xfe, 0x00, 0x3a, 0x73, 0x24,
+	0x05, 0x31, 0xe8, 0x56, 0x36, 0x06, 0x35, 0xee, 0x58, 0x4a, 0x50, 0x76, 0x64, 0x36, 0x2e, 0xfd,
+	0xe5, 0x0a, 0x34, 0xbf, 0x44, 0xfc, 0x05, 0xa1, 0x67, 0x4a, 0x5f, 0x0b, 0x2a, 0xa1, 0x37, 0x41,
+	0x9a, 0xa3, 0x6c, 0x5b, 0xdb, 0x50, 0xa3, 0x53, 0x15, 0x40, 0xf4, 0x7a, 0x56, 0xe9, 0x54, 0x06,
+	0x06, 0xeb, 0x0d, 0x00, 0x3a, 0x75, 0x23, 0xcf, 0x3f, 0x43, 0xda, 0x82, 0x15, 0xa7, 0x4e, 0xa7,
+	0xc7, 0x0a, 0x21, 0x5c, 0x81, 0x4e, 0x5d, 0x44, 0x29, 0xa1, 0x4c, 0xc7, 0xaa, 0x1a, 0x9d, 0xee,
+	0x4b, 0x58, 0x8f, 0x0d, 0x28, 0x89, 0x22, 0x14, 0xf4, 0x56, 0xcd, 0xd8, 0x87, 0x0a, 0x21, 0xa4,
+	0x72, 0x23, 0x75, 0x4d, 0x49, 0xe5, 0xa9, 0x54, 0x9e, 0x4a, 0xad, 0xaa, 0x91, 0x3c, 0x2b, 0x95,
+	0x27, 0x52, 0x6b, 0x4a, 0x2a, 0xcf, 0x48, 0xe5, 0xa9, 0xd4, 0xba, 0x19, 0xab, 0xa5, 0xda, 0x7f,
+	0x56, 0x82, 0xad, 0xd9, 0xc4, 0x4f, 0xe7, 0xa6, 0x1f, 0x42, 0xd3, 0x97, 0xeb, 0x95, 0xf3, 0xc9,
+	0xce, 0xdc, 0x4a, 0x3a, 0x0d, 0x3f, 0x05, 0xac, 0xbb, 0xd0, 0x0a, 0x95, 0x81, 0x13, 0xd7, 0x2c,
+	0xa7, 0xeb, 0x92, 0xb5, 0xbd, 0xd3, 0x0c, 0x33, 0x90, 0x1d, 0x80, 0xf5, 0x15, 0xc5, 0x1c, 0x0d,
+	0x38, 0x45, 0xde, 0xe4, 0x75, 0x64, 0xf7, 0x16, 0x54, 0x64, 0xb6, 0x22, 0x96, 0xa9, 0xe9, 0xc8,
+	0xb6, 0xfd, 0x0e, 0x74, 0x73, 0x52, 0xf4, 0x5c, 0x37, 0xa0, 0x3c, 0x46, 0xa1, 0xe4, 0xde, 0x72,
+	0x44, 0xd3, 0xf6, 0xa0, 0xe3, 0x20, 0x2f, 0x78, 0x7d, 0xda, 0x68, 0x11, 0xe5, 0x54, 0xc4, 0x2d,
+	0xb0, 0xb2, 0x22, 0xb4, 0x2a, 0x46, 0xeb, 0x52, 0x46, 0xeb, 0xa7, 0xd0, 0xd9, 0x1b, 0x13, 0x86,
+	0x06, 0x3c, 0xc0, 0xe1, 0xeb, 0x38, 0x8e, 0xfc, 0x21, 0x74, 0x9f, 0xf1, 0x8b, 0xaf, 0x04, 0x33,
+	0x86, 0xbf, 0x41, 0xaf, 0x69, 0x7e, 0x94, 0xbc, 0x30, 0xf3, 0xa3, 0xe4, 0x85, 0x38, 0xdc, 0xf8,
+	0x64, 0x1c, 0x4f, 0x42, 0xb9, 0x15, 0x5a, 0x8e, 0x86, 0xec, 0x07, 0xd0, 0x54, 0x39, 0xf4, 0x11,
+	0x09, 0xe2, 0x31, 0x2a, 0xdc, 0x83, 0xd7, 0x00, 0x22, 0x8f, 0x7a, 0x13, 0xc4, 0x11, 0x55, 0x3e,
+	0x54, 0x77, 0x32, 0x18, 0xfb, 0x9f, 0x57, 0x60, 0x53, 0x95, 0x24, 0x06, 0xea, 0x24, 0x6e, 0xa6,
+	0xd0, 0x87, 0xda, 0x88, 0x30, 0x9e, 0x61, 0x98, 0xc0, 0x42, 0xc5, 0x20, 0x34, 0xdc, 0x44, 0x33,
+	0x57, 0x27, 0x28, 0x2f, 0xaf, 0x13, 0xcc, 0x55, 0x02, 0x2a, 0xf3, 0x95, 0x00, 0xb1, 0xdb, 0x0c,
+	0x11, 0x56, 0x7b, 0xbc, 0xee, 0xd4, 0x35, 0xe6, 0x30, 0xb0, 0xde, 0x86, 0xf6, 0x50, 0x68, 0xe9,
+	0x8e, 0x08, 0x39, 0x73, 0x23, 0x8f, 0x8f, 0xe4, 0x56, 0xaf, 0x3b, 0x2d, 0x89, 0x7e, 0x4c, 0xc8,
+	0xd9, 0xb1, 0xc7, 0x47, 0xd6, 0xc7, 0xb0, 0xae, 0xd3, 0xc0, 0x89, 0x34, 0x11, 0xeb, 0x55, 0xb3,
+	0xbb, 0x28, 0x6b, 0x3d, 0xa7, 0x75, 0x96, 0x81, 0xe4, 0x69, 0x40, 0x89, 0xd0, 0x29, 0x02, 0x93,
+	0x3f, 0x3d, 0x23, 0x41, 0x27, 0x00, 0xcc, 0xbe, 0x0c, 0x97, 0x1e, 0x22, 0xc6, 0x29, 0xb9, 0xc8,
+	0xdb, 0xcf, 0x7e, 0x07, 0xde, 0x52, 0xc5, 0x86, 0x01, 0xf7, 0xc6, 0xe8, 0x27, 0x98, 0x72, 0x4c,
+	0x4e, 0xd9, 0x60, 0xe4, 0x51, 0x74, 0x44, 0xe2, 0x90, 0x9b, 0xd3, 0xb0, 0xfd, 0xbb, 0x00, 0x87,
+	0x21, 0x47, 0xf4, 0xd4, 0xf3, 0x11, 0xb3, 0x7e, 0x90, 0x85, 0x74, 0xb2, 0xb5, 0xb1, 0xa3, 0x2a,
+	0x4c, 0x49, 0x87, 0x93, 0xa1, 0xb1, 0x77, 0x60, 0xcd, 0x21, 0xb1, 0x08, 0x6f, 0xdf, 0x37, 0x2d,
+	0x3d, 0xae, 0xa9, 0xc7, 0x49, 0xa4, 0xa3, 0xfb, 0xec, 0xc7, 0xe6, 0x48, 0x9c, 0xb2, 0xd3, 0x4b,
+	0xbe, 0x03, 0x75, 0x6c, 0x70, 0x3a, 0x4a, 0xcd, 0x8b, 0x4e, 0x49, 0xec, 0x4f, 0xa0, 0xab, 0x38,
+	0x29, 0xce, 0x86, 0xcd, 0xf7, 0x61, 0x8d, 0x1a, 0x35, 0x4a, 0x69, 0x69, 0x49, 0x13, 0xe9, 0x3e,
+	0xfb, 0x10, 0xae, 0xaa, 0xc1, 0xfb, 0xd1, 0x08, 0x4d, 0x10, 0xf5, 0xc6, 0x39, 0xb3, 0xe4, 0x3c,
+	0xaa, 0xb4, 0xd4, 0xa3, 0xc4, 0x1a, 0x3c, 0xc1, 0x8c, 0xa7, 0x36, 0x31, 0xa6, 0xed, 0x42, 0x47,
+	0x74, 0xe4, 0xd4, 0xb3, 0x1f, 0x41, 0xf3, 0xbe, 0x73, 0xfc, 0x25, 0xc2, 0xc3, 0xd1, 0x89, 0x08,
+	0xec, 0x1f, 0xe5, 0x61, 0x2d, 0xcc, 0xd2, 0x13, 0xcf, 0x74, 0x39, 0x39, 0x3a, 0xfb, 0x73, 0xd8,
+	0xba, 0x1f, 0x04, 0x59, 0x94, 0x51, 0xfd, 0x07, 0x50, 0x0f, 0x33, 0xec, 0x32, 0xbf, 0xd3, 0x1c,
+	0x75, 0x4a, 0x64, 0xdf, 0x06, 0xeb, 0x00, 0xf1, 0xc3, 0xe3, 0x67, 0xde, 0xc9, 0x38, 0x35, 0xe4,
+	0x65, 0xa8, 0x62, 0xe6, 0xe2, 0xe8, 0xfc, 0x23, 0xc9, 0xa5, 0xe6, 0xac, 0x61, 0x76, 0x18, 0x9d,
+	0x7f, 0x64, 0xbf, 0x0b, 0xdd, 0x1c, 0xf9, 0x92, 0x88, 0x77, 0x1f, 0xac, 0xc1, 0x77, 0xe7, 0x9c,
+	0xb0, 0x58, 0xc9, 0xb0, 0x78, 0x17, 0xba, 0x83, 0xef, 0x28, 0xed, 0xe7, 0xd0, 0x7d, 0x1a, 0x8e,
+	0x71, 0x88, 0xf6, 0x8e, 0x9f, 0x1f, 0xa1, 0x24, 0xdc, 0x5b, 0x50, 0x11, 0x69, 0xb1, 0x96, 0x25,
+	0xdb, 0x42, 0x85, 0xf0, 0xc4, 0xf5, 0xa3, 0x98, 0xe9, 0x7a, 0xda, 0x5a, 0x78, 0xb2, 0x17, 0xc5,
+	0x4c, 0xfc, 0xbf, 0x45, 0xfe, 0x46, 0xc2, 0xf1, 0x85, 0x0c, 0x82, 0x35, 0xa7, 0xea, 0x47, 0xf1,
+	0xd3, 0x70, 0x7c, 0x61, 0xff, 0x96, 0x2c, 0x72, 0x20, 0x14, 0x38, 0x5e, 0x18, 0x90, 0xc9, 0x43,
+	0x74, 0x9e, 0x91, 0x30, 0xa7, 0xf7, 0x7f, 0x97, 0xa0, 0x79, 0x7f, 0x88, 0x42, 0xfe, 0x10, 0x71,
+	0x0f, 0x8f, 0xe5, 0xa1, 0xf9, 0x1c, 0x51, 0x86, 0x49, 0xa8, 0x23, 0x9a, 0x01, 0x45, 0xcd, 0x03,
+	0x87, 0x98, 0xbb, 0x81, 0x87, 0x26, 0x24, 0x94, 0x5c, 0x6a, 0x0e, 0x08, 0xd4, 0x43, 0x89, 0xb1,
+	0xde, 0x81, 0xb6, 0x2a, 0x89, 0xba, 0x23, 0x2f, 0x0c, 0xc6, 0x88, 0xaa, 0x30, 0x57, 0x77, 0xd6,
+	0x15, 0xfa, 0xb1, 0xc6, 0x5a, 0xef, 0xc2, 0x86, 0xf6, 0xcb, 0x94, 0xb2, 0x22, 0x29, 0xdb, 0x1a,
+	0x9f, 0x23, 0x8d, 0xa3, 0x88, 0x50, 0xce, 0x5c, 0x86, 0x7c, 0x9f, 0x4c, 0x22, 0x7d, 0xe2, 0x6c,
+	0x1b, 0xfc, 0x40, 0xa1, 0x45, 0x30, 0xc2, 0x13, 0xc1, 0x73, 0x82, 0xb8, 0x27, 0x27, 0xaa, 0xc3,
+	0x9d, 0xc4, 0x1e, 0x69, 0xa4, 0x3d, 0x84, 0xee, 0x81, 0x30, 0x87, 0x9e, 0x70, 0xba, 0x21, 0xd7,
+	0x27, 0x68, 0xe2, 0x9e, 0x8c, 0x89, 0x7f, 0xe6, 0x8a, 0xdf, 0x94, 0x5e, 0x08, 0x91, 0xfa, 0x3e,
+	0x10, 0xc8, 0x01, 0xfe, 0x46, 0xd6, 0x60, 0x04, 0xd5, 0x88, 0xf0, 0x68, 0x1c, 0x0f, 0xdd, 0x88,
+	0x92, 0x13, 0xa4, 0x2d, 0xd1, 0x9e, 0xa0, 0xc9, 0x63, 0x85, 0x3f, 0x16, 0x68, 0xfb, 0x9f, 0x4a,
+	0xb0, 0x99, 0x97, 0xa4, 0x9d, 0x62, 0x17, 0x36, 0xf3, 0xa2, 0x74, 0x22, 0xa6, 0x12, 0xfd, 0x4e,
+	0x56, 0xa0, 0x4a, 0xc9, 0xee, 0x42, 0x4b, 0xd6, 0xd9, 0xdd, 0x40, 0x71, 0xca, 0xa7, 0x9f, 0xd9,
+	0xe5, 0x73, 0x9a, 0x5e, 0x06, 0xb2, 0x3e, 0x86, 0x6d, 0x6d, 0x25, 0x77, 0x5e, 0x6d, 0xe5, 0x37,
+	0x5b, 0x9a, 0xe0, 0x68, 0x46, 0xfb, 0x27, 0xd0, 0x4b, 0x51, 0x0f, 0x2e, 0x24, 0x32, 0xdd, 0xbb,
+	0xdd, 0x99, 0xc9, 0xde, 0x0f, 0x02, 0x2a, 0x83, 0x42, 0xc5, 0x29, 0xea, 0xb2, 0xef, 0xc1, 0xe5,
+	0x01, 0xe2, 0xca, 0x1a, 0x1e, 0xd7, 0x67, 0x42, 0xc5, 0x6c, 0x03, 0xca, 0x03, 0xe4, 0xcb, 0xc9,
+	0x97, 0x1d, 0xd1, 0x14, 0x7e, 0xfa, 0x9c, 0x21, 0x5f, 0xce, 0xb2, 0xec, 0xc8, 0xb6, 0x1d, 0x41,
+	0xf5, 0xd1, 0xe0, 0x40, 0x64, 0x7e, 0xc2, 0xf7, 0x55, 0xa6, 0xa8, 0xb3, 0x82, 0x96, 0x53, 0x95,
+	0xf0, 0x61, 0x60, 0x7d, 0x0e, 0x5d, 0xd5, 0xe5, 0x8f, 0xbc, 0x70, 0x88, 0xdc, 0x88, 0x8c, 0xb1,
+	0xaf, 0x76, 0xc8, 0xfa, 0x9d, 0xbe, 0x8e, 0x56, 0x9a, 0xcf, 0x9e, 0x24, 0x39, 0x96, 0x14, 0x4e,
+	0x67, 0x38, 0x8b, 0xb2, 0xff, 0xa3, 0x04, 0x55, 0x1d, 0x46, 0x45, 0x72, 0x11, 0x50, 0x7c, 0x8e,
+	0xa8, 0xde, 0x13, 0x1a, 0x12, 0x2e, 0xa7, 0x5a, 0x2e, 0x89, 0x38, 0x26, 0xc9, 0xef, 0xbe, 0xa5,
+	0xb0, 0x4f, 0x15, 0x52, 0x0c, 0x57, 0xa5, 0x4f, 0x5d, 0x65, 0xd0, 0x90, 0xc0, 0x9f, 0x32, 0xa1,
+	0x94, 0xfc, 0xbd, 0xd7, 0x1d, 0x0d, 0x89, 0x3d, 0x68, 0xf8, 0xad, 0x4a, 0x7e, 0x06, 0x14, 0x7b,
+	0x70, 0x22, 0xfe, 0x00, 0x6e, 0x44, 0x70, 0xc8, 0xb5, 0x83, 0x83, 0x44, 0x1d, 0x0b, 0x8c, 0x75,
+	0x0b, 0x6a, 0xa7, 0xcc, 0x95, 0xb3, 0x91, 0xb9, 0x7b, 0xf2, 0x47, 0xd0, 0xb3, 0x76, 0xaa, 0xa7,
+	0x4c, 0x36, 0xec, 0x3f, 0x2d, 0xc1, 0x9a, 0xba, 0xc9, 0x10, 0x15, 0x90, 0x24, 0xff, 0x5a, 0xc1,
+	0x32, 0x97, 0x95, 0x5a, 0xa9, 0x9c, 0x4b, 0xb6, 0x45, 0x28, 0x3a, 0x9f, 0xa8, 0x2c, 0x42, 0x4f,
+	0xe2, 0x7c, 0x22, 0xd3, 0x87, 0xb7, 0x60, 0x3d, 0x4d, 0xe3, 0x64, 0xbf, 0x9a, 0x4c, 0x2b, 0xc1,
+	0x4a, 0xb2, 0x85, 0x73, 0xb2, 0xbf, 0x16, 0x85, 0x9f, 0xa4, 0x44, 0xbf, 0x01, 0xe5, 0x38, 0x51,
+	0x46, 0x34, 0x05, 0x66, 0x98, 0x24, 0x80, 0xa2, 0x69, 0xbd, 0x0d, 0xeb, 0x5e, 0x10, 0x60, 0x31,
+	0xdc, 0x1b, 0x1f, 0xe0, 0x20, 0x89, 0x33, 0x79, 0xac, 0xfd, 0xaf, 0x25, 0x68, 0xef, 0x91, 0xe8,
+	0xe2, 0x11, 0x1e, 0xa3, 0x4c, 0x10, 0x94, 0x4a, 0xea, 0xfc, 0x4f, 0xb4, 0xc5, 0x99, 0xe6, 0x14,
+	0x8f, 0x91, 0xda, 0xf6, 0xca, 0xeb, 0x6a, 0x02, 0x21, 0xb7, 0xbc, 0xe9, 0x4c, 0x8a, 0xb3, 0x2d,
+	0xd5, 0x79, 0x24, 0x6a, 0xb2, 0xdb, 0x50, 0x0b, 0x30, 0x75, 0x93, 0x52, 0x6c, 0xcb, 0xa9, 0x06,
+	0x98, 0xca, 0x2e, 0x3d, 0x91, 0x55, 0x59, 0x6a, 0xcf, 0x4e, 0x64, 0x4d, 0x61, 0xc4, 0x44, 0xb6,
+	0x60, 0x8d, 0x9c, 0x9e, 0x32, 0xc4, 0xe5, 0x5a, 0x95, 0x1d, 0x0d, 0x25, 0x91, 0xba, 0x96, 0x89,
+	0xd4, 0x5f, 0x43, 0x5b, 0x24, 0xf0, 0x2f, 0x9b, 0xcb, 0x36, 0x88, 0x7a, 0x40, 0x3a, 0x95, 0x8a,
+	0x53, 0x9d, 0x78, 0x53, 0x39, 0x93, 0x54, 0x9a, 0x3a, 0x4b, 0x6a, 0xc8, 0x7e, 0x1b, 0x36, 0x52,
+	0xce, 0x4b, 0x7e, 0x5c, 0x8f, 0xe0, 0xd2, 0x01, 0xe2, 0x0f, 0xb1, 0x37, 0x0c, 0x09, 0xe3, 0xd8,
+	0x4f, 0x62, 0xe7, 0x6d, 0xe8, 0x0a, 0x99, 0x3a, 0x8b, 0x1c, 0x93, 0x61, 0x1a, 0x40, 0x2b, 0xce,
+	0xc6, 0xc4, 0x9b, 0xaa, 0x1c, 0xf2, 0x09, 0x19, 0x0a, 0x3d, 0xec, 0x13, 0x68, 0x64, 0x98, 0x88,
+	0x34, 0x36, 0x1d, 0xa9, 0x05, 0xd6, 0xcf, 0xcc, 0x08, 0xa1, 0xb5, 0xf4, 0x6f, 0xa6, 0xff, 0x5b,
+	0x1a, 0xb2, 0xae, 0x42, 0x5d, 0xdf, 0xd6, 0x20, 0xa6, 0x4f, 0x5d, 0x29, 0xc2, 0xfe, 0x63, 0x68,
+	0xec, 0x51, 0x1c, 0x9b, 0x1d, 0xf8, 0x0e, 0xb4, 0xb9, 0x1f, 0xb9, 0x88, 0x71, 0xef, 0x64, 0x8c,
+	0xd9, 0x08, 0x05, 0x3a, 0xbc, 0xaf, 0x73, 0x3f, 0xda, 0x4f, 0xb1, 0x42, 0x19, 0xb9, 0xda, 0x22,
+	0x00, 0x33, 0x1d, 0xd9, 0xe5, 0xfa, 0x3f, 0x11, 0x08, 0xeb, 0x16, 0x6c, 0xa0, 0x29, 0x77, 0xe3,
+	0x10, 0x4f, 0x5d, 0x46, 0xd2, 0x83, 0x79, 0xcd, 0x59, 0x47, 0x53, 0xfe, 0x3c, 0xc4, 0xd3, 0x81,
+	0xc2, 0xda, 0xff, 0x50, 0x82, 0xfe, 0xde, 0x08, 0xf9, 0x67, 0x72, 0xa7, 0xbe, 0xca, 0xcd, 0xca,
+	0x1b, 0x00, 0xea, 0x7f, 0x26, 0xd7, 0x58, 0xb9, 0x7f, 0x5d, 0x62, 0xe4, 0x86, 0xba, 0x09, 0xad,
+	0x31, 0xf2, 0xce, 0x91, 0x4b, 0xe3, 0x30, 0xc4, 0xe1, 0x50, 0xeb, 0xd1, 0x94, 0x48, 0x47, 0xe1,
+	0xac, 0xf7, 0xd3, 0x5d, 0x57, 0xc9, 0x9d, 0xa8, 0x53, 0xdb, 0xa4, 0x1b, 0xf1, 0x03, 0xb8, 0x52,
+	0xa8, 0xb1, 0x76, 0x89, 0x4d, 0x58, 0x15, 0x86, 0x50, 0xc9, 0x5f, 0xdd, 0x51, 0x80, 0xfd, 0xe7,
+	0x25, 0xb8, 0xec, 0x20, 0xc6, 0x09, 0x45, 0xff, 0x0f, 0x93, 0xcc, 0xe8, 0x5f, 0x7e, 0xa9, 0xfe,
+	0x9b, 0x32, 0x41, 0x7c, 0xfa, 0xf4, 0x68, 0xff, 0x1c, 0x85, 0xdc, 0xa4, 0xb2, 0xb7, 0xa1, 0x66,
+	0x50, 0xdf, 0xe5, 0x9a, 0xe7, 0x3d, 0x58, 0xbf, 0x1f, 0x04, 0x83, 0x17, 0x5e, 0x64, 0x66, 0xd1,
+	0x83, 0xea, 0xf1, 0xde, 0xe1, 0xb1, 0xda, 0x68, 0x65, 0xb1, 0xc5, 0x35, 0x28, 0x52, 0xe7, 0x03,
+	0xc4, 0x8f, 0x10, 0xa7, 0xe9, 0x66, 0xb0, 0x6f, 0x42, 0x55, 0x63, 0xc4, 0xc8, 0x89, 0x6a, 0x9a,
+	0x5c, 0x4a, 0x83, 0xf6, 0x67, 0x60, 0xfd, 0x44, 0x9c, 0x4f, 0x91, 0x2a, 0x4e, 0x68, 0x49, 0xef,
+	0x41, 0xe7, 0x5c, 0x62, 0x5d, 0x75, 0xaa, 0xca, 0x6c, 0xee, 0xb6, 0xea, 0x90, 0x7f, 0x50, 0x29,
+	0xfb, 0x33, 0xb8, 0x92, 0xd8, 0xbb, 0x80, 0xd5, 0x77, 0x98, 0xe9, 0xdf, 0x94, 0x60, 0xb3, 0x88,
+	0x85, 0x75, 0x1d, 0x1a, 0x01, 0x62, 0x1c, 0x87, 0x1e, 0x4f, 0xd3, 0xc0, 0x2c, 0xaa, 0x58, 0xd1,
+	0x95, 0x42, 0x45, 0xad, 0x5d, 0x73, 0x17, 0xa0, 0x16, 0x70, 0x5b, 0x2d, 0x60, 0x4e, 0x65, 0xe5,
+	0x60, 0xfa, 0x22, 0xc0, 0x7e, 0x06, 0x57, 0x8b, 0x67, 0x96, 0x94, 0x8a, 0xaa, 0x4a, 0x86, 0x39,
+	0x86, 0xf4, 0xb5, 0x4f, 0x14, 0x0d, 0x32, 0xa4, 0xf6, 0x73, 0xe8, 0xaa, 0xf2, 0x83, 0xea, 0x7d,
+	0x05, 0x93, 0x8b, 0x98, 0x98, 0x09, 0xab, 0xb2, 0x6d, 0xff, 0x0c, 0x3a, 0xa2, 0x30, 0xad, 0x9f,
+	0x04, 0xa4, 0x71, 0x59, 0xfe, 0x3f, 0x4b, 0x99, 0xff, 0x67, 0x0f, 0xaa, 0x5e, 0x10, 0x50, 0xc4,
+	0x98, 0x36, 0x94, 0x01, 0xb3, 0x97, 0xe6, 0xe5, 0xfc, 0xa5, 0xf9, 0x13, 0x68, 0x0d, 0x2e, 0x42,
+	0xff, 0x11, 0x7b, 0x2d, 0x57, 0xf0, 0x1f, 0x42, 0xfb, 0x11, 0x45, 0xe8, 0x1b, 0xf4, 0x7f, 0xe1,
+	0x67, 0xb7, 0xa1, 0xf5, 0x6c, 0xe4, 0xbd, 0x48, 0xc6, 0xd8, 0x3f, 0x86, 0xde, 0x00, 0xf1, 0x2f,
+	0x3d, 0x61, 0x43, 0x46, 0xc6, 0xb1, 0x70, 0x08, 0xc3, 0x6f, 0x13, 0x56, 0x45, 0xfd, 0x83, 0xe9,
+	0x28, 0xae, 0x00, 0x91, 0xb4, 0x50, 0x41, 0x7a, 0xee, 0xfa, 0x24, 0x3c, 0xd5, 0x61, 0x1c, 0x14,
+	0x6a, 0x8f, 0x84, 0xa7, 0xf6, 0x36, 0x5c, 0x3e, 0xd0, 0xd9, 0xa1, 0xa9, 0x19, 0x18, 0x69, 0xcf,
+	0xa0, 0x99, 0xc5, 0x17, 0x96, 0x6f, 0x36, 0x95, 0x87, 0x99, 0x7c, 0x45, 0x01, 0xa2, 0x36, 0x43,
+	0x45, 0xbc, 0xa7, 0xda, 0xf5, 0x5a, 0x4e, 0x02, 0xdb, 0xf7, 0xa0, 0x95, 0x93, 0x66, 0xed, 0x40,
+	0x2d, 0x29, 0x61, 0x94, 0xb2, 0xd5, 0x8f, 0x2c, 0x99, 0x93, 0xd0, 0xd8, 0x7f, 0x55, 0x82, 0x8e,
+	0x2e, 0x2f, 0xea, 0x0c, 0x52, 0xd4, 0x96, 0xae, 0x42, 0x3d, 0xc0, 0x14, 0xf9, 0x99, 0x6d, 0x93,
+	0x22, 0x84, 0xea, 0x3e, 0x0e, 0xa8, 0xc9, 0xaa, 0x44, 0x5b, 0x28, 0x29, 0x5f, 0xbd, 0xf8, 0x64,
+	0xac, 0xd3, 0xaa, 0x04, 0x16, 0xf4, 0x11, 0xa1, 0x5c, 0xe7, 0x15, 0xb2, 0x2d, 0xfe, 0xee, 0x28,
+	0x0c, 0x5c, 0x89, 0x5f, 0x55, 0xcb, 0x8b, 0xc2, 0xe0, 0x98, 0x50, 0x6e, 0xff, 0x75, 0x49, 0xe6,
+	0xd8, 0x79, 0xad, 0xd2, 0x10, 0x16, 0x51, 0x32, 0xa4, 0xde, 0x44, 0xaf, 0x8c, 0x01, 0x45, 0x0f,
+	0x0e, 0x87, 0x89, 0x5b, 0xd6, 0x1c, 0x03, 0x8a, 0xff, 0x2e, 0x52, 0x1d, 0xea, 0xc7, 0xa2, 0x21,
+	0xeb, 0x36, 0xac, 0x52, 0x59, 0x25, 0x52, 0xf7, 0x3f, 0x97, 0x73, 0xb5, 0xd6, 0xd4, 0x18, 0x8e,
+	0xa2, 0xb2, 0xdf, 0x84, 0x37, 0x0e, 0x10, 0xd7, 0x67, 0xb4, 0x2f, 0x09, 0xc7, 0xa7, 0xd8, 0xf7,
+	0x32, 0x3e, 0x63, 0xff, 0x63, 0x09, 0xba, 0x05, 0xdd, 0x99, 0xa4, 0xb4, 0x22, 0x93, 0xd2, 0x59,
+	0x5f, 0x5d, 0x59, 0x5a, 0x2e, 0x2c, 0xcf, 0x96, 0x0b, 0x23, 0x1c, 0x68, 0x4b, 0x8a, 0xa6, 0x98,
+	0x37, 0xbb, 0x60, 0xbe, 0x37, 0x1e, 0xeb, 0xc2, 0x99, 0x01, 0x85, 0xd9, 0xc5, 0x25, 0xa6, 0xce,
+	0xad, 0x65, 0x5b, 0xe1, 0x86, 0xaa, 0x30, 0x56, 0x71, 0x64, 0xdb, 0x26, 0x70, 0xa5, 0x70, 0x56,
+	0x3a, 0x4a, 0xcd, 0xaa, 0xbf, 0x09, 0xab, 0xde, 0x78, 0x4c, 0x5e, 0x68, 0x33, 0x2b, 0x40, 0x60,
+	0x65, 0x29, 0x5d, 0xea, 0xbb, 0xea, 0x28, 0x20, 0x7f, 0x03, 0x59, 0xd6, 0x57, 0x0a, 0x77, 0xfe,
+	0xe2, 0xaa, 0x3e, 0xaa, 0x9b, 0xbd, 0x70, 0x00, 0xed, 0x99, 0x87, 0x52, 0xd6, 0x55, 0xf3, 0x7b,
+	0x2c, 0x7a, 0x3f, 0xd5, 0xdf, 0xda, 0x51, 0x0f, 0xaf, 0x76, 0xcc, 0xc3, 0xab, 0x9d, 0x7d, 0xf1,
+	0xf0, 0xca, 0xda, 0x87, 0xf5, 0xfc, 0x93, 0x22, 0xeb, 0x8a, 0x29, 0x23, 0x15, 0x3c, 0x34, 0x5a,
+	0xc8, 0xe6, 0x00, 0xda, 0xaa, 0x9a, 0x37, 0xa7, 0x4f, 0xf1, 0x8b, 0xa2, 0x85, 0x8c, 0xee, 0x41,
+	0x23, 0xf3, 0x56, 0xc8, 0xea, 0x29, 0x26, 0xf3, 0xcf, 0x87, 0x16, 0x32, 0xd8, 0x83, 0x56, 0xee,
+	0xf9, 0x8e, 0xa5, 0x7f, 0x11, 0x45, 0x6f, 0x7a, 0x16, 0x32, 0x79, 0x00, 0x8d, 0xcc, 0x2b, 0x1a,
+	0xa3, 0xc5, 0xfc, 0x53, 0x9d, 0xfe, 0x76, 0x41, 0x8f, 0xf6, 0x82, 0x03, 0x68, 0xcf, 0x3c, 0xad,
+	0x31, 0x26, 0x29, 0x7e, 0x71, 0xb3, 0x50, 0x99, 0x01, 0x5c, 0x2a, 0xac, 0x04, 0x5a, 0x76, 0x96,
+	0x5d, 0x71, 0x99, 0x70, 0x21, 0xd3, 0x2f, 0x60, 0x3d, 0x7f, 0x1d, 0x93, 0x59, 0xf7, 0xf9, 0xd7,
+	0x39, 0xfd, 0xab, 0xc5, 0x9d, 0x7a, 0xaa, 0xfb, 0xb0, 0x9e, 0x7f, 0x98, 0x63, 0x98, 0x15, 0x3e,
+	0xd7, 0x59, 0xee, 0x44, 0xb9, 0x37, 0x3a, 0xa9, 0x13, 0x15, 0x3d, 0xdd, 0x59, 0xc8, 0x08, 0xc1,
+	0xb5, 0xe5, 0xb5, 0x65, 0xeb, 0xfd, 0xac, 0x73, 0xbe, 0xa4, 0x02, 0xbd, 0x50, 0xcc, 0x7d, 0x00,
+	0x7d, 0xc7, 0x13, 0xe0, 0x30, 0x71, 0x92, 0xb9, 0xbb, 0xa5, 0xfe, 0x76, 0x41, 0x8f, 0xb6, 0xdc,
+	0x3d, 0x00, 0x75, 0x35, 0x13, 0x90, 0x98, 0x5b, 0x97, 0x8d, 0x56, 0x33, 0xf7, 0x41, 0xfd, 0xde,
+	0x7c, 0xc7, 0x1c, 0x03, 0x44, 0xe9, 0xab, 0x30, 0xf8, 0x14, 0x20, 0xbd, 0xf2, 0x31, 0x0c, 0xe6,
+	0x2e, 0x81, 0x96, 0xd8, 0xa0, 0x99, 0xbd, 0xe0, 0xb1, 0xf4, 0x5c, 0x0b, 0x2e, 0x7d, 0x96, 0xb0,
+	0x68, 0xcf, 0x14, 0xdc, 0xf3, 0x1b, 0x65, 0xb6, 0x0e, 0xdf, 0x9f, 0x2b, 0xba, 0x5b, 0x77, 0xa1,
+	0x99, 0xad, 0xb4, 0x1b, 0x2d, 0x0a, 0xaa, 0xef, 0xfd, 0x5c, 0xb5, 0xdd, 0xba, 0x07, 0xeb, 0xf9,
+	0xd2, 0xb8, 0xf1, 0xdc, 0xc2, 0x82, 0x79, 0x5f, 0xdf, 0x49, 0x67, 0xc8, 0x3f, 0x00, 0x48, 0x4b,
+	0xe8, 0xc6, 0x7c, 0x73, 0x45, 0xf5, 0x19, 0xa9, 0x07, 0xd0, 0x9e, 0x29, 0x8d, 0x9b, 0x19, 0x17,
+	0x57, 0xcc, 0x97, 0xc5, 0xa9, 0x4c, 0xa1, 0xdb, 0xb8, 0xe0, 0x7c, 0xa9, 0xbc, 0xbf, 0x5d, 0xd0,
+	0xa3, 0x1d, 0xe0, 0x01, 0x34, 0x06, 0xf3, 0x3c, 0x06, 0x0b, 0x79, 0x14, 0xd5, 0xba, 0x3f, 0x04,
+	0x48, 0x4f, 0x43, 0xc6, 0x0a, 0x73, 0xe7, 0xa3, 0x7e, 0xcb, 0xbc, 0x1b, 0x50, 0x74, 0x7b, 0xd0,
+	0xca, 0x5d, 0xad, 0x99, 0x50, 0x5d, 0x74, 0xdf, 0xb6, 0xec, 0x07, 0x96, 0xbf, 0x60, 0x32, 0x2b,
+	0x58, 0x78, 0xed, 0xb4, 0xcc, 0x8f, 0xb3, 0x95, 0x79, 0xe3, 0x41, 0x05, 0xd5, 0xfa, 0x97, 0x84,
+	0xaf, 0x6c, 0xf5, 0x3d, 0x13, 0xbe, 0x0a, 0x8a, 0xf2, 0x0b, 0x19, 0x3d, 0x86, 0xb6, 0xc9, 0x89,
+	0x4d, 0x35, 0x77, 0x3b, 0x93, 0x92, 0xe6, 0xab, 0xd7, 0xfd, 0x7e, 0x51, 0x97, 0x5e, 0x97, 0x2f,
+	0xa0, 0x33, 0x57, 0xc9, 0xb5, 0xae, 0x25, 0xaf, 0x37, 0x0a, 0x4b, 0xbc, 0x0b, 0xd5, 0x3a, 0x84,
+	0x8d, 0xd9, 0x42, 0xae, 0xf5, 0x46, 0xe2, 0x13, 0x45, 0x05, 0xde, 0x85, 0xac, 0x3e, 0x86, 0x9a,
+	0x29, 0xce, 0x59, 0x97, 0xcc, 0x11, 0x2e, 0x57, 0xac, 0x5b, 0x36, 0xd4, 0x54, 0xac, 0xcc, 0xd0,
+	0x99, 0xda, 0x58, 0x7f, 0x6b, 0x16, 0xad, 0xad, 0xf1, 0x19, 0xac, 0xe7, 0x8b, 0x58, 0xc6, 0x55,
+	0x0a, 0x4b, 0x5b, 0x7d, 0x5d, 0x6f, 0xc8, 0xd2, 0xff, 0x14, 0xba, 0x05, 0x65, 0x12, 0xeb, 0xba,
+	0x9e, 0xc2, 0xc2, 0x9a, 0x4f, 0xff, 0xc6, 0x12, 0x0a, 0xad, 0xdd, 0x21, 0x6c, 0xcc, 0x16, 0x53,
+	0x8c, 0x79, 0x17, 0x14, 0x59, 0x16, 0xda, 0xe8, 0xae, 0x0c, 0x0b, 0x49, 0xe9, 0x23, 0x0d, 0x0b,
+	0x33, 0x05, 0x92, 0xbe, 0x7e, 0xf8, 0x93, 0x50, 0xde, 0x85, 0xaa, 0xae, 0x80, 0x58, 0x9b, 0x49,
+	0x40, 0xca, 0x14, 0x44, 0x96, 0xed, 0xc2, 0x03, 0xc4, 0xb3, 0x95, 0x84, 0x5e, 0xc1, 0x61, 0x3f,
+	0x17, 0x47, 0x8a, 0xce, 0xf7, 0x7f, 0x20, 0x4f, 0x83, 0x85, 0x95, 0x89, 0x1b, 0x4b, 0x4e, 0xfa,
+	0x9a, 0xb1, 0xbd, 0x8c, 0x44, 0x4b, 0xb8, 0x0f, 0xcd, 0x6c, 0x2d, 0xc0, 0x6c, 0xac, 0x82, 0xfa,
+	0xc0, 0xc2, 0xb9, 0x7e, 0x0a, 0x90, 0x9e, 0xfb, 0x4d, 0xb0, 0x9b, 0xab, 0x04, 0x2c, 0x1c, 0xfe,
+	0x3b, 0xb0, 0xa6, 0x4e, 0xf6, 0x56, 0x57, 0x6f, 0x9e, 0xec, 0x39, 0x7f, 0x99, 0xdf, 0x9b, 0x23,
+	0xbc, 0xf1, 0xfb, 0x99, 0x23, 0xfd, 0x32, 0x89, 0xea, 0x1c, 0x6f, 0x24, 0xe6, 0x4e, 0xf5, 0x4b,
+	0x52, 0xc4, 0xce, 0xdc, 0x69, 0xdf, 0x04, 0x8f, 0x45, 0x65, 0x80, 0x85, 0xcc, 0x1e, 0xc1, 0xc6,
+	0xec, 0x39, 0xdf, 0x78, 0xf7, 0x82, 0xf3, 0x7f, 0xbf, 0x3b, 0x7f, 0x0c, 0x67, 0x3a, 0x08, 0xe5,
+	0x8e, 0x9c, 0x99, 0x20, 0x54, 0x74, 0x02, 0x5e, 0xa8, 0xd2, 0xd7, 0xb0, 0x55, 0x7c, 0x3c, 0xb5,
+	0x6e, 0x26, 0x8a, 0x2d, 0x3e, 0xbc, 0xa6, 0xbf, 0xc3, 0xf9, 0xf1, 0xbf, 0x07, 0x6f, 0x0e, 0x50,
+	0x18, 0x2c, 0x3b, 0x23, 0xde, 0x58, 0x38, 0xda, 0x90, 0x2c, 0xd2, 0xfb, 0xc1, 0xf4, 0x57, 0xbf,
+	0xbe, 0xf6, 0xbd, 0x7f, 0xff, 0xf5, 0xb5, 0xef, 0xfd, 0xc9, 0xb7, 0xd7, 0x4a, 0xbf, 0xfa, 0xf6,
+	0x5a, 0xe9, 0xdf, 0xbe, 0xbd, 0x56, 0xfa, 0xaf, 0x6f, 0xaf, 0x95, 0x7e, 0xfa, 0xfb, 0x43, 0xcc,
+	0x47, 0xf1, 0xc9, 0x8e, 0x4f, 0x26, 0xbb, 0x67, 0x1e, 0xf7, 0x6e, 0x27, 0x27, 0x64, 0x36, 0x07,
+	0x33, 0xea, 0xef, 0xd2, 0x38, 0x14, 0x15, 0xa2, 0xdd, 0x73, 0x4c, 0x79, 0xa6, 0x2b, 0x3a, 0x1b,
+	0xee, 0xca, 0xbb, 0xc4, 0x5d, 0x53, 0x90, 0x60, 0xbb, 0x42, 0xdb, 0x93, 0x35, 0x09, 0x7f, 0xf0,
+	0xbf, 0x03, 0x00, 0xde, 0xc3, 0xfe, 0xce, 0xfb, 0x33, 0x00, 0x00,
+}
+
+func (m *CreateContainerRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3685,12 +4198,12 @@ func (m *CpuStats) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CpuStats) MarshalTo(dAtA []byte) (int, error) {
+func (m *CreateContainerRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *CpuStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *CreateContainerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3699,9 +4212,19 @@ func (m *CpuStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.ThrottlingData != nil {
+	if m.SandboxPidns {
+		i--
+		if m.SandboxPidns {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.OCI != nil {
 		{
-			size, err := m.ThrottlingData.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.OCI.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -3709,11 +4232,39 @@ func (m *CpuStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintAgent(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0x32
 	}
-	if m.CpuUsage != nil {
+	if len(m.Storages) > 0 {
+		for iNdEx := len(m.Storages) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Storages[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAgent(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.Devices) > 0 {
+		for iNdEx := len(m.Devices) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Devices[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAgent(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.StringUser != nil {
 		{
-			size, err := m.CpuUsage.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.StringUser.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -3721,12 +4272,26 @@ func (m *CpuStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintAgent(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0xa
+		dAtA[i] = 0x1a
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *PidsStats) Marshal() (dAtA []byte, err error) {
+	if len(m.ExecId) > 0 {
+		i -= len(m.ExecId)
+		copy(dAtA[i:], m.ExecId)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ExecId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ContainerId) > 0 {
+		i -= len(m.ContainerId)
+		copy(dAtA[i:], m.ContainerId)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StartContainerRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3736,12 +4301,12 @@ func (m *PidsStats) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *PidsStats) MarshalTo(dAtA []byte) (int, error) {
+func (m *StartContainerRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *PidsStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *StartContainerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3750,20 +4315,22 @@ func (m *PidsStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Limit != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.Limit))
+	if m.ExecTimeout != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.ExecTimeout))
 		i--
 		dAtA[i] = 0x10
 	}
-	if m.Current != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.Current))
+	if len(m.ContainerId) > 0 {
+		i -= len(m.ContainerId)
+		copy(dAtA[i:], m.ContainerId)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
 		i--
-		dAtA[i] = 0x8
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *MemoryData) Marshal() (dAtA []byte, err error) {
+func (m *RemoveContainerRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3773,12 +4340,12 @@ func (m *MemoryData) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *MemoryData) MarshalTo(dAtA []byte) (int, error) {
+func (m *RemoveContainerRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *MemoryData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *RemoveContainerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3787,30 +4354,22 @@ func (m *MemoryData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Limit != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.Limit))
-		i--
-		dAtA[i] = 0x20
-	}
-	if m.Failcnt != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.Failcnt))
-		i--
-		dAtA[i] = 0x18
-	}
-	if m.MaxUsage != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.MaxUsage))
+	if m.Timeout != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Timeout))
 		i--
 		dAtA[i] = 0x10
 	}
-	if m.Usage != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.Usage))
+	if len(m.ContainerId) > 0 {
+		i -= len(m.ContainerId)
+		copy(dAtA[i:], m.ContainerId)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
 		i--
-		dAtA[i] = 0x8
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *MemoryStats) Marshal() (dAtA []byte, err error) {
+func (m *ExecProcessRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3820,12 +4379,12 @@ func (m *MemoryStats) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *MemoryStats) MarshalTo(dAtA []byte) (int, error) {
+func (m *ExecProcessRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *MemoryStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ExecProcessRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3834,36 +4393,9 @@ func (m *MemoryStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Stats) > 0 {
-		for k := range m.Stats {
-			v := m.Stats[k]
-			baseI := i
-			i = encodeVarintAgent(dAtA, i, uint64(v))
-			i--
-			dAtA[i] = 0x10
-			i -= len(k)
-			copy(dAtA[i:], k)
-			i = encodeVarintAgent(dAtA, i, uint64(len(k)))
-			i--
-			dAtA[i] = 0xa
-			i = encodeVarintAgent(dAtA, i, uint64(baseI-i))
-			i--
-			dAtA[i] = 0x32
-		}
-	}
-	if m.UseHierarchy {
-		i--
-		if m.UseHierarchy {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x28
-	}
-	if m.KernelUsage != nil {
+	if m.Process != nil {
 		{
-			size, err := m.KernelUsage.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Process.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -3873,9 +4405,9 @@ func (m *MemoryStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x22
 	}
-	if m.SwapUsage != nil {
+	if m.StringUser != nil {
 		{
-			size, err := m.SwapUsage.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.StringUser.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -3885,27 +4417,24 @@ func (m *MemoryStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x1a
 	}
-	if m.Usage != nil {
-		{
-			size, err := m.Usage.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintAgent(dAtA, i, uint64(size))
-		}
+	if len(m.ExecId) > 0 {
+		i -= len(m.ExecId)
+		copy(dAtA[i:], m.ExecId)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ExecId)))
 		i--
 		dAtA[i] = 0x12
 	}
-	if m.Cache != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.Cache))
+	if len(m.ContainerId) > 0 {
+		i -= len(m.ContainerId)
+		copy(dAtA[i:], m.ContainerId)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
 		i--
-		dAtA[i] = 0x8
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *BlkioStatsEntry) Marshal() (dAtA []byte, err error) {
+func (m *SignalProcessRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3915,12 +4444,12 @@ func (m *BlkioStatsEntry) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *BlkioStatsEntry) MarshalTo(dAtA []byte) (int, error) {
+func (m *SignalProcessRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *BlkioStatsEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *SignalProcessRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3929,32 +4458,102 @@ func (m *BlkioStatsEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Value != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.Value))
+	if m.Signal != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Signal))
 		i--
-		dAtA[i] = 0x20
+		dAtA[i] = 0x18
 	}
-	if len(m.Op) > 0 {
-		i -= len(m.Op)
-		copy(dAtA[i:], m.Op)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.Op)))
+	if len(m.ExecId) > 0 {
+		i -= len(m.ExecId)
+		copy(dAtA[i:], m.ExecId)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ExecId)))
 		i--
-		dAtA[i] = 0x1a
+		dAtA[i] = 0x12
 	}
-	if m.Minor != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.Minor))
+	if len(m.ContainerId) > 0 {
+		i -= len(m.ContainerId)
+		copy(dAtA[i:], m.ContainerId)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
 		i--
-		dAtA[i] = 0x10
+		dAtA[i] = 0xa
 	}
-	if m.Major != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.Major))
+	return len(dAtA) - i, nil
+}
+
+func (m *WaitProcessRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WaitProcessRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WaitProcessRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.ExecId) > 0 {
+		i -= len(m.ExecId)
+		copy(dAtA[i:], m.ExecId)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ExecId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ContainerId) > 0 {
+		i -= len(m.ContainerId)
+		copy(dAtA[i:], m.ContainerId)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WaitProcessResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WaitProcessResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WaitProcessResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Status != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Status))
 		i--
 		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *BlkioStats) Marshal() (dAtA []byte, err error) {
+func (m *UpdateContainerRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3964,12 +4563,12 @@ func (m *BlkioStats) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *BlkioStats) MarshalTo(dAtA []byte) (int, error) {
+func (m *UpdateContainerRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *BlkioStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *UpdateContainerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -3978,122 +4577,29 @@ func (m *BlkioStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.SectorsRecursive) > 0 {
-		for iNdEx := len(m.SectorsRecursive) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.SectorsRecursive[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintAgent(dAtA, i, uint64(size))
+	if m.Resources != nil {
+		{
+			size, err := m.Resources.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
 			}
-			i--
-			dAtA[i] = 0x42
+			i -= size
+			i = encodeVarintAgent(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0x12
 	}
-	if len(m.IoTimeRecursive) > 0 {
-		for iNdEx := len(m.IoTimeRecursive) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.IoTimeRecursive[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintAgent(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x3a
-		}
-	}
-	if len(m.IoMergedRecursive) > 0 {
-		for iNdEx := len(m.IoMergedRecursive) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.IoMergedRecursive[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintAgent(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x32
-		}
-	}
-	if len(m.IoWaitTimeRecursive) > 0 {
-		for iNdEx := len(m.IoWaitTimeRecursive) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.IoWaitTimeRecursive[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintAgent(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x2a
-		}
-	}
-	if len(m.IoServiceTimeRecursive) > 0 {
-		for iNdEx := len(m.IoServiceTimeRecursive) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.IoServiceTimeRecursive[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintAgent(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x22
-		}
-	}
-	if len(m.IoQueuedRecursive) > 0 {
-		for iNdEx := len(m.IoQueuedRecursive) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.IoQueuedRecursive[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintAgent(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x1a
-		}
-	}
-	if len(m.IoServicedRecursive) > 0 {
-		for iNdEx := len(m.IoServicedRecursive) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.IoServicedRecursive[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintAgent(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x12
-		}
-	}
-	if len(m.IoServiceBytesRecursive) > 0 {
-		for iNdEx := len(m.IoServiceBytesRecursive) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.IoServiceBytesRecursive[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintAgent(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+	if len(m.ContainerId) > 0 {
+		i -= len(m.ContainerId)
+		copy(dAtA[i:], m.ContainerId)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
+		i--
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *HugetlbStats) Marshal() (dAtA []byte, err error) {
+func (m *StatsContainerRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4103,12 +4609,12 @@ func (m *HugetlbStats) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *HugetlbStats) MarshalTo(dAtA []byte) (int, error) {
+func (m *StatsContainerRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *HugetlbStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *StatsContainerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4117,25 +4623,17 @@ func (m *HugetlbStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Failcnt != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.Failcnt))
-		i--
-		dAtA[i] = 0x18
-	}
-	if m.MaxUsage != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.MaxUsage))
-		i--
-		dAtA[i] = 0x10
-	}
-	if m.Usage != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.Usage))
+	if len(m.ContainerId) > 0 {
+		i -= len(m.ContainerId)
+		copy(dAtA[i:], m.ContainerId)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
 		i--
-		dAtA[i] = 0x8
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *CgroupStats) Marshal() (dAtA []byte, err error) {
+func (m *PauseContainerRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4145,12 +4643,12 @@ func (m *CgroupStats) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CgroupStats) MarshalTo(dAtA []byte) (int, error) {
+func (m *PauseContainerRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *CgroupStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *PauseContainerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4159,84 +4657,51 @@ func (m *CgroupStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.HugetlbStats) > 0 {
-		for k := range m.HugetlbStats {
-			v := m.HugetlbStats[k]
-			baseI := i
-			if v != nil {
-				{
-					size, err := v.MarshalToSizedBuffer(dAtA[:i])
-					if err != nil {
-						return 0, err
-					}
-					i -= size
-					i = encodeVarintAgent(dAtA, i, uint64(size))
-				}
-				i--
-				dAtA[i] = 0x12
-			}
-			i -= len(k)
-			copy(dAtA[i:], k)
-			i = encodeVarintAgent(dAtA, i, uint64(len(k)))
-			i--
-			dAtA[i] = 0xa
-			i = encodeVarintAgent(dAtA, i, uint64(baseI-i))
-			i--
-			dAtA[i] = 0x2a
-		}
-	}
-	if m.BlkioStats != nil {
-		{
-			size, err := m.BlkioStats.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintAgent(dAtA, i, uint64(size))
-		}
+	if len(m.ContainerId) > 0 {
+		i -= len(m.ContainerId)
+		copy(dAtA[i:], m.ContainerId)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
 		i--
-		dAtA[i] = 0x22
+		dAtA[i] = 0xa
 	}
-	if m.PidsStats != nil {
-		{
-			size, err := m.PidsStats.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintAgent(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x1a
+	return len(dAtA) - i, nil
+}
+
+func (m *ResumeContainerRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	if m.MemoryStats != nil {
-		{
-			size, err := m.MemoryStats.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintAgent(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+	return dAtA[:n], nil
+}
+
+func (m *ResumeContainerRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResumeContainerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.CpuStats != nil {
-		{
-			size, err := m.CpuStats.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintAgent(dAtA, i, uint64(size))
-		}
+	if len(m.ContainerId) > 0 {
+		i -= len(m.ContainerId)
+		copy(dAtA[i:], m.ContainerId)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *NetworkStats) Marshal() (dAtA []byte, err error) {
+func (m *CpuUsage) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4246,12 +4711,12 @@ func (m *NetworkStats) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *NetworkStats) MarshalTo(dAtA []byte) (int, error) {
+func (m *CpuUsage) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *NetworkStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *CpuUsage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4260,57 +4725,43 @@ func (m *NetworkStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.TxDropped != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.TxDropped))
-		i--
-		dAtA[i] = 0x48
-	}
-	if m.TxErrors != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.TxErrors))
+	if m.UsageInUsermode != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.UsageInUsermode))
 		i--
-		dAtA[i] = 0x40
+		dAtA[i] = 0x20
 	}
-	if m.TxPackets != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.TxPackets))
+	if m.UsageInKernelmode != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.UsageInKernelmode))
 		i--
-		dAtA[i] = 0x38
+		dAtA[i] = 0x18
 	}
-	if m.TxBytes != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.TxBytes))
-		i--
-		dAtA[i] = 0x30
-	}
-	if m.RxDropped != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.RxDropped))
-		i--
-		dAtA[i] = 0x28
-	}
-	if m.RxErrors != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.RxErrors))
-		i--
-		dAtA[i] = 0x20
-	}
-	if m.RxPackets != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.RxPackets))
-		i--
-		dAtA[i] = 0x18
-	}
-	if m.RxBytes != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.RxBytes))
+	if len(m.PercpuUsage) > 0 {
+		dAtA7 := make([]byte, len(m.PercpuUsage)*10)
+		var j6 int
+		for _, num := range m.PercpuUsage {
+			for num >= 1<<7 {
+				dAtA7[j6] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j6++
+			}
+			dAtA7[j6] = uint8(num)
+			j6++
+		}
+		i -= j6
+		copy(dAtA[i:], dAtA7[:j6])
+		i = encodeVarintAgent(dAtA, i, uint64(j6))
 		i--
-		dAtA[i] = 0x10
+		dAtA[i] = 0x12
 	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.Name)))
+	if m.TotalUsage != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.TotalUsage))
 		i--
-		dAtA[i] = 0xa
+		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *StatsContainerResponse) Marshal() (dAtA []byte, err error) {
+func (m *ThrottlingData) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4320,12 +4771,12 @@ func (m *StatsContainerResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *StatsContainerResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *ThrottlingData) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *StatsContainerResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ThrottlingData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4334,36 +4785,25 @@ func (m *StatsContainerResponse) MarshalToSizedBuffer(dAtA []byte) (int, error)
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.NetworkStats) > 0 {
-		for iNdEx := len(m.NetworkStats) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.NetworkStats[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintAgent(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x12
-		}
+	if m.ThrottledTime != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.ThrottledTime))
+		i--
+		dAtA[i] = 0x18
 	}
-	if m.CgroupStats != nil {
-		{
-			size, err := m.CgroupStats.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintAgent(dAtA, i, uint64(size))
-		}
+	if m.ThrottledPeriods != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.ThrottledPeriods))
 		i--
-		dAtA[i] = 0xa
+		dAtA[i] = 0x10
+	}
+	if m.Periods != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Periods))
+		i--
+		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *WriteStreamRequest) Marshal() (dAtA []byte, err error) {
+func (m *CpuStats) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4373,12 +4813,12 @@ func (m *WriteStreamRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *WriteStreamRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *CpuStats) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *WriteStreamRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *CpuStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4387,31 +4827,34 @@ func (m *WriteStreamRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Data) > 0 {
-		i -= len(m.Data)
-		copy(dAtA[i:], m.Data)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.Data)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.ExecId) > 0 {
-		i -= len(m.ExecId)
-		copy(dAtA[i:], m.ExecId)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.ExecId)))
+	if m.ThrottlingData != nil {
+		{
+			size, err := m.ThrottlingData.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgent(dAtA, i, uint64(size))
+		}
 		i--
 		dAtA[i] = 0x12
 	}
-	if len(m.ContainerId) > 0 {
-		i -= len(m.ContainerId)
-		copy(dAtA[i:], m.ContainerId)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
+	if m.CpuUsage != nil {
+		{
+			size, err := m.CpuUsage.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgent(dAtA, i, uint64(size))
+		}
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *WriteStreamResponse) Marshal() (dAtA []byte, err error) {
+func (m *PidsStats) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4421,12 +4864,12 @@ func (m *WriteStreamResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *WriteStreamResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *PidsStats) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *WriteStreamResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *PidsStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4435,15 +4878,20 @@ func (m *WriteStreamResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Len != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.Len))
+	if m.Limit != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Limit))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Current != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Current))
 		i--
 		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *ReadStreamRequest) Marshal() (dAtA []byte, err error) {
+func (m *MemoryData) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4453,12 +4901,12 @@ func (m *ReadStreamRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ReadStreamRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *MemoryData) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ReadStreamRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *MemoryData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4467,29 +4915,30 @@ func (m *ReadStreamRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Len != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.Len))
+	if m.Limit != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Limit))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Failcnt != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Failcnt))
 		i--
 		dAtA[i] = 0x18
 	}
-	if len(m.ExecId) > 0 {
-		i -= len(m.ExecId)
-		copy(dAtA[i:], m.ExecId)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.ExecId)))
+	if m.MaxUsage != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.MaxUsage))
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0x10
 	}
-	if len(m.ContainerId) > 0 {
-		i -= len(m.ContainerId)
-		copy(dAtA[i:], m.ContainerId)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
+	if m.Usage != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Usage))
 		i--
-		dAtA[i] = 0xa
+		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *ReadStreamResponse) Marshal() (dAtA []byte, err error) {
+func (m *MemoryStats) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4499,12 +4948,12 @@ func (m *ReadStreamResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ReadStreamResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *MemoryStats) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ReadStreamResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *MemoryStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4513,17 +4962,78 @@ func (m *ReadStreamResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Data) > 0 {
-		i -= len(m.Data)
-		copy(dAtA[i:], m.Data)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.Data)))
+	if len(m.Stats) > 0 {
+		for k := range m.Stats {
+			v := m.Stats[k]
+			baseI := i
+			i = encodeVarintAgent(dAtA, i, uint64(v))
+			i--
+			dAtA[i] = 0x10
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintAgent(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintAgent(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if m.UseHierarchy {
 		i--
-		dAtA[i] = 0xa
+		if m.UseHierarchy {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.KernelUsage != nil {
+		{
+			size, err := m.KernelUsage.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgent(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.SwapUsage != nil {
+		{
+			size, err := m.SwapUsage.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgent(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Usage != nil {
+		{
+			size, err := m.Usage.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgent(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Cache != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Cache))
+		i--
+		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *CloseStdinRequest) Marshal() (dAtA []byte, err error) {
+func (m *BlkioStatsEntry) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4533,12 +5043,12 @@ func (m *CloseStdinRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CloseStdinRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *BlkioStatsEntry) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *CloseStdinRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *BlkioStatsEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4547,75 +5057,32 @@ func (m *CloseStdinRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.ExecId) > 0 {
-		i -= len(m.ExecId)
-		copy(dAtA[i:], m.ExecId)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.ExecId)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.ContainerId) > 0 {
-		i -= len(m.ContainerId)
-		copy(dAtA[i:], m.ContainerId)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
-}
-
-func (m *TtyWinResizeRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *TtyWinResizeRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *TtyWinResizeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Column != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.Column))
+	if m.Value != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Value))
 		i--
 		dAtA[i] = 0x20
 	}
-	if m.Row != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.Row))
+	if len(m.Op) > 0 {
+		i -= len(m.Op)
+		copy(dAtA[i:], m.Op)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Op)))
 		i--
-		dAtA[i] = 0x18
+		dAtA[i] = 0x1a
 	}
-	if len(m.ExecId) > 0 {
-		i -= len(m.ExecId)
-		copy(dAtA[i:], m.ExecId)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.ExecId)))
+	if m.Minor != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Minor))
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0x10
 	}
-	if len(m.ContainerId) > 0 {
-		i -= len(m.ContainerId)
-		copy(dAtA[i:], m.ContainerId)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
+	if m.Major != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Major))
 		i--
-		dAtA[i] = 0xa
+		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *KernelModule) Marshal() (dAtA []byte, err error) {
+func (m *BlkioStats) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4625,12 +5092,12 @@ func (m *KernelModule) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *KernelModule) MarshalTo(dAtA []byte) (int, error) {
+func (m *BlkioStats) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *KernelModule) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *BlkioStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4639,53 +5106,24 @@ func (m *KernelModule) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Parameters) > 0 {
-		for iNdEx := len(m.Parameters) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Parameters[iNdEx])
-			copy(dAtA[i:], m.Parameters[iNdEx])
-			i = encodeVarintAgent(dAtA, i, uint64(len(m.Parameters[iNdEx])))
+	if len(m.SectorsRecursive) > 0 {
+		for iNdEx := len(m.SectorsRecursive) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.SectorsRecursive[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAgent(dAtA, i, uint64(size))
+			}
 			i--
-			dAtA[i] = 0x12
+			dAtA[i] = 0x42
 		}
 	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.Name)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
-}
-
-func (m *CreateSandboxRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *CreateSandboxRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *CreateSandboxRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.KernelModules) > 0 {
-		for iNdEx := len(m.KernelModules) - 1; iNdEx >= 0; iNdEx-- {
+	if len(m.IoTimeRecursive) > 0 {
+		for iNdEx := len(m.IoTimeRecursive) - 1; iNdEx >= 0; iNdEx-- {
 			{
-				size, err := m.KernelModules[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				size, err := m.IoTimeRecursive[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
 				}
@@ -4696,34 +5134,52 @@ func (m *CreateSandboxRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			dAtA[i] = 0x3a
 		}
 	}
-	if len(m.GuestHookPath) > 0 {
-		i -= len(m.GuestHookPath)
-		copy(dAtA[i:], m.GuestHookPath)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.GuestHookPath)))
-		i--
-		dAtA[i] = 0x32
+	if len(m.IoMergedRecursive) > 0 {
+		for iNdEx := len(m.IoMergedRecursive) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.IoMergedRecursive[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAgent(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
 	}
-	if len(m.SandboxId) > 0 {
-		i -= len(m.SandboxId)
-		copy(dAtA[i:], m.SandboxId)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.SandboxId)))
-		i--
-		dAtA[i] = 0x2a
+	if len(m.IoWaitTimeRecursive) > 0 {
+		for iNdEx := len(m.IoWaitTimeRecursive) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.IoWaitTimeRecursive[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAgent(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
 	}
-	if m.SandboxPidns {
-		i--
-		if m.SandboxPidns {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+	if len(m.IoServiceTimeRecursive) > 0 {
+		for iNdEx := len(m.IoServiceTimeRecursive) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.IoServiceTimeRecursive[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAgent(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
 		}
-		i--
-		dAtA[i] = 0x20
 	}
-	if len(m.Storages) > 0 {
-		for iNdEx := len(m.Storages) - 1; iNdEx >= 0; iNdEx-- {
+	if len(m.IoQueuedRecursive) > 0 {
+		for iNdEx := len(m.IoQueuedRecursive) - 1; iNdEx >= 0; iNdEx-- {
 			{
-				size, err := m.Storages[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				size, err := m.IoQueuedRecursive[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
 				}
@@ -4734,26 +5190,38 @@ func (m *CreateSandboxRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			dAtA[i] = 0x1a
 		}
 	}
-	if len(m.Dns) > 0 {
-		for iNdEx := len(m.Dns) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Dns[iNdEx])
-			copy(dAtA[i:], m.Dns[iNdEx])
-			i = encodeVarintAgent(dAtA, i, uint64(len(m.Dns[iNdEx])))
+	if len(m.IoServicedRecursive) > 0 {
+		for iNdEx := len(m.IoServicedRecursive) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.IoServicedRecursive[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAgent(dAtA, i, uint64(size))
+			}
 			i--
 			dAtA[i] = 0x12
 		}
 	}
-	if len(m.Hostname) > 0 {
-		i -= len(m.Hostname)
-		copy(dAtA[i:], m.Hostname)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.Hostname)))
-		i--
-		dAtA[i] = 0xa
+	if len(m.IoServiceBytesRecursive) > 0 {
+		for iNdEx := len(m.IoServiceBytesRecursive) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.IoServiceBytesRecursive[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAgent(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *DestroySandboxRequest) Marshal() (dAtA []byte, err error) {
+func (m *HugetlbStats) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4763,12 +5231,12 @@ func (m *DestroySandboxRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DestroySandboxRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *HugetlbStats) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *DestroySandboxRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *HugetlbStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4777,10 +5245,25 @@ func (m *DestroySandboxRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.Failcnt != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Failcnt))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.MaxUsage != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.MaxUsage))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Usage != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Usage))
+		i--
+		dAtA[i] = 0x8
+	}
 	return len(dAtA) - i, nil
 }
 
-func (m *RemoveStaleVirtiofsShareMountsRequest) Marshal() (dAtA []byte, err error) {
+func (m *CgroupStats) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4790,12 +5273,12 @@ func (m *RemoveStaleVirtiofsShareMountsRequest) Marshal() (dAtA []byte, err erro
 	return dAtA[:n], nil
 }
 
-func (m *RemoveStaleVirtiofsShareMountsRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *CgroupStats) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *RemoveStaleVirtiofsShareMountsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *CgroupStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4804,10 +5287,84 @@ func (m *RemoveStaleVirtiofsShareMountsRequest) MarshalToSizedBuffer(dAtA []byte
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *Interfaces) Marshal() (dAtA []byte, err error) {
+	if len(m.HugetlbStats) > 0 {
+		for k := range m.HugetlbStats {
+			v := m.HugetlbStats[k]
+			baseI := i
+			if v != nil {
+				{
+					size, err := v.MarshalToSizedBuffer(dAtA[:i])
+					if err != nil {
+						return 0, err
+					}
+					i -= size
+					i = encodeVarintAgent(dAtA, i, uint64(size))
+				}
+				i--
+				dAtA[i] = 0x12
+			}
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintAgent(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintAgent(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.BlkioStats != nil {
+		{
+			size, err := m.BlkioStats.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgent(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.PidsStats != nil {
+		{
+			size, err := m.PidsStats.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgent(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.MemoryStats != nil {
+		{
+			size, err := m.MemoryStats.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgent(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.CpuStats != nil {
+		{
+			size, err := m.CpuStats.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgent(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *NetworkStats) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4817,12 +5374,12 @@ func (m *Interfaces) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Interfaces) MarshalTo(dAtA []byte) (int, error) {
+func (m *NetworkStats) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Interfaces) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *NetworkStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4831,24 +5388,57 @@ func (m *Interfaces) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Interfaces) > 0 {
-		for iNdEx := len(m.Interfaces) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Interfaces[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintAgent(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+	if m.TxDropped != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.TxDropped))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.TxErrors != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.TxErrors))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.TxPackets != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.TxPackets))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.TxBytes != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.TxBytes))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.RxDropped != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.RxDropped))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.RxErrors != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.RxErrors))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.RxPackets != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.RxPackets))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.RxBytes != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.RxBytes))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *Routes) Marshal() (dAtA []byte, err error) {
+func (m *StatsContainerResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4858,12 +5448,12 @@ func (m *Routes) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Routes) MarshalTo(dAtA []byte) (int, error) {
+func (m *StatsContainerResponse) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Routes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *StatsContainerResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4872,10 +5462,10 @@ func (m *Routes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Routes) > 0 {
-		for iNdEx := len(m.Routes) - 1; iNdEx >= 0; iNdEx-- {
+	if len(m.NetworkStats) > 0 {
+		for iNdEx := len(m.NetworkStats) - 1; iNdEx >= 0; iNdEx-- {
 			{
-				size, err := m.Routes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				size, err := m.NetworkStats[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
 				}
@@ -4883,39 +5473,12 @@ func (m *Routes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 				i = encodeVarintAgent(dAtA, i, uint64(size))
 			}
 			i--
-			dAtA[i] = 0xa
+			dAtA[i] = 0x12
 		}
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *UpdateInterfaceRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *UpdateInterfaceRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *UpdateInterfaceRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Interface != nil {
+	if m.CgroupStats != nil {
 		{
-			size, err := m.Interface.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.CgroupStats.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -4928,7 +5491,7 @@ func (m *UpdateInterfaceRequest) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	return len(dAtA) - i, nil
 }
 
-func (m *UpdateRoutesRequest) Marshal() (dAtA []byte, err error) {
+func (m *WriteStreamRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4938,12 +5501,12 @@ func (m *UpdateRoutesRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *UpdateRoutesRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *WriteStreamRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *UpdateRoutesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *WriteStreamRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4952,22 +5515,31 @@ func (m *UpdateRoutesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Routes != nil {
-		{
-			size, err := m.Routes.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintAgent(dAtA, i, uint64(size))
-		}
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.ExecId) > 0 {
+		i -= len(m.ExecId)
+		copy(dAtA[i:], m.ExecId)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ExecId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ContainerId) > 0 {
+		i -= len(m.ContainerId)
+		copy(dAtA[i:], m.ContainerId)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *UpdateEphemeralMountsRequest) Marshal() (dAtA []byte, err error) {
+func (m *WriteStreamResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4977,12 +5549,12 @@ func (m *UpdateEphemeralMountsRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *UpdateEphemeralMountsRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *WriteStreamResponse) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *UpdateEphemeralMountsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *WriteStreamResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -4991,24 +5563,15 @@ func (m *UpdateEphemeralMountsRequest) MarshalToSizedBuffer(dAtA []byte) (int, e
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Storages) > 0 {
-		for iNdEx := len(m.Storages) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Storages[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintAgent(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+	if m.Len != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Len))
+		i--
+		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *ListInterfacesRequest) Marshal() (dAtA []byte, err error) {
+func (m *ReadStreamRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5018,12 +5581,12 @@ func (m *ListInterfacesRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ListInterfacesRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *ReadStreamRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ListInterfacesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ReadStreamRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -5032,25 +5595,44 @@ func (m *ListInterfacesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *ListRoutesRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if m.Len != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Len))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.ExecId) > 0 {
+		i -= len(m.ExecId)
+		copy(dAtA[i:], m.ExecId)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ExecId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ContainerId) > 0 {
+		i -= len(m.ContainerId)
+		copy(dAtA[i:], m.ContainerId)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReadStreamResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
 	return dAtA[:n], nil
 }
 
-func (m *ListRoutesRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *ReadStreamResponse) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ListRoutesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ReadStreamResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -5059,10 +5641,17 @@ func (m *ListRoutesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0xa
+	}
 	return len(dAtA) - i, nil
 }
 
-func (m *ARPNeighbors) Marshal() (dAtA []byte, err error) {
+func (m *CloseStdinRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5072,12 +5661,12 @@ func (m *ARPNeighbors) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ARPNeighbors) MarshalTo(dAtA []byte) (int, error) {
+func (m *CloseStdinRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ARPNeighbors) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *CloseStdinRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -5086,24 +5675,24 @@ func (m *ARPNeighbors) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.ARPNeighbors) > 0 {
-		for iNdEx := len(m.ARPNeighbors) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.ARPNeighbors[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintAgent(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+	if len(m.ExecId) > 0 {
+		i -= len(m.ExecId)
+		copy(dAtA[i:], m.ExecId)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ExecId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ContainerId) > 0 {
+		i -= len(m.ContainerId)
+		copy(dAtA[i:], m.ContainerId)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
+		i--
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *AddARPNeighborsRequest) Marshal() (dAtA []byte, err error) {
+func (m *TtyWinResizeRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5113,12 +5702,12 @@ func (m *AddARPNeighborsRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *AddARPNeighborsRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *TtyWinResizeRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *AddARPNeighborsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *TtyWinResizeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -5127,22 +5716,34 @@ func (m *AddARPNeighborsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error)
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Neighbors != nil {
-		{
-			size, err := m.Neighbors.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintAgent(dAtA, i, uint64(size))
-		}
+	if m.Column != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Column))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Row != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Row))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.ExecId) > 0 {
+		i -= len(m.ExecId)
+		copy(dAtA[i:], m.ExecId)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ExecId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ContainerId) > 0 {
+		i -= len(m.ContainerId)
+		copy(dAtA[i:], m.ContainerId)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *GetIPTablesRequest) Marshal() (dAtA []byte, err error) {
+func (m *KernelModule) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5152,12 +5753,12 @@ func (m *GetIPTablesRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GetIPTablesRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *KernelModule) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *GetIPTablesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *KernelModule) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -5166,20 +5767,26 @@ func (m *GetIPTablesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.IsIpv6 {
-		i--
-		if m.IsIpv6 {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+	if len(m.Parameters) > 0 {
+		for iNdEx := len(m.Parameters) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Parameters[iNdEx])
+			copy(dAtA[i:], m.Parameters[iNdEx])
+			i = encodeVarintAgent(dAtA, i, uint64(len(m.Parameters[iNdEx])))
+			i--
+			dAtA[i] = 0x12
 		}
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Name)))
 		i--
-		dAtA[i] = 0x8
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *GetIPTablesResponse) Marshal() (dAtA []byte, err error) {
+func (m *CreateSandboxRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5189,12 +5796,12 @@ func (m *GetIPTablesResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GetIPTablesResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *CreateSandboxRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *GetIPTablesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *CreateSandboxRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -5203,17 +5810,87 @@ func (m *GetIPTablesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Data) > 0 {
-		i -= len(m.Data)
-		copy(dAtA[i:], m.Data)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.Data)))
+	if len(m.GuestServices) > 0 {
+		for iNdEx := len(m.GuestServices) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.GuestServices[iNdEx])
+			copy(dAtA[i:], m.GuestServices[iNdEx])
+			i = encodeVarintAgent(dAtA, i, uint64(len(m.GuestServices[iNdEx])))
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if len(m.KernelModules) > 0 {
+		for iNdEx := len(m.KernelModules) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.KernelModules[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAgent(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.GuestHookPath) > 0 {
+		i -= len(m.GuestHookPath)
+		copy(dAtA[i:], m.GuestHookPath)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.GuestHookPath)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.SandboxId) > 0 {
+		i -= len(m.SandboxId)
+		copy(dAtA[i:], m.SandboxId)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.SandboxId)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.SandboxPidns {
+		i--
+		if m.SandboxPidns {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Storages) > 0 {
+		for iNdEx := len(m.Storages) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Storages[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAgent(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Dns) > 0 {
+		for iNdEx := len(m.Dns) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Dns[iNdEx])
+			copy(dAtA[i:], m.Dns[iNdEx])
+			i = encodeVarintAgent(dAtA, i, uint64(len(m.Dns[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Hostname) > 0 {
+		i -= len(m.Hostname)
+		copy(dAtA[i:], m.Hostname)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Hostname)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *SetIPTablesRequest) Marshal() (dAtA []byte, err error) {
+func (m *DestroySandboxRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5223,12 +5900,12 @@ func (m *SetIPTablesRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *SetIPTablesRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *DestroySandboxRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *SetIPTablesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *DestroySandboxRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -5237,27 +5914,10 @@ func (m *SetIPTablesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Data) > 0 {
-		i -= len(m.Data)
-		copy(dAtA[i:], m.Data)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.Data)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.IsIpv6 {
-		i--
-		if m.IsIpv6 {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x8
-	}
 	return len(dAtA) - i, nil
 }
 
-func (m *SetIPTablesResponse) Marshal() (dAtA []byte, err error) {
+func (m *RemoveStaleVirtiofsShareMountsRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5267,12 +5927,12 @@ func (m *SetIPTablesResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *SetIPTablesResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *RemoveStaleVirtiofsShareMountsRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *SetIPTablesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *RemoveStaleVirtiofsShareMountsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -5281,17 +5941,10 @@ func (m *SetIPTablesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Data) > 0 {
-		i -= len(m.Data)
-		copy(dAtA[i:], m.Data)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.Data)))
-		i--
-		dAtA[i] = 0xa
-	}
 	return len(dAtA) - i, nil
 }
 
-func (m *OnlineCPUMemRequest) Marshal() (dAtA []byte, err error) {
+func (m *Interfaces) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5301,12 +5954,12 @@ func (m *OnlineCPUMemRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *OnlineCPUMemRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *Interfaces) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *OnlineCPUMemRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *Interfaces) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -5315,35 +5968,24 @@ func (m *OnlineCPUMemRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.CpuOnly {
-		i--
-		if m.CpuOnly {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x18
-	}
-	if m.NbCpus != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.NbCpus))
-		i--
-		dAtA[i] = 0x10
-	}
-	if m.Wait {
-		i--
-		if m.Wait {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+	if len(m.Interfaces) > 0 {
+		for iNdEx := len(m.Interfaces) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Interfaces[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAgent(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
 		}
-		i--
-		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *ReseedRandomDevRequest) Marshal() (dAtA []byte, err error) {
+func (m *Routes) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5353,12 +5995,12 @@ func (m *ReseedRandomDevRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ReseedRandomDevRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *Routes) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ReseedRandomDevRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *Routes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -5367,17 +6009,24 @@ func (m *ReseedRandomDevRequest) MarshalToSizedBuffer(dAtA []byte) (int, error)
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Data) > 0 {
-		i -= len(m.Data)
-		copy(dAtA[i:], m.Data)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.Data)))
-		i--
-		dAtA[i] = 0x12
+	if len(m.Routes) > 0 {
+		for iNdEx := len(m.Routes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Routes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAgent(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *AgentDetails) Marshal() (dAtA []byte, err error) {
+func (m *UpdateInterfaceRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5387,12 +6036,12 @@ func (m *AgentDetails) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *AgentDetails) MarshalTo(dAtA []byte) (int, error) {
+func (m *UpdateInterfaceRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *AgentDetails) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *UpdateInterfaceRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -5401,55 +6050,22 @@ func (m *AgentDetails) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.SupportsSeccomp {
-		i--
-		if m.SupportsSeccomp {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x28
-	}
-	if len(m.StorageHandlers) > 0 {
-		for iNdEx := len(m.StorageHandlers) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.StorageHandlers[iNdEx])
-			copy(dAtA[i:], m.StorageHandlers[iNdEx])
-			i = encodeVarintAgent(dAtA, i, uint64(len(m.StorageHandlers[iNdEx])))
-			i--
-			dAtA[i] = 0x22
-		}
-	}
-	if len(m.DeviceHandlers) > 0 {
-		for iNdEx := len(m.DeviceHandlers) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.DeviceHandlers[iNdEx])
-			copy(dAtA[i:], m.DeviceHandlers[iNdEx])
-			i = encodeVarintAgent(dAtA, i, uint64(len(m.DeviceHandlers[iNdEx])))
-			i--
-			dAtA[i] = 0x1a
-		}
-	}
-	if m.InitDaemon {
-		i--
-		if m.InitDaemon {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+	if m.Interface != nil {
+		{
+			size, err := m.Interface.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgent(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0x10
-	}
-	if len(m.Version) > 0 {
-		i -= len(m.Version)
-		copy(dAtA[i:], m.Version)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.Version)))
-		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *GuestDetailsRequest) Marshal() (dAtA []byte, err error) {
+func (m *UpdateRoutesRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5459,12 +6075,12 @@ func (m *GuestDetailsRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GuestDetailsRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *UpdateRoutesRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *GuestDetailsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *UpdateRoutesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -5473,30 +6089,22 @@ func (m *GuestDetailsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.MemHotplugProbe {
-		i--
-		if m.MemHotplugProbe {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x10
-	}
-	if m.MemBlockSize {
-		i--
-		if m.MemBlockSize {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+	if m.Routes != nil {
+		{
+			size, err := m.Routes.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgent(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0x8
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *GuestDetailsResponse) Marshal() (dAtA []byte, err error) {
+func (m *UpdateEphemeralMountsRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5506,12 +6114,12 @@ func (m *GuestDetailsResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GuestDetailsResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *UpdateEphemeralMountsRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *GuestDetailsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *UpdateEphemeralMountsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -5520,37 +6128,24 @@ func (m *GuestDetailsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.SupportMemHotplugProbe {
-		i--
-		if m.SupportMemHotplugProbe {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x18
-	}
-	if m.AgentDetails != nil {
-		{
-			size, err := m.AgentDetails.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
+	if len(m.Storages) > 0 {
+		for iNdEx := len(m.Storages) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Storages[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAgent(dAtA, i, uint64(size))
 			}
-			i -= size
-			i = encodeVarintAgent(dAtA, i, uint64(size))
+			i--
+			dAtA[i] = 0xa
 		}
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.MemBlockSizeBytes != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.MemBlockSizeBytes))
-		i--
-		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *MemHotplugByProbeRequest) Marshal() (dAtA []byte, err error) {
+func (m *ListInterfacesRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5560,12 +6155,12 @@ func (m *MemHotplugByProbeRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *MemHotplugByProbeRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *ListInterfacesRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *MemHotplugByProbeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ListInterfacesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -5574,28 +6169,10 @@ func (m *MemHotplugByProbeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.MemHotplugProbeAddr) > 0 {
-		dAtA24 := make([]byte, len(m.MemHotplugProbeAddr)*10)
-		var j23 int
-		for _, num := range m.MemHotplugProbeAddr {
-			for num >= 1<<7 {
-				dAtA24[j23] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j23++
-			}
-			dAtA24[j23] = uint8(num)
-			j23++
-		}
-		i -= j23
-		copy(dAtA[i:], dAtA24[:j23])
-		i = encodeVarintAgent(dAtA, i, uint64(j23))
-		i--
-		dAtA[i] = 0xa
-	}
 	return len(dAtA) - i, nil
 }
 
-func (m *SetGuestDateTimeRequest) Marshal() (dAtA []byte, err error) {
+func (m *ListRoutesRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5605,12 +6182,12 @@ func (m *SetGuestDateTimeRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *SetGuestDateTimeRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *ListRoutesRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *SetGuestDateTimeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ListRoutesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -5619,20 +6196,10 @@ func (m *SetGuestDateTimeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error)
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Usec != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.Usec))
-		i--
-		dAtA[i] = 0x10
-	}
-	if m.Sec != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.Sec))
-		i--
-		dAtA[i] = 0x8
-	}
 	return len(dAtA) - i, nil
 }
 
-func (m *FSGroup) Marshal() (dAtA []byte, err error) {
+func (m *ARPNeighbors) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5642,12 +6209,12 @@ func (m *FSGroup) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *FSGroup) MarshalTo(dAtA []byte) (int, error) {
+func (m *ARPNeighbors) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *FSGroup) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ARPNeighbors) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -5656,20 +6223,24 @@ func (m *FSGroup) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.GroupChangePolicy != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.GroupChangePolicy))
-		i--
-		dAtA[i] = 0x18
-	}
-	if m.GroupId != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.GroupId))
-		i--
-		dAtA[i] = 0x10
+	if len(m.ARPNeighbors) > 0 {
+		for iNdEx := len(m.ARPNeighbors) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ARPNeighbors[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAgent(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *Storage) Marshal() (dAtA []byte, err error) {
+func (m *AddARPNeighborsRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5679,12 +6250,12 @@ func (m *Storage) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Storage) MarshalTo(dAtA []byte) (int, error) {
+func (m *AddARPNeighborsRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Storage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *AddARPNeighborsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -5693,9 +6264,9 @@ func (m *Storage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.FsGroup != nil {
+	if m.Neighbors != nil {
 		{
-			size, err := m.FsGroup.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Neighbors.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -5703,58 +6274,12 @@ func (m *Storage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintAgent(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0x3a
-	}
-	if len(m.MountPoint) > 0 {
-		i -= len(m.MountPoint)
-		copy(dAtA[i:], m.MountPoint)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.MountPoint)))
-		i--
-		dAtA[i] = 0x32
-	}
-	if len(m.Options) > 0 {
-		for iNdEx := len(m.Options) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Options[iNdEx])
-			copy(dAtA[i:], m.Options[iNdEx])
-			i = encodeVarintAgent(dAtA, i, uint64(len(m.Options[iNdEx])))
-			i--
-			dAtA[i] = 0x2a
-		}
-	}
-	if len(m.Fstype) > 0 {
-		i -= len(m.Fstype)
-		copy(dAtA[i:], m.Fstype)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.Fstype)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if len(m.Source) > 0 {
-		i -= len(m.Source)
-		copy(dAtA[i:], m.Source)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.Source)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.DriverOptions) > 0 {
-		for iNdEx := len(m.DriverOptions) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.DriverOptions[iNdEx])
-			copy(dAtA[i:], m.DriverOptions[iNdEx])
-			i = encodeVarintAgent(dAtA, i, uint64(len(m.DriverOptions[iNdEx])))
-			i--
-			dAtA[i] = 0x12
-		}
-	}
-	if len(m.Driver) > 0 {
-		i -= len(m.Driver)
-		copy(dAtA[i:], m.Driver)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.Driver)))
-		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *Device) Marshal() (dAtA []byte, err error) {
+func (m *GetIPTablesRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5764,12 +6289,12 @@ func (m *Device) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Device) MarshalTo(dAtA []byte) (int, error) {
+func (m *GetIPTablesRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Device) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *GetIPTablesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -5778,47 +6303,20 @@ func (m *Device) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Options) > 0 {
-		for iNdEx := len(m.Options) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Options[iNdEx])
-			copy(dAtA[i:], m.Options[iNdEx])
-			i = encodeVarintAgent(dAtA, i, uint64(len(m.Options[iNdEx])))
-			i--
-			dAtA[i] = 0x2a
-		}
-	}
-	if len(m.ContainerPath) > 0 {
-		i -= len(m.ContainerPath)
-		copy(dAtA[i:], m.ContainerPath)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerPath)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if len(m.VmPath) > 0 {
-		i -= len(m.VmPath)
-		copy(dAtA[i:], m.VmPath)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.VmPath)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.Type) > 0 {
-		i -= len(m.Type)
-		copy(dAtA[i:], m.Type)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.Type)))
+	if m.IsIpv6 {
 		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Id) > 0 {
-		i -= len(m.Id)
-		copy(dAtA[i:], m.Id)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.Id)))
+		if m.IsIpv6 {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
 		i--
-		dAtA[i] = 0xa
+		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *StringUser) Marshal() (dAtA []byte, err error) {
+func (m *GetIPTablesResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5828,12 +6326,12 @@ func (m *StringUser) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *StringUser) MarshalTo(dAtA []byte) (int, error) {
+func (m *GetIPTablesResponse) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *StringUser) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *GetIPTablesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -5842,33 +6340,17 @@ func (m *StringUser) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.AdditionalGids) > 0 {
-		for iNdEx := len(m.AdditionalGids) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.AdditionalGids[iNdEx])
-			copy(dAtA[i:], m.AdditionalGids[iNdEx])
-			i = encodeVarintAgent(dAtA, i, uint64(len(m.AdditionalGids[iNdEx])))
-			i--
-			dAtA[i] = 0x1a
-		}
-	}
-	if len(m.Gid) > 0 {
-		i -= len(m.Gid)
-		copy(dAtA[i:], m.Gid)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.Gid)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Uid) > 0 {
-		i -= len(m.Uid)
-		copy(dAtA[i:], m.Uid)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.Uid)))
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Data)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *CopyFileRequest) Marshal() (dAtA []byte, err error) {
+func (m *SetIPTablesRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5878,12 +6360,12 @@ func (m *CopyFileRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CopyFileRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *SetIPTablesRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *CopyFileRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *SetIPTablesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -5897,49 +6379,22 @@ func (m *CopyFileRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		copy(dAtA[i:], m.Data)
 		i = encodeVarintAgent(dAtA, i, uint64(len(m.Data)))
 		i--
-		dAtA[i] = 0x42
-	}
-	if m.Offset != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.Offset))
-		i--
-		dAtA[i] = 0x38
-	}
-	if m.Gid != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.Gid))
-		i--
-		dAtA[i] = 0x30
-	}
-	if m.Uid != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.Uid))
-		i--
-		dAtA[i] = 0x28
-	}
-	if m.DirMode != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.DirMode))
-		i--
-		dAtA[i] = 0x20
-	}
-	if m.FileMode != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.FileMode))
-		i--
-		dAtA[i] = 0x18
+		dAtA[i] = 0x12
 	}
-	if m.FileSize != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.FileSize))
+	if m.IsIpv6 {
 		i--
-		dAtA[i] = 0x10
-	}
-	if len(m.Path) > 0 {
-		i -= len(m.Path)
-		copy(dAtA[i:], m.Path)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.Path)))
+		if m.IsIpv6 {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
 		i--
-		dAtA[i] = 0xa
+		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *GetOOMEventRequest) Marshal() (dAtA []byte, err error) {
+func (m *SetIPTablesResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5949,12 +6404,12 @@ func (m *GetOOMEventRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GetOOMEventRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *SetIPTablesResponse) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *GetOOMEventRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *SetIPTablesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -5963,10 +6418,17 @@ func (m *GetOOMEventRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0xa
+	}
 	return len(dAtA) - i, nil
 }
 
-func (m *OOMEvent) Marshal() (dAtA []byte, err error) {
+func (m *OnlineCPUMemRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -5976,12 +6438,12 @@ func (m *OOMEvent) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *OOMEvent) MarshalTo(dAtA []byte) (int, error) {
+func (m *OnlineCPUMemRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *OOMEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *OnlineCPUMemRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -5990,17 +6452,35 @@ func (m *OOMEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.ContainerId) > 0 {
-		i -= len(m.ContainerId)
-		copy(dAtA[i:], m.ContainerId)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
+	if m.CpuOnly {
 		i--
-		dAtA[i] = 0xa
+		if m.CpuOnly {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.NbCpus != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.NbCpus))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Wait {
+		i--
+		if m.Wait {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *AddSwapRequest) Marshal() (dAtA []byte, err error) {
+func (m *ReseedRandomDevRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -6010,12 +6490,12 @@ func (m *AddSwapRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *AddSwapRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *ReseedRandomDevRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *AddSwapRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ReseedRandomDevRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -6024,28 +6504,17 @@ func (m *AddSwapRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.PCIPath) > 0 {
-		dAtA27 := make([]byte, len(m.PCIPath)*10)
-		var j26 int
-		for _, num := range m.PCIPath {
-			for num >= 1<<7 {
-				dAtA27[j26] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j26++
-			}
-			dAtA27[j26] = uint8(num)
-			j26++
-		}
-		i -= j26
-		copy(dAtA[i:], dAtA27[:j26])
-		i = encodeVarintAgent(dAtA, i, uint64(j26))
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Data)))
 		i--
-		dAtA[i] = 0xa
+		dAtA[i] = 0x12
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *GetMetricsRequest) Marshal() (dAtA []byte, err error) {
+func (m *AgentDetails) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -6055,12 +6524,12 @@ func (m *GetMetricsRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GetMetricsRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *AgentDetails) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *GetMetricsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *AgentDetails) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -6069,25 +6538,77 @@ func (m *GetMetricsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *Metrics) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if len(m.ImageMetadata) > 0 {
+		i -= len(m.ImageMetadata)
+		copy(dAtA[i:], m.ImageMetadata)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ImageMetadata)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.SupportsSeccomp {
+		i--
+		if m.SupportsSeccomp {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.StorageHandlers) > 0 {
+		for iNdEx := len(m.StorageHandlers) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.StorageHandlers[iNdEx])
+			copy(dAtA[i:], m.StorageHandlers[iNdEx])
+			i = encodeVarintAgent(dAtA, i, uint64(len(m.StorageHandlers[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.DeviceHandlers) > 0 {
+		for iNdEx := len(m.DeviceHandlers) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.DeviceHandlers[iNdEx])
+			copy(dAtA[i:], m.DeviceHandlers[iNdEx])
+			i = encodeVarintAgent(dAtA, i, uint64(len(m.DeviceHandlers[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.InitDaemon {
+		i--
+		if m.InitDaemon {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Version) > 0 {
+		i -= len(m.Version)
+		copy(dAtA[i:], m.Version)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Version)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GuestDetailsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
 	return dAtA[:n], nil
 }
 
-func (m *Metrics) MarshalTo(dAtA []byte) (int, error) {
+func (m *GuestDetailsRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Metrics) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *GuestDetailsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -6096,17 +6617,30 @@ func (m *Metrics) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Metrics) > 0 {
-		i -= len(m.Metrics)
-		copy(dAtA[i:], m.Metrics)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.Metrics)))
+	if m.MemHotplugProbe {
 		i--
-		dAtA[i] = 0xa
+		if m.MemHotplugProbe {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.MemBlockSize {
+		i--
+		if m.MemBlockSize {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *VolumeStatsRequest) Marshal() (dAtA []byte, err error) {
+func (m *GuestDetailsResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -6116,12 +6650,12 @@ func (m *VolumeStatsRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *VolumeStatsRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *GuestDetailsResponse) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *VolumeStatsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *GuestDetailsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -6130,17 +6664,37 @@ func (m *VolumeStatsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.VolumeGuestPath) > 0 {
-		i -= len(m.VolumeGuestPath)
-		copy(dAtA[i:], m.VolumeGuestPath)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.VolumeGuestPath)))
+	if m.SupportMemHotplugProbe {
 		i--
-		dAtA[i] = 0xa
+		if m.SupportMemHotplugProbe {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.AgentDetails != nil {
+		{
+			size, err := m.AgentDetails.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgent(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.MemBlockSizeBytes != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.MemBlockSizeBytes))
+		i--
+		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *ResizeVolumeRequest) Marshal() (dAtA []byte, err error) {
+func (m *MemHotplugByProbeRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -6150,12 +6704,12 @@ func (m *ResizeVolumeRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ResizeVolumeRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *MemHotplugByProbeRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ResizeVolumeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *MemHotplugByProbeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -6164,1284 +6718,1761 @@ func (m *ResizeVolumeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Size_ != 0 {
-		i = encodeVarintAgent(dAtA, i, uint64(m.Size_))
-		i--
-		dAtA[i] = 0x10
-	}
-	if len(m.VolumeGuestPath) > 0 {
-		i -= len(m.VolumeGuestPath)
-		copy(dAtA[i:], m.VolumeGuestPath)
-		i = encodeVarintAgent(dAtA, i, uint64(len(m.VolumeGuestPath)))
+	if len(m.MemHotplugProbeAddr) > 0 {
+		dAtA24 := make([]byte, len(m.MemHotplugProbeAddr)*10)
+		var j23 int
+		for _, num := range m.MemHotplugProbeAddr {
+			for num >= 1<<7 {
+				dAtA24[j23] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j23++
+			}
+			dAtA24[j23] = uint8(num)
+			j23++
+		}
+		i -= j23
+		copy(dAtA[i:], dAtA24[:j23])
+		i = encodeVarintAgent(dAtA, i, uint64(j23))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintAgent(dAtA []byte, offset int, v uint64) int {
-	offset -= sovAgent(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *SetGuestDateTimeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *CreateContainerRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+
+func (m *SetGuestDateTimeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetGuestDateTimeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.ContainerId)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	l = len(m.ExecId)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	if m.StringUser != nil {
-		l = m.StringUser.Size()
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	if len(m.Devices) > 0 {
-		for _, e := range m.Devices {
-			l = e.Size()
-			n += 1 + l + sovAgent(uint64(l))
-		}
-	}
-	if len(m.Storages) > 0 {
-		for _, e := range m.Storages {
-			l = e.Size()
-			n += 1 + l + sovAgent(uint64(l))
-		}
-	}
-	if m.OCI != nil {
-		l = m.OCI.Size()
-		n += 1 + l + sovAgent(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.SandboxPidns {
-		n += 2
+	if m.Usec != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Usec))
+		i--
+		dAtA[i] = 0x10
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if m.Sec != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Sec))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *StartContainerRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.ContainerId)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *FSGroup) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *RemoveContainerRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *FSGroup) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FSGroup) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.ContainerId)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Timeout != 0 {
-		n += 1 + sovAgent(uint64(m.Timeout))
+	if m.GroupChangePolicy != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.GroupChangePolicy))
+		i--
+		dAtA[i] = 0x18
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if m.GroupId != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.GroupId))
+		i--
+		dAtA[i] = 0x10
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *ExecProcessRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.ContainerId)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	l = len(m.ExecId)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	if m.StringUser != nil {
-		l = m.StringUser.Size()
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	if m.Process != nil {
-		l = m.Process.Size()
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *Storage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *SignalProcessRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *Storage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Storage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.ContainerId)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	l = len(m.ExecId)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
+	if m.FsGroup != nil {
+		{
+			size, err := m.FsGroup.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgent(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
 	}
-	if m.Signal != 0 {
-		n += 1 + sovAgent(uint64(m.Signal))
+	if len(m.MountPoint) > 0 {
+		i -= len(m.MountPoint)
+		copy(dAtA[i:], m.MountPoint)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.MountPoint)))
+		i--
+		dAtA[i] = 0x32
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.Options) > 0 {
+		for iNdEx := len(m.Options) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Options[iNdEx])
+			copy(dAtA[i:], m.Options[iNdEx])
+			i = encodeVarintAgent(dAtA, i, uint64(len(m.Options[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
 	}
-	return n
-}
-
-func (m *WaitProcessRequest) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.Fstype) > 0 {
+		i -= len(m.Fstype)
+		copy(dAtA[i:], m.Fstype)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Fstype)))
+		i--
+		dAtA[i] = 0x22
 	}
-	var l int
-	_ = l
-	l = len(m.ContainerId)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
+	if len(m.Source) > 0 {
+		i -= len(m.Source)
+		copy(dAtA[i:], m.Source)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Source)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	l = len(m.ExecId)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
+	if len(m.DriverOptions) > 0 {
+		for iNdEx := len(m.DriverOptions) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.DriverOptions[iNdEx])
+			copy(dAtA[i:], m.DriverOptions[iNdEx])
+			i = encodeVarintAgent(dAtA, i, uint64(len(m.DriverOptions[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.Driver) > 0 {
+		i -= len(m.Driver)
+		copy(dAtA[i:], m.Driver)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Driver)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *WaitProcessResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Status != 0 {
-		n += 1 + sovAgent(uint64(m.Status))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *Device) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *UpdateContainerRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *Device) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Device) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.ContainerId)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Resources != nil {
-		l = m.Resources.Size()
-		n += 1 + l + sovAgent(uint64(l))
+	if len(m.Options) > 0 {
+		for iNdEx := len(m.Options) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Options[iNdEx])
+			copy(dAtA[i:], m.Options[iNdEx])
+			i = encodeVarintAgent(dAtA, i, uint64(len(m.Options[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.ContainerPath) > 0 {
+		i -= len(m.ContainerPath)
+		copy(dAtA[i:], m.ContainerPath)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerPath)))
+		i--
+		dAtA[i] = 0x22
 	}
-	return n
-}
-
-func (m *StatsContainerRequest) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.VmPath) > 0 {
+		i -= len(m.VmPath)
+		copy(dAtA[i:], m.VmPath)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.VmPath)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	var l int
-	_ = l
-	l = len(m.ContainerId)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
+	if len(m.Type) > 0 {
+		i -= len(m.Type)
+		copy(dAtA[i:], m.Type)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Type)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.Id) > 0 {
+		i -= len(m.Id)
+		copy(dAtA[i:], m.Id)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Id)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *PauseContainerRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *StringUser) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *StringUser) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StringUser) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.ContainerId)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
-}
-
-func (m *ResumeContainerRequest) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.AdditionalGids) > 0 {
+		for iNdEx := len(m.AdditionalGids) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.AdditionalGids[iNdEx])
+			copy(dAtA[i:], m.AdditionalGids[iNdEx])
+			i = encodeVarintAgent(dAtA, i, uint64(len(m.AdditionalGids[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
 	}
-	var l int
-	_ = l
-	l = len(m.ContainerId)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
+	if len(m.Gid) > 0 {
+		i -= len(m.Gid)
+		copy(dAtA[i:], m.Gid)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Gid)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.Uid) > 0 {
+		i -= len(m.Uid)
+		copy(dAtA[i:], m.Uid)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Uid)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *CpuUsage) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *CopyFileRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *CopyFileRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CopyFileRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.TotalUsage != 0 {
-		n += 1 + sovAgent(uint64(m.TotalUsage))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.PercpuUsage) > 0 {
-		l = 0
-		for _, e := range m.PercpuUsage {
-			l += sovAgent(uint64(e))
-		}
-		n += 1 + sovAgent(uint64(l)) + l
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x42
 	}
-	if m.UsageInKernelmode != 0 {
-		n += 1 + sovAgent(uint64(m.UsageInKernelmode))
+	if m.Offset != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Offset))
+		i--
+		dAtA[i] = 0x38
 	}
-	if m.UsageInUsermode != 0 {
-		n += 1 + sovAgent(uint64(m.UsageInUsermode))
+	if m.Gid != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Gid))
+		i--
+		dAtA[i] = 0x30
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if m.Uid != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Uid))
+		i--
+		dAtA[i] = 0x28
 	}
-	return n
+	if m.DirMode != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.DirMode))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.FileMode != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.FileMode))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.FileSize != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.FileSize))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *ThrottlingData) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ReadFileRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ReadFileRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReadFileRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Periods != 0 {
-		n += 1 + sovAgent(uint64(m.Periods))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.ThrottledPeriods != 0 {
-		n += 1 + sovAgent(uint64(m.ThrottledPeriods))
+	if m.Offset != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Offset))
+		i--
+		dAtA[i] = 0x18
 	}
-	if m.ThrottledTime != 0 {
-		n += 1 + sovAgent(uint64(m.ThrottledTime))
+	if m.MaxSize != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.MaxSize))
+		i--
+		dAtA[i] = 0x10
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *CpuStats) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ReadFileResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ReadFileResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReadFileResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.CpuUsage != nil {
-		l = m.CpuUsage.Size()
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	if m.ThrottlingData != nil {
-		l = m.ThrottlingData.Size()
-		n += 1 + l + sovAgent(uint64(l))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *PidsStats) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *GetDiagnosticsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *GetDiagnosticsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetDiagnosticsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Current != 0 {
-		n += 1 + sovAgent(uint64(m.Current))
-	}
-	if m.Limit != 0 {
-		n += 1 + sovAgent(uint64(m.Limit))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	if m.MaxKernelLogSize != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.MaxKernelLogSize))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *MemoryData) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *Diagnostics) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *Diagnostics) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Diagnostics) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Usage != 0 {
-		n += 1 + sovAgent(uint64(m.Usage))
-	}
-	if m.MaxUsage != 0 {
-		n += 1 + sovAgent(uint64(m.MaxUsage))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Failcnt != 0 {
-		n += 1 + sovAgent(uint64(m.Failcnt))
+	if len(m.Processes) > 0 {
+		i -= len(m.Processes)
+		copy(dAtA[i:], m.Processes)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Processes)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	if m.Limit != 0 {
-		n += 1 + sovAgent(uint64(m.Limit))
+	if len(m.Mounts) > 0 {
+		i -= len(m.Mounts)
+		copy(dAtA[i:], m.Mounts)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Mounts)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.KernelLog) > 0 {
+		i -= len(m.KernelLog)
+		copy(dAtA[i:], m.KernelLog)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.KernelLog)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *MemoryStats) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *CriuOptions) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *CriuOptions) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CriuOptions) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Cache != 0 {
-		n += 1 + sovAgent(uint64(m.Cache))
-	}
-	if m.Usage != nil {
-		l = m.Usage.Size()
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	if m.SwapUsage != nil {
-		l = m.SwapUsage.Size()
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	if m.KernelUsage != nil {
-		l = m.KernelUsage.Size()
-		n += 1 + l + sovAgent(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.UseHierarchy {
-		n += 2
+	if m.ExtUnixSockets {
+		i--
+		if m.ExtUnixSockets {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
 	}
-	if len(m.Stats) > 0 {
-		for k, v := range m.Stats {
-			_ = k
-			_ = v
-			mapEntrySize := 1 + len(k) + sovAgent(uint64(len(k))) + 1 + sovAgent(uint64(v))
-			n += mapEntrySize + 1 + sovAgent(uint64(mapEntrySize))
+	if m.FileLocks {
+		i--
+		if m.FileLocks {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
+		i--
+		dAtA[i] = 0x10
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if m.TcpEstablished {
+		i--
+		if m.TcpEstablished {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *BlkioStatsEntry) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Major != 0 {
-		n += 1 + sovAgent(uint64(m.Major))
-	}
-	if m.Minor != 0 {
-		n += 1 + sovAgent(uint64(m.Minor))
-	}
-	l = len(m.Op)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	if m.Value != 0 {
-		n += 1 + sovAgent(uint64(m.Value))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *CheckpointContainerRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *BlkioStats) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *CheckpointContainerRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CheckpointContainerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.IoServiceBytesRecursive) > 0 {
-		for _, e := range m.IoServiceBytesRecursive {
-			l = e.Size()
-			n += 1 + l + sovAgent(uint64(l))
-		}
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.IoServicedRecursive) > 0 {
-		for _, e := range m.IoServicedRecursive {
-			l = e.Size()
-			n += 1 + l + sovAgent(uint64(l))
+	if m.Options != nil {
+		{
+			size, err := m.Options.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgent(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0x22
 	}
-	if len(m.IoQueuedRecursive) > 0 {
-		for _, e := range m.IoQueuedRecursive {
-			l = e.Size()
-			n += 1 + l + sovAgent(uint64(l))
+	if m.LeaveRunning {
+		i--
+		if m.LeaveRunning {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
+		i--
+		dAtA[i] = 0x18
 	}
-	if len(m.IoServiceTimeRecursive) > 0 {
-		for _, e := range m.IoServiceTimeRecursive {
-			l = e.Size()
-			n += 1 + l + sovAgent(uint64(l))
-		}
+	if len(m.ImagePath) > 0 {
+		i -= len(m.ImagePath)
+		copy(dAtA[i:], m.ImagePath)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ImagePath)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if len(m.IoWaitTimeRecursive) > 0 {
-		for _, e := range m.IoWaitTimeRecursive {
-			l = e.Size()
-			n += 1 + l + sovAgent(uint64(l))
-		}
+	if len(m.ContainerId) > 0 {
+		i -= len(m.ContainerId)
+		copy(dAtA[i:], m.ContainerId)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
+		i--
+		dAtA[i] = 0xa
 	}
-	if len(m.IoMergedRecursive) > 0 {
-		for _, e := range m.IoMergedRecursive {
-			l = e.Size()
-			n += 1 + l + sovAgent(uint64(l))
-		}
+	return len(dAtA) - i, nil
+}
+
+func (m *CheckpointContainerResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	if len(m.IoTimeRecursive) > 0 {
-		for _, e := range m.IoTimeRecursive {
-			l = e.Size()
-			n += 1 + l + sovAgent(uint64(l))
-		}
+	return dAtA[:n], nil
+}
+
+func (m *CheckpointContainerResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CheckpointContainerResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.SectorsRecursive) > 0 {
-		for _, e := range m.SectorsRecursive {
-			l = e.Size()
-			n += 1 + l + sovAgent(uint64(l))
+	if len(m.Files) > 0 {
+		for iNdEx := len(m.Files) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Files[iNdEx])
+			copy(dAtA[i:], m.Files[iNdEx])
+			i = encodeVarintAgent(dAtA, i, uint64(len(m.Files[iNdEx])))
+			i--
+			dAtA[i] = 0xa
 		}
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *HugetlbStats) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *RestoreContainerRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *RestoreContainerRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RestoreContainerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Usage != 0 {
-		n += 1 + sovAgent(uint64(m.Usage))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.MaxUsage != 0 {
-		n += 1 + sovAgent(uint64(m.MaxUsage))
+	if m.Options != nil {
+		{
+			size, err := m.Options.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgent(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
 	}
-	if m.Failcnt != 0 {
-		n += 1 + sovAgent(uint64(m.Failcnt))
+	if len(m.ImagePath) > 0 {
+		i -= len(m.ImagePath)
+		copy(dAtA[i:], m.ImagePath)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ImagePath)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.ContainerId) > 0 {
+		i -= len(m.ContainerId)
+		copy(dAtA[i:], m.ContainerId)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *CgroupStats) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *GetOOMEventRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetOOMEventRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetOOMEventRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.CpuStats != nil {
-		l = m.CpuStats.Size()
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	if m.MemoryStats != nil {
-		l = m.MemoryStats.Size()
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	if m.PidsStats != nil {
-		l = m.PidsStats.Size()
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	if m.BlkioStats != nil {
-		l = m.BlkioStats.Size()
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	if len(m.HugetlbStats) > 0 {
-		for k, v := range m.HugetlbStats {
-			_ = k
-			_ = v
-			l = 0
-			if v != nil {
-				l = v.Size()
-				l += 1 + sovAgent(uint64(l))
-			}
-			mapEntrySize := 1 + len(k) + sovAgent(uint64(len(k))) + l
-			n += mapEntrySize + 1 + sovAgent(uint64(mapEntrySize))
-		}
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *NetworkStats) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *OOMEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *OOMEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *OOMEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	if m.RxBytes != 0 {
-		n += 1 + sovAgent(uint64(m.RxBytes))
-	}
-	if m.RxPackets != 0 {
-		n += 1 + sovAgent(uint64(m.RxPackets))
-	}
-	if m.RxErrors != 0 {
-		n += 1 + sovAgent(uint64(m.RxErrors))
-	}
-	if m.RxDropped != 0 {
-		n += 1 + sovAgent(uint64(m.RxDropped))
-	}
-	if m.TxBytes != 0 {
-		n += 1 + sovAgent(uint64(m.TxBytes))
-	}
-	if m.TxPackets != 0 {
-		n += 1 + sovAgent(uint64(m.TxPackets))
-	}
-	if m.TxErrors != 0 {
-		n += 1 + sovAgent(uint64(m.TxErrors))
-	}
-	if m.TxDropped != 0 {
-		n += 1 + sovAgent(uint64(m.TxDropped))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	if len(m.ContainerId) > 0 {
+		i -= len(m.ContainerId)
+		copy(dAtA[i:], m.ContainerId)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *StatsContainerResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *AddSwapRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *AddSwapRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AddSwapRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.CgroupStats != nil {
-		l = m.CgroupStats.Size()
-		n += 1 + l + sovAgent(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.NetworkStats) > 0 {
-		for _, e := range m.NetworkStats {
-			l = e.Size()
-			n += 1 + l + sovAgent(uint64(l))
+	if len(m.PCIPath) > 0 {
+		dAtA29 := make([]byte, len(m.PCIPath)*10)
+		var j28 int
+		for _, num := range m.PCIPath {
+			for num >= 1<<7 {
+				dAtA29[j28] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j28++
+			}
+			dAtA29[j28] = uint8(num)
+			j28++
 		}
+		i -= j28
+		copy(dAtA[i:], dAtA29[:j28])
+		i = encodeVarintAgent(dAtA, i, uint64(j28))
+		i--
+		dAtA[i] = 0xa
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *WriteStreamRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *GetMetricsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *GetMetricsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetMetricsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.ContainerId)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	l = len(m.ExecId)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *WriteStreamResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *Metrics) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *Metrics) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Metrics) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Len != 0 {
-		n += 1 + sovAgent(uint64(m.Len))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	if len(m.Metrics) > 0 {
+		i -= len(m.Metrics)
+		copy(dAtA[i:], m.Metrics)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Metrics)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *ReadStreamRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *VolumeStatsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *VolumeStatsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VolumeStatsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.ContainerId)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	l = len(m.ExecId)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Len != 0 {
-		n += 1 + sovAgent(uint64(m.Len))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.VolumeGuestPath) > 0 {
+		i -= len(m.VolumeGuestPath)
+		copy(dAtA[i:], m.VolumeGuestPath)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.VolumeGuestPath)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *ReadStreamResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *ContainerVolumeStatsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *CloseStdinRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *ContainerVolumeStatsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ContainerVolumeStatsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.ContainerId)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	l = len(m.ExecId)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	if len(m.ContainerId) > 0 {
+		i -= len(m.ContainerId)
+		copy(dAtA[i:], m.ContainerId)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *TtyWinResizeRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ContainerVolumeStats) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ContainerVolumeStats) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ContainerVolumeStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.ContainerId)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	l = len(m.ExecId)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Row != 0 {
-		n += 1 + sovAgent(uint64(m.Row))
+	if m.Stats != nil {
+		{
+			size, err := m.Stats.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintAgent(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
 	}
-	if m.Column != 0 {
-		n += 1 + sovAgent(uint64(m.Column))
+	if len(m.VolumeGuestPath) > 0 {
+		i -= len(m.VolumeGuestPath)
+		copy(dAtA[i:], m.VolumeGuestPath)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.VolumeGuestPath)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.Destination) > 0 {
+		i -= len(m.Destination)
+		copy(dAtA[i:], m.Destination)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Destination)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *KernelModule) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ContainerVolumeStatsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ContainerVolumeStatsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ContainerVolumeStatsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Parameters) > 0 {
-		for _, s := range m.Parameters {
-			l = len(s)
-			n += 1 + l + sovAgent(uint64(l))
+	if len(m.Volumes) > 0 {
+		for iNdEx := len(m.Volumes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Volumes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAgent(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
 		}
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *CreateSandboxRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ResizeVolumeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ResizeVolumeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResizeVolumeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Hostname)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	if len(m.Dns) > 0 {
-		for _, s := range m.Dns {
-			l = len(s)
-			n += 1 + l + sovAgent(uint64(l))
-		}
-	}
-	if len(m.Storages) > 0 {
-		for _, e := range m.Storages {
-			l = e.Size()
-			n += 1 + l + sovAgent(uint64(l))
-		}
-	}
-	if m.SandboxPidns {
-		n += 2
-	}
-	l = len(m.SandboxId)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	l = len(m.GuestHookPath)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.KernelModules) > 0 {
-		for _, e := range m.KernelModules {
-			l = e.Size()
-			n += 1 + l + sovAgent(uint64(l))
-		}
+	if m.Size_ != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Size_))
+		i--
+		dAtA[i] = 0x10
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.VolumeGuestPath) > 0 {
+		i -= len(m.VolumeGuestPath)
+		copy(dAtA[i:], m.VolumeGuestPath)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.VolumeGuestPath)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *DestroySandboxRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *WaitDeviceRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *RemoveStaleVirtiofsShareMountsRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+func (m *WaitDeviceRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Interfaces) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *WaitDeviceRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.Interfaces) > 0 {
-		for _, e := range m.Interfaces {
-			l = e.Size()
-			n += 1 + l + sovAgent(uint64(l))
-		}
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
-}
-
-func (m *Routes) Size() (n int) {
-	if m == nil {
-		return 0
+	if m.Timeout != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Timeout))
+		i--
+		dAtA[i] = 0x18
 	}
-	var l int
-	_ = l
-	if len(m.Routes) > 0 {
-		for _, e := range m.Routes {
-			l = e.Size()
-			n += 1 + l + sovAgent(uint64(l))
-		}
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Address)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.Type) > 0 {
+		i -= len(m.Type)
+		copy(dAtA[i:], m.Type)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Type)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *UpdateInterfaceRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *SyncFsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *SyncFsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SyncFsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Interface != nil {
-		l = m.Interface.Size()
-		n += 1 + l + sovAgent(uint64(l))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	if m.Timeout != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Timeout))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ContainerId) > 0 {
+		i -= len(m.ContainerId)
+		copy(dAtA[i:], m.ContainerId)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *UpdateRoutesRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *FreezeFsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *FreezeFsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FreezeFsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Routes != nil {
-		l = m.Routes.Size()
-		n += 1 + l + sovAgent(uint64(l))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	if len(m.ContainerId) > 0 {
+		i -= len(m.ContainerId)
+		copy(dAtA[i:], m.ContainerId)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *UpdateEphemeralMountsRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ThawFsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ThawFsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ThawFsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.Storages) > 0 {
-		for _, e := range m.Storages {
-			l = e.Size()
-			n += 1 + l + sovAgent(uint64(l))
-		}
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *ListInterfacesRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *SetNameResolutionRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *SetNameResolutionRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetNameResolutionRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	if len(m.ResolvConf) > 0 {
+		i -= len(m.ResolvConf)
+		copy(dAtA[i:], m.ResolvConf)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ResolvConf)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Hosts) > 0 {
+		i -= len(m.Hosts)
+		copy(dAtA[i:], m.Hosts)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Hosts)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *ListRoutesRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *GetGuestServicesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *GetGuestServicesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetGuestServicesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *ARPNeighbors) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *GuestService) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *GuestService) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GuestService) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.ARPNeighbors) > 0 {
-		for _, e := range m.ARPNeighbors {
-			l = e.Size()
-			n += 1 + l + sovAgent(uint64(l))
-		}
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
-}
-
-func (m *AddARPNeighborsRequest) Size() (n int) {
-	if m == nil {
-		return 0
+	if m.Restarts != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Restarts))
+		i--
+		dAtA[i] = 0x18
 	}
-	var l int
-	_ = l
-	if m.Neighbors != nil {
-		l = m.Neighbors.Size()
-		n += 1 + l + sovAgent(uint64(l))
+	if len(m.State) > 0 {
+		i -= len(m.State)
+		copy(dAtA[i:], m.State)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.State)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *GetIPTablesRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.IsIpv6 {
-		n += 2
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *GuestServices) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *GetIPTablesResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+func (m *GuestServices) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *SetIPTablesRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *GuestServices) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.IsIpv6 {
-		n += 2
-	}
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	if len(m.Services) > 0 {
+		for iNdEx := len(m.Services) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Services[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAgent(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *SetIPTablesResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *NetworkPolicyRule) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *NetworkPolicyRule) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NetworkPolicyRule) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
-}
-
-func (m *OnlineCPUMemRequest) Size() (n int) {
-	if m == nil {
-		return 0
+	if m.EndPort != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.EndPort))
+		i--
+		dAtA[i] = 0x28
 	}
-	var l int
-	_ = l
-	if m.Wait {
-		n += 2
+	if m.Port != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Port))
+		i--
+		dAtA[i] = 0x20
 	}
-	if m.NbCpus != 0 {
-		n += 1 + sovAgent(uint64(m.NbCpus))
+	if len(m.Protocol) > 0 {
+		i -= len(m.Protocol)
+		copy(dAtA[i:], m.Protocol)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Protocol)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	if m.CpuOnly {
-		n += 2
+	if len(m.Cidr) > 0 {
+		i -= len(m.Cidr)
+		copy(dAtA[i:], m.Cidr)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Cidr)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.Direction) > 0 {
+		i -= len(m.Direction)
+		copy(dAtA[i:], m.Direction)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Direction)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *ReseedRandomDevRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *SetNetworkPolicyRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *AgentDetails) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *SetNetworkPolicyRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetNetworkPolicyRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Version)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	if m.InitDaemon {
-		n += 2
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.DeviceHandlers) > 0 {
-		for _, s := range m.DeviceHandlers {
-			l = len(s)
-			n += 1 + l + sovAgent(uint64(l))
+	if len(m.Rules) > 0 {
+		for iNdEx := len(m.Rules) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Rules[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAgent(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
 		}
 	}
-	if len(m.StorageHandlers) > 0 {
-		for _, s := range m.StorageHandlers {
-			l = len(s)
-			n += 1 + l + sovAgent(uint64(l))
+	if m.Egress {
+		i--
+		if m.Egress {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
+		i--
+		dAtA[i] = 0x18
 	}
-	if m.SupportsSeccomp {
-		n += 2
+	if m.Ingress {
+		i--
+		if m.Ingress {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.Program) > 0 {
+		i -= len(m.Program)
+		copy(dAtA[i:], m.Program)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Program)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *GuestDetailsRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.MemBlockSize {
-		n += 2
-	}
-	if m.MemHotplugProbe {
-		n += 2
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *GetSeccompNotificationRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *GuestDetailsResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.MemBlockSizeBytes != 0 {
-		n += 1 + sovAgent(uint64(m.MemBlockSizeBytes))
-	}
-	if m.AgentDetails != nil {
-		l = m.AgentDetails.Size()
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	if m.SupportMemHotplugProbe {
-		n += 2
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+func (m *GetSeccompNotificationRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *MemHotplugByProbeRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *GetSeccompNotificationRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.MemHotplugProbeAddr) > 0 {
-		l = 0
-		for _, e := range m.MemHotplugProbeAddr {
-			l += sovAgent(uint64(e))
-		}
-		n += 1 + sovAgent(uint64(l)) + l
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *SetGuestDateTimeRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *SeccompNotification) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *SeccompNotification) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SeccompNotification) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Sec != 0 {
-		n += 1 + sovAgent(uint64(m.Sec))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Usec != 0 {
-		n += 1 + sovAgent(uint64(m.Usec))
+	if len(m.Args) > 0 {
+		dAtA32 := make([]byte, len(m.Args)*10)
+		var j31 int
+		for _, num := range m.Args {
+			for num >= 1<<7 {
+				dAtA32[j31] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j31++
+			}
+			dAtA32[j31] = uint8(num)
+			j31++
+		}
+		i -= j31
+		copy(dAtA[i:], dAtA32[:j31])
+		i = encodeVarintAgent(dAtA, i, uint64(j31))
+		i--
+		dAtA[i] = 0x3a
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.Arch) > 0 {
+		i -= len(m.Arch)
+		copy(dAtA[i:], m.Arch)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Arch)))
+		i--
+		dAtA[i] = 0x32
 	}
-	return n
+	if len(m.Syscall) > 0 {
+		i -= len(m.Syscall)
+		copy(dAtA[i:], m.Syscall)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.Syscall)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Pid != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Pid))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.ExecId) > 0 {
+		i -= len(m.ExecId)
+		copy(dAtA[i:], m.ExecId)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ExecId)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.ContainerId) > 0 {
+		i -= len(m.ContainerId)
+		copy(dAtA[i:], m.ContainerId)
+		i = encodeVarintAgent(dAtA, i, uint64(len(m.ContainerId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Id != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Id))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *FSGroup) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *SeccompNotificationResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *SeccompNotificationResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SeccompNotificationResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.GroupId != 0 {
-		n += 1 + sovAgent(uint64(m.GroupId))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.GroupChangePolicy != 0 {
-		n += 1 + sovAgent(uint64(m.GroupChangePolicy))
+	if m.Value != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Value))
+		i--
+		dAtA[i] = 0x20
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if m.Error != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Error))
+		i--
+		dAtA[i] = 0x18
 	}
-	return n
+	if m.Allow {
+		i--
+		if m.Allow {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Id != 0 {
+		i = encodeVarintAgent(dAtA, i, uint64(m.Id))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *Storage) Size() (n int) {
+func encodeVarintAgent(dAtA []byte, offset int, v uint64) int {
+	offset -= sovAgent(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *CreateContainerRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Driver)
+	l = len(m.ContainerId)
 	if l > 0 {
 		n += 1 + l + sovAgent(uint64(l))
 	}
-	if len(m.DriverOptions) > 0 {
-		for _, s := range m.DriverOptions {
-			l = len(s)
-			n += 1 + l + sovAgent(uint64(l))
-		}
-	}
-	l = len(m.Source)
+	l = len(m.ExecId)
 	if l > 0 {
 		n += 1 + l + sovAgent(uint64(l))
 	}
-	l = len(m.Fstype)
-	if l > 0 {
+	if m.StringUser != nil {
+		l = m.StringUser.Size()
 		n += 1 + l + sovAgent(uint64(l))
 	}
-	if len(m.Options) > 0 {
-		for _, s := range m.Options {
-			l = len(s)
+	if len(m.Devices) > 0 {
+		for _, e := range m.Devices {
+			l = e.Size()
 			n += 1 + l + sovAgent(uint64(l))
 		}
 	}
-	l = len(m.MountPoint)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
+	if len(m.Storages) > 0 {
+		for _, e := range m.Storages {
+			l = e.Size()
+			n += 1 + l + sovAgent(uint64(l))
+		}
 	}
-	if m.FsGroup != nil {
-		l = m.FsGroup.Size()
+	if m.OCI != nil {
+		l = m.OCI.Size()
 		n += 1 + l + sovAgent(uint64(l))
 	}
+	if m.SandboxPidns {
+		n += 2
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *Device) Size() (n int) {
+func (m *StartContainerRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Id)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	l = len(m.Type)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	l = len(m.VmPath)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	l = len(m.ContainerPath)
+	l = len(m.ContainerId)
 	if l > 0 {
 		n += 1 + l + sovAgent(uint64(l))
 	}
-	if len(m.Options) > 0 {
-		for _, s := range m.Options {
-			l = len(s)
-			n += 1 + l + sovAgent(uint64(l))
-		}
+	if m.ExecTimeout != 0 {
+		n += 1 + sovAgent(uint64(m.ExecTimeout))
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -7449,25 +8480,18 @@ func (m *Device) Size() (n int) {
 	return n
 }
 
-func (m *StringUser) Size() (n int) {
+func (m *RemoveContainerRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Uid)
-	if l > 0 {
-		n += 1 + l + sovAgent(uint64(l))
-	}
-	l = len(m.Gid)
+	l = len(m.ContainerId)
 	if l > 0 {
 		n += 1 + l + sovAgent(uint64(l))
 	}
-	if len(m.AdditionalGids) > 0 {
-		for _, s := range m.AdditionalGids {
-			l = len(s)
-			n += 1 + l + sovAgent(uint64(l))
-		}
+	if m.Timeout != 0 {
+		n += 1 + sovAgent(uint64(m.Timeout))
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -7475,36 +8499,26 @@ func (m *StringUser) Size() (n int) {
 	return n
 }
 
-func (m *CopyFileRequest) Size() (n int) {
+func (m *ExecProcessRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Path)
+	l = len(m.ContainerId)
 	if l > 0 {
 		n += 1 + l + sovAgent(uint64(l))
 	}
-	if m.FileSize != 0 {
-		n += 1 + sovAgent(uint64(m.FileSize))
-	}
-	if m.FileMode != 0 {
-		n += 1 + sovAgent(uint64(m.FileMode))
-	}
-	if m.DirMode != 0 {
-		n += 1 + sovAgent(uint64(m.DirMode))
-	}
-	if m.Uid != 0 {
-		n += 1 + sovAgent(uint64(m.Uid))
-	}
-	if m.Gid != 0 {
-		n += 1 + sovAgent(uint64(m.Gid))
+	l = len(m.ExecId)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
 	}
-	if m.Offset != 0 {
-		n += 1 + sovAgent(uint64(m.Offset))
+	if m.StringUser != nil {
+		l = m.StringUser.Size()
+		n += 1 + l + sovAgent(uint64(l))
 	}
-	l = len(m.Data)
-	if l > 0 {
+	if m.Process != nil {
+		l = m.Process.Size()
 		n += 1 + l + sovAgent(uint64(l))
 	}
 	if m.XXX_unrecognized != nil {
@@ -7513,19 +8527,30 @@ func (m *CopyFileRequest) Size() (n int) {
 	return n
 }
 
-func (m *GetOOMEventRequest) Size() (n int) {
+func (m *SignalProcessRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	l = len(m.ContainerId)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	l = len(m.ExecId)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.Signal != 0 {
+		n += 1 + sovAgent(uint64(m.Signal))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *OOMEvent) Size() (n int) {
+func (m *WaitProcessRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
@@ -7535,24 +8560,24 @@ func (m *OOMEvent) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovAgent(uint64(l))
 	}
+	l = len(m.ExecId)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *AddSwapRequest) Size() (n int) {
+func (m *WaitProcessResponse) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.PCIPath) > 0 {
-		l = 0
-		for _, e := range m.PCIPath {
-			l += sovAgent(uint64(e))
-		}
-		n += 1 + sovAgent(uint64(l)) + l
+	if m.Status != 0 {
+		n += 1 + sovAgent(uint64(m.Status))
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -7560,25 +8585,33 @@ func (m *AddSwapRequest) Size() (n int) {
 	return n
 }
 
-func (m *GetMetricsRequest) Size() (n int) {
+func (m *UpdateContainerRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	l = len(m.ContainerId)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.Resources != nil {
+		l = m.Resources.Size()
+		n += 1 + l + sovAgent(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *Metrics) Size() (n int) {
+func (m *StatsContainerRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Metrics)
+	l = len(m.ContainerId)
 	if l > 0 {
 		n += 1 + l + sovAgent(uint64(l))
 	}
@@ -7588,13 +8621,13 @@ func (m *Metrics) Size() (n int) {
 	return n
 }
 
-func (m *VolumeStatsRequest) Size() (n int) {
+func (m *PauseContainerRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.VolumeGuestPath)
+	l = len(m.ContainerId)
 	if l > 0 {
 		n += 1 + l + sovAgent(uint64(l))
 	}
@@ -7604,1565 +8637,6572 @@ func (m *VolumeStatsRequest) Size() (n int) {
 	return n
 }
 
-func (m *ResizeVolumeRequest) Size() (n int) {
+func (m *ResumeContainerRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.VolumeGuestPath)
+	l = len(m.ContainerId)
 	if l > 0 {
 		n += 1 + l + sovAgent(uint64(l))
 	}
-	if m.Size_ != 0 {
-		n += 1 + sovAgent(uint64(m.Size_))
-	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func sovAgent(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozAgent(x uint64) (n int) {
-	return sovAgent(uint64((x << 1) ^ uint64((int64(x) >> 63))))
-}
-func (this *CreateContainerRequest) String() string {
-	if this == nil {
-		return "nil"
+func (m *CpuUsage) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	repeatedStringForDevices := "[]*Device{"
-	for _, f := range this.Devices {
-		repeatedStringForDevices += strings.Replace(f.String(), "Device", "Device", 1) + ","
+	var l int
+	_ = l
+	if m.TotalUsage != 0 {
+		n += 1 + sovAgent(uint64(m.TotalUsage))
 	}
-	repeatedStringForDevices += "}"
-	repeatedStringForStorages := "[]*Storage{"
-	for _, f := range this.Storages {
-		repeatedStringForStorages += strings.Replace(f.String(), "Storage", "Storage", 1) + ","
+	if len(m.PercpuUsage) > 0 {
+		l = 0
+		for _, e := range m.PercpuUsage {
+			l += sovAgent(uint64(e))
+		}
+		n += 1 + sovAgent(uint64(l)) + l
 	}
-	repeatedStringForStorages += "}"
-	s := strings.Join([]string{`&CreateContainerRequest{`,
-		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
-		`ExecId:` + fmt.Sprintf("%v", this.ExecId) + `,`,
-		`StringUser:` + strings.Replace(this.StringUser.String(), "StringUser", "StringUser", 1) + `,`,
-		`Devices:` + repeatedStringForDevices + `,`,
-		`Storages:` + repeatedStringForStorages + `,`,
-		`OCI:` + strings.Replace(fmt.Sprintf("%v", this.OCI), "Spec", "Spec", 1) + `,`,
-		`SandboxPidns:` + fmt.Sprintf("%v", this.SandboxPidns) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *StartContainerRequest) String() string {
-	if this == nil {
-		return "nil"
+	if m.UsageInKernelmode != 0 {
+		n += 1 + sovAgent(uint64(m.UsageInKernelmode))
 	}
-	s := strings.Join([]string{`&StartContainerRequest{`,
-		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *RemoveContainerRequest) String() string {
-	if this == nil {
-		return "nil"
+	if m.UsageInUsermode != 0 {
+		n += 1 + sovAgent(uint64(m.UsageInUsermode))
 	}
-	s := strings.Join([]string{`&RemoveContainerRequest{`,
-		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
-		`Timeout:` + fmt.Sprintf("%v", this.Timeout) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *ExecProcessRequest) String() string {
-	if this == nil {
-		return "nil"
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	s := strings.Join([]string{`&ExecProcessRequest{`,
-		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
-		`ExecId:` + fmt.Sprintf("%v", this.ExecId) + `,`,
-		`StringUser:` + strings.Replace(this.StringUser.String(), "StringUser", "StringUser", 1) + `,`,
-		`Process:` + strings.Replace(fmt.Sprintf("%v", this.Process), "Process", "Process", 1) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
+	return n
 }
-func (this *SignalProcessRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *ThrottlingData) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	s := strings.Join([]string{`&SignalProcessRequest{`,
-		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
-		`ExecId:` + fmt.Sprintf("%v", this.ExecId) + `,`,
-		`Signal:` + fmt.Sprintf("%v", this.Signal) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *WaitProcessRequest) String() string {
-	if this == nil {
-		return "nil"
+	var l int
+	_ = l
+	if m.Periods != 0 {
+		n += 1 + sovAgent(uint64(m.Periods))
 	}
-	s := strings.Join([]string{`&WaitProcessRequest{`,
-		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
-		`ExecId:` + fmt.Sprintf("%v", this.ExecId) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *WaitProcessResponse) String() string {
-	if this == nil {
-		return "nil"
+	if m.ThrottledPeriods != 0 {
+		n += 1 + sovAgent(uint64(m.ThrottledPeriods))
 	}
-	s := strings.Join([]string{`&WaitProcessResponse{`,
-		`Status:` + fmt.Sprintf("%v", this.Status) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *UpdateContainerRequest) String() string {
-	if this == nil {
-		return "nil"
+	if m.ThrottledTime != 0 {
+		n += 1 + sovAgent(uint64(m.ThrottledTime))
 	}
-	s := strings.Join([]string{`&UpdateContainerRequest{`,
-		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
-		`Resources:` + strings.Replace(fmt.Sprintf("%v", this.Resources), "LinuxResources", "LinuxResources", 1) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *StatsContainerRequest) String() string {
-	if this == nil {
-		return "nil"
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	s := strings.Join([]string{`&StatsContainerRequest{`,
-		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
+	return n
 }
-func (this *PauseContainerRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *CpuStats) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	s := strings.Join([]string{`&PauseContainerRequest{`,
-		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *ResumeContainerRequest) String() string {
-	if this == nil {
-		return "nil"
+	var l int
+	_ = l
+	if m.CpuUsage != nil {
+		l = m.CpuUsage.Size()
+		n += 1 + l + sovAgent(uint64(l))
 	}
-	s := strings.Join([]string{`&ResumeContainerRequest{`,
-		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *CpuUsage) String() string {
-	if this == nil {
-		return "nil"
+	if m.ThrottlingData != nil {
+		l = m.ThrottlingData.Size()
+		n += 1 + l + sovAgent(uint64(l))
 	}
-	s := strings.Join([]string{`&CpuUsage{`,
-		`TotalUsage:` + fmt.Sprintf("%v", this.TotalUsage) + `,`,
-		`PercpuUsage:` + fmt.Sprintf("%v", this.PercpuUsage) + `,`,
-		`UsageInKernelmode:` + fmt.Sprintf("%v", this.UsageInKernelmode) + `,`,
-		`UsageInUsermode:` + fmt.Sprintf("%v", this.UsageInUsermode) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *ThrottlingData) String() string {
-	if this == nil {
-		return "nil"
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	s := strings.Join([]string{`&ThrottlingData{`,
-		`Periods:` + fmt.Sprintf("%v", this.Periods) + `,`,
-		`ThrottledPeriods:` + fmt.Sprintf("%v", this.ThrottledPeriods) + `,`,
-		`ThrottledTime:` + fmt.Sprintf("%v", this.ThrottledTime) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
+	return n
 }
-func (this *CpuStats) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *PidsStats) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	s := strings.Join([]string{`&CpuStats{`,
-		`CpuUsage:` + strings.Replace(this.CpuUsage.String(), "CpuUsage", "CpuUsage", 1) + `,`,
-		`ThrottlingData:` + strings.Replace(this.ThrottlingData.String(), "ThrottlingData", "ThrottlingData", 1) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *PidsStats) String() string {
-	if this == nil {
-		return "nil"
+	var l int
+	_ = l
+	if m.Current != 0 {
+		n += 1 + sovAgent(uint64(m.Current))
 	}
-	s := strings.Join([]string{`&PidsStats{`,
-		`Current:` + fmt.Sprintf("%v", this.Current) + `,`,
-		`Limit:` + fmt.Sprintf("%v", this.Limit) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *MemoryData) String() string {
-	if this == nil {
-		return "nil"
+	if m.Limit != 0 {
+		n += 1 + sovAgent(uint64(m.Limit))
 	}
-	s := strings.Join([]string{`&MemoryData{`,
-		`Usage:` + fmt.Sprintf("%v", this.Usage) + `,`,
-		`MaxUsage:` + fmt.Sprintf("%v", this.MaxUsage) + `,`,
-		`Failcnt:` + fmt.Sprintf("%v", this.Failcnt) + `,`,
-		`Limit:` + fmt.Sprintf("%v", this.Limit) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *MemoryStats) String() string {
-	if this == nil {
-		return "nil"
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	keysForStats := make([]string, 0, len(this.Stats))
-	for k, _ := range this.Stats {
-		keysForStats = append(keysForStats, k)
-	}
-	github_com_gogo_protobuf_sortkeys.Strings(keysForStats)
-	mapStringForStats := "map[string]uint64{"
-	for _, k := range keysForStats {
-		mapStringForStats += fmt.Sprintf("%v: %v,", k, this.Stats[k])
-	}
-	mapStringForStats += "}"
-	s := strings.Join([]string{`&MemoryStats{`,
-		`Cache:` + fmt.Sprintf("%v", this.Cache) + `,`,
-		`Usage:` + strings.Replace(this.Usage.String(), "MemoryData", "MemoryData", 1) + `,`,
-		`SwapUsage:` + strings.Replace(this.SwapUsage.String(), "MemoryData", "MemoryData", 1) + `,`,
-		`KernelUsage:` + strings.Replace(this.KernelUsage.String(), "MemoryData", "MemoryData", 1) + `,`,
-		`UseHierarchy:` + fmt.Sprintf("%v", this.UseHierarchy) + `,`,
-		`Stats:` + mapStringForStats + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
+	return n
 }
-func (this *BlkioStatsEntry) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *MemoryData) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	s := strings.Join([]string{`&BlkioStatsEntry{`,
-		`Major:` + fmt.Sprintf("%v", this.Major) + `,`,
-		`Minor:` + fmt.Sprintf("%v", this.Minor) + `,`,
-		`Op:` + fmt.Sprintf("%v", this.Op) + `,`,
-		`Value:` + fmt.Sprintf("%v", this.Value) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *BlkioStats) String() string {
-	if this == nil {
-		return "nil"
+	var l int
+	_ = l
+	if m.Usage != 0 {
+		n += 1 + sovAgent(uint64(m.Usage))
 	}
-	repeatedStringForIoServiceBytesRecursive := "[]*BlkioStatsEntry{"
-	for _, f := range this.IoServiceBytesRecursive {
-		repeatedStringForIoServiceBytesRecursive += strings.Replace(f.String(), "BlkioStatsEntry", "BlkioStatsEntry", 1) + ","
+	if m.MaxUsage != 0 {
+		n += 1 + sovAgent(uint64(m.MaxUsage))
 	}
-	repeatedStringForIoServiceBytesRecursive += "}"
-	repeatedStringForIoServicedRecursive := "[]*BlkioStatsEntry{"
-	for _, f := range this.IoServicedRecursive {
-		repeatedStringForIoServicedRecursive += strings.Replace(f.String(), "BlkioStatsEntry", "BlkioStatsEntry", 1) + ","
+	if m.Failcnt != 0 {
+		n += 1 + sovAgent(uint64(m.Failcnt))
 	}
-	repeatedStringForIoServicedRecursive += "}"
-	repeatedStringForIoQueuedRecursive := "[]*BlkioStatsEntry{"
-	for _, f := range this.IoQueuedRecursive {
-		repeatedStringForIoQueuedRecursive += strings.Replace(f.String(), "BlkioStatsEntry", "BlkioStatsEntry", 1) + ","
+	if m.Limit != 0 {
+		n += 1 + sovAgent(uint64(m.Limit))
 	}
-	repeatedStringForIoQueuedRecursive += "}"
-	repeatedStringForIoServiceTimeRecursive := "[]*BlkioStatsEntry{"
-	for _, f := range this.IoServiceTimeRecursive {
-		repeatedStringForIoServiceTimeRecursive += strings.Replace(f.String(), "BlkioStatsEntry", "BlkioStatsEntry", 1) + ","
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	repeatedStringForIoServiceTimeRecursive += "}"
-	repeatedStringForIoWaitTimeRecursive := "[]*BlkioStatsEntry{"
-	for _, f := range this.IoWaitTimeRecursive {
-		repeatedStringForIoWaitTimeRecursive += strings.Replace(f.String(), "BlkioStatsEntry", "BlkioStatsEntry", 1) + ","
+	return n
+}
+
+func (m *MemoryStats) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	repeatedStringForIoWaitTimeRecursive += "}"
-	repeatedStringForIoMergedRecursive := "[]*BlkioStatsEntry{"
-	for _, f := range this.IoMergedRecursive {
-		repeatedStringForIoMergedRecursive += strings.Replace(f.String(), "BlkioStatsEntry", "BlkioStatsEntry", 1) + ","
+	var l int
+	_ = l
+	if m.Cache != 0 {
+		n += 1 + sovAgent(uint64(m.Cache))
 	}
-	repeatedStringForIoMergedRecursive += "}"
-	repeatedStringForIoTimeRecursive := "[]*BlkioStatsEntry{"
-	for _, f := range this.IoTimeRecursive {
-		repeatedStringForIoTimeRecursive += strings.Replace(f.String(), "BlkioStatsEntry", "BlkioStatsEntry", 1) + ","
+	if m.Usage != nil {
+		l = m.Usage.Size()
+		n += 1 + l + sovAgent(uint64(l))
 	}
-	repeatedStringForIoTimeRecursive += "}"
-	repeatedStringForSectorsRecursive := "[]*BlkioStatsEntry{"
-	for _, f := range this.SectorsRecursive {
-		repeatedStringForSectorsRecursive += strings.Replace(f.String(), "BlkioStatsEntry", "BlkioStatsEntry", 1) + ","
+	if m.SwapUsage != nil {
+		l = m.SwapUsage.Size()
+		n += 1 + l + sovAgent(uint64(l))
 	}
-	repeatedStringForSectorsRecursive += "}"
-	s := strings.Join([]string{`&BlkioStats{`,
-		`IoServiceBytesRecursive:` + repeatedStringForIoServiceBytesRecursive + `,`,
-		`IoServicedRecursive:` + repeatedStringForIoServicedRecursive + `,`,
-		`IoQueuedRecursive:` + repeatedStringForIoQueuedRecursive + `,`,
-		`IoServiceTimeRecursive:` + repeatedStringForIoServiceTimeRecursive + `,`,
-		`IoWaitTimeRecursive:` + repeatedStringForIoWaitTimeRecursive + `,`,
-		`IoMergedRecursive:` + repeatedStringForIoMergedRecursive + `,`,
-		`IoTimeRecursive:` + repeatedStringForIoTimeRecursive + `,`,
-		`SectorsRecursive:` + repeatedStringForSectorsRecursive + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *HugetlbStats) String() string {
-	if this == nil {
-		return "nil"
+	if m.KernelUsage != nil {
+		l = m.KernelUsage.Size()
+		n += 1 + l + sovAgent(uint64(l))
 	}
-	s := strings.Join([]string{`&HugetlbStats{`,
-		`Usage:` + fmt.Sprintf("%v", this.Usage) + `,`,
-		`MaxUsage:` + fmt.Sprintf("%v", this.MaxUsage) + `,`,
-		`Failcnt:` + fmt.Sprintf("%v", this.Failcnt) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *CgroupStats) String() string {
-	if this == nil {
-		return "nil"
+	if m.UseHierarchy {
+		n += 2
 	}
-	keysForHugetlbStats := make([]string, 0, len(this.HugetlbStats))
-	for k, _ := range this.HugetlbStats {
-		keysForHugetlbStats = append(keysForHugetlbStats, k)
+	if len(m.Stats) > 0 {
+		for k, v := range m.Stats {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovAgent(uint64(len(k))) + 1 + sovAgent(uint64(v))
+			n += mapEntrySize + 1 + sovAgent(uint64(mapEntrySize))
+		}
 	}
-	github_com_gogo_protobuf_sortkeys.Strings(keysForHugetlbStats)
-	mapStringForHugetlbStats := "map[string]*HugetlbStats{"
-	for _, k := range keysForHugetlbStats {
-		mapStringForHugetlbStats += fmt.Sprintf("%v: %v,", k, this.HugetlbStats[k])
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	mapStringForHugetlbStats += "}"
-	s := strings.Join([]string{`&CgroupStats{`,
-		`CpuStats:` + strings.Replace(this.CpuStats.String(), "CpuStats", "CpuStats", 1) + `,`,
-		`MemoryStats:` + strings.Replace(this.MemoryStats.String(), "MemoryStats", "MemoryStats", 1) + `,`,
-		`PidsStats:` + strings.Replace(this.PidsStats.String(), "PidsStats", "PidsStats", 1) + `,`,
-		`BlkioStats:` + strings.Replace(this.BlkioStats.String(), "BlkioStats", "BlkioStats", 1) + `,`,
-		`HugetlbStats:` + mapStringForHugetlbStats + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
+	return n
 }
-func (this *NetworkStats) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *BlkioStatsEntry) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	s := strings.Join([]string{`&NetworkStats{`,
-		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
-		`RxBytes:` + fmt.Sprintf("%v", this.RxBytes) + `,`,
-		`RxPackets:` + fmt.Sprintf("%v", this.RxPackets) + `,`,
-		`RxErrors:` + fmt.Sprintf("%v", this.RxErrors) + `,`,
-		`RxDropped:` + fmt.Sprintf("%v", this.RxDropped) + `,`,
-		`TxBytes:` + fmt.Sprintf("%v", this.TxBytes) + `,`,
-		`TxPackets:` + fmt.Sprintf("%v", this.TxPackets) + `,`,
-		`TxErrors:` + fmt.Sprintf("%v", this.TxErrors) + `,`,
-		`TxDropped:` + fmt.Sprintf("%v", this.TxDropped) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *StatsContainerResponse) String() string {
-	if this == nil {
-		return "nil"
+	var l int
+	_ = l
+	if m.Major != 0 {
+		n += 1 + sovAgent(uint64(m.Major))
 	}
-	repeatedStringForNetworkStats := "[]*NetworkStats{"
-	for _, f := range this.NetworkStats {
-		repeatedStringForNetworkStats += strings.Replace(f.String(), "NetworkStats", "NetworkStats", 1) + ","
+	if m.Minor != 0 {
+		n += 1 + sovAgent(uint64(m.Minor))
 	}
-	repeatedStringForNetworkStats += "}"
-	s := strings.Join([]string{`&StatsContainerResponse{`,
-		`CgroupStats:` + strings.Replace(this.CgroupStats.String(), "CgroupStats", "CgroupStats", 1) + `,`,
-		`NetworkStats:` + repeatedStringForNetworkStats + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *WriteStreamRequest) String() string {
-	if this == nil {
-		return "nil"
+	l = len(m.Op)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
 	}
-	s := strings.Join([]string{`&WriteStreamRequest{`,
-		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
-		`ExecId:` + fmt.Sprintf("%v", this.ExecId) + `,`,
-		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *WriteStreamResponse) String() string {
-	if this == nil {
-		return "nil"
+	if m.Value != 0 {
+		n += 1 + sovAgent(uint64(m.Value))
 	}
-	s := strings.Join([]string{`&WriteStreamResponse{`,
-		`Len:` + fmt.Sprintf("%v", this.Len) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *ReadStreamRequest) String() string {
-	if this == nil {
-		return "nil"
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	s := strings.Join([]string{`&ReadStreamRequest{`,
-		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
-		`ExecId:` + fmt.Sprintf("%v", this.ExecId) + `,`,
-		`Len:` + fmt.Sprintf("%v", this.Len) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
+	return n
 }
-func (this *ReadStreamResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *BlkioStats) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	s := strings.Join([]string{`&ReadStreamResponse{`,
-		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *CloseStdinRequest) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&CloseStdinRequest{`,
-		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
-		`ExecId:` + fmt.Sprintf("%v", this.ExecId) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *TtyWinResizeRequest) String() string {
-	if this == nil {
-		return "nil"
+	var l int
+	_ = l
+	if len(m.IoServiceBytesRecursive) > 0 {
+		for _, e := range m.IoServiceBytesRecursive {
+			l = e.Size()
+			n += 1 + l + sovAgent(uint64(l))
+		}
 	}
-	s := strings.Join([]string{`&TtyWinResizeRequest{`,
-		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
-		`ExecId:` + fmt.Sprintf("%v", this.ExecId) + `,`,
-		`Row:` + fmt.Sprintf("%v", this.Row) + `,`,
-		`Column:` + fmt.Sprintf("%v", this.Column) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *KernelModule) String() string {
-	if this == nil {
-		return "nil"
+	if len(m.IoServicedRecursive) > 0 {
+		for _, e := range m.IoServicedRecursive {
+			l = e.Size()
+			n += 1 + l + sovAgent(uint64(l))
+		}
 	}
-	s := strings.Join([]string{`&KernelModule{`,
-		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
-		`Parameters:` + fmt.Sprintf("%v", this.Parameters) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *CreateSandboxRequest) String() string {
-	if this == nil {
-		return "nil"
+	if len(m.IoQueuedRecursive) > 0 {
+		for _, e := range m.IoQueuedRecursive {
+			l = e.Size()
+			n += 1 + l + sovAgent(uint64(l))
+		}
 	}
-	repeatedStringForStorages := "[]*Storage{"
-	for _, f := range this.Storages {
-		repeatedStringForStorages += strings.Replace(f.String(), "Storage", "Storage", 1) + ","
+	if len(m.IoServiceTimeRecursive) > 0 {
+		for _, e := range m.IoServiceTimeRecursive {
+			l = e.Size()
+			n += 1 + l + sovAgent(uint64(l))
+		}
 	}
-	repeatedStringForStorages += "}"
-	repeatedStringForKernelModules := "[]*KernelModule{"
-	for _, f := range this.KernelModules {
-		repeatedStringForKernelModules += strings.Replace(f.String(), "KernelModule", "KernelModule", 1) + ","
+	if len(m.IoWaitTimeRecursive) > 0 {
+		for _, e := range m.IoWaitTimeRecursive {
+			l = e.Size()
+			n += 1 + l + sovAgent(uint64(l))
+		}
 	}
-	repeatedStringForKernelModules += "}"
-	s := strings.Join([]string{`&CreateSandboxRequest{`,
-		`Hostname:` + fmt.Sprintf("%v", this.Hostname) + `,`,
-		`Dns:` + fmt.Sprintf("%v", this.Dns) + `,`,
-		`Storages:` + repeatedStringForStorages + `,`,
-		`SandboxPidns:` + fmt.Sprintf("%v", this.SandboxPidns) + `,`,
-		`SandboxId:` + fmt.Sprintf("%v", this.SandboxId) + `,`,
-		`GuestHookPath:` + fmt.Sprintf("%v", this.GuestHookPath) + `,`,
-		`KernelModules:` + repeatedStringForKernelModules + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *DestroySandboxRequest) String() string {
-	if this == nil {
-		return "nil"
+	if len(m.IoMergedRecursive) > 0 {
+		for _, e := range m.IoMergedRecursive {
+			l = e.Size()
+			n += 1 + l + sovAgent(uint64(l))
+		}
 	}
-	s := strings.Join([]string{`&DestroySandboxRequest{`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *RemoveStaleVirtiofsShareMountsRequest) String() string {
-	if this == nil {
-		return "nil"
+	if len(m.IoTimeRecursive) > 0 {
+		for _, e := range m.IoTimeRecursive {
+			l = e.Size()
+			n += 1 + l + sovAgent(uint64(l))
+		}
 	}
-	s := strings.Join([]string{`&RemoveStaleVirtiofsShareMountsRequest{`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *Interfaces) String() string {
-	if this == nil {
-		return "nil"
+	if len(m.SectorsRecursive) > 0 {
+		for _, e := range m.SectorsRecursive {
+			l = e.Size()
+			n += 1 + l + sovAgent(uint64(l))
+		}
 	}
-	repeatedStringForInterfaces := "[]*Interface{"
-	for _, f := range this.Interfaces {
-		repeatedStringForInterfaces += strings.Replace(fmt.Sprintf("%v", f), "Interface", "protocols.Interface", 1) + ","
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	repeatedStringForInterfaces += "}"
-	s := strings.Join([]string{`&Interfaces{`,
-		`Interfaces:` + repeatedStringForInterfaces + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
+	return n
 }
-func (this *Routes) String() string {
-	if this == nil {
-		return "nil"
-	}
-	repeatedStringForRoutes := "[]*Route{"
-	for _, f := range this.Routes {
-		repeatedStringForRoutes += strings.Replace(fmt.Sprintf("%v", f), "Route", "protocols.Route", 1) + ","
+
+func (m *HugetlbStats) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	repeatedStringForRoutes += "}"
-	s := strings.Join([]string{`&Routes{`,
-		`Routes:` + repeatedStringForRoutes + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *UpdateInterfaceRequest) String() string {
-	if this == nil {
-		return "nil"
+	var l int
+	_ = l
+	if m.Usage != 0 {
+		n += 1 + sovAgent(uint64(m.Usage))
 	}
-	s := strings.Join([]string{`&UpdateInterfaceRequest{`,
-		`Interface:` + strings.Replace(fmt.Sprintf("%v", this.Interface), "Interface", "protocols.Interface", 1) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *UpdateRoutesRequest) String() string {
-	if this == nil {
-		return "nil"
+	if m.MaxUsage != 0 {
+		n += 1 + sovAgent(uint64(m.MaxUsage))
 	}
-	s := strings.Join([]string{`&UpdateRoutesRequest{`,
-		`Routes:` + strings.Replace(this.Routes.String(), "Routes", "Routes", 1) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *UpdateEphemeralMountsRequest) String() string {
-	if this == nil {
-		return "nil"
+	if m.Failcnt != 0 {
+		n += 1 + sovAgent(uint64(m.Failcnt))
 	}
-	repeatedStringForStorages := "[]*Storage{"
-	for _, f := range this.Storages {
-		repeatedStringForStorages += strings.Replace(f.String(), "Storage", "Storage", 1) + ","
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	repeatedStringForStorages += "}"
-	s := strings.Join([]string{`&UpdateEphemeralMountsRequest{`,
-		`Storages:` + repeatedStringForStorages + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
+	return n
 }
-func (this *ListInterfacesRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *CgroupStats) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	s := strings.Join([]string{`&ListInterfacesRequest{`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *ListRoutesRequest) String() string {
-	if this == nil {
-		return "nil"
+	var l int
+	_ = l
+	if m.CpuStats != nil {
+		l = m.CpuStats.Size()
+		n += 1 + l + sovAgent(uint64(l))
 	}
-	s := strings.Join([]string{`&ListRoutesRequest{`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *ARPNeighbors) String() string {
-	if this == nil {
-		return "nil"
+	if m.MemoryStats != nil {
+		l = m.MemoryStats.Size()
+		n += 1 + l + sovAgent(uint64(l))
 	}
-	repeatedStringForARPNeighbors := "[]*ARPNeighbor{"
-	for _, f := range this.ARPNeighbors {
-		repeatedStringForARPNeighbors += strings.Replace(fmt.Sprintf("%v", f), "ARPNeighbor", "protocols.ARPNeighbor", 1) + ","
+	if m.PidsStats != nil {
+		l = m.PidsStats.Size()
+		n += 1 + l + sovAgent(uint64(l))
 	}
-	repeatedStringForARPNeighbors += "}"
-	s := strings.Join([]string{`&ARPNeighbors{`,
-		`ARPNeighbors:` + repeatedStringForARPNeighbors + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *AddARPNeighborsRequest) String() string {
-	if this == nil {
-		return "nil"
+	if m.BlkioStats != nil {
+		l = m.BlkioStats.Size()
+		n += 1 + l + sovAgent(uint64(l))
 	}
-	s := strings.Join([]string{`&AddARPNeighborsRequest{`,
-		`Neighbors:` + strings.Replace(this.Neighbors.String(), "ARPNeighbors", "ARPNeighbors", 1) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *GetIPTablesRequest) String() string {
-	if this == nil {
-		return "nil"
+	if len(m.HugetlbStats) > 0 {
+		for k, v := range m.HugetlbStats {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovAgent(uint64(l))
+			}
+			mapEntrySize := 1 + len(k) + sovAgent(uint64(len(k))) + l
+			n += mapEntrySize + 1 + sovAgent(uint64(mapEntrySize))
+		}
 	}
-	s := strings.Join([]string{`&GetIPTablesRequest{`,
-		`IsIpv6:` + fmt.Sprintf("%v", this.IsIpv6) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *GetIPTablesResponse) String() string {
-	if this == nil {
-		return "nil"
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	s := strings.Join([]string{`&GetIPTablesResponse{`,
-		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
+	return n
 }
-func (this *SetIPTablesRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *NetworkStats) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	s := strings.Join([]string{`&SetIPTablesRequest{`,
-		`IsIpv6:` + fmt.Sprintf("%v", this.IsIpv6) + `,`,
-		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *SetIPTablesResponse) String() string {
-	if this == nil {
-		return "nil"
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
 	}
-	s := strings.Join([]string{`&SetIPTablesResponse{`,
-		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *OnlineCPUMemRequest) String() string {
-	if this == nil {
-		return "nil"
+	if m.RxBytes != 0 {
+		n += 1 + sovAgent(uint64(m.RxBytes))
 	}
-	s := strings.Join([]string{`&OnlineCPUMemRequest{`,
-		`Wait:` + fmt.Sprintf("%v", this.Wait) + `,`,
-		`NbCpus:` + fmt.Sprintf("%v", this.NbCpus) + `,`,
-		`CpuOnly:` + fmt.Sprintf("%v", this.CpuOnly) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *ReseedRandomDevRequest) String() string {
-	if this == nil {
-		return "nil"
+	if m.RxPackets != 0 {
+		n += 1 + sovAgent(uint64(m.RxPackets))
 	}
-	s := strings.Join([]string{`&ReseedRandomDevRequest{`,
-		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *AgentDetails) String() string {
-	if this == nil {
-		return "nil"
+	if m.RxErrors != 0 {
+		n += 1 + sovAgent(uint64(m.RxErrors))
 	}
-	s := strings.Join([]string{`&AgentDetails{`,
-		`Version:` + fmt.Sprintf("%v", this.Version) + `,`,
-		`InitDaemon:` + fmt.Sprintf("%v", this.InitDaemon) + `,`,
-		`DeviceHandlers:` + fmt.Sprintf("%v", this.DeviceHandlers) + `,`,
-		`StorageHandlers:` + fmt.Sprintf("%v", this.StorageHandlers) + `,`,
-		`SupportsSeccomp:` + fmt.Sprintf("%v", this.SupportsSeccomp) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *GuestDetailsRequest) String() string {
-	if this == nil {
-		return "nil"
+	if m.RxDropped != 0 {
+		n += 1 + sovAgent(uint64(m.RxDropped))
 	}
-	s := strings.Join([]string{`&GuestDetailsRequest{`,
-		`MemBlockSize:` + fmt.Sprintf("%v", this.MemBlockSize) + `,`,
-		`MemHotplugProbe:` + fmt.Sprintf("%v", this.MemHotplugProbe) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *GuestDetailsResponse) String() string {
-	if this == nil {
-		return "nil"
+	if m.TxBytes != 0 {
+		n += 1 + sovAgent(uint64(m.TxBytes))
 	}
-	s := strings.Join([]string{`&GuestDetailsResponse{`,
-		`MemBlockSizeBytes:` + fmt.Sprintf("%v", this.MemBlockSizeBytes) + `,`,
-		`AgentDetails:` + strings.Replace(this.AgentDetails.String(), "AgentDetails", "AgentDetails", 1) + `,`,
-		`SupportMemHotplugProbe:` + fmt.Sprintf("%v", this.SupportMemHotplugProbe) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *MemHotplugByProbeRequest) String() string {
-	if this == nil {
-		return "nil"
+	if m.TxPackets != 0 {
+		n += 1 + sovAgent(uint64(m.TxPackets))
 	}
-	s := strings.Join([]string{`&MemHotplugByProbeRequest{`,
-		`MemHotplugProbeAddr:` + fmt.Sprintf("%v", this.MemHotplugProbeAddr) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *SetGuestDateTimeRequest) String() string {
-	if this == nil {
-		return "nil"
+	if m.TxErrors != 0 {
+		n += 1 + sovAgent(uint64(m.TxErrors))
 	}
-	s := strings.Join([]string{`&SetGuestDateTimeRequest{`,
-		`Sec:` + fmt.Sprintf("%v", this.Sec) + `,`,
-		`Usec:` + fmt.Sprintf("%v", this.Usec) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *FSGroup) String() string {
-	if this == nil {
-		return "nil"
+	if m.TxDropped != 0 {
+		n += 1 + sovAgent(uint64(m.TxDropped))
 	}
-	s := strings.Join([]string{`&FSGroup{`,
-		`GroupId:` + fmt.Sprintf("%v", this.GroupId) + `,`,
-		`GroupChangePolicy:` + fmt.Sprintf("%v", this.GroupChangePolicy) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *Storage) String() string {
-	if this == nil {
-		return "nil"
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	s := strings.Join([]string{`&Storage{`,
-		`Driver:` + fmt.Sprintf("%v", this.Driver) + `,`,
-		`DriverOptions:` + fmt.Sprintf("%v", this.DriverOptions) + `,`,
-		`Source:` + fmt.Sprintf("%v", this.Source) + `,`,
-		`Fstype:` + fmt.Sprintf("%v", this.Fstype) + `,`,
-		`Options:` + fmt.Sprintf("%v", this.Options) + `,`,
-		`MountPoint:` + fmt.Sprintf("%v", this.MountPoint) + `,`,
-		`FsGroup:` + strings.Replace(this.FsGroup.String(), "FSGroup", "FSGroup", 1) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
+	return n
 }
-func (this *Device) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *StatsContainerResponse) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	s := strings.Join([]string{`&Device{`,
-		`Id:` + fmt.Sprintf("%v", this.Id) + `,`,
-		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
-		`VmPath:` + fmt.Sprintf("%v", this.VmPath) + `,`,
-		`ContainerPath:` + fmt.Sprintf("%v", this.ContainerPath) + `,`,
-		`Options:` + fmt.Sprintf("%v", this.Options) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *StringUser) String() string {
-	if this == nil {
-		return "nil"
+	var l int
+	_ = l
+	if m.CgroupStats != nil {
+		l = m.CgroupStats.Size()
+		n += 1 + l + sovAgent(uint64(l))
 	}
-	s := strings.Join([]string{`&StringUser{`,
-		`Uid:` + fmt.Sprintf("%v", this.Uid) + `,`,
-		`Gid:` + fmt.Sprintf("%v", this.Gid) + `,`,
-		`AdditionalGids:` + fmt.Sprintf("%v", this.AdditionalGids) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *CopyFileRequest) String() string {
-	if this == nil {
-		return "nil"
+	if len(m.NetworkStats) > 0 {
+		for _, e := range m.NetworkStats {
+			l = e.Size()
+			n += 1 + l + sovAgent(uint64(l))
+		}
 	}
-	s := strings.Join([]string{`&CopyFileRequest{`,
-		`Path:` + fmt.Sprintf("%v", this.Path) + `,`,
-		`FileSize:` + fmt.Sprintf("%v", this.FileSize) + `,`,
-		`FileMode:` + fmt.Sprintf("%v", this.FileMode) + `,`,
-		`DirMode:` + fmt.Sprintf("%v", this.DirMode) + `,`,
-		`Uid:` + fmt.Sprintf("%v", this.Uid) + `,`,
-		`Gid:` + fmt.Sprintf("%v", this.Gid) + `,`,
-		`Offset:` + fmt.Sprintf("%v", this.Offset) + `,`,
-		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
 }
-func (this *GetOOMEventRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *WriteStreamRequest) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	s := strings.Join([]string{`&GetOOMEventRequest{`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *OOMEvent) String() string {
-	if this == nil {
-		return "nil"
+	var l int
+	_ = l
+	l = len(m.ContainerId)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
 	}
-	s := strings.Join([]string{`&OOMEvent{`,
-		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *AddSwapRequest) String() string {
-	if this == nil {
-		return "nil"
+	l = len(m.ExecId)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
 	}
-	s := strings.Join([]string{`&AddSwapRequest{`,
-		`PCIPath:` + fmt.Sprintf("%v", this.PCIPath) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
 }
-func (this *GetMetricsRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *WriteStreamResponse) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	s := strings.Join([]string{`&GetMetricsRequest{`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
+	var l int
+	_ = l
+	if m.Len != 0 {
+		n += 1 + sovAgent(uint64(m.Len))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
 }
-func (this *Metrics) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *ReadStreamRequest) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	s := strings.Join([]string{`&Metrics{`,
-		`Metrics:` + fmt.Sprintf("%v", this.Metrics) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
+	var l int
+	_ = l
+	l = len(m.ContainerId)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	l = len(m.ExecId)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.Len != 0 {
+		n += 1 + sovAgent(uint64(m.Len))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
 }
-func (this *VolumeStatsRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *ReadStreamResponse) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	s := strings.Join([]string{`&VolumeStatsRequest{`,
-		`VolumeGuestPath:` + fmt.Sprintf("%v", this.VolumeGuestPath) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
+	var l int
+	_ = l
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
 }
-func (this *ResizeVolumeRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *CloseStdinRequest) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	s := strings.Join([]string{`&ResizeVolumeRequest{`,
-		`VolumeGuestPath:` + fmt.Sprintf("%v", this.VolumeGuestPath) + `,`,
-		`Size_:` + fmt.Sprintf("%v", this.Size_) + `,`,
-		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
-		`}`,
-	}, "")
-	return s
+	var l int
+	_ = l
+	l = len(m.ContainerId)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	l = len(m.ExecId)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
 }
-func valueToStringAgent(v interface{}) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+
+func (m *TtyWinResizeRequest) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("*%v", pv)
+	var l int
+	_ = l
+	l = len(m.ContainerId)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	l = len(m.ExecId)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.Row != 0 {
+		n += 1 + sovAgent(uint64(m.Row))
+	}
+	if m.Column != 0 {
+		n += 1 + sovAgent(uint64(m.Column))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
 }
 
-type AgentServiceService interface {
-	CreateContainer(ctx context.Context, req *CreateContainerRequest) (*types.Empty, error)
-	StartContainer(ctx context.Context, req *StartContainerRequest) (*types.Empty, error)
-	RemoveContainer(ctx context.Context, req *RemoveContainerRequest) (*types.Empty, error)
-	ExecProcess(ctx context.Context, req *ExecProcessRequest) (*types.Empty, error)
-	SignalProcess(ctx context.Context, req *SignalProcessRequest) (*types.Empty, error)
-	WaitProcess(ctx context.Context, req *WaitProcessRequest) (*WaitProcessResponse, error)
-	UpdateContainer(ctx context.Context, req *UpdateContainerRequest) (*types.Empty, error)
-	UpdateEphemeralMounts(ctx context.Context, req *UpdateEphemeralMountsRequest) (*types.Empty, error)
-	StatsContainer(ctx context.Context, req *StatsContainerRequest) (*StatsContainerResponse, error)
-	PauseContainer(ctx context.Context, req *PauseContainerRequest) (*types.Empty, error)
-	ResumeContainer(ctx context.Context, req *ResumeContainerRequest) (*types.Empty, error)
-	RemoveStaleVirtiofsShareMounts(ctx context.Context, req *RemoveStaleVirtiofsShareMountsRequest) (*types.Empty, error)
-	WriteStdin(ctx context.Context, req *WriteStreamRequest) (*WriteStreamResponse, error)
-	ReadStdout(ctx context.Context, req *ReadStreamRequest) (*ReadStreamResponse, error)
-	ReadStderr(ctx context.Context, req *ReadStreamRequest) (*ReadStreamResponse, error)
-	CloseStdin(ctx context.Context, req *CloseStdinRequest) (*types.Empty, error)
-	TtyWinResize(ctx context.Context, req *TtyWinResizeRequest) (*types.Empty, error)
-	UpdateInterface(ctx context.Context, req *UpdateInterfaceRequest) (*protocols.Interface, error)
-	UpdateRoutes(ctx context.Context, req *UpdateRoutesRequest) (*Routes, error)
-	ListInterfaces(ctx context.Context, req *ListInterfacesRequest) (*Interfaces, error)
-	ListRoutes(ctx context.Context, req *ListRoutesRequest) (*Routes, error)
-	AddARPNeighbors(ctx context.Context, req *AddARPNeighborsRequest) (*types.Empty, error)
-	GetIPTables(ctx context.Context, req *GetIPTablesRequest) (*GetIPTablesResponse, error)
-	SetIPTables(ctx context.Context, req *SetIPTablesRequest) (*SetIPTablesResponse, error)
-	GetMetrics(ctx context.Context, req *GetMetricsRequest) (*Metrics, error)
-	CreateSandbox(ctx context.Context, req *CreateSandboxRequest) (*types.Empty, error)
-	DestroySandbox(ctx context.Context, req *DestroySandboxRequest) (*types.Empty, error)
-	OnlineCPUMem(ctx context.Context, req *OnlineCPUMemRequest) (*types.Empty, error)
-	ReseedRandomDev(ctx context.Context, req *ReseedRandomDevRequest) (*types.Empty, error)
-	GetGuestDetails(ctx context.Context, req *GuestDetailsRequest) (*GuestDetailsResponse, error)
-	MemHotplugByProbe(ctx context.Context, req *MemHotplugByProbeRequest) (*types.Empty, error)
-	SetGuestDateTime(ctx context.Context, req *SetGuestDateTimeRequest) (*types.Empty, error)
-	CopyFile(ctx context.Context, req *CopyFileRequest) (*types.Empty, error)
-	GetOOMEvent(ctx context.Context, req *GetOOMEventRequest) (*OOMEvent, error)
-	AddSwap(ctx context.Context, req *AddSwapRequest) (*types.Empty, error)
-	GetVolumeStats(ctx context.Context, req *VolumeStatsRequest) (*VolumeStatsResponse, error)
-	ResizeVolume(ctx context.Context, req *ResizeVolumeRequest) (*types.Empty, error)
+func (m *KernelModule) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if len(m.Parameters) > 0 {
+		for _, s := range m.Parameters {
+			l = len(s)
+			n += 1 + l + sovAgent(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
 }
 
-func RegisterAgentServiceService(srv *github_com_containerd_ttrpc.Server, svc AgentServiceService) {
-	srv.Register("grpc.AgentService", map[string]github_com_containerd_ttrpc.Method{
-		"CreateContainer": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req CreateContainerRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
-			}
-			return svc.CreateContainer(ctx, &req)
-		},
-		"StartContainer": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req StartContainerRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
-			}
-			return svc.StartContainer(ctx, &req)
-		},
-		"RemoveContainer": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req RemoveContainerRequest
+func (m *CreateSandboxRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hostname)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if len(m.Dns) > 0 {
+		for _, s := range m.Dns {
+			l = len(s)
+			n += 1 + l + sovAgent(uint64(l))
+		}
+	}
+	if len(m.Storages) > 0 {
+		for _, e := range m.Storages {
+			l = e.Size()
+			n += 1 + l + sovAgent(uint64(l))
+		}
+	}
+	if m.SandboxPidns {
+		n += 2
+	}
+	l = len(m.SandboxId)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	l = len(m.GuestHookPath)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if len(m.KernelModules) > 0 {
+		for _, e := range m.KernelModules {
+			l = e.Size()
+			n += 1 + l + sovAgent(uint64(l))
+		}
+	}
+	if len(m.GuestServices) > 0 {
+		for _, s := range m.GuestServices {
+			l = len(s)
+			n += 1 + l + sovAgent(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DestroySandboxRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RemoveStaleVirtiofsShareMountsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Interfaces) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Interfaces) > 0 {
+		for _, e := range m.Interfaces {
+			l = e.Size()
+			n += 1 + l + sovAgent(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Routes) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Routes) > 0 {
+		for _, e := range m.Routes {
+			l = e.Size()
+			n += 1 + l + sovAgent(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UpdateInterfaceRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Interface != nil {
+		l = m.Interface.Size()
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UpdateRoutesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Routes != nil {
+		l = m.Routes.Size()
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UpdateEphemeralMountsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Storages) > 0 {
+		for _, e := range m.Storages {
+			l = e.Size()
+			n += 1 + l + sovAgent(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListInterfacesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListRoutesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ARPNeighbors) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.ARPNeighbors) > 0 {
+		for _, e := range m.ARPNeighbors {
+			l = e.Size()
+			n += 1 + l + sovAgent(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AddARPNeighborsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Neighbors != nil {
+		l = m.Neighbors.Size()
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetIPTablesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.IsIpv6 {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetIPTablesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SetIPTablesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.IsIpv6 {
+		n += 2
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SetIPTablesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *OnlineCPUMemRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Wait {
+		n += 2
+	}
+	if m.NbCpus != 0 {
+		n += 1 + sovAgent(uint64(m.NbCpus))
+	}
+	if m.CpuOnly {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ReseedRandomDevRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AgentDetails) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.InitDaemon {
+		n += 2
+	}
+	if len(m.DeviceHandlers) > 0 {
+		for _, s := range m.DeviceHandlers {
+			l = len(s)
+			n += 1 + l + sovAgent(uint64(l))
+		}
+	}
+	if len(m.StorageHandlers) > 0 {
+		for _, s := range m.StorageHandlers {
+			l = len(s)
+			n += 1 + l + sovAgent(uint64(l))
+		}
+	}
+	if m.SupportsSeccomp {
+		n += 2
+	}
+	l = len(m.ImageMetadata)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GuestDetailsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MemBlockSize {
+		n += 2
+	}
+	if m.MemHotplugProbe {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GuestDetailsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MemBlockSizeBytes != 0 {
+		n += 1 + sovAgent(uint64(m.MemBlockSizeBytes))
+	}
+	if m.AgentDetails != nil {
+		l = m.AgentDetails.Size()
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.SupportMemHotplugProbe {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *MemHotplugByProbeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.MemHotplugProbeAddr) > 0 {
+		l = 0
+		for _, e := range m.MemHotplugProbeAddr {
+			l += sovAgent(uint64(e))
+		}
+		n += 1 + sovAgent(uint64(l)) + l
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SetGuestDateTimeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Sec != 0 {
+		n += 1 + sovAgent(uint64(m.Sec))
+	}
+	if m.Usec != 0 {
+		n += 1 + sovAgent(uint64(m.Usec))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *FSGroup) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GroupId != 0 {
+		n += 1 + sovAgent(uint64(m.GroupId))
+	}
+	if m.GroupChangePolicy != 0 {
+		n += 1 + sovAgent(uint64(m.GroupChangePolicy))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Storage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Driver)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if len(m.DriverOptions) > 0 {
+		for _, s := range m.DriverOptions {
+			l = len(s)
+			n += 1 + l + sovAgent(uint64(l))
+		}
+	}
+	l = len(m.Source)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	l = len(m.Fstype)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if len(m.Options) > 0 {
+		for _, s := range m.Options {
+			l = len(s)
+			n += 1 + l + sovAgent(uint64(l))
+		}
+	}
+	l = len(m.MountPoint)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.FsGroup != nil {
+		l = m.FsGroup.Size()
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Device) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	l = len(m.VmPath)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	l = len(m.ContainerPath)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if len(m.Options) > 0 {
+		for _, s := range m.Options {
+			l = len(s)
+			n += 1 + l + sovAgent(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *StringUser) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Uid)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	l = len(m.Gid)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if len(m.AdditionalGids) > 0 {
+		for _, s := range m.AdditionalGids {
+			l = len(s)
+			n += 1 + l + sovAgent(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CopyFileRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.FileSize != 0 {
+		n += 1 + sovAgent(uint64(m.FileSize))
+	}
+	if m.FileMode != 0 {
+		n += 1 + sovAgent(uint64(m.FileMode))
+	}
+	if m.DirMode != 0 {
+		n += 1 + sovAgent(uint64(m.DirMode))
+	}
+	if m.Uid != 0 {
+		n += 1 + sovAgent(uint64(m.Uid))
+	}
+	if m.Gid != 0 {
+		n += 1 + sovAgent(uint64(m.Gid))
+	}
+	if m.Offset != 0 {
+		n += 1 + sovAgent(uint64(m.Offset))
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ReadFileRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.MaxSize != 0 {
+		n += 1 + sovAgent(uint64(m.MaxSize))
+	}
+	if m.Offset != 0 {
+		n += 1 + sovAgent(uint64(m.Offset))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ReadFileResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetDiagnosticsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MaxKernelLogSize != 0 {
+		n += 1 + sovAgent(uint64(m.MaxKernelLogSize))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Diagnostics) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.KernelLog)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	l = len(m.Mounts)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	l = len(m.Processes)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CriuOptions) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.TcpEstablished {
+		n += 2
+	}
+	if m.FileLocks {
+		n += 2
+	}
+	if m.ExtUnixSockets {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CheckpointContainerRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ContainerId)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	l = len(m.ImagePath)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.LeaveRunning {
+		n += 2
+	}
+	if m.Options != nil {
+		l = m.Options.Size()
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CheckpointContainerResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Files) > 0 {
+		for _, s := range m.Files {
+			l = len(s)
+			n += 1 + l + sovAgent(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RestoreContainerRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ContainerId)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	l = len(m.ImagePath)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.Options != nil {
+		l = m.Options.Size()
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetOOMEventRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *OOMEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ContainerId)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AddSwapRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.PCIPath) > 0 {
+		l = 0
+		for _, e := range m.PCIPath {
+			l += sovAgent(uint64(e))
+		}
+		n += 1 + sovAgent(uint64(l)) + l
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetMetricsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Metrics) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Metrics)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *VolumeStatsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.VolumeGuestPath)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ContainerVolumeStatsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ContainerId)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ContainerVolumeStats) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Destination)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	l = len(m.VolumeGuestPath)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.Stats != nil {
+		l = m.Stats.Size()
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ContainerVolumeStatsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Volumes) > 0 {
+		for _, e := range m.Volumes {
+			l = e.Size()
+			n += 1 + l + sovAgent(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResizeVolumeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.VolumeGuestPath)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.Size_ != 0 {
+		n += 1 + sovAgent(uint64(m.Size_))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *WaitDeviceRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.Timeout != 0 {
+		n += 1 + sovAgent(uint64(m.Timeout))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SyncFsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ContainerId)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.Timeout != 0 {
+		n += 1 + sovAgent(uint64(m.Timeout))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *FreezeFsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ContainerId)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ThawFsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SetNameResolutionRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hosts)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	l = len(m.ResolvConf)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetGuestServicesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GuestService) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	l = len(m.State)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.Restarts != 0 {
+		n += 1 + sovAgent(uint64(m.Restarts))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GuestServices) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Services) > 0 {
+		for _, e := range m.Services {
+			l = e.Size()
+			n += 1 + l + sovAgent(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *NetworkPolicyRule) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Direction)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	l = len(m.Cidr)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	l = len(m.Protocol)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.Port != 0 {
+		n += 1 + sovAgent(uint64(m.Port))
+	}
+	if m.EndPort != 0 {
+		n += 1 + sovAgent(uint64(m.EndPort))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SetNetworkPolicyRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Program)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.Ingress {
+		n += 2
+	}
+	if m.Egress {
+		n += 2
+	}
+	if len(m.Rules) > 0 {
+		for _, e := range m.Rules {
+			l = e.Size()
+			n += 1 + l + sovAgent(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetSeccompNotificationRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SeccompNotification) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != 0 {
+		n += 1 + sovAgent(uint64(m.Id))
+	}
+	l = len(m.ContainerId)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	l = len(m.ExecId)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if m.Pid != 0 {
+		n += 1 + sovAgent(uint64(m.Pid))
+	}
+	l = len(m.Syscall)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	l = len(m.Arch)
+	if l > 0 {
+		n += 1 + l + sovAgent(uint64(l))
+	}
+	if len(m.Args) > 0 {
+		l = 0
+		for _, e := range m.Args {
+			l += sovAgent(uint64(e))
+		}
+		n += 1 + sovAgent(uint64(l)) + l
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SeccompNotificationResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != 0 {
+		n += 1 + sovAgent(uint64(m.Id))
+	}
+	if m.Allow {
+		n += 2
+	}
+	if m.Error != 0 {
+		n += 1 + sovAgent(uint64(m.Error))
+	}
+	if m.Value != 0 {
+		n += 1 + sovAgent(uint64(m.Value))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovAgent(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozAgent(x uint64) (n int) {
+	return sovAgent(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *CreateContainerRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForDevices := "[]*Device{"
+	for _, f := range this.Devices {
+		repeatedStringForDevices += strings.Replace(f.String(), "Device", "Device", 1) + ","
+	}
+	repeatedStringForDevices += "}"
+	repeatedStringForStorages := "[]*Storage{"
+	for _, f := range this.Storages {
+		repeatedStringForStorages += strings.Replace(f.String(), "Storage", "Storage", 1) + ","
+	}
+	repeatedStringForStorages += "}"
+	s := strings.Join([]string{`&CreateContainerRequest{`,
+		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
+		`ExecId:` + fmt.Sprintf("%v", this.ExecId) + `,`,
+		`StringUser:` + strings.Replace(this.StringUser.String(), "StringUser", "StringUser", 1) + `,`,
+		`Devices:` + repeatedStringForDevices + `,`,
+		`Storages:` + repeatedStringForStorages + `,`,
+		`OCI:` + strings.Replace(fmt.Sprintf("%v", this.OCI), "Spec", "Spec", 1) + `,`,
+		`SandboxPidns:` + fmt.Sprintf("%v", this.SandboxPidns) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *StartContainerRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&StartContainerRequest{`,
+		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
+		`ExecTimeout:` + fmt.Sprintf("%v", this.ExecTimeout) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *RemoveContainerRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&RemoveContainerRequest{`,
+		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
+		`Timeout:` + fmt.Sprintf("%v", this.Timeout) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ExecProcessRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ExecProcessRequest{`,
+		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
+		`ExecId:` + fmt.Sprintf("%v", this.ExecId) + `,`,
+		`StringUser:` + strings.Replace(this.StringUser.String(), "StringUser", "StringUser", 1) + `,`,
+		`Process:` + strings.Replace(fmt.Sprintf("%v", this.Process), "Process", "Process", 1) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SignalProcessRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&SignalProcessRequest{`,
+		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
+		`ExecId:` + fmt.Sprintf("%v", this.ExecId) + `,`,
+		`Signal:` + fmt.Sprintf("%v", this.Signal) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *WaitProcessRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&WaitProcessRequest{`,
+		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
+		`ExecId:` + fmt.Sprintf("%v", this.ExecId) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *WaitProcessResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&WaitProcessResponse{`,
+		`Status:` + fmt.Sprintf("%v", this.Status) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *UpdateContainerRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&UpdateContainerRequest{`,
+		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
+		`Resources:` + strings.Replace(fmt.Sprintf("%v", this.Resources), "LinuxResources", "LinuxResources", 1) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *StatsContainerRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&StatsContainerRequest{`,
+		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PauseContainerRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&PauseContainerRequest{`,
+		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ResumeContainerRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ResumeContainerRequest{`,
+		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CpuUsage) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&CpuUsage{`,
+		`TotalUsage:` + fmt.Sprintf("%v", this.TotalUsage) + `,`,
+		`PercpuUsage:` + fmt.Sprintf("%v", this.PercpuUsage) + `,`,
+		`UsageInKernelmode:` + fmt.Sprintf("%v", this.UsageInKernelmode) + `,`,
+		`UsageInUsermode:` + fmt.Sprintf("%v", this.UsageInUsermode) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ThrottlingData) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ThrottlingData{`,
+		`Periods:` + fmt.Sprintf("%v", this.Periods) + `,`,
+		`ThrottledPeriods:` + fmt.Sprintf("%v", this.ThrottledPeriods) + `,`,
+		`ThrottledTime:` + fmt.Sprintf("%v", this.ThrottledTime) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CpuStats) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&CpuStats{`,
+		`CpuUsage:` + strings.Replace(this.CpuUsage.String(), "CpuUsage", "CpuUsage", 1) + `,`,
+		`ThrottlingData:` + strings.Replace(this.ThrottlingData.String(), "ThrottlingData", "ThrottlingData", 1) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PidsStats) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&PidsStats{`,
+		`Current:` + fmt.Sprintf("%v", this.Current) + `,`,
+		`Limit:` + fmt.Sprintf("%v", this.Limit) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *MemoryData) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&MemoryData{`,
+		`Usage:` + fmt.Sprintf("%v", this.Usage) + `,`,
+		`MaxUsage:` + fmt.Sprintf("%v", this.MaxUsage) + `,`,
+		`Failcnt:` + fmt.Sprintf("%v", this.Failcnt) + `,`,
+		`Limit:` + fmt.Sprintf("%v", this.Limit) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *MemoryStats) String() string {
+	if this == nil {
+		return "nil"
+	}
+	keysForStats := make([]string, 0, len(this.Stats))
+	for k, _ := range this.Stats {
+		keysForStats = append(keysForStats, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForStats)
+	mapStringForStats := "map[string]uint64{"
+	for _, k := range keysForStats {
+		mapStringForStats += fmt.Sprintf("%v: %v,", k, this.Stats[k])
+	}
+	mapStringForStats += "}"
+	s := strings.Join([]string{`&MemoryStats{`,
+		`Cache:` + fmt.Sprintf("%v", this.Cache) + `,`,
+		`Usage:` + strings.Replace(this.Usage.String(), "MemoryData", "MemoryData", 1) + `,`,
+		`SwapUsage:` + strings.Replace(this.SwapUsage.String(), "MemoryData", "MemoryData", 1) + `,`,
+		`KernelUsage:` + strings.Replace(this.KernelUsage.String(), "MemoryData", "MemoryData", 1) + `,`,
+		`UseHierarchy:` + fmt.Sprintf("%v", this.UseHierarchy) + `,`,
+		`Stats:` + mapStringForStats + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *BlkioStatsEntry) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&BlkioStatsEntry{`,
+		`Major:` + fmt.Sprintf("%v", this.Major) + `,`,
+		`Minor:` + fmt.Sprintf("%v", this.Minor) + `,`,
+		`Op:` + fmt.Sprintf("%v", this.Op) + `,`,
+		`Value:` + fmt.Sprintf("%v", this.Value) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *BlkioStats) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForIoServiceBytesRecursive := "[]*BlkioStatsEntry{"
+	for _, f := range this.IoServiceBytesRecursive {
+		repeatedStringForIoServiceBytesRecursive += strings.Replace(f.String(), "BlkioStatsEntry", "BlkioStatsEntry", 1) + ","
+	}
+	repeatedStringForIoServiceBytesRecursive += "}"
+	repeatedStringForIoServicedRecursive := "[]*BlkioStatsEntry{"
+	for _, f := range this.IoServicedRecursive {
+		repeatedStringForIoServicedRecursive += strings.Replace(f.String(), "BlkioStatsEntry", "BlkioStatsEntry", 1) + ","
+	}
+	repeatedStringForIoServicedRecursive += "}"
+	repeatedStringForIoQueuedRecursive := "[]*BlkioStatsEntry{"
+	for _, f := range this.IoQueuedRecursive {
+		repeatedStringForIoQueuedRecursive += strings.Replace(f.String(), "BlkioStatsEntry", "BlkioStatsEntry", 1) + ","
+	}
+	repeatedStringForIoQueuedRecursive += "}"
+	repeatedStringForIoServiceTimeRecursive := "[]*BlkioStatsEntry{"
+	for _, f := range this.IoServiceTimeRecursive {
+		repeatedStringForIoServiceTimeRecursive += strings.Replace(f.String(), "BlkioStatsEntry", "BlkioStatsEntry", 1) + ","
+	}
+	repeatedStringForIoServiceTimeRecursive += "}"
+	repeatedStringForIoWaitTimeRecursive := "[]*BlkioStatsEntry{"
+	for _, f := range this.IoWaitTimeRecursive {
+		repeatedStringForIoWaitTimeRecursive += strings.Replace(f.String(), "BlkioStatsEntry", "BlkioStatsEntry", 1) + ","
+	}
+	repeatedStringForIoWaitTimeRecursive += "}"
+	repeatedStringForIoMergedRecursive := "[]*BlkioStatsEntry{"
+	for _, f := range this.IoMergedRecursive {
+		repeatedStringForIoMergedRecursive += strings.Replace(f.String(), "BlkioStatsEntry", "BlkioStatsEntry", 1) + ","
+	}
+	repeatedStringForIoMergedRecursive += "}"
+	repeatedStringForIoTimeRecursive := "[]*BlkioStatsEntry{"
+	for _, f := range this.IoTimeRecursive {
+		repeatedStringForIoTimeRecursive += strings.Replace(f.String(), "BlkioStatsEntry", "BlkioStatsEntry", 1) + ","
+	}
+	repeatedStringForIoTimeRecursive += "}"
+	repeatedStringForSectorsRecursive := "[]*BlkioStatsEntry{"
+	for _, f := range this.SectorsRecursive {
+		repeatedStringForSectorsRecursive += strings.Replace(f.String(), "BlkioStatsEntry", "BlkioStatsEntry", 1) + ","
+	}
+	repeatedStringForSectorsRecursive += "}"
+	s := strings.Join([]string{`&BlkioStats{`,
+		`IoServiceBytesRecursive:` + repeatedStringForIoServiceBytesRecursive + `,`,
+		`IoServicedRecursive:` + repeatedStringForIoServicedRecursive + `,`,
+		`IoQueuedRecursive:` + repeatedStringForIoQueuedRecursive + `,`,
+		`IoServiceTimeRecursive:` + repeatedStringForIoServiceTimeRecursive + `,`,
+		`IoWaitTimeRecursive:` + repeatedStringForIoWaitTimeRecursive + `,`,
+		`IoMergedRecursive:` + repeatedStringForIoMergedRecursive + `,`,
+		`IoTimeRecursive:` + repeatedStringForIoTimeRecursive + `,`,
+		`SectorsRecursive:` + repeatedStringForSectorsRecursive + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *HugetlbStats) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&HugetlbStats{`,
+		`Usage:` + fmt.Sprintf("%v", this.Usage) + `,`,
+		`MaxUsage:` + fmt.Sprintf("%v", this.MaxUsage) + `,`,
+		`Failcnt:` + fmt.Sprintf("%v", this.Failcnt) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CgroupStats) String() string {
+	if this == nil {
+		return "nil"
+	}
+	keysForHugetlbStats := make([]string, 0, len(this.HugetlbStats))
+	for k, _ := range this.HugetlbStats {
+		keysForHugetlbStats = append(keysForHugetlbStats, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForHugetlbStats)
+	mapStringForHugetlbStats := "map[string]*HugetlbStats{"
+	for _, k := range keysForHugetlbStats {
+		mapStringForHugetlbStats += fmt.Sprintf("%v: %v,", k, this.HugetlbStats[k])
+	}
+	mapStringForHugetlbStats += "}"
+	s := strings.Join([]string{`&CgroupStats{`,
+		`CpuStats:` + strings.Replace(this.CpuStats.String(), "CpuStats", "CpuStats", 1) + `,`,
+		`MemoryStats:` + strings.Replace(this.MemoryStats.String(), "MemoryStats", "MemoryStats", 1) + `,`,
+		`PidsStats:` + strings.Replace(this.PidsStats.String(), "PidsStats", "PidsStats", 1) + `,`,
+		`BlkioStats:` + strings.Replace(this.BlkioStats.String(), "BlkioStats", "BlkioStats", 1) + `,`,
+		`HugetlbStats:` + mapStringForHugetlbStats + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *NetworkStats) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&NetworkStats{`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`RxBytes:` + fmt.Sprintf("%v", this.RxBytes) + `,`,
+		`RxPackets:` + fmt.Sprintf("%v", this.RxPackets) + `,`,
+		`RxErrors:` + fmt.Sprintf("%v", this.RxErrors) + `,`,
+		`RxDropped:` + fmt.Sprintf("%v", this.RxDropped) + `,`,
+		`TxBytes:` + fmt.Sprintf("%v", this.TxBytes) + `,`,
+		`TxPackets:` + fmt.Sprintf("%v", this.TxPackets) + `,`,
+		`TxErrors:` + fmt.Sprintf("%v", this.TxErrors) + `,`,
+		`TxDropped:` + fmt.Sprintf("%v", this.TxDropped) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *StatsContainerResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForNetworkStats := "[]*NetworkStats{"
+	for _, f := range this.NetworkStats {
+		repeatedStringForNetworkStats += strings.Replace(f.String(), "NetworkStats", "NetworkStats", 1) + ","
+	}
+	repeatedStringForNetworkStats += "}"
+	s := strings.Join([]string{`&StatsContainerResponse{`,
+		`CgroupStats:` + strings.Replace(this.CgroupStats.String(), "CgroupStats", "CgroupStats", 1) + `,`,
+		`NetworkStats:` + repeatedStringForNetworkStats + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *WriteStreamRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&WriteStreamRequest{`,
+		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
+		`ExecId:` + fmt.Sprintf("%v", this.ExecId) + `,`,
+		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *WriteStreamResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&WriteStreamResponse{`,
+		`Len:` + fmt.Sprintf("%v", this.Len) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ReadStreamRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ReadStreamRequest{`,
+		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
+		`ExecId:` + fmt.Sprintf("%v", this.ExecId) + `,`,
+		`Len:` + fmt.Sprintf("%v", this.Len) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ReadStreamResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ReadStreamResponse{`,
+		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CloseStdinRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&CloseStdinRequest{`,
+		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
+		`ExecId:` + fmt.Sprintf("%v", this.ExecId) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TtyWinResizeRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TtyWinResizeRequest{`,
+		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
+		`ExecId:` + fmt.Sprintf("%v", this.ExecId) + `,`,
+		`Row:` + fmt.Sprintf("%v", this.Row) + `,`,
+		`Column:` + fmt.Sprintf("%v", this.Column) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *KernelModule) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&KernelModule{`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`Parameters:` + fmt.Sprintf("%v", this.Parameters) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CreateSandboxRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForStorages := "[]*Storage{"
+	for _, f := range this.Storages {
+		repeatedStringForStorages += strings.Replace(f.String(), "Storage", "Storage", 1) + ","
+	}
+	repeatedStringForStorages += "}"
+	repeatedStringForKernelModules := "[]*KernelModule{"
+	for _, f := range this.KernelModules {
+		repeatedStringForKernelModules += strings.Replace(f.String(), "KernelModule", "KernelModule", 1) + ","
+	}
+	repeatedStringForKernelModules += "}"
+	s := strings.Join([]string{`&CreateSandboxRequest{`,
+		`Hostname:` + fmt.Sprintf("%v", this.Hostname) + `,`,
+		`Dns:` + fmt.Sprintf("%v", this.Dns) + `,`,
+		`Storages:` + repeatedStringForStorages + `,`,
+		`SandboxPidns:` + fmt.Sprintf("%v", this.SandboxPidns) + `,`,
+		`SandboxId:` + fmt.Sprintf("%v", this.SandboxId) + `,`,
+		`GuestHookPath:` + fmt.Sprintf("%v", this.GuestHookPath) + `,`,
+		`KernelModules:` + repeatedStringForKernelModules + `,`,
+		`GuestServices:` + fmt.Sprintf("%v", this.GuestServices) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *DestroySandboxRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&DestroySandboxRequest{`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *RemoveStaleVirtiofsShareMountsRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&RemoveStaleVirtiofsShareMountsRequest{`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Interfaces) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForInterfaces := "[]*Interface{"
+	for _, f := range this.Interfaces {
+		repeatedStringForInterfaces += strings.Replace(fmt.Sprintf("%v", f), "Interface", "protocols.Interface", 1) + ","
+	}
+	repeatedStringForInterfaces += "}"
+	s := strings.Join([]string{`&Interfaces{`,
+		`Interfaces:` + repeatedStringForInterfaces + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Routes) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForRoutes := "[]*Route{"
+	for _, f := range this.Routes {
+		repeatedStringForRoutes += strings.Replace(fmt.Sprintf("%v", f), "Route", "protocols.Route", 1) + ","
+	}
+	repeatedStringForRoutes += "}"
+	s := strings.Join([]string{`&Routes{`,
+		`Routes:` + repeatedStringForRoutes + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *UpdateInterfaceRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&UpdateInterfaceRequest{`,
+		`Interface:` + strings.Replace(fmt.Sprintf("%v", this.Interface), "Interface", "protocols.Interface", 1) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *UpdateRoutesRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&UpdateRoutesRequest{`,
+		`Routes:` + strings.Replace(this.Routes.String(), "Routes", "Routes", 1) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *UpdateEphemeralMountsRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForStorages := "[]*Storage{"
+	for _, f := range this.Storages {
+		repeatedStringForStorages += strings.Replace(f.String(), "Storage", "Storage", 1) + ","
+	}
+	repeatedStringForStorages += "}"
+	s := strings.Join([]string{`&UpdateEphemeralMountsRequest{`,
+		`Storages:` + repeatedStringForStorages + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ListInterfacesRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ListInterfacesRequest{`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ListRoutesRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ListRoutesRequest{`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ARPNeighbors) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForARPNeighbors := "[]*ARPNeighbor{"
+	for _, f := range this.ARPNeighbors {
+		repeatedStringForARPNeighbors += strings.Replace(fmt.Sprintf("%v", f), "ARPNeighbor", "protocols.ARPNeighbor", 1) + ","
+	}
+	repeatedStringForARPNeighbors += "}"
+	s := strings.Join([]string{`&ARPNeighbors{`,
+		`ARPNeighbors:` + repeatedStringForARPNeighbors + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AddARPNeighborsRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&AddARPNeighborsRequest{`,
+		`Neighbors:` + strings.Replace(this.Neighbors.String(), "ARPNeighbors", "ARPNeighbors", 1) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GetIPTablesRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GetIPTablesRequest{`,
+		`IsIpv6:` + fmt.Sprintf("%v", this.IsIpv6) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GetIPTablesResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GetIPTablesResponse{`,
+		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SetIPTablesRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&SetIPTablesRequest{`,
+		`IsIpv6:` + fmt.Sprintf("%v", this.IsIpv6) + `,`,
+		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SetIPTablesResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&SetIPTablesResponse{`,
+		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *OnlineCPUMemRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&OnlineCPUMemRequest{`,
+		`Wait:` + fmt.Sprintf("%v", this.Wait) + `,`,
+		`NbCpus:` + fmt.Sprintf("%v", this.NbCpus) + `,`,
+		`CpuOnly:` + fmt.Sprintf("%v", this.CpuOnly) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ReseedRandomDevRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ReseedRandomDevRequest{`,
+		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AgentDetails) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&AgentDetails{`,
+		`Version:` + fmt.Sprintf("%v", this.Version) + `,`,
+		`InitDaemon:` + fmt.Sprintf("%v", this.InitDaemon) + `,`,
+		`DeviceHandlers:` + fmt.Sprintf("%v", this.DeviceHandlers) + `,`,
+		`StorageHandlers:` + fmt.Sprintf("%v", this.StorageHandlers) + `,`,
+		`SupportsSeccomp:` + fmt.Sprintf("%v", this.SupportsSeccomp) + `,`,
+		`ImageMetadata:` + fmt.Sprintf("%v", this.ImageMetadata) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GuestDetailsRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GuestDetailsRequest{`,
+		`MemBlockSize:` + fmt.Sprintf("%v", this.MemBlockSize) + `,`,
+		`MemHotplugProbe:` + fmt.Sprintf("%v", this.MemHotplugProbe) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GuestDetailsResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GuestDetailsResponse{`,
+		`MemBlockSizeBytes:` + fmt.Sprintf("%v", this.MemBlockSizeBytes) + `,`,
+		`AgentDetails:` + strings.Replace(this.AgentDetails.String(), "AgentDetails", "AgentDetails", 1) + `,`,
+		`SupportMemHotplugProbe:` + fmt.Sprintf("%v", this.SupportMemHotplugProbe) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *MemHotplugByProbeRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&MemHotplugByProbeRequest{`,
+		`MemHotplugProbeAddr:` + fmt.Sprintf("%v", this.MemHotplugProbeAddr) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SetGuestDateTimeRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&SetGuestDateTimeRequest{`,
+		`Sec:` + fmt.Sprintf("%v", this.Sec) + `,`,
+		`Usec:` + fmt.Sprintf("%v", this.Usec) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *FSGroup) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&FSGroup{`,
+		`GroupId:` + fmt.Sprintf("%v", this.GroupId) + `,`,
+		`GroupChangePolicy:` + fmt.Sprintf("%v", this.GroupChangePolicy) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Storage) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Storage{`,
+		`Driver:` + fmt.Sprintf("%v", this.Driver) + `,`,
+		`DriverOptions:` + fmt.Sprintf("%v", this.DriverOptions) + `,`,
+		`Source:` + fmt.Sprintf("%v", this.Source) + `,`,
+		`Fstype:` + fmt.Sprintf("%v", this.Fstype) + `,`,
+		`Options:` + fmt.Sprintf("%v", this.Options) + `,`,
+		`MountPoint:` + fmt.Sprintf("%v", this.MountPoint) + `,`,
+		`FsGroup:` + strings.Replace(this.FsGroup.String(), "FSGroup", "FSGroup", 1) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Device) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Device{`,
+		`Id:` + fmt.Sprintf("%v", this.Id) + `,`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`VmPath:` + fmt.Sprintf("%v", this.VmPath) + `,`,
+		`ContainerPath:` + fmt.Sprintf("%v", this.ContainerPath) + `,`,
+		`Options:` + fmt.Sprintf("%v", this.Options) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *StringUser) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&StringUser{`,
+		`Uid:` + fmt.Sprintf("%v", this.Uid) + `,`,
+		`Gid:` + fmt.Sprintf("%v", this.Gid) + `,`,
+		`AdditionalGids:` + fmt.Sprintf("%v", this.AdditionalGids) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CopyFileRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&CopyFileRequest{`,
+		`Path:` + fmt.Sprintf("%v", this.Path) + `,`,
+		`FileSize:` + fmt.Sprintf("%v", this.FileSize) + `,`,
+		`FileMode:` + fmt.Sprintf("%v", this.FileMode) + `,`,
+		`DirMode:` + fmt.Sprintf("%v", this.DirMode) + `,`,
+		`Uid:` + fmt.Sprintf("%v", this.Uid) + `,`,
+		`Gid:` + fmt.Sprintf("%v", this.Gid) + `,`,
+		`Offset:` + fmt.Sprintf("%v", this.Offset) + `,`,
+		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ReadFileRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ReadFileRequest{`,
+		`Path:` + fmt.Sprintf("%v", this.Path) + `,`,
+		`MaxSize:` + fmt.Sprintf("%v", this.MaxSize) + `,`,
+		`Offset:` + fmt.Sprintf("%v", this.Offset) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ReadFileResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ReadFileResponse{`,
+		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GetDiagnosticsRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GetDiagnosticsRequest{`,
+		`MaxKernelLogSize:` + fmt.Sprintf("%v", this.MaxKernelLogSize) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Diagnostics) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Diagnostics{`,
+		`KernelLog:` + fmt.Sprintf("%v", this.KernelLog) + `,`,
+		`Mounts:` + fmt.Sprintf("%v", this.Mounts) + `,`,
+		`Processes:` + fmt.Sprintf("%v", this.Processes) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CriuOptions) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&CriuOptions{`,
+		`TcpEstablished:` + fmt.Sprintf("%v", this.TcpEstablished) + `,`,
+		`FileLocks:` + fmt.Sprintf("%v", this.FileLocks) + `,`,
+		`ExtUnixSockets:` + fmt.Sprintf("%v", this.ExtUnixSockets) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CheckpointContainerRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&CheckpointContainerRequest{`,
+		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
+		`ImagePath:` + fmt.Sprintf("%v", this.ImagePath) + `,`,
+		`LeaveRunning:` + fmt.Sprintf("%v", this.LeaveRunning) + `,`,
+		`Options:` + strings.Replace(this.Options.String(), "CriuOptions", "CriuOptions", 1) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CheckpointContainerResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&CheckpointContainerResponse{`,
+		`Files:` + fmt.Sprintf("%v", this.Files) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *RestoreContainerRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&RestoreContainerRequest{`,
+		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
+		`ImagePath:` + fmt.Sprintf("%v", this.ImagePath) + `,`,
+		`Options:` + strings.Replace(this.Options.String(), "CriuOptions", "CriuOptions", 1) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GetOOMEventRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GetOOMEventRequest{`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *OOMEvent) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&OOMEvent{`,
+		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AddSwapRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&AddSwapRequest{`,
+		`PCIPath:` + fmt.Sprintf("%v", this.PCIPath) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GetMetricsRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GetMetricsRequest{`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Metrics) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Metrics{`,
+		`Metrics:` + fmt.Sprintf("%v", this.Metrics) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *VolumeStatsRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&VolumeStatsRequest{`,
+		`VolumeGuestPath:` + fmt.Sprintf("%v", this.VolumeGuestPath) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ContainerVolumeStatsRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ContainerVolumeStatsRequest{`,
+		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ContainerVolumeStats) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ContainerVolumeStats{`,
+		`Destination:` + fmt.Sprintf("%v", this.Destination) + `,`,
+		`VolumeGuestPath:` + fmt.Sprintf("%v", this.VolumeGuestPath) + `,`,
+		`Stats:` + strings.Replace(fmt.Sprintf("%v", this.Stats), "VolumeStatsResponse", "VolumeStatsResponse", 1) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ContainerVolumeStatsResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForVolumes := "[]*ContainerVolumeStats{"
+	for _, f := range this.Volumes {
+		repeatedStringForVolumes += strings.Replace(f.String(), "ContainerVolumeStats", "ContainerVolumeStats", 1) + ","
+	}
+	repeatedStringForVolumes += "}"
+	s := strings.Join([]string{`&ContainerVolumeStatsResponse{`,
+		`Volumes:` + repeatedStringForVolumes + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ResizeVolumeRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ResizeVolumeRequest{`,
+		`VolumeGuestPath:` + fmt.Sprintf("%v", this.VolumeGuestPath) + `,`,
+		`Size_:` + fmt.Sprintf("%v", this.Size_) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *WaitDeviceRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&WaitDeviceRequest{`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`Address:` + fmt.Sprintf("%v", this.Address) + `,`,
+		`Timeout:` + fmt.Sprintf("%v", this.Timeout) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SyncFsRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&SyncFsRequest{`,
+		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
+		`Timeout:` + fmt.Sprintf("%v", this.Timeout) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *FreezeFsRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&FreezeFsRequest{`,
+		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ThawFsRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ThawFsRequest{`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SetNameResolutionRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&SetNameResolutionRequest{`,
+		`Hosts:` + fmt.Sprintf("%v", this.Hosts) + `,`,
+		`ResolvConf:` + fmt.Sprintf("%v", this.ResolvConf) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GetGuestServicesRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GetGuestServicesRequest{`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GuestService) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GuestService{`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`State:` + fmt.Sprintf("%v", this.State) + `,`,
+		`Restarts:` + fmt.Sprintf("%v", this.Restarts) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GuestServices) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForServices := "[]*GuestService{"
+	for _, f := range this.Services {
+		repeatedStringForServices += strings.Replace(f.String(), "GuestService", "GuestService", 1) + ","
+	}
+	repeatedStringForServices += "}"
+	s := strings.Join([]string{`&GuestServices{`,
+		`Services:` + repeatedStringForServices + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *NetworkPolicyRule) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&NetworkPolicyRule{`,
+		`Direction:` + fmt.Sprintf("%v", this.Direction) + `,`,
+		`Cidr:` + fmt.Sprintf("%v", this.Cidr) + `,`,
+		`Protocol:` + fmt.Sprintf("%v", this.Protocol) + `,`,
+		`Port:` + fmt.Sprintf("%v", this.Port) + `,`,
+		`EndPort:` + fmt.Sprintf("%v", this.EndPort) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SetNetworkPolicyRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForRules := "[]*NetworkPolicyRule{"
+	for _, f := range this.Rules {
+		repeatedStringForRules += strings.Replace(f.String(), "NetworkPolicyRule", "NetworkPolicyRule", 1) + ","
+	}
+	repeatedStringForRules += "}"
+	s := strings.Join([]string{`&SetNetworkPolicyRequest{`,
+		`Program:` + fmt.Sprintf("%v", this.Program) + `,`,
+		`Ingress:` + fmt.Sprintf("%v", this.Ingress) + `,`,
+		`Egress:` + fmt.Sprintf("%v", this.Egress) + `,`,
+		`Rules:` + repeatedStringForRules + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GetSeccompNotificationRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GetSeccompNotificationRequest{`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SeccompNotification) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&SeccompNotification{`,
+		`Id:` + fmt.Sprintf("%v", this.Id) + `,`,
+		`ContainerId:` + fmt.Sprintf("%v", this.ContainerId) + `,`,
+		`ExecId:` + fmt.Sprintf("%v", this.ExecId) + `,`,
+		`Pid:` + fmt.Sprintf("%v", this.Pid) + `,`,
+		`Syscall:` + fmt.Sprintf("%v", this.Syscall) + `,`,
+		`Arch:` + fmt.Sprintf("%v", this.Arch) + `,`,
+		`Args:` + fmt.Sprintf("%v", this.Args) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SeccompNotificationResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&SeccompNotificationResponse{`,
+		`Id:` + fmt.Sprintf("%v", this.Id) + `,`,
+		`Allow:` + fmt.Sprintf("%v", this.Allow) + `,`,
+		`Error:` + fmt.Sprintf("%v", this.Error) + `,`,
+		`Value:` + fmt.Sprintf("%v", this.Value) + `,`,
+		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringAgent(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+
+type AgentServiceService interface {
+	CreateContainer(ctx context.Context, req *CreateContainerRequest) (*types.Empty, error)
+	StartContainer(ctx context.Context, req *StartContainerRequest) (*types.Empty, error)
+	RemoveContainer(ctx context.Context, req *RemoveContainerRequest) (*types.Empty, error)
+	ExecProcess(ctx context.Context, req *ExecProcessRequest) (*types.Empty, error)
+	SignalProcess(ctx context.Context, req *SignalProcessRequest) (*types.Empty, error)
+	WaitProcess(ctx context.Context, req *WaitProcessRequest) (*WaitProcessResponse, error)
+	UpdateContainer(ctx context.Context, req *UpdateContainerRequest) (*types.Empty, error)
+	UpdateEphemeralMounts(ctx context.Context, req *UpdateEphemeralMountsRequest) (*types.Empty, error)
+	StatsContainer(ctx context.Context, req *StatsContainerRequest) (*StatsContainerResponse, error)
+	PauseContainer(ctx context.Context, req *PauseContainerRequest) (*types.Empty, error)
+	ResumeContainer(ctx context.Context, req *ResumeContainerRequest) (*types.Empty, error)
+	RemoveStaleVirtiofsShareMounts(ctx context.Context, req *RemoveStaleVirtiofsShareMountsRequest) (*types.Empty, error)
+	WriteStdin(ctx context.Context, req *WriteStreamRequest) (*WriteStreamResponse, error)
+	ReadStdout(ctx context.Context, req *ReadStreamRequest) (*ReadStreamResponse, error)
+	ReadStderr(ctx context.Context, req *ReadStreamRequest) (*ReadStreamResponse, error)
+	CloseStdin(ctx context.Context, req *CloseStdinRequest) (*types.Empty, error)
+	TtyWinResize(ctx context.Context, req *TtyWinResizeRequest) (*types.Empty, error)
+	UpdateInterface(ctx context.Context, req *UpdateInterfaceRequest) (*protocols.Interface, error)
+	UpdateRoutes(ctx context.Context, req *UpdateRoutesRequest) (*Routes, error)
+	ListInterfaces(ctx context.Context, req *ListInterfacesRequest) (*Interfaces, error)
+	ListRoutes(ctx context.Context, req *ListRoutesRequest) (*Routes, error)
+	AddARPNeighbors(ctx context.Context, req *AddARPNeighborsRequest) (*types.Empty, error)
+	GetIPTables(ctx context.Context, req *GetIPTablesRequest) (*GetIPTablesResponse, error)
+	SetIPTables(ctx context.Context, req *SetIPTablesRequest) (*SetIPTablesResponse, error)
+	GetMetrics(ctx context.Context, req *GetMetricsRequest) (*Metrics, error)
+	CreateSandbox(ctx context.Context, req *CreateSandboxRequest) (*types.Empty, error)
+	DestroySandbox(ctx context.Context, req *DestroySandboxRequest) (*types.Empty, error)
+	OnlineCPUMem(ctx context.Context, req *OnlineCPUMemRequest) (*types.Empty, error)
+	ReseedRandomDev(ctx context.Context, req *ReseedRandomDevRequest) (*types.Empty, error)
+	GetGuestDetails(ctx context.Context, req *GuestDetailsRequest) (*GuestDetailsResponse, error)
+	MemHotplugByProbe(ctx context.Context, req *MemHotplugByProbeRequest) (*types.Empty, error)
+	SetGuestDateTime(ctx context.Context, req *SetGuestDateTimeRequest) (*types.Empty, error)
+	CopyFile(ctx context.Context, req *CopyFileRequest) (*types.Empty, error)
+	ReadFile(ctx context.Context, req *ReadFileRequest) (*ReadFileResponse, error)
+	GetDiagnostics(ctx context.Context, req *GetDiagnosticsRequest) (*Diagnostics, error)
+	CheckpointContainer(ctx context.Context, req *CheckpointContainerRequest) (*CheckpointContainerResponse, error)
+	RestoreContainer(ctx context.Context, req *RestoreContainerRequest) (*types.Empty, error)
+	GetOOMEvent(ctx context.Context, req *GetOOMEventRequest) (*OOMEvent, error)
+	AddSwap(ctx context.Context, req *AddSwapRequest) (*types.Empty, error)
+	GetVolumeStats(ctx context.Context, req *VolumeStatsRequest) (*VolumeStatsResponse, error)
+	GetContainerVolumeStats(ctx context.Context, req *ContainerVolumeStatsRequest) (*ContainerVolumeStatsResponse, error)
+	ResizeVolume(ctx context.Context, req *ResizeVolumeRequest) (*types.Empty, error)
+	WaitDevice(ctx context.Context, req *WaitDeviceRequest) (*types.Empty, error)
+	SyncFs(ctx context.Context, req *SyncFsRequest) (*types.Empty, error)
+	FreezeFs(ctx context.Context, req *FreezeFsRequest) (*types.Empty, error)
+	ThawFs(ctx context.Context, req *ThawFsRequest) (*types.Empty, error)
+	SetNameResolution(ctx context.Context, req *SetNameResolutionRequest) (*types.Empty, error)
+	GetGuestServices(ctx context.Context, req *GetGuestServicesRequest) (*GuestServices, error)
+	SetNetworkPolicy(ctx context.Context, req *SetNetworkPolicyRequest) (*types.Empty, error)
+	GetSeccompNotification(ctx context.Context, req *GetSeccompNotificationRequest) (*SeccompNotification, error)
+	SendSeccompNotificationResponse(ctx context.Context, req *SeccompNotificationResponse) (*types.Empty, error)
+}
+
+func RegisterAgentServiceService(srv *github_com_containerd_ttrpc.Server, svc AgentServiceService) {
+	srv.Register("grpc.AgentService", map[string]github_com_containerd_ttrpc.Method{
+		"CreateContainer": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req CreateContainerRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.CreateContainer(ctx, &req)
+		},
+		"StartContainer": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req StartContainerRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.StartContainer(ctx, &req)
+		},
+		"RemoveContainer": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req RemoveContainerRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.RemoveContainer(ctx, &req)
+		},
+		"ExecProcess": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req ExecProcessRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.ExecProcess(ctx, &req)
+		},
+		"SignalProcess": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req SignalProcessRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.SignalProcess(ctx, &req)
+		},
+		"WaitProcess": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req WaitProcessRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.WaitProcess(ctx, &req)
+		},
+		"UpdateContainer": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req UpdateContainerRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.UpdateContainer(ctx, &req)
+		},
+		"UpdateEphemeralMounts": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req UpdateEphemeralMountsRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.UpdateEphemeralMounts(ctx, &req)
+		},
+		"StatsContainer": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req StatsContainerRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.StatsContainer(ctx, &req)
+		},
+		"PauseContainer": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req PauseContainerRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.PauseContainer(ctx, &req)
+		},
+		"ResumeContainer": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req ResumeContainerRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.ResumeContainer(ctx, &req)
+		},
+		"RemoveStaleVirtiofsShareMounts": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req RemoveStaleVirtiofsShareMountsRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.RemoveStaleVirtiofsShareMounts(ctx, &req)
+		},
+		"WriteStdin": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req WriteStreamRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.WriteStdin(ctx, &req)
+		},
+		"ReadStdout": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req ReadStreamRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.ReadStdout(ctx, &req)
+		},
+		"ReadStderr": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req ReadStreamRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.ReadStderr(ctx, &req)
+		},
+		"CloseStdin": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req CloseStdinRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.CloseStdin(ctx, &req)
+		},
+		"TtyWinResize": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req TtyWinResizeRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.TtyWinResize(ctx, &req)
+		},
+		"UpdateInterface": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req UpdateInterfaceRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.UpdateInterface(ctx, &req)
+		},
+		"UpdateRoutes": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req UpdateRoutesRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.UpdateRoutes(ctx, &req)
+		},
+		"ListInterfaces": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req ListInterfacesRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.ListInterfaces(ctx, &req)
+		},
+		"ListRoutes": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req ListRoutesRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.ListRoutes(ctx, &req)
+		},
+		"AddARPNeighbors": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req AddARPNeighborsRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.AddARPNeighbors(ctx, &req)
+		},
+		"GetIPTables": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req GetIPTablesRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.GetIPTables(ctx, &req)
+		},
+		"SetIPTables": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req SetIPTablesRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.SetIPTables(ctx, &req)
+		},
+		"GetMetrics": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req GetMetricsRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.GetMetrics(ctx, &req)
+		},
+		"CreateSandbox": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req CreateSandboxRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.CreateSandbox(ctx, &req)
+		},
+		"DestroySandbox": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req DestroySandboxRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.DestroySandbox(ctx, &req)
+		},
+		"OnlineCPUMem": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req OnlineCPUMemRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.OnlineCPUMem(ctx, &req)
+		},
+		"ReseedRandomDev": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req ReseedRandomDevRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.ReseedRandomDev(ctx, &req)
+		},
+		"GetGuestDetails": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req GuestDetailsRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.GetGuestDetails(ctx, &req)
+		},
+		"MemHotplugByProbe": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req MemHotplugByProbeRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.MemHotplugByProbe(ctx, &req)
+		},
+		"SetGuestDateTime": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req SetGuestDateTimeRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.SetGuestDateTime(ctx, &req)
+		},
+		"CopyFile": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req CopyFileRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.CopyFile(ctx, &req)
+		},
+		"ReadFile": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req ReadFileRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.ReadFile(ctx, &req)
+		},
+		"GetDiagnostics": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req GetDiagnosticsRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.GetDiagnostics(ctx, &req)
+		},
+		"CheckpointContainer": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req CheckpointContainerRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.CheckpointContainer(ctx, &req)
+		},
+		"RestoreContainer": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req RestoreContainerRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.RestoreContainer(ctx, &req)
+		},
+		"GetOOMEvent": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req GetOOMEventRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.GetOOMEvent(ctx, &req)
+		},
+		"AddSwap": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req AddSwapRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.AddSwap(ctx, &req)
+		},
+		"GetVolumeStats": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req VolumeStatsRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.GetVolumeStats(ctx, &req)
+		},
+		"GetContainerVolumeStats": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req ContainerVolumeStatsRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.GetContainerVolumeStats(ctx, &req)
+		},
+		"ResizeVolume": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req ResizeVolumeRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.ResizeVolume(ctx, &req)
+		},
+		"WaitDevice": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req WaitDeviceRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.WaitDevice(ctx, &req)
+		},
+		"SyncFs": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req SyncFsRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.SyncFs(ctx, &req)
+		},
+		"FreezeFs": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req FreezeFsRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.FreezeFs(ctx, &req)
+		},
+		"ThawFs": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req ThawFsRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.ThawFs(ctx, &req)
+		},
+		"SetNameResolution": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req SetNameResolutionRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.SetNameResolution(ctx, &req)
+		},
+		"GetGuestServices": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req GetGuestServicesRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.GetGuestServices(ctx, &req)
+		},
+		"SetNetworkPolicy": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req SetNetworkPolicyRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.SetNetworkPolicy(ctx, &req)
+		},
+		"GetSeccompNotification": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req GetSeccompNotificationRequest
+			if err := unmarshal(&req); err != nil {
+				return nil, err
+			}
+			return svc.GetSeccompNotification(ctx, &req)
+		},
+		"SendSeccompNotificationResponse": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
+			var req SeccompNotificationResponse
 			if err := unmarshal(&req); err != nil {
 				return nil, err
 			}
-			return svc.RemoveContainer(ctx, &req)
-		},
-		"ExecProcess": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req ExecProcessRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+			return svc.SendSeccompNotificationResponse(ctx, &req)
+		},
+	})
+}
+
+type agentServiceClient struct {
+	client *github_com_containerd_ttrpc.Client
+}
+
+func NewAgentServiceClient(client *github_com_containerd_ttrpc.Client) AgentServiceService {
+	return &agentServiceClient{
+		client: client,
+	}
+}
+
+func (c *agentServiceClient) CreateContainer(ctx context.Context, req *CreateContainerRequest) (*types.Empty, error) {
+	var resp types.Empty
+	if err := c.client.Call(ctx, "grpc.AgentService", "CreateContainer", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) StartContainer(ctx context.Context, req *StartContainerRequest) (*types.Empty, error) {
+	var resp types.Empty
+	if err := c.client.Call(ctx, "grpc.AgentService", "StartContainer", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) RemoveContainer(ctx context.Context, req *RemoveContainerRequest) (*types.Empty, error) {
+	var resp types.Empty
+	if err := c.client.Call(ctx, "grpc.AgentService", "RemoveContainer", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) ExecProcess(ctx context.Context, req *ExecProcessRequest) (*types.Empty, error) {
+	var resp types.Empty
+	if err := c.client.Call(ctx, "grpc.AgentService", "ExecProcess", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) SignalProcess(ctx context.Context, req *SignalProcessRequest) (*types.Empty, error) {
+	var resp types.Empty
+	if err := c.client.Call(ctx, "grpc.AgentService", "SignalProcess", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) WaitProcess(ctx context.Context, req *WaitProcessRequest) (*WaitProcessResponse, error) {
+	var resp WaitProcessResponse
+	if err := c.client.Call(ctx, "grpc.AgentService", "WaitProcess", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) UpdateContainer(ctx context.Context, req *UpdateContainerRequest) (*types.Empty, error) {
+	var resp types.Empty
+	if err := c.client.Call(ctx, "grpc.AgentService", "UpdateContainer", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) UpdateEphemeralMounts(ctx context.Context, req *UpdateEphemeralMountsRequest) (*types.Empty, error) {
+	var resp types.Empty
+	if err := c.client.Call(ctx, "grpc.AgentService", "UpdateEphemeralMounts", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) StatsContainer(ctx context.Context, req *StatsContainerRequest) (*StatsContainerResponse, error) {
+	var resp StatsContainerResponse
+	if err := c.client.Call(ctx, "grpc.AgentService", "StatsContainer", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) PauseContainer(ctx context.Context, req *PauseContainerRequest) (*types.Empty, error) {
+	var resp types.Empty
+	if err := c.client.Call(ctx, "grpc.AgentService", "PauseContainer", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) ResumeContainer(ctx context.Context, req *ResumeContainerRequest) (*types.Empty, error) {
+	var resp types.Empty
+	if err := c.client.Call(ctx, "grpc.AgentService", "ResumeContainer", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) RemoveStaleVirtiofsShareMounts(ctx context.Context, req *RemoveStaleVirtiofsShareMountsRequest) (*types.Empty, error) {
+	var resp types.Empty
+	if err := c.client.Call(ctx, "grpc.AgentService", "RemoveStaleVirtiofsShareMounts", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) WriteStdin(ctx context.Context, req *WriteStreamRequest) (*WriteStreamResponse, error) {
+	var resp WriteStreamResponse
+	if err := c.client.Call(ctx, "grpc.AgentService", "WriteStdin", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) ReadStdout(ctx context.Context, req *ReadStreamRequest) (*ReadStreamResponse, error) {
+	var resp ReadStreamResponse
+	if err := c.client.Call(ctx, "grpc.AgentService", "ReadStdout", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) ReadStderr(ctx context.Context, req *ReadStreamRequest) (*ReadStreamResponse, error) {
+	var resp ReadStreamResponse
+	if err := c.client.Call(ctx, "grpc.AgentService", "ReadStderr", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) CloseStdin(ctx context.Context, req *CloseStdinRequest) (*types.Empty, error) {
+	var resp types.Empty
+	if err := c.client.Call(ctx, "grpc.AgentService", "CloseStdin", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) TtyWinResize(ctx context.Context, req *TtyWinResizeRequest) (*types.Empty, error) {
+	var resp types.Empty
+	if err := c.client.Call(ctx, "grpc.AgentService", "TtyWinResize", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) UpdateInterface(ctx context.Context, req *UpdateInterfaceRequest) (*protocols.Interface, error) {
+	var resp protocols.Interface
+	if err := c.client.Call(ctx, "grpc.AgentService", "UpdateInterface", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) UpdateRoutes(ctx context.Context, req *UpdateRoutesRequest) (*Routes, error) {
+	var resp Routes
+	if err := c.client.Call(ctx, "grpc.AgentService", "UpdateRoutes", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) ListInterfaces(ctx context.Context, req *ListInterfacesRequest) (*Interfaces, error) {
+	var resp Interfaces
+	if err := c.client.Call(ctx, "grpc.AgentService", "ListInterfaces", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) ListRoutes(ctx context.Context, req *ListRoutesRequest) (*Routes, error) {
+	var resp Routes
+	if err := c.client.Call(ctx, "grpc.AgentService", "ListRoutes", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) AddARPNeighbors(ctx context.Context, req *AddARPNeighborsRequest) (*types.Empty, error) {
+	var resp types.Empty
+	if err := c.client.Call(ctx, "grpc.AgentService", "AddARPNeighbors", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) GetIPTables(ctx context.Context, req *GetIPTablesRequest) (*GetIPTablesResponse, error) {
+	var resp GetIPTablesResponse
+	if err := c.client.Call(ctx, "grpc.AgentService", "GetIPTables", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) SetIPTables(ctx context.Context, req *SetIPTablesRequest) (*SetIPTablesResponse, error) {
+	var resp SetIPTablesResponse
+	if err := c.client.Call(ctx, "grpc.AgentService", "SetIPTables", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) GetMetrics(ctx context.Context, req *GetMetricsRequest) (*Metrics, error) {
+	var resp Metrics
+	if err := c.client.Call(ctx, "grpc.AgentService", "GetMetrics", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) CreateSandbox(ctx context.Context, req *CreateSandboxRequest) (*types.Empty, error) {
+	var resp types.Empty
+	if err := c.client.Call(ctx, "grpc.AgentService", "CreateSandbox", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) DestroySandbox(ctx context.Context, req *DestroySandboxRequest) (*types.Empty, error) {
+	var resp types.Empty
+	if err := c.client.Call(ctx, "grpc.AgentService", "DestroySandbox", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) OnlineCPUMem(ctx context.Context, req *OnlineCPUMemRequest) (*types.Empty, error) {
+	var resp types.Empty
+	if err := c.client.Call(ctx, "grpc.AgentService", "OnlineCPUMem", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) ReseedRandomDev(ctx context.Context, req *ReseedRandomDevRequest) (*types.Empty, error) {
+	var resp types.Empty
+	if err := c.client.Call(ctx, "grpc.AgentService", "ReseedRandomDev", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) GetGuestDetails(ctx context.Context, req *GuestDetailsRequest) (*GuestDetailsResponse, error) {
+	var resp GuestDetailsResponse
+	if err := c.client.Call(ctx, "grpc.AgentService", "GetGuestDetails", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) MemHotplugByProbe(ctx context.Context, req *MemHotplugByProbeRequest) (*types.Empty, error) {
+	var resp types.Empty
+	if err := c.client.Call(ctx, "grpc.AgentService", "MemHotplugByProbe", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) SetGuestDateTime(ctx context.Context, req *SetGuestDateTimeRequest) (*types.Empty, error) {
+	var resp types.Empty
+	if err := c.client.Call(ctx, "grpc.AgentService", "SetGuestDateTime", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) CopyFile(ctx context.Context, req *CopyFileRequest) (*types.Empty, error) {
+	var resp types.Empty
+	if err := c.client.Call(ctx, "grpc.AgentService", "CopyFile", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) ReadFile(ctx context.Context, req *ReadFileRequest) (*ReadFileResponse, error) {
+	var resp ReadFileResponse
+	if err := c.client.Call(ctx, "grpc.AgentService", "ReadFile", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) GetDiagnostics(ctx context.Context, req *GetDiagnosticsRequest) (*Diagnostics, error) {
+	var resp Diagnostics
+	if err := c.client.Call(ctx, "grpc.AgentService", "GetDiagnostics", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) CheckpointContainer(ctx context.Context, req *CheckpointContainerRequest) (*CheckpointContainerResponse, error) {
+	var resp CheckpointContainerResponse
+	if err := c.client.Call(ctx, "grpc.AgentService", "CheckpointContainer", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) RestoreContainer(ctx context.Context, req *RestoreContainerRequest) (*types.Empty, error) {
+	var resp types.Empty
+	if err := c.client.Call(ctx, "grpc.AgentService", "RestoreContainer", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) GetOOMEvent(ctx context.Context, req *GetOOMEventRequest) (*OOMEvent, error) {
+	var resp OOMEvent
+	if err := c.client.Call(ctx, "grpc.AgentService", "GetOOMEvent", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) AddSwap(ctx context.Context, req *AddSwapRequest) (*types.Empty, error) {
+	var resp types.Empty
+	if err := c.client.Call(ctx, "grpc.AgentService", "AddSwap", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) GetVolumeStats(ctx context.Context, req *VolumeStatsRequest) (*VolumeStatsResponse, error) {
+	var resp VolumeStatsResponse
+	if err := c.client.Call(ctx, "grpc.AgentService", "GetVolumeStats", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) GetContainerVolumeStats(ctx context.Context, req *ContainerVolumeStatsRequest) (*ContainerVolumeStatsResponse, error) {
+	var resp ContainerVolumeStatsResponse
+	if err := c.client.Call(ctx, "grpc.AgentService", "GetContainerVolumeStats", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) ResizeVolume(ctx context.Context, req *ResizeVolumeRequest) (*types.Empty, error) {
+	var resp types.Empty
+	if err := c.client.Call(ctx, "grpc.AgentService", "ResizeVolume", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) WaitDevice(ctx context.Context, req *WaitDeviceRequest) (*types.Empty, error) {
+	var resp types.Empty
+	if err := c.client.Call(ctx, "grpc.AgentService", "WaitDevice", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) SyncFs(ctx context.Context, req *SyncFsRequest) (*types.Empty, error) {
+	var resp types.Empty
+	if err := c.client.Call(ctx, "grpc.AgentService", "SyncFs", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) FreezeFs(ctx context.Context, req *FreezeFsRequest) (*types.Empty, error) {
+	var resp types.Empty
+	if err := c.client.Call(ctx, "grpc.AgentService", "FreezeFs", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) ThawFs(ctx context.Context, req *ThawFsRequest) (*types.Empty, error) {
+	var resp types.Empty
+	if err := c.client.Call(ctx, "grpc.AgentService", "ThawFs", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) SetNameResolution(ctx context.Context, req *SetNameResolutionRequest) (*types.Empty, error) {
+	var resp types.Empty
+	if err := c.client.Call(ctx, "grpc.AgentService", "SetNameResolution", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) GetGuestServices(ctx context.Context, req *GetGuestServicesRequest) (*GuestServices, error) {
+	var resp GuestServices
+	if err := c.client.Call(ctx, "grpc.AgentService", "GetGuestServices", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) SetNetworkPolicy(ctx context.Context, req *SetNetworkPolicyRequest) (*types.Empty, error) {
+	var resp types.Empty
+	if err := c.client.Call(ctx, "grpc.AgentService", "SetNetworkPolicy", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) GetSeccompNotification(ctx context.Context, req *GetSeccompNotificationRequest) (*SeccompNotification, error) {
+	var resp SeccompNotification
+	if err := c.client.Call(ctx, "grpc.AgentService", "GetSeccompNotification", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *agentServiceClient) SendSeccompNotificationResponse(ctx context.Context, req *SeccompNotificationResponse) (*types.Empty, error) {
+	var resp types.Empty
+	if err := c.client.Call(ctx, "grpc.AgentService", "SendSeccompNotificationResponse", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+func (m *CreateContainerRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateContainerRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateContainerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContainerId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContainerId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExecId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StringUser", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.StringUser == nil {
+				m.StringUser = &StringUser{}
+			}
+			if err := m.StringUser.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Devices", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Devices = append(m.Devices, &Device{})
+			if err := m.Devices[len(m.Devices)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Storages", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Storages = append(m.Storages, &Storage{})
+			if err := m.Storages[len(m.Storages)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OCI", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.OCI == nil {
+				m.OCI = &Spec{}
+			}
+			if err := m.OCI.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SandboxPidns", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.SandboxPidns = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StartContainerRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StartContainerRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StartContainerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContainerId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContainerId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecTimeout", wireType)
+			}
+			m.ExecTimeout = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExecTimeout |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RemoveContainerRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RemoveContainerRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RemoveContainerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContainerId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContainerId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+			}
+			m.Timeout = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timeout |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExecProcessRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExecProcessRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExecProcessRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContainerId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContainerId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExecId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StringUser", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.StringUser == nil {
+				m.StringUser = &StringUser{}
+			}
+			if err := m.StringUser.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Process", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Process == nil {
+				m.Process = &Process{}
+			}
+			if err := m.Process.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SignalProcessRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SignalProcessRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SignalProcessRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContainerId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContainerId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExecId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signal", wireType)
+			}
+			m.Signal = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Signal |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WaitProcessRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WaitProcessRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WaitProcessRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContainerId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContainerId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExecId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WaitProcessResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WaitProcessResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WaitProcessResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpdateContainerRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdateContainerRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdateContainerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContainerId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContainerId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Resources", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Resources == nil {
+				m.Resources = &LinuxResources{}
+			}
+			if err := m.Resources.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StatsContainerRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StatsContainerRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StatsContainerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContainerId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContainerId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PauseContainerRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PauseContainerRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PauseContainerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContainerId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
-			return svc.ExecProcess(ctx, &req)
-		},
-		"SignalProcess": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req SignalProcessRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+			m.ContainerId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			return svc.SignalProcess(ctx, &req)
-		},
-		"WaitProcess": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req WaitProcessRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAgent
 			}
-			return svc.WaitProcess(ctx, &req)
-		},
-		"UpdateContainer": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req UpdateContainerRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
 			}
-			return svc.UpdateContainer(ctx, &req)
-		},
-		"UpdateEphemeralMounts": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req UpdateEphemeralMountsRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResumeContainerRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
 			}
-			return svc.UpdateEphemeralMounts(ctx, &req)
-		},
-		"StatsContainer": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req StatsContainerRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			return svc.StatsContainer(ctx, &req)
-		},
-		"PauseContainer": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req PauseContainerRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			return svc.PauseContainer(ctx, &req)
-		},
-		"ResumeContainer": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req ResumeContainerRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResumeContainerRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResumeContainerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContainerId", wireType)
 			}
-			return svc.ResumeContainer(ctx, &req)
-		},
-		"RemoveStaleVirtiofsShareMounts": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req RemoveStaleVirtiofsShareMountsRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			return svc.RemoveStaleVirtiofsShareMounts(ctx, &req)
-		},
-		"WriteStdin": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req WriteStreamRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
 			}
-			return svc.WriteStdin(ctx, &req)
-		},
-		"ReadStdout": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req ReadStreamRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
 			}
-			return svc.ReadStdout(ctx, &req)
-		},
-		"ReadStderr": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req ReadStreamRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
-			return svc.ReadStderr(ctx, &req)
-		},
-		"CloseStdin": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req CloseStdinRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+			m.ContainerId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			return svc.CloseStdin(ctx, &req)
-		},
-		"TtyWinResize": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req TtyWinResizeRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAgent
 			}
-			return svc.TtyWinResize(ctx, &req)
-		},
-		"UpdateInterface": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req UpdateInterfaceRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
 			}
-			return svc.UpdateInterface(ctx, &req)
-		},
-		"UpdateRoutes": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req UpdateRoutesRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CpuUsage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CpuUsage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CpuUsage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalUsage", wireType)
+			}
+			m.TotalUsage = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TotalUsage |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowAgent
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.PercpuUsage = append(m.PercpuUsage, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowAgent
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthAgent
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthAgent
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.PercpuUsage) == 0 {
+					m.PercpuUsage = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowAgent
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.PercpuUsage = append(m.PercpuUsage, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field PercpuUsage", wireType)
 			}
-			return svc.UpdateRoutes(ctx, &req)
-		},
-		"ListInterfaces": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req ListInterfacesRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UsageInKernelmode", wireType)
 			}
-			return svc.ListInterfaces(ctx, &req)
-		},
-		"ListRoutes": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req ListRoutesRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+			m.UsageInKernelmode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UsageInKernelmode |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			return svc.ListRoutes(ctx, &req)
-		},
-		"AddARPNeighbors": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req AddARPNeighborsRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UsageInUsermode", wireType)
 			}
-			return svc.AddARPNeighbors(ctx, &req)
-		},
-		"GetIPTables": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req GetIPTablesRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+			m.UsageInUsermode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UsageInUsermode |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			return svc.GetIPTables(ctx, &req)
-		},
-		"SetIPTables": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req SetIPTablesRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			return svc.SetIPTables(ctx, &req)
-		},
-		"GetMetrics": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req GetMetricsRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAgent
 			}
-			return svc.GetMetrics(ctx, &req)
-		},
-		"CreateSandbox": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req CreateSandboxRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
 			}
-			return svc.CreateSandbox(ctx, &req)
-		},
-		"DestroySandbox": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req DestroySandboxRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ThrottlingData) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
 			}
-			return svc.DestroySandbox(ctx, &req)
-		},
-		"OnlineCPUMem": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req OnlineCPUMemRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			return svc.OnlineCPUMem(ctx, &req)
-		},
-		"ReseedRandomDev": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req ReseedRandomDevRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			return svc.ReseedRandomDev(ctx, &req)
-		},
-		"GetGuestDetails": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req GuestDetailsRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ThrottlingData: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ThrottlingData: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Periods", wireType)
 			}
-			return svc.GetGuestDetails(ctx, &req)
-		},
-		"MemHotplugByProbe": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req MemHotplugByProbeRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+			m.Periods = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Periods |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			return svc.MemHotplugByProbe(ctx, &req)
-		},
-		"SetGuestDateTime": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req SetGuestDateTimeRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThrottledPeriods", wireType)
 			}
-			return svc.SetGuestDateTime(ctx, &req)
-		},
-		"CopyFile": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req CopyFileRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+			m.ThrottledPeriods = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ThrottledPeriods |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			return svc.CopyFile(ctx, &req)
-		},
-		"GetOOMEvent": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req GetOOMEventRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThrottledTime", wireType)
 			}
-			return svc.GetOOMEvent(ctx, &req)
-		},
-		"AddSwap": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req AddSwapRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+			m.ThrottledTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ThrottledTime |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			return svc.AddSwap(ctx, &req)
-		},
-		"GetVolumeStats": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req VolumeStatsRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			return svc.GetVolumeStats(ctx, &req)
-		},
-		"ResizeVolume": func(ctx context.Context, unmarshal func(interface{}) error) (interface{}, error) {
-			var req ResizeVolumeRequest
-			if err := unmarshal(&req); err != nil {
-				return nil, err
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAgent
 			}
-			return svc.ResizeVolume(ctx, &req)
-		},
-	})
-}
-
-type agentServiceClient struct {
-	client *github_com_containerd_ttrpc.Client
-}
-
-func NewAgentServiceClient(client *github_com_containerd_ttrpc.Client) AgentServiceService {
-	return &agentServiceClient{
-		client: client,
-	}
-}
-
-func (c *agentServiceClient) CreateContainer(ctx context.Context, req *CreateContainerRequest) (*types.Empty, error) {
-	var resp types.Empty
-	if err := c.client.Call(ctx, "grpc.AgentService", "CreateContainer", req, &resp); err != nil {
-		return nil, err
-	}
-	return &resp, nil
-}
-
-func (c *agentServiceClient) StartContainer(ctx context.Context, req *StartContainerRequest) (*types.Empty, error) {
-	var resp types.Empty
-	if err := c.client.Call(ctx, "grpc.AgentService", "StartContainer", req, &resp); err != nil {
-		return nil, err
-	}
-	return &resp, nil
-}
-
-func (c *agentServiceClient) RemoveContainer(ctx context.Context, req *RemoveContainerRequest) (*types.Empty, error) {
-	var resp types.Empty
-	if err := c.client.Call(ctx, "grpc.AgentService", "RemoveContainer", req, &resp); err != nil {
-		return nil, err
-	}
-	return &resp, nil
-}
-
-func (c *agentServiceClient) ExecProcess(ctx context.Context, req *ExecProcessRequest) (*types.Empty, error) {
-	var resp types.Empty
-	if err := c.client.Call(ctx, "grpc.AgentService", "ExecProcess", req, &resp); err != nil {
-		return nil, err
-	}
-	return &resp, nil
-}
-
-func (c *agentServiceClient) SignalProcess(ctx context.Context, req *SignalProcessRequest) (*types.Empty, error) {
-	var resp types.Empty
-	if err := c.client.Call(ctx, "grpc.AgentService", "SignalProcess", req, &resp); err != nil {
-		return nil, err
-	}
-	return &resp, nil
-}
-
-func (c *agentServiceClient) WaitProcess(ctx context.Context, req *WaitProcessRequest) (*WaitProcessResponse, error) {
-	var resp WaitProcessResponse
-	if err := c.client.Call(ctx, "grpc.AgentService", "WaitProcess", req, &resp); err != nil {
-		return nil, err
-	}
-	return &resp, nil
-}
-
-func (c *agentServiceClient) UpdateContainer(ctx context.Context, req *UpdateContainerRequest) (*types.Empty, error) {
-	var resp types.Empty
-	if err := c.client.Call(ctx, "grpc.AgentService", "UpdateContainer", req, &resp); err != nil {
-		return nil, err
-	}
-	return &resp, nil
-}
-
-func (c *agentServiceClient) UpdateEphemeralMounts(ctx context.Context, req *UpdateEphemeralMountsRequest) (*types.Empty, error) {
-	var resp types.Empty
-	if err := c.client.Call(ctx, "grpc.AgentService", "UpdateEphemeralMounts", req, &resp); err != nil {
-		return nil, err
-	}
-	return &resp, nil
-}
-
-func (c *agentServiceClient) StatsContainer(ctx context.Context, req *StatsContainerRequest) (*StatsContainerResponse, error) {
-	var resp StatsContainerResponse
-	if err := c.client.Call(ctx, "grpc.AgentService", "StatsContainer", req, &resp); err != nil {
-		return nil, err
-	}
-	return &resp, nil
-}
-
-func (c *agentServiceClient) PauseContainer(ctx context.Context, req *PauseContainerRequest) (*types.Empty, error) {
-	var resp types.Empty
-	if err := c.client.Call(ctx, "grpc.AgentService", "PauseContainer", req, &resp); err != nil {
-		return nil, err
-	}
-	return &resp, nil
-}
-
-func (c *agentServiceClient) ResumeContainer(ctx context.Context, req *ResumeContainerRequest) (*types.Empty, error) {
-	var resp types.Empty
-	if err := c.client.Call(ctx, "grpc.AgentService", "ResumeContainer", req, &resp); err != nil {
-		return nil, err
-	}
-	return &resp, nil
-}
-
-func (c *agentServiceClient) RemoveStaleVirtiofsShareMounts(ctx context.Context, req *RemoveStaleVirtiofsShareMountsRequest) (*types.Empty, error) {
-	var resp types.Empty
-	if err := c.client.Call(ctx, "grpc.AgentService", "RemoveStaleVirtiofsShareMounts", req, &resp); err != nil {
-		return nil, err
-	}
-	return &resp, nil
-}
-
-func (c *agentServiceClient) WriteStdin(ctx context.Context, req *WriteStreamRequest) (*WriteStreamResponse, error) {
-	var resp WriteStreamResponse
-	if err := c.client.Call(ctx, "grpc.AgentService", "WriteStdin", req, &resp); err != nil {
-		return nil, err
-	}
-	return &resp, nil
-}
-
-func (c *agentServiceClient) ReadStdout(ctx context.Context, req *ReadStreamRequest) (*ReadStreamResponse, error) {
-	var resp ReadStreamResponse
-	if err := c.client.Call(ctx, "grpc.AgentService", "ReadStdout", req, &resp); err != nil {
-		return nil, err
-	}
-	return &resp, nil
-}
-
-func (c *agentServiceClient) ReadStderr(ctx context.Context, req *ReadStreamRequest) (*ReadStreamResponse, error) {
-	var resp ReadStreamResponse
-	if err := c.client.Call(ctx, "grpc.AgentService", "ReadStderr", req, &resp); err != nil {
-		return nil, err
-	}
-	return &resp, nil
-}
-
-func (c *agentServiceClient) CloseStdin(ctx context.Context, req *CloseStdinRequest) (*types.Empty, error) {
-	var resp types.Empty
-	if err := c.client.Call(ctx, "grpc.AgentService", "CloseStdin", req, &resp); err != nil {
-		return nil, err
-	}
-	return &resp, nil
-}
-
-func (c *agentServiceClient) TtyWinResize(ctx context.Context, req *TtyWinResizeRequest) (*types.Empty, error) {
-	var resp types.Empty
-	if err := c.client.Call(ctx, "grpc.AgentService", "TtyWinResize", req, &resp); err != nil {
-		return nil, err
-	}
-	return &resp, nil
-}
-
-func (c *agentServiceClient) UpdateInterface(ctx context.Context, req *UpdateInterfaceRequest) (*protocols.Interface, error) {
-	var resp protocols.Interface
-	if err := c.client.Call(ctx, "grpc.AgentService", "UpdateInterface", req, &resp); err != nil {
-		return nil, err
-	}
-	return &resp, nil
-}
-
-func (c *agentServiceClient) UpdateRoutes(ctx context.Context, req *UpdateRoutesRequest) (*Routes, error) {
-	var resp Routes
-	if err := c.client.Call(ctx, "grpc.AgentService", "UpdateRoutes", req, &resp); err != nil {
-		return nil, err
-	}
-	return &resp, nil
-}
-
-func (c *agentServiceClient) ListInterfaces(ctx context.Context, req *ListInterfacesRequest) (*Interfaces, error) {
-	var resp Interfaces
-	if err := c.client.Call(ctx, "grpc.AgentService", "ListInterfaces", req, &resp); err != nil {
-		return nil, err
-	}
-	return &resp, nil
-}
-
-func (c *agentServiceClient) ListRoutes(ctx context.Context, req *ListRoutesRequest) (*Routes, error) {
-	var resp Routes
-	if err := c.client.Call(ctx, "grpc.AgentService", "ListRoutes", req, &resp); err != nil {
-		return nil, err
-	}
-	return &resp, nil
-}
-
-func (c *agentServiceClient) AddARPNeighbors(ctx context.Context, req *AddARPNeighborsRequest) (*types.Empty, error) {
-	var resp types.Empty
-	if err := c.client.Call(ctx, "grpc.AgentService", "AddARPNeighbors", req, &resp); err != nil {
-		return nil, err
-	}
-	return &resp, nil
-}
-
-func (c *agentServiceClient) GetIPTables(ctx context.Context, req *GetIPTablesRequest) (*GetIPTablesResponse, error) {
-	var resp GetIPTablesResponse
-	if err := c.client.Call(ctx, "grpc.AgentService", "GetIPTables", req, &resp); err != nil {
-		return nil, err
-	}
-	return &resp, nil
-}
-
-func (c *agentServiceClient) SetIPTables(ctx context.Context, req *SetIPTablesRequest) (*SetIPTablesResponse, error) {
-	var resp SetIPTablesResponse
-	if err := c.client.Call(ctx, "grpc.AgentService", "SetIPTables", req, &resp); err != nil {
-		return nil, err
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
 	}
-	return &resp, nil
-}
 
-func (c *agentServiceClient) GetMetrics(ctx context.Context, req *GetMetricsRequest) (*Metrics, error) {
-	var resp Metrics
-	if err := c.client.Call(ctx, "grpc.AgentService", "GetMetrics", req, &resp); err != nil {
-		return nil, err
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return &resp, nil
+	return nil
 }
-
-func (c *agentServiceClient) CreateSandbox(ctx context.Context, req *CreateSandboxRequest) (*types.Empty, error) {
-	var resp types.Empty
-	if err := c.client.Call(ctx, "grpc.AgentService", "CreateSandbox", req, &resp); err != nil {
-		return nil, err
+func (m *CpuStats) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CpuStats: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CpuStats: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CpuUsage", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.CpuUsage == nil {
+				m.CpuUsage = &CpuUsage{}
+			}
+			if err := m.CpuUsage.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThrottlingData", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ThrottlingData == nil {
+				m.ThrottlingData = &ThrottlingData{}
+			}
+			if err := m.ThrottlingData.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
 	}
-	return &resp, nil
-}
 
-func (c *agentServiceClient) DestroySandbox(ctx context.Context, req *DestroySandboxRequest) (*types.Empty, error) {
-	var resp types.Empty
-	if err := c.client.Call(ctx, "grpc.AgentService", "DestroySandbox", req, &resp); err != nil {
-		return nil, err
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return &resp, nil
+	return nil
 }
-
-func (c *agentServiceClient) OnlineCPUMem(ctx context.Context, req *OnlineCPUMemRequest) (*types.Empty, error) {
-	var resp types.Empty
-	if err := c.client.Call(ctx, "grpc.AgentService", "OnlineCPUMem", req, &resp); err != nil {
-		return nil, err
+func (m *PidsStats) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PidsStats: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PidsStats: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Current", wireType)
+			}
+			m.Current = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Current |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
+			}
+			m.Limit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Limit |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
 	}
-	return &resp, nil
-}
 
-func (c *agentServiceClient) ReseedRandomDev(ctx context.Context, req *ReseedRandomDevRequest) (*types.Empty, error) {
-	var resp types.Empty
-	if err := c.client.Call(ctx, "grpc.AgentService", "ReseedRandomDev", req, &resp); err != nil {
-		return nil, err
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return &resp, nil
+	return nil
 }
-
-func (c *agentServiceClient) GetGuestDetails(ctx context.Context, req *GuestDetailsRequest) (*GuestDetailsResponse, error) {
-	var resp GuestDetailsResponse
-	if err := c.client.Call(ctx, "grpc.AgentService", "GetGuestDetails", req, &resp); err != nil {
-		return nil, err
+func (m *MemoryData) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MemoryData: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MemoryData: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Usage", wireType)
+			}
+			m.Usage = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Usage |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxUsage", wireType)
+			}
+			m.MaxUsage = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxUsage |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Failcnt", wireType)
+			}
+			m.Failcnt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Failcnt |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
+			}
+			m.Limit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Limit |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
 	}
-	return &resp, nil
-}
 
-func (c *agentServiceClient) MemHotplugByProbe(ctx context.Context, req *MemHotplugByProbeRequest) (*types.Empty, error) {
-	var resp types.Empty
-	if err := c.client.Call(ctx, "grpc.AgentService", "MemHotplugByProbe", req, &resp); err != nil {
-		return nil, err
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return &resp, nil
+	return nil
 }
-
-func (c *agentServiceClient) SetGuestDateTime(ctx context.Context, req *SetGuestDateTimeRequest) (*types.Empty, error) {
-	var resp types.Empty
-	if err := c.client.Call(ctx, "grpc.AgentService", "SetGuestDateTime", req, &resp); err != nil {
-		return nil, err
+func (m *MemoryStats) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MemoryStats: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MemoryStats: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cache", wireType)
+			}
+			m.Cache = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Cache |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Usage", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Usage == nil {
+				m.Usage = &MemoryData{}
+			}
+			if err := m.Usage.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SwapUsage", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SwapUsage == nil {
+				m.SwapUsage = &MemoryData{}
+			}
+			if err := m.SwapUsage.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KernelUsage", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.KernelUsage == nil {
+				m.KernelUsage = &MemoryData{}
+			}
+			if err := m.KernelUsage.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UseHierarchy", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.UseHierarchy = bool(v != 0)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Stats == nil {
+				m.Stats = make(map[string]uint64)
+			}
+			var mapkey string
+			var mapvalue uint64
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowAgent
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowAgent
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthAgent
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthAgent
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowAgent
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipAgent(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthAgent
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Stats[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
 	}
-	return &resp, nil
-}
 
-func (c *agentServiceClient) CopyFile(ctx context.Context, req *CopyFileRequest) (*types.Empty, error) {
-	var resp types.Empty
-	if err := c.client.Call(ctx, "grpc.AgentService", "CopyFile", req, &resp); err != nil {
-		return nil, err
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return &resp, nil
+	return nil
 }
-
-func (c *agentServiceClient) GetOOMEvent(ctx context.Context, req *GetOOMEventRequest) (*OOMEvent, error) {
-	var resp OOMEvent
-	if err := c.client.Call(ctx, "grpc.AgentService", "GetOOMEvent", req, &resp); err != nil {
-		return nil, err
+func (m *BlkioStatsEntry) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BlkioStatsEntry: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BlkioStatsEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Major", wireType)
+			}
+			m.Major = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Major |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Minor", wireType)
+			}
+			m.Minor = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Minor |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Op", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Op = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			m.Value = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Value |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
 	}
-	return &resp, nil
-}
 
-func (c *agentServiceClient) AddSwap(ctx context.Context, req *AddSwapRequest) (*types.Empty, error) {
-	var resp types.Empty
-	if err := c.client.Call(ctx, "grpc.AgentService", "AddSwap", req, &resp); err != nil {
-		return nil, err
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return &resp, nil
+	return nil
 }
-
-func (c *agentServiceClient) GetVolumeStats(ctx context.Context, req *VolumeStatsRequest) (*VolumeStatsResponse, error) {
-	var resp VolumeStatsResponse
-	if err := c.client.Call(ctx, "grpc.AgentService", "GetVolumeStats", req, &resp); err != nil {
-		return nil, err
+func (m *BlkioStats) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BlkioStats: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BlkioStats: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IoServiceBytesRecursive", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IoServiceBytesRecursive = append(m.IoServiceBytesRecursive, &BlkioStatsEntry{})
+			if err := m.IoServiceBytesRecursive[len(m.IoServiceBytesRecursive)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IoServicedRecursive", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IoServicedRecursive = append(m.IoServicedRecursive, &BlkioStatsEntry{})
+			if err := m.IoServicedRecursive[len(m.IoServicedRecursive)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IoQueuedRecursive", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IoQueuedRecursive = append(m.IoQueuedRecursive, &BlkioStatsEntry{})
+			if err := m.IoQueuedRecursive[len(m.IoQueuedRecursive)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IoServiceTimeRecursive", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IoServiceTimeRecursive = append(m.IoServiceTimeRecursive, &BlkioStatsEntry{})
+			if err := m.IoServiceTimeRecursive[len(m.IoServiceTimeRecursive)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IoWaitTimeRecursive", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IoWaitTimeRecursive = append(m.IoWaitTimeRecursive, &BlkioStatsEntry{})
+			if err := m.IoWaitTimeRecursive[len(m.IoWaitTimeRecursive)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IoMergedRecursive", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IoMergedRecursive = append(m.IoMergedRecursive, &BlkioStatsEntry{})
+			if err := m.IoMergedRecursive[len(m.IoMergedRecursive)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IoTimeRecursive", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IoTimeRecursive = append(m.IoTimeRecursive, &BlkioStatsEntry{})
+			if err := m.IoTimeRecursive[len(m.IoTimeRecursive)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SectorsRecursive", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SectorsRecursive = append(m.SectorsRecursive, &BlkioStatsEntry{})
+			if err := m.SectorsRecursive[len(m.SectorsRecursive)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
 	}
-	return &resp, nil
-}
 
-func (c *agentServiceClient) ResizeVolume(ctx context.Context, req *ResizeVolumeRequest) (*types.Empty, error) {
-	var resp types.Empty
-	if err := c.client.Call(ctx, "grpc.AgentService", "ResizeVolume", req, &resp); err != nil {
-		return nil, err
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return &resp, nil
+	return nil
 }
-func (m *CreateContainerRequest) Unmarshal(dAtA []byte) error {
+func (m *HugetlbStats) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9185,17 +15225,17 @@ func (m *CreateContainerRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CreateContainerRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: HugetlbStats: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateContainerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HugetlbStats: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ContainerId", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Usage", wireType)
 			}
-			var stringLen uint64
+			m.Usage = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -9205,29 +15245,35 @@ func (m *CreateContainerRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Usage |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAgent
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthAgent
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxUsage", wireType)
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.MaxUsage = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxUsage |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.ContainerId = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExecId", wireType)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Failcnt", wireType)
 			}
-			var stringLen uint64
+			m.Failcnt = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -9237,27 +15283,65 @@ func (m *CreateContainerRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Failcnt |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAgent
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthAgent
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ExecId = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CgroupStats) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CgroupStats: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CgroupStats: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StringUser", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CpuStats", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9284,16 +15368,16 @@ func (m *CreateContainerRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.StringUser == nil {
-				m.StringUser = &StringUser{}
+			if m.CpuStats == nil {
+				m.CpuStats = &CpuStats{}
 			}
-			if err := m.StringUser.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.CpuStats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Devices", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MemoryStats", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9320,14 +15404,16 @@ func (m *CreateContainerRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Devices = append(m.Devices, &Device{})
-			if err := m.Devices[len(m.Devices)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.MemoryStats == nil {
+				m.MemoryStats = &MemoryStats{}
+			}
+			if err := m.MemoryStats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 5:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Storages", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PidsStats", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9354,14 +15440,16 @@ func (m *CreateContainerRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Storages = append(m.Storages, &Storage{})
-			if err := m.Storages[len(m.Storages)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.PidsStats == nil {
+				m.PidsStats = &PidsStats{}
+			}
+			if err := m.PidsStats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 6:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OCI", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BlkioStats", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9388,18 +15476,18 @@ func (m *CreateContainerRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.OCI == nil {
-				m.OCI = &Spec{}
+			if m.BlkioStats == nil {
+				m.BlkioStats = &BlkioStats{}
 			}
-			if err := m.OCI.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.BlkioStats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 7:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SandboxPidns", wireType)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HugetlbStats", wireType)
 			}
-			var v int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -9409,12 +15497,121 @@ func (m *CreateContainerRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.SandboxPidns = bool(v != 0)
+			if msglen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.HugetlbStats == nil {
+				m.HugetlbStats = make(map[string]*HugetlbStats)
+			}
+			var mapkey string
+			var mapvalue *HugetlbStats
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowAgent
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowAgent
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthAgent
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthAgent
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowAgent
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthAgent
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthAgent
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &HugetlbStats{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipAgent(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthAgent
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.HugetlbStats[mapkey] = mapvalue
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAgent(dAtA[iNdEx:])
@@ -9437,7 +15634,7 @@ func (m *CreateContainerRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StartContainerRequest) Unmarshal(dAtA []byte) error {
+func (m *NetworkStats) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9460,17 +15657,106 @@ func (m *StartContainerRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StartContainerRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: NetworkStats: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StartContainerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: NetworkStats: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ContainerId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RxBytes", wireType)
+			}
+			m.RxBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RxBytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RxPackets", wireType)
+			}
+			m.RxPackets = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RxPackets |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RxErrors", wireType)
+			}
+			m.RxErrors = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RxErrors |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RxDropped", wireType)
 			}
-			var stringLen uint64
+			m.RxDropped = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -9480,80 +15766,35 @@ func (m *StartContainerRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.RxDropped |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAgent
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthAgent
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ContainerId = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipAgent(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthAgent
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *RemoveContainerRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowAgent
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxBytes", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.TxBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TxBytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: RemoveContainerRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RemoveContainerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ContainerId", wireType)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxPackets", wireType)
 			}
-			var stringLen uint64
+			m.TxPackets = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -9563,29 +15804,35 @@ func (m *RemoveContainerRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.TxPackets |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAgent
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthAgent
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxErrors", wireType)
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.TxErrors = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TxErrors |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.ContainerId = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
+		case 9:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TxDropped", wireType)
 			}
-			m.Timeout = 0
+			m.TxDropped = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -9595,7 +15842,7 @@ func (m *RemoveContainerRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Timeout |= uint32(b&0x7F) << shift
+				m.TxDropped |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -9622,7 +15869,7 @@ func (m *RemoveContainerRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ExecProcessRequest) Unmarshal(dAtA []byte) error {
+func (m *StatsContainerResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9645,79 +15892,15 @@ func (m *ExecProcessRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ExecProcessRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: StatsContainerResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ExecProcessRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: StatsContainerResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ContainerId", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAgent
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthAgent
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ContainerId = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExecId", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAgent
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthAgent
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ExecId = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StringUser", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CgroupStats", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9744,16 +15927,16 @@ func (m *ExecProcessRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.StringUser == nil {
-				m.StringUser = &StringUser{}
+			if m.CgroupStats == nil {
+				m.CgroupStats = &CgroupStats{}
 			}
-			if err := m.StringUser.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.CgroupStats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Process", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NetworkStats", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9780,10 +15963,8 @@ func (m *ExecProcessRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Process == nil {
-				m.Process = &Process{}
-			}
-			if err := m.Process.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.NetworkStats = append(m.NetworkStats, &NetworkStats{})
+			if err := m.NetworkStats[len(m.NetworkStats)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -9809,7 +15990,7 @@ func (m *ExecProcessRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SignalProcessRequest) Unmarshal(dAtA []byte) error {
+func (m *WriteStreamRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9832,10 +16013,10 @@ func (m *SignalProcessRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SignalProcessRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: WriteStreamRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SignalProcessRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: WriteStreamRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -9868,13 +16049,45 @@ func (m *SignalProcessRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ContainerId = string(dAtA[iNdEx:postIndex])
+			m.ContainerId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExecId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExecId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -9884,29 +16097,82 @@ func (m *SignalProcessRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthAgent
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAgent
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ExecId = string(dAtA[iNdEx:postIndex])
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
 			iNdEx = postIndex
-		case 3:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WriteStreamResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WriteStreamResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WriteStreamResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Signal", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Len", wireType)
 			}
-			m.Signal = 0
+			m.Len = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -9916,7 +16182,7 @@ func (m *SignalProcessRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Signal |= uint32(b&0x7F) << shift
+				m.Len |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -9943,7 +16209,7 @@ func (m *SignalProcessRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *WaitProcessRequest) Unmarshal(dAtA []byte) error {
+func (m *ReadStreamRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9966,10 +16232,10 @@ func (m *WaitProcessRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: WaitProcessRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ReadStreamRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: WaitProcessRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ReadStreamRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -10036,6 +16302,25 @@ func (m *WaitProcessRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.ExecId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Len", wireType)
+			}
+			m.Len = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Len |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAgent(dAtA[iNdEx:])
@@ -10058,7 +16343,7 @@ func (m *WaitProcessRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *WaitProcessResponse) Unmarshal(dAtA []byte) error {
+func (m *ReadStreamResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10081,17 +16366,17 @@ func (m *WaitProcessResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: WaitProcessResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ReadStreamResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: WaitProcessResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ReadStreamResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			m.Status = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -10101,11 +16386,26 @@ func (m *WaitProcessResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Status |= int32(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAgent(dAtA[iNdEx:])
@@ -10128,7 +16428,7 @@ func (m *WaitProcessResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UpdateContainerRequest) Unmarshal(dAtA []byte) error {
+func (m *CloseStdinRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10151,10 +16451,10 @@ func (m *UpdateContainerRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UpdateContainerRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: CloseStdinRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UpdateContainerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CloseStdinRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -10191,9 +16491,9 @@ func (m *UpdateContainerRequest) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Resources", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecId", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -10203,27 +16503,23 @@ func (m *UpdateContainerRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthAgent
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAgent
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Resources == nil {
-				m.Resources = &LinuxResources{}
-			}
-			if err := m.Resources.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.ExecId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -10247,7 +16543,7 @@ func (m *UpdateContainerRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StatsContainerRequest) Unmarshal(dAtA []byte) error {
+func (m *TtyWinResizeRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10270,10 +16566,10 @@ func (m *StatsContainerRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StatsContainerRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: TtyWinResizeRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StatsContainerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TtyWinResizeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -10308,60 +16604,9 @@ func (m *StatsContainerRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.ContainerId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipAgent(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthAgent
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *PauseContainerRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowAgent
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: PauseContainerRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PauseContainerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ContainerId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -10389,8 +16634,46 @@ func (m *PauseContainerRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ContainerId = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
+			m.ExecId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Row", wireType)
+			}
+			m.Row = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Row |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Column", wireType)
+			}
+			m.Column = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Column |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAgent(dAtA[iNdEx:])
@@ -10413,7 +16696,7 @@ func (m *PauseContainerRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResumeContainerRequest) Unmarshal(dAtA []byte) error {
+func (m *KernelModule) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10436,15 +16719,15 @@ func (m *ResumeContainerRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResumeContainerRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: KernelModule: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResumeContainerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: KernelModule: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ContainerId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -10472,7 +16755,39 @@ func (m *ResumeContainerRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ContainerId = string(dAtA[iNdEx:postIndex])
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Parameters", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Parameters = append(m.Parameters, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -10496,7 +16811,7 @@ func (m *ResumeContainerRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CpuUsage) Unmarshal(dAtA []byte) error {
+func (m *CreateSandboxRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10519,17 +16834,17 @@ func (m *CpuUsage) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CpuUsage: wiretype end group for non-group")
+			return fmt.Errorf("proto: CreateSandboxRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CpuUsage: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CreateSandboxRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TotalUsage", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hostname", wireType)
 			}
-			m.TotalUsage = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -10539,92 +16854,29 @@ func (m *CpuUsage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TotalUsage |= uint64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType == 0 {
-				var v uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowAgent
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.PercpuUsage = append(m.PercpuUsage, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowAgent
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthAgent
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthAgent
-				}
-				if postIndex > l {
-					return io.ErrUnexpectedEOF
-				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
-				}
-				elementCount = count
-				if elementCount != 0 && len(m.PercpuUsage) == 0 {
-					m.PercpuUsage = make([]uint64, 0, elementCount)
-				}
-				for iNdEx < postIndex {
-					var v uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowAgent
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.PercpuUsage = append(m.PercpuUsage, v)
-				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field PercpuUsage", wireType)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
 			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UsageInKernelmode", wireType)
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
 			}
-			m.UsageInKernelmode = 0
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hostname = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dns", wireType)
+			}
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -10634,16 +16886,29 @@ func (m *CpuUsage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.UsageInKernelmode |= uint64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UsageInUsermode", wireType)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
 			}
-			m.UsageInUsermode = 0
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Dns = append(m.Dns, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Storages", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -10653,67 +16918,31 @@ func (m *CpuUsage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.UsageInUsermode |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipAgent(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthAgent
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ThrottlingData) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowAgent
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Storages = append(m.Storages, &Storage{})
+			if err := m.Storages[len(m.Storages)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ThrottlingData: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ThrottlingData: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Periods", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SandboxPidns", wireType)
 			}
-			m.Periods = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -10723,16 +16952,17 @@ func (m *ThrottlingData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Periods |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ThrottledPeriods", wireType)
+			m.SandboxPidns = bool(v != 0)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SandboxId", wireType)
 			}
-			m.ThrottledPeriods = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -10742,16 +16972,29 @@ func (m *ThrottlingData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ThrottledPeriods |= uint64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ThrottledTime", wireType)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
 			}
-			m.ThrottledTime = 0
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SandboxId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GuestHookPath", wireType)
+			}
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -10761,65 +17004,27 @@ func (m *ThrottlingData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ThrottledTime |= uint64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipAgent(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthAgent
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *CpuStats) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowAgent
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: CpuStats: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CpuStats: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.GuestHookPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CpuUsage", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field KernelModules", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10846,18 +17051,16 @@ func (m *CpuStats) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.CpuUsage == nil {
-				m.CpuUsage = &CpuUsage{}
-			}
-			if err := m.CpuUsage.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.KernelModules = append(m.KernelModules, &KernelModule{})
+			if err := m.KernelModules[len(m.KernelModules)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ThrottlingData", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GuestServices", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -10867,27 +17070,23 @@ func (m *CpuStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthAgent
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAgent
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ThrottlingData == nil {
-				m.ThrottlingData = &ThrottlingData{}
-			}
-			if err := m.ThrottlingData.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.GuestServices = append(m.GuestServices, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -10911,7 +17110,7 @@ func (m *CpuStats) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PidsStats) Unmarshal(dAtA []byte) error {
+func (m *DestroySandboxRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10934,50 +17133,12 @@ func (m *PidsStats) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PidsStats: wiretype end group for non-group")
+			return fmt.Errorf("proto: DestroySandboxRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PidsStats: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DestroySandboxRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Current", wireType)
-			}
-			m.Current = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Current |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
-			}
-			m.Limit = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Limit |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAgent(dAtA[iNdEx:])
@@ -11000,7 +17161,7 @@ func (m *PidsStats) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *MemoryData) Unmarshal(dAtA []byte) error {
+func (m *RemoveStaleVirtiofsShareMountsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11023,88 +17184,12 @@ func (m *MemoryData) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MemoryData: wiretype end group for non-group")
+			return fmt.Errorf("proto: RemoveStaleVirtiofsShareMountsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MemoryData: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RemoveStaleVirtiofsShareMountsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Usage", wireType)
-			}
-			m.Usage = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Usage |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MaxUsage", wireType)
-			}
-			m.MaxUsage = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.MaxUsage |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Failcnt", wireType)
-			}
-			m.Failcnt = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Failcnt |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
-			}
-			m.Limit = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Limit |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAgent(dAtA[iNdEx:])
@@ -11127,7 +17212,7 @@ func (m *MemoryData) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *MemoryStats) Unmarshal(dAtA []byte) error {
+func (m *Interfaces) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11150,34 +17235,15 @@ func (m *MemoryStats) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MemoryStats: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MemoryStats: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Interfaces: wiretype end group for non-group")
 		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Cache", wireType)
-			}
-			m.Cache = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Cache |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Interfaces: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Usage", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Interfaces", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11204,52 +17270,65 @@ func (m *MemoryStats) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Usage == nil {
-				m.Usage = &MemoryData{}
-			}
-			if err := m.Usage.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Interfaces = append(m.Interfaces, &protocols.Interface{})
+			if err := m.Interfaces[len(m.Interfaces)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SwapUsage", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthAgent
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthAgent
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.SwapUsage == nil {
-				m.SwapUsage = &MemoryData{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Routes) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
 			}
-			if err := m.SwapUsage.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			iNdEx = postIndex
-		case 4:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Routes: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Routes: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field KernelUsage", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Routes", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11276,145 +17355,97 @@ func (m *MemoryStats) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.KernelUsage == nil {
-				m.KernelUsage = &MemoryData{}
-			}
-			if err := m.KernelUsage.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Routes = append(m.Routes, &protocols.Route{})
+			if err := m.Routes[len(m.Routes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UseHierarchy", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.UseHierarchy = bool(v != 0)
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthAgent
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthAgent
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
 			}
-			if postIndex > l {
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpdateInterfaceRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Stats == nil {
-				m.Stats = make(map[string]uint64)
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			var mapkey string
-			var mapvalue uint64
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowAgent
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowAgent
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthAgent
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey < 0 {
-						return ErrInvalidLengthAgent
-					}
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowAgent
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapvalue |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipAgent(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if (skippy < 0) || (iNdEx+skippy) < 0 {
-						return ErrInvalidLengthAgent
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdateInterfaceRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdateInterfaceRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Interface", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
 			}
-			m.Stats[mapkey] = mapvalue
+			if msglen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Interface == nil {
+				m.Interface = &protocols.Interface{}
+			}
+			if err := m.Interface.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -11438,7 +17469,7 @@ func (m *MemoryStats) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BlkioStatsEntry) Unmarshal(dAtA []byte) error {
+func (m *UpdateRoutesRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11461,55 +17492,17 @@ func (m *BlkioStatsEntry) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BlkioStatsEntry: wiretype end group for non-group")
+			return fmt.Errorf("proto: UpdateRoutesRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BlkioStatsEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UpdateRoutesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Major", wireType)
-			}
-			m.Major = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Major |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Minor", wireType)
-			}
-			m.Minor = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Minor |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Op", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Routes", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -11519,43 +17512,28 @@ func (m *BlkioStatsEntry) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthAgent
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthAgent
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Op = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			if m.Routes == nil {
+				m.Routes = &Routes{}
 			}
-			m.Value = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Value |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if err := m.Routes.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAgent(dAtA[iNdEx:])
@@ -11578,7 +17556,7 @@ func (m *BlkioStatsEntry) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BlkioStats) Unmarshal(dAtA []byte) error {
+func (m *UpdateEphemeralMountsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11601,15 +17579,15 @@ func (m *BlkioStats) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BlkioStats: wiretype end group for non-group")
+			return fmt.Errorf("proto: UpdateEphemeralMountsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BlkioStats: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UpdateEphemeralMountsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IoServiceBytesRecursive", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Storages", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11636,48 +17614,167 @@ func (m *BlkioStats) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.IoServiceBytesRecursive = append(m.IoServiceBytesRecursive, &BlkioStatsEntry{})
-			if err := m.IoServiceBytesRecursive[len(m.IoServiceBytesRecursive)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Storages = append(m.Storages, &Storage{})
+			if err := m.Storages[len(m.Storages)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IoServicedRecursive", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAgent
 			}
-			if msglen < 0 {
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListInterfacesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListInterfacesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListInterfacesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthAgent
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListRoutesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListRoutesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListRoutesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthAgent
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.IoServicedRecursive = append(m.IoServicedRecursive, &BlkioStatsEntry{})
-			if err := m.IoServicedRecursive[len(m.IoServicedRecursive)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ARPNeighbors) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
 			}
-			iNdEx = postIndex
-		case 3:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ARPNeighbors: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ARPNeighbors: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IoQueuedRecursive", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ARPNeighbors", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11704,48 +17801,65 @@ func (m *BlkioStats) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.IoQueuedRecursive = append(m.IoQueuedRecursive, &BlkioStatsEntry{})
-			if err := m.IoQueuedRecursive[len(m.IoQueuedRecursive)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.ARPNeighbors = append(m.ARPNeighbors, &protocols.ARPNeighbor{})
+			if err := m.ARPNeighbors[len(m.ARPNeighbors)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IoServiceTimeRecursive", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthAgent
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthAgent
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
 			}
-			if postIndex > l {
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AddARPNeighborsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.IoServiceTimeRecursive = append(m.IoServiceTimeRecursive, &BlkioStatsEntry{})
-			if err := m.IoServiceTimeRecursive[len(m.IoServiceTimeRecursive)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 5:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AddARPNeighborsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AddARPNeighborsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IoWaitTimeRecursive", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Neighbors", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11772,50 +17886,69 @@ func (m *BlkioStats) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.IoWaitTimeRecursive = append(m.IoWaitTimeRecursive, &BlkioStatsEntry{})
-			if err := m.IoWaitTimeRecursive[len(m.IoWaitTimeRecursive)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Neighbors == nil {
+				m.Neighbors = &ARPNeighbors{}
+			}
+			if err := m.Neighbors.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IoMergedRecursive", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthAgent
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthAgent
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
 			}
-			if postIndex > l {
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetIPTablesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.IoMergedRecursive = append(m.IoMergedRecursive, &BlkioStatsEntry{})
-			if err := m.IoMergedRecursive[len(m.IoMergedRecursive)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IoTimeRecursive", wireType)
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetIPTablesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetIPTablesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsIpv6", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -11825,31 +17958,68 @@ func (m *BlkioStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthAgent
+			m.IsIpv6 = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthAgent
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.IoTimeRecursive = append(m.IoTimeRecursive, &BlkioStatsEntry{})
-			if err := m.IoTimeRecursive[len(m.IoTimeRecursive)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetIPTablesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 8:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetIPTablesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetIPTablesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SectorsRecursive", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -11859,24 +18029,24 @@ func (m *BlkioStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthAgent
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAgent
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.SectorsRecursive = append(m.SectorsRecursive, &BlkioStatsEntry{})
-			if err := m.SectorsRecursive[len(m.SectorsRecursive)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -11901,7 +18071,7 @@ func (m *BlkioStats) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HugetlbStats) Unmarshal(dAtA []byte) error {
+func (m *SetIPTablesRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11924,17 +18094,17 @@ func (m *HugetlbStats) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HugetlbStats: wiretype end group for non-group")
+			return fmt.Errorf("proto: SetIPTablesRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HugetlbStats: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SetIPTablesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Usage", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field IsIpv6", wireType)
 			}
-			m.Usage = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -11944,16 +18114,17 @@ func (m *HugetlbStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Usage |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.IsIpv6 = bool(v != 0)
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MaxUsage", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			m.MaxUsage = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -11963,30 +18134,26 @@ func (m *HugetlbStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.MaxUsage |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Failcnt", wireType)
+			if byteLen < 0 {
+				return ErrInvalidLengthAgent
 			}
-			m.Failcnt = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Failcnt |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAgent(dAtA[iNdEx:])
@@ -12009,7 +18176,7 @@ func (m *HugetlbStats) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CgroupStats) Unmarshal(dAtA []byte) error {
+func (m *SetIPTablesResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12032,17 +18199,17 @@ func (m *CgroupStats) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CgroupStats: wiretype end group for non-group")
+			return fmt.Errorf("proto: SetIPTablesResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CgroupStats: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SetIPTablesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CpuStats", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -12052,33 +18219,82 @@ func (m *CgroupStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthAgent
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAgent
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.CpuStats == nil {
-				m.CpuStats = &CpuStats{}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
-			if err := m.CpuStats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MemoryStats", wireType)
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAgent
 			}
-			var msglen int
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *OnlineCPUMemRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: OnlineCPUMemRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: OnlineCPUMemRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Wait", wireType)
+			}
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -12088,33 +18304,17 @@ func (m *CgroupStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthAgent
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthAgent
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.MemoryStats == nil {
-				m.MemoryStats = &MemoryStats{}
-			}
-			if err := m.MemoryStats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PidsStats", wireType)
+			m.Wait = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NbCpus", wireType)
 			}
-			var msglen int
+			m.NbCpus = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -12124,33 +18324,16 @@ func (m *CgroupStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.NbCpus |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthAgent
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthAgent
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.PidsStats == nil {
-				m.PidsStats = &PidsStats{}
-			}
-			if err := m.PidsStats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BlkioStats", wireType)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CpuOnly", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -12160,33 +18343,68 @@ func (m *CgroupStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthAgent
+			m.CpuOnly = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthAgent
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReseedRandomDevRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.BlkioStats == nil {
-				m.BlkioStats = &BlkioStats{}
-			}
-			if err := m.BlkioStats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 5:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReseedRandomDevRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReseedRandomDevRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field HugetlbStats", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -12196,120 +18414,25 @@ func (m *CgroupStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthAgent
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAgent
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.HugetlbStats == nil {
-				m.HugetlbStats = make(map[string]*HugetlbStats)
-			}
-			var mapkey string
-			var mapvalue *HugetlbStats
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowAgent
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowAgent
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthAgent
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey < 0 {
-						return ErrInvalidLengthAgent
-					}
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					var mapmsglen int
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowAgent
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapmsglen |= int(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					if mapmsglen < 0 {
-						return ErrInvalidLengthAgent
-					}
-					postmsgIndex := iNdEx + mapmsglen
-					if postmsgIndex < 0 {
-						return ErrInvalidLengthAgent
-					}
-					if postmsgIndex > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapvalue = &HugetlbStats{}
-					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
-						return err
-					}
-					iNdEx = postmsgIndex
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipAgent(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if (skippy < 0) || (iNdEx+skippy) < 0 {
-						return ErrInvalidLengthAgent
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
-				}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
-			m.HugetlbStats[mapkey] = mapvalue
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -12333,7 +18456,7 @@ func (m *CgroupStats) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *NetworkStats) Unmarshal(dAtA []byte) error {
+func (m *AgentDetails) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12356,15 +18479,15 @@ func (m *NetworkStats) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: NetworkStats: wiretype end group for non-group")
+			return fmt.Errorf("proto: AgentDetails: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: NetworkStats: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AgentDetails: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -12392,13 +18515,13 @@ func (m *NetworkStats) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.Version = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RxBytes", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field InitDaemon", wireType)
 			}
-			m.RxBytes = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -12408,16 +18531,17 @@ func (m *NetworkStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.RxBytes |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.InitDaemon = bool(v != 0)
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RxPackets", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeviceHandlers", wireType)
 			}
-			m.RxPackets = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -12427,16 +18551,29 @@ func (m *NetworkStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.RxPackets |= uint64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeviceHandlers = append(m.DeviceHandlers, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RxErrors", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StorageHandlers", wireType)
 			}
-			m.RxErrors = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -12446,16 +18583,29 @@ func (m *NetworkStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.RxErrors |= uint64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StorageHandlers = append(m.StorageHandlers, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RxDropped", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SupportsSeccomp", wireType)
 			}
-			m.RxDropped = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -12465,16 +18615,17 @@ func (m *NetworkStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.RxDropped |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.SupportsSeccomp = bool(v != 0)
 		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TxBytes", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ImageMetadata", wireType)
 			}
-			m.TxBytes = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -12484,35 +18635,80 @@ func (m *NetworkStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TxBytes |= uint64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 7:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TxPackets", wireType)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ImageMetadata = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GuestDetailsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			m.TxPackets = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.TxPackets |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-		case 8:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GuestDetailsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GuestDetailsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TxErrors", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MemBlockSize", wireType)
 			}
-			m.TxErrors = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -12522,16 +18718,17 @@ func (m *NetworkStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TxErrors |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 9:
+			m.MemBlockSize = bool(v != 0)
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TxDropped", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MemHotplugProbe", wireType)
 			}
-			m.TxDropped = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -12541,11 +18738,12 @@ func (m *NetworkStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TxDropped |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.MemHotplugProbe = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAgent(dAtA[iNdEx:])
@@ -12568,7 +18766,7 @@ func (m *NetworkStats) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StatsContainerResponse) Unmarshal(dAtA []byte) error {
+func (m *GuestDetailsResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12591,17 +18789,17 @@ func (m *StatsContainerResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StatsContainerResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: GuestDetailsResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StatsContainerResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GuestDetailsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CgroupStats", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemBlockSizeBytes", wireType)
 			}
-			var msglen int
+			m.MemBlockSizeBytes = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -12611,31 +18809,14 @@ func (m *StatsContainerResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.MemBlockSizeBytes |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthAgent
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthAgent
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.CgroupStats == nil {
-				m.CgroupStats = &CgroupStats{}
-			}
-			if err := m.CgroupStats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NetworkStats", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AgentDetails", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12662,11 +18843,33 @@ func (m *StatsContainerResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NetworkStats = append(m.NetworkStats, &NetworkStats{})
-			if err := m.NetworkStats[len(m.NetworkStats)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.AgentDetails == nil {
+				m.AgentDetails = &AgentDetails{}
+			}
+			if err := m.AgentDetails.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SupportMemHotplugProbe", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.SupportMemHotplugProbe = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAgent(dAtA[iNdEx:])
@@ -12689,7 +18892,7 @@ func (m *StatsContainerResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *WriteStreamRequest) Unmarshal(dAtA []byte) error {
+func (m *MemHotplugByProbeRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12712,49 +18915,144 @@ func (m *WriteStreamRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: WriteStreamRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: MemHotplugByProbeRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: WriteStreamRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MemHotplugByProbeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ContainerId", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowAgent
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				if iNdEx >= l {
+				m.MemHotplugProbeAddr = append(m.MemHotplugProbeAddr, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowAgent
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthAgent
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthAgent
+				}
+				if postIndex > l {
 					return io.ErrUnexpectedEOF
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.MemHotplugProbeAddr) == 0 {
+					m.MemHotplugProbeAddr = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowAgent
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.MemHotplugProbeAddr = append(m.MemHotplugProbeAddr, v)
 				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemHotplugProbeAddr", wireType)
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAgent
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthAgent
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ContainerId = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExecId", wireType)
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetGuestDateTimeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
 			}
-			var stringLen uint64
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetGuestDateTimeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetGuestDateTimeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sec", wireType)
+			}
+			m.Sec = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -12764,29 +19062,16 @@ func (m *WriteStreamRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Sec |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAgent
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthAgent
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ExecId = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Usec", wireType)
 			}
-			var byteLen int
+			m.Usec = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -12796,26 +19081,11 @@ func (m *WriteStreamRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.Usec |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthAgent
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthAgent
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAgent(dAtA[iNdEx:])
@@ -12838,7 +19108,7 @@ func (m *WriteStreamRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *WriteStreamResponse) Unmarshal(dAtA []byte) error {
+func (m *FSGroup) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12861,17 +19131,17 @@ func (m *WriteStreamResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: WriteStreamResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: FSGroup: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: WriteStreamResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: FSGroup: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Len", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupId", wireType)
 			}
-			m.Len = 0
+			m.GroupId = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -12881,7 +19151,26 @@ func (m *WriteStreamResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Len |= uint32(b&0x7F) << shift
+				m.GroupId |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupChangePolicy", wireType)
+			}
+			m.GroupChangePolicy = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupChangePolicy |= protocols.FSGroupChangePolicy(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -12908,7 +19197,7 @@ func (m *WriteStreamResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ReadStreamRequest) Unmarshal(dAtA []byte) error {
+func (m *Storage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12931,15 +19220,15 @@ func (m *ReadStreamRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ReadStreamRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: Storage: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ReadStreamRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Storage: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ContainerId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Driver", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -12967,11 +19256,11 @@ func (m *ReadStreamRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ContainerId = string(dAtA[iNdEx:postIndex])
+			m.Driver = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExecId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DriverOptions", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -12999,13 +19288,13 @@ func (m *ReadStreamRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ExecId = string(dAtA[iNdEx:postIndex])
+			m.DriverOptions = append(m.DriverOptions, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Len", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
 			}
-			m.Len = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -13015,67 +19304,29 @@ func (m *ReadStreamRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Len |= uint32(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipAgent(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthAgent
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ReadStreamResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowAgent
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ReadStreamResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ReadStreamResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.Source = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Fstype", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -13085,80 +19336,59 @@ func (m *ReadStreamResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthAgent
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAgent
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
-			}
+			m.Fstype = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipAgent(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Options", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthAgent
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *CloseStdinRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowAgent
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: CloseStdinRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CloseStdinRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.Options = append(m.Options, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ContainerId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MountPoint", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -13186,13 +19416,13 @@ func (m *CloseStdinRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ContainerId = string(dAtA[iNdEx:postIndex])
+			m.MountPoint = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExecId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field FsGroup", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -13202,23 +19432,27 @@ func (m *CloseStdinRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthAgent
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthAgent
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ExecId = string(dAtA[iNdEx:postIndex])
+			if m.FsGroup == nil {
+				m.FsGroup = &FSGroup{}
+			}
+			if err := m.FsGroup.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -13242,7 +19476,7 @@ func (m *CloseStdinRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TtyWinResizeRequest) Unmarshal(dAtA []byte) error {
+func (m *Device) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13265,15 +19499,15 @@ func (m *TtyWinResizeRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TtyWinResizeRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: Device: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TtyWinResizeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Device: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ContainerId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -13301,11 +19535,11 @@ func (m *TtyWinResizeRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ContainerId = string(dAtA[iNdEx:postIndex])
+			m.Id = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExecId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -13333,13 +19567,13 @@ func (m *TtyWinResizeRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ExecId = string(dAtA[iNdEx:postIndex])
+			m.Type = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Row", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VmPath", wireType)
 			}
-			m.Row = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -13349,16 +19583,29 @@ func (m *TtyWinResizeRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Row |= uint32(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.VmPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Column", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContainerPath", wireType)
 			}
-			m.Column = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -13368,11 +19615,56 @@ func (m *TtyWinResizeRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Column |= uint32(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContainerPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Options", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Options = append(m.Options, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAgent(dAtA[iNdEx:])
@@ -13395,7 +19687,7 @@ func (m *TtyWinResizeRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *KernelModule) Unmarshal(dAtA []byte) error {
+func (m *StringUser) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13418,15 +19710,15 @@ func (m *KernelModule) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: KernelModule: wiretype end group for non-group")
+			return fmt.Errorf("proto: StringUser: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: KernelModule: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: StringUser: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Uid", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -13454,11 +19746,11 @@ func (m *KernelModule) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.Uid = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Parameters", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Gid", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -13486,7 +19778,39 @@ func (m *KernelModule) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Parameters = append(m.Parameters, string(dAtA[iNdEx:postIndex]))
+			m.Gid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AdditionalGids", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AdditionalGids = append(m.AdditionalGids, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -13510,7 +19834,7 @@ func (m *KernelModule) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CreateSandboxRequest) Unmarshal(dAtA []byte) error {
+func (m *CopyFileRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13533,15 +19857,15 @@ func (m *CreateSandboxRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CreateSandboxRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: CopyFileRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateSandboxRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CopyFileRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Hostname", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -13569,13 +19893,13 @@ func (m *CreateSandboxRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Hostname = string(dAtA[iNdEx:postIndex])
+			m.Path = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Dns", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FileSize", wireType)
 			}
-			var stringLen uint64
+			m.FileSize = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -13585,29 +19909,16 @@ func (m *CreateSandboxRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.FileSize |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAgent
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthAgent
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Dns = append(m.Dns, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Storages", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FileMode", wireType)
 			}
-			var msglen int
+			m.FileMode = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -13617,31 +19928,16 @@ func (m *CreateSandboxRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.FileMode |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthAgent
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthAgent
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Storages = append(m.Storages, &Storage{})
-			if err := m.Storages[len(m.Storages)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SandboxPidns", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DirMode", wireType)
 			}
-			var v int
+			m.DirMode = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -13651,17 +19947,16 @@ func (m *CreateSandboxRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.DirMode |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.SandboxPidns = bool(v != 0)
 		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SandboxId", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uid", wireType)
 			}
-			var stringLen uint64
+			m.Uid = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -13671,29 +19966,16 @@ func (m *CreateSandboxRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Uid |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAgent
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthAgent
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.SandboxId = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GuestHookPath", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Gid", wireType)
 			}
-			var stringLen uint64
+			m.Gid = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -13703,29 +19985,35 @@ func (m *CreateSandboxRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Gid |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAgent
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthAgent
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.Offset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Offset |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.GuestHookPath = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 7:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field KernelModules", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -13735,24 +20023,24 @@ func (m *CreateSandboxRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthAgent
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAgent
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.KernelModules = append(m.KernelModules, &KernelModule{})
-			if err := m.KernelModules[len(m.KernelModules)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -13777,7 +20065,7 @@ func (m *CreateSandboxRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DestroySandboxRequest) Unmarshal(dAtA []byte) error {
+func (m *ReadFileRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13800,63 +20088,82 @@ func (m *DestroySandboxRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DestroySandboxRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ReadFileRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DestroySandboxRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ReadFileRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		default:
-			iNdEx = preIndex
-			skippy, err := skipAgent(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthAgent
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *RemoveStaleVirtiofsShareMountsRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowAgent
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxSize", wireType)
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			m.MaxSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+			}
+			m.Offset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Offset |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: RemoveStaleVirtiofsShareMountsRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RemoveStaleVirtiofsShareMountsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAgent(dAtA[iNdEx:])
@@ -13879,7 +20186,7 @@ func (m *RemoveStaleVirtiofsShareMountsRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Interfaces) Unmarshal(dAtA []byte) error {
+func (m *ReadFileResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13902,17 +20209,17 @@ func (m *Interfaces) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Interfaces: wiretype end group for non-group")
+			return fmt.Errorf("proto: ReadFileResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Interfaces: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ReadFileResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Interfaces", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -13922,24 +20229,24 @@ func (m *Interfaces) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthAgent
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAgent
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Interfaces = append(m.Interfaces, &protocols.Interface{})
-			if err := m.Interfaces[len(m.Interfaces)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -13964,7 +20271,7 @@ func (m *Interfaces) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Routes) Unmarshal(dAtA []byte) error {
+func (m *GetDiagnosticsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13987,17 +20294,17 @@ func (m *Routes) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Routes: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetDiagnosticsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Routes: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetDiagnosticsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Routes", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxKernelLogSize", wireType)
 			}
-			var msglen int
+			m.MaxKernelLogSize = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -14007,26 +20314,11 @@ func (m *Routes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.MaxKernelLogSize |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthAgent
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthAgent
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Routes = append(m.Routes, &protocols.Route{})
-			if err := m.Routes[len(m.Routes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAgent(dAtA[iNdEx:])
@@ -14049,7 +20341,7 @@ func (m *Routes) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UpdateInterfaceRequest) Unmarshal(dAtA []byte) error {
+func (m *Diagnostics) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14072,17 +20364,17 @@ func (m *UpdateInterfaceRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UpdateInterfaceRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: Diagnostics: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UpdateInterfaceRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Diagnostics: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Interface", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field KernelLog", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -14092,84 +20384,65 @@ func (m *UpdateInterfaceRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthAgent
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAgent
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Interface == nil {
-				m.Interface = &protocols.Interface{}
-			}
-			if err := m.Interface.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.KernelLog = append(m.KernelLog[:0], dAtA[iNdEx:postIndex]...)
+			if m.KernelLog == nil {
+				m.KernelLog = []byte{}
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipAgent(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mounts", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthAgent
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			if byteLen < 0 {
+				return ErrInvalidLengthAgent
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *UpdateRoutesRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowAgent
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: UpdateRoutesRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UpdateRoutesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.Mounts = append(m.Mounts[:0], dAtA[iNdEx:postIndex]...)
+			if m.Mounts == nil {
+				m.Mounts = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Routes", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Processes", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -14179,26 +20452,24 @@ func (m *UpdateRoutesRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthAgent
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAgent
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Routes == nil {
-				m.Routes = &Routes{}
-			}
-			if err := m.Routes.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Processes = append(m.Processes[:0], dAtA[iNdEx:postIndex]...)
+			if m.Processes == nil {
+				m.Processes = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -14223,7 +20494,7 @@ func (m *UpdateRoutesRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UpdateEphemeralMountsRequest) Unmarshal(dAtA []byte) error {
+func (m *CriuOptions) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14246,17 +20517,17 @@ func (m *UpdateEphemeralMountsRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UpdateEphemeralMountsRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: CriuOptions: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UpdateEphemeralMountsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CriuOptions: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Storages", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TcpEstablished", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -14266,26 +20537,52 @@ func (m *UpdateEphemeralMountsRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthAgent
+			m.TcpEstablished = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FileLocks", wireType)
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthAgent
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.FileLocks = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExtUnixSockets", wireType)
 			}
-			m.Storages = append(m.Storages, &Storage{})
-			if err := m.Storages[len(m.Storages)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
+			m.ExtUnixSockets = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAgent(dAtA[iNdEx:])
@@ -14308,7 +20605,7 @@ func (m *UpdateEphemeralMountsRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ListInterfacesRequest) Unmarshal(dAtA []byte) error {
+func (m *CheckpointContainerRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14331,117 +20628,99 @@ func (m *ListInterfacesRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ListInterfacesRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: CheckpointContainerRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListInterfacesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CheckpointContainerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		default:
-			iNdEx = preIndex
-			skippy, err := skipAgent(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContainerId", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthAgent
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ListRoutesRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowAgent
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.ContainerId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ImagePath", wireType)
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ListRoutesRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListRoutesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		default:
-			iNdEx = preIndex
-			skippy, err := skipAgent(dAtA[iNdEx:])
-			if err != nil {
-				return err
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthAgent
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ARPNeighbors) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowAgent
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.ImagePath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaveRunning", wireType)
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ARPNeighbors: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ARPNeighbors: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.LeaveRunning = bool(v != 0)
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ARPNeighbors", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Options", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -14468,8 +20747,10 @@ func (m *ARPNeighbors) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ARPNeighbors = append(m.ARPNeighbors, &protocols.ARPNeighbor{})
-			if err := m.ARPNeighbors[len(m.ARPNeighbors)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Options == nil {
+				m.Options = &CriuOptions{}
+			}
+			if err := m.Options.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -14495,7 +20776,7 @@ func (m *ARPNeighbors) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AddARPNeighborsRequest) Unmarshal(dAtA []byte) error {
+func (m *CheckpointContainerResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14518,17 +20799,17 @@ func (m *AddARPNeighborsRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AddARPNeighborsRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: CheckpointContainerResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AddARPNeighborsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CheckpointContainerResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Neighbors", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Files", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -14538,27 +20819,23 @@ func (m *AddARPNeighborsRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthAgent
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAgent
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Neighbors == nil {
-				m.Neighbors = &ARPNeighbors{}
-			}
-			if err := m.Neighbors.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Files = append(m.Files, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -14582,7 +20859,7 @@ func (m *AddARPNeighborsRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetIPTablesRequest) Unmarshal(dAtA []byte) error {
+func (m *RestoreContainerRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14605,17 +20882,17 @@ func (m *GetIPTablesRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetIPTablesRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: RestoreContainerRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetIPTablesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RestoreContainerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IsIpv6", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContainerId", wireType)
 			}
-			var v int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -14625,12 +20902,92 @@ func (m *GetIPTablesRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.IsIpv6 = bool(v != 0)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContainerId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ImagePath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ImagePath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Options", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Options == nil {
+				m.Options = &CriuOptions{}
+			}
+			if err := m.Options.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAgent(dAtA[iNdEx:])
@@ -14653,7 +21010,7 @@ func (m *GetIPTablesRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetIPTablesResponse) Unmarshal(dAtA []byte) error {
+func (m *GetOOMEventRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14676,46 +21033,12 @@ func (m *GetIPTablesResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetIPTablesResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetOOMEventRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetIPTablesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetOOMEventRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthAgent
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthAgent
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAgent(dAtA[iNdEx:])
@@ -14738,7 +21061,7 @@ func (m *GetIPTablesResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SetIPTablesRequest) Unmarshal(dAtA []byte) error {
+func (m *OOMEvent) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14761,37 +21084,17 @@ func (m *SetIPTablesRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SetIPTablesRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: OOMEvent: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SetIPTablesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: OOMEvent: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IsIpv6", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.IsIpv6 = bool(v != 0)
-		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ContainerId", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -14801,25 +21104,23 @@ func (m *SetIPTablesRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthAgent
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAgent
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
-			}
+			m.ContainerId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -14843,7 +21144,7 @@ func (m *SetIPTablesRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SetIPTablesResponse) Unmarshal(dAtA []byte) error {
+func (m *AddSwapRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14866,46 +21167,88 @@ func (m *SetIPTablesResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SetIPTablesResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: AddSwapRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SetIPTablesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AddSwapRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
+			if wireType == 0 {
+				var v uint32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowAgent
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				if iNdEx >= l {
+				m.PCIPath = append(m.PCIPath, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowAgent
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthAgent
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthAgent
+				}
+				if postIndex > l {
 					return io.ErrUnexpectedEOF
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.PCIPath) == 0 {
+					m.PCIPath = make([]uint32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowAgent
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.PCIPath = append(m.PCIPath, v)
 				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field PCIPath", wireType)
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthAgent
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthAgent
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAgent(dAtA[iNdEx:])
@@ -14928,7 +21271,7 @@ func (m *SetIPTablesResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *OnlineCPUMemRequest) Unmarshal(dAtA []byte) error {
+func (m *GetMetricsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14951,71 +21294,12 @@ func (m *OnlineCPUMemRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: OnlineCPUMemRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetMetricsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: OnlineCPUMemRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetMetricsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Wait", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.Wait = bool(v != 0)
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NbCpus", wireType)
-			}
-			m.NbCpus = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.NbCpus |= uint32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CpuOnly", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.CpuOnly = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAgent(dAtA[iNdEx:])
@@ -15038,7 +21322,7 @@ func (m *OnlineCPUMemRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ReseedRandomDevRequest) Unmarshal(dAtA []byte) error {
+func (m *Metrics) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15061,17 +21345,17 @@ func (m *ReseedRandomDevRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ReseedRandomDevRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: Metrics: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ReseedRandomDevRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Metrics: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 2:
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Metrics", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -15081,25 +21365,23 @@ func (m *ReseedRandomDevRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthAgent
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAgent
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
-			}
+			m.Metrics = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -15123,7 +21405,7 @@ func (m *ReseedRandomDevRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AgentDetails) Unmarshal(dAtA []byte) error {
+func (m *VolumeStatsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15146,99 +21428,15 @@ func (m *AgentDetails) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AgentDetails: wiretype end group for non-group")
+			return fmt.Errorf("proto: VolumeStatsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AgentDetails: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: VolumeStatsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAgent
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthAgent
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Version = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InitDaemon", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.InitDaemon = bool(v != 0)
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DeviceHandlers", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAgent
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthAgent
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.DeviceHandlers = append(m.DeviceHandlers, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StorageHandlers", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field VolumeGuestPath", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -15266,28 +21464,8 @@ func (m *AgentDetails) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.StorageHandlers = append(m.StorageHandlers, string(dAtA[iNdEx:postIndex]))
+			m.VolumeGuestPath = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SupportsSeccomp", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.SupportsSeccomp = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAgent(dAtA[iNdEx:])
@@ -15310,7 +21488,7 @@ func (m *AgentDetails) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GuestDetailsRequest) Unmarshal(dAtA []byte) error {
+func (m *ContainerVolumeStatsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15333,17 +21511,17 @@ func (m *GuestDetailsRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GuestDetailsRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ContainerVolumeStatsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GuestDetailsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ContainerVolumeStatsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MemBlockSize", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContainerId", wireType)
 			}
-			var v int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -15353,32 +21531,24 @@ func (m *GuestDetailsRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.MemBlockSize = bool(v != 0)
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MemHotplugProbe", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
 			}
-			m.MemHotplugProbe = bool(v != 0)
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContainerId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAgent(dAtA[iNdEx:])
@@ -15401,7 +21571,7 @@ func (m *GuestDetailsRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GuestDetailsResponse) Unmarshal(dAtA []byte) error {
+func (m *ContainerVolumeStats) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15424,17 +21594,17 @@ func (m *GuestDetailsResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GuestDetailsResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ContainerVolumeStats: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GuestDetailsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ContainerVolumeStats: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MemBlockSizeBytes", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Destination", wireType)
 			}
-			m.MemBlockSizeBytes = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -15444,16 +21614,29 @@ func (m *GuestDetailsResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.MemBlockSizeBytes |= uint64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Destination = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AgentDetails", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field VolumeGuestPath", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -15463,33 +21646,29 @@ func (m *GuestDetailsResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthAgent
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAgent
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.AgentDetails == nil {
-				m.AgentDetails = &AgentDetails{}
-			}
-			if err := m.AgentDetails.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.VolumeGuestPath = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SupportMemHotplugProbe", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
 			}
-			var v int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -15499,12 +21678,28 @@ func (m *GuestDetailsResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.SupportMemHotplugProbe = bool(v != 0)
+			if msglen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Stats == nil {
+				m.Stats = &VolumeStatsResponse{}
+			}
+			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAgent(dAtA[iNdEx:])
@@ -15527,7 +21722,7 @@ func (m *GuestDetailsResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *MemHotplugByProbeRequest) Unmarshal(dAtA []byte) error {
+func (m *ContainerVolumeStatsResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15550,88 +21745,46 @@ func (m *MemHotplugByProbeRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MemHotplugByProbeRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ContainerVolumeStatsResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MemHotplugByProbeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ContainerVolumeStatsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType == 0 {
-				var v uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowAgent
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.MemHotplugProbeAddr = append(m.MemHotplugProbeAddr, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowAgent
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthAgent
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthAgent
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Volumes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
 				}
-				if postIndex > l {
+				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
-				}
-				elementCount = count
-				if elementCount != 0 && len(m.MemHotplugProbeAddr) == 0 {
-					m.MemHotplugProbeAddr = make([]uint64, 0, elementCount)
-				}
-				for iNdEx < postIndex {
-					var v uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowAgent
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.MemHotplugProbeAddr = append(m.MemHotplugProbeAddr, v)
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field MemHotplugProbeAddr", wireType)
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Volumes = append(m.Volumes, &ContainerVolumeStats{})
+			if err := m.Volumes[len(m.Volumes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAgent(dAtA[iNdEx:])
@@ -15654,7 +21807,7 @@ func (m *MemHotplugByProbeRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SetGuestDateTimeRequest) Unmarshal(dAtA []byte) error {
+func (m *ResizeVolumeRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15677,17 +21830,17 @@ func (m *SetGuestDateTimeRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SetGuestDateTimeRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResizeVolumeRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SetGuestDateTimeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResizeVolumeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Sec", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VolumeGuestPath", wireType)
 			}
-			m.Sec = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -15697,16 +21850,29 @@ func (m *SetGuestDateTimeRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Sec |= int64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.VolumeGuestPath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Usec", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Size_", wireType)
 			}
-			m.Usec = 0
+			m.Size_ = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -15716,7 +21882,7 @@ func (m *SetGuestDateTimeRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Usec |= int64(b&0x7F) << shift
+				m.Size_ |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -15743,7 +21909,7 @@ func (m *SetGuestDateTimeRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *FSGroup) Unmarshal(dAtA []byte) error {
+func (m *WaitDeviceRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15766,17 +21932,49 @@ func (m *FSGroup) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: FSGroup: wiretype end group for non-group")
+			return fmt.Errorf("proto: WaitDeviceRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: FSGroup: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: WaitDeviceRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GroupId", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
 			}
-			m.GroupId = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -15786,16 +21984,29 @@ func (m *FSGroup) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.GroupId |= uint32(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GroupChangePolicy", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
 			}
-			m.GroupChangePolicy = 0
+			m.Timeout = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -15805,7 +22016,7 @@ func (m *FSGroup) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.GroupChangePolicy |= protocols.FSGroupChangePolicy(b&0x7F) << shift
+				m.Timeout |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -15832,7 +22043,7 @@ func (m *FSGroup) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Storage) Unmarshal(dAtA []byte) error {
+func (m *SyncFsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15855,15 +22066,15 @@ func (m *Storage) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Storage: wiretype end group for non-group")
+			return fmt.Errorf("proto: SyncFsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Storage: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SyncFsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Driver", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ContainerId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -15891,13 +22102,13 @@ func (m *Storage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Driver = string(dAtA[iNdEx:postIndex])
+			m.ContainerId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DriverOptions", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
 			}
-			var stringLen uint64
+			m.Timeout = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -15907,27 +22118,65 @@ func (m *Storage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Timeout |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAgent
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthAgent
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.DriverOptions = append(m.DriverOptions, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 3:
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FreezeFsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FreezeFsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FreezeFsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ContainerId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -15955,45 +22204,115 @@ func (m *Storage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Source = string(dAtA[iNdEx:postIndex])
+			m.ContainerId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Fstype", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthAgent
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ThawFsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ThawFsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ThawFsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthAgent
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Fstype = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetNameResolutionRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetNameResolutionRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetNameResolutionRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Options", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hosts", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -16003,29 +22322,31 @@ func (m *Storage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthAgent
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAgent
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Options = append(m.Options, string(dAtA[iNdEx:postIndex]))
+			m.Hosts = append(m.Hosts[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hosts == nil {
+				m.Hosts = []byte{}
+			}
 			iNdEx = postIndex
-		case 6:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MountPoint", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ResolvConf", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -16035,60 +22356,77 @@ func (m *Storage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthAgent
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthAgent
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.MountPoint = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FsGroup", wireType)
+			m.ResolvConf = append(m.ResolvConf[:0], dAtA[iNdEx:postIndex]...)
+			if m.ResolvConf == nil {
+				m.ResolvConf = []byte{}
 			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthAgent
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthAgent
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetGuestServicesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
 			}
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.FsGroup == nil {
-				m.FsGroup = &FSGroup{}
-			}
-			if err := m.FsGroup.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetGuestServicesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetGuestServicesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAgent(dAtA[iNdEx:])
@@ -16111,7 +22449,7 @@ func (m *Storage) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Device) Unmarshal(dAtA []byte) error {
+func (m *GuestService) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -16134,15 +22472,15 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Device: wiretype end group for non-group")
+			return fmt.Errorf("proto: GuestService: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Device: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GuestService: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -16170,11 +22508,11 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Id = string(dAtA[iNdEx:postIndex])
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -16202,13 +22540,13 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Type = string(dAtA[iNdEx:postIndex])
+			m.State = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field VmPath", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Restarts", wireType)
 			}
-			var stringLen uint64
+			m.Restarts = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -16218,61 +22556,67 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Restarts |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAgent
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthAgent
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.VmPath = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ContainerPath", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAgent
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthAgent
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GuestServices) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
 			}
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ContainerPath = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GuestServices: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GuestServices: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Options", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Services", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -16282,23 +22626,25 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthAgent
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthAgent
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Options = append(m.Options, string(dAtA[iNdEx:postIndex]))
+			m.Services = append(m.Services, &GuestService{})
+			if err := m.Services[len(m.Services)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -16322,7 +22668,7 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StringUser) Unmarshal(dAtA []byte) error {
+func (m *NetworkPolicyRule) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -16345,15 +22691,15 @@ func (m *StringUser) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StringUser: wiretype end group for non-group")
+			return fmt.Errorf("proto: NetworkPolicyRule: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StringUser: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: NetworkPolicyRule: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Uid", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Direction", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -16381,11 +22727,11 @@ func (m *StringUser) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Uid = string(dAtA[iNdEx:postIndex])
+			m.Direction = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Gid", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Cidr", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -16413,94 +22759,11 @@ func (m *StringUser) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Gid = string(dAtA[iNdEx:postIndex])
+			m.Cidr = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AdditionalGids", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAgent
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthAgent
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.AdditionalGids = append(m.AdditionalGids, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipAgent(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthAgent
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *CopyFileRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowAgent
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: CopyFileRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CopyFileRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Protocol", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -16528,32 +22791,13 @@ func (m *CopyFileRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Path = string(dAtA[iNdEx:postIndex])
+			m.Protocol = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FileSize", wireType)
-			}
-			m.FileSize = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAgent
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.FileSize |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FileMode", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Port", wireType)
 			}
-			m.FileMode = 0
+			m.Port = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -16563,16 +22807,16 @@ func (m *CopyFileRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.FileMode |= uint32(b&0x7F) << shift
+				m.Port |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
+		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DirMode", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EndPort", wireType)
 			}
-			m.DirMode = 0
+			m.EndPort = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -16582,16 +22826,67 @@ func (m *CopyFileRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.DirMode |= uint32(b&0x7F) << shift
+				m.EndPort |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Uid", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAgent(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			m.Uid = 0
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetNetworkPolicyRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAgent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetNetworkPolicyRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetNetworkPolicyRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Program", wireType)
+			}
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -16601,16 +22896,31 @@ func (m *CopyFileRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Uid |= int32(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 6:
+			if byteLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Program = append(m.Program[:0], dAtA[iNdEx:postIndex]...)
+			if m.Program == nil {
+				m.Program = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Gid", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Ingress", wireType)
 			}
-			m.Gid = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -16620,16 +22930,17 @@ func (m *CopyFileRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Gid |= int32(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 7:
+			m.Ingress = bool(v != 0)
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Egress", wireType)
 			}
-			m.Offset = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -16639,16 +22950,17 @@ func (m *CopyFileRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Offset |= int64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 8:
+			m.Egress = bool(v != 0)
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Rules", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -16658,24 +22970,24 @@ func (m *CopyFileRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthAgent
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthAgent
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
+			m.Rules = append(m.Rules, &NetworkPolicyRule{})
+			if err := m.Rules[len(m.Rules)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		default:
@@ -16700,7 +23012,7 @@ func (m *CopyFileRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetOOMEventRequest) Unmarshal(dAtA []byte) error {
+func (m *GetSeccompNotificationRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -16723,10 +23035,10 @@ func (m *GetOOMEventRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetOOMEventRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetSeccompNotificationRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetOOMEventRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetSeccompNotificationRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		default:
@@ -16751,7 +23063,7 @@ func (m *GetOOMEventRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *OOMEvent) Unmarshal(dAtA []byte) error {
+func (m *SeccompNotification) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -16774,13 +23086,32 @@ func (m *OOMEvent) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: OOMEvent: wiretype end group for non-group")
+			return fmt.Errorf("proto: SeccompNotification: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: OOMEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SeccompNotification: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			m.Id = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Id |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field ContainerId", wireType)
 			}
@@ -16812,60 +23143,124 @@ func (m *OOMEvent) Unmarshal(dAtA []byte) error {
 			}
 			m.ContainerId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipAgent(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecId", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthAgent
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *AddSwapRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowAgent
+			m.ExecId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pid", wireType)
 			}
-			if iNdEx >= l {
+			m.Pid = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Pid |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Syscall", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Syscall = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Arch", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAgent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAgent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAgent
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: AddSwapRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AddSwapRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.Arch = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
 			if wireType == 0 {
-				var v uint32
+				var v uint64
 				for shift := uint(0); ; shift += 7 {
 					if shift >= 64 {
 						return ErrIntOverflowAgent
@@ -16875,12 +23270,12 @@ func (m *AddSwapRequest) Unmarshal(dAtA []byte) error {
 					}
 					b := dAtA[iNdEx]
 					iNdEx++
-					v |= uint32(b&0x7F) << shift
+					v |= uint64(b&0x7F) << shift
 					if b < 0x80 {
 						break
 					}
 				}
-				m.PCIPath = append(m.PCIPath, v)
+				m.Args = append(m.Args, v)
 			} else if wireType == 2 {
 				var packedLen int
 				for shift := uint(0); ; shift += 7 {
@@ -16915,11 +23310,11 @@ func (m *AddSwapRequest) Unmarshal(dAtA []byte) error {
 					}
 				}
 				elementCount = count
-				if elementCount != 0 && len(m.PCIPath) == 0 {
-					m.PCIPath = make([]uint32, 0, elementCount)
+				if elementCount != 0 && len(m.Args) == 0 {
+					m.Args = make([]uint64, 0, elementCount)
 				}
 				for iNdEx < postIndex {
-					var v uint32
+					var v uint64
 					for shift := uint(0); ; shift += 7 {
 						if shift >= 64 {
 							return ErrIntOverflowAgent
@@ -16929,67 +23324,16 @@ func (m *AddSwapRequest) Unmarshal(dAtA []byte) error {
 						}
 						b := dAtA[iNdEx]
 						iNdEx++
-						v |= uint32(b&0x7F) << shift
+						v |= uint64(b&0x7F) << shift
 						if b < 0x80 {
 							break
 						}
 					}
-					m.PCIPath = append(m.PCIPath, v)
+					m.Args = append(m.Args, v)
 				}
 			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field PCIPath", wireType)
-			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipAgent(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthAgent
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *GetMetricsRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowAgent
+				return fmt.Errorf("proto: wrong wireType = %d for field Args", wireType)
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: GetMetricsRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetMetricsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAgent(dAtA[iNdEx:])
@@ -17012,7 +23356,7 @@ func (m *GetMetricsRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Metrics) Unmarshal(dAtA []byte) error {
+func (m *SeccompNotificationResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -17035,17 +23379,17 @@ func (m *Metrics) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Metrics: wiretype end group for non-group")
+			return fmt.Errorf("proto: SeccompNotificationResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Metrics: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SeccompNotificationResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Metrics", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
-			var stringLen uint64
+			m.Id = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -17055,80 +23399,16 @@ func (m *Metrics) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Id |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAgent
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthAgent
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Metrics = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipAgent(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthAgent
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *VolumeStatsRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowAgent
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: VolumeStatsRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: VolumeStatsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field VolumeGuestPath", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Allow", wireType)
 			}
-			var stringLen uint64
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -17138,80 +23418,17 @@ func (m *VolumeStatsRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAgent
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthAgent
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.VolumeGuestPath = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipAgent(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthAgent
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ResizeVolumeRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowAgent
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ResizeVolumeRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResizeVolumeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field VolumeGuestPath", wireType)
+			m.Allow = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
-			var stringLen uint64
+			m.Error = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -17221,29 +23438,16 @@ func (m *ResizeVolumeRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Error |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAgent
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthAgent
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.VolumeGuestPath = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Size_", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
-			m.Size_ = 0
+			m.Value = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAgent
@@ -17253,7 +23457,7 @@ func (m *ResizeVolumeRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Size_ |= uint64(b&0x7F) << shift
+				m.Value |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
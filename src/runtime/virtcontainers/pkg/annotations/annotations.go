@@ -59,6 +59,14 @@ const (
 	// that will be passed to the container VM.
 	FirmwareVolumePath = kataAnnotHypervisorPrefix + "firmware_volume"
 
+	// KernelVariant is a sandbox annotation for selecting the guest kernel, among the kernel_variants of the
+	// configuration, that will run the container VM.
+	KernelVariant = kataAnnotHypervisorPrefix + "kernel_variant"
+
+	// FirmwareVariant is a sandbox annotation for selecting the guest firmware, among the firmware_variants of the
+	// configuration, that will run the container VM.
+	FirmwareVariant = kataAnnotHypervisorPrefix + "firmware_variant"
+
 	// KernelHash is a sandbox annotation for passing a container kernel image SHA-512 hash value.
 	KernelHash = kataAnnotHypervisorPrefix + "kernel_hash"
 
@@ -83,7 +91,7 @@ const (
 	// FirmwareVolumeHash is an sandbox annotation for passing a container guest firmware volume SHA-512 hash value.
 	FirmwareVolumeHash = kataAnnotHypervisorPrefix + "firmware_volume_hash"
 
-	// AssetHashType is the hash type used for assets verification
+	// AssetHashType is the hash type used for assets verification, either sha512 (default) or sha256
 	AssetHashType = kataAnnotationsPrefix + "asset_hash_type"
 
 	//
@@ -102,6 +110,10 @@ const (
 	// CPUFeatures is a sandbox annotation to specify cpu specific features.
 	CPUFeatures = kataAnnotHypervisorPrefix + "cpu_features"
 
+	// CPUMitigations is a sandbox annotation that selects the CPU vulnerability mitigation
+	// profile of the guest, among the ones allowed by valid_cpu_mitigations.
+	CPUMitigations = kataAnnotHypervisorPrefix + "cpu_mitigations"
+
 	// DisableVhostNet is a sandbox annotation to specify if vhost-net is not available on the host.
 	DisableVhostNet = kataAnnotHypervisorPrefix + "disable_vhost_net"
 
@@ -122,6 +134,10 @@ const (
 	// DisableImageNvdimm is a sandbox annotation to specify use of nvdimm device for guest rootfs image.
 	DisableImageNvdimm = kataAnnotHypervisorPrefix + "disable_image_nvdimm"
 
+	// PmemVolumesSize is a sandbox annotation to specify the guest physical memory, in MiB,
+	// reserved for the volumes on persistent memory.
+	PmemVolumesSize = kataAnnotHypervisorPrefix + "pmem_volumes_size"
+
 	// HotplugVFIOOnRootBus is a sandbox annotation used to indicate if devices need to be hotplugged on the
 	// root bus instead of a bridge.
 	HotplugVFIOOnRootBus = kataAnnotHypervisorPrefix + "hotplug_vfio_on_root_bus"
@@ -132,6 +148,14 @@ const (
 	// HotPlugVFIO is a sandbox annotation used to indicate if devices need to be hotplugged.
 	HotPlugVFIO = kataAnnotHypervisorPrefix + "hot_plug_vfio"
 
+	// PCIHotplugMode is a sandbox annotation used to select how the PCI devices are
+	// hotplugged on the q35 machine, "auto", "acpi" or "native".
+	PCIHotplugMode = kataAnnotHypervisorPrefix + "pci_hotplug_mode"
+
+	// PCIeP2P is a sandbox annotation used to enable the peer-to-peer DMA between the
+	// VFIO devices sharing a PCIe switch.
+	PCIeP2P = kataAnnotHypervisorPrefix + "pcie_p2p"
+
 	// EntropySource is a sandbox annotation to specify the path to a host source of
 	// entropy (/dev/random, /dev/urandom or real hardware RNG device)
 	EntropySource = kataAnnotHypervisorPrefix + "entropy_source"
@@ -177,9 +201,49 @@ const (
 	// Enable Hypervisor Devices IOMMU_PLATFORM
 	IOMMUPlatform = kataAnnotHypervisorPrefix + "enable_iommu_platform"
 
+	// EnableVTPM is a sandbox annotation to add a TPM 2.0 device emulated by swtpm
+	EnableVTPM = kataAnnotHypervisorPrefix + "enable_vtpm"
+
+	// ExtraArgs is a sandbox annotation to append raw arguments to the hypervisor
+	// command line, it must be allowed by valid_extra_args
+	ExtraArgs = kataAnnotHypervisorPrefix + "extra_args"
+
+	// ExtraAPIFields is a sandbox annotation to apply a JSON merge patch to the VM
+	// configuration sent to the hypervisor API, its fields must be allowed by
+	// valid_extra_api_fields
+	ExtraAPIFields = kataAnnotHypervisorPrefix + "extra_api_fields"
+
+	// VirtioGPU is a sandbox annotation to add a virtio-gpu device rendering
+	// with the host GPU through the venus or drm backend
+	VirtioGPU = kataAnnotHypervisorPrefix + "virtio_gpu"
+
+	// USBDevices is a sandbox annotation to pass host USB devices through to the VM,
+	// as a comma separated list of vendor:product or bus-port
+	USBDevices = kataAnnotHypervisorPrefix + "usb_devices"
+
+	// VirtioGPUHostMem is a sandbox annotation to specify the size in MiB of the
+	// host visible memory region of the virtio-gpu device
+	VirtioGPUHostMem = kataAnnotHypervisorPrefix + "virtio_gpu_hostmem"
+
 	// FileBackedMemRootDir is a sandbox annotation to soecify file based memory backend root directory
 	FileBackedMemRootDir = kataAnnotHypervisorPrefix + "file_mem_backend"
 
+	// HostSharedMemory is a sandbox annotation to specify the host shared memory
+	// segment mapped in the guest
+	HostSharedMemory = kataAnnotHypervisorPrefix + "host_shared_memory"
+
+	// MemoryTHP is a sandbox annotation to specify the transparent huge page policy
+	// (always, madvise or never) applied to the guest memory of the hypervisor process
+	MemoryTHP = kataAnnotHypervisorPrefix + "memory_thp"
+
+	// ReclaimGuestFreedMemory is a sandbox annotation to enable returning memory
+	// freed by the guest to the host through virtio-balloon free page reporting
+	ReclaimGuestFreedMemory = kataAnnotHypervisorPrefix + "reclaim_guest_freed_memory"
+
+	// MemMerge is a sandbox annotation to set the KSM policy of guest memory,
+	// on or off
+	MemMerge = kataAnnotHypervisorPrefix + "mem_merge"
+
 	//
 	// Shared File System related annotations
 	//
@@ -202,6 +266,9 @@ const (
 	// VirtioFSExtraArgs is a sandbox annotation to pass options to virtiofsd daemon
 	VirtioFSExtraArgs = kataAnnotHypervisorPrefix + "virtio_fs_extra_args"
 
+	// VirtioFSSubmounts is a sandbox annotation to make virtiofsd announce the submounts of the shared directory
+	VirtioFSSubmounts = kataAnnotHypervisorPrefix + "virtio_fs_announce_submounts"
+
 	//
 	// Block Device related annotations
 	//
@@ -241,6 +308,13 @@ const (
 
 	// EnableRootlessHypervisor is a sandbox annotation to enable rootless hypervisor (only supported in QEMU currently).
 	EnableRootlessHypervisor = kataAnnotHypervisorPrefix + "rootless"
+
+	// SRIOVVFConfig is a sandbox annotation that sets the host side attributes of the SR-IOV
+	// VFs passed through as network interfaces. Semicolon separated list of interface names
+	// followed by the attributes of their VF, e.g.
+	//
+	//   io.katacontainers.config.hypervisor.sriov_vf_config: "net1 vlan=100 qos=3 spoofchk=off trust=on max_tx_rate=1000"
+	SRIOVVFConfig = kataAnnotHypervisorPrefix + "sriov_vf_config"
 )
 
 // Runtime related annotations
@@ -250,6 +324,91 @@ const (
 	// DisableGuestSeccomp is a sandbox annotation that determines if seccomp should be applied inside guest.
 	DisableGuestSeccomp = kataAnnotRuntimePrefix + "disable_guest_seccomp"
 
+	// GuestSeccompMode is a sandbox annotation that selects how seccomp is applied inside guest,
+	// one of "enforce", "audit" or "unconfined".
+	GuestSeccompMode = kataAnnotRuntimePrefix + "guest_seccomp_mode"
+
+	// GuestSeccompReport is a sandbox annotation that determines if the system calls blocked by
+	// seccomp inside guest should be collected.
+	GuestSeccompReport = kataAnnotRuntimePrefix + "guest_seccomp_report"
+
+	// GuestPidsLimit is a sandbox annotation that sets the pids limit inside guest of the
+	// containers without a limit of their own.
+	GuestPidsLimit = kataAnnotRuntimePrefix + "guest_pids_limit"
+
+	// MultipathEvents is a sandbox annotation that determines if the path failures of the
+	// multipath maps attached to the sandbox are reported.
+	MultipathEvents = kataAnnotRuntimePrefix + "multipath_events"
+
+	// StopFlushTimeout is a sandbox annotation that sets how long in seconds the filesystems
+	// and the drives of the containers are flushed for when they stop, 0 for not flushing them.
+	StopFlushTimeout = kataAnnotRuntimePrefix + "stop_flush_timeout"
+
+	// ConfirmExecTimeout is a sandbox annotation that sets how long in seconds the start of
+	// a container waits for the agent to confirm its process executed its entrypoint.
+	ConfirmExecTimeout = kataAnnotRuntimePrefix + "confirm_exec_timeout"
+
+	// ForensicSnapshotThreshold is a sandbox annotation that sets the number of nonzero exits of
+	// a container after which a diagnostic snapshot is captured at each of its exits.
+	ForensicSnapshotThreshold = kataAnnotRuntimePrefix + "forensic_snapshot_threshold"
+
+	// GuestNameResolution is a sandbox annotation that makes the agent manage the /etc/hosts
+	// and /etc/resolv.conf files of the containers rather than sharing the host files.
+	GuestNameResolution = kataAnnotRuntimePrefix + "guest_name_resolution"
+
+	// Profile is a sandbox annotation that selects the profile of the sandbox, only the
+	// low-latency profile can be selected per pod.
+	Profile = kataAnnotRuntimePrefix + "profile"
+
+	// Pauseless is a sandbox annotation that determines if the sandbox container is not
+	// created inside the guest, the first container created being the init task of the sandbox.
+	Pauseless = kataAnnotRuntimePrefix + "pauseless"
+
+	// MetadataService is a sandbox annotation that determines if the metadata of the pod
+	// is served inside the guest.
+	MetadataService = kataAnnotRuntimePrefix + "metadata_service"
+
+	// GuestServices is a sandbox annotation listing the systemd units of the guest image,
+	// separated by commas, the agent runs for the pod, e.g. "chronyd,iscsid"
+	GuestServices = kataAnnotRuntimePrefix + "guest_services"
+
+	// PlacementNUMANodes is a sandbox annotation that sets the host NUMA nodes the sandbox
+	// should be placed on, e.g. the topology manager hint of the pod.
+	PlacementNUMANodes = kataAnnotRuntimePrefix + "placement_numa_nodes"
+
+	// SharePidNs is a sandbox annotation that determines if the containers of the sandbox
+	// share one PID namespace.
+	SharePidNs = kataAnnotRuntimePrefix + "share_pid_ns"
+
+	// WasmRuntime is a sandbox annotation that selects the WASM runtime of the guest, only
+	// wasmtime for now, running the containers whose image targets WASM.
+	WasmRuntime = kataAnnotRuntimePrefix + "wasm_runtime"
+
+	// NestedContainers is a sandbox annotation that lets the containers run a container engine,
+	// e.g. Docker, inside the guest.
+	NestedContainers = kataAnnotRuntimePrefix + "nested_containers"
+
+	// SizingInitMilliCPUs is a sandbox annotation that declares the peak CPU, in
+	// milli CPUs, of the init containers of the pod, for the sizing of the sandbox.
+	SizingInitMilliCPUs = kataAnnotRuntimePrefix + "sizing_init_milli_cpus"
+
+	// SizingInitMemory is a sandbox annotation that declares the peak memory, in
+	// bytes, of the init containers of the pod, for the sizing of the sandbox.
+	SizingInitMemory = kataAnnotRuntimePrefix + "sizing_init_memory"
+
+	// SizingEphemeralStorage is a sandbox annotation that declares the ephemeral
+	// storage, in bytes, of the pod held in the guest memory, e.g. the images pulled
+	// in the guest or the emptyDir volumes, for the sizing of the sandbox.
+	SizingEphemeralStorage = kataAnnotRuntimePrefix + "sizing_ephemeral_storage"
+
+	// ShmChannel is a sandbox annotation that names the shared memory channel the
+	// sandbox joins, connecting it to the other sandbox of the pod namespace naming it.
+	ShmChannel = kataAnnotRuntimePrefix + "shm_channel"
+
+	// EnableCoreDumps is a sandbox annotation that determines if the core dumps of the
+	// containers are collected on the host.
+	EnableCoreDumps = kataAnnotRuntimePrefix + "enable_core_dumps"
+
 	// GuestSeLinuxLabel is a SELinux security policy that is applied to a container process inside guest.
 	GuestSeLinuxLabel = kataAnnotRuntimePrefix + "guest_selinux_label"
 
@@ -259,12 +418,24 @@ const (
 	// EnableVCPUsPinning is a sandbox annotation that controls bundling between vCPU threads and CPUs
 	EnableVCPUsPinning = kataAnnotationsPrefix + "enable_vcpus_pinning"
 
+	// VMMSchedClass is a sandbox annotation that sets the scheduling class of the VMM threads,
+	// one of default, latency or batch
+	VMMSchedClass = kataAnnotRuntimePrefix + "vmm_sched_class"
+
+	// VMMSchedRTPriority is a sandbox annotation that sets the realtime priority of the vCPU
+	// threads of the latency scheduling class
+	VMMSchedRTPriority = kataAnnotRuntimePrefix + "vmm_sched_rt_priority"
+
 	// EnablePprof is a sandbox annotation that determines if pprof enabled.
 	EnablePprof = kataAnnotRuntimePrefix + "enable_pprof"
 
 	// Experimental is a sandbox annotation that determines if experimental features enabled.
 	Experimental = kataAnnotRuntimePrefix + "experimental"
 
+	// HostContainers is a sandbox annotation listing the names of the containers of the pod,
+	// separated by commas, that are run on the host by the host container runtime
+	HostContainers = kataAnnotRuntimePrefix + "host_containers"
+
 	// InterNetworkModel is a sandbox annotaion that determines how the VM should be connected to the
 	//the container network interface.
 	InterNetworkModel = kataAnnotRuntimePrefix + "internetworking_model"
@@ -275,6 +446,10 @@ const (
 	// VfioMode is a sandbox annotation to specify how attached VFIO devices should be treated
 	// Overrides the runtime.vfio_mode parameter in the global configuration.toml
 	VfioMode = kataAnnotRuntimePrefix + "vfio_mode"
+
+	// VMRestartPolicy is a sandbox annotation that selects if the VM is restarted, along with
+	// the containers that were running, when it crashes, one of "never" or "on-crash".
+	VMRestartPolicy = kataAnnotRuntimePrefix + "vm_restart_policy"
 )
 
 // Agent related annotations
@@ -297,6 +472,10 @@ const (
 	// AgentTrace is a sandbox annotation to enable tracing for the agent.
 	AgentTrace = kataAnnotAgentPrefix + "enable_tracing"
 
+	// AgentStreamConnections is a sandbox annotation to specify the number of dedicated
+	// agent connections used for process stdio and file copies.
+	AgentStreamConnections = kataAnnotAgentPrefix + "stream_connections"
+
 	// AgentContainerPipeSize is an annotation to specify the size of the pipes created for containers
 	AgentContainerPipeSize       = kataAnnotAgentPrefix + ContainerPipeSizeOption
 	ContainerPipeSizeOption      = "container_pipe_size"
@@ -317,6 +496,9 @@ const (
 const (
 	// SHA512 is the SHA-512 (64) hash algorithm
 	SHA512 string = "sha512"
+
+	// SHA256 is the SHA-256 (32) hash algorithm
+	SHA256 string = "sha256"
 )
 
 // Third-party annotations - annotations defined by other projects or k8s plugins
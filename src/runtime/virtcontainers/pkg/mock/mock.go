@@ -241,10 +241,66 @@ func (p *HybridVSockTTRPCMockImp) GetVolumeStats(ctx context.Context, req *pb.Vo
 	return &pb.VolumeStatsResponse{}, nil
 }
 
+func (p *HybridVSockTTRPCMockImp) GetContainerVolumeStats(ctx context.Context, req *pb.ContainerVolumeStatsRequest) (*pb.ContainerVolumeStatsResponse, error) {
+	return &pb.ContainerVolumeStatsResponse{}, nil
+}
+
 func (p *HybridVSockTTRPCMockImp) ResizeVolume(ctx context.Context, req *pb.ResizeVolumeRequest) (*gpb.Empty, error) {
 	return &gpb.Empty{}, nil
 }
 
+func (p *HybridVSockTTRPCMockImp) WaitDevice(ctx context.Context, req *pb.WaitDeviceRequest) (*gpb.Empty, error) {
+	return &gpb.Empty{}, nil
+}
+
+func (p *HybridVSockTTRPCMockImp) SyncFs(ctx context.Context, req *pb.SyncFsRequest) (*gpb.Empty, error) {
+	return &gpb.Empty{}, nil
+}
+
+func (p *HybridVSockTTRPCMockImp) FreezeFs(ctx context.Context, req *pb.FreezeFsRequest) (*gpb.Empty, error) {
+	return &gpb.Empty{}, nil
+}
+
+func (p *HybridVSockTTRPCMockImp) ThawFs(ctx context.Context, req *pb.ThawFsRequest) (*gpb.Empty, error) {
+	return &gpb.Empty{}, nil
+}
+
+func (p *HybridVSockTTRPCMockImp) GetGuestServices(ctx context.Context, req *pb.GetGuestServicesRequest) (*pb.GuestServices, error) {
+	return &pb.GuestServices{}, nil
+}
+
+func (p *HybridVSockTTRPCMockImp) SetNameResolution(ctx context.Context, req *pb.SetNameResolutionRequest) (*gpb.Empty, error) {
+	return &gpb.Empty{}, nil
+}
+
+func (p *HybridVSockTTRPCMockImp) SetNetworkPolicy(ctx context.Context, req *pb.SetNetworkPolicyRequest) (*gpb.Empty, error) {
+	return &gpb.Empty{}, nil
+}
+
+func (p *HybridVSockTTRPCMockImp) GetSeccompNotification(ctx context.Context, req *pb.GetSeccompNotificationRequest) (*pb.SeccompNotification, error) {
+	return &pb.SeccompNotification{}, nil
+}
+
+func (p *HybridVSockTTRPCMockImp) SendSeccompNotificationResponse(ctx context.Context, req *pb.SeccompNotificationResponse) (*gpb.Empty, error) {
+	return &gpb.Empty{}, nil
+}
+
+func (p *HybridVSockTTRPCMockImp) ReadFile(ctx context.Context, req *pb.ReadFileRequest) (*pb.ReadFileResponse, error) {
+	return &pb.ReadFileResponse{}, nil
+}
+
+func (p *HybridVSockTTRPCMockImp) GetDiagnostics(ctx context.Context, req *pb.GetDiagnosticsRequest) (*pb.Diagnostics, error) {
+	return &pb.Diagnostics{}, nil
+}
+
+func (p *HybridVSockTTRPCMockImp) CheckpointContainer(ctx context.Context, req *pb.CheckpointContainerRequest) (*pb.CheckpointContainerResponse, error) {
+	return &pb.CheckpointContainerResponse{}, nil
+}
+
+func (p *HybridVSockTTRPCMockImp) RestoreContainer(ctx context.Context, req *pb.RestoreContainerRequest) (*gpb.Empty, error) {
+	return &gpb.Empty{}, nil
+}
+
 func (p *HybridVSockTTRPCMockImp) RemoveStaleVirtiofsShareMounts(ctx context.Context, req *pb.RemoveStaleVirtiofsShareMountsRequest) (*gpb.Empty, error) {
 	return &gpb.Empty{}, nil
 }
@@ -56,3 +56,12 @@ func (m *VCMock) CleanupContainer(ctx context.Context, sandboxID, containerID st
 	}
 	return fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), m, sandboxID)
 }
+
+// AdoptSandbox implements the VC function of the same name.
+func (m *VCMock) AdoptSandbox(ctx context.Context, sandboxID string) (vc.VCSandbox, error) {
+	if m.AdoptSandboxFunc != nil {
+		return m.AdoptSandboxFunc(ctx, sandboxID)
+	}
+
+	return nil, fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), m, sandboxID)
+}
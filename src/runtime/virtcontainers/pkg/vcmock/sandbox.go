@@ -13,6 +13,7 @@ import (
 
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/api"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/multipath"
 	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
 	pbTypes "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
@@ -146,6 +147,22 @@ func (s *Sandbox) ResumeContainer(ctx context.Context, contID string) error {
 	return nil
 }
 
+// CheckpointContainer implements the VCSandbox function of the same name.
+func (s *Sandbox) CheckpointContainer(ctx context.Context, contID, imageDir string, opts vc.CheckpointOptions) error {
+	if s.CheckpointContainerFunc != nil {
+		return s.CheckpointContainerFunc(contID, imageDir, opts)
+	}
+	return nil
+}
+
+// RestoreContainer implements the VCSandbox function of the same name.
+func (s *Sandbox) RestoreContainer(ctx context.Context, contID, imageDir string) (vc.VCContainer, error) {
+	if s.RestoreContainerFunc != nil {
+		return s.RestoreContainerFunc(contID, imageDir)
+	}
+	return &Container{}, nil
+}
+
 // Status implements the VCSandbox function of the same name.
 func (s *Sandbox) Status() vc.SandboxStatus {
 	return vc.SandboxStatus{}
@@ -203,6 +220,9 @@ func (s *Sandbox) RemoveInterface(ctx context.Context, inf *pbTypes.Interface) (
 
 // ListInterfaces implements the VCSandbox function of the same name.
 func (s *Sandbox) ListInterfaces(ctx context.Context) ([]*pbTypes.Interface, error) {
+	if s.ListInterfacesFunc != nil {
+		return s.ListInterfacesFunc()
+	}
 	return nil, nil
 }
 
@@ -251,6 +271,41 @@ func (s *Sandbox) GetAgentURL() (string, error) {
 	return "", nil
 }
 
+func (s *Sandbox) GetSeccompReport() ([]vc.SeccompViolation, error) {
+	if s.GetSeccompReportFunc != nil {
+		return s.GetSeccompReportFunc()
+	}
+	return nil, nil
+}
+
+func (s *Sandbox) SeccompViolations() <-chan vc.SeccompViolation {
+	if s.SeccompViolationsFunc != nil {
+		return s.SeccompViolationsFunc()
+	}
+	return nil
+}
+
+func (s *Sandbox) MultipathEvents() <-chan multipath.Event {
+	if s.MultipathEventsFunc != nil {
+		return s.MultipathEventsFunc()
+	}
+	return nil
+}
+
+func (s *Sandbox) CanRestart() bool {
+	if s.CanRestartFunc != nil {
+		return s.CanRestartFunc()
+	}
+	return false
+}
+
+func (s *Sandbox) Restart(ctx context.Context) error {
+	if s.RestartFunc != nil {
+		return s.RestartFunc(ctx)
+	}
+	return nil
+}
+
 func (s *Sandbox) GetHypervisorPid() (int, error) {
 	return 0, nil
 }
@@ -258,10 +313,73 @@ func (s *Sandbox) GetHypervisorPid() (int, error) {
 func (s *Sandbox) GuestVolumeStats(ctx context.Context, path string) ([]byte, error) {
 	return nil, nil
 }
+func (s *Sandbox) ContainerVolumeStats(ctx context.Context, containerID string) ([]vc.ContainerVolumeStats, error) {
+	if s.ContainerVolumeStatsFunc != nil {
+		return s.ContainerVolumeStatsFunc(containerID)
+	}
+	return nil, nil
+}
+
 func (s *Sandbox) ResizeGuestVolume(ctx context.Context, path string, size uint64) error {
 	return nil
 }
 
+// Quiesce implements the VCSandbox function of the same name.
+func (s *Sandbox) Quiesce(ctx context.Context) error {
+	if s.QuiesceFunc != nil {
+		return s.QuiesceFunc()
+	}
+	return nil
+}
+
+// Unquiesce implements the VCSandbox function of the same name.
+func (s *Sandbox) Unquiesce(ctx context.Context) error {
+	if s.UnquiesceFunc != nil {
+		return s.UnquiesceFunc()
+	}
+	return nil
+}
+
+// GuestServices implements the VCSandbox function of the same name.
+func (s *Sandbox) GuestServices(ctx context.Context) ([]vc.GuestServiceStatus, error) {
+	if s.GuestServicesFunc != nil {
+		return s.GuestServicesFunc()
+	}
+	return nil, nil
+}
+
+// SetNetworkPolicy implements the VCSandbox function of the same name.
+func (s *Sandbox) SetNetworkPolicy(ctx context.Context, policy *vc.NetworkPolicy) error {
+	if s.SetNetworkPolicyFunc != nil {
+		return s.SetNetworkPolicyFunc(policy)
+	}
+	return nil
+}
+
+// CPUMitigations implements the VCSandbox function of the same name.
+func (s *Sandbox) CPUMitigations() vc.CPUMitigations {
+	if s.CPUMitigationsFunc != nil {
+		return s.CPUMitigationsFunc()
+	}
+	return vc.CPUMitigations{}
+}
+
+// GetSeccompNotification implements the VCSandbox function of the same name.
+func (s *Sandbox) GetSeccompNotification(ctx context.Context) (*vc.SeccompNotification, error) {
+	if s.GetSeccompNotificationFunc != nil {
+		return s.GetSeccompNotificationFunc()
+	}
+	return nil, fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), s, s.MockID)
+}
+
+// RespondSeccompNotification implements the VCSandbox function of the same name.
+func (s *Sandbox) RespondSeccompNotification(ctx context.Context, resp vc.SeccompNotificationResponse) error {
+	if s.RespondSeccompNotificationFunc != nil {
+		return s.RespondSeccompNotificationFunc(resp)
+	}
+	return nil
+}
+
 func (s *Sandbox) GetIPTables(ctx context.Context, isIPv6 bool) ([]byte, error) {
 	return nil, nil
 }
@@ -12,6 +12,7 @@ import (
 
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/api"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/multipath"
 	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
 	pbTypes "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
@@ -29,45 +30,60 @@ type Sandbox struct {
 	MockNetNs       string
 
 	// functions for mocks
-	AnnotationsFunc          func(key string) (string, error)
-	SetAnnotationsFunc       func(annotations map[string]string) error
-	GetAnnotationsFunc       func() map[string]string
-	GetNetNsFunc             func() string
-	GetAllContainersFunc     func() []vc.VCContainer
-	GetContainerFunc         func(containerID string) vc.VCContainer
-	ReleaseFunc              func() error
-	StartFunc                func() error
-	StopFunc                 func(force bool) error
-	PauseFunc                func() error
-	ResumeFunc               func() error
-	DeleteFunc               func() error
-	CreateContainerFunc      func(conf vc.ContainerConfig) (vc.VCContainer, error)
-	DeleteContainerFunc      func(contID string) (vc.VCContainer, error)
-	StartContainerFunc       func(contID string) (vc.VCContainer, error)
-	StopContainerFunc        func(contID string, force bool) (vc.VCContainer, error)
-	KillContainerFunc        func(contID string, signal syscall.Signal, all bool) error
-	StatusContainerFunc      func(contID string) (vc.ContainerStatus, error)
-	StatsContainerFunc       func(contID string) (vc.ContainerStats, error)
-	PauseContainerFunc       func(contID string) error
-	ResumeContainerFunc      func(contID string) error
-	StatusFunc               func() vc.SandboxStatus
-	EnterContainerFunc       func(containerID string, cmd types.Cmd) (vc.VCContainer, *vc.Process, error)
-	MonitorFunc              func() (chan error, error)
-	UpdateContainerFunc      func(containerID string, resources specs.LinuxResources) error
-	WaitProcessFunc          func(containerID, processID string) (int32, error)
-	SignalProcessFunc        func(containerID, processID string, signal syscall.Signal, all bool) error
-	WinsizeProcessFunc       func(containerID, processID string, height, width uint32) error
-	IOStreamFunc             func(containerID, processID string) (io.WriteCloser, io.Reader, io.Reader, error)
-	AddDeviceFunc            func(info config.DeviceInfo) (api.Device, error)
-	AddInterfaceFunc         func(inf *pbTypes.Interface) (*pbTypes.Interface, error)
-	RemoveInterfaceFunc      func(inf *pbTypes.Interface) (*pbTypes.Interface, error)
-	ListInterfacesFunc       func() ([]*pbTypes.Interface, error)
-	UpdateRoutesFunc         func(routes []*pbTypes.Route) ([]*pbTypes.Route, error)
-	ListRoutesFunc           func() ([]*pbTypes.Route, error)
-	UpdateRuntimeMetricsFunc func() error
-	GetAgentMetricsFunc      func() (string, error)
-	StatsFunc                func() (vc.SandboxStats, error)
-	GetAgentURLFunc          func() (string, error)
+	AnnotationsFunc                func(key string) (string, error)
+	SetAnnotationsFunc             func(annotations map[string]string) error
+	GetAnnotationsFunc             func() map[string]string
+	GetNetNsFunc                   func() string
+	GetAllContainersFunc           func() []vc.VCContainer
+	GetContainerFunc               func(containerID string) vc.VCContainer
+	ReleaseFunc                    func() error
+	StartFunc                      func() error
+	StopFunc                       func(force bool) error
+	PauseFunc                      func() error
+	ResumeFunc                     func() error
+	DeleteFunc                     func() error
+	CreateContainerFunc            func(conf vc.ContainerConfig) (vc.VCContainer, error)
+	DeleteContainerFunc            func(contID string) (vc.VCContainer, error)
+	StartContainerFunc             func(contID string) (vc.VCContainer, error)
+	StopContainerFunc              func(contID string, force bool) (vc.VCContainer, error)
+	KillContainerFunc              func(contID string, signal syscall.Signal, all bool) error
+	StatusContainerFunc            func(contID string) (vc.ContainerStatus, error)
+	StatsContainerFunc             func(contID string) (vc.ContainerStats, error)
+	PauseContainerFunc             func(contID string) error
+	ResumeContainerFunc            func(contID string) error
+	CheckpointContainerFunc        func(contID, imageDir string, opts vc.CheckpointOptions) error
+	RestoreContainerFunc           func(contID, imageDir string) (vc.VCContainer, error)
+	StatusFunc                     func() vc.SandboxStatus
+	EnterContainerFunc             func(containerID string, cmd types.Cmd) (vc.VCContainer, *vc.Process, error)
+	MonitorFunc                    func() (chan error, error)
+	UpdateContainerFunc            func(containerID string, resources specs.LinuxResources) error
+	WaitProcessFunc                func(containerID, processID string) (int32, error)
+	SignalProcessFunc              func(containerID, processID string, signal syscall.Signal, all bool) error
+	WinsizeProcessFunc             func(containerID, processID string, height, width uint32) error
+	IOStreamFunc                   func(containerID, processID string) (io.WriteCloser, io.Reader, io.Reader, error)
+	AddDeviceFunc                  func(info config.DeviceInfo) (api.Device, error)
+	AddInterfaceFunc               func(inf *pbTypes.Interface) (*pbTypes.Interface, error)
+	RemoveInterfaceFunc            func(inf *pbTypes.Interface) (*pbTypes.Interface, error)
+	ListInterfacesFunc             func() ([]*pbTypes.Interface, error)
+	UpdateRoutesFunc               func(routes []*pbTypes.Route) ([]*pbTypes.Route, error)
+	ListRoutesFunc                 func() ([]*pbTypes.Route, error)
+	UpdateRuntimeMetricsFunc       func() error
+	GetAgentMetricsFunc            func() (string, error)
+	StatsFunc                      func() (vc.SandboxStats, error)
+	GetAgentURLFunc                func() (string, error)
+	GetSeccompReportFunc           func() ([]vc.SeccompViolation, error)
+	SeccompViolationsFunc          func() <-chan vc.SeccompViolation
+	MultipathEventsFunc            func() <-chan multipath.Event
+	ContainerVolumeStatsFunc       func(containerID string) ([]vc.ContainerVolumeStats, error)
+	QuiesceFunc                    func() error
+	UnquiesceFunc                  func() error
+	GuestServicesFunc              func() ([]vc.GuestServiceStatus, error)
+	SetNetworkPolicyFunc           func(policy *vc.NetworkPolicy) error
+	GetSeccompNotificationFunc     func() (*vc.SeccompNotification, error)
+	RespondSeccompNotificationFunc func(resp vc.SeccompNotificationResponse) error
+	CPUMitigationsFunc             func() vc.CPUMitigations
+	CanRestartFunc                 func() bool
+	RestartFunc                    func(ctx context.Context) error
 }
 
 // Container is a fake Container type used for testing
@@ -90,4 +106,5 @@ type VCMock struct {
 
 	CreateSandboxFunc    func(ctx context.Context, sandboxConfig vc.SandboxConfig, hookFunc func(context.Context) error) (vc.VCSandbox, error)
 	CleanupContainerFunc func(ctx context.Context, sandboxID, containerID string, force bool) error
+	AdoptSandboxFunc     func(ctx context.Context, sandboxID string) (vc.VCSandbox, error)
 }
@@ -0,0 +1,347 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/cpuset"
+	"github.com/sirupsen/logrus"
+)
+
+// With a placement policy, the sandboxes whose containers do not set a
+// cpuset, i.e. whose CPUs were not allocated by the kubelet CPU manager, are
+// confined to a host domain, a NUMA node or the CPUs sharing an L3 cache.
+// The sandboxes placed on each domain are tracked in a file shared by the
+// runtimes of the node.
+
+const (
+	// PlacementSpread places the sandboxes on the least loaded domain.
+	PlacementSpread = "spread"
+
+	// PlacementPack places the sandboxes on the most loaded domain they
+	// still fit on.
+	PlacementPack = "pack"
+
+	// PlacementDomainNUMA places the sandboxes on NUMA nodes.
+	PlacementDomainNUMA = "numa"
+
+	// PlacementDomainL3 places the sandboxes on the CPUs sharing an L3 cache.
+	PlacementDomainL3 = "l3"
+
+	placementTrackerFile = "placement.json"
+)
+
+// hostSysfsPath is the host sysfs mount point.
+var hostSysfsPath = "/sys"
+
+// hostDomain is a set of host CPUs sandboxes are placed on.
+type hostDomain struct {
+	cpus cpuset.CPUSet
+	mems cpuset.CPUSet
+	id   int
+}
+
+// placementEntry is the placement of a sandbox.
+type placementEntry struct {
+	Domain int    `json:"domain"`
+	VCPUs  uint32 `json:"vcpus"`
+}
+
+func readCPUList(path string) (cpuset.CPUSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cpuset.CPUSet{}, err
+	}
+	return cpuset.Parse(strings.TrimSpace(string(data)))
+}
+
+// hostNUMANodes returns the CPUs of the host NUMA nodes.
+func hostNUMANodes() (map[int]cpuset.CPUSet, error) {
+	paths, err := filepath.Glob(filepath.Join(hostSysfsPath, "devices/system/node/node*/cpulist"))
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[int]cpuset.CPUSet)
+	for _, path := range paths {
+		id, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(filepath.Dir(path)), "node"))
+		if err != nil {
+			continue
+		}
+		if nodes[id], err = readCPUList(path); err != nil {
+			return nil, err
+		}
+	}
+
+	return nodes, nil
+}
+
+// hostL3Domains returns the sets of host CPUs sharing an L3 cache.
+func hostL3Domains() ([]cpuset.CPUSet, error) {
+	paths, err := filepath.Glob(filepath.Join(hostSysfsPath, "devices/system/cpu/cpu*/cache/index*/level"))
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var domains []cpuset.CPUSet
+	for _, path := range paths {
+		level, err := os.ReadFile(path)
+		if err != nil || strings.TrimSpace(string(level)) != "3" {
+			continue
+		}
+		cpus, err := readCPUList(filepath.Join(filepath.Dir(path), "shared_cpu_list"))
+		if err != nil {
+			return nil, err
+		}
+		if !seen[cpus.String()] {
+			seen[cpus.String()] = true
+			domains = append(domains, cpus)
+		}
+	}
+
+	sort.Slice(domains, func(i, j int) bool {
+		return domains[i].ToSlice()[0] < domains[j].ToSlice()[0]
+	})
+
+	return domains, nil
+}
+
+// hostDomains returns the host domains of the placement domain type.
+func hostDomains(domainType string) ([]hostDomain, error) {
+	nodes, err := hostNUMANodes()
+	if err != nil {
+		return nil, err
+	}
+
+	var domains []hostDomain
+	switch domainType {
+	case PlacementDomainNUMA, "":
+		for id, cpus := range nodes {
+			if !cpus.IsEmpty() {
+				domains = append(domains, hostDomain{id: id, cpus: cpus, mems: cpuset.NewCPUSet(id)})
+			}
+		}
+		sort.Slice(domains, func(i, j int) bool { return domains[i].id < domains[j].id })
+	case PlacementDomainL3:
+		caches, err := hostL3Domains()
+		if err != nil {
+			return nil, err
+		}
+		for id, cpus := range caches {
+			domain := hostDomain{id: id, cpus: cpus, mems: cpuset.NewCPUSet()}
+			for node, nodeCPUs := range nodes {
+				if !cpus.Intersection(nodeCPUs).IsEmpty() {
+					domain.mems = domain.mems.Union(cpuset.NewCPUSet(node))
+				}
+			}
+			domains = append(domains, domain)
+		}
+	default:
+		return nil, fmt.Errorf("invalid placement domain %q", domainType)
+	}
+
+	return domains, nil
+}
+
+// selectDomain selects the domain of a sandbox with vcpus vCPUs, among the
+// domains on the NUMA nodes hinted if any, given the vCPUs already placed on
+// each domain.
+func selectDomain(policy string, domains []hostDomain, hint cpuset.CPUSet, load map[int]uint32, vcpus uint32) (*hostDomain, error) {
+	var selected *hostDomain
+	for i := range domains {
+		d := &domains[i]
+		if !hint.IsEmpty() && !d.mems.IsSubsetOf(hint) {
+			continue
+		}
+
+		switch policy {
+		case PlacementSpread:
+			if selected == nil || load[d.id] < load[selected.id] {
+				selected = d
+			}
+		case PlacementPack:
+			if load[d.id]+vcpus > uint32(d.cpus.Size()) {
+				continue
+			}
+			if selected == nil || load[d.id] > load[selected.id] {
+				selected = d
+			}
+		default:
+			return nil, fmt.Errorf("invalid placement policy %q", policy)
+		}
+	}
+
+	// Packed sandboxes which fit nowhere are spread.
+	if selected == nil && policy == PlacementPack {
+		return selectDomain(PlacementSpread, domains, hint, load, vcpus)
+	}
+	if selected == nil {
+		return nil, fmt.Errorf("no host domain on the NUMA nodes %s", hint)
+	}
+
+	return selected, nil
+}
+
+// placementTracker tracks the placement of the sandboxes of the node, in a
+// file locked while it is updated.
+type placementTracker struct {
+	// isSandboxLive tells whether the sandbox still exists, the
+	// placements of the other ones are dropped.
+	isSandboxLive func(id string) bool
+	path          string
+}
+
+// updateLockedJSON decodes the JSON file at path into v, calls fn and
+// writes v back, the file being locked meanwhile.
+func updateLockedJSON(path string, v interface{}, fn func() error) error {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		return err
+	}
+	defer syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+
+	if err := json.NewDecoder(f).Decode(v); err != nil && err != io.EOF {
+		return fmt.Errorf("invalid %s: %v", path, err)
+	}
+
+	if err := fn(); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := f.Truncate(0); err != nil {
+		return err
+	}
+	_, err = f.WriteAt(data, 0)
+	return err
+}
+
+func (t *placementTracker) update(fn func(entries map[string]placementEntry) error) error {
+	entries := make(map[string]placementEntry)
+	return updateLockedJSON(t.path, &entries, func() error {
+		for id := range entries {
+			if !t.isSandboxLive(id) {
+				delete(entries, id)
+			}
+		}
+		return fn(entries)
+	})
+}
+
+// place places the sandbox on a domain, or returns the domain it was placed
+// on if it was already.
+func (t *placementTracker) place(id, policy string, domains []hostDomain, hint cpuset.CPUSet, vcpus uint32) (*hostDomain, error) {
+	var domain *hostDomain
+	err := t.update(func(entries map[string]placementEntry) error {
+		if entry, ok := entries[id]; ok {
+			for i := range domains {
+				if domains[i].id == entry.Domain {
+					domain = &domains[i]
+					return nil
+				}
+			}
+		}
+
+		load := make(map[int]uint32)
+		for _, entry := range entries {
+			load[entry.Domain] += entry.VCPUs
+		}
+
+		var err error
+		if domain, err = selectDomain(policy, domains, hint, load, vcpus); err != nil {
+			return err
+		}
+		entries[id] = placementEntry{Domain: domain.id, VCPUs: vcpus}
+		return nil
+	})
+
+	return domain, err
+}
+
+// release drops the placement of the sandbox.
+func (t *placementTracker) release(id string) error {
+	return t.update(func(entries map[string]placementEntry) error {
+		delete(entries, id)
+		return nil
+	})
+}
+
+func (s *Sandbox) placementTracker() *placementTracker {
+	storagePath := s.store.RunStoragePath()
+	return &placementTracker{
+		path: filepath.Join(filepath.Dir(storagePath), placementTrackerFile),
+		isSandboxLive: func(id string) bool {
+			_, err := os.Stat(filepath.Join(storagePath, id))
+			return id == s.id || err == nil
+		},
+	}
+}
+
+// place places the sandbox on a host domain, unless its containers set
+// their cpuset.
+func (s *Sandbox) place() error {
+	if s.config.Placement == "" {
+		return nil
+	}
+
+	cpus, _, err := s.getSandboxCPUSet()
+	if err != nil || cpus != "" {
+		return err
+	}
+
+	hint, err := cpuset.Parse(s.config.PlacementNUMANodes)
+	if err != nil {
+		return fmt.Errorf("invalid NUMA nodes hint %q: %v", s.config.PlacementNUMANodes, err)
+	}
+
+	domains, err := hostDomains(s.config.PlacementDomain)
+	if err != nil {
+		return err
+	}
+	if len(domains) == 0 {
+		s.Logger().Warn("no host domain to place the sandbox on")
+		return nil
+	}
+
+	if s.placement, err = s.placementTracker().place(s.id, s.config.Placement, domains, hint,
+		s.config.HypervisorConfig.NumVCPUs); err != nil {
+		return err
+	}
+
+	s.Logger().WithFields(logrus.Fields{
+		"cpus": s.placement.cpus.String(),
+		"mems": s.placement.mems.String(),
+	}).Info("sandbox placed on a host domain")
+
+	return nil
+}
+
+// releasePlacement releases the host domain of the sandbox.
+func (s *Sandbox) releasePlacement() error {
+	if s.placement == nil {
+		return nil
+	}
+
+	return s.placementTracker().release(s.id)
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/cpuset"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeHostTopology lays out a host with two NUMA nodes of 4 CPUs, each
+// with two L3 caches shared by 2 CPUs.
+func writeHostTopology(t *testing.T) {
+	sysfs := t.TempDir()
+	write := func(path, content string) {
+		path = filepath.Join(sysfs, path)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, os.WriteFile(path, []byte(content+"\n"), 0644))
+	}
+
+	write("devices/system/node/node0/cpulist", "0-3")
+	write("devices/system/node/node1/cpulist", "4-7")
+	for cpu, shared := range []string{"0-1", "0-1", "2-3", "2-3", "4-5", "4-5", "6-7", "6-7"} {
+		dir := filepath.Join("devices/system/cpu", "cpu"+string(rune('0'+cpu)), "cache")
+		write(filepath.Join(dir, "index0/level"), "1")
+		write(filepath.Join(dir, "index0/shared_cpu_list"), string(rune('0'+cpu)))
+		write(filepath.Join(dir, "index3/level"), "3")
+		write(filepath.Join(dir, "index3/shared_cpu_list"), shared)
+	}
+
+	savedSysfsPath := hostSysfsPath
+	hostSysfsPath = sysfs
+	t.Cleanup(func() { hostSysfsPath = savedSysfsPath })
+}
+
+func TestHostDomains(t *testing.T) {
+	assert := assert.New(t)
+	writeHostTopology(t)
+
+	domains, err := hostDomains(PlacementDomainNUMA)
+	assert.NoError(err)
+	assert.Len(domains, 2)
+	assert.Equal("4-7", domains[1].cpus.String())
+	assert.Equal("1", domains[1].mems.String())
+
+	domains, err = hostDomains(PlacementDomainL3)
+	assert.NoError(err)
+	assert.Len(domains, 4)
+	assert.Equal("2-3", domains[1].cpus.String())
+	assert.Equal("0", domains[1].mems.String())
+	assert.Equal("6-7", domains[3].cpus.String())
+	assert.Equal("1", domains[3].mems.String())
+
+	_, err = hostDomains("socket")
+	assert.Error(err)
+}
+
+func TestPlacementTracker(t *testing.T) {
+	assert := assert.New(t)
+	writeHostTopology(t)
+
+	domains, err := hostDomains(PlacementDomainNUMA)
+	assert.NoError(err)
+
+	live := map[string]bool{}
+	tracker := &placementTracker{
+		path:          filepath.Join(t.TempDir(), placementTrackerFile),
+		isSandboxLive: func(id string) bool { return live[id] },
+	}
+	place := func(id, policy, hint string, vcpus uint32) int {
+		live[id] = true
+		hintSet, err := cpuset.Parse(hint)
+		assert.NoError(err)
+		domain, err := tracker.place(id, policy, domains, hintSet, vcpus)
+		assert.NoError(err)
+		return domain.id
+	}
+
+	// spread sandboxes go to the least loaded domain
+	assert.Equal(0, place("a", PlacementSpread, "", 2))
+	assert.Equal(1, place("b", PlacementSpread, "", 1))
+	assert.Equal(1, place("c", PlacementSpread, "", 1))
+	assert.Equal(0, place("d", PlacementSpread, "", 1))
+
+	// placed sandboxes keep their domain
+	assert.Equal(0, place("a", PlacementSpread, "", 2))
+
+	// packed sandboxes go to the most loaded domain they fit on
+	assert.Equal(0, place("e", PlacementPack, "", 1))
+	assert.Equal(1, place("f", PlacementPack, "", 2))
+	assert.Equal(0, place("g", PlacementPack, "", 8))
+
+	// the hint restricts the domains
+	assert.Equal(1, place("h", PlacementSpread, "1", 1))
+
+	// released and dead sandboxes do not count anymore
+	assert.NoError(tracker.release("a"))
+	live["g"] = false
+	assert.Equal(0, place("i", PlacementSpread, "", 1))
+
+	_, err = tracker.place("j", PlacementSpread, domains, cpuset.NewCPUSet(2), 1)
+	assert.Error(err)
+}
@@ -15,6 +15,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"math/bits"
 	"net"
 	"os"
 	"os/exec"
@@ -86,6 +87,8 @@ type QemuState struct {
 	HotpluggedVCPUs      []hv.CPUDevice
 	HotpluggedMemory     int
 	VirtiofsDaemonPid    int
+	VirtioGPUDaemonPid   int
+	SwtpmPid             int
 	HotplugVFIOOnRootBus bool
 	HotplugVFIO          config.PCIePort
 	ColdPlugVFIO         config.PCIePort
@@ -98,6 +101,10 @@ type qemu struct {
 
 	virtiofsDaemon VirtiofsDaemon
 
+	vhostUserGPU *vhostUserGPU
+
+	swtpm *swtpm
+
 	ctx context.Context
 
 	// fds is a list of file descriptors inherited by QEMU process
@@ -119,17 +126,31 @@ type qemu struct {
 
 	nvdimmCount int
 
+	// gpuDirectCliques maps the host PCIe switches of the GPUs to their
+	// GPUDirect peer-to-peer clique in the guest
+	gpuDirectCliques map[string]int
+
+	// spaprPHBs places the VFIO devices on the extra PCI host bridges of a
+	// pseries VM
+	spaprPHBs *spaprPHBAllocator
+
+	// pciTopology is the PCI hierarchy of the VM, from which the PCI
+	// paths of the hotplugged devices are predicted
+	pciTopology *types.PCITopology
+
 	stopped int32
 
 	mu sync.Mutex
 }
 
 const (
-	consoleSocket = "console.sock"
-	qmpSocket     = "qmp.sock"
-	hmpSocket     = "hmp.sock"
-	vhostFSSocket = "vhost-fs.sock"
-	nydusdAPISock = "nydusd-api.sock"
+	consoleSocket  = "console.sock"
+	qmpSocket      = "qmp.sock"
+	hmpSocket      = "hmp.sock"
+	vhostFSSocket  = "vhost-fs.sock"
+	vhostGPUSocket = "vhost-gpu.sock"
+	swtpmSocket    = "swtpm.sock"
+	nydusdAPISock  = "nydusd-api.sock"
 
 	// memory dump format will be set to elf
 	memoryDumpFormat = "elf"
@@ -139,6 +160,11 @@ const (
 
 	scsiControllerID         = "scsi0"
 	rngID                    = "rng0"
+	balloonID                = "balloon0"
+	xhciID                   = "xhci0"
+	virtioGPUID              = "gpu0"
+	vtpmID                   = "vtpm0"
+	igvmID                   = "igvm0"
 	fallbackFileBackedMemDir = "/dev/shm"
 
 	qemuStopSandboxTimeoutSecs = 15
@@ -250,6 +276,10 @@ func (q *qemu) setup(ctx context.Context, id string, hypervisorConfig *Hyperviso
 		return err
 	}
 
+	if q.config.MemoryTHP == MemoryTHPMadvise && !thpMadviseSupported() {
+		return fmt.Errorf("The %q transparent huge page policy is not supported by the host kernel, which lacks PR_THP_DISABLE_EXCEPT_ADVISED", MemoryTHPMadvise)
+	}
+
 	q.id = id
 
 	var err error
@@ -284,6 +314,9 @@ func (q *qemu) setup(ctx context.Context, id string, hypervisorConfig *Hyperviso
 	if create {
 		q.Logger().Debug("Creating bridges")
 		q.arch.bridges(q.config.DefaultBridges)
+		if err = q.createSpaprPHBs(); err != nil {
+			return err
+		}
 
 		q.Logger().Debug("Creating UUID")
 		q.state.UUID = uuid.Generate().String()
@@ -326,7 +359,8 @@ func (q *qemu) cpuTopology() govmmQemu.SMP {
 }
 
 func (q *qemu) memoryTopology() (govmmQemu.Memory, error) {
-	hostMemMb := q.config.DefaultMaxMemorySize
+	// The NVDIMMs of the pmem volumes are mapped above the memory.
+	hostMemMb := q.config.DefaultMaxMemorySize + uint64(q.config.PmemVolumesSize)
 	memMb := uint64(q.config.MemorySize)
 
 	return q.arch.memoryTopology(memMb, hostMemMb, uint8(q.config.MemSlots)), nil
@@ -351,6 +385,14 @@ func (q *qemu) getQemuMachine() (govmmQemu.Machine, error) {
 		machine.Options += accelerators
 	}
 
+	// The volumes on persistent memory are hotplugged as NVDIMMs.
+	if q.config.PmemVolumesSize > 0 && !strings.Contains(machine.Options, qemuNvdimmOption) {
+		if machine.Options != "" {
+			machine.Options += ","
+		}
+		machine.Options += qemuNvdimmOption
+	}
+
 	return machine, nil
 }
 
@@ -443,6 +485,29 @@ func (q *qemu) buildDevices(ctx context.Context, initrdPath string) ([]govmmQemu
 		devices, _ = q.arch.appendPVPanicDevice(devices)
 	}
 
+	if q.config.HostSharedMemory != "" || q.config.ShmChannelSocket != "" {
+		// The memory of a confidential guest is private to it, and the
+		// microvm machine type has no PCI bus.
+		if q.config.ConfidentialGuest || q.config.HypervisorMachineType == QemuMicrovm {
+			return nil, nil, fmt.Errorf("shared memory is not supported by confidential guests and the microvm machine type")
+		}
+	}
+
+	if q.config.HostSharedMemory != "" {
+		devices, err = q.arch.appendHostSharedMemory(devices, q.config.HostSharedMemory)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if q.config.ShmChannelSocket != "" {
+		devices = append(devices, govmmQemu.IvshmemDoorbellDevice{
+			ID:         "shmch0",
+			SocketPath: q.config.ShmChannelSocket,
+			Vectors:    shmChannelVectors,
+		})
+	}
+
 	var ioThread *govmmQemu.IOThread
 	if q.config.BlockDeviceDriver == config.VirtioSCSI {
 		return q.arch.appendSCSIController(ctx, devices, q.config.EnableIOThreads)
@@ -531,6 +596,7 @@ func (q *qemu) createVirtiofsDaemon(sharedPath string) (VirtiofsDaemon, error) {
 		socketPath: virtiofsdSocketPath,
 		extraArgs:  q.config.VirtioFSExtraArgs,
 		cache:      q.config.VirtioFSCache,
+		submounts:  q.config.VirtioFSSubmounts,
 	}, nil
 }
 
@@ -566,6 +632,7 @@ func (q *qemu) CreateVM(ctx context.Context, id string, network Network, hypervi
 		Daemonize:     false,
 		MemPrealloc:   q.config.MemPrealloc,
 		HugePages:     q.config.HugePages,
+		MemNoMerge:    !q.config.MemoryMergeable(),
 		IOMMUPlatform: q.config.IOMMUPlatform,
 	}
 
@@ -607,6 +674,23 @@ func (q *qemu) CreateVM(ctx context.Context, id string, network Network, hypervi
 		knobs.MemShared = true
 	}
 
+	// virtio-gpu blob resources map guest memory into the host GPU, which
+	// requires it to be backed by a shared memfd.
+	if q.config.VirtioGPU != "" {
+		if q.config.BootToBeTemplate || q.config.BootFromTemplate {
+			return errors.New("VM templating has been enabled with virtio-gpu and this configuration will not work")
+		}
+		if machine.Type == QemuCCWVirtio {
+			return fmt.Errorf("virtio-gpu is not supported by machine type %s", machine.Type)
+		}
+		if !q.config.HugePages {
+			knobs.MemFD = true
+		}
+		knobs.MemShared = true
+		// The device renders through EGL, which needs a display.
+		knobs.NoGraphic = false
+	}
+
 	rtc := govmmQemu.RTC{
 		Base:     govmmQemu.UTC,
 		Clock:    govmmQemu.Host,
@@ -640,6 +724,31 @@ func (q *qemu) CreateVM(ctx context.Context, id string, network Network, hypervi
 		return err
 	}
 
+	if err := checkFirmwareProtection(firmwarePath, q.arch.getProtection()); err != nil {
+		return err
+	}
+
+	// QEMU loads the IGVM files from an igvm-cfg object, the firmware
+	// they bundle then replaces the BIOS.
+	var igvm bool
+	if firmwarePath != "" {
+		if igvm, err = isIGVMFirmware(firmwarePath); err != nil {
+			return err
+		}
+	}
+	if igvm {
+		devices = append(devices, govmmQemu.Object{
+			Type: govmmQemu.IGVMCfg,
+			ID:   igvmID,
+			File: firmwarePath,
+		})
+		if machine.Options != "" {
+			machine.Options += ","
+		}
+		machine.Options += "igvm-cfg=" + igvmID
+		firmwarePath = ""
+	}
+
 	pflash, err := q.arch.getPFlash()
 	if err != nil {
 		return err
@@ -685,6 +794,36 @@ func (q *qemu) CreateVM(ctx context.Context, id string, network Network, hypervi
 		PidFile:        filepath.Join(q.config.VMStorePath, q.id, "pid"),
 	}
 
+	if q.config.EnableVTPM {
+		if q.config.BootToBeTemplate || q.config.BootFromTemplate {
+			return errors.New("VM templating has been enabled with the vTPM and this configuration will not work")
+		}
+		driver, err := vtpmDriver(machine.Type)
+		if err != nil {
+			return err
+		}
+		if q.swtpm, err = q.createSwtpm(); err != nil {
+			return err
+		}
+		ekCerts, err := q.swtpm.Setup(ctx)
+		if err != nil {
+			return err
+		}
+		// Bind the EK of the vTPM to the evidence of a confidential guest,
+		// for the verifier to trust the quotes of the vTPM.
+		if q.config.ConfidentialGuest {
+			q.arch.bindVTPM(ekCerts)
+		}
+		qemuConfig.Devices = append(qemuConfig.Devices,
+			govmmQemu.TPMDevice{
+				ID:         vtpmID,
+				CharDevID:  "char-" + vtpmID,
+				SocketPath: q.swtpm.socketPath,
+				Driver:     driver,
+			},
+		)
+	}
+
 	qemuConfig.Devices, qemuConfig.Bios, err = q.arch.appendProtectionDevice(qemuConfig.Devices, firmwarePath, firmwareVolumePath)
 	if err != nil {
 		return err
@@ -693,12 +832,33 @@ func (q *qemu) CreateVM(ctx context.Context, id string, network Network, hypervi
 	if ioThread != nil {
 		qemuConfig.IOThreads = []govmmQemu.IOThread{*ioThread}
 	}
+
+	if mode := PCIHotplugMode(&q.config); mode != "" {
+		// QEMU 6.1 switched the q35 machine to ACPI hotplug, set
+		// the mode explicitly so that it does not depend on the
+		// QEMU version.
+		acpiHotplug := "on"
+		if mode == PCIHotplugNative {
+			acpiHotplug = "off"
+		}
+		qemuConfig.GlobalParams = append(qemuConfig.GlobalParams, "ICH9-LPC.acpi-pci-hotplug-with-bridge-support="+acpiHotplug)
+		q.Logger().WithField("pci-hotplug", mode).Info("PCI hotplug mode selected")
+	}
+
+	if len(q.config.ExtraArgs) != 0 {
+		q.Logger().WithField("extra-args", q.config.ExtraArgs).Warn("appending extra arguments to the qemu command line")
+		qemuConfig.ExtraParams = q.config.ExtraArgs
+	}
+
 	// Add RNG device to hypervisor
 	// Skip for s390x as CPACF is used
 	if machine.Type != QemuCCWVirtio {
 		rngDev := config.RNGDev{
 			ID:       rngID,
 			Filename: q.config.EntropySource,
+			Builtin:  q.config.EntropyBackend == EntropyBackendBuiltin,
+			MaxBytes: q.config.EntropyMaxBytes,
+			Period:   q.config.EntropyPeriod,
 		}
 		qemuConfig.Devices, err = q.arch.appendRNGDevice(ctx, qemuConfig.Devices, rngDev)
 		if err != nil {
@@ -706,12 +866,60 @@ func (q *qemu) CreateVM(ctx context.Context, id string, network Network, hypervi
 		}
 	}
 
+	// Add a balloon device so that memory freed by the guest is
+	// reported and released back to the host.
+	if q.hasBalloon() {
+		qemuConfig.Devices = append(qemuConfig.Devices,
+			govmmQemu.BalloonDevice{
+				ID:                balloonID,
+				DeflateOnOOM:      true,
+				FreePageReporting: true,
+			},
+		)
+	}
+
+	if q.config.VirtioGPU != "" && q.config.VirtioGPUDaemon != "" {
+		// The backend renders, QEMU only needs to reconnect to it when
+		// it is restarted.
+		if q.vhostUserGPU, err = q.createVhostUserGPU(); err != nil {
+			return err
+		}
+		qemuConfig.Devices = append(qemuConfig.Devices,
+			govmmQemu.VhostUserDevice{
+				SocketPath:    q.vhostUserGPU.socketPath,
+				CharDevID:     "char-" + virtioGPUID,
+				TypeDevID:     virtioGPUID,
+				Reconnect:     1,
+				VhostUserType: govmmQemu.VhostUserGPU,
+			},
+		)
+	} else if q.config.VirtioGPU != "" {
+		qemuConfig.Display = "egl-headless"
+		qemuConfig.Devices = append(qemuConfig.Devices,
+			govmmQemu.VirtioGPUDevice{
+				ID:               virtioGPUID,
+				HostMem:          fmt.Sprintf("%dM", q.config.VirtioGPUHostMemMB),
+				Venus:            q.config.VirtioGPU == VirtioGPUVenus,
+				DRMNativeContext: q.config.VirtioGPU == VirtioGPUDRM,
+			},
+		)
+	}
+
+	if len(q.config.USBDevices) != 0 {
+		if qemuConfig.Devices, err = q.appendUSBDevices(qemuConfig.Devices); err != nil {
+			return err
+		}
+	}
+
 	if machine.Type == QemuQ35 || machine.Type == QemuVirt {
 		if err := q.createPCIeTopology(&qemuConfig, hypervisorConfig, machine.Type); err != nil {
 			q.Logger().WithError(err).Errorf("Cannot create PCIe topology")
 			return err
 		}
 	}
+	if q.pciTopology, err = qemuPCITopology(qemuConfig.Devices); err != nil {
+		return err
+	}
 	q.qemuConfig = qemuConfig
 
 	q.virtiofsDaemon, err = q.createVirtiofsDaemon(hypervisorConfig.SharedPath)
@@ -737,6 +945,75 @@ func (q *qemu) checkBpfEnabled() {
 	}
 }
 
+// acpiPCIHotplugMinKernel is the oldest guest kernel whose acpiphp driver
+// reliably handles the ACPI hotplug on the PCIe root ports of the q35
+// machine. The older ones sporadically miss the hotplug notifications, the
+// devices are then hotplugged natively.
+var acpiPCIHotplugMinKernel = [2]int{5, 10}
+
+var kernelVersionRegex = regexp.MustCompile(`(\d+)\.(\d+)(\.\d+)?`)
+
+// guestKernelVersion returns the major and minor version of the guest kernel,
+// as found in the name of its image, such as vmlinux-6.1.38-114, the default
+// images being links to the versioned ones.
+func guestKernelVersion(kernelPath string) (int, int, bool) {
+	if path, err := filepath.EvalSymlinks(kernelPath); err == nil {
+		kernelPath = path
+	}
+
+	m := kernelVersionRegex.FindStringSubmatch(filepath.Base(kernelPath))
+	if m == nil {
+		return 0, 0, false
+	}
+	major, _ := strconv.Atoi(m[1])
+	minor, _ := strconv.Atoi(m[2])
+	return major, minor, true
+}
+
+// PCIHotplugMode returns how the PCI devices are hotplugged on the q35
+// machine, the configured mode or, by default, the one suiting the guest
+// kernel. It is empty for the other machines, whose hotplug mode is fixed.
+func PCIHotplugMode(conf *HypervisorConfig) string {
+	if conf.HypervisorMachineType != QemuQ35 {
+		return ""
+	}
+
+	switch conf.PCIHotplugMode {
+	case PCIHotplugACPI, PCIHotplugNative:
+		return conf.PCIHotplugMode
+	}
+
+	major, minor, ok := guestKernelVersion(conf.KernelPath)
+	if ok && (major < acpiPCIHotplugMinKernel[0] || (major == acpiPCIHotplugMinKernel[0] && minor < acpiPCIHotplugMinKernel[1])) {
+		return PCIHotplugNative
+	}
+	return PCIHotplugACPI
+}
+
+// defaultPCIMMIO64WindowSize is the default size of the 64-bit PCI hole of
+// the q35 machine.
+const defaultPCIMMIO64WindowSize = 32 << 30
+
+// roundUpPowerOf2 rounds n up to the next power of 2.
+func roundUpPowerOf2(n uint64) uint64 {
+	if n == 0 {
+		return 0
+	}
+	return 1 << bits.Len64(n-1)
+}
+
+// pciMMIO64WindowSize returns the size of the 64-bit PCI MMIO window fitting
+// the 64-bit BARs of the passthrough devices, and the prefetchable windows
+// reserved by the pluggable ports for the devices hotplugged later, rounded
+// up to a power of 2 for the windows to be naturally aligned.
+func pciMMIO64WindowSize(devicesMemSize64bit uint64, numOfPorts uint32, portMemSize64bit uint64) uint64 {
+	size := uint64(numOfPorts) * portMemSize64bit
+	if devicesMemSize64bit > size {
+		size = devicesMemSize64bit
+	}
+	return roundUpPowerOf2(size)
+}
+
 // If a user uses 8 GPUs with 4 devices in each IOMMU Group that means we need
 // to hotplug 32 devices. We do not have enough PCIe root bus slots to
 // accomplish this task. Kata will use already some slots for vfio-xxxx-pci
@@ -761,21 +1038,11 @@ func (q *qemu) createPCIeTopology(qemuConfig *govmmQemu.Config, hypervisorConfig
 	// Deduce the right values for mem-reserve and pref-64-reserve memory regions
 	memSize32bit, memSize64bit := q.arch.getBARsMaxAddressableMemory()
 
-	// The default OVMF MMIO aperture is too small for some PCIe devices
-	// with huge BARs so we need to increase it.
-	// memSize64bit is in bytes, convert to MB, OVMF expects MB as a string
-	if strings.Contains(strings.ToLower(hypervisorConfig.FirmwarePath), "ovmf") {
-		pciMmio64Mb := fmt.Sprintf("%d", (memSize64bit / 1024 / 1024))
-		fwCfg := govmmQemu.FwCfg{
-			Name: "opt/ovmf/X-PciMmio64Mb",
-			Str:  pciMmio64Mb,
-		}
-		qemuConfig.FwCfg = append(qemuConfig.FwCfg, fwCfg)
-	}
-
 	// Get the number of hot(cold)-pluggable ports needed from the provided
-	// VFIO devices and VhostUserBlockDevices
+	// VFIO devices and VhostUserBlockDevices, along with the size of
+	// their 64-bit BARs
 	var numOfPluggablePorts uint32 = 0
+	var devicesMemSize64bit uint64 = 0
 	for _, dev := range hypervisorConfig.VFIODevices {
 		var err error
 		dev.HostPath, err = config.GetHostPath(dev, false, "")
@@ -790,11 +1057,47 @@ func (q *qemu) createPCIeTopology(qemuConfig *govmmQemu.Config, hypervisorConfig
 			if drivers.IsPCIeDevice(vfioDevice.BDF) {
 				numOfPluggablePorts = numOfPluggablePorts + 1
 			}
+			if _, size, err := drivers.GetPCIDeviceBARSizes(vfioDevice.BDF); err == nil {
+				devicesMemSize64bit += roundUpPowerOf2(size)
+			}
+		}
+	}
+
+	// The 64-bit MMIO window of the guest must fit the BARs of the
+	// devices, and the prefetchable windows reserved by the ports.
+	mmio64WindowSize := pciMMIO64WindowSize(devicesMemSize64bit, numOfPluggablePorts, memSize64bit)
+
+	// The default OVMF MMIO aperture is too small for some PCIe devices
+	// with huge BARs so we need to increase it.
+	// The window is in bytes, convert to MB, OVMF expects MB as a string
+	if strings.Contains(strings.ToLower(hypervisorConfig.FirmwarePath), "ovmf") {
+		pciMmio64 := memSize64bit
+		if mmio64WindowSize > pciMmio64 {
+			pciMmio64 = mmio64WindowSize
+		}
+		pciMmio64Mb := fmt.Sprintf("%d", (pciMmio64 / 1024 / 1024))
+		fwCfg := govmmQemu.FwCfg{
+			Name: "opt/ovmf/X-PciMmio64Mb",
+			Str:  pciMmio64Mb,
 		}
+		qemuConfig.FwCfg = append(qemuConfig.FwCfg, fwCfg)
+	}
+
+	// The q35 host bridge hole is only enlarged when the default one is
+	// too small, for SeaBIOS and the guest kernel to fit the BARs without
+	// any kernel parameter.
+	if machineType == QemuQ35 && mmio64WindowSize > defaultPCIMMIO64WindowSize {
+		qemuConfig.GlobalParams = append(qemuConfig.GlobalParams, fmt.Sprintf("q35-pcihost.pci-hole64-size=%d", mmio64WindowSize))
 	}
 	vfioOnRootPort := (q.state.HotPlugVFIO == config.RootPort || q.state.ColdPlugVFIO == config.RootPort || q.state.HotplugVFIOOnRootBus)
 	vfioOnSwitchPort := (q.state.HotPlugVFIO == config.SwitchPort || q.state.ColdPlugVFIO == config.SwitchPort)
 
+	// The devices doing peer-to-peer DMA must share a PCIe switch in the
+	// guest, as they do on the host.
+	if hypervisorConfig.PCIeP2P && (vfioOnRootPort || !vfioOnSwitchPort) {
+		return fmt.Errorf("PCIe peer-to-peer DMA requires the VFIO devices to be plugged on a %s", config.SwitchPort)
+	}
+
 	numOfVhostUserBlockDevices := len(hypervisorConfig.VhostUserBlkDevices)
 
 	// If number of PCIe root ports > 16 then bail out otherwise we may
@@ -836,6 +1139,151 @@ func (q *qemu) vhostFSSocketPath(id string) (string, error) {
 	return utils.BuildSocketPath(q.config.VMStorePath, id, vhostFSSocket)
 }
 
+var (
+	usbVendorProductRegex = regexp.MustCompile(`^([0-9a-fA-F]{4}):([0-9a-fA-F]{4})$`)
+	usbBusPortRegex       = regexp.MustCompile(`^([0-9]+)-([0-9]+(\.[0-9]+)*)$`)
+)
+
+// parseUSBDevice parses a host USB device given as vendor:product or bus-port,
+// the latter following the naming of /sys/bus/usb/devices.
+func parseUSBDevice(dev string) (govmmQemu.USBHostDevice, error) {
+	if m := usbVendorProductRegex.FindStringSubmatch(dev); m != nil {
+		return govmmQemu.USBHostDevice{VendorID: "0x" + m[1], ProductID: "0x" + m[2]}, nil
+	}
+
+	if m := usbBusPortRegex.FindStringSubmatch(dev); m != nil {
+		return govmmQemu.USBHostDevice{HostBus: m[1], HostPort: m[2]}, nil
+	}
+
+	return govmmQemu.USBHostDevice{}, fmt.Errorf("Invalid USB device %q, expected vendor:product or bus-port", dev)
+}
+
+// appendUSBDevices adds an xHCI controller the host USB devices are
+// attached to.
+func (q *qemu) appendUSBDevices(devices []govmmQemu.Device) ([]govmmQemu.Device, error) {
+	devices = append(devices, govmmQemu.XHCIDevice{ID: xhciID})
+
+	for i, dev := range q.config.USBDevices {
+		usb, err := parseUSBDevice(dev)
+		if err != nil {
+			return nil, err
+		}
+		usb.ID = fmt.Sprintf("usb%d", i)
+		usb.Bus = xhciID + ".0"
+
+		devices = append(devices, usb)
+	}
+
+	return devices, nil
+}
+
+// vtpmDriver returns the TPM interface device driver of a machine type.
+func vtpmDriver(machineType string) (govmmQemu.DeviceDriver, error) {
+	switch machineType {
+	case QemuQ35:
+		return govmmQemu.TPMCRB, nil
+	case QemuVirt:
+		return govmmQemu.TPMTISDevice, nil
+	case QemuPseries:
+		return govmmQemu.TPMSpapr, nil
+	default:
+		return "", fmt.Errorf("vTPM is not supported by machine type %s", machineType)
+	}
+}
+
+func (q *qemu) createSwtpm() (*swtpm, error) {
+	socketPath, err := utils.BuildSocketPath(q.config.VMStorePath, q.id, swtpmSocket)
+	if err != nil {
+		return nil, err
+	}
+
+	return &swtpm{
+		path:       q.config.SwtpmPath,
+		setupPath:  filepath.Join(filepath.Dir(q.config.SwtpmPath), swtpmSetup),
+		socketPath: socketPath,
+		statePath:  filepath.Join(q.config.VTPMStatePath, q.id),
+		credential: &syscall.Credential{
+			Uid:    q.config.Uid,
+			Gid:    q.config.Gid,
+			Groups: q.config.Groups,
+		},
+	}, nil
+}
+
+func (q *qemu) setupSwtpm(ctx context.Context) error {
+	pid, err := q.swtpm.Start(ctx)
+	if err != nil {
+		return err
+	}
+	q.state.SwtpmPid = pid
+
+	return nil
+}
+
+func (q *qemu) stopSwtpm(ctx context.Context) error {
+	if q.swtpm == nil {
+		return nil
+	}
+
+	if err := q.swtpm.Stop(ctx); err != nil {
+		return err
+	}
+	q.state.SwtpmPid = 0
+	return nil
+}
+
+func (q *qemu) createVhostUserGPU() (*vhostUserGPU, error) {
+	socketPath, err := utils.BuildSocketPath(q.config.VMStorePath, q.id, vhostGPUSocket)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vhostUserGPU{
+		path:       q.config.VirtioGPUDaemon,
+		socketPath: socketPath,
+		extraArgs:  q.config.VirtioGPUExtraArgs,
+		credential: &syscall.Credential{
+			Uid:    q.config.Uid,
+			Gid:    q.config.Gid,
+			Groups: q.config.Groups,
+		},
+		// The backend is restarted by its supervisor goroutine, the state
+		// is updated under the lock held by StopVM and Save.
+		onRestart: func(pid int) {
+			q.mu.Lock()
+			defer q.mu.Unlock()
+			if atomic.LoadInt32(&q.stopped) != 0 {
+				return
+			}
+			q.state.VirtioGPUDaemonPid = pid
+		},
+	}, nil
+}
+
+func (q *qemu) setupVhostUserGPU(ctx context.Context) (err error) {
+	pid, err := q.vhostUserGPU.Start(ctx, func() {
+		q.StopVM(ctx, false)
+	})
+	if err != nil {
+		return err
+	}
+	q.state.VirtioGPUDaemonPid = pid
+
+	return nil
+}
+
+func (q *qemu) stopVhostUserGPU(ctx context.Context) error {
+	if q.vhostUserGPU == nil {
+		return nil
+	}
+
+	if err := q.vhostUserGPU.Stop(ctx); err != nil {
+		return err
+	}
+	q.state.VirtioGPUDaemonPid = 0
+	return nil
+}
+
 func (q *qemu) nydusdAPISocketPath(id string) (string, error) {
 	return utils.BuildSocketPath(q.config.VMStorePath, id, nydusdAPISock)
 }
@@ -1079,6 +1527,45 @@ func (q *qemu) StartVM(ctx context.Context, timeout int) error {
 
 	}
 
+	if q.vhostUserGPU != nil {
+		if err = q.setupVhostUserGPU(ctx); err != nil {
+			return err
+		}
+		defer func() {
+			if err != nil {
+				if shutdownErr := q.stopVhostUserGPU(ctx); shutdownErr != nil {
+					q.Logger().WithError(shutdownErr).Warn("failed to stop vhost-user-gpu")
+				}
+			}
+		}()
+	}
+
+	if q.swtpm != nil {
+		if err = q.setupSwtpm(ctx); err != nil {
+			return err
+		}
+		defer func() {
+			if err != nil {
+				if shutdownErr := q.stopSwtpm(ctx); shutdownErr != nil {
+					q.Logger().WithError(shutdownErr).Warn("failed to stop swtpm")
+				}
+			}
+		}()
+	}
+
+	// The transparent huge page policy is a process attribute inherited
+	// by children, so apply it only for the time it takes to spawn QEMU.
+	if q.config.MemoryTHP != "" {
+		if err = setTHPPolicy(q.config.MemoryTHP); err != nil {
+			return err
+		}
+		defer func() {
+			if err := setTHPPolicy(MemoryTHPAlways); err != nil {
+				q.Logger().WithError(err).Warn("failed to restore transparent huge page policy")
+			}
+		}()
+	}
+
 	qemuCmd, reader, err := govmmQemu.LaunchQemu(q.qemuConfig, newQMPLogger())
 	if err != nil {
 		q.Logger().WithError(err).Error("failed to launch qemu")
@@ -1218,6 +1705,13 @@ func (q *qemu) StopVM(ctx context.Context, waitOnly bool) (err error) {
 		if err != nil {
 			return err
 		}
+	} else if q.config.ShutdownGracePeriod > 0 && q.powerdown() {
+		// QEMU exits once the guest powered itself off, it is killed
+		// when the grace period is over.
+		err := utils.WaitLocalProcess(pid, uint(q.config.ShutdownGracePeriod), syscall.Signal(0), q.Logger())
+		if err != nil {
+			return err
+		}
 	} else {
 		err = syscall.Kill(pid, syscall.SIGKILL)
 		if err != nil {
@@ -1232,9 +1726,29 @@ func (q *qemu) StopVM(ctx context.Context, waitOnly bool) (err error) {
 		}
 	}
 
+	if err := q.stopVhostUserGPU(ctx); err != nil {
+		return err
+	}
+
+	if err := q.stopSwtpm(ctx); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// powerdown presses the ACPI power button of the VM, for the guest to shut
+// down gracefully. It tells if the guest was asked to.
+func (q *qemu) powerdown() bool {
+	if err := q.qmpMonitorCh.qmp.ExecuteSystemPowerdown(q.qmpMonitorCh.ctx); err != nil {
+		q.Logger().WithError(err).Warn("Failed to power the VM down, killing qemu")
+		return false
+	}
+
+	q.Logger().WithField("grace-period", q.config.ShutdownGracePeriod).Info("Waiting for the guest to shut down")
+	return true
+}
+
 func (q *qemu) cleanupVM() error {
 
 	// Cleanup vm path
@@ -1552,15 +2066,11 @@ func (q *qemu) hotplugAddBlockDevice(ctx context.Context, drive *config.BlockDri
 			}
 		}()
 
-		bridgeSlot, err := types.PciSlotFromInt(bridge.Addr)
-		if err != nil {
-			return err
-		}
 		devSlot, err := types.PciSlotFromString(addr)
 		if err != nil {
 			return err
 		}
-		drive.PCIPath, err = types.PciPathFromSlots(bridgeSlot, devSlot)
+		drive.PCIPath, err = q.predictPciPath(bridge.ID, devSlot)
 		if err != nil {
 			return err
 		}
@@ -1611,20 +2121,29 @@ func (q *qemu) hotplugAddBlockDevice(ctx context.Context, drive *config.BlockDri
 	return nil
 }
 
-func (q *qemu) hotplugAddVhostUserBlkDevice(ctx context.Context, vAttr *config.VhostUserDeviceAttrs, op Operation, devID string) (err error) {
+// hotplugAddVhostBlkDevice hotplugs a vhost-user-blk device, or a vhost-vdpa
+// device served by a vDPA block backend.
+func (q *qemu) hotplugAddVhostBlkDevice(ctx context.Context, vAttr *config.VhostUserDeviceAttrs, op Operation, devID string) (err error) {
 
-	err = q.qmpMonitorCh.qmp.ExecuteCharDevUnixSocketAdd(q.qmpMonitorCh.ctx, vAttr.DevID, vAttr.SocketPath, false, false, vAttr.ReconnectTime)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
+	if vAttr.Type == config.VhostUserBlk {
+		err = q.qmpMonitorCh.qmp.ExecuteCharDevUnixSocketAdd(q.qmpMonitorCh.ctx, vAttr.DevID, vAttr.SocketPath, false, false, vAttr.ReconnectTime)
 		if err != nil {
-			q.qmpMonitorCh.qmp.ExecuteChardevDel(q.qmpMonitorCh.ctx, vAttr.DevID)
+			return err
 		}
-	}()
 
-	driver := "vhost-user-blk-pci"
+		defer func() {
+			if err != nil {
+				q.qmpMonitorCh.qmp.ExecuteChardevDel(q.qmpMonitorCh.ctx, vAttr.DevID)
+			}
+		}()
+	}
+
+	deviceAdd := func(addr, bus string) error {
+		if vAttr.Type == config.VhostVDPABlk {
+			return q.qmpMonitorCh.qmp.ExecutePCIVhostVDPADevAdd(q.qmpMonitorCh.ctx, devID, vAttr.SocketPath, addr, bus)
+		}
+		return q.qmpMonitorCh.qmp.ExecutePCIVhostUserDevAdd(q.qmpMonitorCh.ctx, "vhost-user-blk-pci", devID, vAttr.DevID, addr, bus)
+	}
 
 	machineType := q.HypervisorConfig().HypervisorMachineType
 
@@ -1637,23 +2156,17 @@ func (q *qemu) hotplugAddVhostUserBlkDevice(ctx context.Context, vAttr *config.V
 		bridgeID := fmt.Sprintf("%s%d", config.PCIeRootPortPrefix, len(config.PCIeDevices[config.RootPort]))
 		config.PCIeDevices[config.RootPort][devID] = true
 
-		bridgeQomPath := fmt.Sprintf("%s%s", qomPathPrefix, bridgeID)
-		bridgeSlot, err := q.qomGetSlot(bridgeQomPath)
-		if err != nil {
-			return err
-		}
-
 		devSlot, err := types.PciSlotFromString(addr)
 		if err != nil {
 			return err
 		}
 
-		vAttr.PCIPath, err = types.PciPathFromSlots(bridgeSlot, devSlot)
+		vAttr.PCIPath, err = q.predictPciPath(bridgeID, devSlot)
 		if err != nil {
 			return err
 		}
 
-		if err = q.qmpMonitorCh.qmp.ExecutePCIVhostUserDevAdd(q.qmpMonitorCh.ctx, driver, devID, vAttr.DevID, addr, bridgeID); err != nil {
+		if err = deviceAdd(addr, bridgeID); err != nil {
 			return err
 		}
 
@@ -1668,18 +2181,16 @@ func (q *qemu) hotplugAddVhostUserBlkDevice(ctx context.Context, vAttr *config.V
 			}
 		}()
 
-		bridgeSlot, err := types.PciSlotFromInt(bridge.Addr)
+		devSlot, err := types.PciSlotFromString(addr)
 		if err != nil {
 			return err
 		}
-
-		devSlot, err := types.PciSlotFromString(addr)
+		vAttr.PCIPath, err = q.predictPciPath(bridge.ID, devSlot)
 		if err != nil {
 			return err
 		}
-		vAttr.PCIPath, err = types.PciPathFromSlots(bridgeSlot, devSlot)
 
-		if err = q.qmpMonitorCh.qmp.ExecutePCIVhostUserDevAdd(q.qmpMonitorCh.ctx, driver, devID, vAttr.DevID, addr, bridge.ID); err != nil {
+		if err = deviceAdd(addr, bridge.ID); err != nil {
 			return err
 		}
 	}
@@ -1718,8 +2229,8 @@ func (q *qemu) hotplugVhostUserDevice(ctx context.Context, vAttr *config.VhostUs
 
 	if op == AddDevice {
 		switch vAttr.Type {
-		case config.VhostUserBlk:
-			return q.hotplugAddVhostUserBlkDevice(ctx, vAttr, op, devID)
+		case config.VhostUserBlk, config.VhostVDPABlk:
+			return q.hotplugAddVhostBlkDevice(ctx, vAttr, op, devID)
 		default:
 			return fmt.Errorf("Incorrect vhost-user device type found")
 		}
@@ -1737,10 +2248,85 @@ func (q *qemu) hotplugVhostUserDevice(ctx context.Context, vAttr *config.VhostUs
 			return err
 		}
 
+		// vhost-vdpa devices have no chardev
+		if vAttr.Type == config.VhostVDPABlk {
+			return nil
+		}
+
 		return q.qmpMonitorCh.qmp.ExecuteChardevDel(q.qmpMonitorCh.ctx, vAttr.DevID)
 	}
 }
 
+// qemuPCITopology returns the topology of the bridges and ports of devices,
+// all of them being at a given address.
+func qemuPCITopology(devices []govmmQemu.Device) (*types.PCITopology, error) {
+	topo := types.NewPCITopology()
+
+	add := func(id, bus, addr string) error {
+		if bus == defaultBridgeBus || bus == defaultPCBridgeBus {
+			bus = ""
+		}
+		// QEMU reads the addresses as hexadecimal numbers
+		slot, err := types.PciSlotFromString(strings.TrimPrefix(addr, "0x"))
+		if err != nil {
+			return fmt.Errorf("invalid PCI address %q of %s: %v", addr, id, err)
+		}
+		return topo.AddBus(id, bus, slot)
+	}
+
+	for _, device := range devices {
+		var err error
+		switch d := device.(type) {
+		case govmmQemu.BridgeDevice:
+			err = add(d.ID, d.Bus, d.Addr)
+		case govmmQemu.PCIeRootPortDevice:
+			err = add(d.ID, d.Bus, d.Addr)
+		case govmmQemu.PCIeSwitchUpstreamPortDevice:
+			// The upstream port is the only device of its root port.
+			err = add(d.ID, d.Bus, "0")
+		case govmmQemu.PCIeSwitchDownstreamPortDevice:
+			err = add(d.ID, d.Bus, d.Addr)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return topo, nil
+}
+
+// predictPciPath returns the PCI path the device plugged at slot of the bus
+// of the bridge or port bus will have in the guest. The ports are only known
+// by the runtime which created the VM, QEMU is asked where they are
+// otherwise.
+func (q *qemu) predictPciPath(bus string, slot types.PciSlot) (types.PciPath, error) {
+	if q.pciTopology == nil {
+		q.pciTopology = types.NewPCITopology()
+		for _, b := range q.state.Bridges {
+			if b.Type != types.PCI && b.Type != types.PCIE {
+				continue
+			}
+			bridgeSlot, err := types.PciSlotFromInt(b.Addr)
+			if err != nil {
+				return types.PciPath{}, err
+			}
+			if err := q.pciTopology.AddBus(b.ID, "", bridgeSlot); err != nil {
+				return types.PciPath{}, err
+			}
+		}
+	}
+
+	if q.pciTopology.HasBus(bus) {
+		return q.pciTopology.Path(bus, slot)
+	}
+
+	busPath, err := q.qomGetPciPath(qomPathPrefix + bus)
+	if err != nil {
+		return types.PciPath{}, err
+	}
+	return busPath.Append(slot), nil
+}
+
 // Query QMP to find the PCI slot of a device, given its QOM path or ID
 func (q *qemu) qomGetSlot(qomPath string) (types.PciSlot, error) {
 	addr, err := q.qmpMonitorCh.qmp.ExecQomGet(q.qmpMonitorCh.ctx, qomPath, "addr")
@@ -1858,9 +2444,43 @@ func (q *qemu) executePCIVFIODeviceAdd(device *config.VFIODev, addr string, brid
 	}
 }
 
+// setVFIOGuestPciPath sets the PCI path of a hotplugged VFIO device. The path
+// of a device plugged on a root or switch port is predicted from the topology
+// of the VM, and checked against the one QEMU gave to the device, so that a
+// change of the topology fails the hotplug rather than the agent waiting for
+// the device at the wrong place. The path of the devices plugged on a bridge
+// or the root bus is asked to QEMU.
+func (q *qemu) setVFIOGuestPciPath(device *config.VFIODev) error {
+	actual, err := q.qomGetPciPath(device.ID)
+	if err != nil {
+		return err
+	}
+
+	onPort := q.state.HotPlugVFIO == config.RootPort || q.state.HotPlugVFIO == config.SwitchPort
+	if !onPort || q.state.HotplugVFIOOnRootBus || device.Bus == "" {
+		device.GuestPciPath = actual
+		return nil
+	}
+
+	// The device is the only one of its port.
+	predicted, err := q.predictPciPath(device.Bus, types.PciSlot{})
+	if err != nil {
+		return err
+	}
+	if predicted.String() != actual.String() {
+		return fmt.Errorf("VFIO device %s is at PCI path %s instead of the predicted %s", device.ID, actual, predicted)
+	}
+
+	device.GuestPciPath = predicted
+	return nil
+}
+
 func (q *qemu) executeVFIODeviceAdd(device *config.VFIODev) error {
 	switch device.Type {
 	case config.VFIOPCIDeviceNormalType:
+		if device.GPUDirectClique != "" {
+			return q.qmpMonitorCh.qmp.ExecuteGPUDirectVFIODeviceAdd(q.qmpMonitorCh.ctx, device.ID, device.BDF, device.Bus, romFile, device.GPUDirectClique)
+		}
 		return q.qmpMonitorCh.qmp.ExecuteVFIODeviceAdd(q.qmpMonitorCh.ctx, device.ID, device.BDF, device.Bus, romFile)
 	case config.VFIOPCIDeviceMediatedType:
 		return q.qmpMonitorCh.qmp.ExecutePCIVFIOMediatedDeviceAdd(q.qmpMonitorCh.ctx, device.ID, device.SysfsDev, "", device.Bus, romFile)
@@ -1871,6 +2491,55 @@ func (q *qemu) executeVFIODeviceAdd(device *config.VFIODev) error {
 	}
 }
 
+const (
+	nvidiaVendorID = "0x10de"
+
+	// maxGPUDirectCliques is the number of cliques, the clique id being
+	// 4 bits long.
+	maxGPUDirectCliques = 16
+)
+
+// setGPUDirectClique checks that a VFIO device can do peer-to-peer DMA with
+// the other devices of its host PCIe switch, when enabled, and puts the NVIDIA
+// GPUs of a same switch in the same GPUDirect clique. The NICs of the switch
+// need no clique, GPUDirect RDMA only relies on them sharing the switch with
+// the GPUs.
+func (q *qemu) setGPUDirectClique(device *config.VFIODev) error {
+	if !q.config.PCIeP2P || device.Type != config.VFIOPCIDeviceNormalType || !device.IsPCIe {
+		return nil
+	}
+
+	sw, err := drivers.GetPCIeP2PSwitch(device.BDF)
+	if err != nil {
+		return err
+	}
+
+	if drivers.GetPCIDeviceVendor(device.BDF) != nvidiaVendorID {
+		return nil
+	}
+
+	clique, ok := q.gpuDirectCliques[sw]
+	if !ok {
+		if len(q.gpuDirectCliques) == maxGPUDirectCliques {
+			return fmt.Errorf("GPU %s needs more than %d GPUDirect cliques", device.BDF, maxGPUDirectCliques)
+		}
+		if q.gpuDirectCliques == nil {
+			q.gpuDirectCliques = make(map[string]int)
+		}
+		clique = len(q.gpuDirectCliques)
+		q.gpuDirectCliques[sw] = clique
+	}
+	device.GPUDirectClique = strconv.Itoa(clique)
+
+	q.Logger().WithFields(logrus.Fields{
+		"device": device.BDF,
+		"switch": sw,
+		"clique": clique,
+	}).Info("GPU added to GPUDirect clique")
+
+	return nil
+}
+
 func (q *qemu) hotplugVFIODevice(ctx context.Context, device *config.VFIODev, op Operation) (err error) {
 	if err = q.qmpSetup(); err != nil {
 		return err
@@ -1880,9 +2549,18 @@ func (q *qemu) hotplugVFIODevice(ctx context.Context, device *config.VFIODev, op
 		buf, _ := json.Marshal(device)
 		q.Logger().WithFields(logrus.Fields{
 			"machine-type":  q.HypervisorConfig().HypervisorMachineType,
+			"pci-hotplug":   PCIHotplugMode(&q.config),
 			"hot-plug-vfio": q.state.HotPlugVFIO,
 			"device-info":   string(buf),
 		}).Info("Start hot-plug VFIO device")
+		if err := q.setGPUDirectClique(device); err != nil {
+			return err
+		}
+		// In case MachineType is pseries, a device is hotplugged on an
+		// extra PCI host bridge
+		if q.HypervisorConfig().HypervisorMachineType == QemuPseries {
+			return q.hotplugVFIODeviceSpaprPHB(ctx, device)
+		}
 		// In case MachineType is q35, a PCIe device is hotplugged on
 		// a PCIe Root Port or alternatively on a PCIe Switch Port
 		if q.HypervisorConfig().HypervisorMachineType != QemuQ35 && q.HypervisorConfig().HypervisorMachineType != QemuVirt {
@@ -1903,18 +2581,15 @@ func (q *qemu) hotplugVFIODevice(ctx context.Context, device *config.VFIODev, op
 				return err
 			}
 		}
-		// XXX: Depending on whether we're doing root port or
-		// bridge hotplug, and how the bridge is set up in
-		// other parts of the code, we may or may not already
-		// have information about the slot number of the
-		// bridge and or the device.  For simplicity, just
-		// query both of them back from qemu
-		device.GuestPciPath, err = q.qomGetPciPath(device.ID)
-		return err
+		return q.setVFIOGuestPciPath(device)
 	}
 
 	q.Logger().WithField("dev-id", device.ID).Info("Start hot-unplug VFIO device")
 
+	if q.spaprPHBs != nil {
+		q.spaprPHBs.release(device.ID)
+	}
+
 	if !q.state.HotplugVFIOOnRootBus {
 		if err := q.arch.removeDeviceFromBridge(device.ID); err != nil {
 			return err
@@ -1957,6 +2632,9 @@ func (q *qemu) hotplugNetDevice(ctx context.Context, endpoint Endpoint, op Opera
 	switch endpoint.Type() {
 	case VethEndpointType:
 		drive := endpoint.(*VethEndpoint)
+		if drive.NetPair.NetInterworkingModel == NetXConnectAFXDPModel {
+			return fmt.Errorf("hotplug of AF_XDP network devices is not supported")
+		}
 		tap = drive.NetPair.TapInterface
 	case TapEndpointType:
 		drive := endpoint.(*TapEndpoint)
@@ -1988,15 +2666,14 @@ func (q *qemu) hotplugNetDevice(ctx context.Context, endpoint Endpoint, op Opera
 			}
 		}()
 
-		bridgeSlot, err := types.PciSlotFromInt(bridge.Addr)
+		devSlot, err := types.PciSlotFromString(addr)
 		if err != nil {
 			return err
 		}
-		devSlot, err := types.PciSlotFromString(addr)
+		pciPath, err := q.predictPciPath(bridge.ID, devSlot)
 		if err != nil {
 			return err
 		}
-		pciPath, err := types.PciPathFromSlots(bridgeSlot, devSlot)
 		endpoint.SetPciPath(pciPath)
 
 		var machine govmmQemu.Machine
@@ -2093,6 +2770,12 @@ func (q *qemu) hotplugCPUs(vcpus uint32, op Operation) (uint32, error) {
 
 // try to hot add an amount of vCPUs, returns the number of vCPUs added
 func (q *qemu) hotplugAddCPUs(amount uint32) (uint32, error) {
+	if !q.arch.supportGuestCPUHotplug() {
+		// Don't fail, since cgroups still can be updated
+		q.Logger().Warnf("Cannot hotplug %d CPUs, the machine does not support CPU hotplug", amount)
+		return 0, nil
+	}
+
 	currentVCPUs := q.qemuConfig.SMP.CPUs + uint32(len(q.state.HotpluggedVCPUs))
 
 	// Don't fail if the number of max vCPUs is exceeded, log a warning and hot add the vCPUs needed
@@ -2329,6 +3012,14 @@ func (q *qemu) AddDevice(ctx context.Context, devInfo interface{}, devType Devic
 	case config.VhostUserDeviceAttrs:
 		q.qemuConfig.Devices, err = q.arch.appendVhostUserDevice(ctx, q.qemuConfig.Devices, v)
 	case config.VFIODev:
+		if err := q.setGPUDirectClique(&v); err != nil {
+			return err
+		}
+		if q.hasSpaprPHBs() {
+			if _, _, err := q.placeVFIODeviceOnSpaprPHB(ctx, &v); err != nil {
+				return err
+			}
+		}
 		q.qemuConfig.Devices = q.arch.appendVFIODevice(q.qemuConfig.Devices, v)
 	default:
 		q.Logger().WithField("dev-type", v).Warn("Could not append device: unsupported device type")
@@ -2498,11 +3189,41 @@ func (q *qemu) ResizeMemory(ctx context.Context, reqMemMB uint32, memoryBlockSiz
 		currentMemory -= uint32(memoryRemoved)
 	}
 
+	if q.hasBalloon() {
+		if err := q.resizeBalloon(reqMemMB, currentMemory); err != nil {
+			return currentMemory, addMemDevice, err
+		}
+	}
+
 	// currentMemory is the current memory (updated) of the VM, return to caller to allow verify
 	// the current VM memory state.
 	return currentMemory, addMemDevice, nil
 }
 
+// hasBalloon tells if the VM has the balloon device reclaiming the memory
+// freed by the guest, which is not added on s390x CCW.
+func (q *qemu) hasBalloon() bool {
+	return q.config.ReclaimGuestFreedMemory && q.arch.machine().Type != QemuCCWVirtio
+}
+
+// resizeBalloon inflates the balloon so that the memory usable by the guest
+// does not exceed reqMemMB, or deflates it when more memory is allowed.
+// Memory cannot be unplugged, so this is how the guest is kept within the
+// sandbox memory limit enforced by the host cgroup.
+func (q *qemu) resizeBalloon(reqMemMB, currentMemMB uint32) error {
+	targetMemMB := reqMemMB
+	if targetMemMB > currentMemMB {
+		targetMemMB = currentMemMB
+	}
+
+	q.Logger().WithFields(logrus.Fields{
+		"current-memory": currentMemMB,
+		"target-memory":  targetMemMB,
+	}).Debug("resizing memory balloon")
+
+	return q.qmpMonitorCh.qmp.ExecuteBalloon(q.qmpMonitorCh.ctx, uint64(targetMemMB)<<utils.MibToBytesShift)
+}
+
 // genericAppendBridges appends to devices the given bridges
 // nolint: unused, deadcode
 func genericAppendBridges(devices []govmmQemu.Device, bridges []types.Bridge, machineType string) []govmmQemu.Device {
@@ -2520,6 +3241,16 @@ func genericAppendBridges(devices []govmmQemu.Device, bridges []types.Bridge, ma
 		if b.Type == types.CCW {
 			continue
 		}
+		// The address of an extra PCI host bridge is its index
+		if b.Type == types.PHB {
+			devices = append(devices,
+				govmmQemu.SpaprPHBDevice{
+					ID:    b.ID,
+					Index: uint32(b.Addr),
+				},
+			)
+			continue
+		}
 
 		bridges[idx].Addr = bridgePCIStartAddr + idx
 
@@ -2599,20 +3330,30 @@ func genericMemoryTopology(memoryMb, hostMemoryMb uint64, slots uint8, memoryOff
 	return memory
 }
 
+// The ports are at fixed addresses, so that their slots, and the PCI paths of
+// the devices plugged on them, are known before the VM is started. They are at
+// the top of the root bus, the slots at the bottom being assigned by QEMU to
+// the other devices, and below the slot 0x1f of the q35 chipset.
+const pcieSwitchRootPortAddr = 0x1e
+
+// pcieRootPortAddr returns the address of the root port n, below the one of the
+// switch.
+func pcieRootPortAddr(n uint32) string {
+	return fmt.Sprintf("%#x", pcieSwitchRootPortAddr-1-n)
+}
+
 // genericAppendPCIeRootPort appends to devices the given pcie-root-port
 func genericAppendPCIeRootPort(devices []govmmQemu.Device, number uint32, machineType string, memSize32bit uint64, memSize64bit uint64) []govmmQemu.Device {
 	var (
 		bus           string
 		chassis       string
 		multiFunction bool
-		addr          string
 	)
 	switch machineType {
 	case QemuQ35, QemuVirt:
 		bus = defaultBridgeBus
 		chassis = "0"
 		multiFunction = false
-		addr = "0"
 	default:
 		return devices
 	}
@@ -2625,7 +3366,7 @@ func genericAppendPCIeRootPort(devices []govmmQemu.Device, number uint32, machin
 				Chassis:       chassis,
 				Slot:          strconv.FormatUint(uint64(i), 10),
 				Multifunction: multiFunction,
-				Addr:          addr,
+				Addr:          pcieRootPortAddr(i),
 				MemReserve:    fmt.Sprintf("%dB", memSize32bit),
 				Pref64Reserve: fmt.Sprintf("%dB", memSize64bit),
 			},
@@ -2672,7 +3413,7 @@ func genericAppendPCIeSwitchPort(devices []govmmQemu.Device, number uint32, mach
 		Chassis:       "1",
 		Slot:          strconv.FormatUint(uint64(0), 10),
 		Multifunction: false,
-		Addr:          "0",
+		Addr:          fmt.Sprintf("%#x", pcieSwitchRootPortAddr),
 		MemReserve:    fmt.Sprintf("%dB", memSize32bit),
 		Pref64Reserve: fmt.Sprintf("%dB", memSize64bit),
 	}
@@ -2698,6 +3439,7 @@ func genericAppendPCIeSwitchPort(devices []govmmQemu.Device, number uint32, mach
 			Bus:     pcieSwitchUpstreamPort.ID,
 			Chassis: fmt.Sprintf("%d", nextChassis),
 			Slot:    strconv.FormatUint(uint64(i), 10),
+			Addr:    fmt.Sprintf("%#x", i),
 			// TODO: MemReserve:    fmt.Sprintf("%dB", memSize32bit),
 			// TODO: Pref64Reserve: fmt.Sprintf("%dB", memSize64bit),
 		}
@@ -2803,6 +3545,12 @@ func (q *qemu) GetPids() []int {
 	if q.state.VirtiofsDaemonPid != 0 {
 		pids = append(pids, q.state.VirtiofsDaemonPid)
 	}
+	if q.state.VirtioGPUDaemonPid != 0 {
+		pids = append(pids, q.state.VirtioGPUDaemonPid)
+	}
+	if q.state.SwtpmPid != 0 {
+		pids = append(pids, q.state.SwtpmPid)
+	}
 
 	return pids
 }
@@ -2867,6 +3615,8 @@ func (q *qemu) toGrpc(ctx context.Context) ([]byte, error) {
 }
 
 func (q *qemu) Save() (s hv.HypervisorState) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
 
 	// If QEMU isn't even running, there isn't any state to Save
 	if atomic.LoadInt32(&q.stopped) != 0 {
@@ -2878,6 +3628,8 @@ func (q *qemu) Save() (s hv.HypervisorState) {
 		s.Pid = pids[0]
 	}
 	s.VirtiofsDaemonPid = q.state.VirtiofsDaemonPid
+	s.VirtioGPUDaemonPid = q.state.VirtioGPUDaemonPid
+	s.SwtpmPid = q.state.SwtpmPid
 	s.Type = string(QemuHypervisor)
 	s.UUID = q.state.UUID
 	s.HotpluggedMemory = q.state.HotpluggedMemory
@@ -2905,6 +3657,8 @@ func (q *qemu) Load(s hv.HypervisorState) {
 	q.state.HotpluggedMemory = s.HotpluggedMemory
 	q.state.HotplugVFIOOnRootBus = s.HotplugVFIOOnRootBus
 	q.state.VirtiofsDaemonPid = s.VirtiofsDaemonPid
+	q.state.VirtioGPUDaemonPid = s.VirtioGPUDaemonPid
+	q.state.SwtpmPid = s.SwtpmPid
 
 	for _, bridge := range s.Bridges {
 		q.state.Bridges = append(q.state.Bridges, types.NewBridge(types.Type(bridge.Type), bridge.ID, bridge.DeviceAddr, bridge.Addr))
@@ -2948,3 +3702,25 @@ func (q *qemu) GenerateSocket(id string) (interface{}, error) {
 func (q *qemu) IsRateLimiterBuiltin() bool {
 	return false
 }
+
+// FlushDrives completes the requests QEMU has in flight for the block drives
+// and flushes them through to the host page cache, or to the disk unless
+// block_device_cache_noflush is set.
+func (q *qemu) FlushDrives(ctx context.Context, driveIDs []string) error {
+	span, ctx := katatrace.Trace(ctx, q.Logger(), "FlushDrives", qemuTracingTags, map[string]string{"sandbox_id": q.id})
+	defer span.End()
+
+	if len(driveIDs) == 0 {
+		return nil
+	}
+	if err := q.qmpSetup(); err != nil {
+		return err
+	}
+
+	for _, id := range driveIDs {
+		if err := q.qmpMonitorCh.qmp.ExecuteBlockdevFlush(ctx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
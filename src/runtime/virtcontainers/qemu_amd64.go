@@ -9,7 +9,11 @@ package virtcontainers
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
 	"fmt"
+	"hash"
 	"time"
 
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
@@ -162,9 +166,11 @@ func (q *qemuAmd64) capabilities(hConfig HypervisorConfig) types.Capabilities {
 	if q.qemuMachine.Type == QemuQ35 ||
 		q.qemuMachine.Type == QemuVirt {
 		caps.SetBlockDeviceHotplugSupport()
+		caps.SetNetworkDeviceHotplugSupport()
 	}
 
 	caps.SetMultiQueueSupport()
+	caps.SetSerialPortSupport()
 	if hConfig.SharedFS != config.NoSharedFS {
 		caps.SetFsSharingSupport()
 	}
@@ -286,6 +292,7 @@ func (q *qemuAmd64) appendProtectionDevice(devices []govmmQemu.Device, firmware,
 				Debug:          false,
 				File:           firmware,
 				FirmwareVolume: firmwareVolume,
+				MrConfigID:     q.vtpmBinding(sha512.New384()),
 			}), "", nil
 	case sevProtection:
 		return append(devices,
@@ -306,6 +313,7 @@ func (q *qemuAmd64) appendProtectionDevice(devices []govmmQemu.Device, firmware,
 				File:            firmware,
 				CBitPos:         cpuid.AMDMemEncrypt.CBitPosition,
 				ReducedPhysBits: 1,
+				HostData:        q.vtpmBinding(sha256.New()),
 			}), "", nil
 	case noneProtection:
 
@@ -315,3 +323,14 @@ func (q *qemuAmd64) appendProtectionDevice(devices []govmmQemu.Device, firmware,
 		return devices, "", fmt.Errorf("Unsupported guest protection technology: %v", q.protection)
 	}
 }
+
+// vtpmBinding returns the base64 encoded digest of the EK certificates of
+// the vTPM, which the guest evidence carries as its host data or
+// configuration ID, empty without a bound vTPM.
+func (q *qemuAmd64) vtpmBinding(h hash.Hash) string {
+	if len(q.vtpmEKCerts) == 0 {
+		return ""
+	}
+	h.Write(q.vtpmEKCerts)
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
@@ -47,10 +47,12 @@ func TestQemuAmd64Capabilities(t *testing.T) {
 	amd64 := newTestQemu(assert, QemuQ35)
 	caps := amd64.capabilities(config)
 	assert.True(caps.IsBlockDeviceHotplugSupported())
+	assert.True(caps.IsNetworkDeviceHotplugSupported())
 
 	amd64 = newTestQemu(assert, QemuMicrovm)
 	caps = amd64.capabilities(config)
 	assert.False(caps.IsBlockDeviceHotplugSupported())
+	assert.False(caps.IsNetworkDeviceHotplugSupported())
 }
 
 func TestQemuAmd64Bridges(t *testing.T) {
@@ -332,4 +334,37 @@ func TestQemuAmd64AppendProtectionDevice(t *testing.T) {
 	)
 
 	assert.Equal(expectedOut, devices)
+
+	// the EK certificates of the vTPM are bound to the evidence
+	amd64.bindVTPM([]byte("ek"))
+
+	amd64.(*qemuAmd64).protection = snpProtection
+	devices, _, err = amd64.appendProtectionDevice(nil, firmware, "")
+	assert.NoError(err)
+	// sha256("ek")
+	assert.Equal("XpMLGytsZd5HZ533KzpzFqVKhPSw0eIVn0g2V/ujvkM=", devices[0].(govmmQemu.Object).HostData)
+
+	amd64.(*qemuAmd64).protection = tdxProtection
+	devices, _, err = amd64.appendProtectionDevice(nil, firmware, "")
+	assert.NoError(err)
+	assert.Len(devices[0].(govmmQemu.Object).MrConfigID, 64)
+}
+
+func TestQemuAmd64PmemVolumesMachine(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg := qemuConfig(QemuQ35)
+	cfg.DisableImageNvdimm = true
+	arch, err := newQemuArch(cfg)
+	assert.NoError(err)
+
+	q := &qemu{arch: arch, config: cfg}
+	machine, err := q.getQemuMachine()
+	assert.NoError(err)
+	assert.NotContains(machine.Options, qemuNvdimmOption)
+
+	q.config.PmemVolumesSize = 1024
+	machine, err = q.getQemuMachine()
+	assert.NoError(err)
+	assert.Contains(machine.Options, qemuNvdimmOption)
 }
@@ -134,6 +134,9 @@ type qemuArch interface {
 	// supportGuestMemoryHotplug returns if the guest supports memory hotplug
 	supportGuestMemoryHotplug() bool
 
+	// supportGuestCPUHotplug returns if the guest supports CPU hotplug
+	supportGuestCPUHotplug() bool
+
 	// setIgnoreSharedMemoryMigrationCaps set bypass-shared-memory capability for migration
 	setIgnoreSharedMemoryMigrationCaps(context.Context, *govmmQemu.QMP) error
 
@@ -149,15 +152,26 @@ type qemuArch interface {
 	// append pvpanic device
 	appendPVPanicDevice(devices []govmmQemu.Device) ([]govmmQemu.Device, error)
 
+	// appendHostSharedMemory appends a host shared memory segment as an
+	// ivshmem device
+	appendHostSharedMemory(devices []govmmQemu.Device, path string) ([]govmmQemu.Device, error)
+
 	// append protection device.
 	// This implementation is architecture specific, some archs may need
 	// a firmware, returns a string containing the path to the firmware that should
 	// be used with the -bios option, ommit -bios option if the path is empty.
 	appendProtectionDevice(devices []govmmQemu.Device, firmware, firmwareVolume string) ([]govmmQemu.Device, string, error)
 
+	// bindVTPM binds the EK certificates of the vTPM to the evidence of
+	// the protected guest, when the architecture supports it.
+	bindVTPM(ekCerts []byte)
+
 	// scans the PCIe space and returns the biggest BAR sizes for 32-bit
 	// and 64-bit addressable memory
 	getBARsMaxAddressableMemory() (uint64, uint64)
+
+	// getProtection returns the guest protection enabled for the VM
+	getProtection() guestProtection
 }
 
 type qemuArchBase struct {
@@ -177,6 +191,8 @@ type qemuArchBase struct {
 	disableNvdimm bool
 	dax           bool
 	legacySerial  bool
+	// vtpmEKCerts are the EK certificates of the vTPM bound to the guest
+	vtpmEKCerts []byte //nolint:structcheck
 }
 
 const (
@@ -287,7 +303,9 @@ func (q *qemuArchBase) kernelParameters(debug bool) []Param {
 func (q *qemuArchBase) capabilities(hConfig HypervisorConfig) types.Capabilities {
 	var caps types.Capabilities
 	caps.SetBlockDeviceHotplugSupport()
+	caps.SetNetworkDeviceHotplugSupport()
 	caps.SetMultiQueueSupport()
+	caps.SetSerialPortSupport()
 	if hConfig.SharedFS != config.NoSharedFS {
 		caps.SetFsSharingSupport()
 	}
@@ -550,6 +568,8 @@ func networkModelToQemuType(model NetInterworkingModel) govmmQemu.NetDeviceType
 	switch model {
 	case NetXConnectMacVtapModel:
 		return govmmQemu.MACVTAP
+	case NetXConnectAFXDPModel:
+		return govmmQemu.AFXDP
 	default:
 		//TAP should work for most other cases
 		return govmmQemu.TAP
@@ -574,6 +594,15 @@ func genericNetwork(endpoint Endpoint, vhost, nestedRun bool, index int) (govmmQ
 			FDs:           netPair.VMFds,
 			VhostFDs:      netPair.VhostFds,
 		}
+		if netPair.NetInterworkingModel == NetXConnectAFXDPModel {
+			// The sockets are bound to the interface itself, there is
+			// no tap.
+			d.IFName = netPair.VirtIface.Name
+			d.VHost = false
+			d.XDPMode = netPair.afXDP.mode
+			d.XDPQueues = int(netPair.afXDP.queues)
+			d.XDPStartQueue = int(netPair.afXDP.startQueue)
+		}
 	case *MacvtapEndpoint:
 		d = govmmQemu.NetDevice{
 			Type:          govmmQemu.MACVTAP,
@@ -688,10 +717,11 @@ func (q *qemuArchBase) appendVFIODevice(devices []govmmQemu.Device, vfioDev conf
 
 	devices = append(devices,
 		govmmQemu.VFIODevice{
-			BDF:      vfioDev.BDF,
-			VendorID: vfioDev.VendorID,
-			DeviceID: vfioDev.DeviceID,
-			Bus:      vfioDev.Bus,
+			BDF:             vfioDev.BDF,
+			VendorID:        vfioDev.VendorID,
+			DeviceID:        vfioDev.DeviceID,
+			Bus:             vfioDev.Bus,
+			GPUDirectClique: vfioDev.GPUDirectClique,
 		},
 	)
 
@@ -703,6 +733,9 @@ func (q *qemuArchBase) appendRNGDevice(_ context.Context, devices []govmmQemu.De
 		govmmQemu.RngDevice{
 			ID:       rngDev.ID,
 			Filename: rngDev.Filename,
+			Builtin:  rngDev.Builtin,
+			MaxBytes: uint(rngDev.MaxBytes),
+			Period:   uint(rngDev.Period),
 		},
 	)
 
@@ -736,6 +769,14 @@ func (q *qemuArchBase) supportGuestMemoryHotplug() bool {
 	return q.protection == noneProtection
 }
 
+func (q *qemuArchBase) supportGuestCPUHotplug() bool {
+	return true
+}
+
+func (q *qemuArchBase) getProtection() guestProtection {
+	return q.protection
+}
+
 func (q *qemuArchBase) setIgnoreSharedMemoryMigrationCaps(ctx context.Context, qmp *govmmQemu.QMP) error {
 	err := qmp.ExecSetMigrationCaps(ctx, []map[string]interface{}{
 		{
@@ -868,6 +909,34 @@ func (q *qemuArchBase) appendPVPanicDevice(devices []govmmQemu.Device) ([]govmmQ
 	return devices, nil
 }
 
+// appendHostSharedMemory appends a host shared memory segment, which the
+// guest sees as the BAR 2 of an ivshmem PCI device. The writes of the guest
+// and of the host processes mapping the segment are visible to each other.
+func (q *qemuArchBase) appendHostSharedMemory(devices []govmmQemu.Device, path string) ([]govmmQemu.Device, error) {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// The BAR size of an ivshmem device is a power of 2.
+	size := uint64(stat.Size())
+	if !stat.Mode().IsRegular() || size == 0 || size&(size-1) != 0 {
+		return nil, fmt.Errorf("host shared memory %s must be a file whose size is a power of 2", path)
+	}
+
+	object := govmmQemu.Object{
+		Driver:   govmmQemu.IvshmemPlain,
+		Type:     govmmQemu.MemoryBackendFile,
+		DeviceID: "shm0",
+		ID:       "shmmem0",
+		MemPath:  path,
+		Size:     size,
+		Share:    true,
+	}
+
+	return append(devices, object), nil
+}
+
 func (q *qemuArchBase) getPFlash() ([]string, error) {
 	return q.PFlash, nil
 }
@@ -876,6 +945,10 @@ func (q *qemuArchBase) setPFlash(p []string) {
 	q.PFlash = p
 }
 
+func (q *qemuArchBase) bindVTPM(ekCerts []byte) {
+	q.vtpmEKCerts = ekCerts
+}
+
 // append protection device
 func (q *qemuArchBase) appendProtectionDevice(devices []govmmQemu.Device, firmware, firmwareVolume string) ([]govmmQemu.Device, string, error) {
 	hvLogger.WithField("arch", runtime.GOARCH).Warnf("Confidential Computing has not been implemented for this architecture")
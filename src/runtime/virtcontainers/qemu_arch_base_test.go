@@ -587,6 +587,51 @@ func TestQemuArchBaseAppendNetwork(t *testing.T) {
 	assert.Equal(expectedOut, devices)
 }
 
+func TestQemuArchBaseAppendNetworkAFXDP(t *testing.T) {
+	assert := assert.New(t)
+	qemuArchBase := newQemuArchBase()
+
+	vethEp := &VethEndpoint{
+		NetPair: NetworkInterfacePair{
+			TapInterface: TapInterface{
+				ID:   "uniqueTestID-0",
+				Name: "br0_kata",
+				TAPIface: NetworkInterface{
+					Name:     "tap0_kata",
+					HardAddr: "02:00:ca:fe:00:04",
+				},
+			},
+			VirtIface: NetworkInterface{
+				Name: "eth0",
+			},
+			NetInterworkingModel: NetXConnectAFXDPModel,
+			afXDP: afXDPBinding{
+				mode:       AFXDPModeNative,
+				queues:     2,
+				startQueue: 1,
+			},
+		},
+		EndpointType: VethEndpointType,
+	}
+
+	devices, err := qemuArchBase.appendNetwork(context.Background(), nil, vethEp)
+	assert.NoError(err)
+	assert.Equal([]govmmQemu.Device{
+		govmmQemu.NetDevice{
+			Type:          govmmQemu.AFXDP,
+			Driver:        govmmQemu.VirtioNet,
+			ID:            "network-0",
+			IFName:        "eth0",
+			MACAddress:    "02:00:ca:fe:00:04",
+			DownScript:    "no",
+			Script:        "no",
+			XDPMode:       AFXDPModeNative,
+			XDPQueues:     2,
+			XDPStartQueue: 1,
+		},
+	}, devices)
+}
+
 func TestQemuArchBaseAppendIOMMU(t *testing.T) {
 	var devices []govmmQemu.Device
 	var err error
@@ -606,3 +651,32 @@ func TestQemuArchBaseAppendIOMMU(t *testing.T) {
 	assert.NoError(err)
 	assert.Equal(expectedOut, devices)
 }
+
+func TestQemuArchBaseAppendHostSharedMemory(t *testing.T) {
+	assert := assert.New(t)
+	qemuArchBase := newQemuArchBase()
+	dir := t.TempDir()
+
+	_, err := qemuArchBase.appendHostSharedMemory(nil, filepath.Join(dir, "missing"))
+	assert.Error(err)
+
+	segment := filepath.Join(dir, "segment")
+	assert.NoError(os.WriteFile(segment, make([]byte, 3000), 0600))
+	_, err = qemuArchBase.appendHostSharedMemory(nil, segment)
+	assert.Error(err)
+
+	assert.NoError(os.Truncate(segment, 4096))
+	devices, err := qemuArchBase.appendHostSharedMemory(nil, segment)
+	assert.NoError(err)
+	assert.Equal([]govmmQemu.Device{
+		govmmQemu.Object{
+			Driver:   govmmQemu.IvshmemPlain,
+			Type:     govmmQemu.MemoryBackendFile,
+			DeviceID: "shm0",
+			ID:       "shmmem0",
+			MemPath:  segment,
+			Size:     4096,
+			Share:    true,
+		},
+	}, devices)
+}
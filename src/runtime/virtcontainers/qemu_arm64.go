@@ -16,11 +16,16 @@ import (
 
 	govmmQemu "github.com/kata-containers/kata-containers/src/runtime/pkg/govmm/qemu"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/sirupsen/logrus"
 )
 
 type qemuArm64 struct {
 	// inherit from qemuArchBase, overwrite methods if needed
 	qemuArchBase
+
+	ccaMeasurementAlgorithm string
+	ccaPersonalizationValue string
+	ccaMeasurementLog       bool
 }
 
 const defaultQemuPath = "/usr/bin/qemu-system-aarch64"
@@ -31,6 +36,8 @@ const qmpMigrationWaitTimeout = 10 * time.Second
 
 const defaultQemuMachineOptions = "usb=off,accel=kvm,gic-version=host"
 
+const rmeID = "rme0"
+
 var kernelParams = []Param{
 	{"iommu.passthrough", "0"},
 }
@@ -63,6 +70,20 @@ func newQemuArch(config HypervisorConfig) (qemuArch, error) {
 			protection:           noneProtection,
 			legacySerial:         config.LegacySerial,
 		},
+		config.CCAMeasurementAlgorithm,
+		config.CCAPersonalizationValue,
+		config.CCAMeasurementLog,
+	}
+
+	if config.ConfidentialGuest {
+		if err := q.enableProtection(); err != nil {
+			return nil, err
+		}
+
+		if !q.qemuArchBase.disableNvdimm {
+			hvLogger.WithField("subsystem", "qemuArm64").Warn("Nvdimm is not supported with confidential guest, disabling it.")
+			q.qemuArchBase.disableNvdimm = true
+		}
 	}
 
 	if err := q.handleImagePath(config); err != nil {
@@ -145,17 +166,45 @@ func (q *qemuArm64) getPFlash() ([]string, error) {
 	}
 }
 
+// enableProtection enables the Arm CCA Realm of the guest in QEMU's machine
+// option.
 func (q *qemuArm64) enableProtection() error {
-	q.protection, _ = availableGuestProtection()
-	if q.protection != noneProtection {
-		return fmt.Errorf("Protection %v is not supported on arm64", q.protection)
+	protection, err := availableGuestProtection()
+	if err != nil {
+		return err
+	}
+	if protection != ccaProtection {
+		return fmt.Errorf("This system doesn't support Confidential Computing (Guest Protection)")
 	}
 
+	q.protection = protection
+	if q.qemuMachine.Options != "" {
+		q.qemuMachine.Options += ","
+	}
+	q.qemuMachine.Options += fmt.Sprintf("confidential-guest-support=%s", rmeID)
+	hvLogger.WithFields(logrus.Fields{
+		"subsystem": "qemuArm64",
+		"machine":   q.qemuMachine}).
+		Info("Enabling Arm CCA guest protection")
 	return nil
 }
 
+// appendProtectionDevice appends the QEMU object of the Arm CCA Realm.
 func (q *qemuArm64) appendProtectionDevice(devices []govmmQemu.Device, firmware, firmwareVolume string) ([]govmmQemu.Device, string, error) {
-	err := q.enableProtection()
-	hvLogger.WithField("arch", runtime.GOARCH).Warnf("%v", err)
-	return devices, firmware, err
+	switch q.protection {
+	case ccaProtection:
+		return append(devices,
+			govmmQemu.Object{
+				Type:                 govmmQemu.RMEGuest,
+				ID:                   rmeID,
+				MeasurementAlgorithm: q.ccaMeasurementAlgorithm,
+				PersonalizationValue: q.ccaPersonalizationValue,
+				MeasurementLog:       q.ccaMeasurementLog,
+			}), firmware, nil
+	case noneProtection:
+		return devices, firmware, nil
+	default:
+		hvLogger.WithField("arch", runtime.GOARCH).Warnf("Protection %v is not supported on arm64", q.protection)
+		return devices, firmware, nil
+	}
 }
@@ -221,4 +221,18 @@ func TestQemuArm64AppendProtectionDevice(t *testing.T) {
 	assert.Empty(devices)
 	assert.Empty(bios)
 	assert.NoError(err)
+
+	// CCA protection
+	arm64.(*qemuArm64).protection = ccaProtection
+	arm64.(*qemuArm64).ccaMeasurementAlgorithm = CCAMeasurementSHA512
+	devices, bios, err = arm64.appendProtectionDevice(devices, firmware, "")
+	assert.NoError(err)
+	assert.Empty(bios)
+	assert.Equal([]govmmQemu.Device{
+		govmmQemu.Object{
+			Type:                 govmmQemu.RMEGuest,
+			ID:                   rmeID,
+			MeasurementAlgorithm: CCAMeasurementSHA512,
+		},
+	}, devices)
 }
@@ -107,6 +107,7 @@ func (q *qemuPPC64le) capabilities(hConfig HypervisorConfig) types.Capabilities
 	}
 
 	caps.SetMultiQueueSupport()
+	caps.SetSerialPortSupport()
 	if hConfig.SharedFS != config.NoSharedFS {
 		caps.SetFsSharingSupport()
 	}
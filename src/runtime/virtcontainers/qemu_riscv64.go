@@ -0,0 +1,129 @@
+//go:build linux
+
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"time"
+
+	govmmQemu "github.com/kata-containers/kata-containers/src/runtime/pkg/govmm/qemu"
+)
+
+// The riscv64 virt machine has no ACPI in the guest, its PCIe host bridge is
+// described by the device tree. The virtio-pci devices are hotplugged on its
+// root ports by the pciehp driver, but neither the CPUs nor the memory can
+// be, so the VM is started with all its vCPUs.
+type qemuRiscv64 struct {
+	// inherit from qemuArchBase, overwrite methods if needed
+	qemuArchBase
+}
+
+const defaultQemuPath = "/usr/bin/qemu-system-riscv64"
+
+const defaultQemuMachineType = QemuVirt
+
+const qmpMigrationWaitTimeout = 10 * time.Second
+
+const defaultQemuMachineOptions = "accel=kvm"
+
+var kernelParams = []Param{
+	{"rcupdate.rcu_expedited", "1"},
+}
+
+var supportedQemuMachine = govmmQemu.Machine{
+	Type:    QemuVirt,
+	Options: defaultQemuMachineOptions,
+}
+
+func newQemuArch(config HypervisorConfig) (qemuArch, error) {
+	machineType := config.HypervisorMachineType
+	if machineType == "" {
+		machineType = defaultQemuMachineType
+	}
+
+	if machineType != defaultQemuMachineType {
+		return nil, fmt.Errorf("unrecognised machinetype: %v", machineType)
+	}
+
+	q := &qemuRiscv64{
+		qemuArchBase{
+			qemuMachine:          supportedQemuMachine,
+			qemuExePath:          defaultQemuPath,
+			memoryOffset:         config.MemOffset,
+			kernelParamsNonDebug: kernelParamsNonDebug,
+			kernelParamsDebug:    kernelParamsDebug,
+			kernelParams:         kernelParams,
+			disableNvdimm:        true,
+			dax:                  false,
+			protection:           noneProtection,
+			legacySerial:         config.LegacySerial,
+		},
+	}
+
+	if err := q.handleImagePath(config); err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
+
+func (q *qemuRiscv64) bridges(number uint32) {
+	q.Bridges = genericBridges(number, q.qemuMachine.Type)
+}
+
+// cpuTopology returns a topology of a single socket, the virt machine
+// supporting at most 8 of them, with all the vCPUs started at boot.
+func (q *qemuRiscv64) cpuTopology(vcpus, maxvcpus uint32) govmmQemu.SMP {
+	return govmmQemu.SMP{
+		CPUs:    vcpus,
+		Sockets: 1,
+		Cores:   vcpus,
+		Threads: defaultThreads,
+		MaxCPUs: vcpus,
+	}
+}
+
+func (q *qemuRiscv64) memoryTopology(memoryMb, hostMemoryMb uint64, slots uint8) govmmQemu.Memory {
+	return govmmQemu.Memory{
+		Size: fmt.Sprintf("%dM", memoryMb),
+	}
+}
+
+func (q *qemuRiscv64) supportGuestMemoryHotplug() bool {
+	return false
+}
+
+func (q *qemuRiscv64) supportGuestCPUHotplug() bool {
+	return false
+}
+
+func (q *qemuRiscv64) setIgnoreSharedMemoryMigrationCaps(_ context.Context, _ *govmmQemu.QMP) error {
+	// x-ignore-shared not support in riscv64 for now
+	return nil
+}
+
+func (q *qemuRiscv64) appendIOMMU(devices []govmmQemu.Device) ([]govmmQemu.Device, error) {
+	return devices, fmt.Errorf("RISC-V architecture does not support vIOMMU")
+}
+
+func (q *qemuRiscv64) enableProtection() error {
+	q.protection, _ = availableGuestProtection()
+	if q.protection != noneProtection {
+		return fmt.Errorf("Protection %v is not supported on riscv64", q.protection)
+	}
+
+	return nil
+}
+
+func (q *qemuRiscv64) appendProtectionDevice(devices []govmmQemu.Device, firmware, firmwareVolume string) ([]govmmQemu.Device, string, error) {
+	err := q.enableProtection()
+	hvLogger.WithField("arch", runtime.GOARCH).Warnf("%v", err)
+	return devices, firmware, err
+}
@@ -0,0 +1,94 @@
+//go:build linux
+
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/govmm"
+	govmmQemu "github.com/kata-containers/kata-containers/src/runtime/pkg/govmm/qemu"
+	"github.com/stretchr/testify/assert"
+)
+
+func qemuConfig(machineType string) HypervisorConfig {
+	return HypervisorConfig{
+		HypervisorMachineType: machineType,
+	}
+}
+
+func newTestQemu(assert *assert.Assertions, machineType string) qemuArch {
+	config := qemuConfig(machineType)
+	arch, err := newQemuArch(config)
+	assert.NoError(err)
+	return arch
+}
+
+func TestQemuRiscv64BadMachineType(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := newQemuArch(qemuConfig("pseries"))
+	assert.Error(err)
+}
+
+func TestQemuRiscv64Capabilities(t *testing.T) {
+	assert := assert.New(t)
+	riscv64 := newTestQemu(assert, QemuVirt)
+
+	caps := riscv64.capabilities(HypervisorConfig{SharedFS: config.VirtioFS})
+	assert.True(caps.IsBlockDeviceHotplugSupported())
+	assert.True(caps.IsNetworkDeviceHotplugSupported())
+	assert.True(caps.IsFsSharingSupported())
+}
+
+func TestQemuRiscv64CPUTopology(t *testing.T) {
+	assert := assert.New(t)
+	riscv64 := newTestQemu(assert, QemuVirt)
+
+	expectedSMP := govmmQemu.SMP{
+		CPUs:    4,
+		Sockets: 1,
+		Cores:   4,
+		Threads: defaultThreads,
+		MaxCPUs: 4,
+	}
+
+	smp := riscv64.cpuTopology(4, 8)
+	assert.Equal(expectedSMP, smp)
+	assert.False(riscv64.supportGuestCPUHotplug())
+}
+
+func TestQemuRiscv64MemoryTopology(t *testing.T) {
+	assert := assert.New(t)
+	riscv64 := newTestQemu(assert, QemuVirt)
+
+	mem := uint64(1024)
+	expectedMemory := govmmQemu.Memory{
+		Size: fmt.Sprintf("%dM", mem),
+	}
+
+	m := riscv64.memoryTopology(mem, 4096, 3)
+	assert.Equal(expectedMemory, m)
+	assert.False(riscv64.supportGuestMemoryHotplug())
+}
+
+func TestMaxVCPUs(t *testing.T) {
+	assert := assert.New(t)
+
+	vCPUs := govmm.MaxVCPUs()
+	assert.Equal(uint32(512), vCPUs)
+}
+
+func TestQemuRiscv64AppendIOMMU(t *testing.T) {
+	assert := assert.New(t)
+	riscv64 := newTestQemu(assert, QemuVirt)
+
+	_, err := riscv64.appendIOMMU(nil)
+	assert.Error(err)
+}
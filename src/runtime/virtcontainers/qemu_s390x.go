@@ -236,6 +236,9 @@ func (q *qemuS390x) appendRNGDevice(ctx context.Context, devices []govmmQemu.Dev
 		govmmQemu.RngDevice{
 			ID:       rngDev.ID,
 			Filename: rngDev.Filename,
+			Builtin:  rngDev.Builtin,
+			MaxBytes: uint(rngDev.MaxBytes),
+			Period:   uint(rngDev.Period),
 			DevNo:    devno,
 		},
 	)
@@ -9,9 +9,15 @@ package virtcontainers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"syscall"
 	"testing"
 
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/config"
@@ -25,6 +31,39 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// qmpTestCommand is a command received by the fake QMP server of a test.
+type qmpTestCommand struct {
+	Execute   string                 `json:"execute"`
+	Arguments map[string]interface{} `json:"arguments"`
+}
+
+// startQMPTest connects q to a fake QMP server, which replies to each command
+// with the response returned by handle, e.g. `{"return": {}}`.
+func startQMPTest(t *testing.T, q *qemu, handle func(cmd qmpTestCommand) string) {
+	client, server := net.Pipe()
+	go func() {
+		defer server.Close()
+		fmt.Fprintln(server, `{"QMP": {"version": {"qemu": {"micro": 0, "minor": 0, "major": 7}, "package": ""}, "capabilities": []}}`)
+		dec := json.NewDecoder(server)
+		for {
+			var cmd qmpTestCommand
+			if err := dec.Decode(&cmd); err != nil {
+				return
+			}
+			fmt.Fprintln(server, handle(cmd))
+		}
+	}()
+
+	disconnectCh := make(chan struct{})
+	qmp, _, err := govmmQemu.QMPStartWithConn(context.Background(), client, govmmQemu.QMPConfig{Logger: newQMPLogger()}, disconnectCh)
+	assert.NoError(t, err)
+
+	q.qmpMonitorCh.ctx = context.Background()
+	q.qmpMonitorCh.qmp = qmp
+	q.qmpMonitorCh.disconn = disconnectCh
+	t.Cleanup(qmp.Shutdown)
+}
+
 func newQemuConfig() HypervisorConfig {
 	return HypervisorConfig{
 		KernelPath:          testQemuKernelPath,
@@ -145,6 +184,16 @@ func TestQemuCreateVM(t *testing.T) {
 	config17 := newQemuConfig()
 	config17.VMid = "testSandbox"
 
+	config18 := newQemuConfig()
+	config18.MemoryTHP = MemoryTHPMadvise
+
+	// The madvise policy is rejected on the hosts not supporting it
+	savedThpMadviseSupported := thpMadviseSupported
+	thpMadviseSupported = func() bool { return false }
+	defer func() {
+		thpMadviseSupported = savedThpMadviseSupported
+	}()
+
 	type testData struct {
 		config      HypervisorConfig
 		expectError bool
@@ -168,6 +217,7 @@ func TestQemuCreateVM(t *testing.T) {
 		{config15, false, true},
 		{config16, false, true},
 		{config17, false, true},
+		{config18, true, false},
 	}
 
 	for i, d := range data {
@@ -306,6 +356,13 @@ func TestQemuMemoryTopology(t *testing.T) {
 	memory, err := q.memoryTopology()
 	assert.NoError(err)
 	assert.Exactly(memory, expectedOut)
+
+	// the pmem volumes are mapped above the memory
+	q.config.PmemVolumesSize = 2048
+	expectedOut.MaxMem = fmt.Sprintf("%dM", int(maxMem)+2048)
+	memory, err = q.memoryTopology()
+	assert.NoError(err)
+	assert.Exactly(memory, expectedOut)
 }
 
 func TestQemuKnobs(t *testing.T) {
@@ -769,3 +826,301 @@ func TestQemuStartSandbox(t *testing.T) {
 	err = q.StartVM(context.Background(), 10)
 	assert.Error(err)
 }
+
+func TestQemuAppendUSBDevices(t *testing.T) {
+	assert := assert.New(t)
+
+	q := &qemu{
+		config: HypervisorConfig{
+			USBDevices: []string{"18d1:4ee7", "1-1.2"},
+		},
+	}
+
+	devices, err := q.appendUSBDevices(nil)
+	assert.NoError(err)
+	assert.Equal([]govmmQemu.Device{
+		govmmQemu.XHCIDevice{ID: xhciID},
+		govmmQemu.USBHostDevice{ID: "usb0", Bus: "xhci0.0", VendorID: "0x18d1", ProductID: "0x4ee7"},
+		govmmQemu.USBHostDevice{ID: "usb1", Bus: "xhci0.0", HostBus: "1", HostPort: "1.2"},
+	}, devices)
+
+	for _, dev := range []string{"", "18d1", "18d1:4ee7a", "1-", "1-1..2", "/dev/bus/usb/001/002"} {
+		_, err = parseUSBDevice(dev)
+		assert.Error(err, dev)
+	}
+}
+
+func TestPCIMMIO64WindowSize(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(uint64(0), roundUpPowerOf2(0))
+	assert.Equal(uint64(1), roundUpPowerOf2(1))
+	assert.Equal(uint64(64<<30), roundUpPowerOf2(64<<30))
+	assert.Equal(uint64(256<<30), roundUpPowerOf2(128<<30+32<<20))
+
+	// no device
+	assert.Equal(uint64(0), pciMMIO64WindowSize(0, 0, 2<<20))
+
+	// the BARs of the cold plugged devices
+	assert.Equal(uint64(512<<30), pciMMIO64WindowSize(2*(256<<30), 2, 2<<20))
+
+	// the windows reserved by the ports for the devices hotplugged later
+	assert.Equal(uint64(1<<40), pciMMIO64WindowSize(256<<30, 4, 256<<30))
+}
+
+func TestQemuSetGPUDirectClique(t *testing.T) {
+	assert := assert.New(t)
+
+	savedPath := config.SysBusPciDevicesPath
+	defer func() {
+		config.SysBusPciDevicesPath = savedPath
+	}()
+	dir := t.TempDir()
+	config.SysBusPciDevicesPath = filepath.Join(dir, "bus")
+	assert.NoError(os.MkdirAll(config.SysBusPciDevicesPath, 0755))
+
+	// addDevice adds a device behind a downstream port of a switch, the
+	// ports having no ACS
+	addDevice := func(downstreamPort, bdf, vendor string) {
+		devicePath := filepath.Join(dir, "devices", downstreamPort, bdf)
+		assert.NoError(os.MkdirAll(devicePath, 0755))
+		assert.NoError(os.WriteFile(filepath.Join(devicePath, "vendor"), []byte(vendor+"\n"), 0644))
+		assert.NoError(os.WriteFile(filepath.Join(devicePath, "..", "config"), make([]byte, 4096), 0644))
+		assert.NoError(os.Symlink(devicePath, filepath.Join(config.SysBusPciDevicesPath, bdf)))
+	}
+	addDevice("pci0000:00/0000:00:01.0/0000:01:00.0/0000:02:00.0", "0000:03:00.0", nvidiaVendorID)
+	addDevice("pci0000:00/0000:00:01.0/0000:01:00.0/0000:02:01.0", "0000:04:00.0", nvidiaVendorID)
+	addDevice("pci0000:00/0000:00:01.0/0000:01:00.0/0000:02:02.0", "0000:05:00.0", "0x15b3")
+	addDevice("pci0000:80/0000:80:01.0/0000:81:00.0/0000:82:00.0", "0000:83:00.0", nvidiaVendorID)
+
+	newDevice := func(bdf string) *config.VFIODev {
+		return &config.VFIODev{BDF: bdf, Type: config.VFIOPCIDeviceNormalType, IsPCIe: true}
+	}
+
+	q := &qemu{}
+
+	// disabled
+	gpu := newDevice("0000:03:00.0")
+	assert.NoError(q.setGPUDirectClique(gpu))
+	assert.Empty(gpu.GPUDirectClique)
+
+	q.config.PCIeP2P = true
+	for _, d := range []struct {
+		bdf    string
+		clique string
+	}{
+		{"0000:03:00.0", "0"},
+		{"0000:83:00.0", "1"},
+		{"0000:04:00.0", "0"},
+		// the NIC of the switch needs no clique
+		{"0000:05:00.0", ""},
+	} {
+		dev := newDevice(d.bdf)
+		assert.NoError(q.setGPUDirectClique(dev))
+		assert.Equal(d.clique, dev.GPUDirectClique, d.bdf)
+	}
+
+	// a GPU on a root port
+	addDevice("pci0000:00/0000:00:02.0", "0000:06:00.0", nvidiaVendorID)
+	assert.Error(q.setGPUDirectClique(newDevice("0000:06:00.0")))
+}
+
+func TestPCIHotplugMode(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	kernelPath := filepath.Join(dir, "vmlinux.container")
+	assert.NoError(os.WriteFile(filepath.Join(dir, "vmlinux-5.4.60-90"), nil, 0644))
+	assert.NoError(os.Symlink("vmlinux-5.4.60-90", kernelPath))
+
+	major, minor, ok := guestKernelVersion(kernelPath)
+	assert.True(ok)
+	assert.Equal(5, major)
+	assert.Equal(4, minor)
+
+	_, _, ok = guestKernelVersion("/usr/share/kata-containers/vmlinux.container")
+	assert.False(ok)
+
+	conf := &HypervisorConfig{
+		HypervisorMachineType: QemuQ35,
+		KernelPath:            kernelPath,
+	}
+	assert.Equal(PCIHotplugNative, PCIHotplugMode(conf))
+
+	conf.PCIHotplugMode = PCIHotplugACPI
+	assert.Equal(PCIHotplugACPI, PCIHotplugMode(conf))
+
+	conf.PCIHotplugMode = PCIHotplugAuto
+	conf.KernelPath = "/usr/share/kata-containers/vmlinuz-6.1.38-114-nvidia-gpu"
+	assert.Equal(PCIHotplugACPI, PCIHotplugMode(conf))
+
+	// unknown guest kernel
+	conf.KernelPath = "/usr/share/kata-containers/vmlinux.container"
+	assert.Equal(PCIHotplugACPI, PCIHotplugMode(conf))
+
+	conf.HypervisorMachineType = QemuVirt
+	assert.Empty(PCIHotplugMode(conf))
+}
+
+func TestQemuPCITopology(t *testing.T) {
+	assert := assert.New(t)
+
+	bridges := []types.Bridge{types.NewBridge(types.PCI, "pci-bridge-0", make(map[uint32]string), 0)}
+	devices := genericAppendBridges(nil, bridges, QemuQ35)
+	devices = genericAppendPCIeRootPort(devices, 2, QemuQ35, 0, 0)
+	devices = genericAppendPCIeSwitchPort(devices, 2, QemuQ35, 0, 0)
+
+	topo, err := qemuPCITopology(devices)
+	assert.NoError(err)
+
+	q := &qemu{pciTopology: topo}
+	for _, d := range []struct {
+		bus      string
+		slot     string
+		expected string
+	}{
+		{"pci-bridge-0", "03", "02/03"},
+		{"rp0", "00", "1d/00"},
+		{"rp1", "00", "1c/00"},
+		{"swdp1", "00", "1e/00/01/00"},
+	} {
+		slot, err := types.PciSlotFromString(d.slot)
+		assert.NoError(err)
+		path, err := q.predictPciPath(d.bus, slot)
+		assert.NoError(err)
+		assert.Equal(d.expected, path.String(), "bus %s", d.bus)
+	}
+
+	// too many root ports to fit below the switch
+	devices = genericAppendPCIeRootPort(nil, pcieSwitchRootPortAddr+1, QemuQ35, 0, 0)
+	_, err = qemuPCITopology(devices)
+	assert.Error(err)
+
+	// a runtime which did not create the VM knows the bridges
+	q = &qemu{state: QemuState{Bridges: bridges}}
+	slot, err := types.PciSlotFromString("05")
+	assert.NoError(err)
+	path, err := q.predictPciPath("pci-bridge-0", slot)
+	assert.NoError(err)
+	assert.Equal("02/05", path.String())
+}
+
+func TestQemuResizeBalloon(t *testing.T) {
+	assert := assert.New(t)
+
+	var balloon []float64
+	q := &qemu{config: newQemuConfig()}
+	startQMPTest(t, q, func(cmd qmpTestCommand) string {
+		if cmd.Execute == "balloon" {
+			balloon = append(balloon, cmd.Arguments["value"].(float64))
+		}
+		return `{"return": {}}`
+	})
+
+	// The balloon is inflated to keep the guest within a lower limit
+	assert.NoError(q.resizeBalloon(1024, 2048))
+	// and deflated, the guest getting all of its memory, when it grows
+	assert.NoError(q.resizeBalloon(4096, 2048))
+	assert.Equal([]float64{1024 << utils.MibToBytesShift, 2048 << utils.MibToBytesShift}, balloon)
+
+	q = &qemu{config: newQemuConfig()}
+	startQMPTest(t, q, func(cmd qmpTestCommand) string {
+		return `{"error": {"class": "DeviceNotActive", "desc": "No balloon device has been activated"}}`
+	})
+	assert.Error(q.resizeBalloon(1024, 2048))
+}
+
+func TestQemuHasBalloon(t *testing.T) {
+	assert := assert.New(t)
+
+	q := &qemu{
+		config: HypervisorConfig{ReclaimGuestFreedMemory: true},
+		arch:   &qemuArchBase{qemuMachine: govmmQemu.Machine{Type: QemuQ35}},
+	}
+	assert.True(q.hasBalloon())
+
+	// there is no balloon on s390x CCW
+	q.arch = &qemuArchBase{qemuMachine: govmmQemu.Machine{Type: QemuCCWVirtio}}
+	assert.False(q.hasBalloon())
+
+	q.config.ReclaimGuestFreedMemory = false
+	q.arch = &qemuArchBase{qemuMachine: govmmQemu.Machine{Type: QemuQ35}}
+	assert.False(q.hasBalloon())
+}
+
+func TestQemuVhostUserGPURestart(t *testing.T) {
+	assert := assert.New(t)
+
+	q := &qemu{
+		id: "testSandbox",
+		config: HypervisorConfig{
+			VMStorePath:     t.TempDir(),
+			VirtioGPUDaemon: "/usr/bin/vhost-user-gpu",
+		},
+	}
+
+	v, err := q.createVhostUserGPU()
+	assert.NoError(err)
+
+	v.onRestart(42)
+	assert.Equal(42, q.state.VirtioGPUDaemonPid)
+
+	// A backend restarted while the VM is stopped is not recorded
+	atomic.StoreInt32(&q.stopped, 1)
+	v.onRestart(43)
+	assert.Equal(42, q.state.VirtioGPUDaemonPid)
+}
+
+func TestQemuStopVMPowerdown(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, powerdown := range []bool{true, false} {
+		// sleep stands for QEMU, the guest powering itself off ends it
+		cmd := exec.Command("sleep", "60")
+		assert.NoError(cmd.Start())
+
+		pidFile := filepath.Join(t.TempDir(), "pid")
+		assert.NoError(os.WriteFile(pidFile, []byte(strconv.Itoa(cmd.Process.Pid)), 0600))
+
+		q := &qemu{
+			id: "testSandbox",
+			config: HypervisorConfig{
+				VMStorePath:         t.TempDir(),
+				RunStorePath:        t.TempDir(),
+				ShutdownGracePeriod: 5,
+			},
+			qemuConfig: govmmQemu.Config{PidFile: pidFile},
+		}
+
+		var commands []string
+		startQMPTest(t, q, func(c qmpTestCommand) string {
+			commands = append(commands, c.Execute)
+			if c.Execute != "system_powerdown" {
+				return `{"return": {}}`
+			}
+			if !powerdown {
+				return `{"error": {"class": "GenericError", "desc": "powerdown failed"}}`
+			}
+			cmd.Process.Signal(syscall.SIGTERM)
+			return `{"return": {}}` + "\n" + `{"event": "POWERDOWN", "timestamp": {"seconds": 1, "microseconds": 0}}`
+		})
+
+		assert.NoError(q.StopVM(context.Background(), false))
+		assert.Equal([]string{"system_powerdown"}, commands)
+		assert.Equal(int32(1), atomic.LoadInt32(&q.stopped))
+
+		if powerdown {
+			// QEMU was waited for, and reaped, once the guest
+			// powered itself off
+			assert.Equal(syscall.ESRCH, syscall.Kill(cmd.Process.Pid, 0))
+			continue
+		}
+
+		// QEMU is killed when the guest was not powered off
+		err := cmd.Wait()
+		exitErr, ok := err.(*exec.ExitError)
+		if assert.True(ok) {
+			assert.Equal(syscall.SIGKILL, exitErr.Sys().(syscall.WaitStatus).Signal())
+		}
+	}
+}
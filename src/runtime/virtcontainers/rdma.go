@@ -0,0 +1,96 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// The RDMA device nodes of the host, e.g. the ones given by the RDMA device
+// plugins, are of no use in the guest. The VFs they belong to are passed
+// through with VFIO, either as VFIO devices or as physical network
+// endpoints, and the agent gives the containers the device nodes the guest
+// drivers create for them instead.
+const (
+	// path to the RDMA device nodes
+	rdmaDevDir = "/dev/infiniband"
+
+	// prefix of the verbs device nodes, the only ones bound to an RDMA
+	// device, the connection manager node is shared by all of them
+	rdmaVerbsDevPrefix = "uverbs"
+
+	rdmaOptionBDF        = "bdf"
+	rdmaOptionMAC        = "mac"
+	rdmaOptionDriver     = "driver"
+	rdmaOptionHcaHandles = "hca_handle"
+	rdmaOptionHcaObjects = "hca_object"
+)
+
+// path to the sysfs directory of the host verbs devices
+var sysInfinibandVerbsPath = "/sys/class/infiniband_verbs"
+
+func isRDMADevice(path string) bool {
+	return strings.HasPrefix(path, rdmaDevDir+"/")
+}
+
+// rdmaDeviceOptions returns the agent options of the RDMA device node at
+// path: the host PCI address, hardware address and driver of the device it
+// belongs to, to find the matching guest device and load its driver, and the
+// rdma cgroup limits the container has for it.
+func rdmaDeviceOptions(path string, limits map[string]specs.LinuxRdma) ([]string, error) {
+	name := filepath.Base(path)
+	if !strings.HasPrefix(name, rdmaVerbsDevPrefix) {
+		return nil, nil
+	}
+
+	sysPath := filepath.Join(sysInfinibandVerbsPath, name)
+	device, err := filepath.EvalSymlinks(filepath.Join(sysPath, "device"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the device of RDMA node %s: %w", path, err)
+	}
+	driver, err := filepath.EvalSymlinks(filepath.Join(sysPath, "device", "driver"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the driver of RDMA node %s: %w", path, err)
+	}
+
+	options := []string{
+		fmt.Sprintf("%s=%s", rdmaOptionBDF, filepath.Base(device)),
+		fmt.Sprintf("%s=%s", rdmaOptionDriver, filepath.Base(driver)),
+	}
+
+	// The VFs passed through as physical network endpoints are not
+	// VFIO devices of the container, the agent finds them by the hardware
+	// address of their interface.
+	netPath := filepath.Join(device, "net")
+	if ifaces, err := os.ReadDir(netPath); err == nil && len(ifaces) > 0 {
+		mac, err := os.ReadFile(filepath.Join(netPath, ifaces[0].Name(), "address"))
+		if err == nil {
+			options = append(options, fmt.Sprintf("%s=%s", rdmaOptionMAC, strings.TrimSpace(string(mac))))
+		}
+	}
+
+	// The rdma cgroup limits are given by host device name, the agent
+	// applies them to the guest one.
+	ibdev, err := os.ReadFile(filepath.Join(sysPath, "ibdev"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the RDMA device of node %s: %w", path, err)
+	}
+	if limit, ok := limits[strings.TrimSpace(string(ibdev))]; ok {
+		if limit.HcaHandles != nil {
+			options = append(options, fmt.Sprintf("%s=%d", rdmaOptionHcaHandles, *limit.HcaHandles))
+		}
+		if limit.HcaObjects != nil {
+			options = append(options, fmt.Sprintf("%s=%d", rdmaOptionHcaObjects, *limit.HcaObjects))
+		}
+	}
+
+	return options, nil
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/config"
+	deviceManager "github.com/kata-containers/kata-containers/src/runtime/pkg/device/manager"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist"
+)
+
+// Resource hooks are executables called, in order, when a sandbox is
+// created, before anything is sized on its resources: the sandbox resource
+// topology is written on their standard input and the adjustments they
+// request, if any, are read from their standard output. Each hook sees the
+// topology adjusted by the previous ones, and a failing hook fails the
+// creation of the sandbox.
+
+// resourceHookCommand is the command the resource hooks are called with.
+const resourceHookCommand = "adjust-sandbox"
+
+// resourceHookTimeout is how long a resource hook can run for.
+var resourceHookTimeout = 5 * time.Second
+
+// ResourceDevice is a host device passed through to a sandbox.
+type ResourceDevice struct {
+	ContainerID   string `json:"container_id"`
+	HostPath      string `json:"host_path"`
+	ContainerPath string `json:"container_path"`
+}
+
+// ResourceTopology is the resource topology of a sandbox published to the
+// resource hooks.
+type ResourceTopology struct {
+	ID                 string            `json:"id"`
+	CPUs               string            `json:"cpus"`
+	Mems               string            `json:"mems"`
+	Annotations        map[string]string `json:"annotations"`
+	Devices            []ResourceDevice  `json:"devices"`
+	VCPUs              uint32            `json:"vcpus"`
+	MemoryMB           uint32            `json:"memory_mb"`
+	EnableVCPUsPinning bool              `json:"enable_vcpus_pinning"`
+}
+
+// ResourceAdjustment is the adjustment of the sandbox resources a resource
+// hook requests, the unset fields are left unchanged.
+type ResourceAdjustment struct {
+	VCPUs              *uint32 `json:"vcpus,omitempty"`
+	MemoryMB           *uint32 `json:"memory_mb,omitempty"`
+	EnableVCPUsPinning *bool   `json:"enable_vcpus_pinning,omitempty"`
+}
+
+// resourceTopology returns the resource topology of the sandbox.
+func (s *Sandbox) resourceTopology() (ResourceTopology, error) {
+	cpus, mems, err := s.getSandboxCPUSet()
+	if err != nil {
+		return ResourceTopology{}, err
+	}
+
+	topology := ResourceTopology{
+		ID:                 s.id,
+		CPUs:               cpus,
+		Mems:               mems,
+		Annotations:        s.config.Annotations,
+		Devices:            []ResourceDevice{},
+		VCPUs:              s.config.HypervisorConfig.NumVCPUs,
+		MemoryMB:           s.config.HypervisorConfig.MemorySize,
+		EnableVCPUsPinning: s.config.EnableVCPUsPinning,
+	}
+
+	for _, c := range s.config.Containers {
+		for _, device := range c.DeviceInfos {
+			if !deviceManager.IsVFIO(device.HostPath) ||
+				s.config.hostDevicePolicy(device.ContainerPath) != config.HostDevicePassthrough {
+				continue
+			}
+			topology.Devices = append(topology.Devices, ResourceDevice{
+				ContainerID:   c.ID,
+				HostPath:      device.HostPath,
+				ContainerPath: device.ContainerPath,
+			})
+		}
+	}
+
+	return topology, nil
+}
+
+// apply applies the adjustment to the topology.
+func (a *ResourceAdjustment) apply(topology *ResourceTopology) error {
+	if a.VCPUs != nil {
+		if *a.VCPUs == 0 {
+			return fmt.Errorf("invalid number of vCPUs 0")
+		}
+		topology.VCPUs = *a.VCPUs
+	}
+	if a.MemoryMB != nil {
+		if *a.MemoryMB < MinHypervisorMemory {
+			return fmt.Errorf("invalid memory size %d MiB, the minimum is %d MiB", *a.MemoryMB, MinHypervisorMemory)
+		}
+		topology.MemoryMB = *a.MemoryMB
+	}
+	if a.EnableVCPUsPinning != nil {
+		topology.EnableVCPUsPinning = *a.EnableVCPUsPinning
+	}
+
+	return nil
+}
+
+// runResourceHook calls the resource hook with the topology and returns the
+// adjustment it requests.
+func runResourceHook(ctx context.Context, hook string, topology ResourceTopology) (ResourceAdjustment, error) {
+	var adjustment ResourceAdjustment
+
+	input, err := json.Marshal(topology)
+	if err != nil {
+		return adjustment, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, resourceHookTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, hook, resourceHookCommand)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return adjustment, fmt.Errorf("resource hook %s failed: %v: %s", hook, err, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	if len(bytes.TrimSpace(stdout.Bytes())) == 0 {
+		return adjustment, nil
+	}
+
+	if err := json.Unmarshal(stdout.Bytes(), &adjustment); err != nil {
+		return adjustment, fmt.Errorf("invalid adjustment from resource hook %s: %v", hook, err)
+	}
+
+	return adjustment, nil
+}
+
+// runResourceHooks publishes the sandbox resource topology to the resource
+// hooks and applies the adjustments they request.
+func (s *Sandbox) runResourceHooks(ctx context.Context) error {
+	if len(s.config.ResourceHooks) == 0 {
+		return nil
+	}
+
+	topology, err := s.resourceTopology()
+	if err != nil {
+		return err
+	}
+
+	for _, hook := range s.config.ResourceHooks {
+		adjustment, err := runResourceHook(ctx, hook, topology)
+		if err != nil {
+			return err
+		}
+		if err := adjustment.apply(&topology); err != nil {
+			return fmt.Errorf("invalid adjustment from resource hook %s: %v", hook, err)
+		}
+	}
+
+	s.Logger().WithField("topology", topology).Info("sandbox resources adjusted by the resource hooks")
+
+	s.config.HypervisorConfig.NumVCPUs = topology.VCPUs
+	s.config.HypervisorConfig.MemorySize = topology.MemoryMB
+	s.config.EnableVCPUsPinning = topology.EnableVCPUsPinning
+
+	return nil
+}
+
+// sandboxPersisted tells if the state of the sandbox was persisted, the
+// sandbox being restored rather than created.
+func sandboxPersisted(id string) bool {
+	store, err := persist.GetDriver()
+	if err != nil || store == nil {
+		return false
+	}
+	_, _, err = store.FromDisk(id)
+	return err == nil
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeResourceHook(t *testing.T, name, script string) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0755))
+	return path
+}
+
+func TestRunResourceHooks(t *testing.T) {
+	assert := assert.New(t)
+
+	input := filepath.Join(t.TempDir(), "input")
+	s := &Sandbox{
+		id: "sandbox",
+		config: &SandboxConfig{
+			HypervisorConfig: HypervisorConfig{
+				NumVCPUs:   1,
+				MemorySize: 2048,
+			},
+			Containers: []ContainerConfig{
+				{
+					ID: "gpu",
+					DeviceInfos: []config.DeviceInfo{
+						{HostPath: "/dev/vfio/12", ContainerPath: "/dev/vfio/12"},
+						{HostPath: "/dev/null", ContainerPath: "/dev/null"},
+					},
+				},
+			},
+		},
+	}
+
+	// Nothing is adjusted without hooks
+	assert.NoError(s.runResourceHooks(context.Background()))
+	assert.Equal(uint32(1), s.config.HypervisorConfig.NumVCPUs)
+
+	// Each hook sees the adjustments of the previous ones
+	s.config.ResourceHooks = []string{
+		writeResourceHook(t, "vcpus", `echo '{"vcpus": 4, "enable_vcpus_pinning": true}'`),
+		writeResourceHook(t, "noop", "cat > "+input),
+		writeResourceHook(t, "memory", `[ "$1" = adjust-sandbox ] && echo '{"memory_mb": 4096}'`),
+	}
+	assert.NoError(s.runResourceHooks(context.Background()))
+	assert.Equal(uint32(4), s.config.HypervisorConfig.NumVCPUs)
+	assert.Equal(uint32(4096), s.config.HypervisorConfig.MemorySize)
+	assert.True(s.config.EnableVCPUsPinning)
+
+	topology, err := os.ReadFile(input)
+	assert.NoError(err)
+	assert.JSONEq(`{"id": "sandbox", "cpus": "", "mems": "", "annotations": null,
+		"devices": [{"container_id": "gpu", "host_path": "/dev/vfio/12", "container_path": "/dev/vfio/12"}],
+		"vcpus": 4, "memory_mb": 2048, "enable_vcpus_pinning": true}`, string(topology))
+
+	// Failing hooks and invalid adjustments fail the sandbox creation
+	for _, script := range []string{
+		"exit 1",
+		"echo '{'",
+		`echo '{"vcpus": 0}'`,
+		`echo '{"memory_mb": 64}'`,
+	} {
+		s.config.ResourceHooks = []string{writeResourceHook(t, "hook", script)}
+		assert.Error(s.runResourceHooks(context.Background()), script)
+	}
+}
@@ -32,6 +32,8 @@ import (
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/config"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/drivers"
 	deviceManager "github.com/kata-containers/kata-containers/src/runtime/pkg/device/manager"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/multipath"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/faultinject"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils/katatrace"
 	resCtrl "github.com/kata-containers/kata-containers/src/runtime/pkg/resourcecontrol"
 	exp "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/experimental"
@@ -111,7 +113,10 @@ type SandboxStatus struct {
 // SandboxStats describes a sandbox's stats
 type SandboxStats struct {
 	CgroupStats CgroupStats
-	Cpus        int
+	// HypervisorThreads breaks the CPU usage of the hypervisor down by
+	// thread role
+	HypervisorThreads []HypervisorThreadStats
+	Cpus              int
 }
 
 type SandboxResourceSizing struct {
@@ -133,6 +138,10 @@ type SandboxConfig struct {
 	// Custom SELinux security policy to the container process inside the VM
 	GuestSeLinuxLabel string
 
+	// GuestSeccompMode selects how the seccomp profile of the containers
+	// is applied within the guest, enforce when empty
+	GuestSeccompMode string
+
 	HypervisorType HypervisorType
 
 	ID string
@@ -145,6 +154,14 @@ type SandboxConfig struct {
 	// Experimental features enabled
 	Experimental []exp.Feature
 
+	// HostDevicePolicies selects how the OCI spec host devices are
+	// made available to the containers
+	HostDevicePolicies []config.HostDevicePolicyRule
+
+	// KernelParamsRules add guest kernel parameters according to the
+	// resources of the sandbox
+	KernelParamsRules []KernelParamsRule
+
 	// Containers describe the list of containers within a Sandbox.
 	// This list can be empty and populated by adding containers
 	// to the Sandbox a posteriori.
@@ -178,8 +195,143 @@ type SandboxConfig struct {
 	// DisableGuestSeccomp disable seccomp within the guest
 	DisableGuestSeccomp bool
 
+	// GuestSeccompReport collects the system calls blocked by seccomp
+	// within the guest
+	GuestSeccompReport bool
+
+	// GuestPidsLimit is the maximum number of processes within the guest
+	// of the containers without a pids limit of their own, 0 for none
+	GuestPidsLimit uint64
+
+	// MultipathEvents reports the path failures of the multipath maps
+	// attached to the sandbox
+	MultipathEvents bool
+
+	// StopFlushTimeout is how long in seconds the filesystems and the
+	// drives of the containers are flushed for when they stop, 0 for not
+	// flushing them
+	StopFlushTimeout uint32
+
+	// ConfirmExecTimeout is how long in seconds the start of a container
+	// waits for the agent to confirm its process executed its entrypoint,
+	// 0 for not waiting
+	ConfirmExecTimeout uint32
+
+	// ForensicSnapshotThreshold is the number of nonzero exits of a
+	// container after which a diagnostic snapshot is captured at each of
+	// its exits, 0 for none
+	ForensicSnapshotThreshold uint32
+
+	// ForensicSnapshotCount is the number of snapshots kept, the default
+	// when 0
+	ForensicSnapshotCount uint32
+
+	// GuestNameResolution makes the agent manage the /etc/hosts and
+	// /etc/resolv.conf files of the containers, rather than sharing the
+	// host files
+	GuestNameResolution bool
+
+	// Profile is the profile of the sandbox, see LowLatencyProfile
+	Profile string
+
+	// Pauseless does not create the sandbox container inside the guest,
+	// the first container created is the init task of the sandbox
+	Pauseless bool
+
+	// EntitlementsPath is the host directory of the entitlements, e.g.
+	// licenses or certificates, provisioned inside the guest
+	EntitlementsPath string
+
+	// Metadata is the metadata of the pod served inside the guest, nil
+	// for not serving it
+	Metadata *SandboxMetadata
+
+	// GuestServices are the systemd units of the guest image the agent
+	// starts and health-checks, see GuestServices
+	GuestServices []string
+
+	// NetworkPolicy is the network policy enforced inside the guest, nil
+	// for not enforcing one. It is only set from the host, on the
+	// network-policy endpoint of the shim, and enforced again when the VM
+	// restarts.
+	NetworkPolicy *NetworkPolicy
+
+	// NetworkPolicyObject is the host path of the eBPF object enforcing
+	// the network policy
+	NetworkPolicyObject string
+
+	// SeccompNotify lets the seccomp profiles of the containers trap
+	// system calls with SCMP_ACT_NOTIFY rules, the notifications being
+	// responded to by a host policy daemon
+	SeccompNotify bool
+
+	// ResourceHooks are the executables adjusting the sandbox resources
+	// when it is created
+	ResourceHooks []string
+
+	// Placement is the policy placing the sandbox on a host domain, see
+	// PlacementSpread and PlacementPack, no placement when empty
+	Placement string
+
+	// PlacementDomain is the type of the host domains, see
+	// PlacementDomainNUMA and PlacementDomainL3
+	PlacementDomain string
+
+	// WasmRuntime is the WASM runtime of the guest running the WASM
+	// containers, see delegateWasm
+	WasmRuntime string
+
+	// NestedContainers lets the containers run a container engine inside
+	// the guest, see setNestedContainers
+	NestedContainers bool
+
+	// ShmChannel is the shared memory channel the sandbox joins, scoped to
+	// the namespace of the pod, see joinShmChannel
+	ShmChannel string
+
+	// ImageVolumePaths are the patterns of the host paths of the image
+	// volumes, see attachImageVolume
+	ImageVolumePaths []string
+
+	// PlacementNUMANodes is the list of the host NUMA nodes the sandbox
+	// should be placed on, e.g. the topology manager hint of the pod
+	PlacementNUMANodes string
+
+	// CoreDump is the core dump policy of the sandbox containers
+	CoreDump CoreDumpConfig
+
+	// TmpfsSizing is the sizing policy of the tmpfs of the guest
+	TmpfsSizing TmpfsSizing
+
+	// SRIOVVFConfigs are the host side configurations of the SR-IOV VFs
+	// passed through as network endpoints, by network interface name
+	SRIOVVFConfigs map[string]SRIOVVFConfig
+
 	// EnableVCPUsPinning controls whether each vCPU thread should be scheduled to a fixed CPU
 	EnableVCPUsPinning bool
+
+	// VMMSchedClass is the scheduling class of the VMM threads, see VMMSchedLatency
+	// and VMMSchedBatch
+	VMMSchedClass string
+
+	// VMMSchedRTPriority is the realtime priority of the vCPU threads of the
+	// latency scheduling class
+	VMMSchedRTPriority int
+
+	// VMMLimits are the resource limits of the VMM and auxiliary processes
+	VMMLimits VMMLimits
+
+	// BootConcurrency is the number of sandboxes of the node booting at
+	// once, the other ones waiting for a slot, unlimited when 0
+	BootConcurrency uint32
+
+	// LazyDeviceAttach hotplugs the network interfaces while the guest
+	// boots, when the hypervisor supports it, see lazyDeviceAttach
+	LazyDeviceAttach bool
+
+	// VMRestartPolicy selects if the VM is restarted when it crashes,
+	// never when empty
+	VMRestartPolicy string
 }
 
 // valid checks that the sandbox configuration is valid.
@@ -201,6 +353,48 @@ func (sandboxConfig *SandboxConfig) valid() bool {
 	return true
 }
 
+// hostDevicePolicy returns how the host device at path is made available
+// to the containers. Unless configured otherwise, render nodes are provided
+// by the guest when the VM has a virtio-gpu device, and RDMA device nodes
+// always are.
+func (sandboxConfig *SandboxConfig) hostDevicePolicy(path string) config.HostDevicePolicy {
+	// Default rules come after the configured ones, which take precedence.
+	rules := sandboxConfig.HostDevicePolicies
+	rules = rules[:len(rules):len(rules)]
+
+	if sandboxConfig.HypervisorConfig.VirtioGPU != "" {
+		rules = append(rules, config.HostDevicePolicyRule{
+			Pattern: filepath.Join(virtioGPUDevDir, "*"),
+			Policy:  config.HostDeviceEmulate,
+		})
+	}
+
+	// The guest kernel provides its own SGX device nodes on top of the
+	// EPC section given to the VM.
+	if sandboxConfig.HypervisorConfig.SGXEPCSize > 0 {
+		rules = append(rules, config.HostDevicePolicyRule{
+			Pattern: sgxDevPattern,
+			Policy:  config.HostDeviceEmulate,
+		})
+	}
+
+	// The RDMA device nodes are the ones of the VFs passed through to
+	// the guest.
+	rules = append(rules, config.HostDevicePolicyRule{
+		Pattern: filepath.Join(rdmaDevDir, "*"),
+		Policy:  config.HostDeviceEmulate,
+	})
+
+	// The user-space filesystems of the containers are served by the guest
+	// kernel, under the same major and minor numbers as on the host.
+	rules = append(rules, config.HostDevicePolicyRule{
+		Pattern: fuseDevPath,
+		Policy:  config.HostDeviceGuestNode,
+	})
+
+	return config.GetHostDevicePolicy(rules, path)
+}
+
 // Sandbox is composed of a set of containers and a runtime environment.
 // A Sandbox can be created, deleted, started, paused, stopped, listed, entered, and restored.
 type Sandbox struct {
@@ -215,11 +409,36 @@ type Sandbox struct {
 	swapDevices []*config.BlockDrive
 	volumes     []types.Volume
 
+	hotplugLatencies hotplugLatencies
+
 	monitor         *monitor
 	config          *SandboxConfig
 	annotationsLock *sync.RWMutex
 	wg              *sync.WaitGroup
 	cw              *consoleWatcher
+	seccompReport   *seccompReport
+
+	multipathWatcher *multipath.Watcher
+	multipathCancel  context.CancelFunc
+	coreDumpCancel   context.CancelFunc
+
+	nameResolution nameResolution
+	entitlements   entitlements
+	forensics      forensics
+	vmmLimitAlerts vmmLimitAlerts
+
+	// undo releases the resources allocated by a failing creation
+	undo undoStack
+
+	// lazyAttach reports the end of the hotplug of the network
+	// interfaces while the guest boots
+	lazyAttach *lazyAttach
+
+	// pauseless is the sandbox container of a pause-less sandbox
+	pauseless *pauselessSandbox
+
+	// placement is the host domain of the sandbox
+	placement *hostDomain
 
 	sandboxController  resCtrl.ResourceController
 	overheadController resCtrl.ResourceController
@@ -238,10 +457,17 @@ type Sandbox struct {
 	shmSize       uint64
 	swapDeviceNum uint
 
+	// restarts is the number of times the VM was restarted
+	restarts int
+
 	sharePidNs        bool
 	seccompSupported  bool
 	disableVMShutdown bool
 	isVCPUsPinningOn  bool
+
+	// pinnedCPUs is the host CPU each vCPU is pinned to, when the vCPUs
+	// are pinned
+	pinnedCPUs []int
 }
 
 // ID returns the sandbox identifier string.
@@ -317,6 +543,22 @@ func (s *Sandbox) GetAllContainers() []VCContainer {
 	return ifa
 }
 
+// isHostPathShared checks if a container of the sandbox other than c shares
+// hostPath with the guest.
+func (s *Sandbox) isHostPathShared(c *Container, hostPath string) bool {
+	for id, container := range s.containers {
+		if id == c.id {
+			continue
+		}
+		for _, m := range container.mounts {
+			if m.HostPath == hostPath {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // GetContainer returns the container named by the containerID.
 func (s *Sandbox) GetContainer(containerID string) VCContainer {
 	if c, ok := s.containers[containerID]; ok {
@@ -390,7 +632,12 @@ func (s *Sandbox) WaitProcess(ctx context.Context, containerID, processID string
 		return 0, err
 	}
 
-	return c.wait(ctx, processID)
+	exitCode, err := c.wait(ctx, processID)
+	if err == nil && processID == containerID {
+		s.recordContainerExit(c, exitCode)
+	}
+
+	return exitCode, err
 }
 
 // SignalProcess sends a signal to a process of a container when all is false.
@@ -474,6 +721,9 @@ func (s *Sandbox) getAndStoreGuestDetails(ctx context.Context) error {
 		s.state.GuestMemoryBlockSizeMB = uint32(guestDetailRes.MemBlockSizeBytes >> 20)
 		if guestDetailRes.AgentDetails != nil {
 			s.seccompSupported = guestDetailRes.AgentDetails.SupportsSeccomp
+			if err := s.checkGuestImageMetadata(guestDetailRes.AgentDetails.ImageMetadata); err != nil {
+				return err
+			}
 		}
 		s.state.GuestMemoryHotplugProbe = guestDetailRes.SupportMemHotplugProbe
 	}
@@ -486,7 +736,7 @@ func (s *Sandbox) getAndStoreGuestDetails(ctx context.Context) error {
 // It will create and store the sandbox structure, and then ask the hypervisor
 // to physically create that sandbox i.e. starts a VM for that sandbox to eventually
 // be started.
-func createSandbox(ctx context.Context, sandboxConfig SandboxConfig, factory Factory) (*Sandbox, error) {
+func createSandbox(ctx context.Context, sandboxConfig SandboxConfig, factory Factory) (_ *Sandbox, err error) {
 	span, ctx := katatrace.Trace(ctx, nil, "createSandbox", sandboxTracingTags, map[string]string{"sandbox_id": sandboxConfig.ID})
 	defer span.End()
 
@@ -514,16 +764,25 @@ func createSandbox(ctx context.Context, sandboxConfig SandboxConfig, factory Fac
 	// The code below only gets called when initially creating a sandbox, not when restoring or
 	// re-creating it. The above check for the sandbox state enforces that.
 
-	if err := s.fsShare.Prepare(ctx); err != nil {
+	defer func() {
+		if err != nil {
+			s.undo.unwind(s.Logger())
+		}
+	}()
+
+	if err = s.fsShare.Prepare(ctx); err != nil {
 		return nil, err
 	}
+	s.undo.push("shared files", func() error {
+		return s.fsShare.Cleanup(ctx)
+	})
 
-	if err := s.agent.createSandbox(ctx, s); err != nil {
+	if err = s.agent.createSandbox(ctx, s); err != nil {
 		return nil, err
 	}
 
 	// Set sandbox state
-	if err := s.setSandboxState(types.StateReady); err != nil {
+	if err = s.setSandboxState(types.StateReady); err != nil {
 		return nil, err
 	}
 
@@ -571,22 +830,79 @@ func newSandbox(ctx context.Context, sandboxConfig SandboxConfig, factory Factor
 		swapDevices:     []*config.BlockDrive{},
 	}
 
-	fsShare, err := NewFilesystemShare(s)
-	if err != nil {
+	// The resources of a new sandbox are adjusted before the kernel
+	// parameters, the placement and the resource controllers are sized on
+	// them. A restored sandbox keeps those of its running VM.
+	if !sandboxPersisted(s.id) {
+		if err := s.runResourceHooks(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if sandboxConfig.guestSeccompReporting() {
+		s.seccompReport = newSeccompReport()
+		// Get the seccomp audit records on the guest console
+		sandboxConfig.HypervisorConfig.KernelParams = append(sandboxConfig.HypervisorConfig.KernelParams,
+			Param{Key: "loglevel", Value: seccompReportKernelLogLevel})
+	}
+
+	sandboxConfig.HypervisorConfig.KernelParams = append(sandboxConfig.HypervisorConfig.KernelParams,
+		sandboxConfig.CoreDump.kernelParams()...)
+	sandboxConfig.HypervisorConfig.KernelParams = append(sandboxConfig.HypervisorConfig.KernelParams,
+		sandboxConfig.lowLatencyKernelParams()...)
+	sandboxConfig.HypervisorConfig.KernelParams = append(sandboxConfig.HypervisorConfig.KernelParams,
+		sandboxConfig.ruleKernelParams()...)
+	sandboxConfig.HypervisorConfig.KernelParams = append(sandboxConfig.HypervisorConfig.KernelParams,
+		sandboxConfig.nestedContainersKernelParams()...)
+
+	if err := sandboxConfig.HypervisorConfig.applyCPUMitigations(); err != nil {
 		return nil, err
 	}
-	s.fsShare = fsShare
 
-	if s.store, err = persist.GetDriver(); err != nil || s.store == nil {
-		return nil, fmt.Errorf("failed to get fs persist driver: %v", err)
+	if sandboxConfig.Pauseless {
+		s.pauseless = newPauselessSandbox()
 	}
+
+	// Release everything allocated so far when the creation fails.
 	defer func() {
 		if retErr != nil {
 			s.Logger().WithError(retErr).Error("Create new sandbox failed")
-			s.store.Destroy(s.id)
+			s.undo.unwind(s.Logger())
 		}
 	}()
 
+	s.startMultipathWatcher()
+	s.undo.push("multipath watcher", func() error {
+		s.stopMultipathWatcher()
+		return nil
+	})
+	// The watcher only lives in the memory of this process, it is also
+	// stopped when the re-creation of a restored sandbox fails.
+	defer func() {
+		if retErr != nil {
+			s.stopMultipathWatcher()
+		}
+	}()
+
+	s.startCoreDumpPruner()
+	s.undo.push("core dump pruner", func() error {
+		s.stopCoreDumpPruner()
+		return nil
+	})
+
+	fsShare, err := NewFilesystemShare(s)
+	if err != nil {
+		return nil, err
+	}
+	s.fsShare = fsShare
+
+	if s.store, err = persist.GetDriver(); err != nil || s.store == nil {
+		return nil, fmt.Errorf("failed to get fs persist driver: %v", err)
+	}
+	s.undo.push("store", func() error {
+		return s.store.Destroy(s.id)
+	})
+
 	sandboxConfig.HypervisorConfig.VMStorePath = s.store.RunVMStoragePath()
 	sandboxConfig.HypervisorConfig.RunStorePath = s.store.RunStoragePath()
 
@@ -597,22 +913,45 @@ func newSandbox(ctx context.Context, sandboxConfig SandboxConfig, factory Factor
 
 	s.devManager = deviceManager.NewDeviceManager(sandboxConfig.HypervisorConfig.BlockDeviceDriver,
 		sandboxConfig.HypervisorConfig.EnableVhostUserStore,
-		sandboxConfig.HypervisorConfig.VhostUserStorePath, sandboxConfig.HypervisorConfig.VhostUserDeviceReconnect, nil)
+		sandboxConfig.HypervisorConfig.VhostUserStorePath, sandboxConfig.HypervisorConfig.VhostUserDeviceReconnect,
+		sandboxConfig.HypervisorConfig.VhostVDPAHookPath,
+		sandboxConfig.HypervisorConfig.ConfidentialGuest && sandboxConfig.HypervisorConfig.RequireTDISPDevices, nil)
+
+	if err := s.place(); err != nil {
+		return nil, err
+	}
+	s.undo.push("placement", s.releasePlacement)
 
 	// Create the sandbox resource controllers.
 	if err := s.createResourceController(); err != nil {
 		return nil, err
 	}
+	if !rootless.IsRootless() {
+		s.undo.push("resource controllers", s.resourceControllerDelete)
+	}
 
 	// Ignore the error. Restore can fail for a new sandbox
 	if err := s.Restore(); err != nil {
 		s.Logger().WithError(err).Debug("restore sandbox failed")
 	}
 
+	// A restored sandbox is live, its resources must outlive a failure
+	// to re-create it in memory.
+	if s.state.State != "" {
+		s.undo.commit()
+	}
+
 	if err := validateHypervisorConfig(&sandboxConfig.HypervisorConfig); err != nil {
 		return nil, err
 	}
 
+	if s.state.State == "" {
+		if err := s.joinShmChannel(); err != nil {
+			return nil, err
+		}
+		s.undo.push("shared memory channel", s.leaveShmChannel)
+	}
+
 	// If we have a confidential guest we need to cold-plug the PCIe VFIO devices
 	// until we have TDISP/IDE PCIe support.
 	coldPlugVFIO := (sandboxConfig.HypervisorConfig.ColdPlugVFIO != config.NoPort)
@@ -621,14 +960,24 @@ func newSandbox(ctx context.Context, sandboxConfig SandboxConfig, factory Factor
 	hotPlugVFIO := (sandboxConfig.HypervisorConfig.HotPlugVFIO != config.NoPort)
 
 	var vfioDevices []config.DeviceInfo
-	// vhost-user-block device is a PCIe device in Virt, keep track of it
-	// for correct number of PCIe root ports.
+	// vhost-user-block and vhost-vdpa block devices are PCIe devices in
+	// Virt, keep track of them for correct number of PCIe root ports.
 	var vhostUserBlkDevices []config.DeviceInfo
 
 	for cnt, containers := range sandboxConfig.Containers {
 		for dev, device := range containers.DeviceInfos {
+			if sandboxConfig.hostDevicePolicy(device.ContainerPath) != config.HostDevicePassthrough {
+				continue
+			}
+
+			// The vhost-vdpa devices are told apart by their name on the
+			// host, the container may see them under any path.
+			hostPath, err := config.GetHostPathFunc(device, sandboxConfig.HypervisorConfig.EnableVhostUserStore, sandboxConfig.HypervisorConfig.VhostUserStorePath)
+			if err != nil {
+				return nil, err
+			}
 
-			if deviceManager.IsVhostUserBlk(device) {
+			if deviceManager.IsVhostUserBlk(device) || deviceManager.IsVhostVDPA(hostPath) {
 				vhostUserBlkDevices = append(vhostUserBlkDevices, device)
 				continue
 			}
@@ -663,6 +1012,9 @@ func newSandbox(ctx context.Context, sandboxConfig SandboxConfig, factory Factor
 	if err = s.hypervisor.CreateVM(ctx, s.id, s.network, &sandboxConfig.HypervisorConfig); err != nil {
 		return nil, err
 	}
+	s.undo.push("hypervisor", func() error {
+		return s.hypervisor.Cleanup(ctx)
+	})
 
 	if s.disableVMShutdown, err = s.agent.init(ctx, s, sandboxConfig.AgentConfig); err != nil {
 		return nil, err
@@ -673,11 +1025,17 @@ func newSandbox(ctx context.Context, sandboxConfig SandboxConfig, factory Factor
 	}
 
 	for _, dev := range vfioDevices {
-		_, err := s.AddDevice(ctx, dev)
+		d, err := s.AddDevice(ctx, dev)
 		if err != nil {
 			s.Logger().WithError(err).Debug("Cannot cold-plug add device")
 			return nil, err
 		}
+		s.undo.push("device "+d.DeviceID(), func() error {
+			if err := s.devManager.DetachDevice(ctx, d.DeviceID(), s); err != nil {
+				return err
+			}
+			return s.devManager.RemoveDevice(d.DeviceID())
+		})
 	}
 	return s, nil
 }
@@ -898,20 +1256,39 @@ func (s *Sandbox) Delete(ctx context.Context) error {
 		s.monitor.stop()
 	}
 
+	s.stopMultipathWatcher()
+	s.stopCoreDumpPruner()
+
 	if err := s.hypervisor.Cleanup(ctx); err != nil {
 		s.Logger().WithError(err).Error("failed to Cleanup hypervisor")
 	}
 
+	s.removeVTPMState()
+
 	if err := s.fsShare.Cleanup(ctx); err != nil {
 		s.Logger().WithError(err).Error("failed to cleanup share files")
 	}
 
+	s.pruneCoreDumps()
+
+	if err := s.releasePlacement(); err != nil {
+		s.Logger().WithError(err).Error("failed to release the sandbox placement")
+	}
+
+	if err := s.leaveShmChannel(); err != nil {
+		s.Logger().WithError(err).Error("failed to leave the shared memory channel")
+	}
+
+	// The snapshots are written in the sandbox state directory.
+	s.waitForensicSnapshots()
+
 	return s.store.Destroy(s.id)
 }
 
 func (s *Sandbox) createNetwork(ctx context.Context) error {
+	// The simulated network needs no network namespace.
 	if s.config.NetworkConfig.DisableNewNetwork ||
-		s.config.NetworkConfig.NetworkID == "" {
+		(s.config.NetworkConfig.NetworkID == "" && !s.config.NetworkConfig.Simulated) {
 		return nil
 	}
 
@@ -920,8 +1297,8 @@ func (s *Sandbox) createNetwork(ctx context.Context) error {
 	katatrace.AddTags(span, "network", s.network, "NetworkConfig", s.config.NetworkConfig)
 
 	// In case there is a factory, network interfaces are hotplugged
-	// after the vm is started.
-	if s.factory != nil {
+	// after the vm is started, as they are with a lazy device attach.
+	if s.factory != nil || s.lazyDeviceAttach(ctx) {
 		return nil
 	}
 
@@ -938,6 +1315,12 @@ func (s *Sandbox) postCreatedNetwork(ctx context.Context) error {
 		return nil
 	}
 
+	// The endpoints are still being added, and their vhost fds handed to
+	// the hypervisor, while the network interfaces are hotplugged.
+	if err := s.waitLazyAttach(); err != nil {
+		return err
+	}
+
 	if s.network.Endpoints() == nil {
 		return nil
 	}
@@ -1127,6 +1510,10 @@ func (cw *consoleWatcher) start(s *Sandbox) (err error) {
 				"sandbox":          s.id,
 				"vmconsole":        scanner.Text(),
 			}).Debug("reading guest console")
+
+			if s.seccompReport != nil {
+				s.reportSeccompViolation(scanner.Text())
+			}
 		}
 
 		if err := scanner.Err(); err != nil {
@@ -1277,7 +1664,7 @@ func (s *Sandbox) startVM(ctx context.Context, prestartHookFunc func(context.Con
 
 	s.Logger().Info("Starting VM")
 
-	if s.config.HypervisorConfig.Debug {
+	if s.config.HypervisorConfig.Debug || s.seccompReport != nil {
 		// create console watcher
 		consoleWatcher, err := newConsoleWatcher(ctx, s)
 		if err != nil {
@@ -1306,11 +1693,27 @@ func (s *Sandbox) startVM(ctx context.Context, prestartHookFunc func(context.Con
 			return vm.assignSandbox(s)
 		}
 
+		if err := faultinject.Inject(ctx, faultinject.HypervisorStartVM); err != nil {
+			return err
+		}
+
 		return s.hypervisor.StartVM(ctx, VmStartTimeout)
 	}); err != nil {
 		return err
 	}
 
+	if err := s.isolateVMMCores(); err != nil {
+		return err
+	}
+
+	s.startLazyAttach(ctx)
+	defer func() {
+		// Do not leave the hotplug running while the VM is stopped.
+		if err != nil {
+			s.waitLazyAttach()
+		}
+	}()
+
 	if prestartHookFunc != nil {
 		hid, err := s.GetHypervisorPid()
 		if err != nil {
@@ -1329,6 +1732,9 @@ func (s *Sandbox) startVM(ctx context.Context, prestartHookFunc func(context.Con
 	// 3. In case of prestartHookFunc, network config might have been changed. We need to
 	//    rescan and handle the change.
 	if !s.config.NetworkConfig.DisableNewNetwork && (s.factory != nil || prestartHookFunc != nil) {
+		if err := s.waitLazyAttach(); err != nil {
+			return err
+		}
 		if _, err := s.network.AddEndpoints(ctx, s, nil, true); err != nil {
 			return err
 		}
@@ -1336,6 +1742,14 @@ func (s *Sandbox) startVM(ctx context.Context, prestartHookFunc func(context.Con
 
 	s.Logger().Info("VM started")
 
+	if err := s.checkVMMSched(ctx); err != nil {
+		s.Logger().WithError(err).Warn("failed to schedule the VMM threads")
+	}
+
+	if err := s.checkVMMLimits(); err != nil {
+		s.Logger().WithError(err).Warn("failed to limit the VMM processes")
+	}
+
 	if s.cw != nil {
 		s.Logger().Debug("console watcher starts")
 		if err := s.cw.start(s); err != nil {
@@ -1362,7 +1776,15 @@ func (s *Sandbox) startVM(ctx context.Context, prestartHookFunc func(context.Con
 		}
 	}()
 
-	return nil
+	if err = s.writeMetadata(ctx); err != nil {
+		return err
+	}
+
+	if err = s.enforceNetworkPolicy(ctx); err != nil {
+		return err
+	}
+
+	return s.provisionEntitlements(ctx)
 }
 
 // stopVM: stop the sandbox's VM
@@ -1377,6 +1799,10 @@ func (s *Sandbox) stopVM(ctx context.Context) error {
 
 	s.Logger().Info("Stopping VM")
 
+	if err := faultinject.Inject(ctx, faultinject.HypervisorStopVM); err != nil {
+		return err
+	}
+
 	return s.hypervisor.StopVM(ctx, s.disableVMShutdown)
 }
 
@@ -1547,6 +1973,8 @@ func (s *Sandbox) DeleteContainer(ctx context.Context, containerID string) (VCCo
 		return nil, err
 	}
 
+	s.pruneCoreDumps()
+
 	// Update sandbox config
 	for idx, contConfig := range s.config.Containers {
 		if contConfig.ID == containerID {
@@ -1669,9 +2097,23 @@ func (s *Sandbox) Stats(ctx context.Context) (SandboxStats, error) {
 	case v1.Metrics:
 		stats.CgroupStats.CPUStats.CPUUsage.TotalUsage = mt.CPU.Usage.Total
 		stats.CgroupStats.MemoryStats.Usage.Usage = mt.Memory.Usage.Usage
+		stats.CgroupStats.MemoryStats.Usage.MaxUsage = mt.Memory.Usage.Max
+		if mt.Blkio != nil {
+			for _, e := range mt.Blkio.IoServiceBytesRecursive {
+				stats.CgroupStats.BlkioStats.IoServiceBytesRecursive = append(stats.CgroupStats.BlkioStats.IoServiceBytesRecursive,
+					BlkioStatEntry{Op: e.Op, Major: e.Major, Minor: e.Minor, Value: e.Value})
+			}
+		}
 	case v2.Metrics:
 		stats.CgroupStats.CPUStats.CPUUsage.TotalUsage = mt.CPU.UsageUsec
 		stats.CgroupStats.MemoryStats.Usage.Usage = mt.Memory.Usage
+		if mt.Io != nil {
+			for _, e := range mt.Io.Usage {
+				stats.CgroupStats.BlkioStats.IoServiceBytesRecursive = append(stats.CgroupStats.BlkioStats.IoServiceBytesRecursive,
+					BlkioStatEntry{Op: "Read", Major: e.Major, Minor: e.Minor, Value: e.Rbytes},
+					BlkioStatEntry{Op: "Write", Major: e.Major, Minor: e.Minor, Value: e.Wbytes})
+			}
+		}
 	}
 
 	tids, err := s.hypervisor.GetThreadIDs(ctx)
@@ -1680,6 +2122,13 @@ func (s *Sandbox) Stats(ctx context.Context) (SandboxStats, error) {
 	}
 	stats.Cpus = len(tids.vcpus)
 
+	if pids := s.hypervisor.GetPids(); len(pids) > 0 {
+		stats.HypervisorThreads, err = hypervisorThreadStats("/proc", pids[0], tids.vcpuThreads())
+		if err != nil {
+			s.Logger().WithError(err).Warn("failed to get the hypervisor thread stats")
+		}
+	}
+
 	return stats, nil
 }
 
@@ -1816,6 +2265,10 @@ func (s *Sandbox) Stop(ctx context.Context, force bool) error {
 		}
 	}
 
+	s.flushAll(ctx)
+	s.stopNameResolution()
+	s.stopEntitlements()
+
 	if err := s.stopVM(ctx); err != nil && !force {
 		return err
 	}
@@ -1903,6 +2356,10 @@ func (s *Sandbox) HotplugAddDevice(ctx context.Context, device api.Device, devTy
 	span, ctx := katatrace.Trace(ctx, s.Logger(), "HotplugAddDevice", sandboxTracingTags, map[string]string{"sandbox_id": s.id})
 	defer span.End()
 
+	if err := faultinject.Inject(ctx, faultinject.HypervisorHotplugAddDevice); err != nil {
+		return err
+	}
+
 	if s.sandboxController != nil {
 		if err := s.sandboxController.AddDevice(device.GetHostPath()); err != nil {
 			s.Logger().WithError(err).WithField("device", device).
@@ -1945,6 +2402,13 @@ func (s *Sandbox) HotplugAddDevice(ctx context.Context, device api.Device, devTy
 		}
 		_, err := s.hypervisor.HotplugAddDevice(ctx, vhostUserBlkDevice.VhostUserDeviceAttrs, VhostuserDev)
 		return err
+	case config.VhostVDPABlk:
+		vhostVDPABlkDevice, ok := device.(*drivers.VhostVDPABlkDevice)
+		if !ok {
+			return fmt.Errorf("device type mismatch, expect device type to be %s", devType)
+		}
+		_, err := s.hypervisor.HotplugAddDevice(ctx, vhostVDPABlkDevice.VhostUserDeviceAttrs, VhostuserDev)
+		return err
 	case config.DeviceGeneric:
 		// TODO: what?
 		return nil
@@ -1955,6 +2419,10 @@ func (s *Sandbox) HotplugAddDevice(ctx context.Context, device api.Device, devTy
 // HotplugRemoveDevice is used for removing a device from sandbox
 // Sandbox implement DeviceReceiver interface from device/api/interface.go
 func (s *Sandbox) HotplugRemoveDevice(ctx context.Context, device api.Device, devType config.DeviceType) error {
+	if err := faultinject.Inject(ctx, faultinject.HypervisorHotplugRemoveDevice); err != nil {
+		return err
+	}
+
 	defer func() {
 		if s.sandboxController != nil {
 			if err := s.sandboxController.RemoveDevice(device.GetHostPath()); err != nil {
@@ -1996,7 +2464,7 @@ func (s *Sandbox) HotplugRemoveDevice(ctx context.Context, device api.Device, de
 		}
 		_, err := s.hypervisor.HotplugRemoveDevice(ctx, blockDrive, BlockDev)
 		return err
-	case config.VhostUserBlk:
+	case config.VhostUserBlk, config.VhostVDPABlk:
 		vhostUserDeviceAttrs, ok := device.GetDeviceInfo().(*config.VhostUserDeviceAttrs)
 		if !ok {
 			return fmt.Errorf("device type mismatch, expect device type to be %s", devType)
@@ -2492,6 +2960,50 @@ func (s *Sandbox) GetOOMEvent(ctx context.Context) (string, error) {
 	return s.agent.getOOMEvent(ctx)
 }
 
+// GetSeccompReport returns the system calls blocked, or logged in audit
+// mode, by seccomp within the guest so far.
+func (s *Sandbox) GetSeccompReport() ([]SeccompViolation, error) {
+	if s.seccompReport == nil {
+		return nil, fmt.Errorf("guest seccomp reporting is not enabled for sandbox %s", s.id)
+	}
+
+	return s.seccompReport.list(), nil
+}
+
+// reportSeccompViolation records the seccomp violation of a guest console
+// line, if any. Only the first occurrence is logged.
+func (s *Sandbox) reportSeccompViolation(line string) {
+	v, ok := parseSeccompAuditLine(line)
+	if !ok {
+		return
+	}
+
+	if s.seccompReport.add(v) {
+		s.Logger().WithFields(logrus.Fields{
+			"comm":    v.Comm,
+			"exe":     v.Exe,
+			"arch":    v.Arch,
+			"syscall": v.Syscall,
+			"mode":    s.config.GuestSeccompMode,
+		}).Warn("guest seccomp violation")
+
+		select {
+		case s.seccompReport.events <- v:
+		default:
+			s.Logger().WithField("syscall", v.Syscall).Debug("seccomp violation events pending, not publishing it")
+		}
+	}
+}
+
+// SeccompViolations returns the channel of the first occurrences of the
+// seccomp violations of the sandbox, nil when they are not reported.
+func (s *Sandbox) SeccompViolations() <-chan SeccompViolation {
+	if s.seccompReport == nil {
+		return nil
+	}
+	return s.seccompReport.events
+}
+
 func (s *Sandbox) GetAgentURL() (string, error) {
 	return s.agent.getAgentURL()
 }
@@ -2515,6 +3027,47 @@ func (s *Sandbox) GuestVolumeStats(ctx context.Context, volumePath string) ([]by
 	return s.agent.getGuestVolumeStats(ctx, guestMountPath)
 }
 
+// ContainerVolumeStats are the filesystem stats of a volume of a container.
+type ContainerVolumeStats struct {
+	// Source is the path of the volume on the host, e.g. the one of the
+	// volume of the pod in the kubelet directory
+	Source string `json:"source"`
+	// Destination is the path of the volume in the container
+	Destination string `json:"destination"`
+	// Stats are the capacity and inode usage and the condition of the
+	// volume, as in the CSI NodeGetVolumeStats response
+	Stats *grpc.VolumeStatsResponse `json:"stats"`
+}
+
+// ContainerVolumeStats returns the filesystem stats in the guest of the
+// volumes of a container, the directories bind mounted in it.
+func (s *Sandbox) ContainerVolumeStats(ctx context.Context, containerID string) ([]ContainerVolumeStats, error) {
+	c, err := s.findContainer(containerID)
+	if err != nil {
+		return nil, err
+	}
+
+	volumes, err := s.agent.getContainerVolumeStats(ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make(map[string]string)
+	for _, m := range c.mounts {
+		sources[m.Destination] = m.Source
+	}
+
+	stats := make([]ContainerVolumeStats, 0, len(volumes))
+	for _, v := range volumes {
+		stats = append(stats, ContainerVolumeStats{
+			Source:      sources[v.Destination],
+			Destination: v.Destination,
+			Stats:       v.Stats,
+		})
+	}
+	return stats, nil
+}
+
 // ResizeGuestVolume resizes a volume in the guest.
 func (s *Sandbox) ResizeGuestVolume(ctx context.Context, volumePath string, size uint64) error {
 	// TODO: https://github.com/kata-containers/kata-containers/issues/3694.
@@ -2568,6 +3121,11 @@ func (s *Sandbox) getSandboxCPUSet() (string, string, error) {
 		}
 	}
 
+	// Sandboxes without a cpuset are confined to their host domain.
+	if cpuResult.IsEmpty() && s.placement != nil {
+		return s.placement.cpus.String(), s.placement.mems.String(), nil
+	}
+
 	return cpuResult.String(), memResult.String(), nil
 }
 
@@ -2598,6 +3156,7 @@ func fetchSandbox(ctx context.Context, sandboxID string) (sandbox *Sandbox, err
 	// This sandbox already exists, we don't need to recreate the containers in the guest.
 	// We only need to fetch the containers from storage and create the container structs.
 	if err := sandbox.fetchContainers(ctx); err != nil {
+		sandbox.stopMultipathWatcher()
 		return nil, err
 	}
 
@@ -2658,6 +3217,9 @@ func (s *Sandbox) checkVCPUsPinning(ctx context.Context) error {
 		return fmt.Errorf("failed to parse CPUSet string: %v", err)
 	}
 	cpuSetSlice := cpuSet.ToSlice()
+	if s.config.Profile == LowLatencyProfile {
+		cpuSetSlice = housekeepingCPUsFirst(cpuSetSlice)
+	}
 
 	// check if vCPU thread numbers and CPU numbers are equal
 	numVCPUs, numCPUs := len(vCPUThreadsMap.vcpus), len(cpuSetSlice)
@@ -2665,6 +3227,7 @@ func (s *Sandbox) checkVCPUsPinning(ctx context.Context) error {
 	if numVCPUs != numCPUs {
 		if s.isVCPUsPinningOn {
 			s.isVCPUsPinningOn = false
+			s.pinnedCPUs = nil
 			return s.resetVCPUsPinning(ctx, vCPUThreadsMap, cpuSetSlice)
 		}
 		return nil
@@ -2672,6 +3235,8 @@ func (s *Sandbox) checkVCPUsPinning(ctx context.Context) error {
 	// if equal, we can use vCPU thread pinning
 	for i, tid := range vCPUThreadsMap.vcpus {
 		if err := resCtrl.SetThreadAffinity(tid, cpuSetSlice[i:i+1]); err != nil {
+			s.isVCPUsPinningOn = false
+			s.pinnedCPUs = nil
 			if err := s.resetVCPUsPinning(ctx, vCPUThreadsMap, cpuSetSlice); err != nil {
 				return err
 			}
@@ -2679,9 +3244,33 @@ func (s *Sandbox) checkVCPUsPinning(ctx context.Context) error {
 		}
 	}
 	s.isVCPUsPinningOn = true
+	s.pinnedCPUs = cpuSetSlice
 	return nil
 }
 
+// guestCPUs returns the cpuset of the guest matching a cpuset of the host,
+// the vCPUs pinned to its CPUs. The guest does not know the CPUs of the host,
+// so it is empty when the vCPUs are not pinned.
+func (s *Sandbox) guestCPUs(hostCPUs string) string {
+	if hostCPUs == "" || !s.isVCPUsPinningOn {
+		return ""
+	}
+
+	cpus, err := cpuset.Parse(hostCPUs)
+	if err != nil {
+		s.Logger().WithError(err).WithField("cpuset", hostCPUs).Warn("failed to parse the container cpuset")
+		return ""
+	}
+
+	b := cpuset.NewBuilder()
+	for vcpu, cpu := range s.pinnedCPUs {
+		if cpus.Contains(cpu) {
+			b.Add(vcpu)
+		}
+	}
+	return b.Result().String()
+}
+
 // resetVCPUsPinning cancels current pinning and restores default random vCPU threads scheduling
 func (s *Sandbox) resetVCPUsPinning(ctx context.Context, vCPUThreadsMap VcpuThreadIDs, cpuSetSlice []int) error {
 	for _, tid := range vCPUThreadsMap.vcpus {
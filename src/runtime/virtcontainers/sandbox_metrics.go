@@ -10,6 +10,7 @@ import (
 
 	mutils "github.com/kata-containers/kata-containers/src/runtime/pkg/utils"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/grpc"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/procfs"
 )
@@ -64,6 +65,28 @@ var (
 		Help:      "Open FDs for hypervisor.",
 	})
 
+	hypervisorUnbackedGuestMemory = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespaceHypervisor,
+		Name:      "unbacked_guest_memory_bytes",
+		Help:      "Guest memory not backed by the hypervisor resident set, either never touched by the guest or released through the balloon and free page reporting.",
+	})
+
+	hypervisorRoleThreads = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespaceHypervisor,
+		Name:      "role_threads",
+		Help:      "Hypervisor process threads by role.",
+	},
+		[]string{"role"},
+	)
+
+	hypervisorRoleCPUSeconds = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespaceHypervisor,
+		Name:      "role_cpu_seconds",
+		Help:      "Host CPU time of the hypervisor threads by role: vcpu, iothread and emulator.",
+	},
+		[]string{"role", "mode"},
+	)
+
 	// agent
 	agentRPCDurationsHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
 		Namespace: namespaceKatashim,
@@ -74,6 +97,14 @@ var (
 		[]string{"action"},
 	)
 
+	// sandbox
+	bootQueueWaitHistogram = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespaceKatashim,
+		Name:      "sandbox_boot_queue_wait_milliseconds",
+		Help:      "Time the sandboxes waited for a boot slot.",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 16),
+	})
+
 	// virtiofsd
 	virtiofsdThreads = prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: namespaceVirtiofsd,
@@ -120,8 +151,13 @@ func RegisterMetrics() {
 	prometheus.MustRegister(hypervisorNetdev)
 	prometheus.MustRegister(hypervisorIOStat)
 	prometheus.MustRegister(hypervisorOpenFDs)
+	prometheus.MustRegister(hypervisorUnbackedGuestMemory)
+	prometheus.MustRegister(hypervisorRoleThreads)
+	prometheus.MustRegister(hypervisorRoleCPUSeconds)
 	// agent
 	prometheus.MustRegister(agentRPCDurationsHistogram)
+	// sandbox
+	prometheus.MustRegister(bootQueueWaitHistogram)
 	// virtiofsd
 	prometheus.MustRegister(virtiofsdThreads)
 	prometheus.MustRegister(virtiofsdProcStatus)
@@ -166,6 +202,15 @@ func (s *Sandbox) UpdateRuntimeMetrics() error {
 	// process status
 	if procStatus, err := proc.NewStatus(); err == nil {
 		mutils.SetGaugeVecProcStatus(hypervisorProcStatus, procStatus)
+
+		// This includes the memory the guest never touched, so it is not
+		// the memory given back through free page reporting and ballooning.
+		guestMemory := uint64(s.hypervisor.GetTotalMemoryMB(context.Background())) << utils.MibToBytesShift
+		unbacked := uint64(0)
+		if guestMemory > procStatus.VmRSS {
+			unbacked = guestMemory - procStatus.VmRSS
+		}
+		hypervisorUnbackedGuestMemory.Set(float64(unbacked))
 	}
 
 	// process IO statistics
@@ -173,6 +218,15 @@ func (s *Sandbox) UpdateRuntimeMetrics() error {
 		mutils.SetGaugeVecProcIO(hypervisorIOStat, ioStat)
 	}
 
+	// CPU usage of the threads by role
+	if threads, err := s.HypervisorThreadStats(context.Background()); err == nil {
+		for _, t := range threads {
+			hypervisorRoleThreads.WithLabelValues(t.Role).Set(float64(t.Threads))
+			hypervisorRoleCPUSeconds.WithLabelValues(t.Role, "user").Set(t.UserSeconds)
+			hypervisorRoleCPUSeconds.WithLabelValues(t.Role, "system").Set(t.SystemSeconds)
+		}
+	}
+
 	// virtiofs metrics
 	err = s.UpdateVirtiofsdMetrics()
 	if err != nil {
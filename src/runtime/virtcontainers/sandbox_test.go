@@ -600,7 +600,7 @@ func TestSandboxAttachDevicesVFIO(t *testing.T) {
 		config.SysIOMMUGroupPath = savedIOMMUPath
 	}()
 
-	dm := manager.NewDeviceManager(config.VirtioSCSI, false, "", 0, nil)
+	dm := manager.NewDeviceManager(config.VirtioSCSI, false, "", 0, "", false, nil)
 	path := filepath.Join(vfioPath, testFDIOGroup)
 	deviceInfo := config.DeviceInfo{
 		HostPath:      path,
@@ -1139,7 +1139,7 @@ func TestAttachBlockDevice(t *testing.T) {
 		DevType:       "b",
 	}
 
-	dm := manager.NewDeviceManager(config.VirtioBlock, false, "", 0, nil)
+	dm := manager.NewDeviceManager(config.VirtioBlock, false, "", 0, "", false, nil)
 	device, err := dm.NewDevice(deviceInfo)
 	assert.Nil(t, err)
 	_, ok := device.(*drivers.BlockDevice)
@@ -1195,7 +1195,7 @@ func TestPreAddDevice(t *testing.T) {
 		HypervisorConfig: hConfig,
 	}
 
-	dm := manager.NewDeviceManager(config.VirtioBlock, false, "", 0, nil)
+	dm := manager.NewDeviceManager(config.VirtioBlock, false, "", 0, "", false, nil)
 	// create a sandbox first
 	sandbox := &Sandbox{
 		id:         testSandboxID,
@@ -1386,6 +1386,37 @@ func TestSandboxExperimentalFeature(t *testing.T) {
 	assert.True(t, sconfig.valid())
 }
 
+func TestSandboxConfigHostDevicePolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	sconfig := SandboxConfig{
+		HostDevicePolicies: []config.HostDevicePolicyRule{
+			{Pattern: "/dev/dri/card*", Policy: config.HostDeviceReject},
+		},
+	}
+
+	assert.Equal(config.HostDevicePassthrough, sconfig.hostDevicePolicy("/dev/dri/renderD128"))
+	assert.Equal(config.HostDeviceReject, sconfig.hostDevicePolicy("/dev/dri/card0"))
+
+	sconfig.HypervisorConfig.VirtioGPU = VirtioGPUVenus
+	assert.Equal(config.HostDeviceEmulate, sconfig.hostDevicePolicy("/dev/dri/renderD128"))
+	assert.Equal(config.HostDeviceReject, sconfig.hostDevicePolicy("/dev/dri/card0"))
+	assert.Equal(config.HostDeviceGuestNode, sconfig.hostDevicePolicy("/dev/fuse"))
+	assert.Len(sconfig.HostDevicePolicies, 1)
+
+	assert.Equal(config.HostDevicePassthrough, sconfig.hostDevicePolicy("/dev/sgx_enclave"))
+	sconfig.HypervisorConfig.SGXEPCSize = 64 << 20
+	assert.Equal(config.HostDeviceEmulate, sconfig.hostDevicePolicy("/dev/sgx_enclave"))
+	assert.Equal(config.HostDeviceEmulate, sconfig.hostDevicePolicy("/dev/sgx_provision"))
+	assert.Len(sconfig.HostDevicePolicies, 1)
+
+	assert.Equal(config.HostDeviceEmulate, sconfig.hostDevicePolicy("/dev/infiniband/uverbs0"))
+	assert.Equal(config.HostDeviceEmulate, sconfig.hostDevicePolicy("/dev/infiniband/rdma_cm"))
+
+	sconfig.HostDevicePolicies = append(sconfig.HostDevicePolicies, config.HostDevicePolicyRule{Pattern: "/dev/fuse", Policy: config.HostDeviceReject})
+	assert.Equal(config.HostDeviceReject, sconfig.hostDevicePolicy("/dev/fuse"))
+}
+
 func TestSandbox_Cgroups(t *testing.T) {
 	sandboxContainer := ContainerConfig{}
 	sandboxContainer.Annotations = make(map[string]string)
@@ -1643,3 +1674,35 @@ func TestSandboxHugepageLimit(t *testing.T) {
 	err = s.updateResources(context.Background())
 	assert.NoError(t, err)
 }
+
+func TestSandboxIsHostPathShared(t *testing.T) {
+	assert := assert.New(t)
+
+	c1 := &Container{id: "c1", mounts: []Mount{{HostPath: "/shared/propagated"}, {HostPath: "/shared/c1"}}}
+	c2 := &Container{id: "c2", mounts: []Mount{{HostPath: "/shared/propagated"}}}
+	s := &Sandbox{containers: map[string]*Container{"c1": c1, "c2": c2}}
+
+	assert.True(s.isHostPathShared(c1, "/shared/propagated"))
+	assert.False(s.isHostPathShared(c1, "/shared/c1"))
+	assert.True(s.isHostPathShared(c2, "/shared/c1"))
+
+	delete(s.containers, "c2")
+	assert.False(s.isHostPathShared(c1, "/shared/propagated"))
+}
+
+func TestSandboxGuestCPUs(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{}
+	// the guest does not know the CPUs of the host without pinning
+	assert.Empty(s.guestCPUs("4-5"))
+
+	// vCPU i is pinned to the host CPU pinnedCPUs[i]
+	s.isVCPUsPinningOn = true
+	s.pinnedCPUs = []int{4, 5, 8, 9}
+	assert.Equal("0-1", s.guestCPUs("4-5"))
+	assert.Equal("1-2", s.guestCPUs("5,8"))
+	assert.Empty(s.guestCPUs("6"))
+	assert.Empty(s.guestCPUs(""))
+	assert.Empty(s.guestCPUs("invalid"))
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/grpc"
+)
+
+const seccompActNotify = "SCMP_ACT_NOTIFY"
+
+// SeccompNotification is a system call of a container process trapped by a
+// SCMP_ACT_NOTIFY rule of its seccomp profile. The process is blocked until
+// the notification is responded to.
+type SeccompNotification struct {
+	// ID identifies the notification in its response
+	ID          uint64
+	ContainerID string
+	// ExecID is the process of the container, empty for its init process
+	ExecID string
+	// Pid is the thread making the system call, in the PID namespace of
+	// the agent
+	Pid     uint32
+	Syscall string
+	Arch    string
+	// Args are the arguments of the system call, the pointers being
+	// addresses in the guest
+	Args []uint64
+}
+
+// SeccompNotificationResponse lets the system call of a notification run,
+// fail with Errno, or return Value without running.
+type SeccompNotificationResponse struct {
+	ID    uint64
+	Allow bool
+	Errno int32
+	Value int64
+}
+
+// hasSeccompNotifyRules tells if the profile traps system calls with
+// SCMP_ACT_NOTIFY rules.
+func hasSeccompNotifyRules(profile *grpc.LinuxSeccomp) bool {
+	if profile == nil {
+		return false
+	}
+	for _, syscall := range profile.Syscalls {
+		if syscall.Action == seccompActNotify {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSeccompNotify checks the SCMP_ACT_NOTIFY rules of the seccomp profile
+// of a container can be responded to.
+func (s *Sandbox) checkSeccompNotify(profile *grpc.LinuxSeccomp, listenerPath string) error {
+	if profile == nil {
+		return nil
+	}
+	// The listener of the notifications is in the guest, it cannot be
+	// handed to the process listening on the host.
+	if listenerPath != "" {
+		return fmt.Errorf("seccomp listenerPath %s is not supported, the notifications are forwarded to the seccomp notification socket", listenerPath)
+	}
+	// The notifications of all the system calls, including the ones of
+	// the agent starting the container, could not be responded to.
+	if profile.DefaultAction == seccompActNotify {
+		return fmt.Errorf("%s cannot be the default action of a seccomp profile", seccompActNotify)
+	}
+	if hasSeccompNotifyRules(profile) && !s.config.SeccompNotify {
+		return fmt.Errorf("the seccomp profile has %s rules, but no seccomp notification socket is configured", seccompActNotify)
+	}
+	return nil
+}
+
+// GetSeccompNotification waits for the next system call of the containers
+// trapped by a SCMP_ACT_NOTIFY rule.
+func (s *Sandbox) GetSeccompNotification(ctx context.Context) (*SeccompNotification, error) {
+	return s.agent.getSeccompNotification(ctx)
+}
+
+// RespondSeccompNotification responds to a notification, which unblocks the
+// process of the container.
+func (s *Sandbox) RespondSeccompNotification(ctx context.Context, resp SeccompNotificationResponse) error {
+	return s.agent.respondSeccompNotification(ctx, resp)
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/grpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckSeccompNotify(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{config: &SandboxConfig{}}
+
+	profile := testSeccompProfile()
+	assert.False(hasSeccompNotifyRules(profile))
+	assert.NoError(s.checkSeccompNotify(profile, ""))
+	assert.NoError(s.checkSeccompNotify(nil, ""))
+
+	profile.Syscalls = append(profile.Syscalls, grpc.LinuxSyscall{Names: []string{"mknod"}, Action: seccompActNotify})
+	assert.True(hasSeccompNotifyRules(profile))
+	assert.Error(s.checkSeccompNotify(profile, ""))
+
+	s.config.SeccompNotify = true
+	assert.NoError(s.checkSeccompNotify(profile, ""))
+	assert.Error(s.checkSeccompNotify(profile, "/run/seccomp-agent.sock"))
+
+	profile.DefaultAction = seccompActNotify
+	assert.Error(s.checkSeccompNotify(profile, ""))
+}
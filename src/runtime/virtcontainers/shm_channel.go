@@ -0,0 +1,278 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// A shared memory channel connects two sandboxes of the node which both
+// name it, with a shared memory region and doorbell interrupts: each guest
+// sees an ivshmem-doorbell device connected to the same ivshmem-server. The
+// server of a channel is started by the runtime of the first sandbox joining
+// it and stopped by the runtime of the last one leaving it, the members of
+// each channel being tracked in a file shared by the runtimes of the node. A
+// third sandbox naming a channel is rejected.
+
+const (
+	shmChannelsDir = "shm-channels"
+	shmChannelFile = "channels.json"
+
+	// shmChannelMembers is the number of sandboxes a channel connects.
+	shmChannelMembers = 2
+
+	// shmChannelVectors is the number of doorbell interrupt vectors of
+	// each member.
+	shmChannelVectors = 1
+)
+
+// shmChannelServerTimeout is how long the ivshmem-server of a channel has
+// to start.
+var shmChannelServerTimeout = 5 * time.Second
+
+var shmChannelNameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9.]{0,61}[a-z0-9])?$`)
+
+// ValidShmChannelName tells whether name is a valid shared memory channel
+// name, a DNS label.
+func ValidShmChannelName(name string) bool {
+	return shmChannelNameRegexp.MatchString(name)
+}
+
+// shmChannelEntry is the state of a channel.
+type shmChannelEntry struct {
+	Members []string `json:"members"`
+	PID     int      `json:"pid"`
+}
+
+// shmChannelTracker tracks the channels of the node, in a file locked
+// while it is updated.
+type shmChannelTracker struct {
+	// isSandboxLive tells whether the sandbox still exists, the other
+	// members are dropped.
+	isSandboxLive func(id string) bool
+	serverPath    string
+	dir           string
+	sizeMB        uint32
+}
+
+// channelID returns the ID of a channel, which names its files.
+func channelID(channel string) string {
+	sum := sha256.Sum256([]byte(channel))
+	return hex.EncodeToString(sum[:8])
+}
+
+func (t *shmChannelTracker) socketPath(channel string) string {
+	return filepath.Join(t.dir, channelID(channel)+".sock")
+}
+
+func (t *shmChannelTracker) pidFilePath(channel string) string {
+	return filepath.Join(t.dir, channelID(channel)+".pid")
+}
+
+func isProcessLive(pid int) bool {
+	return pid > 0 && syscall.Kill(pid, 0) == nil
+}
+
+func (t *shmChannelTracker) update(fn func(entries map[string]*shmChannelEntry) error) error {
+	if err := os.MkdirAll(t.dir, 0700); err != nil {
+		return err
+	}
+
+	entries := make(map[string]*shmChannelEntry)
+	return updateLockedJSON(filepath.Join(t.dir, shmChannelFile), &entries, func() error {
+		for _, entry := range entries {
+			members := entry.Members[:0]
+			for _, id := range entry.Members {
+				if t.isSandboxLive(id) {
+					members = append(members, id)
+				}
+			}
+			entry.Members = members
+		}
+		if err := fn(entries); err != nil {
+			return err
+		}
+		for channel, entry := range entries {
+			if len(entry.Members) == 0 {
+				t.stopServer(channel, entry.PID)
+				delete(entries, channel)
+			}
+		}
+		return nil
+	})
+}
+
+// startServer starts the ivshmem-server of a channel, which daemonizes
+// once it listens, and returns its PID.
+func (t *shmChannelTracker) startServer(channel string) (int, error) {
+	socket := t.socketPath(channel)
+	pidFile := t.pidFilePath(channel)
+	os.Remove(socket)
+	os.Remove(pidFile)
+
+	cmd := exec.Command(t.serverPath,
+		"-S", socket,
+		"-M", "kata-shm-"+channelID(channel),
+		"-l", fmt.Sprintf("%dM", t.sizeMB),
+		"-n", strconv.Itoa(shmChannelVectors),
+		"-p", pidFile)
+	if err := cmd.Run(); err != nil {
+		t.stopServer(channel, 0)
+		return 0, fmt.Errorf("failed to start the ivshmem-server of shared memory channel %s: %v", channel, err)
+	}
+
+	// The PID file is written once the server listens.
+	for deadline := time.Now().Add(shmChannelServerTimeout); time.Now().Before(deadline); time.Sleep(50 * time.Millisecond) {
+		if pid, err := readPIDFile(pidFile); err == nil {
+			return pid, nil
+		}
+	}
+
+	// Stop the server if it wrote its PID file in the meantime.
+	pid, _ := readPIDFile(pidFile)
+	t.stopServer(channel, pid)
+
+	return 0, fmt.Errorf("the ivshmem-server of shared memory channel %s did not start in %v", channel, shmChannelServerTimeout)
+}
+
+func readPIDFile(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
+
+func (t *shmChannelTracker) stopServer(channel string, pid int) {
+	if isProcessLive(pid) {
+		if err := syscall.Kill(pid, syscall.SIGTERM); err != nil {
+			virtLog.WithError(err).WithField("channel", channel).Warn("failed to stop the ivshmem-server")
+		}
+	}
+	os.Remove(t.socketPath(channel))
+	os.Remove(t.pidFilePath(channel))
+}
+
+// join adds the sandbox to the members of the channel, starting its server
+// if needed, and returns the socket of the server. The channels are left
+// unchanged when it fails, and the server it started is stopped.
+func (t *shmChannelTracker) join(id, channel string) (string, error) {
+	started := 0
+	err := t.update(func(entries map[string]*shmChannelEntry) error {
+		entry, ok := entries[channel]
+		if !ok {
+			entry = &shmChannelEntry{}
+			entries[channel] = entry
+		}
+
+		member := false
+		for _, m := range entry.Members {
+			member = member || m == id
+		}
+		if !member {
+			if len(entry.Members) >= shmChannelMembers {
+				return fmt.Errorf("shared memory channel %s already connects the sandboxes %s",
+					channel, strings.Join(entry.Members, ", "))
+			}
+			entry.Members = append(entry.Members, id)
+		}
+
+		if !isProcessLive(entry.PID) {
+			// The entries are not written back on error, which drops
+			// the member added above.
+			pid, err := t.startServer(channel)
+			if err != nil {
+				return err
+			}
+			entry.PID = pid
+			started = pid
+		}
+
+		return nil
+	})
+	if err != nil {
+		// The entries could not be written back, nobody would stop
+		// the server.
+		if started != 0 {
+			t.stopServer(channel, started)
+		}
+		return "", err
+	}
+
+	return t.socketPath(channel), nil
+}
+
+// leave drops the sandbox from the members of the channel, stopping its
+// server if it was the last one.
+func (t *shmChannelTracker) leave(id, channel string) error {
+	return t.update(func(entries map[string]*shmChannelEntry) error {
+		entry, ok := entries[channel]
+		if !ok {
+			return nil
+		}
+		members := entry.Members[:0]
+		for _, m := range entry.Members {
+			if m != id {
+				members = append(members, m)
+			}
+		}
+		entry.Members = members
+		return nil
+	})
+}
+
+func (s *Sandbox) shmChannelTracker() *shmChannelTracker {
+	storagePath := s.store.RunStoragePath()
+	return &shmChannelTracker{
+		dir:        filepath.Join(filepath.Dir(storagePath), shmChannelsDir),
+		serverPath: s.config.HypervisorConfig.IvshmemServerPath,
+		sizeMB:     s.config.HypervisorConfig.ShmChannelSizeMB,
+		isSandboxLive: func(id string) bool {
+			_, err := os.Stat(filepath.Join(storagePath, id))
+			return id == s.id || err == nil
+		},
+	}
+}
+
+// joinShmChannel joins the shared memory channel of the sandbox, if any.
+func (s *Sandbox) joinShmChannel() error {
+	if s.config.ShmChannel == "" {
+		return nil
+	}
+
+	if s.config.HypervisorConfig.IvshmemServerPath == "" {
+		return fmt.Errorf("shared memory channel %s needs ivshmem_server in the hypervisor configuration", s.config.ShmChannel)
+	}
+
+	socket, err := s.shmChannelTracker().join(s.id, s.config.ShmChannel)
+	if err != nil {
+		return err
+	}
+	s.config.HypervisorConfig.ShmChannelSocket = socket
+
+	s.Logger().WithField("channel", s.config.ShmChannel).Info("shared memory channel joined")
+
+	return nil
+}
+
+// leaveShmChannel leaves the shared memory channel of the sandbox, if any.
+func (s *Sandbox) leaveShmChannel() error {
+	if s.config.ShmChannel == "" {
+		return nil
+	}
+
+	return s.shmChannelTracker().leave(s.id, s.config.ShmChannel)
+}
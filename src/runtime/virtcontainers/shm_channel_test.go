@@ -0,0 +1,130 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeIvshmemServer daemonizes a sleep and writes its PID file, as
+// ivshmem-server does once it listens.
+const fakeIvshmemServer = `#!/bin/sh
+while [ $# -gt 0 ]; do
+	[ "$1" = "-p" ] && pidfile="$2"
+	shift
+done
+sleep 60 >/dev/null 2>&1 &
+echo $! > "$pidfile"
+`
+
+func TestValidShmChannelName(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(ValidShmChannelName("frontend-cache"))
+	assert.True(ValidShmChannelName("a.b"))
+	assert.False(ValidShmChannelName(""))
+	assert.False(ValidShmChannelName("Frontend"))
+	assert.False(ValidShmChannelName("../frontend"))
+	assert.False(ValidShmChannelName("frontend/cache"))
+}
+
+func TestShmChannelTracker(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+
+	server := filepath.Join(dir, "ivshmem-server")
+	assert.NoError(os.WriteFile(server, []byte(fakeIvshmemServer), 0700))
+
+	live := map[string]bool{"a": true, "b": true, "c": true}
+	tracker := &shmChannelTracker{
+		dir:           filepath.Join(dir, shmChannelsDir),
+		serverPath:    server,
+		sizeMB:        defaultShmChannelSizeMB,
+		isSandboxLive: func(id string) bool { return live[id] },
+	}
+	channel := "shop/frontend-cache"
+
+	socket, err := tracker.join("a", channel)
+	assert.NoError(err)
+	assert.Equal(tracker.socketPath(channel), socket)
+
+	// Joining again is a no-op.
+	_, err = tracker.join("a", channel)
+	assert.NoError(err)
+
+	_, err = tracker.join("b", channel)
+	assert.NoError(err)
+
+	// A channel connects two sandboxes.
+	_, err = tracker.join("c", channel)
+	assert.Error(err)
+
+	// The members which no longer exist are dropped.
+	live["b"] = false
+	_, err = tracker.join("c", channel)
+	assert.NoError(err)
+
+	var pid int
+	entries := make(map[string]*shmChannelEntry)
+	assert.NoError(updateLockedJSON(filepath.Join(tracker.dir, shmChannelFile), &entries, func() error {
+		assert.ElementsMatch([]string{"a", "c"}, entries[channel].Members)
+		pid = entries[channel].PID
+		return nil
+	}))
+	assert.True(isProcessLive(pid))
+
+	// The server is stopped when the last member leaves.
+	assert.NoError(tracker.leave("a", channel))
+	assert.True(isProcessLive(pid))
+	assert.NoError(tracker.leave("c", channel))
+	assert.NoFileExists(tracker.pidFilePath(channel))
+}
+
+func TestShmChannelTrackerJoinFailure(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+
+	savedTimeout := shmChannelServerTimeout
+	shmChannelServerTimeout = 100 * time.Millisecond
+	defer func() { shmChannelServerTimeout = savedTimeout }()
+
+	// A server which never writes a valid PID file
+	server := filepath.Join(dir, "ivshmem-server")
+	assert.NoError(os.WriteFile(server, []byte(`#!/bin/sh
+while [ $# -gt 0 ]; do
+	[ "$1" = "-S" ] && socket="$2"
+	[ "$1" = "-p" ] && pidfile="$2"
+	shift
+done
+touch "$socket"
+echo starting > "$pidfile"
+`), 0700))
+
+	tracker := &shmChannelTracker{
+		dir:           filepath.Join(dir, shmChannelsDir),
+		serverPath:    server,
+		sizeMB:        defaultShmChannelSizeMB,
+		isSandboxLive: func(id string) bool { return true },
+	}
+	channel := "shop/frontend-cache"
+
+	_, err := tracker.join("a", channel)
+	assert.Error(err)
+
+	// The sandbox is not a member, and the files of the server are removed
+	entries := make(map[string]*shmChannelEntry)
+	assert.NoError(updateLockedJSON(filepath.Join(tracker.dir, shmChannelFile), &entries, func() error {
+		assert.NotContains(entries, channel)
+		return nil
+	}))
+	assert.NoFileExists(tracker.socketPath(channel))
+	assert.NoFileExists(tracker.pidFilePath(channel))
+}
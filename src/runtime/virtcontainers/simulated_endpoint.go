@@ -0,0 +1,155 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	vcTypes "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+)
+
+var simulatedTrace = getNetworkTrace(SimulatedEndpointType)
+
+// SimulatedEndpoint is an endpoint of the simulated network. It is given to
+// the guest like the other endpoints, but it has no host interface and is not
+// attached to the hypervisor.
+type SimulatedEndpoint struct {
+	Iface              NetworkInterface
+	EndpointProperties NetworkInfo
+	EndpointType       EndpointType
+	PCIPath            vcTypes.PciPath
+	RxRateLimiter      bool
+	TxRateLimiter      bool
+}
+
+func createSimulatedEndpoint(netInfo NetworkInfo) *SimulatedEndpoint {
+	return &SimulatedEndpoint{
+		Iface: NetworkInterface{
+			Name:     netInfo.Iface.Name,
+			HardAddr: netInfo.Iface.HardwareAddr.String(),
+		},
+		EndpointProperties: netInfo,
+		EndpointType:       SimulatedEndpointType,
+	}
+}
+
+// Properties returns the properties of the simulated interface.
+func (endpoint *SimulatedEndpoint) Properties() NetworkInfo {
+	return endpoint.EndpointProperties
+}
+
+// Name returns the name of the simulated interface.
+func (endpoint *SimulatedEndpoint) Name() string {
+	return endpoint.Iface.Name
+}
+
+// HardwareAddr returns the mac address of the simulated interface.
+func (endpoint *SimulatedEndpoint) HardwareAddr() string {
+	return endpoint.Iface.HardAddr
+}
+
+// Type identifies the endpoint as a simulated endpoint.
+func (endpoint *SimulatedEndpoint) Type() EndpointType {
+	return endpoint.EndpointType
+}
+
+// PciPath returns the PCI path of the endpoint.
+func (endpoint *SimulatedEndpoint) PciPath() vcTypes.PciPath {
+	return endpoint.PCIPath
+}
+
+// SetPciPath sets the PCI path of the endpoint.
+func (endpoint *SimulatedEndpoint) SetPciPath(pciPath vcTypes.PciPath) {
+	endpoint.PCIPath = pciPath
+}
+
+// NetworkPair returns the network pair of the endpoint.
+func (endpoint *SimulatedEndpoint) NetworkPair() *NetworkInterfacePair {
+	return nil
+}
+
+// SetProperties sets the properties for the endpoint.
+func (endpoint *SimulatedEndpoint) SetProperties(properties NetworkInfo) {
+	endpoint.EndpointProperties = properties
+}
+
+// Attach for the simulated endpoint does nothing.
+func (endpoint *SimulatedEndpoint) Attach(ctx context.Context, s *Sandbox) error {
+	span, _ := simulatedTrace(ctx, "Attach", endpoint)
+	defer span.End()
+
+	networkLogger().WithField("endpoint", endpoint.Name()).Info("Attaching simulated endpoint")
+	return nil
+}
+
+// Detach for the simulated endpoint does nothing.
+func (endpoint *SimulatedEndpoint) Detach(ctx context.Context, netNsCreated bool, netNsPath string) error {
+	span, _ := simulatedTrace(ctx, "Detach", endpoint)
+	defer span.End()
+
+	networkLogger().WithField("endpoint", endpoint.Name()).Info("Detaching simulated endpoint")
+	return nil
+}
+
+// HotAttach for the simulated endpoint does nothing.
+func (endpoint *SimulatedEndpoint) HotAttach(ctx context.Context, h Hypervisor) error {
+	span, _ := simulatedTrace(ctx, "HotAttach", endpoint)
+	defer span.End()
+
+	networkLogger().WithField("endpoint", endpoint.Name()).Info("Hot attaching simulated endpoint")
+	return nil
+}
+
+// HotDetach for the simulated endpoint does nothing.
+func (endpoint *SimulatedEndpoint) HotDetach(ctx context.Context, h Hypervisor, netNsCreated bool, netNsPath string) error {
+	span, _ := simulatedTrace(ctx, "HotDetach", endpoint)
+	defer span.End()
+
+	networkLogger().WithField("endpoint", endpoint.Name()).Info("Hot detaching simulated endpoint")
+	return nil
+}
+
+func (endpoint *SimulatedEndpoint) save() persistapi.NetworkEndpoint {
+	return persistapi.NetworkEndpoint{
+		Type: string(endpoint.Type()),
+		Simulated: &persistapi.SimulatedEndpoint{
+			Iface: persistapi.NetworkInterface{
+				Name:     endpoint.Iface.Name,
+				HardAddr: endpoint.Iface.HardAddr,
+			},
+		},
+	}
+}
+
+func (endpoint *SimulatedEndpoint) load(s persistapi.NetworkEndpoint) {
+	endpoint.EndpointType = SimulatedEndpointType
+
+	if s.Simulated != nil {
+		endpoint.Iface = NetworkInterface{
+			Name:     s.Simulated.Iface.Name,
+			HardAddr: s.Simulated.Iface.HardAddr,
+		}
+	}
+}
+
+func (endpoint *SimulatedEndpoint) GetRxRateLimiter() bool {
+	return endpoint.RxRateLimiter
+}
+
+func (endpoint *SimulatedEndpoint) SetRxRateLimiter() error {
+	endpoint.RxRateLimiter = true
+	return nil
+}
+
+func (endpoint *SimulatedEndpoint) GetTxRateLimiter() bool {
+	return endpoint.TxRateLimiter
+}
+
+func (endpoint *SimulatedEndpoint) SetTxRateLimiter() error {
+	endpoint.TxRateLimiter = true
+	return nil
+}
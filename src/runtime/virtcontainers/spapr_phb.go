@@ -0,0 +1,380 @@
+//go:build linux
+
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/drivers"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+)
+
+// The pseries machine has a single PCI host bridge (PHB) by default, whose
+// MMIO windows are shared by all the PCI devices of the VM, so that a few
+// VFIO devices with large BARs exhaust them. QEMU can add extra PHBs, each
+// getting its own 32-bit and 64-bit windows placed from its index, and the
+// guest sees each of them as the root bus of the PCI domain of its index.
+//
+// The VFIO devices of a pseries VM are spread on extra PHBs, created at boot
+// for the devices the sandbox is started with, and hotplugged for the
+// hotplugged devices which fit on none of them. A device goes on the PHB of
+// the other devices of its IOMMU group, which must share a DMA address
+// space, or else on the first PHB with room left in its windows for the BARs
+// of the device.
+
+const (
+	// spaprMaxPHBs is the number of PHBs of a pseries machine, the one of
+	// the machine included.
+	spaprMaxPHBs = 31
+
+	// The sizes of the 32-bit and 64-bit MMIO windows of a PHB.
+	spaprPHBMemSize32bit = 2 << 30
+	spaprPHBMemSize64bit = 1 << 40
+)
+
+// errNoSpaprPHBRoom is returned when no PHB has room left for a device.
+var errNoSpaprPHBRoom = errors.New("no PCI host bridge left with room")
+
+// spaprPHBDevice is a VFIO device to place on a PHB.
+type spaprPHBDevice struct {
+	id         string
+	iommuGroup string
+
+	// The sizes of the 32-bit and 64-bit BARs of the device, each rounded
+	// up to a power of 2 for the BARs of the devices of a PHB to be
+	// naturally aligned in its windows.
+	memSize32bit uint64
+	memSize64bit uint64
+}
+
+// spaprPHBDeviceFromVFIO returns the PHB device of a VFIO device, from its
+// host PCI device.
+func spaprPHBDeviceFromVFIO(dev *config.VFIODev) (spaprPHBDevice, error) {
+	// A mediated device is the only one of its IOMMU group, and its BARs
+	// are not known from the host.
+	if dev.Type != config.VFIOPCIDeviceNormalType {
+		return spaprPHBDevice{id: dev.ID, iommuGroup: dev.SysfsDev}, nil
+	}
+
+	group, err := drivers.GetPCIDeviceIOMMUGroup(dev.BDF)
+	if err != nil {
+		return spaprPHBDevice{}, fmt.Errorf("cannot get the IOMMU group of VFIO device %s: %v", dev.BDF, err)
+	}
+
+	memSize32bit, memSize64bit, err := drivers.GetPCIDeviceBARSizes(dev.BDF)
+	if err != nil {
+		return spaprPHBDevice{}, fmt.Errorf("cannot get the BARs of VFIO device %s: %v", dev.BDF, err)
+	}
+
+	return spaprPHBDevice{
+		id:           dev.ID,
+		iommuGroup:   group,
+		memSize32bit: roundUpPowerOf2(memSize32bit),
+		memSize64bit: roundUpPowerOf2(memSize64bit),
+	}, nil
+}
+
+// spaprPHBUsage is what the VFIO devices of a PHB use of it.
+type spaprPHBUsage struct {
+	// iommuGroups counts the devices of the PHB per IOMMU group
+	iommuGroups  map[string]int
+	memSize32bit uint64
+	memSize64bit uint64
+}
+
+func (u *spaprPHBUsage) fits(dev spaprPHBDevice) bool {
+	return u.memSize32bit+dev.memSize32bit <= spaprPHBMemSize32bit &&
+		u.memSize64bit+dev.memSize64bit <= spaprPHBMemSize64bit
+}
+
+// spaprPHBAllocator places the VFIO devices of a VM on its extra PHBs,
+// which are bridges of type PHB of the VM.
+type spaprPHBAllocator struct {
+	// usage is the usage of the PHBs, by PHB ID
+	usage map[string]*spaprPHBUsage
+
+	// devices are the placed devices, by device ID
+	devices map[string]spaprPHBDevice
+
+	// phbs are the PHB IDs of the placed devices, by device ID
+	phbs map[string]string
+}
+
+func newSpaprPHBAllocator() *spaprPHBAllocator {
+	return &spaprPHBAllocator{
+		usage:   make(map[string]*spaprPHBUsage),
+		devices: make(map[string]spaprPHBDevice),
+		phbs:    make(map[string]string),
+	}
+}
+
+// allocate places dev on one of the PHBs of bridges, and returns the PHB and
+// the slot of the device on the root bus of the PHB.
+func (a *spaprPHBAllocator) allocate(ctx context.Context, bridges []types.Bridge, dev spaprPHBDevice) (types.Bridge, uint32, error) {
+	var phb *types.Bridge
+
+	for i := range bridges {
+		b := &bridges[i]
+		if b.Type != types.PHB {
+			continue
+		}
+
+		usage, ok := a.usage[b.ID]
+		if !ok {
+			// The usage of the PHBs is not persisted, the PHBs with
+			// devices plugged before the runtime restarted are left
+			// alone.
+			if len(b.Devices) > 0 {
+				continue
+			}
+			usage = &spaprPHBUsage{iommuGroups: make(map[string]int)}
+			a.usage[b.ID] = usage
+		}
+
+		if usage.iommuGroups[dev.iommuGroup] > 0 {
+			if !usage.fits(dev) {
+				return types.Bridge{}, 0, fmt.Errorf("PCI host bridge %s has no room left for the BARs of VFIO device %s, which must share it with the devices of IOMMU group %s", b.ID, dev.id, dev.iommuGroup)
+			}
+			phb = b
+			break
+		}
+
+		if phb == nil && usage.fits(dev) {
+			phb = b
+		}
+	}
+
+	if phb == nil {
+		return types.Bridge{}, 0, fmt.Errorf("%w for the BARs of VFIO device %s (%d MiB of 32-bit and %d MiB of 64-bit MMIO)", errNoSpaprPHBRoom, dev.id, dev.memSize32bit>>20, dev.memSize64bit>>20)
+	}
+
+	slot, err := phb.AddDevice(ctx, dev.id)
+	if err != nil {
+		return types.Bridge{}, 0, err
+	}
+
+	usage := a.usage[phb.ID]
+	usage.iommuGroups[dev.iommuGroup]++
+	usage.memSize32bit += dev.memSize32bit
+	usage.memSize64bit += dev.memSize64bit
+	a.devices[dev.id] = dev
+	a.phbs[dev.id] = phb.ID
+
+	return *phb, slot, nil
+}
+
+// release frees the windows used by the device of ID id.
+func (a *spaprPHBAllocator) release(id string) {
+	dev, ok := a.devices[id]
+	if !ok {
+		return
+	}
+
+	usage := a.usage[a.phbs[id]]
+	usage.iommuGroups[dev.iommuGroup]--
+	if usage.iommuGroups[dev.iommuGroup] == 0 {
+		delete(usage.iommuGroups, dev.iommuGroup)
+	}
+	usage.memSize32bit -= dev.memSize32bit
+	usage.memSize64bit -= dev.memSize64bit
+	delete(a.devices, id)
+	delete(a.phbs, id)
+}
+
+// spaprPHBBridges returns the bridges of number extra PHBs, of indexes 1 to
+// number.
+func spaprPHBBridges(number uint32) []types.Bridge {
+	var bridges []types.Bridge
+
+	for i := uint32(1); i <= number; i++ {
+		bridges = append(bridges, types.NewBridge(types.PHB, fmt.Sprintf("phb%d", i), make(map[uint32]string), int(i)))
+	}
+
+	return bridges
+}
+
+// nextSpaprPHBIndex returns the index of the PHB to add to the bridges, the
+// one following the indexes of their PHBs.
+func nextSpaprPHBIndex(bridges []types.Bridge) (int, error) {
+	index := 1
+	for _, b := range bridges {
+		if b.Type == types.PHB && b.Addr >= index {
+			index = b.Addr + 1
+		}
+	}
+
+	if index >= spaprMaxPHBs {
+		return 0, fmt.Errorf("%w, the VM has its %d extra PCI host bridges", errNoSpaprPHBRoom, spaprMaxPHBs-1)
+	}
+	return index, nil
+}
+
+// spaprPHBCount returns the number of extra PHBs the devices need, placing
+// the devices with the largest BARs first.
+func spaprPHBCount(devices []spaprPHBDevice) (uint32, error) {
+	devices = append([]spaprPHBDevice(nil), devices...)
+	sort.SliceStable(devices, func(i, j int) bool {
+		if devices[i].memSize64bit != devices[j].memSize64bit {
+			return devices[i].memSize64bit > devices[j].memSize64bit
+		}
+		return devices[i].memSize32bit > devices[j].memSize32bit
+	})
+
+	a := newSpaprPHBAllocator()
+	bridges := spaprPHBBridges(spaprMaxPHBs - 1)
+
+	var count uint32
+	for _, dev := range devices {
+		phb, _, err := a.allocate(context.Background(), bridges, dev)
+		if err != nil {
+			return 0, err
+		}
+		if uint32(phb.Addr) > count {
+			count = uint32(phb.Addr)
+		}
+	}
+
+	return count, nil
+}
+
+// createSpaprPHBs adds to a pseries VM the extra PHBs of the VFIO devices
+// the sandbox is started with.
+func (q *qemu) createSpaprPHBs() error {
+	if q.arch.machine().Type != QemuPseries || len(q.config.VFIODevices) == 0 {
+		return nil
+	}
+
+	var devices []spaprPHBDevice
+	for _, dev := range q.config.VFIODevices {
+		var err error
+		dev.HostPath, err = config.GetHostPath(dev, false, "")
+		if err != nil {
+			return fmt.Errorf("Cannot get host path for device: %v err: %v", dev, err)
+		}
+		devicesPerIOMMUGroup, err := drivers.GetAllVFIODevicesFromIOMMUGroup(dev)
+		if err != nil {
+			return fmt.Errorf("Cannot get all VFIO devices from IOMMU group with device: %v err: %v", dev, err)
+		}
+		for _, vfioDevice := range devicesPerIOMMUGroup {
+			phbDevice, err := spaprPHBDeviceFromVFIO(vfioDevice)
+			if err != nil {
+				return err
+			}
+			devices = append(devices, phbDevice)
+		}
+	}
+
+	count, err := spaprPHBCount(devices)
+	if err != nil {
+		return err
+	}
+
+	for _, b := range spaprPHBBridges(count) {
+		q.arch.addBridge(b)
+	}
+	q.spaprPHBs = newSpaprPHBAllocator()
+
+	q.Logger().WithField("phbs", count).Info("PCI host bridges created for the VFIO devices")
+
+	return nil
+}
+
+// hasSpaprPHBs returns whether the VM has extra PHBs.
+func (q *qemu) hasSpaprPHBs() bool {
+	for _, b := range q.arch.getBridges() {
+		if b.Type == types.PHB {
+			return true
+		}
+	}
+	return false
+}
+
+// placeVFIODeviceOnSpaprPHB places a VFIO device on an extra PHB of the VM,
+// setting the bus of the device to the root bus of the PHB, and returns the
+// PHB and the slot of the device.
+func (q *qemu) placeVFIODeviceOnSpaprPHB(ctx context.Context, device *config.VFIODev) (types.Bridge, uint32, error) {
+	dev, err := spaprPHBDeviceFromVFIO(device)
+	if err != nil {
+		return types.Bridge{}, 0, err
+	}
+
+	if q.spaprPHBs == nil {
+		q.spaprPHBs = newSpaprPHBAllocator()
+	}
+
+	phb, slot, err := q.spaprPHBs.allocate(ctx, q.arch.getBridges(), dev)
+	if err != nil {
+		return types.Bridge{}, 0, err
+	}
+	device.Bus = phb.ID + ".0"
+
+	return phb, slot, nil
+}
+
+// hotplugSpaprPHB hotplugs an extra PHB on a pseries VM.
+func (q *qemu) hotplugSpaprPHB() error {
+	index, err := nextSpaprPHBIndex(q.arch.getBridges())
+	if err != nil {
+		return err
+	}
+
+	phb := types.NewBridge(types.PHB, fmt.Sprintf("phb%d", index), make(map[uint32]string), index)
+	if err := q.qmpMonitorCh.qmp.ExecuteSpaprPHBDeviceAdd(q.qmpMonitorCh.ctx, phb.ID, uint32(index)); err != nil {
+		return err
+	}
+	q.arch.addBridge(phb)
+
+	q.Logger().WithField("phb", phb.ID).Info("PCI host bridge hotplugged for a VFIO device")
+
+	return nil
+}
+
+// hotplugVFIODeviceSpaprPHB hotplugs a VFIO device on the root bus of an
+// extra PHB of a pseries VM. A PHB is hotplugged first when none of the VM
+// has room left for the device, e.g. when the sandbox was started without
+// VFIO devices.
+func (q *qemu) hotplugVFIODeviceSpaprPHB(ctx context.Context, device *config.VFIODev) (err error) {
+	phb, slot, err := q.placeVFIODeviceOnSpaprPHB(ctx, device)
+	if errors.Is(err, errNoSpaprPHBRoom) {
+		if err = q.hotplugSpaprPHB(); err != nil {
+			return err
+		}
+		phb, slot, err = q.placeVFIODeviceOnSpaprPHB(ctx, device)
+	}
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			q.spaprPHBs.release(device.ID)
+			q.arch.removeDeviceFromBridge(device.ID)
+		}
+	}()
+
+	if err = q.executePCIVFIODeviceAdd(device, fmt.Sprintf("%02x", slot), device.Bus); err != nil {
+		return err
+	}
+
+	devSlot, err := types.PciSlotFromInt(int(slot))
+	if err != nil {
+		return err
+	}
+	pciPath, err := types.PciPathFromSlots(devSlot)
+	if err != nil {
+		return err
+	}
+	// QEMU gives the PHB of index n the PCI domain n in the guest.
+	device.GuestPciPath = pciPath.InDomain(uint16(phb.Addr))
+
+	return nil
+}
@@ -0,0 +1,131 @@
+//go:build linux
+
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	govmmQemu "github.com/kata-containers/kata-containers/src/runtime/pkg/govmm/qemu"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpaprPHBAllocator(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	bridges := append(genericBridges(1, QemuPseries), spaprPHBBridges(2)...)
+	a := newSpaprPHBAllocator()
+
+	// A GPU and its audio function, in the same IOMMU group
+	gpu := spaprPHBDevice{id: "gpu", iommuGroup: "1", memSize32bit: 16 << 20, memSize64bit: 512 << 30}
+	audio := spaprPHBDevice{id: "audio", iommuGroup: "1", memSize32bit: 16 << 10}
+	phb, slot, err := a.allocate(ctx, bridges, gpu)
+	assert.NoError(err)
+	assert.Equal("phb1", phb.ID)
+	assert.Equal(uint32(1), slot)
+	phb, slot, err = a.allocate(ctx, bridges, audio)
+	assert.NoError(err)
+	assert.Equal("phb1", phb.ID)
+	assert.Equal(uint32(2), slot)
+
+	// A second GPU fills the 64-bit window of the first PHB
+	gpu2 := spaprPHBDevice{id: "gpu2", iommuGroup: "2", memSize64bit: 512 << 30}
+	phb, _, err = a.allocate(ctx, bridges, gpu2)
+	assert.NoError(err)
+	assert.Equal("phb1", phb.ID)
+
+	// so that a third one goes on the second PHB
+	gpu3 := spaprPHBDevice{id: "gpu3", iommuGroup: "3", memSize64bit: 512 << 30}
+	phb, slot, err = a.allocate(ctx, bridges, gpu3)
+	assert.NoError(err)
+	assert.Equal("phb2", phb.ID)
+	assert.Equal(uint32(1), slot)
+
+	// A device of the IOMMU group of the first GPU must share its PHB,
+	// which is full
+	_, _, err = a.allocate(ctx, bridges, spaprPHBDevice{id: "gpu-usb", iommuGroup: "1", memSize64bit: 1 << 30})
+	assert.Error(err)
+
+	// and no PHB has room left for a device larger than a window
+	_, _, err = a.allocate(ctx, bridges, spaprPHBDevice{id: "nic", iommuGroup: "4", memSize32bit: 4 << 30})
+	assert.Error(err)
+
+	// The windows of the unplugged devices are reused
+	a.release("gpu2")
+	assert.NoError(bridges[1].RemoveDevice("gpu2"))
+	phb, _, err = a.allocate(ctx, bridges, spaprPHBDevice{id: "gpu4", iommuGroup: "5", memSize64bit: 512 << 30})
+	assert.NoError(err)
+	assert.Equal("phb1", phb.ID)
+
+	// The PHBs with devices plugged before the runtime restarted are not
+	// used
+	a = newSpaprPHBAllocator()
+	_, _, err = a.allocate(ctx, bridges, spaprPHBDevice{id: "nic", iommuGroup: "4", memSize32bit: 1 << 20})
+	assert.True(errors.Is(err, errNoSpaprPHBRoom))
+}
+
+func TestNextSpaprPHBIndex(t *testing.T) {
+	assert := assert.New(t)
+
+	index, err := nextSpaprPHBIndex(genericBridges(1, QemuPseries))
+	assert.NoError(err)
+	assert.Equal(1, index)
+
+	bridges := append(genericBridges(1, QemuPseries), spaprPHBBridges(2)...)
+	index, err = nextSpaprPHBIndex(bridges)
+	assert.NoError(err)
+	assert.Equal(3, index)
+
+	_, err = nextSpaprPHBIndex(spaprPHBBridges(spaprMaxPHBs - 1))
+	assert.True(errors.Is(err, errNoSpaprPHBRoom))
+}
+
+func TestSpaprPHBCount(t *testing.T) {
+	assert := assert.New(t)
+
+	count, err := spaprPHBCount(nil)
+	assert.NoError(err)
+	assert.Equal(uint32(0), count)
+
+	var devices []spaprPHBDevice
+	for _, id := range []string{"nic0", "gpu0", "nic1", "gpu1", "gpu2"} {
+		dev := spaprPHBDevice{id: id, iommuGroup: id, memSize32bit: 1 << 30}
+		if id[:3] == "gpu" {
+			dev.memSize64bit = 512 << 30
+		}
+		devices = append(devices, dev)
+	}
+
+	// The GPUs, placed first, fill two PHBs, the 32-bit BARs of the NICs
+	// needing a third one
+	count, err = spaprPHBCount(devices)
+	assert.NoError(err)
+	assert.Equal(uint32(3), count)
+
+	for i := 0; i < 2*spaprMaxPHBs; i++ {
+		devices = append(devices, spaprPHBDevice{id: "gpu", iommuGroup: "gpu", memSize64bit: 512 << 30})
+	}
+	_, err = spaprPHBCount(devices)
+	assert.Error(err)
+}
+
+func TestGenericAppendBridgesSpaprPHB(t *testing.T) {
+	assert := assert.New(t)
+
+	bridges := append(genericBridges(1, QemuPseries), spaprPHBBridges(2)...)
+	devices := genericAppendBridges(nil, bridges, QemuPseries)
+
+	assert.Len(devices, 3)
+	assert.Equal(govmmQemu.SpaprPHBDevice{ID: "phb1", Index: 1}, devices[1])
+	assert.Equal(govmmQemu.SpaprPHBDevice{ID: "phb2", Index: 2}, devices[2])
+	assert.Equal(types.PHB, bridges[2].Type)
+	assert.Equal(2, bridges[2].Addr)
+}
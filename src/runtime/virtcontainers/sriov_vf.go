@@ -0,0 +1,129 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// VF link states, as given to "ip link set <PF> vf <index> state"
+const (
+	VFLinkStateAuto    uint32 = 0
+	VFLinkStateEnable  uint32 = 1
+	VFLinkStateDisable uint32 = 2
+)
+
+var vfLinkStates = map[string]uint32{
+	"auto":    VFLinkStateAuto,
+	"enable":  VFLinkStateEnable,
+	"disable": VFLinkStateDisable,
+}
+
+// SRIOVVFConfig is the configuration of an SR-IOV VF programmed on its PF
+// in the host, the attributes left unset are not changed.
+type SRIOVVFConfig struct {
+	Vlan      *int
+	Qos       *int
+	SpoofChk  *bool
+	Trust     *bool
+	MinTxRate *uint32
+	MaxTxRate *uint32
+	LinkState *uint32
+}
+
+// ParseSRIOVVFConfigs parses the configuration of the VFs of the sandbox,
+// given as a semicolon separated list of network interface names followed by
+// the attributes of their VF, with the names used by ip-link(8), e.g.
+//
+//	net1 vlan=100 qos=3 spoofchk=off trust=on max_tx_rate=1000; net2 state=enable
+//
+// The rates are in Mbps.
+func ParseSRIOVVFConfigs(value string) (map[string]SRIOVVFConfig, error) {
+	configs := make(map[string]SRIOVVFConfig)
+
+	for _, entry := range strings.Split(value, ";") {
+		fields := strings.Fields(entry)
+		if len(fields) == 0 {
+			continue
+		}
+
+		iface := fields[0]
+		if _, ok := configs[iface]; ok {
+			return nil, fmt.Errorf("duplicate VF configuration for interface %s", iface)
+		}
+
+		var config SRIOVVFConfig
+		for _, attr := range fields[1:] {
+			if err := config.set(attr); err != nil {
+				return nil, fmt.Errorf("invalid VF configuration for interface %s: %w", iface, err)
+			}
+		}
+		configs[iface] = config
+	}
+
+	return configs, nil
+}
+
+func (config *SRIOVVFConfig) set(attr string) error {
+	name, value, ok := strings.Cut(attr, "=")
+	if !ok {
+		return fmt.Errorf("missing value of %q", attr)
+	}
+
+	parseInt := func(max uint64) (*int, error) {
+		n, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || n > max {
+			return nil, fmt.Errorf("%s must be between 0 and %d", name, max)
+		}
+		v := int(n)
+		return &v, nil
+	}
+
+	parseBool := func() (*bool, error) {
+		if value != "on" && value != "off" {
+			return nil, fmt.Errorf("%s must be on or off", name)
+		}
+		b := value == "on"
+		return &b, nil
+	}
+
+	parseRate := func() (*uint32, error) {
+		rate, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", name, err)
+		}
+		r := uint32(rate)
+		return &r, nil
+	}
+
+	var err error
+	switch name {
+	case "vlan":
+		config.Vlan, err = parseInt(4095)
+	case "qos":
+		config.Qos, err = parseInt(7)
+	case "spoofchk":
+		config.SpoofChk, err = parseBool()
+	case "trust":
+		config.Trust, err = parseBool()
+	case "min_tx_rate":
+		config.MinTxRate, err = parseRate()
+	case "max_tx_rate":
+		config.MaxTxRate, err = parseRate()
+	case "state":
+		state, ok := vfLinkStates[value]
+		if !ok {
+			return fmt.Errorf("state must be auto, enable or disable")
+		}
+		config.LinkState = &state
+	default:
+		return fmt.Errorf("unknown attribute %s", name)
+	}
+
+	return err
+}
@@ -0,0 +1,284 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+)
+
+// hostNetNS is the network namespace the runtime started in. The PFs stay
+// there while their VFs are attached from the pod network namespace.
+var hostNetNS struct {
+	once sync.Once
+	ns   netns.NsHandle
+	err  error
+}
+
+// getHostNetNS returns the network namespace the runtime started in, it is
+// only opened when a VF is first programmed.
+func getHostNetNS() (netns.NsHandle, error) {
+	hostNetNS.once.Do(func() {
+		// The caller may have its thread in the pod network namespace,
+		// the threads the other goroutines are scheduled on are always in
+		// the host one.
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			runtime.LockOSThread()
+			defer runtime.UnlockOSThread()
+			hostNetNS.ns, hostNetNS.err = netns.Get()
+		}()
+		<-done
+	})
+	return hostNetNS.ns, hostNetNS.err
+}
+
+// SRIOVVF is an SR-IOV VF programmed on its PF in the host.
+type SRIOVVF struct {
+	// PF is the name of the PF network interface
+	PF string
+
+	// Saved is the configuration the VF had before it was programmed
+	Saved SRIOVVFConfig
+
+	// Index is the index of the VF on its PF
+	Index int
+}
+
+// vfLinkSetter programs the VFs of a PF, it is implemented by
+// netlink.Handle and overridden in tests.
+type vfLinkSetter interface {
+	LinkSetVfVlanQos(link netlink.Link, vf, vlan, qos int) error
+	LinkSetVfSpoofchk(link netlink.Link, vf int, check bool) error
+	LinkSetVfTrust(link netlink.Link, vf int, state bool) error
+	LinkSetVfRate(link netlink.Link, vf, minRate, maxRate int) error
+	LinkSetVfState(link netlink.Link, vf int, state uint32) error
+}
+
+// findSRIOVVF returns the name of the PF network interface and the index of
+// the VF at bdf.
+func findSRIOVVF(bdf string) (string, int, error) {
+	pfPath, err := filepath.EvalSymlinks(filepath.Join(sysPCIDevicesPath, bdf, "physfn"))
+	if err != nil {
+		return "", 0, fmt.Errorf("%s is not an SR-IOV VF: %w", bdf, err)
+	}
+
+	ifaces, err := os.ReadDir(filepath.Join(pfPath, "net"))
+	if err != nil || len(ifaces) == 0 {
+		return "", 0, fmt.Errorf("no network interface found for the PF of VF %s", bdf)
+	}
+
+	virtfns, err := filepath.Glob(filepath.Join(pfPath, "virtfn*"))
+	if err != nil {
+		return "", 0, err
+	}
+
+	for _, virtfn := range virtfns {
+		link, err := os.Readlink(virtfn)
+		if err != nil || filepath.Base(link) != bdf {
+			continue
+		}
+		index, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(virtfn), "virtfn"))
+		if err != nil {
+			continue
+		}
+		return ifaces[0].Name(), index, nil
+	}
+
+	return "", 0, fmt.Errorf("VF %s not found on PF %s", bdf, filepath.Base(pfPath))
+}
+
+// currentSRIOVVFConfig returns the current values of the VF attributes set
+// in config. The VLAN and QoS, and the minimum and maximum rates, are
+// programmed together and always both returned.
+func currentSRIOVVFConfig(info netlink.VfInfo, config SRIOVVFConfig) SRIOVVFConfig {
+	var current SRIOVVFConfig
+
+	if config.Vlan != nil || config.Qos != nil {
+		vlan, qos := info.Vlan, info.Qos
+		current.Vlan, current.Qos = &vlan, &qos
+	}
+	if config.SpoofChk != nil {
+		spoofChk := info.Spoofchk
+		current.SpoofChk = &spoofChk
+	}
+	if config.Trust != nil {
+		trust := info.Trust != 0
+		current.Trust = &trust
+	}
+	if config.MinTxRate != nil || config.MaxTxRate != nil {
+		minTxRate, maxTxRate := info.MinTxRate, info.MaxTxRate
+		current.MinTxRate, current.MaxTxRate = &minTxRate, &maxTxRate
+	}
+	if config.LinkState != nil {
+		linkState := info.LinkState
+		current.LinkState = &linkState
+	}
+
+	return current
+}
+
+// merge returns the configuration with the attributes set in config
+// overridden.
+func (current SRIOVVFConfig) merge(config SRIOVVFConfig) SRIOVVFConfig {
+	if config.Vlan != nil {
+		current.Vlan = config.Vlan
+	}
+	if config.Qos != nil {
+		current.Qos = config.Qos
+	}
+	if config.SpoofChk != nil {
+		current.SpoofChk = config.SpoofChk
+	}
+	if config.Trust != nil {
+		current.Trust = config.Trust
+	}
+	if config.MinTxRate != nil {
+		current.MinTxRate = config.MinTxRate
+	}
+	if config.MaxTxRate != nil {
+		current.MaxTxRate = config.MaxTxRate
+	}
+	if config.LinkState != nil {
+		current.LinkState = config.LinkState
+	}
+	return current
+}
+
+// applySRIOVVFConfig programs the attributes set in config on the VF, the
+// VLAN and QoS, and the rates, must be both set or unset.
+func applySRIOVVFConfig(h vfLinkSetter, link netlink.Link, vf int, config SRIOVVFConfig) error {
+	if config.Vlan != nil && config.Qos != nil {
+		if err := h.LinkSetVfVlanQos(link, vf, *config.Vlan, *config.Qos); err != nil {
+			return fmt.Errorf("failed to set the VLAN of VF %d: %w", vf, err)
+		}
+	}
+	if config.SpoofChk != nil {
+		if err := h.LinkSetVfSpoofchk(link, vf, *config.SpoofChk); err != nil {
+			return fmt.Errorf("failed to set the spoof check of VF %d: %w", vf, err)
+		}
+	}
+	if config.Trust != nil {
+		if err := h.LinkSetVfTrust(link, vf, *config.Trust); err != nil {
+			return fmt.Errorf("failed to set the trust of VF %d: %w", vf, err)
+		}
+	}
+	if config.MinTxRate != nil && config.MaxTxRate != nil {
+		if err := h.LinkSetVfRate(link, vf, int(*config.MinTxRate), int(*config.MaxTxRate)); err != nil {
+			return fmt.Errorf("failed to set the rate of VF %d: %w", vf, err)
+		}
+	}
+	if config.LinkState != nil {
+		if err := h.LinkSetVfState(link, vf, *config.LinkState); err != nil {
+			return fmt.Errorf("failed to set the link state of VF %d: %w", vf, err)
+		}
+	}
+	return nil
+}
+
+// withPFLink calls fn with a netlink handle of the host network namespace and
+// the PF link.
+func withPFLink(pf string, fn func(h *netlink.Handle, link netlink.Link) error) error {
+	ns, err := getHostNetNS()
+	if err != nil {
+		return err
+	}
+	h, err := netlink.NewHandleAt(ns)
+	if err != nil {
+		return err
+	}
+	defer h.Close()
+
+	link, err := h.LinkByName(pf)
+	if err != nil {
+		return fmt.Errorf("failed to find PF %s: %w", pf, err)
+	}
+
+	return fn(h, link)
+}
+
+// programSRIOVVF programs the VF at bdf and returns its previous
+// configuration. The VF is returned along with the error when it was
+// partially programmed, so that it can be restored.
+func programSRIOVVF(bdf string, config SRIOVVFConfig) (*SRIOVVF, error) {
+	pf, index, err := findSRIOVVF(bdf)
+	if err != nil {
+		return nil, err
+	}
+
+	var vf *SRIOVVF
+	err = withPFLink(pf, func(h *netlink.Handle, link netlink.Link) error {
+		for _, info := range link.Attrs().Vfs {
+			if info.ID != index {
+				continue
+			}
+
+			current := currentSRIOVVFConfig(info, config)
+			vf = &SRIOVVF{PF: pf, Index: index, Saved: current}
+			return applySRIOVVFConfig(h, link, index, current.merge(config))
+		}
+		return fmt.Errorf("VF %d not found on PF %s", index, pf)
+	})
+
+	return vf, err
+}
+
+// restoreSRIOVVF programs the VF back to its saved configuration.
+func restoreSRIOVVF(vf *SRIOVVF) error {
+	return withPFLink(vf.PF, func(h *netlink.Handle, link netlink.Link) error {
+		return applySRIOVVFConfig(h, link, vf.Index, vf.Saved)
+	})
+}
+
+func (vf *SRIOVVF) save() *persistapi.SRIOVVF {
+	if vf == nil {
+		return nil
+	}
+
+	return &persistapi.SRIOVVF{
+		PF:    vf.PF,
+		Index: vf.Index,
+		Saved: persistapi.SRIOVVFConfig{
+			Vlan:      vf.Saved.Vlan,
+			Qos:       vf.Saved.Qos,
+			SpoofChk:  vf.Saved.SpoofChk,
+			Trust:     vf.Saved.Trust,
+			MinTxRate: vf.Saved.MinTxRate,
+			MaxTxRate: vf.Saved.MaxTxRate,
+			LinkState: vf.Saved.LinkState,
+		},
+	}
+}
+
+func loadSRIOVVF(vf *persistapi.SRIOVVF) *SRIOVVF {
+	if vf == nil {
+		return nil
+	}
+
+	return &SRIOVVF{
+		PF:    vf.PF,
+		Index: vf.Index,
+		Saved: SRIOVVFConfig{
+			Vlan:      vf.Saved.Vlan,
+			Qos:       vf.Saved.Qos,
+			SpoofChk:  vf.Saved.SpoofChk,
+			Trust:     vf.Saved.Trust,
+			MinTxRate: vf.Saved.MinTxRate,
+			MaxTxRate: vf.Saved.MaxTxRate,
+			LinkState: vf.Saved.LinkState,
+		},
+	}
+}
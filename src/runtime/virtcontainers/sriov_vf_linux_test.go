@@ -0,0 +1,99 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vishvananda/netlink"
+)
+
+type mockVFLinkSetter struct {
+	calls []string
+}
+
+func (m *mockVFLinkSetter) LinkSetVfVlanQos(link netlink.Link, vf, vlan, qos int) error {
+	m.calls = append(m.calls, fmt.Sprintf("vf %d vlan %d qos %d", vf, vlan, qos))
+	return nil
+}
+
+func (m *mockVFLinkSetter) LinkSetVfSpoofchk(link netlink.Link, vf int, check bool) error {
+	m.calls = append(m.calls, fmt.Sprintf("vf %d spoofchk %v", vf, check))
+	return nil
+}
+
+func (m *mockVFLinkSetter) LinkSetVfTrust(link netlink.Link, vf int, state bool) error {
+	m.calls = append(m.calls, fmt.Sprintf("vf %d trust %v", vf, state))
+	return nil
+}
+
+func (m *mockVFLinkSetter) LinkSetVfRate(link netlink.Link, vf, minRate, maxRate int) error {
+	m.calls = append(m.calls, fmt.Sprintf("vf %d min_tx_rate %d max_tx_rate %d", vf, minRate, maxRate))
+	return nil
+}
+
+func (m *mockVFLinkSetter) LinkSetVfState(link netlink.Link, vf int, state uint32) error {
+	m.calls = append(m.calls, fmt.Sprintf("vf %d state %d", vf, state))
+	return nil
+}
+
+func TestFindSRIOVVF(t *testing.T) {
+	assert := assert.New(t)
+
+	savedSysPCIDevicesPath := sysPCIDevicesPath
+	defer func() { sysPCIDevicesPath = savedSysPCIDevicesPath }()
+	sysPCIDevicesPath = t.TempDir()
+
+	pf := filepath.Join(sysPCIDevicesPath, "0000:3b:00.0")
+	assert.NoError(os.MkdirAll(filepath.Join(pf, "net", "ens1f0"), 0700))
+	for i, bdf := range []string{"0000:3b:00.2", "0000:3b:00.3"} {
+		vf := filepath.Join(sysPCIDevicesPath, bdf)
+		assert.NoError(os.MkdirAll(vf, 0700))
+		assert.NoError(os.Symlink(pf, filepath.Join(vf, "physfn")))
+		assert.NoError(os.Symlink(vf, filepath.Join(pf, fmt.Sprintf("virtfn%d", i))))
+	}
+
+	name, index, err := findSRIOVVF("0000:3b:00.3")
+	assert.NoError(err)
+	assert.Equal("ens1f0", name)
+	assert.Equal(1, index)
+
+	_, _, err = findSRIOVVF("0000:3b:00.0")
+	assert.Error(err)
+}
+
+func TestSRIOVVFConfigApplyAndRestore(t *testing.T) {
+	assert := assert.New(t)
+
+	vlan := 100
+	maxTxRate := uint32(1000)
+	trust := true
+	config := SRIOVVFConfig{Vlan: &vlan, MaxTxRate: &maxTxRate, Trust: &trust}
+
+	info := netlink.VfInfo{ID: 2, Qos: 3, MinTxRate: 10, Spoofchk: true}
+	saved := currentSRIOVVFConfig(info, config)
+
+	m := &mockVFLinkSetter{}
+	assert.NoError(applySRIOVVFConfig(m, nil, 2, saved.merge(config)))
+	assert.Equal([]string{
+		"vf 2 vlan 100 qos 3",
+		"vf 2 trust true",
+		"vf 2 min_tx_rate 10 max_tx_rate 1000",
+	}, m.calls)
+
+	m.calls = nil
+	vf := loadSRIOVVF((&SRIOVVF{PF: "ens1f0", Index: 2, Saved: saved}).save())
+	assert.NoError(applySRIOVVFConfig(m, nil, vf.Index, vf.Saved))
+	assert.Equal([]string{
+		"vf 2 vlan 0 qos 3",
+		"vf 2 trust false",
+		"vf 2 min_tx_rate 10 max_tx_rate 0",
+	}, m.calls)
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSRIOVVFConfigs(t *testing.T) {
+	assert := assert.New(t)
+
+	vlan, qos := 100, 3
+	off, on := false, true
+	maxTxRate := uint32(1000)
+	enable := VFLinkStateEnable
+
+	configs, err := ParseSRIOVVFConfigs("net1 vlan=100 qos=3 spoofchk=off trust=on max_tx_rate=1000; net2 state=enable;; net3")
+	assert.NoError(err)
+	assert.Equal(map[string]SRIOVVFConfig{
+		"net1": {Vlan: &vlan, Qos: &qos, SpoofChk: &off, Trust: &on, MaxTxRate: &maxTxRate},
+		"net2": {LinkState: &enable},
+		"net3": {},
+	}, configs)
+
+	configs, err = ParseSRIOVVFConfigs("")
+	assert.NoError(err)
+	assert.Empty(configs)
+
+	for _, value := range []string{
+		"net1 vlan",
+		"net1 vlan=4096",
+		"net1 qos=8",
+		"net1 spoofchk=yes",
+		"net1 min_tx_rate=-1",
+		"net1 state=up",
+		"net1 mac=02:00:00:00:00:01",
+		"net1 vlan=1; net1 qos=1",
+	} {
+		_, err := ParseSRIOVVFConfigs(value)
+		assert.Error(err, value)
+	}
+}
@@ -0,0 +1,290 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils/katatrace"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// swtpmTracingTags defines tags for the trace span
+var swtpmTracingTags = map[string]string{
+	"source":    "runtime",
+	"package":   "virtcontainers",
+	"subsystem": "swtpm",
+}
+
+// swtpmSetup manufactures the TPM, it is installed along with swtpm.
+const swtpmSetup = "swtpm_setup"
+
+var (
+	errSwtpmPathEmpty       = errors.New("swtpm path is empty")
+	errSwtpmSocketPathEmpty = errors.New("swtpm socket path is empty")
+	errSwtpmStatePathEmpty  = errors.New("swtpm state path is empty")
+)
+
+// swtpm runs the TPM 2.0 emulator backing the vTPM of the VM.
+//
+// The TPM state is kept out of the VM store directory, on persistent
+// storage, so that it outlives the restarts of the VM and of the host, and
+// is removed with the sandbox. The TPM is manufactured with an endorsement
+// key (EK) and its certificate the first time it starts.
+type swtpm struct {
+	// ctrlFD is the listening control channel socket handed over to swtpm
+	ctrlFD *os.File
+	// path to the swtpm binary
+	path string
+	// setupPath to the swtpm_setup binary manufacturing the TPM
+	setupPath string
+	// socketPath of the control channel the hypervisor connects to
+	socketPath string
+	// statePath is the directory holding the TPM state
+	statePath string
+	// credential swtpm runs with, the same as the hypervisor's
+	credential *syscall.Credential
+	// PID process ID of the swtpm process
+	PID int
+}
+
+// Open the control channel socket on behalf of swtpm
+// return file descriptor to be used by swtpm.
+func (s *swtpm) getSocketFD() (*os.File, error) {
+	if _, err := os.Stat(filepath.Dir(s.socketPath)); err != nil {
+		return nil, errors.Errorf("Socket directory does not exist %s", filepath.Dir(s.socketPath))
+	}
+
+	listener, err := net.ListenUnix("unix", &net.UnixAddr{Name: s.socketPath, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := utils.ChownToParent(s.socketPath); err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	// no longer needed since fd is a dup
+	defer listener.Close()
+
+	listener.SetUnlinkOnClose(false)
+
+	return listener.File()
+}
+
+// Start swtpm, return the pid of the swtpm process.
+func (s *swtpm) Start(ctx context.Context) (int, error) {
+	span, _ := katatrace.Trace(ctx, s.Logger(), "Start", swtpmTracingTags)
+	defer span.End()
+
+	if err := s.valid(); err != nil {
+		return 0, err
+	}
+
+	ctrlFD, err := s.getSocketFD()
+	if err != nil {
+		return 0, err
+	}
+	s.ctrlFD = ctrlFD
+
+	cmd := exec.Command(s.path)
+	cmd.ExtraFiles = append(cmd.ExtraFiles, s.ctrlFD)
+	cmd.Env = []string{}
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Credential: s.credential,
+		Setsid:     true,
+	}
+
+	// Extra files start from 2 (0: stdin, 1: stdout, 2: stderr)
+	ctrlFdNumber := 2 + uint(len(cmd.ExtraFiles))
+	args := s.args(ctrlFdNumber)
+	cmd.Args = append(cmd.Args, args...)
+
+	s.Logger().WithField("path", s.path).Info()
+	s.Logger().WithField("args", strings.Join(args, " ")).Info()
+
+	if err := utils.StartCmd(cmd); err != nil {
+		s.ctrlFD.Close()
+		s.ctrlFD = nil
+		return 0, err
+	}
+
+	// swtpm terminates when the hypervisor goes away, reap it.
+	go func() {
+		if err := cmd.Wait(); err != nil {
+			s.Logger().WithError(err).Warn("swtpm quits")
+		}
+	}()
+
+	s.PID = cmd.Process.Pid
+
+	return s.PID, nil
+}
+
+// Stop swtpm and remove its socket, the TPM state is kept.
+func (s *swtpm) Stop(ctx context.Context) error {
+	span, _ := katatrace.Trace(ctx, s.Logger(), "Stop", swtpmTracingTags)
+	defer span.End()
+
+	if s.PID != 0 {
+		if err := syscall.Kill(s.PID, syscall.SIGKILL); err != nil && err != syscall.ESRCH {
+			s.Logger().WithError(err).WithField("pid", s.PID).Warn("kill swtpm failed")
+		}
+		s.PID = 0
+	}
+
+	if s.ctrlFD != nil {
+		s.ctrlFD.Close()
+		s.ctrlFD = nil
+	}
+
+	if err := os.Remove(s.socketPath); err != nil && !os.IsNotExist(err) {
+		s.Logger().WithError(err).WithField("path", s.socketPath).Warn("removing swtpm socket failed")
+	}
+
+	return nil
+}
+
+// Setup creates the TPM state, with an endorsement key and its certificate,
+// unless it is already there, and returns the EK certificates.
+func (s *swtpm) Setup(ctx context.Context) ([]byte, error) {
+	span, _ := katatrace.Trace(ctx, s.Logger(), "Setup", swtpmTracingTags)
+	defer span.End()
+
+	if err := s.valid(); err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(s.statePath, 0700); err != nil {
+		return nil, err
+	}
+
+	if s.credential != nil {
+		if err := os.Chown(s.statePath, int(s.credential.Uid), int(s.credential.Gid)); err != nil {
+			return nil, err
+		}
+	}
+
+	ekCerts, err := s.ekCertificates()
+	if err != nil {
+		return nil, err
+	}
+	if len(ekCerts) != 0 {
+		return ekCerts, nil
+	}
+
+	cmd := exec.Command(s.setupPath, s.setupArgs()...)
+	cmd.Env = []string{}
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Credential: s.credential,
+	}
+
+	s.Logger().WithField("path", s.setupPath).WithField("args", strings.Join(cmd.Args[1:], " ")).Info("manufacturing the vTPM")
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return nil, errors.Wrapf(err, "swtpm_setup failed: %s", strings.TrimSpace(string(output)))
+	}
+
+	if ekCerts, err = s.ekCertificates(); err != nil {
+		return nil, err
+	}
+	if len(ekCerts) == 0 {
+		return nil, errors.Errorf("swtpm_setup wrote no EK certificate in %s", s.statePath)
+	}
+
+	return ekCerts, nil
+}
+
+// ekCertificates returns the EK certificates written in the state directory
+// when the TPM was manufactured, concatenated in the order of their names.
+func (s *swtpm) ekCertificates() ([]byte, error) {
+	paths, err := filepath.Glob(filepath.Join(s.statePath, "ek-*.crt"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	var certs []byte
+	for _, path := range paths {
+		cert, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert...)
+	}
+
+	return certs, nil
+}
+
+func (s *swtpm) setupArgs() []string {
+	return []string{
+		"--tpm2",
+		"--tpmstate", s.statePath,
+		"--create-ek-cert",
+		"--create-platform-cert",
+		"--lock-nvram",
+		"--not-overwrite",
+		// the EK certificates bound to the evidence of the guest
+		"--write-ek-cert-files", s.statePath,
+	}
+}
+
+func (s *swtpm) args(ctrlFdNumber uint) []string {
+	return []string{
+		"socket",
+		"--tpm2",
+		fmt.Sprintf("--tpmstate=dir=%s,mode=0600", s.statePath),
+		// fd number of the control channel socket
+		fmt.Sprintf("--ctrl=type=unixio,fd=%v", ctrlFdNumber),
+		// exit once the hypervisor closes the control channel
+		"--terminate",
+	}
+}
+
+func (s *swtpm) valid() error {
+	if s.path == "" || s.setupPath == "" {
+		return errSwtpmPathEmpty
+	}
+
+	if s.socketPath == "" {
+		return errSwtpmSocketPathEmpty
+	}
+
+	if s.statePath == "" {
+		return errSwtpmStatePathEmpty
+	}
+
+	return nil
+}
+
+func (s *swtpm) Logger() *log.Entry {
+	return hvLogger.WithField("subsystem", "swtpm")
+}
+
+// removeVTPMState removes the TPM state of the vTPM of the sandbox, which
+// outlives the restarts of its VM.
+func (s *Sandbox) removeVTPMState() {
+	hConfig := s.config.HypervisorConfig
+	if !hConfig.EnableVTPM || hConfig.VTPMStatePath == "" {
+		return
+	}
+
+	statePath := filepath.Join(hConfig.VTPMStatePath, s.id)
+	if err := os.RemoveAll(statePath); err != nil {
+		s.Logger().WithError(err).WithField("path", statePath).Warn("removing vTPM state failed")
+	}
+}
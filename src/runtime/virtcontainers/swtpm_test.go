@@ -0,0 +1,92 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	govmmQemu "github.com/kata-containers/kata-containers/src/runtime/pkg/govmm/qemu"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSwtpmArgs(t *testing.T) {
+	s := &swtpm{
+		statePath: "/var/lib/kata-containers/vtpm/foo",
+	}
+
+	expected := []string{
+		"socket",
+		"--tpm2",
+		"--tpmstate=dir=/var/lib/kata-containers/vtpm/foo,mode=0600",
+		"--ctrl=type=unixio,fd=3",
+		"--terminate",
+	}
+	assert.Equal(t, expected, s.args(3))
+}
+
+func TestSwtpmValid(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &swtpm{}
+	assert.Equal(errSwtpmPathEmpty, s.valid())
+
+	s.path = "/usr/bin/swtpm"
+	assert.Equal(errSwtpmPathEmpty, s.valid())
+
+	s.setupPath = "/usr/bin/swtpm_setup"
+	assert.Equal(errSwtpmSocketPathEmpty, s.valid())
+
+	s.socketPath = "/run/vc/vm/foo/swtpm.sock"
+	assert.Equal(errSwtpmStatePathEmpty, s.valid())
+
+	s.statePath = "/run/vc/vm/foo/tpm"
+	assert.NoError(s.valid())
+}
+
+func TestSwtpmSetup(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	setupPath := filepath.Join(dir, "swtpm_setup")
+	// writes the EK certificate in the directory after --write-ek-cert-files
+	script := "#!/bin/sh\nwhile [ \"$1\" != --write-ek-cert-files ]; do shift; done\necho cert > \"$2/ek-rsa2048.crt\"\n"
+	assert.NoError(os.WriteFile(setupPath, []byte(script), 0755))
+
+	s := &swtpm{
+		path:       "/usr/bin/swtpm",
+		setupPath:  setupPath,
+		socketPath: filepath.Join(dir, "swtpm.sock"),
+		statePath:  filepath.Join(dir, "state"),
+	}
+
+	ekCerts, err := s.Setup(context.Background())
+	assert.NoError(err)
+	assert.Equal("cert\n", string(ekCerts))
+
+	// the TPM is only manufactured once
+	assert.NoError(os.Remove(setupPath))
+	ekCerts, err = s.Setup(context.Background())
+	assert.NoError(err)
+	assert.Equal("cert\n", string(ekCerts))
+}
+
+func TestVTPMDriver(t *testing.T) {
+	assert := assert.New(t)
+
+	driver, err := vtpmDriver(QemuQ35)
+	assert.NoError(err)
+	assert.Equal(govmmQemu.TPMCRB, driver)
+
+	driver, err = vtpmDriver(QemuVirt)
+	assert.NoError(err)
+	assert.Equal(govmmQemu.TPMTISDevice, driver)
+
+	_, err = vtpmDriver(QemuCCWVirtio)
+	assert.Error(err)
+}
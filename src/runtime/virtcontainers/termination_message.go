@@ -0,0 +1,66 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+)
+
+// Kubelet bind mounts a file of the pod directory at the terminationMessagePath
+// of each container, and reads it once the container exited. When the file
+// is shared with the guest, the message the container writes reaches the
+// host as is. When the file is copied into the guest instead, the copy is
+// read back when the container stops, before its exit is reported.
+
+// terminationMessageMaxSize is the size of the termination messages kubelet
+// reads at most.
+const terminationMessageMaxSize = 4096
+
+// isTerminationMessageMount tells whether the mount is the termination
+// message file of a container, kubelet creating the files as
+// <pod dir>/containers/<container name>/<id>.
+func isTerminationMessageMount(m Mount) bool {
+	if m.Type != "bind" || m.ReadOnly {
+		return false
+	}
+
+	containerDir := filepath.Dir(m.Source)
+	containersDir := filepath.Dir(containerDir)
+	podsDir := filepath.Dir(filepath.Dir(containersDir))
+
+	return filepath.Base(containersDir) == "containers" && filepath.Base(podsDir) == "pods"
+}
+
+// propagateTerminationMessage writes the termination message the container
+// wrote in the guest copy of its termination message file to the host file.
+func (c *Container) propagateTerminationMessage(ctx context.Context) {
+	for _, m := range c.mounts {
+		if m.GuestPath == "" || !isTerminationMessageMount(m) {
+			continue
+		}
+
+		logger := c.Logger().WithField("termination-message-path", m.Destination)
+
+		data, err := c.sandbox.agent.readFile(ctx, m.GuestPath, 0, terminationMessageMaxSize)
+		if err == errUnimplemented {
+			logger.Debug("the agent cannot read the termination message")
+			return
+		} else if err != nil {
+			logger.WithError(err).Warn("failed to read the termination message")
+			continue
+		}
+		if len(data) == 0 {
+			continue
+		}
+
+		// The file is written in place, kubelet keeping its mode.
+		if err := os.WriteFile(m.Source, data, 0644); err != nil {
+			logger.WithError(err).Warn("failed to write the termination message")
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// guestFilesAgent reads the files of a fake guest.
+type guestFilesAgent struct {
+	mockAgent
+	files map[string]string
+}
+
+func (g *guestFilesAgent) readFile(ctx context.Context, path string, offset, maxSize uint64) ([]byte, error) {
+	data, ok := g.files[path]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	if offset > uint64(len(data)) {
+		offset = uint64(len(data))
+	}
+	data = data[offset:]
+	if maxSize != 0 && uint64(len(data)) > maxSize {
+		data = data[:maxSize]
+	}
+	return []byte(data), nil
+}
+
+func TestIsTerminationMessageMount(t *testing.T) {
+	assert := assert.New(t)
+
+	source := "/var/lib/kubelet/pods/9d2a/containers/app/5e1c"
+	assert.True(isTerminationMessageMount(Mount{Source: source, Destination: "/dev/termination-log", Type: "bind"}))
+	assert.False(isTerminationMessageMount(Mount{Source: source, Destination: "/dev/termination-log", Type: "bind", ReadOnly: true}))
+	assert.False(isTerminationMessageMount(Mount{Source: "/var/lib/kubelet/pods/9d2a/etc-hosts", Destination: "/etc/hosts", Type: "bind"}))
+	assert.False(isTerminationMessageMount(Mount{Source: "/var/lib/kubelet/pods/9d2a/volumes/kubernetes.io~empty-dir/app", Destination: "/data", Type: "bind"}))
+}
+
+func TestPropagateTerminationMessage(t *testing.T) {
+	assert := assert.New(t)
+
+	containerDir := filepath.Join(t.TempDir(), "pods", "9d2a", "containers", "app")
+	assert.NoError(os.MkdirAll(containerDir, 0755))
+	source := filepath.Join(containerDir, "5e1c")
+	assert.NoError(os.WriteFile(source, nil, 0666))
+	shared := filepath.Join(containerDir, "shared")
+	assert.NoError(os.WriteFile(shared, nil, 0666))
+
+	guestPath := filepath.Join(kataGuestSharedDir(), "app-5e1c-termination-log")
+	agent := &guestFilesAgent{files: map[string]string{
+		guestPath: "failed to connect to the database",
+	}}
+	c := &Container{
+		id:      "app",
+		sandbox: &Sandbox{agent: agent, config: &SandboxConfig{}},
+		mounts: []Mount{
+			{Source: source, Destination: "/dev/termination-log", Type: "bind", GuestPath: guestPath},
+			// The shared files are written by the container itself.
+			{Source: shared, Destination: "/tmp/termination-log", Type: "bind"},
+		},
+	}
+
+	c.propagateTerminationMessage(context.Background())
+
+	data, err := os.ReadFile(source)
+	assert.NoError(err)
+	assert.Equal("failed to connect to the database", string(data))
+
+	data, err = os.ReadFile(shared)
+	assert.NoError(err)
+	assert.Empty(data)
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/docker/go-units"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// The tmpfs filesystems created inside the guest default to half of the
+// guest memory, which lets a container consume the memory of the others
+// through /dev/shm or a memory backed volume. The tmpfs sizing policy caps
+// their size to a percentage of the pod memory limit, or of the guest
+// memory when the pod has no limit.
+
+// TmpfsSizing is the tmpfs sizing policy of a sandbox, the percentages
+// are not capped when 0.
+type TmpfsSizing struct {
+	// ShmPercent caps the size of the sandbox /dev/shm.
+	ShmPercent uint32
+
+	// MemoryVolumePercent caps the size of each memory backed volume,
+	// e.g. the emptyDir volumes of the Memory medium.
+	MemoryVolumePercent uint32
+
+	// TmpfsPercent caps the size of each tmpfs mount of the containers,
+	// e.g. /tmp.
+	TmpfsPercent uint32
+}
+
+// tmpfsSizeLimit returns the size in bytes percent of the pod memory limit
+// is, 0 when percent is 0.
+func (s *Sandbox) tmpfsSizeLimit(percent uint32) uint64 {
+	memMB := s.config.SandboxResources.WorkloadMemMB
+	if memMB == 0 {
+		memMB = s.config.HypervisorConfig.MemorySize
+	}
+
+	return uint64(memMB) << 20 * uint64(percent) / 100
+}
+
+// capTmpfsSize caps a tmpfs size, unlimited when 0, to limit, unlimited
+// when 0.
+func capTmpfsSize(size, limit uint64) uint64 {
+	if limit != 0 && (size == 0 || size > limit) {
+		return limit
+	}
+	return size
+}
+
+// guestShmSize returns the size of the sandbox /dev/shm.
+func (s *Sandbox) guestShmSize() uint64 {
+	return capTmpfsSize(s.shmSize, s.tmpfsSizeLimit(s.config.TmpfsSizing.ShmPercent))
+}
+
+// parseTmpfsSize parses the value of the size option of a tmpfs mount,
+// memMB being the memory the percentages are relative to.
+func parseTmpfsSize(value string, memMB uint32) (uint64, error) {
+	if strings.HasSuffix(value, "%") {
+		p, err := strconv.ParseUint(strings.TrimSuffix(value, "%"), 10, 32)
+		if err != nil {
+			return 0, err
+		}
+		return uint64(memMB) << 20 * p / 100, nil
+	}
+
+	size, err := units.RAMInBytes(value)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(size), nil
+}
+
+// capTmpfsMounts caps the size of the tmpfs mounts of a container.
+func (s *Sandbox) capTmpfsMounts(mounts []specs.Mount) error {
+	limit := s.tmpfsSizeLimit(s.config.TmpfsSizing.TmpfsPercent)
+	if limit == 0 {
+		return nil
+	}
+
+	for i, m := range mounts {
+		// The container /dev/shm is the sandbox one.
+		if m.Type != "tmpfs" || m.Destination == "/dev/shm" {
+			continue
+		}
+
+		var size uint64
+		var options []string
+		for _, o := range m.Options {
+			if !strings.HasPrefix(o, "size=") {
+				options = append(options, o)
+				continue
+			}
+			var err error
+			if size, err = parseTmpfsSize(strings.TrimPrefix(o, "size="), s.config.HypervisorConfig.MemorySize); err != nil {
+				return fmt.Errorf("invalid size of tmpfs mount %s: %v", m.Destination, err)
+			}
+		}
+
+		mounts[i].Options = append(options, fmt.Sprintf("size=%d", capTmpfsSize(size, limit)))
+	}
+
+	return nil
+}
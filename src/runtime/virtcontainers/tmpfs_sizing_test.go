@@ -0,0 +1,70 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"testing"
+
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTmpfsSizing(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{
+		shmSize: 64 << 20,
+		config: &SandboxConfig{
+			HypervisorConfig: HypervisorConfig{MemorySize: 2048},
+		},
+	}
+
+	// Nothing is capped by default
+	assert.Equal(uint64(64<<20), s.guestShmSize())
+	mounts := []specs.Mount{{Destination: "/tmp", Type: "tmpfs", Options: []string{"nosuid"}}}
+	assert.NoError(s.capTmpfsMounts(mounts))
+	assert.Equal([]string{"nosuid"}, mounts[0].Options)
+
+	// The caps are relative to the guest memory without a pod limit
+	s.config.TmpfsSizing = TmpfsSizing{ShmPercent: 1, TmpfsPercent: 10}
+	assert.Equal(uint64(2048<<20/100), s.guestShmSize())
+
+	// and to the pod limit otherwise
+	s.config.SandboxResources.WorkloadMemMB = 1024
+	assert.Equal(uint64(1024<<20/100), s.guestShmSize())
+	s.config.TmpfsSizing.ShmPercent = 50
+	assert.Equal(uint64(64<<20), s.guestShmSize())
+
+	limit := fmt.Sprintf("size=%d", 1024<<20/10)
+	mounts = []specs.Mount{
+		{Destination: "/tmp", Type: "tmpfs", Options: []string{"nosuid", "mode=1777"}},
+		{Destination: "/run", Type: "tmpfs", Options: []string{"size=64m"}},
+		{Destination: "/cache", Type: "tmpfs", Options: []string{"size=50%"}},
+		{Destination: "/dev/shm", Type: "tmpfs", Options: []string{"size=1g"}},
+		{Destination: "/data", Type: "bind", Options: []string{"rbind"}},
+	}
+	assert.NoError(s.capTmpfsMounts(mounts))
+	assert.Equal([]string{"nosuid", "mode=1777", limit}, mounts[0].Options)
+	assert.Equal([]string{fmt.Sprintf("size=%d", 64<<20)}, mounts[1].Options)
+	assert.Equal([]string{limit}, mounts[2].Options)
+	assert.Equal([]string{"size=1g"}, mounts[3].Options)
+	assert.Equal([]string{"rbind"}, mounts[4].Options)
+
+	mounts = []specs.Mount{{Destination: "/tmp", Type: "tmpfs", Options: []string{"size=lots"}}}
+	assert.Error(s.capTmpfsMounts(mounts))
+}
+
+func TestHandleEphemeralStorageSizeLimit(t *testing.T) {
+	assert := assert.New(t)
+
+	k := kataAgent{}
+	mounts := []specs.Mount{{Type: KataEphemeralDevType, Source: t.TempDir()}}
+
+	storages, err := k.handleEphemeralStorage(mounts, 4096)
+	assert.NoError(err)
+	assert.Contains(storages[0].Options, "size=4096")
+}
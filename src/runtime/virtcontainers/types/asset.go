@@ -6,6 +6,7 @@
 package types
 
 import (
+	"crypto/sha256"
 	"crypto/sha512"
 	"encoding/hex"
 	"fmt"
@@ -147,7 +148,6 @@ func (a *Asset) Hash(hashType string) (string, error) {
 	}
 
 	// Build the asset hash and convert it to a string.
-	// We only support SHA512 for now.
 	switch hashType {
 	case annotations.SHA512:
 		hashComputed := sha512.Sum512(bytes)
@@ -155,6 +155,9 @@ func (a *Asset) Hash(hashType string) (string, error) {
 		hashEncoded := make([]byte, hashEncodedLen)
 		hex.Encode(hashEncoded, hashComputed[:])
 		hash = string(hashEncoded[:])
+	case annotations.SHA256:
+		hashComputed := sha256.Sum256(bytes)
+		hash = hex.EncodeToString(hashComputed[:])
 	default:
 		return "", fmt.Errorf("Invalid hash type %s", hashType)
 	}
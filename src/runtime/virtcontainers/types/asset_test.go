@@ -16,6 +16,7 @@ import (
 
 var assetContent = []byte("FakeAsset fake asset FAKE ASSET")
 var assetContentHash = "92549f8d2018a95a294d28a65e795ed7d1a9d150009a28cea108ae10101178676f04ab82a6950d0099e4924f9c5e41dcba8ece56b75fc8b4e0a7492cb2a8c880"
+var assetContentSHA256Hash = "d58d4016d9fec8e1db4e53548aa79cb08943f7d2f6b48283dab7d6e821deecb6"
 var assetContentWrongHash = "92549f8d2018a95a294d28a65e795ed7d1a9d150009a28cea108ae10101178676f04ab82a6950d0099e4924f9c5e41dcba8ece56b75fc8b4e0a7492cb2a8c881"
 
 func TestAssetWrongHashType(t *testing.T) {
@@ -63,6 +64,11 @@ func TestAssetHash(t *testing.T) {
 	assert.Nil(err)
 	assert.Equal(assetContentHash, hash)
 	assert.Equal(assetContentHash, a.computedHash)
+
+	hash, err = a.Hash(annotations.SHA256)
+	assert.Nil(err)
+	assert.Equal(assetContentSHA256Hash, hash)
+	assert.Equal(assetContentSHA256Hash, a.computedHash)
 }
 
 func testPath(t *testing.T, a *Asset, correctPath string, msg string) {
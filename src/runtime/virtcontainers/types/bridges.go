@@ -23,6 +23,12 @@ const (
 	PCIE Type = "pcie"
 )
 
+const (
+	// PHB represents an extra PCI host bridge of a pseries machine, the
+	// root bus of its own PCI domain in the guest
+	PHB Type = "phb"
+)
+
 const CCWBridgeMaxCapacity = 0xffff
 
 const (
@@ -52,6 +58,8 @@ func NewBridge(bt Type, id string, devices map[uint32]string, addr int) Bridge {
 	case PCI:
 		fallthrough
 	case PCIE:
+		fallthrough
+	case PHB:
 		maxCapacity = PCIBridgeMaxCapacity
 	case CCW:
 		maxCapacity = CCWBridgeMaxCapacity
@@ -10,6 +10,8 @@ const (
 	blockDeviceHotplugSupport
 	multiQueueSupport
 	fsSharingSupported
+	serialPortSupport
+	networkDeviceHotplugSupport
 )
 
 // Capabilities describe a virtcontainers hypervisor capabilities
@@ -57,3 +59,23 @@ func (caps *Capabilities) IsFsSharingSupported() bool {
 func (caps *Capabilities) SetFsSharingSupport() {
 	caps.flags |= fsSharingSupported
 }
+
+// IsSerialPortSupported tells if an hypervisor supports virtio-serial ports.
+func (caps *Capabilities) IsSerialPortSupported() bool {
+	return caps.flags&serialPortSupport != 0
+}
+
+// SetSerialPortSupport sets the virtio-serial port capability to true.
+func (caps *Capabilities) SetSerialPortSupport() {
+	caps.flags |= serialPortSupport
+}
+
+// IsNetworkDeviceHotplugSupported tells if an hypervisor supports hotplugging network devices.
+func (caps *Capabilities) IsNetworkDeviceHotplugSupported() bool {
+	return caps.flags&networkDeviceHotplugSupport != 0
+}
+
+// SetNetworkDeviceHotplugSupport sets the network device hotplugging capability to true.
+func (caps *Capabilities) SetNetworkDeviceHotplugSupport() {
+	caps.flags |= networkDeviceHotplugSupport
+}
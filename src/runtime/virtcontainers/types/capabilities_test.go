@@ -43,3 +43,19 @@ func TestMultiQueueCapability(t *testing.T) {
 	caps.SetMultiQueueSupport()
 	assert.True(caps.IsMultiQueueSupported())
 }
+
+func TestSerialPortCapability(t *testing.T) {
+	var caps Capabilities
+
+	assert.False(t, caps.IsSerialPortSupported())
+	caps.SetSerialPortSupport()
+	assert.True(t, caps.IsSerialPortSupported())
+}
+
+func TestNetworkDeviceHotplugCapability(t *testing.T) {
+	var caps Capabilities
+
+	assert.False(t, caps.IsNetworkDeviceHotplugSupported())
+	caps.SetNetworkDeviceHotplugSupport()
+	assert.True(t, caps.IsNetworkDeviceHotplugSupported())
+}
@@ -59,8 +59,13 @@ func (slot PciSlot) String() string {
 // bridge on its parent bridge and so forth until xx is the slot of
 // the "most upstream" bridge on the root bus.  If a device is
 // connected directly to the root bus, its PciPath is just "zz"
+//
+// The path of a device outside of the PCI domain 0, e.g. on an extra
+// PCI host bridge of a pseries machine, is prefixed by its domain, as
+// in "dddd:xx/.../zz"
 type PciPath struct {
-	slots []PciSlot
+	slots  []PciSlot
+	domain uint16
 }
 
 func (p PciPath) String() string {
@@ -68,9 +73,22 @@ func (p PciPath) String() string {
 	for i, slot := range p.slots {
 		tokens[i] = slot.String()
 	}
+	if p.domain != 0 {
+		return fmt.Sprintf("%04x:%s", p.domain, strings.Join(tokens, "/"))
+	}
 	return strings.Join(tokens, "/")
 }
 
+// Domain returns the PCI domain of the path.
+func (p PciPath) Domain() uint16 {
+	return p.domain
+}
+
+// InDomain returns the path p in the PCI domain domain.
+func (p PciPath) InDomain(domain uint16) PciPath {
+	return PciPath{slots: p.slots, domain: domain}
+}
+
 func (p PciPath) IsNil() bool {
 	return p.slots == nil
 }
@@ -83,11 +101,29 @@ func (p PciPath) ToArray() []uint32 {
 	return slots
 }
 
+// Append returns the path of the device at slot of the bus of the bridge at
+// path p.
+func (p PciPath) Append(slot PciSlot) PciPath {
+	slots := make([]PciSlot, len(p.slots), len(p.slots)+1)
+	copy(slots, p.slots)
+	return PciPath{slots: append(slots, slot), domain: p.domain}
+}
+
 func PciPathFromString(s string) (PciPath, error) {
 	if s == "" {
 		return PciPath{}, nil
 	}
 
+	var domain uint16
+	if d, path, ok := strings.Cut(s, ":"); ok {
+		v, err := strconv.ParseUint(d, 16, 16)
+		if err != nil {
+			return PciPath{}, fmt.Errorf("invalid PCI domain in path %q: %v", s, err)
+		}
+		domain = uint16(v)
+		s = path
+	}
+
 	tokens := strings.Split(s, "/")
 	slots := make([]PciSlot, len(tokens))
 	for i, t := range tokens {
@@ -97,7 +133,7 @@ func PciPathFromString(s string) (PciPath, error) {
 			return PciPath{}, err
 		}
 	}
-	return PciPath{slots: slots}, nil
+	return PciPath{slots: slots, domain: domain}, nil
 }
 
 func PciPathFromSlots(slots ...PciSlot) (PciPath, error) {
@@ -94,6 +94,26 @@ func TestPciPath(t *testing.T) {
 	assert.NoError(err)
 	assert.Equal(pcipath, pcipath2)
 
+	pcipath, err = PciPathFromSlots(slot3, slot4)
+	assert.NoError(err)
+	assert.Equal(pcipath.Append(slot5), pcipath2)
+	assert.Equal(pcipath.String(), "03/04")
+
+	// Paths outside of the domain 0
+	pcipath, err = PciPathFromSlots(slot3, slot4)
+	assert.NoError(err)
+	pcipath = pcipath.InDomain(0x1e)
+	assert.Equal(uint16(0x1e), pcipath.Domain())
+	assert.Equal("001e:03/04", pcipath.String())
+	pcipath2, err = PciPathFromString("001e:03/04")
+	assert.NoError(err)
+	assert.Equal(pcipath, pcipath2)
+	assert.Equal("001e:03/04/05", pcipath.Append(slot5).String())
+
+	pcipath2, err = PciPathFromString("0000:03/04")
+	assert.NoError(err)
+	assert.Equal("03/04", pcipath2.String())
+
 	// Bad paths
 	_, err = PciPathFromSlots()
 	assert.Error(err)
@@ -107,4 +127,10 @@ func TestPciPath(t *testing.T) {
 	_, err = PciPathFromString("xyz")
 	assert.Error(err)
 
+	_, err = PciPathFromString("10000:03")
+	assert.Error(err)
+
+	_, err = PciPathFromString("0001:")
+	assert.Error(err)
+
 }
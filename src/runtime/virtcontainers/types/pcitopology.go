@@ -0,0 +1,101 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package types
+
+import (
+	"fmt"
+	"sync"
+)
+
+// pciMaxTopologyDepth bounds the number of bridges between the root bus and a
+// device, it protects Path() against a loop in the topology.
+const pciMaxTopologyDepth = 10
+
+// pciBus is the secondary bus of a bridge or port, plugged at slot of the
+// bus of parent.
+type pciBus struct {
+	parent string
+	slot   PciSlot
+}
+
+// PCITopology models the PCI hierarchy of the guest: the PCI bridges, PCIe
+// root ports and switch ports of the hypervisor, each one at a known slot of
+// the bus of its parent, the root bus being "". It is built along with the
+// configuration of the hypervisor, and predicts the PCI path of the devices
+// from the bus they are plugged on, which is what the agent looks them up by.
+type PCITopology struct {
+	buses map[string]pciBus
+	// used are the slots taken on each bus by the bridges and ports
+	used map[string]map[PciSlot]string
+	sync.Mutex
+}
+
+// NewPCITopology returns the topology of a guest with only a root bus.
+func NewPCITopology() *PCITopology {
+	return &PCITopology{
+		buses: make(map[string]pciBus),
+		used:  make(map[string]map[PciSlot]string),
+	}
+}
+
+// AddBus adds the bridge or port id at slot of the bus of parent. It fails
+// when the slot is already taken by another bridge or port, so that the
+// overlapping topologies are caught before the hypervisor is started.
+func (t *PCITopology) AddBus(id, parent string, slot PciSlot) error {
+	t.Lock()
+	defer t.Unlock()
+
+	if id == "" {
+		return fmt.Errorf("missing ID of the PCI bus at slot %s of %q", slot, parent)
+	}
+	if _, ok := t.buses[id]; ok {
+		return fmt.Errorf("PCI bus %s is already in the topology", id)
+	}
+	if _, ok := t.buses[parent]; parent != "" && !ok {
+		return fmt.Errorf("unknown parent PCI bus %s of %s", parent, id)
+	}
+	if other, ok := t.used[parent][slot]; ok {
+		return fmt.Errorf("PCI slot %s of %q is taken by both %s and %s", slot, parent, other, id)
+	}
+
+	t.buses[id] = pciBus{parent: parent, slot: slot}
+	if t.used[parent] == nil {
+		t.used[parent] = make(map[PciSlot]string)
+	}
+	t.used[parent][slot] = id
+	return nil
+}
+
+// HasBus tells if the bridge or port id is in the topology.
+func (t *PCITopology) HasBus(id string) bool {
+	t.Lock()
+	defer t.Unlock()
+
+	_, ok := t.buses[id]
+	return id == "" || ok
+}
+
+// Path returns the PCI path of the device plugged at slot of the bus of the
+// bridge or port id, the root bus being "".
+func (t *PCITopology) Path(id string, slot PciSlot) (PciPath, error) {
+	t.Lock()
+	defer t.Unlock()
+
+	slots := []PciSlot{slot}
+	for bus := id; bus != ""; {
+		if len(slots) > pciMaxTopologyDepth {
+			return PciPath{}, fmt.Errorf("PCI bus %s is deeper than %d bridges", id, pciMaxTopologyDepth)
+		}
+		b, ok := t.buses[bus]
+		if !ok {
+			return PciPath{}, fmt.Errorf("unknown PCI bus %s", bus)
+		}
+		slots = append([]PciSlot{b.slot}, slots...)
+		bus = b.parent
+	}
+
+	return PciPathFromSlots(slots...)
+}
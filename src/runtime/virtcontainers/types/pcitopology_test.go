@@ -0,0 +1,59 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPCITopology(t *testing.T) {
+	assert := assert.New(t)
+
+	slot := func(v int) PciSlot {
+		s, err := PciSlotFromInt(v)
+		assert.NoError(err)
+		return s
+	}
+
+	topo := NewPCITopology()
+	assert.True(topo.HasBus(""))
+	assert.False(topo.HasBus("rp0"))
+
+	// a bridge, a root port and a switch behind another root port
+	assert.NoError(topo.AddBus("pci-bridge-0", "", slot(2)))
+	assert.NoError(topo.AddBus("rp0", "", slot(0x1e)))
+	assert.NoError(topo.AddBus("swrp0", "", slot(0x1d)))
+	assert.NoError(topo.AddBus("swup0", "swrp0", slot(0)))
+	assert.NoError(topo.AddBus("swdp0", "swup0", slot(0)))
+	assert.NoError(topo.AddBus("swdp1", "swup0", slot(1)))
+	assert.True(topo.HasBus("swdp1"))
+
+	for _, d := range []struct {
+		bus      string
+		slot     int
+		expected string
+	}{
+		{"", 5, "05"},
+		{"pci-bridge-0", 3, "02/03"},
+		{"rp0", 0, "1e/00"},
+		{"swdp1", 0, "1d/00/01/00"},
+	} {
+		path, err := topo.Path(d.bus, slot(d.slot))
+		assert.NoError(err)
+		assert.Equal(d.expected, path.String(), "bus %q", d.bus)
+	}
+
+	_, err := topo.Path("rp1", slot(0))
+	assert.Error(err)
+
+	// overlapping and dangling buses
+	assert.Error(topo.AddBus("rp1", "", slot(0x1e)))
+	assert.Error(topo.AddBus("rp0", "", slot(0x1c)))
+	assert.Error(topo.AddBus("swdp2", "swup1", slot(2)))
+	assert.Error(topo.AddBus("", "", slot(0x1c)))
+}
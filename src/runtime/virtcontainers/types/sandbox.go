@@ -36,6 +36,7 @@ const (
 const (
 	HybridVSockScheme     = "hvsock"
 	MockHybridVSockScheme = "mock"
+	SerialSockScheme      = "serial"
 	VSockScheme           = "vsock"
 )
 
@@ -219,6 +220,19 @@ func (s *MockHybridVSock) String() string {
 	return fmt.Sprintf("%s://%s", MockHybridVSockScheme, s.UdsPath)
 }
 
+// SerialSock defines a virtio-serial port to communicate between
+// the host and any process inside the VM.
+// The host side of the port is a unix socket served by the hypervisor,
+// only one client can be connected at a time.
+type SerialSock struct {
+	UdsPath string
+	Name    string
+}
+
+func (s *SerialSock) String() string {
+	return fmt.Sprintf("%s://%s", SerialSockScheme, s.UdsPath)
+}
+
 // Socket defines a socket to communicate between
 // the host and any process inside the VM.
 type Socket struct {
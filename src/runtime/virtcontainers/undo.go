@@ -0,0 +1,47 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"github.com/sirupsen/logrus"
+)
+
+// undoAction releases a resource allocated while creating a sandbox.
+type undoAction struct {
+	undo func() error
+	name string
+}
+
+// undoStack records the resources allocated while creating a sandbox, the
+// network endpoints, devices, sockets or cgroups, so that all of them are
+// released, in the reverse order, when the creation fails part way.
+type undoStack struct {
+	actions []undoAction
+}
+
+// push registers the action releasing a resource just allocated.
+func (u *undoStack) push(name string, undo func() error) {
+	u.actions = append(u.actions, undoAction{name: name, undo: undo})
+}
+
+// unwind releases the resources in the reverse order of their allocation.
+// A failing action is logged and does not keep the next ones from running,
+// which would leak their resources.
+func (u *undoStack) unwind(logger *logrus.Entry) {
+	for i := len(u.actions) - 1; i >= 0; i-- {
+		action := u.actions[i]
+		if err := action.undo(); err != nil {
+			logger.WithError(err).WithField("resource", action.name).Error("failed to roll back the sandbox creation")
+		}
+	}
+	u.actions = nil
+}
+
+// commit forgets the resources once the sandbox is created, they are
+// then released by Sandbox.Delete.
+func (u *undoStack) commit() {
+	u.actions = nil
+}
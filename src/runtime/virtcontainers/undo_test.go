@@ -0,0 +1,48 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUndoStackUnwind(t *testing.T) {
+	assert := assert.New(t)
+
+	var undone []string
+	var u undoStack
+	for _, name := range []string{"network", "VM", "containers"} {
+		name := name
+		u.push(name, func() error {
+			undone = append(undone, name)
+			if name == "containers" {
+				return errors.New("failed")
+			}
+			return nil
+		})
+	}
+
+	u.unwind(virtLog)
+	assert.Equal([]string{"containers", "VM", "network"}, undone)
+
+	// The actions only ever run once.
+	u.unwind(virtLog)
+	assert.Len(undone, 3)
+}
+
+func TestUndoStackCommit(t *testing.T) {
+	var u undoStack
+	u.push("store", func() error {
+		t.Fatal("committed action should not run")
+		return nil
+	})
+
+	u.commit()
+	u.unwind(virtLog)
+}
@@ -28,6 +28,9 @@ var ioctlFunc = Ioctl
 // See http://stefanha.github.io/virtio/
 var maxUInt uint64 = 1<<32 - 1
 
+// context IDs 0x0, 0x1 and 0x2 are reserved, 0x3 is the first context ID usable.
+const firstContextID uint64 = 0x3
+
 func Ioctl(fd uintptr, request, data uintptr) error {
 	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, fd, request, data); errno != 0 {
 		return os.NewSyscallError("ioctl", fmt.Errorf("%d", int(errno)))
@@ -36,22 +39,12 @@ func Ioctl(fd uintptr, request, data uintptr) error {
 	return nil
 }
 
-// FindContextID finds a unique context ID by generating a random number between 3 and max unsigned int (maxUint).
-// Using the ioctl VHOST_VSOCK_SET_GUEST_CID, findContextID asks to the kernel if the given
-// context ID (N) is available, when the context ID is not available, incrementing by 1 findContextID
-// iterates from N to maxUint until an available context ID is found, otherwise decrementing by 1
-// findContextID iterates from N to 3 until an available context ID is found, this is the last chance
-// to find a context ID available.
-// On success vhost file and a context ID greater or equal than 3 are returned, otherwise 0 and an error are returned.
-// vhost file can be used to send vhost file decriptor to QEMU. It's the caller's responsibility to
-// close vhost file descriptor.
-//
-// Benefits of using random context IDs:
-//   - Reduce the probability of a *DoS attack*, since other processes don't know whatis the initial context ID
-//     used by findContextID to find a context ID available
+// FindContextID finds a unique context ID like FindContextIDFrom, starting
+// from a random number between 3 and max unsigned int (maxUInt). Other
+// processes then don't know which context ID the search starts from, which
+// reduces the probability of a *DoS attack*. The sandboxes start from a
+// context ID derived from their ID instead, see FindContextIDFrom.
 func FindContextID() (*os.File, uint64, error) {
-	// context IDs 0x0, 0x1 and 0x2 are reserved, 0x3 is the first context ID usable.
-	var firstContextID uint64 = 0x3
 	var contextID = firstContextID
 
 	// Generate a random number
@@ -60,6 +53,23 @@ func FindContextID() (*os.File, uint64, error) {
 		contextID = uint64(n.Int64())
 	}
 
+	return FindContextIDFrom(contextID)
+}
+
+// FindContextIDFrom finds a unique context ID, starting from contextID.
+// Using the ioctl VHOST_VSOCK_SET_GUEST_CID, FindContextIDFrom asks to the kernel if the given
+// context ID (N) is available, when the context ID is not available, incrementing by 1 FindContextIDFrom
+// iterates from N to maxUInt until an available context ID is found, otherwise decrementing by 1
+// FindContextIDFrom iterates from N to 3 until an available context ID is found, this is the last chance
+// to find a context ID available.
+// On success vhost file and a context ID greater or equal than 3 are returned, otherwise 0 and an error are returned.
+// vhost file can be used to send vhost file decriptor to QEMU. It's the caller's responsibility to
+// close vhost file descriptor.
+func FindContextIDFrom(contextID uint64) (*os.File, uint64, error) {
+	if contextID < firstContextID || contextID > maxUInt {
+		return nil, 0, fmt.Errorf("Invalid context ID %d", contextID)
+	}
+
 	// Open vhost-vsock device to check what context ID is available.
 	// This file descriptor holds/locks the context ID and it should be
 	// inherited by QEMU process.
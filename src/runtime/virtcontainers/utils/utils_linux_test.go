@@ -35,6 +35,13 @@ func TestFindContextID(t *testing.T) {
 	assert.Nil(f)
 	assert.Zero(cid)
 	assert.Error(err)
+
+	for _, contextID := range []uint64{0, 2, maxUInt + 1} {
+		f, cid, err = FindContextIDFrom(contextID)
+		assert.Nil(f)
+		assert.Zero(cid)
+		assert.Error(err)
+	}
 }
 
 func TestGetDevicePathAndFsTypeOptionsEmptyMount(t *testing.T) {
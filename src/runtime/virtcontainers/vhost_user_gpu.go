@@ -0,0 +1,272 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils/katatrace"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// vhostUserGPUTracingTags defines tags for the trace span
+var vhostUserGPUTracingTags = map[string]string{
+	"source":    "runtime",
+	"package":   "virtcontainers",
+	"subsystem": "vhost-user-gpu",
+}
+
+var (
+	errVhostUserGPUDaemonPathEmpty = errors.New("vhost-user-gpu daemon path is empty")
+	errVhostUserGPUSocketPathEmpty = errors.New("vhost-user-gpu socket path is empty")
+)
+
+const (
+	// vhostUserGPUMaxRestarts is the number of times a crashed backend is
+	// restarted before the VM is stopped.
+	vhostUserGPUMaxRestarts = 3
+)
+
+// vhostUserGPURestartDelay is the time waited before restarting a crashed backend.
+var vhostUserGPURestartDelay = time.Second
+
+// vhostUserGPU supervises the external vhost-user-gpu backend rendering for
+// the virtio-gpu device of the VM.
+//
+// The runtime owns the listening socket and hands it over to the backend, so
+// that a crashed backend can be restarted on the same socket while the
+// hypervisor reconnects to it.
+type vhostUserGPU struct {
+	// socketFD is the listening socket handed over to the backend
+	socketFD *os.File
+	// onRestart is called with the PID of a restarted backend
+	onRestart func(pid int)
+	// path to the backend binary
+	path string
+	// socketPath where the backend will serve
+	socketPath string
+	// extraArgs list of extra args to append to the backend command
+	extraArgs []string
+	// credential the backend runs with, the same as the hypervisor's
+	credential *syscall.Credential
+	// PID process ID of the backend process
+	PID int
+	// restarts is the number of times the backend was restarted
+	restarts int
+	// stopped is set once the backend is stopped on purpose
+	stopped bool
+	mu      sync.Mutex
+}
+
+// Open socket on behalf of the backend
+// return file descriptor to be used by the backend.
+func (v *vhostUserGPU) getSocketFD() (*os.File, error) {
+	if _, err := os.Stat(filepath.Dir(v.socketPath)); err != nil {
+		return nil, errors.Errorf("Socket directory does not exist %s", filepath.Dir(v.socketPath))
+	}
+
+	// Remove the socket of a previous run
+	if err := os.Remove(v.socketPath); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	listener, err := net.ListenUnix("unix", &net.UnixAddr{Name: v.socketPath, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := utils.ChownToParent(v.socketPath); err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	// no longer needed since fd is a dup
+	defer listener.Close()
+
+	listener.SetUnlinkOnClose(false)
+
+	return listener.File()
+}
+
+// Start the vhost-user-gpu backend and supervise it until it is stopped.
+// onQuit is called when the backend cannot be restarted.
+func (v *vhostUserGPU) Start(ctx context.Context, onQuit onQuitFunc) (int, error) {
+	span, _ := katatrace.Trace(ctx, v.Logger(), "Start", vhostUserGPUTracingTags)
+	defer span.End()
+
+	if err := v.valid(); err != nil {
+		return 0, err
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	socketFD, err := v.getSocketFD()
+	if err != nil {
+		return 0, err
+	}
+	v.socketFD = socketFD
+
+	cmd, err := v.spawn()
+	if err != nil {
+		v.socketFD.Close()
+		return 0, err
+	}
+
+	go v.supervise(cmd, onQuit)
+
+	return v.PID, nil
+}
+
+// spawn starts a backend process serving on the socket. Must be called
+// with mu held.
+func (v *vhostUserGPU) spawn() (*exec.Cmd, error) {
+	cmd := exec.Command(v.path)
+	cmd.ExtraFiles = append(cmd.ExtraFiles, v.socketFD)
+	// The backend does not need anything from the runtime environment.
+	cmd.Env = []string{}
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Credential: v.credential,
+		Setsid:     true,
+	}
+
+	// Extra files start from 2 (0: stdin, 1: stdout, 2: stderr)
+	socketFdNumber := 2 + uint(len(cmd.ExtraFiles))
+	args := v.args(socketFdNumber)
+	cmd.Args = append(cmd.Args, args...)
+
+	v.Logger().WithField("path", v.path).Info()
+	v.Logger().WithField("args", strings.Join(args, " ")).Info()
+
+	if err := utils.StartCmd(cmd); err != nil {
+		return nil, err
+	}
+
+	v.PID = cmd.Process.Pid
+
+	return cmd, nil
+}
+
+// supervise restarts the backend when it quits unexpectedly.
+func (v *vhostUserGPU) supervise(cmd *exec.Cmd, onQuit onQuitFunc) {
+	for {
+		err := cmd.Wait()
+
+		v.mu.Lock()
+		if v.stopped {
+			v.mu.Unlock()
+			return
+		}
+
+		v.Logger().WithError(err).WithField("restarts", v.restarts).Warn("vhost-user-gpu quits")
+
+		if v.restarts >= vhostUserGPUMaxRestarts {
+			v.PID = 0
+			v.mu.Unlock()
+			v.Logger().Error("vhost-user-gpu keeps crashing, giving up")
+			if onQuit != nil {
+				onQuit()
+			}
+			return
+		}
+		v.restarts++
+		v.mu.Unlock()
+
+		time.Sleep(vhostUserGPURestartDelay)
+
+		v.mu.Lock()
+		if v.stopped {
+			v.mu.Unlock()
+			return
+		}
+		cmd, err = v.spawn()
+		pid := v.PID
+		v.mu.Unlock()
+
+		if err != nil {
+			v.Logger().WithError(err).Error("failed to restart vhost-user-gpu")
+			if onQuit != nil {
+				onQuit()
+			}
+			return
+		}
+
+		if v.onRestart != nil {
+			v.onRestart(pid)
+		}
+	}
+}
+
+// Stop the backend and remove its socket.
+func (v *vhostUserGPU) Stop(ctx context.Context) error {
+	span, _ := katatrace.Trace(ctx, v.Logger(), "Stop", vhostUserGPUTracingTags)
+	defer span.End()
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.stopped = true
+
+	if v.PID != 0 {
+		if err := syscall.Kill(v.PID, syscall.SIGKILL); err != nil {
+			v.Logger().WithError(err).WithField("pid", v.PID).Warn("kill vhost-user-gpu failed")
+		}
+		v.PID = 0
+	}
+
+	if v.socketFD != nil {
+		v.socketFD.Close()
+		v.socketFD = nil
+	}
+
+	if err := os.Remove(v.socketPath); err != nil && !os.IsNotExist(err) {
+		v.Logger().WithError(err).WithField("path", v.socketPath).Warn("removing vhost-user-gpu socket failed")
+	}
+
+	return nil
+}
+
+func (v *vhostUserGPU) args(fdSocketNumber uint) []string {
+	args := []string{
+		// fd number of vhost-user socket
+		fmt.Sprintf("--fd=%v", fdSocketNumber),
+		// render with virglrenderer
+		"--virgl",
+	}
+
+	if len(v.extraArgs) != 0 {
+		args = append(args, v.extraArgs...)
+	}
+
+	return args
+}
+
+func (v *vhostUserGPU) valid() error {
+	if v.path == "" {
+		return errVhostUserGPUDaemonPathEmpty
+	}
+
+	if v.socketPath == "" {
+		return errVhostUserGPUSocketPathEmpty
+	}
+
+	return nil
+}
+
+func (v *vhostUserGPU) Logger() *log.Entry {
+	return hvLogger.WithField("subsystem", "vhost-user-gpu")
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVhostUserGPUArgs(t *testing.T) {
+	v := &vhostUserGPU{
+		extraArgs: []string{"--render-node=/dev/dri/renderD129"},
+	}
+
+	assert.Equal(t, []string{"--fd=3", "--virgl", "--render-node=/dev/dri/renderD129"}, v.args(3))
+}
+
+func TestVhostUserGPUValid(t *testing.T) {
+	assert := assert.New(t)
+
+	v := &vhostUserGPU{}
+	assert.Equal(errVhostUserGPUDaemonPathEmpty, v.valid())
+
+	v.path = "/usr/libexec/vhost-user-gpu"
+	assert.Equal(errVhostUserGPUSocketPathEmpty, v.valid())
+
+	v.socketPath = "/run/vc/vm/foo/vhost-gpu.sock"
+	assert.NoError(v.valid())
+}
+
+func TestVhostUserGPURestart(t *testing.T) {
+	assert := assert.New(t)
+
+	falsePath, err := exec.LookPath("false")
+	if err != nil {
+		t.Skip("false not found")
+	}
+
+	savedDelay := vhostUserGPURestartDelay
+	vhostUserGPURestartDelay = time.Millisecond
+	defer func() { vhostUserGPURestartDelay = savedDelay }()
+
+	restarted := make(chan int, vhostUserGPUMaxRestarts)
+	quit := make(chan struct{})
+
+	v := &vhostUserGPU{
+		path:       falsePath,
+		socketPath: filepath.Join(t.TempDir(), "vhost-gpu.sock"),
+		onRestart: func(pid int) {
+			restarted <- pid
+		},
+	}
+
+	pid, err := v.Start(context.Background(), func() { close(quit) })
+	assert.NoError(err)
+	assert.NotZero(pid)
+
+	select {
+	case <-quit:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for vhost-user-gpu to give up")
+	}
+	assert.Len(restarted, vhostUserGPUMaxRestarts)
+
+	_, err = os.Stat(v.socketPath)
+	assert.NoError(err)
+
+	assert.NoError(v.Stop(context.Background()))
+	_, err = os.Stat(v.socketPath)
+	assert.True(os.IsNotExist(err))
+}
@@ -135,3 +135,7 @@ func (vfw *virtFramework) GenerateSocket(id string) (interface{}, error) {
 func (vfw *virtFramework) IsRateLimiterBuiltin() bool {
 	return false
 }
+
+func (vfw *virtFramework) FlushDrives(ctx context.Context, driveIDs []string) error {
+	return nil
+}
@@ -77,6 +77,8 @@ type virtiofsd struct {
 	sourcePath string
 	// extraArgs list of extra args to append to virtiofsd command
 	extraArgs []string
+	// submounts announces the submounts of sourcePath to the guest
+	submounts bool
 	// PID process ID of virtiosd process
 	PID int
 }
@@ -193,6 +195,10 @@ func (v *virtiofsd) args(FdSocketNumber uint) ([]string, error) {
 		fmt.Sprintf("--fd=%v", FdSocketNumber),
 	}
 
+	if v.submounts {
+		args = append(args, "--announce-submounts")
+	}
+
 	if len(v.extraArgs) != 0 {
 		args = append(args, v.extraArgs...)
 	}
@@ -88,6 +88,12 @@ func TestVirtiofsdArgs(t *testing.T) {
 	args, err = v.args(456)
 	assert.NoError(err)
 	assert.Equal(expected, strings.Join(args, " "))
+
+	v.submounts = true
+	expected = "--syslog --cache=none --shared-dir=/run/kata-shared/foo --fd=456 --announce-submounts"
+	args, err = v.args(456)
+	assert.NoError(err)
+	assert.Equal(expected, strings.Join(args, " "))
 }
 
 func TestValid(t *testing.T) {
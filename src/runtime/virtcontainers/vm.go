@@ -263,6 +263,11 @@ func (v *VM) Stop(ctx context.Context) error {
 	return v.store.Destroy(v.id)
 }
 
+// GetPids returns the process IDs of the VM hypervisor.
+func (v *VM) GetPids() []int {
+	return v.hypervisor.GetPids()
+}
+
 // AddCPUs adds num of CPUs to the VM.
 func (v *VM) AddCPUs(ctx context.Context, num uint32) error {
 	if num > 0 {
@@ -0,0 +1,193 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils/katatrace"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+)
+
+const (
+	// VMRestartNever never restarts the VM of the sandbox, its containers
+	// exit with it.
+	VMRestartNever = "never"
+
+	// VMRestartOnCrash restarts the VM of the sandbox when the hypervisor
+	// or the agent quits unexpectedly, along with the containers that
+	// were running.
+	VMRestartOnCrash = "on-crash"
+)
+
+// vmMaxRestarts is the number of times the VM of a sandbox is restarted
+// before its containers are left to exit.
+const vmMaxRestarts = 3
+
+// ValidVMRestartPolicy tells if policy is a known VM restart policy, the
+// empty string being the default never policy.
+func ValidVMRestartPolicy(policy string) bool {
+	switch policy {
+	case "", VMRestartNever, VMRestartOnCrash:
+		return true
+	default:
+		return false
+	}
+}
+
+// CanRestart tells if the VM of the sandbox is restarted after a crash.
+// The sandboxes with cold plugged devices are not, the devices would have
+// to be added to the new VM before it is started.
+func (s *Sandbox) CanRestart() bool {
+	if s.config.VMRestartPolicy != VMRestartOnCrash || s.factory != nil {
+		return false
+	}
+	if s.config.HypervisorConfig.ColdPlugVFIO != config.NoPort && len(s.config.HypervisorConfig.VFIODevices) > 0 {
+		return false
+	}
+
+	return s.restarts < vmMaxRestarts
+}
+
+// Restart recreates the VM of a sandbox whose hypervisor or agent quit
+// unexpectedly. The network endpoints are attached to the new VM as they
+// were saved, without calling the network plugin again, and the containers
+// that were running are created again from their configuration and rootfs,
+// then started. The stateless workloads get the restart semantics they
+// would have with a process based runtime.
+func (s *Sandbox) Restart(ctx context.Context) error {
+	span, ctx := katatrace.Trace(ctx, s.Logger(), "Restart", sandboxTracingTags, map[string]string{"sandbox_id": s.id})
+	defer span.End()
+
+	if !s.CanRestart() {
+		return fmt.Errorf("sandbox %s VM cannot be restarted", s.id)
+	}
+	if s.state.State != types.StateRunning {
+		return errSandboxNotRunning
+	}
+
+	s.restarts++
+	s.Logger().WithField("restarts", s.restarts).Warn("Restarting the VM")
+
+	if s.monitor != nil {
+		s.monitor.stop()
+		s.monitor = nil
+	}
+
+	var running []*Container
+	for _, c := range s.containers {
+		if c.state.State == types.StateRunning {
+			running = append(running, c)
+		}
+		if err := c.stop(ctx, true); err != nil {
+			c.Logger().WithError(err).Warn("Failed to stop container of the crashed VM")
+		}
+	}
+
+	if err := s.tearDownVM(ctx); err != nil {
+		return err
+	}
+
+	if err := s.recreateVM(ctx); err != nil {
+		return err
+	}
+
+	for _, c := range running {
+		if err := c.create(ctx); err != nil {
+			return err
+		}
+	}
+
+	// The new VM has the default resources, the ones of the containers
+	// are added back.
+	if err := s.updateResources(ctx); err != nil {
+		return err
+	}
+	if err := s.resourceControllerUpdate(ctx); err != nil {
+		return err
+	}
+
+	for _, c := range running {
+		if err := c.start(ctx); err != nil {
+			return err
+		}
+	}
+
+	s.Logger().Info("VM restarted")
+
+	return s.storeSandbox(ctx)
+}
+
+// tearDownVM releases what is left of the crashed VM. The endpoints are
+// detached as if the runtime had created the network namespace: the
+// interfaces of the network plugin are kept, the taps and filters
+// connecting them to the crashed VM are removed.
+func (s *Sandbox) tearDownVM(ctx context.Context) error {
+	if err := s.stopVM(ctx); err != nil {
+		s.Logger().WithError(err).Warn("Failed to stop the crashed VM")
+	}
+
+	if s.cw != nil {
+		s.cw.stop()
+		s.cw = nil
+	}
+
+	if err := s.agent.disconnect(ctx); err != nil {
+		s.Logger().WithError(err).Warn("Failed to disconnect from the agent of the crashed VM")
+	}
+
+	if err := s.hypervisor.Cleanup(ctx); err != nil {
+		s.Logger().WithError(err).Warn("Failed to cleanup the crashed hypervisor")
+	}
+
+	for _, endpoint := range s.network.Endpoints() {
+		if err := endpoint.Detach(ctx, true, s.network.NetworkID()); err != nil {
+			return fmt.Errorf("failed to detach endpoint %s from the crashed VM: %w", endpoint.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// recreateVM creates and starts a new VM for the sandbox, with its network
+// endpoints attached.
+func (s *Sandbox) recreateVM(ctx context.Context) error {
+	hypervisor, err := NewHypervisor(s.config.HypervisorType)
+	if err != nil {
+		return err
+	}
+	s.hypervisor = hypervisor
+
+	if err := s.hypervisor.CreateVM(ctx, s.id, s.network, &s.config.HypervisorConfig); err != nil {
+		return err
+	}
+
+	s.agent = getNewAgentFunc(ctx)()
+	if s.disableVMShutdown, err = s.agent.init(ctx, s, s.config.AgentConfig); err != nil {
+		return err
+	}
+
+	if err := s.network.Run(ctx, func() error {
+		for _, endpoint := range s.network.Endpoints() {
+			if err := endpoint.Attach(ctx, s); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := s.startVM(ctx, nil); err != nil {
+		return err
+	}
+
+	s.postCreatedNetwork(ctx)
+
+	return s.getAndStoreGuestDetails(ctx)
+}
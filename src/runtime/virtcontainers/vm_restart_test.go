@@ -0,0 +1,72 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/device/config"
+	ktu "github.com/kata-containers/kata-containers/src/runtime/pkg/katatestutils"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidVMRestartPolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, policy := range []string{"", VMRestartNever, VMRestartOnCrash} {
+		assert.True(ValidVMRestartPolicy(policy), policy)
+	}
+	assert.False(ValidVMRestartPolicy("always"))
+}
+
+func TestSandboxCanRestart(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{config: &SandboxConfig{}}
+	assert.False(s.CanRestart())
+
+	s.config.VMRestartPolicy = VMRestartOnCrash
+	assert.True(s.CanRestart())
+
+	s.restarts = vmMaxRestarts
+	assert.False(s.CanRestart())
+
+	s.restarts = 0
+	s.config.HypervisorConfig.ColdPlugVFIO = config.RootPort
+	s.config.HypervisorConfig.VFIODevices = []config.DeviceInfo{{ContainerPath: "/dev/vfio/1"}}
+	assert.False(s.CanRestart())
+}
+
+func TestSandboxRestart(t *testing.T) {
+	if tc.NotValid(ktu.NeedRoot()) {
+		t.Skip(testDisabledAsNonRoot)
+	}
+	defer cleanUp()
+
+	assert := assert.New(t)
+
+	config := newTestSandboxConfigNoop()
+	config.VMRestartPolicy = VMRestartOnCrash
+
+	ctx := WithNewAgentFunc(context.Background(), newMockAgent)
+	p, _, err := createAndStartSandbox(ctx, config)
+	assert.NoError(err)
+	s := p.(*Sandbox)
+
+	for i := 0; i < vmMaxRestarts; i++ {
+		assert.NoError(s.Restart(ctx))
+		assert.Equal(types.StateRunning, s.state.State)
+
+		c, err := s.findContainer(containerID)
+		assert.NoError(err)
+		assert.Equal(types.StateRunning, c.state.State)
+	}
+
+	assert.False(s.CanRestart())
+	assert.Error(s.Restart(ctx))
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"sync"
+
+	v1 "github.com/containerd/cgroups/stats/v1"
+	v2 "github.com/containerd/cgroups/v2/stats"
+	resCtrl "github.com/kata-containers/kata-containers/src/runtime/pkg/resourcecontrol"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/sirupsen/logrus"
+)
+
+// VMMLimits are the resource limits of the VMM and of the auxiliary processes
+// of a sandbox, such as virtiofsd, so that a misbehaving sandbox does not
+// exhaust the resources of the node.
+type VMMLimits struct {
+	// MaxOpenFiles is the soft RLIMIT_NOFILE of each process. The hard
+	// limit is left as is, so that the limit of a running process can
+	// still be raised, e.g. for the devices hotplugged later. It is not
+	// changed when 0.
+	MaxOpenFiles uint64
+
+	// MaxThreads is the number of processes and threads of each host
+	// cgroup of the sandbox, enforced through the pids controller, the
+	// kernel having no per process limit enforced for root. It is not
+	// changed when 0.
+	MaxThreads uint64
+
+	// AlertPercent is the usage of a limit, in percent, above which an
+	// alert is logged. The open files are checked against RLIMIT_NOFILE
+	// even when MaxOpenFiles is 0. No alert is raised when 0.
+	AlertPercent uint32
+}
+
+// vmmLimitAlerts remembers the limits the VMM processes are nearing, so that
+// the alert is logged once when the usage goes above the threshold, and once
+// when it goes back below.
+type vmmLimitAlerts struct {
+	raised map[string]bool
+	sync.Mutex
+}
+
+// update records the usage of a limit, the key telling apart the limits of
+// the resources of the processes and cgroups. It tells if the alert was
+// raised or cleared by this usage.
+func (a *vmmLimitAlerts) update(key string, usage, limit uint64, percent uint32) (raised, cleared bool) {
+	a.Lock()
+	defer a.Unlock()
+
+	if a.raised == nil {
+		a.raised = make(map[string]bool)
+	}
+
+	above := limit > 0 && usage*100 >= limit*uint64(percent)
+	if above == a.raised[key] {
+		return false, false
+	}
+
+	if above {
+		a.raised[key] = true
+		return true, false
+	}
+	delete(a.raised, key)
+	return false, true
+}
+
+// checkVMMLimits applies the resource limits of the sandbox to the VMM and
+// auxiliary processes, and alerts when they near them. Like checkVMMSched,
+// it is called periodically by the monitor, so that the auxiliary processes
+// started since get the limits as well.
+func (s *Sandbox) checkVMMLimits() error {
+	if s.config == nil {
+		return fmt.Errorf("no sandbox config found")
+	}
+
+	limits := s.config.VMMLimits
+	if limits == (VMMLimits{}) {
+		return nil
+	}
+
+	var limitErr error
+	for _, pid := range s.hypervisor.GetPids() {
+		if pid == 0 {
+			continue
+		}
+
+		if limits.MaxOpenFiles > 0 {
+			changed, err := resCtrl.SetProcessSoftRlimit(pid, resCtrl.RlimitNofile, limits.MaxOpenFiles)
+			if err != nil && limitErr == nil {
+				limitErr = err
+			}
+			if changed {
+				s.Logger().WithFields(logrus.Fields{"pid": pid, "limit": limits.MaxOpenFiles}).Debug("VMM process open files limited")
+			}
+		}
+
+		if limits.AlertPercent == 0 {
+			continue
+		}
+
+		// the soft limit is read back, the process may have raised it
+		maxOpenFiles, err := resCtrl.ProcessRlimit(pid, resCtrl.RlimitNofile)
+		if err != nil {
+			continue
+		}
+		if files, err := resCtrl.ProcessOpenFiles(pid); err == nil {
+			s.alertVMMLimit(fmt.Sprintf("%d/open-files", pid), logrus.Fields{"pid": pid, "resource": "open-files"}, uint64(files), maxOpenFiles)
+		}
+	}
+
+	if limits.MaxThreads == 0 {
+		return limitErr
+	}
+
+	for _, controller := range []resCtrl.ResourceController{s.sandboxController, s.overheadController} {
+		if controller == nil {
+			continue
+		}
+
+		if err := controller.Update(&specs.LinuxResources{
+			Pids: &specs.LinuxPids{Limit: int64(limits.MaxThreads)},
+		}); err != nil {
+			if limitErr == nil {
+				limitErr = fmt.Errorf("failed to limit the threads of %s: %v", controller.ID(), err)
+			}
+			continue
+		}
+
+		if limits.AlertPercent == 0 {
+			continue
+		}
+
+		if threads, err := controllerThreads(controller); err == nil {
+			s.alertVMMLimit(controller.ID()+"/threads", logrus.Fields{"cgroup": controller.ID(), "resource": "threads"}, threads, limits.MaxThreads)
+		}
+	}
+
+	return limitErr
+}
+
+// controllerThreads returns the number of processes and threads of a
+// controller, as counted by its pids controller.
+func controllerThreads(controller resCtrl.ResourceController) (uint64, error) {
+	metrics, err := controller.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	switch mt := metrics.(type) {
+	case *v1.Metrics:
+		if mt.Pids != nil {
+			return mt.Pids.Current, nil
+		}
+	case *v2.Metrics:
+		if mt.Pids != nil {
+			return mt.Pids.Current, nil
+		}
+	}
+
+	return 0, fmt.Errorf("no pids statistics for %s", controller.ID())
+}
+
+func (s *Sandbox) alertVMMLimit(key string, fields logrus.Fields, usage, limit uint64) {
+	raised, cleared := s.vmmLimitAlerts.update(key, usage, limit, s.config.VMMLimits.AlertPercent)
+	if !raised && !cleared {
+		return
+	}
+
+	logger := s.Logger().WithFields(fields).WithFields(logrus.Fields{
+		"usage": usage,
+		"limit": limit,
+	})
+	if raised {
+		logger.Warn("VMM is nearing its resource limit")
+	} else {
+		logger.Info("VMM is back below its resource limit alert")
+	}
+}
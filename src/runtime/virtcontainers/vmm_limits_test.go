@@ -0,0 +1,101 @@
+// Copyright (c) 2026 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	v2 "github.com/containerd/cgroups/v2/stats"
+	resCtrl "github.com/kata-containers/kata-containers/src/runtime/pkg/resourcecontrol"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVMMLimitAlerts(t *testing.T) {
+	assert := assert.New(t)
+
+	var a vmmLimitAlerts
+
+	raised, cleared := a.update("42/open-files", 80, 100, 90)
+	assert.False(raised)
+	assert.False(cleared)
+
+	raised, cleared = a.update("42/open-files", 90, 100, 90)
+	assert.True(raised)
+	assert.False(cleared)
+
+	// raised once
+	raised, cleared = a.update("42/open-files", 95, 100, 90)
+	assert.False(raised)
+	assert.False(cleared)
+
+	// per key
+	raised, _ = a.update("43/open-files", 95, 100, 90)
+	assert.True(raised)
+	raised, _ = a.update("/kata_42/threads", 95, 100, 90)
+	assert.True(raised)
+
+	raised, cleared = a.update("42/open-files", 10, 100, 90)
+	assert.False(raised)
+	assert.True(cleared)
+
+	// no limit
+	raised, _ = a.update("44/open-files", 95, 0, 90)
+	assert.False(raised)
+}
+
+// fakeThreadsController is a resource controller counting its threads.
+type fakeThreadsController struct {
+	resCtrl.ResourceController
+	resources *specs.LinuxResources
+	threads   uint64
+}
+
+func (c *fakeThreadsController) ID() string {
+	return "/kata_test"
+}
+
+func (c *fakeThreadsController) Update(resources *specs.LinuxResources) error {
+	c.resources = resources
+	return nil
+}
+
+func (c *fakeThreadsController) Stat() (interface{}, error) {
+	return &v2.Metrics{Pids: &v2.PidsStat{Current: c.threads}}, nil
+}
+
+func TestSandboxCheckVMMLimits(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{
+		config:     &SandboxConfig{},
+		hypervisor: &mockHypervisor{},
+	}
+	assert.NoError(s.checkVMMLimits())
+
+	// the mock hypervisor has no process
+	s.config.VMMLimits = VMMLimits{MaxOpenFiles: 1024, AlertPercent: 90}
+	assert.NoError(s.checkVMMLimits())
+
+	// the alerts of the current process, its limits are left as is
+	s.hypervisor = &mockHypervisor{mockPid: os.Getpid()}
+	s.config.VMMLimits = VMMLimits{AlertPercent: 100}
+	assert.NoError(s.checkVMMLimits())
+	assert.NotContains(s.vmmLimitAlerts.raised, fmt.Sprintf("%d/open-files", os.Getpid()))
+
+	// the threads are limited through the pids controller
+	controller := &fakeThreadsController{threads: 60}
+	s.sandboxController = controller
+	s.config.VMMLimits = VMMLimits{MaxThreads: 100, AlertPercent: 50}
+	assert.NoError(s.checkVMMLimits())
+	assert.Equal(int64(100), controller.resources.Pids.Limit)
+	assert.True(s.vmmLimitAlerts.raised["/kata_test/threads"])
+
+	s.config = nil
+	assert.Error(s.checkVMMLimits())
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+
+	resCtrl "github.com/kata-containers/kata-containers/src/runtime/pkg/resourcecontrol"
+)
+
+const (
+	// VMMSchedDefault leaves the scheduling of the VMM threads to the
+	// host defaults.
+	VMMSchedDefault = "default"
+
+	// VMMSchedLatency runs the vCPU threads with the SCHED_FIFO realtime
+	// policy, and gives the VMM threads the highest best effort IO
+	// priority, for the latency critical pods.
+	VMMSchedLatency = "latency"
+
+	// VMMSchedBatch runs the VMM threads with the SCHED_IDLE policy and
+	// the idle IO class, for the batch pods.
+	VMMSchedBatch = "batch"
+)
+
+// vmmSchedMaxRTPriority is the highest static priority of the realtime
+// scheduling policies.
+const vmmSchedMaxRTPriority = 99
+
+// ValidVMMSchedClass tells if class is a known VMM scheduling class, the
+// empty string being the default class.
+func ValidVMMSchedClass(class string) bool {
+	switch class {
+	case "", VMMSchedDefault, VMMSchedLatency, VMMSchedBatch:
+		return true
+	default:
+		return false
+	}
+}
+
+// ValidVMMSchedRTPriority checks the realtime priority of the vCPU threads of
+// the latency class against the highest priority allowed, realtime
+// scheduling being disabled when it is 0.
+func ValidVMMSchedRTPriority(priority, maxPriority int) error {
+	if maxPriority <= 0 {
+		return fmt.Errorf("realtime scheduling of the vCPU threads is disabled")
+	}
+	if maxPriority > vmmSchedMaxRTPriority {
+		return fmt.Errorf("highest realtime priority %d is above %d", maxPriority, vmmSchedMaxRTPriority)
+	}
+	if priority < 1 || priority > maxPriority {
+		return fmt.Errorf("realtime priority %d is not between 1 and %d", priority, maxPriority)
+	}
+	return nil
+}
+
+// checkVMMSched applies the scheduling class of the sandbox to the threads of
+// the VMM. It is called periodically by the monitor, so that the threads
+// created since, such as the ones of the hotplugged vCPUs, are scheduled as
+// well, and the threads whose scheduling was changed behind our back are
+// set again.
+func (s *Sandbox) checkVMMSched(ctx context.Context) error {
+	if s.config == nil {
+		return fmt.Errorf("no sandbox config found")
+	}
+
+	var vmmSched, vcpuSched resCtrl.ThreadSched
+	switch s.config.VMMSchedClass {
+	case VMMSchedLatency:
+		vmmSched = resCtrl.ThreadSched{Policy: resCtrl.SchedOther, IOClass: resCtrl.IOPrioClassBestEffort}
+		vcpuSched = vmmSched
+		vcpuSched.Policy = resCtrl.SchedFIFO
+		vcpuSched.Priority = s.config.VMMSchedRTPriority
+	case VMMSchedBatch:
+		vmmSched = resCtrl.ThreadSched{Policy: resCtrl.SchedIdle, IOClass: resCtrl.IOPrioClassIdle}
+		vcpuSched = vmmSched
+	default:
+		return nil
+	}
+
+	pids := s.hypervisor.GetPids()
+	if len(pids) == 0 || pids[0] == 0 {
+		return nil
+	}
+	tids, err := resCtrl.ProcessThreadIDs(pids[0])
+	if err != nil {
+		return fmt.Errorf("failed to get the VMM threads: %v", err)
+	}
+
+	vcpus := make(map[int]bool)
+	if vCPUThreadsMap, err := s.hypervisor.GetThreadIDs(ctx); err == nil {
+		for _, tid := range vCPUThreadsMap.vcpus {
+			vcpus[tid] = true
+		}
+	} else {
+		s.Logger().WithError(err).Warn("failed to get the vCPU threads")
+	}
+
+	// The threads may exit meanwhile, the other threads are scheduled
+	// anyway.
+	var schedErr error
+	for _, tid := range tids {
+		sched := vmmSched
+		if vcpus[tid] {
+			sched = vcpuSched
+		}
+		changed, err := resCtrl.SetThreadSched(tid, sched)
+		if err != nil {
+			if schedErr == nil {
+				schedErr = err
+			}
+			continue
+		}
+		if changed {
+			s.Logger().WithField("thread", tid).WithField("class", s.config.VMMSchedClass).Debug("VMM thread scheduled")
+		}
+	}
+
+	return schedErr
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidVMMSchedClass(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, class := range []string{"", VMMSchedDefault, VMMSchedLatency, VMMSchedBatch} {
+		assert.True(ValidVMMSchedClass(class), class)
+	}
+	assert.False(ValidVMMSchedClass("realtime"))
+}
+
+func TestValidVMMSchedRTPriority(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(ValidVMMSchedRTPriority(1, 1))
+	assert.NoError(ValidVMMSchedRTPriority(10, 50))
+
+	// realtime scheduling disabled
+	assert.Error(ValidVMMSchedRTPriority(1, 0))
+	assert.Error(ValidVMMSchedRTPriority(1, 100))
+	assert.Error(ValidVMMSchedRTPriority(0, 50))
+	assert.Error(ValidVMMSchedRTPriority(51, 50))
+}
+
+func TestSandboxCheckVMMSched(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{
+		config:     &SandboxConfig{},
+		hypervisor: &mockHypervisor{},
+	}
+	assert.NoError(s.checkVMMSched(context.Background()))
+
+	// the mock hypervisor has no process
+	s.config.VMMSchedClass = VMMSchedBatch
+	assert.NoError(s.checkVMMSched(context.Background()))
+
+	s.config = nil
+	assert.Error(s.checkVMMSched(context.Background()))
+}
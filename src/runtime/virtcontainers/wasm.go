@@ -0,0 +1,107 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/grpc"
+)
+
+// With a WASM runtime, the containers whose image targets WASM are run by
+// the WASM runtime of the guest image: the runtime binary is bind mounted in
+// the container rootfs and runs the module of the container entrypoint, with
+// the arguments and the environment of the container, the rootfs being
+// preopened. The other containers of the pod are run natively.
+//
+// The binary runs from the rootfs of the container, which has no libraries
+// to link against, so the guest image ships a static build of the runtime,
+// see the WASMTIME option of the rootfs builder.
+
+const (
+	// WasmRuntimeWasmtime runs the WASM containers with wasmtime.
+	WasmRuntimeWasmtime = "wasmtime"
+
+	// wasmVariantAnnotation is the annotation of the WASM images, as
+	// set by the image builders and honored by crun and youki.
+	wasmVariantAnnotation = "module.wasm.image/variant"
+	wasmVariantCompat     = "compat"
+	// With the compat-smart variant, only the containers whose
+	// entrypoint is a WASM module are run by the WASM runtime.
+	wasmVariantCompatSmart = "compat-smart"
+
+	// wasmHandlerAnnotation selects the handler of a container, as
+	// honored by crun.
+	wasmHandlerAnnotation = "run.oci.handler"
+	wasmHandler           = "wasm"
+
+	// guestWasmRuntimeDir is where the WASM runtimes are installed in the
+	// guest image.
+	guestWasmRuntimeDir = "/usr/bin"
+
+	// wasmRuntimeMountDir is where the WASM runtime is mounted in the
+	// container rootfs.
+	wasmRuntimeMountDir = "/.kata-wasm"
+)
+
+// ValidWasmRuntime tells whether the WASM runtime is supported.
+func ValidWasmRuntime(runtime string) bool {
+	return runtime == WasmRuntimeWasmtime
+}
+
+// isWasmContainer tells whether the container described by the spec targets
+// WASM.
+func isWasmContainer(grpcSpec *grpc.Spec) bool {
+	if grpcSpec.Process == nil || len(grpcSpec.Process.Args) == 0 {
+		return false
+	}
+
+	if grpcSpec.Annotations[wasmHandlerAnnotation] == wasmHandler {
+		return true
+	}
+
+	switch grpcSpec.Annotations[wasmVariantAnnotation] {
+	case wasmVariantCompat:
+		return true
+	case wasmVariantCompatSmart:
+		return strings.HasSuffix(grpcSpec.Process.Args[0], ".wasm")
+	default:
+		return false
+	}
+}
+
+// delegateWasm has the WASM runtime run the container if it targets WASM.
+func delegateWasm(grpcSpec *grpc.Spec, runtime string) error {
+	if runtime == "" || !isWasmContainer(grpcSpec) {
+		return nil
+	}
+
+	binary := filepath.Join(wasmRuntimeMountDir, runtime)
+	grpcSpec.Mounts = append(grpcSpec.Mounts, grpc.Mount{
+		Source:      filepath.Join(guestWasmRuntimeDir, runtime),
+		Destination: binary,
+		Type:        "bind",
+		Options:     []string{"rbind", "ro", "nosuid", "nodev"},
+	})
+
+	args := []string{binary}
+	switch runtime {
+	case WasmRuntimeWasmtime:
+		args = append(args, "run", "--dir=/")
+	default:
+		return fmt.Errorf("unsupported WASM runtime %q", runtime)
+	}
+	for _, env := range grpcSpec.Process.Env {
+		args = append(args, "--env", env)
+	}
+
+	// The arguments after the module are the arguments of the module.
+	grpcSpec.Process.Args = append(args, grpcSpec.Process.Args...)
+
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2023 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/grpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelegateWasm(t *testing.T) {
+	assert := assert.New(t)
+
+	newSpec := func(annotations map[string]string, args ...string) *grpc.Spec {
+		return &grpc.Spec{
+			Annotations: annotations,
+			Process: &grpc.Process{
+				Args: args,
+				Env:  []string{"PATH=/bin", "MODE=fast"},
+			},
+		}
+	}
+
+	// Native containers are left alone
+	for _, spec := range []*grpc.Spec{
+		newSpec(nil, "/app.wasm"),
+		newSpec(map[string]string{wasmVariantAnnotation: wasmVariantCompatSmart}, "/bin/sh"),
+		newSpec(map[string]string{wasmHandlerAnnotation: "krun"}, "/app.wasm"),
+	} {
+		assert.NoError(delegateWasm(spec, WasmRuntimeWasmtime))
+		assert.Empty(spec.Mounts)
+		assert.Len(spec.Process.Args, 1)
+	}
+
+	// So are the WASM containers without a WASM runtime
+	spec := newSpec(map[string]string{wasmVariantAnnotation: wasmVariantCompat}, "/app.wasm")
+	assert.NoError(delegateWasm(spec, ""))
+	assert.Equal([]string{"/app.wasm"}, spec.Process.Args)
+
+	spec = newSpec(map[string]string{wasmVariantAnnotation: wasmVariantCompatSmart}, "/app.wasm", "--port", "80")
+	assert.NoError(delegateWasm(spec, WasmRuntimeWasmtime))
+	assert.Equal([]string{"/.kata-wasm/wasmtime", "run", "--dir=/", "--env", "PATH=/bin", "--env", "MODE=fast",
+		"/app.wasm", "--port", "80"}, spec.Process.Args)
+	assert.Len(spec.Mounts, 1)
+	assert.Equal("/usr/bin/wasmtime", spec.Mounts[0].Source)
+	assert.Equal("/.kata-wasm/wasmtime", spec.Mounts[0].Destination)
+	assert.Contains(spec.Mounts[0].Options, "ro")
+
+	spec = newSpec(map[string]string{wasmHandlerAnnotation: wasmHandler}, "/app.wasm")
+	assert.NoError(delegateWasm(spec, WasmRuntimeWasmtime))
+	assert.Equal([]string{"/.kata-wasm/wasmtime", "run", "--dir=/", "--env", "PATH=/bin", "--env", "MODE=fast",
+		"/app.wasm"}, spec.Process.Args)
+
+	spec = newSpec(map[string]string{wasmHandlerAnnotation: wasmHandler}, "/app.wasm")
+	assert.Error(delegateWasm(spec, "wasmedge"))
+}
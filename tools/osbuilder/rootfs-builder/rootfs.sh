@@ -16,8 +16,11 @@ AGENT_VERSION=${AGENT_VERSION:-}
 RUST_VERSION="null"
 AGENT_BIN=${AGENT_BIN:-kata-agent}
 AGENT_INIT=${AGENT_INIT:-no}
+CRIU=${CRIU:-no}
 MEASURED_ROOTFS=${MEASURED_ROOTFS:-no}
 KERNEL_MODULES_DIR=${KERNEL_MODULES_DIR:-""}
+KERNEL_BUILD_DIR=${KERNEL_BUILD_DIR:-""}
+GUEST_SERVICES=${GUEST_SERVICES:-""}
 OSBUILDER_VERSION="unknown"
 DOCKER_RUNTIME=${DOCKER_RUNTIME:-runc}
 # this GOPATH is for installing yq from install_yq.sh
@@ -27,6 +30,7 @@ LIBC=${LIBC:-musl}
 # However, it is not enforced by default: you need to enable that in the main configuration file.
 SECCOMP=${SECCOMP:-"yes"}
 SELINUX=${SELINUX:-"no"}
+WASMTIME=${WASMTIME:-"no"}
 
 lib_file="${script_dir}/../scripts/lib.sh"
 source "$lib_file"
@@ -114,6 +118,11 @@ ARCH                Target architecture (according to \`uname -m\`).
                     and glibc agents.
                     Default value: $(uname -m)
 
+CRIU                When set to "yes", include criu in the rootfs, for the
+                    container_checkpoint experimental feature of the runtime.
+                    Only supported for Ubuntu.
+                    Default value: no
+
 DISTRO_REPO         Use host repositories to install guest packages.
                     Default value: <not set>
 
@@ -127,10 +136,19 @@ GRACEFUL_EXIT       If set, and if the DISTRO configuration specifies a
                     specific distributions.
                     Default value: <not set>
 
+GUEST_SERVICES      Space separated list of the systemd units of the rootfs the
+                    pods can ask the kata-agent to run, with the
+                    io.katacontainers.config.runtime.guest_services annotation.
+                    Default value: <empty>
+
 IMAGE_REGISTRY      Hostname for the image registry used to pull down the rootfs
                     build image.
                     Default value: docker.io
 
+KERNEL_BUILD_DIR    Path to the build tree of the guest kernel, whose built-in
+                    and loadable modules are listed in the image metadata.
+                    Default value: <empty>
+
 KERNEL_MODULES_DIR  Path to a directory containing kernel modules to include in
                     the rootfs.
                     Default value: <empty>
@@ -149,6 +167,11 @@ SELINUX             When set to "yes", build the rootfs with the required packag
                     Make sure the guest kernel is compiled with SELinux enabled.
                     Default value: "no"
 
+WASMTIME            When set to "yes", include a static build of wasmtime in the
+                    rootfs, for the io.katacontainers.config.runtime.wasm_runtime
+                    annotation of the runtime.
+                    Default value: "no"
+
 USE_DOCKER          If set, build the rootfs inside a container (requires
                     Docker).
                     Default value: <not set>
@@ -262,6 +285,31 @@ copy_kernel_modules()
 	OK "Kernel modules copied"
 }
 
+# Build wasmtime statically: it runs from the rootfs of the WASM containers,
+# which have no libraries to link against.
+build_wasmtime()
+{
+	local dest_dir="$1"
+
+	local wasmtime_version="$(get_package_version_from_kata_yaml "externals.wasmtime.version")"
+	[ -n "${wasmtime_version}" ] || die "Could not detect the required wasmtime version"
+
+	test -r "${HOME}/.cargo/env" && source "${HOME}/.cargo/env"
+	command -v cargo > /dev/null || die "cargo is needed to build wasmtime"
+
+	local rustarch="${ARCH}"
+	[ "${ARCH}" = ppc64le ] && rustarch=powerpc64le
+
+	local install_dir=$(mktemp -d -t wasmtime.XXXXXXXXXX)
+	RUSTFLAGS="-C target-feature=+crt-static" cargo install wasmtime-cli \
+		--locked \
+		--version "${wasmtime_version#v}" \
+		--target "${rustarch}-unknown-linux-${LIBC}" \
+		--root "${install_dir}"
+	install -D -m 0755 "${install_dir}/bin/wasmtime" "${dest_dir}/wasmtime"
+	rm -rf "${install_dir}"
+}
+
 error_handler()
 {
 	[ "$?" -eq 0 ] && return
@@ -307,6 +355,8 @@ check_env_variables()
 
 	[ -n "${KERNEL_MODULES_DIR}" ] && [ ! -d "${KERNEL_MODULES_DIR}" ] && die "KERNEL_MODULES_DIR defined but is not an existing directory"
 
+	[ -n "${KERNEL_BUILD_DIR}" ] && [ ! -d "${KERNEL_BUILD_DIR}" ] && die "KERNEL_BUILD_DIR defined but is not an existing directory"
+
 	[ -n "${OSBUILDER_VERSION}" ] || die "need osbuilder version"
 }
 
@@ -353,6 +403,10 @@ build_rootfs_distro()
 
 	echo "Required rust version: $RUST_VERSION"
 
+	if [ "${CRIU}" == "yes" ] && [ "${distro}" != "ubuntu" ]; then
+		die "The guest rootfs must be Ubuntu to include criu"
+	fi
+
 	if [ "${SELINUX}" == "yes" ]; then
 		if [ "${AGENT_INIT}" == "yes" ]; then
 			die "Guest SELinux with the agent init is not supported yet"
@@ -390,6 +444,8 @@ build_rootfs_distro()
 
 		# fake mapping if KERNEL_MODULES_DIR is unset
 		kernel_mod_dir=${KERNEL_MODULES_DIR:-${ROOTFS_DIR}}
+		# fake mapping if KERNEL_BUILD_DIR is unset
+		kernel_build_dir=${KERNEL_BUILD_DIR:-${ROOTFS_DIR}}
 
 		engine_run_args=""
 		engine_run_args+=" --rm"
@@ -415,6 +471,7 @@ build_rootfs_distro()
 					  "${ROOTFS_DIR}" \
 					  "${script_dir}/../scripts" \
 					  "${kernel_mod_dir}" \
+					  "${kernel_build_dir}" \
 					  "${SRC_VOL[@]}"; do
 				chcon -Rt svirt_sandbox_file_t "$volume_dir"
 			done
@@ -435,8 +492,11 @@ build_rootfs_distro()
 			--env AGENT_INIT="${AGENT_INIT}" \
 			--env ARCH="${ARCH}" \
 			--env CI="${CI}" \
+			--env CRIU="${CRIU}" \
 			--env MEASURED_ROOTFS="${MEASURED_ROOTFS}" \
 			--env KERNEL_MODULES_DIR="${KERNEL_MODULES_DIR}" \
+			--env KERNEL_BUILD_DIR="${KERNEL_BUILD_DIR}" \
+			--env GUEST_SERVICES="${GUEST_SERVICES}" \
 			--env LIBC="${LIBC}" \
 			--env EXTRA_PKGS="${EXTRA_PKGS}" \
 			--env OSBUILDER_VERSION="${OSBUILDER_VERSION}" \
@@ -444,12 +504,14 @@ build_rootfs_distro()
 			--env INSIDE_CONTAINER=1 \
 			--env SECCOMP="${SECCOMP}" \
 			--env SELINUX="${SELINUX}" \
+			--env WASMTIME="${WASMTIME}" \
 			--env DEBUG="${DEBUG}" \
 			--env HOME="/root" \
 			-v "${repo_dir}":"/kata-containers" \
 			-v "${ROOTFS_DIR}":"/rootfs" \
 			-v "${script_dir}/../scripts":"/scripts" \
 			-v "${kernel_mod_dir}":"${kernel_mod_dir}" \
+			-v "${kernel_build_dir}":"${kernel_build_dir}" \
 			$engine_run_args \
 			${image_name} \
 			bash /kata-containers/tools/osbuilder/rootfs-builder/rootfs.sh "${distro}"
@@ -529,6 +591,12 @@ EOF
 	info "Create ${ROOTFS_DIR}/etc"
 	mkdir -p "${ROOTFS_DIR}/etc"
 
+	if [ -n "${GUEST_SERVICES}" ]; then
+		info "Allow the guest services ${GUEST_SERVICES}"
+		mkdir -p "${ROOTFS_DIR}/etc/kata-containers"
+		echo "${GUEST_SERVICES}" | tr ' ' '\n' > "${ROOTFS_DIR}/etc/kata-containers/guest-services"
+	fi
+
 	case "${distro}" in
 		"ubuntu" | "debian")
 			echo "I am ubuntu or debian"
@@ -619,6 +687,12 @@ EOF
 	[ -x "${AGENT_DEST}" ] || die "${AGENT_DEST} is not installed in ${ROOTFS_DIR}"
 	OK "Agent installed"
 
+	if [ "${WASMTIME}" == "yes" ]; then
+		info "Build wasmtime"
+		build_wasmtime "${ROOTFS_DIR}/usr/bin"
+		OK "wasmtime installed"
+	fi
+
 	if [ "${AGENT_INIT}" == "yes" ]; then
 		setup_agent_init "${AGENT_DEST}" "${init}"
 	else
@@ -23,6 +23,7 @@ bootstrap=Ubuntu
 source=$REPO_URL
 keyring=ubuntu-keyring
 suite=focal
+components=main universe
 packages=$PACKAGES $EXTRA_PKGS
 EOF
 	if ! multistrap -a "$DEB_ARCH" -d "$rootfs_dir" -f "$multistrap_conf"; then
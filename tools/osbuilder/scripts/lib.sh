@@ -209,6 +209,57 @@ EOF
 
 	local rootfs_file="${file_dir}/$(basename "${file}")"
 	info "Created summary file '${rootfs_file}' inside rootfs"
+
+	create_image_metadata_file "${rootfs_dir}" "${agent_version}"
+}
+
+# create_image_metadata_file writes the metadata the kata-agent reports to the
+# runtime, which checks its configuration against it when the sandbox starts.
+# Kernel modules are only listed when KERNEL_BUILD_DIR gives the build tree of
+# the guest kernel, the runtime otherwise cannot tell which ones it provides.
+create_image_metadata_file()
+{
+	local -r rootfs_dir="$1"
+	local -r agent_version="$2"
+
+	local -r file_dir="/var/lib/osbuilder"
+	local -r file="${rootfs_dir}${file_dir}/image-metadata.json"
+
+	# Semantic version of the metadata file format, checked by the runtime.
+	#
+	# XXX: Increment every time the format of the metadata file changes!
+	local -r format_version="1.0.0"
+
+	local features=""
+	[ "${SECCOMP:-}" = yes ] && features="\"seccomp\""
+
+	local modules_entry=""
+	local -r kernel_build_dir="${KERNEL_BUILD_DIR:-}"
+	if [ -n "${kernel_build_dir}" ]; then
+		[ -f "${kernel_build_dir}/modules.builtin" ] || \
+			die "${kernel_build_dir}/modules.builtin not found, is KERNEL_BUILD_DIR a built kernel tree?"
+		# Built-in and loadable modules of the guest kernel, named as
+		# modprobe knows them.
+		local -r modules=$(cat "${kernel_build_dir}/modules.builtin" \
+			"${kernel_build_dir}/modules.order" 2>/dev/null | \
+			xargs -r -n1 basename | \
+			sed -e 's/\.k\?o$//' -e 's/-/_/g' | sort -u | \
+			sed -e 's/.*/"&"/' | paste -sd, -)
+		modules_entry=",
+	  \"kernel_modules\": [${modules}]"
+	fi
+
+	cat >"$file"<<-EOF
+	{
+	  "format_version": "${format_version}",
+	  "agent": {
+	    "version": "${agent_version}",
+	    "features": [${features}]
+	  }${modules_entry}
+	}
+EOF
+
+	info "Created image metadata file '${file_dir}/$(basename "${file}")' inside rootfs"
 }
 
 # generate_dockerfile takes as only argument a path. It expects a Dockerfile.in